@@ -43,5 +43,23 @@ func accountsFlags(flagNames, envNames config.Flags, defaults config.Defaults) [
 			Value:   defaults.AccountsReasonRequired,
 			EnvVars: []string{envNames.AccountsReasonRequired},
 		},
+		&cli.BoolFlag{
+			Name:    flagNames.AccountsAllowCustomCSS,
+			Usage:   "Allow accounts to set custom CSS for their profile and status pages.",
+			Value:   defaults.AccountsAllowCustomCSS,
+			EnvVars: []string{envNames.AccountsAllowCustomCSS},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.AccountsCustomCSSLength,
+			Usage:   "Maximum length of account custom CSS, in characters.",
+			Value:   defaults.AccountsCustomCSSLength,
+			EnvVars: []string{envNames.AccountsCustomCSSLength},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.AccountsKeyType,
+			Usage:   "Type of key to generate for signing federated requests for new accounts: rsa or ed25519. Accounts always get an RSA key as well, for instances that don't support ed25519.",
+			Value:   defaults.AccountsKeyType,
+			EnvVars: []string{envNames.AccountsKeyType},
+		},
 	}
 }
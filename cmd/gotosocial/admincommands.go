@@ -20,6 +20,11 @@ package main
 
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/cliactions/admin/account"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions/admin/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions/admin/prune"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions/admin/stats"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions/admin/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions/admin/token"
 	"github.com/superseriousbusiness/gotosocial/internal/cliactions/admin/trans"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/urfave/cli/v2"
@@ -150,6 +155,133 @@ func adminCommands() []*cli.Command {
 						},
 					},
 				},
+				{
+					Name:  "federation",
+					Usage: "admin commands related to federation",
+					Subcommands: []*cli.Command{
+						{
+							Name:  "retry",
+							Usage: "retry failed deliveries of federated messages, either to one domain or to all domains",
+							Flags: []cli.Flag{
+								&cli.StringFlag{
+									Name:  config.DomainFlag,
+									Usage: config.DomainUsage,
+								},
+								&cli.BoolFlag{
+									Name:  config.AllFlag,
+									Usage: config.AllUsage,
+								},
+							},
+							Action: func(c *cli.Context) error {
+								return runAction(c, federation.Retry)
+							},
+						},
+					},
+				},
+				{
+					Name:  "tokens",
+					Usage: "admin commands related to oauth tokens",
+					Subcommands: []*cli.Command{
+						{
+							Name:  "list",
+							Usage: "list oauth tokens, optionally filtered by user and/or application",
+							Flags: []cli.Flag{
+								&cli.StringFlag{
+									Name:  config.UsernameFlag,
+									Usage: config.UsernameUsage,
+								},
+								&cli.StringFlag{
+									Name:  config.ApplicationIDFlag,
+									Usage: config.ApplicationIDUsage,
+								},
+							},
+							Action: func(c *cli.Context) error {
+								return runAction(c, token.List)
+							},
+						},
+						{
+							Name:  "revoke",
+							Usage: "revoke one oauth token by id, or all oauth tokens belonging to a user and/or application",
+							Flags: []cli.Flag{
+								&cli.StringFlag{
+									Name:  config.TokenIDFlag,
+									Usage: config.TokenIDUsage,
+								},
+								&cli.StringFlag{
+									Name:  config.UsernameFlag,
+									Usage: config.UsernameUsage,
+								},
+								&cli.StringFlag{
+									Name:  config.ApplicationIDFlag,
+									Usage: config.ApplicationIDUsage,
+								},
+							},
+							Action: func(c *cli.Context) error {
+								return runAction(c, token.Revoke)
+							},
+						},
+					},
+				},
+				{
+					Name:  "prune",
+					Usage: "admin commands for removing old cached data",
+					Subcommands: []*cli.Command{
+						{
+							Name:  "remote-accounts",
+							Usage: "remove cached remote accounts that haven't been seen for a while and that nobody here follows or has interacted with",
+							Flags: []cli.Flag{
+								&cli.IntFlag{
+									Name:  config.PruneDaysFlag,
+									Usage: config.PruneDaysUsage,
+									Value: 30,
+								},
+								&cli.BoolFlag{
+									Name:  config.PruneDryRunFlag,
+									Usage: config.PruneDryRunUsage,
+								},
+							},
+							Action: func(c *cli.Context) error {
+								return runAction(c, prune.RemoteAccounts)
+							},
+						},
+					},
+				},
+				{
+					Name:  "stats",
+					Usage: "print statistics about this instance: users, statuses, storage usage, federation and queues",
+					Action: func(c *cli.Context) error {
+						return runAction(c, stats.Print)
+					},
+				},
+				{
+					Name:  "storage",
+					Usage: "admin commands related to media storage",
+					Subcommands: []*cli.Command{
+						{
+							Name:  "migrate",
+							Usage: "copy all stored media from one storage backend to another, eg., from local to s3",
+							Flags: []cli.Flag{
+								&cli.StringFlag{
+									Name:     config.StorageFromFlag,
+									Usage:    config.StorageFromUsage,
+									Required: true,
+								},
+								&cli.StringFlag{
+									Name:     config.StorageToFlag,
+									Usage:    config.StorageToUsage,
+									Required: true,
+								},
+								&cli.StringFlag{
+									Name:  config.StorageServeURLFlag,
+									Usage: config.StorageServeURLUsage,
+								},
+							},
+							Action: func(c *cli.Context) error {
+								return runAction(c, storage.Migrate)
+							},
+						},
+					},
+				},
 				{
 					Name:  "export",
 					Usage: "export data from the database to file at the given path",
@@ -159,6 +291,10 @@ func adminCommands() []*cli.Command {
 							Usage:    config.TransPathUsage,
 							Required: true,
 						},
+						&cli.StringFlag{
+							Name:  config.TransAccountFlag,
+							Usage: config.TransAccountUsage,
+						},
 					},
 					Action: func(c *cli.Context) error {
 						return runAction(c, trans.Export)
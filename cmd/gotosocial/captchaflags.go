@@ -0,0 +1,53 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func captchaFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagNames.CaptchaProvider,
+			Usage:   "Captcha provider to check on sign up: one of hcaptcha, recaptcha or mcaptcha. Leave empty to not require a captcha.",
+			Value:   defaults.CaptchaProvider,
+			EnvVars: []string{envNames.CaptchaProvider},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.CaptchaSiteKey,
+			Usage:   "Public site key for the captcha provider; this is shown to clients so they can render the captcha.",
+			Value:   defaults.CaptchaSiteKey,
+			EnvVars: []string{envNames.CaptchaSiteKey},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.CaptchaSecretKey,
+			Usage:   "Secret key for the captcha provider, used to verify captcha responses.",
+			Value:   defaults.CaptchaSecretKey,
+			EnvVars: []string{envNames.CaptchaSecretKey},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.CaptchaURL,
+			Usage:   "Base url of the mCaptcha server to verify against, eg., https://mcaptcha.example.org. Only used for the mcaptcha provider.",
+			Value:   defaults.CaptchaURL,
+			EnvVars: []string{envNames.CaptchaURL},
+		},
+	}
+}
@@ -27,7 +27,9 @@ func getCommands() []*cli.Command {
 	commandSets := [][]*cli.Command{
 		serverCommands(),
 		adminCommands(),
+		checkConfigCommands(),
 		testrigCommands(),
+		completionCommands(),
 	}
 	for _, cs := range commandSets {
 		commands = append(commands, cs...)
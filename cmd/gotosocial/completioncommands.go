@@ -0,0 +1,113 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// bashCompletion asks gotosocial itself for completions of the current command line.
+const bashCompletion = `_gotosocial_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} ${cur} --generate-bash-completion )
+    else
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F _gotosocial_bash_autocomplete gotosocial
+`
+
+// zshCompletion asks gotosocial itself for completions of the current command line.
+const zshCompletion = `#compdef gotosocial
+
+_gotosocial() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion)}")
+  else
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} --generate-bash-completion)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _gotosocial gotosocial
+`
+
+func completionCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "completion",
+			Usage: "print a shell completion script for gotosocial",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "bash",
+					Usage: "print a bash completion script, eg., source <(gotosocial completion bash)",
+					Action: func(c *cli.Context) error {
+						_, err := fmt.Fprint(c.App.Writer, bashCompletion)
+						return err
+					},
+				},
+				{
+					Name:  "zsh",
+					Usage: "print a zsh completion script, eg., gotosocial completion zsh > \"${fpath[1]}/_gotosocial\"",
+					Action: func(c *cli.Context) error {
+						_, err := fmt.Fprint(c.App.Writer, zshCompletion)
+						return err
+					},
+				},
+				{
+					Name:  "fish",
+					Usage: "print a fish completion script, eg., gotosocial completion fish > ~/.config/fish/completions/gotosocial.fish",
+					Action: func(c *cli.Context) error {
+						// generate from the root app, so that all commands are included
+						app := c.App
+						for _, parent := range c.Lineage() {
+							if parent.App != nil {
+								app = parent.App
+							}
+						}
+						fish, err := app.ToFishCompletion()
+						if err != nil {
+							return fmt.Errorf("error generating fish completion: %s", err)
+						}
+						_, err = fmt.Fprint(c.App.Writer, fish)
+						return err
+					},
+				},
+			},
+		},
+	}
+}
@@ -71,7 +71,13 @@ func databaseFlags(flagNames, envNames config.Flags, defaults config.Defaults) [
 			Name:    flagNames.DbTLSCACert,
 			Usage:   "Path to CA cert for db tls connection",
 			Value:   defaults.DBTlsCACert,
-			EnvVars: []string{envNames.DbTLSCACert},
+			EnvVars: []string{envNames.DbTLSCACert, "GTS_DB_CA_CERT"}, // GTS_DB_CA_CERT is the old name, kept so existing deployments still work
+		},
+		&cli.StringFlag{
+			Name:    flagNames.DbApplicationName,
+			Usage:   "Application name to report to the database server; defaults to application-name if not set",
+			Value:   defaults.DbApplicationName,
+			EnvVars: []string{envNames.DbApplicationName},
 		},
 	}
 }
@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func errorReportingFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagNames.ErrorReportingDSN,
+			Usage:   "Sentry-compatible DSN to send error reports to, eg. 'https://publickey@sentry.example.org/1'.",
+			Value:   defaults.ErrorReportingDSN,
+			EnvVars: []string{envNames.ErrorReportingDSN},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.ErrorReportingWebhookURL,
+			Usage:   "URL to POST error reports to as json.",
+			Value:   defaults.ErrorReportingWebhookURL,
+			EnvVars: []string{envNames.ErrorReportingWebhookURL},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.ErrorReportingEnvironment,
+			Usage:   "Name of the environment this instance runs in, included in error reports so that they can be told apart, eg. 'production' or 'staging'.",
+			Value:   defaults.ErrorReportingEnvironment,
+			EnvVars: []string{envNames.ErrorReportingEnvironment},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func federationCacheFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    flagNames.FederationCacheTTLSeconds,
+			Usage:   "How long in seconds a fetched remote actor or collection is used without checking back with the remote.",
+			Value:   defaults.FederationCacheTTLSeconds,
+			EnvVars: []string{envNames.FederationCacheTTLSeconds},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.FederationCacheMaxEntries,
+			Usage:   "Max number of fetched remote actors and collections to keep in the cache. 0 disables the cache.",
+			Value:   defaults.FederationCacheMaxEntries,
+			EnvVars: []string{envNames.FederationCacheMaxEntries},
+		},
+	}
+}
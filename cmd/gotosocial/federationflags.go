@@ -0,0 +1,41 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func federationFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    flagNames.FederationSendBlocks,
+			Usage:   "Send Block activities to the instances of blocked accounts. If false, blocks are only enforced on this instance.",
+			Value:   defaults.FederationSendBlocks,
+			EnvVars: []string{envNames.FederationSendBlocks},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.FederationMode,
+			Usage:   "Federation mode of this instance: 'blocklist' to federate with every domain that isn't blocked, or 'allowlist' to only federate with explicitly allowed domains.",
+			Value:   defaults.FederationMode,
+			EnvVars: []string{envNames.FederationMode},
+		},
+	}
+}
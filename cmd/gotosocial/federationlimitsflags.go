@@ -0,0 +1,71 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func federationLimitsFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    flagNames.FederationLimitsPayloadSize,
+			Usage:   "Max size in bytes of an activitypub document fetched from a remote instance. 0 means no limit.",
+			Value:   defaults.FederationLimitsPayloadSize,
+			EnvVars: []string{envNames.FederationLimitsPayloadSize},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.FederationLimitsCollectionPages,
+			Usage:   "Max number of pages to fetch when working through a remote collection, such as the replies to a status. 0 means no limit.",
+			Value:   defaults.FederationLimitsCollectionPages,
+			EnvVars: []string{envNames.FederationLimitsCollectionPages},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.FederationLimitsCollectionItems,
+			Usage:   "Max number of items to take from a single fetched collection or collection page. 0 means no limit.",
+			Value:   defaults.FederationLimitsCollectionItems,
+			EnvVars: []string{envNames.FederationLimitsCollectionItems},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.FederationLimitsThreadDepth,
+			Usage:   "Max number of statuses to walk up or down a thread when dereferencing its ancestors and descendants. 0 means no limit.",
+			Value:   defaults.FederationLimitsThreadDepth,
+			EnvVars: []string{envNames.FederationLimitsThreadDepth},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.FederationLimitsBackfillStatuses,
+			Usage:   "Max number of new statuses to fetch when backfilling the thread of a remote status that someone has opened. 0 means no limit.",
+			Value:   defaults.FederationLimitsBackfillStatuses,
+			EnvVars: []string{envNames.FederationLimitsBackfillStatuses},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.FederationLimitsDeliveryConcurrency,
+			Usage:   "Max number of inboxes to deliver a single activity to at once. 0 means no limit.",
+			Value:   defaults.FederationLimitsDeliveryConcurrency,
+			EnvVars: []string{envNames.FederationLimitsDeliveryConcurrency},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.FederationLimitsDeliveryAttempts,
+			Usage:   "Max number of times to try delivering an activity to an inbox before giving up on it. 0 means no limit.",
+			Value:   defaults.FederationLimitsDeliveryAttempts,
+			EnvVars: []string{envNames.FederationLimitsDeliveryAttempts},
+		},
+	}
+}
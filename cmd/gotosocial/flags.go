@@ -39,10 +39,38 @@ func getFlags() []cli.Flag {
 		statusesFlags(flagNames, envNames, defaults),
 		letsEncryptFlags(flagNames, envNames, defaults),
 		oidcFlags(flagNames, envNames, defaults),
+		throttlingFlags(flagNames, envNames, defaults),
+		captchaFlags(flagNames, envNames, defaults),
+		spamFlags(flagNames, envNames, defaults),
+		healthFlags(flagNames, envNames, defaults),
+		requestLimitsFlags(flagNames, envNames, defaults),
+		hiddenServicesFlags(flagNames, envNames, defaults),
+		outboundProxyFlags(flagNames, envNames, defaults),
+		errorReportingFlags(flagNames, envNames, defaults),
+		retentionFlags(flagNames, envNames, defaults),
+		federationFlags(flagNames, envNames, defaults),
+		inboxFilterFlags(flagNames, envNames, defaults),
+		federationLimitsFlags(flagNames, envNames, defaults),
+		federationCacheFlags(flagNames, envNames, defaults),
+		webPushFlags(flagNames, envNames, defaults),
+		smtpFlags(flagNames, envNames, defaults),
+		rateLimitFlags(flagNames, envNames, defaults),
 	}
 	for _, fs := range flagSets {
 		flags = append(flags, fs...)
 	}
 
-	return flags
+	return append(flags, outputFlags()...)
+}
+
+// outputFlags are global flags that change how cli actions report what they did,
+// rather than configuring gotosocial itself.
+func outputFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  config.FormatFlag,
+			Usage: config.FormatUsage,
+			Value: config.OutputFormatText,
+		},
+	}
 }
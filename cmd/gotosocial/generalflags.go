@@ -31,6 +31,12 @@ func generalFlags(flagNames, envNames config.Flags, defaults config.Defaults) []
 			Value:   defaults.LogLevel,
 			EnvVars: []string{envNames.LogLevel},
 		},
+		&cli.StringFlag{
+			Name:    flagNames.LogFormat,
+			Usage:   "Format to write logs in: text or json",
+			Value:   defaults.LogFormat,
+			EnvVars: []string{envNames.LogFormat},
+		},
 		&cli.StringFlag{
 			Name:    flagNames.ApplicationName,
 			Usage:   "Name of the application, used in various places internally",
@@ -74,5 +80,11 @@ func generalFlags(flagNames, envNames config.Flags, defaults config.Defaults) []
 			Value:   cli.NewStringSlice(defaults.TrustedProxies...),
 			EnvVars: []string{envNames.TrustedProxies},
 		},
+		&cli.StringFlag{
+			Name:    flagNames.UnixSocket,
+			Usage:   "Path of a unix domain socket to listen on instead of a TCP port. Ignored if a socket is passed in using systemd socket activation.",
+			Value:   defaults.UnixSocket,
+			EnvVars: []string{envNames.UnixSocket},
+		},
 	}
 }
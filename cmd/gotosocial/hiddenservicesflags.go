@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func hiddenServicesFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagNames.HiddenServicesOnionHost,
+			Usage:   "Tor onion address that this instance can also be reached at, eg. 'abcdef...xyz.onion'. If set, it's advertised to Tor Browser users with an Onion-Location header.",
+			Value:   defaults.HiddenServicesOnionHost,
+			EnvVars: []string{envNames.HiddenServicesOnionHost},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.HiddenServicesTorProxy,
+			Usage:   "Address of the socks5 proxy to send requests to .onion hosts through, eg. '127.0.0.1:9050'. If not set, .onion hosts can't be federated with.",
+			Value:   defaults.HiddenServicesTorProxy,
+			EnvVars: []string{envNames.HiddenServicesTorProxy},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.HiddenServicesI2PProxy,
+			Usage:   "Address of the socks5 proxy to send requests to .i2p hosts through, eg. '127.0.0.1:4447'. If not set, .i2p hosts can't be federated with.",
+			Value:   defaults.HiddenServicesI2PProxy,
+			EnvVars: []string{envNames.HiddenServicesI2PProxy},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func inboxFilterFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    flagNames.InboxFilterMaxMentions,
+			Usage:   "Reject incoming statuses which mention more than this many accounts. 0 means no limit.",
+			Value:   defaults.InboxFilterMaxMentions,
+			EnvVars: []string{envNames.InboxFilterMaxMentions},
+		},
+		&cli.BoolFlag{
+			Name:    flagNames.InboxFilterRejectLinkOnly,
+			Usage:   "Reject incoming statuses from new accounts which contain nothing but links and mentions.",
+			Value:   defaults.InboxFilterRejectLinkOnly,
+			EnvVars: []string{envNames.InboxFilterRejectLinkOnly},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.InboxFilterNewAccountHours,
+			Usage:   "Remote accounts first seen less than this many hours ago count as new, for the purposes of rejecting link-only statuses.",
+			Value:   defaults.InboxFilterNewAccountHours,
+			EnvVars: []string{envNames.InboxFilterNewAccountHours},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagNames.InboxFilterRejectKeywords,
+			Usage:   "Reject incoming statuses containing any of these keywords, matched case-insensitively against the status text.",
+			Value:   cli.NewStringSlice(defaults.InboxFilterRejectKeywords...),
+			EnvVars: []string{envNames.InboxFilterRejectKeywords},
+		},
+		&cli.BoolFlag{
+			Name:    flagNames.InboxFilterReportRejections,
+			Usage:   "File a report for moderators whenever an incoming activity is rejected by the inbox filter.",
+			Value:   defaults.InboxFilterReportRejections,
+			EnvVars: []string{envNames.InboxFilterReportRejections},
+		},
+	}
+}
@@ -49,5 +49,35 @@ func letsEncryptFlags(flagNames, envNames config.Flags, defaults config.Defaults
 			Value:   defaults.LetsEncryptEmailAddress,
 			EnvVars: []string{envNames.LetsEncryptEmailAddress},
 		},
+		&cli.StringFlag{
+			Name:    flagNames.LetsEncryptChallenge,
+			Usage:   "Challenge to use to prove control of the domain to letsencrypt: http-01 or dns-01.",
+			Value:   defaults.LetsEncryptChallenge,
+			EnvVars: []string{envNames.LetsEncryptChallenge},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.LetsEncryptKeyType,
+			Usage:   "Type of private key to use for letsencrypt certs: ecdsa-p256, ecdsa-p384, rsa-2048 or rsa-4096. Only used for the dns-01 challenge; with http-01, the key type is picked automatically based on what each client supports.",
+			Value:   defaults.LetsEncryptKeyType,
+			EnvVars: []string{envNames.LetsEncryptKeyType},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.LetsEncryptDNSProvider,
+			Usage:   "DNS provider to use for solving dns-01 challenges: exec or httpreq.",
+			Value:   defaults.LetsEncryptDNSProvider,
+			EnvVars: []string{envNames.LetsEncryptDNSProvider},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.LetsEncryptDNSExecPath,
+			Usage:   "Path to an executable that will be called to present and clean up dns-01 TXT records, when using the exec DNS provider.",
+			Value:   defaults.LetsEncryptDNSExecPath,
+			EnvVars: []string{envNames.LetsEncryptDNSExecPath},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.LetsEncryptDNSHTTPReqEndpoint,
+			Usage:   "URL that dns-01 TXT records will be POSTed to, when using the httpreq DNS provider.",
+			Value:   defaults.LetsEncryptDNSHTTPReqEndpoint,
+			EnvVars: []string{envNames.LetsEncryptDNSHTTPReqEndpoint},
+		},
 	}
 }
@@ -43,10 +43,13 @@ func main() {
 	}
 
 	app := &cli.App{
+		Name:     "gotosocial",
 		Version:  v,
 		Usage:    "a fediverse social media server",
 		Flags:    getFlags(),
 		Commands: getCommands(),
+		// enables the hidden --generate-bash-completion flag used by the bash and zsh completion scripts
+		EnableBashCompletion: true,
 	}
 
 	err := app.Run(os.Args)
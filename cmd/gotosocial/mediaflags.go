@@ -37,6 +37,24 @@ func mediaFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cl
 			Value:   defaults.MediaMaxVideoSize,
 			EnvVars: []string{envNames.MediaMaxVideoSize},
 		},
+		&cli.IntFlag{
+			Name:    flagNames.MediaMaxVideoDuration,
+			Usage:   "Max duration of accepted videos in seconds",
+			Value:   defaults.MediaMaxVideoDuration,
+			EnvVars: []string{envNames.MediaMaxVideoDuration},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.MediaFFmpegPath,
+			Usage:   "Path to the ffmpeg binary, used for transcoding videos and taking video thumbnails",
+			Value:   defaults.MediaFFmpegPath,
+			EnvVars: []string{envNames.MediaFFmpegPath},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.MediaFFprobePath,
+			Usage:   "Path to the ffprobe binary, used for reading the dimensions and duration of videos",
+			Value:   defaults.MediaFFprobePath,
+			EnvVars: []string{envNames.MediaFFprobePath},
+		},
 		&cli.IntFlag{
 			Name:    flagNames.MediaMinDescriptionChars,
 			Usage:   "Min required chars for an image description",
@@ -67,5 +67,41 @@ func oidcFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli
 			Value:   cli.NewStringSlice(defaults.OIDCScopes...),
 			EnvVars: []string{envNames.OIDCScopes},
 		},
+		&cli.StringFlag{
+			Name:    flagNames.OIDCUsernameClaim,
+			Usage:   "Claim of the id_token to derive the username of new users from, eg., 'name' or 'preferred_username'.",
+			Value:   defaults.OIDCUsernameClaim,
+			EnvVars: []string{envNames.OIDCUsernameClaim},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.OIDCEmailClaim,
+			Usage:   "Claim of the id_token to take the email address of users from.",
+			Value:   defaults.OIDCEmailClaim,
+			EnvVars: []string{envNames.OIDCEmailClaim},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.OIDCGroupsClaim,
+			Usage:   "Claim of the id_token to take the group memberships of users from.",
+			Value:   defaults.OIDCGroupsClaim,
+			EnvVars: []string{envNames.OIDCGroupsClaim},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagNames.OIDCAdminGroups,
+			Usage:   "Members of any of these OIDC groups will be made admins of this instance when they sign in.",
+			Value:   cli.NewStringSlice(defaults.OIDCAdminGroups...),
+			EnvVars: []string{envNames.OIDCAdminGroups},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagNames.OIDCModeratorGroups,
+			Usage:   "Members of any of these OIDC groups will be made moderators of this instance when they sign in.",
+			Value:   cli.NewStringSlice(defaults.OIDCModeratorGroups...),
+			EnvVars: []string{envNames.OIDCModeratorGroups},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.OIDCUsernameConflict,
+			Usage:   "What to do when the username of a new OIDC user is already taken. 'increment' appends a number to the username, 'reject' refuses the sign in.",
+			Value:   defaults.OIDCUsernameConflict,
+			EnvVars: []string{envNames.OIDCUsernameConflict},
+		},
 	}
 }
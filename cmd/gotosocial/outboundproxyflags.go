@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func outboundProxyFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagNames.OutboundProxyURL,
+			Usage:   "URL of the http, https or socks5 proxy to send federation requests through, eg. 'http://proxy.example.org:3128'. If not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used instead.",
+			Value:   defaults.OutboundProxyURL,
+			EnvVars: []string{envNames.OutboundProxyURL},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagNames.OutboundProxyNoProxy,
+			Usage:   "Domains, and their subdomains, to connect to directly rather than through the proxy.",
+			Value:   cli.NewStringSlice(defaults.OutboundProxyNoProxy...),
+			EnvVars: []string{envNames.OutboundProxyNoProxy},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagNames.OutboundProxyDomains,
+			Usage:   "Per-domain overrides, in the form 'domain=proxy url' or 'domain=direct', eg. 'example.org=socks5://127.0.0.1:1080'. These apply to subdomains too, and take precedence over the other proxy settings.",
+			Value:   cli.NewStringSlice(defaults.OutboundProxyDomains...),
+			EnvVars: []string{envNames.OutboundProxyDomains},
+		},
+	}
+}
@@ -0,0 +1,53 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func rateLimitFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    flagNames.RateLimitAPIRequests,
+			Usage:   "Max number of client API requests per five minutes, per oauth token, or per ip address for requests without one. 0 means no limit.",
+			Value:   defaults.RateLimitAPIRequests,
+			EnvVars: []string{envNames.RateLimitAPIRequests},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RateLimitInboxRequests,
+			Usage:   "Max number of activitypub inbox POSTs per five minutes per ip address. 0 means no limit.",
+			Value:   defaults.RateLimitInboxRequests,
+			EnvVars: []string{envNames.RateLimitInboxRequests},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RateLimitAuthRequests,
+			Usage:   "Max number of sign in, sign up, password reset, and oauth token requests per five minutes per ip address. 0 means no limit.",
+			Value:   defaults.RateLimitAuthRequests,
+			EnvVars: []string{envNames.RateLimitAuthRequests},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagNames.RateLimitExemptIPs,
+			Usage:   "Ip addresses or ranges, in CIDR notation, that are never rate limited.",
+			Value:   cli.NewStringSlice(defaults.RateLimitExemptIPs...),
+			EnvVars: []string{envNames.RateLimitExemptIPs},
+		},
+	}
+}
@@ -0,0 +1,83 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func requestLimitsFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsTimeout,
+			Usage:   "Seconds allowed for handling a request, for requests that don't have a more specific timeout. 0 means no limit.",
+			Value:   defaults.RequestLimitsTimeout,
+			EnvVars: []string{envNames.RequestLimitsTimeout},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsMediaTimeout,
+			Usage:   "Seconds allowed for handling a multipart form upload, such as a media attachment or avatar. 0 means no limit.",
+			Value:   defaults.RequestLimitsMediaTimeout,
+			EnvVars: []string{envNames.RequestLimitsMediaTimeout},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsInboxTimeout,
+			Usage:   "Seconds allowed for handling an activitypub inbox POST. 0 means no limit.",
+			Value:   defaults.RequestLimitsInboxTimeout,
+			EnvVars: []string{envNames.RequestLimitsInboxTimeout},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsBodySize,
+			Usage:   "Max size in bytes of a request body, for requests that don't have a more specific limit. 0 means no limit.",
+			Value:   defaults.RequestLimitsBodySize,
+			EnvVars: []string{envNames.RequestLimitsBodySize},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsMediaBodySize,
+			Usage:   "Max size in bytes of a multipart form upload, such as a media attachment or avatar. Must be at least as big as the max image and video sizes. 0 means no limit.",
+			Value:   defaults.RequestLimitsMediaBodySize,
+			EnvVars: []string{envNames.RequestLimitsMediaBodySize},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsInboxBodySize,
+			Usage:   "Max size in bytes of an activitypub inbox POST. 0 means no limit.",
+			Value:   defaults.RequestLimitsInboxBodySize,
+			EnvVars: []string{envNames.RequestLimitsInboxBodySize},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsReadHeaderTimeout,
+			Usage:   "Seconds allowed for a client to send the headers of a request, before the connection is closed. 0 means no limit.",
+			Value:   defaults.RequestLimitsReadHeaderTimeout,
+			EnvVars: []string{envNames.RequestLimitsReadHeaderTimeout},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsReadTimeout,
+			Usage:   "Seconds allowed for a client to send a whole request including the body, before the connection is closed. 0 means no limit.",
+			Value:   defaults.RequestLimitsReadTimeout,
+			EnvVars: []string{envNames.RequestLimitsReadTimeout},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RequestLimitsIdleTimeout,
+			Usage:   "Seconds to keep an idle keep-alive connection open while waiting for the next request. 0 means use the read timeout instead.",
+			Value:   defaults.RequestLimitsIdleTimeout,
+			EnvVars: []string{envNames.RequestLimitsIdleTimeout},
+		},
+	}
+}
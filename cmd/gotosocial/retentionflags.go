@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func retentionFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    flagNames.RetentionRemoteStatusDays,
+			Usage:   "Remove remote statuses that nobody on this instance has interacted with once they haven't been updated for this many days. 0 means keep them forever.",
+			Value:   defaults.RetentionRemoteStatusDays,
+			EnvVars: []string{envNames.RetentionRemoteStatusDays},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RetentionRemoteAccountDays,
+			Usage:   "Remove remote accounts with no relationship or interaction with this instance once they haven't been updated for this many days. 0 means keep them forever.",
+			Value:   defaults.RetentionRemoteAccountDays,
+			EnvVars: []string{envNames.RetentionRemoteAccountDays},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RetentionSweepIntervalMinutes,
+			Usage:   "How often to check for remote content to remove, in minutes.",
+			Value:   defaults.RetentionSweepIntervalMinutes,
+			EnvVars: []string{envNames.RetentionSweepIntervalMinutes},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RetentionInboxActivityDays,
+			Usage:   "Forget which activities have already been received after this many days. Activities that are delivered again within this time are dropped. 0 means never forget.",
+			Value:   defaults.RetentionInboxActivityDays,
+			EnvVars: []string{envNames.RetentionInboxActivityDays},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.RetentionFailedDeliveryDays,
+			Usage:   "Give up on retrying deliveries to remote inboxes that failed this many days ago. 0 means keep retrying them forever.",
+			Value:   defaults.RetentionFailedDeliveryDays,
+			EnvVars: []string{envNames.RetentionFailedDeliveryDays},
+		},
+	}
+}
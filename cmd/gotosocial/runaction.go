@@ -19,7 +19,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -41,11 +43,26 @@ func runAction(c *cli.Context, a cliactions.GTSAction) error {
 		return fmt.Errorf("error parsing config: %s", err)
 	}
 
+	if conf.OutputFormat != config.OutputFormatText && conf.OutputFormat != config.OutputFormatJSON {
+		return fmt.Errorf("%s must be one of %s or %s, got '%s'", config.FormatFlag, config.OutputFormatText, config.OutputFormatJSON, conf.OutputFormat)
+	}
+
 	// create a logger with the log level, formatting, and output splitter already set
-	log, err := log.New(conf.LogLevel)
+	log, err := log.New(conf.LogLevel, conf.LogFormat)
 	if err != nil {
 		return fmt.Errorf("error creating logger: %s", err)
 	}
 
-	return a(c.Context, conf, log)
+	if conf.OutputFormat == config.OutputFormatJSON {
+		// keep stdout clean for the json result of the action
+		log.SetOutput(os.Stderr)
+	}
+
+	if err := a(c.Context, conf, log); err != nil {
+		if conf.OutputFormat == config.OutputFormatJSON {
+			_ = json.NewEncoder(os.Stdout).Encode(map[string]string{"error": err.Error()})
+		}
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,83 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func spamFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    flagNames.SpamEnabled,
+			Usage:   "Check incoming federated statuses for spam",
+			Value:   defaults.SpamEnabled,
+			EnvVars: []string{envNames.SpamEnabled},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.SpamReportScore,
+			Usage:   "Flag incoming statuses scoring at least this much for moderators to look at. 0 means never.",
+			Value:   defaults.SpamReportScore,
+			EnvVars: []string{envNames.SpamReportScore},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.SpamHoldScore,
+			Usage:   "Hold incoming statuses scoring at least this much until a moderator releases them. 0 means never.",
+			Value:   defaults.SpamHoldScore,
+			EnvVars: []string{envNames.SpamHoldScore},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.SpamDropScore,
+			Usage:   "Drop incoming statuses scoring at least this much. 0 means never.",
+			Value:   defaults.SpamDropScore,
+			EnvVars: []string{envNames.SpamDropScore},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.SpamNewAccountHours,
+			Usage:   "Remote accounts that were first seen less than this many hours ago count as new.",
+			Value:   defaults.SpamNewAccountHours,
+			EnvVars: []string{envNames.SpamNewAccountHours},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.SpamFirstContactMentionScore,
+			Usage:   "Score to add for each local account mentioned in a status by someone they don't follow.",
+			Value:   defaults.SpamFirstContactMentionScore,
+			EnvVars: []string{envNames.SpamFirstContactMentionScore},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.SpamLinkOnlyScore,
+			Usage:   "Score to add for statuses from new accounts that contain nothing but links and mentions.",
+			Value:   defaults.SpamLinkOnlyScore,
+			EnvVars: []string{envNames.SpamLinkOnlyScore},
+		},
+		&cli.StringSliceFlag{
+			Name:    flagNames.SpamKeywords,
+			Usage:   "Keywords that mark a status as spam, matched case-insensitively against the status text.",
+			Value:   cli.NewStringSlice(defaults.SpamKeywords...),
+			EnvVars: []string{envNames.SpamKeywords},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.SpamKeywordScore,
+			Usage:   "Score to add for each spam keyword found in a status.",
+			Value:   defaults.SpamKeywordScore,
+			EnvVars: []string{envNames.SpamKeywordScore},
+		},
+	}
+}
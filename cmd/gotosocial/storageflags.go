@@ -27,7 +27,7 @@ func storageFlags(flagNames, envNames config.Flags, defaults config.Defaults) []
 	return []cli.Flag{
 		&cli.StringFlag{
 			Name:    flagNames.StorageBackend,
-			Usage:   "Storage backend to use for media attachments",
+			Usage:   "Storage backend to use for media attachments: local or s3",
 			Value:   defaults.StorageBackend,
 			EnvVars: []string{envNames.StorageBackend},
 		},
@@ -55,5 +55,41 @@ func storageFlags(flagNames, envNames config.Flags, defaults config.Defaults) []
 			Value:   defaults.StorageServeBasePath,
 			EnvVars: []string{envNames.StorageServeBasePath},
 		},
+		&cli.StringFlag{
+			Name:    flagNames.StorageS3Endpoint,
+			Usage:   "Endpoint of the s3-compatible object store to use when storage backend is s3, eg., https://s3.eu-west-1.amazonaws.com",
+			Value:   defaults.StorageS3Endpoint,
+			EnvVars: []string{envNames.StorageS3Endpoint},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.StorageS3Region,
+			Usage:   "Region of the s3 bucket, used when signing requests",
+			Value:   defaults.StorageS3Region,
+			EnvVars: []string{envNames.StorageS3Region},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.StorageS3Bucket,
+			Usage:   "Name of an already-created s3 bucket to store media files in",
+			Value:   defaults.StorageS3Bucket,
+			EnvVars: []string{envNames.StorageS3Bucket},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.StorageS3AccessKey,
+			Usage:   "Access key to use for the s3 bucket",
+			Value:   defaults.StorageS3AccessKey,
+			EnvVars: []string{envNames.StorageS3AccessKey},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.StorageS3SecretKey,
+			Usage:   "Secret key to use for the s3 bucket",
+			Value:   defaults.StorageS3SecretKey,
+			EnvVars: []string{envNames.StorageS3SecretKey},
+		},
+		&cli.BoolFlag{
+			Name:    flagNames.StorageS3Presign,
+			Usage:   "Redirect requests for media to short-lived presigned urls of the s3 bucket, instead of proxying the media",
+			Value:   defaults.StorageS3Presign,
+			EnvVars: []string{envNames.StorageS3Presign},
+		},
 	}
 }
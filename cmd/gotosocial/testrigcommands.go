@@ -20,6 +20,7 @@ package main
 
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/cliactions/testrig"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/urfave/cli/v2"
 )
 
@@ -36,6 +37,40 @@ func testrigCommands() []*cli.Command {
 						return runAction(c, testrig.Start)
 					},
 				},
+				{
+					Name:  "seed",
+					Usage: "populate an instance with demo accounts, follows, statuses and media, for load testing and ui development",
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:  config.SeedAccountsFlag,
+							Usage: config.SeedAccountsUsage,
+							Value: 10,
+						},
+						&cli.IntFlag{
+							Name:  config.SeedFollowsFlag,
+							Usage: config.SeedFollowsUsage,
+							Value: 5,
+						},
+						&cli.IntFlag{
+							Name:  config.SeedStatusesFlag,
+							Usage: config.SeedStatusesUsage,
+							Value: 20,
+						},
+						&cli.IntFlag{
+							Name:  config.SeedMediaFlag,
+							Usage: config.SeedMediaUsage,
+							Value: 2,
+						},
+						&cli.StringFlag{
+							Name:  config.SeedMediaPathFlag,
+							Usage: config.SeedMediaPathUsage,
+							Value: "./testrig/media",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return runAction(c, testrig.Seed)
+					},
+				},
 			},
 		},
 	}
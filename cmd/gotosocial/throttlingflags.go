@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func throttlingFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    flagNames.ThrottlingSearchConcurrency,
+			Usage:   "Max number of search requests to handle at once; further requests get a 503. 0 means no limit.",
+			Value:   defaults.ThrottlingSearchConcurrency,
+			EnvVars: []string{envNames.ThrottlingSearchConcurrency},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.ThrottlingSearchPerIPPerMinute,
+			Usage:   "Max number of search requests one ip address can make per minute; further requests get a 429. 0 means no limit.",
+			Value:   defaults.ThrottlingSearchPerIPPerMinute,
+			EnvVars: []string{envNames.ThrottlingSearchPerIPPerMinute},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.ThrottlingMediaConcurrency,
+			Usage:   "Max number of media uploads to handle at once; further requests get a 503. 0 means no limit.",
+			Value:   defaults.ThrottlingMediaConcurrency,
+			EnvVars: []string{envNames.ThrottlingMediaConcurrency},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.ThrottlingMediaPerIPPerMinute,
+			Usage:   "Max number of media uploads one ip address can make per minute; further requests get a 429. 0 means no limit.",
+			Value:   defaults.ThrottlingMediaPerIPPerMinute,
+			EnvVars: []string{envNames.ThrottlingMediaPerIPPerMinute},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.ThrottlingInboxConcurrency,
+			Usage:   "Max number of activitypub inbox POSTs to handle at once; further requests get a 503. 0 means no limit.",
+			Value:   defaults.ThrottlingInboxConcurrency,
+			EnvVars: []string{envNames.ThrottlingInboxConcurrency},
+		},
+		&cli.IntFlag{
+			Name:    flagNames.ThrottlingInboxPerIPPerMinute,
+			Usage:   "Max number of activitypub inbox POSTs one ip address can make per minute; further requests get a 429. 0 means no limit.",
+			Value:   defaults.ThrottlingInboxPerIPPerMinute,
+			EnvVars: []string{envNames.ThrottlingInboxPerIPPerMinute},
+		},
+	}
+}
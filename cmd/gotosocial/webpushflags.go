@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/urfave/cli/v2"
+)
+
+func webPushFlags(flagNames, envNames config.Flags, defaults config.Defaults) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagNames.WebPushContactEmail,
+			Usage:   "Email address that push services can use to contact the instance admin. If not set, the instance url is given instead.",
+			Value:   defaults.WebPushContactEmail,
+			EnvVars: []string{envNames.WebPushContactEmail},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.WebPushVAPIDPublicKey,
+			Usage:   "Base64url encoded VAPID public key to identify the instance to push services with. If not set, a key pair is generated and stored in the database.",
+			Value:   defaults.WebPushVAPIDPublicKey,
+			EnvVars: []string{envNames.WebPushVAPIDPublicKey},
+		},
+		&cli.StringFlag{
+			Name:    flagNames.WebPushVAPIDPrivateKey,
+			Usage:   "Base64url encoded VAPID private key belonging to the VAPID public key.",
+			Value:   defaults.WebPushVAPIDPrivateKey,
+			EnvVars: []string{envNames.WebPushVAPIDPrivateKey},
+		},
+	}
+}
@@ -0,0 +1,57 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package acmedns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// execProvider solves challenges by running an external program, as:
+//
+//	<path> present <fqdn> <value>
+//	<path> cleanup <fqdn> <value>
+type execProvider struct {
+	path string
+}
+
+func newExecProvider(path string) (Provider, error) {
+	if path == "" {
+		return nil, errors.New("exec dns provider needs a path to run")
+	}
+	return &execProvider{path: path}, nil
+}
+
+func (p *execProvider) Present(ctx context.Context, fqdn string, value string) error {
+	return p.run(ctx, "present", fqdn, value)
+}
+
+func (p *execProvider) CleanUp(ctx context.Context, fqdn string, value string) error {
+	return p.run(ctx, "cleanup", fqdn, value)
+}
+
+func (p *execProvider) run(ctx context.Context, action string, fqdn string, value string) error {
+	out, err := exec.CommandContext(ctx, p.path, action, fqdn, value).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error running %s %s: %s: %s", p.path, action, err, out)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package acmedns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// httpReqProvider solves challenges by POSTing the record to an http endpoint, as:
+//
+//	POST <endpoint>/present {"fqdn":"<fqdn>","value":"<value>"}
+//	POST <endpoint>/cleanup {"fqdn":"<fqdn>","value":"<value>"}
+//
+// Basic auth credentials can be included in the endpoint url.
+type httpReqProvider struct {
+	endpoint string
+	client   *http.Client
+}
+
+type httpReqRecord struct {
+	FQDN  string `json:"fqdn"`
+	Value string `json:"value"`
+}
+
+func newHTTPReqProvider(endpoint string, client *http.Client) (Provider, error) {
+	if endpoint == "" {
+		return nil, errors.New("httpreq dns provider needs an endpoint to post to")
+	}
+	return &httpReqProvider{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   client,
+	}, nil
+}
+
+func (p *httpReqProvider) Present(ctx context.Context, fqdn string, value string) error {
+	return p.post(ctx, "present", fqdn, value)
+}
+
+func (p *httpReqProvider) CleanUp(ctx context.Context, fqdn string, value string) error {
+	return p.post(ctx, "cleanup", fqdn, value)
+}
+
+func (p *httpReqProvider) post(ctx context.Context, action string, fqdn string, value string) error {
+	b, err := json.Marshal(&httpReqRecord{
+		FQDN:  fqdn,
+		Value: value,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/"+action, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting to %s: %s", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("error posting to %s: got status %s", action, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,405 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package acmedns
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	// renew certificates when they have less than this long left before they expire
+	renewBefore = 30 * 24 * time.Hour
+	// how often to check if the certificate needs renewing
+	checkInterval = 12 * time.Hour
+	// how long to wait before trying again if obtaining a certificate failed
+	retryInterval = time.Hour
+	// how long to wait for a TXT record to become visible before asking the CA to check it anyway
+	propagationTimeout = 2 * time.Minute
+	// how often to check whether a TXT record is visible yet
+	propagationInterval = 5 * time.Second
+)
+
+// Manager obtains and renews a letsencrypt certificate for the instance host using dns-01 challenges,
+// and serves it through GetCertificate.
+type Manager struct {
+	host       string
+	email      string
+	certDir    string
+	keyType    string
+	provider   Provider
+	httpClient *http.Client
+	log        *logrus.Logger
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// NewManager returns a new Manager using the letsencrypt settings from the given config.
+// Any certificate obtained on a previous run is loaded from the cert dir.
+func NewManager(c *config.Config, log *logrus.Logger) (*Manager, error) {
+	provider, err := NewProvider(c)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := transport.NewClient(c)
+	if err != nil {
+		return nil, fmt.Errorf("error creating acme http client: %s", err)
+	}
+
+	if err := os.MkdirAll(c.LetsEncryptConfig.CertDir, 0700); err != nil {
+		return nil, fmt.Errorf("error creating cert dir: %s", err)
+	}
+
+	m := &Manager{
+		host:       c.Host,
+		email:      c.LetsEncryptConfig.EmailAddress,
+		certDir:    c.LetsEncryptConfig.CertDir,
+		keyType:    c.LetsEncryptConfig.KeyType,
+		provider:   provider,
+		httpClient: httpClient,
+		log:        log,
+	}
+
+	cert, err := tls.LoadX509KeyPair(m.certPath(), m.keyPath())
+	switch {
+	case err == nil:
+		m.cert = &cert
+	case errors.Is(err, os.ErrNotExist):
+		// we'll get one when Run is called
+	default:
+		return nil, fmt.Errorf("error loading stored certificate: %s", err)
+	}
+
+	return m, nil
+}
+
+// GetCertificate can be used as the GetCertificate function of a tls.Config.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.cert == nil {
+		return nil, errors.New("no certificate has been obtained yet")
+	}
+	return m.cert, nil
+}
+
+// Run obtains a certificate if there isn't one yet, and then keeps it renewed until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context) {
+	for {
+		wait := checkInterval
+		if m.needsRenewal() {
+			m.log.WithContext(ctx).WithField("host", m.host).Info("obtaining letsencrypt certificate using dns-01 challenge")
+			if err := m.obtain(ctx); err != nil {
+				m.log.WithError(err).Error("error obtaining letsencrypt certificate")
+				wait = retryInterval
+			} else {
+				m.log.WithContext(ctx).WithField("host", m.host).Info("obtained letsencrypt certificate")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (m *Manager) needsRenewal() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.cert == nil || len(m.cert.Certificate) == 0 {
+		return true
+	}
+
+	leaf, err := x509.ParseCertificate(m.cert.Certificate[0])
+	if err != nil {
+		return true
+	}
+	return time.Until(leaf.NotAfter) < renewBefore
+}
+
+// obtain does the whole dance of ordering a certificate, solving the challenges, and storing the result.
+func (m *Manager) obtain(ctx context.Context) error {
+	client, err := m.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(m.host))
+	if err != nil {
+		return fmt.Errorf("error creating order: %s", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := m.authorize(ctx, client, authzURL); err != nil {
+			return err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return fmt.Errorf("error waiting for order: %s", err)
+	}
+
+	key, err := newKey(m.keyType)
+	if err != nil {
+		return err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{m.host}}, key)
+	if err != nil {
+		return fmt.Errorf("error creating certificate request: %s", err)
+	}
+
+	der, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return fmt.Errorf("error finalizing order: %s", err)
+	}
+
+	return m.store(key, der)
+}
+
+// authorize solves the dns-01 challenge of the authorization at authzURL.
+func (m *Manager) authorize(ctx context.Context, client *acme.Client, authzURL string) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("error getting authorization: %s", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("no dns-01 challenge offered for %s", authz.Identifier.Value)
+	}
+
+	value, err := client.DNS01ChallengeRecord(challenge.Token)
+	if err != nil {
+		return fmt.Errorf("error deriving challenge record: %s", err)
+	}
+	fqdn := "_acme-challenge." + authz.Identifier.Value + "."
+
+	if err := m.provider.Present(ctx, fqdn, value); err != nil {
+		return fmt.Errorf("error presenting challenge record: %s", err)
+	}
+	defer func() {
+		if err := m.provider.CleanUp(ctx, fqdn, value); err != nil {
+			m.log.WithError(err).WithField("fqdn", fqdn).Error("error cleaning up challenge record")
+		}
+	}()
+
+	if !waitForTXT(ctx, fqdn, value) {
+		m.log.WithContext(ctx).WithFields(logrus.Fields{
+			"fqdn":    fqdn,
+			"timeout": propagationTimeout,
+		}).Warn("challenge record not visible in time, asking letsencrypt to check anyway")
+	}
+
+	if _, err := client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("error accepting challenge: %s", err)
+	}
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("error waiting for authorization: %s", err)
+	}
+	return nil
+}
+
+// client returns an acme client using the stored account key, registering the account if necessary.
+func (m *Manager) client(ctx context.Context) (*acme.Client, error) {
+	key, err := m.accountKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client := &acme.Client{
+		Key:        key,
+		HTTPClient: m.httpClient,
+		UserAgent:  "gotosocial",
+	}
+
+	account := &acme.Account{}
+	if m.email != "" {
+		account.Contact = []string{"mailto:" + m.email}
+	}
+	if _, err := client.Register(ctx, account, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("error registering acme account: %s", err)
+	}
+
+	return client, nil
+}
+
+// accountKey loads the acme account key from the cert dir, creating it if it doesn't exist yet.
+func (m *Manager) accountKey() (crypto.Signer, error) {
+	path := filepath.Join(m.certDir, "dns01_account.key")
+
+	b, err := os.ReadFile(path)
+	if err == nil {
+		return parseKey(b)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading acme account key: %s", err)
+	}
+
+	key, err := newKey("ecdsa-p256")
+	if err != nil {
+		return nil, err
+	}
+	b, err = encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return nil, fmt.Errorf("error writing acme account key: %s", err)
+	}
+	return key, nil
+}
+
+// store writes the certificate chain and key to the cert dir, and starts serving them.
+func (m *Manager) store(key crypto.Signer, der [][]byte) error {
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return err
+	}
+
+	certPEM := []byte{}
+	for _, d := range der {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: d})...)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("error parsing obtained certificate: %s", err)
+	}
+
+	if err := os.WriteFile(m.keyPath(), keyPEM, 0600); err != nil {
+		return fmt.Errorf("error writing certificate key: %s", err)
+	}
+	if err := os.WriteFile(m.certPath(), certPEM, 0600); err != nil {
+		return fmt.Errorf("error writing certificate: %s", err)
+	}
+
+	m.mu.Lock()
+	m.cert = &cert
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Manager) certPath() string {
+	return filepath.Join(m.certDir, "dns01_"+m.host+".crt")
+}
+
+func (m *Manager) keyPath() string {
+	return filepath.Join(m.certDir, "dns01_"+m.host+".key")
+}
+
+// waitForTXT polls until a TXT record with the given value is visible at fqdn, returning
+// false if it still isn't visible once propagationTimeout has passed.
+func waitForTXT(ctx context.Context, fqdn string, value string) bool {
+	ctx, cancel := context.WithTimeout(ctx, propagationTimeout)
+	defer cancel()
+
+	for {
+		records, _ := net.DefaultResolver.LookupTXT(ctx, fqdn)
+		for _, r := range records {
+			if r == value {
+				return true
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(propagationInterval):
+		}
+	}
+}
+
+// newKey generates a new private key of the given type.
+func newKey(keyType string) (crypto.Signer, error) {
+	switch keyType {
+	case "ecdsa-p256":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ecdsa-p384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "rsa-2048":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "rsa-4096":
+		return rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		return nil, fmt.Errorf("key type %s not supported", keyType)
+	}
+}
+
+func encodeKey(key crypto.Signer) ([]byte, error) {
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding key: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}), nil
+}
+
+func parseKey(b []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("error decoding key: no pem data found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing key: %s", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("error parsing key: key is not a signer")
+	}
+	return signer, nil
+}
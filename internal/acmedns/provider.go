@@ -0,0 +1,53 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package acmedns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+// Provider creates and removes the TXT records used to solve acme dns-01 challenges.
+//
+// To add support for a new DNS service, implement this interface and add it to NewProvider.
+type Provider interface {
+	// Present creates a TXT record with the given value at fqdn, eg., '_acme-challenge.example.org.'.
+	Present(ctx context.Context, fqdn string, value string) error
+	// CleanUp removes the TXT record with the given value at fqdn, once the challenge is done with.
+	CleanUp(ctx context.Context, fqdn string, value string) error
+}
+
+// NewProvider returns the dns Provider selected in the letsencrypt config.
+func NewProvider(c *config.Config) (Provider, error) {
+	switch c.LetsEncryptConfig.DNSProvider {
+	case "exec":
+		return newExecProvider(c.LetsEncryptConfig.DNSExecPath)
+	case "httpreq":
+		client, err := transport.NewClient(c)
+		if err != nil {
+			return nil, fmt.Errorf("error creating http client: %s", err)
+		}
+		return newHTTPReqProvider(c.LetsEncryptConfig.DNSHTTPReqEndpoint, client)
+	default:
+		return nil, fmt.Errorf("dns provider %s not supported", c.LetsEncryptConfig.DNSProvider)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package acmedns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type ProviderTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ProviderTestSuite) TestHTTPReqProvider() {
+	received := map[string]httpReqRecord{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		suite.Equal("user", user)
+		suite.Equal("pass", pass)
+
+		record := httpReqRecord{}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&record))
+		received[r.URL.Path] = record
+	}))
+	defer server.Close()
+
+	c := config.TestDefault()
+	c.LetsEncryptConfig.DNSProvider = "httpreq"
+	c.LetsEncryptConfig.DNSHTTPReqEndpoint = "http://user:pass@" + server.Listener.Addr().String() + "/acme/"
+
+	provider, err := NewProvider(c)
+	suite.NoError(err)
+
+	suite.NoError(provider.Present(context.Background(), "_acme-challenge.example.org.", "some-value"))
+	suite.NoError(provider.CleanUp(context.Background(), "_acme-challenge.example.org.", "some-value"))
+
+	suite.Equal(map[string]httpReqRecord{
+		"/acme/present": {FQDN: "_acme-challenge.example.org.", Value: "some-value"},
+		"/acme/cleanup": {FQDN: "_acme-challenge.example.org.", Value: "some-value"},
+	}, received)
+}
+
+func (suite *ProviderTestSuite) TestHTTPReqProviderErrorStatus() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	provider, err := newHTTPReqProvider(server.URL, server.Client())
+	suite.NoError(err)
+
+	err = provider.Present(context.Background(), "_acme-challenge.example.org.", "some-value")
+	suite.EqualError(err, "error posting to present: got status 403 Forbidden")
+}
+
+func (suite *ProviderTestSuite) TestUnknownProvider() {
+	c := config.TestDefault()
+	c.LetsEncryptConfig.DNSProvider = "carrier-pigeon"
+
+	_, err := NewProvider(c)
+	suite.EqualError(err, "dns provider carrier-pigeon not supported")
+}
+
+func (suite *ProviderTestSuite) TestKeyRoundTrip() {
+	for _, keyType := range []string{"ecdsa-p256", "ecdsa-p384", "rsa-2048"} {
+		key, err := newKey(keyType)
+		suite.NoError(err)
+
+		b, err := encodeKey(key)
+		suite.NoError(err)
+
+		parsed, err := parseKey(b)
+		suite.NoError(err)
+		suite.Equal(key.Public(), parsed.Public())
+	}
+}
+
+func TestProviderTestSuite(t *testing.T) {
+	suite.Run(t, &ProviderTestSuite{})
+}
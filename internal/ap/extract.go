@@ -28,12 +28,14 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/go-fed/activity/pub"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/text/language"
 )
 
 // ExtractPreferredUsername returns a string representation of an interface's preferredUsername property.
@@ -346,6 +348,38 @@ func ExtractContent(i WithContent) (string, error) {
 	return "", errors.New("no content found")
 }
 
+// ExtractLanguage returns the ISO 639-1 code of the language of the interface's content, taken from
+// its contentMap. If the contentMap has more than one language, the alphabetically first one is used.
+func ExtractLanguage(i WithContent) (string, error) {
+	contentProperty := i.GetActivityStreamsContent()
+	if contentProperty == nil {
+		return "", errors.New("content property was nil")
+	}
+
+	langs := []string{}
+	for iter := contentProperty.Begin(); iter != contentProperty.End(); iter = iter.Next() {
+		if !iter.IsRDFLangString() {
+			continue
+		}
+		for lang := range iter.GetRDFLangString() {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		// contentMap keys are bcp47 tags like 'en-GB', of which we only keep the language
+		tag, err := language.Parse(lang)
+		if err != nil {
+			continue
+		}
+		if base, confidence := tag.Base(); confidence != language.No {
+			return base.String(), nil
+		}
+	}
+	return "", errors.New("no language found")
+}
+
 // ExtractAttachments returns a slice of attachments on the interface.
 func ExtractAttachments(i WithAttachment) ([]*gtsmodel.MediaAttachment, error) {
 	attachments := []*gtsmodel.MediaAttachment{}
@@ -389,12 +423,20 @@ func ExtractAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
 	}
 	attachment.File.ContentType = mediaType.Get()
 	attachment.Type = gtsmodel.FileTypeImage
+	if i.GetTypeName() == ObjectVideo || strings.HasPrefix(attachment.File.ContentType, "video/") {
+		attachment.Type = gtsmodel.FileTypeVideo
+	}
 
 	name, err := ExtractName(i)
 	if err == nil {
 		attachment.Description = name
 	}
 
+	if focusx, focusy, err := ExtractFocalPoint(i); err == nil {
+		attachment.FileMeta.Focus.X = focusx
+		attachment.FileMeta.Focus.Y = focusy
+	}
+
 	attachment.Processing = gtsmodel.ProcessingStatusReceived
 
 	return attachment, nil
@@ -411,28 +453,32 @@ func ExtractAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
 // }
 
 // ExtractHashtags returns a slice of tags on the interface.
+//
+// go-fed doesn't know about the Hashtag type, so hashtags are left in the tag property
+// as raw json, and have to be read out of the serialized property instead.
 func ExtractHashtags(i WithTag) ([]*gtsmodel.Tag, error) {
 	tags := []*gtsmodel.Tag{}
 	tagsProp := i.GetActivityStreamsTag()
 	if tagsProp == nil {
 		return tags, nil
 	}
-	for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
-		t := iter.GetType()
-		if t == nil {
-			continue
-		}
 
-		if t.GetTypeName() != "Hashtag" {
-			continue
-		}
+	raw, err := tagsProp.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing tags: %s", err)
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		items = []interface{}{raw}
+	}
 
-		hashtaggable, ok := t.(Hashtaggable)
-		if !ok {
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok || m["type"] != TagHashtag {
 			continue
 		}
 
-		tag, err := ExtractHashtag(hashtaggable)
+		tag, err := ExtractHashtag(m)
 		if err != nil {
 			continue
 		}
@@ -442,19 +488,23 @@ func ExtractHashtags(i WithTag) ([]*gtsmodel.Tag, error) {
 	return tags, nil
 }
 
-// ExtractHashtag returns a gtsmodel tag from a hashtaggable.
-func ExtractHashtag(i Hashtaggable) (*gtsmodel.Tag, error) {
+// ExtractHashtag returns a gtsmodel tag from the raw json of a hashtag.
+func ExtractHashtag(m map[string]interface{}) (*gtsmodel.Tag, error) {
 	tag := &gtsmodel.Tag{}
 
-	hrefProp := i.GetActivityStreamsHref()
-	if hrefProp == nil || !hrefProp.IsIRI() {
+	href, ok := m["href"].(string)
+	if !ok {
 		return nil, errors.New("no href prop")
 	}
-	tag.URL = hrefProp.GetIRI().String()
-
-	name, err := ExtractName(i)
+	hrefURL, err := url.Parse(href)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error parsing href %s: %s", href, err)
+	}
+	tag.URL = hrefURL.String()
+
+	name, ok := m["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.New("no name prop")
 	}
 	tag.Name = strings.TrimPrefix(name, "#")
 
@@ -610,3 +660,138 @@ func ExtractObject(i WithObject) (*url.URL, error) {
 	}
 	return nil, errors.New("no iri found for object prop")
 }
+
+// ExtractAnnouncedObject extracts the URL of the object of an Announce. Usually this is just
+// an IRI, but group actors often embed the object they're announcing, or the Create activity
+// that wraps it, so in those cases the id of the embedded object is returned instead.
+func ExtractAnnouncedObject(i WithObject) (*url.URL, error) {
+	objectProp := i.GetActivityStreamsObject()
+	if objectProp == nil {
+		return nil, errors.New("object property was nil")
+	}
+	for iter := objectProp.Begin(); iter != objectProp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI() != nil {
+			return iter.GetIRI(), nil
+		}
+		if iter.IsActivityStreamsCreate() {
+			return ExtractAnnouncedObject(iter.GetActivityStreamsCreate())
+		}
+		if t := iter.GetType(); t != nil {
+			if idProp := t.GetJSONLDId(); idProp != nil && idProp.IsIRI() {
+				return idProp.GetIRI(), nil
+			}
+		}
+	}
+	return nil, errors.New("no iri found for object prop")
+}
+
+// ExtractObjects extracts all the URL objects from a WithObject interface, for activities that can have more than one object.
+func ExtractObjects(i WithObject) ([]*url.URL, error) {
+	objectProp := i.GetActivityStreamsObject()
+	if objectProp == nil {
+		return nil, errors.New("object property was nil")
+	}
+	objects := []*url.URL{}
+	for iter := objectProp.Begin(); iter != objectProp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI() != nil {
+			objects = append(objects, iter.GetIRI())
+		}
+	}
+	if len(objects) == 0 {
+		return nil, errors.New("no iris found for object prop")
+	}
+	return objects, nil
+}
+
+// ExtractTarget extracts a URL target from a WithTarget interface.
+func ExtractTarget(i WithTarget) (*url.URL, error) {
+	targetProp := i.GetActivityStreamsTarget()
+	if targetProp == nil {
+		return nil, errors.New("target property was nil")
+	}
+	for iter := targetProp.Begin(); iter != targetProp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI() != nil {
+			return iter.GetIRI(), nil
+		}
+	}
+	return nil, errors.New("no iri found for target prop")
+}
+
+// ExtractAlsoKnownAs extracts the alsoKnownAs URIs of an interface, if any are set.
+// Unparseable entries are skipped.
+func ExtractAlsoKnownAs(i WithUnknownProperties) []*url.URL {
+	uris := []*url.URL{}
+	switch v := i.GetUnknownProperties()[PropertyAlsoKnownAs].(type) {
+	case []interface{}:
+		for _, item := range v {
+			if u, err := unknownPropertyIRI(item); err == nil {
+				uris = append(uris, u)
+			}
+		}
+	case nil:
+	default:
+		if u, err := unknownPropertyIRI(v); err == nil {
+			uris = append(uris, u)
+		}
+	}
+	return uris
+}
+
+// ExtractMovedTo extracts the movedTo URI of an interface.
+func ExtractMovedTo(i WithUnknownProperties) (*url.URL, error) {
+	v, ok := i.GetUnknownProperties()[PropertyMovedTo]
+	if !ok {
+		return nil, errors.New("movedTo property was not set")
+	}
+	return unknownPropertyIRI(v)
+}
+
+// ExtractFocalPoint extracts the focal point of an interface, which should be
+// given as an array of two numbers, each between -1 and 1.
+func ExtractFocalPoint(i WithUnknownProperties) (focusx, focusy float32, err error) {
+	v, ok := i.GetUnknownProperties()[PropertyFocalPoint].([]interface{})
+	if !ok || len(v) != 2 {
+		err = errors.New("focalPoint property was not set or was not an array of two items")
+		return
+	}
+
+	x, xOK := v[0].(float64)
+	y, yOK := v[1].(float64)
+	if !xOK || !yOK || x < -1 || x > 1 || y < -1 || y > 1 {
+		err = fmt.Errorf("focalPoint %v was not two numbers between -1 and 1", v)
+		return
+	}
+
+	return float32(x), float32(y), nil
+}
+
+// unknownPropertyIRI parses an IRI out of a raw json value, such as that of an unknown property,
+// which may be either a plain string, or an object with an id.
+func unknownPropertyIRI(v interface{}) (*url.URL, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case map[string]interface{}:
+		s, _ = v["id"].(string)
+	}
+	if s == "" {
+		return nil, errors.New("no iri found for property")
+	}
+	return url.Parse(s)
+}
+
+// ExtractSharedInbox extracts the sharedInbox URI from the endpoints of an actor, if it has one.
+func ExtractSharedInbox(i WithSerialize) (*url.URL, error) {
+	m, err := i.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, ok := m["endpoints"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("endpoints property was not set")
+	}
+
+	return unknownPropertyIRI(endpoints["sharedInbox"])
+}
@@ -86,6 +86,27 @@ func (suite *ExtractAttachmentsTestSuite) TestExtractAttachmentMissingURL() {
 	suite.Nil(attachment)
 }
 
+func (suite *ExtractAttachmentsTestSuite) TestExtractAttachmentFocalPoint() {
+	d1 := suite.document1
+	d1.GetUnknownProperties()[ap.PropertyFocalPoint] = []interface{}{-0.5, 0.25}
+
+	attachment, err := ap.ExtractAttachment(d1)
+	suite.NoError(err)
+	suite.Equal(float32(-0.5), attachment.FileMeta.Focus.X)
+	suite.Equal(float32(0.25), attachment.FileMeta.Focus.Y)
+}
+
+func (suite *ExtractAttachmentsTestSuite) TestExtractAttachmentInvalidFocalPoint() {
+	d1 := suite.document1
+	d1.GetUnknownProperties()[ap.PropertyFocalPoint] = []interface{}{-0.5, 2.0}
+
+	// the attachment is still fine, it just keeps the default focus
+	attachment, err := ap.ExtractAttachment(d1)
+	suite.NoError(err)
+	suite.Zero(attachment.FileMeta.Focus.X)
+	suite.Zero(attachment.FileMeta.Focus.Y)
+}
+
 func TestExtractAttachmentsTestSuite(t *testing.T) {
 	suite.Run(t, &ExtractAttachmentsTestSuite{})
 }
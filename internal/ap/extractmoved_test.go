@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ap_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+const movedPersonJSON = `{
+  "@context": ["https://www.w3.org/ns/activitystreams", "https://w3id.org/security/v1"],
+  "id": "https://old.example.org/users/someone",
+  "type": "Person",
+  "preferredUsername": "someone",
+  "inbox": "https://old.example.org/users/someone/inbox",
+  "alsoKnownAs": ["https://new.example.org/users/someone", {"id": "https://other.example.org/@someone"}],
+  "movedTo": "https://new.example.org/users/someone"
+}`
+
+type ExtractMovedTestSuite struct {
+	ExtractTestSuite
+}
+
+func (suite *ExtractMovedTestSuite) TestExtractAlsoKnownAsAndMovedTo() {
+	m := make(map[string]interface{})
+	suite.NoError(json.Unmarshal([]byte(movedPersonJSON), &m))
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	person, ok := t.(ap.WithUnknownProperties)
+	suite.True(ok)
+
+	alsoKnownAs := ap.ExtractAlsoKnownAs(person)
+	suite.Len(alsoKnownAs, 2)
+	suite.Equal("https://new.example.org/users/someone", alsoKnownAs[0].String())
+	suite.Equal("https://other.example.org/@someone", alsoKnownAs[1].String())
+
+	movedTo, err := ap.ExtractMovedTo(person)
+	suite.NoError(err)
+	suite.Equal("https://new.example.org/users/someone", movedTo.String())
+}
+
+func (suite *ExtractMovedTestSuite) TestExtractMovedToNotSet() {
+	note := suite.noteWithMentions1
+
+	suite.Empty(ap.ExtractAlsoKnownAs(note))
+
+	_, err := ap.ExtractMovedTo(note)
+	suite.Error(err)
+}
+
+func TestExtractMovedTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractMovedTestSuite{})
+}
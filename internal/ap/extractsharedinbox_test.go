@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ap_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+const personWithSharedInboxJSON = `{
+  "@context": ["https://www.w3.org/ns/activitystreams", "https://w3id.org/security/v1"],
+  "id": "https://example.org/users/someone",
+  "type": "Person",
+  "preferredUsername": "someone",
+  "inbox": "https://example.org/users/someone/inbox",
+  "endpoints": {
+    "sharedInbox": "https://example.org/inbox"
+  }
+}`
+
+type ExtractSharedInboxTestSuite struct {
+	ExtractTestSuite
+}
+
+func (suite *ExtractSharedInboxTestSuite) TestExtractSharedInbox() {
+	m := make(map[string]interface{})
+	suite.NoError(json.Unmarshal([]byte(personWithSharedInboxJSON), &m))
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	person, ok := t.(ap.WithSerialize)
+	suite.True(ok)
+
+	sharedInbox, err := ap.ExtractSharedInbox(person)
+	suite.NoError(err)
+	suite.Equal("https://example.org/inbox", sharedInbox.String())
+}
+
+func (suite *ExtractSharedInboxTestSuite) TestExtractSharedInboxNotSet() {
+	_, err := ap.ExtractSharedInbox(suite.noteWithMentions1)
+	suite.Error(err)
+}
+
+func TestExtractSharedInboxTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractSharedInboxTestSuite{})
+}
@@ -40,6 +40,9 @@ type Accountable interface {
 	WithFollowers
 	WithFeatured
 	WithManuallyApprovesFollowers
+	WithTag
+	WithUnknownProperties
+	WithSerialize
 }
 
 // Statusable represents the minimum activitypub interface for representing a 'status'.
@@ -70,13 +73,7 @@ type Attachmentable interface {
 	WithMediaType
 	WithURL
 	WithName
-}
-
-// Hashtaggable represents the minimum activitypub interface for representing a 'hashtag' tag.
-type Hashtaggable interface {
-	WithTypeName
-	WithHref
-	WithName
+	WithUnknownProperties
 }
 
 // Emojiable represents the minimum interface for an 'emoji' tag.
@@ -110,6 +107,7 @@ type Likeable interface {
 
 	WithActor
 	WithObject
+	WithContent
 }
 
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
@@ -121,6 +119,16 @@ type Blockable interface {
 	WithObject
 }
 
+// Flaggable represents the minimum interface for an activitystreams 'flag' activity.
+type Flaggable interface {
+	WithJSONLDId
+	WithTypeName
+
+	WithActor
+	WithObject
+	WithContent
+}
+
 // Announceable represents the minimum interface for an activitystreams 'announce' activity.
 type Announceable interface {
 	WithJSONLDId
@@ -133,6 +141,16 @@ type Announceable interface {
 	WithCC
 }
 
+// Moveable represents the minimum interface for an activitystreams 'move' activity.
+type Moveable interface {
+	WithJSONLDId
+	WithTypeName
+
+	WithActor
+	WithObject
+	WithTarget
+}
+
 // CollectionPageable represents the minimum interface for an activitystreams 'CollectionPage' object.
 type CollectionPageable interface {
 	WithJSONLDId
@@ -306,6 +324,21 @@ type WithObject interface {
 	GetActivityStreamsObject() vocab.ActivityStreamsObjectProperty
 }
 
+// WithTarget represents an activity with ActivityStreamsTargetProperty
+type WithTarget interface {
+	GetActivityStreamsTarget() vocab.ActivityStreamsTargetProperty
+}
+
+// WithUnknownProperties represents an activity with properties that go-fed doesn't know about, such as alsoKnownAs and movedTo.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
+// WithSerialize represents a type that can be serialized into its raw json-ld form.
+type WithSerialize interface {
+	Serialize() (map[string]interface{}, error)
+}
+
 // WithNext represents an activity with ActivityStreamsNextProperty
 type WithNext interface {
 	GetActivityStreamsNext() vocab.ActivityStreamsNextProperty
@@ -25,6 +25,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/captcha"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 
@@ -42,9 +43,23 @@ const (
 	MaxIDKey = "max_id"
 	// MediaOnlyKey is for specifying that only statuses with media should be returned in a list of returned statuses by an account.
 	MediaOnlyKey = "only_media"
+	// ExcludeReblogsKey is for specifying whether to exclude boosts in a list of returned statuses by an account.
+	ExcludeReblogsKey = "exclude_reblogs"
+	// SinceIDKey is for specifying the ID that returned statuses should be newer than.
+	SinceIDKey = "since_id"
+	// MinIDKey is for specifying the minimum ID of the status to retrieve.
+	MinIDKey = "min_id"
+	// TaggedKey is for specifying that only statuses using the given hashtag should be returned in a list of returned statuses by an account.
+	TaggedKey = "tagged"
 
 	// IDKey is the key to use for retrieving account ID in requests
 	IDKey = "id"
+	// AlsoKnownAsURIsKey is for giving the aliases of an account
+	AlsoKnownAsURIsKey = "also_known_as_uris"
+	// AcctKey is for looking up an account by its username, or username and domain
+	AcctKey = "acct"
+	// ExportIDKey is the key to use for retrieving export ID in requests
+	ExportIDKey = "export_id"
 	// BasePath is the base API path for this module
 	BasePath = "/api/v1/accounts"
 	// BasePathWithID is the base path for this module with the ID key
@@ -53,12 +68,28 @@ const (
 	VerifyPath = BasePath + "/verify_credentials"
 	// UpdateCredentialsPath is for updating account credentials
 	UpdateCredentialsPath = BasePath + "/update_credentials"
+	// LookupPath is for looking up an account by its acct
+	LookupPath = BasePath + "/lookup"
+	// RotateKeysPath is for replacing the keypair of an account
+	RotateKeysPath = BasePath + "/rotate_keys"
+	// DeletePath is for deleting the requesting account
+	DeletePath = BasePath + "/delete"
+	// AliasPath is for setting the aliases of the requesting account
+	AliasPath = BasePath + "/alias"
+	// MovePath is for moving the requesting account to another account
+	MovePath = BasePath + "/move"
+	// ExportsPath is for requesting and listing exports of the requesting account's data
+	ExportsPath = BasePath + "/exports"
+	// ExportsPathWithID is for downloading a single export
+	ExportsPathWithID = ExportsPath + "/:" + ExportIDKey
 	// GetStatusesPath is for showing an account's statuses
 	GetStatusesPath = BasePathWithID + "/statuses"
 	// GetFollowersPath is for showing an account's followers
 	GetFollowersPath = BasePathWithID + "/followers"
 	// GetFollowingPath is for showing account's that an account follows.
 	GetFollowingPath = BasePathWithID + "/following"
+	// GetListsPath is for showing which of the requesting account's lists an account is in
+	GetListsPath = BasePathWithID + "/lists"
 	// GetRelationshipsPath is for showing an account's relationship with other accounts
 	GetRelationshipsPath = BasePath + "/relationships"
 	// FollowPath is for POSTing new follows to, and updating existing follows
@@ -69,20 +100,28 @@ const (
 	BlockPath = BasePathWithID + "/block"
 	// UnblockPath is for removing a block of an account
 	UnblockPath = BasePathWithID + "/unblock"
+	// MutePath is for creating or updating a mute of an account
+	MutePath = BasePathWithID + "/mute"
+	// UnmutePath is for removing a mute of an account
+	UnmutePath = BasePathWithID + "/unmute"
+	// NotePath is for setting a private note on an account
+	NotePath = BasePathWithID + "/note"
 )
 
 // Module implements the ClientAPIModule interface for account-related actions
 type Module struct {
 	config    *config.Config
 	processor processing.Processor
+	captcha   captcha.Verifier
 	log       *logrus.Logger
 }
 
-// New returns a new account module
-func New(config *config.Config, processor processing.Processor, log *logrus.Logger) api.ClientModule {
+// New returns a new account module. captchaVerifier may be nil, in which case no captcha is required on sign up.
+func New(config *config.Config, processor processing.Processor, captchaVerifier captcha.Verifier, log *logrus.Logger) api.ClientModule {
 	return &Module{
 		config:    config,
 		processor: processor,
+		captcha:   captchaVerifier,
 		log:       log,
 	}
 }
@@ -95,9 +134,27 @@ func (m *Module) Route(r router.Router) error {
 	// get account
 	r.AttachHandler(http.MethodGet, BasePathWithID, m.muxHandler)
 
+	// look up account by acct
+	r.AttachHandler(http.MethodGet, LookupPath, m.AccountLookupGETHandler)
+
 	// modify account
 	r.AttachHandler(http.MethodPatch, BasePathWithID, m.muxHandler)
 
+	// rotate account keys
+	r.AttachHandler(http.MethodPost, BasePathWithID, m.muxHandler)
+
+	// delete own account
+	r.AttachHandler(http.MethodPost, DeletePath, m.AccountDeletePOSTHandler)
+
+	// set own account aliases, and move own account
+	r.AttachHandler(http.MethodPost, AliasPath, m.AccountAliasPOSTHandler)
+	r.AttachHandler(http.MethodPost, MovePath, m.AccountMovePOSTHandler)
+
+	// export own account data
+	r.AttachHandler(http.MethodGet, ExportsPath, m.AccountExportsGETHandler)
+	r.AttachHandler(http.MethodPost, ExportsPath, m.AccountExportPOSTHandler)
+	r.AttachHandler(http.MethodGet, ExportsPathWithID, m.AccountExportGETHandler)
+
 	// get account's statuses
 	r.AttachHandler(http.MethodGet, GetStatusesPath, m.AccountStatusesGETHandler)
 
@@ -105,6 +162,9 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, GetFollowersPath, m.AccountFollowersGETHandler)
 	r.AttachHandler(http.MethodGet, GetFollowingPath, m.AccountFollowingGETHandler)
 
+	// get lists that the account is in
+	r.AttachHandler(http.MethodGet, GetListsPath, m.AccountListsGETHandler)
+
 	// get relationship with account
 	r.AttachHandler(http.MethodGet, GetRelationshipsPath, m.AccountRelationshipsGETHandler)
 
@@ -116,6 +176,13 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
 	r.AttachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
 
+	// mute or unmute account
+	r.AttachHandler(http.MethodPost, MutePath, m.AccountMutePOSTHandler)
+	r.AttachHandler(http.MethodPost, UnmutePath, m.AccountUnmutePOSTHandler)
+
+	// set a private note on account
+	r.AttachHandler(http.MethodPost, NotePath, m.AccountNotePOSTHandler)
+
 	return nil
 }
 
@@ -132,5 +199,9 @@ func (m *Module) muxHandler(c *gin.Context) {
 		if strings.HasPrefix(ru, UpdateCredentialsPath) {
 			m.AccountUpdateCredentialsPATCHHandler(c)
 		}
+	case http.MethodPost:
+		if strings.HasPrefix(ru, RotateKeysPath) {
+			m.AccountRotateKeysPOSTHandler(c)
+		}
 	}
 }
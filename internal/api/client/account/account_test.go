@@ -62,7 +62,7 @@ func (suite *AccountStandardTestSuite) SetupTest() {
 	suite.log = testrig.NewTestLog()
 	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
 	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
-	suite.accountModule = account.New(suite.config, suite.processor, suite.log).(*account.Module)
+	suite.accountModule = account.New(suite.config, suite.processor, nil, suite.log).(*account.Module)
 	testrig.StandardDBSetup(suite.db, nil)
 	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
 }
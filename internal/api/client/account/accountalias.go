@@ -0,0 +1,105 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountAliasPOSTHandler swagger:operation POST /api/v1/accounts/alias accountAlias
+//
+// Set the aliases of the requesting account.
+//
+// Aliases are the ActivityPub URIs of other accounts owned by the same person. An account must list
+// another account as an alias before that other account can move its followers to it. The given aliases
+// replace any existing ones; giving no aliases removes them all.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - accounts
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// parameters:
+// - name: also_known_as_uris
+//   in: formData
+//   description: ActivityPub URIs of the accounts to set as aliases. At most 5 can be given.
+//   type: array
+//   items:
+//     type: string
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: "The account with its new aliases."
+//     schema:
+//       "$ref": "#/definitions/account"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '500':
+//      description: internal error
+func (m *Module) AccountAliasPOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountAliasPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.AccountAliasRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// clients often send also_known_as_uris[] rather than also_known_as_uris when submitting a form
+	if len(form.AlsoKnownAsURIs) == 0 {
+		form.AlsoKnownAsURIs = c.PostFormArray(AlsoKnownAsURIsKey + "[]")
+	}
+
+	acctSensitive, errWithCode := m.processor.AccountAlias(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error setting account aliases")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, acctSensitive)
+}
@@ -37,6 +37,8 @@ import (
 // The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
 // The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
 //
+// If the instance requires a captcha on sign up, captcha_response must be set to the response token from the captcha widget.
+//
 // ---
 // tags:
 // - accounts
@@ -67,10 +69,10 @@ import (
 //   '500':
 //      description: internal error
 func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "accountCreatePOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "accountCreatePOSTHandler")
 	authed, err := oauth.Authed(c, true, true, false, false)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
@@ -78,32 +80,46 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 	l.Trace("parsing request form")
 	form := &model.AccountCreateRequest{}
 	if err := c.ShouldBind(form); err != nil || form == nil {
-		l.Debugf("could not parse form from request: %s", err)
+		l.WithError(err).Debug("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
 		return
 	}
 
-	l.Tracef("validating form %+v", form)
+	l.WithField("form", form).Trace("validating form")
 	if err := validateCreateAccount(form, m.config.AccountsConfig); err != nil {
-		l.Debugf("error validating form: %s", err)
+		l.WithError(err).Debug("error validating form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	clientIP := c.ClientIP()
-	l.Tracef("attempting to parse client ip address %s", clientIP)
+	l.WithField("clientIP", clientIP).Trace("attempting to parse client ip address")
 	signUpIP := net.ParseIP(clientIP)
 	if signUpIP == nil {
-		l.Debugf("error validating sign up ip address %s", clientIP)
+		l.WithField("clientIP", clientIP).Debug("error validating sign up ip address")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "ip address could not be parsed from request"})
 		return
 	}
 
 	form.IP = signUpIP
 
+	if m.captcha != nil {
+		ok, err := m.captcha.Verify(c.Request.Context(), form.CaptchaResponse, clientIP)
+		if err != nil {
+			l.WithError(err).Error("error verifying captcha")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "captcha could not be verified, try again later"})
+			return
+		}
+		if !ok {
+			l.Debug("captcha verification failed")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "captcha verification failed"})
+			return
+		}
+	}
+
 	ti, err := m.processor.AccountCreate(c.Request.Context(), authed, form)
 	if err != nil {
-		l.Errorf("internal server error while creating new account: %s", err)
+		l.WithError(err).Error("internal server error while creating new account")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -114,10 +130,6 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 // validateCreateAccount checks through all the necessary prerequisites for creating a new account,
 // according to the provided account create request. If the account isn't eligible, an error will be returned.
 func validateCreateAccount(form *model.AccountCreateRequest, c *config.AccountsConfig) error {
-	if !c.OpenRegistration {
-		return errors.New("registration is not open for this server")
-	}
-
 	if err := validate.Username(form.Username); err != nil {
 		return err
 	}
@@ -131,7 +143,7 @@ func validateCreateAccount(form *model.AccountCreateRequest, c *config.AccountsC
 	}
 
 	if !form.Agreement {
-		return errors.New("agreement to terms and conditions not given")
+		return errors.New("agreement to the rules, terms and conditions of this instance not given")
 	}
 
 	if err := validate.Language(form.Locale); err != nil {
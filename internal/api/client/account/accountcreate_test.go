@@ -17,3 +17,66 @@
 // */
 
 package account_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+)
+
+type AccountCreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// fakeCaptcha accepts only the captcha response "solved".
+type fakeCaptcha struct{}
+
+func (fakeCaptcha) Verify(ctx context.Context, response string, remoteIP string) (bool, error) {
+	return response == "solved", nil
+}
+
+func (suite *AccountCreateTestSuite) createAccount(captchaResponse string) *httptest.ResponseRecorder {
+	form := url.Values{
+		"reason":           {"i'd like to join this instance to talk about gardening"},
+		"username":         {"new_user"},
+		"email":            {"new_user@example.org"},
+		"password":         {"a_very_long_and_complicated_password_1234"},
+		"agreement":        {"true"},
+		"locale":           {"en"},
+		"captcha_response": {captchaResponse},
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), account.BasePath, "application/x-www-form-urlencoded")
+	suite.accountModule.AccountCreatePOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *AccountCreateTestSuite) TestAccountCreateCaptchaFailed() {
+	suite.accountModule = account.New(suite.config, suite.processor, fakeCaptcha{}, suite.log).(*account.Module)
+
+	recorder := suite.createAccount("not solved")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"captcha verification failed"}`, recorder.Body.String())
+}
+
+func (suite *AccountCreateTestSuite) TestAccountCreateCaptchaSolved() {
+	suite.accountModule = account.New(suite.config, suite.processor, fakeCaptcha{}, suite.log).(*account.Module)
+
+	recorder := suite.createAccount("solved")
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *AccountCreateTestSuite) TestAccountCreateNoCaptchaConfigured() {
+	recorder := suite.createAccount("")
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func TestAccountCreateTestSuite(t *testing.T) {
+	suite.Run(t, &AccountCreateTestSuite{})
+}
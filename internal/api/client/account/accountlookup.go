@@ -0,0 +1,88 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountLookupGETHandler swagger:operation GET /api/v1/accounts/lookup accountLookup
+//
+// Look up an account by its username, or by its username and domain, without knowing its ID.
+//
+// Only accounts already known to this instance can be looked up; use search to resolve remote accounts.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: acct
+//   type: string
+//   description: The username of a local account, like `someone`, or the username and domain of any account, like `someone@example.org`.
+//   in: query
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     schema:
+//       "$ref": "#/definitions/account"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountLookupGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountLookupGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("error authing")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	acct := c.Query(AcctKey)
+	if acct == "" {
+		l.Debug("no acct specified in query")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no acct specified"})
+		return
+	}
+
+	account, errWithCode := m.processor.AccountLookup(c.Request.Context(), authed, acct)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error looking up account")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
@@ -0,0 +1,84 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountLookupTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountLookupTestSuite) lookup(acct string) (int, *apimodel.Account) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, account.LookupPath+"?acct="+acct, "")
+
+	suite.accountModule.AccountLookupGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	if recorder.Code != http.StatusOK {
+		return recorder.Code, nil
+	}
+
+	apimodelAccount := &apimodel.Account{}
+	suite.NoError(json.Unmarshal(b, apimodelAccount))
+	return recorder.Code, apimodelAccount
+}
+
+func (suite *AccountLookupTestSuite) TestLookupLocal() {
+	code, apimodelAccount := suite.lookup("admin")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(suite.testAccounts["admin_account"].ID, apimodelAccount.ID)
+
+	// the local domain can be given too
+	code, apimodelAccount = suite.lookup("@the_mighty_zork@localhost:8080")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, apimodelAccount.ID)
+}
+
+func (suite *AccountLookupTestSuite) TestLookupRemote() {
+	code, apimodelAccount := suite.lookup("foss_satan@fossbros-anonymous.io")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(suite.testAccounts["remote_account_1"].ID, apimodelAccount.ID)
+}
+
+func (suite *AccountLookupTestSuite) TestLookupNotFound() {
+	code, _ := suite.lookup("nobody@example.org")
+	suite.Equal(http.StatusNotFound, code)
+
+	code, _ = suite.lookup("")
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func TestAccountLookupTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountLookupTestSuite))
+}
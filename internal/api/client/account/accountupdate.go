@@ -73,6 +73,15 @@ import (
 //   in: formData
 //   description: Require manual approval of follow requests.
 //   type: boolean
+// - name: enable_rss
+//   in: formData
+//   description: Serve an RSS/Atom feed of public posts at /@username/feed.rss and /@username/feed.atom.
+//   type: boolean
+// - name: custom_css
+//   in: formData
+//   description: Custom CSS to use on the account's profile and status pages. Only allowed if the instance has enabled custom CSS for accounts.
+//   type: string
+//   allowEmptyValue: true
 // - name: source[privacy]
 //   in: formData
 //   description: Default post privacy for authored statuses.
@@ -85,6 +94,20 @@ import (
 //   in: formData
 //   description: Default language to use for authored statuses (ISO 6391).
 //   type: string
+// - name: source[private_blocks]
+//   in: formData
+//   description: Keep blocks private, rather than sending them to the blocked account's instance. Blocks are still enforced here either way.
+//   type: boolean
+// - name: fields_attributes[0][name]
+//   in: formData
+//   description: Name of the first profile field. Up to four fields can be set, indexed 0 to 3.
+//   type: string
+// - name: fields_attributes[0][value]
+//   in: formData
+//   description: |-
+//     Value of the first profile field. Up to four fields can be set, indexed 0 to 3.
+//     A URL value is verified by checking that the linked page links back to the profile with rel="me".
+//   type: string
 //
 // security:
 // - OAuth2 Bearer:
@@ -100,14 +123,14 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
-	l := m.log.WithField("func", "accountUpdateCredentialsPATCHHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "accountUpdateCredentialsPATCHHandler")
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
-	l.Tracef("retrieved account %+v", authed.Account.ID)
+	l.WithField("accountID", authed.Account.ID).Trace("retrieved account")
 
 	form, err := parseUpdateAccountForm(c)
 	if err != nil {
@@ -123,23 +146,26 @@ func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
 		form.Avatar == nil &&
 		form.Header == nil &&
 		form.Locked == nil &&
+		form.EnableRSS == nil &&
+		form.CustomCSS == nil &&
 		form.Source.Privacy == nil &&
 		form.Source.Sensitive == nil &&
 		form.Source.Language == nil &&
+		form.Source.PrivateBlocks == nil &&
 		form.FieldsAttributes == nil {
-		l.Debugf("could not parse form from request")
+		l.Debug("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "empty form submitted"})
 		return
 	}
 
 	acctSensitive, err := m.processor.AccountUpdate(c.Request.Context(), authed, form)
 	if err != nil {
-		l.Debugf("could not update account: %s", err)
+		l.WithError(err).Debug("could not update account")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	l.Tracef("conversion successful, returning OK and mastosensitive account %+v", acctSensitive)
+	l.WithField("account", acctSensitive).Trace("conversion successful, returning OK and mastosensitive account")
 	c.JSON(http.StatusOK, acctSensitive)
 }
 
@@ -171,5 +197,36 @@ func parseUpdateAccountForm(c *gin.Context) (*model.UpdateCredentialsRequest, er
 		form.Source.Language = &language
 	}
 
+	if privateBlocks, ok := sourceMap["private_blocks"]; ok {
+		privateBlocksBool, err := strconv.ParseBool(privateBlocks)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing form source[private_blocks]: %s", err)
+		}
+		form.Source.PrivateBlocks = &privateBlocksBool
+	}
+
+	// parse profile fields index-by-index, stopping at the first index with neither a name nor a value
+	fields := []model.UpdateField{}
+	for i := 0; ; i++ {
+		name, nameOK := c.GetPostForm(fmt.Sprintf("fields_attributes[%d][name]", i))
+		value, valueOK := c.GetPostForm(fmt.Sprintf("fields_attributes[%d][value]", i))
+		if !nameOK && !valueOK {
+			break
+		}
+
+		field := model.UpdateField{}
+		if nameOK {
+			field.Name = &name
+		}
+		if valueOK {
+			field.Value = &value
+		}
+		fields = append(fields, field)
+	}
+
+	if len(fields) != 0 {
+		form.FieldsAttributes = &fields
+	}
+
 	return form, nil
 }
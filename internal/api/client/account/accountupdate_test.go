@@ -238,6 +238,43 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateCredentialsPATCHHandlerTwo
 	suite.True(apimodelAccount.Locked)
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateCredentialsPATCHHandlerProfileFields() {
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string]string{
+			"fields_attributes[0][name]":  "website",
+			"fields_attributes[0][value]": "https://example.org",
+			"fields_attributes[1][name]":  "pronouns",
+			"fields_attributes[1][value]": "they/them",
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPatch, bodyBytes, account.UpdateCredentialsPath, w.FormDataContentType())
+
+	// call the handler
+	suite.accountModule.AccountUpdateCredentialsPATCHHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	apimodelAccount := &apimodel.Account{}
+	err = json.Unmarshal(b, apimodelAccount)
+	suite.NoError(err)
+
+	// fields should be set in order
+	suite.Len(apimodelAccount.Fields, 2)
+	suite.Equal("website", apimodelAccount.Fields[0].Name)
+	suite.Equal("https://example.org", apimodelAccount.Fields[0].Value)
+	suite.Equal("pronouns", apimodelAccount.Fields[1].Name)
+	suite.Equal("they/them", apimodelAccount.Fields[1].Value)
+}
+
 func (suite *AccountUpdateTestSuite) TestAccountUpdateCredentialsPATCHHandlerWithMedia() {
 	// set up the request
 	// we're updating the header image, the display name, and the locked status of zork
@@ -51,17 +51,17 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) AccountVerifyGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "accountVerifyGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "accountVerifyGETHandler")
 	authed, err := oauth.Authed(c, true, false, false, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 
 	acctSensitive, err := m.processor.AccountGet(c.Request.Context(), authed, authed.Account.ID)
 	if err != nil {
-		l.Debugf("error getting account from processor: %s", err)
+		l.WithError(err).Debug("error getting account from processor")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
@@ -74,6 +74,7 @@ func (m *Module) AccountBlockPOSTHandler(c *gin.Context) {
 
 	relationship, errWithCode := m.processor.AccountBlockCreate(c.Request.Context(), authed, targetAcctID)
 	if errWithCode != nil {
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -0,0 +1,188 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountExportsGETHandler swagger:operation GET /api/v1/accounts/exports accountExportsGet
+//
+// List the downloadable exports of the requesting account's data.
+//
+// Only the most recently requested export is kept, so this will contain at most one export.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: "Exports of the requesting account's data."
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/accountExport"
+//   '401':
+//      description: unauthorized
+//   '500':
+//      description: internal error
+func (m *Module) AccountExportsGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountExportsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	exports, errWithCode := m.processor.AccountExportsGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting account exports")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exports)
+}
+
+// AccountExportPOSTHandler swagger:operation POST /api/v1/accounts/exports accountExportCreate
+//
+// Request a new export of the requesting account's data.
+//
+// The export is a zip archive containing the account's profile and statuses as ActivityStreams json,
+// along with csv files of its follows, blocks, mutes and bookmarks which can be imported elsewhere.
+// Any previous export is removed.
+//
+// The archive is built in the background; it can be downloaded from the url of the export once ready is true.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '202':
+//     description: "The new export, which is still being built."
+//     schema:
+//       "$ref": "#/definitions/accountExport"
+//   '401':
+//      description: unauthorized
+//   '500':
+//      description: internal error
+func (m *Module) AccountExportPOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountExportPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	export, errWithCode := m.processor.AccountExportCreate(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error creating account export")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+// AccountExportGETHandler swagger:operation GET /api/v1/accounts/exports/{export_id} accountExportGet
+//
+// Download the archive of an export of the requesting account's data.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/zip
+//
+// parameters:
+// - name: export_id
+//   type: string
+//   description: The id of the export.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//      description: "The zip archive of the export."
+//   '202':
+//      description: "The export is still being built, try again later."
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: not found
+func (m *Module) AccountExportGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountExportGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	exportID := c.Param(ExportIDKey)
+	if exportID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no export id provided"})
+		return
+	}
+
+	content, errWithCode := m.processor.AccountExportFileGet(c.Request.Context(), authed, exportID)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting account export")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, bytes.NewReader(content.Content), map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s-%s.zip\"", authed.Account.Username, exportID),
+	})
+}
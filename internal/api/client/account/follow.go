@@ -101,6 +101,7 @@ func (m *Module) AccountFollowPOSTHandler(c *gin.Context) {
 
 	relationship, errWithCode := m.processor.AccountFollowCreate(c.Request.Context(), authed, form)
 	if errWithCode != nil {
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -76,6 +76,7 @@ func (m *Module) AccountFollowersGETHandler(c *gin.Context) {
 
 	followers, errWithCode := m.processor.AccountFollowersGet(c.Request.Context(), authed, targetAcctID)
 	if errWithCode != nil {
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -76,6 +76,7 @@ func (m *Module) AccountFollowingGETHandler(c *gin.Context) {
 
 	following, errWithCode := m.processor.AccountFollowingGet(c.Request.Context(), authed, targetAcctID)
 	if errWithCode != nil {
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
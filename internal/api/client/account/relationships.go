@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -24,7 +23,7 @@ import (
 //   type: array
 //   items:
 //     type: string
-//   description: Account IDs.
+//   description: IDs of up to 100 accounts. Relationships are returned in the same order.
 //   in: query
 //   required: true
 //
@@ -47,11 +46,11 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) AccountRelationshipsGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "AccountRelationshipsGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountRelationshipsGETHandler")
 
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("error authing: %s", err)
+		l.WithError(err).Debug("error authing")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -59,24 +58,20 @@ func (m *Module) AccountRelationshipsGETHandler(c *gin.Context) {
 	targetAccountIDs := c.QueryArray("id[]")
 	if len(targetAccountIDs) == 0 {
 		// check fallback -- let's be generous and see if maybe it's just set as 'id'?
-		id := c.Query("id")
-		if id == "" {
-			l.Debug("no account id specified in query")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "no account id specified"})
-			return
-		}
-		targetAccountIDs = append(targetAccountIDs, id)
+		targetAccountIDs = c.QueryArray("id")
+	}
+	if len(targetAccountIDs) == 0 {
+		l.Debug("no account id specified in query")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no account id specified"})
+		return
 	}
 
-	relationships := []model.Relationship{}
-
-	for _, targetAccountID := range targetAccountIDs {
-		r, errWithCode := m.processor.AccountRelationshipGet(c.Request.Context(), authed, targetAccountID)
-		if err != nil {
-			c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
-			return
-		}
-		relationships = append(relationships, *r)
+	relationships, errWithCode := m.processor.AccountRelationshipsGet(c.Request.Context(), authed, targetAccountIDs)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting relationships")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
 	}
 
 	c.JSON(http.StatusOK, relationships)
@@ -0,0 +1,66 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountRelationshipsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountRelationshipsTestSuite) TestRelationships() {
+	zork := suite.testAccounts["local_account_1"]
+	admin := suite.testAccounts["admin_account"]
+	turtle := suite.testAccounts["local_account_2"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, account.GetRelationshipsPath+"?id[]="+admin.ID+"&id[]="+zork.ID+"&id[]="+turtle.ID, "")
+
+	suite.accountModule.AccountRelationshipsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	relationships := []apimodel.Relationship{}
+	suite.NoError(json.Unmarshal(b, &relationships))
+	suite.Len(relationships, 3)
+	suite.Equal(admin.ID, relationships[0].ID)
+	suite.True(relationships[0].Following)
+	suite.Equal(zork.ID, relationships[1].ID)
+	suite.False(relationships[1].Following)
+	suite.Equal(turtle.ID, relationships[2].ID)
+	suite.True(relationships[2].Following)
+}
+
+func TestAccountRelationshipsTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountRelationshipsTestSuite))
+}
@@ -21,6 +21,7 @@ package account
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -53,7 +54,15 @@ import (
 //   required: false
 // - name: exclude_replies
 //   type: boolean
-//   description: Exclude statuses that are a reply to another status.
+//   description: |-
+//     Exclude statuses that are a reply to another status.
+//     Replies to the account's own statuses are still included, since they're part of a thread.
+//   default: false
+//   in: query
+//   required: false
+// - name: exclude_reblogs
+//   type: boolean
+//   description: Exclude statuses that are a boost of another status.
 //   default: false
 //   in: query
 //   required: false
@@ -64,18 +73,37 @@ import (
 //     The status with the specified ID will not be included in the response.
 //   in: query
 //   required: false
-// - name: pinned_only
+// - name: since_id
+//   type: string
+//   description: |-
+//     Return only statuses *NEWER* than the given since status ID.
+//     The status with the specified ID will not be included in the response.
+//   in: query
+//   required: false
+// - name: min_id
+//   type: string
+//   description: |-
+//     Return only statuses *NEWER* than the given min status ID.
+//     The status with the specified ID will not be included in the response.
+//   in: query
+//   required: false
+// - name: pinned
 //   type: boolean
-//   description: Show only pinned statuses. In other words,e xclude statuses that are not pinned to the given account ID.
+//   description: Show only pinned statuses. In other words, exclude statuses that are not pinned to the given account ID.
 //   default: false
 //   in: query
 //   required: false
-// - name: media_only
+// - name: only_media
 //   type: boolean
 //   description: Show only statuses with media attachments.
 //   default: false
 //   in: query
 //   required: false
+// - name: tagged
+//   type: string
+//   description: Show only statuses that use the hashtag with the given name, without the leading `#`.
+//   in: query
+//   required: false
 //
 // security:
 // - OAuth2 Bearer:
@@ -96,11 +124,11 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "AccountStatusesGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountStatusesGETHandler")
 
 	authed, err := oauth.Authed(c, false, false, false, false)
 	if err != nil {
-		l.Debugf("error authing: %s", err)
+		l.WithError(err).Debug("error authing")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -117,7 +145,7 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if limitString != "" {
 		i, err := strconv.ParseInt(limitString, 10, 64)
 		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
+			l.WithError(err).Debug("error parsing limit string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
 			return
 		}
@@ -129,25 +157,49 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if excludeRepliesString != "" {
 		i, err := strconv.ParseBool(excludeRepliesString)
 		if err != nil {
-			l.Debugf("error parsing replies string: %s", err)
+			l.WithError(err).Debug("error parsing replies string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse exclude replies query param"})
 			return
 		}
 		excludeReplies = i
 	}
 
+	excludeReblogs := false
+	excludeReblogsString := c.Query(ExcludeReblogsKey)
+	if excludeReblogsString != "" {
+		i, err := strconv.ParseBool(excludeReblogsString)
+		if err != nil {
+			l.WithError(err).Debug("error parsing reblogs string")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse exclude reblogs query param"})
+			return
+		}
+		excludeReblogs = i
+	}
+
 	maxID := ""
 	maxIDString := c.Query(MaxIDKey)
 	if maxIDString != "" {
 		maxID = maxIDString
 	}
 
+	sinceID := ""
+	sinceIDString := c.Query(SinceIDKey)
+	if sinceIDString != "" {
+		sinceID = sinceIDString
+	}
+
+	minID := ""
+	minIDString := c.Query(MinIDKey)
+	if minIDString != "" {
+		minID = minIDString
+	}
+
 	pinnedOnly := false
 	pinnedString := c.Query(PinnedKey)
 	if pinnedString != "" {
 		i, err := strconv.ParseBool(pinnedString)
 		if err != nil {
-			l.Debugf("error parsing pinned string: %s", err)
+			l.WithError(err).Debug("error parsing pinned string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse pinned query param"})
 			return
 		}
@@ -159,16 +211,19 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if mediaOnlyString != "" {
 		i, err := strconv.ParseBool(mediaOnlyString)
 		if err != nil {
-			l.Debugf("error parsing media only string: %s", err)
+			l.WithError(err).Debug("error parsing media only string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse media only query param"})
 			return
 		}
 		mediaOnly = i
 	}
 
-	statuses, errWithCode := m.processor.AccountStatusesGet(c.Request.Context(), authed, targetAcctID, limit, excludeReplies, maxID, pinnedOnly, mediaOnly)
+	tagged := strings.TrimPrefix(c.Query(TaggedKey), "#")
+
+	statuses, errWithCode := m.processor.AccountStatusesGet(c.Request.Context(), authed, targetAcctID, limit, excludeReplies, excludeReblogs, maxID, sinceID, minID, pinnedOnly, mediaOnly, tagged)
 	if errWithCode != nil {
-		l.Debugf("error from processor account statuses get: %s", errWithCode)
+		l.WithError(errWithCode).Debug("error from processor account statuses get")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
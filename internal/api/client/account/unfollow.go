@@ -60,17 +60,17 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) AccountUnfollowPOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "AccountUnfollowPOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AccountUnfollowPOSTHandler")
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debug(err)
+		l.WithError(err).Debug("not authed")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
 	targetAcctID := c.Param(IDKey)
 	if targetAcctID == "" {
-		l.Debug(err)
+		l.Debug("no target account id specified")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "no account id specified"})
 		return
 	}
@@ -78,6 +78,7 @@ func (m *Module) AccountUnfollowPOSTHandler(c *gin.Context) {
 	relationship, errWithCode := m.processor.AccountFollowRemove(c.Request.Context(), authed, targetAcctID)
 	if errWithCode != nil {
 		l.Debug(errWithCode.Error())
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -0,0 +1,137 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountActionPOSTHandler swagger:operation POST /api/v1/admin/accounts/{id}/action adminAccountAction
+//
+// Take a moderation action against the account with the given ID.
+//
+// Silencing an account hides its statuses from the public timelines. Suspending an account removes its
+// statuses, media and relationships, in the same way as blocking its domain would; for a local account,
+// its user is removed too, so it can't be logged in to again.
+//
+// Any action against a local account is recorded as a warning on the account, which its user can be emailed about.
+// The action type `none` only gives a local account a warning, without silencing or suspending it.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the account.
+//   in: path
+//   required: true
+// - name: type
+//   type: string
+//   description: The action to take. One of `none`, `silence` or `suspend`.
+//   in: formData
+//   required: true
+// - name: report_id
+//   type: string
+//   description: The id of an unresolved report against the account, to mark as resolved by this action.
+//   in: formData
+//   required: false
+// - name: text
+//   type: string
+//   description: Explanation of the action, recorded on the warning given to a local account. Max 5,000 chars.
+//   in: formData
+//   required: false
+// - name: send_email_notification
+//   type: boolean
+//   description: Email the user of a local account about the warning.
+//   in: formData
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The account, with the action applied.
+//     schema:
+//       "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "AccountActionPOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	accountID := c.Param(IDKey)
+	if accountID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no account id provided"})
+		return
+	}
+
+	form := &model.AdminAccountActionRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).WithField("form", c.Request.Form).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	account, errWithCode := m.processor.AdminAccountAction(c.Request.Context(), authed, accountID, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error taking action against account")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
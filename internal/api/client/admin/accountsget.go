@@ -0,0 +1,164 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountsGETHandler swagger:operation GET /api/v1/admin/accounts adminAccountsGet
+//
+// View accounts known to this instance, newest first.
+//
+// By default, all accounts are returned. Each filter that is set to true narrows down the results.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: local
+//   type: boolean
+//   description: Return only accounts on this instance.
+//   in: query
+//   required: false
+// - name: remote
+//   type: boolean
+//   description: Return only accounts on other instances.
+//   in: query
+//   required: false
+// - name: pending
+//   type: boolean
+//   description: Return only local accounts that are waiting for their signup to be approved.
+//   in: query
+//   required: false
+// - name: suspended
+//   type: boolean
+//   description: Return only suspended accounts.
+//   in: query
+//   required: false
+// - name: silenced
+//   type: boolean
+//   description: Return only silenced accounts.
+//   in: query
+//   required: false
+// - name: max_id
+//   type: string
+//   description: Return only accounts older than the account with this id.
+//   in: query
+//   required: false
+// - name: limit
+//   type: integer
+//   description: Number of accounts to return. Defaults to, and can't be more than, 100.
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: All matching accounts.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/adminAccountInfo"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountsGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "AccountsGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	filters := map[string]bool{}
+	for _, key := range []string{LocalQueryKey, RemoteQueryKey, PendingQueryKey, SuspendedQueryKey, SilencedQueryKey} {
+		filterString := c.Query(key)
+		if filterString == "" {
+			continue
+		}
+		i, err := strconv.ParseBool(filterString)
+		if err != nil {
+			l.WithError(err).Debugf("error parsing %s string", key)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse " + key + " query param"})
+			return
+		}
+		filters[key] = i
+	}
+
+	limit := 0
+	limitString := c.Query(LimitQueryKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.WithError(err).Debug("error parsing limit string")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	accounts, errWithCode := m.processor.AdminAccountsGet(
+		c.Request.Context(),
+		authed,
+		filters[LocalQueryKey],
+		filters[RemoteQueryKey],
+		filters[PendingQueryKey],
+		filters[SuspendedQueryKey],
+		filters[SilencedQueryKey],
+		c.Query(MaxIDQueryKey),
+		limit,
+	)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting accounts")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
@@ -37,13 +37,115 @@ const (
 	DomainBlocksPath = BasePath + "/domain_blocks"
 	// DomainBlocksPathWithID is used for interacting with a single domain block.
 	DomainBlocksPathWithID = DomainBlocksPath + "/:" + IDKey
+	// DomainAllowsPath is used for posting domain allows.
+	DomainAllowsPath = BasePath + "/domain_allows"
+	// DomainAllowsPathWithID is used for interacting with a single domain allow.
+	DomainAllowsPathWithID = DomainAllowsPath + "/:" + IDKey
+	// DomainPoliciesPath is used for posting domain policies.
+	DomainPoliciesPath = BasePath + "/domain_policies"
+	// DomainPoliciesPathWithID is used for interacting with a single domain policy.
+	DomainPoliciesPathWithID = DomainPoliciesPath + "/:" + IDKey
+	// DeliveriesPath is used for inspecting the delivery of outgoing activities.
+	DeliveriesPath = BasePath + "/deliveries"
+	// SpamFlagsPath is used for reviewing incoming statuses flagged by the spam checks.
+	SpamFlagsPath = BasePath + "/spam_flags"
+	// SpamFlagsPathWithID is used for interacting with a single spam flag.
+	SpamFlagsPathWithID = SpamFlagsPath + "/:" + IDKey
+	// SpamFlagReleasePath is used for marking a flagged status as not spam.
+	SpamFlagReleasePath = SpamFlagsPathWithID + "/release"
+	// ReportsPath is used for reviewing reports filed against accounts on this instance.
+	ReportsPath = BasePath + "/reports"
+	// ReportsPathWithID is used for interacting with a single report.
+	ReportsPathWithID = ReportsPath + "/:" + IDKey
+	// ReportAssignPath is used for assigning a report to the requesting moderator.
+	ReportAssignPath = ReportsPathWithID + "/assign_to_self"
+	// ReportUnassignPath is used for removing the moderator assigned to a report.
+	ReportUnassignPath = ReportsPathWithID + "/unassign"
+	// ReportResolvePath is used for marking a report as resolved.
+	ReportResolvePath = ReportsPathWithID + "/resolve"
+	// ReportReopenPath is used for marking a resolved report as unresolved again.
+	ReportReopenPath = ReportsPathWithID + "/reopen"
+	// AccountsPath is used for reviewing accounts known to this instance.
+	AccountsPath = BasePath + "/accounts"
+	// AccountsPathWithID is used for acting on a single account.
+	AccountsPathWithID = AccountsPath + "/:" + IDKey
+	// AccountActionPath is used for silencing or suspending an account.
+	AccountActionPath = AccountsPathWithID + "/action"
+	// AccountApprovePath is used for approving the pending signup of a local account.
+	AccountApprovePath = AccountsPathWithID + "/approve"
+	// AccountRejectPath is used for rejecting the pending signup of a local account.
+	AccountRejectPath = AccountsPathWithID + "/reject"
+	// AccountUnsilencePath is used for lifting the silence on an account.
+	AccountUnsilencePath = AccountsPathWithID + "/unsilence"
+	// AccountUnsuspendPath is used for lifting the suspension of a remote account.
+	AccountUnsuspendPath = AccountsPathWithID + "/unsuspend"
+	// AccountRotateKeysPath is used for replacing the keypair of a local account.
+	AccountRotateKeysPath = AccountsPathWithID + "/rotate_keys"
+	// AccountNotesPath is used for writing moderation notes about a local account.
+	AccountNotesPath = AccountsPathWithID + "/notes"
+	// AccountNotesPathWithID is used for deleting a single moderation note.
+	AccountNotesPathWithID = AccountNotesPath + "/:" + NoteIDKey
+	// TrendingTagsPath is used for reviewing trending hashtags.
+	TrendingTagsPath = BasePath + "/trends/tags"
+	// TrendingTagsPathWithID is used for interacting with a single trending hashtag.
+	TrendingTagsPathWithID = TrendingTagsPath + "/:" + IDKey
+	// TrendingTagApprovePath is used for allowing a hashtag to be shown in trends.
+	TrendingTagApprovePath = TrendingTagsPathWithID + "/approve"
+	// TrendingTagRejectPath is used for stopping a hashtag from being shown in trends.
+	TrendingTagRejectPath = TrendingTagsPathWithID + "/reject"
+	// PagesPath is used for listing static instance pages.
+	PagesPath = BasePath + "/pages"
+	// PagesPathWithSlug is used for creating, replacing and deleting a single static instance page.
+	PagesPathWithSlug = PagesPath + "/:" + SlugKey
+	// RulesPath is used for creating instance rules.
+	RulesPath = BasePath + "/rules"
+	// RulesPathWithID is used for updating and deleting a single instance rule.
+	RulesPathWithID = RulesPath + "/:" + IDKey
+	// AuditLogsPath is used for reviewing the actions that admins have taken.
+	AuditLogsPath = BasePath + "/audit_logs"
+	// MeasuresPath is used for counting events on this instance over time.
+	MeasuresPath = BasePath + "/measures"
+	// DimensionsPath is used for breaking down activity on this instance.
+	DimensionsPath = BasePath + "/dimensions"
 
+	// LocalQueryKey is for requesting only local accounts.
+	LocalQueryKey = "local"
+	// RemoteQueryKey is for requesting only remote accounts.
+	RemoteQueryKey = "remote"
+	// PendingQueryKey is for requesting only accounts that are waiting for their signup to be approved.
+	PendingQueryKey = "pending"
+	// SuspendedQueryKey is for requesting only suspended accounts.
+	SuspendedQueryKey = "suspended"
+	// SilencedQueryKey is for requesting only silenced accounts.
+	SilencedQueryKey = "silenced"
+	// MaxIDQueryKey is for paging back through results older than the given id.
+	MaxIDQueryKey = "max_id"
+	// LimitQueryKey is for setting the maximum number of results to return.
+	LimitQueryKey = "limit"
 	// ExportQueryKey is for requesting a public export of some data.
 	ExportQueryKey = "export"
+	// FormatQueryKey is for requesting an export in a format other than json, such as csv.
+	FormatQueryKey = "format"
+	// ResolvedQueryKey is for requesting resolved rather than unresolved reports.
+	ResolvedQueryKey = "resolved"
+	// AccountIDQueryKey is for requesting only reports filed by, or audit log entries for actions taken by, the given account.
+	AccountIDQueryKey = "account_id"
+	// TargetAccountIDQueryKey is for requesting only reports filed against the given account.
+	TargetAccountIDQueryKey = "target_account_id"
+	// ActivityURIQueryKey is for specifying the id of an outgoing activity.
+	ActivityURIQueryKey = "activity_uri"
+	// StatusIDQueryKey is for specifying the id of a status.
+	StatusIDQueryKey = "status_id"
 	// ImportQueryKey is for submitting an import of some data.
 	ImportQueryKey = "import"
+	// KeysKey is for specifying which measures or dimensions to return.
+	KeysKey = "keys"
 	// IDKey specifies the ID of a single item being interacted with.
 	IDKey = "id"
+	// NoteIDKey specifies the ID of a single moderation note.
+	NoteIDKey = "note_id"
+	// SlugKey specifies the slug of a single static instance page.
+	SlugKey = "slug"
 )
 
 // Module implements the ClientAPIModule interface for admin-related actions (reports, emojis, etc)
@@ -68,6 +170,48 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
 	r.AttachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
 	r.AttachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
+	r.AttachHandler(http.MethodPut, DomainBlocksPathWithID, m.DomainBlockPUTHandler)
 	r.AttachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
+	r.AttachHandler(http.MethodPost, DomainAllowsPath, m.DomainAllowsPOSTHandler)
+	r.AttachHandler(http.MethodGet, DomainAllowsPath, m.DomainAllowsGETHandler)
+	r.AttachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
+	r.AttachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
+	r.AttachHandler(http.MethodPost, DomainPoliciesPath, m.DomainPoliciesPOSTHandler)
+	r.AttachHandler(http.MethodGet, DomainPoliciesPath, m.DomainPoliciesGETHandler)
+	r.AttachHandler(http.MethodGet, DomainPoliciesPathWithID, m.DomainPolicyGETHandler)
+	r.AttachHandler(http.MethodDelete, DomainPoliciesPathWithID, m.DomainPolicyDELETEHandler)
+	r.AttachHandler(http.MethodGet, DeliveriesPath, m.DeliveriesGETHandler)
+	r.AttachHandler(http.MethodGet, SpamFlagsPath, m.SpamFlagsGETHandler)
+	r.AttachHandler(http.MethodGet, SpamFlagsPathWithID, m.SpamFlagGETHandler)
+	r.AttachHandler(http.MethodPost, SpamFlagReleasePath, m.SpamFlagReleasePOSTHandler)
+	r.AttachHandler(http.MethodDelete, SpamFlagsPathWithID, m.SpamFlagDELETEHandler)
+	r.AttachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
+	r.AttachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
+	r.AttachHandler(http.MethodPost, ReportAssignPath, m.ReportAssignPOSTHandler)
+	r.AttachHandler(http.MethodPost, ReportUnassignPath, m.ReportUnassignPOSTHandler)
+	r.AttachHandler(http.MethodPost, ReportResolvePath, m.ReportResolvePOSTHandler)
+	r.AttachHandler(http.MethodPost, ReportReopenPath, m.ReportReopenPOSTHandler)
+	r.AttachHandler(http.MethodGet, AccountsPath, m.AccountsGETHandler)
+	r.AttachHandler(http.MethodGet, AccountsPathWithID, m.AccountGETHandler)
+	r.AttachHandler(http.MethodPost, AccountActionPath, m.AccountActionPOSTHandler)
+	r.AttachHandler(http.MethodPost, AccountApprovePath, m.AccountApprovePOSTHandler)
+	r.AttachHandler(http.MethodPost, AccountRejectPath, m.AccountRejectPOSTHandler)
+	r.AttachHandler(http.MethodPost, AccountUnsilencePath, m.AccountUnsilencePOSTHandler)
+	r.AttachHandler(http.MethodPost, AccountUnsuspendPath, m.AccountUnsuspendPOSTHandler)
+	r.AttachHandler(http.MethodPost, AccountRotateKeysPath, m.AccountRotateKeysPOSTHandler)
+	r.AttachHandler(http.MethodPost, AccountNotesPath, m.AccountNotePOSTHandler)
+	r.AttachHandler(http.MethodDelete, AccountNotesPathWithID, m.AccountNoteDELETEHandler)
+	r.AttachHandler(http.MethodGet, TrendingTagsPath, m.TrendingTagsGETHandler)
+	r.AttachHandler(http.MethodPost, TrendingTagApprovePath, m.TrendingTagApprovePOSTHandler)
+	r.AttachHandler(http.MethodPost, TrendingTagRejectPath, m.TrendingTagRejectPOSTHandler)
+	r.AttachHandler(http.MethodGet, PagesPath, m.InstancePagesGETHandler)
+	r.AttachHandler(http.MethodPut, PagesPathWithSlug, m.InstancePagePUTHandler)
+	r.AttachHandler(http.MethodDelete, PagesPathWithSlug, m.InstancePageDELETEHandler)
+	r.AttachHandler(http.MethodPost, RulesPath, m.RulePOSTHandler)
+	r.AttachHandler(http.MethodPatch, RulesPathWithID, m.RulePATCHHandler)
+	r.AttachHandler(http.MethodDelete, RulesPathWithID, m.RuleDELETEHandler)
+	r.AttachHandler(http.MethodGet, AuditLogsPath, m.AuditLogsGETHandler)
+	r.AttachHandler(http.MethodPost, MeasuresPath, m.MeasuresPOSTHandler)
+	r.AttachHandler(http.MethodPost, DimensionsPath, m.DimensionsPOSTHandler)
 	return nil
 }
@@ -0,0 +1,119 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AuditLogsGETHandler swagger:operation GET /api/v1/admin/audit_logs adminAuditLogsGet
+//
+// View the actions that admins have taken on this instance, newest first.
+//
+// Entries are recorded for actions on accounts, reports, domain blocks, allows and policies,
+// emojis, rules, instance pages, spam flags, trending tags and instance settings,
+// and can't be changed or removed.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: account_id
+//   type: string
+//   description: Return only actions taken by the admin account with this id.
+//   in: query
+//   required: false
+// - name: max_id
+//   type: string
+//   description: Return only entries older than the entry with this id.
+//   in: query
+//   required: false
+// - name: limit
+//   type: integer
+//   description: Number of entries to return. Defaults to, and can't be more than, 100.
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: Matching audit log entries.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/adminAuditLog"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) AuditLogsGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "AuditLogsGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	limit := 0
+	limitString := c.Query(LimitQueryKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 64)
+		if err != nil {
+			l.WithError(err).Debug("error parsing limit string")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+			return
+		}
+		limit = int(i)
+	}
+
+	auditLogs, errWithCode := m.processor.AdminAuditLogsGet(c.Request.Context(), authed, c.Query(AccountIDQueryKey), c.Query(MaxIDQueryKey), limit)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting audit logs")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, auditLogs)
+}
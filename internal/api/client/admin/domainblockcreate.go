@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // DomainBlocksPOSTHandler swagger:operation POST /api/v1/admin/domain_blocks domainBlockCreate
@@ -37,13 +38,15 @@ import (
 //   in: query
 //   description: |-
 //     Signal that a list of domain blocks is being imported as a file.
-//     If set to true, then 'domains' must be present as a JSON-formatted file.
+//     If set to true, then 'domains' must be present as a JSON-formatted or CSV file.
 //     If set to false, then 'domains' will be ignored, and 'domain' must be present.
 //   type: boolean
 // - name: domains
 //   in: formData
 //   description: |-
-//     JSON-formatted list of domain blocks to import.
+//     List of domain blocks to import.
+//     This can be a JSON-formatted list of domain blocks as exported by GoToSocial,
+//     or a blocklist CSV file as exported by Mastodon, with or without a header row.
 //     This is only used if `import` is set to true.
 //   type: file
 // - name: domain
@@ -59,6 +62,27 @@ import (
 //     Eg., 'example.org' becomes something like 'ex***e.org'.
 //     Used only if `import` is not true.
 //   type: boolean
+// - name: severity
+//   in: formData
+//   description: |-
+//     How severe the block is. One of 'suspend', 'silence' or 'noop'. Defaults to 'suspend'.
+//     A 'suspend' block removes all accounts and statuses from the domain and refuses all federation with it.
+//     A 'silence' block hides statuses from the domain from public timelines.
+//     A 'noop' block does neither, but can be used to reject media or reports from the domain.
+//     Used only if `import` is not true.
+//   type: string
+// - name: reject_media
+//   in: formData
+//   description: |-
+//     Reject media attachments from the domain. Only relevant for 'silence' and 'noop' blocks.
+//     Used only if `import` is not true.
+//   type: boolean
+// - name: reject_reports
+//   in: formData
+//   description: |-
+//     Ignore reports from the domain. Only relevant for 'silence' and 'noop' blocks.
+//     Used only if `import` is not true.
+//   type: boolean
 // - name: public_comment
 //   in: formData
 //   description: |-
@@ -90,7 +114,7 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "DomainBlocksPOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -100,12 +124,12 @@ func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
 	// make sure we're authed with an admin account
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 	if !authed.User.Admin {
-		l.Debugf("user %s not an admin", authed.User.ID)
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
 		return
 	}
@@ -115,7 +139,7 @@ func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
 	if importString != "" {
 		i, err := strconv.ParseBool(importString)
 		if err != nil {
-			l.Debugf("error parsing import string: %s", err)
+			l.WithError(err).Debug("error parsing import string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse import query param"})
 			return
 		}
@@ -123,18 +147,18 @@ func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
 	}
 
 	// extract the media create form from the request context
-	l.Tracef("parsing request form: %+v", c.Request.Form)
+	l.WithField("form", c.Request.Form).Trace("parsing request form")
 	form := &model.DomainBlockCreateRequest{}
 	if err := c.ShouldBind(form); err != nil {
-		l.Debugf("error parsing form %+v: %s", c.Request.Form, err)
+		l.WithError(err).WithField("form", c.Request.Form).Debug("error parsing form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
 		return
 	}
 
 	// Give the fields on the request form a first pass to make sure the request is superficially valid.
-	l.Tracef("validating form %+v", form)
+	l.WithField("form", form).Trace("validating form")
 	if err := validateCreateDomainBlock(form, imp); err != nil {
-		l.Debugf("error validating form: %s", err)
+		l.WithError(err).Debug("error validating form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -143,7 +167,7 @@ func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
 		// we're importing multiple blocks
 		domainBlocks, err := m.processor.AdminDomainBlocksImport(c.Request.Context(), authed, form)
 		if err != nil {
-			l.Debugf("error importing domain blocks: %s", err)
+			l.WithError(err).Debug("error importing domain blocks")
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -152,7 +176,7 @@ func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
 		// we're just creating one block
 		domainBlock, err := m.processor.AdminDomainBlockCreate(c.Request.Context(), authed, form)
 		if err != nil {
-			l.Debugf("error creating domain block: %s", err)
+			l.WithError(err).Debug("error creating domain block")
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -170,6 +194,11 @@ func validateCreateDomainBlock(form *model.DomainBlockCreateRequest, imp bool) e
 		if form.Domain == "" {
 			return errors.New("empty domain provided")
 		}
+		if form.Severity != "" {
+			if err := validate.DomainBlockSeverity(form.Severity); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
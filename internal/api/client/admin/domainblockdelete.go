@@ -42,7 +42,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) DomainBlockDELETEHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "DomainBlockDELETEHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -52,12 +52,12 @@ func (m *Module) DomainBlockDELETEHandler(c *gin.Context) {
 	// make sure we're authed with an admin account
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 	if !authed.User.Admin {
-		l.Debugf("user %s not an admin", authed.User.ID)
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
 		return
 	}
@@ -70,7 +70,8 @@ func (m *Module) DomainBlockDELETEHandler(c *gin.Context) {
 
 	domainBlock, errWithCode := m.processor.AdminDomainBlockDelete(c.Request.Context(), authed, domainBlockID)
 	if errWithCode != nil {
-		l.Debugf("error deleting domain block: %s", errWithCode.Error())
+		l.WithError(errWithCode).Debug("error deleting domain block")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -43,7 +43,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) DomainBlockGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "DomainBlockGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -53,12 +53,12 @@ func (m *Module) DomainBlockGETHandler(c *gin.Context) {
 	// make sure we're authed with an admin account
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 	if !authed.User.Admin {
-		l.Debugf("user %s not an admin", authed.User.ID)
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
 		return
 	}
@@ -74,7 +74,7 @@ func (m *Module) DomainBlockGETHandler(c *gin.Context) {
 	if exportString != "" {
 		i, err := strconv.ParseBool(exportString)
 		if err != nil {
-			l.Debugf("error parsing export string: %s", err)
+			l.WithError(err).Debug("error parsing export string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse export query param"})
 			return
 		}
@@ -83,7 +83,7 @@ func (m *Module) DomainBlockGETHandler(c *gin.Context) {
 
 	domainBlock, err := m.processor.AdminDomainBlockGet(c.Request.Context(), authed, domainBlockID, export)
 	if err != nil {
-		l.Debugf("error getting domain block: %s", err)
+		l.WithError(err).Debug("error getting domain block")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
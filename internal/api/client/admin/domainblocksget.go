@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -19,17 +21,27 @@ import (
 //
 // produces:
 // - application/json
+// - text/csv
 //
 // parameters:
 // - name: export
 //   type: boolean
 //   description: |-
 //     If set to true, then each entry in the returned list of domain blocks will only consist of
-//     the fields 'domain' and 'public_comment'. This is perfect for when you want to save and share
+//     the fields 'domain', 'public_comment', 'obfuscate', 'severity', 'reject_media' and 'reject_reports'.
+//     This is perfect for when you want to save and share
 //     a list of all the domains you have blocked on your instance, so that someone else can easily import them,
 //     but you don't need them to see the database IDs of your blocks, or private comments etc.
 //   in: query
 //   required: false
+// - name: format
+//   type: string
+//   description: |-
+//     Set to 'csv' to download all domain blocks as a blocklist csv file in the format used by Mastodon,
+//     with the columns #domain, #severity, #reject_media, #reject_reports, #public_comment and #obfuscate.
+//     The file can be imported by another GoToSocial or Mastodon instance.
+//   in: query
+//   required: false
 //
 // security:
 // - OAuth2 Bearer:
@@ -49,7 +61,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) DomainBlocksGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "DomainBlocksGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -59,12 +71,12 @@ func (m *Module) DomainBlocksGETHandler(c *gin.Context) {
 	// make sure we're authed with an admin account
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 	if !authed.User.Admin {
-		l.Debugf("user %s not an admin", authed.User.ID)
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
 		return
 	}
@@ -74,16 +86,31 @@ func (m *Module) DomainBlocksGETHandler(c *gin.Context) {
 	if exportString != "" {
 		i, err := strconv.ParseBool(exportString)
 		if err != nil {
-			l.Debugf("error parsing export string: %s", err)
+			l.WithError(err).Debug("error parsing export string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse export query param"})
 			return
 		}
 		export = i
 	}
 
+	if c.Query(FormatQueryKey) == "csv" {
+		content, errWithCode := m.processor.AdminDomainBlocksExportCSV(c.Request.Context(), authed)
+		if errWithCode != nil {
+			l.WithError(errWithCode).Debug("error exporting domain blocks")
+			c.Error(errWithCode)
+			c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+			return
+		}
+
+		c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, bytes.NewReader(content.Content), map[string]string{
+			"Content-Disposition": fmt.Sprintf("attachment; filename=\"%s_domain_blocks.csv\"", m.config.Host),
+		})
+		return
+	}
+
 	domainBlocks, err := m.processor.AdminDomainBlocksGet(c.Request.Context(), authed, export)
 	if err != nil {
-		l.Debugf("error getting domain blocks: %s", err)
+		l.WithError(err).Debug("error getting domain blocks")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
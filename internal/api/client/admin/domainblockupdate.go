@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DomainBlockPUTHandler swagger:operation PUT /api/v1/admin/domain_blocks/{id} domainBlockUpdate
+//
+// Update the domain block with the given ID.
+//
+// Only the fields that are provided will be changed.
+// Raising the severity of a block to 'suspend' removes all accounts and statuses from the domain,
+// while lowering it from 'suspend' lifts the suspension of the domain's instance and accounts.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the domain block.
+//   in: path
+//   required: true
+// - name: obfuscate
+//   in: formData
+//   description: |-
+//     Obfuscate the name of the domain when serving it publicly.
+//     Eg., 'example.org' becomes something like 'ex***e.org'.
+//   type: boolean
+// - name: severity
+//   in: formData
+//   description: |-
+//     How severe the block is. One of 'suspend', 'silence' or 'noop'.
+//   type: string
+// - name: reject_media
+//   in: formData
+//   description: Reject media attachments from the domain. Only relevant for 'silence' and 'noop' blocks.
+//   type: boolean
+// - name: reject_reports
+//   in: formData
+//   description: Ignore reports from the domain. Only relevant for 'silence' and 'noop' blocks.
+//   type: boolean
+// - name: public_comment
+//   in: formData
+//   description: |-
+//     Public comment about this domain block.
+//     Will be displayed alongside the domain block if you choose to share blocks.
+//   type: string
+// - name: private_comment
+//   in: formData
+//   description: |-
+//     Private comment about this domain block. Will only be shown to other admins, so this
+//     is a useful way of internally keeping track of why a certain domain ended up blocked.
+//   type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated domain block.
+//     schema:
+//       "$ref": "#/definitions/domainBlock"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) DomainBlockPUTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "DomainBlockPUTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	domainBlockID := c.Param(IDKey)
+	if domainBlockID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no domain block id provided"})
+		return
+	}
+
+	form := &model.DomainBlockUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	domainBlock, errWithCode := m.processor.AdminDomainBlockUpdate(c.Request.Context(), authed, domainBlockID, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error updating domain block")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domainBlock)
+}
@@ -0,0 +1,151 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DomainPoliciesPOSTHandler swagger:operation POST /api/v1/admin/domain_policies domainPolicyCreate
+//
+// Create or update a domain policy.
+//
+// Domain policies are a more granular alternative to domain blocks: each of the policy flags can be set
+// independently to restrict what this instance accepts from the domain. If a policy already exists for
+// the given domain, it will be updated with the provided values instead.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: domain
+//   in: formData
+//   description: Single domain to apply the policy to.
+//   type: string
+//   required: true
+// - name: reject_media
+//   in: formData
+//   description: Don't fetch media attachments, avatars or headers from this domain.
+//   type: boolean
+// - name: sensitive_media
+//   in: formData
+//   description: Mark all statuses with media from this domain as sensitive.
+//   type: boolean
+// - name: reject_boosts
+//   in: formData
+//   description: Ignore boosts made by accounts on this domain.
+//   type: boolean
+// - name: silence
+//   in: formData
+//   description: |-
+//     Hide statuses from this domain from the public timeline, and drop notifications
+//     from its accounts to local accounts that don't follow them.
+//   type: boolean
+// - name: public_comment
+//   in: formData
+//   description: Public comment about this domain policy.
+//   type: string
+// - name: private_comment
+//   in: formData
+//   description: |-
+//     Private comment about this domain policy. Will only be shown to other admins, so this
+//     is a useful way of internally keeping track of why a certain domain ended up restricted.
+//   type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The newly created or updated domain policy.
+//     schema:
+//       "$ref": "#/definitions/domainPolicy"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) DomainPoliciesPOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "DomainPoliciesPOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	l.WithField("form", c.Request.Form).Trace("parsing request form")
+	form := &model.DomainPolicyCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).WithField("form", c.Request.Form).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	l.WithField("form", form).Trace("validating form")
+	if err := validateCreateDomainPolicy(form); err != nil {
+		l.WithError(err).Debug("error validating form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	domainPolicy, errWithCode := m.processor.AdminDomainPolicyCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error creating domain policy")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domainPolicy)
+}
+
+func validateCreateDomainPolicy(form *model.DomainPolicyCreateRequest) error {
+	if form.Domain == "" {
+		return errors.New("empty domain provided")
+	}
+
+	return nil
+}
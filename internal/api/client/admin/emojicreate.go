@@ -74,7 +74,7 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) emojiCreatePOSTHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "emojiCreatePOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -84,36 +84,36 @@ func (m *Module) emojiCreatePOSTHandler(c *gin.Context) {
 	// make sure we're authed with an admin account
 	authed, err := oauth.Authed(c, true, true, true, true) // posting a status is serious business so we want *everything*
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
 	if !authed.User.Admin {
-		l.Debugf("user %s not an admin", authed.User.ID)
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
 		return
 	}
 
 	// extract the media create form from the request context
-	l.Tracef("parsing request form: %+v", c.Request.Form)
+	l.WithField("form", c.Request.Form).Trace("parsing request form")
 	form := &model.EmojiCreateRequest{}
 	if err := c.ShouldBind(form); err != nil {
-		l.Debugf("error parsing form %+v: %s", c.Request.Form, err)
+		l.WithError(err).WithField("form", c.Request.Form).Debug("error parsing form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
 		return
 	}
 
 	// Give the fields on the request form a first pass to make sure the request is superficially valid.
-	l.Tracef("validating form %+v", form)
+	l.WithField("form", form).Trace("validating form")
 	if err := validateCreateEmoji(form); err != nil {
-		l.Debugf("error validating form: %s", err)
+		l.WithError(err).Debug("error validating form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	mastoEmoji, err := m.processor.AdminEmojiCreate(c.Request.Context(), authed, form)
 	if err != nil {
-		l.Debugf("error creating emoji: %s", err)
+		l.WithError(err).Debug("error creating emoji")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
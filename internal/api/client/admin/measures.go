@@ -0,0 +1,125 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MeasuresPOSTHandler swagger:operation POST /api/v1/admin/measures adminMeasures
+//
+// Count events on this instance for each day of a period of time, so that they can be charted.
+//
+// The available measures are `new_users` (sign ups), `new_statuses` (statuses posted by local accounts),
+// `opened_reports` and `resolved_reports`. Days run from midnight to midnight UTC.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+// - application/json
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: keys[]
+//   type: array
+//   items:
+//     type: string
+//   description: The measures to return.
+//   in: formData
+//   required: true
+// - name: start_at
+//   type: string
+//   description: The first day to count, as a date or datetime. Defaults to 29 days before end_at.
+//   in: formData
+//   required: false
+// - name: end_at
+//   type: string
+//   description: The last day to count, as a date or datetime. Defaults to today.
+//   in: formData
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The requested measures, in the order they were asked for.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/adminMeasure"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) MeasuresPOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "MeasuresPOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	form := &model.AdminStatisticsRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	// clients often send keys[] rather than keys when submitting a form
+	if len(form.Keys) == 0 {
+		form.Keys = c.PostFormArray(KeysKey + "[]")
+	}
+
+	measures, errWithCode := m.processor.AdminMeasuresGet(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting measures")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, measures)
+}
@@ -0,0 +1,127 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// InstancePagePUTHandler swagger:operation PUT /api/v1/admin/pages/{slug} instancePagePut
+//
+// Create or replace the static instance page with the given slug.
+//
+// The page with slug 'about' is served at /about, and the page with slug 'privacy' is served at /privacy.
+// Any other page is served at /pages/{slug}. All pages are linked from /api/v1/instance.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+// - application/json
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: slug
+//   type: string
+//   description: |-
+//     Short name of the page, used in its url.
+//     Lowercase letters and numbers, optionally separated by single dashes, max 64 characters.
+//   in: path
+//   required: true
+// - name: title
+//   in: formData
+//   description: Title of the page, max 200 characters.
+//   type: string
+//   required: true
+// - name: content
+//   in: formData
+//   description: Content of the page, max 50,000 characters.
+//   type: string
+// - name: content_type
+//   in: formData
+//   description: Format of the content, either text/markdown or text/html. Defaults to text/markdown.
+//   type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The created or updated page.
+//     schema:
+//       "$ref": "#/definitions/instancePage"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) InstancePagePUTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "InstancePagePUTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	slug := c.Param(SlugKey)
+	if slug == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no page slug provided"})
+		return
+	}
+
+	form := &apimodel.InstancePageUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	page, errWithCode := m.processor.AdminInstancePagePut(c.Request.Context(), authed, slug, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error putting instance page")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,119 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ReportsGETHandler swagger:operation GET /api/v1/admin/reports reportsGet
+//
+// View reports filed against accounts on this instance, newest first.
+//
+// By default, only reports that are still waiting for a moderator are returned.
+//
+// ---
+// tags:
+// - admin
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: resolved
+//   type: boolean
+//   description: If set to true, return only reports that have already been resolved, instead of unresolved ones.
+//   in: query
+//   required: false
+// - name: account_id
+//   type: string
+//   description: Return only reports filed by the account with this id.
+//   in: query
+//   required: false
+// - name: target_account_id
+//   type: string
+//   description: Return only reports filed against the account with this id.
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: All matching reports.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/adminReport"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportsGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "ReportsGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if !authed.User.Admin {
+		l.WithField("userID", authed.User.ID).Debug("user not an admin")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	resolved := false
+	resolvedString := c.Query(ResolvedQueryKey)
+	if resolvedString != "" {
+		i, err := strconv.ParseBool(resolvedString)
+		if err != nil {
+			l.WithError(err).Debug("error parsing resolved string")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse resolved query param"})
+			return
+		}
+		resolved = i
+	}
+
+	reports, errWithCode := m.processor.AdminReportsGet(c.Request.Context(), authed, resolved, c.Query(AccountIDQueryKey), c.Query(TargetAccountIDQueryKey))
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting reports")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
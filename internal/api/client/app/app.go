@@ -28,8 +28,18 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
-// BasePath is the base path for this api module
-const BasePath = "/api/v1/apps"
+const (
+	// IDKey is the key to use for retrieving application ID in requests
+	IDKey = "id"
+	// BasePath is the base path for this api module
+	BasePath = "/api/v1/apps"
+	// VerifyPath is for checking the application that owns the token used in a request
+	VerifyPath = BasePath + "/verify_credentials"
+	// AuthorizedPath is for listing the applications that the requesting user has authorized
+	AuthorizedPath = BasePath + "/authorized"
+	// AuthorizedPathWithID is for revoking access for a single authorized application
+	AuthorizedPathWithID = AuthorizedPath + "/:" + IDKey
+)
 
 // Module implements the ClientAPIModule interface for requests relating to registering/removing applications
 type Module struct {
@@ -50,5 +60,8 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route satisfies the RESTAPIModule interface
 func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodPost, BasePath, m.AppsPOSTHandler)
+	s.AttachHandler(http.MethodGet, VerifyPath, m.AppVerifyCredentialsGETHandler)
+	s.AttachHandler(http.MethodGet, AuthorizedPath, m.AppsAuthorizedGETHandler)
+	s.AttachHandler(http.MethodDelete, AuthorizedPathWithID, m.AppAuthorizedDELETEHandler)
 	return nil
 }
@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AppAuthorizedDELETEHandler swagger:operation DELETE /api/v1/apps/authorized/{id} appAuthorizedRevoke
+//
+// Revoke an application's access to the requesting user's account.
+//
+// All access tokens and authorization codes that the application holds for the user are deleted.
+// If the token used to make this request belongs to the application, it stops working too.
+//
+// ---
+// tags:
+// - apps
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the application.
+//   in: path
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write
+//
+// responses:
+//   '200':
+//     description: "Access for the application was revoked."
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AppAuthorizedDELETEHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AppAuthorizedDELETEHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	appID := c.Param(IDKey)
+	if appID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no application id provided"})
+		return
+	}
+
+	if errWithCode := m.processor.AppAuthorizedRevoke(c.Request.Context(), authed, appID); errWithCode != nil {
+		l.WithError(errWithCode).Debug("error revoking authorized app")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
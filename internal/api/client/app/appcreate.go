@@ -63,7 +63,7 @@ import (
 //   '500':
 //      description: internal error
 func (m *Module) AppsPOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "AppsPOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AppsPOSTHandler")
 	l.Trace("entering AppsPOSTHandler")
 
 	authed, err := oauth.Authed(c, false, false, false, false)
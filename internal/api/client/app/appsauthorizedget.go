@@ -0,0 +1,75 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AppsAuthorizedGETHandler swagger:operation GET /api/v1/apps/authorized appsAuthorizedGet
+//
+// List the applications that the requesting user has granted access to their account.
+//
+// Each application is listed once, with every scope granted to it across all of its tokens.
+//
+// ---
+// tags:
+// - apps
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read
+//
+// responses:
+//   '200':
+//     description: "Applications authorized by the requesting user, oldest authorization first."
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/authorizedApplication"
+//   '401':
+//      description: unauthorized
+//   '500':
+//      description: internal error
+func (m *Module) AppsAuthorizedGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AppsAuthorizedGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	apps, errWithCode := m.processor.AppsAuthorizedGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting authorized apps")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, apps)
+}
@@ -31,13 +31,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 // AuthorizeGETHandler should be served as GET at https://example.org/oauth/authorize
 // The idea here is to present an oauth authorize page to the user, with a button
 // that they have to click to accept. See here: https://docs.joinmastodon.org/methods/apps/oauth/#authorize-a-user
 func (m *Module) AuthorizeGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "AuthorizeGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AuthorizeGETHandler")
 	s := sessions.Default(c)
 
 	// UserID will be set in the session by AuthorizePOSTHandler if the caller has already gone through the authentication flow
@@ -47,15 +48,15 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		l.Trace("userid was empty, parsing form then redirecting to sign in page")
 		form := &model.OAuthAuthorize{}
 		if err := c.Bind(form); err != nil {
-			l.Debugf("invalid auth form: %s", err)
+			l.WithError(err).Debug("invalid auth form")
 			m.clearSession(s)
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		l.Debugf("parsed auth form: %+v", form)
+		l.WithField("form", form).Debug("parsed auth form")
 
 		if err := extractAuthForm(s, form); err != nil {
-			l.Debugf(fmt.Sprintf("error parsing form at /oauth/authorize: %s", err))
+			l.WithError(err).Debug("error parsing form at /oauth/authorize")
 			m.clearSession(s)
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -105,6 +106,11 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "no scope found in session"})
 		return
 	}
+	if !oauth.ScopesGranted(app.Scopes, scope) {
+		m.clearSession(s)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("scope %s is not allowed for application %s", scope, app.Name)})
+		return
+	}
 
 	// the authorize template will display a form to the user where they can get some information
 	// about the app that's trying to authorize, and the scope of the request.
@@ -115,6 +121,7 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		"appwebsite": app.Website,
 		"redirect":   redirect,
 		sessionScope: scope,
+		"scopes":     oauth.DescribeScopes(scope),
 		"user":       acct.Username,
 	})
 }
@@ -124,7 +131,7 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 // so we should proceed with the authentication flow and generate an oauth token for them if we can.
 // See here: https://docs.joinmastodon.org/methods/apps/oauth/#authorize-a-user
 func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "AuthorizePOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "AuthorizePOSTHandler")
 	s := sessions.Default(c)
 
 	// We need to retrieve the original form submitted to the authorizeGEThandler, and
@@ -179,7 +186,7 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 	values.Set(sessionScope, scope)
 	values.Set(sessionUserID, userID)
 	c.Request.Form = values
-	l.Tracef("values on request set to %+v", c.Request.Form)
+	l.WithField("form", c.Request.Form).Trace("values on request set")
 
 	// and proceed with authorization using the oauth2 library
 	if err := m.server.HandleAuthorizeRequest(c.Writer, c.Request); err != nil {
@@ -197,7 +204,10 @@ func extractAuthForm(s sessions.Session, form *model.OAuthAuthorize) error {
 
 	// set default scope to read
 	if form.Scope == "" {
-		form.Scope = "read"
+		form.Scope = oauth.ScopeRead
+	}
+	if err := oauth.ValidateScopes(form.Scope); err != nil {
+		return err
 	}
 
 	// save these values from the form so we can use them elsewhere in the session
@@ -26,10 +26,12 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
@@ -111,7 +113,10 @@ func (m *Module) parseUserFromClaims(ctx context.Context, claims *oidc.Claims, i
 	user := &gtsmodel.User{}
 	err := m.db.GetWhere(ctx, []db.Where{{Key: "email", Value: claims.Email}}, user)
 	if err == nil {
-		// we do! so we can just return it
+		// we do! so we just need to bring their roles in line with their groups before returning it
+		if err := m.syncRolesFromClaims(ctx, claims, user); err != nil {
+			return nil, err
+		}
 		return user, nil
 	}
 
@@ -193,16 +198,14 @@ func (m *Module) parseUserFromClaims(ctx context.Context, claims *oidc.Claims, i
 			username = username + iString
 			continue
 		}
+		if m.config.OIDCConfig.UsernameConflict == config.OIDCUsernameConflictReject {
+			return nil, fmt.Errorf("username %s is already taken", username)
+		}
 		iString = strconv.Itoa(i)
 	}
 
-	// check if the user is in any recognised admin groups
-	var admin bool
-	for _, g := range claims.Groups {
-		if strings.EqualFold(g, "admin") || strings.EqualFold(g, "admins") {
-			admin = true
-		}
-	}
+	// check if the user is in any recognised admin or moderator groups
+	admin, moderator := m.rolesFromClaims(claims)
 
 	// we still need to set *a* password even if it's not a password the user will end up using, so set something random
 	// in this case, we'll just set two uuids on top of each other, which should be long + random enough to baffle any attempts to crack.
@@ -216,6 +219,55 @@ func (m *Module) parseUserFromClaims(ctx context.Context, claims *oidc.Claims, i
 		return nil, fmt.Errorf("error creating user: %s", err)
 	}
 
+	// signing up only knows about admins, so moderators have to be set afterwards
+	if moderator && !user.Moderator {
+		user.Moderator = true
+		user.UpdatedAt = time.Now()
+		if err := m.db.UpdateByPrimaryKey(ctx, user); err != nil {
+			return nil, fmt.Errorf("error setting moderator role for user: %s", err)
+		}
+	}
+
 	return user, nil
 
 }
+
+// rolesFromClaims returns whether the groups in the given claims make their user an admin and/or a moderator.
+// Admins are always moderators too.
+func (m *Module) rolesFromClaims(claims *oidc.Claims) (admin bool, moderator bool) {
+	for _, g := range claims.Groups {
+		for _, adminGroup := range m.config.OIDCConfig.AdminGroups {
+			if strings.EqualFold(g, adminGroup) {
+				admin = true
+			}
+		}
+		for _, moderatorGroup := range m.config.OIDCConfig.ModeratorGroups {
+			if strings.EqualFold(g, moderatorGroup) {
+				moderator = true
+			}
+		}
+	}
+	return admin, admin || moderator
+}
+
+// syncRolesFromClaims updates the admin and moderator roles of an existing user to match the groups in
+// the given claims, so that promotions and demotions made at the OIDC provider carry over to GoToSocial.
+// If the provider didn't return any groups claim at all, roles are left as they are.
+func (m *Module) syncRolesFromClaims(ctx context.Context, claims *oidc.Claims, user *gtsmodel.User) error {
+	if claims.Groups == nil {
+		return nil
+	}
+
+	admin, moderator := m.rolesFromClaims(claims)
+	if user.Admin == admin && user.Moderator == moderator {
+		return nil
+	}
+
+	user.Admin = admin
+	user.Moderator = moderator
+	user.UpdatedAt = time.Now()
+	if err := m.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return fmt.Errorf("error updating roles for user %s: %s", user.ID, err)
+	}
+	return nil
+}
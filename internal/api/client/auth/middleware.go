@@ -19,61 +19,105 @@
 package auth
 
 import (
+	"net"
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
+// tokenLastUsedInterval is how often the last use of a token is written to the database;
+// requests made with a token within this interval of its recorded last use don't update it.
+const tokenLastUsedInterval = time.Minute
+
 // OauthTokenMiddleware checks if the client has presented a valid oauth Bearer token.
 // If so, it will check the User that the token belongs to, and set that in the context of
 // the request. Then, it will look up the account for that user, and set that in the request too.
 // If user or account can't be found, then the handler won't *fail*, in case the server wants to allow
 // public requests that don't have a Bearer token set (eg., for public instance information and so on).
 func (m *Module) OauthTokenMiddleware(c *gin.Context) {
-	l := m.log.WithField("func", "OauthTokenMiddleware")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "OauthTokenMiddleware")
 	l.Trace("entering OauthTokenMiddleware")
 
 	ti, err := m.server.ValidationBearerToken(c.Copy().Request)
 	if err != nil {
-		l.Tracef("could not validate token: %s", err)
+		l.WithError(err).Trace("could not validate token")
+		return
+	}
+
+	// tokens can only be used for what the user agreed to when they authorized the app
+	if required := oauth.RequiredScope(c.Request.Method, c.FullPath()); !oauth.ScopeGranted(ti.GetScope(), required) {
+		l.WithFields(logrus.Fields{
+			"requiredScope": required,
+			"scope":         ti.GetScope(),
+		}).Debug("token scope doesn't cover request")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This action is outside the authorized scopes"})
 		return
 	}
+
+	// keep track of when and where the token was last used, so users can see their active sessions
+	now := time.Now()
+	if err := m.db.UpdateTokenLastUsed(c.Request.Context(), ti.GetAccess(), net.ParseIP(c.ClientIP()), now, now.Add(-tokenLastUsedInterval)); err != nil {
+		l.WithError(err).Warn("couldn't record last use of token")
+	}
+
 	l.Trace("continuing with unauthenticated request")
 	c.Set(oauth.SessionAuthorizedToken, ti)
-	l.Tracef("set gin context %s to %+v", oauth.SessionAuthorizedToken, ti)
+	l.WithFields(logrus.Fields{
+		"key":   oauth.SessionAuthorizedToken,
+		"token": ti,
+	}).Trace("set gin context")
 
 	// check for user-level token
 	if uid := ti.GetUserID(); uid != "" {
-		l.Tracef("authenticated user %s with bearer token, scope is %s", uid, ti.GetScope())
+		l.WithFields(logrus.Fields{
+			"userID": uid,
+			"scope":  ti.GetScope(),
+		}).Trace("authenticated user with bearer token")
 
 		// fetch user's and account for this user id
 		user := &gtsmodel.User{}
 		if err := m.db.GetByID(c.Request.Context(), uid, user); err != nil || user == nil {
-			l.Warnf("no user found for validated uid %s", uid)
+			l.WithField("userID", uid).Warn("no user found for validated uid")
 			return
 		}
 		c.Set(oauth.SessionAuthorizedUser, user)
-		l.Tracef("set gin context %s to %+v", oauth.SessionAuthorizedUser, user)
+		l.WithFields(logrus.Fields{
+			"key":  oauth.SessionAuthorizedUser,
+			"user": user,
+		}).Trace("set gin context")
 
 		acct, err := m.db.GetAccountByID(c.Request.Context(), user.AccountID)
 		if err != nil || acct == nil {
-			l.Warnf("no account found for validated user %s", uid)
+			l.WithField("userID", uid).Warn("no account found for validated user")
 			return
 		}
 		c.Set(oauth.SessionAuthorizedAccount, acct)
-		l.Tracef("set gin context %s to %+v", oauth.SessionAuthorizedAccount, acct)
+		l.WithFields(logrus.Fields{
+			"key":     oauth.SessionAuthorizedAccount,
+			"account": acct,
+		}).Trace("set gin context")
 	}
 
 	// check for application token
 	if cid := ti.GetClientID(); cid != "" {
-		l.Tracef("authenticated client %s with bearer token, scope is %s", cid, ti.GetScope())
+		l.WithFields(logrus.Fields{
+			"clientID": cid,
+			"scope":    ti.GetScope(),
+		}).Trace("authenticated client with bearer token")
 		app := &gtsmodel.Application{}
 		if err := m.db.GetWhere(c.Request.Context(), []db.Where{{Key: "client_id", Value: cid}}, app); err != nil {
-			l.Tracef("no app found for client %s", cid)
+			l.WithField("clientID", cid).Trace("no app found for client")
 		}
 		c.Set(oauth.SessionAuthorizedApplication, app)
-		l.Tracef("set gin context %s to %+v", oauth.SessionAuthorizedApplication, app)
+		l.WithFields(logrus.Fields{
+			"key":         oauth.SessionAuthorizedApplication,
+			"application": app,
+		}).Trace("set gin context")
 	}
 	c.Next()
 }
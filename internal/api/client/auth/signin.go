@@ -40,7 +40,7 @@ type login struct {
 // The idea is to present a sign in page to the user, where they can enter their username and password.
 // The form will then POST to the sign in page, which will be handled by SignInPOSTHandler
 func (m *Module) SignInGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "SignInGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "SignInGETHandler")
 	l.Trace("entering sign in handler")
 	if m.idp != nil {
 		s := sessions.Default(c)
@@ -54,7 +54,7 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 		}
 
 		redirect := m.idp.AuthCodeURL(state)
-		l.Debugf("redirecting to external idp at %s", redirect)
+		l.WithField("redirect", redirect).Debug("redirecting to external idp")
 		c.Redirect(http.StatusSeeOther, redirect)
 		return
 	}
@@ -65,7 +65,7 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 // The idea is to present a sign in page to the user, where they can enter their username and password.
 // The handler will then redirect to the auth handler served at /auth
 func (m *Module) SignInPOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "SignInPOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "SignInPOSTHandler")
 	s := sessions.Default(c)
 	form := &login{}
 	if err := c.ShouldBind(form); err != nil {
@@ -73,7 +73,7 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		m.clearSession(s)
 		return
 	}
-	l.Tracef("parsed form: %+v", form)
+	l.WithField("form", form).Trace("parsed form")
 
 	userid, err := m.ValidatePassword(c.Request.Context(), form.Email, form.Password)
 	if err != nil {
@@ -98,7 +98,7 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 // address stored in the database. If OK, we return the userid (a ulid) for that user,
 // so that it can be used in further Oauth flows to generate a token/retreieve an oauth client from the db.
 func (m *Module) ValidatePassword(ctx context.Context, email string, password string) (userid string, err error) {
-	l := m.log.WithField("func", "ValidatePassword")
+	l := m.log.WithContext(ctx).WithField("func", "ValidatePassword")
 
 	// make sure an email/password was provided and bail if not
 	if email == "" || password == "" {
@@ -110,25 +110,25 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 	gtsUser := &gtsmodel.User{}
 
 	if err := m.db.GetWhere(ctx, []db.Where{{Key: "email", Value: email}}, gtsUser); err != nil {
-		l.Debugf("user %s was not retrievable from db during oauth authorization attempt: %s", email, err)
+		l.WithError(err).WithField("email", email).Debug("user was not retrievable from db during oauth authorization attempt")
 		return incorrectPassword()
 	}
 
 	// make sure a password is actually set and bail if not
 	if gtsUser.EncryptedPassword == "" {
-		l.Warnf("encrypted password for user %s was empty for some reason", gtsUser.Email)
+		l.WithField("email", gtsUser.Email).Warn("encrypted password for user was empty for some reason")
 		return incorrectPassword()
 	}
 
 	// compare the provided password with the encrypted one from the db, bail if they don't match
 	if err := bcrypt.CompareHashAndPassword([]byte(gtsUser.EncryptedPassword), []byte(password)); err != nil {
-		l.Debugf("password hash didn't match for user %s during login attempt: %s", gtsUser.Email, err)
+		l.WithError(err).WithField("email", gtsUser.Email).Debug("password hash didn't match for user during login attempt")
 		return incorrectPassword()
 	}
 
 	// If we've made it this far the email/password is correct, so we can just return the id of the user.
 	userid = gtsUser.ID
-	l.Tracef("returning (%s, %s)", userid, err)
+	l.WithError(err).WithField("userID", userid).Trace("returning")
 	return
 }
 
@@ -38,7 +38,7 @@ type tokenBody struct {
 // The idea here is to serve an oauth access token to a user, which can be used for authorizing against non-public APIs.
 // See https://docs.joinmastodon.org/methods/apps/oauth/#obtain-a-token
 func (m *Module) TokenPOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "TokenPOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "TokenPOSTHandler")
 	l.Trace("entered TokenPOSTHandler")
 
 	form := &tokenBody{}
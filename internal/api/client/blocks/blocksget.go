@@ -84,11 +84,11 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) BlocksGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "PublicTimelineGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "PublicTimelineGETHandler")
 
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("error authing: %s", err)
+		l.WithError(err).Debug("error authing")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -110,7 +110,7 @@ func (m *Module) BlocksGETHandler(c *gin.Context) {
 	if limitString != "" {
 		i, err := strconv.ParseInt(limitString, 10, 64)
 		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
+			l.WithError(err).Debug("error parsing limit string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
 			return
 		}
@@ -119,7 +119,8 @@ func (m *Module) BlocksGETHandler(c *gin.Context) {
 
 	resp, errWithCode := m.processor.BlocksGet(c.Request.Context(), authed, maxID, sinceID, limit)
 	if errWithCode != nil {
-		l.Debugf("error from processor BlocksGet: %s", errWithCode)
+		l.WithError(errWithCode).Debug("error from processor BlocksGet")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
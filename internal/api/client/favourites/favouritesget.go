@@ -10,11 +10,11 @@ import (
 
 // FavouritesGETHandler handles GETting favourites.
 func (m *Module) FavouritesGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "PublicTimelineGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "PublicTimelineGETHandler")
 
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("error authing: %s", err)
+		l.WithError(err).Debug("error authing")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -36,7 +36,7 @@ func (m *Module) FavouritesGETHandler(c *gin.Context) {
 	if limitString != "" {
 		i, err := strconv.ParseInt(limitString, 10, 64)
 		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
+			l.WithError(err).Debug("error parsing limit string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
 			return
 		}
@@ -45,7 +45,8 @@ func (m *Module) FavouritesGETHandler(c *gin.Context) {
 
 	resp, errWithCode := m.processor.FavedTimelineGet(c.Request.Context(), authed, maxID, minID, limit)
 	if errWithCode != nil {
-		l.Debugf("error from processor FavedTimelineGet: %s", errWithCode)
+		l.WithError(errWithCode).Debug("error from processor FavedTimelineGet")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
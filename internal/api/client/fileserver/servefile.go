@@ -33,7 +33,7 @@ import (
 // Note: to mitigate scraping attempts, no information should be given out on a bad request except "404 page not found".
 // Don't give away account ids or media ids or anything like that; callers shouldn't be able to infer anything.
 func (m *FileServer) ServeFile(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "ServeFile",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -85,7 +85,7 @@ func (m *FileServer) ServeFile(c *gin.Context) {
 		FileName:  fileName,
 	})
 	if err != nil {
-		l.Debug(err)
+		l.WithError(err).Debug("error getting file from processor")
 		c.String(http.StatusNotFound, "404 page not found")
 		return
 	}
@@ -94,10 +94,19 @@ func (m *FileServer) ServeFile(c *gin.Context) {
 	// This is mostly needed because when sharing a link to a gts-hosted file on something like mastodon, the masto servers will
 	// attempt to look up the content to provide a preview of the link, and they ask for text/html.
 	if c.NegotiateFormat(content.ContentType) == "" {
-		l.Debugf("couldn't negotiate content for Accept headers %+v: we have content type %s", c.Request.Header.Get("Accepted"), content.ContentType)
+		l.WithFields(logrus.Fields{
+			"accept":      c.Request.Header.Get("Accepted"),
+			"contentType": content.ContentType,
+		}).Debug("couldn't negotiate content for Accept headers")
 		c.AbortWithStatus(http.StatusNotAcceptable)
 		return
 	}
 
+	// the file can be fetched straight from storage, so send the requester there
+	if content.URL != "" {
+		c.Redirect(http.StatusFound, content.URL)
+		return
+	}
+
 	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, bytes.NewReader(content.Content), nil)
 }
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"git.iim.gay/grufwub/go-store/kv"
@@ -158,6 +159,53 @@ func (suite *ServeFileTestSuite) TestServeOriginalFileSuccessful() {
 	assert.Equal(suite.T(), b, fileInStorage)
 }
 
+func (suite *ServeFileTestSuite) TestServeOriginalFilePresigned() {
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// serve media from an s3 bucket through presigned urls
+	c := testrig.NewTestConfig()
+	c.StorageConfig.Backend = "s3"
+	c.StorageConfig.S3Endpoint = "http://localhost:9000"
+	c.StorageConfig.S3Bucket = "gotosocial"
+	c.StorageConfig.S3AccessKey = "key"
+	c.StorageConfig.S3SecretKey = "secret"
+	c.StorageConfig.S3Presign = true
+	processor := processing.NewProcessor(c, suite.tc, suite.federator, suite.oauthServer, suite.mediaHandler, suite.storage, testrig.NewTestTimelineManager(suite.db), suite.db, testrig.NewTestFieldVerifier(), testrig.NewTestWebPushSender(suite.db), suite.log)
+	fileServer := fileserver.New(c, processor, suite.log).(*fileserver.FileServer)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, targetAttachment.URL, nil)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   fileserver.AccountIDKey,
+			Value: targetAttachment.AccountID,
+		},
+		gin.Param{
+			Key:   fileserver.MediaTypeKey,
+			Value: string(media.Attachment),
+		},
+		gin.Param{
+			Key:   fileserver.MediaSizeKey,
+			Value: string(media.Original),
+		},
+		gin.Param{
+			Key:   fileserver.FileNameKey,
+			Value: fmt.Sprintf("%s.jpeg", targetAttachment.ID),
+		},
+	}
+
+	// the requester should be sent to the bucket rather than getting the file from us
+	fileServer.ServeFile(ctx)
+	suite.EqualValues(http.StatusFound, recorder.Code)
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	suite.NoError(err)
+	suite.Equal("localhost:9000", location.Host)
+	suite.Equal("/gotosocial/"+targetAttachment.File.Path, location.Path)
+	suite.NotEmpty(location.Query().Get("X-Amz-Signature"))
+}
+
 func TestServeFileTestSuite(t *testing.T) {
 	suite.Run(t, new(ServeFileTestSuite))
 }
@@ -21,16 +21,29 @@ package filter
 import (
 	"net/http"
 
+	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
 const (
+	// IDKey is the key to use for retrieving filter ID in requests
+	IDKey = "id"
 	// BasePath is the base path for serving the filter API
 	BasePath = "/api/v1/filters"
+	// BasePathWithID is the base path with the ID key in it, for operations on a single filter.
+	BasePathWithID = BasePath + "/:" + IDKey
+	// BasePathV2 is the base path for serving version 2 of the filter API
+	BasePathV2 = "/api/v2/filters"
+	// BasePathV2WithID is the version 2 base path with the ID key in it, for operations on a single filter.
+	BasePathV2WithID = BasePathV2 + "/:" + IDKey
+
+	// ContextKey is for specifying the contexts that a filter applies in.
+	ContextKey = "context"
 )
 
 // Module implements the ClientAPIModule interface for every related to filters
@@ -52,5 +65,44 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, BasePath, m.FiltersGETHandler)
+	r.AttachHandler(http.MethodPost, BasePath, m.FilterCreatePOSTHandler)
+	r.AttachHandler(http.MethodGet, BasePathWithID, m.FilterGETHandler)
+	r.AttachHandler(http.MethodPut, BasePathWithID, m.FilterUpdatePUTHandler)
+	r.AttachHandler(http.MethodDelete, BasePathWithID, m.FilterDELETEHandler)
+	r.AttachHandler(http.MethodGet, BasePathV2, m.FiltersV2GETHandler)
+	r.AttachHandler(http.MethodPost, BasePathV2, m.FilterV2CreatePOSTHandler)
+	r.AttachHandler(http.MethodGet, BasePathV2WithID, m.FilterV2GETHandler)
+	r.AttachHandler(http.MethodPut, BasePathV2WithID, m.FilterV2UpdatePUTHandler)
+	r.AttachHandler(http.MethodDelete, BasePathV2WithID, m.FilterV2DELETEHandler)
 	return nil
 }
+
+// parseFilterFormV1 parses a version 1 filter form. Clients often send the contexts as context[],
+// which gin doesn't bind to the context field by itself.
+func parseFilterFormV1(c *gin.Context) (*model.FilterCreateUpdateRequestV1, error) {
+	form := &model.FilterCreateUpdateRequestV1{}
+	if err := c.ShouldBind(form); err != nil {
+		return nil, err
+	}
+
+	if len(form.Context) == 0 {
+		form.Context = c.PostFormArray(ContextKey + "[]")
+	}
+
+	return form, nil
+}
+
+// parseFilterFormV2 parses a version 2 filter form. Clients often send the contexts as context[],
+// which gin doesn't bind to the context field by itself.
+func parseFilterFormV2(c *gin.Context) (*model.FilterCreateUpdateRequestV2, error) {
+	form := &model.FilterCreateUpdateRequestV2{}
+	if err := c.ShouldBind(form); err != nil {
+		return nil, err
+	}
+
+	if len(form.Context) == 0 {
+		form.Context = c.PostFormArray(ContextKey + "[]")
+	}
+
+	return form, nil
+}
@@ -0,0 +1,128 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package filter
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FilterUpdatePUTHandler swagger:operation PUT /api/v1/filters/{id} filterUpdate
+//
+// Replace a version 1 filter, by the id of its keyword.
+//
+// ---
+// tags:
+// - filters
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the filter keyword.
+//   in: path
+//   required: true
+// - name: phrase
+//   in: formData
+//   description: The text to be filtered, max 200 characters.
+//   type: string
+//   required: true
+// - name: context
+//   in: formData
+//   description: |-
+//     The contexts in which the filter should be applied.
+//     Any of home, notifications, public, thread, or account.
+//   type: array
+//   items:
+//     type: string
+//   collectionFormat: multi
+//   required: true
+// - name: irreversible
+//   in: formData
+//   description: Should matching statuses be hidden by the server, rather than shown with a warning?
+//   type: boolean
+//   default: false
+// - name: whole_word
+//   in: formData
+//   description: Should the filter consider word boundaries?
+//   type: boolean
+//   default: false
+// - name: expires_in
+//   in: formData
+//   description: Number of seconds from now that the filter should expire. If not provided, the filter never expires.
+//   type: integer
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:filters
+//
+// responses:
+//   '200':
+//     description: "The updated filter."
+//     schema:
+//       "$ref": "#/definitions/filter"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) FilterUpdatePUTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "FilterUpdatePUTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	filterID := c.Param(IDKey)
+	if filterID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no filter id provided"})
+		return
+	}
+
+	form, err := parseFilterFormV1(c)
+	if err != nil {
+		l.WithError(err).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	filter, errWithCode := m.processor.FilterUpdateV1(c.Request.Context(), authed, filterID, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error updating filter")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
@@ -0,0 +1,121 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package filter
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FilterV2CreatePOSTHandler swagger:operation POST /api/v2/filters filterV2Create
+//
+// Create a new filter, with any number of keywords.
+//
+// ---
+// tags:
+// - filters
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: title
+//   in: formData
+//   description: The name of the filter, max 200 characters.
+//   type: string
+//   required: true
+// - name: context
+//   in: formData
+//   description: |-
+//     The contexts in which the filter should be applied.
+//     Any of home, notifications, public, thread, or account.
+//   type: array
+//   items:
+//     type: string
+//   collectionFormat: multi
+//   required: true
+// - name: filter_action
+//   in: formData
+//   description: |-
+//     What to do with statuses that match the filter.
+//     One of warn (show the status with a warning) or hide (don't show the status at all).
+//   type: string
+//   default: warn
+// - name: expires_in
+//   in: formData
+//   description: Number of seconds from now that the filter should expire. If not provided, the filter never expires.
+//   type: integer
+// - name: keywords_attributes
+//   in: body
+//   description: |-
+//     Keywords to add to the filter.
+//     These can only be given when the body of the request is JSON.
+//   schema:
+//     type: array
+//     items:
+//       "$ref": "#/definitions/filterKeywordCreateUpdateRequest"
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:filters
+//
+// responses:
+//   '200':
+//     description: "The newly created filter."
+//     schema:
+//       "$ref": "#/definitions/filterV2"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) FilterV2CreatePOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "FilterV2CreatePOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	form, err := parseFilterFormV2(c)
+	if err != nil {
+		l.WithError(err).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	filter, errWithCode := m.processor.FilterCreateV2(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error creating filter")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
@@ -31,6 +31,14 @@ import (
 const (
 	// IDKey is for status UUIDs
 	IDKey = "id"
+	// MaxIDKey is the url query for setting a max ID to return
+	MaxIDKey = "max_id"
+	// SinceIDKey is the url query for returning results newer than the given ID
+	SinceIDKey = "since_id"
+	// MinIDKey is the url query for returning results immediately newer than the given ID
+	MinIDKey = "min_id"
+	// LimitKey is for specifying maximum number of results to return.
+	LimitKey = "limit"
 	// BasePath is the base path for serving the follow request API
 	BasePath = "/api/v1/follow_requests"
 	// BasePathWithID is just the base path with the ID key in it.
@@ -0,0 +1,140 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/health"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+	config    *config.Config
+	db        db.DB
+	log       *logrus.Logger
+	storage   *kv.KVStore
+	processor processing.Processor
+
+	healthModule *health.Module
+}
+
+func (suite *HealthTestSuite) SetupSuite() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.storage = testrig.NewTestStorage()
+	federator := testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, federator)
+	suite.healthModule = health.New(suite.config, suite.processor, suite.log).(*health.Module)
+}
+
+func (suite *HealthTestSuite) TearDownSuite() {
+	if err := suite.db.Stop(context.Background()); err != nil {
+		logrus.Panicf("error closing db connection: %s", err)
+	}
+}
+
+func (suite *HealthTestSuite) SetupTest() {
+	suite.config.HealthConfig.Token = ""
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *HealthTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// readyz calls the readyz handler with the given request, returning the status code and parsed response body.
+func (suite *HealthTestSuite) readyz(request *http.Request) (int, *apimodel.Readiness) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = request
+	suite.healthModule.ReadyzGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	readiness := &apimodel.Readiness{}
+	if result.StatusCode != http.StatusUnauthorized {
+		suite.NoError(json.Unmarshal(b, readiness))
+	}
+	return result.StatusCode, readiness
+}
+
+func (suite *HealthTestSuite) TestLivez() {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/livez", nil)
+	suite.healthModule.LivezGETHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("no-store", recorder.Header().Get("Cache-Control"))
+	suite.Equal(`{"status":"ok"}`, recorder.Body.String())
+}
+
+func (suite *HealthTestSuite) TestReadyz() {
+	code, readiness := suite.readyz(httptest.NewRequest(http.MethodGet, "http://localhost:8080/readyz", nil))
+	suite.Equal(http.StatusOK, code)
+	suite.True(readiness.Ready)
+	suite.Len(readiness.Checks, 3)
+}
+
+func (suite *HealthTestSuite) TestReadyzWithToken() {
+	suite.config.HealthConfig.Token = "some-secret-token"
+
+	// no token
+	code, _ := suite.readyz(httptest.NewRequest(http.MethodGet, "http://localhost:8080/readyz", nil))
+	suite.Equal(http.StatusUnauthorized, code)
+
+	// wrong token
+	code, _ = suite.readyz(httptest.NewRequest(http.MethodGet, "http://localhost:8080/readyz?token=nope", nil))
+	suite.Equal(http.StatusUnauthorized, code)
+
+	// token in query
+	code, readiness := suite.readyz(httptest.NewRequest(http.MethodGet, "http://localhost:8080/readyz?token=some-secret-token", nil))
+	suite.Equal(http.StatusOK, code)
+	suite.True(readiness.Ready)
+
+	// token in header
+	request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/readyz", nil)
+	request.Header.Set("Authorization", "Bearer some-secret-token")
+	code, readiness = suite.readyz(request)
+	suite.Equal(http.StatusOK, code)
+	suite.True(readiness.Ready)
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
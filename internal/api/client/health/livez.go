@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LivezGETHandler swagger:operation GET /livez livezGet
+//
+// Check whether GoToSocial is running.
+//
+// This does no work beyond answering the request, so it's cheap enough to call as often as you like.
+// Use /readyz to check whether GoToSocial is actually able to serve requests properly. Does not require authorization.
+//
+// ---
+// tags:
+// - health
+//
+// produces:
+// - application/json
+//
+// responses:
+//   '200':
+//     description: GoToSocial is running.
+func (m *Module) LivezGETHandler(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
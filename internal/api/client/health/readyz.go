@@ -0,0 +1,92 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package health
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadyzGETHandler swagger:operation GET /readyz readyzGet
+//
+// Check whether GoToSocial is ready to serve requests.
+//
+// The database, the storage backend, and the internal work queues are all checked.
+// If the health token is set in the instance config, it must be given either as a bearer token or as the token query parameter.
+//
+// ---
+// tags:
+// - health
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: token
+//   type: string
+//   description: The health token, if one is set and it's not given in the Authorization header instead.
+//   in: query
+//
+// responses:
+//   '200':
+//     description: All checks passed.
+//     schema:
+//       "$ref": "#/definitions/readiness"
+//   '401':
+//      description: unauthorized
+//   '503':
+//     description: One or more checks failed.
+//     schema:
+//       "$ref": "#/definitions/readiness"
+func (m *Module) ReadyzGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "ReadyzGETHandler")
+	c.Header("Cache-Control", "no-store")
+
+	if !m.tokenOK(c) {
+		l.Debug("readiness requested with missing or incorrect token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	readiness := m.processor.HealthReadyGet(c.Request.Context())
+	if !readiness.Ready {
+		c.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	c.JSON(http.StatusOK, readiness)
+}
+
+// tokenOK checks the token given with the request against the configured health token, if there is one.
+func (m *Module) tokenOK(c *gin.Context) bool {
+	want := m.config.HealthConfig.Token
+	if want == "" {
+		return true
+	}
+
+	got := c.Query("token")
+	if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		got = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
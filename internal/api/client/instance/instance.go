@@ -13,6 +13,10 @@ import (
 const (
 	// InstanceInformationPath is for serving instance info requests
 	InstanceInformationPath = "api/v1/instance"
+	// InstanceInformationPathV2 is for serving v2 instance info requests
+	InstanceInformationPathV2 = "api/v2/instance"
+	// InstanceRulesPath is for serving the rules of this instance
+	InstanceRulesPath = InstanceInformationPath + "/rules"
 )
 
 // Module implements the ClientModule interface
@@ -35,5 +39,7 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, InstanceInformationPath, m.InstanceInformationGETHandler)
 	s.AttachHandler(http.MethodPatch, InstanceInformationPath, m.InstanceUpdatePATCHHandler)
+	s.AttachHandler(http.MethodGet, InstanceInformationPathV2, m.InstanceInformationGETHandlerV2)
+	s.AttachHandler(http.MethodGet, InstanceRulesPath, m.InstanceRulesGETHandler)
 	return nil
 }
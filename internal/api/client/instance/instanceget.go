@@ -29,11 +29,11 @@ import (
 //   '500':
 //      description: internal error
 func (m *Module) InstanceInformationGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "InstanceInformationGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "InstanceInformationGETHandler")
 
 	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
 	if err != nil {
-		l.Debugf("error getting instance from processor: %s", err)
+		l.WithError(err).Debug("error getting instance from processor")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
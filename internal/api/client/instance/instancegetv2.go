@@ -0,0 +1,42 @@
+package instance
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InstanceInformationGETHandlerV2 swagger:operation GET /api/v2/instance instanceGetV2
+//
+// View information about this instance.
+//
+// This has the same information as `/api/v1/instance`, arranged the way newer Mastodon applications expect,
+// and includes the limits that client applications should respect when posting statuses and uploading media.
+//
+// ---
+// tags:
+// - instance
+//
+// produces:
+// - application/json
+//
+// responses:
+//   '200':
+//     description: "Instance information."
+//     schema:
+//       "$ref": "#/definitions/instanceV2"
+//   '500':
+//      description: internal error
+func (m *Module) InstanceInformationGETHandlerV2(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "InstanceInformationGETHandlerV2")
+
+	instance, errWithCode := m.processor.InstanceGetV2(c.Request.Context())
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting instance from processor")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, instance)
+}
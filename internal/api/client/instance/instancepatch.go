@@ -61,6 +61,12 @@ import (
 //   type: string
 //   maximum: 5000
 //   allowEmptyValue: true
+// - name: custom_css
+//   in: formData
+//   description: Custom CSS to use on all web pages of the instance.
+//   type: string
+//   maximum: 50000
+//   allowEmptyValue: true
 // - name: avatar
 //   in: formData
 //   description: Avatar of the instance.
@@ -69,6 +75,10 @@ import (
 //   in: formData
 //   description: Header of the instance.
 //   type: file
+// - name: thumbnail
+//   in: formData
+//   description: Thumbnail of the instance, shown in /api/v1/instance and /api/v2/instance. This is the same image as the header, so it replaces the header if set.
+//   type: file
 //
 // security:
 // - OAuth2 Bearer:
@@ -84,10 +94,10 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) InstanceUpdatePATCHHandler(c *gin.Context) {
-	l := m.log.WithField("func", "InstanceUpdatePATCHHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "InstanceUpdatePATCHHandler")
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
@@ -102,23 +112,24 @@ func (m *Module) InstanceUpdatePATCHHandler(c *gin.Context) {
 	l.Debug("parsing request form")
 	form := &model.InstanceSettingsUpdateRequest{}
 	if err := c.ShouldBind(&form); err != nil || form == nil {
-		l.Debugf("could not parse form from request: %s", err)
+		l.WithError(err).Debug("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	l.Debugf("parsed form: %+v", form)
+	l.WithField("form", form).Debug("parsed form")
 
 	// if everything on the form is nil, then nothing has been set and we shouldn't continue
-	if form.Title == nil && form.ContactUsername == nil && form.ContactEmail == nil && form.ShortDescription == nil && form.Description == nil && form.Terms == nil && form.Avatar == nil && form.Header == nil {
-		l.Debugf("could not parse form from request")
+	if form.Title == nil && form.ContactUsername == nil && form.ContactEmail == nil && form.ShortDescription == nil && form.Description == nil && form.Terms == nil && form.CustomCSS == nil && form.Avatar == nil && form.Header == nil && form.Thumbnail == nil {
+		l.Debug("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "empty form submitted"})
 		return
 	}
 
-	i, errWithCode := m.processor.InstancePatch(c.Request.Context(), form)
+	i, errWithCode := m.processor.InstancePatch(c.Request.Context(), authed, form)
 	if errWithCode != nil {
-		l.Debugf("error with instance patch request: %s", errWithCode.Error())
+		l.WithError(errWithCode).Debug("error with instance patch request")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -0,0 +1,41 @@
+package instance
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InstanceRulesGETHandler swagger:operation GET /api/v1/instance/rules instanceRulesGet
+//
+// View the rules of this instance, in the order they should be shown.
+//
+// ---
+// tags:
+// - instance
+//
+// produces:
+// - application/json
+//
+// responses:
+//   '200':
+//     description: "Rules of this instance."
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/instanceRule"
+//   '500':
+//      description: internal error
+func (m *Module) InstanceRulesGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "InstanceRulesGETHandler")
+
+	rules, errWithCode := m.processor.InstanceRulesGet(c.Request.Context())
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting instance rules from processor")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
@@ -21,16 +21,33 @@ package list
 import (
 	"net/http"
 
+	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
 const (
+	// IDKey is the key to use for retrieving list ID in requests
+	IDKey = "id"
 	// BasePath is the base path for serving the lists API
 	BasePath = "/api/v1/lists"
+	// BasePathWithID is the base path with the ID key in it, for operations on a single list.
+	BasePathWithID = BasePath + "/:" + IDKey
+	// AccountsPath is for viewing and changing the accounts in a list.
+	AccountsPath = BasePathWithID + "/accounts"
+
+	// AccountIDsKey is for specifying the ids of accounts to add to or remove from a list.
+	AccountIDsKey = "account_ids"
+	// MaxIDKey is for specifying the maximum ID of the list entries to retrieve.
+	MaxIDKey = "max_id"
+	// SinceIDKey is for specifying the minimum ID of the list entries to retrieve.
+	SinceIDKey = "since_id"
+	// LimitKey is for specifying the maximum number of accounts to return.
+	LimitKey = "limit"
 )
 
 // Module implements the ClientAPIModule interface for everything related to lists
@@ -52,5 +69,35 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, BasePath, m.ListsGETHandler)
+	r.AttachHandler(http.MethodPost, BasePath, m.ListCreatePOSTHandler)
+	r.AttachHandler(http.MethodGet, BasePathWithID, m.ListGETHandler)
+	r.AttachHandler(http.MethodPut, BasePathWithID, m.ListUpdatePUTHandler)
+	r.AttachHandler(http.MethodDelete, BasePathWithID, m.ListDELETEHandler)
+	r.AttachHandler(http.MethodGet, AccountsPath, m.ListAccountsGETHandler)
+	r.AttachHandler(http.MethodPost, AccountsPath, m.ListAccountsPOSTHandler)
+	r.AttachHandler(http.MethodDelete, AccountsPath, m.ListAccountsDELETEHandler)
 	return nil
 }
+
+// parseAccountsChangeForm gets the ids of the accounts to add to or remove from a list. Clients send these
+// either in the body as account_ids or account_ids[], or in the query, which is common for DELETE requests.
+func parseAccountsChangeForm(c *gin.Context) (*model.ListAccountsChangeRequest, error) {
+	form := &model.ListAccountsChangeRequest{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBind(form); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(form.AccountIDs) == 0 {
+		form.AccountIDs = c.PostFormArray(AccountIDsKey + "[]")
+	}
+	if len(form.AccountIDs) == 0 {
+		form.AccountIDs = c.QueryArray(AccountIDsKey + "[]")
+	}
+	if len(form.AccountIDs) == 0 {
+		form.AccountIDs = c.QueryArray(AccountIDsKey)
+	}
+
+	return form, nil
+}
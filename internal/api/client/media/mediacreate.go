@@ -82,27 +82,27 @@ import (
 //   '422':
 //      description: unprocessable
 func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "statusCreatePOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "statusCreatePOSTHandler")
 	authed, err := oauth.Authed(c, true, true, true, true) // posting new media is serious business so we want *everything*
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
 	// extract the media create form from the request context
-	l.Tracef("parsing request form: %s", c.Request.Form)
+	l.WithField("form", c.Request.Form).Trace("parsing request form")
 	form := &model.AttachmentRequest{}
 	if err := c.ShouldBind(&form); err != nil {
-		l.Debugf("error parsing form: %s", err)
+		l.WithError(err).Debug("error parsing form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Errorf("could not parse form: %s", err)})
 		return
 	}
 
 	// Give the fields on the request form a first pass to make sure the request is superficially valid.
-	l.Tracef("validating form %+v", form)
+	l.WithField("form", form).Trace("validating form")
 	if err := validateCreateMedia(form, m.config.MediaConfig); err != nil {
-		l.Debugf("error validating form: %s", err)
+		l.WithError(err).Debug("error validating form")
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		return
 	}
@@ -110,7 +110,7 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 	l.Debug("calling processor media create func")
 	mastoAttachment, err := m.processor.MediaCreate(c.Request.Context(), authed, form)
 	if err != nil {
-		l.Debugf("error creating attachment: %s", err)
+		l.WithError(err).Debug("error creating attachment")
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		return
 	}
@@ -33,7 +33,8 @@ import (
 //
 // Update a media attachment.
 //
-// You must own the media attachment, and the attachment must not yet be attached to a status.
+// You must own the media attachment. If the attachment is already part of a status,
+// the new description and focus are federated to remote instances along with the status.
 //
 // The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
 // The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
@@ -91,10 +92,10 @@ import (
 //   '422':
 //      description: unprocessable
 func (m *Module) MediaPUTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "MediaGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "MediaPUTHandler")
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
@@ -106,24 +107,25 @@ func (m *Module) MediaPUTHandler(c *gin.Context) {
 	}
 
 	// extract the media update form from the request context
-	l.Tracef("parsing request form: %s", c.Request.Form)
+	l.WithField("form", c.Request.Form).Trace("parsing request form")
 	var form model.AttachmentUpdateRequest
 	if err := c.ShouldBind(&form); err != nil {
-		l.Debugf("could not parse form from request: %s", err)
+		l.WithError(err).Debug("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
 		return
 	}
 
 	// Give the fields on the request form a first pass to make sure the request is superficially valid.
-	l.Tracef("validating form %+v", form)
+	l.WithField("form", form).Trace("validating form")
 	if err := validateUpdateMedia(&form, m.config.MediaConfig); err != nil {
-		l.Debugf("error validating form: %s", err)
+		l.WithError(err).Debug("error validating form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	attachment, errWithCode := m.processor.MediaUpdate(c.Request.Context(), authed, attachmentID, &form)
 	if errWithCode != nil {
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -0,0 +1,175 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package media_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	mediamodule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MediaUpdateTestSuite struct {
+	// standard suite interfaces
+	suite.Suite
+	config    *config.Config
+	db        db.DB
+	log       *logrus.Logger
+	storage   *kv.KVStore
+	federator federation.Federator
+	processor processing.Processor
+
+	// standard suite models
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+	testUsers        map[string]*gtsmodel.User
+	testAccounts     map[string]*gtsmodel.Account
+	testAttachments  map[string]*gtsmodel.MediaAttachment
+
+	// item being tested
+	mediaModule *mediamodule.Module
+}
+
+func (suite *MediaUpdateTestSuite) SetupSuite() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.storage = testrig.NewTestStorage()
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), suite.storage)
+	suite.processor = testrig.NewTestProcessor(suite.db, suite.storage, suite.federator)
+	suite.mediaModule = mediamodule.New(suite.config, suite.processor, suite.log).(*mediamodule.Module)
+}
+
+func (suite *MediaUpdateTestSuite) TearDownSuite() {
+	if err := suite.db.Stop(context.Background()); err != nil {
+		logrus.Panicf("error closing db connection: %s", err)
+	}
+}
+
+func (suite *MediaUpdateTestSuite) SetupTest() {
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+}
+
+func (suite *MediaUpdateTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+func (suite *MediaUpdateTestSuite) updateMedia(accountName string, attachmentID string, form url.Values) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountName]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountName])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountName])
+	ctx.Request = httptest.NewRequest(http.MethodPut, fmt.Sprintf("http://localhost:8080%s/%s", mediamodule.BasePath, attachmentID), strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   mediamodule.IDKey,
+			Value: attachmentID,
+		},
+	}
+
+	suite.mediaModule.MediaPUTHandler(ctx)
+	return recorder
+}
+
+func (suite *MediaUpdateTestSuite) TestUpdateDescriptionAndFocus() {
+	toUpdate := suite.testAttachments["local_account_1_unattached_1"]
+
+	recorder := suite.updateMedia("local_account_1", toUpdate.ID, url.Values{
+		"description": {"a new description"},
+		"focus":       {"-0.5,0.25"},
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(recorder.Result().Body)
+	suite.NoError(err)
+
+	attachment := &model.Attachment{}
+	err = json.Unmarshal(b, attachment)
+	suite.NoError(err)
+	suite.Equal("a new description", attachment.Description)
+	suite.Equal(float32(-0.5), attachment.Meta.Focus.X)
+	suite.Equal(float32(0.25), attachment.Meta.Focus.Y)
+
+	// the focus should have been stored too
+	dbAttachment, err := suite.db.GetAttachmentByID(context.Background(), toUpdate.ID)
+	suite.NoError(err)
+	suite.Equal("a new description", dbAttachment.Description)
+	suite.Equal(float32(-0.5), dbAttachment.FileMeta.Focus.X)
+	suite.Equal(float32(0.25), dbAttachment.FileMeta.Focus.Y)
+}
+
+func (suite *MediaUpdateTestSuite) TestUpdatePostedAttachment() {
+	toUpdate := suite.testAttachments["local_account_1_status_4_attachment_1"]
+
+	recorder := suite.updateMedia("local_account_1", toUpdate.ID, url.Values{
+		"description": {"a better description"},
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(context.Background(), toUpdate.ID)
+	suite.NoError(err)
+	suite.Equal("a better description", dbAttachment.Description)
+}
+
+func (suite *MediaUpdateTestSuite) TestUpdateBadFocus() {
+	recorder := suite.updateMedia("local_account_1", suite.testAttachments["local_account_1_unattached_1"].ID, url.Values{
+		"focus": {"-0.5,3"},
+	})
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *MediaUpdateTestSuite) TestUpdateSomeoneElsesAttachment() {
+	recorder := suite.updateMedia("local_account_2", suite.testAttachments["local_account_1_unattached_1"].ID, url.Values{
+		"description": {"not mine"},
+	})
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestMediaUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(MediaUpdateTestSuite))
+}
@@ -36,6 +36,12 @@ const (
 	// BasePathWithID is just the base path with the ID key in it.
 	// Use this anywhere you need to know the ID of the notification being queried.
 	BasePathWithID = BasePath + "/:" + IDKey
+	// DismissPath is for dismissing a single notification.
+	DismissPath = BasePathWithID + "/dismiss"
+	// ClearPath is for clearing all notifications.
+	ClearPath = BasePath + "/clear"
+	// PreferencesPath is for viewing and changing which types of notification are received.
+	PreferencesPath = BasePath + "/preferences"
 
 	// MaxIDKey is the url query for setting a max notification ID to return
 	MaxIDKey = "max_id"
@@ -43,6 +49,10 @@ const (
 	LimitKey = "limit"
 	// SinceIDKey is for specifying the minimum notification ID to return.
 	SinceIDKey = "since_id"
+	// TypesKey is for specifying which types of notification to return.
+	TypesKey = "types[]"
+	// ExcludeTypesKey is for specifying which types of notification not to return.
+	ExcludeTypesKey = "exclude_types[]"
 )
 
 // Module implements the ClientAPIModule interface for every related to posting/deleting/interacting with notifications
@@ -64,5 +74,9 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, BasePath, m.NotificationsGETHandler)
+	r.AttachHandler(http.MethodPost, DismissPath, m.NotificationDismissPOSTHandler)
+	r.AttachHandler(http.MethodPost, ClearPath, m.NotificationsClearPOSTHandler)
+	r.AttachHandler(http.MethodGet, PreferencesPath, m.NotificationPreferencesGETHandler)
+	r.AttachHandler(http.MethodPatch, PreferencesPath, m.NotificationPreferencesPATCHHandler)
 	return nil
 }
@@ -0,0 +1,153 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package notification
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// NotificationPreferencesPATCHHandler swagger:operation PATCH /api/v1/notifications/preferences notificationPreferencesUpdate
+//
+// Change which types of notification the requesting account receives.
+//
+// Only the given preferences are changed; any that aren't given are left as they are.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - notifications
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: follow
+//   type: boolean
+//   description: Receive notifications when someone follows you.
+//   in: formData
+//   x-go-name: Follow
+// - name: follow_request
+//   type: boolean
+//   description: Receive notifications when someone requests to follow you.
+//   in: formData
+//   x-go-name: FollowRequest
+// - name: follow_reject
+//   type: boolean
+//   description: Receive notifications when someone rejects your request to follow them.
+//   in: formData
+//   x-go-name: FollowReject
+// - name: mention
+//   type: boolean
+//   description: Receive notifications when someone mentions you in their status.
+//   in: formData
+//   x-go-name: Mention
+// - name: reblog
+//   type: boolean
+//   description: Receive notifications when someone boosts one of your statuses.
+//   in: formData
+//   x-go-name: Reblog
+// - name: favourite
+//   type: boolean
+//   description: Receive notifications when someone favourites one of your statuses.
+//   in: formData
+//   x-go-name: Favourite
+// - name: poll
+//   type: boolean
+//   description: Receive notifications when a poll you have voted in or created has ended.
+//   in: formData
+//   x-go-name: Poll
+// - name: status
+//   type: boolean
+//   description: Receive notifications when someone you enabled notifications for has posted a status.
+//   in: formData
+//   x-go-name: Status
+// - name: admin.report
+//   type: boolean
+//   description: Receive notifications when a new report has been filed. Only relevant for admins.
+//   in: formData
+//   x-go-name: AdminReport
+// - name: report_resolved
+//   type: boolean
+//   description: Receive notifications when a report you filed has been resolved by a moderator.
+//   in: formData
+//   x-go-name: ReportResolved
+// - name: admin.sign_up
+//   type: boolean
+//   description: Receive notifications when someone signs up and is waiting for approval. Only relevant for admins.
+//   in: formData
+//   x-go-name: AdminSignUp
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:notifications
+//
+// responses:
+//   '200':
+//     description: The updated notification preferences of the requesting account.
+//     schema:
+//       "$ref": "#/definitions/notificationPreferences"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) NotificationPreferencesPATCHHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "NotificationPreferencesPATCHHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+	l.Debug("entering function")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.NotificationPreferencesUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).Debug("could not parse form from request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, errWithCode := m.processor.NotificationPreferencesUpdate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error processing notification preferences update")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
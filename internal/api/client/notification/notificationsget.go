@@ -21,6 +21,7 @@ package notification
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -29,17 +30,17 @@ import (
 
 // NotificationsGETHandler serves a list of notifications to the caller, with the desired query parameters
 func (m *Module) NotificationsGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "NotificationsGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, true, true, true) // we don't really need an app here but we want everything else
 	if err != nil {
-		l.Errorf("error authing status faved by request: %s", err)
+		l.WithError(err).Error("error authing status faved by request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "not authed"})
 		return
 	}
@@ -49,7 +50,7 @@ func (m *Module) NotificationsGETHandler(c *gin.Context) {
 	if limitString != "" {
 		i, err := strconv.ParseInt(limitString, 10, 64)
 		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
+			l.WithError(err).Debug("error parsing limit string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
 			return
 		}
@@ -68,12 +69,25 @@ func (m *Module) NotificationsGETHandler(c *gin.Context) {
 		sinceID = sinceIDString
 	}
 
-	notifs, errWithCode := m.processor.NotificationsGet(c.Request.Context(), authed, limit, maxID, sinceID)
+	types := queryArray(c, TypesKey)
+	excludeTypes := queryArray(c, ExcludeTypesKey)
+
+	notifs, errWithCode := m.processor.NotificationsGet(c.Request.Context(), authed, types, excludeTypes, limit, maxID, sinceID)
 	if errWithCode != nil {
-		l.Debugf("error processing notifications get: %s", errWithCode.Error())
+		l.WithError(errWithCode).Debug("error processing notifications get")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
 
 	c.JSON(http.StatusOK, notifs)
 }
+
+// queryArray returns the values of the given array query key, for example "types[]".
+// Values given without the brackets, as in "types=mention", are accepted as well.
+func queryArray(c *gin.Context, key string) []string {
+	if values := c.QueryArray(key); len(values) != 0 {
+		return values
+	}
+	return c.QueryArray(strings.TrimSuffix(key, "[]"))
+}
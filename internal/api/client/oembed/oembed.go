@@ -0,0 +1,38 @@
+package oembed
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// OEmbedPath is for serving oEmbed representations of statuses
+	OEmbedPath = "api/oembed"
+)
+
+// Module implements the ClientModule interface
+type Module struct {
+	config    *config.Config
+	processor processing.Processor
+	log       *logrus.Logger
+}
+
+// New returns a new oEmbed module
+func New(config *config.Config, processor processing.Processor, log *logrus.Logger) api.ClientModule {
+	return &Module{
+		config:    config,
+		processor: processor,
+		log:       log,
+	}
+}
+
+// Route satisfies the ClientModule interface
+func (m *Module) Route(s router.Router) error {
+	s.AttachHandler(http.MethodGet, OEmbedPath, m.OEmbedGETHandler)
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oembed
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+// OEmbedGETHandler swagger:operation GET /api/oembed oEmbedGet
+//
+// Get the oEmbed representation of a public status, for embedding it in another website.
+//
+// See https://oembed.com/. Does not require authorization.
+//
+// ---
+// tags:
+// - oembed
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: url
+//   type: string
+//   description: URL of the status to embed, either its web url or its activitypub uri.
+//   in: query
+//   required: true
+// - name: maxwidth
+//   type: integer
+//   description: Maximum width of the embed, in pixels.
+//   in: query
+//   default: 400
+// - name: maxheight
+//   type: integer
+//   description: Maximum height of the embed, in pixels.
+//   in: query
+// - name: format
+//   type: string
+//   description: Response format. Only json is supported.
+//   in: query
+//   default: json
+//
+// responses:
+//   '200':
+//     description: "The oEmbed representation of the status."
+//     schema:
+//       "$ref": "#/definitions/oEmbed"
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+//   '501':
+//      description: format not implemented
+func (m *Module) OEmbedGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "OEmbedGETHandler")
+
+	form := &apimodel.OEmbedRequest{}
+	if err := c.ShouldBindQuery(form); err != nil {
+		l.WithError(err).Debug("error parsing oembed query")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if form.URL == "" {
+		err := errors.New("no url provided")
+		l.WithError(err).Debug("error parsing oembed query")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	oEmbed, errWithCode := m.processor.OEmbedGet(c.Request.Context(), form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting oembed")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, oEmbed)
+}
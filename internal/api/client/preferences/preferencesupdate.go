@@ -0,0 +1,152 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package preferences
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PreferencesPATCHHandler swagger:operation PATCH /api/v1/preferences preferencesUpdate
+//
+// Update the posting, reading and post expiry preferences of the requesting user. Fields that aren't set are left as they are.
+//
+// The posting preferences are the same as the privacy, sensitive and language fields of the account source,
+// so they can also be set through /api/v1/accounts/update_credentials.
+//
+// ---
+// tags:
+// - preferences
+//
+// consumes:
+// - multipart/form-data
+// - application/json
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: posting:default:visibility
+//   in: formData
+//   description: Default visibility for new posts.
+//   type: string
+//   enum:
+//   - public
+//   - unlisted
+//   - private
+//   - mutuals_only
+//   - direct
+// - name: posting:default:sensitive
+//   in: formData
+//   description: Mark new posts as sensitive by default.
+//   type: boolean
+// - name: posting:default:language
+//   in: formData
+//   description: Default language for new posts, as an ISO 639-1 two-letter code.
+//   type: string
+// - name: reading:expand:media
+//   in: formData
+//   description: |-
+//     How media attachments should be shown.
+//     default: hide media marked as sensitive.
+//     show_all: always show all media.
+//     hide_all: always hide all media.
+//   type: string
+//   enum:
+//   - default
+//   - show_all
+//   - hide_all
+// - name: reading:expand:spoilers
+//   in: formData
+//   description: Expand content warnings by default.
+//   type: boolean
+// - name: reading:languages
+//   in: formData
+//   description: |-
+//     ISO 639-1 two-letter codes of the languages of posts to show in the home and public timelines.
+//     Give a single empty value to show posts in all languages again.
+//   type: array
+//   items:
+//     type: string
+// - name: posting:expiry:days
+//   in: formData
+//   description: |-
+//     Delete posts older than this many days automatically, up to 3650. Deletes are federated as usual.
+//     Boosts are not deleted. Give 0 to keep posts forever.
+//   type: integer
+// - name: posting:expiry:keep_pinned
+//   in: formData
+//   description: Keep pinned posts when old posts are deleted automatically.
+//   type: boolean
+// - name: posting:expiry:keep_faved
+//   in: formData
+//   description: Keep posts you have favourited yourself when old posts are deleted automatically.
+//   type: boolean
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:accounts
+//
+// responses:
+//   '200':
+//     description: "The updated preferences of the requesting user."
+//     schema:
+//       "$ref": "#/definitions/preferences"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) PreferencesPATCHHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "PreferencesPATCHHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &apimodel.PreferencesUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	// clients often send reading:languages[] rather than reading:languages when submitting a form
+	if form.ReadingLanguages == nil {
+		if languages, ok := c.GetPostFormArray("reading:languages[]"); ok {
+			form.ReadingLanguages = &languages
+		}
+	}
+
+	preferences, errWithCode := m.processor.PreferencesUpdate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error updating preferences")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
@@ -0,0 +1,136 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPUTHandler swagger:operation PUT /api/v1/push/subscription pushSubscriptionUpdate
+//
+// Change which alerts the push subscription of the token the request is made with will give.
+//
+// Only the given alerts are changed; any that aren't given are left as they are.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+// ---
+// tags:
+// - push
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: data[alerts][follow]
+//   type: boolean
+//   description: Receive a push notification when someone has followed you.
+//   in: formData
+// - name: data[alerts][follow_request]
+//   type: boolean
+//   description: Receive a push notification when someone has requested to follow you.
+//   in: formData
+// - name: data[alerts][favourite]
+//   type: boolean
+//   description: Receive a push notification when a status you created has been favourited by someone else.
+//   in: formData
+// - name: data[alerts][mention]
+//   type: boolean
+//   description: Receive a push notification when someone else has mentioned you in a status.
+//   in: formData
+// - name: data[alerts][reblog]
+//   type: boolean
+//   description: Receive a push notification when a status you created has been boosted by someone else.
+//   in: formData
+// - name: data[alerts][poll]
+//   type: boolean
+//   description: Receive a push notification when a poll you voted in or created has ended.
+//   in: formData
+// - name: data[alerts][status]
+//   type: boolean
+//   description: Receive a push notification when someone you enabled notifications for has posted a status.
+//   in: formData
+// - name: data[policy]
+//   type: string
+//   description: Whose notifications to push; one of all, followed, follower or none.
+//   in: formData
+// - name: policy
+//   type: string
+//   description: Same as data[policy].
+//   in: formData
+//
+// security:
+// - OAuth2 Bearer:
+//   - push
+//
+// responses:
+//   '200':
+//     description: The updated push subscription.
+//     schema:
+//       "$ref": "#/definitions/pushSubscription"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) PushSubscriptionPUTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "PushSubscriptionPUTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+	l.Debug("entering function")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	form := &model.PushSubscriptionUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).Debug("could not parse form from request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub, errWithCode := m.processor.PushSubscriptionUpdate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error processing push subscription update")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
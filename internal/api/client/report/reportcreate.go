@@ -0,0 +1,128 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package report
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ReportCreatePOSTHandler swagger:operation POST /api/v1/reports reportCreate
+//
+// Report an account, and optionally some of its statuses, to the moderators of this instance.
+//
+// ---
+// tags:
+// - reports
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: account_id
+//   in: formData
+//   description: ID of the account to report.
+//   type: string
+//   required: true
+// - name: status_ids
+//   in: formData
+//   description: IDs of statuses by the reported account to attach to the report.
+//   type: array
+//   items:
+//     type: string
+// - name: rule_ids
+//   in: formData
+//   description: IDs of instance rules that the reported account broke. Only allowed with the violation category, which is the default when rule IDs are given.
+//   type: array
+//   items:
+//     type: string
+// - name: comment
+//   in: formData
+//   description: Reason for the report, max 1000 characters.
+//   type: string
+// - name: forward
+//   in: formData
+//   description: If the reported account is remote, whether to forward the report to its instance as well.
+//   type: boolean
+//   default: false
+// - name: category
+//   in: formData
+//   description: What kind of problem the report is about. One of spam, legal, violation, or other. Defaults to violation if rule_ids are given.
+//   type: string
+//   default: other
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:reports
+//
+// responses:
+//   '200':
+//     description: "The newly created report."
+//     schema:
+//       "$ref": "#/definitions/report"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) ReportCreatePOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "ReportCreatePOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &model.ReportCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.WithError(err).Debug("error parsing form")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	// clients often send status_ids[] rather than status_ids when submitting a form
+	if len(form.StatusIDs) == 0 {
+		form.StatusIDs = c.PostFormArray(StatusIDsKey + "[]")
+	}
+	if len(form.RuleIDs) == 0 {
+		form.RuleIDs = c.PostFormArray(RuleIDsKey + "[]")
+	}
+
+	report, errWithCode := m.processor.ReportCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error creating report")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -35,6 +35,11 @@ import (
 //
 // If statuses are in the result, they will be returned in descending chronological order (newest first), with sequential IDs (bigger = newer).
 //
+// If the query isn't a mention or a URI, then accounts, hashtags, and statuses will be searched for by text. Only statuses posted
+// by the requesting account, or by accounts it follows, are searched in this way.
+//
+// This endpoint is also served at /api/v2/search.
+//
 // ---
 // tags:
 // - search
@@ -56,18 +61,18 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) SearchGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "SearchGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, true, true, true) // we don't really need an app here but we want everything else
 	if err != nil {
-		l.Errorf("error authing search request: %s", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "not authed"})
+		l.WithError(err).Debug("error authing search request")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authed"})
 		return
 	}
 
@@ -75,6 +80,12 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 	maxID := c.Query(MaxIDKey)
 	minID := c.Query(MinIDKey)
 	searchType := c.Query(TypeKey)
+	switch searchType {
+	case "", TypeAccounts, TypeHashtags, TypeStatuses:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("param %s must be one of %s, %s, %s", TypeKey, TypeAccounts, TypeHashtags, TypeStatuses)})
+		return
+	}
 
 	excludeUnreviewed := false
 	excludeUnreviewedString := c.Query(ExcludeUnreviewedKey)
@@ -109,7 +120,7 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 	if limitString != "" {
 		i, err := strconv.ParseInt(limitString, 10, 64)
 		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
+			l.WithError(err).Debug("error parsing limit string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
 			return
 		}
@@ -127,17 +138,15 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 	if offsetString != "" {
 		i, err := strconv.ParseInt(offsetString, 10, 64)
 		if err != nil {
-			l.Debugf("error parsing offset string: %s", err)
+			l.WithError(err).Debug("error parsing offset string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse offset query param"})
 			return
 		}
 		offset = int(i)
 	}
-	if limit > 40 {
-		limit = 40
-	}
-	if limit < 1 {
-		limit = 1
+	if offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset query param must not be negative"})
+		return
 	}
 
 	following := false
@@ -166,7 +175,8 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 
 	results, errWithCode := m.processor.SearchGet(c.Request.Context(), authed, searchQuery)
 	if errWithCode != nil {
-		l.Debugf("error searching: %s", errWithCode.Error())
+		l.WithError(errWithCode).Debug("error searching")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
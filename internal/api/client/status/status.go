@@ -42,6 +42,11 @@ const (
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
 
+	// HistoryPath is used for fetching all versions of an edited post
+	HistoryPath = BasePathWithID + "/history"
+	// SourcePath is used for fetching the plain source of a post, so that it can be edited
+	SourcePath = BasePathWithID + "/source"
+
 	// FavouritedPath is for seeing who's faved a given status
 	FavouritedPath = BasePathWithID + "/favourited_by"
 	// FavouritePath is for posting a fave on a status
@@ -91,6 +96,7 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
+	r.AttachHandler(http.MethodPut, BasePathWithID, m.StatusEditPUTHandler)
 	r.AttachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
 
 	r.AttachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
@@ -101,7 +107,12 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, UnreblogPath, m.StatusUnboostPOSTHandler)
 	r.AttachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
 
+	r.AttachHandler(http.MethodPost, MutePath, m.StatusMutePOSTHandler)
+	r.AttachHandler(http.MethodPost, UnmutePath, m.StatusUnmutePOSTHandler)
+
 	r.AttachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+	r.AttachHandler(http.MethodGet, HistoryPath, m.StatusHistoryGETHandler)
+	r.AttachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
 
 	r.AttachHandler(http.MethodGet, BasePathWithID, m.muxHandler)
 	return nil
@@ -66,13 +66,13 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusBoostPOSTHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusBoostPOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, false, true, true) // we don't really need an app here but we want everything else
 	if err != nil {
@@ -89,7 +89,8 @@ func (m *Module) StatusBoostPOSTHandler(c *gin.Context) {
 
 	mastoStatus, errWithCode := m.processor.StatusBoost(c.Request.Context(), authed, targetStatusID)
 	if errWithCode != nil {
-		l.Debugf("error processing status boost: %s", errWithCode.Error())
+		l.WithError(errWithCode).Debug("error processing status boost")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
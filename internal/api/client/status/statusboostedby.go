@@ -63,17 +63,17 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusBoostedByGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusBoostedByGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, true, true, true) // we don't really need an app here but we want everything else
 	if err != nil {
-		l.Errorf("error authing status boosted by request: %s", err)
+		l.WithError(err).Error("error authing status boosted by request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "not authed"})
 		return
 	}
@@ -86,7 +86,7 @@ func (m *Module) StatusBoostedByGETHandler(c *gin.Context) {
 
 	mastoAccounts, err := m.processor.StatusBoostedBy(c.Request.Context(), authed, targetStatusID)
 	if err != nil {
-		l.Debugf("error processing status boosted by request: %s", err)
+		l.WithError(err).Debug("error processing status boosted by request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
 		return
 	}
@@ -65,17 +65,17 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusContextGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusContextGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Errorf("error authing status context request: %s", err)
+		l.WithError(err).Error("error authing status context request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "not authed"})
 		return
 	}
@@ -88,7 +88,8 @@ func (m *Module) StatusContextGETHandler(c *gin.Context) {
 
 	statusContext, errWithCode := m.processor.StatusGetContext(c.Request.Context(), authed, targetStatusID)
 	if errWithCode != nil {
-		l.Debugf("error getting status context: %s", errWithCode.Error())
+		l.WithError(errWithCode).Debug("error getting status context")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
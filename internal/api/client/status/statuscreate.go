@@ -26,6 +26,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
@@ -67,10 +68,10 @@ import (
 //   '500':
 //      description: internal error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
-	l := m.log.WithField("func", "statusCreatePOSTHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "statusCreatePOSTHandler")
 	authed, err := oauth.Authed(c, true, true, true, true) // posting a status is serious business so we want *everything*
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
@@ -78,32 +79,32 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	// First check this user/account is permitted to post new statuses.
 	// There's no point continuing otherwise.
 	if authed.User.Disabled || !authed.User.Approved || !authed.Account.SuspendedAt.IsZero() {
-		l.Debugf("couldn't auth: %s", err)
+		l.WithError(err).Debug("couldn't auth")
 		c.JSON(http.StatusForbidden, gin.H{"error": "account is disabled, not yet approved, or suspended"})
 		return
 	}
 
 	// extract the status create form from the request context
-	l.Debugf("parsing request form: %s", c.Request.Form)
+	l.WithField("form", c.Request.Form).Debug("parsing request form")
 	form := &model.AdvancedStatusCreateForm{}
 	if err := c.ShouldBind(form); err != nil || form == nil {
-		l.Debugf("could not parse form from request: %s", err)
+		l.WithError(err).Debug("could not parse form from request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
 		return
 	}
-	l.Debugf("handling status request form: %+v", form)
+	l.WithField("form", form).Debug("handling status request form")
 
 	// Give the fields on the request form a first pass to make sure the request is superficially valid.
-	l.Tracef("validating form %+v", form)
+	l.WithField("form", form).Trace("validating form")
 	if err := validateCreateStatus(form, m.config.StatusesConfig); err != nil {
-		l.Debugf("error validating form: %s", err)
+		l.WithError(err).Debug("error validating form")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	mastoStatus, err := m.processor.StatusCreate(c.Request.Context(), authed, form)
 	if err != nil {
-		l.Debugf("error processing status create: %s", err)
+		l.WithError(err).Debug("error processing status create")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
 		return
 	}
@@ -138,6 +139,12 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm, config *config.S
 		if form.Poll.Options == nil {
 			return errors.New("poll with no options")
 		}
+		if len(form.Poll.Options) < 2 {
+			return errors.New("poll must have at least 2 options")
+		}
+		if form.Poll.ExpiresIn <= 0 {
+			return errors.New("poll expires_in must be a positive number of seconds")
+		}
 		if len(form.Poll.Options) > config.PollMaxOptions {
 			return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), config.PollMaxOptions)
 		}
@@ -155,6 +162,15 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm, config *config.S
 		}
 	}
 
+	// validate interaction policies
+	for _, p := range [][2]string{{"reply", form.ReplyPolicy}, {"boost", form.BoostPolicy}, {"like", form.LikePolicy}} {
+		switch p[1] {
+		case "", string(gtsmodel.InteractionPolicyPublic), string(gtsmodel.InteractionPolicyFollowers), string(gtsmodel.InteractionPolicyMentioned):
+		default:
+			return fmt.Errorf("%s policy %s not recognized", p[0], p[1])
+		}
+	}
+
 	// validate post language
 	if form.Language != "" {
 		if err := validate.Language(form.Language); err != nil {
@@ -65,13 +65,13 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusDELETEHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusDELETEHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, false, true, true) // we don't really need an app here but we want everything else
 	if err != nil {
@@ -88,7 +88,7 @@ func (m *Module) StatusDELETEHandler(c *gin.Context) {
 
 	mastoStatus, err := m.processor.StatusDelete(c.Request.Context(), authed, targetStatusID)
 	if err != nil {
-		l.Debugf("error processing status delete: %s", err)
+		l.WithError(err).Debug("error processing status delete")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
 		return
 	}
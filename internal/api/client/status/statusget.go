@@ -62,17 +62,17 @@ import (
 //   '500':
 //      description: internal error
 func (m *Module) StatusGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "statusGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, false, false, false, false) // we don't really need an app here but we want everything else
 	if err != nil {
-		l.Errorf("error authing status faved by request: %s", err)
+		l.WithError(err).Error("error authing status faved by request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "not authed"})
 		return
 	}
@@ -85,7 +85,7 @@ func (m *Module) StatusGETHandler(c *gin.Context) {
 
 	mastoStatus, err := m.processor.StatusGet(c.Request.Context(), authed, targetStatusID)
 	if err != nil {
-		l.Debugf("error processing status get: %s", err)
+		l.WithError(err).Debug("error processing status get")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
 		return
 	}
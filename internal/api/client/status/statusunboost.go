@@ -63,13 +63,13 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusUnboostPOSTHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusUnboostPOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, false, true, true) // we don't really need an app here but we want everything else
 	if err != nil {
@@ -86,7 +86,8 @@ func (m *Module) StatusUnboostPOSTHandler(c *gin.Context) {
 
 	mastoStatus, errWithCode := m.processor.StatusUnboost(c.Request.Context(), authed, targetStatusID)
 	if errWithCode != nil {
-		l.Debugf("error processing status unboost: %s", errWithCode.Error())
+		l.WithError(errWithCode).Debug("error processing status unboost")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
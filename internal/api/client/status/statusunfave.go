@@ -62,13 +62,13 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusUnfavePOSTHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusUnfavePOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
 		"origin_ip":   c.ClientIP(),
 	})
-	l.Debugf("entering function")
+	l.Debug("entering function")
 
 	authed, err := oauth.Authed(c, true, false, true, true) // we don't really need an app here but we want everything else
 	if err != nil {
@@ -85,7 +85,7 @@ func (m *Module) StatusUnfavePOSTHandler(c *gin.Context) {
 
 	mastoStatus, err := m.processor.StatusUnfave(c.Request.Context(), authed, targetStatusID)
 	if err != nil {
-		l.Debugf("error processing status unfave: %s", err)
+		l.WithError(err).Debug("error processing status unfave")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
 		return
 	}
@@ -107,7 +107,7 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) StreamGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "StreamGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "StreamGETHandler")
 
 	streamType := c.Query(StreamQueryKey)
 	if streamType == "" {
@@ -141,7 +141,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// do the actual upgrade here
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		l.Infof("error upgrading websocket connection: %s", err)
+		l.WithError(err).Info("error upgrading websocket connection")
 		return
 	}
 	defer conn.Close() // whatever happens, when we leave this function we want to close the websocket connection
@@ -149,6 +149,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// inform the processor that we have a new connection and want a s for it
 	s, errWithCode := m.processor.OpenStreamForAccount(c.Request.Context(), account, streamType)
 	if errWithCode != nil {
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), errWithCode.Safe())
 		return
 	}
@@ -165,7 +166,7 @@ sendLoop:
 			// we've got a streaming message!!
 			l.Trace("received message from stream")
 			if err := conn.WriteJSON(m); err != nil {
-				l.Debugf("error writing json to websocket connection: %s", err)
+				l.WithError(err).Debug("error writing json to websocket connection")
 				// if something is wrong we want to bail and drop the connection -- the client will create a new one
 				break sendLoop
 			}
@@ -173,7 +174,7 @@ sendLoop:
 		case <-t.C:
 			l.Trace("received TICK from ticker")
 			if err := conn.WriteMessage(websocket.PingMessage, []byte(": ping")); err != nil {
-				l.Debugf("error writing ping to websocket connection: %s", err)
+				l.WithError(err).Debug("error writing ping to websocket connection")
 				// if something is wrong we want to bail and drop the connection -- the client will create a new one
 				break sendLoop
 			}
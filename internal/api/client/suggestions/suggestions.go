@@ -0,0 +1,87 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package suggestions
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// AccountIDKey is the key to use for retrieving the suggested account ID in requests
+	AccountIDKey = "account_id"
+	// BasePath is the base path for serving version 1 of the suggestions API
+	BasePath = "/api/v1/suggestions"
+	// BasePathWithID is for dismissing a single suggestion
+	BasePathWithID = BasePath + "/:" + AccountIDKey
+	// BasePathV2 is the base path for serving version 2 of the suggestions API
+	BasePathV2 = "/api/v2/suggestions"
+
+	// LimitKey is for specifying the maximum number of results to return.
+	LimitKey = "limit"
+)
+
+// Module implements the ClientAPIModule interface for everything related to follow suggestions
+type Module struct {
+	config    *config.Config
+	processor processing.Processor
+	log       *logrus.Logger
+}
+
+// New returns a new suggestions module
+func New(config *config.Config, processor processing.Processor, log *logrus.Logger) api.ClientModule {
+	return &Module{
+		config:    config,
+		processor: processor,
+		log:       log,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodGet, BasePath, m.SuggestionsGETHandler)
+	r.AttachHandler(http.MethodGet, BasePathV2, m.SuggestionsV2GETHandler)
+	r.AttachHandler(http.MethodDelete, BasePathWithID, m.SuggestionDELETEHandler)
+	return nil
+}
+
+// parseLimit parses the limit query param of the request, capping it
+// at 80 and using 40 if it isn't given.
+func parseLimit(c *gin.Context) (int, error) {
+	limit := 40
+	if limitString := c.Query(LimitKey); limitString != "" {
+		i, err := strconv.Atoi(limitString)
+		if err != nil || i <= 0 {
+			return 0, errors.New("couldn't parse limit query param")
+		}
+		limit = i
+	}
+	if limit > 80 {
+		limit = 80
+	}
+	return limit, nil
+}
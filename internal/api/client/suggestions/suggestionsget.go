@@ -0,0 +1,160 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package suggestions
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// SuggestionsGETHandler swagger:operation GET /api/v1/suggestions suggestionsGet
+//
+// See accounts that the requesting account might want to follow.
+//
+// Accounts followed by the accounts you follow are suggested first, then accounts you've
+// interacted with, then the most followed accounts on this instance. Only discoverable
+// accounts are suggested.
+//
+// ---
+// tags:
+// - suggestions
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: limit
+//   type: integer
+//   description: Number of accounts to return. Defaults to 40, and can't be more than 80.
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - read
+//
+// responses:
+//   '200':
+//     description: The suggested accounts, most relevant first.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/account"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) SuggestionsGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "SuggestionsGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	suggestions, errWithCode := m.processor.SuggestionsGet(c.Request.Context(), authed, limit)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting suggestions")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	accounts := make([]*model.Account, 0, len(suggestions))
+	for _, s := range suggestions {
+		accounts = append(accounts, s.Account)
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+// SuggestionsV2GETHandler swagger:operation GET /api/v2/suggestions suggestionsV2Get
+//
+// See accounts that the requesting account might want to follow, along with why they're suggested.
+//
+// Accounts followed by the accounts you follow are suggested first, then accounts you've
+// interacted with, then the most followed accounts on this instance. Only discoverable
+// accounts are suggested.
+//
+// ---
+// tags:
+// - suggestions
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: limit
+//   type: integer
+//   description: Number of suggestions to return. Defaults to 40, and can't be more than 80.
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - read
+//
+// responses:
+//   '200':
+//     description: The suggestions, most relevant first.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/suggestion"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) SuggestionsV2GETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "SuggestionsV2GETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	suggestions, errWithCode := m.processor.SuggestionsGet(c.Request.Context(), authed, limit)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting suggestions")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
@@ -0,0 +1,66 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tag
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// NameKey is for specifying the name of a hashtag, without the #
+	NameKey = "tag_name"
+	// BasePath is the base path for serving the tags API
+	BasePath = "/api/v1/tags"
+	// BasePathWithName is the base path with the name key in it, for operations on a single tag.
+	BasePathWithName = BasePath + "/:" + NameKey
+	// FollowPath is for following a tag.
+	FollowPath = BasePathWithName + "/follow"
+	// UnfollowPath is for unfollowing a tag.
+	UnfollowPath = BasePathWithName + "/unfollow"
+)
+
+// Module implements the ClientAPIModule interface for everything related to hashtags
+type Module struct {
+	config    *config.Config
+	processor processing.Processor
+	log       *logrus.Logger
+}
+
+// New returns a new tag module
+func New(config *config.Config, processor processing.Processor, log *logrus.Logger) api.ClientModule {
+	return &Module{
+		config:    config,
+		processor: processor,
+		log:       log,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodGet, BasePathWithName, m.TagGETHandler)
+	r.AttachHandler(http.MethodPost, FollowPath, m.TagFollowPOSTHandler)
+	r.AttachHandler(http.MethodPost, UnfollowPath, m.TagUnfollowPOSTHandler)
+	return nil
+}
@@ -102,11 +102,11 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) HomeTimelineGETHandler(c *gin.Context) {
-	l := m.log.WithField("func", "HomeTimelineGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "HomeTimelineGETHandler")
 
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("error authing: %s", err)
+		l.WithError(err).Debug("error authing")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
@@ -134,7 +134,7 @@ func (m *Module) HomeTimelineGETHandler(c *gin.Context) {
 	if limitString != "" {
 		i, err := strconv.ParseInt(limitString, 10, 64)
 		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
+			l.WithError(err).Debug("error parsing limit string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
 			return
 		}
@@ -146,7 +146,7 @@ func (m *Module) HomeTimelineGETHandler(c *gin.Context) {
 	if localString != "" {
 		i, err := strconv.ParseBool(localString)
 		if err != nil {
-			l.Debugf("error parsing local string: %s", err)
+			l.WithError(err).Debug("error parsing local string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse local query param"})
 			return
 		}
@@ -155,7 +155,8 @@ func (m *Module) HomeTimelineGETHandler(c *gin.Context) {
 
 	resp, errWithCode := m.processor.HomeTimelineGet(c.Request.Context(), authed, maxID, sinceID, minID, limit, local)
 	if errWithCode != nil {
-		l.Debugf("error from processor HomeTimelineGet: %s", errWithCode)
+		l.WithError(errWithCode).Debug("error from processor HomeTimelineGet")
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
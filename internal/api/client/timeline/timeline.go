@@ -35,6 +35,14 @@ const (
 	HomeTimeline = BasePath + "/home"
 	// PublicTimeline is the path for the public (and public local) timeline
 	PublicTimeline = BasePath + "/public"
+	// TagTimeline is the path for the timeline of a single hashtag
+	TagTimeline = BasePath + "/tag/:" + TagNameKey
+	// ListTimeline is the path for the timeline of a single list
+	ListTimeline = BasePath + "/list/:" + ListIDKey
+	// ListIDKey is for specifying the id of a list
+	ListIDKey = "list_id"
+	// TagNameKey is for specifying the name of a hashtag, without the #
+	TagNameKey = "tag_name"
 	// MaxIDKey is the url query for setting a max status ID to return
 	MaxIDKey = "max_id"
 	// SinceIDKey is the url query for returning results newer than the given ID
@@ -67,5 +75,7 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, HomeTimeline, m.HomeTimelineGETHandler)
 	r.AttachHandler(http.MethodGet, PublicTimeline, m.PublicTimelineGETHandler)
+	r.AttachHandler(http.MethodGet, TagTimeline, m.TagTimelineGETHandler)
+	r.AttachHandler(http.MethodGet, ListTimeline, m.ListTimelineGETHandler)
 	return nil
 }
@@ -0,0 +1,91 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trends
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TrendingLinksGETHandler swagger:operation GET /api/v1/trends/links trendingLinks
+//
+// See the links that are being shared by the most accounts on this instance right now.
+//
+// ---
+// tags:
+// - trends
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: limit
+//   type: integer
+//   description: Number of results to return. Defaults to 10, and can't be more than 20.
+//   in: query
+//   required: false
+// - name: offset
+//   type: integer
+//   description: Skip this many results, for fetching the next page.
+//   in: query
+//   required: false
+//
+// security:
+// - OAuth2 Bearer:
+//   - read
+//
+// responses:
+//   '200':
+//     description: The trending links, most trending first.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/trendsLink"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+func (m *Module) TrendingLinksGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "TrendingLinksGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.WithError(err).Debug("couldn't auth")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, offset, err := parseLimitAndOffset(c, 10, 20)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	links, errWithCode := m.processor.TrendingLinksGet(c.Request.Context(), authed, limit, offset)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting trending links")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
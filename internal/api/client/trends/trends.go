@@ -0,0 +1,99 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trends
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// BasePath is the base path for serving the trends API
+	BasePath = "/api/v1/trends"
+	// TagsPath is for serving trending hashtags.
+	TagsPath = BasePath + "/tags"
+	// StatusesPath is for serving trending statuses.
+	StatusesPath = BasePath + "/statuses"
+	// LinksPath is for serving trending links.
+	LinksPath = BasePath + "/links"
+
+	// LimitKey is for specifying the maximum number of results to return.
+	LimitKey = "limit"
+	// OffsetKey is for skipping the first n results.
+	OffsetKey = "offset"
+)
+
+// Module implements the ClientAPIModule interface for everything related to trends
+type Module struct {
+	config    *config.Config
+	processor processing.Processor
+	log       *logrus.Logger
+}
+
+// New returns a new trends module
+func New(config *config.Config, processor processing.Processor, log *logrus.Logger) api.ClientModule {
+	return &Module{
+		config:    config,
+		processor: processor,
+		log:       log,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodGet, TagsPath, m.TrendingTagsGETHandler)
+	r.AttachHandler(http.MethodGet, StatusesPath, m.TrendingStatusesGETHandler)
+	r.AttachHandler(http.MethodGet, LinksPath, m.TrendingLinksGETHandler)
+	return nil
+}
+
+// parseLimitAndOffset parses the limit and offset query params of the request, capping
+// the limit at maxLimit and using defaultLimit if it isn't given.
+func parseLimitAndOffset(c *gin.Context, defaultLimit int, maxLimit int) (int, int, error) {
+	limit := defaultLimit
+	if limitString := c.Query(LimitKey); limitString != "" {
+		i, err := strconv.Atoi(limitString)
+		if err != nil || i <= 0 {
+			return 0, 0, errors.New("couldn't parse limit query param")
+		}
+		limit = i
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset := 0
+	if offsetString := c.Query(OffsetKey); offsetString != "" {
+		i, err := strconv.Atoi(offsetString)
+		if err != nil || i < 0 {
+			return 0, 0, errors.New("couldn't parse offset query param")
+		}
+		offset = i
+	}
+
+	return limit, offset, nil
+}
@@ -46,8 +46,15 @@ type Account struct {
 	Locked bool `json:"locked"`
 	// Account has opted into discovery features.
 	Discoverable bool `json:"discoverable,omitempty"`
+	// Account has an RSS/Atom feed of its public posts, at {url}/feed.rss and {url}/feed.atom.
+	EnableRSS bool `json:"enable_rss,omitempty"`
+	// Custom CSS used on the account's profile and status pages.
+	// Only set if the instance allows custom CSS.
+	CustomCSS string `json:"custom_css,omitempty"`
 	// Account identifies as a bot.
 	Bot bool `json:"bot"`
+	// Account is a group actor, which redistributes posts addressed to it to its members.
+	Group bool `json:"group"`
 	// When the account was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
@@ -85,6 +92,8 @@ type Account struct {
 	Fields []Field `json:"fields"`
 	// Account has been suspended by our instance.
 	Suspended bool `json:"suspended,omitempty"`
+	// Account that this account has moved to, if it has moved.
+	Moved *Account `json:"moved,omitempty"`
 	// If this account has been muted, when will the mute expire (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	MuteExpiresAt string `json:"mute_expires_at,omitempty"`
@@ -114,7 +123,8 @@ type AccountCreateRequest struct {
 	// example: some_really_really_really_strong_password
 	// required: true
 	Password string `form:"password" json:"password" xml:"password" binding:"required"`
-	// The user agrees to the terms, conditions, and policies of the instance.
+	// The user agrees to the rules, terms, conditions, and policies of the instance.
+	// Clients should show the rules from /api/v1/instance/rules before asking for agreement.
 	// swagger:parameters
 	// required: true
 	Agreement bool `form:"agreement"  json:"agreement" xml:"agreement" binding:"required"`
@@ -123,6 +133,10 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// The response token produced by the captcha widget, if the instance requires a captcha on sign up.
+	// See the captcha field of the instance entity for which captcha provider to use.
+	// swagger:parameters
+	CaptchaResponse string `form:"captcha_response" json:"captcha_response" xml:"captcha_response"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -147,6 +161,10 @@ type UpdateCredentialsRequest struct {
 	Header *multipart.FileHeader `form:"header" json:"header" xml:"header"`
 	// Require manual approval of follow requests.
 	Locked *bool `form:"locked" json:"locked" xml:"locked"`
+	// Serve an RSS/Atom feed of public posts at the account's profile URL.
+	EnableRSS *bool `form:"enable_rss" json:"enable_rss" xml:"enable_rss"`
+	// Custom CSS to use on the account's profile and status pages. Only allowed if the instance permits it.
+	CustomCSS *string `form:"custom_css" json:"custom_css" xml:"custom_css"`
 	// New Source values for this account.
 	Source *UpdateSource `form:"source" json:"source" xml:"source"`
 	// Profile metadata name and value
@@ -163,6 +181,8 @@ type UpdateSource struct {
 	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Default language to use for authored statuses. (ISO 6391)
 	Language *string `form:"language" json:"language" xml:"language"`
+	// Keep blocks private, rather than sending them to the blocked account's instance.
+	PrivateBlocks *bool `form:"private_blocks" json:"private_blocks" xml:"private_blocks"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -187,3 +207,74 @@ type AccountFollowRequest struct {
 	// Notify when this account posts.
 	Notify *bool `form:"notify" json:"notify" xml:"notify"`
 }
+
+// AccountNoteRequest models a request to set a private note on an account.
+//
+// swagger:ignore
+type AccountNoteRequest struct {
+	// The id of the account to set the note on.
+	ID string `form:"-" json:"-" xml:"-"`
+	// Text of the note. An empty comment removes the note.
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+}
+
+// AccountMuteRequest models a request to mute an account.
+//
+// swagger:ignore
+type AccountMuteRequest struct {
+	// The id of the account to mute.
+	ID string `form:"-" json:"-" xml:"-"`
+	// Mute notifications from this account too, as well as its statuses.
+	Notifications *bool `form:"notifications" json:"notifications" xml:"notifications"`
+	// How long the mute should last, in seconds. 0 means the mute lasts until it's removed.
+	Duration int `form:"duration" json:"duration" xml:"duration"`
+}
+
+// AccountDeleteRequest models a request by a user to delete their own account.
+//
+// swagger:ignore
+type AccountDeleteRequest struct {
+	// Password of the account's user, for confirmation.
+	Password string `form:"password" json:"password" xml:"password"`
+}
+
+// AccountAliasRequest models a request by a user to set the aliases of their account.
+//
+// swagger:ignore
+type AccountAliasRequest struct {
+	// ActivityPub URIs of other accounts that are also owned by the user.
+	// Providing an empty list removes all aliases.
+	AlsoKnownAsURIs []string `form:"also_known_as_uris" json:"also_known_as_uris" xml:"also_known_as_uris"`
+}
+
+// AccountMoveRequest models a request by a user to move their account, and its followers, to another account.
+//
+// swagger:ignore
+type AccountMoveRequest struct {
+	// Password of the account's user, for confirmation.
+	Password string `form:"password" json:"password" xml:"password"`
+	// ActivityPub URI of the account to move to. This account must list the moving account as an alias.
+	MovedToURI string `form:"moved_to_uri" json:"moved_to_uri" xml:"moved_to_uri"`
+}
+
+// AccountExport models an archive of the requesting account's data, which can be downloaded.
+//
+// swagger:model accountExport
+type AccountExport struct {
+	// The ID of the export.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// When the export was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Size of the archive in bytes.
+	// Zero until the archive is ready.
+	// example: 102400
+	Size int `json:"size"`
+	// Whether the archive has been built and can be downloaded.
+	// example: true
+	Ready bool `json:"ready"`
+	// URL at which the archive can be downloaded, using the same access token.
+	// example: https://example.org/api/v1/accounts/exports/01FBW9XGEP7G6K88VY4S9MPE1R
+	URL string `json:"url"`
+}
@@ -19,6 +19,8 @@
 package model
 
 // AdminAccountInfo models the admin view of an account's details.
+//
+// swagger:model adminAccountInfo
 type AdminAccountInfo struct {
 	// The ID of the account in the database.
 	ID string `json:"id"`
@@ -54,14 +56,106 @@ type AdminAccountInfo struct {
 	CreatedByApplicationID string `json:"created_by_application_id,omitempty"`
 	// The ID of the account that invited this user
 	InvitedByAccountID string `json:"invited_by_account_id"`
+	// When the user acknowledged the rules of the instance while signing up, if there were any. (ISO 8601 Datetime)
+	RulesAcknowledgedAt string `json:"rules_acknowledged_at,omitempty"`
+	// Rules the account has been found to break by resolved reports, in the order of the rules of the instance.
+	RuleViolations []AdminRuleViolation `json:"rule_violations"`
+	// Private notes that moderators have written about the account, newest first. Only for local accounts.
+	ModerationNotes []AdminModerationNote `json:"moderation_notes"`
+	// Formal warnings that moderators have given the account, newest first. Only for local accounts.
+	Warnings []AdminAccountWarning `json:"warnings"`
+}
+
+// AdminModerationNote models a private note that a moderator has written about a local account.
+//
+// swagger:model adminModerationNote
+type AdminModerationNote struct {
+	// The ID of the note in the database.
+	ID string `json:"id"`
+	// The time the note was written. (ISO 8601 Datetime)
+	CreatedAt string `json:"created_at"`
+	// The moderator account that wrote the note.
+	Account *Account `json:"account"`
+	// The ID of the account that the note is about.
+	TargetAccountID string `json:"target_account_id"`
+	// The ID of a report about the account that the note relates to, if any.
+	ReportID string `json:"report_id,omitempty"`
+	// Text of the note.
+	Content string `json:"content"`
+}
+
+// AdminModerationNoteCreateRequest models a moderator writing a note about a local account.
+//
+// swagger:ignore
+type AdminModerationNoteCreateRequest struct {
+	// Text of the note, max 5,000 chars.
+	Content string `form:"content" json:"content" xml:"content"`
+	// ID of a report about the account that the note relates to.
+	ReportID string `form:"report_id" json:"report_id" xml:"report_id"`
+}
+
+// AdminAccountWarning models a formal warning, or strike, that a moderator has given a local account.
+//
+// swagger:model adminAccountWarning
+type AdminAccountWarning struct {
+	// The ID of the warning in the database.
+	ID string `json:"id"`
+	// The time the warning was given. (ISO 8601 Datetime)
+	CreatedAt string `json:"created_at"`
+	// The moderator account that gave the warning.
+	Account *Account `json:"account"`
+	// The ID of the account that was warned.
+	TargetAccountID string `json:"target_account_id"`
+	// The ID of the report that the warning was given because of, if any.
+	ReportID string `json:"report_id,omitempty"`
+	// What was done to the account along with the warning: none, silence, or suspend.
+	Action string `json:"action"`
+	// Explanation of the warning, shown to the user.
+	Text string `json:"text"`
+	// Whether the user was sent an email about the warning.
+	Emailed bool `json:"emailed"`
+}
+
+// AdminRuleViolation models how often an account has been found to break one rule of the instance.
+//
+// swagger:model adminRuleViolation
+type AdminRuleViolation struct {
+	// The rule that was broken.
+	Rule Rule `json:"rule"`
+	// How many resolved reports found the account to break the rule.
+	Count int `json:"count"`
+	// When the most recent of those reports was resolved. (ISO 8601 Datetime)
+	LastViolatedAt string `json:"last_violated_at"`
+}
+
+// AdminAccountActionRequest models a moderation action to be taken against an account.
+//
+// swagger:ignore
+type AdminAccountActionRequest struct {
+	// Type of action to be taken. One of: none, silence, suspend. None only gives a warning, so it's for local accounts only.
+	Type string `form:"type" json:"type" xml:"type"`
+	// ID of an unresolved report against the account, to resolve as a result of this action.
+	ReportID string `form:"report_id" json:"report_id" xml:"report_id"`
+	// Explanation of the action, recorded as a warning on a local account and shown to its user. Max 5,000 chars.
+	Text string `form:"text" json:"text" xml:"text"`
+	// Whether to email the user of a local account about the warning.
+	SendEmailNotification bool `form:"send_email_notification" json:"send_email_notification" xml:"send_email_notification"`
 }
 
 // AdminReportInfo models the admin view of a report.
+//
+// swagger:model adminReport
 type AdminReportInfo struct {
 	// The ID of the report in the database.
 	ID string `json:"id"`
-	// The action taken to resolve this report.
-	ActionTaken string `json:"action_taken"`
+	// Whether a moderator has resolved this report yet.
+	ActionTaken bool `json:"action_taken"`
+	// The time the report was resolved, if it has been. (ISO 8601 Datetime)
+	ActionTakenAt string `json:"action_taken_at,omitempty"`
+	// What the moderator who resolved this report did about it, if anything.
+	ActionTakenComment string `json:"action_taken_comment,omitempty"`
+	// What kind of problem the report is about: spam, legal, violation, or other.
+	Category string `json:"category"`
 	// An optional reason for reporting.
 	Comment string `json:"comment"`
 	// The time the report was filed. (ISO 8601 Datetime)
@@ -74,8 +168,101 @@ type AdminReportInfo struct {
 	TargetAccount *Account `json:"target_account"`
 	// The account of the moderator assigned to this report.
 	AssignedAccount *Account `json:"assigned_account"`
-	// The action taken by the moderator who handled the report.
-	ActionTakenByAccount string `json:"action_taken_by_account"`
+	// The account of the moderator who resolved the report.
+	ActionTakenByAccount *Account `json:"action_taken_by_account"`
 	// Statuses attached to the report, for context.
 	Statuses []Status `json:"statuses"`
+	// Instance rules that the reported account broke, according to the reporter.
+	Rules []Rule `json:"rules"`
+	// Rules the reported account has been found to break by earlier, resolved reports, so that
+	// moderators can see whether this is a repeat violation.
+	TargetAccountViolations []AdminRuleViolation `json:"target_account_violations"`
+}
+
+// AdminReportResolveRequest models a moderator resolving a report.
+//
+// swagger:ignore
+type AdminReportResolveRequest struct {
+	// Note about what was done to resolve the report. Only visible to moderators.
+	ActionTakenComment string `form:"action_taken_comment" json:"action_taken_comment" xml:"action_taken_comment"`
+}
+
+// AdminAuditLog models one entry in the admin audit log: an action that an admin took on this instance.
+//
+// swagger:model adminAuditLog
+type AdminAuditLog struct {
+	// The ID of the audit log entry in the database.
+	ID string `json:"id"`
+	// The time the action was taken. (ISO 8601 Datetime)
+	CreatedAt string `json:"created_at"`
+	// The admin account that took the action.
+	Account *Account `json:"account"`
+	// What was done, eg., create, delete, suspend, resolve.
+	Action string `json:"action"`
+	// What kind of thing it was done to, eg., account, domain_block, report.
+	TargetType string `json:"target_type"`
+	// The ID of the thing it was done to, if it has one.
+	TargetID string `json:"target_id,omitempty"`
+	// Human-readable description of the thing it was done to, eg., a username or domain.
+	Summary string `json:"summary,omitempty"`
+}
+
+// AdminMeasure models the daily counts of one kind of event on this instance over a period of time.
+//
+// swagger:model adminMeasure
+type AdminMeasure struct {
+	// The measure that was counted, eg., new_users.
+	Key string `json:"key"`
+	// The total count over the requested period.
+	Total string `json:"total"`
+	// The total count over the same length of time immediately before the requested period.
+	PreviousTotal string `json:"previous_total"`
+	// The count for each day of the requested period, oldest first.
+	Data []AdminMeasureData `json:"data"`
+}
+
+// AdminMeasureData models the count of a measure on one day.
+//
+// swagger:model adminMeasureData
+type AdminMeasureData struct {
+	// Midnight UTC at the start of the day. (ISO 8601 Datetime)
+	Date string `json:"date"`
+	// The count on that day.
+	Value string `json:"value"`
+}
+
+// AdminDimension models a breakdown of activity on this instance over a period of time, eg., by language.
+//
+// swagger:model adminDimension
+type AdminDimension struct {
+	// The dimension that was broken down, eg., languages.
+	Key string `json:"key"`
+	// The items of the breakdown, largest first.
+	Data []AdminDimensionData `json:"data"`
+}
+
+// AdminDimensionData models one item of a dimension.
+//
+// swagger:model adminDimensionData
+type AdminDimensionData struct {
+	// Machine-readable key of the item, eg., a language code or a domain.
+	Key string `json:"key"`
+	// Human-readable name of the item.
+	HumanKey string `json:"human_key"`
+	// The value of the item, eg., how many statuses were written in a language.
+	Value string `json:"value"`
+}
+
+// AdminStatisticsRequest models a request for measures or dimensions of this instance's activity.
+//
+// swagger:ignore
+type AdminStatisticsRequest struct {
+	// Keys of the measures or dimensions to return.
+	Keys []string `form:"keys" json:"keys" xml:"keys"`
+	// Start of the period to count, as a date or datetime. Defaults to 29 days before the end.
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period to count, as a date or datetime. Defaults to today.
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Maximum number of items to return for each dimension.
+	Limit int `form:"limit" json:"limit" xml:"limit"`
 }
@@ -40,6 +40,31 @@ type Application struct {
 	ClientSecret string `json:"client_secret,omitempty"`
 	// Push API key for this application.
 	VapidKey string `json:"vapid_key,omitempty"`
+	// Scopes granted to the token used to make this request.
+	// Only set when verifying app credentials.
+	// example: ["read","write"]
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// AuthorizedApplication models an application that a user has granted access to their account.
+//
+// swagger:model authorizedApplication
+type AuthorizedApplication struct {
+	// The ID of the application.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The name of the application.
+	// example: Tusky
+	Name string `json:"name"`
+	// The website associated with the application (url)
+	// example: https://tusky.app
+	Website string `json:"website,omitempty"`
+	// All scopes granted to the application by this user.
+	// example: ["read","write","follow"]
+	Scopes []string `json:"scopes"`
+	// When the user first authorized this application (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
 }
 
 // ApplicationCreateRequest models app create parameters.
@@ -26,6 +26,8 @@ type Content struct {
 	ContentLength int64
 	// Actual content blob
 	Content []byte
+	// URL to redirect the requester to for the content, instead of serving Content, if set
+	URL string
 }
 
 // GetContentRequestForm describes a piece of content desired by the caller of the fileserver API.
@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// DeliveryReceipt represents the outcome of delivering one outgoing activity to one remote inbox.
+//
+// swagger:model deliveryReceipt
+type DeliveryReceipt struct {
+	// The ID of the delivery receipt.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The ActivityPub id of the activity that was delivered.
+	// example: http://localhost:8080/users/admin/statuses/01FBW21XJA09XYX51KV5JVBW0F/activity
+	ActivityURI string `json:"activity_uri"`
+	// The ActivityStreams type of the activity that was delivered.
+	// example: Create
+	ActivityType string `json:"activity_type,omitempty"`
+	// The ActivityPub id of the object of the activity, if it had one.
+	// example: http://localhost:8080/users/admin/statuses/01FBW21XJA09XYX51KV5JVBW0F
+	ObjectURI string `json:"object_uri,omitempty"`
+	// The domain of the inbox the activity was delivered to.
+	// example: example.org
+	Domain string `json:"domain"`
+	// The inbox (or shared inbox) the activity was delivered to.
+	// example: https://example.org/inbox
+	InboxURI string `json:"inbox_uri"`
+	// Whether the most recent delivery attempt succeeded.
+	// example: true
+	Delivered bool `json:"delivered"`
+	// How many times delivery has been attempted.
+	// example: 1
+	Attempts int `json:"attempts"`
+	// The error returned by the most recent delivery attempt, if it failed.
+	// example: POST request to https://example.org/inbox failed (502): 502 Bad Gateway
+	LastError string `json:"last_error,omitempty"`
+	// Time of the first delivery attempt (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time of the most recent delivery attempt (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
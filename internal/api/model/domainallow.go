@@ -0,0 +1,56 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// DomainAllow represents an allow on one domain, used when the instance is federating in allowlist mode.
+//
+// swagger:model domainAllow
+type DomainAllow struct {
+	// The ID of the domain allow.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The hostname of the allowed domain.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Private comment for this allow, visible to our instance admins only.
+	// example: they're our friends
+	PrivateComment string `json:"private_comment,omitempty"`
+	// Public comment for this allow.
+	// example: nice folks
+	PublicComment string `json:"public_comment,omitempty"`
+	// ID of the account that created this domain allow.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// Time at which this allow was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// DomainAllowCreateRequest is the form submitted as a POST to /api/v1/admin/domain_allows to create a new allow.
+//
+// swagger:model domainAllowCreateRequest
+type DomainAllowCreateRequest struct {
+	// hostname/domain to allow
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// private comment for other admins on why the domain was allowed
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+	// public comment on the reason for the domain allow
+	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
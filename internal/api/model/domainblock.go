@@ -41,6 +41,15 @@ type DomainBlock struct {
 	// Public comment for this block, visible if domain blocks are served publicly.
 	// example: they smell
 	PublicComment string `form:"public_comment" json:"public_comment,omitempty"`
+	// How severe the block is: suspend (no federation at all), silence (hide from public timelines), or noop.
+	// example: suspend
+	Severity string `json:"severity,omitempty"`
+	// Don't fetch media attachments, avatars or headers from the domain. Only relevant to silence and noop blocks.
+	// example: false
+	RejectMedia bool `json:"reject_media,omitempty"`
+	// Ignore reports sent from the domain. Only relevant to silence and noop blocks.
+	// example: false
+	RejectReports bool `json:"reject_reports,omitempty"`
 	// The ID of the subscription that created/caused this domain block.
 	// example: 01FBW25TF5J67JW3HFHZCSD23K
 	SubscriptionID string `json:"subscription_id,omitempty"`
@@ -62,8 +71,33 @@ type DomainBlockCreateRequest struct {
 	Domain string `form:"domain" json:"domain" xml:"domain"`
 	// whether the domain should be obfuscated when being displayed publicly
 	Obfuscate bool `form:"obfuscate" json:"obfuscate" xml:"obfuscate"`
+	// how severe the block is: suspend (the default), silence, or noop
+	Severity string `form:"severity" json:"severity" xml:"severity"`
+	// whether media from the domain should be rejected, for silence and noop blocks
+	RejectMedia bool `form:"reject_media" json:"reject_media" xml:"reject_media"`
+	// whether reports from the domain should be rejected, for silence and noop blocks
+	RejectReports bool `form:"reject_reports" json:"reject_reports" xml:"reject_reports"`
 	// private comment for other admins on why the domain was blocked
 	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
 	// public comment on the reason for the domain block
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
 }
+
+// DomainBlockUpdateRequest is the form submitted as a PUT to /api/v1/admin/domain_blocks/{id} to change an existing block.
+// Fields that aren't set are left as they are.
+//
+// swagger:ignore
+type DomainBlockUpdateRequest struct {
+	// whether the domain should be obfuscated when being displayed publicly
+	Obfuscate *bool `form:"obfuscate" json:"obfuscate" xml:"obfuscate"`
+	// how severe the block is: suspend, silence, or noop
+	Severity *string `form:"severity" json:"severity" xml:"severity"`
+	// whether media from the domain should be rejected, for silence and noop blocks
+	RejectMedia *bool `form:"reject_media" json:"reject_media" xml:"reject_media"`
+	// whether reports from the domain should be rejected, for silence and noop blocks
+	RejectReports *bool `form:"reject_reports" json:"reject_reports" xml:"reject_reports"`
+	// private comment for other admins on why the domain was blocked
+	PrivateComment *string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+	// public comment on the reason for the domain block
+	PublicComment *string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
@@ -0,0 +1,80 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// DomainPolicy represents a federation policy on one domain which is less severe than a full domain block.
+//
+// swagger:model domainPolicy
+type DomainPolicy struct {
+	// The ID of the domain policy.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The hostname of the domain this policy applies to.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Media attachments, avatars and headers from this domain are not fetched.
+	// example: false
+	RejectMedia bool `json:"reject_media"`
+	// Statuses with media from this domain are marked as sensitive.
+	// example: true
+	SensitiveMedia bool `json:"sensitive_media"`
+	// Boosts made by accounts on this domain are ignored.
+	// example: false
+	RejectBoosts bool `json:"reject_boosts"`
+	// Statuses from this domain are hidden from the public timeline, and notifications
+	// from its accounts are only shown to local accounts that follow them.
+	// example: false
+	Silence bool `json:"silence"`
+	// Private comment for this policy, visible to our instance admins only.
+	// example: they post a lot of nsfw stuff
+	PrivateComment string `json:"private_comment,omitempty"`
+	// Public comment for this policy.
+	// example: nsfw instance
+	PublicComment string `json:"public_comment,omitempty"`
+	// ID of the account that created this domain policy.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// Time at which this policy was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time at which this policy was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
+
+// DomainPolicyCreateRequest is the form submitted as a POST to /api/v1/admin/domain_policies to create or update a policy.
+//
+// swagger:model domainPolicyCreateRequest
+type DomainPolicyCreateRequest struct {
+	// hostname/domain to apply the policy to
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// don't fetch media from the domain
+	RejectMedia bool `form:"reject_media" json:"reject_media" xml:"reject_media"`
+	// mark statuses with media from the domain as sensitive
+	SensitiveMedia bool `form:"sensitive_media" json:"sensitive_media" xml:"sensitive_media"`
+	// ignore boosts from the domain
+	RejectBoosts bool `form:"reject_boosts" json:"reject_boosts" xml:"reject_boosts"`
+	// silence the domain
+	Silence bool `form:"silence" json:"silence" xml:"silence"`
+	// private comment for other admins on why the policy was created
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+	// public comment on the reason for the policy
+	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
@@ -25,6 +25,8 @@ package model
 // If the phrase starts with a word character, and if the previous character before matched range is a word character, its matched range should be treated to not match.
 // If the phrase ends with a word character, and if the next character after matched range is a word character, its matched range should be treated to not match.
 // Please check app/javascript/mastodon/selectors/index.js and app/lib/feed_manager.rb in the Mastodon source code for more details.
+//
+// swagger:model filter
 type Filter struct {
 	// The ID of the filter in the database.
 	ID string `json:"id"`
@@ -44,3 +46,113 @@ type Filter struct {
 	// Should matching entities in home and notifications be dropped by the server?
 	Irreversible bool `json:"irreversible"`
 }
+
+// FilterV2 represents a user-defined filter for determining which statuses should not be shown to the user,
+// as used by version 2 of the filters API. See https://docs.joinmastodon.org/entities/Filter/
+//
+// swagger:model filterV2
+type FilterV2 struct {
+	// The ID of the filter in the database.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// A title given by the user to name the filter.
+	// example: spoilers
+	Title string `json:"title"`
+	// The contexts in which the filter should be applied.
+	// Array of String (Enumerable anyOf)
+	//	home = home timeline and lists
+	//	notifications = notifications timeline
+	//	public = public timelines
+	//	thread = expanded thread of a detailed status
+	//	account = when viewing a profile
+	Context []string `json:"context"`
+	// When the filter should no longer be applied (ISO 8601 Datetime), or null if the filter does not expire.
+	ExpiresAt *string `json:"expires_at"`
+	// The action to be taken when a status matches this filter.
+	//	warn = show a warning that identifies the matching filter
+	//	hide = do not show this status if it is received
+	// example: warn
+	FilterAction string `json:"filter_action"`
+	// The keywords grouped under this filter.
+	Keywords []FilterKeyword `json:"keywords"`
+	// The statuses grouped under this filter. Always empty, since filtering single statuses isn't supported.
+	Statuses []interface{} `json:"statuses"`
+}
+
+// FilterKeyword represents a keyword that, if matched, should cause the filter action to be taken.
+// See https://docs.joinmastodon.org/entities/FilterKeyword/
+//
+// swagger:model filterKeyword
+type FilterKeyword struct {
+	// The ID of the keyword in the database.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The phrase to be matched against.
+	// example: fnord
+	Keyword string `json:"keyword"`
+	// Should the filter consider word boundaries?
+	WholeWord bool `json:"whole_word"`
+}
+
+// FilterResult represents a filter whose keywords matched a given status.
+// See https://docs.joinmastodon.org/entities/FilterResult/
+//
+// swagger:model filterResult
+type FilterResult struct {
+	// The filter that was matched.
+	Filter FilterV2 `json:"filter"`
+	// The keywords within the filter that were matched.
+	KeywordMatches []string `json:"keyword_matches"`
+	// The status IDs within the filter that were matched. Always empty, since filtering single statuses isn't supported.
+	StatusMatches []string `json:"status_matches"`
+}
+
+// FilterCreateUpdateRequestV1 is the form submitted as a POST to /api/v1/filters to create a filter,
+// or as a PUT to /api/v1/filters/:id to update one.
+//
+// swagger:model filterCreateUpdateRequestV1
+type FilterCreateUpdateRequestV1 struct {
+	// the text to be filtered
+	Phrase string `form:"phrase" json:"phrase" xml:"phrase"`
+	// the contexts in which the filter should be applied: home, notifications, public, thread, and/or account
+	Context []string `form:"context" json:"context" xml:"context"`
+	// should matching statuses in home and notifications be dropped by the server, rather than shown with a warning
+	Irreversible bool `form:"irreversible" json:"irreversible" xml:"irreversible"`
+	// should the filter consider word boundaries
+	WholeWord bool `form:"whole_word" json:"whole_word" xml:"whole_word"`
+	// number of seconds from now that the filter should expire, or 0 for no expiry
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
+
+// FilterCreateUpdateRequestV2 is the form submitted as a POST to /api/v2/filters to create a filter,
+// or as a PUT to /api/v2/filters/:id to update one.
+//
+// swagger:model filterCreateUpdateRequestV2
+type FilterCreateUpdateRequestV2 struct {
+	// the name of the filter
+	Title string `form:"title" json:"title" xml:"title"`
+	// the contexts in which the filter should be applied: home, notifications, public, thread, and/or account
+	Context []string `form:"context" json:"context" xml:"context"`
+	// the action to take when a status matches the filter: warn or hide
+	FilterAction string `form:"filter_action" json:"filter_action" xml:"filter_action"`
+	// number of seconds from now that the filter should expire, or 0 for no expiry; leave out to keep the current expiry when updating
+	ExpiresIn *int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+	// keywords to add to, change on, or remove from the filter
+	KeywordsAttributes []FilterKeywordCreateUpdateRequest `form:"keywords_attributes" json:"keywords_attributes" xml:"keywords_attributes"`
+}
+
+// FilterKeywordCreateUpdateRequest describes one keyword to add to, change on, or remove from a filter.
+//
+// swagger:model filterKeywordCreateUpdateRequest
+type FilterKeywordCreateUpdateRequest struct {
+	// id of an existing keyword to change or remove; leave empty to add a new keyword
+	ID string `form:"id" json:"id" xml:"id"`
+	// the text to be filtered
+	Keyword string `form:"keyword" json:"keyword" xml:"keyword"`
+	// should the keyword consider word boundaries
+	WholeWord bool `form:"whole_word" json:"whole_word" xml:"whole_word"`
+	// remove the keyword with the given id from the filter
+	Destroy bool `form:"_destroy" json:"_destroy" xml:"_destroy"`
+}
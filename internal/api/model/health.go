@@ -0,0 +1,31 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Readiness is the result of checking whether this instance is able to serve requests.
+//
+// swagger:model readiness
+type Readiness struct {
+	// Whether all of the checks passed.
+	// example: true
+	Ready bool `json:"ready"`
+	// Outcome of each check, keyed by the name of the thing checked.
+	// Each value is either "ok" or a description of what went wrong.
+	Checks map[string]string `json:"checks"`
+}
@@ -77,6 +77,179 @@ type Instance struct {
 	//
 	// example: 5000
 	MaxTootChars uint `json:"max_toot_chars"`
+	// Captcha that must be solved to sign up on this instance.
+	// Only set if the instance requires a captcha on sign up.
+	Captcha *InstanceCaptcha `json:"captcha,omitempty"`
+	// Custom CSS used on all web pages of this instance.
+	CustomCSS string `json:"custom_css,omitempty"`
+	// Static pages set by the admin of this instance, such as an about page or a privacy policy.
+	Pages []InstancePageLink `json:"pages,omitempty"`
+	// Rules of the instance, in the order they should be shown.
+	Rules []Rule `json:"rules"`
+	// Limits of the instance that client applications should know about.
+	// Only set for this instance.
+	Configuration *InstanceConfiguration `json:"configuration,omitempty"`
+}
+
+// InstanceV2 models information about this instance, for serving at /api/v2/instance.
+//
+// swagger:model instanceV2
+type InstanceV2 struct {
+	// The domain of the instance.
+	// example: example.org
+	Domain string `json:"domain"`
+	// The title of the instance.
+	// example: GoToSocial Example Instance
+	Title string `json:"title"`
+	// The version of GoToSocial installed on the instance.
+	// example: 0.1.1 cb85f65
+	Version string `json:"version"`
+	// URL of the source code of the software running on the instance.
+	// example: https://github.com/superseriousbusiness/gotosocial
+	SourceURL string `json:"source_url"`
+	// A short description of the instance.
+	//
+	// Should be HTML formatted, but might be plaintext.
+	Description string `json:"description"`
+	// Usage statistics of the instance.
+	Usage InstanceV2Usage `json:"usage"`
+	// Banner image of the instance.
+	Thumbnail InstanceV2Thumbnail `json:"thumbnail"`
+	// Primary languages of the instance.
+	Languages []string `json:"languages"`
+	// Limits of the instance that client applications should know about.
+	Configuration InstanceConfiguration `json:"configuration"`
+	// Information about signing up on the instance.
+	Registrations InstanceV2Registrations `json:"registrations"`
+	// How to contact the admins of the instance.
+	Contact InstanceV2Contact `json:"contact"`
+	// Rules of the instance, in the order they should be shown.
+	Rules []Rule `json:"rules"`
+	// Captcha that must be solved to sign up on this instance.
+	// Only set if the instance requires a captcha on sign up.
+	Captcha *InstanceCaptcha `json:"captcha,omitempty"`
+	// Static pages set by the admin of this instance, such as an about page or a privacy policy.
+	Pages []InstancePageLink `json:"pages,omitempty"`
+}
+
+// InstanceV2Usage models usage statistics of an instance.
+//
+// swagger:model instanceV2Usage
+type InstanceV2Usage struct {
+	// Statistics about the users of the instance.
+	Users InstanceV2Users `json:"users"`
+}
+
+// InstanceV2Users models statistics about the users of an instance.
+//
+// swagger:model instanceV2Users
+type InstanceV2Users struct {
+	// Number of local users that have posted in the last 30 days.
+	// example: 42
+	ActiveMonth int `json:"active_month"`
+}
+
+// InstanceV2Thumbnail models the banner image of an instance.
+//
+// swagger:model instanceV2Thumbnail
+type InstanceV2Thumbnail struct {
+	// URL of the image. Empty if no image has been set.
+	// example: https://example.org/fileserver/01BPSX2MKCRVMD4YN4D71G9CP5/attachment/original/01H88X0KQ2DFYYDSWYP93VDJZA.png
+	URL string `json:"url"`
+	// Blurhash of the image, for showing while it loads.
+	// example: UeKUpFxuo~R%0nW;WCnhF6RjaJt757oJodS$
+	Blurhash string `json:"blurhash,omitempty"`
+}
+
+// InstanceV2Registrations models information about signing up on an instance.
+//
+// swagger:model instanceV2Registrations
+type InstanceV2Registrations struct {
+	// New account registrations are enabled on this instance.
+	Enabled bool `json:"enabled"`
+	// New account registrations require admin approval.
+	ApprovalRequired bool `json:"approval_required"`
+	// Custom message shown when registrations are closed.
+	Message *string `json:"message"`
+}
+
+// InstanceV2Contact models how to contact the admins of an instance.
+//
+// swagger:model instanceV2Contact
+type InstanceV2Contact struct {
+	// An email address that may be used for inquiries.
+	// example: admin@example.org
+	Email string `json:"email"`
+	// Contact account for the instance. Null if no contact account has been set.
+	Account *Account `json:"account"`
+}
+
+// InstanceConfiguration models the limits of an instance that client applications should know about.
+//
+// swagger:model instanceConfiguration
+type InstanceConfiguration struct {
+	// URLs of interest for client applications. Only set in /api/v2/instance.
+	URLs *InstanceConfigurationURLs `json:"urls,omitempty"`
+	// Limits on statuses.
+	Statuses InstanceConfigurationStatuses `json:"statuses"`
+	// Limits on media attachments.
+	MediaAttachments InstanceConfigurationMediaAttachments `json:"media_attachments"`
+	// Limits on polls.
+	Polls InstanceConfigurationPolls `json:"polls"`
+}
+
+// InstanceConfigurationURLs models instance-relevant URLs for client application consumption.
+//
+// swagger:model instanceConfigurationURLs
+type InstanceConfigurationURLs struct {
+	// Websockets address for status and notification streaming.
+	// example: wss://example.org
+	Streaming string `json:"streaming"`
+}
+
+// InstanceConfigurationStatuses models the limits on statuses of an instance.
+//
+// swagger:model instanceConfigurationStatuses
+type InstanceConfigurationStatuses struct {
+	// Maximum allowed length of a post on this instance, in characters.
+	// example: 5000
+	MaxCharacters int `json:"max_characters"`
+	// Maximum allowed length of the content warning of a post on this instance, in characters.
+	// example: 500
+	MaxContentWarningCharacters int `json:"max_content_warning_characters"`
+	// Maximum number of media attachments allowed on a post.
+	// example: 4
+	MaxMediaAttachments int `json:"max_media_attachments"`
+}
+
+// InstanceConfigurationMediaAttachments models the limits on media attachments of an instance.
+//
+// swagger:model instanceConfigurationMediaAttachments
+type InstanceConfigurationMediaAttachments struct {
+	// Mime types of media that can be uploaded.
+	// example: ["image/jpeg","image/gif","image/png","video/mp4"]
+	SupportedMimeTypes []string `json:"supported_mime_types"`
+	// Maximum size of an uploaded image, in bytes.
+	// example: 2097152
+	ImageSizeLimit int `json:"image_size_limit"`
+	// Maximum size of an uploaded video, in bytes.
+	// example: 10485760
+	VideoSizeLimit int `json:"video_size_limit"`
+	// Maximum length of the description of a media attachment, in characters.
+	// example: 500
+	DescriptionLimit int `json:"description_limit"`
+}
+
+// InstanceConfigurationPolls models the limits on polls of an instance.
+//
+// swagger:model instanceConfigurationPolls
+type InstanceConfigurationPolls struct {
+	// Maximum number of options in a poll.
+	// example: 6
+	MaxOptions int `json:"max_options"`
+	// Maximum length of each poll option, in characters.
+	// example: 50
+	MaxCharactersPerOption int `json:"max_characters_per_option"`
 }
 
 // InstanceURLs models instance-relevant URLs for client application consumption.
@@ -88,6 +261,20 @@ type InstanceURLs struct {
 	StreamingAPI string `json:"streaming_api"`
 }
 
+// InstanceCaptcha models the captcha that clients must show to users signing up.
+//
+// swagger:model instanceCaptcha
+type InstanceCaptcha struct {
+	// The captcha provider: one of hcaptcha, recaptcha or mcaptcha.
+	// example: hcaptcha
+	Provider string `json:"provider"`
+	// Public site key to render the captcha widget with.
+	SiteKey string `json:"site_key"`
+	// Base url of the mCaptcha server. Only set for the mcaptcha provider.
+	// example: https://mcaptcha.example.org
+	URL string `json:"url,omitempty"`
+}
+
 // InstanceSettingsUpdateRequest models an instance update request.
 //
 // swagger:ignore
@@ -104,8 +291,12 @@ type InstanceSettingsUpdateRequest struct {
 	Description *string `form:"description" json:"description" xml:"description"`
 	// Terms and conditions of the instance, max 5,000 chars. HTML formatting accepted.
 	Terms *string `form:"terms" json:"terms" xml:"terms"`
-	// Image to use as the instance thumbnail.
+	// Image to use as the instance avatar.
 	Avatar *multipart.FileHeader `form:"avatar" json:"avatar" xml:"avatar"`
 	// Image to use as the instance header.
 	Header *multipart.FileHeader `form:"header" json:"header" xml:"header"`
+	// Image to use as the instance thumbnail. This is the same image as the instance header, so it replaces the header if set.
+	Thumbnail *multipart.FileHeader `form:"thumbnail" json:"thumbnail" xml:"thumbnail"`
+	// Custom CSS to use on all web pages of the instance, max 50,000 chars.
+	CustomCSS *string `form:"custom_css" json:"custom_css" xml:"custom_css"`
 }
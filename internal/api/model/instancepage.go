@@ -0,0 +1,71 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// InstancePage represents a static page set by the admin of this instance, such as an about page or a privacy policy.
+//
+// swagger:model instancePage
+type InstancePage struct {
+	// Short name of the page, used in its url.
+	// example: privacy
+	Slug string `json:"slug"`
+	// Title of the page.
+	// example: Privacy Policy
+	Title string `json:"title"`
+	// Web url of the page.
+	// example: https://example.org/privacy
+	URL string `json:"url"`
+	// Sanitized html content of the page.
+	Content string `json:"content"`
+	// Content of the page as written by the admin. Only shown to admins.
+	Source string `json:"source,omitempty"`
+	// Format of the source: text/markdown or text/html. Only shown to admins.
+	// example: text/markdown
+	ContentType string `json:"content_type,omitempty"`
+	// Time at which the page was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
+
+// InstancePageLink links to a static page set by the admin of an instance.
+//
+// swagger:model instancePageLink
+type InstancePageLink struct {
+	// Short name of the page, used in its url.
+	// example: privacy
+	Slug string `json:"slug"`
+	// Title of the page.
+	// example: Privacy Policy
+	Title string `json:"title"`
+	// Web url of the page.
+	// example: https://example.org/privacy
+	URL string `json:"url"`
+}
+
+// InstancePageUpdateRequest models a request to create or replace a static instance page.
+//
+// swagger:ignore
+type InstancePageUpdateRequest struct {
+	// Title of the page. Max 200 chars.
+	Title string `form:"title" json:"title" xml:"title"`
+	// Content of the page, max 50,000 chars.
+	Content string `form:"content" json:"content" xml:"content"`
+	// Format of the content: text/markdown or text/html. Defaults to text/markdown.
+	ContentType string `form:"content_type" json:"content_type" xml:"content_type"`
+}
@@ -19,13 +19,47 @@
 package model
 
 // List represents a list of some users that the authenticated user follows. See https://docs.joinmastodon.org/entities/list/
+//
+// swagger:model list
 type List struct {
 	// The internal database ID of the list.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
 	ID string `json:"id"`
 	// The user-defined title of the list.
+	// example: best friends
 	Title string `json:"title"`
-	// followed = Show replies to any followed user
+	// Which replies should be shown in the list timeline.
+	//	followed = Show replies to any followed user
 	//	list = Show replies to members of the list
 	//	none = Show replies to no one
+	// example: list
 	RepliesPolicy string `json:"replies_policy"`
 }
+
+// ListCreateRequest is the form submitted as a POST to /api/v1/lists to create a list,
+// or as a PUT to /api/v1/lists/:id to update one.
+//
+// swagger:model listCreateRequest
+type ListCreateRequest struct {
+	// title of the list
+	Title string `form:"title" json:"title" xml:"title"`
+	// which replies should be shown in the list timeline: followed, list, or none
+	RepliesPolicy string `form:"replies_policy" json:"replies_policy" xml:"replies_policy"`
+}
+
+// ListAccountsChangeRequest is the form submitted as a POST to /api/v1/lists/:id/accounts to add accounts
+// to a list, or as a DELETE to the same path to remove them.
+//
+// swagger:model listAccountsChangeRequest
+type ListAccountsChangeRequest struct {
+	// ids of the accounts to add to or remove from the list
+	AccountIDs []string `form:"account_ids" json:"account_ids" xml:"account_ids"`
+}
+
+// ListAccountsResponse wraps a slice of accounts in a list, ready to be serialized, along with the Link
+// header for the previous and next queries, to be returned to the client.
+type ListAccountsResponse struct {
+	Accounts   []*Account
+	LinkHeader string
+}
@@ -27,11 +27,16 @@ type Notification struct {
 	// The type of event that resulted in the notification.
 	// 	follow = Someone followed you
 	// 	follow_request = Someone requested to follow you
+	// 	follow_reject = Someone rejected your request to follow them
 	// 	mention = Someone mentioned you in their status
 	// 	reblog = Someone boosted one of your statuses
 	// 	favourite = Someone favourited one of your statuses
 	// 	poll = A poll you have voted in or created has ended
 	// 	status = Someone you enabled notifications for has posted a status
+	// 	move = Someone you followed has moved to another account, and you now follow that account instead
+	// 	admin.report = A new report has been filed
+	// 	admin.sign_up = Someone signed up and is waiting for their account to be approved
+	// 	report_resolved = A report you filed has been resolved by a moderator
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -42,4 +47,67 @@ type Notification struct {
 
 	// Status that was the object of the notification, e.g. in mentions, reblogs, favourites, or polls.
 	Status *Status `json:"status,omitempty"`
+
+	// Report that was the object of the notification, in admin.report notifications.
+	Report *AdminReportInfo `json:"report,omitempty"`
+
+	// Report that was resolved, in report_resolved notifications. This is the reporter's view of the report.
+	ResolvedReport *Report `json:"resolved_report,omitempty"`
+}
+
+// NotificationPreferences represents which types of notification an account wants to receive.
+//
+// swagger:model notificationPreferences
+type NotificationPreferences struct {
+	// Receive notifications when someone follows you.
+	Follow bool `json:"follow"`
+	// Receive notifications when someone requests to follow you.
+	FollowRequest bool `json:"follow_request"`
+	// Receive notifications when someone rejects your request to follow them.
+	FollowReject bool `json:"follow_reject"`
+	// Receive notifications when someone mentions you in their status.
+	Mention bool `json:"mention"`
+	// Receive notifications when someone boosts one of your statuses.
+	Reblog bool `json:"reblog"`
+	// Receive notifications when someone favourites one of your statuses.
+	Favourite bool `json:"favourite"`
+	// Receive notifications when a poll you have voted in or created has ended.
+	Poll bool `json:"poll"`
+	// Receive notifications when someone you enabled notifications for has posted a status.
+	Status bool `json:"status"`
+	// Receive notifications when a new report has been filed. Only relevant for admins.
+	AdminReport bool `json:"admin.report"`
+	// Receive notifications when a report you filed has been resolved by a moderator.
+	ReportResolved bool `json:"report_resolved"`
+	// Receive notifications when someone signs up and is waiting for approval. Only relevant for admins.
+	AdminSignUp bool `json:"admin.sign_up"`
+}
+
+// NotificationPreferencesUpdateRequest models a request to change which types of notification an account wants to receive.
+// Preferences that aren't set are left as they are.
+//
+// swagger:ignore
+type NotificationPreferencesUpdateRequest struct {
+	// Receive notifications when someone follows you.
+	Follow *bool `form:"follow" json:"follow" xml:"follow"`
+	// Receive notifications when someone requests to follow you.
+	FollowRequest *bool `form:"follow_request" json:"follow_request" xml:"follow_request"`
+	// Receive notifications when someone rejects your request to follow them.
+	FollowReject *bool `form:"follow_reject" json:"follow_reject" xml:"follow_reject"`
+	// Receive notifications when someone mentions you in their status.
+	Mention *bool `form:"mention" json:"mention" xml:"mention"`
+	// Receive notifications when someone boosts one of your statuses.
+	Reblog *bool `form:"reblog" json:"reblog" xml:"reblog"`
+	// Receive notifications when someone favourites one of your statuses.
+	Favourite *bool `form:"favourite" json:"favourite" xml:"favourite"`
+	// Receive notifications when a poll you have voted in or created has ended.
+	Poll *bool `form:"poll" json:"poll" xml:"poll"`
+	// Receive notifications when someone you enabled notifications for has posted a status.
+	Status *bool `form:"status" json:"status" xml:"status"`
+	// Receive notifications when a new report has been filed. Only relevant for admins.
+	AdminReport *bool `form:"admin.report" json:"admin.report" xml:"admin.report"`
+	// Receive notifications when a report you filed has been resolved by a moderator.
+	ReportResolved *bool `form:"report_resolved" json:"report_resolved" xml:"report_resolved"`
+	// Receive notifications when someone signs up and is waiting for approval. Only relevant for admins.
+	AdminSignUp *bool `form:"admin.sign_up" json:"admin.sign_up" xml:"admin.sign_up"`
 }
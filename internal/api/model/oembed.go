@@ -0,0 +1,70 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// OEmbedRequest represents a request for the oEmbed representation of a status.
+//
+// See https://oembed.com/
+type OEmbedRequest struct {
+	// URL of the status to embed.
+	URL string `form:"url" json:"url" xml:"url"`
+	// Maximum width of the embed, in pixels.
+	MaxWidth int `form:"maxwidth" json:"maxwidth" xml:"maxwidth"`
+	// Maximum height of the embed, in pixels.
+	MaxHeight int `form:"maxheight" json:"maxheight" xml:"maxheight"`
+	// Requested response format. Only json is supported.
+	Format string `form:"format" json:"format" xml:"format"`
+}
+
+// OEmbed is the oEmbed representation of a status, for embedding it in third-party sites.
+//
+// swagger:model oEmbed
+type OEmbed struct {
+	// The resource type. Always rich for statuses.
+	// example: rich
+	Type string `json:"type"`
+	// The oEmbed version number. Always 1.0.
+	// example: 1.0
+	Version string `json:"version"`
+	// A text title describing the resource.
+	// example: New post by some_user
+	Title string `json:"title"`
+	// Display name (or username) of the author of the status.
+	// example: big jeff (he/him)
+	AuthorName string `json:"author_name"`
+	// Web location of the author's profile.
+	// example: https://example.org/@some_user
+	AuthorURL string `json:"author_url"`
+	// Name of this instance.
+	// example: example.org
+	ProviderName string `json:"provider_name"`
+	// Web location of this instance.
+	// example: https://example.org
+	ProviderURL string `json:"provider_url"`
+	// How long the embed may be cached for, in seconds.
+	// example: 86400
+	CacheAge int `json:"cache_age"`
+	// HTML to insert into the embedding page: an iframe pointing at the embeddable view of the status.
+	HTML string `json:"html"`
+	// Width of the iframe, in pixels.
+	// example: 400
+	Width int `json:"width"`
+	// Height of the iframe in pixels, or null if it should be sized by the embedding page.
+	Height *int `json:"height"`
+}
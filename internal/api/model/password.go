@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// PasswordChangeRequest models a request by a signed in user to change their password.
+//
+// swagger:ignore
+type PasswordChangeRequest struct {
+	// The user's current password, to check that it's really them.
+	OldPassword string `form:"old_password" json:"old_password" xml:"old_password" validation:"required"`
+	// The password the user wants to use from now on.
+	NewPassword string `form:"new_password" json:"new_password" xml:"new_password" validation:"required"`
+}
+
+// PasswordResetRequest models a request to be emailed a link for choosing a new password.
+//
+// swagger:ignore
+type PasswordResetRequest struct {
+	// Confirmed email address of the user who forgot their password.
+	Email string `form:"email" json:"email" xml:"email"`
+}
+
+// PasswordResetConfirmRequest models a request to choose a new password using the token from a password reset email.
+//
+// swagger:ignore
+type PasswordResetConfirmRequest struct {
+	// Token from the link in the password reset email.
+	Token string `form:"token" json:"token" xml:"token"`
+	// The password the user wants to use from now on.
+	NewPassword string `form:"new_password" json:"new_password" xml:"new_password"`
+}
@@ -19,6 +19,8 @@
 package model
 
 // Preferences represents a user's preferences. See https://docs.joinmastodon.org/entities/preferences/
+//
+// swagger:model preferences
 type Preferences struct {
 	// Default visibility for new posts.
 	// 	public = Public post
@@ -37,4 +39,38 @@ type Preferences struct {
 	ReadingExpandMedia string `json:"reading:expand:media"`
 	// Whether CWs should be expanded by default.
 	ReadingExpandSpoilers bool `json:"reading:expand:spoilers"`
+	// Languages (ISO 639-1 language two-letter codes) of the posts to show in the home and public timelines.
+	// Posts in other languages are hidden. If empty, posts in all languages are shown.
+	ReadingLanguages []string `json:"reading:languages"`
+	// Posts older than this many days are deleted automatically. 0 means posts are kept forever.
+	PostingExpiryDays int `json:"posting:expiry:days"`
+	// Whether pinned posts are kept when old posts are deleted automatically.
+	PostingExpiryKeepPinned bool `json:"posting:expiry:keep_pinned"`
+	// Whether posts that the user has favourited themselves are kept when old posts are deleted automatically.
+	PostingExpiryKeepFaved bool `json:"posting:expiry:keep_faved"`
+}
+
+// PreferencesUpdateRequest models a request to update a user's preferences. Fields that aren't set are left as they are.
+//
+// swagger:ignore
+type PreferencesUpdateRequest struct {
+	// Default visibility for new posts: public, unlisted, private, mutuals_only, or direct.
+	PostingDefaultVisibility *string `form:"posting:default:visibility" json:"posting:default:visibility" xml:"posting:default:visibility"`
+	// Default sensitivity flag for new posts.
+	PostingDefaultSensitive *bool `form:"posting:default:sensitive" json:"posting:default:sensitive" xml:"posting:default:sensitive"`
+	// Default language for new posts, as an ISO 639-1 language two-letter code.
+	PostingDefaultLanguage *string `form:"posting:default:language" json:"posting:default:language" xml:"posting:default:language"`
+	// Whether media attachments should be automatically displayed or blurred/hidden: default, show_all, or hide_all.
+	ReadingExpandMedia *string `form:"reading:expand:media" json:"reading:expand:media" xml:"reading:expand:media"`
+	// Whether CWs should be expanded by default.
+	ReadingExpandSpoilers *bool `form:"reading:expand:spoilers" json:"reading:expand:spoilers" xml:"reading:expand:spoilers"`
+	// Languages (ISO 639-1 language two-letter codes) of the posts to show in the home and public timelines.
+	// Giving a single empty language shows posts in all languages again.
+	ReadingLanguages *[]string `form:"reading:languages" json:"reading:languages" xml:"reading:languages"`
+	// Delete posts older than this many days automatically. 0 keeps posts forever.
+	PostingExpiryDays *int `form:"posting:expiry:days" json:"posting:expiry:days" xml:"posting:expiry:days"`
+	// Whether pinned posts are kept when old posts are deleted automatically.
+	PostingExpiryKeepPinned *bool `form:"posting:expiry:keep_pinned" json:"posting:expiry:keep_pinned" xml:"posting:expiry:keep_pinned"`
+	// Whether posts that the user has favourited themselves are kept when old posts are deleted automatically.
+	PostingExpiryKeepFaved *bool `form:"posting:expiry:keep_faved" json:"posting:expiry:keep_faved" xml:"posting:expiry:keep_faved"`
 }
@@ -19,6 +19,8 @@
 package model
 
 // PushSubscription represents a subscription to the push streaming server. See https://docs.joinmastodon.org/entities/pushsubscription/
+//
+// swagger:model pushSubscription
 type PushSubscription struct {
 	// The id of the push subscription in the database.
 	ID string `json:"id"`
@@ -28,12 +30,18 @@ type PushSubscription struct {
 	ServerKey string `json:"server_key"`
 	// Which alerts should be delivered to the endpoint.
 	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Whose notifications should be delivered to the endpoint: all, followed, follower or none.
+	Policy string `json:"policy"`
 }
 
 // PushSubscriptionAlerts represents the specific alerts that this push subscription will give.
+//
+// swagger:model pushSubscriptionAlerts
 type PushSubscriptionAlerts struct {
 	// Receive a push notification when someone has followed you?
 	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
 	// Receive a push notification when a status you created has been favourited by someone else?
 	Favourite bool `json:"favourite"`
 	// Receive a push notification when someone else has mentioned you in a status?
@@ -42,4 +50,72 @@ type PushSubscriptionAlerts struct {
 	Reblog bool `json:"reblog"`
 	// Receive a push notification when a poll you voted in or created has ended?
 	Poll bool `json:"poll"`
+	// Receive a push notification when someone you've enabled notifications for has posted a status?
+	Status bool `json:"status"`
+}
+
+// PushSubscriptionCreateRequest is the form submitted as a POST to /api/v1/push/subscription to subscribe
+// to push notifications with the token the request is made with.
+//
+// swagger:ignore
+type PushSubscriptionCreateRequest struct {
+	// The push endpoint and its keys.
+	Subscription *PushSubscriptionRequestSubscription `json:"subscription" xml:"subscription"`
+	// Which alerts to deliver, and whose.
+	Data *PushSubscriptionRequestData `json:"data" xml:"data"`
+}
+
+// PushSubscriptionUpdateRequest is the form submitted as a PUT to /api/v1/push/subscription to change
+// which alerts the push subscription of the token the request is made with will give.
+//
+// swagger:ignore
+type PushSubscriptionUpdateRequest struct {
+	// Which alerts to deliver, and whose.
+	Data *PushSubscriptionRequestData `json:"data" xml:"data"`
+	// Whose notifications to deliver: all, followed, follower or none. Same as data[policy].
+	Policy string `form:"policy" json:"policy" xml:"policy"`
+}
+
+// PushSubscriptionRequestSubscription is the push endpoint part of a PushSubscriptionCreateRequest.
+//
+// swagger:ignore
+type PushSubscriptionRequestSubscription struct {
+	// Where push alerts will be sent to.
+	Endpoint string `form:"subscription[endpoint]" json:"endpoint" xml:"endpoint"`
+	// Keys for encrypting push alerts, as given by the push service.
+	Keys *PushSubscriptionRequestKeys `json:"keys" xml:"keys"`
+}
+
+// PushSubscriptionRequestKeys are the encryption keys of a PushSubscriptionRequestSubscription.
+//
+// swagger:ignore
+type PushSubscriptionRequestKeys struct {
+	// User agent public key: base64url encoded P-256 public key in uncompressed form.
+	P256dh string `form:"subscription[keys][p256dh]" json:"p256dh" xml:"p256dh"`
+	// Auth secret: base64url encoded 16 bytes of random data.
+	Auth string `form:"subscription[keys][auth]" json:"auth" xml:"auth"`
+}
+
+// PushSubscriptionRequestData is the part of a push subscription request that says which alerts to deliver.
+//
+// swagger:ignore
+type PushSubscriptionRequestData struct {
+	// Which alerts to deliver. Alerts that aren't given are not delivered when creating
+	// a subscription, and left as they are when updating one.
+	Alerts *PushSubscriptionRequestAlerts `json:"alerts" xml:"alerts"`
+	// Whose notifications to deliver: all, followed, follower or none.
+	Policy string `form:"data[policy]" json:"policy" xml:"policy"`
+}
+
+// PushSubscriptionRequestAlerts are the alerts requested in a PushSubscriptionRequestData.
+//
+// swagger:ignore
+type PushSubscriptionRequestAlerts struct {
+	Follow        *bool `form:"data[alerts][follow]" json:"follow" xml:"follow"`
+	FollowRequest *bool `form:"data[alerts][follow_request]" json:"follow_request" xml:"follow_request"`
+	Favourite     *bool `form:"data[alerts][favourite]" json:"favourite" xml:"favourite"`
+	Mention       *bool `form:"data[alerts][mention]" json:"mention" xml:"mention"`
+	Reblog        *bool `form:"data[alerts][reblog]" json:"reblog" xml:"reblog"`
+	Poll          *bool `form:"data[alerts][poll]" json:"poll" xml:"poll"`
+	Status        *bool `form:"data[alerts][status]" json:"status" xml:"status"`
 }
@@ -0,0 +1,66 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Report represents a report filed by the authenticated user. See https://docs.joinmastodon.org/entities/report/
+//
+// swagger:model report
+type Report struct {
+	// The internal database ID of the report.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	// readonly: true
+	ID string `json:"id"`
+	// Whether a moderator has resolved this report yet.
+	ActionTaken bool `json:"action_taken"`
+	// The time the report was resolved, if it has been. (ISO 8601 Datetime)
+	ActionTakenAt string `json:"action_taken_at,omitempty"`
+	// What kind of problem the report is about: spam, legal, violation, or other.
+	// example: spam
+	Category string `json:"category"`
+	// The reason given for the report.
+	Comment string `json:"comment"`
+	// Whether the report was forwarded to the instance of the reported account.
+	Forwarded bool `json:"forwarded"`
+	// The time the report was filed. (ISO 8601 Datetime)
+	CreatedAt string `json:"created_at"`
+	// IDs of the statuses attached to the report.
+	StatusIDs []string `json:"status_ids"`
+	// IDs of the instance rules that the reported account broke, according to the report.
+	RuleIDs []string `json:"rule_ids"`
+	// The account that was reported.
+	TargetAccount *Account `json:"target_account"`
+}
+
+// ReportCreateRequest is the form submitted as a POST to /api/v1/reports to report an account.
+//
+// swagger:model reportCreateRequest
+type ReportCreateRequest struct {
+	// id of the account to report
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+	// ids of statuses by the reported account to attach to the report
+	StatusIDs []string `form:"status_ids" json:"status_ids" xml:"status_ids"`
+	// ids of instance rules that the reported account broke; only for reports in the violation category
+	RuleIDs []string `form:"rule_ids" json:"rule_ids" xml:"rule_ids"`
+	// reason for the report, max 1000 characters
+	Comment string `form:"comment" json:"comment" xml:"comment"`
+	// if the reported account is remote, whether to forward the report to its instance as well
+	Forward bool `form:"forward" json:"forward" xml:"forward"`
+	// what kind of problem the report is about: spam, legal, violation, or other
+	Category string `form:"category" json:"category" xml:"category"`
+}
@@ -0,0 +1,58 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Rule represents one rule of an instance, which users of the instance are expected to follow.
+//
+// swagger:model instanceRule
+type Rule struct {
+	// The id of the rule.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Short text of the rule.
+	// example: No harassment.
+	Text string `json:"text"`
+	// Optional longer explanation of the rule.
+	// example: Don't send unwanted messages to people, or follow them around from post to post.
+	Hint string `json:"hint"`
+}
+
+// RuleCreateRequest models a request to create an instance rule.
+//
+// swagger:ignore
+type RuleCreateRequest struct {
+	// Short text of the rule, max 500 chars.
+	Text string `form:"text" json:"text" xml:"text"`
+	// Optional longer explanation of the rule, max 5,000 chars.
+	Hint string `form:"hint" json:"hint" xml:"hint"`
+	// Position of the rule in the list of rules, lowest first. Defaults to after all other rules.
+	Priority *int `form:"priority" json:"priority" xml:"priority"`
+}
+
+// RuleUpdateRequest models a request to update an instance rule. Fields that aren't set are left as they are.
+//
+// swagger:ignore
+type RuleUpdateRequest struct {
+	// Short text of the rule, max 500 chars.
+	Text *string `form:"text" json:"text" xml:"text"`
+	// Optional longer explanation of the rule, max 5,000 chars.
+	Hint *string `form:"hint" json:"hint" xml:"hint"`
+	// Position of the rule in the list of rules, lowest first.
+	Priority *int `form:"priority" json:"priority" xml:"priority"`
+}
@@ -36,7 +36,7 @@ type SearchQuery struct {
 	// The entry with this ID will not be included in the search results.
 	// in: query
 	MinID string `json:"min_id"`
-	// Type of the search query to perform.
+	// Type of the search query to perform. If not set, all types will be searched for.
 	//
 	// Must be one of: `accounts`, `hashtags`, `statuses`.
 	//
@@ -44,7 +44,6 @@ type SearchQuery struct {
 	// - accounts
 	// - hashtags
 	// - statuses
-	// required: true
 	// in: query
 	Type string `json:"type"`
 	// Filter out tags that haven't been reviewed and approved by an instance admin.
@@ -58,11 +57,16 @@ type SearchQuery struct {
 	//
 	// For a status, this can be in the format: `https://some.instance.com/@someaccount/SOME_ID_OF_A_STATUS`
 	//
+	// Anything else will be searched for as text in account usernames and display names, hashtag names, and status content.
+	//
 	// required: true
 	// in: query
 	Query string `json:"q"`
 	// Attempt to resolve the query by performing a remote webfinger lookup, if the query includes a remote host.
+	//
+	// This is only done for the first page of results, ie., when offset is 0.
 	// default: false
+	// in: query
 	Resolve bool `json:"resolve"`
 	// Maximum number of results to load, per type.
 	// default: 20
@@ -0,0 +1,48 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Session models an oauth token that the user holds, ie., an app or device signed in to their account.
+//
+// swagger:model session
+type Session struct {
+	// The ID of the session.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The name of the application that the session was created for.
+	// example: Tusky
+	Application string `json:"application"`
+	// The website associated with the application (url)
+	// example: https://tusky.app
+	Website string `json:"website,omitempty"`
+	// Scopes granted to the session.
+	// example: ["read","write","follow"]
+	Scopes []string `json:"scopes"`
+	// When the session was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the session was last used to make a request (ISO 8601 Datetime), if it's been used at all.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	// The IP address that the session was last used from, if it's been used at all.
+	// example: 192.0.2.1
+	IP string `json:"ip,omitempty"`
+	// Whether this is the session that the request listing it was made with.
+	Current bool `json:"current"`
+}
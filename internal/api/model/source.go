@@ -32,6 +32,8 @@ type Source struct {
 	Sensitive bool `json:"sensitive,omitempty"`
 	// The default posting language for new statuses.
 	Language string `json:"language,omitempty"`
+	// Whether blocks made by this account are kept private, rather than being sent to the blocked account's instance.
+	PrivateBlocks bool `json:"private_blocks,omitempty"`
 	// Profile bio.
 	Note string `json:"note"`
 	// Metadata about the account.
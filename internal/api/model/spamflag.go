@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// SpamFlag represents an incoming status that was flagged by the spam checks, for review by moderators.
+//
+// swagger:model spamFlag
+type SpamFlag struct {
+	// The ID of the spam flag.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Time at which the status was flagged (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// What was done with the status.
+	//
+	// report: the status was processed as normal, and flagged for moderators.
+	// hold: the status is kept out of timelines and notifications until it's released.
+	// example: hold
+	Action string `json:"action"`
+	// Spam score the status got.
+	// example: 120
+	Score int `json:"score"`
+	// Reasons for the score.
+	Reasons []string `json:"reasons"`
+	// The account that posted the status.
+	Account *Account `json:"account"`
+	// The flagged status.
+	Status *Status `json:"status"`
+}
@@ -28,6 +28,9 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// The date when this status was last edited by its author (ISO 8601 Datetime), if it has been edited.
+	// example: 2021-07-30T09:25:25+00:00
+	EditedAt string `json:"edited_at,omitempty"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	InReplyToID string `json:"in_reply_to_id,omitempty"`
@@ -43,6 +46,9 @@ type Status struct {
 	// Visibility of this status.
 	// example: unlisted
 	Visibility Visibility `json:"visibility"`
+	// This status is only shown to accounts on this instance, and isn't federated.
+	// example: false
+	LocalOnly bool `json:"local_only,omitempty"`
 	// Primary language of this status (ISO 639 Part 1 two-letter language code).
 	// example: en
 	Language string `json:"language"`
@@ -86,6 +92,8 @@ type Status struct {
 	Tags []Tag `json:"tags"`
 	// Custom emoji to be used when rendering status content.
 	Emojis []Emoji `json:"emojis"`
+	// Emoji reactions to this status, grouped by emoji, in the order that each emoji was first used.
+	EmojiReactions []StatusReaction `json:"emoji_reactions"`
 	// Preview card for links included within status content.
 	Card *Card `json:"card"`
 	// The poll attached to the status.
@@ -94,6 +102,9 @@ type Status struct {
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
 	Text string `json:"text"`
+	// The filters of the account viewing the status that matched it, if any.
+	// Only set when the status is shown with a warning rather than being hidden.
+	Filtered []FilterResult `json:"filtered,omitempty"`
 }
 
 // StatusReblogged represents a reblogged status.
@@ -123,8 +134,9 @@ type StatusCreateRequest struct {
 	// in: formData
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
 	// Status and attached media should be marked as sensitive.
+	// If not set, the default sensitivity of the posting account will be used.
 	// in: formData
-	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
 	// Statuses are generally collapsed behind this field.
 	// in: formData
@@ -193,12 +205,27 @@ type AdvancedStatusCreateForm struct {
 type AdvancedVisibilityFlagsForm struct {
 	// This status will be federated beyond the local timeline(s).
 	Federated *bool `form:"federated" json:"federated" xml:"federated"`
+	// This status will only be shown to accounts on this instance, and will not be federated.
+	// The opposite of federated, and takes precedence over it if both are set. Ignored for direct statuses.
+	LocalOnly *bool `form:"local_only" json:"local_only" xml:"local_only"`
 	// This status can be boosted/reblogged.
 	Boostable *bool `form:"boostable" json:"boostable" xml:"boostable"`
+	// Who, apart from the author, may boost this status if it's boostable.
+	// Either public (anyone), followers (followers and mentioned accounts), or mentioned (mentioned accounts only).
+	// Defaults to public.
+	BoostPolicy string `form:"boost_policy" json:"boost_policy" xml:"boost_policy"`
 	// This status can be replied to.
 	Replyable *bool `form:"replyable" json:"replyable" xml:"replyable"`
+	// Who, apart from the author, may reply to this status if it's replyable.
+	// Either public (anyone), followers (followers and mentioned accounts), or mentioned (mentioned accounts only).
+	// Defaults to public.
+	ReplyPolicy string `form:"reply_policy" json:"reply_policy" xml:"reply_policy"`
 	// This status can be liked/faved.
 	Likeable *bool `form:"likeable" json:"likeable" xml:"likeable"`
+	// Who, apart from the author, may like this status if it's likeable.
+	// Either public (anyone), followers (followers and mentioned accounts), or mentioned (mentioned accounts only).
+	// Defaults to public.
+	LikePolicy string `form:"like_policy" json:"like_policy" xml:"like_policy"`
 }
 
 // StatusFormat is the format in which to parse the submitted status.
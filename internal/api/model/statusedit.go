@@ -0,0 +1,80 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// StatusEdit models one version of a status, as shown in the edit history of that status.
+//
+// swagger:model statusEdit
+type StatusEdit struct {
+	// The content of this version of the status.
+	Content string `json:"content"`
+	// Subject, summary, or content warning for this version of the status.
+	SpoilerText string `json:"spoiler_text"`
+	// This version of the status contains sensitive content.
+	Sensitive bool `json:"sensitive"`
+	// The date when this version of the status was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The account that authored the status.
+	Account *Account `json:"account"`
+	// Media that is attached to this version of the status.
+	MediaAttachments []Attachment `json:"media_attachments"`
+	// Custom emoji to be used when rendering this version of the status.
+	Emojis []Emoji `json:"emojis"`
+}
+
+// StatusEditRequest models status edit parameters.
+//
+// swagger:parameters statusEdit
+type StatusEditRequest struct {
+	// Text content of the edited status.
+	// in: formData
+	Status string `form:"status" json:"status" xml:"status"`
+	// Status and attached media should be marked as sensitive.
+	// in: formData
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	// in: formData
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// Format to use when parsing the edited status text.
+	// in: formData
+	Format StatusFormat `form:"format" json:"format" xml:"format"`
+	// Array of Attachment ids to be attached to the edited status as media.
+	// If not provided, the attachments of the status are left as they are.
+	// in: formData
+	MediaIDs []string `form:"media_ids" json:"media_ids" xml:"media_ids"`
+	// ISO 639 language code for the edited status.
+	// If not provided, the language of the status is left as it is.
+	// in: formData
+	Language string `form:"language" json:"language" xml:"language"`
+}
+
+// StatusSource models the plain source of a status, as submitted by its author, so that it can be edited.
+//
+// swagger:model statusSource
+type StatusSource struct {
+	// ID of the status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Plain text source of the status.
+	Text string `json:"text"`
+	// Plain text version of the spoiler text, or content warning, of the status.
+	SpoilerText string `json:"spoiler_text"`
+}
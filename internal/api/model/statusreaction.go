@@ -0,0 +1,33 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// StatusReaction models all the emoji reactions to a status that used the same emoji.
+//
+// swagger:model statusReaction
+type StatusReaction struct {
+	// The emoji used for the reaction. Either a unicode emoji, or a custom emoji's shortcode surrounded by colons.
+	// example: :blobcat_uwu:
+	Name string `json:"name"`
+	// The total number of accounts that reacted to the status with this emoji.
+	// example: 5
+	Count int `json:"count"`
+	// The account viewing the status reacted to it with this emoji.
+	Me bool `json:"me"`
+}
@@ -0,0 +1,35 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Suggestion models an account that the requesting account might want to follow.
+//
+// swagger:model suggestion
+type Suggestion struct {
+	// The reason this account is being suggested, in the form used by older clients.
+	// One of past_interactions or global.
+	// example: global
+	Source string `json:"source"`
+	// All the reasons this account is being suggested.
+	// Any of friends_of_friends, past_interactions or most_followed.
+	// example: ["friends_of_friends"]
+	Sources []string `json:"sources"`
+	// The account being suggested.
+	Account *Account `json:"account"`
+}
@@ -28,4 +28,40 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
+	// Whether the requesting account follows this hashtag.
+	// Only set when viewing, following or unfollowing the hashtag itself.
+	Following *bool `json:"following,omitempty"`
+	// Usage statistics of this hashtag, for each of the last few days, today first.
+	// Only set when viewing trending hashtags.
+	History []TagHistory `json:"history,omitempty"`
+}
+
+// TagHistory represents how much a hashtag or link was used on one day.
+//
+// swagger:model tagHistory
+type TagHistory struct {
+	// UNIX timestamp of midnight (UTC) at the start of the day.
+	// example: 1641340800
+	Day string `json:"day"`
+	// How many statuses used the hashtag or link on this day.
+	// example: 12
+	Uses string `json:"uses"`
+	// How many different accounts used the hashtag or link on this day.
+	// example: 5
+	Accounts string `json:"accounts"`
+}
+
+// AdminTag models the admin view of a hashtag.
+//
+// swagger:model adminTag
+type AdminTag struct {
+	Tag
+	// The ID of the hashtag in the database.
+	ID string `json:"id"`
+	// Whether the hashtag has been approved to be shown in trends.
+	Trendable bool `json:"trendable"`
+	// Whether the hashtag can be used in statuses posted on this instance.
+	Usable bool `json:"usable"`
+	// Whether the hashtag is waiting for an admin to approve or reject it for trends.
+	RequiresReview bool `json:"requires_review"`
 }
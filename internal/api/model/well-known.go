@@ -18,6 +18,8 @@
 
 package model
 
+import "encoding/xml"
+
 // WellKnownResponse represents the response to either a webfinger request for an 'acct' resource, or a request to nodeinfo.
 // For example, it would be returned from https://example.org/.well-known/webfinger?resource=acct:some_username@example.org
 //
@@ -32,10 +34,20 @@ type WellKnownResponse struct {
 //
 // See https://webfinger.net/
 type Link struct {
-	Rel      string `json:"rel"`
-	Type     string `json:"type,omitempty"`
-	Href     string `json:"href,omitempty"`
-	Template string `json:"template,omitempty"`
+	Rel      string `json:"rel" xml:"rel,attr"`
+	Type     string `json:"type,omitempty" xml:"type,attr,omitempty"`
+	Href     string `json:"href,omitempty" xml:"href,attr,omitempty"`
+	Template string `json:"template,omitempty" xml:"template,attr,omitempty"`
+}
+
+// HostMeta represents the XRD document returned from a request to /.well-known/host-meta.
+// For example, it would be returned from https://example.org/.well-known/host-meta
+//
+// See https://www.rfc-editor.org/rfc/rfc6415.html
+type HostMeta struct {
+	XMLName xml.Name `xml:"XRD"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	Links   []Link   `xml:"Link"`
 }
 
 // Nodeinfo represents a version 2.1 or version 2.0 nodeinfo schema.
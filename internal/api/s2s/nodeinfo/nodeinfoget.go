@@ -28,14 +28,15 @@ import (
 // NodeInfoGETHandler returns a compliant nodeinfo response to node info queries.
 // See: https://nodeinfo.diaspora.software/
 func (m *Module) NodeInfoGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":       "NodeInfoGETHandler",
 		"user-agent": c.Request.UserAgent(),
 	})
 
 	ni, err := m.processor.GetNodeInfo(c.Request.Context(), c.Request)
 	if err != nil {
-		l.Debugf("error with get node info request: %s", err)
+		l.WithError(err).Debug("error with get node info request")
+		c.Error(err)
 		c.JSON(err.Code(), err.Safe())
 		return
 	}
@@ -28,14 +28,15 @@ import (
 // NodeInfoWellKnownGETHandler returns a well known response to a query to /.well-known/nodeinfo,
 // directing (but not redirecting...) callers to the NodeInfoGETHandler.
 func (m *Module) NodeInfoWellKnownGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":       "NodeInfoWellKnownGETHandler",
 		"user-agent": c.Request.UserAgent(),
 	})
 
 	niRel, err := m.processor.GetNodeInfoRel(c.Request.Context(), c.Request)
 	if err != nil {
-		l.Debugf("error with get node info rel request: %s", err)
+		l.WithError(err).Debug("error with get node info rel request")
+		c.Error(err)
 		c.JSON(err.Code(), err.Safe())
 		return
 	}
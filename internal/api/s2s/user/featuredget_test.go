@@ -0,0 +1,113 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FeaturedGetTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *FeaturedGetTestSuite) TestGetFeatured() {
+	// the dereference we're gonna use
+	derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+	signedRequest := derefRequests["foss_satan_dereference_local_account_1_featured"]
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	// pin one public and one mutuals-only status; only the public one should be served
+	publicStatus := suite.testStatuses["local_account_1_status_1"]
+	publicStatus.Pinned = true
+	suite.NoError(suite.db.UpdateByPrimaryKey(context.Background(), publicStatus))
+
+	mutualsStatus := suite.testStatuses["local_account_1_status_3"]
+	mutualsStatus.Pinned = true
+	suite.NoError(suite.db.UpdateByPrimaryKey(context.Background(), mutualsStatus))
+
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db)
+	federator := testrig.NewTestFederator(suite.db, tc, suite.storage)
+	processor := testrig.NewTestProcessor(suite.db, suite.storage, federator)
+	userModule := user.New(suite.config, processor, suite.log).(*user.Module)
+
+	// setup request
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, targetAccount.FeaturedCollectionURI, nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("Signature", signedRequest.SignatureHeader)
+	ctx.Request.Header.Set("Date", signedRequest.DateHeader)
+
+	// we need to pass the context through signature check first to set appropriate values on it
+	suite.securityModule.SignatureCheck(ctx)
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: targetAccount.Username,
+		},
+	}
+
+	// trigger the function being tested
+	userModule.FeaturedCollectionGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// should be an OrderedCollection
+	m := make(map[string]interface{})
+	err = json.Unmarshal(b, &m)
+	suite.NoError(err)
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	collection, ok := t.(vocab.ActivityStreamsOrderedCollection)
+	suite.True(ok)
+
+	suite.Equal(targetAccount.FeaturedCollectionURI, collection.GetJSONLDId().GetIRI().String())
+	suite.Equal(1, collection.GetActivityStreamsTotalItems().Get())
+
+	items := collection.GetActivityStreamsOrderedItems()
+	suite.Equal(1, items.Len())
+	suite.True(items.At(0).IsActivityStreamsNote())
+	suite.Equal(publicStatus.URI, items.At(0).GetActivityStreamsNote().GetJSONLDId().GetIRI().String())
+}
+
+func TestFeaturedGetTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedGetTestSuite))
+}
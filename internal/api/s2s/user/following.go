@@ -29,7 +29,7 @@ import (
 
 // FollowingGETHandler returns a collection of URIs for accounts that the target user follows, formatted so that other AP servers can understand it.
 func (m *Module) FollowingGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "FollowingGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -45,13 +45,14 @@ func (m *Module) FollowingGETHandler(c *gin.Context) {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": fmt.Sprintf("could not negotiate format with given Accept header(s): %s", err)})
 		return
 	}
-	l.Tracef("negotiated format: %s", format)
+	l.WithField("format", format).Trace("negotiated format")
 
 	ctx := transferContext(c)
 
 	following, errWithCode := m.processor.GetFediFollowing(ctx, requestedUsername, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -59,7 +60,7 @@ func (m *Module) FollowingGETHandler(c *gin.Context) {
 	b, mErr := json.Marshal(following)
 	if mErr != nil {
 		err := fmt.Errorf("could not marshal json: %s", mErr)
-		l.Error(err)
+		l.WithError(mErr).Error("could not marshal json")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
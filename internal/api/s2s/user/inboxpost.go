@@ -29,7 +29,7 @@ import (
 // InboxPOSTHandler deals with incoming POST requests to an actor's inbox.
 // Eg., POST to https://example.org/users/whatever/inbox.
 func (m *Module) InboxPOSTHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "InboxPOSTHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -45,17 +45,17 @@ func (m *Module) InboxPOSTHandler(c *gin.Context) {
 	posted, err := m.processor.InboxPost(ctx, c.Writer, c.Request)
 	if err != nil {
 		if withCode, ok := err.(gtserror.WithCode); ok {
-			l.Debugf("InboxPOSTHandler: %s", withCode.Error())
+			l.WithError(withCode).Debug("InboxPOSTHandler")
 			c.JSON(withCode.Code(), withCode.Safe())
 			return
 		}
-		l.Debugf("InboxPOSTHandler: error processing request: %s", err)
+		l.WithError(err).Debug("InboxPOSTHandler: error processing request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "unable to process request"})
 		return
 	}
 
 	if !posted {
-		l.Debugf("InboxPOSTHandler: request could not be handled as an AP request; headers were: %+v", c.Request.Header)
+		l.WithField("headers", c.Request.Header).Debug("InboxPOSTHandler: request could not be handled as an AP request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "unable to process request"})
 	}
 }
@@ -126,6 +126,86 @@ func (suite *InboxPostTestSuite) TestPostBlock() {
 	suite.Equal("http://fossbros-anonymous.io/users/foss_satan/blocks/01FG9C441MCTW3R2W117V2PQK3", dbBlock.URI)
 }
 
+// TestPostBlockTwice verifies that an activity that's delivered to the same inbox a second time is dropped rather than handled again.
+func (suite *InboxPostTestSuite) TestPostBlockTwice() {
+	blockingAccount := suite.testAccounts["remote_account_1"]
+	blockedAccount := suite.testAccounts["local_account_1"]
+	blockURI := testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/blocks/01FG9C441MCTW3R2W117V2PQK3")
+
+	block := streams.NewActivityStreamsBlock()
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(blockingAccount.URI))
+	block.SetActivityStreamsActor(actorProp)
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(blockURI)
+	block.SetJSONLDId(idProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(blockedAccount.URI))
+	block.SetActivityStreamsObject(objectProp)
+
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(testrig.URLMustParse(blockedAccount.URI))
+	block.SetActivityStreamsTo(toProp)
+
+	targetURI := testrig.URLMustParse(blockedAccount.InboxURI)
+
+	bodyI, err := streams.Serialize(block)
+	suite.NoError(err)
+	bodyJson, err := json.Marshal(bodyI)
+	suite.NoError(err)
+
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db)
+	federator := testrig.NewTestFederator(suite.db, tc, suite.storage)
+	processor := testrig.NewTestProcessor(suite.db, suite.storage, federator)
+	userModule := user.New(suite.config, processor, suite.log).(*user.Module)
+
+	post := func() {
+		signature, digestHeader, dateHeader := testrig.GetSignatureForActivity(block, blockingAccount.PublicKeyURI, blockingAccount.PrivateKey, targetURI)
+
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodPost, targetURI.String(), bytes.NewReader(bodyJson))
+		ctx.Request.Header.Set("Signature", signature)
+		ctx.Request.Header.Set("Date", dateHeader)
+		ctx.Request.Header.Set("Digest", digestHeader)
+		ctx.Request.Header.Set("Content-Type", "application/activity+json")
+		suite.securityModule.SignatureCheck(ctx)
+		ctx.Params = gin.Params{
+			gin.Param{
+				Key:   user.UsernameKey,
+				Value: blockedAccount.Username,
+			},
+		}
+
+		userModule.InboxPOSTHandler(ctx)
+
+		result := recorder.Result()
+		defer result.Body.Close()
+		suite.Equal(http.StatusOK, result.StatusCode)
+	}
+
+	// the first delivery creates the block, and the activity is remembered
+	post()
+	dbBlock, err := suite.db.GetBlock(context.Background(), blockingAccount.ID, blockedAccount.ID)
+	suite.NoError(err)
+	suite.NotNil(dbBlock)
+
+	exists, err := suite.db.InboxActivityExists(context.Background(), blockURI.String())
+	suite.NoError(err)
+	suite.True(exists)
+
+	// remove the block, so we can tell whether the second delivery is handled
+	suite.NoError(suite.db.DeleteByID(context.Background(), dbBlock.ID, &gtsmodel.Block{}))
+
+	// the second delivery of the same activity is dropped, so the block doesn't come back
+	post()
+	_, err = suite.db.GetBlock(context.Background(), blockingAccount.ID, blockedAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 // TestPostUnblock verifies that a remote account with a block targeting one of our instance users should be able to undo that block.
 func (suite *InboxPostTestSuite) TestPostUnblock() {
 	blockingAccount := suite.testAccounts["remote_account_1"]
@@ -435,7 +515,7 @@ func (suite *InboxPostTestSuite) TestPostDelete() {
 	suite.ErrorIs(err, db.ErrNoEntries)
 
 	// no statuses from foss satan should be left in the database
-	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, "", false, false)
+	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", "", false, false, "")
 	suite.ErrorIs(err, db.ErrNoEntries)
 	suite.Empty(dbStatuses)
 
@@ -0,0 +1,173 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// OutboxGETHandler swagger:operation GET /users/{username}/outbox s2sOutboxGet
+//
+// Get the public outbox collection for an actor.
+//
+// Note that the response will be an OrderedCollection with a link to the first page as `first`, as shown below, if `page` is `false`.
+//
+// If `page` is `true`, then the response will be a single `OrderedCollectionPage` without the wrapping `OrderedCollection`.
+//
+// HTTP signature is required on the request.
+//
+// ---
+// tags:
+// - s2s/federation
+//
+// produces:
+// - application/activity+json
+//
+// parameters:
+// - name: username
+//   type: string
+//   description: Username of the account.
+//   in: path
+//   required: true
+// - name: page
+//   type: boolean
+//   description: Return response as a OrderedCollectionPage.
+//   in: query
+//   default: false
+// - name: max_id
+//   type: string
+//   description: Return only activities *OLDER* than the given max ID. The activity with the specified ID will not be included in the response.
+//   in: query
+// - name: min_id
+//   type: string
+//   description: Return only activities *NEWER* than the given min ID. The activity with the specified ID will not be included in the response.
+//   in: query
+//
+// responses:
+//   '200':
+//      in: body
+//      schema:
+//        "$ref": "#/definitions/swaggerOutboxCollection"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '404':
+//      description: not found
+func (m *Module) OutboxGETHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func": "OutboxGETHandler",
+		"url":  c.Request.RequestURI,
+	})
+
+	requestedUsername := c.Param(UsernameKey)
+	if requestedUsername == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no username specified in request"})
+		return
+	}
+
+	page := false
+	pageString := c.Query(PageKey)
+	if pageString != "" {
+		i, err := strconv.ParseBool(pageString)
+		if err != nil {
+			l.WithError(err).Debug("error parsing page string")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse page query param"})
+			return
+		}
+		page = i
+	}
+
+	maxID := c.Query(MaxIDKey)
+	minID := c.Query(MinIDKey)
+
+	format, err := negotiateFormat(c)
+	if err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": fmt.Sprintf("could not negotiate format with given Accept header(s): %s", err)})
+		return
+	}
+	l.WithField("format", format).Trace("negotiated format")
+
+	ctx := transferContext(c)
+
+	outbox, errWithCode := m.processor.GetFediOutbox(ctx, requestedUsername, page, maxID, minID, c.Request.URL)
+	if errWithCode != nil {
+		l.Info(errWithCode.Error())
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	b, mErr := json.Marshal(outbox)
+	if mErr != nil {
+		err := fmt.Errorf("could not marshal json: %s", mErr)
+		l.WithError(mErr).Error("could not marshal json")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, format, b)
+}
+
+// SwaggerOutboxCollection represents a response to GET /users/{username}/outbox.
+// swagger:model swaggerOutboxCollection
+type SwaggerOutboxCollection struct {
+	// ActivityStreams context.
+	// example: https://www.w3.org/ns/activitystreams
+	Context string `json:"@context"`
+	// ActivityStreams ID.
+	// example: https://example.org/users/some_user/outbox
+	ID string `json:"id"`
+	// ActivityStreams type.
+	// example: OrderedCollection
+	Type string `json:"type"`
+	// Link to the first page of the collection.
+	// example: https://example.org/users/some_user/outbox?page=true
+	First string `json:"first"`
+}
+
+// SwaggerOutboxCollectionPage represents one page of an outbox collection.
+// swagger:model swaggerOutboxCollectionPage
+type SwaggerOutboxCollectionPage struct {
+	// ActivityStreams ID.
+	// example: https://example.org/users/some_user/outbox?page=true
+	ID string `json:"id"`
+	// ActivityStreams type.
+	// example: OrderedCollectionPage
+	Type string `json:"type"`
+	// Link to the next (older) page.
+	// example: https://example.org/users/some_user/outbox?max_id=01FJ1S8DX3STJJ6CEYPMZ1M0R3&page=true
+	Next string `json:"next"`
+	// Link to the previous (newer) page.
+	// example: https://example.org/users/some_user/outbox?min_id=01FJ1S8DX3STJJ6CEYPMZ1M0R3&page=true
+	Prev string `json:"prev"`
+	// Collection this page belongs to.
+	// example: https://example.org/users/some_user/outbox
+	PartOf string `json:"partOf"`
+	// Create or Announce activities on this page.
+	OrderedItems []interface{} `json:"orderedItems"`
+}
@@ -33,7 +33,7 @@ import (
 // in the form of a vocab.ActivityStreamsPerson. The account will only contain the id,
 // public key, username, and type of the account.
 func (m *Module) PublicKeyGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "PublicKeyGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -49,13 +49,14 @@ func (m *Module) PublicKeyGETHandler(c *gin.Context) {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": fmt.Sprintf("could not negotiate format with given Accept header(s): %s", err)})
 		return
 	}
-	l.Tracef("negotiated format: %s", format)
+	l.WithField("format", format).Trace("negotiated format")
 
 	ctx := transferContext(c)
 
 	user, errWithCode := m.processor.GetFediUser(ctx, requestedUsername, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -63,7 +64,7 @@ func (m *Module) PublicKeyGETHandler(c *gin.Context) {
 	b, mErr := json.Marshal(user)
 	if mErr != nil {
 		err := fmt.Errorf("could not marshal json: %s", mErr)
-		l.Error(err)
+		l.WithError(mErr).Error("could not marshal json")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
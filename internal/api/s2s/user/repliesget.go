@@ -85,7 +85,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusRepliesGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "StatusRepliesGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -107,7 +107,7 @@ func (m *Module) StatusRepliesGETHandler(c *gin.Context) {
 	if pageString != "" {
 		i, err := strconv.ParseBool(pageString)
 		if err != nil {
-			l.Debugf("error parsing page string: %s", err)
+			l.WithError(err).Debug("error parsing page string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse page query param"})
 			return
 		}
@@ -119,7 +119,7 @@ func (m *Module) StatusRepliesGETHandler(c *gin.Context) {
 	if onlyOtherAccountsString != "" {
 		i, err := strconv.ParseBool(onlyOtherAccountsString)
 		if err != nil {
-			l.Debugf("error parsing only_other_accounts string: %s", err)
+			l.WithError(err).Debug("error parsing only_other_accounts string")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse only_other_accounts query param"})
 			return
 		}
@@ -137,13 +137,14 @@ func (m *Module) StatusRepliesGETHandler(c *gin.Context) {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": fmt.Sprintf("could not negotiate format with given Accept header(s): %s", err)})
 		return
 	}
-	l.Tracef("negotiated format: %s", format)
+	l.WithField("format", format).Trace("negotiated format")
 
 	ctx := transferContext(c)
 
 	replies, errWithCode := m.processor.GetFediStatusReplies(ctx, requestedUsername, requestedStatusID, page, onlyOtherAccounts, minID, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -151,7 +152,7 @@ func (m *Module) StatusRepliesGETHandler(c *gin.Context) {
 	b, mErr := json.Marshal(replies)
 	if mErr != nil {
 		err := fmt.Errorf("could not marshal json: %s", mErr)
-		l.Error(err)
+		l.WithError(mErr).Error("could not marshal json")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
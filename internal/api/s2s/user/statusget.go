@@ -29,7 +29,7 @@ import (
 
 // StatusGETHandler serves the target status as an activitystreams NOTE so that other AP servers can parse it.
 func (m *Module) StatusGETHandler(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "StatusGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -51,13 +51,14 @@ func (m *Module) StatusGETHandler(c *gin.Context) {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": fmt.Sprintf("could not negotiate format with given Accept header(s): %s", err)})
 		return
 	}
-	l.Tracef("negotiated format: %s", format)
+	l.WithField("format", format).Trace("negotiated format")
 
 	ctx := transferContext(c)
 
 	status, errWithCode := m.processor.GetFediStatus(ctx, requestedUsername, requestedStatusID, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
+		c.Error(errWithCode)
 		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
 		return
 	}
@@ -65,7 +66,7 @@ func (m *Module) StatusGETHandler(c *gin.Context) {
 	b, mErr := json.Marshal(status)
 	if mErr != nil {
 		err := fmt.Errorf("could not marshal json: %s", mErr)
-		l.Error(err)
+		l.WithError(mErr).Error("could not marshal json")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
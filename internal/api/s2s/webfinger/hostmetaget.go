@@ -0,0 +1,55 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webfinger
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// HostMetaGETRequest handles requests to, for example, https://example.org/.well-known/host-meta
+//
+// Remote servers that only know the account domain of one of our accounts will look here to find out
+// where our webfinger endpoint lives, which matters when the account domain differs from the host.
+func (m *Module) HostMetaGETRequest(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":       "HostMetaGETRequest",
+		"user-agent": c.Request.UserAgent(),
+	})
+
+	hostMeta, errWithCode := m.processor.GetHostMeta(c.Request.Context())
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("aborting request with an error")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	b, err := xml.Marshal(hostMeta)
+	if err != nil {
+		l.WithError(err).Error("error marshalling host-meta")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xrd+xml; charset=utf-8", append([]byte(xml.Header), b...))
+}
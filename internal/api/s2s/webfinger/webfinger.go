@@ -31,6 +31,8 @@ import (
 const (
 	// WebfingerBasePath is the base path for serving webfinger lookup requests
 	WebfingerBasePath = ".well-known/webfinger"
+	// HostMetaPath is the path for serving the host-meta document, which tells remote servers where to find our webfinger endpoint
+	HostMetaPath = ".well-known/host-meta"
 )
 
 // Module implements the FederationModule interface
@@ -52,5 +54,6 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 // Route satisfies the FederationModule interface
 func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, WebfingerBasePath, m.WebfingerGETRequest)
+	s.AttachHandler(http.MethodGet, HostMetaPath, m.HostMetaGETRequest)
 	return nil
 }
@@ -20,8 +20,10 @@ package webfinger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -31,7 +33,7 @@ import (
 
 // WebfingerGETRequest handles requests to, for example, https://example.org/.well-known/webfinger?resource=acct:some_user@example.org
 func (m *Module) WebfingerGETRequest(c *gin.Context) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":       "WebfingerGETRequest",
 		"user-agent": c.Request.UserAgent(),
 	})
@@ -43,48 +45,81 @@ func (m *Module) WebfingerGETRequest(c *gin.Context) {
 		return
 	}
 
+	username, err := m.parseResource(q)
+	if err != nil {
+		l.WithError(err).WithField("resource", q).Debug("aborting request because resource could not be parsed")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// transfer the signature verifier from the gin context to the request context
+	ctx := c.Request.Context()
+	verifier, signed := c.Get(string(util.APRequestingPublicKeyVerifier))
+	if signed {
+		ctx = context.WithValue(ctx, util.APRequestingPublicKeyVerifier, verifier)
+	}
+
+	resp, errWithCode := m.processor.GetWebfingerAccount(ctx, username)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("aborting request with an error")
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseResource returns the username of the local account that the given webfinger resource refers to.
+//
+// The resource can be given either as an acct, like acct:some_user@example.org or some_user@example.org,
+// or as the URI or web URL of the account, like https://example.org/users/some_user or https://example.org/@some_user.
+//
+// Accts are accepted for both our account domain and our host, since remote servers that reached us
+// through the host rather than the account domain will ask for the latter.
+func (m *Module) parseResource(resource string) (string, error) {
+	if strings.HasPrefix(resource, "https://") || strings.HasPrefix(resource, "http://") {
+		resourceURL, err := url.Parse(resource)
+		if err != nil {
+			return "", fmt.Errorf("resource %s could not be parsed as a url", resource)
+		}
+
+		if !strings.EqualFold(resourceURL.Host, m.config.Host) {
+			return "", fmt.Errorf("host %s does not belong to this instance", resourceURL.Host)
+		}
+
+		if util.IsUserPath(resourceURL) {
+			return util.ParseUserPath(resourceURL)
+		}
+
+		// web url of the account, eg /@some_user
+		username := strings.TrimPrefix(resourceURL.Path, "/@")
+		if username == resourceURL.Path || username == "" || strings.Contains(username, "/") {
+			return "", fmt.Errorf("resource %s does not point to an account", resource)
+		}
+		return strings.ToLower(username), nil
+	}
+
 	// remove the acct: prefix if it's present
-	trimAcct := strings.TrimPrefix(q, "acct:")
+	trimAcct := strings.TrimPrefix(resource, "acct:")
 	// remove the first @ in @whatever@example.org if it's present
 	namestring := strings.TrimPrefix(trimAcct, "@")
 
 	// at this point we should have a string like some_user@example.org
-	l.Debugf("got finger request for '%s'", namestring)
-
 	usernameAndAccountDomain := strings.Split(namestring, "@")
 	if len(usernameAndAccountDomain) != 2 {
-		l.Debugf("aborting request because username and domain could not be parsed from %s", namestring)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
-		return
+		return "", errors.New("bad request")
 	}
 
 	username := strings.ToLower(usernameAndAccountDomain[0])
 	accountDomain := strings.ToLower(usernameAndAccountDomain[1])
 	if username == "" || accountDomain == "" {
-		l.Debug("aborting request because username or domain was empty")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
-		return
+		return "", errors.New("bad request")
 	}
 
 	if accountDomain != m.config.AccountDomain && accountDomain != m.config.Host {
-		l.Debugf("aborting request because accountDomain %s does not belong to this instance", accountDomain)
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("accountDomain %s does not belong to this instance", accountDomain)})
-		return
+		return "", fmt.Errorf("accountDomain %s does not belong to this instance", accountDomain)
 	}
 
-	// transfer the signature verifier from the gin context to the request context
-	ctx := c.Request.Context()
-	verifier, signed := c.Get(string(util.APRequestingPublicKeyVerifier))
-	if signed {
-		ctx = context.WithValue(ctx, util.APRequestingPublicKeyVerifier, verifier)
-	}
-
-	resp, err := m.processor.GetWebfingerAccount(ctx, username)
-	if err != nil {
-		l.Debugf("aborting request with an error: %s", err.Error())
-		c.JSON(err.Code(), gin.H{"error": err.Safe()})
-		return
-	}
-
-	c.JSON(http.StatusOK, resp)
+	return username, nil
 }
@@ -65,7 +65,7 @@ func (suite *WebfingerGetTestSuite) TestFingerUser() {
 func (suite *WebfingerGetTestSuite) TestFingerUserWithDifferentAccountDomainByHost() {
 	suite.config.Host = "gts.example.org"
 	suite.config.AccountDomain = "example.org"
-	suite.processor = processing.NewProcessor(suite.config, suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaHandler(suite.db, suite.storage), suite.storage, testrig.NewTestTimelineManager(suite.db), suite.db, suite.log)
+	suite.processor = processing.NewProcessor(suite.config, suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaHandler(suite.db, suite.storage), suite.storage, testrig.NewTestTimelineManager(suite.db), suite.db, testrig.NewTestFieldVerifier(), testrig.NewTestWebPushSender(suite.db), suite.log)
 	suite.webfingerModule = webfinger.New(suite.config, suite.processor, suite.log).(*webfinger.Module)
 
 	targetAccount := accountDomainAccount()
@@ -97,7 +97,7 @@ func (suite *WebfingerGetTestSuite) TestFingerUserWithDifferentAccountDomainByHo
 func (suite *WebfingerGetTestSuite) TestFingerUserWithDifferentAccountDomainByAccountDomain() {
 	suite.config.Host = "gts.example.org"
 	suite.config.AccountDomain = "example.org"
-	suite.processor = processing.NewProcessor(suite.config, suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaHandler(suite.db, suite.storage), suite.storage, testrig.NewTestTimelineManager(suite.db), suite.db, suite.log)
+	suite.processor = processing.NewProcessor(suite.config, suite.tc, suite.federator, testrig.NewTestOauthServer(suite.db), testrig.NewTestMediaHandler(suite.db, suite.storage), suite.storage, testrig.NewTestTimelineManager(suite.db), suite.db, testrig.NewTestFieldVerifier(), testrig.NewTestWebPushSender(suite.db), suite.log)
 	suite.webfingerModule = webfinger.New(suite.config, suite.processor, suite.log).(*webfinger.Module)
 
 	targetAccount := accountDomainAccount()
@@ -150,6 +150,47 @@ func (suite *WebfingerGetTestSuite) TestFingerUserWithoutAcct() {
 	suite.Equal(`{"subject":"acct:the_mighty_zork@localhost:8080","aliases":["http://localhost:8080/users/the_mighty_zork","http://localhost:8080/@the_mighty_zork"],"links":[{"rel":"http://webfinger.net/rel/profile-page","type":"text/html","href":"http://localhost:8080/@the_mighty_zork"},{"rel":"self","type":"application/activity+json","href":"http://localhost:8080/users/the_mighty_zork"}]}`, string(b))
 }
 
+func (suite *WebfingerGetTestSuite) TestFingerUserByURI() {
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	for _, resource := range []string{targetAccount.URI, targetAccount.URL} {
+		// setup request
+		requestPath := fmt.Sprintf("/%s?resource=%s", webfinger.WebfingerBasePath, resource)
+
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodGet, requestPath, nil) // the endpoint we're hitting
+
+		// trigger the function being tested
+		suite.webfingerModule.WebfingerGETRequest(ctx)
+
+		// check response
+		suite.EqualValues(http.StatusOK, recorder.Code)
+
+		result := recorder.Result()
+		defer result.Body.Close()
+		b, err := ioutil.ReadAll(result.Body)
+		assert.NoError(suite.T(), err)
+
+		suite.Equal(`{"subject":"acct:the_mighty_zork@localhost:8080","aliases":["http://localhost:8080/users/the_mighty_zork","http://localhost:8080/@the_mighty_zork"],"links":[{"rel":"http://webfinger.net/rel/profile-page","type":"text/html","href":"http://localhost:8080/@the_mighty_zork"},{"rel":"self","type":"application/activity+json","href":"http://localhost:8080/users/the_mighty_zork"}]}`, string(b))
+	}
+}
+
+func (suite *WebfingerGetTestSuite) TestFingerUserByURIOnOtherHost() {
+	// setup request
+	requestPath := fmt.Sprintf("/%s?resource=%s", webfinger.WebfingerBasePath, "https://fossbros-anonymous.io/users/foss_satan")
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, requestPath, nil) // the endpoint we're hitting
+
+	// trigger the function being tested
+	suite.webfingerModule.WebfingerGETRequest(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+}
+
 func TestWebfingerGetTestSuite(t *testing.T) {
 	suite.Run(t, new(WebfingerGetTestSuite))
 }
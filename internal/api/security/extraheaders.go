@@ -5,4 +5,9 @@ import "github.com/gin-gonic/gin"
 // ExtraHeaders adds any additional required headers to the response
 func (m *Module) ExtraHeaders(c *gin.Context) {
 	c.Header("Server", "gotosocial")
+
+	// let Tor Browser users know they can use our onion address instead
+	if onionHost := m.config.HiddenServicesConfig.OnionHost; onionHost != "" {
+		c.Header("Onion-Location", "http://"+onionHost+c.Request.URL.RequestURI())
+	}
 }
@@ -0,0 +1,266 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+)
+
+// timeoutBody is the response sent when a request takes too long to handle.
+var timeoutBody = []byte(`{"error":"request timed out"}`)
+
+// requestLimits returns how long the given request may take to handle, and how big its body may be.
+// Multipart form uploads like media attachments and avatars, and activitypub inbox POSTs, have
+// their own limits; everything else gets the general ones. A value of 0 means no limit.
+func (m *Module) requestLimits(c *gin.Context) (time.Duration, int64) {
+	limits := m.config.RequestLimitsConfig
+
+	switch {
+	case c.FullPath() == streaming.BasePath:
+		// streaming connections are meant to stay open
+		return 0, int64(limits.BodySize)
+	case c.Request.Method == http.MethodPost && c.FullPath() == user.UsersInboxPath:
+		return time.Duration(limits.InboxTimeout) * time.Second, int64(limits.InboxBodySize)
+	case c.ContentType() == "multipart/form-data":
+		return time.Duration(limits.MediaTimeout) * time.Second, int64(limits.MediaBodySize)
+	default:
+		return time.Duration(limits.Timeout) * time.Second, int64(limits.BodySize)
+	}
+}
+
+// RequestLimits caps how big request bodies can be and how long requests can take to handle.
+//
+// Requests that say up front that their body is too big get a 413 straight away; bodies that turn out to
+// be too big while they're being read are cut off, so the handler sees an error. Requests that aren't
+// handled in time get a 503, and their context is cancelled so that the handler can give up on them.
+func (m *Module) RequestLimits(c *gin.Context) {
+	timeout, bodySize := m.requestLimits(c)
+
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":     "RequestLimits",
+		"clientIP": c.ClientIP(),
+		"path":     c.FullPath(),
+	})
+
+	if bodySize > 0 && c.Request.Body != nil {
+		if c.Request.ContentLength > bodySize {
+			l.Debugf("aborting request because body of %d bytes is over the limit of %d", c.Request.ContentLength, bodySize)
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("request body too large, max size is %d bytes", bodySize)})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, bodySize)
+	}
+
+	if timeout <= 0 {
+		c.Next()
+		return
+	}
+
+	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithDeadline(c.Request.Context(), deadline)
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
+
+	original := c.Writer
+	tw := newTimeoutWriter(original, deadline)
+	c.Writer = tw
+
+	timer := time.AfterFunc(timeout, func() {
+		if tw.timeout() {
+			l.Warnf("request not handled within %s", timeout)
+		}
+	})
+
+	c.Next()
+
+	timer.Stop()
+	tw.finish()
+	c.Writer = original
+}
+
+// timeoutWriter sits between a handler and the real response writer, so that a timeout
+// response can be sent while the handler is still running, as long as the handler hasn't
+// started writing its own response yet. Anything the handler writes after that is silently dropped.
+//
+// Handlers that give up as soon as their context is done may try to respond at the same
+// moment that the timeout response is due, so whichever of them comes first checks the
+// deadline, rather than relying on the timer to go off before the handler writes.
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	deadline time.Time
+	header   http.Header
+	status   int
+	wrote    bool // whether the handler has started writing the response
+	timedOut bool // whether the timeout response has been written
+	done     bool // whether the handler has returned
+}
+
+func newTimeoutWriter(w gin.ResponseWriter, deadline time.Time) *timeoutWriter {
+	return &timeoutWriter{
+		ResponseWriter: w,
+		deadline:       deadline,
+		header:         w.Header().Clone(),
+	}
+}
+
+// timeout writes the timeout response, if the handler is still running and hasn't started its own response.
+// It returns true if the response was written.
+func (tw *timeoutWriter) timeout() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.done || tw.wrote || tw.timedOut {
+		return false
+	}
+	tw.writeTimeout()
+	return true
+}
+
+// writeTimeout writes the timeout response. Callers must hold the lock.
+func (tw *timeoutWriter) writeTimeout() {
+	tw.timedOut = true
+
+	tw.ResponseWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	tw.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = tw.ResponseWriter.Write(timeoutBody)
+}
+
+// finish passes on any headers and status that the handler set without writing anything, and stops the timeout
+// response from being written from now on. It should be called once the handler has returned.
+func (tw *timeoutWriter) finish() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.done = true
+	if tw.timedOut || tw.wrote {
+		return
+	}
+	if !time.Now().Before(tw.deadline) {
+		tw.writeTimeout()
+		return
+	}
+	tw.passHeader()
+}
+
+// passHeader copies the headers and status set by the handler to the real response writer. Callers must hold the lock.
+func (tw *timeoutWriter) passHeader() {
+	dst := tw.ResponseWriter.Header()
+	for k := range dst {
+		if _, ok := tw.header[k]; !ok {
+			dst.Del(k)
+		}
+	}
+	for k, v := range tw.header {
+		dst[k] = v
+	}
+
+	if tw.status != 0 {
+		tw.ResponseWriter.WriteHeader(tw.status)
+	}
+}
+
+// start is called before the handler writes any part of the response. It returns false if
+// the response has timed out, and the handler's writes should be dropped. Callers must hold the lock.
+func (tw *timeoutWriter) start() bool {
+	if tw.timedOut {
+		return false
+	}
+	if !tw.wrote && !time.Now().Before(tw.deadline) {
+		tw.writeTimeout()
+		return false
+	}
+	if !tw.wrote {
+		tw.passHeader()
+		tw.wrote = true
+	}
+	return true
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if !tw.wrote && !tw.timedOut {
+		tw.status = code
+	}
+}
+
+func (tw *timeoutWriter) WriteHeaderNow() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.start() {
+		tw.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if !tw.start() {
+		// gin panics on write errors, so pretend the write worked
+		return len(b), nil
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+func (tw *timeoutWriter) WriteString(s string) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if !tw.start() {
+		return len(s), nil
+	}
+	return tw.ResponseWriter.WriteString(s)
+}
+
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.start() {
+		tw.ResponseWriter.Flush()
+	}
+}
+
+func (tw *timeoutWriter) Status() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if !tw.wrote && !tw.timedOut && tw.status != 0 {
+		return tw.status
+	}
+	return tw.ResponseWriter.Status()
+}
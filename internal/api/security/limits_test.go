@@ -0,0 +1,222 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/internal/api/security"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RequestLimitsTestSuite struct {
+	suite.Suite
+	config *config.Config
+}
+
+func (suite *RequestLimitsTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+}
+
+func (suite *RequestLimitsTestSuite) engine(handler gin.HandlerFunc) *gin.Engine {
+	module := security.New(suite.config, nil, testrig.NewTestLog()).(*security.Module)
+	engine := gin.New()
+	engine.Use(module.RequestLimits)
+	engine.POST(media.BasePath, handler)
+	engine.POST(user.UsersInboxPath, handler)
+	engine.GET(streaming.BasePath, handler)
+	engine.POST("/api/v1/statuses", handler)
+	return engine
+}
+
+func (suite *RequestLimitsTestSuite) do(engine *gin.Engine, request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// readBody is a handler that reads the whole request body, and echoes it back if that works.
+func readBody(c *gin.Context) {
+	b, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.String(http.StatusOK, string(b))
+}
+
+func (suite *RequestLimitsTestSuite) TestBodySize() {
+	suite.config.RequestLimitsConfig.BodySize = 10
+	engine := suite.engine(readBody)
+
+	recorder := suite.do(engine, httptest.NewRequest(http.MethodPost, "/api/v1/statuses", strings.NewReader("short")))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("short", recorder.Body.String())
+
+	recorder = suite.do(engine, httptest.NewRequest(http.MethodPost, "/api/v1/statuses", strings.NewReader("much too long")))
+	suite.Equal(http.StatusRequestEntityTooLarge, recorder.Code)
+	suite.Equal(`{"error":"request body too large, max size is 10 bytes"}`, recorder.Body.String())
+}
+
+func (suite *RequestLimitsTestSuite) TestBodySizeUndeclared() {
+	suite.config.RequestLimitsConfig.BodySize = 10
+	engine := suite.engine(readBody)
+
+	// a body without a content length can't be read past the limit either
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/statuses", io.MultiReader(strings.NewReader("much too "), strings.NewReader("long")))
+	request.ContentLength = -1
+	recorder := suite.do(engine, request)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"http: request body too large"}`, recorder.Body.String())
+}
+
+func (suite *RequestLimitsTestSuite) TestBodySizeByKind() {
+	suite.config.RequestLimitsConfig.BodySize = 10
+	suite.config.RequestLimitsConfig.MediaBodySize = 100
+	suite.config.RequestLimitsConfig.InboxBodySize = 20
+	engine := suite.engine(readBody)
+	body := bytes.Repeat([]byte("a"), 50)
+
+	// multipart uploads get the media limit, wherever they're sent
+	request := httptest.NewRequest(http.MethodPost, media.BasePath, bytes.NewReader(body))
+	request.Header.Set("Content-Type", "multipart/form-data; boundary=whatever")
+	suite.Equal(http.StatusOK, suite.do(engine, request).Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/api/v1/statuses", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "multipart/form-data; boundary=whatever")
+	suite.Equal(http.StatusOK, suite.do(engine, request).Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/api/v1/statuses", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	suite.Equal(http.StatusRequestEntityTooLarge, suite.do(engine, request).Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/users/the_mighty_zork/inbox", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/activity+json")
+	suite.Equal(http.StatusRequestEntityTooLarge, suite.do(engine, request).Code)
+
+	request = httptest.NewRequest(http.MethodPost, "/users/the_mighty_zork/inbox", bytes.NewReader(body[:15]))
+	request.Header.Set("Content-Type", "application/activity+json")
+	suite.Equal(http.StatusOK, suite.do(engine, request).Code)
+}
+
+func (suite *RequestLimitsTestSuite) TestBodySizeNoLimit() {
+	suite.config.RequestLimitsConfig.BodySize = 0
+	engine := suite.engine(readBody)
+
+	recorder := suite.do(engine, httptest.NewRequest(http.MethodPost, "/api/v1/statuses", bytes.NewReader(bytes.Repeat([]byte("a"), 2<<20))))
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *RequestLimitsTestSuite) TestTimeout() {
+	suite.config.RequestLimitsConfig.Timeout = 1
+
+	handlerDone := make(chan struct{})
+	engine := suite.engine(func(c *gin.Context) {
+		defer close(handlerDone)
+
+		// wait for the request to be cancelled, then try to respond anyway
+		<-c.Request.Context().Done()
+		c.Header("X-Too-Late", "true")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "context cancelled"})
+	})
+
+	start := time.Now()
+	recorder := suite.do(engine, httptest.NewRequest(http.MethodPost, "/api/v1/statuses", nil))
+	<-handlerDone
+	suite.WithinDuration(start.Add(time.Second), time.Now(), 500*time.Millisecond)
+
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.Empty(recorder.Header().Get("X-Too-Late"))
+	suite.Equal(`{"error":"request timed out"}`, recorder.Body.String())
+}
+
+func (suite *RequestLimitsTestSuite) TestNoTimeout() {
+	suite.config.RequestLimitsConfig.Timeout = 1
+	engine := suite.engine(func(c *gin.Context) {
+		c.Header("X-Custom", "yep")
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	recorder := suite.do(engine, httptest.NewRequest(http.MethodPost, "/api/v1/statuses", nil))
+	suite.Equal(http.StatusCreated, recorder.Code)
+	suite.Equal("yep", recorder.Header().Get("X-Custom"))
+	suite.Equal(`{"ok":true}`, recorder.Body.String())
+
+	// a status without a body should still come through
+	engine = suite.engine(func(c *gin.Context) {
+		c.Status(http.StatusAccepted)
+	})
+	recorder = suite.do(engine, httptest.NewRequest(http.MethodPost, "/api/v1/statuses", nil))
+	suite.Equal(http.StatusAccepted, recorder.Code)
+	suite.Empty(recorder.Body.String())
+}
+
+func (suite *RequestLimitsTestSuite) TestTimeoutStreaming() {
+	// streaming connections are never timed out
+	suite.config.RequestLimitsConfig.Timeout = 1
+	engine := suite.engine(func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		suite.False(hasDeadline)
+		c.Status(http.StatusOK)
+	})
+
+	recorder := suite.do(engine, httptest.NewRequest(http.MethodGet, streaming.BasePath, nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *RequestLimitsTestSuite) TestTimeoutByKind() {
+	suite.config.RequestLimitsConfig.Timeout = 1
+	suite.config.RequestLimitsConfig.MediaTimeout = 120
+	suite.config.RequestLimitsConfig.InboxTimeout = 30
+
+	var deadline time.Time
+	engine := suite.engine(func(c *gin.Context) {
+		deadline, _ = c.Request.Context().Deadline()
+		c.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodPost, media.BasePath, nil)
+	request.Header.Set("Content-Type", "multipart/form-data; boundary=whatever")
+	suite.do(engine, request)
+	suite.WithinDuration(time.Now().Add(120*time.Second), deadline, time.Second)
+
+	suite.do(engine, httptest.NewRequest(http.MethodPost, "/users/the_mighty_zork/inbox", nil))
+	suite.WithinDuration(time.Now().Add(30*time.Second), deadline, time.Second)
+
+	suite.do(engine, httptest.NewRequest(http.MethodPost, "/api/v1/statuses", nil))
+	suite.WithinDuration(time.Now().Add(time.Second), deadline, time.Second)
+}
+
+func TestRequestLimitsTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestLimitsTestSuite))
+}
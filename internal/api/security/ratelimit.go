@@ -0,0 +1,150 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/throttling"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// rateLimitWindow is the length of the fixed windows that requests are counted in.
+const rateLimitWindow = 5 * time.Minute
+
+// RateLimitModule implements the ClientAPIModule interface for rate limiting middleware. It's separate from
+// the other security middleware because it has to run after oauth tokens are checked, to count requests per token.
+type RateLimitModule struct {
+	log    *logrus.Logger
+	api    *throttling.Limiter
+	inbox  *throttling.Limiter
+	auth   *throttling.Limiter
+	exempt []*net.IPNet
+}
+
+// NewRateLimit returns a new rate limiting module
+func NewRateLimit(config *config.Config, log *logrus.Logger) api.ClientModule {
+	c := config.RateLimitConfig
+
+	exempt := []*net.IPNet{}
+	for _, e := range c.ExemptIPs {
+		// plain ip addresses are treated as a range of just that address
+		if ip := net.ParseIP(e); ip != nil {
+			if ip.To4() != nil {
+				e = e + "/32"
+			} else {
+				e = e + "/128"
+			}
+		}
+		if _, ipNet, err := net.ParseCIDR(e); err == nil {
+			exempt = append(exempt, ipNet)
+		}
+	}
+
+	return &RateLimitModule{
+		log:    log,
+		api:    throttling.NewLimiter(c.APIRequests, rateLimitWindow),
+		inbox:  throttling.NewLimiter(c.InboxRequests, rateLimitWindow),
+		auth:   throttling.NewLimiter(c.AuthRequests, rateLimitWindow),
+		exempt: exempt,
+	}
+}
+
+// Route attaches rate limiting middleware to the given router
+func (m *RateLimitModule) Route(s router.Router) error {
+	s.AttachMiddleware(m.RateLimit)
+	return nil
+}
+
+// RateLimit counts requests against the budget for their kind: client API requests per oauth token, or per
+// ip address without one; activitypub inbox POSTs per ip address; and sign in, sign up, password reset and token
+// requests per ip address. Every counted response gets X-RateLimit headers, and requests over budget get a 429.
+//
+// Requests from exempt ip addresses, and requests made with the token of an admin, aren't counted.
+func (m *RateLimitModule) RateLimit(c *gin.Context) {
+	limiter, key := m.budget(c)
+	if limiter == nil || limiter.Limit() <= 0 {
+		return
+	}
+
+	allowed, remaining, reset := limiter.Take(key)
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limiter.Limit()))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("X-RateLimit-Reset", reset.UTC().Format(time.RFC3339))
+
+	if !allowed {
+		m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+			"func":     "RateLimit",
+			"clientIP": c.ClientIP(),
+			"path":     c.FullPath(),
+		}).Debug("aborting request because it's over the rate limit")
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(reset).Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, try again later"})
+		return
+	}
+}
+
+// budget returns the limiter that the request counts against, and the key to count it under,
+// or a nil limiter if the request isn't rate limited.
+func (m *RateLimitModule) budget(c *gin.Context) (*throttling.Limiter, string) {
+	ip := c.ClientIP()
+	if parsed := net.ParseIP(ip); parsed != nil {
+		for _, e := range m.exempt {
+			if e.Contains(parsed) {
+				return nil, ""
+			}
+		}
+	}
+
+	if c.Request.Method == http.MethodPost {
+		switch c.FullPath() {
+		case user.UsersInboxPath:
+			return m.inbox, ip
+		case auth.AuthSignInPath, auth.OauthTokenPath, account.BasePath, "/" + util.ForgotPasswordPath, "/" + util.ResetPasswordPath:
+			return m.auth, ip
+		}
+	}
+
+	if !strings.HasPrefix(c.Request.URL.Path, "/api/") {
+		return nil, ""
+	}
+
+	authed, err := oauth.Authed(c, false, false, false, false)
+	if err != nil || authed.Token == nil {
+		return m.api, "ip " + ip
+	}
+	if authed.User != nil && authed.User.Admin {
+		return nil, ""
+	}
+	return m.api, "token " + authed.Token.GetAccess()
+}
@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/internal/api/security"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"github.com/superseriousbusiness/oauth2/v4/models"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+	config *config.Config
+}
+
+func (suite *RateLimitTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.config.RateLimitConfig.APIRequests = 2
+	suite.config.RateLimitConfig.InboxRequests = 2
+	suite.config.RateLimitConfig.AuthRequests = 1
+}
+
+// engine returns an engine with the rate limit middleware, which pretends that requests with
+// an Authorization header were made with that token, by the user given in the X-Test-User header.
+func (suite *RateLimitTestSuite) engine() *gin.Engine {
+	module := security.NewRateLimit(suite.config, testrig.NewTestLog()).(*security.RateLimitModule)
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if token := c.GetHeader("Authorization"); token != "" {
+			c.Set(oauth.SessionAuthorizedToken, &models.Token{Access: token})
+			c.Set(oauth.SessionAuthorizedUser, &gtsmodel.User{Admin: c.GetHeader("X-Test-User") == "admin"})
+		}
+	})
+	engine.Use(module.RateLimit)
+	engine.GET("/api/v1/timelines/home", handler)
+	engine.POST(user.UsersInboxPath, handler)
+	engine.POST(auth.AuthSignInPath, handler)
+	engine.POST("/"+util.ForgotPasswordPath, handler)
+	engine.GET("/users/:username", handler)
+	return engine
+}
+
+func (suite *RateLimitTestSuite) do(engine *gin.Engine, method string, path string, token string, remoteAddr string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		request.Header.Set("Authorization", token)
+	}
+	if token == "admin-token" {
+		request.Header.Set("X-Test-User", "admin")
+	}
+	if remoteAddr != "" {
+		request.RemoteAddr = remoteAddr
+	}
+	engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (suite *RateLimitTestSuite) TestAPIPerToken() {
+	engine := suite.engine()
+
+	recorder := suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "token-1", "")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("2", recorder.Header().Get("X-RateLimit-Limit"))
+	suite.Equal("1", recorder.Header().Get("X-RateLimit-Remaining"))
+	suite.NotEmpty(recorder.Header().Get("X-RateLimit-Reset"))
+
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "token-1", "").Code)
+
+	recorder = suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "token-1", "")
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Equal("0", recorder.Header().Get("X-RateLimit-Remaining"))
+	suite.NotEmpty(recorder.Header().Get("Retry-After"))
+
+	// another token from the same ip address has its own budget, and so do requests without a token
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "token-2", "").Code)
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "", "").Code)
+
+	// non-api routes aren't counted
+	recorder = suite.do(engine, http.MethodGet, "/users/the_mighty_zork", "token-1", "")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("X-RateLimit-Limit"))
+}
+
+func (suite *RateLimitTestSuite) TestInboxAndAuthBudgets() {
+	engine := suite.engine()
+
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "", "").Code)
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodPost, "/users/1happyturtle/inbox", "", "").Code)
+	suite.Equal(http.StatusTooManyRequests, suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "", "").Code)
+
+	// sign ins have their own, separate, budget
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodPost, auth.AuthSignInPath, "", "").Code)
+	suite.Equal(http.StatusTooManyRequests, suite.do(engine, http.MethodPost, auth.AuthSignInPath, "", "").Code)
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodPost, auth.AuthSignInPath, "", "198.51.100.1:1234").Code)
+
+	// asking for password reset emails comes out of the same budget, so it can't be used to flood someone's inbox
+	suite.Equal(http.StatusTooManyRequests, suite.do(engine, http.MethodPost, "/"+util.ForgotPasswordPath, "", "198.51.100.1:1234").Code)
+}
+
+func (suite *RateLimitTestSuite) TestExemptions() {
+	suite.config.RateLimitConfig.ExemptIPs = []string{"10.0.0.0/8", "198.51.100.1"}
+	engine := suite.engine()
+
+	for i := 0; i < 5; i++ {
+		suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "admin-token", "").Code)
+		suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/timelines/home", "token-1", "10.1.2.3:1234").Code)
+		suite.Equal(http.StatusOK, suite.do(engine, http.MethodPost, "/users/the_mighty_zork/inbox", "", "198.51.100.1:1234").Code)
+	}
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, &RateLimitTestSuite{})
+}
@@ -26,32 +26,37 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/throttling"
 )
 
 const robotsPath = "/robots.txt"
 
 // Module implements the ClientAPIModule interface for security middleware
 type Module struct {
-	config *config.Config
-	log    *logrus.Logger
-	db     db.DB
+	config    *config.Config
+	log       *logrus.Logger
+	db        db.DB
+	throttles map[string]*throttling.Throttle
 }
 
 // New returns a new security module
 func New(config *config.Config, db db.DB, log *logrus.Logger) api.ClientModule {
 	return &Module{
-		config: config,
-		log:    log,
-		db:     db,
+		config:    config,
+		log:       log,
+		db:        db,
+		throttles: newThrottles(config.ThrottlingConfig),
 	}
 }
 
 // Route attaches security middleware to the given router
 func (m *Module) Route(s router.Router) error {
+	s.AttachMiddleware(m.RequestLimits)
 	s.AttachMiddleware(m.SignatureCheck)
 	s.AttachMiddleware(m.FlocBlock)
 	s.AttachMiddleware(m.ExtraHeaders)
 	s.AttachMiddleware(m.UserAgentBlock)
+	s.AttachMiddleware(m.Throttle)
 	s.AttachHandler(http.MethodGet, robotsPath, m.RobotsGETHandler)
 	return nil
 }
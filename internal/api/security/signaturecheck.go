@@ -13,7 +13,7 @@ import (
 // that signed the request is permitted to access the server. If it is permitted, the handler will set the key
 // verifier and the signature in the gin context for use down the line.
 func (m *Module) SignatureCheck(c *gin.Context) {
-	l := m.log.WithField("func", "DomainBlockChecker")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "DomainBlockChecker")
 
 	// create the verifier from the request
 	// if the request is signed, it will have a signature header
@@ -30,12 +30,12 @@ func (m *Module) SignatureCheck(c *gin.Context) {
 			// if the domain is blocked we want to bail as early as possible
 			blocked, err := m.db.IsURIBlocked(c.Request.Context(), requestingPublicKeyID)
 			if err != nil {
-				l.Errorf("could not tell if domain %s was blocked or not: %s", requestingPublicKeyID.Host, err)
+				l.WithError(err).WithField("domain", requestingPublicKeyID.Host).Error("could not tell if domain was blocked or not")
 				c.AbortWithStatus(http.StatusInternalServerError)
 				return
 			}
 			if blocked {
-				l.Infof("domain %s is blocked", requestingPublicKeyID.Host)
+				l.WithField("domain", requestingPublicKeyID.Host).Info("domain is blocked")
 				c.AbortWithStatus(http.StatusForbidden)
 				return
 			}
@@ -0,0 +1,82 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/throttling"
+)
+
+// newThrottles returns throttles for the expensive endpoints, keyed by method and route path.
+// Routes that share a throttle, like v1 and v2 search, share limits too.
+func newThrottles(c *config.ThrottlingConfig) map[string]*throttling.Throttle {
+	searchThrottle := throttling.New(c.SearchConcurrency, c.SearchPerIPPerMinute)
+	mediaThrottle := throttling.New(c.MediaConcurrency, c.MediaPerIPPerMinute)
+	inboxThrottle := throttling.New(c.InboxConcurrency, c.InboxPerIPPerMinute)
+
+	return map[string]*throttling.Throttle{
+		http.MethodGet + " " + search.BasePathV1:    searchThrottle,
+		http.MethodGet + " " + search.BasePathV2:    searchThrottle,
+		http.MethodPost + " " + media.BasePath:      mediaThrottle,
+		http.MethodPost + " " + user.UsersInboxPath: inboxThrottle,
+	}
+}
+
+// Throttle applies per-ip and concurrency limits to expensive endpoints like search, media upload, and
+// the activitypub inbox. Requests over the per-ip limit get a 429, and requests that come in while the
+// endpoint is already handling as many requests as it's allowed to get a 503; both with a Retry-After header.
+func (m *Module) Throttle(c *gin.Context) {
+	t, ok := m.throttles[c.Request.Method+" "+c.FullPath()]
+	if !ok {
+		return
+	}
+
+	l := m.log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":     "Throttle",
+		"clientIP": c.ClientIP(),
+		"path":     c.FullPath(),
+	})
+
+	if allowed, wait := t.Allow(c.ClientIP()); !allowed {
+		l.Debug("aborting request because client ip is over the per-ip limit")
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, try again later"})
+		return
+	}
+
+	acquired, release := t.Acquire()
+	if !acquired {
+		l.Debug("aborting request because too many requests are already being handled")
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "server busy, try again later"})
+		return
+	}
+	defer release()
+
+	c.Next()
+}
@@ -0,0 +1,112 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package security_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
+	"github.com/superseriousbusiness/gotosocial/internal/api/security"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ThrottleTestSuite struct {
+	suite.Suite
+	config *config.Config
+}
+
+func (suite *ThrottleTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+}
+
+func (suite *ThrottleTestSuite) engine(handler gin.HandlerFunc) *gin.Engine {
+	module := security.New(suite.config, nil, testrig.NewTestLog()).(*security.Module)
+	engine := gin.New()
+	engine.Use(module.Throttle)
+	engine.GET(search.BasePathV1, handler)
+	engine.GET(search.BasePathV2, handler)
+	engine.POST(media.BasePath, handler)
+	engine.GET("/api/v1/instance", handler)
+	return engine
+}
+
+func (suite *ThrottleTestSuite) do(engine *gin.Engine, method string, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, nil)
+	engine.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (suite *ThrottleTestSuite) TestPerIPLimit() {
+	suite.config.ThrottlingConfig.SearchPerIPPerMinute = 2
+	engine := suite.engine(func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// v1 and v2 search share the same limit
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, search.BasePathV1).Code)
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, search.BasePathV2).Code)
+
+	recorder := suite.do(engine, http.MethodGet, search.BasePathV1)
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Equal("30", recorder.Header().Get("Retry-After"))
+	suite.Equal(`{"error":"too many requests, try again later"}`, recorder.Body.String())
+
+	// other endpoints aren't affected
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodPost, media.BasePath).Code)
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodGet, "/api/v1/instance").Code)
+}
+
+func (suite *ThrottleTestSuite) TestConcurrencyLimit() {
+	suite.config.ThrottlingConfig.MediaConcurrency = 1
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	engine := suite.engine(func(c *gin.Context) {
+		started <- struct{}{}
+		<-finish
+		c.Status(http.StatusOK)
+	})
+
+	done := make(chan int)
+	go func() {
+		done <- suite.do(engine, http.MethodPost, media.BasePath).Code
+	}()
+	<-started
+
+	// the first upload is still being handled, so this one is turned away
+	recorder := suite.do(engine, http.MethodPost, media.BasePath)
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.Equal("1", recorder.Header().Get("Retry-After"))
+
+	close(finish)
+	suite.Equal(http.StatusOK, <-done)
+
+	// and once it's done, there's room again
+	go func() { <-started }()
+	suite.Equal(http.StatusOK, suite.do(engine, http.MethodPost, media.BasePath).Code)
+}
+
+func TestThrottleTestSuite(t *testing.T) {
+	suite.Run(t, &ThrottleTestSuite{})
+}
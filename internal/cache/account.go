@@ -125,7 +125,9 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		Fields:                  account.Fields,
 		Note:                    account.Note,
 		Memorial:                account.Memorial,
+		EmojiIDs:                account.EmojiIDs,
 		MovedToAccountID:        account.MovedToAccountID,
+		AlsoKnownAsURIs:         account.AlsoKnownAsURIs,
 		CreatedAt:               account.CreatedAt,
 		UpdatedAt:               account.UpdatedAt,
 		Bot:                     account.Bot,
@@ -139,6 +141,7 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		URL:                     account.URL,
 		LastWebfingeredAt:       account.LastWebfingeredAt,
 		InboxURI:                account.InboxURI,
+		SharedInboxURI:          account.SharedInboxURI,
 		OutboxURI:               account.OutboxURI,
 		FollowingURI:            account.FollowingURI,
 		FollowersURI:            account.FollowersURI,
@@ -148,10 +151,15 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		PrivateKey:              account.PrivateKey,
 		PublicKey:               account.PublicKey,
 		PublicKeyURI:            account.PublicKeyURI,
+		Ed25519PrivateKey:       account.Ed25519PrivateKey,
+		Ed25519PublicKeyURI:     account.Ed25519PublicKeyURI,
 		SensitizedAt:            account.SensitizedAt,
 		SilencedAt:              account.SilencedAt,
 		SuspendedAt:             account.SuspendedAt,
 		HideCollections:         account.HideCollections,
 		SuspensionOrigin:        account.SuspensionOrigin,
+		EnableRSS:               account.EnableRSS,
+		PrivateBlocks:           account.PrivateBlocks,
+		CustomCSS:               account.CustomCSS,
 	}
 }
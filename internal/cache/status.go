@@ -126,6 +126,7 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		Emojis:                   nil,
 		CreatedAt:                status.CreatedAt,
 		UpdatedAt:                status.UpdatedAt,
+		EditedAt:                 status.EditedAt,
 		Local:                    status.Local,
 		AccountID:                status.AccountID,
 		Account:                  nil,
@@ -146,8 +147,11 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		CreatedWithApplicationID: status.CreatedWithApplicationID,
 		Federated:                status.Federated,
 		Boostable:                status.Boostable,
+		BoostPolicy:              status.BoostPolicy,
 		Replyable:                status.Replyable,
+		ReplyPolicy:              status.ReplyPolicy,
 		Likeable:                 status.Likeable,
+		LikePolicy:               status.LikePolicy,
 		ActivityStreamsType:      status.ActivityStreamsType,
 		Text:                     status.Text,
 		Pinned:                   status.Pinned,
@@ -0,0 +1,195 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	// ProviderHCaptcha is the provider name for https://www.hcaptcha.com
+	ProviderHCaptcha = "hcaptcha"
+	// ProviderReCaptcha is the provider name for https://www.google.com/recaptcha
+	ProviderReCaptcha = "recaptcha"
+	// ProviderMCaptcha is the provider name for a self-hosted https://mcaptcha.org server
+	ProviderMCaptcha = "mcaptcha"
+
+	hCaptchaVerifyURL  = "https://hcaptcha.com/siteverify"
+	reCaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+	mCaptchaVerifyPath = "/api/v1/pow/siteverify"
+)
+
+// Verifier checks captcha responses submitted by clients with the configured captcha provider.
+type Verifier interface {
+	// Verify returns true if the given captcha response, as produced by the captcha widget on the client,
+	// was accepted by the captcha provider. remoteIP is the ip address of the client that solved the
+	// captcha; it may be empty.
+	//
+	// An error is only returned if the provider couldn't be asked, not if the response was rejected.
+	Verify(ctx context.Context, response string, remoteIP string) (bool, error)
+}
+
+// New returns a new Verifier for the captcha provider set in the given config, which will use
+// the given http client to talk to the provider. If no captcha provider is set, then nil, nil
+// will be returned.
+func New(c *config.Config, client *http.Client) (Verifier, error) {
+	if c.CaptchaConfig == nil || c.CaptchaConfig.Provider == "" {
+		return nil, nil
+	}
+
+	if c.CaptchaConfig.SiteKey == "" {
+		return nil, fmt.Errorf("not set: SiteKey")
+	}
+	if c.CaptchaConfig.SecretKey == "" {
+		return nil, fmt.Errorf("not set: SecretKey")
+	}
+
+	switch c.CaptchaConfig.Provider {
+	case ProviderHCaptcha:
+		return &siteVerifier{
+			client:    client,
+			verifyURL: hCaptchaVerifyURL,
+			siteKey:   c.CaptchaConfig.SiteKey,
+			secretKey: c.CaptchaConfig.SecretKey,
+		}, nil
+	case ProviderReCaptcha:
+		return &siteVerifier{
+			client:    client,
+			verifyURL: reCaptchaVerifyURL,
+			secretKey: c.CaptchaConfig.SecretKey,
+		}, nil
+	case ProviderMCaptcha:
+		if c.CaptchaConfig.URL == "" {
+			return nil, fmt.Errorf("not set: URL")
+		}
+		return &mCaptchaVerifier{
+			client:    client,
+			verifyURL: strings.TrimSuffix(c.CaptchaConfig.URL, "/") + mCaptchaVerifyPath,
+			siteKey:   c.CaptchaConfig.SiteKey,
+			secretKey: c.CaptchaConfig.SecretKey,
+		}, nil
+	default:
+		return nil, fmt.Errorf("captcha provider %s not recognised", c.CaptchaConfig.Provider)
+	}
+}
+
+// siteVerifier verifies responses with hCaptcha or reCAPTCHA, which share the same siteverify api.
+type siteVerifier struct {
+	client    *http.Client
+	verifyURL string
+	siteKey   string // only sent to hCaptcha
+	secretKey string
+}
+
+func (v *siteVerifier) Verify(ctx context.Context, response string, remoteIP string) (bool, error) {
+	if response == "" {
+		return false, nil
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secretKey)
+	form.Set("response", response)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	if v.siteKey != "" {
+		form.Set("sitekey", v.siteKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, fmt.Errorf("error creating captcha verify request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	result := struct {
+		Success bool `json:"success"`
+	}{}
+	if err := do(v.client, req, &result); err != nil {
+		return false, err
+	}
+
+	return result.Success, nil
+}
+
+// mCaptchaVerifier verifies responses with an mCaptcha server.
+type mCaptchaVerifier struct {
+	client    *http.Client
+	verifyURL string
+	siteKey   string
+	secretKey string
+}
+
+func (v *mCaptchaVerifier) Verify(ctx context.Context, response string, remoteIP string) (bool, error) {
+	if response == "" {
+		return false, nil
+	}
+
+	b, err := json.Marshal(map[string]string{
+		"token":  response,
+		"key":    v.siteKey,
+		"secret": v.secretKey,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error marshalling captcha verify request: %s", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(string(b)))
+	if err != nil {
+		return false, fmt.Errorf("error creating captcha verify request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	result := struct {
+		Valid bool `json:"valid"`
+	}{}
+	if err := do(v.client, req, &result); err != nil {
+		return false, err
+	}
+
+	return result.Valid, nil
+}
+
+// do performs the given request, and decodes the json response body into result.
+func do(client *http.Client, req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error contacting captcha provider: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha provider returned status %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("error decoding captcha provider response: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type CaptchaTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CaptchaTestSuite) TestNewDisabled() {
+	v, err := New(config.TestDefault(), http.DefaultClient)
+	suite.NoError(err)
+	suite.Nil(v)
+}
+
+func (suite *CaptchaTestSuite) TestNewMissingKeys() {
+	c := config.TestDefault()
+	c.CaptchaConfig.Provider = ProviderHCaptcha
+
+	v, err := New(c, http.DefaultClient)
+	suite.EqualError(err, "not set: SiteKey")
+	suite.Nil(v)
+}
+
+func (suite *CaptchaTestSuite) TestHCaptcha() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.NoError(r.ParseForm())
+		suite.Equal("secret", r.PostForm.Get("secret"))
+		suite.Equal("site", r.PostForm.Get("sitekey"))
+		suite.Equal("127.0.0.1", r.PostForm.Get("remoteip"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"success":%t}`, r.PostForm.Get("response") == "good")
+	}))
+	defer server.Close()
+
+	c := config.TestDefault()
+	c.CaptchaConfig.Provider = ProviderHCaptcha
+	c.CaptchaConfig.SiteKey = "site"
+	c.CaptchaConfig.SecretKey = "secret"
+
+	v, err := New(c, server.Client())
+	suite.NoError(err)
+	v.(*siteVerifier).verifyURL = server.URL
+
+	ok, err := v.Verify(context.Background(), "good", "127.0.0.1")
+	suite.NoError(err)
+	suite.True(ok)
+
+	ok, err = v.Verify(context.Background(), "bad", "127.0.0.1")
+	suite.NoError(err)
+	suite.False(ok)
+
+	// an empty response is rejected without asking the provider
+	ok, err = v.Verify(context.Background(), "", "127.0.0.1")
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *CaptchaTestSuite) TestMCaptcha() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Equal(mCaptchaVerifyPath, r.URL.Path)
+		req := map[string]string{}
+		suite.NoError(json.NewDecoder(r.Body).Decode(&req))
+		suite.Equal("site", req["key"])
+		suite.Equal("secret", req["secret"])
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"valid":%t}`, req["token"] == "good")
+	}))
+	defer server.Close()
+
+	c := config.TestDefault()
+	c.CaptchaConfig.Provider = ProviderMCaptcha
+	c.CaptchaConfig.SiteKey = "site"
+	c.CaptchaConfig.SecretKey = "secret"
+	c.CaptchaConfig.URL = server.URL + "/"
+
+	v, err := New(c, server.Client())
+	suite.NoError(err)
+
+	ok, err := v.Verify(context.Background(), "good", "")
+	suite.NoError(err)
+	suite.True(ok)
+
+	ok, err = v.Verify(context.Background(), "bad", "")
+	suite.NoError(err)
+	suite.False(ok)
+}
+
+func (suite *CaptchaTestSuite) TestProviderError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := config.TestDefault()
+	c.CaptchaConfig.Provider = ProviderReCaptcha
+	c.CaptchaConfig.SiteKey = "site"
+	c.CaptchaConfig.SecretKey = "secret"
+
+	v, err := New(c, server.Client())
+	suite.NoError(err)
+	v.(*siteVerifier).verifyURL = server.URL
+
+	ok, err := v.Verify(context.Background(), "good", "")
+	suite.EqualError(err, "captcha provider returned status 502 Bad Gateway")
+	suite.False(ok)
+}
+
+func TestCaptchaTestSuite(t *testing.T) {
+	suite.Run(t, &CaptchaTestSuite{})
+}
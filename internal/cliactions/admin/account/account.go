@@ -34,6 +34,12 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// accountResult is output by account actions when json output is requested.
+type accountResult struct {
+	Username  string `json:"username"`
+	AccountID string `json:"accountID"`
+}
+
 // Create creates a new account in the database using the provided flags.
 var Create cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
 	dbConn, err := bundb.NewBunDBService(ctx, c, log)
@@ -65,11 +71,15 @@ var Create cliactions.GTSAction = func(ctx context.Context, c *config.Config, lo
 		return err
 	}
 
-	_, err = dbConn.NewSignup(ctx, username, "", false, email, password, nil, "", "", false, false)
+	u, err := dbConn.NewSignup(ctx, username, "", false, email, password, nil, "", "", false, false)
 	if err != nil {
 		return err
 	}
 
+	if err := cliactions.Output(c, &accountResult{Username: username, AccountID: u.AccountID}, nil); err != nil {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -105,6 +115,10 @@ var Confirm cliactions.GTSAction = func(ctx context.Context, c *config.Config, l
 		return err
 	}
 
+	if err := cliactions.Output(c, &accountResult{Username: username, AccountID: a.ID}, nil); err != nil {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -137,6 +151,10 @@ var Promote cliactions.GTSAction = func(ctx context.Context, c *config.Config, l
 		return err
 	}
 
+	if err := cliactions.Output(c, &accountResult{Username: username, AccountID: a.ID}, nil); err != nil {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -169,6 +187,10 @@ var Demote cliactions.GTSAction = func(ctx context.Context, c *config.Config, lo
 		return err
 	}
 
+	if err := cliactions.Output(c, &accountResult{Username: username, AccountID: a.ID}, nil); err != nil {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -201,6 +223,10 @@ var Disable cliactions.GTSAction = func(ctx context.Context, c *config.Config, l
 		return err
 	}
 
+	if err := cliactions.Output(c, &accountResult{Username: username, AccountID: a.ID}, nil); err != nil {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
 
@@ -254,5 +280,9 @@ var Password cliactions.GTSAction = func(ctx context.Context, c *config.Config,
 		return err
 	}
 
+	if err := cliactions.Output(c, &accountResult{Username: username, AccountID: a.ID}, nil); err != nil {
+		return err
+	}
+
 	return nil
 }
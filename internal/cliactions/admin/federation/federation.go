@@ -0,0 +1,112 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+// Retry attempts to redeliver failed federation deliveries, either for one domain or for all domains.
+var Retry cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+	defer func() {
+		if err := dbConn.Stop(ctx); err != nil {
+			log.WithError(err).Error("error closing dbservice")
+		}
+	}()
+
+	domain := c.FederationCLIFlags[config.DomainFlag]
+	all := c.FederationCLIFlags[config.AllFlag] == "true"
+	if domain == "" && !all {
+		return errors.New("either a domain or all must be set")
+	}
+	if domain != "" && all {
+		return errors.New("domain and all cannot both be set")
+	}
+
+	failedDeliveries := []*gtsmodel.FailedDelivery{}
+	if all {
+		err = dbConn.GetAll(ctx, &failedDeliveries)
+	} else {
+		err = dbConn.GetWhere(ctx, []db.Where{{Key: "domain", Value: domain}}, &failedDeliveries)
+	}
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting failed deliveries: %s", err)
+	}
+
+	client, err := transport.NewClient(c)
+	if err != nil {
+		return fmt.Errorf("error creating http client: %s", err)
+	}
+	transportController := transport.NewController(c, dbConn, &federation.Clock{}, client, log)
+
+	// keep one transport per signing key, so that we're not fetching the same accounts over and over
+	transports := make(map[string]transport.Transport)
+
+	var delivered int
+	for _, fd := range failedDeliveries {
+		t, ok := transports[fd.PubKeyID]
+		if !ok {
+			account := &gtsmodel.Account{}
+			if err := dbConn.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: fd.PubKeyID}}, account); err != nil {
+				log.WithError(err).WithFields(logrus.Fields{
+					"pubKeyID":   fd.PubKeyID,
+					"deliveryID": fd.ID,
+				}).Error("couldn't get account with public key for delivery")
+				continue
+			}
+
+			t, err = transportController.NewTransport(account.PublicKeyURI, account.PrivateKey)
+			if err != nil {
+				return fmt.Errorf("error creating transport for account %s: %s", account.ID, err)
+			}
+			transports[fd.PubKeyID] = t
+		}
+
+		if err := t.Redeliver(ctx, fd); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"deliveryID": fd.ID,
+				"inboxURI":   fd.InboxURI,
+			}).Info("delivery failed again")
+			continue
+		}
+		delivered = delivered + 1
+	}
+
+	log.WithFields(logrus.Fields{
+		"redelivered": delivered,
+		"total":       len(failedDeliveries),
+	}).Info("redelivered failed deliveries")
+
+	return cliactions.Output(c, map[string]int{"redelivered": delivered, "failed": len(failedDeliveries) - delivered}, nil)
+}
@@ -0,0 +1,135 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package prune
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/retention"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// pruneBatchSize is how many accounts to select for pruning at a time.
+const pruneBatchSize = 100
+
+// pruneResult is what RemoteAccounts outputs when it's done.
+type pruneResult struct {
+	Accounts []string `json:"accounts"`
+	Media    int      `json:"media"`
+	DryRun   bool     `json:"dryRun"`
+}
+
+// RemoteAccounts removes cached remote accounts that haven't been seen for a while, and that no local account
+// has any relationship or interaction with, along with their statuses and cached media.
+var RemoteAccounts cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	days, err := strconv.Atoi(c.PruneCLIFlags[config.PruneDaysFlag])
+	if err != nil || days < 1 {
+		return fmt.Errorf("%s must be a number of days greater than 0", config.PruneDaysFlag)
+	}
+	dryRun := c.PruneCLIFlags[config.PruneDryRunFlag] == "true"
+
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	storage, err := gtsstorage.New(c, c.StorageConfig.Backend)
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %s", err)
+	}
+
+	result, err := pruneRemoteAccounts(ctx, dbConn, storage, time.Now().Add(time.Duration(-days)*24*time.Hour), dryRun, log)
+	if err != nil {
+		return err
+	}
+
+	if err := cliactions.Output(c, result, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if dryRun {
+			fmt.Fprintf(tw, "accounts that would be pruned:\t%d\n", len(result.Accounts))
+		} else {
+			fmt.Fprintf(tw, "accounts pruned:\t%d\n", len(result.Accounts))
+			fmt.Fprintf(tw, "media attachments removed:\t%d\n", result.Media)
+		}
+		return tw.Flush()
+	}); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
+
+// pruneRemoteAccounts prunes all prunable remote accounts not seen since olderThan.
+// If dryRun is true, the accounts are only gathered up and returned, not removed.
+func pruneRemoteAccounts(ctx context.Context, dbConn db.DB, storage *kv.KVStore, olderThan time.Time, dryRun bool, log *logrus.Logger) (*pruneResult, error) {
+	result := &pruneResult{
+		Accounts: []string{},
+		DryRun:   dryRun,
+	}
+
+	if dryRun {
+		accounts, err := dbConn.GetPrunableRemoteAccounts(ctx, olderThan, 0)
+		if err != nil && err != db.ErrNoEntries {
+			return nil, fmt.Errorf("error getting accounts to prune: %s", err)
+		}
+		for _, a := range accounts {
+			log.WithFields(logrus.Fields{
+				"username": a.Username,
+				"domain":   a.Domain,
+			}).Info("would prune account")
+			result.Accounts = append(result.Accounts, a.URI)
+		}
+		return result, nil
+	}
+
+	// pruned accounts drop out of the selection, so just keep selecting until there are none left
+	for {
+		accounts, err := dbConn.GetPrunableRemoteAccounts(ctx, olderThan, pruneBatchSize)
+		if err != nil && err != db.ErrNoEntries {
+			return nil, fmt.Errorf("error getting accounts to prune: %s", err)
+		}
+		if len(accounts) == 0 {
+			return result, nil
+		}
+
+		for _, a := range accounts {
+			media, err := retention.PruneAccount(ctx, dbConn, storage, a, log)
+			if err != nil {
+				return nil, fmt.Errorf("error pruning %s@%s: %s", a.Username, a.Domain, err)
+			}
+			log.WithFields(logrus.Fields{
+				"username": a.Username,
+				"domain":   a.Domain,
+			}).Info("pruned account")
+			result.Accounts = append(result.Accounts, a.URI)
+			result.Media = result.Media + media
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package prune
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PruneTestSuite struct {
+	suite.Suite
+	db      db.DB
+	storage *kv.KVStore
+	log     *logrus.Logger
+
+	testAccounts    map[string]*gtsmodel.Account
+	testAttachments map[string]*gtsmodel.MediaAttachment
+}
+
+func (suite *PruneTestSuite) SetupTest() {
+	suite.db = testrig.NewTestDB()
+	suite.storage = testrig.NewTestStorage()
+	suite.log = testrig.NewTestLog()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+
+	testrig.StandardDBSetup(suite.db, suite.testAccounts)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+}
+
+func (suite *PruneTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+}
+
+// makePrunable removes everything that keeps remote_account_1 from being pruned, and gives it some cached media.
+func (suite *PruneTestSuite) makePrunable() (*gtsmodel.Account, *gtsmodel.MediaAttachment) {
+	ctx := context.Background()
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	suite.NoError(suite.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: remoteAccount.ID}}, &[]*gtsmodel.Block{}))
+	suite.NoError(suite.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: remoteAccount.ID}}, &[]*gtsmodel.Mention{}))
+
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	attachment.AccountID = remoteAccount.ID
+	suite.NoError(suite.db.UpdateByPrimaryKey(ctx, attachment))
+
+	return remoteAccount, attachment
+}
+
+func (suite *PruneTestSuite) TestPruneNothing() {
+	result, err := pruneRemoteAccounts(context.Background(), suite.db, suite.storage, time.Now(), false, suite.log)
+	suite.NoError(err)
+	suite.Empty(result.Accounts)
+	suite.Equal(0, result.Media)
+}
+
+func (suite *PruneTestSuite) TestPruneDryRun() {
+	remoteAccount, attachment := suite.makePrunable()
+
+	result, err := pruneRemoteAccounts(context.Background(), suite.db, suite.storage, time.Now(), true, suite.log)
+	suite.NoError(err)
+	suite.Equal([]string{remoteAccount.URI}, result.Accounts)
+	suite.True(result.DryRun)
+
+	// nothing should actually be gone
+	suite.NoError(suite.db.GetByID(context.Background(), remoteAccount.ID, &gtsmodel.Account{}))
+	has, err := suite.storage.Has(attachment.File.Path)
+	suite.NoError(err)
+	suite.True(has)
+}
+
+func (suite *PruneTestSuite) TestPrune() {
+	ctx := context.Background()
+	remoteAccount, attachment := suite.makePrunable()
+
+	result, err := pruneRemoteAccounts(ctx, suite.db, suite.storage, time.Now(), false, suite.log)
+	suite.NoError(err)
+	suite.Equal([]string{remoteAccount.URI}, result.Accounts)
+	suite.Equal(1, result.Media)
+
+	suite.ErrorIs(suite.db.GetByID(ctx, remoteAccount.ID, &gtsmodel.Account{}), db.ErrNoEntries)
+	suite.ErrorIs(suite.db.GetByID(ctx, attachment.ID, &gtsmodel.MediaAttachment{}), db.ErrNoEntries)
+
+	statuses := []*gtsmodel.Status{}
+	if err := suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: remoteAccount.ID}}, &statuses); err != nil {
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+	suite.Empty(statuses)
+
+	has, err := suite.storage.Has(attachment.File.Path)
+	suite.NoError(err)
+	suite.False(has)
+	has, err = suite.storage.Has(attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.False(has)
+
+	// local accounts and their media are untouched
+	suite.NoError(suite.db.GetByID(ctx, suite.testAccounts["local_account_1"].ID, &gtsmodel.Account{}))
+	has, err = suite.storage.Has(suite.testAttachments["local_account_1_avatar"].File.Path)
+	suite.NoError(err)
+	suite.True(has)
+}
+
+func TestPruneTestSuite(t *testing.T) {
+	suite.Run(t, &PruneTestSuite{})
+}
@@ -0,0 +1,148 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package stats
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+)
+
+// topDomainsLimit is how many of the domains we know the most accounts on to include.
+const topDomainsLimit = 10
+
+// Stats is a snapshot of the size and health of this instance.
+type Stats struct {
+	Users            int               `json:"users"`
+	ActiveUsersWeek  int               `json:"activeUsersWeek"`
+	ActiveUsersMonth int               `json:"activeUsersMonth"`
+	Statuses         int               `json:"statuses"`
+	Domains          int               `json:"domains"`
+	MediaBytes       int               `json:"mediaBytes"`
+	FailedDeliveries int               `json:"failedDeliveries"`
+	TopDomains       []*db.DomainCount `json:"topDomains"`
+}
+
+// Print writes instance statistics to stdout.
+var Print cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	stats, err := gather(ctx, dbConn, c.Host)
+	if err != nil {
+		return err
+	}
+
+	if err := cliactions.Output(c, stats, func(w io.Writer) error {
+		return printText(w, stats)
+	}); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
+
+func gather(ctx context.Context, dbConn db.DB, host string) (*Stats, error) {
+	var err error
+	stats := &Stats{}
+
+	if stats.Users, err = dbConn.CountInstanceUsers(ctx, host); err != nil {
+		return nil, fmt.Errorf("error counting users: %s", err)
+	}
+
+	now := time.Now()
+	if stats.ActiveUsersWeek, err = dbConn.CountInstanceActiveUsers(ctx, now.Add(-7*24*time.Hour)); err != nil {
+		return nil, fmt.Errorf("error counting active users: %s", err)
+	}
+	if stats.ActiveUsersMonth, err = dbConn.CountInstanceActiveUsers(ctx, now.Add(-30*24*time.Hour)); err != nil {
+		return nil, fmt.Errorf("error counting active users: %s", err)
+	}
+
+	if stats.Statuses, err = dbConn.CountInstanceStatuses(ctx, host); err != nil {
+		return nil, fmt.Errorf("error counting statuses: %s", err)
+	}
+
+	if stats.Domains, err = dbConn.CountInstanceDomains(ctx, host); err != nil {
+		return nil, fmt.Errorf("error counting domains: %s", err)
+	}
+
+	if stats.MediaBytes, err = dbConn.CountInstanceMediaBytes(ctx); err != nil {
+		return nil, fmt.Errorf("error counting storage usage: %s", err)
+	}
+
+	if stats.FailedDeliveries, err = dbConn.CountInstanceFailedDeliveries(ctx); err != nil {
+		return nil, fmt.Errorf("error counting failed deliveries: %s", err)
+	}
+
+	if stats.TopDomains, err = dbConn.GetInstanceTopDomains(ctx, topDomainsLimit); err != nil {
+		return nil, fmt.Errorf("error getting top domains: %s", err)
+	}
+
+	return stats, nil
+}
+
+func printText(out io.Writer, stats *Stats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "users:\t%d\n", stats.Users)
+	fmt.Fprintf(w, "active users (7 days):\t%d\n", stats.ActiveUsersWeek)
+	fmt.Fprintf(w, "active users (30 days):\t%d\n", stats.ActiveUsersMonth)
+	fmt.Fprintf(w, "statuses:\t%d\n", stats.Statuses)
+	fmt.Fprintf(w, "known domains:\t%d\n", stats.Domains)
+	fmt.Fprintf(w, "storage used:\t%s\n", formatBytes(stats.MediaBytes))
+	fmt.Fprintf(w, "failed deliveries waiting for retry:\t%d\n", stats.FailedDeliveries)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(stats.TopDomains) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tACCOUNTS")
+	for _, d := range stats.TopDomains {
+		fmt.Fprintf(w, "%s\t%d\n", d.Domain, d.Count)
+	}
+	return w.Flush()
+}
+
+// formatBytes formats b in the largest binary unit that it's at least one of, eg., 1.5 MiB.
+func formatBytes(b int) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := unit, 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,145 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// Migrate copies all stored media from one configured storage backend to another, verifying
+// each copy as it goes. It can safely be run again to resume after an interruption.
+//
+// If a serve url is given, once everything has been copied the urls of local media attachments
+// and emojis are switched over from the current storage serve url to the new one.
+var Migrate cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	fromBackend := c.StorageCLIFlags[config.StorageFromFlag]
+	toBackend := c.StorageCLIFlags[config.StorageToFlag]
+	if fromBackend == toBackend {
+		return errors.New("storage backends to migrate from and to must be different")
+	}
+
+	serveURL := strings.TrimSuffix(c.StorageCLIFlags[config.StorageServeURLFlag], "/")
+
+	from, err := gtsstorage.New(c, fromBackend)
+	if err != nil {
+		return fmt.Errorf("error opening storage backend to migrate from: %s", err)
+	}
+
+	to, err := gtsstorage.New(c, toBackend)
+	if err != nil {
+		return fmt.Errorf("error opening storage backend to migrate to: %s", err)
+	}
+
+	result, err := gtsstorage.Migrate(from, to, log)
+	if err != nil {
+		return fmt.Errorf("error migrating storage, run the command again to resume: %s", err)
+	}
+	log.WithFields(logrus.Fields{
+		"from":    fromBackend,
+		"to":      toBackend,
+		"copied":  result.Copied,
+		"skipped": result.Skipped,
+	}).Info("migrated storage")
+
+	if serveURL != "" {
+		dbConn, err := bundb.NewBunDBService(ctx, c, log)
+		if err != nil {
+			return fmt.Errorf("error creating dbservice: %s", err)
+		}
+
+		oldServeURL := fmt.Sprintf("%s://%s%s", c.StorageConfig.ServeProtocol, c.StorageConfig.ServeHost, c.StorageConfig.ServeBasePath)
+		if err := switchServeURL(ctx, dbConn, oldServeURL, serveURL, log); err != nil {
+			return err
+		}
+
+		if err := dbConn.Stop(ctx); err != nil {
+			return err
+		}
+	}
+
+	log.WithField("storageBackend", toBackend).Info("storage migration complete: set storage-backend in your config and restart gotosocial to start using it")
+	return cliactions.Output(c, result, nil)
+}
+
+// switchServeURL rewrites the urls of media attachments and emojis that start with oldServeURL to start with newServeURL instead.
+func switchServeURL(ctx context.Context, dbConn db.DB, oldServeURL string, newServeURL string, log *logrus.Logger) error {
+	swap := func(url string) (string, bool) {
+		if !strings.HasPrefix(url, oldServeURL+"/") {
+			return url, false
+		}
+		return newServeURL + strings.TrimPrefix(url, oldServeURL), true
+	}
+
+	attachments := []*gtsmodel.MediaAttachment{}
+	if err := dbConn.GetAll(ctx, &attachments); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting media attachments: %s", err)
+	}
+
+	updatedAttachments := 0
+	for _, a := range attachments {
+		var urlChanged, thumbChanged bool
+		a.URL, urlChanged = swap(a.URL)
+		a.Thumbnail.URL, thumbChanged = swap(a.Thumbnail.URL)
+		if !urlChanged && !thumbChanged {
+			continue
+		}
+		if err := dbConn.UpdateByPrimaryKey(ctx, a); err != nil {
+			return fmt.Errorf("error updating url of media attachment %s: %s", a.ID, err)
+		}
+		updatedAttachments++
+	}
+
+	emojis := []*gtsmodel.Emoji{}
+	if err := dbConn.GetAll(ctx, &emojis); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting emojis: %s", err)
+	}
+
+	updatedEmojis := 0
+	for _, e := range emojis {
+		var imageChanged, staticChanged bool
+		e.ImageURL, imageChanged = swap(e.ImageURL)
+		e.ImageStaticURL, staticChanged = swap(e.ImageStaticURL)
+		if !imageChanged && !staticChanged {
+			continue
+		}
+		if err := dbConn.UpdateByPrimaryKey(ctx, e); err != nil {
+			return fmt.Errorf("error updating url of emoji %s: %s", e.ID, err)
+		}
+		updatedEmojis++
+	}
+
+	log.WithFields(logrus.Fields{
+		"from":        oldServeURL,
+		"to":          newServeURL,
+		"attachments": updatedAttachments,
+		"emojis":      updatedEmojis,
+	}).Info("switched serve url")
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// tokenRow is one token as listed by List.
+type tokenRow struct {
+	ID          string    `json:"id"`
+	Username    string    `json:"username"`
+	Application string    `json:"application"`
+	Scope       string    `json:"scope"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// List prints oauth tokens, optionally filtered by the username and/or application ID provided in flags.
+var List cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	where, err := tokenWhere(ctx, dbConn, c)
+	if err != nil {
+		return err
+	}
+
+	tokens := []*gtsmodel.Token{}
+	if len(where) == 0 {
+		err = dbConn.GetAll(ctx, &tokens)
+	} else {
+		err = dbConn.GetWhere(ctx, where, &tokens)
+	}
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting tokens: %s", err)
+	}
+
+	rows := make([]*tokenRow, 0, len(tokens))
+	for _, t := range tokens {
+		rows = append(rows, &tokenRow{
+			ID:          t.ID,
+			Username:    tokenUsername(ctx, dbConn, t),
+			Application: tokenApplication(ctx, dbConn, t),
+			Scope:       t.Scope,
+			CreatedAt:   t.AccessCreateAt,
+			ExpiresAt:   t.AccessExpiresAt,
+		})
+	}
+
+	if err := cliactions.Output(c, rows, func(out io.Writer) error {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tUSER\tAPPLICATION\tSCOPE\tCREATED\tEXPIRES")
+		for _, r := range rows {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.Username, r.Application, r.Scope, formatTime(r.CreatedAt), formatTime(r.ExpiresAt))
+		}
+		return w.Flush()
+	}); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
+
+// Revoke deletes either the oauth token with the ID provided in flags, or all tokens matching the given username and/or application ID.
+var Revoke cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	where, err := tokenWhere(ctx, dbConn, c)
+	if err != nil {
+		return err
+	}
+
+	if tokenID := c.TokenCLIFlags[config.TokenIDFlag]; tokenID != "" {
+		where = append(where, db.Where{Key: "id", Value: tokenID})
+	}
+
+	// never revoke every token on the instance by accident
+	if len(where) == 0 {
+		return errors.New("at least one of token id, username, or application id must be set")
+	}
+
+	tokens := []*gtsmodel.Token{}
+	if err := dbConn.GetWhere(ctx, where, &tokens); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting tokens: %s", err)
+	}
+
+	for _, t := range tokens {
+		if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: "token_id", Value: t.ID}}, &gtsmodel.WebPushSubscription{}); err != nil && err != db.ErrNoEntries {
+			return fmt.Errorf("error deleting push subscription of token %s: %s", t.ID, err)
+		}
+		if err := dbConn.DeleteByID(ctx, t.ID, &gtsmodel.Token{}); err != nil {
+			return fmt.Errorf("error revoking token %s: %s", t.ID, err)
+		}
+	}
+
+	log.WithField("revoked", len(tokens)).Info("revoked tokens")
+
+	if err := cliactions.Output(c, map[string]int{"revoked": len(tokens)}, nil); err != nil {
+		return err
+	}
+
+	return dbConn.Stop(ctx)
+}
+
+// tokenWhere derives db where clauses for selecting tokens from the username and application ID flags.
+func tokenWhere(ctx context.Context, dbConn db.DB, c *config.Config) ([]db.Where, error) {
+	where := []db.Where{}
+
+	if username := c.TokenCLIFlags[config.UsernameFlag]; username != "" {
+		if err := validate.Username(username); err != nil {
+			return nil, err
+		}
+
+		a, err := dbConn.GetLocalAccountByUsername(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account %s: %s", username, err)
+		}
+
+		u := &gtsmodel.User{}
+		if err := dbConn.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, u); err != nil {
+			return nil, fmt.Errorf("error getting user for account %s: %s", username, err)
+		}
+
+		where = append(where, db.Where{Key: "user_id", Value: u.ID})
+	}
+
+	if applicationID := c.TokenCLIFlags[config.ApplicationIDFlag]; applicationID != "" {
+		app := &gtsmodel.Application{}
+		if err := dbConn.GetByID(ctx, applicationID, app); err != nil {
+			return nil, fmt.Errorf("error getting application %s: %s", applicationID, err)
+		}
+
+		where = append(where, db.Where{Key: "client_id", Value: app.ClientID})
+	}
+
+	return where, nil
+}
+
+func tokenUsername(ctx context.Context, dbConn db.DB, t *gtsmodel.Token) string {
+	if t.UserID == "" {
+		return "-"
+	}
+
+	u := &gtsmodel.User{}
+	if err := dbConn.GetByID(ctx, t.UserID, u); err != nil {
+		return t.UserID
+	}
+
+	a, err := dbConn.GetAccountByID(ctx, u.AccountID)
+	if err != nil {
+		return t.UserID
+	}
+
+	return a.Username
+}
+
+func tokenApplication(ctx context.Context, dbConn db.DB, t *gtsmodel.Token) string {
+	app := &gtsmodel.Application{}
+	if err := dbConn.GetWhere(ctx, []db.Where{{Key: "client_id", Value: t.ClientID}}, app); err != nil {
+		return t.ClientID
+	}
+	return fmt.Sprintf("%s (%s)", app.Name, app.ID)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
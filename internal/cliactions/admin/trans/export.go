@@ -44,7 +44,15 @@ var Export cliactions.GTSAction = func(ctx context.Context, c *config.Config, lo
 		return errors.New("no path set")
 	}
 
-	if err := exporter.ExportMinimal(ctx, path); err != nil {
+	if username := c.ExportCLIFlags[config.TransAccountFlag]; username != "" {
+		if err := exporter.ExportAccount(ctx, path, username); err != nil {
+			return err
+		}
+	} else if err := exporter.ExportMinimal(ctx, path); err != nil {
+		return err
+	}
+
+	if err := cliactions.Output(c, map[string]string{"exported": path}, nil); err != nil {
 		return err
 	}
 
@@ -52,5 +52,9 @@ var Import cliactions.GTSAction = func(ctx context.Context, c *config.Config, lo
 		return err
 	}
 
+	if err := cliactions.Output(c, map[string]string{"imported": path}, nil); err != nil {
+		return err
+	}
+
 	return dbConn.Stop(ctx)
 }
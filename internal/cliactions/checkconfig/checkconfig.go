@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package checkconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// Check validates the loaded config, then makes sure that the database and
+// storage it points to are actually reachable. Any problem is returned as an
+// error, so that the process exits non-zero and can be used as a pre-start check.
+var Check cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	// connecting to the database also pings it, so this tells us if it's there and listening
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %s", err)
+	}
+	if err := dbConn.Stop(ctx); err != nil {
+		return fmt.Errorf("error closing database connection: %s", err)
+	}
+
+	if c.StorageConfig.Backend == storage.BackendS3 {
+		err = checkS3(c)
+	} else {
+		err = checkStorage(c.StorageConfig.BasePath)
+	}
+	if err != nil {
+		return fmt.Errorf("error checking storage: %s", err)
+	}
+
+	log.Info("config OK")
+	return nil
+}
+
+// checkStorage makes sure that basePath is an existing directory that we can write to.
+func checkStorage(basePath string) error {
+	info, err := os.Stat(basePath)
+	if err != nil {
+		return fmt.Errorf("storage base path %s could not be accessed: %s", basePath, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("storage base path %s is not a directory", basePath)
+	}
+
+	f, err := os.CreateTemp(basePath, ".gotosocial-check-config-*")
+	if err != nil {
+		return fmt.Errorf("storage base path %s is not writable: %s", basePath, err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// checkS3 makes sure that we can write to, read from, and remove from the configured bucket.
+func checkS3(c *config.Config) error {
+	st, err := storage.New(c, storage.BackendS3)
+	if err != nil {
+		return err
+	}
+
+	key := ".gotosocial-check-config"
+	if err := st.Put(key, []byte("ok")); err != nil {
+		return fmt.Errorf("bucket %s is not writable: %s", c.StorageConfig.S3Bucket, err)
+	}
+	if _, err := st.Get(key); err != nil {
+		return fmt.Errorf("bucket %s is not readable: %s", c.StorageConfig.S3Bucket, err)
+	}
+	return st.Delete(key)
+}
@@ -0,0 +1,46 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cliactions
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// Output writes the result of an action to stdout.
+//
+// If json output was requested on the command line, result is written as a
+// single line of json. Otherwise text is called to write a human-readable
+// version of the result; text may be nil for actions that just log what they did.
+func Output(c *config.Config, result interface{}, text func(w io.Writer) error) error {
+	return output(os.Stdout, c.OutputFormat, result, text)
+}
+
+func output(w io.Writer, format string, result interface{}, text func(w io.Writer) error) error {
+	if format == config.OutputFormatJSON {
+		return json.NewEncoder(w).Encode(result)
+	}
+	if text == nil {
+		return nil
+	}
+	return text(w)
+}
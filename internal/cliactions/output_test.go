@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cliactions
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type OutputTestSuite struct {
+	suite.Suite
+}
+
+type testResult struct {
+	Username string `json:"username"`
+	Count    int    `json:"count"`
+}
+
+func (suite *OutputTestSuite) TestOutputJSON() {
+	b := &bytes.Buffer{}
+	err := output(b, config.OutputFormatJSON, &testResult{Username: "the_mighty_zork", Count: 2}, func(w io.Writer) error {
+		suite.FailNow("text should not be called for json output")
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal(`{"username":"the_mighty_zork","count":2}`+"\n", b.String())
+}
+
+func (suite *OutputTestSuite) TestOutputText() {
+	b := &bytes.Buffer{}
+	err := output(b, config.OutputFormatText, &testResult{Username: "the_mighty_zork", Count: 2}, func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, "the_mighty_zork: 2")
+		return err
+	})
+	suite.NoError(err)
+	suite.Equal("the_mighty_zork: 2\n", b.String())
+}
+
+func (suite *OutputTestSuite) TestOutputTextNil() {
+	b := &bytes.Buffer{}
+	suite.NoError(output(b, config.OutputFormatText, &testResult{}, nil))
+	suite.Empty(b.String())
+}
+
+func TestOutputTestSuite(t *testing.T) {
+	suite.Run(t, &OutputTestSuite{})
+}
@@ -3,12 +3,11 @@ package server
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	"git.iim.gay/grufwub/go-store/kv"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
@@ -16,26 +15,41 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/app"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/emails"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/emoji"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/fileserver"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/filter"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequest"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/health"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/poll"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/report"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/sessions"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tag"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
+	userClient "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/webfinger"
 	"github.com/superseriousbusiness/gotosocial/internal/api/security"
+	"github.com/superseriousbusiness/gotosocial/internal/captcha"
 	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/errorreport"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
 	"github.com/superseriousbusiness/gotosocial/internal/gotosocial"
@@ -43,15 +57,23 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/relme"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	timelineprocessing "github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/watchdog"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Start creates and starts a gotosocial server
 var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
 	dbService, err := bundb.NewBunDBService(ctx, c, log)
 	if err != nil {
 		return fmt.Errorf("error creating dbservice: %s", err)
@@ -77,7 +99,7 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 	}
 
 	// Open the storage backend
-	storage, err := kv.OpenFile(c.StorageConfig.BasePath, nil)
+	storage, err := gtsstorage.New(c, c.StorageConfig.Backend)
 	if err != nil {
 		return fmt.Errorf("error creating storage backend: %s", err)
 	}
@@ -89,9 +111,33 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 	// build backend handlers
 	mediaHandler := media.New(c, dbService, storage, log)
 	oauthServer := oauth.New(dbService, log)
-	transportController := transport.NewController(c, dbService, &federation.Clock{}, http.DefaultClient, log)
+	client, err := transport.NewClient(c)
+	if err != nil {
+		return fmt.Errorf("error creating http client: %s", err)
+	}
+
+	// report anything logged at error level to the configured error tracker, if any
+	reporter, err := errorreport.New(c, client, log)
+	if err != nil {
+		return fmt.Errorf("error creating error reporter: %s", err)
+	}
+	if reporter != nil {
+		log.AddHook(reporter)
+	}
+
+	transportController := transport.NewController(c, dbService, &federation.Clock{}, client, log)
 	federator := federation.NewFederator(dbService, federatingDB, transportController, c, log, typeConverter, mediaHandler)
-	processor := processing.NewProcessor(c, typeConverter, federator, oauthServer, mediaHandler, storage, timelineManager, dbService, log)
+	// profile field links are given to us by users, so they're fetched with a client that can't reach private addresses
+	publicClient, err := transport.NewPublicClient(c, 10*time.Second)
+	if err != nil {
+		return fmt.Errorf("error creating public http client: %s", err)
+	}
+	// as are web push endpoints, which are given to us by users when they subscribe to notifications
+	pushClient, err := transport.NewPublicClient(c, 30*time.Second)
+	if err != nil {
+		return fmt.Errorf("error creating push http client: %s", err)
+	}
+	processor := processing.NewProcessor(c, typeConverter, federator, oauthServer, mediaHandler, storage, timelineManager, dbService, relme.NewVerifier(publicClient), webpush.NewSender(c, dbService, pushClient, log), log)
 	if err := processor.Start(ctx); err != nil {
 		return fmt.Errorf("error starting processor: %s", err)
 	}
@@ -101,9 +147,14 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 		return fmt.Errorf("error creating oidc idp: %s", err)
 	}
 
+	captchaVerifier, err := captcha.New(c, client)
+	if err != nil {
+		return fmt.Errorf("error creating captcha verifier: %s", err)
+	}
+
 	// build client api modules
 	authModule := auth.New(c, dbService, oauthServer, idp, log)
-	accountModule := account.New(c, processor, log)
+	accountModule := account.New(c, processor, captchaVerifier, log)
 	instanceModule := instance.New(c, processor, log)
 	appsModule := app.New(c, processor, log)
 	followRequestsModule := followrequest.New(c, processor, log)
@@ -122,14 +173,29 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 	adminModule := admin.New(c, processor, log)
 	statusModule := status.New(c, processor, log)
 	securityModule := security.New(c, dbService, log)
+	rateLimitModule := security.NewRateLimit(c, log)
 	streamingModule := streaming.New(c, processor, log)
 	favouritesModule := favourites.New(c, processor, log)
 	blocksModule := blocks.New(c, processor, log)
+	mutesModule := mutes.New(c, processor, log)
+	pollModule := poll.New(c, processor, log)
+	preferencesModule := preferences.New(c, processor, log)
+	pushModule := push.New(c, processor, log)
+	oEmbedModule := oembed.New(c, processor, log)
+	healthModule := health.New(c, processor, log)
+	tagModule := tag.New(c, processor, log)
+	trendsModule := trends.New(c, processor, log)
+	sessionsModule := sessions.New(c, processor, log)
+	suggestionsModule := suggestions.New(c, processor, log)
+	reportModule := report.New(c, processor, log)
+	emailsModule := emails.New(c, processor, log)
+	userClientModule := userClient.New(c, processor, log)
 
 	apis := []api.ClientModule{
 		// modules with middleware go first
 		securityModule,
 		authModule,
+		rateLimitModule, // after auth, so that requests can be counted per token
 
 		// now everything else
 		webBaseModule,
@@ -153,6 +219,19 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 		streamingModule,
 		favouritesModule,
 		blocksModule,
+		mutesModule,
+		pollModule,
+		preferencesModule,
+		pushModule,
+		oEmbedModule,
+		healthModule,
+		tagModule,
+		trendsModule,
+		sessionsModule,
+		suggestionsModule,
+		reportModule,
+		emailsModule,
+		userClientModule,
 	}
 
 	for _, m := range apis {
@@ -170,11 +249,18 @@ var Start cliactions.GTSAction = func(ctx context.Context, c *config.Config, log
 		return fmt.Errorf("error starting gotosocial service: %s", err)
 	}
 
+	// let systemd know we've started, and keep its watchdog (if any) notified for as long as we're healthy
+	watchdogCtx, stopWatchdog := context.WithCancel(ctx)
+	go watchdog.Run(watchdogCtx, func(ctx context.Context) bool {
+		return processor.HealthReadyGet(ctx).Ready
+	}, log)
+
 	// catch shutdown signals from the operating system
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
 	sig := <-sigs
-	log.Infof("received signal %s, shutting down", sig)
+	log.WithField("signal", sig).Info("received signal, shutting down")
+	stopWatchdog()
 
 	// close down all running services in order
 	if err := gts.Stop(ctx); err != nil {
@@ -0,0 +1,70 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testrig
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/cliactions"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+// Seed populates the configured database and storage with demo accounts, follows, statuses and media.
+var Seed cliactions.GTSAction = func(ctx context.Context, c *config.Config, log *logrus.Logger) error {
+	counts := testrig.SeedCounts{}
+	for flag, count := range map[string]*int{
+		config.SeedAccountsFlag: &counts.Accounts,
+		config.SeedFollowsFlag:  &counts.Follows,
+		config.SeedStatusesFlag: &counts.Statuses,
+		config.SeedMediaFlag:    &counts.Media,
+	} {
+		i, err := strconv.Atoi(c.SeedCLIFlags[flag])
+		if err != nil || i < 0 {
+			return fmt.Errorf("%s must be a number that isn't negative", flag)
+		}
+		*count = i
+	}
+
+	dbConn, err := bundb.NewBunDBService(ctx, c, log)
+	if err != nil {
+		return fmt.Errorf("error creating dbservice: %s", err)
+	}
+
+	storage, err := gtsstorage.New(c, c.StorageConfig.Backend)
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %s", err)
+	}
+
+	if err := testrig.Seed(ctx, c, dbConn, storage, c.SeedCLIFlags[config.SeedMediaPathFlag], counts); err != nil {
+		return err
+	}
+
+	log.WithFields(logrus.Fields{
+		"accounts": counts.Accounts,
+		"password": testrig.SeedPassword,
+	}).Info("seeded accounts")
+
+	return dbConn.Stop(ctx)
+}
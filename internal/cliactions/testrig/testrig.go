@@ -17,19 +17,32 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/app"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/emails"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/emoji"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/fileserver"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/filter"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequest"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/health"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/poll"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/report"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/sessions"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tag"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
+	userClient "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/webfinger"
@@ -74,7 +87,7 @@ var Start cliactions.GTSAction = func(ctx context.Context, _ *config.Config, log
 
 	// build client api modules
 	authModule := auth.New(c, dbService, oauthServer, idp, log)
-	accountModule := account.New(c, processor, log)
+	accountModule := account.New(c, processor, nil, log)
 	instanceModule := instance.New(c, processor, log)
 	appsModule := app.New(c, processor, log)
 	followRequestsModule := followrequest.New(c, processor, log)
@@ -93,14 +106,29 @@ var Start cliactions.GTSAction = func(ctx context.Context, _ *config.Config, log
 	adminModule := admin.New(c, processor, log)
 	statusModule := status.New(c, processor, log)
 	securityModule := security.New(c, dbService, log)
+	rateLimitModule := security.NewRateLimit(c, log)
 	streamingModule := streaming.New(c, processor, log)
 	favouritesModule := favourites.New(c, processor, log)
 	blocksModule := blocks.New(c, processor, log)
+	mutesModule := mutes.New(c, processor, log)
+	pollModule := poll.New(c, processor, log)
+	preferencesModule := preferences.New(c, processor, log)
+	pushModule := push.New(c, processor, log)
+	oEmbedModule := oembed.New(c, processor, log)
+	healthModule := health.New(c, processor, log)
+	tagModule := tag.New(c, processor, log)
+	trendsModule := trends.New(c, processor, log)
+	sessionsModule := sessions.New(c, processor, log)
+	suggestionsModule := suggestions.New(c, processor, log)
+	reportModule := report.New(c, processor, log)
+	emailsModule := emails.New(c, processor, log)
+	userClientModule := userClient.New(c, processor, log)
 
 	apis := []api.ClientModule{
 		// modules with middleware go first
 		securityModule,
 		authModule,
+		rateLimitModule, // after auth, so that requests can be counted per token
 
 		// now everything else
 		webBaseModule,
@@ -124,6 +152,19 @@ var Start cliactions.GTSAction = func(ctx context.Context, _ *config.Config, log
 		streamingModule,
 		favouritesModule,
 		blocksModule,
+		mutesModule,
+		pollModule,
+		preferencesModule,
+		pushModule,
+		oEmbedModule,
+		healthModule,
+		tagModule,
+		trendsModule,
+		sessionsModule,
+		suggestionsModule,
+		reportModule,
+		emailsModule,
+		userClientModule,
 	}
 
 	for _, m := range apis {
@@ -145,7 +186,7 @@ var Start cliactions.GTSAction = func(ctx context.Context, _ *config.Config, log
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
 	sig := <-sigs
-	log.Infof("received signal %s, shutting down", sig)
+	log.WithField("signal", sig).Info("received signal, shutting down")
 
 	testrig.StandardDBTeardown(dbService)
 	testrig.StandardStorageTeardown(storageBackend)
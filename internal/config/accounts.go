@@ -21,9 +21,24 @@ package config
 // AccountsConfig contains configuration to do with creating accounts, new registrations, and defaults.
 type AccountsConfig struct {
 	// Do we want people to be able to just submit sign up requests, or do we want invite only?
+	// When false, sign up requests are still taken, but they always need approval.
 	OpenRegistration bool `yaml:"openRegistration"`
 	// Do sign up requests require approval from an admin/moderator?
 	RequireApproval bool `yaml:"requireApproval"`
 	// Do we require a reason for a sign up or is an empty string OK?
 	ReasonRequired bool `yaml:"reasonRequired"`
+	// Can accounts set custom CSS for their profile and status pages?
+	AllowCustomCSS bool `yaml:"allowCustomCSS"`
+	// Maximum length of custom CSS, in characters.
+	CustomCSSLength int `yaml:"customCSSLength"`
+	// Type of key to generate for signing federated requests when a new account is created.
+	// Accounts always get an RSA key for compatibility; if this is ed25519, they get an Ed25519 key as well.
+	KeyType string `yaml:"keyType"`
 }
+
+const (
+	// KeyTypeRSA means new accounts only get an RSA key.
+	KeyTypeRSA = "rsa"
+	// KeyTypeEd25519 means new accounts get an Ed25519 key, which they'll prefer to sign with, as well as an RSA key.
+	KeyTypeEd25519 = "ed25519"
+)
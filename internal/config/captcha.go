@@ -0,0 +1,31 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// CaptchaConfig pertains to verifying a captcha when new accounts sign up.
+type CaptchaConfig struct {
+	// Captcha provider to use: hcaptcha, recaptcha or mcaptcha. Empty means no captcha
+	Provider string `yaml:"provider"`
+	// Public site key, given to clients so they can render the captcha widget
+	SiteKey string `yaml:"siteKey"`
+	// Secret key used to verify captcha responses with the provider
+	SecretKey string `yaml:"secretKey"`
+	// Base url of the mcaptcha instance to use; only used for mcaptcha
+	URL string `yaml:"url"`
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"gopkg.in/yaml.v2"
 )
@@ -39,6 +40,59 @@ const (
 
 	TransPathFlag  = "path"
 	TransPathUsage = "the path of the file to import from/export to"
+
+	TransAccountFlag  = "account"
+	TransAccountUsage = "the username of a single local account to export; if not set, all accounts will be exported"
+
+	DomainFlag  = "domain"
+	DomainUsage = "the domain to perform this action on"
+
+	AllFlag  = "all"
+	AllUsage = "perform this action on all domains"
+
+	TokenIDFlag  = "id"
+	TokenIDUsage = "the id of the token to revoke"
+
+	ApplicationIDFlag  = "application-id"
+	ApplicationIDUsage = "the id of the application whose tokens to list/revoke"
+
+	SeedAccountsFlag  = "accounts"
+	SeedAccountsUsage = "the number of accounts to create"
+
+	SeedFollowsFlag  = "follows"
+	SeedFollowsUsage = "the number of other created accounts that each created account should follow"
+
+	SeedStatusesFlag  = "statuses"
+	SeedStatusesUsage = "the number of statuses to create for each account"
+
+	SeedMediaFlag  = "media"
+	SeedMediaUsage = "the number of each account's statuses that should have a media attachment"
+
+	SeedMediaPathFlag  = "media-path"
+	SeedMediaPathUsage = "the path of the testrig media directory to take media files from"
+
+	StorageFromFlag  = "from"
+	StorageFromUsage = "the storage backend to migrate from: local or s3"
+
+	StorageToFlag  = "to"
+	StorageToUsage = "the storage backend to migrate to: local or s3"
+
+	StorageServeURLFlag  = "serve-url"
+	StorageServeURLUsage = "if set, rewrite the urls of stored media to start with this url instead of the current storage serve url once migration is done"
+
+	PruneDaysFlag  = "days"
+	PruneDaysUsage = "only prune remote accounts that haven't been seen for at least this many days"
+
+	PruneDryRunFlag  = "dry-run"
+	PruneDryRunUsage = "just list what would be pruned, without removing anything"
+
+	FormatFlag  = "format"
+	FormatUsage = "output format for cli actions: text or json"
+
+	// OutputFormatText is the default, human-readable output format for cli actions.
+	OutputFormatText = "text"
+	// OutputFormatJSON makes cli actions write their results to stdout as json, for scripting.
+	OutputFormatJSON = "json"
 )
 
 // Config pulls together all the configuration needed to run gotosocial
@@ -48,28 +102,57 @@ type Config struct {
 		For long-running commands (server start etc).
 	*/
 
-	LogLevel          string             `yaml:"logLevel"`
-	ApplicationName   string             `yaml:"applicationName"`
-	Host              string             `yaml:"host"`
-	AccountDomain     string             `yaml:"accountDomain"`
-	Protocol          string             `yaml:"protocol"`
-	Port              int                `yaml:"port"`
-	TrustedProxies    []string           `yaml:"trustedProxies"`
-	DBConfig          *DBConfig          `yaml:"db"`
-	TemplateConfig    *TemplateConfig    `yaml:"template"`
-	AccountsConfig    *AccountsConfig    `yaml:"accounts"`
-	MediaConfig       *MediaConfig       `yaml:"media"`
-	StorageConfig     *StorageConfig     `yaml:"storage"`
-	StatusesConfig    *StatusesConfig    `yaml:"statuses"`
-	LetsEncryptConfig *LetsEncryptConfig `yaml:"letsEncrypt"`
-	OIDCConfig        *OIDCConfig        `yaml:"oidc"`
+	LogLevel               string                  `yaml:"logLevel"`
+	LogFormat              string                  `yaml:"logFormat"`
+	ApplicationName        string                  `yaml:"applicationName"`
+	Host                   string                  `yaml:"host"`
+	AccountDomain          string                  `yaml:"accountDomain"`
+	Protocol               string                  `yaml:"protocol"`
+	Port                   int                     `yaml:"port"`
+	TrustedProxies         []string                `yaml:"trustedProxies"`
+	UnixSocket             string                  `yaml:"unixSocket"`
+	DBConfig               *DBConfig               `yaml:"db"`
+	TemplateConfig         *TemplateConfig         `yaml:"template"`
+	AccountsConfig         *AccountsConfig         `yaml:"accounts"`
+	MediaConfig            *MediaConfig            `yaml:"media"`
+	StorageConfig          *StorageConfig          `yaml:"storage"`
+	StatusesConfig         *StatusesConfig         `yaml:"statuses"`
+	LetsEncryptConfig      *LetsEncryptConfig      `yaml:"letsEncrypt"`
+	OIDCConfig             *OIDCConfig             `yaml:"oidc"`
+	ThrottlingConfig       *ThrottlingConfig       `yaml:"throttling"`
+	CaptchaConfig          *CaptchaConfig          `yaml:"captcha"`
+	SpamConfig             *SpamConfig             `yaml:"spam"`
+	HealthConfig           *HealthConfig           `yaml:"health"`
+	RequestLimitsConfig    *RequestLimitsConfig    `yaml:"requestLimits"`
+	HiddenServicesConfig   *HiddenServicesConfig   `yaml:"hiddenServices"`
+	OutboundProxyConfig    *OutboundProxyConfig    `yaml:"outboundProxy"`
+	ErrorReportingConfig   *ErrorReportingConfig   `yaml:"errorReporting"`
+	RetentionConfig        *RetentionConfig        `yaml:"retention"`
+	FederationConfig       *FederationConfig       `yaml:"federation"`
+	InboxFilterConfig      *InboxFilterConfig      `yaml:"inboxFilter"`
+	FederationLimitsConfig *FederationLimitsConfig `yaml:"federationLimits"`
+	FederationCacheConfig  *FederationCacheConfig  `yaml:"federationCache"`
+	WebPushConfig          *WebPushConfig          `yaml:"webPush"`
+	SMTPConfig             *SMTPConfig             `yaml:"smtp"`
+	RateLimitConfig        *RateLimitConfig        `yaml:"rateLimit"`
 
 	/*
 		Not parsed from .yaml configuration file.
 	*/
-	AccountCLIFlags map[string]string
-	ExportCLIFlags  map[string]string
-	SoftwareVersion string
+	AccountCLIFlags    map[string]string
+	ExportCLIFlags     map[string]string
+	FederationCLIFlags map[string]string
+	TokenCLIFlags      map[string]string
+	SeedCLIFlags       map[string]string
+	StorageCLIFlags    map[string]string
+	PruneCLIFlags      map[string]string
+	SoftwareVersion    string
+	OutputFormat       string
+
+	// fileKeys records which keys were present in the parsed .yaml
+	// configuration file, eg., "accounts.openRegistration", so that
+	// we can tell false bools in the file apart from unset ones.
+	fileKeys map[string]bool
 }
 
 // FromFile returns a new config from a file, or an error if something goes amiss.
@@ -87,16 +170,37 @@ func FromFile(path string) (*Config, error) {
 // Empty just returns a new empty config
 func Empty() *Config {
 	return &Config{
-		DBConfig:          &DBConfig{},
-		TemplateConfig:    &TemplateConfig{},
-		AccountsConfig:    &AccountsConfig{},
-		MediaConfig:       &MediaConfig{},
-		StorageConfig:     &StorageConfig{},
-		StatusesConfig:    &StatusesConfig{},
-		LetsEncryptConfig: &LetsEncryptConfig{},
-		OIDCConfig:        &OIDCConfig{},
-		AccountCLIFlags:   make(map[string]string),
-		ExportCLIFlags:    make(map[string]string),
+		DBConfig:               &DBConfig{},
+		TemplateConfig:         &TemplateConfig{},
+		AccountsConfig:         &AccountsConfig{},
+		MediaConfig:            &MediaConfig{},
+		StorageConfig:          &StorageConfig{},
+		StatusesConfig:         &StatusesConfig{},
+		LetsEncryptConfig:      &LetsEncryptConfig{},
+		OIDCConfig:             &OIDCConfig{},
+		ThrottlingConfig:       &ThrottlingConfig{},
+		CaptchaConfig:          &CaptchaConfig{},
+		SpamConfig:             &SpamConfig{},
+		HealthConfig:           &HealthConfig{},
+		RequestLimitsConfig:    &RequestLimitsConfig{},
+		HiddenServicesConfig:   &HiddenServicesConfig{},
+		OutboundProxyConfig:    &OutboundProxyConfig{},
+		ErrorReportingConfig:   &ErrorReportingConfig{},
+		RetentionConfig:        &RetentionConfig{},
+		FederationConfig:       &FederationConfig{},
+		InboxFilterConfig:      &InboxFilterConfig{},
+		FederationLimitsConfig: &FederationLimitsConfig{},
+		FederationCacheConfig:  &FederationCacheConfig{},
+		WebPushConfig:          &WebPushConfig{},
+		SMTPConfig:             &SMTPConfig{},
+		RateLimitConfig:        &RateLimitConfig{},
+		AccountCLIFlags:        make(map[string]string),
+		ExportCLIFlags:         make(map[string]string),
+		FederationCLIFlags:     make(map[string]string),
+		TokenCLIFlags:          make(map[string]string),
+		SeedCLIFlags:           make(map[string]string),
+		StorageCLIFlags:        make(map[string]string),
+		PruneCLIFlags:          make(map[string]string),
 	}
 }
 
@@ -112,9 +216,40 @@ func loadFromFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("could not unmarshal file at path %s: %s", path, err)
 	}
 
+	raw := make(map[string]interface{})
+	if err := yaml.Unmarshal(bytes, &raw); err != nil {
+		return nil, fmt.Errorf("could not unmarshal file at path %s: %s", path, err)
+	}
+	config.fileKeys = make(map[string]bool)
+	addFileKeys(config.fileKeys, "", raw)
+
 	return config, nil
 }
 
+// addFileKeys adds the dotted path of every key in m, and in any maps nested within it, to keys.
+func addFileKeys(keys map[string]bool, prefix string, m map[string]interface{}) {
+	for k, v := range m {
+		key := prefix + k
+		keys[key] = true
+
+		switch nested := v.(type) {
+		case map[string]interface{}:
+			addFileKeys(keys, key+".", nested)
+		case map[interface{}]interface{}:
+			converted := make(map[string]interface{}, len(nested))
+			for nk, nv := range nested {
+				converted[fmt.Sprint(nk)] = nv
+			}
+			addFileKeys(keys, key+".", converted)
+		}
+	}
+}
+
+// inFile returns true if the given dotted key, eg., "accounts.openRegistration", was set in the config file.
+func (c *Config) inFile(key string) bool {
+	return c.fileKeys[key]
+}
+
 // ParseCLIFlags sets flags on the config using the provided Flags object
 func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 	fn := GetFlagNames()
@@ -126,12 +261,19 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 	//
 	// b) They may have been set in the config, but they've *also* been set explicitly
 	//    as a command-line argument or an env variable, which takes priority.
+	//
+	// So the order of precedence is flag > env > file > default. Since false is a
+	// perfectly good value for a bool, bools check whether the key was in the file.
 
 	// general flags
 	if c.LogLevel == "" || f.IsSet(fn.LogLevel) {
 		c.LogLevel = f.String(fn.LogLevel)
 	}
 
+	if c.LogFormat == "" || f.IsSet(fn.LogFormat) {
+		c.LogFormat = f.String(fn.LogFormat)
+	}
+
 	if c.ApplicationName == "" || f.IsSet(fn.ApplicationName) {
 		c.ApplicationName = f.String(fn.ApplicationName)
 	}
@@ -165,6 +307,10 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.TrustedProxies = f.StringSlice(fn.TrustedProxies)
 	}
 
+	if c.UnixSocket == "" || f.IsSet(fn.UnixSocket) {
+		c.UnixSocket = f.String(fn.UnixSocket)
+	}
+
 	// db flags
 	if c.DBConfig.Type == "" || f.IsSet(fn.DbType) {
 		c.DBConfig.Type = f.String(fn.DbType)
@@ -198,6 +344,13 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.DBConfig.TLSCACert = f.String(fn.DbTLSCACert)
 	}
 
+	if c.DBConfig.ApplicationName == "" || f.IsSet(fn.DbApplicationName) {
+		c.DBConfig.ApplicationName = f.String(fn.DbApplicationName)
+	}
+	if c.DBConfig.ApplicationName == "" {
+		c.DBConfig.ApplicationName = c.ApplicationName // default to the application name, if this is empty
+	}
+
 	// template flags
 	if c.TemplateConfig.BaseDir == "" || f.IsSet(fn.TemplateBaseDir) {
 		c.TemplateConfig.BaseDir = f.String(fn.TemplateBaseDir)
@@ -209,14 +362,30 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 	}
 
 	// accounts flags
-	if f.IsSet(fn.AccountsOpenRegistration) {
+	if !c.inFile("accounts.openRegistration") || f.IsSet(fn.AccountsOpenRegistration) {
 		c.AccountsConfig.OpenRegistration = f.Bool(fn.AccountsOpenRegistration)
 	}
 
-	if f.IsSet(fn.AccountsApprovalRequired) {
+	if !c.inFile("accounts.requireApproval") || f.IsSet(fn.AccountsApprovalRequired) {
 		c.AccountsConfig.RequireApproval = f.Bool(fn.AccountsApprovalRequired)
 	}
 
+	if !c.inFile("accounts.reasonRequired") || f.IsSet(fn.AccountsReasonRequired) {
+		c.AccountsConfig.ReasonRequired = f.Bool(fn.AccountsReasonRequired)
+	}
+
+	if !c.inFile("accounts.allowCustomCSS") || f.IsSet(fn.AccountsAllowCustomCSS) {
+		c.AccountsConfig.AllowCustomCSS = f.Bool(fn.AccountsAllowCustomCSS)
+	}
+
+	if c.AccountsConfig.CustomCSSLength == 0 || f.IsSet(fn.AccountsCustomCSSLength) {
+		c.AccountsConfig.CustomCSSLength = f.Int(fn.AccountsCustomCSSLength)
+	}
+
+	if c.AccountsConfig.KeyType == "" || f.IsSet(fn.AccountsKeyType) {
+		c.AccountsConfig.KeyType = f.String(fn.AccountsKeyType)
+	}
+
 	// media flags
 	if c.MediaConfig.MaxImageSize == 0 || f.IsSet(fn.MediaMaxImageSize) {
 		c.MediaConfig.MaxImageSize = f.Int(fn.MediaMaxImageSize)
@@ -226,6 +395,18 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.MediaConfig.MaxVideoSize = f.Int(fn.MediaMaxVideoSize)
 	}
 
+	if c.MediaConfig.MaxVideoDuration == 0 || f.IsSet(fn.MediaMaxVideoDuration) {
+		c.MediaConfig.MaxVideoDuration = f.Int(fn.MediaMaxVideoDuration)
+	}
+
+	if c.MediaConfig.FFmpegPath == "" || f.IsSet(fn.MediaFFmpegPath) {
+		c.MediaConfig.FFmpegPath = f.String(fn.MediaFFmpegPath)
+	}
+
+	if c.MediaConfig.FFprobePath == "" || f.IsSet(fn.MediaFFprobePath) {
+		c.MediaConfig.FFprobePath = f.String(fn.MediaFFprobePath)
+	}
+
 	if c.MediaConfig.MinDescriptionChars == 0 || f.IsSet(fn.MediaMinDescriptionChars) {
 		c.MediaConfig.MinDescriptionChars = f.Int(fn.MediaMinDescriptionChars)
 	}
@@ -255,6 +436,30 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.StorageConfig.ServeBasePath = f.String(fn.StorageServeBasePath)
 	}
 
+	if c.StorageConfig.S3Endpoint == "" || f.IsSet(fn.StorageS3Endpoint) {
+		c.StorageConfig.S3Endpoint = f.String(fn.StorageS3Endpoint)
+	}
+
+	if c.StorageConfig.S3Region == "" || f.IsSet(fn.StorageS3Region) {
+		c.StorageConfig.S3Region = f.String(fn.StorageS3Region)
+	}
+
+	if c.StorageConfig.S3Bucket == "" || f.IsSet(fn.StorageS3Bucket) {
+		c.StorageConfig.S3Bucket = f.String(fn.StorageS3Bucket)
+	}
+
+	if c.StorageConfig.S3AccessKey == "" || f.IsSet(fn.StorageS3AccessKey) {
+		c.StorageConfig.S3AccessKey = f.String(fn.StorageS3AccessKey)
+	}
+
+	if c.StorageConfig.S3SecretKey == "" || f.IsSet(fn.StorageS3SecretKey) {
+		c.StorageConfig.S3SecretKey = f.String(fn.StorageS3SecretKey)
+	}
+
+	if !c.inFile("storage.s3Presign") || f.IsSet(fn.StorageS3Presign) {
+		c.StorageConfig.S3Presign = f.Bool(fn.StorageS3Presign)
+	}
+
 	// statuses flags
 	if c.StatusesConfig.MaxChars == 0 || f.IsSet(fn.StatusesMaxChars) {
 		c.StatusesConfig.MaxChars = f.Int(fn.StatusesMaxChars)
@@ -273,7 +478,7 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 	}
 
 	// letsencrypt flags
-	if f.IsSet(fn.LetsEncryptEnabled) {
+	if !c.inFile("letsEncrypt.enabled") || f.IsSet(fn.LetsEncryptEnabled) {
 		c.LetsEncryptConfig.Enabled = f.Bool(fn.LetsEncryptEnabled)
 	}
 
@@ -289,8 +494,28 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.LetsEncryptConfig.EmailAddress = f.String(fn.LetsEncryptEmailAddress)
 	}
 
+	if c.LetsEncryptConfig.Challenge == "" || f.IsSet(fn.LetsEncryptChallenge) {
+		c.LetsEncryptConfig.Challenge = f.String(fn.LetsEncryptChallenge)
+	}
+
+	if c.LetsEncryptConfig.KeyType == "" || f.IsSet(fn.LetsEncryptKeyType) {
+		c.LetsEncryptConfig.KeyType = f.String(fn.LetsEncryptKeyType)
+	}
+
+	if c.LetsEncryptConfig.DNSProvider == "" || f.IsSet(fn.LetsEncryptDNSProvider) {
+		c.LetsEncryptConfig.DNSProvider = f.String(fn.LetsEncryptDNSProvider)
+	}
+
+	if c.LetsEncryptConfig.DNSExecPath == "" || f.IsSet(fn.LetsEncryptDNSExecPath) {
+		c.LetsEncryptConfig.DNSExecPath = f.String(fn.LetsEncryptDNSExecPath)
+	}
+
+	if c.LetsEncryptConfig.DNSHTTPReqEndpoint == "" || f.IsSet(fn.LetsEncryptDNSHTTPReqEndpoint) {
+		c.LetsEncryptConfig.DNSHTTPReqEndpoint = f.String(fn.LetsEncryptDNSHTTPReqEndpoint)
+	}
+
 	// OIDC flags
-	if f.IsSet(fn.OIDCEnabled) {
+	if !c.inFile("oidc.enabled") || f.IsSet(fn.OIDCEnabled) {
 		c.OIDCConfig.Enabled = f.Bool(fn.OIDCEnabled)
 	}
 
@@ -298,7 +523,7 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.OIDCConfig.IDPName = f.String(fn.OIDCIdpName)
 	}
 
-	if f.IsSet(fn.OIDCSkipVerification) {
+	if !c.inFile("oidc.skipVerification") || f.IsSet(fn.OIDCSkipVerification) {
 		c.OIDCConfig.SkipVerification = f.Bool(fn.OIDCSkipVerification)
 	}
 
@@ -318,6 +543,330 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 		c.OIDCConfig.Scopes = f.StringSlice(fn.OIDCScopes)
 	}
 
+	if c.OIDCConfig.UsernameClaim == "" || f.IsSet(fn.OIDCUsernameClaim) {
+		c.OIDCConfig.UsernameClaim = f.String(fn.OIDCUsernameClaim)
+	}
+
+	if c.OIDCConfig.EmailClaim == "" || f.IsSet(fn.OIDCEmailClaim) {
+		c.OIDCConfig.EmailClaim = f.String(fn.OIDCEmailClaim)
+	}
+
+	if c.OIDCConfig.GroupsClaim == "" || f.IsSet(fn.OIDCGroupsClaim) {
+		c.OIDCConfig.GroupsClaim = f.String(fn.OIDCGroupsClaim)
+	}
+
+	if !c.inFile("oidc.adminGroups") || f.IsSet(fn.OIDCAdminGroups) {
+		c.OIDCConfig.AdminGroups = f.StringSlice(fn.OIDCAdminGroups)
+	}
+
+	if !c.inFile("oidc.moderatorGroups") || f.IsSet(fn.OIDCModeratorGroups) {
+		c.OIDCConfig.ModeratorGroups = f.StringSlice(fn.OIDCModeratorGroups)
+	}
+
+	if c.OIDCConfig.UsernameConflict == "" || f.IsSet(fn.OIDCUsernameConflict) {
+		c.OIDCConfig.UsernameConflict = f.String(fn.OIDCUsernameConflict)
+	}
+
+	// throttling flags
+	if !c.inFile("throttling.searchConcurrency") || f.IsSet(fn.ThrottlingSearchConcurrency) {
+		c.ThrottlingConfig.SearchConcurrency = f.Int(fn.ThrottlingSearchConcurrency)
+	}
+
+	if !c.inFile("throttling.searchPerIPPerMinute") || f.IsSet(fn.ThrottlingSearchPerIPPerMinute) {
+		c.ThrottlingConfig.SearchPerIPPerMinute = f.Int(fn.ThrottlingSearchPerIPPerMinute)
+	}
+
+	if !c.inFile("throttling.mediaConcurrency") || f.IsSet(fn.ThrottlingMediaConcurrency) {
+		c.ThrottlingConfig.MediaConcurrency = f.Int(fn.ThrottlingMediaConcurrency)
+	}
+
+	if !c.inFile("throttling.mediaPerIPPerMinute") || f.IsSet(fn.ThrottlingMediaPerIPPerMinute) {
+		c.ThrottlingConfig.MediaPerIPPerMinute = f.Int(fn.ThrottlingMediaPerIPPerMinute)
+	}
+
+	if !c.inFile("throttling.inboxConcurrency") || f.IsSet(fn.ThrottlingInboxConcurrency) {
+		c.ThrottlingConfig.InboxConcurrency = f.Int(fn.ThrottlingInboxConcurrency)
+	}
+
+	if !c.inFile("throttling.inboxPerIPPerMinute") || f.IsSet(fn.ThrottlingInboxPerIPPerMinute) {
+		c.ThrottlingConfig.InboxPerIPPerMinute = f.Int(fn.ThrottlingInboxPerIPPerMinute)
+	}
+
+	// captcha flags
+	if c.CaptchaConfig.Provider == "" || f.IsSet(fn.CaptchaProvider) {
+		c.CaptchaConfig.Provider = f.String(fn.CaptchaProvider)
+	}
+
+	if c.CaptchaConfig.SiteKey == "" || f.IsSet(fn.CaptchaSiteKey) {
+		c.CaptchaConfig.SiteKey = f.String(fn.CaptchaSiteKey)
+	}
+
+	if c.CaptchaConfig.SecretKey == "" || f.IsSet(fn.CaptchaSecretKey) {
+		c.CaptchaConfig.SecretKey = f.String(fn.CaptchaSecretKey)
+	}
+
+	if c.CaptchaConfig.URL == "" || f.IsSet(fn.CaptchaURL) {
+		c.CaptchaConfig.URL = f.String(fn.CaptchaURL)
+	}
+
+	// spam flags
+	if !c.inFile("spam.enabled") || f.IsSet(fn.SpamEnabled) {
+		c.SpamConfig.Enabled = f.Bool(fn.SpamEnabled)
+	}
+
+	if !c.inFile("spam.reportScore") || f.IsSet(fn.SpamReportScore) {
+		c.SpamConfig.ReportScore = f.Int(fn.SpamReportScore)
+	}
+
+	if !c.inFile("spam.holdScore") || f.IsSet(fn.SpamHoldScore) {
+		c.SpamConfig.HoldScore = f.Int(fn.SpamHoldScore)
+	}
+
+	if !c.inFile("spam.dropScore") || f.IsSet(fn.SpamDropScore) {
+		c.SpamConfig.DropScore = f.Int(fn.SpamDropScore)
+	}
+
+	if !c.inFile("spam.newAccountHours") || f.IsSet(fn.SpamNewAccountHours) {
+		c.SpamConfig.NewAccountHours = f.Int(fn.SpamNewAccountHours)
+	}
+
+	if !c.inFile("spam.firstContactMentionScore") || f.IsSet(fn.SpamFirstContactMentionScore) {
+		c.SpamConfig.FirstContactMentionScore = f.Int(fn.SpamFirstContactMentionScore)
+	}
+
+	if !c.inFile("spam.linkOnlyScore") || f.IsSet(fn.SpamLinkOnlyScore) {
+		c.SpamConfig.LinkOnlyScore = f.Int(fn.SpamLinkOnlyScore)
+	}
+
+	if len(c.SpamConfig.Keywords) == 0 || f.IsSet(fn.SpamKeywords) {
+		c.SpamConfig.Keywords = f.StringSlice(fn.SpamKeywords)
+	}
+
+	if !c.inFile("spam.keywordScore") || f.IsSet(fn.SpamKeywordScore) {
+		c.SpamConfig.KeywordScore = f.Int(fn.SpamKeywordScore)
+	}
+
+	// health flags
+	if c.HealthConfig.Token == "" || f.IsSet(fn.HealthToken) {
+		c.HealthConfig.Token = f.String(fn.HealthToken)
+	}
+
+	// request-limits flags
+	if !c.inFile("requestLimits.timeout") || f.IsSet(fn.RequestLimitsTimeout) {
+		c.RequestLimitsConfig.Timeout = f.Int(fn.RequestLimitsTimeout)
+	}
+
+	if !c.inFile("requestLimits.mediaTimeout") || f.IsSet(fn.RequestLimitsMediaTimeout) {
+		c.RequestLimitsConfig.MediaTimeout = f.Int(fn.RequestLimitsMediaTimeout)
+	}
+
+	if !c.inFile("requestLimits.inboxTimeout") || f.IsSet(fn.RequestLimitsInboxTimeout) {
+		c.RequestLimitsConfig.InboxTimeout = f.Int(fn.RequestLimitsInboxTimeout)
+	}
+
+	if !c.inFile("requestLimits.bodySize") || f.IsSet(fn.RequestLimitsBodySize) {
+		c.RequestLimitsConfig.BodySize = f.Int(fn.RequestLimitsBodySize)
+	}
+
+	if !c.inFile("requestLimits.mediaBodySize") || f.IsSet(fn.RequestLimitsMediaBodySize) {
+		c.RequestLimitsConfig.MediaBodySize = f.Int(fn.RequestLimitsMediaBodySize)
+	}
+
+	if !c.inFile("requestLimits.inboxBodySize") || f.IsSet(fn.RequestLimitsInboxBodySize) {
+		c.RequestLimitsConfig.InboxBodySize = f.Int(fn.RequestLimitsInboxBodySize)
+	}
+
+	if !c.inFile("requestLimits.readHeaderTimeout") || f.IsSet(fn.RequestLimitsReadHeaderTimeout) {
+		c.RequestLimitsConfig.ReadHeaderTimeout = f.Int(fn.RequestLimitsReadHeaderTimeout)
+	}
+
+	if !c.inFile("requestLimits.readTimeout") || f.IsSet(fn.RequestLimitsReadTimeout) {
+		c.RequestLimitsConfig.ReadTimeout = f.Int(fn.RequestLimitsReadTimeout)
+	}
+
+	if !c.inFile("requestLimits.idleTimeout") || f.IsSet(fn.RequestLimitsIdleTimeout) {
+		c.RequestLimitsConfig.IdleTimeout = f.Int(fn.RequestLimitsIdleTimeout)
+	}
+
+	// hidden-services flags
+	if c.HiddenServicesConfig.OnionHost == "" || f.IsSet(fn.HiddenServicesOnionHost) {
+		c.HiddenServicesConfig.OnionHost = f.String(fn.HiddenServicesOnionHost)
+	}
+
+	if c.HiddenServicesConfig.TorProxy == "" || f.IsSet(fn.HiddenServicesTorProxy) {
+		c.HiddenServicesConfig.TorProxy = f.String(fn.HiddenServicesTorProxy)
+	}
+
+	if c.HiddenServicesConfig.I2PProxy == "" || f.IsSet(fn.HiddenServicesI2PProxy) {
+		c.HiddenServicesConfig.I2PProxy = f.String(fn.HiddenServicesI2PProxy)
+	}
+
+	// outbound-proxy flags
+	if c.OutboundProxyConfig.URL == "" || f.IsSet(fn.OutboundProxyURL) {
+		c.OutboundProxyConfig.URL = f.String(fn.OutboundProxyURL)
+	}
+
+	if len(c.OutboundProxyConfig.NoProxy) == 0 || f.IsSet(fn.OutboundProxyNoProxy) {
+		c.OutboundProxyConfig.NoProxy = f.StringSlice(fn.OutboundProxyNoProxy)
+	}
+
+	if len(c.OutboundProxyConfig.Domains) == 0 || f.IsSet(fn.OutboundProxyDomains) {
+		c.OutboundProxyConfig.Domains = f.StringSlice(fn.OutboundProxyDomains)
+	}
+
+	// error-reporting flags
+	if c.ErrorReportingConfig.DSN == "" || f.IsSet(fn.ErrorReportingDSN) {
+		c.ErrorReportingConfig.DSN = f.String(fn.ErrorReportingDSN)
+	}
+
+	if c.ErrorReportingConfig.WebhookURL == "" || f.IsSet(fn.ErrorReportingWebhookURL) {
+		c.ErrorReportingConfig.WebhookURL = f.String(fn.ErrorReportingWebhookURL)
+	}
+
+	if c.ErrorReportingConfig.Environment == "" || f.IsSet(fn.ErrorReportingEnvironment) {
+		c.ErrorReportingConfig.Environment = f.String(fn.ErrorReportingEnvironment)
+	}
+
+	// retention flags
+	if !c.inFile("retention.remoteStatusDays") || f.IsSet(fn.RetentionRemoteStatusDays) {
+		c.RetentionConfig.RemoteStatusDays = f.Int(fn.RetentionRemoteStatusDays)
+	}
+
+	if !c.inFile("retention.remoteAccountDays") || f.IsSet(fn.RetentionRemoteAccountDays) {
+		c.RetentionConfig.RemoteAccountDays = f.Int(fn.RetentionRemoteAccountDays)
+	}
+
+	if c.RetentionConfig.SweepIntervalMinutes == 0 || f.IsSet(fn.RetentionSweepIntervalMinutes) {
+		c.RetentionConfig.SweepIntervalMinutes = f.Int(fn.RetentionSweepIntervalMinutes)
+	}
+
+	if !c.inFile("retention.inboxActivityDays") || f.IsSet(fn.RetentionInboxActivityDays) {
+		c.RetentionConfig.InboxActivityDays = f.Int(fn.RetentionInboxActivityDays)
+	}
+
+	if !c.inFile("retention.failedDeliveryDays") || f.IsSet(fn.RetentionFailedDeliveryDays) {
+		c.RetentionConfig.FailedDeliveryDays = f.Int(fn.RetentionFailedDeliveryDays)
+	}
+
+	// federation flags
+	if !c.inFile("federation.sendBlocks") || f.IsSet(fn.FederationSendBlocks) {
+		c.FederationConfig.SendBlocks = f.Bool(fn.FederationSendBlocks)
+	}
+
+	if c.FederationConfig.Mode == "" || f.IsSet(fn.FederationMode) {
+		c.FederationConfig.Mode = f.String(fn.FederationMode)
+	}
+
+	// inbox filter flags
+	if !c.inFile("inboxFilter.maxMentions") || f.IsSet(fn.InboxFilterMaxMentions) {
+		c.InboxFilterConfig.MaxMentions = f.Int(fn.InboxFilterMaxMentions)
+	}
+
+	if !c.inFile("inboxFilter.rejectLinkOnly") || f.IsSet(fn.InboxFilterRejectLinkOnly) {
+		c.InboxFilterConfig.RejectLinkOnly = f.Bool(fn.InboxFilterRejectLinkOnly)
+	}
+
+	if !c.inFile("inboxFilter.newAccountHours") || f.IsSet(fn.InboxFilterNewAccountHours) {
+		c.InboxFilterConfig.NewAccountHours = f.Int(fn.InboxFilterNewAccountHours)
+	}
+
+	if len(c.InboxFilterConfig.RejectKeywords) == 0 || f.IsSet(fn.InboxFilterRejectKeywords) {
+		c.InboxFilterConfig.RejectKeywords = f.StringSlice(fn.InboxFilterRejectKeywords)
+	}
+
+	if !c.inFile("inboxFilter.reportRejections") || f.IsSet(fn.InboxFilterReportRejections) {
+		c.InboxFilterConfig.ReportRejections = f.Bool(fn.InboxFilterReportRejections)
+	}
+
+	// federation limits flags
+	if !c.inFile("federationLimits.payloadSize") || f.IsSet(fn.FederationLimitsPayloadSize) {
+		c.FederationLimitsConfig.PayloadSize = f.Int(fn.FederationLimitsPayloadSize)
+	}
+
+	if !c.inFile("federationLimits.collectionPages") || f.IsSet(fn.FederationLimitsCollectionPages) {
+		c.FederationLimitsConfig.CollectionPages = f.Int(fn.FederationLimitsCollectionPages)
+	}
+
+	if !c.inFile("federationLimits.collectionItems") || f.IsSet(fn.FederationLimitsCollectionItems) {
+		c.FederationLimitsConfig.CollectionItems = f.Int(fn.FederationLimitsCollectionItems)
+	}
+
+	if !c.inFile("federationLimits.threadDepth") || f.IsSet(fn.FederationLimitsThreadDepth) {
+		c.FederationLimitsConfig.ThreadDepth = f.Int(fn.FederationLimitsThreadDepth)
+	}
+
+	if !c.inFile("federationLimits.backfillStatuses") || f.IsSet(fn.FederationLimitsBackfillStatuses) {
+		c.FederationLimitsConfig.BackfillStatuses = f.Int(fn.FederationLimitsBackfillStatuses)
+	}
+
+	if !c.inFile("federationLimits.deliveryConcurrency") || f.IsSet(fn.FederationLimitsDeliveryConcurrency) {
+		c.FederationLimitsConfig.DeliveryConcurrency = f.Int(fn.FederationLimitsDeliveryConcurrency)
+	}
+
+	if !c.inFile("federationLimits.deliveryAttempts") || f.IsSet(fn.FederationLimitsDeliveryAttempts) {
+		c.FederationLimitsConfig.DeliveryAttempts = f.Int(fn.FederationLimitsDeliveryAttempts)
+	}
+
+	// federation cache flags
+	if !c.inFile("federationCache.ttlSeconds") || f.IsSet(fn.FederationCacheTTLSeconds) {
+		c.FederationCacheConfig.TTLSeconds = f.Int(fn.FederationCacheTTLSeconds)
+	}
+
+	if !c.inFile("federationCache.maxEntries") || f.IsSet(fn.FederationCacheMaxEntries) {
+		c.FederationCacheConfig.MaxEntries = f.Int(fn.FederationCacheMaxEntries)
+	}
+
+	// web push flags
+	if c.WebPushConfig.ContactEmail == "" || f.IsSet(fn.WebPushContactEmail) {
+		c.WebPushConfig.ContactEmail = f.String(fn.WebPushContactEmail)
+	}
+
+	if c.WebPushConfig.VAPIDPublicKey == "" || f.IsSet(fn.WebPushVAPIDPublicKey) {
+		c.WebPushConfig.VAPIDPublicKey = f.String(fn.WebPushVAPIDPublicKey)
+	}
+
+	if c.WebPushConfig.VAPIDPrivateKey == "" || f.IsSet(fn.WebPushVAPIDPrivateKey) {
+		c.WebPushConfig.VAPIDPrivateKey = f.String(fn.WebPushVAPIDPrivateKey)
+	}
+
+	// smtp flags
+	if c.SMTPConfig.Host == "" || f.IsSet(fn.SMTPHost) {
+		c.SMTPConfig.Host = f.String(fn.SMTPHost)
+	}
+
+	if !c.inFile("smtp.port") || f.IsSet(fn.SMTPPort) {
+		c.SMTPConfig.Port = f.Int(fn.SMTPPort)
+	}
+
+	if c.SMTPConfig.Username == "" || f.IsSet(fn.SMTPUsername) {
+		c.SMTPConfig.Username = f.String(fn.SMTPUsername)
+	}
+
+	if c.SMTPConfig.Password == "" || f.IsSet(fn.SMTPPassword) {
+		c.SMTPConfig.Password = f.String(fn.SMTPPassword)
+	}
+
+	if c.SMTPConfig.From == "" || f.IsSet(fn.SMTPFrom) {
+		c.SMTPConfig.From = f.String(fn.SMTPFrom)
+	}
+
+	// rate limit flags
+	if !c.inFile("rateLimit.apiRequests") || f.IsSet(fn.RateLimitAPIRequests) {
+		c.RateLimitConfig.APIRequests = f.Int(fn.RateLimitAPIRequests)
+	}
+
+	if !c.inFile("rateLimit.inboxRequests") || f.IsSet(fn.RateLimitInboxRequests) {
+		c.RateLimitConfig.InboxRequests = f.Int(fn.RateLimitInboxRequests)
+	}
+
+	if !c.inFile("rateLimit.authRequests") || f.IsSet(fn.RateLimitAuthRequests) {
+		c.RateLimitConfig.AuthRequests = f.Int(fn.RateLimitAuthRequests)
+	}
+
+	if len(c.RateLimitConfig.ExemptIPs) == 0 || f.IsSet(fn.RateLimitExemptIPs) {
+		c.RateLimitConfig.ExemptIPs = f.StringSlice(fn.RateLimitExemptIPs)
+	}
+
 	// command-specific flags
 
 	// admin account CLI flags
@@ -327,6 +876,35 @@ func (c *Config) ParseCLIFlags(f KeyedFlags, version string) error {
 
 	// export CLI flags
 	c.ExportCLIFlags[TransPathFlag] = f.String(TransPathFlag)
+	c.ExportCLIFlags[TransAccountFlag] = f.String(TransAccountFlag)
+
+	// federation CLI flags
+	c.FederationCLIFlags[DomainFlag] = f.String(DomainFlag)
+	c.FederationCLIFlags[AllFlag] = strconv.FormatBool(f.Bool(AllFlag))
+
+	// token CLI flags
+	c.TokenCLIFlags[TokenIDFlag] = f.String(TokenIDFlag)
+	c.TokenCLIFlags[UsernameFlag] = f.String(UsernameFlag)
+	c.TokenCLIFlags[ApplicationIDFlag] = f.String(ApplicationIDFlag)
+
+	// testrig seed CLI flags
+	c.SeedCLIFlags[SeedAccountsFlag] = strconv.Itoa(f.Int(SeedAccountsFlag))
+	c.SeedCLIFlags[SeedFollowsFlag] = strconv.Itoa(f.Int(SeedFollowsFlag))
+	c.SeedCLIFlags[SeedStatusesFlag] = strconv.Itoa(f.Int(SeedStatusesFlag))
+	c.SeedCLIFlags[SeedMediaFlag] = strconv.Itoa(f.Int(SeedMediaFlag))
+	c.SeedCLIFlags[SeedMediaPathFlag] = f.String(SeedMediaPathFlag)
+
+	// storage CLI flags
+	c.StorageCLIFlags[StorageFromFlag] = f.String(StorageFromFlag)
+	c.StorageCLIFlags[StorageToFlag] = f.String(StorageToFlag)
+	c.StorageCLIFlags[StorageServeURLFlag] = f.String(StorageServeURLFlag)
+
+	// prune CLI flags
+	c.PruneCLIFlags[PruneDaysFlag] = strconv.Itoa(f.Int(PruneDaysFlag))
+	c.PruneCLIFlags[PruneDryRunFlag] = strconv.FormatBool(f.Bool(PruneDryRunFlag))
+
+	// output format is global to all cli actions
+	c.OutputFormat = f.String(FormatFlag)
 
 	c.SoftwareVersion = version
 	return nil
@@ -346,6 +924,7 @@ type KeyedFlags interface {
 // initializing and storing urfavecli flag variables.
 type Flags struct {
 	LogLevel        string
+	LogFormat       string
 	ApplicationName string
 	ConfigPath      string
 	Host            string
@@ -353,15 +932,17 @@ type Flags struct {
 	Protocol        string
 	Port            string
 	TrustedProxies  string
-
-	DbType      string
-	DbAddress   string
-	DbPort      string
-	DbUser      string
-	DbPassword  string
-	DbDatabase  string
-	DbTLSMode   string
-	DbTLSCACert string
+	UnixSocket      string
+
+	DbType            string
+	DbAddress         string
+	DbPort            string
+	DbUser            string
+	DbPassword        string
+	DbDatabase        string
+	DbTLSMode         string
+	DbTLSCACert       string
+	DbApplicationName string
 
 	TemplateBaseDir string
 	AssetBaseDir    string
@@ -369,9 +950,15 @@ type Flags struct {
 	AccountsOpenRegistration string
 	AccountsApprovalRequired string
 	AccountsReasonRequired   string
+	AccountsAllowCustomCSS   string
+	AccountsCustomCSSLength  string
+	AccountsKeyType          string
 
 	MediaMaxImageSize        string
 	MediaMaxVideoSize        string
+	MediaMaxVideoDuration    string
+	MediaFFmpegPath          string
+	MediaFFprobePath         string
 	MediaMinDescriptionChars string
 	MediaMaxDescriptionChars string
 
@@ -380,6 +967,12 @@ type Flags struct {
 	StorageServeProtocol string
 	StorageServeHost     string
 	StorageServeBasePath string
+	StorageS3Endpoint    string
+	StorageS3Region      string
+	StorageS3Bucket      string
+	StorageS3AccessKey   string
+	StorageS3SecretKey   string
+	StorageS3Presign     string
 
 	StatusesMaxChars           string
 	StatusesCWMaxChars         string
@@ -391,6 +984,12 @@ type Flags struct {
 	LetsEncryptCertDir      string
 	LetsEncryptEmailAddress string
 	LetsEncryptPort         string
+	LetsEncryptChallenge    string
+	LetsEncryptKeyType      string
+
+	LetsEncryptDNSProvider        string
+	LetsEncryptDNSExecPath        string
+	LetsEncryptDNSHTTPReqEndpoint string
 
 	OIDCEnabled          string
 	OIDCIdpName          string
@@ -399,11 +998,105 @@ type Flags struct {
 	OIDCClientID         string
 	OIDCClientSecret     string
 	OIDCScopes           string
+	OIDCUsernameClaim    string
+	OIDCEmailClaim       string
+	OIDCGroupsClaim      string
+	OIDCAdminGroups      string
+	OIDCModeratorGroups  string
+	OIDCUsernameConflict string
+
+	ThrottlingSearchConcurrency    string
+	ThrottlingSearchPerIPPerMinute string
+	ThrottlingMediaConcurrency     string
+	ThrottlingMediaPerIPPerMinute  string
+	ThrottlingInboxConcurrency     string
+	ThrottlingInboxPerIPPerMinute  string
+
+	CaptchaProvider  string
+	CaptchaSiteKey   string
+	CaptchaSecretKey string
+	CaptchaURL       string
+
+	SpamEnabled                  string
+	SpamReportScore              string
+	SpamHoldScore                string
+	SpamDropScore                string
+	SpamNewAccountHours          string
+	SpamFirstContactMentionScore string
+	SpamLinkOnlyScore            string
+	SpamKeywords                 string
+	SpamKeywordScore             string
+
+	HealthToken string
+
+	RequestLimitsTimeout           string
+	RequestLimitsMediaTimeout      string
+	RequestLimitsInboxTimeout      string
+	RequestLimitsBodySize          string
+	RequestLimitsMediaBodySize     string
+	RequestLimitsInboxBodySize     string
+	RequestLimitsReadHeaderTimeout string
+	RequestLimitsReadTimeout       string
+	RequestLimitsIdleTimeout       string
+
+	HiddenServicesOnionHost string
+	HiddenServicesTorProxy  string
+	HiddenServicesI2PProxy  string
+
+	OutboundProxyURL     string
+	OutboundProxyNoProxy string
+	OutboundProxyDomains string
+
+	ErrorReportingDSN         string
+	ErrorReportingWebhookURL  string
+	ErrorReportingEnvironment string
+
+	RetentionRemoteStatusDays     string
+	RetentionRemoteAccountDays    string
+	RetentionSweepIntervalMinutes string
+	RetentionInboxActivityDays    string
+	RetentionFailedDeliveryDays   string
+
+	FederationSendBlocks string
+	FederationMode       string
+
+	InboxFilterMaxMentions      string
+	InboxFilterRejectLinkOnly   string
+	InboxFilterNewAccountHours  string
+	InboxFilterRejectKeywords   string
+	InboxFilterReportRejections string
+
+	FederationLimitsPayloadSize         string
+	FederationLimitsCollectionPages     string
+	FederationLimitsCollectionItems     string
+	FederationLimitsThreadDepth         string
+	FederationLimitsBackfillStatuses    string
+	FederationLimitsDeliveryConcurrency string
+	FederationLimitsDeliveryAttempts    string
+
+	FederationCacheTTLSeconds string
+	FederationCacheMaxEntries string
+
+	WebPushContactEmail    string
+	WebPushVAPIDPublicKey  string
+	WebPushVAPIDPrivateKey string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	RateLimitAPIRequests   string
+	RateLimitInboxRequests string
+	RateLimitAuthRequests  string
+	RateLimitExemptIPs     string
 }
 
 // Defaults contains all the default values for a gotosocial config
 type Defaults struct {
 	LogLevel        string
+	LogFormat       string
 	ApplicationName string
 	ConfigPath      string
 	Host            string
@@ -411,16 +1104,18 @@ type Defaults struct {
 	Protocol        string
 	Port            int
 	TrustedProxies  []string
+	UnixSocket      string
 	SoftwareVersion string
 
-	DbType      string
-	DbAddress   string
-	DbPort      int
-	DbUser      string
-	DbPassword  string
-	DbDatabase  string
-	DBTlsMode   string
-	DBTlsCACert string
+	DbType            string
+	DbAddress         string
+	DbPort            int
+	DbUser            string
+	DbPassword        string
+	DbDatabase        string
+	DBTlsMode         string
+	DBTlsCACert       string
+	DbApplicationName string
 
 	TemplateBaseDir string
 	AssetBaseDir    string
@@ -428,9 +1123,15 @@ type Defaults struct {
 	AccountsOpenRegistration bool
 	AccountsRequireApproval  bool
 	AccountsReasonRequired   bool
+	AccountsAllowCustomCSS   bool
+	AccountsCustomCSSLength  int
+	AccountsKeyType          string
 
 	MediaMaxImageSize        int
 	MediaMaxVideoSize        int
+	MediaMaxVideoDuration    int
+	MediaFFmpegPath          string
+	MediaFFprobePath         string
 	MediaMinDescriptionChars int
 	MediaMaxDescriptionChars int
 
@@ -439,6 +1140,12 @@ type Defaults struct {
 	StorageServeProtocol string
 	StorageServeHost     string
 	StorageServeBasePath string
+	StorageS3Endpoint    string
+	StorageS3Region      string
+	StorageS3Bucket      string
+	StorageS3AccessKey   string
+	StorageS3SecretKey   string
+	StorageS3Presign     bool
 
 	StatusesMaxChars           int
 	StatusesCWMaxChars         int
@@ -450,6 +1157,12 @@ type Defaults struct {
 	LetsEncryptCertDir      string
 	LetsEncryptEmailAddress string
 	LetsEncryptPort         int
+	LetsEncryptChallenge    string
+	LetsEncryptKeyType      string
+
+	LetsEncryptDNSProvider        string
+	LetsEncryptDNSExecPath        string
+	LetsEncryptDNSHTTPReqEndpoint string
 
 	OIDCEnabled          bool
 	OIDCIdpName          string
@@ -458,6 +1171,99 @@ type Defaults struct {
 	OIDCClientID         string
 	OIDCClientSecret     string
 	OIDCScopes           []string
+	OIDCUsernameClaim    string
+	OIDCEmailClaim       string
+	OIDCGroupsClaim      string
+	OIDCAdminGroups      []string
+	OIDCModeratorGroups  []string
+	OIDCUsernameConflict string
+
+	ThrottlingSearchConcurrency    int
+	ThrottlingSearchPerIPPerMinute int
+	ThrottlingMediaConcurrency     int
+	ThrottlingMediaPerIPPerMinute  int
+	ThrottlingInboxConcurrency     int
+	ThrottlingInboxPerIPPerMinute  int
+
+	CaptchaProvider  string
+	CaptchaSiteKey   string
+	CaptchaSecretKey string
+	CaptchaURL       string
+
+	SpamEnabled                  bool
+	SpamReportScore              int
+	SpamHoldScore                int
+	SpamDropScore                int
+	SpamNewAccountHours          int
+	SpamFirstContactMentionScore int
+	SpamLinkOnlyScore            int
+	SpamKeywords                 []string
+	SpamKeywordScore             int
+
+	HealthToken string
+
+	RequestLimitsTimeout           int
+	RequestLimitsMediaTimeout      int
+	RequestLimitsInboxTimeout      int
+	RequestLimitsBodySize          int
+	RequestLimitsMediaBodySize     int
+	RequestLimitsInboxBodySize     int
+	RequestLimitsReadHeaderTimeout int
+	RequestLimitsReadTimeout       int
+	RequestLimitsIdleTimeout       int
+
+	HiddenServicesOnionHost string
+	HiddenServicesTorProxy  string
+	HiddenServicesI2PProxy  string
+
+	OutboundProxyURL     string
+	OutboundProxyNoProxy []string
+	OutboundProxyDomains []string
+
+	ErrorReportingDSN         string
+	ErrorReportingWebhookURL  string
+	ErrorReportingEnvironment string
+
+	RetentionRemoteStatusDays     int
+	RetentionRemoteAccountDays    int
+	RetentionSweepIntervalMinutes int
+	RetentionInboxActivityDays    int
+	RetentionFailedDeliveryDays   int
+
+	FederationSendBlocks bool
+	FederationMode       string
+
+	InboxFilterMaxMentions      int
+	InboxFilterRejectLinkOnly   bool
+	InboxFilterNewAccountHours  int
+	InboxFilterRejectKeywords   []string
+	InboxFilterReportRejections bool
+
+	FederationLimitsPayloadSize         int
+	FederationLimitsCollectionPages     int
+	FederationLimitsCollectionItems     int
+	FederationLimitsThreadDepth         int
+	FederationLimitsBackfillStatuses    int
+	FederationLimitsDeliveryConcurrency int
+	FederationLimitsDeliveryAttempts    int
+
+	FederationCacheTTLSeconds int
+	FederationCacheMaxEntries int
+
+	WebPushContactEmail    string
+	WebPushVAPIDPublicKey  string
+	WebPushVAPIDPrivateKey string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	RateLimitAPIRequests   int
+	RateLimitInboxRequests int
+	RateLimitAuthRequests  int
+	RateLimitExemptIPs     []string
 }
 
 // GetFlagNames returns a struct containing the names of the various flags used for
@@ -465,6 +1271,7 @@ type Defaults struct {
 func GetFlagNames() Flags {
 	return Flags{
 		LogLevel:        "log-level",
+		LogFormat:       "log-format",
 		ApplicationName: "application-name",
 		ConfigPath:      "config-path",
 		Host:            "host",
@@ -472,15 +1279,17 @@ func GetFlagNames() Flags {
 		Protocol:        "protocol",
 		Port:            "port",
 		TrustedProxies:  "trusted-proxies",
-
-		DbType:      "db-type",
-		DbAddress:   "db-address",
-		DbPort:      "db-port",
-		DbUser:      "db-user",
-		DbPassword:  "db-password",
-		DbDatabase:  "db-database",
-		DbTLSMode:   "db-tls-mode",
-		DbTLSCACert: "db-tls-ca-cert",
+		UnixSocket:      "unix-socket",
+
+		DbType:            "db-type",
+		DbAddress:         "db-address",
+		DbPort:            "db-port",
+		DbUser:            "db-user",
+		DbPassword:        "db-password",
+		DbDatabase:        "db-database",
+		DbTLSMode:         "db-tls-mode",
+		DbTLSCACert:       "db-tls-ca-cert",
+		DbApplicationName: "db-application-name",
 
 		TemplateBaseDir: "template-basedir",
 		AssetBaseDir:    "asset-basedir",
@@ -488,9 +1297,15 @@ func GetFlagNames() Flags {
 		AccountsOpenRegistration: "accounts-open-registration",
 		AccountsApprovalRequired: "accounts-approval-required",
 		AccountsReasonRequired:   "accounts-reason-required",
+		AccountsAllowCustomCSS:   "accounts-allow-custom-css",
+		AccountsCustomCSSLength:  "accounts-custom-css-length",
+		AccountsKeyType:          "accounts-key-type",
 
 		MediaMaxImageSize:        "media-max-image-size",
 		MediaMaxVideoSize:        "media-max-video-size",
+		MediaMaxVideoDuration:    "media-max-video-duration",
+		MediaFFmpegPath:          "media-ffmpeg-path",
+		MediaFFprobePath:         "media-ffprobe-path",
 		MediaMinDescriptionChars: "media-min-description-chars",
 		MediaMaxDescriptionChars: "media-max-description-chars",
 
@@ -499,6 +1314,12 @@ func GetFlagNames() Flags {
 		StorageServeProtocol: "storage-serve-protocol",
 		StorageServeHost:     "storage-serve-host",
 		StorageServeBasePath: "storage-serve-base-path",
+		StorageS3Endpoint:    "storage-s3-endpoint",
+		StorageS3Region:      "storage-s3-region",
+		StorageS3Bucket:      "storage-s3-bucket",
+		StorageS3AccessKey:   "storage-s3-access-key",
+		StorageS3SecretKey:   "storage-s3-secret-key",
+		StorageS3Presign:     "storage-s3-presign",
 
 		StatusesMaxChars:           "statuses-max-chars",
 		StatusesCWMaxChars:         "statuses-cw-max-chars",
@@ -510,6 +1331,12 @@ func GetFlagNames() Flags {
 		LetsEncryptPort:         "letsencrypt-port",
 		LetsEncryptCertDir:      "letsencrypt-cert-dir",
 		LetsEncryptEmailAddress: "letsencrypt-email",
+		LetsEncryptChallenge:    "letsencrypt-challenge",
+		LetsEncryptKeyType:      "letsencrypt-key-type",
+
+		LetsEncryptDNSProvider:        "letsencrypt-dns-provider",
+		LetsEncryptDNSExecPath:        "letsencrypt-dns-exec-path",
+		LetsEncryptDNSHTTPReqEndpoint: "letsencrypt-dns-httpreq-endpoint",
 
 		OIDCEnabled:          "oidc-enabled",
 		OIDCIdpName:          "oidc-idp-name",
@@ -518,6 +1345,99 @@ func GetFlagNames() Flags {
 		OIDCClientID:         "oidc-client-id",
 		OIDCClientSecret:     "oidc-client-secret",
 		OIDCScopes:           "oidc-scopes",
+		OIDCUsernameClaim:    "oidc-username-claim",
+		OIDCEmailClaim:       "oidc-email-claim",
+		OIDCGroupsClaim:      "oidc-groups-claim",
+		OIDCAdminGroups:      "oidc-admin-groups",
+		OIDCModeratorGroups:  "oidc-moderator-groups",
+		OIDCUsernameConflict: "oidc-username-conflict",
+
+		ThrottlingSearchConcurrency:    "throttling-search-concurrency",
+		ThrottlingSearchPerIPPerMinute: "throttling-search-per-ip-per-minute",
+		ThrottlingMediaConcurrency:     "throttling-media-concurrency",
+		ThrottlingMediaPerIPPerMinute:  "throttling-media-per-ip-per-minute",
+		ThrottlingInboxConcurrency:     "throttling-inbox-concurrency",
+		ThrottlingInboxPerIPPerMinute:  "throttling-inbox-per-ip-per-minute",
+
+		CaptchaProvider:  "captcha-provider",
+		CaptchaSiteKey:   "captcha-site-key",
+		CaptchaSecretKey: "captcha-secret-key",
+		CaptchaURL:       "captcha-url",
+
+		SpamEnabled:                  "spam-enabled",
+		SpamReportScore:              "spam-report-score",
+		SpamHoldScore:                "spam-hold-score",
+		SpamDropScore:                "spam-drop-score",
+		SpamNewAccountHours:          "spam-new-account-hours",
+		SpamFirstContactMentionScore: "spam-first-contact-mention-score",
+		SpamLinkOnlyScore:            "spam-link-only-score",
+		SpamKeywords:                 "spam-keywords",
+		SpamKeywordScore:             "spam-keyword-score",
+
+		HealthToken: "health-token",
+
+		RequestLimitsTimeout:           "request-limits-timeout",
+		RequestLimitsMediaTimeout:      "request-limits-media-timeout",
+		RequestLimitsInboxTimeout:      "request-limits-inbox-timeout",
+		RequestLimitsBodySize:          "request-limits-body-size",
+		RequestLimitsMediaBodySize:     "request-limits-media-body-size",
+		RequestLimitsInboxBodySize:     "request-limits-inbox-body-size",
+		RequestLimitsReadHeaderTimeout: "request-limits-read-header-timeout",
+		RequestLimitsReadTimeout:       "request-limits-read-timeout",
+		RequestLimitsIdleTimeout:       "request-limits-idle-timeout",
+
+		HiddenServicesOnionHost: "hidden-services-onion-host",
+		HiddenServicesTorProxy:  "hidden-services-tor-proxy",
+		HiddenServicesI2PProxy:  "hidden-services-i2p-proxy",
+
+		OutboundProxyURL:     "outbound-proxy-url",
+		OutboundProxyNoProxy: "outbound-proxy-no-proxy",
+		OutboundProxyDomains: "outbound-proxy-domains",
+
+		ErrorReportingDSN:         "error-reporting-dsn",
+		ErrorReportingWebhookURL:  "error-reporting-webhook-url",
+		ErrorReportingEnvironment: "error-reporting-environment",
+
+		RetentionRemoteStatusDays:     "retention-remote-status-days",
+		RetentionRemoteAccountDays:    "retention-remote-account-days",
+		RetentionSweepIntervalMinutes: "retention-sweep-interval-minutes",
+		RetentionInboxActivityDays:    "retention-inbox-activity-days",
+		RetentionFailedDeliveryDays:   "retention-failed-delivery-days",
+
+		FederationSendBlocks: "federation-send-blocks",
+		FederationMode:       "federation-mode",
+
+		InboxFilterMaxMentions:      "inbox-filter-max-mentions",
+		InboxFilterRejectLinkOnly:   "inbox-filter-reject-link-only",
+		InboxFilterNewAccountHours:  "inbox-filter-new-account-hours",
+		InboxFilterRejectKeywords:   "inbox-filter-reject-keywords",
+		InboxFilterReportRejections: "inbox-filter-report-rejections",
+
+		FederationLimitsPayloadSize:         "federation-limits-payload-size",
+		FederationLimitsCollectionPages:     "federation-limits-collection-pages",
+		FederationLimitsCollectionItems:     "federation-limits-collection-items",
+		FederationLimitsThreadDepth:         "federation-limits-thread-depth",
+		FederationLimitsBackfillStatuses:    "federation-limits-backfill-statuses",
+		FederationLimitsDeliveryConcurrency: "federation-limits-delivery-concurrency",
+		FederationLimitsDeliveryAttempts:    "federation-limits-delivery-attempts",
+
+		FederationCacheTTLSeconds: "federation-cache-ttl-seconds",
+		FederationCacheMaxEntries: "federation-cache-max-entries",
+
+		WebPushContactEmail:    "web-push-contact-email",
+		WebPushVAPIDPublicKey:  "web-push-vapid-public-key",
+		WebPushVAPIDPrivateKey: "web-push-vapid-private-key",
+
+		SMTPHost:     "smtp-host",
+		SMTPPort:     "smtp-port",
+		SMTPUsername: "smtp-username",
+		SMTPPassword: "smtp-password",
+		SMTPFrom:     "smtp-from",
+
+		RateLimitAPIRequests:   "rate-limit-api-requests",
+		RateLimitInboxRequests: "rate-limit-inbox-requests",
+		RateLimitAuthRequests:  "rate-limit-auth-requests",
+		RateLimitExemptIPs:     "rate-limit-exempt-ips",
 	}
 }
 
@@ -526,6 +1446,7 @@ func GetFlagNames() Flags {
 func GetEnvNames() Flags {
 	return Flags{
 		LogLevel:        "GTS_LOG_LEVEL",
+		LogFormat:       "GTS_LOG_FORMAT",
 		ApplicationName: "GTS_APPLICATION_NAME",
 		ConfigPath:      "GTS_CONFIG_PATH",
 		Host:            "GTS_HOST",
@@ -533,15 +1454,17 @@ func GetEnvNames() Flags {
 		Protocol:        "GTS_PROTOCOL",
 		Port:            "GTS_PORT",
 		TrustedProxies:  "GTS_TRUSTED_PROXIES",
-
-		DbType:      "GTS_DB_TYPE",
-		DbAddress:   "GTS_DB_ADDRESS",
-		DbPort:      "GTS_DB_PORT",
-		DbUser:      "GTS_DB_USER",
-		DbPassword:  "GTS_DB_PASSWORD",
-		DbDatabase:  "GTS_DB_DATABASE",
-		DbTLSMode:   "GTS_DB_TLS_MODE",
-		DbTLSCACert: "GTS_DB_CA_CERT",
+		UnixSocket:      "GTS_UNIX_SOCKET",
+
+		DbType:            "GTS_DB_TYPE",
+		DbAddress:         "GTS_DB_ADDRESS",
+		DbPort:            "GTS_DB_PORT",
+		DbUser:            "GTS_DB_USER",
+		DbPassword:        "GTS_DB_PASSWORD",
+		DbDatabase:        "GTS_DB_DATABASE",
+		DbTLSMode:         "GTS_DB_TLS_MODE",
+		DbTLSCACert:       "GTS_DB_TLS_CA_CERT",
+		DbApplicationName: "GTS_DB_APPLICATION_NAME",
 
 		TemplateBaseDir: "GTS_TEMPLATE_BASEDIR",
 		AssetBaseDir:    "GTS_ASSET_BASEDIR",
@@ -549,9 +1472,15 @@ func GetEnvNames() Flags {
 		AccountsOpenRegistration: "GTS_ACCOUNTS_OPEN_REGISTRATION",
 		AccountsApprovalRequired: "GTS_ACCOUNTS_APPROVAL_REQUIRED",
 		AccountsReasonRequired:   "GTS_ACCOUNTS_REASON_REQUIRED",
+		AccountsAllowCustomCSS:   "GTS_ACCOUNTS_ALLOW_CUSTOM_CSS",
+		AccountsCustomCSSLength:  "GTS_ACCOUNTS_CUSTOM_CSS_LENGTH",
+		AccountsKeyType:          "GTS_ACCOUNTS_KEY_TYPE",
 
 		MediaMaxImageSize:        "GTS_MEDIA_MAX_IMAGE_SIZE",
 		MediaMaxVideoSize:        "GTS_MEDIA_MAX_VIDEO_SIZE",
+		MediaMaxVideoDuration:    "GTS_MEDIA_MAX_VIDEO_DURATION",
+		MediaFFmpegPath:          "GTS_MEDIA_FFMPEG_PATH",
+		MediaFFprobePath:         "GTS_MEDIA_FFPROBE_PATH",
 		MediaMinDescriptionChars: "GTS_MEDIA_MIN_DESCRIPTION_CHARS",
 		MediaMaxDescriptionChars: "GTS_MEDIA_MAX_DESCRIPTION_CHARS",
 
@@ -560,6 +1489,12 @@ func GetEnvNames() Flags {
 		StorageServeProtocol: "GTS_STORAGE_SERVE_PROTOCOL",
 		StorageServeHost:     "GTS_STORAGE_SERVE_HOST",
 		StorageServeBasePath: "GTS_STORAGE_SERVE_BASE_PATH",
+		StorageS3Endpoint:    "GTS_STORAGE_S3_ENDPOINT",
+		StorageS3Region:      "GTS_STORAGE_S3_REGION",
+		StorageS3Bucket:      "GTS_STORAGE_S3_BUCKET",
+		StorageS3AccessKey:   "GTS_STORAGE_S3_ACCESS_KEY",
+		StorageS3SecretKey:   "GTS_STORAGE_S3_SECRET_KEY",
+		StorageS3Presign:     "GTS_STORAGE_S3_PRESIGN",
 
 		StatusesMaxChars:           "GTS_STATUSES_MAX_CHARS",
 		StatusesCWMaxChars:         "GTS_STATUSES_CW_MAX_CHARS",
@@ -571,6 +1506,12 @@ func GetEnvNames() Flags {
 		LetsEncryptPort:         "GTS_LETSENCRYPT_PORT",
 		LetsEncryptCertDir:      "GTS_LETSENCRYPT_CERT_DIR",
 		LetsEncryptEmailAddress: "GTS_LETSENCRYPT_EMAIL",
+		LetsEncryptChallenge:    "GTS_LETSENCRYPT_CHALLENGE",
+		LetsEncryptKeyType:      "GTS_LETSENCRYPT_KEY_TYPE",
+
+		LetsEncryptDNSProvider:        "GTS_LETSENCRYPT_DNS_PROVIDER",
+		LetsEncryptDNSExecPath:        "GTS_LETSENCRYPT_DNS_EXEC_PATH",
+		LetsEncryptDNSHTTPReqEndpoint: "GTS_LETSENCRYPT_DNS_HTTPREQ_ENDPOINT",
 
 		OIDCEnabled:          "GTS_OIDC_ENABLED",
 		OIDCIdpName:          "GTS_OIDC_IDP_NAME",
@@ -579,5 +1520,98 @@ func GetEnvNames() Flags {
 		OIDCClientID:         "GTS_OIDC_CLIENT_ID",
 		OIDCClientSecret:     "GTS_OIDC_CLIENT_SECRET",
 		OIDCScopes:           "GTS_OIDC_SCOPES",
+		OIDCUsernameClaim:    "GTS_OIDC_USERNAME_CLAIM",
+		OIDCEmailClaim:       "GTS_OIDC_EMAIL_CLAIM",
+		OIDCGroupsClaim:      "GTS_OIDC_GROUPS_CLAIM",
+		OIDCAdminGroups:      "GTS_OIDC_ADMIN_GROUPS",
+		OIDCModeratorGroups:  "GTS_OIDC_MODERATOR_GROUPS",
+		OIDCUsernameConflict: "GTS_OIDC_USERNAME_CONFLICT",
+
+		ThrottlingSearchConcurrency:    "GTS_THROTTLING_SEARCH_CONCURRENCY",
+		ThrottlingSearchPerIPPerMinute: "GTS_THROTTLING_SEARCH_PER_IP_PER_MINUTE",
+		ThrottlingMediaConcurrency:     "GTS_THROTTLING_MEDIA_CONCURRENCY",
+		ThrottlingMediaPerIPPerMinute:  "GTS_THROTTLING_MEDIA_PER_IP_PER_MINUTE",
+		ThrottlingInboxConcurrency:     "GTS_THROTTLING_INBOX_CONCURRENCY",
+		ThrottlingInboxPerIPPerMinute:  "GTS_THROTTLING_INBOX_PER_IP_PER_MINUTE",
+
+		CaptchaProvider:  "GTS_CAPTCHA_PROVIDER",
+		CaptchaSiteKey:   "GTS_CAPTCHA_SITE_KEY",
+		CaptchaSecretKey: "GTS_CAPTCHA_SECRET_KEY",
+		CaptchaURL:       "GTS_CAPTCHA_URL",
+
+		SpamEnabled:                  "GTS_SPAM_ENABLED",
+		SpamReportScore:              "GTS_SPAM_REPORT_SCORE",
+		SpamHoldScore:                "GTS_SPAM_HOLD_SCORE",
+		SpamDropScore:                "GTS_SPAM_DROP_SCORE",
+		SpamNewAccountHours:          "GTS_SPAM_NEW_ACCOUNT_HOURS",
+		SpamFirstContactMentionScore: "GTS_SPAM_FIRST_CONTACT_MENTION_SCORE",
+		SpamLinkOnlyScore:            "GTS_SPAM_LINK_ONLY_SCORE",
+		SpamKeywords:                 "GTS_SPAM_KEYWORDS",
+		SpamKeywordScore:             "GTS_SPAM_KEYWORD_SCORE",
+
+		HealthToken: "GTS_HEALTH_TOKEN",
+
+		RequestLimitsTimeout:           "GTS_REQUEST_LIMITS_TIMEOUT",
+		RequestLimitsMediaTimeout:      "GTS_REQUEST_LIMITS_MEDIA_TIMEOUT",
+		RequestLimitsInboxTimeout:      "GTS_REQUEST_LIMITS_INBOX_TIMEOUT",
+		RequestLimitsBodySize:          "GTS_REQUEST_LIMITS_BODY_SIZE",
+		RequestLimitsMediaBodySize:     "GTS_REQUEST_LIMITS_MEDIA_BODY_SIZE",
+		RequestLimitsInboxBodySize:     "GTS_REQUEST_LIMITS_INBOX_BODY_SIZE",
+		RequestLimitsReadHeaderTimeout: "GTS_REQUEST_LIMITS_READ_HEADER_TIMEOUT",
+		RequestLimitsReadTimeout:       "GTS_REQUEST_LIMITS_READ_TIMEOUT",
+		RequestLimitsIdleTimeout:       "GTS_REQUEST_LIMITS_IDLE_TIMEOUT",
+
+		HiddenServicesOnionHost: "GTS_HIDDEN_SERVICES_ONION_HOST",
+		HiddenServicesTorProxy:  "GTS_HIDDEN_SERVICES_TOR_PROXY",
+		HiddenServicesI2PProxy:  "GTS_HIDDEN_SERVICES_I2P_PROXY",
+
+		OutboundProxyURL:     "GTS_OUTBOUND_PROXY_URL",
+		OutboundProxyNoProxy: "GTS_OUTBOUND_PROXY_NO_PROXY",
+		OutboundProxyDomains: "GTS_OUTBOUND_PROXY_DOMAINS",
+
+		ErrorReportingDSN:         "GTS_ERROR_REPORTING_DSN",
+		ErrorReportingWebhookURL:  "GTS_ERROR_REPORTING_WEBHOOK_URL",
+		ErrorReportingEnvironment: "GTS_ERROR_REPORTING_ENVIRONMENT",
+
+		RetentionRemoteStatusDays:     "GTS_RETENTION_REMOTE_STATUS_DAYS",
+		RetentionRemoteAccountDays:    "GTS_RETENTION_REMOTE_ACCOUNT_DAYS",
+		RetentionSweepIntervalMinutes: "GTS_RETENTION_SWEEP_INTERVAL_MINUTES",
+		RetentionInboxActivityDays:    "GTS_RETENTION_INBOX_ACTIVITY_DAYS",
+		RetentionFailedDeliveryDays:   "GTS_RETENTION_FAILED_DELIVERY_DAYS",
+
+		FederationSendBlocks: "GTS_FEDERATION_SEND_BLOCKS",
+		FederationMode:       "GTS_FEDERATION_MODE",
+
+		InboxFilterMaxMentions:      "GTS_INBOX_FILTER_MAX_MENTIONS",
+		InboxFilterRejectLinkOnly:   "GTS_INBOX_FILTER_REJECT_LINK_ONLY",
+		InboxFilterNewAccountHours:  "GTS_INBOX_FILTER_NEW_ACCOUNT_HOURS",
+		InboxFilterRejectKeywords:   "GTS_INBOX_FILTER_REJECT_KEYWORDS",
+		InboxFilterReportRejections: "GTS_INBOX_FILTER_REPORT_REJECTIONS",
+
+		FederationLimitsPayloadSize:         "GTS_FEDERATION_LIMITS_PAYLOAD_SIZE",
+		FederationLimitsCollectionPages:     "GTS_FEDERATION_LIMITS_COLLECTION_PAGES",
+		FederationLimitsCollectionItems:     "GTS_FEDERATION_LIMITS_COLLECTION_ITEMS",
+		FederationLimitsThreadDepth:         "GTS_FEDERATION_LIMITS_THREAD_DEPTH",
+		FederationLimitsBackfillStatuses:    "GTS_FEDERATION_LIMITS_BACKFILL_STATUSES",
+		FederationLimitsDeliveryConcurrency: "GTS_FEDERATION_LIMITS_DELIVERY_CONCURRENCY",
+		FederationLimitsDeliveryAttempts:    "GTS_FEDERATION_LIMITS_DELIVERY_ATTEMPTS",
+
+		FederationCacheTTLSeconds: "GTS_FEDERATION_CACHE_TTL_SECONDS",
+		FederationCacheMaxEntries: "GTS_FEDERATION_CACHE_MAX_ENTRIES",
+
+		WebPushContactEmail:    "GTS_WEB_PUSH_CONTACT_EMAIL",
+		WebPushVAPIDPublicKey:  "GTS_WEB_PUSH_VAPID_PUBLIC_KEY",
+		WebPushVAPIDPrivateKey: "GTS_WEB_PUSH_VAPID_PRIVATE_KEY",
+
+		SMTPHost:     "GTS_SMTP_HOST",
+		SMTPPort:     "GTS_SMTP_PORT",
+		SMTPUsername: "GTS_SMTP_USERNAME",
+		SMTPPassword: "GTS_SMTP_PASSWORD",
+		SMTPFrom:     "GTS_SMTP_FROM",
+
+		RateLimitAPIRequests:   "GTS_RATE_LIMIT_API_REQUESTS",
+		RateLimitInboxRequests: "GTS_RATE_LIMIT_INBOX_REQUESTS",
+		RateLimitAuthRequests:  "GTS_RATE_LIMIT_AUTH_REQUESTS",
+		RateLimitExemptIPs:     "GTS_RATE_LIMIT_EXEMPT_IPS",
 	}
 }
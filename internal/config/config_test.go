@@ -0,0 +1,162 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// fakeFlags implements config.KeyedFlags. Flags in set are reported as
+// explicitly set; everything else returns the value from values, if any.
+type fakeFlags struct {
+	values map[string]interface{}
+	set    map[string]bool
+}
+
+func (f *fakeFlags) Bool(k string) bool {
+	b, _ := f.values[k].(bool)
+	return b
+}
+
+func (f *fakeFlags) String(k string) string {
+	s, _ := f.values[k].(string)
+	return s
+}
+
+func (f *fakeFlags) StringSlice(k string) []string {
+	s, _ := f.values[k].([]string)
+	return s
+}
+
+func (f *fakeFlags) Int(k string) int {
+	i, _ := f.values[k].(int)
+	return i
+}
+
+func (f *fakeFlags) IsSet(k string) bool {
+	return f.set[k]
+}
+
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+// allSetFlags implements config.KeyedFlags, with every flag
+// explicitly set to a non-zero value of whichever type is asked for.
+type allSetFlags struct{}
+
+func (f allSetFlags) Bool(k string) bool            { return true }
+func (f allSetFlags) String(k string) string        { return "some-" + k }
+func (f allSetFlags) StringSlice(k string) []string { return []string{"some-" + k} }
+func (f allSetFlags) Int(k string) int              { return 1 }
+func (f allSetFlags) IsSet(k string) bool           { return true }
+
+// checkAllSet fails the test for every yaml-tagged field in v, or in structs nested within it, that is a zero value.
+func (suite *ConfigTestSuite) checkAllSet(prefix string, v reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("yaml")
+		if tag == "" {
+			continue
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Ptr && fv.Elem().Kind() == reflect.Struct {
+			suite.checkAllSet(prefix+tag+".", fv)
+			continue
+		}
+		suite.False(fv.IsZero(), "config field %s%s could not be set with a flag", prefix, tag)
+	}
+}
+
+func (suite *ConfigTestSuite) TestEveryFieldHasAFlag() {
+	c := config.Empty()
+	err := c.ParseCLIFlags(allSetFlags{}, "")
+	suite.NoError(err)
+
+	suite.checkAllSet("", reflect.ValueOf(c))
+}
+
+func (suite *ConfigTestSuite) TestEnvNamesMatchFlagNames() {
+	fn := reflect.ValueOf(config.GetFlagNames())
+	en := reflect.ValueOf(config.GetEnvNames())
+	for i := 0; i < fn.NumField(); i++ {
+		flag := fn.Field(i).String()
+		suite.NotEmpty(flag, "flag name for %s is empty", fn.Type().Field(i).Name)
+		expected := "GTS_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+		suite.Equal(expected, en.Field(i).String(), "env name for flag %s", flag)
+	}
+}
+
+func (suite *ConfigTestSuite) TestPrecedence() {
+	fn := config.GetFlagNames()
+	path := filepath.Join(suite.T().TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`host: "file.example.org"
+port: 9000
+accounts:
+  openRegistration: false
+`), 0600)
+	suite.NoError(err)
+
+	c, err := config.FromFile(path)
+	suite.NoError(err)
+
+	defaults := config.GetDefaults()
+	f := &fakeFlags{
+		values: map[string]interface{}{
+			fn.Host:                     "flag.example.org",
+			fn.Port:                     defaults.Port,
+			fn.Protocol:                 defaults.Protocol,
+			fn.AccountsOpenRegistration: defaults.AccountsOpenRegistration,
+			fn.AccountsApprovalRequired: defaults.AccountsRequireApproval,
+			fn.LetsEncryptEnabled:       defaults.LetsEncryptEnabled,
+		},
+		set: map[string]bool{
+			fn.Host: true,
+		},
+	}
+	err = c.ParseCLIFlags(f, "")
+	suite.NoError(err)
+
+	// flag/env beats file
+	suite.Equal("flag.example.org", c.Host)
+	// file beats default
+	suite.Equal(9000, c.Port)
+	suite.False(c.AccountsConfig.OpenRegistration)
+	// default is used when neither file nor flag/env set it
+	suite.Equal(defaults.Protocol, c.Protocol)
+	suite.Equal(defaults.AccountsRequireApproval, c.AccountsConfig.RequireApproval)
+	suite.Equal(defaults.LetsEncryptEnabled, c.LetsEncryptConfig.Enabled)
+	// the db application name falls back to the application name
+	suite.Equal(c.ApplicationName, c.DBConfig.ApplicationName)
+}
+
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, &ConfigTestSuite{})
+}
@@ -7,12 +7,14 @@ func TestDefault() *Config {
 	defaults := GetTestDefaults()
 	return &Config{
 		LogLevel:        defaults.LogLevel,
+		LogFormat:       defaults.LogFormat,
 		ApplicationName: defaults.ApplicationName,
 		Host:            defaults.Host,
 		AccountDomain:   defaults.AccountDomain,
 		Protocol:        defaults.Protocol,
 		Port:            defaults.Port,
 		TrustedProxies:  defaults.TrustedProxies,
+		UnixSocket:      defaults.UnixSocket,
 		SoftwareVersion: defaults.SoftwareVersion,
 		DBConfig: &DBConfig{
 			Type:            defaults.DbType,
@@ -31,10 +33,16 @@ func TestDefault() *Config {
 			OpenRegistration: defaults.AccountsOpenRegistration,
 			RequireApproval:  defaults.AccountsRequireApproval,
 			ReasonRequired:   defaults.AccountsReasonRequired,
+			AllowCustomCSS:   defaults.AccountsAllowCustomCSS,
+			CustomCSSLength:  defaults.AccountsCustomCSSLength,
+			KeyType:          defaults.AccountsKeyType,
 		},
 		MediaConfig: &MediaConfig{
 			MaxImageSize:        defaults.MediaMaxImageSize,
 			MaxVideoSize:        defaults.MediaMaxVideoSize,
+			MaxVideoDuration:    defaults.MediaMaxVideoDuration,
+			FFmpegPath:          defaults.MediaFFmpegPath,
+			FFprobePath:         defaults.MediaFFprobePath,
 			MinDescriptionChars: defaults.MediaMinDescriptionChars,
 			MaxDescriptionChars: defaults.MediaMaxDescriptionChars,
 		},
@@ -44,6 +52,12 @@ func TestDefault() *Config {
 			ServeProtocol: defaults.StorageServeProtocol,
 			ServeHost:     defaults.StorageServeHost,
 			ServeBasePath: defaults.StorageServeBasePath,
+			S3Endpoint:    defaults.StorageS3Endpoint,
+			S3Region:      defaults.StorageS3Region,
+			S3Bucket:      defaults.StorageS3Bucket,
+			S3AccessKey:   defaults.StorageS3AccessKey,
+			S3SecretKey:   defaults.StorageS3SecretKey,
+			S3Presign:     defaults.StorageS3Presign,
 		},
 		StatusesConfig: &StatusesConfig{
 			MaxChars:           defaults.StatusesMaxChars,
@@ -57,6 +71,12 @@ func TestDefault() *Config {
 			Port:         defaults.LetsEncryptPort,
 			CertDir:      defaults.LetsEncryptCertDir,
 			EmailAddress: defaults.LetsEncryptEmailAddress,
+			Challenge:    defaults.LetsEncryptChallenge,
+			KeyType:      defaults.LetsEncryptKeyType,
+
+			DNSProvider:        defaults.LetsEncryptDNSProvider,
+			DNSExecPath:        defaults.LetsEncryptDNSExecPath,
+			DNSHTTPReqEndpoint: defaults.LetsEncryptDNSHTTPReqEndpoint,
 		},
 		OIDCConfig: &OIDCConfig{
 			Enabled:          defaults.OIDCEnabled,
@@ -66,6 +86,115 @@ func TestDefault() *Config {
 			ClientID:         defaults.OIDCClientID,
 			ClientSecret:     defaults.OIDCClientSecret,
 			Scopes:           defaults.OIDCScopes,
+			UsernameClaim:    defaults.OIDCUsernameClaim,
+			EmailClaim:       defaults.OIDCEmailClaim,
+			GroupsClaim:      defaults.OIDCGroupsClaim,
+			AdminGroups:      defaults.OIDCAdminGroups,
+			ModeratorGroups:  defaults.OIDCModeratorGroups,
+			UsernameConflict: defaults.OIDCUsernameConflict,
+		},
+		ThrottlingConfig: &ThrottlingConfig{
+			SearchConcurrency:    defaults.ThrottlingSearchConcurrency,
+			SearchPerIPPerMinute: defaults.ThrottlingSearchPerIPPerMinute,
+			MediaConcurrency:     defaults.ThrottlingMediaConcurrency,
+			MediaPerIPPerMinute:  defaults.ThrottlingMediaPerIPPerMinute,
+			InboxConcurrency:     defaults.ThrottlingInboxConcurrency,
+			InboxPerIPPerMinute:  defaults.ThrottlingInboxPerIPPerMinute,
+		},
+		CaptchaConfig: &CaptchaConfig{
+			Provider:  defaults.CaptchaProvider,
+			SiteKey:   defaults.CaptchaSiteKey,
+			SecretKey: defaults.CaptchaSecretKey,
+			URL:       defaults.CaptchaURL,
+		},
+		SpamConfig: &SpamConfig{
+			Enabled:                  defaults.SpamEnabled,
+			ReportScore:              defaults.SpamReportScore,
+			HoldScore:                defaults.SpamHoldScore,
+			DropScore:                defaults.SpamDropScore,
+			NewAccountHours:          defaults.SpamNewAccountHours,
+			FirstContactMentionScore: defaults.SpamFirstContactMentionScore,
+			LinkOnlyScore:            defaults.SpamLinkOnlyScore,
+			Keywords:                 defaults.SpamKeywords,
+			KeywordScore:             defaults.SpamKeywordScore,
+		},
+		HealthConfig: &HealthConfig{
+			Token: defaults.HealthToken,
+		},
+		RequestLimitsConfig: &RequestLimitsConfig{
+			Timeout:           defaults.RequestLimitsTimeout,
+			MediaTimeout:      defaults.RequestLimitsMediaTimeout,
+			InboxTimeout:      defaults.RequestLimitsInboxTimeout,
+			BodySize:          defaults.RequestLimitsBodySize,
+			MediaBodySize:     defaults.RequestLimitsMediaBodySize,
+			InboxBodySize:     defaults.RequestLimitsInboxBodySize,
+			ReadHeaderTimeout: defaults.RequestLimitsReadHeaderTimeout,
+			ReadTimeout:       defaults.RequestLimitsReadTimeout,
+			IdleTimeout:       defaults.RequestLimitsIdleTimeout,
+		},
+		HiddenServicesConfig: &HiddenServicesConfig{
+			OnionHost: defaults.HiddenServicesOnionHost,
+			TorProxy:  defaults.HiddenServicesTorProxy,
+			I2PProxy:  defaults.HiddenServicesI2PProxy,
+		},
+		OutboundProxyConfig: &OutboundProxyConfig{
+			URL:     defaults.OutboundProxyURL,
+			NoProxy: defaults.OutboundProxyNoProxy,
+			Domains: defaults.OutboundProxyDomains,
+		},
+		ErrorReportingConfig: &ErrorReportingConfig{
+			DSN:         defaults.ErrorReportingDSN,
+			WebhookURL:  defaults.ErrorReportingWebhookURL,
+			Environment: defaults.ErrorReportingEnvironment,
+		},
+		RetentionConfig: &RetentionConfig{
+			RemoteStatusDays:     defaults.RetentionRemoteStatusDays,
+			RemoteAccountDays:    defaults.RetentionRemoteAccountDays,
+			SweepIntervalMinutes: defaults.RetentionSweepIntervalMinutes,
+			InboxActivityDays:    defaults.RetentionInboxActivityDays,
+			FailedDeliveryDays:   defaults.RetentionFailedDeliveryDays,
+		},
+		FederationConfig: &FederationConfig{
+			SendBlocks: defaults.FederationSendBlocks,
+			Mode:       defaults.FederationMode,
+		},
+		InboxFilterConfig: &InboxFilterConfig{
+			MaxMentions:      defaults.InboxFilterMaxMentions,
+			RejectLinkOnly:   defaults.InboxFilterRejectLinkOnly,
+			NewAccountHours:  defaults.InboxFilterNewAccountHours,
+			RejectKeywords:   defaults.InboxFilterRejectKeywords,
+			ReportRejections: defaults.InboxFilterReportRejections,
+		},
+		FederationLimitsConfig: &FederationLimitsConfig{
+			PayloadSize:         defaults.FederationLimitsPayloadSize,
+			CollectionPages:     defaults.FederationLimitsCollectionPages,
+			CollectionItems:     defaults.FederationLimitsCollectionItems,
+			ThreadDepth:         defaults.FederationLimitsThreadDepth,
+			BackfillStatuses:    defaults.FederationLimitsBackfillStatuses,
+			DeliveryConcurrency: defaults.FederationLimitsDeliveryConcurrency,
+			DeliveryAttempts:    defaults.FederationLimitsDeliveryAttempts,
+		},
+		FederationCacheConfig: &FederationCacheConfig{
+			TTLSeconds: defaults.FederationCacheTTLSeconds,
+			MaxEntries: defaults.FederationCacheMaxEntries,
+		},
+		WebPushConfig: &WebPushConfig{
+			ContactEmail:    defaults.WebPushContactEmail,
+			VAPIDPublicKey:  defaults.WebPushVAPIDPublicKey,
+			VAPIDPrivateKey: defaults.WebPushVAPIDPrivateKey,
+		},
+		SMTPConfig: &SMTPConfig{
+			Host:     defaults.SMTPHost,
+			Port:     defaults.SMTPPort,
+			Username: defaults.SMTPUsername,
+			Password: defaults.SMTPPassword,
+			From:     defaults.SMTPFrom,
+		},
+		RateLimitConfig: &RateLimitConfig{
+			APIRequests:   defaults.RateLimitAPIRequests,
+			InboxRequests: defaults.RateLimitInboxRequests,
+			AuthRequests:  defaults.RateLimitAuthRequests,
+			ExemptIPs:     defaults.RateLimitExemptIPs,
 		},
 	}
 }
@@ -75,11 +204,13 @@ func Default() *Config {
 	defaults := GetDefaults()
 	return &Config{
 		LogLevel:        defaults.LogLevel,
+		LogFormat:       defaults.LogFormat,
 		ApplicationName: defaults.ApplicationName,
 		Host:            defaults.Host,
 		Protocol:        defaults.Protocol,
 		Port:            defaults.Port,
 		TrustedProxies:  defaults.TrustedProxies,
+		UnixSocket:      defaults.UnixSocket,
 		SoftwareVersion: defaults.SoftwareVersion,
 		DBConfig: &DBConfig{
 			Type:            defaults.DbType,
@@ -98,10 +229,16 @@ func Default() *Config {
 			OpenRegistration: defaults.AccountsOpenRegistration,
 			RequireApproval:  defaults.AccountsRequireApproval,
 			ReasonRequired:   defaults.AccountsReasonRequired,
+			AllowCustomCSS:   defaults.AccountsAllowCustomCSS,
+			CustomCSSLength:  defaults.AccountsCustomCSSLength,
+			KeyType:          defaults.AccountsKeyType,
 		},
 		MediaConfig: &MediaConfig{
 			MaxImageSize:        defaults.MediaMaxImageSize,
 			MaxVideoSize:        defaults.MediaMaxVideoSize,
+			MaxVideoDuration:    defaults.MediaMaxVideoDuration,
+			FFmpegPath:          defaults.MediaFFmpegPath,
+			FFprobePath:         defaults.MediaFFprobePath,
 			MinDescriptionChars: defaults.MediaMinDescriptionChars,
 			MaxDescriptionChars: defaults.MediaMaxDescriptionChars,
 		},
@@ -111,6 +248,12 @@ func Default() *Config {
 			ServeProtocol: defaults.StorageServeProtocol,
 			ServeHost:     defaults.StorageServeHost,
 			ServeBasePath: defaults.StorageServeBasePath,
+			S3Endpoint:    defaults.StorageS3Endpoint,
+			S3Region:      defaults.StorageS3Region,
+			S3Bucket:      defaults.StorageS3Bucket,
+			S3AccessKey:   defaults.StorageS3AccessKey,
+			S3SecretKey:   defaults.StorageS3SecretKey,
+			S3Presign:     defaults.StorageS3Presign,
 		},
 		StatusesConfig: &StatusesConfig{
 			MaxChars:           defaults.StatusesMaxChars,
@@ -124,6 +267,12 @@ func Default() *Config {
 			Port:         defaults.LetsEncryptPort,
 			CertDir:      defaults.LetsEncryptCertDir,
 			EmailAddress: defaults.LetsEncryptEmailAddress,
+			Challenge:    defaults.LetsEncryptChallenge,
+			KeyType:      defaults.LetsEncryptKeyType,
+
+			DNSProvider:        defaults.LetsEncryptDNSProvider,
+			DNSExecPath:        defaults.LetsEncryptDNSExecPath,
+			DNSHTTPReqEndpoint: defaults.LetsEncryptDNSHTTPReqEndpoint,
 		},
 		OIDCConfig: &OIDCConfig{
 			Enabled:          defaults.OIDCEnabled,
@@ -133,6 +282,115 @@ func Default() *Config {
 			ClientID:         defaults.OIDCClientID,
 			ClientSecret:     defaults.OIDCClientSecret,
 			Scopes:           defaults.OIDCScopes,
+			UsernameClaim:    defaults.OIDCUsernameClaim,
+			EmailClaim:       defaults.OIDCEmailClaim,
+			GroupsClaim:      defaults.OIDCGroupsClaim,
+			AdminGroups:      defaults.OIDCAdminGroups,
+			ModeratorGroups:  defaults.OIDCModeratorGroups,
+			UsernameConflict: defaults.OIDCUsernameConflict,
+		},
+		ThrottlingConfig: &ThrottlingConfig{
+			SearchConcurrency:    defaults.ThrottlingSearchConcurrency,
+			SearchPerIPPerMinute: defaults.ThrottlingSearchPerIPPerMinute,
+			MediaConcurrency:     defaults.ThrottlingMediaConcurrency,
+			MediaPerIPPerMinute:  defaults.ThrottlingMediaPerIPPerMinute,
+			InboxConcurrency:     defaults.ThrottlingInboxConcurrency,
+			InboxPerIPPerMinute:  defaults.ThrottlingInboxPerIPPerMinute,
+		},
+		CaptchaConfig: &CaptchaConfig{
+			Provider:  defaults.CaptchaProvider,
+			SiteKey:   defaults.CaptchaSiteKey,
+			SecretKey: defaults.CaptchaSecretKey,
+			URL:       defaults.CaptchaURL,
+		},
+		SpamConfig: &SpamConfig{
+			Enabled:                  defaults.SpamEnabled,
+			ReportScore:              defaults.SpamReportScore,
+			HoldScore:                defaults.SpamHoldScore,
+			DropScore:                defaults.SpamDropScore,
+			NewAccountHours:          defaults.SpamNewAccountHours,
+			FirstContactMentionScore: defaults.SpamFirstContactMentionScore,
+			LinkOnlyScore:            defaults.SpamLinkOnlyScore,
+			Keywords:                 defaults.SpamKeywords,
+			KeywordScore:             defaults.SpamKeywordScore,
+		},
+		HealthConfig: &HealthConfig{
+			Token: defaults.HealthToken,
+		},
+		RequestLimitsConfig: &RequestLimitsConfig{
+			Timeout:           defaults.RequestLimitsTimeout,
+			MediaTimeout:      defaults.RequestLimitsMediaTimeout,
+			InboxTimeout:      defaults.RequestLimitsInboxTimeout,
+			BodySize:          defaults.RequestLimitsBodySize,
+			MediaBodySize:     defaults.RequestLimitsMediaBodySize,
+			InboxBodySize:     defaults.RequestLimitsInboxBodySize,
+			ReadHeaderTimeout: defaults.RequestLimitsReadHeaderTimeout,
+			ReadTimeout:       defaults.RequestLimitsReadTimeout,
+			IdleTimeout:       defaults.RequestLimitsIdleTimeout,
+		},
+		HiddenServicesConfig: &HiddenServicesConfig{
+			OnionHost: defaults.HiddenServicesOnionHost,
+			TorProxy:  defaults.HiddenServicesTorProxy,
+			I2PProxy:  defaults.HiddenServicesI2PProxy,
+		},
+		OutboundProxyConfig: &OutboundProxyConfig{
+			URL:     defaults.OutboundProxyURL,
+			NoProxy: defaults.OutboundProxyNoProxy,
+			Domains: defaults.OutboundProxyDomains,
+		},
+		ErrorReportingConfig: &ErrorReportingConfig{
+			DSN:         defaults.ErrorReportingDSN,
+			WebhookURL:  defaults.ErrorReportingWebhookURL,
+			Environment: defaults.ErrorReportingEnvironment,
+		},
+		RetentionConfig: &RetentionConfig{
+			RemoteStatusDays:     defaults.RetentionRemoteStatusDays,
+			RemoteAccountDays:    defaults.RetentionRemoteAccountDays,
+			SweepIntervalMinutes: defaults.RetentionSweepIntervalMinutes,
+			InboxActivityDays:    defaults.RetentionInboxActivityDays,
+			FailedDeliveryDays:   defaults.RetentionFailedDeliveryDays,
+		},
+		FederationConfig: &FederationConfig{
+			SendBlocks: defaults.FederationSendBlocks,
+			Mode:       defaults.FederationMode,
+		},
+		InboxFilterConfig: &InboxFilterConfig{
+			MaxMentions:      defaults.InboxFilterMaxMentions,
+			RejectLinkOnly:   defaults.InboxFilterRejectLinkOnly,
+			NewAccountHours:  defaults.InboxFilterNewAccountHours,
+			RejectKeywords:   defaults.InboxFilterRejectKeywords,
+			ReportRejections: defaults.InboxFilterReportRejections,
+		},
+		FederationLimitsConfig: &FederationLimitsConfig{
+			PayloadSize:         defaults.FederationLimitsPayloadSize,
+			CollectionPages:     defaults.FederationLimitsCollectionPages,
+			CollectionItems:     defaults.FederationLimitsCollectionItems,
+			ThreadDepth:         defaults.FederationLimitsThreadDepth,
+			BackfillStatuses:    defaults.FederationLimitsBackfillStatuses,
+			DeliveryConcurrency: defaults.FederationLimitsDeliveryConcurrency,
+			DeliveryAttempts:    defaults.FederationLimitsDeliveryAttempts,
+		},
+		FederationCacheConfig: &FederationCacheConfig{
+			TTLSeconds: defaults.FederationCacheTTLSeconds,
+			MaxEntries: defaults.FederationCacheMaxEntries,
+		},
+		WebPushConfig: &WebPushConfig{
+			ContactEmail:    defaults.WebPushContactEmail,
+			VAPIDPublicKey:  defaults.WebPushVAPIDPublicKey,
+			VAPIDPrivateKey: defaults.WebPushVAPIDPrivateKey,
+		},
+		SMTPConfig: &SMTPConfig{
+			Host:     defaults.SMTPHost,
+			Port:     defaults.SMTPPort,
+			Username: defaults.SMTPUsername,
+			Password: defaults.SMTPPassword,
+			From:     defaults.SMTPFrom,
+		},
+		RateLimitConfig: &RateLimitConfig{
+			APIRequests:   defaults.RateLimitAPIRequests,
+			InboxRequests: defaults.RateLimitInboxRequests,
+			AuthRequests:  defaults.RateLimitAuthRequests,
+			ExemptIPs:     defaults.RateLimitExemptIPs,
 		},
 	}
 }
@@ -142,6 +400,7 @@ func Default() *Config {
 func GetDefaults() Defaults {
 	return Defaults{
 		LogLevel:        "info",
+		LogFormat:       "text",
 		ApplicationName: "gotosocial",
 		ConfigPath:      "",
 		Host:            "",
@@ -149,15 +408,17 @@ func GetDefaults() Defaults {
 		Protocol:        "https",
 		Port:            8080,
 		TrustedProxies:  []string{"127.0.0.1/32"}, // localhost
+		UnixSocket:      "",
 
-		DbType:      "postgres",
-		DbAddress:   "localhost",
-		DbPort:      5432,
-		DbUser:      "postgres",
-		DbPassword:  "postgres",
-		DbDatabase:  "postgres",
-		DBTlsMode:   "disable",
-		DBTlsCACert: "",
+		DbType:            "postgres",
+		DbAddress:         "localhost",
+		DbPort:            5432,
+		DbUser:            "postgres",
+		DbPassword:        "postgres",
+		DbDatabase:        "postgres",
+		DBTlsMode:         "disable",
+		DBTlsCACert:       "",
+		DbApplicationName: "",
 
 		TemplateBaseDir: "./web/template/",
 		AssetBaseDir:    "./web/assets/",
@@ -165,9 +426,15 @@ func GetDefaults() Defaults {
 		AccountsOpenRegistration: true,
 		AccountsRequireApproval:  true,
 		AccountsReasonRequired:   true,
+		AccountsAllowCustomCSS:   false,
+		AccountsCustomCSSLength:  10000,
+		AccountsKeyType:          KeyTypeRSA,
 
 		MediaMaxImageSize:        2097152,  //2mb
 		MediaMaxVideoSize:        10485760, //10mb
+		MediaMaxVideoDuration:    300,      //5 minutes
+		MediaFFmpegPath:          "ffmpeg",
+		MediaFFprobePath:         "ffprobe",
 		MediaMinDescriptionChars: 0,
 		MediaMaxDescriptionChars: 500,
 
@@ -176,6 +443,12 @@ func GetDefaults() Defaults {
 		StorageServeProtocol: "https",
 		StorageServeHost:     "localhost",
 		StorageServeBasePath: "/fileserver",
+		StorageS3Endpoint:    "",
+		StorageS3Region:      "us-east-1",
+		StorageS3Bucket:      "",
+		StorageS3AccessKey:   "",
+		StorageS3SecretKey:   "",
+		StorageS3Presign:     false,
 
 		StatusesMaxChars:           5000,
 		StatusesCWMaxChars:         100,
@@ -187,6 +460,12 @@ func GetDefaults() Defaults {
 		LetsEncryptPort:         80,
 		LetsEncryptCertDir:      "/gotosocial/storage/certs",
 		LetsEncryptEmailAddress: "",
+		LetsEncryptChallenge:    "http-01",
+		LetsEncryptKeyType:      "ecdsa-p256",
+
+		LetsEncryptDNSProvider:        "",
+		LetsEncryptDNSExecPath:        "",
+		LetsEncryptDNSHTTPReqEndpoint: "",
 
 		OIDCEnabled:          false,
 		OIDCIdpName:          "",
@@ -195,6 +474,99 @@ func GetDefaults() Defaults {
 		OIDCClientID:         "",
 		OIDCClientSecret:     "",
 		OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
+		OIDCUsernameClaim:    "name",
+		OIDCEmailClaim:       "email",
+		OIDCGroupsClaim:      "groups",
+		OIDCAdminGroups:      []string{"admin", "admins"},
+		OIDCModeratorGroups:  []string{},
+		OIDCUsernameConflict: OIDCUsernameConflictIncrement,
+
+		ThrottlingSearchConcurrency:    10,
+		ThrottlingSearchPerIPPerMinute: 30,
+		ThrottlingMediaConcurrency:     5,
+		ThrottlingMediaPerIPPerMinute:  20,
+		ThrottlingInboxConcurrency:     50,
+		ThrottlingInboxPerIPPerMinute:  600,
+
+		CaptchaProvider:  "",
+		CaptchaSiteKey:   "",
+		CaptchaSecretKey: "",
+		CaptchaURL:       "",
+
+		SpamEnabled:                  false,
+		SpamReportScore:              50,
+		SpamHoldScore:                100,
+		SpamDropScore:                0,
+		SpamNewAccountHours:          24,
+		SpamFirstContactMentionScore: 30,
+		SpamLinkOnlyScore:            60,
+		SpamKeywords:                 []string{},
+		SpamKeywordScore:             100,
+
+		HealthToken: "",
+
+		RequestLimitsTimeout:           30,
+		RequestLimitsMediaTimeout:      120,
+		RequestLimitsInboxTimeout:      30,
+		RequestLimitsBodySize:          1048576,
+		RequestLimitsMediaBodySize:     20971520,
+		RequestLimitsInboxBodySize:     1048576,
+		RequestLimitsReadHeaderTimeout: 30,
+		RequestLimitsReadTimeout:       60,
+		RequestLimitsIdleTimeout:       30,
+
+		HiddenServicesOnionHost: "",
+		HiddenServicesTorProxy:  "",
+		HiddenServicesI2PProxy:  "",
+
+		OutboundProxyURL:     "",
+		OutboundProxyNoProxy: []string{},
+		OutboundProxyDomains: []string{},
+
+		ErrorReportingDSN:         "",
+		ErrorReportingWebhookURL:  "",
+		ErrorReportingEnvironment: "production",
+
+		RetentionRemoteStatusDays:     0,
+		RetentionRemoteAccountDays:    0,
+		RetentionSweepIntervalMinutes: 60,
+		RetentionInboxActivityDays:    7,
+		RetentionFailedDeliveryDays:   7,
+
+		FederationSendBlocks: true,
+		FederationMode:       FederationModeBlocklist,
+
+		InboxFilterMaxMentions:      0,
+		InboxFilterRejectLinkOnly:   false,
+		InboxFilterNewAccountHours:  24,
+		InboxFilterRejectKeywords:   []string{},
+		InboxFilterReportRejections: true,
+
+		FederationLimitsPayloadSize:         1048576,
+		FederationLimitsCollectionPages:     10,
+		FederationLimitsCollectionItems:     100,
+		FederationLimitsThreadDepth:         100,
+		FederationLimitsBackfillStatuses:    50,
+		FederationLimitsDeliveryConcurrency: 25,
+		FederationLimitsDeliveryAttempts:    10,
+
+		FederationCacheTTLSeconds: 300,
+		FederationCacheMaxEntries: 1000,
+
+		WebPushContactEmail:    "",
+		WebPushVAPIDPublicKey:  "",
+		WebPushVAPIDPrivateKey: "",
+
+		SMTPHost:     "",
+		SMTPPort:     587,
+		SMTPUsername: "",
+		SMTPPassword: "",
+		SMTPFrom:     "",
+
+		RateLimitAPIRequests:   300,
+		RateLimitInboxRequests: 1500,
+		RateLimitAuthRequests:  25,
+		RateLimitExemptIPs:     []string{"127.0.0.1/32", "::1/128"},
 	}
 }
 
@@ -202,6 +574,7 @@ func GetDefaults() Defaults {
 func GetTestDefaults() Defaults {
 	return Defaults{
 		LogLevel:        "trace",
+		LogFormat:       "text",
 		ApplicationName: "gotosocial",
 		ConfigPath:      "",
 		Host:            "localhost:8080",
@@ -209,6 +582,7 @@ func GetTestDefaults() Defaults {
 		Protocol:        "http",
 		Port:            8080,
 		TrustedProxies:  []string{"127.0.0.1/32"},
+		UnixSocket:      "",
 
 		DbType:     "sqlite",
 		DbAddress:  ":memory:",
@@ -223,9 +597,15 @@ func GetTestDefaults() Defaults {
 		AccountsOpenRegistration: true,
 		AccountsRequireApproval:  true,
 		AccountsReasonRequired:   true,
+		AccountsAllowCustomCSS:   false,
+		AccountsCustomCSSLength:  10000,
+		AccountsKeyType:          KeyTypeRSA,
 
 		MediaMaxImageSize:        1048576, //1mb
 		MediaMaxVideoSize:        5242880, //5mb
+		MediaMaxVideoDuration:    300,     //5 minutes
+		MediaFFmpegPath:          "ffmpeg",
+		MediaFFprobePath:         "ffprobe",
 		MediaMinDescriptionChars: 0,
 		MediaMaxDescriptionChars: 500,
 
@@ -234,6 +614,12 @@ func GetTestDefaults() Defaults {
 		StorageServeProtocol: "http",
 		StorageServeHost:     "localhost:8080",
 		StorageServeBasePath: "/fileserver",
+		StorageS3Endpoint:    "",
+		StorageS3Region:      "us-east-1",
+		StorageS3Bucket:      "",
+		StorageS3AccessKey:   "",
+		StorageS3SecretKey:   "",
+		StorageS3Presign:     false,
 
 		StatusesMaxChars:           5000,
 		StatusesCWMaxChars:         100,
@@ -245,6 +631,12 @@ func GetTestDefaults() Defaults {
 		LetsEncryptPort:         0,
 		LetsEncryptCertDir:      "",
 		LetsEncryptEmailAddress: "",
+		LetsEncryptChallenge:    "http-01",
+		LetsEncryptKeyType:      "ecdsa-p256",
+
+		LetsEncryptDNSProvider:        "",
+		LetsEncryptDNSExecPath:        "",
+		LetsEncryptDNSHTTPReqEndpoint: "",
 
 		OIDCEnabled:          false,
 		OIDCIdpName:          "",
@@ -253,5 +645,98 @@ func GetTestDefaults() Defaults {
 		OIDCClientID:         "",
 		OIDCClientSecret:     "",
 		OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
+		OIDCUsernameClaim:    "name",
+		OIDCEmailClaim:       "email",
+		OIDCGroupsClaim:      "groups",
+		OIDCAdminGroups:      []string{"admin", "admins"},
+		OIDCModeratorGroups:  []string{},
+		OIDCUsernameConflict: OIDCUsernameConflictIncrement,
+
+		ThrottlingSearchConcurrency:    10,
+		ThrottlingSearchPerIPPerMinute: 30,
+		ThrottlingMediaConcurrency:     5,
+		ThrottlingMediaPerIPPerMinute:  20,
+		ThrottlingInboxConcurrency:     50,
+		ThrottlingInboxPerIPPerMinute:  600,
+
+		CaptchaProvider:  "",
+		CaptchaSiteKey:   "",
+		CaptchaSecretKey: "",
+		CaptchaURL:       "",
+
+		SpamEnabled:                  false,
+		SpamReportScore:              50,
+		SpamHoldScore:                100,
+		SpamDropScore:                0,
+		SpamNewAccountHours:          24,
+		SpamFirstContactMentionScore: 30,
+		SpamLinkOnlyScore:            60,
+		SpamKeywords:                 []string{},
+		SpamKeywordScore:             100,
+
+		HealthToken: "",
+
+		RequestLimitsTimeout:           30,
+		RequestLimitsMediaTimeout:      120,
+		RequestLimitsInboxTimeout:      30,
+		RequestLimitsBodySize:          1048576,
+		RequestLimitsMediaBodySize:     20971520,
+		RequestLimitsInboxBodySize:     1048576,
+		RequestLimitsReadHeaderTimeout: 30,
+		RequestLimitsReadTimeout:       60,
+		RequestLimitsIdleTimeout:       30,
+
+		HiddenServicesOnionHost: "",
+		HiddenServicesTorProxy:  "",
+		HiddenServicesI2PProxy:  "",
+
+		OutboundProxyURL:     "",
+		OutboundProxyNoProxy: []string{},
+		OutboundProxyDomains: []string{},
+
+		ErrorReportingDSN:         "",
+		ErrorReportingWebhookURL:  "",
+		ErrorReportingEnvironment: "test",
+
+		RetentionRemoteStatusDays:     0,
+		RetentionRemoteAccountDays:    0,
+		RetentionSweepIntervalMinutes: 60,
+		RetentionInboxActivityDays:    7,
+		RetentionFailedDeliveryDays:   7,
+
+		FederationSendBlocks: true,
+		FederationMode:       FederationModeBlocklist,
+
+		InboxFilterMaxMentions:      0,
+		InboxFilterRejectLinkOnly:   false,
+		InboxFilterNewAccountHours:  24,
+		InboxFilterRejectKeywords:   []string{},
+		InboxFilterReportRejections: true,
+
+		FederationLimitsPayloadSize:         1048576,
+		FederationLimitsCollectionPages:     10,
+		FederationLimitsCollectionItems:     100,
+		FederationLimitsThreadDepth:         100,
+		FederationLimitsBackfillStatuses:    50,
+		FederationLimitsDeliveryConcurrency: 25,
+		FederationLimitsDeliveryAttempts:    10,
+
+		FederationCacheTTLSeconds: 300,
+		FederationCacheMaxEntries: 1000,
+
+		WebPushContactEmail:    "",
+		WebPushVAPIDPublicKey:  "",
+		WebPushVAPIDPrivateKey: "",
+
+		SMTPHost:     "",
+		SMTPPort:     587,
+		SMTPUsername: "",
+		SMTPPassword: "",
+		SMTPFrom:     "",
+
+		RateLimitAPIRequests:   300,
+		RateLimitInboxRequests: 1500,
+		RateLimitAuthRequests:  25,
+		RateLimitExemptIPs:     []string{"127.0.0.1/32", "::1/128"},
 	}
 }
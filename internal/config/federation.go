@@ -0,0 +1,36 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// FederationConfig pertains to how this instance federates with others.
+type FederationConfig struct {
+	// Send Block activities to the instances of blocked accounts, so that they can hide our content from them too.
+	// If false, blocks are still enforced on this instance, but other instances aren't told about them.
+	SendBlocks bool `yaml:"sendBlocks"`
+	// Mode is the federation mode of this instance: either FederationModeBlocklist, where every domain that isn't
+	// blocked may federate with us, or FederationModeAllowlist, where only explicitly allowed domains may.
+	Mode string `yaml:"mode"`
+}
+
+const (
+	// FederationModeBlocklist means federating with every domain that isn't blocked.
+	FederationModeBlocklist = "blocklist"
+	// FederationModeAllowlist means only federating with domains that have been explicitly allowed.
+	FederationModeAllowlist = "allowlist"
+)
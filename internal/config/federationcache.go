@@ -0,0 +1,29 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// FederationCacheConfig pertains to caching of actors and collections fetched from remote instances,
+// so that the same followers, following or outbox endpoint isn't dereferenced over and over again.
+type FederationCacheConfig struct {
+	// How long in seconds a fetched document is used without checking back with the remote. After this, the
+	// remote is asked whether it has changed, using the ETag and Last-Modified headers it sent with it.
+	TTLSeconds int `yaml:"ttlSeconds"`
+	// Max number of fetched documents to keep in the cache. 0 disables the cache.
+	MaxEntries int `yaml:"maxEntries"`
+}
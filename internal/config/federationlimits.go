@@ -0,0 +1,38 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// FederationLimitsConfig pertains to how much work a remote instance can make us do when we fetch things from it,
+// so that a malicious or broken remote can't make us recurse or allocate without bound. For all of these, 0 means no limit.
+type FederationLimitsConfig struct {
+	// Max size in bytes of an activitypub document fetched from a remote instance
+	PayloadSize int `yaml:"payloadSize"`
+	// Max number of pages to fetch when working through a remote collection, such as the replies to a status
+	CollectionPages int `yaml:"collectionPages"`
+	// Max number of items to take from a single fetched collection or collection page
+	CollectionItems int `yaml:"collectionItems"`
+	// Max number of statuses to walk up or down a thread when dereferencing its ancestors and descendants
+	ThreadDepth int `yaml:"threadDepth"`
+	// Max number of new statuses to fetch when backfilling the thread of a remote status that someone has opened
+	BackfillStatuses int `yaml:"backfillStatuses"`
+	// Max number of inboxes to deliver a single activity to at once
+	DeliveryConcurrency int `yaml:"deliveryConcurrency"`
+	// Max number of times to try delivering an activity to an inbox before giving up on it
+	DeliveryAttempts int `yaml:"deliveryAttempts"`
+}
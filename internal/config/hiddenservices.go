@@ -0,0 +1,29 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// HiddenServicesConfig pertains to federating over Tor and I2P.
+type HiddenServicesConfig struct {
+	// Tor onion address that this instance can also be reached at, advertised with an Onion-Location header
+	OnionHost string `yaml:"onionHost"`
+	// Address of the socks5 proxy to send requests to .onion hosts through
+	TorProxy string `yaml:"torProxy"`
+	// Address of the socks5 proxy to send requests to .i2p hosts through
+	I2PProxy string `yaml:"i2pProxy"`
+}
@@ -0,0 +1,33 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// InboxFilterConfig pertains to checks which can reject incoming federated activities outright, before they're processed.
+type InboxFilterConfig struct {
+	// Reject incoming statuses which mention more than this many accounts. 0 means no limit
+	MaxMentions int `yaml:"maxMentions"`
+	// Reject incoming statuses from new accounts which contain nothing but links and mentions
+	RejectLinkOnly bool `yaml:"rejectLinkOnly"`
+	// Remote accounts first seen less than this many hours ago count as new
+	NewAccountHours int `yaml:"newAccountHours"`
+	// Reject incoming statuses containing any of these keywords, matched case-insensitively
+	RejectKeywords []string `yaml:"rejectKeywords"`
+	// File a report for moderators whenever an incoming activity is rejected
+	ReportRejections bool `yaml:"reportRejections"`
+}
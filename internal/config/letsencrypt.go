@@ -10,4 +10,21 @@ type LetsEncryptConfig struct {
 	CertDir string `yaml:"certDir"`
 	// Email address to pass to letsencrypt for notifications about certificate expiry etc.
 	EmailAddress string `yaml:"emailAddress"`
+	// Which challenge to use to prove control of the domain: http-01 or dns-01.
+	Challenge string `yaml:"challenge"`
+	// Type of private key to generate for dns-01 certificates: ecdsa-p256, ecdsa-p384, rsa-2048 or rsa-4096.
+	KeyType string `yaml:"keyType"`
+	// Name of the DNS provider to use for solving dns-01 challenges.
+	DNSProvider string `yaml:"dnsProvider"`
+	// Path to the executable used by the 'exec' DNS provider.
+	DNSExecPath string `yaml:"dnsExecPath"`
+	// Endpoint used by the 'httpreq' DNS provider.
+	DNSHTTPReqEndpoint string `yaml:"dnsHTTPReqEndpoint"`
 }
+
+const (
+	// LetsEncryptChallengeHTTP01 proves control of the domain by serving a token over http.
+	LetsEncryptChallengeHTTP01 = "http-01"
+	// LetsEncryptChallengeDNS01 proves control of the domain by publishing a TXT record.
+	LetsEncryptChallengeDNS01 = "dns-01"
+)
@@ -24,6 +24,12 @@ type MediaConfig struct {
 	MaxImageSize int `yaml:"maxImageSize"`
 	// Max size of uploaded video in bytes
 	MaxVideoSize int `yaml:"maxVideoSize"`
+	// Max duration of uploaded video in seconds
+	MaxVideoDuration int `yaml:"maxVideoDuration"`
+	// Path to the ffmpeg binary, used for transcoding video and taking video thumbnails
+	FFmpegPath string `yaml:"ffmpegPath"`
+	// Path to the ffprobe binary, used for reading the dimensions, duration etc. of video
+	FFprobePath string `yaml:"ffprobePath"`
 	// Minimum amount of chars required in an image description
 	MinDescriptionChars int `yaml:"minDescriptionChars"`
 	// Max amount of chars allowed in an image description
@@ -27,4 +27,17 @@ type OIDCConfig struct {
 	ClientID         string   `yaml:"clientID"`
 	ClientSecret     string   `yaml:"clientSecret"`
 	Scopes           []string `yaml:"scopes"`
+	UsernameClaim    string   `yaml:"usernameClaim"`
+	EmailClaim       string   `yaml:"emailClaim"`
+	GroupsClaim      string   `yaml:"groupsClaim"`
+	AdminGroups      []string `yaml:"adminGroups"`
+	ModeratorGroups  []string `yaml:"moderatorGroups"`
+	UsernameConflict string   `yaml:"usernameConflict"`
 }
+
+const (
+	// OIDCUsernameConflictIncrement means that a new user whose username is already taken gets a number appended to it.
+	OIDCUsernameConflictIncrement = "increment"
+	// OIDCUsernameConflictReject means that a new user whose username is already taken is refused.
+	OIDCUsernameConflictReject = "reject"
+)
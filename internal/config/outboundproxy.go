@@ -0,0 +1,29 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// OutboundProxyConfig pertains to sending federation requests through an http proxy.
+type OutboundProxyConfig struct {
+	// URL of the proxy to send federation requests through; if empty, HTTP_PROXY, HTTPS_PROXY and NO_PROXY are used
+	URL string `yaml:"url"`
+	// Domains, and their subdomains, that are connected to directly rather than through the proxy
+	NoProxy []string `yaml:"noProxy"`
+	// Per-domain overrides, in the form 'domain=proxy url' or 'domain=direct'
+	Domains []string `yaml:"domains"`
+}
@@ -0,0 +1,32 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// RateLimitConfig pertains to general rate limiting of requests, as opposed to the throttling of expensive endpoints.
+// Requests are counted in fixed windows of five minutes. For all of the limits, 0 means no limit.
+type RateLimitConfig struct {
+	// Max number of client API requests per five minutes, per oauth token, or per ip address for requests without one
+	APIRequests int `yaml:"apiRequests"`
+	// Max number of activitypub inbox POSTs per five minutes per ip address
+	InboxRequests int `yaml:"inboxRequests"`
+	// Max number of sign in, sign up, password reset, and oauth token requests per five minutes per ip address
+	AuthRequests int `yaml:"authRequests"`
+	// Ip addresses or ranges, in CIDR notation, that are never rate limited, such as internal traffic
+	ExemptIPs []string `yaml:"exemptIPs"`
+}
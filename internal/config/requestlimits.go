@@ -0,0 +1,42 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// RequestLimitsConfig pertains to how long requests may take and how big they may be, to stop slow or oversized requests from tying up the server.
+// For all of these, 0 means no limit.
+type RequestLimitsConfig struct {
+	// Seconds allowed for handling a request, for requests that don't have a more specific timeout
+	Timeout int `yaml:"timeout"`
+	// Seconds allowed for handling a multipart form upload, such as a media attachment or avatar
+	MediaTimeout int `yaml:"mediaTimeout"`
+	// Seconds allowed for handling an activitypub inbox POST
+	InboxTimeout int `yaml:"inboxTimeout"`
+	// Max size in bytes of a request body, for requests that don't have a more specific limit
+	BodySize int `yaml:"bodySize"`
+	// Max size in bytes of a multipart form upload
+	MediaBodySize int `yaml:"mediaBodySize"`
+	// Max size in bytes of an activitypub inbox POST
+	InboxBodySize int `yaml:"inboxBodySize"`
+	// Seconds allowed for a client to send the headers of a request
+	ReadHeaderTimeout int `yaml:"readHeaderTimeout"`
+	// Seconds allowed for a client to send a whole request, including the body
+	ReadTimeout int `yaml:"readTimeout"`
+	// Seconds to keep an idle keep-alive connection open while waiting for the next request
+	IdleTimeout int `yaml:"idleTimeout"`
+}
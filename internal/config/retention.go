@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// RetentionConfig pertains to how long cached remote content is kept around for when nobody on this instance is interacting with it.
+// Pruned content isn't lost for good: it'll just be dereferenced again if it's needed later on. For all of these, 0 means keep forever.
+type RetentionConfig struct {
+	// Remote statuses that no local account has interacted with are removed once they haven't been updated for this many days
+	RemoteStatusDays int `yaml:"remoteStatusDays"`
+	// Remote accounts that have no relationship or interaction with local accounts are removed once they haven't been updated for this many days
+	RemoteAccountDays int `yaml:"remoteAccountDays"`
+	// How often to sweep the database for remote content to remove, in minutes
+	SweepIntervalMinutes int `yaml:"sweepIntervalMinutes"`
+	// Records of which activities have already been received, used to drop activities that are delivered more than once, are removed after this many days
+	InboxActivityDays int `yaml:"inboxActivityDays"`
+	// Deliveries to remote inboxes that failed and are waiting to be retried are given up on after this many days
+	FailedDeliveryDays int `yaml:"failedDeliveryDays"`
+}
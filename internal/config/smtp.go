@@ -0,0 +1,33 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// SMTPConfig pertains to sending emails to users through an SMTP server.
+type SMTPConfig struct {
+	// Host of the SMTP server to send emails through. If not set, no emails are sent.
+	Host string `yaml:"host"`
+	// Port of the SMTP server.
+	Port int `yaml:"port"`
+	// Username to authenticate with the SMTP server. If not set, no authentication is attempted.
+	Username string `yaml:"username"`
+	// Password to authenticate with the SMTP server.
+	Password string `yaml:"password"`
+	// Address that emails are sent from, eg., gotosocial@example.org.
+	From string `yaml:"from"`
+}
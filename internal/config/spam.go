@@ -0,0 +1,41 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// SpamConfig pertains to scoring incoming federated statuses for spam, and what to do with statuses that score too highly.
+type SpamConfig struct {
+	// Whether to check incoming statuses for spam at all
+	Enabled bool `yaml:"enabled"`
+	// Statuses scoring at least this much are flagged for moderators, but otherwise processed as normal. 0 means never
+	ReportScore int `yaml:"reportScore"`
+	// Statuses scoring at least this much are held back from timelines and notifications until a moderator releases them. 0 means never
+	HoldScore int `yaml:"holdScore"`
+	// Statuses scoring at least this much are deleted straight away. 0 means never
+	DropScore int `yaml:"dropScore"`
+	// Remote accounts first seen less than this many hours ago count as new
+	NewAccountHours int `yaml:"newAccountHours"`
+	// Score added for each local account mentioned by a stranger
+	FirstContactMentionScore int `yaml:"firstContactMentionScore"`
+	// Score added for statuses from new accounts which contain nothing but links and mentions
+	LinkOnlyScore int `yaml:"linkOnlyScore"`
+	// Keywords which mark a status as spam, matched case-insensitively
+	Keywords []string `yaml:"keywords"`
+	// Score added for each keyword found in a status
+	KeywordScore int `yaml:"keywordScore"`
+}
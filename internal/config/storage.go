@@ -20,8 +20,7 @@ package config
 
 // StorageConfig contains configuration for storage and serving of media files and attachments
 type StorageConfig struct {
-	// Type of storage backend to use: currently only 'local' is supported.
-	// TODO: add S3 support here.
+	// Type of storage backend to use: 'local' or 's3'.
 	Backend string `yaml:"backend"`
 
 	// The base path for storing things. Should be an already-existing directory.
@@ -33,4 +32,17 @@ type StorageConfig struct {
 	ServeHost string `yaml:"serveHost"`
 	// Base path to use when *serving* media files from storage
 	ServeBasePath string `yaml:"serveBasePath"`
+
+	// Endpoint of the s3-compatible object store to use, eg., https://s3.eu-west-1.amazonaws.com
+	S3Endpoint string `yaml:"s3Endpoint"`
+	// Region of the bucket, used when signing requests
+	S3Region string `yaml:"s3Region"`
+	// Bucket to store things in. Should already exist.
+	S3Bucket string `yaml:"s3Bucket"`
+	// Access key to use when accessing the bucket
+	S3AccessKey string `yaml:"s3AccessKey"`
+	// Secret key to use when accessing the bucket
+	S3SecretKey string `yaml:"s3SecretKey"`
+	// Redirect requests for media to short-lived presigned urls of the bucket, rather than proxying the media through gotosocial
+	S3Presign bool `yaml:"s3Presign"`
 }
@@ -0,0 +1,36 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// ThrottlingConfig pertains to limiting requests to expensive endpoints, separately from any general rate limiting.
+// For all of these, 0 means no limit.
+type ThrottlingConfig struct {
+	// Max number of search requests to handle at once
+	SearchConcurrency int `yaml:"searchConcurrency"`
+	// Max number of search requests that one ip address can make per minute
+	SearchPerIPPerMinute int `yaml:"searchPerIPPerMinute"`
+	// Max number of media uploads to handle at once
+	MediaConcurrency int `yaml:"mediaConcurrency"`
+	// Max number of media uploads that one ip address can make per minute
+	MediaPerIPPerMinute int `yaml:"mediaPerIPPerMinute"`
+	// Max number of activitypub inbox POSTs to handle at once
+	InboxConcurrency int `yaml:"inboxConcurrency"`
+	// Max number of activitypub inbox POSTs that one ip address can make per minute
+	InboxPerIPPerMinute int `yaml:"inboxPerIPPerMinute"`
+}
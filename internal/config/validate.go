@@ -0,0 +1,422 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Validate checks the config for missing or nonsensical values, returning
+// an error describing every problem found, or nil if the config is usable.
+//
+// Validate does not try to reach the database or storage; it only looks at
+// the values that have been set.
+func (c *Config) Validate() error {
+	fn := GetFlagNames()
+
+	problems := []string{}
+	problem := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	// general
+	if c.Host == "" {
+		problem("%s was not set", fn.Host)
+	}
+	if c.Protocol != "http" && c.Protocol != "https" {
+		problem("%s must be one of http or https, got '%s'", fn.Protocol, c.Protocol)
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		problem("%s must be between 1 and 65535, got %d", fn.Port, c.Port)
+	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		problem("%s must be one of text or json, got '%s'", fn.LogFormat, c.LogFormat)
+	}
+
+	// db
+	switch strings.ToLower(c.DBConfig.Type) {
+	case "postgres":
+		if c.DBConfig.Address == "" {
+			problem("%s was not set", fn.DbAddress)
+		}
+		if c.DBConfig.User == "" {
+			problem("%s was not set", fn.DbUser)
+		}
+		if c.DBConfig.Password == "" {
+			problem("%s was not set", fn.DbPassword)
+		}
+		if c.DBConfig.Database == "" {
+			problem("%s was not set", fn.DbDatabase)
+		}
+	case "sqlite":
+		if c.DBConfig.Address == "" {
+			problem("%s was not set", fn.DbAddress)
+		}
+	default:
+		problem("%s must be one of postgres or sqlite, got '%s'", fn.DbType, c.DBConfig.Type)
+	}
+	switch c.DBConfig.TLSMode {
+	case DBTLSModeUnset, DBTLSModeDisable, DBTLSModeEnable, DBTLSModeRequire:
+	default:
+		problem("%s must be one of disable, enable or require, got '%s'", fn.DbTLSMode, c.DBConfig.TLSMode)
+	}
+
+	// storage
+	switch c.StorageConfig.Backend {
+	case "local":
+		if c.StorageConfig.BasePath == "" {
+			problem("%s was not set", fn.StorageBasePath)
+		}
+		if c.StorageConfig.S3Presign {
+			problem("%s can only be used with the s3 backend", fn.StorageS3Presign)
+		}
+	case "s3":
+		if c.StorageConfig.S3Endpoint == "" {
+			problem("%s was not set", fn.StorageS3Endpoint)
+		}
+		if c.StorageConfig.S3Bucket == "" {
+			problem("%s was not set", fn.StorageS3Bucket)
+		}
+		if c.StorageConfig.S3AccessKey == "" || c.StorageConfig.S3SecretKey == "" {
+			problem("%s and %s must both be set", fn.StorageS3AccessKey, fn.StorageS3SecretKey)
+		}
+	default:
+		problem("%s must be one of local or s3, got '%s'", fn.StorageBackend, c.StorageConfig.Backend)
+	}
+
+	// accounts
+	if c.AccountsConfig.CustomCSSLength <= 0 {
+		problem("%s must be greater than 0", fn.AccountsCustomCSSLength)
+	}
+	switch c.AccountsConfig.KeyType {
+	case KeyTypeRSA, KeyTypeEd25519:
+	default:
+		problem("%s must be one of %s or %s, got '%s'", fn.AccountsKeyType, KeyTypeRSA, KeyTypeEd25519, c.AccountsConfig.KeyType)
+	}
+
+	// media
+	if c.MediaConfig.MaxImageSize <= 0 {
+		problem("%s must be greater than 0", fn.MediaMaxImageSize)
+	}
+	if c.MediaConfig.MaxVideoSize <= 0 {
+		problem("%s must be greater than 0", fn.MediaMaxVideoSize)
+	}
+	if c.MediaConfig.MaxVideoDuration <= 0 {
+		problem("%s must be greater than 0", fn.MediaMaxVideoDuration)
+	}
+	if c.MediaConfig.MinDescriptionChars > c.MediaConfig.MaxDescriptionChars {
+		problem("%s must not be greater than %s", fn.MediaMinDescriptionChars, fn.MediaMaxDescriptionChars)
+	}
+
+	// letsencrypt
+	if c.LetsEncryptConfig.Enabled {
+		if c.LetsEncryptConfig.CertDir == "" {
+			problem("%s must be set when letsencrypt is enabled", fn.LetsEncryptCertDir)
+		}
+		if c.LetsEncryptConfig.EmailAddress == "" {
+			problem("%s must be set when letsencrypt is enabled", fn.LetsEncryptEmailAddress)
+		}
+
+		switch c.LetsEncryptConfig.KeyType {
+		case "ecdsa-p256", "ecdsa-p384", "rsa-2048", "rsa-4096":
+		default:
+			problem("%s must be one of ecdsa-p256, ecdsa-p384, rsa-2048 or rsa-4096, got '%s'", fn.LetsEncryptKeyType, c.LetsEncryptConfig.KeyType)
+		}
+
+		switch c.LetsEncryptConfig.Challenge {
+		case LetsEncryptChallengeHTTP01:
+		case LetsEncryptChallengeDNS01:
+			switch c.LetsEncryptConfig.DNSProvider {
+			case "exec":
+				if c.LetsEncryptConfig.DNSExecPath == "" {
+					problem("%s must be set when using the exec dns provider", fn.LetsEncryptDNSExecPath)
+				}
+			case "httpreq":
+				if c.LetsEncryptConfig.DNSHTTPReqEndpoint == "" {
+					problem("%s must be set when using the httpreq dns provider", fn.LetsEncryptDNSHTTPReqEndpoint)
+				}
+			default:
+				problem("%s must be one of exec or httpreq when using the dns-01 challenge, got '%s'", fn.LetsEncryptDNSProvider, c.LetsEncryptConfig.DNSProvider)
+			}
+		default:
+			problem("%s must be one of http-01 or dns-01, got '%s'", fn.LetsEncryptChallenge, c.LetsEncryptConfig.Challenge)
+		}
+	}
+
+	// oidc
+	if c.OIDCConfig.Enabled {
+		if c.OIDCConfig.Issuer == "" {
+			problem("%s must be set when oidc is enabled", fn.OIDCIssuer)
+		}
+		if c.OIDCConfig.ClientID == "" {
+			problem("%s must be set when oidc is enabled", fn.OIDCClientID)
+		}
+		if c.OIDCConfig.ClientSecret == "" {
+			problem("%s must be set when oidc is enabled", fn.OIDCClientSecret)
+		}
+		switch c.OIDCConfig.UsernameConflict {
+		case "", OIDCUsernameConflictIncrement, OIDCUsernameConflictReject:
+		default:
+			problem("%s must be one of %s or %s, got '%s'", fn.OIDCUsernameConflict, OIDCUsernameConflictIncrement, OIDCUsernameConflictReject, c.OIDCConfig.UsernameConflict)
+		}
+	}
+
+	// throttling
+	for _, t := range []struct {
+		flag  string
+		value int
+	}{
+		{fn.ThrottlingSearchConcurrency, c.ThrottlingConfig.SearchConcurrency},
+		{fn.ThrottlingSearchPerIPPerMinute, c.ThrottlingConfig.SearchPerIPPerMinute},
+		{fn.ThrottlingMediaConcurrency, c.ThrottlingConfig.MediaConcurrency},
+		{fn.ThrottlingMediaPerIPPerMinute, c.ThrottlingConfig.MediaPerIPPerMinute},
+		{fn.ThrottlingInboxConcurrency, c.ThrottlingConfig.InboxConcurrency},
+		{fn.ThrottlingInboxPerIPPerMinute, c.ThrottlingConfig.InboxPerIPPerMinute},
+	} {
+		if t.value < 0 {
+			problem("%s must not be negative", t.flag)
+		}
+	}
+
+	// request limits
+	for _, t := range []struct {
+		flag  string
+		value int
+	}{
+		{fn.RequestLimitsTimeout, c.RequestLimitsConfig.Timeout},
+		{fn.RequestLimitsMediaTimeout, c.RequestLimitsConfig.MediaTimeout},
+		{fn.RequestLimitsInboxTimeout, c.RequestLimitsConfig.InboxTimeout},
+		{fn.RequestLimitsBodySize, c.RequestLimitsConfig.BodySize},
+		{fn.RequestLimitsMediaBodySize, c.RequestLimitsConfig.MediaBodySize},
+		{fn.RequestLimitsInboxBodySize, c.RequestLimitsConfig.InboxBodySize},
+		{fn.RequestLimitsReadHeaderTimeout, c.RequestLimitsConfig.ReadHeaderTimeout},
+		{fn.RequestLimitsReadTimeout, c.RequestLimitsConfig.ReadTimeout},
+		{fn.RequestLimitsIdleTimeout, c.RequestLimitsConfig.IdleTimeout},
+	} {
+		if t.value < 0 {
+			problem("%s must not be negative", t.flag)
+		}
+	}
+	if mediaBodySize := c.RequestLimitsConfig.MediaBodySize; mediaBodySize != 0 {
+		if mediaBodySize < c.MediaConfig.MaxImageSize {
+			problem("%s must not be less than %s", fn.RequestLimitsMediaBodySize, fn.MediaMaxImageSize)
+		}
+		if mediaBodySize < c.MediaConfig.MaxVideoSize {
+			problem("%s must not be less than %s", fn.RequestLimitsMediaBodySize, fn.MediaMaxVideoSize)
+		}
+	}
+
+	// spam
+	for _, t := range []struct {
+		flag  string
+		value int
+	}{
+		{fn.SpamReportScore, c.SpamConfig.ReportScore},
+		{fn.SpamHoldScore, c.SpamConfig.HoldScore},
+		{fn.SpamDropScore, c.SpamConfig.DropScore},
+		{fn.SpamNewAccountHours, c.SpamConfig.NewAccountHours},
+		{fn.SpamFirstContactMentionScore, c.SpamConfig.FirstContactMentionScore},
+		{fn.SpamLinkOnlyScore, c.SpamConfig.LinkOnlyScore},
+		{fn.SpamKeywordScore, c.SpamConfig.KeywordScore},
+	} {
+		if t.value < 0 {
+			problem("%s must not be negative", t.flag)
+		}
+	}
+
+	// captcha
+	switch c.CaptchaConfig.Provider {
+	case "":
+	case "hcaptcha", "recaptcha", "mcaptcha":
+		if c.CaptchaConfig.SiteKey == "" {
+			problem("%s must be set when a captcha provider is set", fn.CaptchaSiteKey)
+		}
+		if c.CaptchaConfig.SecretKey == "" {
+			problem("%s must be set when a captcha provider is set", fn.CaptchaSecretKey)
+		}
+		if c.CaptchaConfig.Provider == "mcaptcha" && c.CaptchaConfig.URL == "" {
+			problem("%s must be set when using the mcaptcha provider", fn.CaptchaURL)
+		}
+	default:
+		problem("%s must be one of hcaptcha, recaptcha or mcaptcha, got '%s'", fn.CaptchaProvider, c.CaptchaConfig.Provider)
+	}
+
+	// hidden services
+	if onionHost := c.HiddenServicesConfig.OnionHost; onionHost != "" {
+		if strings.Contains(onionHost, "://") || strings.Contains(onionHost, "/") {
+			problem("%s must be a host name without a scheme or path, got '%s'", fn.HiddenServicesOnionHost, onionHost)
+		} else if !strings.HasSuffix(strings.ToLower(onionHost), ".onion") {
+			problem("%s must end in .onion, got '%s'", fn.HiddenServicesOnionHost, onionHost)
+		}
+	}
+	for _, p := range []struct {
+		flag    string
+		address string
+	}{
+		{fn.HiddenServicesTorProxy, c.HiddenServicesConfig.TorProxy},
+		{fn.HiddenServicesI2PProxy, c.HiddenServicesConfig.I2PProxy},
+	} {
+		if p.address == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(p.address); err != nil {
+			problem("%s must be a host:port address, got '%s'", p.flag, p.address)
+		}
+	}
+
+	// outbound proxy
+	validProxyURL := func(rawurl string) bool {
+		u, err := url.Parse(rawurl)
+		return err == nil && u.Host != "" && (u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "socks5")
+	}
+	if c.OutboundProxyConfig.URL != "" && !validProxyURL(c.OutboundProxyConfig.URL) {
+		problem("%s must be an http, https or socks5 url, got '%s'", fn.OutboundProxyURL, c.OutboundProxyConfig.URL)
+	}
+	for _, d := range c.OutboundProxyConfig.Domains {
+		i := strings.Index(d, "=")
+		if i < 1 {
+			problem("%s entries must be in the form 'domain=proxy url' or 'domain=direct', got '%s'", fn.OutboundProxyDomains, d)
+			continue
+		}
+		if target := strings.TrimSpace(d[i+1:]); target != "direct" && !validProxyURL(target) {
+			problem("%s entries must use 'direct' or an http, https or socks5 url, got '%s'", fn.OutboundProxyDomains, d)
+		}
+	}
+
+	// error reporting
+	if dsn := c.ErrorReportingConfig.DSN; dsn != "" {
+		if u, err := url.Parse(dsn); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User == nil || u.User.Username() == "" || strings.Trim(u.Path, "/") == "" {
+			problem("%s must be a dsn like https://publickey@sentry.example.org/1, got '%s'", fn.ErrorReportingDSN, dsn)
+		}
+	}
+	if webhookURL := c.ErrorReportingConfig.WebhookURL; webhookURL != "" {
+		if u, err := url.Parse(webhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problem("%s must be an http or https url, got '%s'", fn.ErrorReportingWebhookURL, webhookURL)
+		}
+	}
+
+	// retention
+	if c.RetentionConfig.RemoteStatusDays < 0 {
+		problem("%s must not be negative", fn.RetentionRemoteStatusDays)
+	}
+	if c.RetentionConfig.RemoteAccountDays < 0 {
+		problem("%s must not be negative", fn.RetentionRemoteAccountDays)
+	}
+	if c.RetentionConfig.SweepIntervalMinutes <= 0 {
+		problem("%s must be greater than 0", fn.RetentionSweepIntervalMinutes)
+	}
+	if c.RetentionConfig.InboxActivityDays < 0 {
+		problem("%s must not be negative", fn.RetentionInboxActivityDays)
+	}
+	if c.RetentionConfig.FailedDeliveryDays < 0 {
+		problem("%s must not be negative", fn.RetentionFailedDeliveryDays)
+	}
+
+	// inbox filter
+	if c.InboxFilterConfig.MaxMentions < 0 {
+		problem("%s must not be negative", fn.InboxFilterMaxMentions)
+	}
+	if c.InboxFilterConfig.RejectLinkOnly && c.InboxFilterConfig.NewAccountHours <= 0 {
+		problem("%s must be greater than 0 when %s is true", fn.InboxFilterNewAccountHours, fn.InboxFilterRejectLinkOnly)
+	}
+
+	// federation
+	switch c.FederationConfig.Mode {
+	case FederationModeBlocklist, FederationModeAllowlist:
+	default:
+		problem("%s must be one of %s or %s, got '%s'", fn.FederationMode, FederationModeBlocklist, FederationModeAllowlist, c.FederationConfig.Mode)
+	}
+
+	// federation limits
+	if c.FederationLimitsConfig.PayloadSize < 0 {
+		problem("%s must not be negative", fn.FederationLimitsPayloadSize)
+	}
+	if c.FederationLimitsConfig.CollectionPages < 0 {
+		problem("%s must not be negative", fn.FederationLimitsCollectionPages)
+	}
+	if c.FederationLimitsConfig.CollectionItems < 0 {
+		problem("%s must not be negative", fn.FederationLimitsCollectionItems)
+	}
+	if c.FederationLimitsConfig.ThreadDepth < 0 {
+		problem("%s must not be negative", fn.FederationLimitsThreadDepth)
+	}
+	if c.FederationLimitsConfig.BackfillStatuses < 0 {
+		problem("%s must not be negative", fn.FederationLimitsBackfillStatuses)
+	}
+	if c.FederationLimitsConfig.DeliveryConcurrency < 0 {
+		problem("%s must not be negative", fn.FederationLimitsDeliveryConcurrency)
+	}
+	if c.FederationLimitsConfig.DeliveryAttempts < 0 {
+		problem("%s must not be negative", fn.FederationLimitsDeliveryAttempts)
+	}
+
+	// federation cache
+	if c.FederationCacheConfig.TTLSeconds < 0 {
+		problem("%s must not be negative", fn.FederationCacheTTLSeconds)
+	}
+	if c.FederationCacheConfig.MaxEntries < 0 {
+		problem("%s must not be negative", fn.FederationCacheMaxEntries)
+	}
+
+	// web push
+	if c.WebPushConfig.ContactEmail != "" && !strings.Contains(c.WebPushConfig.ContactEmail, "@") {
+		problem("%s must be an email address, got '%s'", fn.WebPushContactEmail, c.WebPushConfig.ContactEmail)
+	}
+	if (c.WebPushConfig.VAPIDPublicKey == "") != (c.WebPushConfig.VAPIDPrivateKey == "") {
+		problem("%s and %s must be set together", fn.WebPushVAPIDPublicKey, fn.WebPushVAPIDPrivateKey)
+	}
+
+	// smtp
+	if c.SMTPConfig.Host != "" {
+		if c.SMTPConfig.Port <= 0 || c.SMTPConfig.Port > 65535 {
+			problem("%s must be a valid port number, got %d", fn.SMTPPort, c.SMTPConfig.Port)
+		}
+		if !strings.Contains(c.SMTPConfig.From, "@") {
+			problem("%s must be an email address when %s is set, got '%s'", fn.SMTPFrom, fn.SMTPHost, c.SMTPConfig.From)
+		}
+	}
+
+	// rate limits
+	for _, t := range []struct {
+		flag  string
+		value int
+	}{
+		{fn.RateLimitAPIRequests, c.RateLimitConfig.APIRequests},
+		{fn.RateLimitInboxRequests, c.RateLimitConfig.InboxRequests},
+		{fn.RateLimitAuthRequests, c.RateLimitConfig.AuthRequests},
+	} {
+		if t.value < 0 {
+			problem("%s must not be negative", t.flag)
+		}
+	}
+	for _, ip := range c.RateLimitConfig.ExemptIPs {
+		if _, _, err := net.ParseCIDR(ip); err != nil && net.ParseIP(ip) == nil {
+			problem("%s must be ip addresses or ranges in CIDR notation, got '%s'", fn.RateLimitExemptIPs, ip)
+		}
+	}
+
+	if len(problems) != 0 {
+		return errors.New("invalid config: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ValidateTestSuite) TestValidateTestDefault() {
+	suite.NoError(config.TestDefault().Validate())
+}
+
+func (suite *ValidateTestSuite) TestValidateReportsAllProblems() {
+	c := config.TestDefault()
+	c.Host = ""
+	c.DBConfig.Type = "mysql"
+	c.OIDCConfig.Enabled = true
+	c.OIDCConfig.Issuer = ""
+
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: host was not set; db-type must be one of postgres or sqlite, got 'mysql'; oidc-issuer must be set when oidc is enabled; oidc-client-id must be set when oidc is enabled; oidc-client-secret must be set when oidc is enabled")
+}
+
+func (suite *ValidateTestSuite) TestValidateOIDCUsernameConflict() {
+	c := config.TestDefault()
+	c.OIDCConfig.Enabled = true
+	c.OIDCConfig.Issuer = "https://example.org"
+	c.OIDCConfig.ClientID = "gotosocial"
+	c.OIDCConfig.ClientSecret = "secret"
+	c.OIDCConfig.UsernameConflict = "overwrite"
+
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: oidc-username-conflict must be one of increment or reject, got 'overwrite'")
+
+	c.OIDCConfig.UsernameConflict = config.OIDCUsernameConflictReject
+	suite.NoError(c.Validate())
+}
+
+func (suite *ValidateTestSuite) TestValidateS3Storage() {
+	c := config.TestDefault()
+	c.StorageConfig.Backend = "s3"
+	c.StorageConfig.S3Endpoint = "http://localhost:9000"
+	c.StorageConfig.S3AccessKey = "key"
+
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: storage-s3-bucket was not set; storage-s3-access-key and storage-s3-secret-key must both be set")
+
+	c.StorageConfig.S3Bucket = "gotosocial"
+	c.StorageConfig.S3SecretKey = "secret"
+	suite.NoError(c.Validate())
+}
+
+func (suite *ValidateTestSuite) TestValidateS3PresignLocalStorage() {
+	c := config.TestDefault()
+	c.StorageConfig.S3Presign = true
+
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: storage-s3-presign can only be used with the s3 backend")
+}
+
+func (suite *ValidateTestSuite) TestValidateLogFormat() {
+	c := config.TestDefault()
+	c.LogFormat = "xml"
+
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: log-format must be one of text or json, got 'xml'")
+
+	c.LogFormat = "json"
+	suite.NoError(c.Validate())
+}
+
+func (suite *ValidateTestSuite) TestValidateThrottling() {
+	c := config.TestDefault()
+	c.ThrottlingConfig.SearchConcurrency = 0
+	suite.NoError(c.Validate())
+
+	c.ThrottlingConfig.InboxPerIPPerMinute = -1
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: throttling-inbox-per-ip-per-minute must not be negative")
+}
+
+func (suite *ValidateTestSuite) TestValidateRateLimit() {
+	c := config.TestDefault()
+	c.RateLimitConfig.ExemptIPs = []string{"10.0.0.0/8", "192.168.1.1"}
+	suite.NoError(c.Validate())
+
+	c.RateLimitConfig.APIRequests = -1
+	c.RateLimitConfig.ExemptIPs = []string{"localhost"}
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: rate-limit-api-requests must not be negative; rate-limit-exempt-ips must be ip addresses or ranges in CIDR notation, got 'localhost'")
+}
+
+func (suite *ValidateTestSuite) TestValidateRequestLimits() {
+	c := config.TestDefault()
+	c.RequestLimitsConfig.Timeout = 0
+	c.RequestLimitsConfig.MediaBodySize = 0
+	suite.NoError(c.Validate())
+
+	c.RequestLimitsConfig.ReadTimeout = -1
+	c.RequestLimitsConfig.MediaBodySize = 2097152
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: request-limits-read-timeout must not be negative; request-limits-media-body-size must not be less than media-max-video-size")
+}
+
+func (suite *ValidateTestSuite) TestValidateCaptcha() {
+	c := config.TestDefault()
+	c.CaptchaConfig.Provider = "mcaptcha"
+
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: captcha-site-key must be set when a captcha provider is set; captcha-secret-key must be set when a captcha provider is set; captcha-url must be set when using the mcaptcha provider")
+
+	c.CaptchaConfig.Provider = "hcaptcha"
+	c.CaptchaConfig.SiteKey = "site"
+	c.CaptchaConfig.SecretKey = "secret"
+	suite.NoError(c.Validate())
+
+	c.CaptchaConfig.Provider = "turnstile"
+	err = c.Validate()
+	suite.EqualError(err, "invalid config: captcha-provider must be one of hcaptcha, recaptcha or mcaptcha, got 'turnstile'")
+}
+
+func (suite *ValidateTestSuite) TestValidateHiddenServices() {
+	c := config.TestDefault()
+	c.HiddenServicesConfig.OnionHost = "gtsexampleonionaddress.onion"
+	c.HiddenServicesConfig.TorProxy = "127.0.0.1:9050"
+	suite.NoError(c.Validate())
+
+	c.HiddenServicesConfig.OnionHost = "http://gtsexampleonionaddress.onion"
+	c.HiddenServicesConfig.I2PProxy = "localhost"
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: hidden-services-onion-host must be a host name without a scheme or path, got 'http://gtsexampleonionaddress.onion'; hidden-services-i2p-proxy must be a host:port address, got 'localhost'")
+
+	c.HiddenServicesConfig.OnionHost = "example.org"
+	c.HiddenServicesConfig.I2PProxy = ""
+	err = c.Validate()
+	suite.EqualError(err, "invalid config: hidden-services-onion-host must end in .onion, got 'example.org'")
+}
+
+func (suite *ValidateTestSuite) TestValidateOutboundProxy() {
+	c := config.TestDefault()
+	c.OutboundProxyConfig.URL = "http://proxy.example.org:3128"
+	c.OutboundProxyConfig.Domains = []string{"example.org=direct", "example.com=socks5://127.0.0.1:1080"}
+	suite.NoError(c.Validate())
+
+	c.OutboundProxyConfig.URL = "proxy.example.org:3128"
+	c.OutboundProxyConfig.Domains = []string{"example.org", "example.com=ftp://127.0.0.1"}
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: outbound-proxy-url must be an http, https or socks5 url, got 'proxy.example.org:3128'; outbound-proxy-domains entries must be in the form 'domain=proxy url' or 'domain=direct', got 'example.org'; outbound-proxy-domains entries must use 'direct' or an http, https or socks5 url, got 'example.com=ftp://127.0.0.1'")
+}
+
+func (suite *ValidateTestSuite) TestValidateErrorReporting() {
+	c := config.TestDefault()
+	c.ErrorReportingConfig.DSN = "https://publickey@sentry.example.org/1"
+	c.ErrorReportingConfig.WebhookURL = "https://hooks.example.org/errors"
+	suite.NoError(c.Validate())
+
+	c.ErrorReportingConfig.DSN = "https://sentry.example.org/1"
+	c.ErrorReportingConfig.WebhookURL = "hooks.example.org/errors"
+	err := c.Validate()
+	suite.EqualError(err, "invalid config: error-reporting-dsn must be a dsn like https://publickey@sentry.example.org/1, got 'https://sentry.example.org/1'; error-reporting-webhook-url must be an http or https url, got 'hooks.example.org/errors'")
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, &ValidateTestSuite{})
+}
@@ -0,0 +1,31 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// WebPushConfig pertains to delivering notifications to web push subscriptions, as described in RFC 8030.
+type WebPushConfig struct {
+	// Email address that push services can use to contact the instance admin, sent with every push message.
+	// If not set, the url of the instance is sent instead.
+	ContactEmail string `yaml:"contactEmail"`
+	// Base64url encoded VAPID public key to identify the instance to push services with. If not set, a key pair
+	// is generated the first time it's needed and stored in the database.
+	VAPIDPublicKey string `yaml:"vapidPublicKey"`
+	// Base64url encoded VAPID private key belonging to VAPIDPublicKey.
+	VAPIDPrivateKey string `yaml:"vapidPrivateKey"`
+}
@@ -42,6 +42,11 @@ type Account interface {
 	// GetLocalAccountByUsername returns an account on this instance by its username.
 	GetLocalAccountByUsername(ctx context.Context, username string) (*gtsmodel.Account, Error)
 
+	// GetLocalAccounts returns up to limit accounts on this instance with an ID greater than sinceID, oldest first,
+	// so that all local accounts can be worked through in batches.
+	// In case of no entries, a 'no entries' error will be returned
+	GetLocalAccounts(ctx context.Context, sinceID string, limit int) ([]*gtsmodel.Account, Error)
+
 	// GetAccountFaves fetches faves/likes created by the target accountID.
 	GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, Error)
 
@@ -51,11 +56,28 @@ type Account interface {
 	// GetAccountStatuses is a shortcut for getting the most recent statuses. accountID is optional, if not provided
 	// then all statuses will be returned. If limit is set to 0, the size of the returned slice will not be limited. This can
 	// be very memory intensive so you probably shouldn't do this!
+	//
+	// Replies are excluded with excludeReplies, except for replies to the account's own statuses, and boosts are
+	// excluded with excludeReblogs. If tagged is set, only statuses using the hashtag with that name are returned.
 	// In case of no entries, a 'no entries' error will be returned
-	GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, maxID string, pinnedOnly bool, mediaOnly bool) ([]*gtsmodel.Status, Error)
+	GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, sinceID string, minID string, pinnedOnly bool, mediaOnly bool, tagged string) ([]*gtsmodel.Status, Error)
+
+	// GetAccountWebStatuses is similar to GetAccountStatuses, but it only returns statuses that are suitable for
+	// showing to anyone on the web: public, top-level posts that aren't boosts.
+	// In case of no entries, a 'no entries' error will be returned
+	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, Error)
+
+	// GetAccountOutboxStatuses returns public and unlocked statuses and boosts created by the given account that aren't local-only,
+	// suitable for serving as items in the account's ActivityPub outbox.
+	// In case of no entries, a 'no entries' error will be returned
+	GetAccountOutboxStatuses(ctx context.Context, accountID string, limit int, maxID string, minID string) ([]*gtsmodel.Status, Error)
 
 	GetAccountBlocks(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.Account, string, string, Error)
 
+	// GetAccountMutes returns the unexpired mutes created by the given accountID, with their target accounts populated.
+	// The next max ID and previous min ID for paging are returned along with the mutes.
+	GetAccountMutes(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.AccountMute, string, string, Error)
+
 	// GetAccountLastPosted simply gets the timestamp of the most recent post by the account.
 	//
 	// The returned time will be zero if account has never posted anything.
@@ -67,4 +89,16 @@ type Account interface {
 	// GetInstanceAccount returns the instance account for the given domain.
 	// If domain is empty, this instance account will be returned.
 	GetInstanceAccount(ctx context.Context, domain string) (*gtsmodel.Account, Error)
+
+	// GetPrunableRemoteAccounts returns up to limit remote accounts that haven't been updated since olderThan,
+	// and that have no follows, follow requests, or blocks, and no interactions with local accounts or statuses.
+	// These are just taking up space in the cache, and can be removed safely.
+	GetPrunableRemoteAccounts(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.Account, Error)
+
+	// SearchForAccounts returns accounts whose username starts with query, or whose display name contains it,
+	// matched case-insensitively. If domain is set, only accounts whose domain starts with it are returned.
+	// If following is true, only accounts followed by the given accountID are returned.
+	//
+	// Suspended accounts and instance accounts are left out, and exact username matches are returned first.
+	SearchForAccounts(ctx context.Context, accountID string, query string, domain string, following bool, limit int, offset int) ([]*gtsmodel.Account, Error)
 }
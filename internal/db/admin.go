@@ -51,4 +51,21 @@ type Admin interface {
 	// Ie., if the instance is hosted at 'example.org' the instance will have a domain of 'example.org'.
 	// This is needed for things like serving instance information through /api/v1/instance
 	CreateInstanceInstance(ctx context.Context) Error
+
+	// GetStatusExpiryUsers returns all users who have chosen to have their old statuses deleted automatically.
+	// In case of no entries, a 'no entries' error will be returned
+	GetStatusExpiryUsers(ctx context.Context) ([]*gtsmodel.User, Error)
+
+	// GetAdminAccounts returns accounts known to this instance for moderation, newest first, starting from maxID (exclusive).
+	// Each filter that is set narrows the results: local and remote restrict them to accounts of that origin, pending to local
+	// accounts whose signup hasn't been approved yet, and suspended and silenced to accounts that have had that action taken.
+	GetAdminAccounts(ctx context.Context, local bool, remote bool, pending bool, suspended bool, silenced bool, maxID string, limit int) ([]*gtsmodel.Account, Error)
+
+	// CreateAdminAuditLog appends an entry to the admin audit log, recording that the given admin account took action on the given target.
+	// Summary should be something that identifies the target to a human, eg., a username or a domain.
+	CreateAdminAuditLog(ctx context.Context, accountID string, action gtsmodel.AdminAuditLogAction, targetType gtsmodel.AdminAuditLogTarget, targetID string, summary string) Error
+
+	// GetAdminAuditLogs returns entries of the admin audit log, newest first, starting from maxID (exclusive).
+	// If accountID is set, only actions taken by that account will be returned.
+	GetAdminAuditLogs(ctx context.Context, accountID string, maxID string, limit int) ([]*gtsmodel.AdminAuditLog, Error)
 }
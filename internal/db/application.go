@@ -0,0 +1,44 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Application contains functionality for getting the oauth applications and tokens that users have authorized.
+type Application interface {
+	// GetApplicationByClientID returns the application that owns the oauth client with the given ID.
+	GetApplicationByClientID(ctx context.Context, clientID string) (*gtsmodel.Application, Error)
+
+	// GetTokensForUserID returns all oauth tokens held by the given user, newest first.
+	GetTokensForUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, Error)
+
+	// DeleteTokensForClientIDAndUserID deletes every oauth token and authorization code
+	// that the given client holds on behalf of the given user.
+	DeleteTokensForClientIDAndUserID(ctx context.Context, clientID string, userID string) Error
+
+	// UpdateTokenLastUsed records that the token with the given access code was used from the given IP at the given time.
+	// To save writing on every request, nothing is updated if the token was already recorded as used after notBefore.
+	UpdateTokenLastUsed(ctx context.Context, access string, ip net.IP, at time.Time, notBefore time.Time) Error
+}
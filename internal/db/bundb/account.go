@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
@@ -127,13 +128,13 @@ func (a *accountDB) GetInstanceAccount(ctx context.Context, domain string) (*gts
 
 	q := a.newAccountQ(account)
 
-	if domain == "" {
+	if domain != "" {
 		q = q.
 			Where("account.username = ?", domain).
 			Where("account.domain = ?", domain)
 	} else {
 		q = q.
-			Where("account.username = ?", domain).
+			Where("account.username = ?", a.config.Host).
 			WhereGroup(" AND ", whereEmptyOrNull("domain"))
 	}
 
@@ -231,16 +232,17 @@ func (a *accountDB) CountAccountStatuses(ctx context.Context, accountID string)
 		Count(ctx)
 }
 
-func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, maxID string, pinnedOnly bool, mediaOnly bool) ([]*gtsmodel.Status, db.Error) {
+func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, sinceID string, minID string, pinnedOnly bool, mediaOnly bool, tagged string) ([]*gtsmodel.Status, db.Error) {
 	statuses := []*gtsmodel.Status{}
 
 	q := a.conn.
 		NewSelect().
 		Model(&statuses).
-		Order("id DESC")
+		ColumnExpr("status.*").
+		Order("status.id DESC")
 
 	if accountID != "" {
-		q = q.Where("account_id = ?", accountID)
+		q = q.Where("status.account_id = ?", accountID)
 	}
 
 	if limit != 0 {
@@ -248,23 +250,124 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	}
 
 	if pinnedOnly {
-		q = q.Where("pinned = ?", true)
+		q = q.Where("status.pinned = ?", true)
 	}
 
 	if maxID != "" {
-		q = q.Where("id < ?", maxID)
+		// return only statuses LOWER (ie., older) than maxID
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		// return only statuses HIGHER (ie., newer) than sinceID
+		q = q.Where("status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		// return only statuses HIGHER (ie., newer) than minID
+		q = q.Where("status.id > ?", minID)
 	}
 
 	if mediaOnly {
 		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
 			return q.
-				WhereOr("? IS NOT NULL", bun.Ident("attachments")).
-				WhereOr("attachments != '{}'")
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? NOT IN ('{}', '[]', 'null', '')", bun.Ident("status.attachments"))
 		})
 	}
 
 	if excludeReplies {
-		q = q.WhereGroup(" AND ", whereEmptyOrNull("in_reply_to_id"))
+		// replies to the account's own statuses are part of a thread, so they're kept
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereGroup(" OR ", whereEmptyOrNull("status.in_reply_to_id")).
+				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), bun.Ident("status.account_id"))
+		})
+	}
+
+	if excludeReblogs {
+		q = q.WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id"))
+	}
+
+	if tagged != "" {
+		// find statuses that use the tag
+		q = q.
+			Join("JOIN status_to_tags AS st ON st.status_id = status.id").
+			Join("JOIN tags AS tag ON tag.id = st.tag_id").
+			Where("LOWER(tag.name) = LOWER(?)", tagged)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(statuses) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return statuses, nil
+}
+
+func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, db.Error) {
+	statuses := []*gtsmodel.Status{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&statuses).
+		Where("account_id = ?", accountID).
+		Where("visibility = ?", gtsmodel.VisibilityPublic).
+		WhereGroup(" AND ", whereEmptyOrNull("in_reply_to_id")).
+		WhereGroup(" AND ", whereEmptyOrNull("boost_of_id")).
+		Order("id DESC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if maxID != "" {
+		q = q.Where("id < ?", maxID)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(statuses) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return statuses, nil
+}
+
+func (a *accountDB) GetAccountOutboxStatuses(ctx context.Context, accountID string, limit int, maxID string, minID string) ([]*gtsmodel.Status, db.Error) {
+	statuses := []*gtsmodel.Status{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&statuses).
+		Where("account_id = ?", accountID).
+		Where("visibility IN (?)", bun.In([]gtsmodel.Visibility{gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked})).
+		Where("federated = ?", true)
+
+	if maxID != "" {
+		q = q.Where("id < ?", maxID)
+	}
+
+	// if we're paging up from minID without a maxID, take the statuses
+	// directly after minID, and then flip them back to newest first
+	pagingUp := minID != "" && maxID == ""
+	if minID != "" {
+		q = q.Where("id > ?", minID)
+	}
+
+	if pagingUp {
+		q = q.Order("id ASC")
+	} else {
+		q = q.Order("id DESC")
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
 	}
 
 	if err := q.Scan(ctx); err != nil {
@@ -275,6 +378,12 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 		return nil, db.ErrNoEntries
 	}
 
+	if pagingUp {
+		for i, j := 0, len(statuses)-1; i < j; i, j = i+1, j-1 {
+			statuses[i], statuses[j] = statuses[j], statuses[i]
+		}
+	}
+
 	return statuses, nil
 }
 
@@ -318,3 +427,149 @@ func (a *accountDB) GetAccountBlocks(ctx context.Context, accountID string, maxI
 	prevMinID := blocks[0].ID
 	return accounts, nextMaxID, prevMinID, nil
 }
+
+func (a *accountDB) GetAccountMutes(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.AccountMute, string, string, db.Error) {
+	mutes := []*gtsmodel.AccountMute{}
+
+	fq := a.conn.
+		NewSelect().
+		Model(&mutes).
+		Where("account_mute.account_id = ?", accountID).
+		WhereGroup(" AND ", whereNotExpired("account_mute.expires_at")).
+		Relation("TargetAccount").
+		Order("account_mute.id DESC")
+
+	if maxID != "" {
+		fq = fq.Where("account_mute.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		fq = fq.Where("account_mute.id > ?", sinceID)
+	}
+
+	if limit > 0 {
+		fq = fq.Limit(limit)
+	}
+
+	err := fq.Scan(ctx)
+	if err != nil {
+		return nil, "", "", a.conn.ProcessError(err)
+	}
+
+	if len(mutes) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	nextMaxID := mutes[len(mutes)-1].ID
+	prevMinID := mutes[0].ID
+	return mutes, nextMaxID, prevMinID, nil
+}
+
+func (a *accountDB) GetLocalAccounts(ctx context.Context, sinceID string, limit int) ([]*gtsmodel.Account, db.Error) {
+	accounts := []*gtsmodel.Account{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&accounts).
+		Where("(? IS NULL OR ? = '')", bun.Ident("account.domain"), bun.Ident("account.domain")).
+		Order("account.id ASC")
+
+	if sinceID != "" {
+		q = q.Where("? > ?", bun.Ident("account.id"), sinceID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(accounts) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return accounts, nil
+}
+
+func (a *accountDB) GetPrunableRemoteAccounts(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.Account, db.Error) {
+	accounts := []*gtsmodel.Account{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&accounts).
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Where("? != ''", bun.Ident("account.domain")).
+		Where("? < ?", bun.Ident("account.updated_at"), olderThan).
+		// no relationships in either direction
+		Where("NOT EXISTS (SELECT 1 FROM follows WHERE follows.account_id = account.id OR follows.target_account_id = account.id)").
+		Where("NOT EXISTS (SELECT 1 FROM follow_requests WHERE follow_requests.account_id = account.id OR follow_requests.target_account_id = account.id)").
+		Where("NOT EXISTS (SELECT 1 FROM blocks WHERE blocks.account_id = account.id OR blocks.target_account_id = account.id)").
+		Where("NOT EXISTS (SELECT 1 FROM account_mutes WHERE account_mutes.account_id = account.id OR account_mutes.target_account_id = account.id)").
+		// they haven't interacted with any local accounts
+		Where("NOT EXISTS (SELECT 1 FROM notifications WHERE notifications.origin_account_id = account.id)").
+		// and no local accounts have interacted with them
+		Where("NOT EXISTS (SELECT 1 FROM status_faves WHERE status_faves.target_account_id = account.id)").
+		Where("NOT EXISTS (SELECT 1 FROM status_bookmarks WHERE status_bookmarks.target_account_id = account.id)").
+		Where("NOT EXISTS (SELECT 1 FROM status_mutes WHERE status_mutes.target_account_id = account.id)").
+		Where("NOT EXISTS (SELECT 1 FROM statuses WHERE statuses.local = ? AND (statuses.in_reply_to_account_id = account.id OR statuses.boost_of_account_id = account.id))", true).
+		Where("NOT EXISTS (SELECT 1 FROM mentions JOIN statuses ON statuses.id = mentions.status_id WHERE mentions.target_account_id = account.id AND statuses.local = ?)", true).
+		Order("account.id ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return accounts, nil
+}
+
+func (a *accountDB) SearchForAccounts(ctx context.Context, accountID string, query string, domain string, following bool, limit int, offset int) ([]*gtsmodel.Account, db.Error) {
+	accounts := []*gtsmodel.Account{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&accounts).
+		Where("account.suspended_at IS NULL").
+		// leave out instance accounts, ours and everyone else's
+		Where("NOT (account.username = ? AND (account.domain IS NULL OR account.domain = ''))", a.config.Host).
+		Where("(account.domain IS NULL OR account.username != account.domain)").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereOr("LOWER(account.username) LIKE ? ESCAPE '\\'", likePattern(query, true)).
+				WhereOr("LOWER(account.display_name) LIKE ? ESCAPE '\\'", likePattern(query, false))
+		}).
+		// exact username matches first, then everything else alphabetically
+		OrderExpr("CASE WHEN LOWER(account.username) = ? THEN 0 ELSE 1 END", strings.ToLower(query)).
+		Order("account.username ASC", "account.id ASC")
+
+	if domain != "" {
+		q = q.WhereGroup(" AND ", whereLike("account.domain", domain, true))
+	}
+
+	if following {
+		q = q.Where("account.id IN (?)", a.conn.
+			NewSelect().
+			Model((*gtsmodel.Follow)(nil)).
+			Column("target_account_id").
+			Where("account_id = ?", accountID))
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return accounts, nil
+}
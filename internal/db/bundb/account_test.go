@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -60,6 +61,72 @@ func (suite *AccountTestSuite) TestUpdateAccount() {
 	suite.WithinDuration(time.Now(), updated.UpdatedAt, 5*time.Second)
 }
 
+func (suite *AccountTestSuite) TestGetAccountWebStatuses() {
+	statuses, err := suite.db.GetAccountWebStatuses(context.Background(), suite.testAccounts["admin_account"].ID, 20, "")
+	suite.NoError(err)
+
+	// the admin's reply isn't included, just the public top-level posts
+	suite.Len(statuses, 2)
+	suite.Equal(suite.testStatuses["admin_account_status_2"].ID, statuses[0].ID)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[1].ID)
+
+	statuses, err = suite.db.GetAccountWebStatuses(context.Background(), suite.testAccounts["admin_account"].ID, 20, statuses[0].ID)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// local_account_1 has no public top-level posts other than its introduction
+	statuses, err = suite.db.GetAccountWebStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 20, "")
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].ID, statuses[0].ID)
+}
+
+func (suite *AccountTestSuite) TestGetAccountStatusesFilters() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["admin_account"].ID
+
+	// the admin's reply to local_account_1 is left out, but a reply to themself would be kept
+	statuses, err := suite.db.GetAccountStatuses(ctx, accountID, 20, true, false, "", "", "", false, false, "")
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+	suite.Equal(suite.testStatuses["admin_account_status_2"].ID, statuses[0].ID)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[1].ID)
+
+	// only the first status has media
+	statuses, err = suite.db.GetAccountStatuses(ctx, accountID, 20, false, false, "", "", "", false, true, "")
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+
+	// local_account_1_status_5 has an empty, rather than null, list of attachments
+	statuses, err = suite.db.GetAccountStatuses(ctx, suite.testAccounts["local_account_1"].ID, 20, false, false, "", "", "", false, true, "")
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["local_account_1_status_4"].ID, statuses[0].ID)
+
+	// since_id and min_id both give statuses newer than the given one
+	statuses, err = suite.db.GetAccountStatuses(ctx, accountID, 20, false, false, "", suite.testStatuses["admin_account_status_2"].ID, "", false, false, "")
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_3"].ID, statuses[0].ID)
+
+	statuses, err = suite.db.GetAccountStatuses(ctx, accountID, 20, false, false, "", "", suite.testStatuses["admin_account_status_1"].ID, false, false, "")
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	// updating the status links it to the tags it uses
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	suite.NoError(suite.db.UpdateStatus(ctx, testStatus))
+
+	statuses, err = suite.db.GetAccountStatuses(ctx, accountID, 20, false, false, "", "", "", false, false, "Welcome")
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(testStatus.ID, statuses[0].ID)
+
+	_, err = suite.db.GetAccountStatuses(ctx, accountID, 20, false, false, "", "", "", false, false, "nobodyusesthistag")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *AccountTestSuite) TestInsertAccountWithDefaults() {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	suite.NoError(err)
@@ -88,6 +155,29 @@ func (suite *AccountTestSuite) TestInsertAccountWithDefaults() {
 	suite.False(newAccount.HideCollections)
 }
 
+func (suite *AccountTestSuite) TestGetPrunableRemoteAccounts() {
+	ctx := context.Background()
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	// remote_account_1 is blocked by local_account_2, and mentioned by local_account_1
+	accounts, err := suite.db.GetPrunableRemoteAccounts(ctx, time.Now(), 0)
+	suite.NoError(err)
+	suite.Empty(accounts)
+
+	suite.NoError(suite.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: remoteAccount.ID}}, &[]*gtsmodel.Block{}))
+	suite.NoError(suite.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: remoteAccount.ID}}, &[]*gtsmodel.Mention{}))
+
+	accounts, err = suite.db.GetPrunableRemoteAccounts(ctx, time.Now(), 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(remoteAccount.ID, accounts[0].ID)
+
+	// it was last updated more recently than this
+	accounts, err = suite.db.GetPrunableRemoteAccounts(ctx, time.Now().Add(-72*time.Hour), 0)
+	suite.NoError(err)
+	suite.Empty(accounts)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
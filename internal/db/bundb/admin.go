@@ -20,6 +20,7 @@ package bundb
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"database/sql"
@@ -29,12 +30,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -86,7 +89,7 @@ func (a *adminDB) IsEmailAvailable(ctx context.Context, email string) (bool, db.
 func (a *adminDB) NewSignup(ctx context.Context, username string, reason string, requireApproval bool, email string, password string, signUpIP net.IP, locale string, appID string, emailVerified bool, admin bool) (*gtsmodel.User, db.Error) {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
-		a.conn.log.Errorf("error creating new rsa key: %s", err)
+		a.conn.log.WithError(err).Error("error creating new rsa key")
 		return nil, err
 	}
 
@@ -123,6 +126,9 @@ func (a *adminDB) NewSignup(ctx context.Context, username string, reason string,
 			FollowingURI:          newAccountURIs.FollowingURI,
 			FeaturedCollectionURI: newAccountURIs.CollectionURI,
 		}
+		if err := a.addEd25519Key(acct, newAccountURIs); err != nil {
+			return nil, err
+		}
 		if _, err = a.conn.
 			NewInsert().
 			Model(acct).
@@ -182,16 +188,17 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) db.Error {
 		Where("username = ?", username).
 		WhereGroup(" AND ", whereEmptyOrNull("domain"))
 	count, err := existsQ.Count(ctx)
-	if err != nil && count == 1 {
-		a.conn.log.Infof("instance account %s already exists", username)
-		return nil
-	} else if err != sql.ErrNoRows {
+	if err != nil && err != sql.ErrNoRows {
 		return a.conn.ProcessError(err)
 	}
+	if count != 0 {
+		a.conn.log.WithContext(ctx).WithField("username", username).Info("instance account already exists")
+		return nil
+	}
 
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
-		a.conn.log.Errorf("error creating new rsa key: %s", err)
+		a.conn.log.WithError(err).Error("error creating new rsa key")
 		return err
 	}
 
@@ -217,6 +224,9 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) db.Error {
 		FollowingURI:          newAccountURIs.FollowingURI,
 		FeaturedCollectionURI: newAccountURIs.CollectionURI,
 	}
+	if err := a.addEd25519Key(acct, newAccountURIs); err != nil {
+		return err
+	}
 
 	insertQ := a.conn.
 		NewInsert().
@@ -226,7 +236,34 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) db.Error {
 		return a.conn.ProcessError(err)
 	}
 
-	a.conn.log.Infof("instance account %s CREATED with id %s", username, acct.ID)
+	a.conn.log.WithContext(ctx).WithFields(logrus.Fields{
+		"username":  username,
+		"accountID": acct.ID,
+	}).Info("created instance account")
+	return nil
+}
+
+// addEd25519Key generates an ed25519 key for the given new local account, if
+// the instance is configured to give new accounts one. Accounts always keep
+// their rsa key as well, for remotes that don't understand ed25519 signatures.
+func (a *adminDB) addEd25519Key(acct *gtsmodel.Account, accountURIs *util.UserURIs) error {
+	if a.config.AccountsConfig.KeyType != config.KeyTypeEd25519 {
+		return nil
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		a.conn.log.WithError(err).Error("error creating new ed25519 key")
+		return err
+	}
+
+	encodedPrivateKey, err := util.EncodeEd25519PrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	acct.Ed25519PrivateKey = encodedPrivateKey
+	acct.Ed25519PublicKeyURI = accountURIs.Ed25519PublicKeyURI
 	return nil
 }
 
@@ -244,7 +281,7 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) db.Error {
 		return err
 	}
 	if exists {
-		a.conn.log.Infof("instance entry already exists")
+		a.conn.log.Info("instance entry already exists")
 		return nil
 	}
 
@@ -269,6 +306,126 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) db.Error {
 		return a.conn.ProcessError(err)
 	}
 
-	a.conn.log.Infof("created instance instance %s with id %s", domain, i.ID)
+	a.conn.log.WithContext(ctx).WithFields(logrus.Fields{
+		"domain":     domain,
+		"instanceID": i.ID,
+	}).Info("created instance entry")
+	return nil
+}
+
+func (a *adminDB) GetStatusExpiryUsers(ctx context.Context) ([]*gtsmodel.User, db.Error) {
+	users := []*gtsmodel.User{}
+
+	if err := a.conn.
+		NewSelect().
+		Model(&users).
+		Where("? > 0", bun.Ident("user.status_expiry_days")).
+		Order("user.id ASC").
+		Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(users) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return users, nil
+}
+
+func (a *adminDB) GetAdminAccounts(ctx context.Context, local bool, remote bool, pending bool, suspended bool, silenced bool, maxID string, limit int) ([]*gtsmodel.Account, db.Error) {
+	accounts := []*gtsmodel.Account{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&accounts).
+		Order("account.id DESC")
+
+	if local || pending {
+		q = q.WhereGroup(" AND ", whereEmptyOrNull("account.domain"))
+	}
+
+	if remote {
+		q = q.
+			Where("? IS NOT NULL", bun.Ident("account.domain")).
+			Where("? != ''", bun.Ident("account.domain"))
+	}
+
+	if pending {
+		q = q.Where("EXISTS (SELECT 1 FROM users WHERE users.account_id = account.id AND users.approved = ?)", false)
+	}
+
+	if suspended {
+		q = q.Where("? IS NOT NULL", bun.Ident("account.suspended_at"))
+	}
+
+	if silenced {
+		q = q.Where("? IS NOT NULL", bun.Ident("account.silenced_at"))
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return accounts, nil
+}
+
+func (a *adminDB) CreateAdminAuditLog(ctx context.Context, accountID string, action gtsmodel.AdminAuditLogAction, targetType gtsmodel.AdminAuditLogTarget, targetID string, summary string) db.Error {
+	logID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	auditLog := &gtsmodel.AdminAuditLog{
+		ID:         logID,
+		AccountID:  accountID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Summary:    summary,
+	}
+
+	if _, err := a.conn.
+		NewInsert().
+		Model(auditLog).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+
 	return nil
 }
+
+func (a *adminDB) GetAdminAuditLogs(ctx context.Context, accountID string, maxID string, limit int) ([]*gtsmodel.AdminAuditLog, db.Error) {
+	auditLogs := []*gtsmodel.AdminAuditLog{}
+
+	q := a.conn.
+		NewSelect().
+		Model(&auditLogs).
+		Relation("Account").
+		Order("admin_audit_log.id DESC")
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("admin_audit_log.account_id"), accountID)
+	}
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("admin_audit_log.id"), maxID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return auditLogs, nil
+}
@@ -0,0 +1,92 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type applicationDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (a *applicationDB) GetApplicationByClientID(ctx context.Context, clientID string) (*gtsmodel.Application, db.Error) {
+	app := &gtsmodel.Application{}
+
+	if err := a.conn.
+		NewSelect().
+		Model(app).
+		Where("application.client_id = ?", clientID).
+		Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+	return app, nil
+}
+
+func (a *applicationDB) GetTokensForUserID(ctx context.Context, userID string) ([]*gtsmodel.Token, db.Error) {
+	tokens := []*gtsmodel.Token{}
+
+	if err := a.conn.
+		NewSelect().
+		Model(&tokens).
+		Where("token.user_id = ?", userID).
+		Order("token.created_at DESC").
+		Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+	return tokens, nil
+}
+
+func (a *applicationDB) DeleteTokensForClientIDAndUserID(ctx context.Context, clientID string, userID string) db.Error {
+	if _, err := a.conn.
+		NewDelete().
+		Model(&gtsmodel.Token{}).
+		Where("client_id = ?", clientID).
+		Where("user_id = ?", userID).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+	return nil
+}
+
+func (a *applicationDB) UpdateTokenLastUsed(ctx context.Context, access string, ip net.IP, at time.Time, notBefore time.Time) db.Error {
+	if _, err := a.conn.
+		NewUpdate().
+		Model(&gtsmodel.Token{}).
+		Set("last_used_at = ?", at).
+		Set("last_used_ip = ?", ip).
+		Where("access = ?", access).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("last_used_at IS NULL").
+				WhereOr("last_used_at < ?", notBefore)
+		}).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+	return nil
+}
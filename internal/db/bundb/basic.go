@@ -127,6 +127,8 @@ func (b *basicDB) CreateAllTables(ctx context.Context) db.Error {
 		&gtsmodel.Application{},
 		&gtsmodel.Block{},
 		&gtsmodel.DomainBlock{},
+		&gtsmodel.DomainAllow{},
+		&gtsmodel.DomainPolicy{},
 		&gtsmodel.EmailDomainBlock{},
 		&gtsmodel.Follow{},
 		&gtsmodel.FollowRequest{},
@@ -136,22 +138,76 @@ func (b *basicDB) CreateAllTables(ctx context.Context) db.Error {
 		&gtsmodel.StatusToEmoji{},
 		&gtsmodel.StatusToTag{},
 		&gtsmodel.StatusFave{},
+		&gtsmodel.StatusReaction{},
 		&gtsmodel.StatusBookmark{},
 		&gtsmodel.StatusMute{},
+		&gtsmodel.StatusEdit{},
 		&gtsmodel.Tag{},
 		&gtsmodel.User{},
 		&gtsmodel.Emoji{},
 		&gtsmodel.Instance{},
+		&gtsmodel.InstancePage{},
 		&gtsmodel.Notification{},
 		&gtsmodel.RouterSession{},
 		&gtsmodel.Token{},
+		&gtsmodel.Tombstone{},
 		&gtsmodel.Client{},
+		&gtsmodel.FailedDelivery{},
+		&gtsmodel.DeliveryReceipt{},
+		&gtsmodel.InboxActivity{},
+		&gtsmodel.List{},
+		&gtsmodel.ListEntry{},
+		&gtsmodel.Filter{},
+		&gtsmodel.FilterKeyword{},
+		&gtsmodel.AccountMute{},
+		&gtsmodel.NotificationPreferences{},
+		&gtsmodel.SpamFlag{},
+		&gtsmodel.Report{},
+		&gtsmodel.WebPushSubscription{},
+		&gtsmodel.VAPIDKeyPair{},
+		&gtsmodel.Poll{},
+		&gtsmodel.PollVote{},
+		&gtsmodel.AccountNote{},
+		&gtsmodel.TagFollow{},
+		&gtsmodel.Rule{},
+		&gtsmodel.AccountExport{},
+		&gtsmodel.SuggestionDismissal{},
+		&gtsmodel.AdminAuditLog{},
+		&gtsmodel.ModerationNote{},
+		&gtsmodel.AccountWarning{},
+		&gtsmodel.PasswordReset{},
 	}
 	for _, i := range models {
 		if err := b.CreateTable(ctx, i); err != nil {
 			return err
 		}
 	}
+	return b.createIndexes(ctx)
+}
+
+// createIndexes creates indexes that speed up common queries, if they don't exist yet.
+// Keep these in step with the indexes created by migrations, so new and migrated databases match.
+func (b *basicDB) createIndexes(ctx context.Context) db.Error {
+	// used for fetching the statuses of an account, newest first
+	if _, err := b.conn.NewCreateIndex().
+		Model(&gtsmodel.Status{}).
+		Index("statuses_account_id_id_idx").
+		Column("account_id", "id").
+		IfNotExists().
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// used for fetching the statuses that use a tag
+	if _, err := b.conn.NewCreateIndex().
+		Model(&gtsmodel.StatusToTag{}).
+		Index("status_to_tags_tag_id_idx").
+		Column("tag_id").
+		IfNotExists().
+		Exec(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
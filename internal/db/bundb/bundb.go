@@ -64,16 +64,23 @@ var registerTables []interface{} = []interface{}{
 type bunDBService struct {
 	db.Account
 	db.Admin
+	db.Application
 	db.Basic
 	db.Domain
+	db.Filter
+	db.Inbox
 	db.Instance
+	db.List
 	db.Media
 	db.Mention
 	db.Notification
 	db.Relationship
 	db.Session
 	db.Status
+	db.Suggestion
+	db.Tag
 	db.Timeline
+	db.Tombstone
 	config *config.Config
 	conn   *DBConn
 }
@@ -100,7 +107,7 @@ func doMigration(ctx context.Context, db *bun.DB, log *logrus.Logger) error {
 		return nil
 	}
 
-	l.Infof("MIGRATED DATABASE TO %s", group)
+	l.WithField("group", group).Info("migrated database")
 	return nil
 }
 
@@ -180,6 +187,10 @@ func NewBunDBService(ctx context.Context, c *config.Config, log *logrus.Logger)
 			config: c,
 			conn:   conn,
 		},
+		Application: &applicationDB{
+			config: c,
+			conn:   conn,
+		},
 		Basic: &basicDB{
 			config: c,
 			conn:   conn,
@@ -188,10 +199,22 @@ func NewBunDBService(ctx context.Context, c *config.Config, log *logrus.Logger)
 			config: c,
 			conn:   conn,
 		},
+		Filter: &filterDB{
+			config: c,
+			conn:   conn,
+		},
+		Inbox: &inboxDB{
+			config: c,
+			conn:   conn,
+		},
 		Instance: &instanceDB{
 			config: c,
 			conn:   conn,
 		},
+		List: &listDB{
+			config: c,
+			conn:   conn,
+		},
 		Media: &mediaDB{
 			config: c,
 			conn:   conn,
@@ -220,10 +243,22 @@ func NewBunDBService(ctx context.Context, c *config.Config, log *logrus.Logger)
 			cache:    cache.NewStatusCache(),
 			accounts: accounts,
 		},
+		Suggestion: &suggestionDB{
+			config: c,
+			conn:   conn,
+		},
+		Tag: &tagDB{
+			config: c,
+			conn:   conn,
+		},
 		Timeline: &timelineDB{
 			config: c,
 			conn:   conn,
 		},
+		Tombstone: &tombstoneDB{
+			config: c,
+			conn:   conn,
+		},
 		config: c,
 		conn:   conn,
 	}
@@ -398,7 +433,10 @@ func (ps *bunDBService) MentionStringsToMentions(ctx context.Context, targetAcco
 		if err != nil {
 			if err == sql.ErrNoRows {
 				// no result found for this username/domain so just don't include it as a mencho and carry on about our business
-				ps.conn.log.Debugf("no account found with username '%s' and domain '%s', skipping it", username, domain)
+				ps.conn.log.WithContext(ctx).WithFields(logrus.Fields{
+					"username": username,
+					"domain":   domain,
+				}).Debug("no account found for mention, skipping it")
 				continue
 			}
 			// a serious error has happened so bail
@@ -460,11 +498,11 @@ func (ps *bunDBService) EmojiStringsToEmojis(ctx context.Context, emojis []strin
 	newEmojis := []*gtsmodel.Emoji{}
 	for _, e := range emojis {
 		emoji := &gtsmodel.Emoji{}
-		err := ps.conn.NewSelect().Model(emoji).Where("shortcode = ?", e).Where("visible_in_picker = true").Where("disabled = false").Scan(ctx)
+		err := ps.conn.NewSelect().Model(emoji).Where("shortcode = ?", e).Where("domain = ?", "").Where("visible_in_picker = true").Where("disabled = false").Scan(ctx)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				// no result found for this username/domain so just don't include it as an emoji and carry on about our business
-				ps.conn.log.Debugf("no emoji found with shortcode %s, skipping it", e)
+				ps.conn.log.WithContext(ctx).WithField("shortcode", e).Debug("no emoji found with shortcode, skipping it")
 				continue
 			}
 			// a serious error has happened so bail
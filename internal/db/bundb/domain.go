@@ -42,6 +42,7 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, db
 		NewSelect().
 		Model(&gtsmodel.DomainBlock{}).
 		Where("LOWER(domain) = LOWER(?)", domain).
+		Where("severity = ?", gtsmodel.DomainBlockSeveritySuspend).
 		Limit(1)
 
 	return d.conn.Exists(ctx, q)
@@ -76,3 +77,63 @@ func (d *domainDB) AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, d
 
 	return d.AreDomainsBlocked(ctx, domains)
 }
+
+func (d *domainDB) IsDomainAllowed(ctx context.Context, domain string) (bool, db.Error) {
+	if domain == "" {
+		return false, nil
+	}
+
+	q := d.conn.
+		NewSelect().
+		Model(&gtsmodel.DomainAllow{}).
+		Where("LOWER(domain) = LOWER(?)", domain).
+		Limit(1)
+
+	return d.conn.Exists(ctx, q)
+}
+
+func (d *domainDB) GetDomainPolicy(ctx context.Context, domain string) (*gtsmodel.DomainPolicy, db.Error) {
+	policy := &gtsmodel.DomainPolicy{}
+	if domain == "" {
+		return policy, nil
+	}
+
+	err := d.conn.
+		NewSelect().
+		Model(policy).
+		Where("LOWER(domain) = LOWER(?)", domain).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if err = d.conn.ProcessError(err); err != db.ErrNoEntries {
+			return nil, err
+		}
+		// no policy for this domain, so nothing is restricted unless a less severe domain block says otherwise
+		policy = &gtsmodel.DomainPolicy{Domain: domain}
+	}
+
+	// domain blocks that fall short of a suspension are enforced in the same way as the equivalent policy flags
+	block := &gtsmodel.DomainBlock{}
+	err = d.conn.
+		NewSelect().
+		Model(block).
+		Where("LOWER(domain) = LOWER(?)", domain).
+		Where("severity != ?", gtsmodel.DomainBlockSeveritySuspend).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if err = d.conn.ProcessError(err); err != db.ErrNoEntries {
+			return nil, err
+		}
+		return policy, nil
+	}
+
+	if block.Severity == gtsmodel.DomainBlockSeveritySilence {
+		policy.Silence = true
+	}
+	if block.RejectMedia {
+		policy.RejectMedia = true
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DomainTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DomainTestSuite) TestIsDomainAllowed() {
+	ctx := context.Background()
+
+	allowed, err := suite.db.IsDomainAllowed(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.False(allowed)
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.DomainAllow{
+		ID:                 "01FQN8P4V3C6G1WF5YX2HZE1KT",
+		Domain:             "Fossbros-Anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	allowed, err = suite.db.IsDomainAllowed(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.True(allowed)
+
+	allowed, err = suite.db.IsDomainAllowed(ctx, "example.org")
+	suite.NoError(err)
+	suite.False(allowed)
+}
+
+func (suite *DomainTestSuite) TestGetDomainPolicy() {
+	ctx := context.Background()
+
+	// no policy yet, so nothing should be restricted
+	policy, err := suite.db.GetDomainPolicy(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Empty(policy.ID)
+	suite.False(policy.RejectMedia)
+	suite.False(policy.Silence)
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.DomainPolicy{
+		ID:                 "01FQNB3J9YQ8VZ7V4A2DN5XW7C",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Silence:            true,
+	}))
+
+	policy, err = suite.db.GetDomainPolicy(ctx, "Fossbros-Anonymous.io")
+	suite.NoError(err)
+	suite.Equal("01FQNB3J9YQ8VZ7V4A2DN5XW7C", policy.ID)
+	suite.False(policy.RejectMedia)
+	suite.True(policy.Silence)
+}
+
+func (suite *DomainTestSuite) TestDomainBlockSeverity() {
+	ctx := context.Background()
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01FQNG4A7Y3RZ6X0HWFKX5V8BN",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Severity:           gtsmodel.DomainBlockSeveritySilence,
+		RejectMedia:        true,
+	}))
+
+	// a silence isn't a full block...
+	blocked, err := suite.db.IsDomainBlocked(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// ...but it shows up in the domain's policy
+	policy, err := suite.db.GetDomainPolicy(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.True(policy.Silence)
+	suite.True(policy.RejectMedia)
+	suite.False(policy.RejectBoosts)
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01FQNG5QJ2M8D4T6ZC0R9YEXKS",
+		Domain:             "example.org",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	// blocks are suspensions unless said otherwise
+	blocked, err = suite.db.IsDomainBlocked(ctx, "example.org")
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
+func TestDomainTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainTestSuite))
+}
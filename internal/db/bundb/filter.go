@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type filterDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (f *filterDB) newFilterQ(filter interface{}) *bun.SelectQuery {
+	return f.conn.
+		NewSelect().
+		Model(filter).
+		Relation("Keywords", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("filter_keyword.id ASC")
+		})
+}
+
+func (f *filterDB) GetFilterByID(ctx context.Context, id string) (*gtsmodel.Filter, db.Error) {
+	filter := &gtsmodel.Filter{}
+
+	if err := f.newFilterQ(filter).Where("filter.id = ?", id).Scan(ctx); err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return filter, nil
+}
+
+func (f *filterDB) GetFiltersForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Filter, db.Error) {
+	filters := []*gtsmodel.Filter{}
+
+	if err := f.newFilterQ(&filters).
+		Where("filter.account_id = ?", accountID).
+		Order("filter.id ASC").
+		Scan(ctx); err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return filters, nil
+}
+
+func (f *filterDB) GetFilterKeywordByID(ctx context.Context, id string) (*gtsmodel.FilterKeyword, db.Error) {
+	keyword := &gtsmodel.FilterKeyword{}
+
+	if err := f.conn.
+		NewSelect().
+		Model(keyword).
+		Relation("Filter").
+		Where("filter_keyword.id = ?", id).
+		Scan(ctx); err != nil {
+		return nil, f.conn.ProcessError(err)
+	}
+	return keyword, nil
+}
+
+func (f *filterDB) PutFilter(ctx context.Context, filter *gtsmodel.Filter) db.Error {
+	if _, err := f.conn.
+		NewInsert().
+		Model(filter).
+		Exec(ctx); err != nil {
+		return f.conn.ProcessError(err)
+	}
+
+	for _, keyword := range filter.Keywords {
+		if _, err := f.conn.
+			NewInsert().
+			Model(keyword).
+			Exec(ctx); err != nil {
+			return f.conn.ProcessError(err)
+		}
+	}
+
+	return nil
+}
+
+func (f *filterDB) DeleteFilterByID(ctx context.Context, id string) db.Error {
+	if _, err := f.conn.
+		NewDelete().
+		Model(&gtsmodel.FilterKeyword{}).
+		Where("filter_id = ?", id).
+		Exec(ctx); err != nil {
+		return f.conn.ProcessError(err)
+	}
+
+	if _, err := f.conn.
+		NewDelete().
+		Model(&gtsmodel.Filter{}).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return f.conn.ProcessError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type inboxDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (i *inboxDB) InboxActivityExists(ctx context.Context, activityURI string) (bool, db.Error) {
+	q := i.conn.
+		NewSelect().
+		Model(&gtsmodel.InboxActivity{}).
+		Where("activity_uri = ?", activityURI).
+		Limit(1)
+
+	return i.conn.Exists(ctx, q)
+}
+
+func (i *inboxDB) PruneInboxActivities(ctx context.Context, olderThan time.Time) (int, db.Error) {
+	res, err := i.conn.
+		NewDelete().
+		Model(&gtsmodel.InboxActivity{}).
+		Where("created_at < ?", olderThan).
+		Exec(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+	return int(pruned), nil
+}
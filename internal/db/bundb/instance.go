@@ -20,11 +20,15 @@ package bundb
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 )
 
 type instanceDB struct {
@@ -121,3 +125,232 @@ func (i *instanceDB) GetInstanceAccounts(ctx context.Context, domain string, max
 	}
 	return accounts, nil
 }
+
+func (i *instanceDB) CountInstanceActiveUsers(ctx context.Context, since time.Time) (int, db.Error) {
+	active := i.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.Status{}).
+		ColumnExpr("DISTINCT ?", bun.Ident("account_id")).
+		Where("local = ?", true).
+		Where("created_at > ?", since)
+
+	count, err := i.conn.
+		NewSelect().
+		TableExpr("(?) AS active", active).
+		Count(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
+func (i *instanceDB) CountInstanceMediaBytes(ctx context.Context) (int, db.Error) {
+	// file details are stored as json, so we can't sum them in the query itself
+	attachments := []*gtsmodel.MediaAttachment{}
+	if err := i.conn.
+		NewSelect().
+		Model(&attachments).
+		Column("file", "thumbnail").
+		Scan(ctx); err != nil {
+		if err := i.conn.ProcessError(err); err != db.ErrNoEntries {
+			return 0, err
+		}
+	}
+
+	var total int
+	for _, a := range attachments {
+		total = total + a.File.FileSize + a.Thumbnail.FileSize
+	}
+
+	var emojiTotal sql.NullInt64
+	if err := i.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.Emoji{}).
+		ColumnExpr("SUM(? + ?)", bun.Ident("image_file_size"), bun.Ident("image_static_file_size")).
+		Scan(ctx, &emojiTotal); err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+
+	return total + int(emojiTotal.Int64), nil
+}
+
+func (i *instanceDB) CountInstanceFailedDeliveries(ctx context.Context) (int, db.Error) {
+	count, err := i.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.FailedDelivery{}).
+		Count(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
+func (i *instanceDB) PruneFailedDeliveries(ctx context.Context, olderThan time.Time) (int, db.Error) {
+	res, err := i.conn.
+		NewDelete().
+		Model(&gtsmodel.FailedDelivery{}).
+		Where("created_at < ?", olderThan).
+		Exec(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+	return int(pruned), nil
+}
+
+func (i *instanceDB) GetInstanceTopDomains(ctx context.Context, limit int) ([]*db.DomainCount, db.Error) {
+	counts := []*db.DomainCount{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.Account{}).
+		Column("domain").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? IS NOT NULL", bun.Ident("domain")).
+		Where("? != ''", bun.Ident("domain")).
+		Group("domain").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("domain")).
+		Limit(limit).
+		Scan(ctx, &counts); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
+
+func (i *instanceDB) GetInstanceRules(ctx context.Context) ([]*gtsmodel.Rule, db.Error) {
+	rules := []*gtsmodel.Rule{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(&rules).
+		Order("rule.priority ASC", "rule.id ASC").
+		Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return rules, nil
+}
+
+// measureQuery returns a select query for the rows counted by the given measure, and the time column to count them by.
+func (i *instanceDB) measureQuery(measure db.InstanceMeasure) (*bun.SelectQuery, string, error) {
+	switch measure {
+	case db.InstanceMeasureNewUsers:
+		return i.conn.
+			NewSelect().
+			Model(&[]*gtsmodel.User{}), "created_at", nil
+	case db.InstanceMeasureNewStatuses:
+		return i.conn.
+			NewSelect().
+			Model(&[]*gtsmodel.Status{}).
+			Where("local = ?", true), "created_at", nil
+	case db.InstanceMeasureOpenedReports:
+		return i.conn.
+			NewSelect().
+			Model(&[]*gtsmodel.Report{}), "created_at", nil
+	case db.InstanceMeasureResolvedReports:
+		return i.conn.
+			NewSelect().
+			Model(&[]*gtsmodel.Report{}).
+			Where("action_taken = ?", true), "action_taken_at", nil
+	default:
+		return nil, "", fmt.Errorf("unknown measure %s", measure)
+	}
+}
+
+func (i *instanceDB) GetInstanceMeasureCounts(ctx context.Context, measure db.InstanceMeasure, start time.Time, end time.Time) ([]*db.DayCount, db.Error) {
+	q, column, err := i.measureQuery(measure)
+	if err != nil {
+		return nil, err
+	}
+
+	// the day of each event, in utc, as YYYY-MM-DD
+	dayExpr := "date(?)"
+	if i.conn.Dialect().Name() == dialect.PG {
+		dayExpr = "to_char(? AT TIME ZONE 'UTC', 'YYYY-MM-DD')"
+	}
+
+	counts := []*db.DayCount{}
+	if err := q.
+		ColumnExpr(dayExpr+" AS ?", bun.Ident(column), bun.Ident("day")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? >= ?", bun.Ident(column), start).
+		Where("? < ?", bun.Ident(column), end).
+		GroupExpr("?", bun.Ident("day")).
+		OrderExpr("? ASC", bun.Ident("day")).
+		Scan(ctx, &counts); err != nil {
+		if err := i.conn.ProcessError(err); err != db.ErrNoEntries {
+			return nil, err
+		}
+	}
+
+	return counts, nil
+}
+
+func (i *instanceDB) CountInstanceMeasure(ctx context.Context, measure db.InstanceMeasure, start time.Time, end time.Time) (int, db.Error) {
+	q, column, err := i.measureQuery(measure)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := q.
+		Where("? >= ?", bun.Ident(column), start).
+		Where("? < ?", bun.Ident(column), end).
+		Count(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (i *instanceDB) GetInstanceStatusLanguages(ctx context.Context, start time.Time, end time.Time, limit int) ([]*db.LanguageCount, db.Error) {
+	counts := []*db.LanguageCount{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.Status{}).
+		Column("language").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("local = ?", true).
+		Where("? IS NOT NULL", bun.Ident("language")).
+		Where("? != ''", bun.Ident("language")).
+		Where("created_at >= ?", start).
+		Where("created_at < ?", end).
+		Group("language").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("language")).
+		Limit(limit).
+		Scan(ctx, &counts); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
+
+func (i *instanceDB) GetInstanceStatusDomains(ctx context.Context, start time.Time, end time.Time, limit int) ([]*db.DomainCount, db.Error) {
+	counts := []*db.DomainCount{}
+
+	if err := i.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.Status{}).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("domain")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Join("JOIN accounts AS account ON account.id = status.account_id").
+		Where("status.local = ?", false).
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Where("? != ''", bun.Ident("account.domain")).
+		Where("status.created_at >= ?", start).
+		Where("status.created_at < ?", end).
+		Group("account.domain").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("domain")).
+		Limit(limit).
+		Scan(ctx, &counts); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,177 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InstanceTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceActiveUsers() {
+	active := map[string]bool{}
+	for _, s := range suite.testStatuses {
+		if s.Local {
+			active[s.AccountID] = true
+		}
+	}
+
+	count, err := suite.db.CountInstanceActiveUsers(context.Background(), time.Time{})
+	suite.NoError(err)
+	suite.Equal(len(active), count)
+
+	count, err = suite.db.CountInstanceActiveUsers(context.Background(), time.Now())
+	suite.NoError(err)
+	suite.Equal(0, count)
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceMediaBytes() {
+	var expected int
+	for _, a := range suite.testAttachments {
+		expected = expected + a.File.FileSize + a.Thumbnail.FileSize
+	}
+	for _, e := range testrig.NewTestEmojis() {
+		expected = expected + e.ImageFileSize + e.ImageStaticFileSize
+	}
+
+	count, err := suite.db.CountInstanceMediaBytes(context.Background())
+	suite.NoError(err)
+	suite.Equal(expected, count)
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceFailedDeliveries() {
+	count, err := suite.db.CountInstanceFailedDeliveries(context.Background())
+	suite.NoError(err)
+	suite.Equal(0, count)
+}
+
+func (suite *InstanceTestSuite) TestPruneFailedDeliveries() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	for id, createdAt := range map[string]time.Time{
+		"01FHE1BKNF3H0MJYQJY6FJEDG7": time.Now().Add(-10 * 24 * time.Hour),
+		"01FHE1BKNF3H0MJYQJY6FJEDG8": time.Now(),
+	} {
+		suite.NoError(suite.db.Put(ctx, &gtsmodel.FailedDelivery{
+			ID:        id,
+			CreatedAt: createdAt,
+			Domain:    "fossbros-anonymous.io",
+			InboxURI:  "http://fossbros-anonymous.io/users/foss_satan/inbox",
+			PubKeyID:  account.PublicKeyURI,
+			Payload:   `{"type":"Create"}`,
+			Attempts:  1,
+		}))
+	}
+
+	pruned, err := suite.db.PruneFailedDeliveries(ctx, time.Now().Add(-7*24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, pruned)
+
+	count, err := suite.db.CountInstanceFailedDeliveries(ctx)
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceTopDomains() {
+	expected := map[string]int{}
+	for _, a := range suite.testAccounts {
+		if a.Domain != "" {
+			expected[a.Domain] = expected[a.Domain] + 1
+		}
+	}
+
+	counts, err := suite.db.GetInstanceTopDomains(context.Background(), 10)
+	suite.NoError(err)
+	suite.Len(counts, len(expected))
+	suite.True(sort.SliceIsSorted(counts, func(i, j int) bool { return counts[i].Count > counts[j].Count }))
+	for _, c := range counts {
+		suite.Equal(expected[c.Domain], c.Count)
+	}
+
+	counts, err = suite.db.GetInstanceTopDomains(context.Background(), 1)
+	suite.NoError(err)
+	suite.Len(counts, 1)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceMeasureCounts() {
+	expected := map[string]int{}
+	var total int
+	for _, s := range suite.testStatuses {
+		if s.Local {
+			expected[s.CreatedAt.UTC().Format("2006-01-02")]++
+			total++
+		}
+	}
+
+	counts, err := suite.db.GetInstanceMeasureCounts(context.Background(), db.InstanceMeasureNewStatuses, time.Time{}, time.Now())
+	suite.NoError(err)
+	suite.Len(counts, len(expected))
+	suite.True(sort.SliceIsSorted(counts, func(i, j int) bool { return counts[i].Day < counts[j].Day }))
+	for _, c := range counts {
+		suite.Equal(expected[c.Day], c.Count, c.Day)
+	}
+
+	count, err := suite.db.CountInstanceMeasure(context.Background(), db.InstanceMeasureNewStatuses, time.Time{}, time.Now())
+	suite.NoError(err)
+	suite.Equal(total, count)
+
+	count, err = suite.db.CountInstanceMeasure(context.Background(), db.InstanceMeasureNewUsers, time.Time{}, time.Now())
+	suite.NoError(err)
+	suite.Equal(len(suite.testUsers), count)
+
+	counts, err = suite.db.GetInstanceMeasureCounts(context.Background(), db.InstanceMeasureResolvedReports, time.Now(), time.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceStatusDomains() {
+	domains := map[string]string{}
+	for _, a := range suite.testAccounts {
+		domains[a.ID] = a.Domain
+	}
+
+	expected := map[string]int{}
+	for _, s := range suite.testStatuses {
+		if !s.Local {
+			expected[domains[s.AccountID]] = expected[domains[s.AccountID]] + 1
+		}
+	}
+
+	counts, err := suite.db.GetInstanceStatusDomains(context.Background(), time.Time{}, time.Now(), 10)
+	suite.NoError(err)
+	suite.Len(counts, len(expected))
+	for _, c := range counts {
+		suite.Equal(expected[c.Domain], c.Count)
+	}
+}
+
+func TestInstanceTestSuite(t *testing.T) {
+	suite.Run(t, new(InstanceTestSuite))
+}
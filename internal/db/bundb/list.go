@@ -0,0 +1,173 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type listDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (l *listDB) newListQ(list interface{}) *bun.SelectQuery {
+	return l.conn.
+		NewSelect().
+		Model(list).
+		Relation("Account")
+}
+
+func (l *listDB) GetListByID(ctx context.Context, id string) (*gtsmodel.List, db.Error) {
+	list := &gtsmodel.List{}
+
+	if err := l.newListQ(list).Where("list.id = ?", id).Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return list, nil
+}
+
+func (l *listDB) GetListsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.List, db.Error) {
+	lists := []*gtsmodel.List{}
+
+	if err := l.newListQ(&lists).
+		Where("list.account_id = ?", accountID).
+		Order("list.id ASC").
+		Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return lists, nil
+}
+
+func (l *listDB) GetListsContainingAccount(ctx context.Context, ownerAccountID string, accountID string) ([]*gtsmodel.List, db.Error) {
+	lists := []*gtsmodel.List{}
+
+	if err := l.newListQ(&lists).
+		Join("JOIN list_entries AS le ON le.list_id = list.id").
+		Where("list.account_id = ?", ownerAccountID).
+		Where("le.account_id = ?", accountID).
+		Order("list.id ASC").
+		Scan(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+	return lists, nil
+}
+
+func (l *listDB) GetListAccounts(ctx context.Context, listID string, maxID string, sinceID string, limit int) ([]*gtsmodel.Account, string, string, db.Error) {
+	entries := []*gtsmodel.ListEntry{}
+
+	q := l.conn.
+		NewSelect().
+		Model(&entries).
+		Where("list_entry.list_id = ?", listID).
+		Relation("Account").
+		Order("list_entry.id DESC")
+
+	if maxID != "" {
+		q = q.Where("list_entry.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("list_entry.id > ?", sinceID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, "", "", l.conn.ProcessError(err)
+	}
+
+	if len(entries) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	accounts := []*gtsmodel.Account{}
+	for _, e := range entries {
+		accounts = append(accounts, e.Account)
+	}
+
+	nextMaxID := entries[len(entries)-1].ID
+	prevMinID := entries[0].ID
+	return accounts, nextMaxID, prevMinID, nil
+}
+
+func (l *listDB) IsAccountInList(ctx context.Context, listID string, accountID string) (bool, db.Error) {
+	q := l.conn.
+		NewSelect().
+		Model(&gtsmodel.ListEntry{}).
+		Where("list_id = ?", listID).
+		Where("account_id = ?", accountID).
+		Limit(1)
+
+	return l.conn.Exists(ctx, q)
+}
+
+func (l *listDB) DeleteListByID(ctx context.Context, id string) db.Error {
+	if _, err := l.conn.
+		NewDelete().
+		Model(&gtsmodel.ListEntry{}).
+		Where("list_id = ?", id).
+		Exec(ctx); err != nil {
+		return l.conn.ProcessError(err)
+	}
+
+	if _, err := l.conn.
+		NewDelete().
+		Model(&gtsmodel.List{}).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return l.conn.ProcessError(err)
+	}
+
+	return nil
+}
+
+func (l *listDB) DeleteListEntriesForAccount(ctx context.Context, ownerAccountID string, accountID string) ([]string, db.Error) {
+	lists, err := l.GetListsContainingAccount(ctx, ownerAccountID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	listIDs := make([]string, 0, len(lists))
+	for _, list := range lists {
+		listIDs = append(listIDs, list.ID)
+	}
+
+	if len(listIDs) == 0 {
+		return listIDs, nil
+	}
+
+	if _, err := l.conn.
+		NewDelete().
+		Model(&gtsmodel.ListEntry{}).
+		Where("list_id IN (?)", bun.In(listIDs)).
+		Where("account_id = ?", accountID).
+		Exec(ctx); err != nil {
+		return nil, l.conn.ProcessError(err)
+	}
+
+	return listIDs, nil
+}
@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		// On a fresh database the accounts table doesn't exist yet; it will be
+		// created later with the new column already in place, so that's fine.
+		_, err := db.NewAddColumn().
+			Model(&gtsmodel.Account{}).
+			ColumnExpr("? BOOLEAN DEFAULT false", bun.Ident("enable_rss")).
+			Exec(ctx)
+		if err != nil && !ignorableColumnError(err) {
+			return err
+		}
+		return nil
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		_, err := db.NewDropColumn().
+			Model(&gtsmodel.Account{}).
+			Column("enable_rss").
+			Exec(ctx)
+		if err != nil && !ignorableColumnError(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// ignorableColumnError returns true if err indicates that the table being altered
+// doesn't exist yet, or that the column has already been added or dropped.
+func ignorableColumnError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "no such table") || // sqlite
+		strings.Contains(msg, "no such column") || // sqlite
+		strings.Contains(msg, "duplicate column name") || // sqlite
+		strings.Contains(msg, "SQLSTATE 42P01") || // postgres: undefined table
+		strings.Contains(msg, "SQLSTATE 42703") || // postgres: undefined column
+		strings.Contains(msg, "SQLSTATE 42701") // postgres: duplicate column
+}
@@ -0,0 +1,61 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		// postgres stores arrays natively, sqlite stores them as json in a text column
+		arrayType := "VARCHAR"
+		if db.Dialect().Name() == dialect.PG {
+			arrayType = "VARCHAR[]"
+		}
+
+		if _, err := db.NewAddColumn().
+			Model(&gtsmodel.Account{}).
+			ColumnExpr("? "+arrayType, bun.Ident("emojis")).
+			Exec(ctx); err != nil && !ignorableColumnError(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		if _, err := db.NewDropColumn().
+			Model(&gtsmodel.Account{}).
+			Column("emojis").
+			Exec(ctx); err != nil && !ignorableColumnError(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		// used for fetching the statuses of an account, newest first
+		if _, err := db.NewCreateIndex().
+			Model(&gtsmodel.Status{}).
+			Index("statuses_account_id_id_idx").
+			Column("account_id", "id").
+			IfNotExists().
+			Exec(ctx); err != nil && !ignorableColumnError(err) {
+			return err
+		}
+
+		// used for fetching the statuses that use a tag
+		if _, err := db.NewCreateIndex().
+			Model(&gtsmodel.StatusToTag{}).
+			Index("status_to_tags_tag_id_idx").
+			Column("tag_id").
+			IfNotExists().
+			Exec(ctx); err != nil && !ignorableColumnError(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		if _, err := db.NewDropIndex().
+			Model(&gtsmodel.Status{}).
+			Index("statuses_account_id_id_idx").
+			IfExists().
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if _, err := db.NewDropIndex().
+			Model(&gtsmodel.StatusToTag{}).
+			Index("status_to_tags_tag_id_idx").
+			IfExists().
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		// On a fresh database the tags table doesn't exist yet; it will be
+		// created later with the new columns already in place, so that's fine.
+		if _, err := db.NewAddColumn().
+			Model(&gtsmodel.Tag{}).
+			ColumnExpr("? BOOLEAN DEFAULT false", bun.Ident("trendable")).
+			Exec(ctx); err != nil && !ignorableColumnError(err) {
+			return err
+		}
+
+		if _, err := db.NewAddColumn().
+			Model(&gtsmodel.Tag{}).
+			ColumnExpr("? TIMESTAMPTZ", bun.Ident("reviewed_at")).
+			Exec(ctx); err != nil && !ignorableColumnError(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		for _, column := range []string{"trendable", "reviewed_at"} {
+			if _, err := db.NewDropColumn().
+				Model(&gtsmodel.Tag{}).
+				Column(column).
+				Exec(ctx); err != nil && !ignorableColumnError(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
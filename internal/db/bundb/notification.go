@@ -56,7 +56,7 @@ func (n *notificationDB) GetNotification(ctx context.Context, id string) (*gtsmo
 	return notif, nil
 }
 
-func (n *notificationDB) GetNotifications(ctx context.Context, accountID string, limit int, maxID string, sinceID string) ([]*gtsmodel.Notification, db.Error) {
+func (n *notificationDB) GetNotifications(ctx context.Context, accountID string, types []string, excludeTypes []string, limit int, maxID string, sinceID string) ([]*gtsmodel.Notification, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -80,6 +80,14 @@ func (n *notificationDB) GetNotifications(ctx context.Context, accountID string,
 		q = q.Where("id > ?", sinceID)
 	}
 
+	if len(types) != 0 {
+		q = q.Where("notification_type IN (?)", bun.In(types))
+	}
+
+	if len(excludeTypes) != 0 {
+		q = q.Where("notification_type NOT IN (?)", bun.In(excludeTypes))
+	}
+
 	if limit != 0 {
 		q = q.Limit(limit)
 	}
@@ -109,6 +117,63 @@ func (n *notificationDB) GetNotifications(ctx context.Context, accountID string,
 	return notifications, nil
 }
 
+func (n *notificationDB) DeleteNotification(ctx context.Context, id string) db.Error {
+	if _, err := n.conn.
+		NewDelete().
+		Model(&gtsmodel.Notification{}).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return n.conn.ProcessError(err)
+	}
+
+	n.cache.Remove(id)
+	return nil
+}
+
+func (n *notificationDB) ClearNotifications(ctx context.Context, accountID string) db.Error {
+	notifIDs := []string{}
+
+	if err := n.conn.
+		NewSelect().
+		Model(&gtsmodel.Notification{}).
+		Column("id").
+		Where("target_account_id = ?", accountID).
+		Scan(ctx, &notifIDs); err != nil {
+		return n.conn.ProcessError(err)
+	}
+
+	if len(notifIDs) == 0 {
+		return nil
+	}
+
+	if _, err := n.conn.
+		NewDelete().
+		Model(&gtsmodel.Notification{}).
+		Where("id IN (?)", bun.In(notifIDs)).
+		Exec(ctx); err != nil {
+		return n.conn.ProcessError(err)
+	}
+
+	for _, id := range notifIDs {
+		n.cache.Remove(id)
+	}
+	return nil
+}
+
+func (n *notificationDB) GetNotificationPreferences(ctx context.Context, accountID string) (*gtsmodel.NotificationPreferences, db.Error) {
+	prefs := &gtsmodel.NotificationPreferences{}
+
+	if err := n.conn.
+		NewSelect().
+		Model(prefs).
+		Where("account_id = ?", accountID).
+		Scan(ctx); err != nil {
+		return nil, n.conn.ProcessError(err)
+	}
+
+	return prefs, nil
+}
+
 func (n *notificationDB) getNotificationCache(id string) (*gtsmodel.Notification, bool) {
 	v, ok := n.cache.Get(id)
 	if !ok {
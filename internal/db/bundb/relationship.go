@@ -81,87 +81,200 @@ func (r *relationshipDB) GetBlock(ctx context.Context, account1 string, account2
 	return block, nil
 }
 
+func (r *relationshipDB) IsMuted(ctx context.Context, account1 string, account2 string, notifications bool) (bool, db.Error) {
+	q := r.conn.
+		NewSelect().
+		Model(&gtsmodel.AccountMute{}).
+		Where("account_id = ?", account1).
+		Where("target_account_id = ?", account2).
+		WhereGroup(" AND ", whereNotExpired("expires_at")).
+		Limit(1)
+
+	if notifications {
+		q = q.Where("notifications = ?", true)
+	}
+
+	return r.conn.Exists(ctx, q)
+}
+
+func (r *relationshipDB) GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.AccountMute, db.Error) {
+	mute := &gtsmodel.AccountMute{}
+
+	q := r.conn.
+		NewSelect().
+		Model(mute).
+		Relation("Account").
+		Relation("TargetAccount").
+		Where("account_mute.account_id = ?", account1).
+		Where("account_mute.target_account_id = ?", account2)
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return mute, nil
+}
+
+func (r *relationshipDB) GetNote(ctx context.Context, account1 string, account2 string) (*gtsmodel.AccountNote, db.Error) {
+	note := &gtsmodel.AccountNote{}
+
+	q := r.conn.
+		NewSelect().
+		Model(note).
+		Where("account_note.account_id = ?", account1).
+		Where("account_note.target_account_id = ?", account2)
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return note, nil
+}
+
 func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, db.Error) {
-	rel := &gtsmodel.Relationship{
-		ID: targetAccount,
+	rels, err := r.GetRelationships(ctx, requestingAccount, []string{targetAccount})
+	if err != nil {
+		return nil, err
 	}
+	return rels[0], nil
+}
 
-	// check if the requesting account follows the target account
-	follow := &gtsmodel.Follow{}
+func (r *relationshipDB) GetRelationships(ctx context.Context, requestingAccount string, targetAccounts []string) ([]*gtsmodel.Relationship, db.Error) {
+	rels := make([]*gtsmodel.Relationship, 0, len(targetAccounts))
+	relsByID := make(map[string]*gtsmodel.Relationship, len(targetAccounts))
+	for _, targetAccount := range targetAccounts {
+		rel, ok := relsByID[targetAccount]
+		if !ok {
+			rel = &gtsmodel.Relationship{ID: targetAccount}
+			relsByID[targetAccount] = rel
+		}
+		rels = append(rels, rel)
+	}
+
+	if len(relsByID) == 0 {
+		return rels, nil
+	}
+
+	// follows in either direction between the requesting account and the target accounts
+	follows := []*gtsmodel.Follow{}
 	if err := r.conn.
 		NewSelect().
-		Model(follow).
-		Where("account_id = ?", requestingAccount).
-		Where("target_account_id = ?", targetAccount).
-		Limit(1).
+		Model(&follows).
+		Column("account_id", "target_account_id", "show_reblogs", "notify").
+		WhereGroup(" AND ", whereRelationshipWith(requestingAccount, targetAccounts)).
 		Scan(ctx); err != nil {
-		if err != sql.ErrNoRows {
-			// a proper error
-			return nil, fmt.Errorf("getrelationship: error checking follow existence: %s", err)
+		return nil, fmt.Errorf("getrelationships: error checking follows: %s", err)
+	}
+	for _, follow := range follows {
+		if follow.AccountID == requestingAccount {
+			if rel, ok := relsByID[follow.TargetAccountID]; ok {
+				rel.Following = true
+				rel.ShowingReblogs = follow.ShowReblogs
+				rel.Notifying = follow.Notify
+			}
+		}
+		if follow.TargetAccountID == requestingAccount {
+			if rel, ok := relsByID[follow.AccountID]; ok {
+				rel.FollowedBy = true
+			}
 		}
-		// no follow exists so these are all false
-		rel.Following = false
-		rel.ShowingReblogs = false
-		rel.Notifying = false
-	} else {
-		// follow exists so we can fill these fields out...
-		rel.Following = true
-		rel.ShowingReblogs = follow.ShowReblogs
-		rel.Notifying = follow.Notify
 	}
 
-	// check if the target account follows the requesting account
-	count, err := r.conn.
+	// blocks in either direction between the requesting account and the target accounts
+	blocks := []*gtsmodel.Block{}
+	if err := r.conn.
 		NewSelect().
-		Model(&gtsmodel.Follow{}).
-		Where("account_id = ?", targetAccount).
-		Where("target_account_id = ?", requestingAccount).
-		Limit(1).
-		Count(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("getrelationship: error checking followed_by existence: %s", err)
+		Model(&blocks).
+		Column("account_id", "target_account_id").
+		WhereGroup(" AND ", whereRelationshipWith(requestingAccount, targetAccounts)).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("getrelationships: error checking blocks: %s", err)
+	}
+	for _, block := range blocks {
+		if block.AccountID == requestingAccount {
+			if rel, ok := relsByID[block.TargetAccountID]; ok {
+				rel.Blocking = true
+			}
+		}
+		if block.TargetAccountID == requestingAccount {
+			if rel, ok := relsByID[block.AccountID]; ok {
+				rel.BlockedBy = true
+			}
+		}
 	}
-	rel.FollowedBy = count > 0
 
-	// check if the requesting account blocks the target account
-	count, err = r.conn.NewSelect().
-		Model(&gtsmodel.Block{}).
+	// unexpired mutes of the target accounts by the requesting account
+	mutes := []*gtsmodel.AccountMute{}
+	if err := r.conn.
+		NewSelect().
+		Model(&mutes).
+		Column("target_account_id", "notifications").
 		Where("account_id = ?", requestingAccount).
-		Where("target_account_id = ?", targetAccount).
-		Limit(1).
-		Count(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("getrelationship: error checking blocking existence: %s", err)
+		Where("target_account_id IN (?)", bun.In(targetAccounts)).
+		WhereGroup(" AND ", whereNotExpired("expires_at")).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("getrelationships: error checking mutes: %s", err)
+	}
+	for _, mute := range mutes {
+		if rel, ok := relsByID[mute.TargetAccountID]; ok {
+			rel.Muting = true
+			rel.MutingNotifications = mute.Notifications
+		}
 	}
-	rel.Blocking = count > 0
 
-	// check if the target account blocks the requesting account
-	count, err = r.conn.
+	// pending follow requests from the requesting account to the target accounts
+	followRequests := []*gtsmodel.FollowRequest{}
+	if err := r.conn.
 		NewSelect().
-		Model(&gtsmodel.Block{}).
-		Where("account_id = ?", targetAccount).
-		Where("target_account_id = ?", requestingAccount).
-		Limit(1).
-		Count(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("getrelationship: error checking blocked existence: %s", err)
+		Model(&followRequests).
+		Column("target_account_id").
+		Where("account_id = ?", requestingAccount).
+		Where("target_account_id IN (?)", bun.In(targetAccounts)).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("getrelationships: error checking follow requests: %s", err)
+	}
+	for _, followRequest := range followRequests {
+		if rel, ok := relsByID[followRequest.TargetAccountID]; ok {
+			rel.Requested = true
+		}
 	}
-	rel.BlockedBy = count > 0
 
-	// check if there's a pending following request from requesting account to target account
-	count, err = r.conn.
+	// notes written by the requesting account about the target accounts
+	notes := []*gtsmodel.AccountNote{}
+	if err := r.conn.
 		NewSelect().
-		Model(&gtsmodel.FollowRequest{}).
+		Model(&notes).
+		Column("target_account_id", "comment").
 		Where("account_id = ?", requestingAccount).
-		Where("target_account_id = ?", targetAccount).
-		Limit(1).
-		Count(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("getrelationship: error checking blocked existence: %s", err)
+		Where("target_account_id IN (?)", bun.In(targetAccounts)).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("getrelationships: error checking notes: %s", err)
+	}
+	for _, note := range notes {
+		if rel, ok := relsByID[note.TargetAccountID]; ok {
+			rel.Note = note.Comment
+		}
 	}
-	rel.Requested = count > 0
 
-	return rel, nil
+	return rels, nil
+}
+
+// whereRelationshipWith is a convenience function to return a bun WhereGroup that selects rows
+// going from the given account to any of the target accounts, or from any of them to the given account.
+func whereRelationshipWith(account string, targetAccounts []string) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("account_id = ?", account).
+					Where("target_account_id IN (?)", bun.In(targetAccounts))
+			}).
+			WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("account_id IN (?)", bun.In(targetAccounts)).
+					Where("target_account_id = ?", account)
+			})
+	}
 }
 
 func (r *relationshipDB) IsFollowing(ctx context.Context, sourceAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (bool, db.Error) {
@@ -222,15 +335,32 @@ func (r *relationshipDB) AcceptFollowRequest(ctx context.Context, originAccountI
 		Where("account_id = ?", originAccountID).
 		Where("target_account_id = ?", targetAccountID).
 		Scan(ctx); err != nil {
-		return nil, r.conn.ProcessError(err)
+		err = r.conn.ProcessError(err)
+		if err != db.ErrNoEntries {
+			return nil, err
+		}
+
+		// the request may already have been accepted, in which case we can just return the follow
+		follow := &gtsmodel.Follow{}
+		if followErr := r.conn.
+			NewSelect().
+			Model(follow).
+			Where("account_id = ?", originAccountID).
+			Where("target_account_id = ?", targetAccountID).
+			Scan(ctx); followErr != nil {
+			return nil, err
+		}
+		return follow, nil
 	}
 
-	// create a new follow to 'replace' the request with
+	// create a new follow to 'replace' the request with, keeping the preferences of the requester
 	follow := &gtsmodel.Follow{
 		ID:              fr.ID,
 		AccountID:       originAccountID,
 		TargetAccountID: targetAccountID,
 		URI:             fr.URI,
+		ShowReblogs:     fr.ShowReblogs,
+		Notify:          fr.Notify,
 	}
 
 	// if the follow already exists, just update the URI -- we don't need to do anything else
@@ -255,17 +385,73 @@ func (r *relationshipDB) AcceptFollowRequest(ctx context.Context, originAccountI
 	return follow, nil
 }
 
-func (r *relationshipDB) GetAccountFollowRequests(ctx context.Context, accountID string) ([]*gtsmodel.FollowRequest, db.Error) {
+func (r *relationshipDB) RejectFollowRequest(ctx context.Context, originAccountID string, targetAccountID string) (*gtsmodel.FollowRequest, db.Error) {
+	// make sure the original follow request exists
+	fr := &gtsmodel.FollowRequest{}
+	if err := r.conn.
+		NewSelect().
+		Model(fr).
+		Where("account_id = ?", originAccountID).
+		Where("target_account_id = ?", targetAccountID).
+		Scan(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	// now remove it
+	if _, err := r.conn.
+		NewDelete().
+		Model(&gtsmodel.FollowRequest{}).
+		Where("id = ?", fr.ID).
+		Exec(ctx); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	return fr, nil
+}
+
+func (r *relationshipDB) GetAccountFollowRequests(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.FollowRequest, string, string, db.Error) {
 	followRequests := []*gtsmodel.FollowRequest{}
 
 	q := r.newFollowQ(&followRequests).
-		Where("target_account_id = ?", accountID)
+		Where("follow_request.target_account_id = ?", accountID).
+		Order("follow_request.id DESC")
+
+	if maxID != "" {
+		q = q.Where("follow_request.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("follow_request.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("follow_request.id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
 
 	err := q.Scan(ctx)
 	if err != nil {
-		return nil, r.conn.ProcessError(err)
+		return nil, "", "", r.conn.ProcessError(err)
 	}
-	return followRequests, nil
+
+	if len(followRequests) == 0 {
+		return nil, "", "", db.ErrNoEntries
+	}
+
+	nextMaxID := followRequests[len(followRequests)-1].ID
+	prevMinID := followRequests[0].ID
+	return followRequests, nextMaxID, prevMinID, nil
+}
+
+func (r *relationshipDB) CountAccountFollowRequests(ctx context.Context, accountID string) (int, db.Error) {
+	return r.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.FollowRequest{}).
+		Where("target_account_id = ?", accountID).
+		Count(ctx)
 }
 
 func (r *relationshipDB) GetAccountFollows(ctx context.Context, accountID string) ([]*gtsmodel.Follow, db.Error) {
@@ -312,6 +498,28 @@ func (r *relationshipDB) GetAccountFollowedBy(ctx context.Context, accountID str
 	return follows, nil
 }
 
+func (r *relationshipDB) GetAccountFollowersByDomain(ctx context.Context, accountID string, domain string) ([]*gtsmodel.Follow, db.Error) {
+	follows := []*gtsmodel.Follow{}
+
+	q := r.conn.
+		NewSelect().
+		Model(&follows).
+		Relation("Account").
+		Where("follow.target_account_id = ?", accountID)
+
+	if domain == "" {
+		q = q.WhereGroup(" AND ", whereEmptyOrNull("account.domain"))
+	} else {
+		q = q.Where("account.domain = ?", domain)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, r.conn.ProcessError(err)
+	}
+	return follows, nil
+}
+
 func (r *relationshipDB) CountAccountFollowedBy(ctx context.Context, accountID string, localOnly bool) (int, db.Error) {
 	return r.conn.
 		NewSelect().
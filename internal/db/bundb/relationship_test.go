@@ -25,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type RelationshipTestSuite struct {
@@ -40,7 +41,63 @@ func (suite *RelationshipTestSuite) TestGetBlock() {
 }
 
 func (suite *RelationshipTestSuite) TestGetRelationship() {
-	suite.Suite.T().Skip("TODO: implement")
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["admin_account"]
+
+	relationship, err := suite.db.GetRelationship(context.Background(), requestingAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.Equal(targetAccount.ID, relationship.ID)
+	suite.True(relationship.Following)
+	suite.True(relationship.ShowingReblogs)
+	suite.False(relationship.Notifying)
+	suite.False(relationship.FollowedBy)
+	suite.False(relationship.Blocking)
+	suite.False(relationship.Muting)
+}
+
+func (suite *RelationshipTestSuite) TestGetRelationships() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_2"]
+	zork := suite.testAccounts["local_account_1"]
+	admin := suite.testAccounts["admin_account"]
+	remote := suite.testAccounts["remote_account_1"]
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.AccountMute{
+		ID:              "01FSBV7KBWD4Z8GHR8YB8M2P0K",
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: admin.ID,
+		Notifications:   true,
+	}))
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.AccountNote{
+		ID:              "01FSBV8A6M7M9Q4X5G3R4T0C3E",
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: admin.ID,
+		Comment:         "runs the place",
+	}))
+
+	relationships, err := suite.db.GetRelationships(ctx, requestingAccount.ID, []string{zork.ID, admin.ID, remote.ID, zork.ID, "01FSBV9C8TNAZ0BPMD1H3VW4BN"})
+	suite.NoError(err)
+	suite.Len(relationships, 5)
+
+	// relationships come back in the order they were asked for
+	suite.Equal(zork.ID, relationships[0].ID)
+	suite.True(relationships[0].FollowedBy)
+	suite.False(relationships[0].Following)
+
+	suite.Equal(admin.ID, relationships[1].ID)
+	suite.True(relationships[1].Muting)
+	suite.True(relationships[1].MutingNotifications)
+	suite.Equal("runs the place", relationships[1].Note)
+	suite.False(relationships[1].FollowedBy)
+
+	suite.Equal(remote.ID, relationships[2].ID)
+	suite.True(relationships[2].Blocking)
+	suite.False(relationships[2].BlockedBy)
+
+	suite.Equal(relationships[0], relationships[3])
+
+	// an unknown account just has no relationship
+	suite.Equal(&gtsmodel.Relationship{ID: "01FSBV9C8TNAZ0BPMD1H3VW4BN"}, relationships[4])
 }
 
 func (suite *RelationshipTestSuite) TestIsFollowing() {
@@ -136,26 +136,54 @@ func (s *statusDB) getStatus(ctx context.Context, cacheGet func() (*gtsmodel.Sta
 	return status, nil
 }
 
-func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Error {
-	return s.conn.RunInTx(ctx, func(tx bun.Tx) error {
-		// create links between this status and any emojis it uses
-		for _, i := range status.EmojiIDs {
-			if _, err := tx.NewInsert().Model(&gtsmodel.StatusToEmoji{
-				StatusID: status.ID,
-				EmojiID:  i,
-			}).Exec(ctx); err != nil {
-				return err
-			}
+// putStatusLinks creates links between the given status and any emojis and tags it uses.
+func (s *statusDB) putStatusLinks(ctx context.Context, tx bun.Tx, status *gtsmodel.Status) error {
+	// create links between this status and any emojis it uses
+	for _, i := range status.EmojiIDs {
+		if _, err := tx.NewInsert().Model(&gtsmodel.StatusToEmoji{
+			StatusID: status.ID,
+			EmojiID:  i,
+		}).Exec(ctx); err != nil {
+			return err
 		}
+	}
 
-		// create links between this status and any tags it uses
-		for _, i := range status.TagIDs {
-			if _, err := tx.NewInsert().Model(&gtsmodel.StatusToTag{
-				StatusID: status.ID,
-				TagID:    i,
-			}).Exec(ctx); err != nil {
-				return err
-			}
+	// create links between this status and any tags it uses
+	for _, i := range status.TagIDs {
+		if _, err := tx.NewInsert().Model(&gtsmodel.StatusToTag{
+			StatusID: status.ID,
+			TagID:    i,
+		}).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceStatusLinks drops the old links between the given status and its emojis and tags, and makes them again.
+func (s *statusDB) replaceStatusLinks(ctx context.Context, tx bun.Tx, status *gtsmodel.Status) error {
+	if _, err := tx.NewDelete().
+		Model((*gtsmodel.StatusToEmoji)(nil)).
+		Where("status_id = ?", status.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewDelete().
+		Model((*gtsmodel.StatusToTag)(nil)).
+		Where("status_id = ?", status.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return s.putStatusLinks(ctx, tx, status)
+}
+
+func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Error {
+	return s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if err := s.putStatusLinks(ctx, tx, status); err != nil {
+			return err
 		}
 
 		// change the status ID of the media attachments to the new status
@@ -169,12 +197,65 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Er
 			}
 		}
 
+		// insert the poll attached to the status, if it has one
+		if status.Poll != nil {
+			status.Poll.StatusID = status.ID
+			if _, err := tx.NewInsert().Model(status.Poll).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
 		// Finally, insert the status
 		_, err := tx.NewInsert().Model(status).Exec(ctx)
 		return err
 	})
 }
 
+func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status) db.Error {
+	err := s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if err := s.replaceStatusLinks(ctx, tx, status); err != nil {
+			return err
+		}
+
+		_, err := tx.NewUpdate().Model(status).WherePK().Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return s.conn.ProcessError(err)
+	}
+
+	// Place updated status in cache
+	// (this will replace existing, i.e. invalidating)
+	s.cache.Put(status)
+
+	return nil
+}
+
+func (s *statusDB) EditStatus(ctx context.Context, status *gtsmodel.Status, previous *gtsmodel.StatusEdit) db.Error {
+	err := s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(previous).Exec(ctx); err != nil {
+			return err
+		}
+
+		// the edit may have changed the tags and emojis used by the status
+		if err := s.replaceStatusLinks(ctx, tx, status); err != nil {
+			return err
+		}
+
+		_, err := tx.NewUpdate().Model(status).WherePK().Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return s.conn.ProcessError(err)
+	}
+
+	// Place updated status in cache
+	// (this will replace existing, i.e. invalidating)
+	s.cache.Put(status)
+
+	return nil
+}
+
 func (s *statusDB) GetStatusParents(ctx context.Context, status *gtsmodel.Status, onlyDirect bool) ([]*gtsmodel.Status, db.Error) {
 	parents := []*gtsmodel.Status{}
 	s.statusParent(ctx, status, &parents, onlyDirect)
@@ -187,9 +268,11 @@ func (s *statusDB) statusParent(ctx context.Context, status *gtsmodel.Status, fo
 	}
 
 	parentStatus, err := s.GetStatusByID(ctx, status.InReplyToID)
-	if err == nil {
-		*foundStatuses = append(*foundStatuses, parentStatus)
+	if err != nil {
+		// we don't have the parent, so this is as far up the thread as we can go
+		return
 	}
+	*foundStatuses = append(*foundStatuses, parentStatus)
 
 	if onlyDirect {
 		return
@@ -281,10 +364,20 @@ func (s *statusDB) IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Sta
 }
 
 func (s *statusDB) IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
+	// a mute covers the whole thread below the muted status, so check all the way up
+	statusIDs := []string{status.ID}
+	parents, err := s.GetStatusParents(ctx, status, false)
+	if err != nil {
+		return false, err
+	}
+	for _, parent := range parents {
+		statusIDs = append(statusIDs, parent.ID)
+	}
+
 	q := s.conn.
 		NewSelect().
 		Model(&gtsmodel.StatusMute{}).
-		Where("status_id = ?", status.ID).
+		Where("status_id IN (?)", bun.In(statusIDs)).
 		Where("account_id = ?", accountID)
 
 	return s.conn.Exists(ctx, q)
@@ -313,6 +406,21 @@ func (s *statusDB) GetStatusFaves(ctx context.Context, status *gtsmodel.Status)
 	return faves, nil
 }
 
+func (s *statusDB) GetStatusReactions(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusReaction, db.Error) {
+	reactions := []*gtsmodel.StatusReaction{}
+
+	err := s.conn.
+		NewSelect().
+		Model(&reactions).
+		Where("status_id = ?", status.ID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return reactions, nil
+}
+
 func (s *statusDB) GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, db.Error) {
 	reblogs := []*gtsmodel.Status{}
 
@@ -325,3 +433,140 @@ func (s *statusDB) GetStatusReblogs(ctx context.Context, status *gtsmodel.Status
 	}
 	return reblogs, nil
 }
+
+func (s *statusDB) GetStatusEdits(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusEdit, db.Error) {
+	edits := []*gtsmodel.StatusEdit{}
+
+	err := s.conn.
+		NewSelect().
+		Model(&edits).
+		Where("status_id = ?", status.ID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return edits, nil
+}
+
+func (s *statusDB) GetStatusPoll(ctx context.Context, statusID string) (*gtsmodel.Poll, db.Error) {
+	poll := &gtsmodel.Poll{}
+
+	err := s.conn.
+		NewSelect().
+		Model(poll).
+		Where("status_id = ?", statusID).
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return poll, nil
+}
+
+func (s *statusDB) GetPollVotes(ctx context.Context, pollID string) ([]*gtsmodel.PollVote, db.Error) {
+	votes := []*gtsmodel.PollVote{}
+
+	err := s.conn.
+		NewSelect().
+		Model(&votes).
+		Where("poll_id = ?", pollID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return votes, nil
+}
+
+func (s *statusDB) PutPollVotes(ctx context.Context, votes []*gtsmodel.PollVote) db.Error {
+	return s.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		for _, v := range votes {
+			if _, err := tx.NewInsert().Model(v).Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *statusDB) GetExpiredPolls(ctx context.Context, limit int) ([]*gtsmodel.Poll, db.Error) {
+	polls := []*gtsmodel.Poll{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&polls).
+		Where("? IS NOT NULL", bun.Ident("expires_at")).
+		Where("? <= ?", bun.Ident("expires_at"), time.Now()).
+		Where("? IS NULL", bun.Ident("closed_at")).
+		Order("expires_at ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return polls, nil
+}
+
+func (s *statusDB) GetPrunableRemoteStatuses(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.Status, db.Error) {
+	statuses := []*gtsmodel.Status{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&statuses).
+		Where("? = ?", bun.Ident("status.local"), false).
+		Where("? < ?", bun.Ident("status.updated_at"), olderThan).
+		Where("? = ?", bun.Ident("status.pinned"), false).
+		// no local accounts have interacted with it
+		Where("NOT EXISTS (SELECT 1 FROM status_faves WHERE status_faves.status_id = status.id)").
+		Where("NOT EXISTS (SELECT 1 FROM status_bookmarks WHERE status_bookmarks.status_id = status.id)").
+		Where("NOT EXISTS (SELECT 1 FROM status_mutes WHERE status_mutes.status_id = status.id)").
+		Where("NOT EXISTS (SELECT 1 FROM notifications WHERE notifications.status_id = status.id)").
+		Where("NOT EXISTS (SELECT 1 FROM spam_flags WHERE spam_flags.status_id = status.id)").
+		Where("NOT EXISTS (SELECT 1 FROM statuses AS local_statuses WHERE local_statuses.local = ? AND (local_statuses.in_reply_to_id = status.id OR local_statuses.boost_of_id = status.id))", true).
+		// and it doesn't mention any local accounts
+		Where("NOT EXISTS (SELECT 1 FROM mentions JOIN accounts ON accounts.id = mentions.target_account_id WHERE mentions.status_id = status.id AND (accounts.domain IS NULL OR accounts.domain = ''))").
+		Order("status.id ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return statuses, nil
+}
+
+func (s *statusDB) GetExpiredStatuses(ctx context.Context, accountID string, olderThan time.Time, keepPinned bool, keepFaved bool, limit int) ([]*gtsmodel.Status, db.Error) {
+	statuses := []*gtsmodel.Status{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&statuses).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? < ?", bun.Ident("status.created_at"), olderThan).
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id")).
+		Order("status.id ASC")
+
+	if keepPinned {
+		q = q.Where("? = ?", bun.Ident("status.pinned"), false)
+	}
+
+	if keepFaved {
+		q = q.Where("NOT EXISTS (SELECT 1 FROM status_faves WHERE status_faves.status_id = status.id AND status_faves.account_id = ?)", accountID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return statuses, nil
+}
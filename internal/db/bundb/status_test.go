@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type StatusTestSuite struct {
@@ -132,6 +133,129 @@ func (suite *StatusTestSuite) TestGetStatusChildren() {
 	}
 }
 
+func (suite *StatusTestSuite) TestEditStatus() {
+	ctx := context.Background()
+
+	status, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_1"].ID)
+	suite.NoError(err)
+
+	previous := &gtsmodel.StatusEdit{
+		ID:             "01FP5BB9JEX7D1T5CFDZK1D2MA",
+		CreatedAt:      status.CreatedAt,
+		StatusID:       status.ID,
+		AccountID:      status.AccountID,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Sensitive:      status.Sensitive,
+	}
+
+	status.Content = "hello world, now edited"
+	status.EditedAt = time.Now()
+	suite.NoError(suite.db.EditStatus(ctx, status, previous))
+
+	// the edited version should come back, both from the cache and from the db
+	edited, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Equal("hello world, now edited", edited.Content)
+	suite.False(edited.EditedAt.IsZero())
+
+	edits, err := suite.db.GetStatusEdits(ctx, status)
+	suite.NoError(err)
+	suite.Len(edits, 1)
+	suite.Equal(previous.Content, edits[0].Content)
+	suite.Equal(status.ID, edits[0].StatusID)
+}
+
+func (suite *StatusTestSuite) TestGetPrunableRemoteStatuses() {
+	ctx := context.Background()
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	// none of the test statuses are remote
+	statuses, err := suite.db.GetPrunableRemoteStatuses(ctx, time.Now(), 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	status := &gtsmodel.Status{
+		ID:                  "01FQ2Y3TW5AYMC3CJ0QZ6WRSJP",
+		URI:                 "http://fossbros-anonymous.io/users/foss_satan/statuses/01FQ2Y3TW5AYMC3CJ0QZ6WRSJP",
+		URL:                 "http://fossbros-anonymous.io/@foss_satan/01FQ2Y3TW5AYMC3CJ0QZ6WRSJP",
+		Content:             "nobody here cares about this",
+		CreatedAt:           time.Now().Add(-240 * time.Hour),
+		UpdatedAt:           time.Now().Add(-240 * time.Hour),
+		Local:               false,
+		AccountURI:          remoteAccount.URI,
+		AccountID:           remoteAccount.ID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: "Note",
+		Federated:           true,
+		Boostable:           true,
+		Replyable:           true,
+		Likeable:            true,
+	}
+	suite.NoError(suite.db.PutStatus(ctx, status))
+
+	statuses, err = suite.db.GetPrunableRemoteStatuses(ctx, time.Now(), 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(status.ID, statuses[0].ID)
+
+	// it was last updated more recently than this
+	statuses, err = suite.db.GetPrunableRemoteStatuses(ctx, time.Now().Add(-480*time.Hour), 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// once a local account has faved it, it should be kept
+	localAccount := suite.testAccounts["local_account_1"]
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.StatusFave{
+		ID:              "01FQ2Y4ZJ1D2MZ0Q7NXKJX4J5B",
+		AccountID:       localAccount.ID,
+		TargetAccountID: remoteAccount.ID,
+		StatusID:        status.ID,
+		URI:             localAccount.URI + "/liked/01FQ2Y4ZJ1D2MZ0Q7NXKJX4J5B",
+	}))
+
+	statuses, err = suite.db.GetPrunableRemoteStatuses(ctx, time.Now(), 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *StatusTestSuite) TestGetExpiredStatuses() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	olderThan := time.Now().Add(-24 * time.Hour)
+
+	// statuses 1, 2 and 3 are older than a day
+	statuses, err := suite.db.GetExpiredStatuses(ctx, account.ID, olderThan, true, true, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 3)
+
+	pinned := suite.testStatuses["local_account_1_status_2"]
+	pinned.Pinned = true
+	suite.NoError(suite.db.UpdateByPrimaryKey(ctx, pinned))
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.StatusFave{
+		ID:              "01FSBQ1N3V4X3F0G5YCH8JXQ8M",
+		AccountID:       account.ID,
+		TargetAccountID: account.ID,
+		StatusID:        suite.testStatuses["local_account_1_status_3"].ID,
+		URI:             account.URI + "/liked/01FSBQ1N3V4X3F0G5YCH8JXQ8M",
+	}))
+
+	// pinned and self-faved statuses are only returned if they're not being kept
+	statuses, err = suite.db.GetExpiredStatuses(ctx, account.ID, olderThan, true, true, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["local_account_1_status_1"].ID, statuses[0].ID)
+
+	statuses, err = suite.db.GetExpiredStatuses(ctx, account.ID, olderThan, false, true, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	statuses, err = suite.db.GetExpiredStatuses(ctx, account.ID, olderThan, false, false, 1)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
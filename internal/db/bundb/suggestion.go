@@ -0,0 +1,170 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type suggestionDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+// suggestible restricts the given query to accounts in column that could be suggested to the given account:
+// discoverable, unsuspended accounts that aren't the account itself, and that it doesn't already follow,
+// hasn't requested to follow, and hasn't dismissed as a suggestion.
+//
+// The query must join the accounts table as 'account' on column.
+func (s *suggestionDB) suggestible(q *bun.SelectQuery, column string, accountID string) *bun.SelectQuery {
+	followed := s.conn.
+		NewSelect().
+		Table("follows").
+		Column("target_account_id").
+		Where("account_id = ?", accountID)
+
+	requested := s.conn.
+		NewSelect().
+		Table("follow_requests").
+		Column("target_account_id").
+		Where("account_id = ?", accountID)
+
+	dismissed := s.conn.
+		NewSelect().
+		Table("suggestion_dismissals").
+		Column("target_account_id").
+		Where("account_id = ?", accountID)
+
+	return q.
+		Where("? != ?", bun.Ident(column), accountID).
+		Where("? NOT IN (?)", bun.Ident(column), followed).
+		Where("? NOT IN (?)", bun.Ident(column), requested).
+		Where("? NOT IN (?)", bun.Ident(column), dismissed).
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at"))
+}
+
+func (s *suggestionDB) GetFriendsOfFriendsSuggestions(ctx context.Context, accountID string, limit int) ([]*db.AccountCount, db.Error) {
+	counts := []*db.AccountCount{}
+
+	followed := s.conn.
+		NewSelect().
+		Table("follows").
+		Column("target_account_id").
+		Where("account_id = ?", accountID)
+
+	q := s.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.Follow{}).
+		ColumnExpr("? AS ?", bun.Ident("follow.target_account_id"), bun.Ident("account_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Join("JOIN accounts AS account ON account.id = follow.target_account_id").
+		Where("? IN (?)", bun.Ident("follow.account_id"), followed)
+
+	if err := s.suggestible(q, "follow.target_account_id", accountID).
+		Group("follow.target_account_id").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("account_id")).
+		Limit(limit).
+		Scan(ctx, &counts); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
+
+func (s *suggestionDB) GetPastInteractionSuggestions(ctx context.Context, accountID string, limit int) ([]*db.AccountCount, db.Error) {
+	// faves, boosts and replies are counted separately and then added up
+	// here, since not every database we support can select from a union
+	interactions := []struct {
+		model  interface{}
+		owner  string
+		column string
+	}{
+		{model: &[]*gtsmodel.StatusFave{}, owner: "status_fave.account_id", column: "status_fave.target_account_id"},
+		{model: &[]*gtsmodel.Status{}, owner: "status.account_id", column: "status.boost_of_account_id"},
+		{model: &[]*gtsmodel.Status{}, owner: "status.account_id", column: "status.in_reply_to_account_id"},
+	}
+
+	totals := make(map[string]int)
+	for _, i := range interactions {
+		counts := []*db.AccountCount{}
+
+		q := s.conn.
+			NewSelect().
+			Model(i.model).
+			ColumnExpr("? AS ?", bun.Ident(i.column), bun.Ident("account_id")).
+			ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+			Join("JOIN accounts AS account ON account.id = ?", bun.Ident(i.column)).
+			Where("? = ?", bun.Ident(i.owner), accountID)
+
+		if err := s.suggestible(q, i.column, accountID).
+			Group(i.column).
+			Scan(ctx, &counts); err != nil {
+			return nil, s.conn.ProcessError(err)
+		}
+
+		for _, c := range counts {
+			totals[c.AccountID] += c.Count
+		}
+	}
+
+	counts := make([]*db.AccountCount, 0, len(totals))
+	for id, count := range totals {
+		counts = append(counts, &db.AccountCount{AccountID: id, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].AccountID < counts[j].AccountID
+	})
+
+	if len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts, nil
+}
+
+func (s *suggestionDB) GetMostFollowedSuggestions(ctx context.Context, accountID string, limit int) ([]*db.AccountCount, db.Error) {
+	counts := []*db.AccountCount{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&[]*gtsmodel.Follow{}).
+		ColumnExpr("? AS ?", bun.Ident("follow.target_account_id"), bun.Ident("account_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Join("JOIN accounts AS account ON account.id = follow.target_account_id").
+		WhereGroup(" AND ", whereEmptyOrNull("account.domain"))
+
+	if err := s.suggestible(q, "follow.target_account_id", accountID).
+		Group("follow.target_account_id").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("account_id")).
+		Limit(limit).
+		Scan(ctx, &counts); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,125 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type tagDB struct {
+	config *config.Config
+	conn   *DBConn
+}
+
+func (t *tagDB) GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, db.Error) {
+	tag := &gtsmodel.Tag{}
+
+	if err := t.conn.
+		NewSelect().
+		Model(tag).
+		Where("LOWER(?) = LOWER(?)", bun.Ident("name"), name).
+		Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tag, nil
+}
+
+func (t *tagDB) GetTagByID(ctx context.Context, id string) (*gtsmodel.Tag, db.Error) {
+	tag := &gtsmodel.Tag{}
+
+	if err := t.conn.
+		NewSelect().
+		Model(tag).
+		Where("tag.id = ?", id).
+		Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tag, nil
+}
+
+func (t *tagDB) GetTagFollow(ctx context.Context, accountID string, tagID string) (*gtsmodel.TagFollow, db.Error) {
+	tagFollow := &gtsmodel.TagFollow{}
+
+	if err := t.conn.
+		NewSelect().
+		Model(tagFollow).
+		Where("tag_follow.account_id = ?", accountID).
+		Where("tag_follow.tag_id = ?", tagID).
+		Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tagFollow, nil
+}
+
+func (t *tagDB) GetTagFollowerIDs(ctx context.Context, tagIDs []string) ([]string, db.Error) {
+	accountIDs := []string{}
+
+	if len(tagIDs) == 0 {
+		return accountIDs, nil
+	}
+
+	if err := t.conn.
+		NewSelect().
+		Model((*gtsmodel.TagFollow)(nil)).
+		Column("account_id").
+		Distinct().
+		Where("tag_id IN (?)", bun.In(tagIDs)).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return accountIDs, nil
+}
+
+func (t *tagDB) SearchForTags(ctx context.Context, query string, excludeUnreviewed bool, limit int, offset int) ([]*gtsmodel.Tag, db.Error) {
+	tags := []*gtsmodel.Tag{}
+
+	q := t.conn.
+		NewSelect().
+		Model(&tags).
+		Where("tag.listable = ?", true).
+		WhereGroup(" AND ", whereLike("tag.name", query, true)).
+		// exact match first, then everything else alphabetically
+		OrderExpr("CASE WHEN LOWER(tag.name) = ? THEN 0 ELSE 1 END", strings.ToLower(query)).
+		Order("tag.name ASC", "tag.id ASC")
+
+	if excludeUnreviewed {
+		q = q.
+			Where("tag.reviewed_at IS NOT NULL").
+			Where("tag.trendable = ?", true)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tags, nil
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"database/sql"
 	"sort"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -34,6 +35,15 @@ type timelineDB struct {
 	conn   *DBConn
 }
 
+// heldStatusIDs returns a subquery selecting the ids of statuses which are held back by the spam checks.
+func (t *timelineDB) heldStatusIDs() *bun.SelectQuery {
+	return t.conn.
+		NewSelect().
+		Model((*gtsmodel.SpamFlag)(nil)).
+		Column("status_id").
+		Where("action = ?", gtsmodel.SpamActionHold)
+}
+
 func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
@@ -48,8 +58,8 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 		Model(&statuses)
 
 	q = q.ColumnExpr("status.*").
-		// Find out who accountID follows.
-		Join("LEFT JOIN follows AS f ON f.target_account_id = status.account_id").
+		// Leave out statuses held back by the spam checks.
+		Where("status.id NOT IN (?)", t.heldStatusIDs()).
 		// Sort by highest ID (newest) to lowest ID (oldest)
 		Order("status.id DESC")
 
@@ -78,15 +88,32 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 		q = q.Limit(limit)
 	}
 
+	// Find out who accountID follows.
+	followedAccountIDs := t.conn.
+		NewSelect().
+		Model((*gtsmodel.Follow)(nil)).
+		Column("target_account_id").
+		Where("account_id = ?", accountID)
+
+	// Find out which statuses use a hashtag that accountID follows.
+	followedTagStatusIDs := t.conn.
+		NewSelect().
+		Model((*gtsmodel.StatusToTag)(nil)).
+		Column("status_to_tag.status_id").
+		Join("JOIN tag_follows AS tf ON tf.tag_id = status_to_tag.tag_id").
+		Where("tf.account_id = ?", accountID)
+
 	// Use a WhereGroup here to specify that we want EITHER statuses posted by accounts that accountID follows,
-	// OR statuses posted by accountID itself (since a user should be able to see their own statuses).
+	// OR statuses posted by accountID itself (since a user should be able to see their own statuses),
+	// OR public statuses that use a hashtag that accountID follows.
 	//
-	// This is equivalent to something like WHERE ... AND (... OR ...)
+	// This is equivalent to something like WHERE ... AND (... OR ... OR ...)
 	// See: https://bun.uptrace.dev/guide/queries.html#select
 	whereGroup := func(*bun.SelectQuery) *bun.SelectQuery {
 		return q.
-			WhereOr("f.account_id = ?", accountID).
-			WhereOr("status.account_id = ?", accountID)
+			WhereOr("status.account_id IN (?)", followedAccountIDs).
+			WhereOr("status.account_id = ?", accountID).
+			WhereOr("status.visibility = ? AND status.id IN (?)", gtsmodel.VisibilityPublic, followedTagStatusIDs)
 	}
 
 	q = q.WhereGroup(" AND ", whereGroup)
@@ -98,6 +125,53 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	return statuses, nil
 }
 
+func (t *timelineDB) GetListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses).
+		ColumnExpr("status.*").
+		// Find out who is in the list.
+		Join("JOIN list_entries AS le ON le.account_id = status.account_id").
+		Where("le.list_id = ?", listID).
+		// Leave out statuses held back by the spam checks.
+		Where("status.id NOT IN (?)", t.heldStatusIDs()).
+		// Sort by highest ID (newest) to lowest ID (oldest)
+		Order("status.id DESC")
+
+	if maxID != "" {
+		// return only statuses LOWER (ie., older) than maxID
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		// return only statuses HIGHER (ie., newer) than sinceID
+		q = q.Where("status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		// return only statuses HIGHER (ie., newer) than minID
+		q = q.Where("status.id > ?", minID)
+	}
+
+	if limit > 0 {
+		// limit amount of statuses returned
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
+
 func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
@@ -114,6 +188,56 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, ma
 		WhereGroup(" AND ", whereEmptyOrNull("in_reply_to_id")).
 		WhereGroup(" AND ", whereEmptyOrNull("in_reply_to_uri")).
 		WhereGroup(" AND ", whereEmptyOrNull("boost_of_id")).
+		Where("status.id NOT IN (?)", t.heldStatusIDs()).
+		Order("status.id DESC")
+
+	if maxID != "" {
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if sinceID != "" {
+		q = q.Where("status.id > ?", sinceID)
+	}
+
+	if minID != "" {
+		q = q.Where("status.id > ?", minID)
+	}
+
+	if local {
+		q = q.Where("status.local = ?", local)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	err := q.Scan(ctx)
+	if err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
+
+func (t *timelineDB) GetTagTimeline(ctx context.Context, accountID string, tagName string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses).
+		ColumnExpr("status.*").
+		// Find statuses that use the tag.
+		Join("JOIN status_to_tags AS st ON st.status_id = status.id").
+		Join("JOIN tags AS tag ON tag.id = st.tag_id").
+		Where("LOWER(tag.name) = LOWER(?)", tagName).
+		Where("status.visibility = ?", gtsmodel.VisibilityPublic).
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id")).
+		Where("status.id NOT IN (?)", t.heldStatusIDs()).
 		Order("status.id DESC")
 
 	if maxID != "" {
@@ -218,3 +342,145 @@ func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, max
 	prevMinID := faves[0].ID
 	return statuses, nextMaxID, prevMinID, nil
 }
+
+func (t *timelineDB) GetTrendableStatuses(ctx context.Context, since time.Time, maxID string, limit int) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses).
+		ColumnExpr("status.*").
+		Join("JOIN accounts AS a ON a.id = status.account_id").
+		Where("status.visibility = ?", gtsmodel.VisibilityPublic).
+		Where("status.created_at >= ?", since).
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id")).
+		Where("status.id NOT IN (?)", t.heldStatusIDs()).
+		Where("a.discoverable = ?", true).
+		Where("a.suspended_at IS NULL").
+		Where("a.silenced_at IS NULL").
+		Order("status.id DESC")
+
+	if maxID != "" {
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
+
+// statusInteractionCount is the number of faves or boosts of one status.
+type statusInteractionCount struct {
+	StatusID string `bun:"status_id"`
+	Count    int    `bun:"count"`
+}
+
+func (t *timelineDB) CountStatusInteractionsSince(ctx context.Context, since time.Time) (map[string]int, db.Error) {
+	faves := []*statusInteractionCount{}
+	if err := t.conn.
+		NewSelect().
+		Model((*gtsmodel.StatusFave)(nil)).
+		Column("status_id").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("created_at >= ?", since).
+		Group("status_id").
+		Scan(ctx, &faves); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	boosts := []*statusInteractionCount{}
+	if err := t.conn.
+		NewSelect().
+		Model((*gtsmodel.Status)(nil)).
+		ColumnExpr("? AS ?", bun.Ident("boost_of_id"), bun.Ident("status_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("created_at >= ?", since).
+		Where("? IS NOT NULL", bun.Ident("boost_of_id")).
+		Where("? != ''", bun.Ident("boost_of_id")).
+		Group("boost_of_id").
+		Scan(ctx, &boosts); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	counts := make(map[string]int, len(faves)+len(boosts))
+	for _, c := range append(faves, boosts...) {
+		counts[c.StatusID] = counts[c.StatusID] + c.Count
+	}
+	return counts, nil
+}
+
+func (t *timelineDB) SearchForStatuses(ctx context.Context, accountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, db.Error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statuses := make([]*gtsmodel.Status, 0, limit)
+
+	// Find out who accountID follows.
+	followedAccountIDs := t.conn.
+		NewSelect().
+		Model((*gtsmodel.Follow)(nil)).
+		Column("target_account_id").
+		Where("account_id = ?", accountID)
+
+	pattern := likePattern(query, false)
+
+	q := t.conn.
+		NewSelect().
+		Model(&statuses).
+		ColumnExpr("status.*").
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id")).
+		Where("status.id NOT IN (?)", t.heldStatusIDs()).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereOr("status.account_id IN (?)", followedAccountIDs).
+				WhereOr("status.account_id = ?", accountID)
+		}).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereOr("LOWER(status.content) LIKE ? ESCAPE '\\'", pattern).
+				WhereOr("LOWER(status.content_warning) LIKE ? ESCAPE '\\'", pattern)
+		}).
+		// Sort by highest ID (newest) to lowest ID (oldest)
+		Order("status.id DESC")
+
+	if fromAccountID != "" {
+		q = q.Where("status.account_id = ?", fromAccountID)
+	}
+
+	if maxID != "" {
+		// return only statuses LOWER (ie., older) than maxID
+		q = q.Where("status.id < ?", maxID)
+	}
+
+	if minID != "" {
+		// return only statuses HIGHER (ie., newer) than minID
+		q = q.Where("status.id > ?", minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return statuses, nil
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type TimelineTestSuite struct {
@@ -38,6 +39,54 @@ func (suite *TimelineTestSuite) TestGetPublicTimeline() {
 	suite.Len(s, 6)
 }
 
+func (suite *TimelineTestSuite) TestGetTagTimeline() {
+	viewingAccount := suite.testAccounts["local_account_1"]
+
+	// updating the status links it to the tags it uses
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	suite.NoError(suite.db.UpdateStatus(context.Background(), testStatus))
+
+	s, err := suite.db.GetTagTimeline(context.Background(), viewingAccount.ID, "Welcome", "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Len(s, 1)
+	suite.Equal(testStatus.ID, s[0].ID)
+
+	s, err = suite.db.GetTagTimeline(context.Background(), viewingAccount.ID, "nobodyusesthistag", "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Empty(s)
+}
+
+func (suite *TimelineTestSuite) TestGetHomeTimelineFollowedTag() {
+	ctx := context.Background()
+	viewingAccount := suite.testAccounts["local_account_2"]
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	suite.NoError(suite.db.UpdateStatus(ctx, testStatus))
+
+	inHomeTimeline := func() bool {
+		s, err := suite.db.GetHomeTimeline(ctx, viewingAccount.ID, "", "", "", 20, false)
+		suite.NoError(err)
+		for _, status := range s {
+			if status.ID == testStatus.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// local_account_2 doesn't follow the admin account
+	suite.False(inHomeTimeline())
+
+	tag, err := suite.db.GetTagByName(ctx, "welcome")
+	suite.NoError(err)
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.TagFollow{
+		ID:        "01FRBZ4QKQ0S9T4B7W5FX3PZ8M",
+		AccountID: viewingAccount.ID,
+		TagID:     tag.ID,
+	}))
+
+	suite.True(inHomeTimeline())
+}
+
 func TestTimelineTestSuite(t *testing.T) {
 	suite.Run(t, new(TimelineTestSuite))
 }
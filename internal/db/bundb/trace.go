@@ -45,7 +45,7 @@ func (q *debugQueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent)
 // AfterQuery logs the time taken to query, the operation (select, update, etc), and the query itself as translated by bun.
 func (q *debugQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 	dur := time.Since(event.StartTime).Round(time.Microsecond)
-	l := q.log.WithFields(logrus.Fields{
+	l := q.log.WithContext(ctx).WithFields(logrus.Fields{
 		"queryTime": dur,
 		"operation": event.Operation(),
 	})
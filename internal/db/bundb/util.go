@@ -19,6 +19,9 @@
 package bundb
 
 import (
+	"strings"
+	"time"
+
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/uptrace/bun"
 )
@@ -37,6 +40,44 @@ func whereEmptyOrNull(column string) func(*bun.SelectQuery) *bun.SelectQuery {
 	}
 }
 
+// whereNotExpired is a convenience function to return a bun WhereGroup that specifies
+// that the given expiry column should be EITHER null OR a time in the future.
+//
+// Use it as follows:
+//
+//   q = q.WhereGroup(" AND ", whereNotExpired("whatever_column"))
+func whereNotExpired(column string) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			WhereOr("? IS NULL", bun.Ident(column)).
+			WhereOr("? > ?", bun.Ident(column), time.Now())
+	}
+}
+
+// whereLike is a convenience function to return a bun WhereGroup that specifies that
+// the given column should contain the given text, matched case-insensitively.
+//
+// If prefix is true, the column should start with the text instead.
+//
+// Use it as follows:
+//
+//   q = q.WhereGroup(" AND ", whereLike("whatever_column", "some text", false))
+func whereLike(column string, text string, prefix bool) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("LOWER(?) LIKE ? ESCAPE '\\'", bun.Ident(column), likePattern(text, prefix))
+	}
+}
+
+// likePattern escapes any LIKE wildcards in the given text, and
+// lowercases and wraps it in wildcards so it can be used in a LIKE clause.
+func likePattern(text string, prefix bool) string {
+	text = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(text))
+	if prefix {
+		return text + "%"
+	}
+	return "%" + text + "%"
+}
+
 // updateWhere parses []db.Where and adds it to the given update query.
 func updateWhere(q *bun.UpdateQuery, where []db.Where) {
 	for _, w := range where {
@@ -33,16 +33,23 @@ const (
 type DB interface {
 	Account
 	Admin
+	Application
 	Basic
 	Domain
+	Filter
+	Inbox
 	Instance
+	List
 	Media
 	Mention
 	Notification
 	Relationship
 	Session
 	Status
+	Suggestion
+	Tag
 	Timeline
+	Tombstone
 
 	/*
 		USEFUL CONVERSION FUNCTIONS
@@ -21,11 +21,14 @@ package db
 import (
 	"context"
 	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-// Domain contains DB functions related to domains and domain blocks.
+// Domain contains DB functions related to domains, domain blocks and domain allows.
 type Domain interface {
 	// IsDomainBlocked checks if an instance-level domain block exists for the given domain string (eg., `example.org`).
+	// Only blocks with suspend severity count here; less severe blocks are enforced through GetDomainPolicy.
 	IsDomainBlocked(ctx context.Context, domain string) (bool, Error)
 
 	// AreDomainsBlocked checks if an instance-level domain block exists for any of the given domains strings, and returns true if even one is found.
@@ -36,4 +39,13 @@ type Domain interface {
 
 	// AreURIsBlocked checks if an instance-level domain block exists for any `host` in the given URI slice, and returns true if even one is found.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, Error)
+
+	// IsDomainAllowed checks if an instance-level domain allow exists for the given domain string (eg., `example.org`).
+	// Domain allows only matter when the instance is in allowlist federation mode.
+	IsDomainAllowed(ctx context.Context, domain string) (bool, Error)
+
+	// GetDomainPolicy returns the instance-level federation policy for the given domain string (eg., `example.org`).
+	// If no policy has been created for the domain, an empty policy is returned, so callers can always just check its flags.
+	// A domain block with silence or noop severity is folded into the returned policy, setting its Silence and RejectMedia flags as appropriate.
+	GetDomainPolicy(ctx context.Context, domain string) (*gtsmodel.DomainPolicy, Error)
 }
@@ -0,0 +1,43 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Filter contains functionality for getting and managing keyword filters.
+type Filter interface {
+	// GetFilterByID returns the filter with the given ID, with its keywords.
+	GetFilterByID(ctx context.Context, id string) (*gtsmodel.Filter, Error)
+
+	// GetFiltersForAccountID returns all filters owned by the given account, with their keywords, oldest first.
+	GetFiltersForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Filter, Error)
+
+	// GetFilterKeywordByID returns the filter keyword with the given ID, with the filter it belongs to.
+	GetFilterKeywordByID(ctx context.Context, id string) (*gtsmodel.FilterKeyword, Error)
+
+	// PutFilter stores the given filter, along with its keywords.
+	PutFilter(ctx context.Context, filter *gtsmodel.Filter) Error
+
+	// DeleteFilterByID deletes the filter with the given ID, along with all of its keywords.
+	DeleteFilterByID(ctx context.Context, id string) Error
+}
@@ -0,0 +1,33 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Inbox contains functionality for keeping track of the activities received in the inboxes of local accounts.
+type Inbox interface {
+	// InboxActivityExists returns true if the activity with the given URI has already been received.
+	InboxActivityExists(ctx context.Context, activityURI string) (bool, Error)
+
+	// PruneInboxActivities removes the records of activities received before olderThan, and returns how many were removed.
+	PruneInboxActivities(ctx context.Context, olderThan time.Time) (int, Error)
+}
@@ -20,6 +20,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -37,4 +38,67 @@ type Instance interface {
 
 	// GetInstanceAccounts returns a slice of accounts from the given instance, arranged by ID.
 	GetInstanceAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, Error)
+
+	// CountInstanceActiveUsers returns the number of local accounts that have posted a status since the given time.
+	CountInstanceActiveUsers(ctx context.Context, since time.Time) (int, Error)
+
+	// CountInstanceMediaBytes returns the total size in bytes of all media attachments and emojis in storage, including thumbnails.
+	CountInstanceMediaBytes(ctx context.Context) (int, Error)
+
+	// CountInstanceFailedDeliveries returns the number of failed deliveries that are waiting to be retried.
+	CountInstanceFailedDeliveries(ctx context.Context) (int, Error)
+
+	// PruneFailedDeliveries removes failed deliveries that were first attempted before olderThan, and returns how many were removed.
+	PruneFailedDeliveries(ctx context.Context, olderThan time.Time) (int, Error)
+
+	// GetInstanceTopDomains returns up to limit remote domains, ordered by how many of their accounts we know about.
+	GetInstanceTopDomains(ctx context.Context, limit int) ([]*DomainCount, Error)
+
+	// GetInstanceRules returns the rules of this instance, in the order they should be shown.
+	GetInstanceRules(ctx context.Context) ([]*gtsmodel.Rule, Error)
+
+	// GetInstanceMeasureCounts returns how many events counted by the given measure happened on each day (in UTC)
+	// in the period from start (inclusive) to end (exclusive), in date order. Days without any events are left out.
+	GetInstanceMeasureCounts(ctx context.Context, measure InstanceMeasure, start time.Time, end time.Time) ([]*DayCount, Error)
+
+	// CountInstanceMeasure returns how many events counted by the given measure happened from start (inclusive) to end (exclusive).
+	CountInstanceMeasure(ctx context.Context, measure InstanceMeasure, start time.Time, end time.Time) (int, Error)
+
+	// GetInstanceStatusLanguages returns up to limit languages, ordered by how many local statuses were written in them from start to end.
+	GetInstanceStatusLanguages(ctx context.Context, start time.Time, end time.Time, limit int) ([]*LanguageCount, Error)
+
+	// GetInstanceStatusDomains returns up to limit remote domains, ordered by how many of their statuses we received from start to end.
+	GetInstanceStatusDomains(ctx context.Context, start time.Time, end time.Time, limit int) ([]*DomainCount, Error)
+}
+
+// InstanceMeasure is a kind of event on this instance that can be counted over time.
+type InstanceMeasure string
+
+const (
+	// InstanceMeasureNewUsers counts local sign ups.
+	InstanceMeasureNewUsers InstanceMeasure = "new_users"
+	// InstanceMeasureNewStatuses counts statuses posted by local accounts.
+	InstanceMeasureNewStatuses InstanceMeasure = "new_statuses"
+	// InstanceMeasureOpenedReports counts reports filed with this instance.
+	InstanceMeasureOpenedReports InstanceMeasure = "opened_reports"
+	// InstanceMeasureResolvedReports counts reports resolved by moderators of this instance.
+	InstanceMeasureResolvedReports InstanceMeasure = "resolved_reports"
+)
+
+// DomainCount is the number of known accounts from one domain.
+type DomainCount struct {
+	Domain string `bun:"domain" json:"domain"`
+	Count  int    `bun:"count" json:"count"`
+}
+
+// DayCount is the number of events that happened on one day.
+type DayCount struct {
+	Day   string `bun:"day" json:"day"` // YYYY-MM-DD
+	Count int    `bun:"count" json:"count"`
+}
+
+// LanguageCount is the number of statuses written in one language.
+type LanguageCount struct {
+	Language string `bun:"language" json:"language"`
+	Count    int    `bun:"count" json:"count"`
 }
@@ -0,0 +1,51 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// List contains functionality for getting and managing lists of accounts, and their members.
+type List interface {
+	// GetListByID returns the list with the given ID, with the account that owns it.
+	GetListByID(ctx context.Context, id string) (*gtsmodel.List, Error)
+
+	// GetListsForAccountID returns all lists owned by the given account, oldest first.
+	GetListsForAccountID(ctx context.Context, accountID string) ([]*gtsmodel.List, Error)
+
+	// GetListsContainingAccount returns all lists owned by ownerAccountID that accountID is a member of.
+	GetListsContainingAccount(ctx context.Context, ownerAccountID string, accountID string) ([]*gtsmodel.List, Error)
+
+	// GetListAccounts returns the accounts that are members of the given list, newest entry first.
+	//
+	// Also note the extra return values, which correspond to the nextMaxID and prevMinID for building Link headers.
+	GetListAccounts(ctx context.Context, listID string, maxID string, sinceID string, limit int) ([]*gtsmodel.Account, string, string, Error)
+
+	// IsAccountInList returns true if the given account is a member of the given list.
+	IsAccountInList(ctx context.Context, listID string, accountID string) (bool, Error)
+
+	// DeleteListByID deletes the list with the given ID, along with all of its entries.
+	DeleteListByID(ctx context.Context, id string) Error
+
+	// DeleteListEntriesForAccount removes accountID from all lists owned by ownerAccountID, and returns the IDs of the lists it was removed from.
+	DeleteListEntriesForAccount(ctx context.Context, ownerAccountID string, accountID string) ([]string, Error)
+}
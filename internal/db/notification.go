@@ -28,8 +28,18 @@ import (
 type Notification interface {
 	// GetNotifications returns a slice of notifications that pertain to the given accountID.
 	//
+	// If types is not empty, only notifications of those types will be returned. Notifications of any of the excludeTypes
+	// will never be returned.
+	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
-	GetNotifications(ctx context.Context, accountID string, limit int, maxID string, sinceID string) ([]*gtsmodel.Notification, Error)
+	GetNotifications(ctx context.Context, accountID string, types []string, excludeTypes []string, limit int, maxID string, sinceID string) ([]*gtsmodel.Notification, Error)
 	// GetNotification returns one notification according to its id.
 	GetNotification(ctx context.Context, id string) (*gtsmodel.Notification, Error)
+	// DeleteNotification deletes one notification according to its id.
+	DeleteNotification(ctx context.Context, id string) Error
+	// ClearNotifications deletes all notifications that pertain to the given accountID.
+	ClearNotifications(ctx context.Context, accountID string) Error
+	// GetNotificationPreferences returns the notification preferences stored for the given accountID.
+	// If the account hasn't stored any preferences, a 'no entries' error will be returned.
+	GetNotificationPreferences(ctx context.Context, accountID string) (*gtsmodel.NotificationPreferences, Error)
 }
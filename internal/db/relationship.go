@@ -36,9 +36,25 @@ type Relationship interface {
 	// not if you're just checking for the existence of a block.
 	GetBlock(ctx context.Context, account1 string, account2 string) (*gtsmodel.Block, Error)
 
+	// IsMuted checks whether account1 has an unexpired mute in place against account2.
+	// If notifications is true, then the function only returns true if the mute covers notifications too.
+	IsMuted(ctx context.Context, account1 string, account2 string, notifications bool) (bool, Error)
+
+	// GetMute returns the mute from account1 targeting account2, if it exists, or an error if it doesn't.
+	//
+	// Unlike IsMuted, this will also return a mute that has expired.
+	GetMute(ctx context.Context, account1 string, account2 string) (*gtsmodel.AccountMute, Error)
+
+	// GetNote returns the note written by account1 about account2, if it exists, or an error if it doesn't.
+	GetNote(ctx context.Context, account1 string, account2 string) (*gtsmodel.AccountNote, Error)
+
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, Error)
 
+	// GetRelationships retrieves the relationships of each of the targetAccounts to the requestingAccount, in the same order,
+	// using a fixed number of queries however many target accounts are given.
+	GetRelationships(ctx context.Context, requestingAccount string, targetAccounts []string) ([]*gtsmodel.Relationship, Error)
+
 	// IsFollowing returns true if sourceAccount follows target account, or an error if something goes wrong while finding out.
 	IsFollowing(ctx context.Context, sourceAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (bool, Error)
 
@@ -51,11 +67,21 @@ type Relationship interface {
 	// AcceptFollowRequest moves a follow request in the database from the follow_requests table to the follows table.
 	// In other words, it should create the follow, and delete the existing follow request.
 	//
-	// It will return the newly created follow for further processing.
+	// It will return the newly created follow for further processing. If the request has
+	// already been accepted, the existing follow will be returned instead.
 	AcceptFollowRequest(ctx context.Context, originAccountID string, targetAccountID string) (*gtsmodel.Follow, Error)
 
-	// GetAccountFollowRequests returns all follow requests targeting the given account.
-	GetAccountFollowRequests(ctx context.Context, accountID string) ([]*gtsmodel.FollowRequest, Error)
+	// RejectFollowRequest removes a follow request from the database without creating a follow.
+	//
+	// It will return the removed follow request for further processing.
+	RejectFollowRequest(ctx context.Context, originAccountID string, targetAccountID string) (*gtsmodel.FollowRequest, Error)
+
+	// GetAccountFollowRequests returns follow requests targeting the given account, newest first, along with the
+	// IDs to use for fetching the next and previous pages. If limit is set to 0, all follow requests are returned.
+	GetAccountFollowRequests(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.FollowRequest, string, string, Error)
+
+	// CountAccountFollowRequests returns the number of follow requests targeting the given account.
+	CountAccountFollowRequests(ctx context.Context, accountID string) (int, Error)
 
 	// GetAccountFollows returns a slice of follows owned by the given accountID.
 	GetAccountFollows(ctx context.Context, accountID string) ([]*gtsmodel.Follow, Error)
@@ -70,6 +96,10 @@ type Relationship interface {
 	// If localOnly is set to true, then only follows from *this instance* will be returned.
 	GetAccountFollowedBy(ctx context.Context, accountID string, localOnly bool) ([]*gtsmodel.Follow, Error)
 
+	// GetAccountFollowersByDomain fetches follows that target the given accountID, owned by accounts on the given domain.
+	// Use an empty domain to get follows owned by local accounts. The Account of each follow will be populated.
+	GetAccountFollowersByDomain(ctx context.Context, accountID string, domain string) ([]*gtsmodel.Follow, Error)
+
 	// CountAccountFollowedBy returns the amounts that the given ID is followed by.
 	CountAccountFollowedBy(ctx context.Context, accountID string, localOnly bool) (int, Error)
 }
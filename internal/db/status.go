@@ -20,6 +20,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -38,6 +39,14 @@ type Status interface {
 	// PutStatus stores one status in the database.
 	PutStatus(ctx context.Context, status *gtsmodel.Status) Error
 
+	// UpdateStatus updates the given status in the database, along with the links between it
+	// and the tags and emojis it uses, which might have changed since it was first stored.
+	UpdateStatus(ctx context.Context, status *gtsmodel.Status) Error
+
+	// EditStatus stores the given previous version of a status, and updates the status itself to
+	// its new version, along with the links between it and the tags and emojis it uses, in one transaction.
+	EditStatus(ctx context.Context, status *gtsmodel.Status, previous *gtsmodel.StatusEdit) Error
+
 	// CountStatusReplies returns the amount of replies recorded for a status, or an error if something goes wrong
 	CountStatusReplies(ctx context.Context, status *gtsmodel.Status) (int, Error)
 
@@ -63,7 +72,7 @@ type Status interface {
 	// IsStatusRebloggedBy checks if a given status has been reblogged/boosted by a given account ID
 	IsStatusRebloggedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
-	// IsStatusMutedBy checks if a given status has been muted by a given account ID
+	// IsStatusMutedBy checks if a given status, or the thread it's part of, has been muted by a given account ID
 	IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
 	// IsStatusBookmarkedBy checks if a given status has been bookmarked by a given account ID
@@ -73,7 +82,36 @@ type Status interface {
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusFaves(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusFave, Error)
 
+	// GetStatusReactions returns a slice of emoji reactions to the given status, oldest first.
+	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
+	GetStatusReactions(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusReaction, Error)
+
 	// GetStatusReblogs returns a slice of statuses that are a boost/reblog of the given status.
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, Error)
+
+	// GetStatusEdits returns the previous versions of the given status, oldest first.
+	GetStatusEdits(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusEdit, Error)
+
+	// GetStatusPoll returns the poll attached to the status with the given ID, or ErrNoEntries if it doesn't have one.
+	GetStatusPoll(ctx context.Context, statusID string) (*gtsmodel.Poll, Error)
+
+	// GetPollVotes returns every vote cast in the given poll, oldest first.
+	GetPollVotes(ctx context.Context, pollID string) ([]*gtsmodel.PollVote, Error)
+
+	// PutPollVotes stores the given votes in one transaction, so that either all or none of them are stored.
+	PutPollVotes(ctx context.Context, votes []*gtsmodel.PollVote) Error
+
+	// GetExpiredPolls returns up to limit polls that have ended but haven't been closed yet, oldest first.
+	GetExpiredPolls(ctx context.Context, limit int) ([]*gtsmodel.Poll, Error)
+
+	// GetPrunableRemoteStatuses returns up to limit remote statuses that haven't been updated since olderThan,
+	// and that no local account has interacted with, so that they can be removed from the database.
+	// If limit is 0, all such statuses will be returned.
+	GetPrunableRemoteStatuses(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.Status, Error)
+
+	// GetExpiredStatuses returns up to limit statuses created by the given account before olderThan, oldest first,
+	// so that they can be deleted automatically. Boosts are not included. If keepPinned is true, pinned statuses are
+	// not included, and if keepFaved is true, statuses that the account has faved themselves are not included.
+	GetExpiredStatuses(ctx context.Context, accountID string, olderThan time.Time, keepPinned bool, keepFaved bool, limit int) ([]*gtsmodel.Status, Error)
 }
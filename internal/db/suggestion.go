@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+)
+
+// Suggestion contains functionality for finding accounts to suggest that an account follows.
+//
+// Each function only returns accounts that could sensibly be suggested: not the account itself, and not
+// accounts that it already follows, has requested to follow, or has dismissed as a suggestion. Results
+// are ordered by count, highest first.
+type Suggestion interface {
+	// GetFriendsOfFriendsSuggestions returns accounts followed by the accounts that the given account follows,
+	// counted by how many of those accounts follow them.
+	GetFriendsOfFriendsSuggestions(ctx context.Context, accountID string, limit int) ([]*AccountCount, Error)
+
+	// GetPastInteractionSuggestions returns accounts whose statuses the given account has faved, boosted or
+	// replied to, counted by how many times it did so.
+	GetPastInteractionSuggestions(ctx context.Context, accountID string, limit int) ([]*AccountCount, Error)
+
+	// GetMostFollowedSuggestions returns local accounts, counted by how many followers they have.
+	GetMostFollowedSuggestions(ctx context.Context, accountID string, limit int) ([]*AccountCount, Error)
+}
+
+// AccountCount is a count of something relating to one account.
+type AccountCount struct {
+	AccountID string `bun:"account_id" json:"account_id"`
+	Count     int    `bun:"count" json:"count"`
+}
@@ -0,0 +1,45 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Tag contains functionality for getting hashtags, and the follows of hashtags by accounts.
+type Tag interface {
+	// GetTagByName returns the tag with the given name, without the # symbol. The name is matched case-insensitively.
+	GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, Error)
+
+	// GetTagByID returns the tag with the given ID.
+	GetTagByID(ctx context.Context, id string) (*gtsmodel.Tag, Error)
+
+	// GetTagFollow returns the follow of the given tag by the given account, if it exists.
+	GetTagFollow(ctx context.Context, accountID string, tagID string) (*gtsmodel.TagFollow, Error)
+
+	// GetTagFollowerIDs returns the IDs of all accounts that follow at least one of the given tags.
+	GetTagFollowerIDs(ctx context.Context, tagIDs []string) ([]string, Error)
+
+	// SearchForTags returns listable tags whose name starts with the given query, without the # symbol,
+	// matched case-insensitively. An exact match is returned first. If excludeUnreviewed is true,
+	// only tags that have been reviewed and approved for trends by an admin are returned.
+	SearchForTags(ctx context.Context, query string, excludeUnreviewed bool, limit int, offset int) ([]*gtsmodel.Tag, Error)
+}
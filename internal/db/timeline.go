@@ -20,6 +20,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -31,12 +32,24 @@ type Timeline interface {
 	// Statuses should be returned in descending order of when they were created (newest first).
 	GetHomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, Error)
 
+	// GetListTimeline returns a slice of statuses from accounts that are members of the given list.
+	//
+	// Statuses should be returned in descending order of when they were created (newest first).
+	GetListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, Error)
+
 	// GetPublicTimeline fetches the account's PUBLIC timeline -- ie., posts and replies that are public.
 	// It will use the given filters and try to return as many statuses as possible up to the limit.
 	//
 	// Statuses should be returned in descending order of when they were created (newest first).
 	GetPublicTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, Error)
 
+	// GetTagTimeline fetches public statuses, from here and elsewhere, that use the tag with the given name.
+	// The name is matched case-insensitively. It will use the given filters and try to return as many statuses
+	// as possible up to the limit.
+	//
+	// Statuses should be returned in descending order of when they were created (newest first).
+	GetTagTimeline(ctx context.Context, accountID string, tagName string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, Error)
+
 	// GetFavedTimeline fetches the account's FAVED timeline -- ie., posts and replies that the requesting account has faved.
 	// It will use the given filters and try to return as many statuses as possible up to the limit.
 	//
@@ -45,4 +58,23 @@ type Timeline interface {
 	//
 	// Also note the extra return values, which correspond to the nextMaxID and prevMinID for building Link headers.
 	GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, Error)
+
+	// GetTrendableStatuses returns public statuses created since the given time which may count towards trends,
+	// ie., statuses that aren't boosts or held back by the spam checks, by discoverable accounts that aren't
+	// suspended or silenced.
+	//
+	// Statuses should be returned in descending order of when they were created (newest first).
+	GetTrendableStatuses(ctx context.Context, since time.Time, maxID string, limit int) ([]*gtsmodel.Status, Error)
+
+	// CountStatusInteractionsSince returns how many times each status has been faved or boosted since the given time, keyed by status ID.
+	// Statuses that haven't been interacted with since then are left out.
+	CountStatusInteractionsSince(ctx context.Context, since time.Time) (map[string]int, Error)
+
+	// SearchForStatuses returns statuses whose content or content warning contains the given query, matched
+	// case-insensitively. Only statuses posted by the given accountID, or by accounts it follows, are searched.
+	// If fromAccountID is set, only statuses posted by that account are returned. Boosts and statuses held back
+	// by the spam checks are left out.
+	//
+	// Statuses should be returned in descending order of when they were created (newest first).
+	SearchForStatuses(ctx context.Context, accountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, Error)
 }
@@ -0,0 +1,265 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/smtp"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	signupApprovedTemplate = "email-signup-approved.tmpl"
+	signupRejectedTemplate = "email-signup-rejected.tmpl"
+	accountWarningTemplate = "email-account-warning.tmpl"
+	confirmTemplate        = "email-confirm.tmpl"
+	resetPasswordTemplate  = "email-reset-password.tmpl"
+	newReportTemplate      = "email-new-report.tmpl"
+	newSignupTemplate      = "email-new-signup.tmpl"
+)
+
+// Sender sends emails to users through the configured SMTP server. If no SMTP server
+// is configured, nothing is sent and every Send function returns nil.
+type Sender interface {
+	// SendSignupApprovedEmail tells someone that their sign up request was approved, and that they can log in now.
+	SendSignupApprovedEmail(toAddress string, data SignupData) error
+	// SendSignupRejectedEmail tells someone that their sign up request was rejected.
+	SendSignupRejectedEmail(toAddress string, data SignupData) error
+	// SendAccountWarningEmail tells someone that a moderator has given their account a warning.
+	SendAccountWarningEmail(toAddress string, data WarningData) error
+	// SendConfirmEmail asks someone to confirm their email address by following the link in data.
+	SendConfirmEmail(toAddress string, data ConfirmData) error
+	// SendResetPasswordEmail gives someone a link they can follow to choose a new password.
+	SendResetPasswordEmail(toAddress string, data ResetPasswordData) error
+	// SendNewReportEmail tells a moderator that a new report is waiting to be handled.
+	SendNewReportEmail(toAddress string, data NewReportData) error
+	// SendNewSignupEmail tells a moderator that someone signed up and is waiting to be approved.
+	SendNewSignupEmail(toAddress string, data NewSignupData) error
+}
+
+// SignupData is passed to the templates of emails about sign up requests.
+type SignupData struct {
+	// Username that was signed up with.
+	Username string
+	// Host of this instance, eg., example.org.
+	InstanceHost string
+	// URL of this instance, eg., https://example.org.
+	InstanceURL string
+}
+
+// WarningData is passed to the template of emails about account warnings.
+type WarningData struct {
+	// Username of the warned account.
+	Username string
+	// Host of this instance, eg., example.org.
+	InstanceHost string
+	// URL of this instance, eg., https://example.org.
+	InstanceURL string
+	// What was done to the account along with the warning: none, silence, or suspend.
+	Action string
+	// Explanation of the warning given by the moderator. May be empty.
+	Text string
+}
+
+// ConfirmData is passed to the template of emails asking people to confirm their email address.
+type ConfirmData struct {
+	// Username of the account the email address belongs to.
+	Username string
+	// Host of this instance, eg., example.org.
+	InstanceHost string
+	// URL of this instance, eg., https://example.org.
+	InstanceURL string
+	// Link to follow to confirm the email address, including the confirmation token.
+	ConfirmLink string
+}
+
+// ResetPasswordData is passed to the template of emails about resetting passwords.
+type ResetPasswordData struct {
+	// Username of the account whose password can be reset.
+	Username string
+	// Host of this instance, eg., example.org.
+	InstanceHost string
+	// URL of this instance, eg., https://example.org.
+	InstanceURL string
+	// Link to follow to choose a new password, including the reset token.
+	ResetLink string
+}
+
+// NewReportData is passed to the template of emails telling moderators about a new report.
+type NewReportData struct {
+	// Username of the moderator the email is sent to.
+	Username string
+	// Host of this instance, eg., example.org.
+	InstanceHost string
+	// URL of this instance, eg., https://example.org.
+	InstanceURL string
+	// Account that made the report, eg., someone@example.org, or just a username for local accounts.
+	ReporterAcct string
+	// Account that was reported, in the same format as ReporterAcct.
+	TargetAcct string
+	// Comment left by the reporter. May be empty.
+	Comment string
+	// URL of the admin panel of this instance, where the report can be handled.
+	AdminURL string
+}
+
+// NewSignupData is passed to the template of emails telling moderators about a new sign up.
+type NewSignupData struct {
+	// Username of the moderator the email is sent to.
+	Username string
+	// Host of this instance, eg., example.org.
+	InstanceHost string
+	// URL of this instance, eg., https://example.org.
+	InstanceURL string
+	// Username that was signed up with.
+	SignupUsername string
+	// Email address that was signed up with.
+	SignupEmail string
+	// Reason given for wanting to join. May be empty.
+	SignupReason string
+	// URL of the admin panel of this instance, where the sign up can be approved or rejected.
+	AdminURL string
+}
+
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+type sender struct {
+	config   *config.Config
+	log      *logrus.Logger
+	sendMail sendMailFunc
+
+	templatesLock sync.Mutex
+	templates     *template.Template
+}
+
+// NewSender returns a new Sender which sends emails using the SMTP config of the given config.
+func NewSender(config *config.Config, log *logrus.Logger) Sender {
+	return &sender{
+		config:   config,
+		log:      log,
+		sendMail: smtp.SendMail,
+	}
+}
+
+func (s *sender) SendSignupApprovedEmail(toAddress string, data SignupData) error {
+	return s.send(toAddress, fmt.Sprintf("Your sign up request for %s was approved", data.InstanceHost), signupApprovedTemplate, data)
+}
+
+func (s *sender) SendSignupRejectedEmail(toAddress string, data SignupData) error {
+	return s.send(toAddress, fmt.Sprintf("Your sign up request for %s was rejected", data.InstanceHost), signupRejectedTemplate, data)
+}
+
+func (s *sender) SendAccountWarningEmail(toAddress string, data WarningData) error {
+	return s.send(toAddress, fmt.Sprintf("Your account on %s has received a warning", data.InstanceHost), accountWarningTemplate, data)
+}
+
+func (s *sender) SendConfirmEmail(toAddress string, data ConfirmData) error {
+	return s.send(toAddress, fmt.Sprintf("Confirm your email address for %s", data.InstanceHost), confirmTemplate, data)
+}
+
+func (s *sender) SendResetPasswordEmail(toAddress string, data ResetPasswordData) error {
+	return s.send(toAddress, fmt.Sprintf("Reset your password for %s", data.InstanceHost), resetPasswordTemplate, data)
+}
+
+func (s *sender) SendNewReportEmail(toAddress string, data NewReportData) error {
+	return s.send(toAddress, fmt.Sprintf("New report on %s: %s reported %s", data.InstanceHost, data.ReporterAcct, data.TargetAcct), newReportTemplate, data)
+}
+
+func (s *sender) SendNewSignupEmail(toAddress string, data NewSignupData) error {
+	return s.send(toAddress, fmt.Sprintf("New sign up on %s: %s is waiting for approval", data.InstanceHost, data.SignupUsername), newSignupTemplate, data)
+}
+
+// send renders the given template with data as the body of an email, and sends it to toAddress.
+func (s *sender) send(toAddress string, subject string, templateName string, data interface{}) error {
+	smtpConfig := s.config.SMTPConfig
+	if smtpConfig.Host == "" {
+		s.log.WithField("func", "send").Debugf("no smtp host configured, not sending email '%s'", subject)
+		return nil
+	}
+
+	if toAddress == "" {
+		return fmt.Errorf("send: no address to send email '%s' to", subject)
+	}
+
+	templates, err := s.loadTemplates()
+	if err != nil {
+		return fmt.Errorf("send: %s", err)
+	}
+
+	body := &bytes.Buffer{}
+	if err := templates.ExecuteTemplate(body, templateName, data); err != nil {
+		return fmt.Errorf("send: error executing template %s: %s", templateName, err)
+	}
+
+	msg := assembleMessage(smtpConfig.From, toAddress, subject, body.Bytes())
+
+	var auth smtp.Auth
+	if smtpConfig.Username != "" {
+		auth = smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
+	}
+
+	addr := net.JoinHostPort(smtpConfig.Host, strconv.Itoa(smtpConfig.Port))
+	if err := s.sendMail(addr, auth, smtpConfig.From, []string{toAddress}, msg); err != nil {
+		return fmt.Errorf("send: error sending email '%s' via %s: %s", subject, addr, err)
+	}
+
+	return nil
+}
+
+// loadTemplates parses the email templates from the template directory the first time they're needed.
+func (s *sender) loadTemplates() (*template.Template, error) {
+	s.templatesLock.Lock()
+	defer s.templatesLock.Unlock()
+
+	if s.templates != nil {
+		return s.templates, nil
+	}
+
+	templates, err := template.ParseGlob(filepath.Join(s.config.TemplateConfig.BaseDir, "email-*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing email templates: %s", err)
+	}
+
+	s.templates = templates
+	return s.templates, nil
+}
+
+// assembleMessage puts together a plain text email message with the given headers and body.
+func assembleMessage(from string, to string, subject string, body []byte) []byte {
+	msg := &bytes.Buffer{}
+	msg.WriteString("From: " + from + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + subject + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(string(body), "\n", "\r\n"))
+	return msg.Bytes()
+}
@@ -0,0 +1,190 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package email_test
+
+import (
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SenderTestSuite struct {
+	suite.Suite
+	config *config.Config
+	sender email.Sender
+
+	// emails that would have been sent
+	addrs []string
+	tos   [][]string
+	msgs  []string
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.config.TemplateConfig.BaseDir = "../../web/template/"
+	suite.config.SMTPConfig.Host = "smtp.example.org"
+	suite.config.SMTPConfig.Port = 587
+	suite.config.SMTPConfig.From = "gotosocial@example.org"
+
+	suite.addrs = []string{}
+	suite.tos = [][]string{}
+	suite.msgs = []string{}
+
+	suite.sender = email.NewSender(suite.config, testrig.NewTestLog())
+	email.SetSendMail(suite.sender, func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		suite.addrs = append(suite.addrs, addr)
+		suite.tos = append(suite.tos, to)
+		suite.msgs = append(suite.msgs, string(msg))
+		return nil
+	})
+}
+
+func (suite *SenderTestSuite) signupData() email.SignupData {
+	return email.SignupData{
+		Username:     "weed_lord420",
+		InstanceHost: "localhost:8080",
+		InstanceURL:  "http://localhost:8080",
+	}
+}
+
+func (suite *SenderTestSuite) TestSendSignupApprovedEmail() {
+	err := suite.sender.SendSignupApprovedEmail("weed_lord420@example.org", suite.signupData())
+	suite.NoError(err)
+
+	suite.Len(suite.msgs, 1)
+	suite.Equal("smtp.example.org:587", suite.addrs[0])
+	suite.Equal([]string{"weed_lord420@example.org"}, suite.tos[0])
+
+	msg := suite.msgs[0]
+	suite.Contains(msg, "From: gotosocial@example.org\r\n")
+	suite.Contains(msg, "To: weed_lord420@example.org\r\n")
+	suite.Contains(msg, "Subject: Your sign up request for localhost:8080 was approved\r\n")
+	suite.Contains(msg, "Hello weed_lord420!")
+	suite.Contains(msg, "You can log in now at http://localhost:8080")
+}
+
+func (suite *SenderTestSuite) TestSendSignupRejectedEmail() {
+	err := suite.sender.SendSignupRejectedEmail("weed_lord420@example.org", suite.signupData())
+	suite.NoError(err)
+
+	suite.Len(suite.msgs, 1)
+	suite.Contains(suite.msgs[0], "Subject: Your sign up request for localhost:8080 was rejected\r\n")
+	suite.Contains(suite.msgs[0], "was not approved by an admin")
+}
+
+func (suite *SenderTestSuite) TestSendAccountWarningEmail() {
+	err := suite.sender.SendAccountWarningEmail("weed_lord420@example.org", email.WarningData{
+		Username:     "weed_lord420",
+		InstanceHost: "localhost:8080",
+		InstanceURL:  "http://localhost:8080",
+		Action:       "silence",
+		Text:         "Please stop posting spam.",
+	})
+	suite.NoError(err)
+
+	suite.Len(suite.msgs, 1)
+	suite.Contains(suite.msgs[0], "Subject: Your account on localhost:8080 has received a warning\r\n")
+	suite.Contains(suite.msgs[0], "Your account has been silenced")
+	suite.Contains(suite.msgs[0], "Please stop posting spam.")
+}
+
+func (suite *SenderTestSuite) TestSendConfirmEmail() {
+	err := suite.sender.SendConfirmEmail("weed_lord420@example.org", email.ConfirmData{
+		Username:     "weed_lord420",
+		InstanceHost: "localhost:8080",
+		InstanceURL:  "http://localhost:8080",
+		ConfirmLink:  "http://localhost:8080/confirm_email?token=a5a280bd-34be-44a3-8330-a57eaf61b8dd",
+	})
+	suite.NoError(err)
+
+	suite.Len(suite.msgs, 1)
+	suite.Contains(suite.msgs[0], "Subject: Confirm your email address for localhost:8080\r\n")
+	suite.Contains(suite.msgs[0], "http://localhost:8080/confirm_email?token=a5a280bd-34be-44a3-8330-a57eaf61b8dd")
+}
+
+func (suite *SenderTestSuite) TestSendResetPasswordEmail() {
+	err := suite.sender.SendResetPasswordEmail("weed_lord420@example.org", email.ResetPasswordData{
+		Username:     "weed_lord420",
+		InstanceHost: "localhost:8080",
+		InstanceURL:  "http://localhost:8080",
+		ResetLink:    "http://localhost:8080/reset_password?token=8e3b57b4-1e4f-4f4a-a3f5-3d9e3c3ff1d0",
+	})
+	suite.NoError(err)
+
+	suite.Len(suite.msgs, 1)
+	suite.Contains(suite.msgs[0], "Subject: Reset your password for localhost:8080\r\n")
+	suite.Contains(suite.msgs[0], "http://localhost:8080/reset_password?token=8e3b57b4-1e4f-4f4a-a3f5-3d9e3c3ff1d0")
+}
+
+func (suite *SenderTestSuite) TestSendNewReportEmail() {
+	err := suite.sender.SendNewReportEmail("admin@example.org", email.NewReportData{
+		Username:     "admin",
+		InstanceHost: "localhost:8080",
+		InstanceURL:  "http://localhost:8080",
+		ReporterAcct: "the_mighty_zork",
+		TargetAcct:   "foss_satan@fossbros-anonymous.io",
+		Comment:      "this is spam",
+		AdminURL:     "http://localhost:8080/admin",
+	})
+	suite.NoError(err)
+
+	suite.Len(suite.msgs, 1)
+	suite.Contains(suite.msgs[0], "Subject: New report on localhost:8080: the_mighty_zork reported foss_satan@fossbros-anonymous.io\r\n")
+	suite.Contains(suite.msgs[0], "this is spam")
+	suite.Contains(suite.msgs[0], "http://localhost:8080/admin")
+}
+
+func (suite *SenderTestSuite) TestSendNewSignupEmail() {
+	err := suite.sender.SendNewSignupEmail("admin@example.org", email.NewSignupData{
+		Username:       "admin",
+		InstanceHost:   "localhost:8080",
+		InstanceURL:    "http://localhost:8080",
+		SignupUsername: "weed_lord420",
+		SignupEmail:    "weed_lord420@example.org",
+		AdminURL:       "http://localhost:8080/admin",
+	})
+	suite.NoError(err)
+
+	suite.Len(suite.msgs, 1)
+	suite.Contains(suite.msgs[0], "Subject: New sign up on localhost:8080: weed_lord420 is waiting for approval\r\n")
+	suite.Contains(suite.msgs[0], "weed_lord420 (weed_lord420@example.org) has signed up")
+	suite.NotContains(suite.msgs[0], "They gave this reason")
+}
+
+func (suite *SenderTestSuite) TestSendNoHost() {
+	suite.config.SMTPConfig.Host = ""
+
+	err := suite.sender.SendSignupApprovedEmail("weed_lord420@example.org", suite.signupData())
+	suite.NoError(err)
+	suite.Empty(suite.msgs)
+}
+
+func (suite *SenderTestSuite) TestSendNoAddress() {
+	err := suite.sender.SendSignupApprovedEmail("", suite.signupData())
+	suite.Error(err)
+	suite.Empty(suite.msgs)
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, &SenderTestSuite{})
+}
@@ -0,0 +1,168 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package errorreport sends reports of errors to an external error tracker, such as Sentry or a generic webhook,
+// so that admins find out about failures without having to watch the logs.
+//
+// Reports are taken from the logs: the Reporter is a logrus hook that picks up everything logged at error level
+// or above, along with its fields and the request it was logged for.
+package errorreport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// maxInFlight is the most reports that are sent at the same time; any more are dropped, so that
+	// a flood of errors can't pile up goroutines waiting on a slow or unreachable error tracker.
+	maxInFlight = 10
+	// sendTimeout is how long to wait for the error tracker to accept a report.
+	sendTimeout = 10 * time.Second
+)
+
+// Event is a single error report.
+type Event struct {
+	// Time at which the error happened.
+	Time time.Time
+	// Level of the error: error, fatal or panic.
+	Level string
+	// Message describing the error.
+	Message string
+	// RequestID of the request the error happened while handling, if any.
+	RequestID string
+	// Fields logged along with the error, such as the error itself, the request path, or a stack trace.
+	Fields map[string]string
+}
+
+// Reporter sends error reports to an external error tracker.
+type Reporter interface {
+	// Hook lets the Reporter be added to a logger, to report everything logged at error level or above.
+	// Reports from the hook are sent in the background, and dropped if the error tracker can't keep up.
+	logrus.Hook
+	// Report sends the given event, waiting until the error tracker has accepted it.
+	Report(ctx context.Context, e *Event) error
+}
+
+// sink sends events to one kind of error tracker.
+type sink interface {
+	send(ctx context.Context, e *Event) error
+}
+
+type reporter struct {
+	sinks    []sink
+	inFlight chan struct{}
+	log      *logrus.Logger
+}
+
+// New returns a Reporter that sends error reports to the error trackers set in the given config,
+// or nil if no error tracker is set. Reports are sent using the given http client.
+func New(c *config.Config, client *http.Client, log *logrus.Logger) (Reporter, error) {
+	sinks := []sink{}
+
+	if c.ErrorReportingConfig.DSN != "" {
+		s, err := newSentrySink(c, client)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing error reporting dsn: %s", err)
+		}
+		sinks = append(sinks, s)
+	}
+
+	if c.ErrorReportingConfig.WebhookURL != "" {
+		sinks = append(sinks, newWebhookSink(c, client))
+	}
+
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	return &reporter{
+		sinks:    sinks,
+		inFlight: make(chan struct{}, maxInFlight),
+		log:      log,
+	}, nil
+}
+
+func (r *reporter) Report(ctx context.Context, e *Event) error {
+	errs := []string{}
+	for _, s := range r.sinks {
+		if err := s.send(ctx, e); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("error sending error report: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (r *reporter) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel}
+}
+
+func (r *reporter) Fire(entry *logrus.Entry) error {
+	e := &Event{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+		Fields:  make(map[string]string, len(entry.Data)),
+	}
+
+	// the entry is reused once the hooks have run, so take copies of everything now
+	for k, v := range entry.Data {
+		switch v := v.(type) {
+		case error:
+			e.Fields[k] = v.Error()
+		default:
+			e.Fields[k] = fmt.Sprint(v)
+		}
+	}
+
+	if entry.Context != nil {
+		e.RequestID = log.RequestID(entry.Context)
+	}
+
+	select {
+	case r.inFlight <- struct{}{}:
+	default:
+		// warn rather than error, or this would be reported too
+		r.log.Warn("too many error reports in flight, dropping one")
+		return nil
+	}
+
+	go func() {
+		defer func() { <-r.inFlight }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+
+		if err := r.Report(ctx, e); err != nil {
+			r.log.WithField("func", "errorreport.Fire").Warn(err)
+		}
+	}()
+
+	return nil
+}
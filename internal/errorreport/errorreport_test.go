@@ -0,0 +1,165 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package errorreport_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/errorreport"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type receivedRequest struct {
+	path    string
+	headers http.Header
+	body    map[string]interface{}
+}
+
+type ErrorReportTestSuite struct {
+	suite.Suite
+	config   *config.Config
+	log      *logrus.Logger
+	server   *httptest.Server
+	received chan *receivedRequest
+}
+
+func (suite *ErrorReportTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.log = testrig.NewTestLog()
+	suite.received = make(chan *receivedRequest, 10)
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body := map[string]interface{}{}
+		_ = json.Unmarshal(b, &body)
+		suite.received <- &receivedRequest{path: r.URL.Path, headers: r.Header, body: body}
+	}))
+}
+
+func (suite *ErrorReportTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *ErrorReportTestSuite) event() *errorreport.Event {
+	return &errorreport.Event{
+		Time:      time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC),
+		Level:     "error",
+		Message:   "Internal Server Error",
+		RequestID: "01FN1FZ0J8QM6RY4QE9AQ0TQ1T",
+		Fields:    map[string]string{"path": "/api/v1/statuses", "error": "db error"},
+	}
+}
+
+func (suite *ErrorReportTestSuite) TestNotConfigured() {
+	reporter, err := errorreport.New(suite.config, http.DefaultClient, suite.log)
+	suite.NoError(err)
+	suite.Nil(reporter)
+}
+
+func (suite *ErrorReportTestSuite) TestSentry() {
+	suite.config.ErrorReportingConfig.DSN = "http://publickey@" + suite.server.Listener.Addr().String() + "/sentry/42"
+
+	reporter, err := errorreport.New(suite.config, suite.server.Client(), suite.log)
+	suite.NoError(err)
+	suite.NoError(reporter.Report(context.Background(), suite.event()))
+
+	r := <-suite.received
+	suite.Equal("/sentry/api/42/store/", r.path)
+	suite.Equal("Sentry sentry_version=7, sentry_client=gotosocial/, sentry_key=publickey", r.headers.Get("X-Sentry-Auth"))
+	suite.Len(r.body["event_id"], 32)
+	suite.Equal("2021-12-01T12:00:00Z", r.body["timestamp"])
+	suite.Equal("error", r.body["level"])
+	suite.Equal("Internal Server Error", r.body["message"])
+	suite.Equal("test", r.body["environment"])
+	suite.Equal(map[string]interface{}{"request_id": "01FN1FZ0J8QM6RY4QE9AQ0TQ1T"}, r.body["tags"])
+	suite.Equal(map[string]interface{}{"path": "/api/v1/statuses", "error": "db error"}, r.body["extra"])
+}
+
+func (suite *ErrorReportTestSuite) TestInvalidDSN() {
+	for _, dsn := range []string{"https://sentry.example.org/1", "https://publickey@sentry.example.org/", "ftp://publickey@sentry.example.org/1"} {
+		suite.config.ErrorReportingConfig.DSN = dsn
+		_, err := errorreport.New(suite.config, http.DefaultClient, suite.log)
+		suite.Error(err, dsn)
+	}
+}
+
+func (suite *ErrorReportTestSuite) TestWebhook() {
+	suite.config.ErrorReportingConfig.WebhookURL = suite.server.URL + "/hooks/errors"
+
+	reporter, err := errorreport.New(suite.config, suite.server.Client(), suite.log)
+	suite.NoError(err)
+	suite.NoError(reporter.Report(context.Background(), suite.event()))
+
+	r := <-suite.received
+	suite.Equal("/hooks/errors", r.path)
+	suite.Equal(map[string]interface{}{
+		"time":        "2021-12-01T12:00:00Z",
+		"level":       "error",
+		"message":     "Internal Server Error",
+		"host":        "localhost:8080",
+		"version":     "",
+		"environment": "test",
+		"request_id":  "01FN1FZ0J8QM6RY4QE9AQ0TQ1T",
+		"fields":      map[string]interface{}{"path": "/api/v1/statuses", "error": "db error"},
+	}, r.body)
+}
+
+func (suite *ErrorReportTestSuite) TestHook() {
+	suite.config.ErrorReportingConfig.WebhookURL = suite.server.URL
+
+	reporter, err := errorreport.New(suite.config, suite.server.Client(), suite.log)
+	suite.NoError(err)
+	suite.log.AddHook(reporter)
+
+	ctx := log.WithRequestID(context.Background(), "01FN1FZ0J8QM6RY4QE9AQ0TQ1T")
+
+	// things below error level aren't reported
+	suite.log.WithContext(ctx).Warn("just a warning")
+	suite.log.WithContext(ctx).WithError(errors.New("db error")).WithField("statusCode", 500).Error("Internal Server Error")
+
+	select {
+	case r := <-suite.received:
+		suite.Equal("Internal Server Error", r.body["message"])
+		suite.Equal("01FN1FZ0J8QM6RY4QE9AQ0TQ1T", r.body["request_id"])
+		suite.Equal("db error", r.body["fields"].(map[string]interface{})["error"])
+		suite.Equal("500", r.body["fields"].(map[string]interface{})["statusCode"])
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for error report")
+	}
+
+	select {
+	case r := <-suite.received:
+		suite.FailNow("unexpected error report", r.body["message"])
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestErrorReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorReportTestSuite))
+}
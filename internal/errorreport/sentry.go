@@ -0,0 +1,148 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// sentrySink sends events to Sentry, or anything else that speaks its store api.
+//
+// See https://develop.sentry.dev/sdk/store/
+type sentrySink struct {
+	storeURL    string
+	auth        string
+	serverName  string
+	release     string
+	environment string
+	client      *http.Client
+}
+
+// sentryEvent is the subset of a Sentry event that gotosocial fills in.
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Logger      string            `json:"logger"`
+	Platform    string            `json:"platform"`
+	Message     string            `json:"message"`
+	ServerName  string            `json:"server_name"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+// newSentrySink parses a dsn like https://publickey@sentry.example.org/1 into a sink for that project.
+func newSentrySink(c *config.Config, client *http.Client) (*sentrySink, error) {
+	dsn, err := url.Parse(c.ErrorReportingConfig.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	if dsn.Scheme != "http" && dsn.Scheme != "https" {
+		return nil, fmt.Errorf("dsn scheme must be http or https, got '%s'", dsn.Scheme)
+	}
+
+	if dsn.User == nil || dsn.User.Username() == "" {
+		return nil, fmt.Errorf("dsn has no public key")
+	}
+
+	// the project id is the last part of the path, anything before it is a prefix for the api
+	path := strings.TrimSuffix(dsn.Path, "/")
+	i := strings.LastIndex(path, "/")
+	if i == -1 || path[i+1:] == "" {
+		return nil, fmt.Errorf("dsn has no project id")
+	}
+	prefix, projectID := path[:i], path[i+1:]
+
+	auth := fmt.Sprintf("Sentry sentry_version=7, sentry_client=gotosocial/%s, sentry_key=%s", c.SoftwareVersion, dsn.User.Username())
+	if secret, ok := dsn.User.Password(); ok {
+		auth += ", sentry_secret=" + secret
+	}
+
+	return &sentrySink{
+		storeURL:    fmt.Sprintf("%s://%s%s/api/%s/store/", dsn.Scheme, dsn.Host, prefix, projectID),
+		auth:        auth,
+		serverName:  c.Host,
+		release:     c.SoftwareVersion,
+		environment: c.ErrorReportingConfig.Environment,
+		client:      client,
+	}, nil
+}
+
+func (s *sentrySink) send(ctx context.Context, e *Event) error {
+	eventID := make([]byte, 16)
+	if _, err := rand.Read(eventID); err != nil {
+		return err
+	}
+
+	se := &sentryEvent{
+		EventID:     hex.EncodeToString(eventID),
+		Timestamp:   e.Time.UTC().Format(time.RFC3339),
+		Level:       e.Level,
+		Logger:      "gotosocial",
+		Platform:    "go",
+		Message:     e.Message,
+		ServerName:  s.serverName,
+		Release:     s.release,
+		Environment: s.environment,
+		Extra:       e.Fields,
+	}
+	if e.Level == "panic" {
+		se.Level = "fatal"
+	}
+	if e.RequestID != "" {
+		se.Tags = map[string]string{"request_id": e.RequestID}
+	}
+
+	body, err := json.Marshal(se)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.storeURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sentry: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("sentry: unexpected response %s", resp.Status)
+	}
+	return nil
+}
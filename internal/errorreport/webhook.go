@@ -0,0 +1,94 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// webhookSink POSTs events as json to a url of the admin's choosing.
+type webhookSink struct {
+	url         string
+	host        string
+	version     string
+	environment string
+	client      *http.Client
+}
+
+// webhookEvent is the json body of a webhook error report.
+type webhookEvent struct {
+	Time        string            `json:"time"`
+	Level       string            `json:"level"`
+	Message     string            `json:"message"`
+	Host        string            `json:"host"`
+	Version     string            `json:"version"`
+	Environment string            `json:"environment,omitempty"`
+	RequestID   string            `json:"request_id,omitempty"`
+	Fields      map[string]string `json:"fields"`
+}
+
+func newWebhookSink(c *config.Config, client *http.Client) *webhookSink {
+	return &webhookSink{
+		url:         c.ErrorReportingConfig.WebhookURL,
+		host:        c.Host,
+		version:     c.SoftwareVersion,
+		environment: c.ErrorReportingConfig.Environment,
+		client:      client,
+	}
+}
+
+func (s *webhookSink) send(ctx context.Context, e *Event) error {
+	body, err := json.Marshal(&webhookEvent{
+		Time:        e.Time.UTC().Format(time.RFC3339),
+		Level:       e.Level,
+		Message:     e.Message,
+		Host:        s.host,
+		Version:     s.version,
+		Environment: s.environment,
+		RequestID:   e.RequestID,
+		Fields:      e.Fields,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: unexpected response %s", resp.Status)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ package federation
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -32,8 +33,11 @@ import (
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/go-fed/httpsig"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -112,7 +116,7 @@ func getPublicKeyFromResponse(c context.Context, b []byte, keyID *url.URL) (voca
 // Also note that this function *does not* dereference the remote account that the signature key is associated with.
 // Other functions should use the returned URL to dereference the remote account, if required.
 func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedUsername string) (*url.URL, bool, error) {
-	l := f.log.WithField("func", "AuthenticateFederatedRequest")
+	l := f.log.WithContext(ctx).WithField("func", "AuthenticateFederatedRequest")
 
 	var publicKey interface{}
 	var pkOwnerURI *url.URL
@@ -153,14 +157,29 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	requestingRemoteAccount := &gtsmodel.Account{}
 	requestingLocalAccount := &gtsmodel.Account{}
 	requestingHost := requestingPublicKeyID.Host
+
+	if allowed, err := f.domainAllowed(ctx, requestingPublicKeyID); err != nil {
+		return nil, false, err
+	} else if !allowed {
+		l.WithField("requestingHost", requestingHost).Debug("request came from a domain that isn't allowed")
+		return nil, false, nil // we're in allowlist mode and the domain isn't on the list
+	}
 	if strings.EqualFold(requestingHost, f.config.Host) {
 		// LOCAL ACCOUNT REQUEST
 		// the request is coming from INSIDE THE HOUSE so skip the remote dereferencing
-		l.Tracef("proceeding without dereference for local public key %s", requestingPublicKeyID)
-		if err := f.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: requestingPublicKeyID.String()}}, requestingLocalAccount); err != nil {
+		l.WithField("requestingPublicKeyID", requestingPublicKeyID).Trace("proceeding without dereference for local public key")
+		if err := f.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: requestingPublicKeyID.String()}}, requestingLocalAccount); err == nil {
+			publicKey = requestingLocalAccount.PublicKey
+		} else if err := f.db.GetWhere(ctx, []db.Where{{Key: "ed25519_public_key_uri", Value: requestingPublicKeyID.String()}}, requestingLocalAccount); err == nil {
+			// the request was signed with the account's ed25519 key rather than its rsa key
+			privateKey, err := util.DecodeEd25519PrivateKey(requestingLocalAccount.Ed25519PrivateKey)
+			if err != nil {
+				return nil, false, fmt.Errorf("couldn't decode ed25519 key of local account %s: %s", requestingLocalAccount.ID, err)
+			}
+			publicKey = privateKey.Public()
+		} else {
 			return nil, false, fmt.Errorf("couldn't get local account with public key uri %s from the database: %s", requestingPublicKeyID.String(), err)
 		}
-		publicKey = requestingLocalAccount.PublicKey
 		pkOwnerURI, err = url.Parse(requestingLocalAccount.URI)
 		if err != nil {
 			return nil, false, fmt.Errorf("error parsing url %s: %s", requestingLocalAccount.URI, err)
@@ -168,7 +187,7 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	} else if err := f.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: requestingPublicKeyID.String()}}, requestingRemoteAccount); err == nil {
 		// REMOTE ACCOUNT REQUEST WITH KEY CACHED LOCALLY
 		// this is a remote account and we already have the public key for it so use that
-		l.Tracef("proceeding without dereference for cached public key %s", requestingPublicKeyID)
+		l.WithField("requestingPublicKeyID", requestingPublicKeyID).Trace("proceeding without dereference for cached public key")
 		publicKey = requestingRemoteAccount.PublicKey
 		pkOwnerURI, err = url.Parse(requestingRemoteAccount.URI)
 		if err != nil {
@@ -178,56 +197,124 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		// REMOTE ACCOUNT REQUEST WITHOUT KEY CACHED LOCALLY
 		// the request is remote and we don't have the public key yet,
 		// so we need to authenticate the request properly by dereferencing the remote key
-		l.Tracef("proceeding with dereference for uncached public key %s", requestingPublicKeyID)
-		transport, err := f.transportController.NewTransportForUsername(ctx, requestedUsername)
+		l.WithField("requestingPublicKeyID", requestingPublicKeyID).Trace("proceeding with dereference for uncached public key")
+		publicKey, pkOwnerURI, err = f.dereferencePublicKey(context.Background(), requestedUsername, requestingPublicKeyID)
 		if err != nil {
-			return nil, false, fmt.Errorf("transport err: %s", err)
+			return nil, false, err
 		}
+	}
 
-		// The actual http call to the remote server is made right here in the Dereference function.
-		b, err := transport.Dereference(context.Background(), requestingPublicKeyID)
-		if err != nil {
-			return nil, false, fmt.Errorf("error deferencing key %s: %s", requestingPublicKeyID.String(), err)
-		}
+	// after all that, public key should be defined
+	if publicKey == nil {
+		return nil, false, errors.New("returned public key was empty")
+	}
+
+	// the key owner might live somewhere other than the key, so check that's allowed too
+	if allowed, err := f.domainAllowed(ctx, pkOwnerURI); err != nil {
+		return nil, false, err
+	} else if !allowed {
+		l.WithField("pkOwnerURI", pkOwnerURI).Debug("public key owner is on a domain that isn't allowed")
+		return nil, false, nil
+	}
+
+	// do the actual authentication here!
+	if verifySignature(l, verifier, publicKey, pkOwnerURI) {
+		return pkOwnerURI, true, nil
+	}
 
-		// if the key isn't in the response, we can't authenticate the request
-		requestingPublicKey, err := getPublicKeyFromResponse(context.Background(), b, requestingPublicKeyID)
+	// the remote account might have rotated its keys since we cached its public key,
+	// so fetch the key again, bypassing any cached copy, and give it one more try
+	if requestingRemoteAccount.ID != "" {
+		l.WithField("requestingPublicKeyID", requestingPublicKeyID).Debug("authentication with cached public key failed, dereferencing it again")
+		freshPublicKey, freshPkOwnerURI, err := f.dereferencePublicKey(transport.WithRevalidate(context.Background()), requestedUsername, requestingPublicKeyID)
 		if err != nil {
-			return nil, false, fmt.Errorf("error getting key %s from response %s: %s", requestingPublicKeyID.String(), string(b), err)
+			l.WithError(err).Debug("error dereferencing public key again")
+		} else if freshPkOwnerURI.String() == requestingRemoteAccount.URI && verifySignature(l, verifier, freshPublicKey, freshPkOwnerURI) {
+			// store the new key so that we don't have to fetch it on every request
+			if rsaPublicKey, ok := freshPublicKey.(*rsa.PublicKey); ok {
+				requestingRemoteAccount.PublicKey = rsaPublicKey
+				if _, err := f.db.UpdateAccount(ctx, requestingRemoteAccount); err != nil {
+					l.WithError(err).WithField("accountID", requestingRemoteAccount.ID).Error("error updating public key of account")
+				} else {
+					l.WithField("accountID", requestingRemoteAccount.ID).Info("public key of account has changed, updated it")
+				}
+			}
+			return freshPkOwnerURI, true, nil
 		}
+	}
 
-		// we should be able to get the actual key embedded in the vocab.W3IDSecurityV1PublicKey
-		pkPemProp := requestingPublicKey.GetW3IDSecurityV1PublicKeyPem()
-		if pkPemProp == nil || !pkPemProp.IsXMLSchemaString() {
-			return nil, false, errors.New("publicKeyPem property is not provided or it is not embedded as a value")
-		}
+	l.WithFields(logrus.Fields{
+		"pkOwnerURI": pkOwnerURI,
+		"signature":  signature,
+	}).Info("authentication not passed for public key owner")
+	return nil, false, nil
+}
 
-		// and decode the PEM so that we can parse it as a golang public key
-		pubKeyPem := pkPemProp.Get()
-		block, _ := pem.Decode([]byte(pubKeyPem))
-		if block == nil || block.Type != "PUBLIC KEY" {
-			return nil, false, errors.New("could not decode publicKeyPem to PUBLIC KEY pem block type")
-		}
+// domainAllowed returns true if the host of the given uri may federate with this instance. That's always
+// the case in blocklist mode; in allowlist mode, the host must be our own or have a domain allow entry.
+func (f *federator) domainAllowed(ctx context.Context, uri *url.URL) (bool, error) {
+	if f.config.FederationConfig.Mode != config.FederationModeAllowlist || strings.EqualFold(uri.Host, f.config.Host) {
+		return true, nil
+	}
 
-		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
-		if err != nil {
-			return nil, false, fmt.Errorf("could not parse public key from block bytes: %s", err)
-		}
+	allowed, err := f.db.IsDomainAllowed(ctx, uri.Hostname())
+	if err != nil {
+		return false, fmt.Errorf("error checking domain allow for %s: %s", uri.Hostname(), err)
+	}
 
-		// all good! we just need the URI of the key owner to return
-		pkOwnerProp := requestingPublicKey.GetW3IDSecurityV1Owner()
-		if pkOwnerProp == nil || !pkOwnerProp.IsIRI() {
-			return nil, false, errors.New("publicKeyOwner property is not provided or it is not embedded as a value")
-		}
-		pkOwnerURI = pkOwnerProp.GetIRI()
+	return allowed, nil
+}
+
+// dereferencePublicKey fetches the public key with the given ID from a remote server, using a transport for the
+// given username, and returns the parsed key along with the URI of its owner.
+func (f *federator) dereferencePublicKey(ctx context.Context, requestedUsername string, keyID *url.URL) (interface{}, *url.URL, error) {
+	t, err := f.transportController.NewTransportForUsername(ctx, requestedUsername)
+	if err != nil {
+		return nil, nil, fmt.Errorf("transport err: %s", err)
 	}
 
-	// after all that, public key should be defined
-	if publicKey == nil {
-		return nil, false, errors.New("returned public key was empty")
+	// The actual http call to the remote server is made right here in the Dereference function.
+	b, err := t.Dereference(ctx, keyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error deferencing key %s: %s", keyID.String(), err)
 	}
 
-	// do the actual authentication here!
+	// if the key isn't in the response, we can't authenticate the request
+	requestingPublicKey, err := getPublicKeyFromResponse(ctx, b, keyID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting key %s from response %s: %s", keyID.String(), string(b), err)
+	}
+
+	// we should be able to get the actual key embedded in the vocab.W3IDSecurityV1PublicKey
+	pkPemProp := requestingPublicKey.GetW3IDSecurityV1PublicKeyPem()
+	if pkPemProp == nil || !pkPemProp.IsXMLSchemaString() {
+		return nil, nil, errors.New("publicKeyPem property is not provided or it is not embedded as a value")
+	}
+
+	// and decode the PEM so that we can parse it as a golang public key
+	pubKeyPem := pkPemProp.Get()
+	block, _ := pem.Decode([]byte(pubKeyPem))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, nil, errors.New("could not decode publicKeyPem to PUBLIC KEY pem block type")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse public key from block bytes: %s", err)
+	}
+
+	// all good! we just need the URI of the key owner to return
+	pkOwnerProp := requestingPublicKey.GetW3IDSecurityV1Owner()
+	if pkOwnerProp == nil || !pkOwnerProp.IsIRI() {
+		return nil, nil, errors.New("publicKeyOwner property is not provided or it is not embedded as a value")
+	}
+
+	return publicKey, pkOwnerProp.GetIRI(), nil
+}
+
+// verifySignature returns true if the signature of the request can be verified with the given public key,
+// using any of the algorithms that we support.
+func verifySignature(l *logrus.Entry, verifier httpsig.Verifier, publicKey interface{}, pkOwnerURI *url.URL) bool {
 	algos := []httpsig.Algorithm{
 		httpsig.RSA_SHA512,
 		httpsig.RSA_SHA256,
@@ -235,15 +322,20 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	}
 
 	for _, algo := range algos {
-		l.Tracef("trying algo: %s", algo)
+		l.WithField("algo", algo).Trace("trying algo")
 		err := verifier.Verify(publicKey, algo)
 		if err == nil {
-			l.Tracef("authentication for %s PASSED with algorithm %s", pkOwnerURI, algo)
-			return pkOwnerURI, true, nil
+			l.WithFields(logrus.Fields{
+				"pkOwnerURI": pkOwnerURI,
+				"algo":       algo,
+			}).Trace("authentication PASSED")
+			return true
 		}
-		l.Tracef("authentication for %s NOT PASSED with algorithm %s: %s", pkOwnerURI, algo, err)
+		l.WithError(err).WithFields(logrus.Fields{
+			"pkOwnerURI": pkOwnerURI,
+			"algo":       algo,
+		}).Trace("authentication NOT PASSED")
 	}
 
-	l.Infof("authentication not passed for public key owner %s; signature value was '%s'", pkOwnerURI, signature)
-	return nil, false, nil
+	return false
 }
@@ -46,6 +46,10 @@ func (f *federator) DereferenceRemoteThread(ctx context.Context, username string
 	return f.dereferencer.DereferenceThread(ctx, username, statusIRI)
 }
 
+func (f *federator) BackfillRemoteThread(ctx context.Context, username string, statusIRI *url.URL) error {
+	return f.dereferencer.BackfillThread(ctx, username, statusIRI)
+}
+
 func (f *federator) GetRemoteInstance(ctx context.Context, username string, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error) {
 	return f.dereferencer.GetRemoteInstance(ctx, username, remoteInstanceURI)
 }
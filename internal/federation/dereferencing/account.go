@@ -30,6 +30,8 @@ import (
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
@@ -59,7 +61,7 @@ func (d *deref) EnrichRemoteAccount(ctx context.Context, username string, accoun
 
 	updated, err := d.db.UpdateAccount(ctx, account)
 	if err != nil {
-		d.log.Errorf("EnrichRemoteAccount: error updating account: %s", err)
+		d.log.WithContext(ctx).WithError(err).WithField("func", "EnrichRemoteAccount").Error("error updating account")
 		return account, nil
 	}
 
@@ -100,6 +102,12 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 		return nil, new, fmt.Errorf("FullyDereferenceAccount: error converting accountable to account: %s", err)
 	}
 
+	// the account might live on a subdomain while using an apex domain for its handle, so check
+	// what the remote server says the account domain is before we store anything
+	if !instanceAccount(gtsAccount) {
+		gtsAccount.Domain = d.fingerAccountDomain(ctx, username, gtsAccount)
+	}
+
 	if new {
 		// generate a new id since we haven't seen this account before, and do a put
 		ulid, err := id.NewRandomULID()
@@ -113,7 +121,16 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 		}
 
 		if err := d.db.Put(ctx, gtsAccount); err != nil {
-			return nil, new, fmt.Errorf("FullyDereferenceAccount: error putting new account: %s", err)
+			if err != db.ErrAlreadyExists {
+				return nil, new, fmt.Errorf("FullyDereferenceAccount: error putting new account: %s", err)
+			}
+
+			// another dereference of the same account beat us to it, so just use theirs
+			existingAccount, err := d.db.GetAccountByURI(ctx, remoteAccountID.String())
+			if err != nil {
+				return nil, new, fmt.Errorf("FullyDereferenceAccount: error getting existing account: %s", err)
+			}
+			return existingAccount, false, nil
 		}
 	} else {
 		// take the id we already have and do an update
@@ -129,6 +146,14 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 		}
 	}
 
+	// now that the account is stored we can get its pinned statuses, which will refer back to it
+	if gtsAccount.FeaturedCollectionURI != "" {
+		if err := d.dereferenceFeatured(ctx, username, gtsAccount); err != nil {
+			// if this doesn't work, just skip it -- we can do it next time the account is refreshed
+			d.log.WithContext(ctx).WithError(err).WithField("func", "GetRemoteAccount").Debug("error dereferencing featured collection")
+		}
+	}
+
 	return gtsAccount, new, nil
 }
 
@@ -149,7 +174,7 @@ func (d *deref) dereferenceAccountable(ctx context.Context, username string, rem
 		return nil, fmt.Errorf("DereferenceAccountable: transport err: %s", err)
 	}
 
-	b, err := transport.Dereference(context.Background(), remoteAccountID)
+	b, err := d.dereferenceShared(ctx, username, transport, remoteAccountID)
 	if err != nil {
 		return nil, fmt.Errorf("DereferenceAccountable: error deferencing %s: %s", remoteAccountID.String(), err)
 	}
@@ -203,7 +228,7 @@ func (d *deref) dereferenceAccountable(ctx context.Context, username string, rem
 // PopulateAccountFields populates any fields on the given account that weren't populated by the initial
 // dereferencing. This includes things like header and avatar etc.
 func (d *deref) PopulateAccountFields(ctx context.Context, account *gtsmodel.Account, requestingUsername string, refresh bool) error {
-	l := d.log.WithFields(logrus.Fields{
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":               "PopulateAccountFields",
 		"requestingUsername": requestingUsername,
 	})
@@ -224,9 +249,46 @@ func (d *deref) PopulateAccountFields(ctx context.Context, account *gtsmodel.Acc
 	// fetch the header and avatar
 	if err := d.fetchHeaderAndAviForAccount(ctx, account, t, refresh); err != nil {
 		// if this doesn't work, just skip it -- we can do it later
-		l.Debugf("error fetching header/avi for account: %s", err)
+		l.WithError(err).Debug("error fetching header/avi for account")
+	}
+
+	// fetch any emojis used in the display name or note
+	if err := d.fetchEmojisForAccount(ctx, account, t); err != nil {
+		l.WithError(err).Debug("error fetching emojis for account")
+	}
+
+	return nil
+}
+
+// fetchEmojisForAccount fetches the custom emojis used by a remote account, using a transport on behalf of
+// requestingUsername. This only does anything if the account has just been converted from its AS representation,
+// since that's the only time Emojis will be set on it without EmojiIDs.
+//
+// targetAccount's EmojiIDs will be updated as necessary.
+//
+// SIDE EFFECTS: remote emojis will be stored in local storage.
+func (d *deref) fetchEmojisForAccount(ctx context.Context, targetAccount *gtsmodel.Account, t transport.Transport) error {
+	if targetAccount.Emojis == nil {
+		return nil
 	}
 
+	accountURI, err := url.Parse(targetAccount.URI)
+	if err != nil {
+		return fmt.Errorf("fetchEmojisForAccount: couldn't parse account URI %s: %s", targetAccount.URI, err)
+	}
+
+	policy, err := d.db.GetDomainPolicy(ctx, accountURI.Hostname())
+	if err != nil {
+		return fmt.Errorf("fetchEmojisForAccount: error getting domain policy for %s: %s", accountURI.Host, err)
+	}
+	if policy.RejectMedia {
+		// we don't take media from this domain, so just leave the account without emojis
+		targetAccount.Emojis = []*gtsmodel.Emoji{}
+	} else {
+		targetAccount.Emojis = d.populateEmojis(ctx, targetAccount.Emojis, accountURI.Host, t)
+	}
+	targetAccount.EmojiIDs = emojiIDs(targetAccount.Emojis)
+
 	return nil
 }
 
@@ -245,6 +307,15 @@ func (d *deref) fetchHeaderAndAviForAccount(ctx context.Context, targetAccount *
 		return fmt.Errorf("fetchHeaderAndAviForAccount: domain %s is blocked", accountURI.Host)
 	}
 
+	policy, err := d.db.GetDomainPolicy(ctx, accountURI.Hostname())
+	if err != nil {
+		return fmt.Errorf("fetchHeaderAndAviForAccount: error getting domain policy for %s: %s", accountURI.Host, err)
+	}
+	if policy.RejectMedia {
+		// we don't take media from this domain, so just leave the account without header and avatar
+		return nil
+	}
+
 	if targetAccount.AvatarRemoteURL != "" && (targetAccount.AvatarMediaAttachmentID == "" || refresh) {
 		a, err := d.mediaHandler.ProcessRemoteHeaderOrAvatar(ctx, t, &gtsmodel.MediaAttachment{
 			RemoteURL: targetAccount.AvatarRemoteURL,
@@ -268,3 +339,74 @@ func (d *deref) fetchHeaderAndAviForAccount(ctx context.Context, targetAccount *
 	}
 	return nil
 }
+
+// fingerAccountDomain works out the account domain of the given remote account, which will be the host of
+// its URI unless a webfinger lookup says otherwise.
+//
+// A server at eg gts.example.org can give its accounts handles like @someone@example.org. In that case a
+// webfinger request to gts.example.org will return a subject with example.org as the domain, and we only
+// believe it if a webfinger request to example.org (or wherever its host-meta points) gives back the same
+// account, so that a server can't claim handles on domains it doesn't control.
+//
+// If anything goes wrong along the way, the account's current domain is returned unchanged.
+func (d *deref) fingerAccountDomain(ctx context.Context, username string, account *gtsmodel.Account) string {
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":       "fingerAccountDomain",
+		"accountURI": account.URI,
+	})
+
+	t, err := d.transportController.NewTransportForUsername(ctx, username)
+	if err != nil {
+		l.WithError(err).Debug("error getting transport")
+		return account.Domain
+	}
+
+	subjectDomain, err := d.fingerSelf(ctx, t, account.Username, account.Domain, account.URI)
+	if err != nil {
+		l.WithError(err).Debug("error fingering account at its own host")
+		return account.Domain
+	}
+
+	if subjectDomain == "" || strings.EqualFold(subjectDomain, account.Domain) {
+		return account.Domain
+	}
+
+	if blocked, err := d.db.IsDomainBlocked(ctx, subjectDomain); blocked || err != nil {
+		l.WithField("subjectDomain", subjectDomain).Debug("account domain is blocked")
+		return account.Domain
+	}
+
+	if _, err := d.fingerSelf(ctx, t, account.Username, subjectDomain, account.URI); err != nil {
+		l.WithError(err).WithField("subjectDomain", subjectDomain).Debug("account domain does not point back to account")
+		return account.Domain
+	}
+
+	return strings.ToLower(subjectDomain)
+}
+
+// fingerSelf does a webfinger lookup for username@domain, checks that the self link in the response
+// matches the given account uri, and returns the domain part of the subject of the response.
+func (d *deref) fingerSelf(ctx context.Context, t transport.Transport, username string, domain string, accountURI string) (string, error) {
+	b, err := t.Finger(ctx, username, domain)
+	if err != nil {
+		return "", err
+	}
+
+	resp := &apimodel.WellKnownResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return "", fmt.Errorf("could not unmarshal webfinger response: %s", err)
+	}
+
+	for _, l := range resp.Links {
+		if l.Rel != "self" || l.Href != accountURI {
+			continue
+		}
+		subject := strings.TrimPrefix(resp.Subject, "acct:")
+		if i := strings.LastIndex(subject, "@"); i != -1 {
+			return subject[i+1:], nil
+		}
+		return "", nil
+	}
+
+	return "", fmt.Errorf("webfinger response for %s@%s did not point to %s", username, domain, accountURI)
+}
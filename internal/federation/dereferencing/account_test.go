@@ -24,6 +24,8 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -52,6 +54,56 @@ func (suite *AccountTestSuite) TestDereferenceGroup() {
 	suite.Equal(ap.ActorGroup, dbGroup.ActorType)
 }
 
+func (suite *AccountTestSuite) TestDereferenceAccountWithAccountDomain() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// the account lives on unknown-instance.com but its handle is on unknown.com
+	suite.testWebfingers["https://unknown-instance.com/.well-known/webfinger?resource=acct:brand_new_person@unknown-instance.com"] = `{"subject":"acct:brand_new_person@unknown.com","links":[{"rel":"self","type":"application/activity+json","href":"https://unknown-instance.com/users/brand_new_person"}]}`
+	suite.testWebfingers["https://unknown.com/.well-known/webfinger?resource=acct:brand_new_person@unknown.com"] = `{"subject":"acct:brand_new_person@unknown.com","links":[{"rel":"self","type":"application/activity+json","href":"https://unknown-instance.com/users/brand_new_person"}]}`
+
+	personURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person")
+	person, new, err := suite.dereferencer.GetRemoteAccount(context.Background(), fetchingAccount.Username, personURL, false)
+	suite.NoError(err)
+	suite.NotNil(person)
+	suite.True(new)
+	suite.Equal("unknown.com", person.Domain)
+
+	// we should be able to find the account by its handle
+	dbPerson := &gtsmodel.Account{}
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "username", Value: "brand_new_person"}, {Key: "domain", Value: "unknown.com"}}, dbPerson)
+	suite.NoError(err)
+	suite.Equal(person.ID, dbPerson.ID)
+}
+
+func (suite *AccountTestSuite) TestDereferenceAccountWithUnverifiedAccountDomain() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// the account claims a handle on unknown.com, but unknown.com doesn't know about it
+	suite.testWebfingers["https://unknown-instance.com/.well-known/webfinger?resource=acct:brand_new_person@unknown-instance.com"] = `{"subject":"acct:brand_new_person@unknown.com","links":[{"rel":"self","type":"application/activity+json","href":"https://unknown-instance.com/users/brand_new_person"}]}`
+	suite.testWebfingers["https://unknown.com/.well-known/webfinger?resource=acct:brand_new_person@unknown.com"] = `{"subject":"acct:brand_new_person@unknown.com","links":[{"rel":"self","type":"application/activity+json","href":"https://somewhere-else.com/users/brand_new_person"}]}`
+
+	personURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person")
+	person, _, err := suite.dereferencer.GetRemoteAccount(context.Background(), fetchingAccount.Username, personURL, false)
+	suite.NoError(err)
+	suite.NotNil(person)
+	suite.Equal("unknown-instance.com", person.Domain)
+}
+
+func (suite *AccountTestSuite) TestDereferenceAccountWithAccountDomainViaHostMeta() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// unknown.com doesn't serve webfinger itself, but its host-meta points to unknown-instance.com
+	suite.testWebfingers["https://unknown-instance.com/.well-known/webfinger?resource=acct:brand_new_person@unknown-instance.com"] = `{"subject":"acct:brand_new_person@unknown.com","links":[{"rel":"self","type":"application/activity+json","href":"https://unknown-instance.com/users/brand_new_person"}]}`
+	suite.testWebfingers["https://unknown.com/.well-known/host-meta"] = `<?xml version="1.0" encoding="UTF-8"?><XRD xmlns="http://docs.oasis-open.org/ns/xri/xrd-1.0"><Link rel="lrdd" type="application/jrd+json" template="https://unknown-instance.com/.well-known/webfinger?resource={uri}"></Link></XRD>`
+	suite.testWebfingers["https://unknown-instance.com/.well-known/webfinger?resource=acct%3Abrand_new_person%40unknown.com"] = `{"subject":"acct:brand_new_person@unknown.com","links":[{"rel":"self","type":"application/activity+json","href":"https://unknown-instance.com/users/brand_new_person"}]}`
+
+	personURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person")
+	person, _, err := suite.dereferencer.GetRemoteAccount(context.Background(), fetchingAccount.Username, personURL, false)
+	suite.NoError(err)
+	suite.NotNil(person)
+	suite.Equal("unknown.com", person.Domain)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -34,7 +34,7 @@ func (d *deref) GetRemoteAttachment(ctx context.Context, requestingUsername stri
 	}
 	remoteAttachmentURL := minAttachment.RemoteURL
 
-	l := d.log.WithFields(logrus.Fields{
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
 		"username":            requestingUsername,
 		"remoteAttachmentURL": remoteAttachmentURL,
 	})
@@ -50,7 +50,7 @@ func (d *deref) GetRemoteAttachment(ctx context.Context, requestingUsername stri
 
 	if err := d.db.GetWhere(ctx, where, maybeAttachment); err == nil {
 		// we already the attachment in the database
-		l.Debugf("GetRemoteAttachment: attachment already exists with id %s", maybeAttachment.ID)
+		l.WithField("attachmentID", maybeAttachment.ID).Debug("GetRemoteAttachment: attachment already exists")
 		return maybeAttachment, nil
 	}
 
@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// threadBackfillInterval is how long we wait before backfilling the same thread again, so that lots of
+// people opening the same remote status (or one person refreshing it over and over) doesn't result in a
+// storm of requests to the remote servers involved in the thread.
+const threadBackfillInterval = 10 * time.Minute
+
+// BackfillThread dereferences the ancestors and replies of the given remote status, so that its thread context
+// is populated, fetching at most as many new statuses as the federation limits allow per backfill.
+//
+// If the thread of this status was already backfilled in the last threadBackfillInterval, or a backfill of it
+// is still in progress, nothing is done. Whether or not it succeeds, a backfill is not retried until then.
+func (d *deref) BackfillThread(ctx context.Context, username string, statusIRI *url.URL) error {
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":      "BackfillThread",
+		"username":  username,
+		"statusIRI": statusIRI.String(),
+	})
+
+	// if it's our status we already have everything stashed so we can bail early
+	if statusIRI.Host == d.config.Host {
+		return nil
+	}
+
+	key := statusIRI.String()
+	now := time.Now()
+
+	d.backfillsSync.Lock()
+
+	// lazily initialize backfills
+	if d.backfills == nil {
+		d.backfills = make(map[string]time.Time)
+	}
+
+	// clear out anything that's old enough to be backfilled again while we're here
+	for k, started := range d.backfills {
+		if now.Sub(started) > threadBackfillInterval {
+			delete(d.backfills, k)
+		}
+	}
+
+	if _, ok := d.backfills[key]; ok {
+		d.backfillsSync.Unlock()
+		l.Debug("thread was backfilled recently, bailing")
+		return nil
+	}
+	d.backfills[key] = now
+	d.backfillsSync.Unlock()
+
+	var budget *threadBudget
+	if maxStatuses := d.config.FederationLimitsConfig.BackfillStatuses; maxStatuses != 0 {
+		budget = &threadBudget{remaining: maxStatuses}
+	}
+
+	if err := d.dereferenceThread(ctx, username, statusIRI, budget); err != nil {
+		return fmt.Errorf("BackfillThread: %s", err)
+	}
+	return nil
+}
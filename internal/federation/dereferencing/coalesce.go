@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+// derefCacheTime is how long the result of a successful dereference is kept around for, so that
+// a burst of requests for the same remote IRI (eg., a popular status being boosted by lots of
+// remote accounts at once) only results in one actual request to the remote server.
+const derefCacheTime = 30 * time.Second
+
+// derefResult is an in-progress or recently completed dereference of an IRI.
+type derefResult struct {
+	done    chan struct{} // closed when the dereference is complete and b and err are set
+	b       []byte
+	err     error
+	expires time.Time // when this result should no longer be used; only set once done is closed
+}
+
+// dereferenceShared dereferences the given IRI using the given transport, which should be the transport for username.
+//
+// If a dereference of the same IRI on behalf of the same username is already in progress, this function will wait for
+// that to complete and return its result, rather than making another request. Successful results are also cached for
+// derefCacheTime. Callers must not modify the returned bytes, since they may be shared with other callers.
+func (d *deref) dereferenceShared(ctx context.Context, username string, t transport.Transport, iri *url.URL) ([]byte, error) {
+	// results are keyed by username as well as iri, since the remote might return something different depending on who's asking
+	key := username + " " + iri.String()
+
+	d.derefsSync.Lock()
+
+	// lazily initialize derefs
+	if d.derefs == nil {
+		d.derefs = make(map[string]*derefResult)
+	}
+
+	// clear out anything that's expired while we're here
+	now := time.Now()
+	for k, r := range d.derefs {
+		select {
+		case <-r.done:
+			if now.After(r.expires) {
+				delete(d.derefs, k)
+			}
+		default:
+		}
+	}
+
+	if r, ok := d.derefs[key]; ok {
+		// someone's already dereferencing this, or did so recently, so just wait for their result
+		d.derefsSync.Unlock()
+		select {
+		case <-r.done:
+			return r.b, r.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	r := &derefResult{
+		done: make(chan struct{}),
+	}
+	d.derefs[key] = r
+	d.derefsSync.Unlock()
+
+	r.b, r.err = t.Dereference(context.Background(), iri)
+
+	d.derefsSync.Lock()
+	if r.err != nil {
+		// don't cache failures, the next caller can try again
+		delete(d.derefs, key)
+	} else {
+		r.expires = time.Now().Add(derefCacheTime)
+	}
+	d.derefsSync.Unlock()
+	close(r.done)
+
+	return r.b, r.err
+}
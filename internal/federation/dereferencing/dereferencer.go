@@ -22,6 +22,7 @@ import (
 	"context"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -74,6 +75,9 @@ type Dereferencer interface {
 
 	DereferenceAnnounce(ctx context.Context, announce *gtsmodel.Status, requestingUsername string) error
 	DereferenceThread(ctx context.Context, username string, statusIRI *url.URL) error
+	// BackfillThread dereferences the ancestors and replies of the given remote status, up to the configured limit, so that
+	// its thread context is populated. It does nothing if the same thread was already backfilled recently.
+	BackfillThread(ctx context.Context, username string, statusIRI *url.URL) error
 
 	Handshaking(ctx context.Context, username string, remoteAccountID *url.URL) bool
 }
@@ -87,6 +91,10 @@ type deref struct {
 	config              *config.Config
 	handshakes          map[string][]*url.URL
 	handshakeSync       *sync.Mutex // mutex to lock/unlock when checking or updating the handshakes map
+	derefs              map[string]*derefResult
+	derefsSync          *sync.Mutex // mutex to lock/unlock when checking or updating the derefs map
+	backfills           map[string]time.Time
+	backfillsSync       *sync.Mutex // mutex to lock/unlock when checking or updating the backfills map
 }
 
 // NewDereferencer returns a Dereferencer initialized with the given parameters.
@@ -99,5 +107,7 @@ func NewDereferencer(config *config.Config, db db.DB, typeConverter typeutils.Ty
 		mediaHandler:        mediaHandler,
 		config:              config,
 		handshakeSync:       &sync.Mutex{},
+		derefsSync:          &sync.Mutex{},
+		backfillsSync:       &sync.Mutex{},
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sync"
 
 	"git.iim.gay/grufwub/go-store/kv"
 	"github.com/go-fed/activity/streams"
@@ -49,8 +50,12 @@ type DereferencerStandardTestSuite struct {
 	testRemoteGroups      map[string]vocab.ActivityStreamsGroup
 	testRemoteAttachments map[string]testrig.RemoteAttachmentFile
 	testAccounts          map[string]*gtsmodel.Account
+	testWebfingers        map[string]string // webfinger and host-meta responses, by url
 
 	dereferencer dereferencing.Dereferencer
+
+	requests   map[string]int // count of requests made to the mock transport controller, by url
+	requestsMu sync.Mutex
 }
 
 func (suite *DereferencerStandardTestSuite) SetupSuite() {
@@ -66,6 +71,8 @@ func (suite *DereferencerStandardTestSuite) SetupTest() {
 	suite.db = testrig.NewTestDB()
 	suite.log = testrig.NewTestLog()
 	suite.storage = testrig.NewTestStorage()
+	suite.requests = make(map[string]int)
+	suite.testWebfingers = make(map[string]string)
 	suite.dereferencer = dereferencing.NewDereferencer(suite.config,
 		suite.db,
 		testrig.NewTestTypeConverter(suite.db),
@@ -86,6 +93,9 @@ func (suite *DereferencerStandardTestSuite) TearDownTest() {
 func (suite *DereferencerStandardTestSuite) mockTransportController() transport.Controller {
 	do := func(req *http.Request) (*http.Response, error) {
 		suite.log.Debugf("received request for %s", req.URL)
+		suite.requestsMu.Lock()
+		suite.requests[req.URL.String()]++
+		suite.requestsMu.Unlock()
 
 		responseBytes := []byte{}
 		responseType := ""
@@ -138,6 +148,11 @@ func (suite *DereferencerStandardTestSuite) mockTransportController() transport.
 			responseType = attachment.ContentType
 		}
 
+		if webfinger, ok := suite.testWebfingers[req.URL.String()]; ok {
+			responseBytes = []byte(webfinger)
+			responseType = "application/jrd+json"
+		}
+
 		if len(responseBytes) != 0 {
 			// we found something, so print what we're going to return
 			suite.log.Debugf("returning response %s", string(responseBytes))
@@ -0,0 +1,113 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+// populateEmojis takes the minimal emojis extracted from the tags of a remote status or account, and returns
+// them fully populated. Emojis we already know about are taken from the database, and the rest are fetched using
+// the given transport, stored, and put in the database. Emojis that can't be fetched are left out.
+//
+// The domain of each emoji is set to the given domain, which should be the domain of the status or account
+// that used it, so that one instance can't overwrite the emojis of another by claiming their shortcodes.
+func (d *deref) populateEmojis(ctx context.Context, minEmojis []*gtsmodel.Emoji, domain string, t transport.Transport) []*gtsmodel.Emoji {
+	l := d.log.WithContext(ctx).WithField("func", "populateEmojis")
+
+	emojis := []*gtsmodel.Emoji{}
+	for _, e := range minEmojis {
+		e.Domain = domain
+		emoji, err := d.getRemoteEmoji(ctx, e, t)
+		if err != nil {
+			l.WithError(err).WithField("emojiURI", e.URI).Debug("couldn't get remote emoji")
+			continue
+		}
+		if emoji.Disabled {
+			continue
+		}
+		emojis = append(emojis, emoji)
+	}
+
+	return emojis
+}
+
+func (d *deref) getRemoteEmoji(ctx context.Context, minEmoji *gtsmodel.Emoji, t transport.Transport) (*gtsmodel.Emoji, error) {
+	// return early if we already have the emoji from an earlier status or account
+	where := []db.Where{
+		{
+			Key:   "shortcode",
+			Value: minEmoji.Shortcode,
+		},
+		{
+			Key:   "domain",
+			Value: minEmoji.Domain,
+		},
+	}
+
+	maybeEmoji := &gtsmodel.Emoji{}
+	if err := d.db.GetWhere(ctx, where, maybeEmoji); err == nil {
+		return maybeEmoji, nil
+	} else if err != db.ErrNoEntries {
+		return nil, fmt.Errorf("getRemoteEmoji: error checking database for emoji: %s", err)
+	}
+
+	imageURL, err := url.Parse(minEmoji.ImageRemoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("getRemoteEmoji: couldn't parse emoji image url %s: %s", minEmoji.ImageRemoteURL, err)
+	}
+
+	emojiBytes, err := t.DereferenceMedia(ctx, imageURL, "image/*")
+	if err != nil {
+		return nil, fmt.Errorf("getRemoteEmoji: error dereferencing emoji image: %s", err)
+	}
+
+	emoji, err := d.mediaHandler.ProcessRemoteEmoji(ctx, emojiBytes, minEmoji)
+	if err != nil {
+		return nil, fmt.Errorf("getRemoteEmoji: error processing emoji: %s", err)
+	}
+
+	if err := d.db.Put(ctx, emoji); err != nil {
+		if err != db.ErrAlreadyExists {
+			return nil, fmt.Errorf("getRemoteEmoji: error inserting emoji: %s", err)
+		}
+		// something else put the same emoji in while we were fetching it, so use that one instead
+		if err := d.db.GetWhere(ctx, where, maybeEmoji); err != nil {
+			return nil, fmt.Errorf("getRemoteEmoji: error getting emoji: %s", err)
+		}
+		return maybeEmoji, nil
+	}
+
+	return emoji, nil
+}
+
+// emojiIDs returns the ids of the given emojis.
+func emojiIDs(emojis []*gtsmodel.Emoji) []string {
+	ids := make([]string, 0, len(emojis))
+	for _, e := range emojis {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
@@ -0,0 +1,170 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// dereferenceFeatured fetches the featured (pinned) statuses collection of the given remote account,
+// dereferences each status in it, and marks them as pinned. Statuses of the account that were previously
+// pinned, but which are no longer in the collection, will be unpinned.
+//
+// SIDE EFFECTS: remote statuses will be stored in the database, and the pinned field of statuses may be updated.
+func (d *deref) dereferenceFeatured(ctx context.Context, username string, account *gtsmodel.Account) error {
+	featuredURI, err := url.Parse(account.FeaturedCollectionURI)
+	if err != nil {
+		return fmt.Errorf("dereferenceFeatured: couldn't parse featured collection URI %s: %s", account.FeaturedCollectionURI, err)
+	}
+
+	if blocked, err := d.db.IsDomainBlocked(ctx, featuredURI.Host); blocked || err != nil {
+		return fmt.Errorf("dereferenceFeatured: domain %s is blocked", featuredURI.Host)
+	}
+
+	transport, err := d.transportController.NewTransportForUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("dereferenceFeatured: error creating transport: %s", err)
+	}
+
+	b, err := transport.Dereference(ctx, featuredURI)
+	if err != nil {
+		return fmt.Errorf("dereferenceFeatured: error deferencing %s: %s", featuredURI.String(), err)
+	}
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("dereferenceFeatured: error unmarshalling bytes into json: %s", err)
+	}
+
+	t, err := streams.ToType(ctx, m)
+	if err != nil {
+		return fmt.Errorf("dereferenceFeatured: error resolving json into ap vocab type: %s", err)
+	}
+
+	// the collection might be ordered or not, and the items might be embedded or just IRIs
+	itemIRIs := []*url.URL{}
+	switch t.GetTypeName() {
+	case ap.ObjectOrderedCollection:
+		collection, ok := t.(vocab.ActivityStreamsOrderedCollection)
+		if !ok {
+			return fmt.Errorf("dereferenceFeatured: error resolving type as activitystreams ordered collection")
+		}
+		if items := collection.GetActivityStreamsOrderedItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				if itemIRI := featuredItemIRI(iter); itemIRI != nil {
+					itemIRIs = append(itemIRIs, itemIRI)
+				}
+			}
+		}
+	case ap.ObjectCollection:
+		collection, ok := t.(vocab.ActivityStreamsCollection)
+		if !ok {
+			return fmt.Errorf("dereferenceFeatured: error resolving type as activitystreams collection")
+		}
+		if items := collection.GetActivityStreamsItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				if itemIRI := featuredItemIRI(iter); itemIRI != nil {
+					itemIRIs = append(itemIRIs, itemIRI)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("dereferenceFeatured: type name %s not supported", t.GetTypeName())
+	}
+
+	if maxItems := d.config.FederationLimitsConfig.CollectionItems; maxItems != 0 && len(itemIRIs) > maxItems {
+		itemIRIs = itemIRIs[:maxItems]
+	}
+
+	// dereference and pin each status in the collection, as long as it really belongs to this account
+	pinnedIDs := make(map[string]bool, len(itemIRIs))
+	for _, itemIRI := range itemIRIs {
+		status, _, _, err := d.GetRemoteStatus(ctx, username, itemIRI, false, false)
+		if err != nil {
+			d.log.WithContext(ctx).WithError(err).WithField("func", "dereferenceFeatured").Debugf("error getting featured status %s", itemIRI.String())
+			continue
+		}
+
+		if status.AccountID != account.ID {
+			continue
+		}
+		pinnedIDs[status.ID] = true
+
+		if status.Pinned {
+			continue
+		}
+		status.Pinned = true
+		if err := d.db.UpdateByPrimaryKey(ctx, status); err != nil {
+			return fmt.Errorf("dereferenceFeatured: error pinning status %s: %s", status.ID, err)
+		}
+	}
+
+	// unpin anything that's not in the collection anymore
+	previouslyPinned, err := d.db.GetAccountStatuses(ctx, account.ID, 0, false, false, "", "", "", true, false, "")
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("dereferenceFeatured: error getting pinned statuses of account %s: %s", account.ID, err)
+	}
+
+	for _, status := range previouslyPinned {
+		if pinnedIDs[status.ID] {
+			continue
+		}
+		status.Pinned = false
+		if err := d.db.UpdateByPrimaryKey(ctx, status); err != nil {
+			return fmt.Errorf("dereferenceFeatured: error unpinning status %s: %s", status.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// featuredItem is satisfied by the iterators of both the items and orderedItems properties.
+type featuredItem interface {
+	IsIRI() bool
+	GetIRI() *url.URL
+	GetType() vocab.Type
+}
+
+// featuredItemIRI returns the IRI of the given collection item, whether it's embedded or not.
+func featuredItemIRI(item featuredItem) *url.URL {
+	if item.IsIRI() {
+		return item.GetIRI()
+	}
+
+	t := item.GetType()
+	if t == nil {
+		return nil
+	}
+
+	idProp := t.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		return nil
+	}
+
+	return idProp.GetIRI()
+}
@@ -30,8 +30,11 @@ import (
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // EnrichRemoteStatus takes a status that's already been inserted into the database in a minimal form,
@@ -44,7 +47,7 @@ func (d *deref) EnrichRemoteStatus(ctx context.Context, username string, status
 		return nil, err
 	}
 
-	if err := d.db.UpdateByPrimaryKey(ctx, status); err != nil {
+	if err := d.db.UpdateStatus(ctx, status); err != nil {
 		return nil, fmt.Errorf("EnrichRemoteStatus: error updating status: %s", err)
 	}
 
@@ -75,6 +78,15 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 		if !refresh {
 			return maybeStatus, nil, new, nil
 		}
+	} else {
+		// we don't have the status, but maybe we had it once and it was deleted, in which case leave it be
+		tombstoned, err := d.db.TombstoneExistsWithURI(ctx, remoteStatusID.String())
+		if err != nil {
+			return nil, nil, new, fmt.Errorf("GetRemoteStatus: error checking for tombstone: %s", err)
+		}
+		if tombstoned {
+			return nil, nil, new, fmt.Errorf("GetRemoteStatus: status %s has been deleted", remoteStatusID.String())
+		}
 	}
 
 	statusable, err := d.dereferenceStatusable(ctx, username, remoteStatusID)
@@ -110,7 +122,16 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 		}
 
 		if err := d.db.PutStatus(ctx, gtsStatus); err != nil {
-			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error putting new status: %s", err)
+			if err != db.ErrAlreadyExists {
+				return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error putting new status: %s", err)
+			}
+
+			// another dereference of the same status beat us to it, so just use theirs
+			existingStatus, err := d.db.GetStatusByURI(ctx, remoteStatusID.String())
+			if err != nil {
+				return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error getting existing status: %s", err)
+			}
+			return existingStatus, statusable, false, nil
 		}
 	} else {
 		gtsStatus.ID = maybeStatus.ID
@@ -119,7 +140,7 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error populating status fields: %s", err)
 		}
 
-		if err := d.db.UpdateByPrimaryKey(ctx, gtsStatus); err != nil {
+		if err := d.db.UpdateStatus(ctx, gtsStatus); err != nil {
 			return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error updating status: %s", err)
 		}
 	}
@@ -137,7 +158,7 @@ func (d *deref) dereferenceStatusable(ctx context.Context, username string, remo
 		return nil, fmt.Errorf("DereferenceStatusable: transport err: %s", err)
 	}
 
-	b, err := transport.Dereference(context.Background(), remoteStatusID)
+	b, err := d.dereferenceShared(ctx, username, transport, remoteStatusID)
 	if err != nil {
 		return nil, fmt.Errorf("DereferenceStatusable: error deferencing %s: %s", remoteStatusID.String(), err)
 	}
@@ -236,7 +257,7 @@ func (d *deref) dereferenceStatusable(ctx context.Context, username string, remo
 // and attach them to the status. The status itself will not be added to the database yet,
 // that's up the caller to do.
 func (d *deref) populateStatusFields(ctx context.Context, status *gtsmodel.Status, requestingUsername string, includeParent bool) error {
-	l := d.log.WithFields(logrus.Fields{
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "dereferenceStatusFields",
 		"status": fmt.Sprintf("%+v", status),
 	})
@@ -264,16 +285,36 @@ func (d *deref) populateStatusFields(ctx context.Context, status *gtsmodel.Statu
 		status.ID = newID
 	}
 
+	policy, err := d.db.GetDomainPolicy(ctx, statusIRI.Hostname())
+	if err != nil {
+		return fmt.Errorf("populateStatusFields: error getting domain policy for %s: %s", statusIRI.Host, err)
+	}
+
 	// 1. Media attachments.
-	if err := d.populateStatusAttachments(ctx, status, requestingUsername); err != nil {
+	if policy.RejectMedia {
+		// we don't take media from this domain, so drop the attachments without fetching them
+		status.AttachmentIDs = []string{}
+		status.Attachments = []*gtsmodel.MediaAttachment{}
+	} else if err := d.populateStatusAttachments(ctx, status, requestingUsername); err != nil {
 		return fmt.Errorf("populateStatusFields: error populating status attachments: %s", err)
 	}
+	if policy.SensitiveMedia && len(status.Attachments) != 0 {
+		status.Sensitive = true
+	}
 
 	// 2. Hashtags
-	// TODO
+	if err := d.populateStatusTags(ctx, status); err != nil {
+		return fmt.Errorf("populateStatusFields: error populating status tags: %s", err)
+	}
 
 	// 3. Emojis
-	// TODO
+	if policy.RejectMedia {
+		// emojis are media too, so drop them without fetching the images
+		status.EmojiIDs = []string{}
+		status.Emojis = []*gtsmodel.Emoji{}
+	} else if err := d.populateStatusEmojis(ctx, status, statusIRI.Host, requestingUsername); err != nil {
+		return fmt.Errorf("populateStatusFields: error populating status emojis: %s", err)
+	}
 
 	// 4. Mentions
 	// TODO: do we need to handle removing empty mention objects and just using mention IDs slice?
@@ -315,7 +356,7 @@ func (d *deref) populateStatusMentions(ctx context.Context, status *gtsmodel.Sta
 
 		targetAccountURI, err := url.Parse(m.TargetAccountURI)
 		if err != nil {
-			l.Debugf("populateStatusMentions: error parsing mentioned account uri %s: %s", m.TargetAccountURI, err)
+			l.WithError(err).WithField("targetAccountURI", m.TargetAccountURI).Debug("populateStatusMentions: error parsing mentioned account uri")
 			continue
 		}
 
@@ -326,7 +367,10 @@ func (d *deref) populateStatusMentions(ctx context.Context, status *gtsmodel.Sta
 		if a, err := d.db.GetAccountByURI(ctx, targetAccountURI.String()); err != nil {
 			errs = append(errs, err.Error())
 		} else {
-			l.Debugf("populateStatusMentions: got target account %s with id %s through GetAccountByURI", targetAccountURI, a.ID)
+			l.WithFields(logrus.Fields{
+				"targetAccountURI": targetAccountURI,
+				"accountID":        a.ID,
+			}).Debug("populateStatusMentions: got target account through GetAccountByURI")
 			targetAccount = a
 		}
 
@@ -336,13 +380,19 @@ func (d *deref) populateStatusMentions(ctx context.Context, status *gtsmodel.Sta
 			if a, _, err := d.GetRemoteAccount(ctx, requestingUsername, targetAccountURI, false); err != nil {
 				errs = append(errs, err.Error())
 			} else {
-				l.Debugf("populateStatusMentions: got target account %s with id %s through GetRemoteAccount", targetAccountURI, a.ID)
+				l.WithFields(logrus.Fields{
+					"targetAccountURI": targetAccountURI,
+					"accountID":        a.ID,
+				}).Debug("populateStatusMentions: got target account through GetRemoteAccount")
 				targetAccount = a
 			}
 		}
 
 		if targetAccount == nil {
-			l.Debugf("populateStatusMentions: couldn't get target account %s: %s", m.TargetAccountURI, strings.Join(errs, " : "))
+			l.WithFields(logrus.Fields{
+				"targetAccountURI": m.TargetAccountURI,
+				"errors":           strings.Join(errs, " : "),
+			}).Debug("populateStatusMentions: couldn't get target account")
 			continue
 		}
 
@@ -399,7 +449,7 @@ func (d *deref) populateStatusAttachments(ctx context.Context, status *gtsmodel.
 
 		attachment, err := d.GetRemoteAttachment(ctx, requestingUsername, a)
 		if err != nil {
-			l.Errorf("populateStatusAttachments: couldn't get remote attachment %s: %s", a.RemoteURL, err)
+			l.WithError(err).WithField("remoteURL", a.RemoteURL).Error("populateStatusAttachments: couldn't get remote attachment")
 			continue
 		}
 
@@ -413,6 +463,53 @@ func (d *deref) populateStatusAttachments(ctx context.Context, status *gtsmodel.
 	return nil
 }
 
+func (d *deref) populateStatusTags(ctx context.Context, status *gtsmodel.Status) error {
+	// At this point, tags should have the name set on them, which is all we need: a hashtag
+	// is the same hashtag no matter which instance it was used on, so we use our own tags.
+	names := []string{}
+	for _, t := range status.Tags {
+		if !regexes.HashtagName.MatchString(t.Name) {
+			continue
+		}
+		names = append(names, strings.ToLower(t.Name))
+	}
+
+	tags, err := d.db.TagStringsToTags(ctx, util.UniqueStrings(names), status.AccountID)
+	if err != nil {
+		return fmt.Errorf("populateStatusTags: error getting tags: %s", err)
+	}
+
+	tagIDs := []string{}
+	for _, t := range tags {
+		if err := d.db.Put(ctx, t); err != nil && err != db.ErrAlreadyExists {
+			return fmt.Errorf("populateStatusTags: error putting tag %s: %s", t.Name, err)
+		}
+		tagIDs = append(tagIDs, t.ID)
+	}
+
+	status.Tags = tags
+	status.TagIDs = tagIDs
+
+	return nil
+}
+
+func (d *deref) populateStatusEmojis(ctx context.Context, status *gtsmodel.Status, domain string, requestingUsername string) error {
+	if len(status.Emojis) == 0 {
+		status.EmojiIDs = []string{}
+		return nil
+	}
+
+	t, err := d.transportController.NewTransportForUsername(ctx, requestingUsername)
+	if err != nil {
+		return fmt.Errorf("populateStatusEmojis: error creating transport: %s", err)
+	}
+
+	status.Emojis = d.populateEmojis(ctx, status.Emojis, domain, t)
+	status.EmojiIDs = emojiIDs(status.Emojis)
+
+	return nil
+}
+
 func (d *deref) populateStatusRepliedTo(ctx context.Context, status *gtsmodel.Status, requestingUsername string) error {
 	if status.InReplyToURI != "" && status.InReplyToID == "" {
 		statusURI, err := url.Parse(status.InReplyToURI)
@@ -20,6 +20,8 @@ package dereferencing_test
 
 import (
 	"context"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -131,6 +133,184 @@ func (suite *StatusTestSuite) TestDereferenceStatusWithMention() {
 	suite.False(m.Silent)
 }
 
+func (suite *StatusTestSuite) remoteStatusWithAttachment() *gtsmodel.Status {
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	return &gtsmodel.Status{
+		URI:        "http://fossbros-anonymous.io/users/foss_satan/statuses/01FQNB6B3Y0P6FD0EC8T5AQE9V",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		AccountID:  remoteAccount.ID,
+		AccountURI: remoteAccount.URI,
+		Account:    remoteAccount,
+		Attachments: []*gtsmodel.MediaAttachment{
+			{
+				RemoteURL: "https://s3-us-west-2.amazonaws.com/plushcity/media_attachments/files/106/867/380/219/163/828/original/88e8758c5f011439.jpg",
+				File: gtsmodel.File{
+					ContentType: "image/jpeg",
+				},
+			},
+		},
+		Visibility: gtsmodel.VisibilityPublic,
+	}
+}
+
+func (suite *StatusTestSuite) TestEnrichStatusSensitiveMediaPolicy() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.DomainPolicy{
+		ID:                 "01FQNB3J9YQ8VZ7V4A2DN5XW7C",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		SensitiveMedia:     true,
+	}))
+
+	status, err := suite.dereferencer.EnrichRemoteStatus(context.Background(), fetchingAccount.Username, suite.remoteStatusWithAttachment(), false)
+	suite.NoError(err)
+	suite.Len(status.AttachmentIDs, 1)
+	suite.True(status.Sensitive)
+}
+
+func (suite *StatusTestSuite) TestEnrichStatusRejectMediaPolicy() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.DomainPolicy{
+		ID:                 "01FQNB3J9YQ8VZ7V4A2DN5XW7C",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		RejectMedia:        true,
+		SensitiveMedia:     true,
+	}))
+
+	status, err := suite.dereferencer.EnrichRemoteStatus(context.Background(), fetchingAccount.Username, suite.remoteStatusWithAttachment(), false)
+	suite.NoError(err)
+	suite.Empty(status.AttachmentIDs)
+	suite.Empty(status.Attachments)
+	suite.False(status.Sensitive)
+
+	// the attachment shouldn't have been fetched at all
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "remote_url", Value: "https://s3-us-west-2.amazonaws.com/plushcity/media_attachments/files/106/867/380/219/163/828/original/88e8758c5f011439.jpg"}}, &gtsmodel.MediaAttachment{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *StatusTestSuite) TestEnrichStatusWithEmoji() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	rainbowBytes, err := os.ReadFile("../../../testrig/media/rainbow-original.png")
+	suite.NoError(err)
+	suite.testRemoteAttachments["http://fossbros-anonymous.io/emoji/original/rainbow.png"] = testrig.RemoteAttachmentFile{
+		Data:        rainbowBytes,
+		ContentType: "image/png",
+	}
+
+	newStatus := func(uri string) *gtsmodel.Status {
+		status := suite.remoteStatusWithAttachment()
+		status.URI = uri
+		status.Attachments = nil
+		status.Emojis = []*gtsmodel.Emoji{
+			{
+				// the emoji claims to be from a different instance, but we should store it as belonging to the poster's
+				URI:            "https://unknown-instance.com/emoji/rainbow",
+				Shortcode:      "rainbow",
+				Domain:         "unknown-instance.com",
+				ImageRemoteURL: "http://fossbros-anonymous.io/emoji/original/rainbow.png",
+			},
+		}
+		return status
+	}
+
+	status, err := suite.dereferencer.EnrichRemoteStatus(context.Background(), fetchingAccount.Username, newStatus("http://fossbros-anonymous.io/users/foss_satan/statuses/01FR0F2H8R6CZ2X9GZ6X8W4Q1B"), false)
+	suite.NoError(err)
+	suite.Len(status.EmojiIDs, 1)
+	suite.Len(status.Emojis, 1)
+
+	emoji := status.Emojis[0]
+	suite.Equal(status.EmojiIDs[0], emoji.ID)
+	suite.Equal("rainbow", emoji.Shortcode)
+	suite.Equal("fossbros-anonymous.io", emoji.Domain)
+	suite.Equal("http://fossbros-anonymous.io/emoji/original/rainbow.png", emoji.ImageRemoteURL)
+	suite.NotEmpty(emoji.ImageURL)
+	suite.False(emoji.VisibleInPicker)
+
+	// a second status using the same emoji should get the one we already have
+	status, err = suite.dereferencer.EnrichRemoteStatus(context.Background(), fetchingAccount.Username, newStatus("http://fossbros-anonymous.io/users/foss_satan/statuses/01FR0F2H8R6CZ2X9GZ6X8W4Q1C"), false)
+	suite.NoError(err)
+	suite.Equal([]string{emoji.ID}, status.EmojiIDs)
+
+	suite.requestsMu.Lock()
+	defer suite.requestsMu.Unlock()
+	suite.Equal(1, suite.requests["http://fossbros-anonymous.io/emoji/original/rainbow.png"])
+}
+
+func (suite *StatusTestSuite) TestEnrichStatusWithHashtags() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	status := suite.remoteStatusWithAttachment()
+	status.Attachments = nil
+	status.Tags = []*gtsmodel.Tag{
+		{Name: "Welcome", URL: "http://fossbros-anonymous.io/tags/welcome"},
+		{Name: "gnu", URL: "http://fossbros-anonymous.io/tags/gnu"},
+		{Name: "not a real hashtag", URL: "http://fossbros-anonymous.io/tags/not"},
+	}
+
+	status, err := suite.dereferencer.EnrichRemoteStatus(context.Background(), fetchingAccount.Username, status, false)
+	suite.NoError(err)
+	suite.Len(status.TagIDs, 2)
+
+	// the hashtag we already know about should be reused, and the new one should be one of ours too
+	suite.Equal(testrig.NewTestTags()["welcome"].ID, status.TagIDs[0])
+	newTag := &gtsmodel.Tag{}
+	suite.NoError(suite.db.GetByID(context.Background(), status.TagIDs[1], newTag))
+	suite.Equal("gnu", newTag.Name)
+	suite.Equal("http://localhost:8080/tags/gnu", newTag.URL)
+}
+
+func (suite *StatusTestSuite) TestDereferenceTombstonedStatus() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+
+	// the status was deleted at some point before we tried to fetch it
+	err := suite.db.Put(context.Background(), &gtsmodel.Tombstone{
+		ID:     "01FN3VJGFH10KR7S2PB0GFJZYG",
+		Domain: statusURL.Host,
+		URI:    statusURL.String(),
+	})
+	suite.NoError(err)
+
+	status, _, _, err := suite.dereferencer.GetRemoteStatus(context.Background(), fetchingAccount.Username, statusURL, false, false)
+	suite.Error(err)
+	suite.Nil(status)
+
+	// status should not be in the database
+	_, err = suite.db.GetStatusByURI(context.Background(), statusURL.String())
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *StatusTestSuite) TestDereferenceStatusSharesRequests() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+
+	// fetch the same status a few times, concurrently, as though lots of remotes boosted it at once
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _, err := suite.dereferencer.GetRemoteStatus(context.Background(), fetchingAccount.Username, statusURL, true, false)
+			suite.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	// and once more after all that, to make sure the result was cached
+	status, _, _, err := suite.dereferencer.GetRemoteStatus(context.Background(), fetchingAccount.Username, statusURL, true, false)
+	suite.NoError(err)
+	suite.Equal("Hello world!", status.Content)
+
+	// the remote should only have been asked for the status once
+	suite.requestsMu.Lock()
+	defer suite.requestsMu.Unlock()
+	suite.Equal(1, suite.requests[statusURL.String()])
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
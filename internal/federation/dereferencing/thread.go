@@ -35,47 +35,81 @@ import (
 // presented by remote instances as part of their replies collections, and will likely involve making several calls to
 // multiple different hosts.
 func (d *deref) DereferenceThread(ctx context.Context, username string, statusIRI *url.URL) error {
-	l := d.log.WithFields(logrus.Fields{
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "DereferenceThread",
 		"username":  username,
 		"statusIRI": statusIRI.String(),
 	})
 	l.Debug("entering DereferenceThread")
 
+	if err := d.dereferenceThread(ctx, username, statusIRI, nil); err != nil {
+		return fmt.Errorf("DereferenceThread: %s", err)
+	}
+	return nil
+}
+
+// dereferenceThread does the work of DereferenceThread, but stops fetching statuses once the given budget has been spent.
+// If budget is nil, as many statuses as the federation limits allow will be fetched.
+func (d *deref) dereferenceThread(ctx context.Context, username string, statusIRI *url.URL, budget *threadBudget) error {
 	// if it's our status we already have everything stashed so we can bail early
 	if statusIRI.Host == d.config.Host {
-		l.Debug("iri belongs to us, bailing")
 		return nil
 	}
 
 	// first make sure we have this status in our db
 	_, statusable, _, err := d.GetRemoteStatus(ctx, username, statusIRI, true, false)
 	if err != nil {
-		return fmt.Errorf("DereferenceThread: error getting status with id %s: %s", statusIRI.String(), err)
+		return fmt.Errorf("error getting status with id %s: %s", statusIRI.String(), err)
 	}
 
 	// first iterate up through ancestors, dereferencing if necessary as we go
-	if err := d.iterateAncestors(ctx, username, *statusIRI); err != nil {
-		return fmt.Errorf("error iterating ancestors of status %s: %s", statusIRI.String(), err)
+	if inReplyTo := ap.ExtractInReplyToURI(statusable); inReplyTo != nil && inReplyTo.String() != "" {
+		if err := d.iterateAncestors(ctx, username, *inReplyTo, 0, budget); err != nil {
+			return fmt.Errorf("error iterating ancestors of status %s: %s", statusIRI.String(), err)
+		}
 	}
 
 	// now iterate down through descendants, again dereferencing as we go
-	if err := d.iterateDescendants(ctx, username, *statusIRI, statusable); err != nil {
+	if err := d.iterateDescendants(ctx, username, *statusIRI, statusable, 0, budget); err != nil {
 		return fmt.Errorf("error iterating descendants of status %s: %s", statusIRI.String(), err)
 	}
 
 	return nil
 }
 
+// threadBudget counts down how many more remote statuses a thread dereference may fetch.
+type threadBudget struct {
+	remaining int
+}
+
+// spend uses up one status of the budget, returning false if there's nothing left to spend.
+// A nil budget is never used up.
+func (b *threadBudget) spend() bool {
+	if b == nil {
+		return true
+	}
+	if b.remaining <= 0 {
+		return false
+	}
+	b.remaining = b.remaining - 1
+	return true
+}
+
 // iterateAncestors has the goal of reaching the oldest ancestor of a given status, and stashing all statuses along the way.
-func (d *deref) iterateAncestors(ctx context.Context, username string, statusIRI url.URL) error {
-	l := d.log.WithFields(logrus.Fields{
+// Depth is how many ancestors we've already walked up through, and we stop once it reaches the configured thread depth limit.
+func (d *deref) iterateAncestors(ctx context.Context, username string, statusIRI url.URL, depth int, budget *threadBudget) error {
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "iterateAncestors",
 		"username":  username,
 		"statusIRI": statusIRI.String(),
 	})
 	l.Debug("entering iterateAncestors")
 
+	if maxDepth := d.config.FederationLimitsConfig.ThreadDepth; maxDepth != 0 && depth >= maxDepth {
+		l.Debug("reached max thread depth, bailing")
+		return nil
+	}
+
 	// if it's our status we don't need to dereference anything so we can immediately move up the chain
 	if statusIRI.Host == d.config.Host {
 		l.Debug("iri belongs to us, moving up to next ancestor")
@@ -95,18 +129,22 @@ func (d *deref) iterateAncestors(ctx context.Context, username string, statusIRI
 			// status doesn't reply to anything
 			return nil
 		}
-		nextIRI, err := url.Parse(status.URI)
+		nextIRI, err := url.Parse(status.InReplyToURI)
 		if err != nil {
 			return err
 		}
-		return d.iterateAncestors(ctx, username, *nextIRI)
+		return d.iterateAncestors(ctx, username, *nextIRI, depth+1, budget)
 	}
 
 	// If we reach here, we're looking at a remote status -- make sure we have it in our db by calling GetRemoteStatus
 	// We call it with refresh to true because we want the statusable representation to parse inReplyTo from.
+	if !budget.spend() {
+		l.Debug("thread budget spent, bailing")
+		return nil
+	}
 	_, statusable, _, err := d.GetRemoteStatus(ctx, username, &statusIRI, true, false)
 	if err != nil {
-		l.Debugf("error getting remote status: %s", err)
+		l.WithError(err).Debug("error getting remote status")
 		return nil
 	}
 
@@ -117,17 +155,26 @@ func (d *deref) iterateAncestors(ctx context.Context, username string, statusIRI
 	}
 
 	// now move up to the next ancestor
-	return d.iterateAncestors(ctx, username, *inReplyTo)
+	return d.iterateAncestors(ctx, username, *inReplyTo, depth+1, budget)
 }
 
-func (d *deref) iterateDescendants(ctx context.Context, username string, statusIRI url.URL, statusable ap.Statusable) error {
-	l := d.log.WithFields(logrus.Fields{
+// iterateDescendants works down through the replies collection of the given status, stashing all the replies it finds and
+// then working down through their replies in turn. Depth is how many levels of replies we've already walked down through,
+// and we stop once it reaches the configured thread depth limit.
+func (d *deref) iterateDescendants(ctx context.Context, username string, statusIRI url.URL, statusable ap.Statusable, depth int, budget *threadBudget) error {
+	l := d.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "iterateDescendants",
 		"username":  username,
 		"statusIRI": statusIRI.String(),
 	})
 	l.Debug("entering iterateDescendants")
 
+	limits := d.config.FederationLimitsConfig
+	if limits.ThreadDepth != 0 && depth >= limits.ThreadDepth {
+		l.Debug("reached max thread depth, bailing")
+		return nil
+	}
+
 	// if it's our status we already have descendants stashed so we can bail early
 	if statusIRI.Host == d.config.Host {
 		l.Debug("iri belongs to us, bailing")
@@ -165,11 +212,18 @@ func (d *deref) iterateDescendants(ctx context.Context, username string, statusI
 	}
 
 	var foundReplies int
+	var fetchedPages int
 	currentPageIRI := firstPageNext.GetIRI()
 
 pageLoop:
 	for {
-		l.Debugf("dereferencing page %s", currentPageIRI)
+		if limits.CollectionPages != 0 && fetchedPages >= limits.CollectionPages {
+			l.Debug("reached max collection pages, bailing")
+			break pageLoop
+		}
+		fetchedPages = fetchedPages + 1
+
+		l.WithField("currentPageIRI", currentPageIRI).Debug("dereferencing page")
 		nextPage, err := d.DereferenceCollectionPage(ctx, username, currentPageIRI)
 		if err != nil {
 			return nil
@@ -184,7 +238,14 @@ pageLoop:
 		}
 
 		// have a look through items and see what we can find
+		var seenItems int
 		for iter := nextItems.Begin(); iter != nextItems.End(); iter = iter.Next() {
+			if limits.CollectionItems != 0 && seenItems >= limits.CollectionItems {
+				l.Debug("reached max collection items, skipping the rest of this page")
+				break
+			}
+			seenItems = seenItems + 1
+
 			// We're looking for a url to feed to GetRemoteStatus.
 			// Items can be either an IRI, or a Note.
 			// If a note, we grab the ID from it and call it, rather than parsing the note.
@@ -213,11 +274,21 @@ pageLoop:
 			// we can confidently say now that we found something
 			foundReplies = foundReplies + 1
 
+			if _, err := d.db.GetStatusByURI(ctx, itemURI.String()); err == nil {
+				// we already have this reply, and so we've already been down through its replies too
+				continue
+			}
+
+			if !budget.spend() {
+				l.Debug("thread budget spent, bailing")
+				break pageLoop
+			}
+
 			// get the remote statusable and put it in the db
 			_, statusable, new, err := d.GetRemoteStatus(ctx, username, itemURI, false, false)
 			if new && err == nil && statusable != nil {
 				// now iterate descendants of *that* status
-				if err := d.iterateDescendants(ctx, username, *itemURI, statusable); err != nil {
+				if err := d.iterateDescendants(ctx, username, *itemURI, statusable, depth+1, budget); err != nil {
 					continue
 				}
 			}
@@ -233,6 +304,6 @@ pageLoop:
 		}
 	}
 
-	l.Debugf("foundReplies %d", foundReplies)
+	l.WithField("foundReplies", foundReplies).Debug("found replies")
 	return nil
 }
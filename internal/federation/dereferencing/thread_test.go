@@ -0,0 +1,101 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ThreadTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+// addReply adds a remote note with the given id, replying to inReplyTo, to the statuses served by the mock transport.
+func (suite *ThreadTestSuite) addReply(id string, inReplyTo string) {
+	noteJson := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + id + `",
+  "type": "Note",
+  "published": "2021-12-28T10:00:00Z",
+  "attributedTo": "https://unknown-instance.com/users/brand_new_person",
+  "to": ["https://www.w3.org/ns/activitystreams#Public"],
+  "inReplyTo": "` + inReplyTo + `",
+  "content": "replying to you"
+}`
+
+	m := make(map[string]interface{})
+	suite.NoError(json.Unmarshal([]byte(noteJson), &m))
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	note, ok := t.(vocab.ActivityStreamsNote)
+	suite.True(ok)
+	suite.testRemoteStatuses[id] = note
+}
+
+func (suite *ThreadTestSuite) TestBackfillThread() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	rootURI := "https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839"
+	reply1URI := "https://unknown-instance.com/users/brand_new_person/statuses/01FQ2Y8X0QZ3W1G7A2V8T5K9JD"
+	reply2URI := "https://unknown-instance.com/users/brand_new_person/statuses/01FQ2Y9G6J8S0M5B3R4N7C2XWE"
+	suite.addReply(reply1URI, rootURI)
+	suite.addReply(reply2URI, reply1URI)
+	defer delete(suite.testRemoteStatuses, reply1URI)
+	defer delete(suite.testRemoteStatuses, reply2URI)
+
+	// only allow one status on top of the one being opened
+	suite.config.FederationLimitsConfig.BackfillStatuses = 1
+
+	err := suite.dereferencer.BackfillThread(context.Background(), fetchingAccount.Username, testrig.URLMustParse(reply2URI))
+	suite.NoError(err)
+
+	// the opened status and its parent should be stored, but the budget ran out before the root
+	_, err = suite.db.GetStatusByURI(context.Background(), reply2URI)
+	suite.NoError(err)
+	_, err = suite.db.GetStatusByURI(context.Background(), reply1URI)
+	suite.NoError(err)
+	_, err = suite.db.GetStatusByURI(context.Background(), rootURI)
+	suite.Error(err)
+
+	// backfilling again straight away shouldn't do anything, even with a bigger budget
+	suite.config.FederationLimitsConfig.BackfillStatuses = 0
+	err = suite.dereferencer.BackfillThread(context.Background(), fetchingAccount.Username, testrig.URLMustParse(reply2URI))
+	suite.NoError(err)
+
+	_, err = suite.db.GetStatusByURI(context.Background(), rootURI)
+	suite.Error(err)
+
+	suite.requestsMu.Lock()
+	defer suite.requestsMu.Unlock()
+	suite.Equal(1, suite.requests[reply2URI])
+	suite.Zero(suite.requests[rootURI])
+}
+
+func TestThreadTestSuite(t *testing.T) {
+	suite.Run(t, new(ThreadTestSuite))
+}
@@ -0,0 +1,143 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federation
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+// deliveryFanOut works out which inboxes an activity actually needs to be POSTed to,
+// given the personal inboxes of all of its recipients.
+//
+// Recipients on the same host that share an inbox get the activity only once, at that
+// shared inbox. It's up to the receiving instance to hand it out to the right accounts,
+// based on the addressing of the activity.
+type deliveryFanOut struct {
+	db  db.DB
+	log *logrus.Logger
+}
+
+// hostDeliveries holds the inboxes that one activity will be POSTed to on a single host.
+type hostDeliveries struct {
+	// shared inboxes on this host, keyed by uri
+	sharedInboxes map[string]*url.URL
+	// personal inboxes on this host of recipients without a known shared inbox, keyed by uri
+	inboxes map[string]*url.URL
+}
+
+func newDeliveryFanOut(db db.DB, log *logrus.Logger) *deliveryFanOut {
+	return &deliveryFanOut{
+		db:  db,
+		log: log,
+	}
+}
+
+// deliveryMap builds the per-host delivery map for the given recipient inboxes.
+func (f *deliveryFanOut) deliveryMap(ctx context.Context, recipients []*url.URL) map[string]*hostDeliveries {
+	l := f.log.WithContext(ctx).WithField("func", "deliveryMap")
+
+	deliveries := make(map[string]*hostDeliveries)
+	for _, recipient := range recipients {
+		if recipient == nil {
+			continue
+		}
+
+		host := recipient.Host
+		hd, ok := deliveries[host]
+		if !ok {
+			hd = &hostDeliveries{
+				sharedInboxes: make(map[string]*url.URL),
+				inboxes:       make(map[string]*url.URL),
+			}
+			deliveries[host] = hd
+		}
+
+		if sharedInbox := f.sharedInboxFor(ctx, recipient); sharedInbox != nil {
+			hd.sharedInboxes[sharedInbox.String()] = sharedInbox
+			continue
+		}
+
+		hd.inboxes[recipient.String()] = recipient
+	}
+
+	for host, hd := range deliveries {
+		l.WithFields(logrus.Fields{
+			"host":          host,
+			"sharedInboxes": len(hd.sharedInboxes),
+			"inboxes":       len(hd.inboxes),
+		}).Trace("built host deliveries")
+	}
+
+	return deliveries
+}
+
+// Inboxes returns the deduplicated inboxes that an activity for the given recipient inboxes should be delivered to.
+func (f *deliveryFanOut) Inboxes(ctx context.Context, recipients []*url.URL) []*url.URL {
+	inboxes := []*url.URL{}
+	for _, hd := range f.deliveryMap(ctx, recipients) {
+		for _, sharedInbox := range hd.sharedInboxes {
+			inboxes = append(inboxes, sharedInbox)
+		}
+		for _, inbox := range hd.inboxes {
+			inboxes = append(inboxes, inbox)
+		}
+	}
+	return inboxes
+}
+
+// sharedInboxFor returns the shared inbox of the account with the given personal inbox,
+// or nil if we don't know of one. A shared inbox on another host than the personal inbox
+// is never used, since we can't be sure that it will deliver to the right account.
+func (f *deliveryFanOut) sharedInboxFor(ctx context.Context, inbox *url.URL) *url.URL {
+	account := &gtsmodel.Account{}
+	if err := f.db.GetWhere(ctx, []db.Where{{Key: "inbox_uri", Value: inbox.String()}}, account); err != nil {
+		if err != db.ErrNoEntries {
+			f.log.WithContext(ctx).WithError(err).WithField("inbox", inbox.String()).Error("sharedInboxFor: error getting account")
+		}
+		return nil
+	}
+
+	if account.SharedInboxURI == "" {
+		return nil
+	}
+
+	sharedInbox, err := url.Parse(account.SharedInboxURI)
+	if err != nil || sharedInbox.Host != inbox.Host {
+		return nil
+	}
+
+	return sharedInbox
+}
+
+// fanOutTransport wraps a transport so that batch deliveries go through a deliveryFanOut first.
+type fanOutTransport struct {
+	transport.Transport
+	fanOut *deliveryFanOut
+}
+
+// BatchDeliver delivers b once to each inbox that the deliveryFanOut collapses the recipients into.
+func (t *fanOutTransport) BatchDeliver(ctx context.Context, b []byte, recipients []*url.URL) error {
+	return t.Transport.BatchDeliver(ctx, b, t.fanOut.Inboxes(ctx, recipients))
+}
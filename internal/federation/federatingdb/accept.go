@@ -30,12 +30,13 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":   "Accept",
 			"asType": accept.GetTypeName(),
@@ -49,7 +50,7 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 	if err != nil {
 		return err
 	}
-	l.Debugf("received ACCEPT asType %s", string(b))
+	l.WithField("asType", string(b)).Debug("received ACCEPT asType")
 
 	targetAcctI := ctx.Value(util.APAccount)
 	if targetAcctI == nil {
@@ -89,6 +90,13 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 				// ACCEPT FOLLOW
 				gtsFollowRequest := &gtsmodel.FollowRequest{}
 				if err := f.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: acceptedObjectIRI.String()}}, gtsFollowRequest); err != nil {
+					if err == db.ErrNoEntries {
+						// the follow request may have been accepted already, in which case there's nothing left to do
+						if err := f.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: acceptedObjectIRI.String()}}, &gtsmodel.Follow{}); err == nil {
+							l.Debug("ACCEPT: follow request was already accepted")
+							return nil
+						}
+					}
 					return fmt.Errorf("ACCEPT: couldn't get follow request with id %s from the database: %s", acceptedObjectIRI.String(), err)
 				}
 
@@ -102,6 +110,7 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 				}
 
 				fromFederatorChan <- messages.FromFederator{
+					RequestID:        log.RequestID(ctx),
 					APObjectType:     ap.ActivityFollow,
 					APActivityType:   ap.ActivityAccept,
 					GTSModel:         follow,
@@ -139,6 +148,7 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 			}
 
 			fromFederatorChan <- messages.FromFederator{
+				RequestID:        log.RequestID(ctx),
 				APObjectType:     ap.ActivityFollow,
 				APActivityType:   ap.ActivityAccept,
 				GTSModel:         follow,
@@ -22,18 +22,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Announce",
 		},
@@ -47,7 +49,7 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return err
 	}
 
-	l.Debugf("received ANNOUNCE %s", string(b))
+	l.WithField("asType", string(b)).Debug("received ANNOUNCE")
 
 	targetAcctI := ctx.Value(util.APAccount)
 	if targetAcctI == nil {
@@ -83,8 +85,22 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return nil
 	}
 
+	boostingAccountURI, err := url.Parse(boost.AccountURI)
+	if err != nil {
+		return fmt.Errorf("ANNOUNCE: error parsing boosting account uri %s: %s", boost.AccountURI, err)
+	}
+	policy, err := f.db.GetDomainPolicy(ctx, boostingAccountURI.Hostname())
+	if err != nil {
+		return fmt.Errorf("ANNOUNCE: error getting domain policy for %s: %s", boostingAccountURI.Host, err)
+	}
+	if policy.RejectBoosts {
+		l.WithField("domain", boostingAccountURI.Host).Debug("ANNOUNCE: ignoring boost from domain with rejected boosts")
+		return nil
+	}
+
 	// it's a new announce so pass it back to the processor async for dereferencing etc
 	fromFederatorChan <- messages.FromFederator{
+		RequestID:        log.RequestID(ctx),
 		APObjectType:     ap.ActivityAnnounce,
 		APActivityType:   ap.ActivityCreate,
 		GTSModel:         boost,
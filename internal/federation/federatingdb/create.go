@@ -31,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -48,7 +49,7 @@ import (
 // Under certain conditions and network activities, Create may be called
 // multiple times for the same ActivityStreams object.
 func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":   "Create",
 			"asType": asType.GetTypeName(),
@@ -63,7 +64,7 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 		return err
 	}
 
-	l.Debugf("received CREATE asType %s", string(b))
+	l.WithField("asType", string(b)).Debug("received CREATE asType")
 
 	targetAcctI := ctx.Value(util.APAccount)
 	if targetAcctI == nil {
@@ -89,6 +90,11 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 		return nil
 	}
 
+	if err := f.recordInboxActivity(ctx, asType, targetAcct); err != nil {
+		// not a reason to stop handling it, it just might be handled again if it's redelivered
+		l.WithError(err).Error("CREATE: error recording inbox activity")
+	}
+
 	switch asType.GetTypeName() {
 	case ap.ActivityCreate:
 		// CREATE SOMETHING
@@ -102,6 +108,19 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 			case ap.ObjectNote:
 				// CREATE A NOTE
 				note := objectIter.GetActivityStreamsNote()
+
+				// a create that arrives after the delete of the same note shouldn't bring it back
+				if noteID := note.GetJSONLDId(); noteID != nil && noteID.GetIRI() != nil {
+					tombstoned, err := f.db.TombstoneExistsWithURI(ctx, noteID.GetIRI().String())
+					if err != nil {
+						return fmt.Errorf("CREATE: error checking for tombstone: %s", err)
+					}
+					if tombstoned {
+						l.WithField("noteURI", noteID.GetIRI().String()).Debug("note has been deleted already, ignoring create")
+						return nil
+					}
+				}
+
 				status, err := f.typeConverter.ASStatusToStatus(ctx, note)
 				if err != nil {
 					return fmt.Errorf("CREATE: error converting note to status: %s", err)
@@ -114,6 +133,26 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 				}
 				status.ID = statusID
 
+				// replies to our statuses have to respect the reply policy of the status they reply to;
+				// if this one doesn't, it gets rejected rather than stored
+				if status.InReplyTo != nil && status.InReplyTo.Local {
+					replyable, err := f.filter.StatusReplyable(ctx, status.InReplyTo, status.Account)
+					if err != nil {
+						return fmt.Errorf("CREATE: error checking if status is replyable: %s", err)
+					}
+					if !replyable {
+						l.WithField("statusURI", status.URI).Debug("reply violates reply policy, rejecting it")
+						fromFederatorChan <- messages.FromFederator{
+							RequestID:        log.RequestID(ctx),
+							APObjectType:     ap.ObjectNote,
+							APActivityType:   ap.ActivityReject,
+							GTSModel:         status,
+							ReceivingAccount: targetAcct,
+						}
+						return nil
+					}
+				}
+
 				if err := f.db.PutStatus(ctx, status); err != nil {
 					if err == db.ErrAlreadyExists {
 						// the status already exists in the database, which means we've already handled everything else,
@@ -125,6 +164,7 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 				}
 
 				fromFederatorChan <- messages.FromFederator{
+					RequestID:        log.RequestID(ctx),
 					APObjectType:     ap.ObjectNote,
 					APActivityType:   ap.ActivityCreate,
 					GTSModel:         status,
@@ -155,6 +195,7 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 		}
 
 		fromFederatorChan <- messages.FromFederator{
+			RequestID:        log.RequestID(ctx),
 			APObjectType:     ap.ActivityFollow,
 			APActivityType:   ap.ActivityCreate,
 			GTSModel:         followRequest,
@@ -167,11 +208,57 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 			return errors.New("CREATE: could not convert type to like")
 		}
 
+		// a like that carries content is an emoji reaction (pleroma, misskey) rather than a plain fave
+		if content, err := ap.ExtractContent(like); err == nil && content != "" {
+			reaction, err := f.typeConverter.ASLikeToStatusReaction(ctx, like)
+			if err != nil {
+				return fmt.Errorf("CREATE: could not convert Like to status reaction: %s", err)
+			}
+
+			// reactions are a kind of like, so they have to respect the like policy of our statuses too
+			if reaction.Status.Local {
+				likeable, err := f.filter.StatusLikeable(ctx, reaction.Status, reaction.Account)
+				if err != nil {
+					return fmt.Errorf("CREATE: error checking if status is likeable: %s", err)
+				}
+				if !likeable {
+					l.WithField("reactionURI", reaction.URI).Debug("reaction violates like policy, ignoring it")
+					return nil
+				}
+			}
+
+			newID, err := id.NewULID()
+			if err != nil {
+				return err
+			}
+			reaction.ID = newID
+
+			if err := f.db.Put(ctx, reaction); err != nil {
+				if err == db.ErrAlreadyExists {
+					return nil
+				}
+				return fmt.Errorf("CREATE: database error inserting status reaction: %s", err)
+			}
+			return nil
+		}
+
 		fave, err := f.typeConverter.ASLikeToFave(ctx, like)
 		if err != nil {
 			return fmt.Errorf("CREATE: could not convert Like to fave: %s", err)
 		}
 
+		// likes of our statuses have to respect the like policy of the status
+		if fave.Status.Local {
+			likeable, err := f.filter.StatusLikeable(ctx, fave.Status, fave.Account)
+			if err != nil {
+				return fmt.Errorf("CREATE: error checking if status is likeable: %s", err)
+			}
+			if !likeable {
+				l.WithField("faveURI", fave.URI).Debug("like violates like policy, ignoring it")
+				return nil
+			}
+		}
+
 		newID, err := id.NewULID()
 		if err != nil {
 			return err
@@ -183,6 +270,7 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 		}
 
 		fromFederatorChan <- messages.FromFederator{
+			RequestID:        log.RequestID(ctx),
 			APObjectType:     ap.ActivityLike,
 			APActivityType:   ap.ActivityCreate,
 			GTSModel:         fave,
@@ -211,6 +299,7 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 		}
 
 		fromFederatorChan <- messages.FromFederator{
+			RequestID:        log.RequestID(ctx),
 			APObjectType:     ap.ActivityBlock,
 			APActivityType:   ap.ActivityCreate,
 			GTSModel:         block,
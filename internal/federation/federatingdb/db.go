@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 // DB wraps the pub.Database interface with a couple of custom functions for GoToSocial.
@@ -36,7 +37,10 @@ type DB interface {
 	pub.Database
 	Undo(ctx context.Context, undo vocab.ActivityStreamsUndo) error
 	Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) error
+	Reject(ctx context.Context, reject vocab.ActivityStreamsReject) error
 	Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error
+	Move(ctx context.Context, move vocab.ActivityStreamsMove) error
+	Flag(ctx context.Context, flag vocab.ActivityStreamsFlag) error
 }
 
 // FederatingDB uses the underlying DB interface to implement the go-fed pub.Database interface.
@@ -49,6 +53,7 @@ type federatingDB struct {
 	config        *config.Config
 	log           *logrus.Logger
 	typeConverter typeutils.TypeConverter
+	filter        visibility.Filter
 }
 
 // New returns a DB interface using the given database, config, and logger.
@@ -61,6 +66,7 @@ func New(db db.DB, config *config.Config, log *logrus.Logger) DB {
 		config:        config,
 		log:           log,
 		typeConverter: typeutils.NewConverter(config, db, log),
+		filter:        visibility.NewFilter(db, log),
 	}
 	go fdb.cleanupLocks()
 	return &fdb
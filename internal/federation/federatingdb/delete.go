@@ -26,6 +26,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -37,13 +38,13 @@ import (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Delete(ctx context.Context, id *url.URL) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Delete",
 			"id":   id.String(),
 		},
 	)
-	l.Debugf("received DELETE id %s", id.String())
+	l.WithField("id", id.String()).Debug("received DELETE id")
 
 	targetAcctI := ctx.Value(util.APAccount)
 	if targetAcctI == nil {
@@ -74,11 +75,19 @@ func (f *federatingDB) Delete(ctx context.Context, id *url.URL) error {
 	s, err := f.db.GetStatusByURI(ctx, id.String())
 	if err == nil {
 		// it's a status
-		l.Debugf("uri is for status with id: %s", s.ID)
+		l.WithField("statusID", s.ID).Debug("uri is for status")
 		if err := f.db.DeleteByID(ctx, s.ID, &gtsmodel.Status{}); err != nil {
 			return fmt.Errorf("DELETE: err deleting status: %s", err)
 		}
+
+		// remember that this status was deleted, so that we don't fetch it again later
+		if !s.Local {
+			if err := f.putTombstone(ctx, id); err != nil {
+				return fmt.Errorf("DELETE: err putting tombstone: %s", err)
+			}
+		}
 		fromFederatorChan <- messages.FromFederator{
+			RequestID:        log.RequestID(ctx),
 			APObjectType:     ap.ObjectNote,
 			APActivityType:   ap.ActivityDelete,
 			GTSModel:         s,
@@ -89,8 +98,9 @@ func (f *federatingDB) Delete(ctx context.Context, id *url.URL) error {
 	a, err := f.db.GetAccountByURI(ctx, id.String())
 	if err == nil {
 		// it's an account
-		l.Debugf("uri is for an account with id %s, passing delete message to the processor", a.ID)
+		l.WithField("accountID", a.ID).Debug("uri is for an account, passing delete message to the processor")
 		fromFederatorChan <- messages.FromFederator{
+			RequestID:        log.RequestID(ctx),
 			APObjectType:     ap.ObjectProfile,
 			APActivityType:   ap.ActivityDelete,
 			GTSModel:         a,
@@ -20,6 +20,7 @@ package federatingdb
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 
 	"github.com/sirupsen/logrus"
@@ -28,15 +29,23 @@ import (
 // Exists returns true if the database has an entry for the specified
 // id. It may not be owned by this application instance.
 //
+// The library calls this with the id of an activity that's been POSTed to an inbox, and only
+// calls Create with the activity if it doesn't exist yet, so we report activities that have
+// already been received as existing, so that they're not handled again.
+//
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Exists(c context.Context, id *url.URL) (exists bool, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(c).WithFields(
 		logrus.Fields{
 			"func": "Exists",
 			"id":   id.String(),
 		},
 	)
-	l.Debugf("entering EXISTS function with id %s", id.String())
+	l.WithField("id", id.String()).Debug("entering EXISTS function")
 
-	return false, nil
+	exists, err = f.db.InboxActivityExists(c, id.String())
+	if err != nil {
+		return false, fmt.Errorf("error checking whether activity %s has been received already: %s", id.String(), err)
+	}
+	return exists, nil
 }
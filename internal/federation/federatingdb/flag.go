@@ -0,0 +1,139 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (f *federatingDB) Flag(ctx context.Context, flag vocab.ActivityStreamsFlag) error {
+	l := f.log.WithContext(ctx).WithFields(
+		logrus.Fields{
+			"func": "Flag",
+		},
+	)
+	m, err := streams.Serialize(flag)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	l.WithField("asType", string(b)).Debug("received FLAG")
+
+	targetAcctI := ctx.Value(util.APAccount)
+	if targetAcctI == nil {
+		// If the target account wasn't set on the context, that means this request didn't pass through the
+		// API, but came from inside GtS as the result of another activity on this instance. That being so,
+		// we can safely just ignore this activity, since we know we've already processed it elsewhere.
+		return nil
+	}
+	targetAcct, ok := targetAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("FLAG: target account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	requestingAcctI := ctx.Value(util.APRequestingAccount)
+	if requestingAcctI == nil {
+		l.Error("FLAG: requesting account wasn't set on context")
+		return nil
+	}
+	requestingAcct, ok := requestingAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("FLAG: requesting account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	fromFederatorChanI := ctx.Value(util.APFromFederatorChanKey)
+	if fromFederatorChanI == nil {
+		l.Error("FLAG: from federator channel wasn't set on context")
+		return nil
+	}
+	fromFederatorChan, ok := fromFederatorChanI.(chan messages.FromFederator)
+	if !ok {
+		l.Error("FLAG: from federator channel was set on context but couldn't be parsed")
+		return nil
+	}
+
+	// the domain of the reporter may be blocked with reject_reports set, in which case we drop the flag
+	if err := f.db.GetWhere(ctx, []db.Where{
+		{Key: "domain", Value: requestingAcct.Domain, CaseInsensitive: true},
+		{Key: "reject_reports", Value: true},
+	}, &gtsmodel.DomainBlock{}); err == nil {
+		l.Debugf("FLAG: dropping flag from %s because reports from that domain are rejected", requestingAcct.Domain)
+		return nil
+	} else if err != db.ErrNoEntries {
+		return fmt.Errorf("FLAG: error checking domain block: %s", err)
+	}
+
+	// a flag may be delivered to several of our inboxes, but we only want one report for it
+	if flagID := flag.GetJSONLDId(); flagID != nil && flagID.IsIRI() {
+		if err := f.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: flagID.GetIRI().String()}}, &gtsmodel.Report{}); err == nil {
+			return nil
+		} else if err != db.ErrNoEntries {
+			return fmt.Errorf("FLAG: error checking existence of report: %s", err)
+		}
+	}
+
+	report, err := f.typeConverter.ASFlagToReport(ctx, flag)
+	if err != nil {
+		return fmt.Errorf("FLAG: could not convert Flag to report: %s", err)
+	}
+
+	if report.AccountID != requestingAcct.ID {
+		return fmt.Errorf("FLAG: flag by account %s was requested by account %s, this is not valid", report.Account.URI, requestingAcct.URI)
+	}
+
+	newID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+	report.ID = newID
+
+	if err := f.db.Put(ctx, report); err != nil {
+		return fmt.Errorf("FLAG: database error inserting report: %s", err)
+	}
+
+	// pass to the processor so that admins can be notified about the report
+	fromFederatorChan <- messages.FromFederator{
+		RequestID:        log.RequestID(ctx),
+		APObjectType:     ap.ObjectProfile,
+		APActivityType:   ap.ActivityFlag,
+		GTSModel:         report,
+		ReceivingAccount: targetAcct,
+	}
+
+	return nil
+}
@@ -20,13 +20,13 @@ import (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Followers(ctx context.Context, actorIRI *url.URL) (followers vocab.ActivityStreamsCollection, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":     "Followers",
 			"actorIRI": actorIRI.String(),
 		},
 	)
-	l.Debugf("entering FOLLOWERS function with actorIRI %s", actorIRI.String())
+	l.WithField("actorIRI", actorIRI.String()).Debug("entering FOLLOWERS function")
 
 	acct := &gtsmodel.Account{}
 
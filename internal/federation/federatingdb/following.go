@@ -20,13 +20,13 @@ import (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Following(ctx context.Context, actorIRI *url.URL) (following vocab.ActivityStreamsCollection, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":     "Following",
 			"actorIRI": actorIRI.String(),
 		},
 	)
-	l.Debugf("entering FOLLOWING function with actorIRI %s", actorIRI.String())
+	l.WithField("actorIRI", actorIRI.String()).Debug("entering FOLLOWING function")
 
 	var acct *gtsmodel.Account
 	if util.IsUserPath(actorIRI) {
@@ -27,21 +27,31 @@ import (
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // InboxContains returns true if the OrderedCollection at 'inbox'
 // contains the specified 'id'.
 //
+// We don't keep whole inboxes around, but we do remember which activities have already been
+// received and handled, so that an activity that's delivered more than once is dropped. See
+// Exists and recordInboxActivity.
+//
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) InboxContains(c context.Context, inbox, id *url.URL) (contains bool, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(c).WithFields(
 		logrus.Fields{
 			"func": "InboxContains",
 			"id":   id.String(),
 		},
 	)
-	l.Debugf("entering INBOXCONTAINS function with for inbox %s and id %s", inbox.String(), id.String())
+	l.WithFields(logrus.Fields{
+		"inbox": inbox.String(),
+		"id":    id.String(),
+	}).Debug("entering INBOXCONTAINS function")
 
 	if !util.IsInboxPath(inbox) {
 		return false, fmt.Errorf("%s is not an inbox URI", inbox.String())
@@ -56,9 +66,49 @@ func (f *federatingDB) InboxContains(c context.Context, inbox, id *url.URL) (con
 		return false, fmt.Errorf("could not parse contextual activity for id %s", id.String())
 	}
 
-	l.Debugf("activity type %s for id %s", activity.GetTypeName(), id.String())
+	l.WithFields(logrus.Fields{
+		"activityType": activity.GetTypeName(),
+		"id":           id.String(),
+	}).Debug("got activity for id")
+
+	contains, err = f.db.InboxActivityExists(c, id.String())
+	if err != nil {
+		return false, fmt.Errorf("error checking whether activity %s has been received already: %s", id.String(), err)
+	}
+	if contains {
+		l.Debug("activity has been received already, dropping it")
+	}
+	return contains, nil
+}
+
+// recordInboxActivity remembers that the given type has been received and handled, if it's the activity that
+// was POSTed to the inbox, so that it will be dropped if it's delivered again.
+func (f *federatingDB) recordInboxActivity(ctx context.Context, asType vocab.Type, receivingAccount *gtsmodel.Account) error {
+	activity, ok := ctx.Value(util.APActivity).(pub.Activity)
+	if !ok || activity == nil {
+		return nil
+	}
+
+	activityID := activity.GetJSONLDId()
+	typeID := asType.GetJSONLDId()
+	if activityID == nil || !activityID.IsIRI() || typeID == nil || !typeID.IsIRI() || activityID.GetIRI().String() != typeID.GetIRI().String() {
+		// it's something the activity contained rather than the activity itself
+		return nil
+	}
 
-	return false, nil
+	inboxActivityID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	if err := f.db.Put(ctx, &gtsmodel.InboxActivity{
+		ID:          inboxActivityID,
+		ActivityURI: activityID.GetIRI().String(),
+		InboxURI:    receivingAccount.InboxURI,
+	}); err != nil && err != db.ErrAlreadyExists {
+		return err
+	}
+	return nil
 }
 
 // GetInbox returns the first ordered collection page of the outbox at
@@ -66,12 +116,12 @@ func (f *federatingDB) InboxContains(c context.Context, inbox, id *url.URL) (con
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) GetInbox(c context.Context, inboxIRI *url.URL) (inbox vocab.ActivityStreamsOrderedCollectionPage, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(c).WithFields(
 		logrus.Fields{
 			"func": "GetInbox",
 		},
 	)
-	l.Debugf("entering GETINBOX function with inboxIRI %s", inboxIRI.String())
+	l.WithField("inboxIRI", inboxIRI.String()).Debug("entering GETINBOX function")
 	return streams.NewActivityStreamsOrderedCollectionPage(), nil
 }
 
@@ -81,7 +131,7 @@ func (f *federatingDB) GetInbox(c context.Context, inboxIRI *url.URL) (inbox voc
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) SetInbox(c context.Context, inbox vocab.ActivityStreamsOrderedCollectionPage) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(c).WithFields(
 		logrus.Fields{
 			"func": "SetInbox",
 		},
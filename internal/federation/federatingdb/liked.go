@@ -33,12 +33,12 @@ import (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Liked(c context.Context, actorIRI *url.URL) (liked vocab.ActivityStreamsCollection, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(c).WithFields(
 		logrus.Fields{
 			"func":     "Liked",
 			"actorIRI": actorIRI.String(),
 		},
 	)
-	l.Debugf("entering LIKED function with actorIRI %s", actorIRI.String())
+	l.WithField("actorIRI", actorIRI.String()).Debug("entering LIKED function")
 	return nil, nil
 }
@@ -36,7 +36,7 @@ import (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) GetOutbox(ctx context.Context, outboxIRI *url.URL) (inbox vocab.ActivityStreamsOrderedCollectionPage, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "GetOutbox",
 		},
@@ -52,7 +52,7 @@ func (f *federatingDB) GetOutbox(ctx context.Context, outboxIRI *url.URL) (inbox
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) SetOutbox(ctx context.Context, outbox vocab.ActivityStreamsOrderedCollectionPage) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "SetOutbox",
 		},
@@ -67,13 +67,13 @@ func (f *federatingDB) SetOutbox(ctx context.Context, outbox vocab.ActivityStrea
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) OutboxForInbox(ctx context.Context, inboxIRI *url.URL) (outboxIRI *url.URL, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":     "OutboxForInbox",
 			"inboxIRI": inboxIRI.String(),
 		},
 	)
-	l.Debugf("entering OUTBOXFORINBOX function with inboxIRI %s", inboxIRI.String())
+	l.WithField("inboxIRI", inboxIRI.String()).Debug("entering OUTBOXFORINBOX function")
 
 	if !util.IsInboxPath(inboxIRI) {
 		return nil, fmt.Errorf("%s is not an inbox URI", inboxIRI.String())
@@ -33,17 +33,20 @@ import (
 // the database has an entry for the IRI.
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Owns(ctx context.Context, id *url.URL) (bool, error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Owns",
 			"id":   id.String(),
 		},
 	)
-	l.Tracef("entering OWNS function with id %s", id.String())
+	l.WithField("id", id.String()).Trace("entering OWNS function")
 
 	// if the id host isn't this instance host, we don't own this IRI
 	if id.Host != f.config.Host {
-		l.Tracef("we DO NOT own activity because the host is %s not %s", id.Host, f.config.Host)
+		l.WithFields(logrus.Fields{
+			"host":       id.Host,
+			"configHost": f.config.Host,
+		}).Trace("we DO NOT own activity because the host is not ours")
 		return false, nil
 	}
 
@@ -79,7 +82,7 @@ func (f *federatingDB) Owns(ctx context.Context, id *url.URL) (bool, error) {
 			// an actual error happened
 			return false, fmt.Errorf("database error fetching account with username %s: %s", username, err)
 		}
-		l.Debugf("we own url %s", id.String())
+		l.WithField("id", id.String()).Debug("we own url")
 		return true, nil
 	}
 
@@ -96,7 +99,7 @@ func (f *federatingDB) Owns(ctx context.Context, id *url.URL) (bool, error) {
 			// an actual error happened
 			return false, fmt.Errorf("database error fetching account with username %s: %s", username, err)
 		}
-		l.Debugf("we own url %s", id.String())
+		l.WithField("id", id.String()).Debug("we own url")
 		return true, nil
 	}
 
@@ -113,7 +116,7 @@ func (f *federatingDB) Owns(ctx context.Context, id *url.URL) (bool, error) {
 			// an actual error happened
 			return false, fmt.Errorf("database error fetching account with username %s: %s", username, err)
 		}
-		l.Debugf("we own url %s", id.String())
+		l.WithField("id", id.String()).Debug("we own url")
 		return true, nil
 	}
 
@@ -138,7 +141,7 @@ func (f *federatingDB) Owns(ctx context.Context, id *url.URL) (bool, error) {
 			// an actual error happened
 			return false, fmt.Errorf("database error fetching like with id %s: %s", likeID, err)
 		}
-		l.Debugf("we own url %s", id.String())
+		l.WithField("id", id.String()).Debug("we own url")
 		return true, nil
 	}
 
@@ -163,7 +166,7 @@ func (f *federatingDB) Owns(ctx context.Context, id *url.URL) (bool, error) {
 			// an actual error happened
 			return false, fmt.Errorf("database error fetching block with id %s: %s", blockID, err)
 		}
-		l.Debugf("we own url %s", id.String())
+		l.WithField("id", id.String()).Debug("we own url")
 		return true, nil
 	}
 
@@ -0,0 +1,160 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsReject) error {
+	l := f.log.WithContext(ctx).WithFields(
+		logrus.Fields{
+			"func":   "Reject",
+			"asType": reject.GetTypeName(),
+		},
+	)
+	m, err := streams.Serialize(reject)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	l.WithField("asType", string(b)).Debug("received REJECT asType")
+
+	targetAcctI := ctx.Value(util.APAccount)
+	if targetAcctI == nil {
+		// If the target account wasn't set on the context, that means this request didn't pass through the
+		// API, but came from inside GtS as the result of another activity on this instance. That being so,
+		// we can safely just ignore this activity, since we know we've already processed it elsewhere.
+		return nil
+	}
+	targetAcct, ok := targetAcctI.(*gtsmodel.Account)
+	if !ok {
+		l.Error("REJECT: target account was set on context but couldn't be parsed")
+		return nil
+	}
+
+	fromFederatorChanI := ctx.Value(util.APFromFederatorChanKey)
+	if fromFederatorChanI == nil {
+		l.Error("REJECT: from federator channel wasn't set on context")
+		return nil
+	}
+	fromFederatorChan, ok := fromFederatorChanI.(chan messages.FromFederator)
+	if !ok {
+		l.Error("REJECT: from federator channel was set on context but couldn't be parsed")
+		return nil
+	}
+
+	rejectObject := reject.GetActivityStreamsObject()
+	if rejectObject == nil {
+		return errors.New("REJECT: no object set on vocab.ActivityStreamsReject")
+	}
+
+	for iter := rejectObject.Begin(); iter != rejectObject.End(); iter = iter.Next() {
+		// check if the object is an IRI
+		if iter.IsIRI() {
+			// we have just the URI of whatever is being rejected, so we need to find out what it is
+			rejectedObjectIRI := iter.GetIRI()
+			if util.IsFollowPath(rejectedObjectIRI) {
+				// REJECT FOLLOW
+				gtsFollowRequest := &gtsmodel.FollowRequest{}
+				if err := f.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: rejectedObjectIRI.String()}}, gtsFollowRequest); err != nil {
+					if err == db.ErrNoEntries {
+						// the follow request may have been rejected already, in which case there's nothing left to do
+						l.Debug("REJECT: follow request was already rejected or doesn't exist")
+						return nil
+					}
+					return fmt.Errorf("REJECT: couldn't get follow request with id %s from the database: %s", rejectedObjectIRI.String(), err)
+				}
+
+				// make sure the addressee of the original follow is the same as whatever inbox this landed in
+				if gtsFollowRequest.AccountID != targetAcct.ID {
+					return errors.New("REJECT: follow object account and inbox account were not the same")
+				}
+
+				return f.rejectFollowRequest(ctx, gtsFollowRequest.AccountID, gtsFollowRequest.TargetAccountID, targetAcct, fromFederatorChan)
+			}
+		}
+
+		// check if iter is an AP object / type
+		if iter.GetType() == nil {
+			continue
+		}
+		switch iter.GetType().GetTypeName() {
+		// we have the whole object so we can figure out what we're rejecting
+		case ap.ActivityFollow:
+			// REJECT FOLLOW
+			asFollow, ok := iter.GetType().(vocab.ActivityStreamsFollow)
+			if !ok {
+				return errors.New("REJECT: couldn't parse follow into vocab.ActivityStreamsFollow")
+			}
+			// convert the follow to something we can understand
+			gtsFollow, err := f.typeConverter.ASFollowToFollow(ctx, asFollow)
+			if err != nil {
+				return fmt.Errorf("REJECT: error converting asfollow to gtsfollow: %s", err)
+			}
+			// make sure the addressee of the original follow is the same as whatever inbox this landed in
+			if gtsFollow.AccountID != targetAcct.ID {
+				return errors.New("REJECT: follow object account and inbox account were not the same")
+			}
+
+			return f.rejectFollowRequest(ctx, gtsFollow.AccountID, gtsFollow.TargetAccountID, targetAcct, fromFederatorChan)
+		}
+	}
+
+	return nil
+}
+
+// rejectFollowRequest removes the follow request from originAccountID to targetAccountID, and passes it
+// on to the processor so that the requesting account can be notified.
+func (f *federatingDB) rejectFollowRequest(ctx context.Context, originAccountID string, targetAccountID string, receivingAccount *gtsmodel.Account, fromFederatorChan chan messages.FromFederator) error {
+	followRequest, err := f.db.RejectFollowRequest(ctx, originAccountID, targetAccountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// already rejected, or never requested in the first place
+			return nil
+		}
+		return fmt.Errorf("REJECT: error rejecting follow request: %s", err)
+	}
+
+	fromFederatorChan <- messages.FromFederator{
+		RequestID:        log.RequestID(ctx),
+		APObjectType:     ap.ActivityFollow,
+		APActivityType:   ap.ActivityReject,
+		GTSModel:         followRequest,
+		ReceivingAccount: receivingAccount,
+	}
+
+	return nil
+}
@@ -34,7 +34,7 @@ import (
 )
 
 func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":   "Undo",
 			"asType": undo.GetTypeName(),
@@ -48,7 +48,7 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 	if err != nil {
 		return err
 	}
-	l.Debugf("received UNDO asType %s", string(b))
+	l.WithField("asType", string(b)).Debug("received UNDO asType")
 
 	targetAcctI := ctx.Value(util.APAccount)
 	if targetAcctI == nil {
@@ -104,6 +104,33 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 			return nil
 		case ap.ActivityLike:
 			// UNDO LIKE
+			ASLike, ok := iter.GetType().(vocab.ActivityStreamsLike)
+			if !ok {
+				return errors.New("UNDO: couldn't parse like into vocab.ActivityStreamsLike")
+			}
+			// make sure the actor owns the like
+			if !sameActor(undo.GetActivityStreamsActor(), ASLike.GetActivityStreamsActor()) {
+				return errors.New("UNDO: like actor and activity actor not the same")
+			}
+			// only emoji reactions are handled here for now
+			if content, err := ap.ExtractContent(ASLike); err != nil || content == "" {
+				return nil
+			}
+			// convert the like to something we can understand
+			gtsReaction, err := f.typeConverter.ASLikeToStatusReaction(ctx, ASLike)
+			if err != nil {
+				return fmt.Errorf("UNDO: error converting aslike to gtsreaction: %s", err)
+			}
+			// make sure the reacted-to status belongs to whatever inbox this landed in
+			if gtsReaction.TargetAccountID != targetAcct.ID {
+				return errors.New("UNDO: reaction target account and inbox account were not the same")
+			}
+			// delete any existing REACTION
+			if err := f.db.DeleteWhere(ctx, []db.Where{{Key: "uri", Value: gtsReaction.URI}}, &gtsmodel.StatusReaction{}); err != nil {
+				return fmt.Errorf("UNDO: db error removing status reaction: %s", err)
+			}
+			l.Debug("reaction undone")
+			return nil
 		case ap.ActivityAnnounce:
 			// UNDO BOOST/REBLOG/ANNOUNCE
 		case ap.ActivityBlock:
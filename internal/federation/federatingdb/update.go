@@ -23,12 +23,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -43,7 +46,7 @@ import (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":   "Update",
 			"asType": asType.GetTypeName(),
@@ -58,7 +61,7 @@ func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
 		return err
 	}
 
-	l.Debugf("received UPDATE asType %s", string(b))
+	l.WithField("asType", string(b)).Debug("received UPDATE asType")
 
 	targetAcctI := ctx.Value(util.APAccount)
 	if targetAcctI == nil {
@@ -164,6 +167,7 @@ func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
 
 		// pass to the processor for further processing of eg., avatar/header
 		fromFederatorChan <- messages.FromFederator{
+			RequestID:        log.RequestID(ctx),
 			APObjectType:     ap.ObjectProfile,
 			APActivityType:   ap.ActivityUpdate,
 			GTSModel:         updatedAcct,
@@ -171,5 +175,189 @@ func (f *federatingDB) Update(ctx context.Context, asType vocab.Type) error {
 		}
 	}
 
+	if typeName == ap.ObjectNote {
+		// it's an UPDATE to a status, ie., an edit
+		l.Debug("got update for NOTE")
+		note, ok := asType.(vocab.ActivityStreamsNote)
+		if !ok {
+			return errors.New("UPDATE: could not convert type to note")
+		}
+
+		return f.updateNote(ctx, note, requestingAcct, targetAcct, fromFederatorChan)
+	}
+
 	return nil
 }
+
+// updateNote applies an edit of a remote status to our copy of it, keeping the previous version in its edit history.
+func (f *federatingDB) updateNote(ctx context.Context, note vocab.ActivityStreamsNote, requestingAcct *gtsmodel.Account, receivingAcct *gtsmodel.Account, fromFederatorChan chan messages.FromFederator) error {
+	if requestingAcct == nil {
+		return errors.New("UPDATE: no requesting account for note update")
+	}
+
+	noteID := note.GetJSONLDId()
+	if noteID == nil || noteID.GetIRI() == nil {
+		return errors.New("UPDATE: note had no id")
+	}
+
+	status, err := f.db.GetStatusByURI(ctx, noteID.GetIRI().String())
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// we never saw the original, so there's nothing to edit
+			return nil
+		}
+		return fmt.Errorf("UPDATE: error getting status %s: %s", noteID.GetIRI(), err)
+	}
+
+	if status.Local {
+		// no need to update local statuses
+		return nil
+	}
+
+	if requestingAcct.URI != status.AccountURI {
+		return fmt.Errorf("UPDATE: update for status %s was requested by account %s, this is not valid", status.URI, requestingAcct.URI)
+	}
+
+	content, _ := ap.ExtractContent(note)
+	contentWarning, _ := ap.ExtractSummary(note)
+
+	attachments, err := ap.ExtractAttachments(note)
+	if err != nil {
+		return fmt.Errorf("UPDATE: error extracting attachments: %s", err)
+	}
+	keptAttachmentIDs, attachmentsChanged, err := f.keptAttachments(ctx, status, attachments)
+	if err != nil {
+		return err
+	}
+
+	mentions, err := ap.ExtractMentions(note)
+	if err != nil {
+		return fmt.Errorf("UPDATE: error extracting mentions: %s", err)
+	}
+	keptMentions, droppedMentionIDs, err := f.keptMentions(ctx, status, mentions)
+	if err != nil {
+		return err
+	}
+	mentionsChanged := len(droppedMentionIDs) != 0 || len(keptMentions) != len(mentions)
+
+	if content == status.Content && contentWarning == status.ContentWarning && !attachmentsChanged && !mentionsChanged {
+		// the same update may be delivered to several of our inboxes, and
+		// updates can be for things we don't track, so only store real edits
+		return nil
+	}
+
+	editedAt := time.Now()
+	if updatedProp := note.GetActivityStreamsUpdated(); updatedProp != nil && updatedProp.IsXMLSchemaDateTime() {
+		editedAt = updatedProp.Get()
+	}
+	if !status.EditedAt.IsZero() && !editedAt.After(status.EditedAt) {
+		// we already have this version or a newer one
+		return nil
+	}
+
+	previous, err := f.typeConverter.StatusToStatusEdit(ctx, status)
+	if err != nil {
+		return fmt.Errorf("UPDATE: error creating status edit: %s", err)
+	}
+
+	status.Content = content
+	status.ContentWarning = contentWarning
+	status.UpdatedAt = time.Now()
+	status.EditedAt = editedAt
+
+	// attachments and mentions that are new in this version are left in their minimal
+	// form, to be dereferenced by the processor once the edit has been stored
+	status.AttachmentIDs = keptAttachmentIDs
+	status.Attachments = attachments
+	status.MentionIDs = []string{}
+	for _, m := range keptMentions {
+		status.MentionIDs = append(status.MentionIDs, m.ID)
+	}
+	status.Mentions = keptMentions
+	for _, m := range mentions {
+		if !mentionTargetIn(m.TargetAccountURI, keptMentions) {
+			status.Mentions = append(status.Mentions, m)
+		}
+	}
+
+	if err := f.db.EditStatus(ctx, status, previous); err != nil {
+		return fmt.Errorf("UPDATE: database error updating status: %s", err)
+	}
+
+	for _, mentionID := range droppedMentionIDs {
+		if err := f.db.DeleteByID(ctx, mentionID, &gtsmodel.Mention{}); err != nil {
+			return fmt.Errorf("UPDATE: database error deleting mention %s: %s", mentionID, err)
+		}
+	}
+
+	// pass to the processor for further processing of eg., timelines
+	fromFederatorChan <- messages.FromFederator{
+		RequestID:        log.RequestID(ctx),
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityUpdate,
+		GTSModel:         status,
+		ReceivingAccount: receivingAcct,
+	}
+
+	return nil
+}
+
+// keptAttachments returns the ids of the attachments of status that are still attached in the given
+// new version of the attachments, and whether the attachments changed at all between the two versions.
+func (f *federatingDB) keptAttachments(ctx context.Context, status *gtsmodel.Status, attachments []*gtsmodel.MediaAttachment) ([]string, bool, error) {
+	keptIDs := []string{}
+	for _, attachmentID := range status.AttachmentIDs {
+		attachment, err := f.db.GetAttachmentByID(ctx, attachmentID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				continue
+			}
+			return nil, false, fmt.Errorf("UPDATE: error getting attachment %s: %s", attachmentID, err)
+		}
+
+		for _, a := range attachments {
+			if a.RemoteURL == attachment.RemoteURL {
+				keptIDs = append(keptIDs, attachment.ID)
+				break
+			}
+		}
+	}
+
+	changed := len(keptIDs) != len(status.AttachmentIDs) || len(keptIDs) != len(attachments)
+	return keptIDs, changed, nil
+}
+
+// keptMentions returns the mentions of status whose targets are still mentioned in the given new
+// version of the mentions, and the ids of the mentions of status that were dropped in the new version.
+func (f *federatingDB) keptMentions(ctx context.Context, status *gtsmodel.Status, mentions []*gtsmodel.Mention) ([]*gtsmodel.Mention, []string, error) {
+	kept := []*gtsmodel.Mention{}
+	droppedIDs := []string{}
+
+	if len(status.MentionIDs) == 0 {
+		return kept, droppedIDs, nil
+	}
+
+	existing, err := f.db.GetMentions(ctx, status.MentionIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("UPDATE: error getting mentions: %s", err)
+	}
+
+	for _, m := range existing {
+		if mentionTargetIn(m.TargetAccountURI, mentions) {
+			kept = append(kept, m)
+		} else {
+			droppedIDs = append(droppedIDs, m.ID)
+		}
+	}
+
+	return kept, droppedIDs, nil
+}
+
+func mentionTargetIn(targetAccountURI string, mentions []*gtsmodel.Mention) bool {
+	for _, m := range mentions {
+		if m.TargetAccountURI == targetAccountURI {
+			return true
+		}
+	}
+	return false
+}
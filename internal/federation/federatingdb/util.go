@@ -62,7 +62,7 @@ func sameActor(activityActor vocab.ActivityStreamsActorProperty, followActor voc
 // The go-fed library will handle setting the 'id' property on the
 // activity or object provided with the value returned.
 func (f *federatingDB) NewID(ctx context.Context, t vocab.Type) (idURL *url.URL, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":   "NewID",
 			"asType": t.GetTypeName(),
@@ -76,7 +76,7 @@ func (f *federatingDB) NewID(ctx context.Context, t vocab.Type) (idURL *url.URL,
 	if err != nil {
 		return nil, err
 	}
-	l.Debugf("received NEWID request for asType %s", string(b))
+	l.WithField("asType", string(b)).Debug("received NEWID request for asType")
 
 	switch t.GetTypeName() {
 	case ap.ActivityFollow:
@@ -201,13 +201,13 @@ func (f *federatingDB) NewID(ctx context.Context, t vocab.Type) (idURL *url.URL,
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) ActorForOutbox(ctx context.Context, outboxIRI *url.URL) (actorIRI *url.URL, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":     "ActorForOutbox",
 			"inboxIRI": outboxIRI.String(),
 		},
 	)
-	l.Debugf("entering ACTORFOROUTBOX function with outboxIRI %s", outboxIRI.String())
+	l.WithField("outboxIRI", outboxIRI.String()).Debug("entering ACTORFOROUTBOX function")
 
 	if !util.IsOutboxPath(outboxIRI) {
 		return nil, fmt.Errorf("%s is not an outbox URI", outboxIRI.String())
@@ -226,13 +226,13 @@ func (f *federatingDB) ActorForOutbox(ctx context.Context, outboxIRI *url.URL) (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) ActorForInbox(ctx context.Context, inboxIRI *url.URL) (actorIRI *url.URL, err error) {
-	l := f.log.WithFields(
+	l := f.log.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func":     "ActorForInbox",
 			"inboxIRI": inboxIRI.String(),
 		},
 	)
-	l.Debugf("entering ACTORFORINBOX function with inboxIRI %s", inboxIRI.String())
+	l.WithField("inboxIRI", inboxIRI.String()).Debug("entering ACTORFORINBOX function")
 
 	if !util.IsInboxPath(inboxIRI) {
 		return nil, fmt.Errorf("%s is not an inbox URI", inboxIRI.String())
@@ -246,3 +246,22 @@ func (f *federatingDB) ActorForInbox(ctx context.Context, inboxIRI *url.URL) (ac
 	}
 	return url.Parse(acct.URI)
 }
+
+// putTombstone records that the remote object with the given URI has been deleted,
+// so that we don't go and fetch or recreate it later. Existing tombstones are left alone.
+func (f *federatingDB) putTombstone(ctx context.Context, uri *url.URL) error {
+	tombstoneID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	if err := f.db.Put(ctx, &gtsmodel.Tombstone{
+		ID:     tombstoneID,
+		Domain: uri.Host,
+		URI:    uri.String(),
+	}); err != nil && err != db.ErrAlreadyExists {
+		return err
+	}
+
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -61,7 +62,7 @@ import (
 // write a response to the ResponseWriter as is expected that the caller
 // to PostInbox will do so when handling the error.
 func (f *federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Request, activity pub.Activity) (context.Context, error) {
-	l := f.log.WithFields(logrus.Fields{
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "PostInboxRequestBodyHook",
 		"useragent": r.UserAgent(),
 		"url":       r.URL.String(),
@@ -69,7 +70,7 @@ func (f *federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Reques
 
 	if activity == nil {
 		err := errors.New("nil activity in PostInboxRequestBodyHook")
-		l.Debug(err)
+		l.Debug("nil activity in PostInboxRequestBodyHook")
 		return nil, err
 	}
 	// set the activity on the context for use later on
@@ -93,7 +94,7 @@ func (f *federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Reques
 // authenticated must be true and error nil. The request will continue
 // to be processed.
 func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
-	l := f.log.WithFields(logrus.Fields{
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "AuthenticatePostInbox",
 		"useragent": r.UserAgent(),
 		"url":       r.URL.String(),
@@ -120,7 +121,7 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 
 	publicKeyOwnerURI, authenticated, err := f.AuthenticateFederatedRequest(ctx, requestedAccount.Username)
 	if err != nil {
-		l.Debugf("request not authenticated: %s", err)
+		l.WithError(err).Debug("request not authenticated")
 		return ctx, false, err
 	}
 
@@ -157,6 +158,16 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		return nil, false, fmt.Errorf("couldn't get remote account: %s", err)
 	}
 
+	// if the requester wants us to check our idea of who follows them, do that in the background,
+	// there's no need to make them wait for it
+	if header := r.Header.Get(transport.CollectionSynchronizationHeader); header != "" {
+		go func() {
+			if err := f.synchronizeFollowers(context.Background(), username, requestingAccount, header); err != nil {
+				l.WithError(err).Debug("couldn't synchronize followers")
+			}
+		}()
+	}
+
 	withRequester := context.WithValue(ctx, util.APRequestingAccount, requestingAccount)
 	withRequested := context.WithValue(withRequester, util.APAccount, requestedAccount)
 	return withRequested, true, nil
@@ -177,15 +188,15 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 // blocked must be false and error nil. The request will continue
 // to be processed.
 func (f *federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, error) {
-	l := f.log.WithFields(logrus.Fields{
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func": "Blocked",
 	})
-	l.Debugf("entering BLOCKED function with IRI list: %+v", actorIRIs)
+	l.WithField("actorIRIs", actorIRIs).Debug("entering BLOCKED function")
 
 	requestedAccountI := ctx.Value(util.APAccount)
 	requestedAccount, ok := requestedAccountI.(*gtsmodel.Account)
 	if !ok {
-		f.log.Errorf("requested account not set on request context")
+		l.Error("requested account not set on request context")
 		return false, errors.New("requested account not set on request context, so couldn't determine blocks")
 	}
 
@@ -203,7 +214,7 @@ func (f *federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, er
 			if err == db.ErrNoEntries {
 				// we don't have an entry for this account so it's not blocked
 				// TODO: allow a different default to be set for this behavior
-				l.Tracef("no entry for account with URI %s so it can't be blocked", uri)
+				l.WithField("uri", uri).Trace("no entry for account so it can't be blocked")
 				continue
 			}
 			return false, fmt.Errorf("error getting account with uri %s: %s", uri.String(), err)
@@ -214,7 +225,10 @@ func (f *federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, er
 			return false, fmt.Errorf("error checking account block: %s", err)
 		}
 		if blocked {
-			l.Tracef("local account %s blocks account with uri %s", requestedAccount.Username, uri)
+			l.WithFields(logrus.Fields{
+				"username": requestedAccount.Username,
+				"uri":      uri,
+			}).Trace("local account blocks account")
 			return true, nil
 		}
 	}
@@ -259,10 +273,22 @@ func (f *federator) FederatingCallbacks(ctx context.Context) (wrapped pub.Federa
 		func(ctx context.Context, accept vocab.ActivityStreamsAccept) error {
 			return f.FederatingDB().Accept(ctx, accept)
 		},
+		// override default reject behavior and trigger our own side effects
+		func(ctx context.Context, reject vocab.ActivityStreamsReject) error {
+			return f.FederatingDB().Reject(ctx, reject)
+		},
 		// override default announce behavior and trigger our own side effects
 		func(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
 			return f.FederatingDB().Announce(ctx, announce)
 		},
+		// go-fed has no default move behavior, so handle account migrations ourselves
+		func(ctx context.Context, move vocab.ActivityStreamsMove) error {
+			return f.FederatingDB().Move(ctx, move)
+		},
+		// go-fed has no default flag behavior either, so turn flags into reports for our moderators
+		func(ctx context.Context, flag vocab.ActivityStreamsFlag) error {
+			return f.FederatingDB().Flag(ctx, flag)
+		},
 	}
 
 	return
@@ -276,11 +302,11 @@ func (f *federator) FederatingCallbacks(ctx context.Context) (wrapped pub.Federa
 // type and extension, so the unhandled ones are passed to
 // DefaultCallback.
 func (f *federator) DefaultCallback(ctx context.Context, activity pub.Activity) error {
-	l := f.log.WithFields(logrus.Fields{
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "DefaultCallback",
 		"aptype": activity.GetTypeName(),
 	})
-	l.Debugf("received unhandle-able activity type so ignoring it")
+	l.Debug("received unhandle-able activity type so ignoring it")
 	return nil
 }
 
@@ -57,6 +57,9 @@ type Federator interface {
 	FingerRemoteAccount(ctx context.Context, requestingUsername string, targetUsername string, targetDomain string) (*url.URL, error)
 
 	DereferenceRemoteThread(ctx context.Context, username string, statusURI *url.URL) error
+	// BackfillRemoteThread dereferences the ancestors and replies of the given remote status, up to the configured limit,
+	// so that its thread context is populated. It does nothing if the same thread was already backfilled recently.
+	BackfillRemoteThread(ctx context.Context, username string, statusURI *url.URL) error
 	DereferenceAnnounce(ctx context.Context, announce *gtsmodel.Status, requestingUsername string) error
 
 	GetRemoteAccount(ctx context.Context, username string, remoteAccountID *url.URL, refresh bool) (*gtsmodel.Account, bool, error)
@@ -83,6 +86,7 @@ type federator struct {
 	dereferencer        dereferencing.Dereferencer
 	mediaHandler        media.Handler
 	actor               pub.FederatingActor
+	fanOut              *deliveryFanOut
 	log                 *logrus.Logger
 }
 
@@ -100,6 +104,7 @@ func NewFederator(db db.DB, federatingDB federatingdb.DB, transportController tr
 		transportController: transportController,
 		dereferencer:        dereferencer,
 		mediaHandler:        mediaHandler,
+		fanOut:              newDeliveryFanOut(db, log),
 		log:                 log,
 	}
 	actor := newFederatingActor(f, f, federatingDB, clock)
@@ -0,0 +1,228 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// synchronizeFollowers compares the Collection-Synchronization header sent along with a delivery from remoteAccount to
+// the local accounts that we think follow remoteAccount. If they've drifted apart, the partial followers collection for
+// this instance is fetched from remoteAccount's instance, and used to repair the drift on both sides:
+//
+// Local follows that the remote instance doesn't know about are removed, since nothing is being delivered for them anyway.
+//
+// Local accounts that the remote instance thinks follow remoteAccount, but don't, are unfollowed with an Undo, so that
+// the remote instance stops delivering to them.
+func (f *federator) synchronizeFollowers(ctx context.Context, username string, remoteAccount *gtsmodel.Account, header string) error {
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":          "synchronizeFollowers",
+		"remoteAccount": remoteAccount.URI,
+	})
+
+	collectionSync, err := transport.ParseCollectionSynchronization(header)
+	if err != nil {
+		return err
+	}
+
+	// the remote account can only speak for its own followers
+	if collectionSync.CollectionID != remoteAccount.FollowersURI {
+		return fmt.Errorf("collection %s isn't the followers collection of %s", collectionSync.CollectionID, remoteAccount.URI)
+	}
+
+	syncURL, err := url.Parse(collectionSync.URL)
+	if err != nil {
+		return fmt.Errorf("error parsing url %s: %s", collectionSync.URL, err)
+	}
+
+	remoteAccountURI, err := url.Parse(remoteAccount.URI)
+	if err != nil {
+		return fmt.Errorf("error parsing url %s: %s", remoteAccount.URI, err)
+	}
+
+	if syncURL.Host != remoteAccountURI.Host {
+		return fmt.Errorf("synchronization url %s isn't on the same host as %s", syncURL.String(), remoteAccount.URI)
+	}
+
+	follows, err := f.db.GetAccountFollowersByDomain(ctx, remoteAccount.ID, "")
+	if err != nil {
+		return fmt.Errorf("error getting local followers of %s: %s", remoteAccount.URI, err)
+	}
+
+	localFollowers := make(map[string]*gtsmodel.Follow, len(follows))
+	localFollowerURIs := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		localFollowers[follow.Account.URI] = follow
+		localFollowerURIs = append(localFollowerURIs, follow.Account.URI)
+	}
+
+	if transport.FollowersDigest(localFollowerURIs) == collectionSync.Digest {
+		// we're in sync, nothing to do
+		return nil
+	}
+	l.Debug("followers have drifted, synchronizing")
+
+	t, err := f.transportController.NewTransportForUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("error creating transport: %s", err)
+	}
+
+	// we already know our copy of the collection is out of date, so make sure we don't get a cached one
+	b, err := t.Dereference(transport.WithRevalidate(ctx), syncURL)
+	if err != nil {
+		return fmt.Errorf("error dereferencing %s: %s", syncURL.String(), err)
+	}
+
+	remoteFollowerURIs, err := followerSyncItems(ctx, b)
+	if err != nil {
+		return fmt.Errorf("error parsing followers from %s: %s", syncURL.String(), err)
+	}
+
+	remoteFollowers := make(map[string]bool, len(remoteFollowerURIs))
+	for _, uri := range remoteFollowerURIs {
+		remoteFollowers[uri.String()] = true
+	}
+
+	for uri, follow := range localFollowers {
+		if remoteFollowers[uri] {
+			continue
+		}
+		l.WithField("follower", uri).Debug("removing follow unknown to remote instance")
+		if err := f.db.DeleteByID(ctx, follow.ID, &gtsmodel.Follow{}); err != nil {
+			return fmt.Errorf("error removing follow %s: %s", follow.ID, err)
+		}
+	}
+
+	for _, uri := range remoteFollowerURIs {
+		if uri.Host != f.config.Host || localFollowers[uri.String()] != nil {
+			continue
+		}
+
+		localAccount, err := f.db.GetAccountByURI(ctx, uri.String())
+		if err != nil {
+			// this account doesn't exist (anymore), the remote instance will have to find out on its own
+			l.WithError(err).WithField("follower", uri.String()).Debug("couldn't get local account listed as follower")
+			continue
+		}
+
+		l.WithField("follower", uri.String()).Debug("undoing follow unknown to this instance")
+		if err := f.undoUnknownFollow(ctx, localAccount, remoteAccount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// undoUnknownFollow sends an Undo of a follow from localAccount to remoteAccount that only the remote instance knows about.
+// Since we don't have the original follow anymore, the Undo carries a freshly minted one with the same actor and object.
+func (f *federator) undoUnknownFollow(ctx context.Context, localAccount *gtsmodel.Account, remoteAccount *gtsmodel.Account) error {
+	followID, err := id.NewRandomULID()
+	if err != nil {
+		return err
+	}
+
+	follow := &gtsmodel.Follow{
+		ID:              followID,
+		AccountID:       localAccount.ID,
+		TargetAccountID: remoteAccount.ID,
+		URI:             util.GenerateURIForFollow(localAccount.Username, f.config.Protocol, f.config.Host, followID),
+	}
+
+	asFollow, err := f.typeConverter.FollowToAS(ctx, follow, localAccount, remoteAccount)
+	if err != nil {
+		return fmt.Errorf("error converting follow to as format: %s", err)
+	}
+
+	remoteAccountURI, err := url.Parse(remoteAccount.URI)
+	if err != nil {
+		return fmt.Errorf("error parsing uri %s: %s", remoteAccount.URI, err)
+	}
+
+	// create an Undo and set the appropriate actor on it
+	undo := streams.NewActivityStreamsUndo()
+	undo.SetActivityStreamsActor(asFollow.GetActivityStreamsActor())
+
+	// Set the recreated follow as the 'object' property.
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsFollow(asFollow)
+	undo.SetActivityStreamsObject(undoObject)
+
+	// Set the To of the undo as the target of the recreated follow
+	undoTo := streams.NewActivityStreamsToProperty()
+	undoTo.AppendIRI(remoteAccountURI)
+	undo.SetActivityStreamsTo(undoTo)
+
+	outboxIRI, err := url.Parse(localAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("error parsing outboxURI %s: %s", localAccount.OutboxURI, err)
+	}
+
+	_, err = f.FederatingActor().Send(ctx, outboxIRI, undo)
+	return err
+}
+
+// followerSyncItems returns the items of the (ordered) collection in b.
+func followerSyncItems(ctx context.Context, b []byte) ([]*url.URL, error) {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	t, err := streams.ToType(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	uris := []*url.URL{}
+	switch c := t.(type) {
+	case vocab.ActivityStreamsOrderedCollection:
+		if items := c.GetActivityStreamsOrderedItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				if iter.IsIRI() {
+					uris = append(uris, iter.GetIRI())
+				}
+			}
+		}
+	case vocab.ActivityStreamsCollection:
+		if items := c.GetActivityStreamsItems(); items != nil {
+			for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+				if iter.IsIRI() {
+					uris = append(uris, iter.GetIRI())
+				}
+			}
+		}
+	default:
+		return nil, errors.New("not a collection")
+	}
+
+	return uris, nil
+}
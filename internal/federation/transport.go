@@ -68,5 +68,14 @@ func (f *federator) NewTransport(ctx context.Context, actorBoxIRI *url.URL, gofe
 		return nil, fmt.Errorf("id %s was neither an inbox path nor an outbox path", actorBoxIRI.String())
 	}
 
-	return f.transportController.NewTransportForUsername(ctx, username)
+	tp, err := f.transportController.NewTransportForUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// collapse deliveries to recipients on the same host into one POST to their shared inbox
+	return &fanOutTransport{
+		Transport: tp,
+		fanOut:    f.fanOut,
+	}, nil
 }
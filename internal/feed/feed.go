@@ -0,0 +1,242 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package feed renders syndication feeds (RSS 2.0 and Atom) for public posts.
+package feed
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+)
+
+// Feed is a format-agnostic syndication feed.
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	Author      string
+	Image       string
+	Updated     time.Time
+	Items       []*Item
+}
+
+// Item is a single entry in a Feed.
+type Item struct {
+	ID          string
+	Title       string
+	Link        string
+	Description string
+	Created     time.Time
+	Enclosures  []*Enclosure
+}
+
+// Enclosure is a media file attached to an Item.
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int
+}
+
+// RSS renders the feed as an RSS 2.0 document.
+func (f *Feed) RSS() ([]byte, error) {
+	channel := rssChannel{
+		Title:         f.Title,
+		Link:          f.Link,
+		Description:   f.Description,
+		LastBuildDate: formatRSSTime(f.Updated),
+		Items:         make([]rssItem, 0, len(f.Items)),
+	}
+
+	if f.Image != "" {
+		channel.Image = &rssImage{
+			URL:   f.Image,
+			Title: f.Title,
+			Link:  f.Link,
+		}
+	}
+
+	for _, i := range f.Items {
+		item := rssItem{
+			Title:       i.Title,
+			Link:        i.Link,
+			Description: rssCDATA{i.Description},
+			GUID:        rssGUID{ID: i.ID, IsPermaLink: i.ID == i.Link},
+			PubDate:     formatRSSTime(i.Created),
+		}
+
+		// RSS only allows one enclosure per item, so take the first
+		if len(i.Enclosures) != 0 {
+			e := i.Enclosures[0]
+			item.Enclosure = &rssEnclosure{
+				URL:    e.URL,
+				Type:   e.Type,
+				Length: strconv.Itoa(e.Length),
+			}
+		}
+
+		channel.Items = append(channel.Items, item)
+	}
+
+	return marshal(rss{Version: "2.0", Channel: channel})
+}
+
+// Atom renders the feed as an Atom 1.0 document. selfLink should be the URL the feed itself is served at.
+func (f *Feed) Atom(selfLink string) ([]byte, error) {
+	doc := atomFeed{
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		ID:      f.Link,
+		Title:   f.Title,
+		Updated: formatAtomTime(f.Updated),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+			{Href: selfLink, Rel: "self", Type: "application/atom+xml"},
+		},
+		Subtitle: f.Description,
+		Icon:     f.Image,
+		Author:   &atomAuthor{Name: f.Author, URI: f.Link},
+		Entries:  make([]atomEntry, 0, len(f.Items)),
+	}
+
+	for _, i := range f.Items {
+		entry := atomEntry{
+			ID:        i.ID,
+			Title:     i.Title,
+			Published: formatAtomTime(i.Created),
+			Updated:   formatAtomTime(i.Created),
+			Links:     []atomLink{{Href: i.Link, Rel: "alternate", Type: "text/html"}},
+			Content:   &atomContent{Type: "html", Body: i.Description},
+		}
+
+		for _, e := range i.Enclosures {
+			entry.Links = append(entry.Links, atomLink{
+				Href:   e.URL,
+				Rel:    "enclosure",
+				Type:   e.Type,
+				Length: strconv.Itoa(e.Length),
+			})
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return marshal(doc)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+func formatRSSTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC1123Z)
+}
+
+func formatAtomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Image         *rssImage `xml:"image,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description rssCDATA      `xml:"description"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssCDATA struct {
+	Text string `xml:",cdata"`
+}
+
+type rssGUID struct {
+	ID          string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	XMLNS    string      `xml:"xmlns,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Icon     string      `xml:"icon,omitempty"`
+	Author   *atomAuthor `xml:"author,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string       `xml:"id"`
+	Title     string       `xml:"title"`
+	Published string       `xml:"published"`
+	Updated   string       `xml:"updated"`
+	Links     []atomLink   `xml:"link"`
+	Content   *atomContent `xml:"content,omitempty"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
@@ -0,0 +1,121 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FeedTestSuite struct {
+	suite.Suite
+	feed *Feed
+}
+
+func (suite *FeedTestSuite) SetupTest() {
+	created := time.Date(2021, 10, 31, 12, 0, 0, 0, time.UTC)
+	suite.feed = &Feed{
+		Title:       "Posts from @admin@example.org",
+		Link:        "https://example.org/@admin",
+		Description: "Public posts from admin (@admin@example.org)",
+		Author:      "admin",
+		Image:       "https://example.org/fileserver/avatar.jpeg",
+		Updated:     created,
+		Items: []*Item{
+			{
+				ID:          "https://example.org/users/admin/statuses/01FF",
+				Title:       "hello world",
+				Link:        "https://example.org/@admin/statuses/01FF",
+				Description: "<p>hello world &amp; friends</p>",
+				Created:     created,
+				Enclosures: []*Enclosure{
+					{URL: "https://example.org/fileserver/1.jpeg", Type: "image/jpeg", Length: 62529},
+					{URL: "https://example.org/fileserver/2.png", Type: "image/png", Length: 1024},
+				},
+			},
+		},
+	}
+}
+
+func (suite *FeedTestSuite) TestRSS() {
+	b, err := suite.feed.RSS()
+	suite.NoError(err)
+	suite.True(strings.HasPrefix(string(b), xml.Header))
+	suite.Contains(string(b), "<![CDATA[<p>hello world &amp; friends</p>]]>")
+	suite.Contains(string(b), "<pubDate>Sun, 31 Oct 2021 12:00:00 +0000</pubDate>")
+
+	parsed := &rss{}
+	suite.NoError(xml.Unmarshal(b, parsed))
+	suite.Equal("2.0", parsed.Version)
+	suite.Equal(suite.feed.Title, parsed.Channel.Title)
+	suite.Equal(suite.feed.Image, parsed.Channel.Image.URL)
+	suite.Len(parsed.Channel.Items, 1)
+
+	item := parsed.Channel.Items[0]
+	suite.Equal("https://example.org/users/admin/statuses/01FF", item.GUID.ID)
+	suite.False(item.GUID.IsPermaLink)
+	suite.Equal("<p>hello world &amp; friends</p>", item.Description.Text)
+
+	// rss only allows a single enclosure
+	suite.Equal("https://example.org/fileserver/1.jpeg", item.Enclosure.URL)
+	suite.Equal("image/jpeg", item.Enclosure.Type)
+	suite.Equal("62529", item.Enclosure.Length)
+}
+
+func (suite *FeedTestSuite) TestAtom() {
+	b, err := suite.feed.Atom("https://example.org/@admin/feed.atom")
+	suite.NoError(err)
+
+	parsed := &atomFeed{}
+	suite.NoError(xml.Unmarshal(b, parsed))
+	suite.Equal(suite.feed.Link, parsed.ID)
+	suite.Equal("2021-10-31T12:00:00Z", parsed.Updated)
+	suite.Contains(parsed.Links, atomLink{Href: "https://example.org/@admin/feed.atom", Rel: "self", Type: "application/atom+xml"})
+	suite.Len(parsed.Entries, 1)
+
+	entry := parsed.Entries[0]
+	suite.Equal("https://example.org/users/admin/statuses/01FF", entry.ID)
+	suite.Equal("html", entry.Content.Type)
+	suite.Equal("<p>hello world &amp; friends</p>", entry.Content.Body)
+	suite.Equal([]atomLink{
+		{Href: "https://example.org/@admin/statuses/01FF", Rel: "alternate", Type: "text/html"},
+		{Href: "https://example.org/fileserver/1.jpeg", Rel: "enclosure", Type: "image/jpeg", Length: "62529"},
+		{Href: "https://example.org/fileserver/2.png", Rel: "enclosure", Type: "image/png", Length: "1024"},
+	}, entry.Links)
+}
+
+func (suite *FeedTestSuite) TestEmptyFeed() {
+	suite.feed.Items = nil
+
+	b, err := suite.feed.RSS()
+	suite.NoError(err)
+	suite.NotContains(string(b), "<item>")
+
+	b, err = suite.feed.Atom("https://example.org/@admin/feed.atom")
+	suite.NoError(err)
+	suite.NotContains(string(b), "<entry>")
+}
+
+func TestFeedTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedTestSuite))
+}
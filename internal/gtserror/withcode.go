@@ -58,6 +58,20 @@ func (e withCode) Code() int {
 	return e.code
 }
 
+// NewErrorAccepted returns an ErrorWithCode 202 with the given original error and optional help text.
+// It's for requests for something that has been asked for, but isn't ready yet.
+func NewErrorAccepted(original error, helpText ...string) WithCode {
+	safe := "accepted"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusAccepted,
+	}
+}
+
 // NewErrorBadRequest returns an ErrorWithCode 400 with the given original error and optional help text.
 func NewErrorBadRequest(original error, helpText ...string) WithCode {
 	safe := "bad request"
@@ -122,3 +136,16 @@ func NewErrorInternalError(original error, helpText ...string) WithCode {
 		code:     http.StatusInternalServerError,
 	}
 }
+
+// NewErrorNotImplemented returns an ErrorWithCode 501 with the given original error and optional help text.
+func NewErrorNotImplemented(original error, helpText ...string) WithCode {
+	safe := "not implemented"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusNotImplemented,
+	}
+}
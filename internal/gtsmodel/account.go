@@ -44,8 +44,11 @@ type Account struct {
 	Fields                  []Field          `validate:"-"`                                                                                                          // a key/value map of fields that this account has added to their profile
 	Note                    string           `validate:"-" bun:""`                                                                                                   // A note that this account has on their profile (ie., the account's bio/description of themselves)
 	Memorial                bool             `validate:"-" bun:",default:false"`                                                                                     // Is this a memorial account, ie., has the user passed away?
+	EmojiIDs                []string         `validate:"dive,ulid" bun:"emojis,array"`                                                                               // Database IDs of any custom emojis used in this account's display name or note
+	Emojis                  []*Emoji         `validate:"-" bun:"-"`                                                                                                  // Emojis corresponding to emojiIDs; not stored in the database, populated as needed
 	AlsoKnownAs             string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // This account is associated with x account id (TODO: migrate to be AlsoKnownAsID)
 	MovedToAccountID        string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // This account has moved this account id in the database
+	AlsoKnownAsURIs         []string         `validate:"dive,url" bun:"also_known_as_uris,array"`                                                                    // ActivityPub URIs of other accounts that this account claims to also be; a Move to one of these accounts is only honoured if it lists this account in turn
 	Bot                     bool             `validate:"-" bun:",default:false"`                                                                                     // Does this account identify itself as a bot?
 	Reason                  string           `validate:"-" bun:""`                                                                                                   // What reason was given for signing up when this account was created?
 	Locked                  bool             `validate:"-" bun:",default:true"`                                                                                      // Does this account need an approval for new followers?
@@ -57,6 +60,7 @@ type Account struct {
 	URL                     string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // Web URL for this account's profile
 	LastWebfingeredAt       time.Time        `validate:"required_with=Domain" bun:"type:timestamptz,nullzero"`                                                       // Last time this account was refreshed/located with webfinger.
 	InboxURI                string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // Address of this account's ActivityPub inbox, for sending activity to
+	SharedInboxURI          string           `validate:"omitempty,url" bun:",nullzero"`                                                                              // Address of the shared inbox of this account's instance, if it has one; activities for several accounts on the same instance can be delivered here at once
 	OutboxURI               string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // Address of this account's activitypub outbox
 	FollowingURI            string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // URI for getting the following list of this account
 	FollowersURI            string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // URI for getting the followers list of this account
@@ -65,10 +69,15 @@ type Account struct {
 	PrivateKey              *rsa.PrivateKey  `validate:"required_without=Domain"`                                                                                    // Privatekey for validating activitypub requests, will only be defined for local accounts
 	PublicKey               *rsa.PublicKey   `validate:"required"`                                                                                                   // Publickey for encoding activitypub requests, will be defined for both local and remote accounts
 	PublicKeyURI            string           `validate:"required,url" bun:",nullzero,notnull,unique"`                                                                // Web-reachable location of this account's public key
+	Ed25519PrivateKey       string           `validate:"-" bun:",nullzero"`                                                                                          // PEM-encoded ed25519 private key for signing activitypub requests, only defined for local accounts created when the ed25519 key type was configured
+	Ed25519PublicKeyURI     string           `validate:"omitempty,url" bun:",nullzero,unique"`                                                                       // Web-reachable location of this account's ed25519 public key, if it has one
 	SensitizedAt            time.Time        `validate:"-" bun:"type:timestamptz,nullzero"`                                                                          // When was this account set to have all its media shown as sensitive?
 	SilencedAt              time.Time        `validate:"-" bun:"type:timestamptz,nullzero"`                                                                          // When was this account silenced (eg., statuses only visible to followers, not public)?
 	SuspendedAt             time.Time        `validate:"-" bun:"type:timestamptz,nullzero"`                                                                          // When was this account suspended (eg., don't allow it to log in/post, don't accept media/posts from this account)
 	HideCollections         bool             `validate:"-" bun:",default:false"`                                                                                     // Hide this account's collections
+	EnableRSS               bool             `validate:"-" bun:",default:false"`                                                                                     // Serve an RSS/Atom feed of this account's public posts
+	PrivateBlocks           bool             `validate:"-" bun:",default:false"`                                                                                     // Don't send Block activities to the instances of accounts blocked by this account
+	CustomCSS               string           `validate:"-" bun:",nullzero"`                                                                                          // Custom CSS to use on this account's profile and status pages
 	SuspensionOrigin        string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
 }
 
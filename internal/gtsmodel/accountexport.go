@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// AccountExport represents an archive of a local account's data, built at the request of that account so that it can be downloaded.
+type AccountExport struct {
+	ID          string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID   string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account whose data is in the archive
+	Account     *Account  `validate:"-" bun:"rel:belongs-to"`                                              // pointer to the account specified by accountID
+	Path        string    `validate:"required" bun:",nullzero,notnull"`                                    // path of the archive in storage
+	ContentType string    `validate:"required" bun:",nullzero,notnull"`                                    // mime type of the archive
+	FileSize    int       `validate:"min=0" bun:",notnull,default:0"`                                      // size of the archive in bytes
+	ReadyAt     time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when the archive finished building; zero while it's still being built
+}
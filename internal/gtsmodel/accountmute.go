@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// AccountMute refers to one account having muted another account, so that its posts and (optionally) notifications are hidden.
+type AccountMute struct {
+	ID              string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`            // id of this item in the database
+	CreatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`     // when was item created
+	UpdatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`     // when was item last updated
+	ExpiresAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                       // when does this mute stop being applied, if ever
+	AccountID       string    `validate:"required,ulid" bun:"type:CHAR(26),unique:mutesrctarget,notnull,nullzero"` // id of the account that created ('did') the mute
+	Account         *Account  `validate:"-" bun:"rel:belongs-to"`                                                  // pointer to the account specified by accountID
+	TargetAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),unique:mutesrctarget,notnull,nullzero"` // id of the account that has been muted
+	TargetAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                                  // pointer to the account specified by targetAccountID
+	Notifications   bool      `validate:"-" bun:",default:false"`                                                  // should notifications from the target account also be muted?
+}
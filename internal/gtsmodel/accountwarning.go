@@ -0,0 +1,49 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// AccountWarning is a formal warning, or strike, that a moderator has given a local account, optionally along with
+// a silence or suspension. Unlike moderation notes, the user can be told about a warning by email.
+type AccountWarning struct {
+	ID              string               `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt       time.Time            `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt       time.Time            `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID       string               `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the moderator account that gave the warning
+	Account         *Account             `validate:"-" bun:"rel:belongs-to"`                                              // account corresponding to accountID
+	TargetAccountID string               `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that was warned
+	TargetAccount   *Account             `validate:"-" bun:"rel:belongs-to"`                                              // account corresponding to targetAccountID
+	ReportID        string               `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // id of the report that the warning was given because of, if any
+	Action          AccountWarningAction `validate:"oneof=none silence suspend" bun:",nullzero,notnull,default:'none'"`   // what was done to the account along with the warning
+	Text            string               `validate:"-" bun:",nullzero"`                                                   // explanation of the warning, shown to the user
+	Emailed         bool                 `validate:"-" bun:",notnull,default:false"`                                      // was the user sent an email about the warning?
+}
+
+// AccountWarningAction is what was done to an account along with a warning.
+type AccountWarningAction string
+
+const (
+	// AccountWarningActionNone means the account was only warned.
+	AccountWarningActionNone AccountWarningAction = "none"
+	// AccountWarningActionSilence means the account was silenced as well as warned.
+	AccountWarningActionSilence AccountWarningAction = "silence"
+	// AccountWarningActionSuspend means the account was suspended as well as warned.
+	AccountWarningActionSuspend AccountWarningAction = "suspend"
+)
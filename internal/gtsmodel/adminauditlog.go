@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// AdminAuditLog records one action that an admin or moderator took on this instance.
+// Entries are only ever appended, so that admins can review who did what.
+type AdminAuditLog struct {
+	ID         string              `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time           `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID  string              `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the admin account that took the action
+	Account    *Account            `validate:"-" bun:"rel:belongs-to"`                                              // account corresponding to accountID
+	Action     AdminAuditLogAction `validate:"required" bun:",nullzero,notnull"`                                    // what was done
+	TargetType AdminAuditLogTarget `validate:"required" bun:",nullzero,notnull"`                                    // what kind of thing it was done to
+	TargetID   string              `validate:"-" bun:",nullzero"`                                                   // database id of the thing it was done to, if it has one
+	Summary    string              `validate:"-" bun:",nullzero"`                                                   // human-readable description of the target, eg., a username or domain, since the target itself may since have been deleted
+}
+
+// AdminAuditLogAction is the kind of action that an admin audit log entry records.
+type AdminAuditLogAction string
+
+const (
+	// AdminAuditLogActionCreate means the target was created.
+	AdminAuditLogActionCreate AdminAuditLogAction = "create"
+	// AdminAuditLogActionUpdate means the target was changed.
+	AdminAuditLogActionUpdate AdminAuditLogAction = "update"
+	// AdminAuditLogActionDelete means the target was deleted.
+	AdminAuditLogActionDelete AdminAuditLogAction = "delete"
+	// AdminAuditLogActionSuspend means the target account was suspended.
+	AdminAuditLogActionSuspend AdminAuditLogAction = "suspend"
+	// AdminAuditLogActionUnsuspend means the suspension of the target account was lifted.
+	AdminAuditLogActionUnsuspend AdminAuditLogAction = "unsuspend"
+	// AdminAuditLogActionSilence means the target account was silenced.
+	AdminAuditLogActionSilence AdminAuditLogAction = "silence"
+	// AdminAuditLogActionUnsilence means the silence of the target account was lifted.
+	AdminAuditLogActionUnsilence AdminAuditLogAction = "unsilence"
+	// AdminAuditLogActionApprove means the target signup, spam flag or trending tag was approved.
+	AdminAuditLogActionApprove AdminAuditLogAction = "approve"
+	// AdminAuditLogActionReject means the target signup or trending tag was rejected.
+	AdminAuditLogActionReject AdminAuditLogAction = "reject"
+	// AdminAuditLogActionRotateKeys means the keys of the target account were rotated.
+	AdminAuditLogActionRotateKeys AdminAuditLogAction = "rotate_keys"
+	// AdminAuditLogActionAssign means the target report was assigned to the admin.
+	AdminAuditLogActionAssign AdminAuditLogAction = "assign"
+	// AdminAuditLogActionUnassign means the target report was unassigned.
+	AdminAuditLogActionUnassign AdminAuditLogAction = "unassign"
+	// AdminAuditLogActionResolve means the target report was resolved.
+	AdminAuditLogActionResolve AdminAuditLogAction = "resolve"
+	// AdminAuditLogActionReopen means the target report was reopened.
+	AdminAuditLogActionReopen AdminAuditLogAction = "reopen"
+	// AdminAuditLogActionWarn means the target account was given a warning.
+	AdminAuditLogActionWarn AdminAuditLogAction = "warn"
+)
+
+// AdminAuditLogTarget is the kind of thing that an admin audit log entry's action was taken on.
+type AdminAuditLogTarget string
+
+const (
+	// AdminAuditLogTargetAccount is an account.
+	AdminAuditLogTargetAccount AdminAuditLogTarget = "account"
+	// AdminAuditLogTargetDomainBlock is a domain block.
+	AdminAuditLogTargetDomainBlock AdminAuditLogTarget = "domain_block"
+	// AdminAuditLogTargetDomainAllow is a domain allow.
+	AdminAuditLogTargetDomainAllow AdminAuditLogTarget = "domain_allow"
+	// AdminAuditLogTargetDomainPolicy is a domain policy.
+	AdminAuditLogTargetDomainPolicy AdminAuditLogTarget = "domain_policy"
+	// AdminAuditLogTargetEmoji is a custom emoji.
+	AdminAuditLogTargetEmoji AdminAuditLogTarget = "emoji"
+	// AdminAuditLogTargetReport is a report.
+	AdminAuditLogTargetReport AdminAuditLogTarget = "report"
+	// AdminAuditLogTargetRule is an instance rule.
+	AdminAuditLogTargetRule AdminAuditLogTarget = "rule"
+	// AdminAuditLogTargetInstancePage is an instance page.
+	AdminAuditLogTargetInstancePage AdminAuditLogTarget = "instance_page"
+	// AdminAuditLogTargetInstance is the settings of this instance.
+	AdminAuditLogTargetInstance AdminAuditLogTarget = "instance"
+	// AdminAuditLogTargetSpamFlag is a status that was flagged as spam.
+	AdminAuditLogTargetSpamFlag AdminAuditLogTarget = "spam_flag"
+	// AdminAuditLogTargetTag is a trending hashtag.
+	AdminAuditLogTargetTag AdminAuditLogTarget = "tag"
+	// AdminAuditLogTargetModerationNote is a moderation note about an account.
+	AdminAuditLogTargetModerationNote AdminAuditLogTarget = "moderation_note"
+)
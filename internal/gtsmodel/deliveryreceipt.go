@@ -0,0 +1,38 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// DeliveryReceipt records the outcome of delivering one outgoing activity to one remote inbox. There's at most
+// one receipt per activity and inbox, which is updated with each delivery attempt, so that admins can check
+// whether an activity actually made it to each of its recipients.
+type DeliveryReceipt struct {
+	ID           string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ActivityURI  string    `validate:"required,url" bun:",nullzero,notnull,unique:activityinbox"`           // id of the activity that was delivered
+	ActivityType string    `validate:"-" bun:",nullzero"`                                                   // activitystreams type of the activity, eg 'Create'
+	ObjectURI    string    `validate:"omitempty,url" bun:",nullzero"`                                       // id of the object of the activity, if it had one
+	Domain       string    `validate:"required,fqdn" bun:",nullzero,notnull"`                               // domain of the inbox the activity was delivered to
+	InboxURI     string    `validate:"required,url" bun:",nullzero,notnull,unique:activityinbox"`           // inbox (or shared inbox) the activity was POSTed to
+	Delivered    bool      `validate:"-" bun:",notnull,default:false"`                                      // did the most recent attempt succeed?
+	Attempts     int       `validate:"min=1" bun:",notnull,default:1"`                                      // how many times has delivery been attempted?
+	LastError    string    `validate:"-" bun:""`                                                            // error returned from the most recent attempt, if it failed
+}
@@ -22,14 +22,29 @@ import "time"
 
 // DomainBlock represents a federation block against a particular domain
 type DomainBlock struct {
-	ID                 string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
-	CreatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Domain             string    `validate:"required,fqdn" bun:",nullzero,notnull"`                               // domain to block. Eg. 'whatever.com'
-	CreatedByAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // Account ID of the creator of this block
-	CreatedByAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
-	PrivateComment     string    `validate:"-" bun:""`                                                            // Private comment on this block, viewable to admins
-	PublicComment      string    `validate:"-" bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
-	Obfuscate          bool      `validate:"-" bun:",default:false"`                                              // whether the domain name should appear obfuscated when displaying it publicly
-	SubscriptionID     string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // if this block was created through a subscription, what's the subscription ID?
+	ID                 string              `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                   // id of this item in the database
+	CreatedAt          time.Time           `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item created
+	UpdatedAt          time.Time           `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item last updated
+	Domain             string              `validate:"required,fqdn" bun:",nullzero,notnull"`                                          // domain to block. Eg. 'whatever.com'
+	CreatedByAccountID string              `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                             // Account ID of the creator of this block
+	CreatedByAccount   *Account            `validate:"-" bun:"rel:belongs-to"`                                                         // Account corresponding to createdByAccountID
+	PrivateComment     string              `validate:"-" bun:""`                                                                       // Private comment on this block, viewable to admins
+	PublicComment      string              `validate:"-" bun:""`                                                                       // Public comment on this block, viewable (optionally) by everyone
+	Obfuscate          bool                `validate:"-" bun:",default:false"`                                                         // whether the domain name should appear obfuscated when displaying it publicly
+	SubscriptionID     string              `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                    // if this block was created through a subscription, what's the subscription ID?
+	Severity           DomainBlockSeverity `validate:"omitempty,oneof=suspend silence noop" bun:",nullzero,notnull,default:'suspend'"` // how severe is this block? only suspend cuts the domain off completely
+	RejectMedia        bool                `validate:"-" bun:",notnull,default:false"`                                                 // don't fetch media attachments, avatars or headers from this domain (for less severe blocks)
+	RejectReports      bool                `validate:"-" bun:",notnull,default:false"`                                                 // ignore reports (flags) sent from this domain (for less severe blocks)
 }
+
+// DomainBlockSeverity represents how severely a domain is blocked.
+type DomainBlockSeverity string
+
+const (
+	// DomainBlockSeveritySuspend means no federation at all takes place with the domain, and all its accounts are removed.
+	DomainBlockSeveritySuspend DomainBlockSeverity = "suspend"
+	// DomainBlockSeveritySilence means statuses from the domain are hidden from public timelines.
+	DomainBlockSeveritySilence DomainBlockSeverity = "silence"
+	// DomainBlockSeverityNoop means the block only applies its media and report rejections, if any.
+	DomainBlockSeverityNoop DomainBlockSeverity = "noop"
+)
@@ -0,0 +1,38 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// DomainPolicy represents a federation policy for a particular domain which is less severe than a full domain block.
+// Each of the policy flags can be set independently of the others.
+type DomainPolicy struct {
+	ID                 string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string    `validate:"required,fqdn" bun:",nullzero,notnull,unique"`                        // domain this policy applies to. Eg. 'whatever.com'
+	CreatedByAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // Account ID of the creator of this policy
+	CreatedByAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                              // Account corresponding to createdByAccountID
+	RejectMedia        bool      `validate:"-" bun:",notnull,default:false"`                                      // don't fetch media attachments, avatars or headers from this domain
+	SensitiveMedia     bool      `validate:"-" bun:",notnull,default:false"`                                      // mark all statuses with media from this domain as sensitive
+	RejectBoosts       bool      `validate:"-" bun:",notnull,default:false"`                                      // ignore boosts (announces) made by accounts on this domain
+	Silence            bool      `validate:"-" bun:",notnull,default:false"`                                      // hide statuses from this domain from public timelines, and notifications from accounts people don't follow
+	PrivateComment     string    `validate:"-" bun:""`                                                            // Private comment on this policy, viewable to admins
+	PublicComment      string    `validate:"-" bun:""`                                                            // Public comment on this policy, viewable (optionally) by everyone
+}
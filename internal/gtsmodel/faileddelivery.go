@@ -0,0 +1,36 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// FailedDelivery represents one POST of an activity to a remote inbox that could not be completed.
+// Failed deliveries are kept in the database so that they can be retried later, for example after
+// a remote instance comes back online following an outage.
+type FailedDelivery struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain    string    `validate:"required,fqdn" bun:",nullzero,notnull"`                               // domain of the inbox this delivery was destined for
+	InboxURI  string    `validate:"required,url" bun:",nullzero,notnull"`                                // inbox (or shared inbox) this delivery should be POSTed to
+	PubKeyID  string    `validate:"required,url" bun:",nullzero,notnull"`                                // public key id of the local account that signed the original delivery
+	Payload   string    `validate:"required" bun:",nullzero,notnull"`                                    // serialized activity to deliver
+	Attempts  int       `validate:"min=1" bun:",notnull,default:1"`                                      // how many times has delivery been attempted?
+	LastError string    `validate:"-" bun:""`                                                            // error returned from the most recent delivery attempt
+}
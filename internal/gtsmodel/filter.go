@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Filter refers to a set of keywords that an account doesn't want to see, or wants to be warned about, in certain contexts.
+type Filter struct {
+	ID                   string           `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt            time.Time        `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt            time.Time        `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ExpiresAt            time.Time        `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when does this filter stop being applied, if ever
+	AccountID            string           `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that owns the filter
+	Title                string           `validate:"required" bun:",nullzero,notnull"`                                    // user-defined title of the filter
+	Action               FilterAction     `validate:"oneof=warn hide" bun:",nullzero,notnull,default:'warn'"`              // what to do with statuses that match the filter
+	ContextHome          bool             `validate:"-" bun:",default:false"`                                              // apply the filter to the home timeline and lists
+	ContextNotifications bool             `validate:"-" bun:",default:false"`                                              // apply the filter to notifications
+	ContextPublic        bool             `validate:"-" bun:",default:false"`                                              // apply the filter to public timelines
+	ContextThread        bool             `validate:"-" bun:",default:false"`                                              // apply the filter to expanded threads
+	ContextAccount       bool             `validate:"-" bun:",default:false"`                                              // apply the filter to account profiles
+	Keywords             []*FilterKeyword `validate:"-" bun:"rel:has-many"`                                                // keywords that make up this filter
+}
+
+// FilterKeyword refers to one keyword or phrase of a filter.
+type FilterKeyword struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that owns the filter
+	FilterID  string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the filter this keyword belongs to
+	Filter    *Filter   `validate:"-" bun:"rel:belongs-to"`                                              // filter this keyword belongs to
+	Keyword   string    `validate:"required" bun:",nullzero,notnull"`                                    // the text to be filtered
+	WholeWord bool      `validate:"-" bun:",default:false"`                                              // should the keyword only match whole words
+}
+
+// FilterAction represents what should happen to a status that matches a filter.
+type FilterAction string
+
+const (
+	// FilterActionWarn means the status is shown with a warning naming the filter.
+	FilterActionWarn FilterAction = "warn"
+	// FilterActionHide means the status is not shown at all.
+	FilterActionHide FilterAction = "hide"
+)
+
+// FilterContext represents a place where statuses are shown, to which a filter can apply.
+type FilterContext string
+
+const (
+	// FilterContextHome is the home timeline and lists.
+	FilterContextHome FilterContext = "home"
+	// FilterContextNotifications is the notifications timeline.
+	FilterContextNotifications FilterContext = "notifications"
+	// FilterContextPublic is the public and tag timelines.
+	FilterContextPublic FilterContext = "public"
+	// FilterContextThread is the expanded thread of a status.
+	FilterContextThread FilterContext = "thread"
+	// FilterContextAccount is the statuses shown on an account profile.
+	FilterContextAccount FilterContext = "account"
+)
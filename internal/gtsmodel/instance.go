@@ -40,4 +40,5 @@ type Instance struct {
 	ContactAccount         *Account     `validate:"-" bun:"rel:belongs-to"`                                                           // account corresponding to contactAccountID
 	Reputation             int64        `validate:"-" bun:",notnull,default:0"`                                                       // Reputation score of this instance
 	Version                string       `validate:"-" bun:",nullzero"`                                                                // Version of the software used on this instance
+	CustomCSS              string       `validate:"-" bun:",nullzero"`                                                                // Custom CSS to use on all web pages of this instance
 }
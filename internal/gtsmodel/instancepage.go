@@ -0,0 +1,40 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// InstancePage represents a static page set by the admin of this instance, such as an about page or a privacy policy.
+type InstancePage struct {
+	ID          string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Slug        string    `validate:"required" bun:",nullzero,notnull,unique"`                             // short name of the page, used in its url, eg. about
+	Title       string    `validate:"required" bun:",nullzero,notnull"`                                    // title of the page
+	Source      string    `validate:"-" bun:""`                                                            // content of the page as written by the admin
+	ContentType string    `validate:"oneof=text/markdown text/html" bun:",nullzero,notnull"`               // format of the source: text/markdown or text/html
+	Content     string    `validate:"-" bun:""`                                                            // sanitized html content of the page, rendered from the source
+}
+
+const (
+	// InstancePageAbout is the slug of the page served at /about.
+	InstancePageAbout = "about"
+	// InstancePagePrivacy is the slug of the page served at /privacy.
+	InstancePagePrivacy = "privacy"
+)
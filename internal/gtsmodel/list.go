@@ -0,0 +1,55 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// List refers to a list of accounts, followed by the owner of the list, whose statuses are shown in a separate timeline.
+type List struct {
+	ID            string            `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt     time.Time         `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time         `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Title         string            `validate:"required" bun:",nullzero,notnull"`                                    // user-defined title of the list
+	AccountID     string            `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that owns the list
+	Account       *Account          `validate:"-" bun:"rel:belongs-to"`                                              // account that owns the list
+	RepliesPolicy ListRepliesPolicy `validate:"oneof=followed list none" bun:",nullzero,notnull,default:'followed'"` // which replies should be shown in the list timeline
+}
+
+// ListEntry refers to one account being a member of a list.
+type ListEntry struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                   // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item last updated
+	ListID    string    `validate:"required,ulid" bun:"type:CHAR(26),unique:listentrylistaccount,nullzero,notnull"` // id of the list this entry belongs to
+	List      *List     `validate:"-" bun:"rel:belongs-to"`                                                         // list this entry belongs to
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),unique:listentrylistaccount,nullzero,notnull"` // id of the account that is a member of the list
+	Account   *Account  `validate:"-" bun:"rel:belongs-to"`                                                         // account that is a member of the list
+}
+
+// ListRepliesPolicy represents which replies by list members are shown in a list timeline.
+type ListRepliesPolicy string
+
+const (
+	// ListRepliesPolicyFollowed means replies to any account followed by the list owner are shown.
+	ListRepliesPolicyFollowed ListRepliesPolicy = "followed"
+	// ListRepliesPolicyList means only replies to other members of the list are shown.
+	ListRepliesPolicyList ListRepliesPolicy = "list"
+	// ListRepliesPolicyNone means no replies to other accounts are shown.
+	ListRepliesPolicyNone ListRepliesPolicy = "none"
+)
@@ -107,6 +107,10 @@ type Original struct {
 	Height int     `validate:"required_with=Width Size Aspect"`   // height in pixels
 	Size   int     `validate:"required_with=Width Height Aspect"` // size in pixels (width * height)
 	Aspect float64 `validate:"required_with=Widhth Height Size"`  // aspect ratio (width / height)
+	// Below fields are only set for video
+	Duration  float32 `validate:"omitempty,min=0"` // duration in seconds
+	Framerate float32 `validate:"omitempty,min=0"` // frames per second
+	Bitrate   uint64  `validate:"omitempty,min=0"` // bits per second
 }
 
 // Focus describes the 'center' of the image for display purposes.
@@ -25,13 +25,15 @@ type Notification struct {
 	ID               string           `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                                                                                                    // id of this item in the database
 	CreatedAt        time.Time        `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                                                                                                             // when was item created
 	UpdatedAt        time.Time        `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                                                                                                             // when was item last updated                                                                                                                            // when was item created
-	NotificationType NotificationType `validate:"oneof=follow follow_request mention reblog favourite poll status" bun:",nullzero,notnull"`                                                                                                        // Type of this notification
+	NotificationType NotificationType `validate:"oneof=follow follow_request follow_reject mention reblog favourite poll status move admin.report admin.sign_up report_resolved" bun:",nullzero,notnull"`                                          // Type of this notification
 	TargetAccountID  string           `validate:"ulid" bun:"type:CHAR(26),nullzero,notnull"`                                                                                                                                                       // Which account does this notification target (ie., who will receive the notification?)
 	TargetAccount    *Account         `validate:"-" bun:"rel:belongs-to"`                                                                                                                                                                          // Which account performed the action that created this notification?
 	OriginAccountID  string           `validate:"ulid" bun:"type:CHAR(26),nullzero,notnull"`                                                                                                                                                       // ID of the account that performed the action that created the notification.
 	OriginAccount    *Account         `validate:"-" bun:"rel:belongs-to"`                                                                                                                                                                          // Account corresponding to originAccountID
 	StatusID         string           `validate:"required_if=NotificationType mention,required_if=NotificationType reblog,required_if=NotificationType favourite,required_if=NotificationType status,omitempty,ulid" bun:"type:CHAR(26),nullzero"` // If the notification pertains to a status, what is the database ID of that status?
 	Status           *Status          `validate:"-" bun:"rel:belongs-to"`                                                                                                                                                                          // Status corresponding to statusID
+	ReportID         string           `validate:"required_if=NotificationType admin.report,required_if=NotificationType report_resolved,omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                              // If the notification pertains to a report, what is the database ID of that report?
+	Report           *Report          `validate:"-" bun:"rel:belongs-to"`                                                                                                                                                                          // Report corresponding to reportID
 	Read             bool             `validate:"-" bun:",notnull,default:false"`                                                                                                                                                                  // Notification has been seen/read
 }
 
@@ -40,11 +42,36 @@ type NotificationType string
 
 // Notification Types
 const (
-	NotificationFollow        NotificationType = "follow"         // NotificationFollow -- someone followed you
-	NotificationFollowRequest NotificationType = "follow_request" // NotificationFollowRequest -- someone requested to follow you
-	NotificationMention       NotificationType = "mention"        // NotificationMention -- someone mentioned you in their status
-	NotificationReblog        NotificationType = "reblog"         // NotificationReblog -- someone boosted one of your statuses
-	NotificationFave          NotificationType = "favourite"      // NotificationFave -- someone faved/liked one of your statuses
-	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
-	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
+	NotificationFollow         NotificationType = "follow"          // NotificationFollow -- someone followed you
+	NotificationFollowRequest  NotificationType = "follow_request"  // NotificationFollowRequest -- someone requested to follow you
+	NotificationFollowReject   NotificationType = "follow_reject"   // NotificationFollowReject -- someone rejected your request to follow them
+	NotificationMention        NotificationType = "mention"         // NotificationMention -- someone mentioned you in their status
+	NotificationReblog         NotificationType = "reblog"          // NotificationReblog -- someone boosted one of your statuses
+	NotificationFave           NotificationType = "favourite"       // NotificationFave -- someone faved/liked one of your statuses
+	NotificationPoll           NotificationType = "poll"            // NotificationPoll -- a poll you voted in or created has ended
+	NotificationStatus         NotificationType = "status"          // NotificationStatus -- someone you enabled notifications for has posted a status.
+	NotificationMove           NotificationType = "move"            // NotificationMove -- someone you followed has moved to another account, and your follow has moved with them
+	NotificationAdminReport    NotificationType = "admin.report"    // NotificationAdminReport -- a new report has been filed, only sent to admins.
+	NotificationAdminSignUp    NotificationType = "admin.sign_up"   // NotificationAdminSignUp -- someone signed up and is waiting for approval, only sent to admins.
+	NotificationReportResolved NotificationType = "report_resolved" // NotificationReportResolved -- a report you filed has been resolved by a moderator
 )
+
+// NotificationPreferences holds the choices an account has made about which types of notification it wants to receive.
+// An account without stored preferences receives notifications of every type.
+type NotificationPreferences struct {
+	ID             string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt      time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt      time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID      string    `validate:"required,ulid" bun:"type:CHAR(26),unique,nullzero,notnull"`           // id of the account these preferences belong to
+	Follow         bool      `validate:"-" bun:",notnull,default:true"`                                       // create follow notifications?
+	FollowRequest  bool      `validate:"-" bun:",notnull,default:true"`                                       // create follow request notifications?
+	FollowReject   bool      `validate:"-" bun:",notnull,default:true"`                                       // create follow reject notifications?
+	Mention        bool      `validate:"-" bun:",notnull,default:true"`                                       // create mention notifications?
+	Reblog         bool      `validate:"-" bun:",notnull,default:true"`                                       // create reblog notifications?
+	Favourite      bool      `validate:"-" bun:",notnull,default:true"`                                       // create favourite notifications?
+	Poll           bool      `validate:"-" bun:",notnull,default:true"`                                       // create poll notifications?
+	Status         bool      `validate:"-" bun:",notnull,default:true"`                                       // create new status notifications?
+	AdminReport    bool      `validate:"-" bun:",notnull,default:true"`                                       // create new report notifications? (admins only)
+	ReportResolved bool      `validate:"-" bun:",notnull,default:true"`                                       // create resolved report notifications?
+	AdminSignUp    bool      `validate:"-" bun:",notnull,default:true"`                                       // create new sign up notifications? (admins only)
+}
@@ -0,0 +1,33 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// PasswordReset represents a request to reset the password of a local user, which can be completed by following the link
+// emailed to their confirmed email address. Only a hash of the token in the link is stored, so that a leaked database
+// can't be used to take over accounts.
+type PasswordReset struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	UserID    string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the user whose password can be reset
+	TokenHash string    `validate:"required" bun:",nullzero,notnull,unique"`                             // hex encoded sha256 hash of the token in the emailed link
+	ExpiresAt time.Time `validate:"required" bun:"type:timestamptz,nullzero,notnull"`                    // after this time the link stops working
+}
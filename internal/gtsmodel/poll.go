@@ -0,0 +1,52 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Poll represents a poll attached to a status.
+type Poll struct {
+	ID         string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	StatusID   string    `validate:"required,ulid" bun:"type:CHAR(26),unique,nullzero,notnull"`           // id of the status this poll is attached to
+	Status     *Status   `validate:"-" bun:"rel:belongs-to"`                                              // status corresponding to statusID
+	Options    []string  `validate:"min=2,dive,required" bun:"options,array"`                             // the answers that can be chosen, in order
+	Multiple   bool      `validate:"-" bun:",notnull,default:false"`                                      // can more than one option be chosen?
+	HideCounts bool      `validate:"-" bun:",notnull,default:false"`                                      // hide vote counts until the poll has ended?
+	ExpiresAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when does the poll end? zero means never
+	ClosedAt   time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when was the poll closed and its voters notified, if it has been
+}
+
+// Expired returns true if the poll has ended, and can't be voted in anymore.
+func (p *Poll) Expired() bool {
+	return !p.ExpiresAt.IsZero() && !p.ExpiresAt.After(time.Now())
+}
+
+// PollVote represents one option chosen by an account in a poll. An account that chooses
+// several options in a multiple choice poll has one PollVote for each of them.
+type PollVote struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	PollID    string    `validate:"required,ulid" bun:"type:CHAR(26),unique:pollvote,nullzero,notnull"`  // id of the poll voted in
+	Poll      *Poll     `validate:"-" bun:"rel:belongs-to"`                                              // poll corresponding to pollID
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),unique:pollvote,nullzero,notnull"`  // id of the account that voted
+	Account   *Account  `validate:"-" bun:"rel:belongs-to"`                                              // account corresponding to accountID
+	Choice    int       `validate:"min=0" bun:",unique:pollvote,notnull"`                                // index of the chosen option in the poll's options
+}
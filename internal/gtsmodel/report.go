@@ -0,0 +1,57 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Report models a user, local or remote, reporting an account and optionally some of its statuses to the moderators of an instance.
+type Report struct {
+	ID                     string         `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`             // id of this item in the database
+	CreatedAt              time.Time      `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`      // when was item created
+	UpdatedAt              time.Time      `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`      // when was item last updated
+	URI                    string         `validate:"required,url" bun:",unique,nullzero,notnull"`                              // activitypub URI of the Flag that this report was created from, or that was/will be federated for it
+	AccountID              string         `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                       // id of the account that filed the report; for remote reports this is often the instance account of the remote instance
+	Account                *Account       `validate:"-" bun:"rel:belongs-to"`                                                   // account corresponding to accountID
+	TargetAccountID        string         `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                       // id of the account that was reported
+	TargetAccount          *Account       `validate:"-" bun:"rel:belongs-to"`                                                   // account corresponding to targetAccountID
+	StatusIDs              []string       `validate:"dive,ulid" bun:"statuses,array"`                                           // database IDs of any statuses of the target account that were reported along with it
+	RuleIDs                []string       `validate:"dive,ulid" bun:"rules,array"`                                              // database IDs of any instance rules that the reporter says the target account broke
+	Comment                string         `validate:"-" bun:",nullzero"`                                                        // comment given by the reporter about why they filed the report
+	Category               ReportCategory `validate:"oneof=spam legal violation other" bun:",nullzero,notnull,default:'other'"` // what kind of problem the reporter is flagging
+	Forwarded              bool           `validate:"-" bun:",notnull,default:false"`                                           // should a Flag for this report be federated to the instance of the target account (only applies to local reports on remote accounts)
+	ActionTaken            bool           `validate:"-" bun:",notnull,default:false"`                                           // has a moderator resolved this report yet?
+	ActionTakenAt          time.Time      `validate:"-" bun:"type:timestamptz,nullzero"`                                        // when was this report resolved
+	ActionTakenByAccountID string         `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                              // id of the moderator account that resolved this report
+	ActionTakenComment     string         `validate:"-" bun:",nullzero"`                                                        // note left by the moderator about what was done to resolve this report
+	AssignedAccountID      string         `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                              // id of the moderator account that is handling this report
+}
+
+// ReportCategory represents the kind of problem that a report is about.
+type ReportCategory string
+
+const (
+	// ReportCategorySpam means the reported account or statuses are spam.
+	ReportCategorySpam ReportCategory = "spam"
+	// ReportCategoryLegal means the reported account or statuses are illegal.
+	ReportCategoryLegal ReportCategory = "legal"
+	// ReportCategoryViolation means the reported account or statuses violate the rules of the instance.
+	ReportCategoryViolation ReportCategory = "violation"
+	// ReportCategoryOther means the report doesn't fit into any other category.
+	ReportCategoryOther ReportCategory = "other"
+)
@@ -0,0 +1,50 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// SpamFlag represents an incoming federated status that scored too highly on the spam checks,
+// and which is waiting for a moderator to look at it.
+type SpamFlag struct {
+	ID        string     `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	StatusID  string     `validate:"required,ulid" bun:"type:CHAR(26),unique,nullzero,notnull"`           // id of the flagged status
+	Status    *Status    `validate:"-" bun:"rel:belongs-to"`                                              // flagged status corresponding to statusID
+	AccountID string     `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that posted the flagged status
+	Account   *Account   `validate:"-" bun:"rel:belongs-to"`                                              // account corresponding to accountID
+	Score     int        `validate:"-" bun:",notnull,default:0"`                                          // spam score the status got
+	Reasons   []string   `validate:"-" bun:"reasons,array"`                                               // human-readable reasons for the score
+	Action    SpamAction `validate:"oneof=report hold" bun:",nullzero,notnull"`                           // what was done with the status
+}
+
+// SpamAction describes what is done with an incoming status that looks like spam.
+type SpamAction string
+
+const (
+	// SpamActionNone means the status is processed as normal.
+	SpamActionNone SpamAction = ""
+	// SpamActionReport means the status is processed as normal, but flagged for moderators to look at.
+	SpamActionReport SpamAction = "report"
+	// SpamActionHold means the status is kept out of timelines and notifications until a moderator releases it.
+	SpamActionHold SpamAction = "hold"
+	// SpamActionDrop means the status is deleted straight away.
+	SpamActionDrop SpamAction = "drop"
+)
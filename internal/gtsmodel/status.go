@@ -27,6 +27,7 @@ type Status struct {
 	ID                       string             `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                              // id of this item in the database
 	CreatedAt                time.Time          `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                       // when was item created
 	UpdatedAt                time.Time          `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                       // when was item last updated
+	EditedAt                 time.Time          `validate:"-" bun:"type:timestamptz,nullzero"`                                                         // when was this status last edited by its author, if ever
 	URI                      string             `validate:"required,url" bun:",unique,nullzero,notnull"`                                               // activitypub URI of this status
 	URL                      string             `validate:"url" bun:",nullzero"`                                                                       // web url for viewing this status
 	Content                  string             `validate:"-" bun:""`                                                                                  // content of this status; likely html-formatted but not guaranteed
@@ -62,8 +63,12 @@ type Status struct {
 	Pinned                   bool               `validate:"-" bun:",notnull,default:false"`                                                            // Has this status been pinned by its owner?
 	Federated                bool               `validate:"-" bun:",notnull"`                                                                          // This status will be federated beyond the local timeline(s)
 	Boostable                bool               `validate:"-" bun:",notnull"`                                                                          // This status can be boosted/reblogged
+	BoostPolicy              InteractionPolicy  `validate:"omitempty,oneof=public followers mentioned" bun:",nullzero"`                                // Who, apart from the author, may boost this status if it's boostable; empty means the same as public
 	Replyable                bool               `validate:"-" bun:",notnull"`                                                                          // This status can be replied to
+	ReplyPolicy              InteractionPolicy  `validate:"omitempty,oneof=public followers mentioned" bun:",nullzero"`                                // Who, apart from the author, may reply to this status if it's replyable; empty means the same as public
 	Likeable                 bool               `validate:"-" bun:",notnull"`                                                                          // This status can be liked/faved
+	LikePolicy               InteractionPolicy  `validate:"omitempty,oneof=public followers mentioned" bun:",nullzero"`                                // Who, apart from the author, may like this status if it's likeable; empty means the same as public
+	Poll                     *Poll              `validate:"-" bun:"-"`                                                                                 // poll attached to this status; only set when the status is being created, otherwise look it up by status ID
 }
 
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
@@ -99,3 +104,15 @@ const (
 	// VisibilityDefault is used when no other setting can be found.
 	VisibilityDefault Visibility = VisibilityUnlocked
 )
+
+// InteractionPolicy represents who is permitted to reply to, boost, or like a status.
+type InteractionPolicy string
+
+const (
+	// InteractionPolicyPublic means anyone who can see the status can interact with it.
+	InteractionPolicyPublic InteractionPolicy = "public"
+	// InteractionPolicyFollowers means only followers of the author, and accounts mentioned in the status, can interact with it.
+	InteractionPolicyFollowers InteractionPolicy = "followers"
+	// InteractionPolicyMentioned means only accounts mentioned in the status can interact with it.
+	InteractionPolicyMentioned InteractionPolicy = "mentioned"
+)
@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// StatusEdit is a snapshot of a previous version of a status, stored whenever the status is edited by its author.
+// Together with the current version of the status, these make up the edit history of the status.
+type StatusEdit struct {
+	ID             string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt      time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was this version of the status created
+	StatusID       string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // database id of the status that this is a previous version of
+	AccountID      string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that authored the status
+	Content        string    `validate:"-" bun:""`                                                            // content of this version of the status; likely html-formatted but not guaranteed
+	Text           string    `validate:"-" bun:""`                                                            // original text of this version of the status without formatting, only set for local statuses
+	ContentWarning string    `validate:"-" bun:",nullzero"`                                                   // cw string for this version of the status
+	Sensitive      bool      `validate:"-" bun:",default:false"`                                              // was this version of the status marked sensitive
+}
@@ -0,0 +1,37 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// StatusReaction refers to an emoji reaction to a status, from one account, targeting the status of another account.
+// Emoji reactions are sent by eg., Pleroma and Misskey as Like activities that carry the reaction in their content.
+type StatusReaction struct {
+	ID              string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID       string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that reacted to the status
+	Account         *Account  `validate:"-" bun:"rel:belongs-to"`                                              // account that reacted to the status
+	TargetAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id the account owning the status that was reacted to
+	TargetAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                              // account owning the status that was reacted to
+	StatusID        string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // database id of the status that was reacted to
+	Status          *Status   `validate:"-" bun:"rel:belongs-to"`                                              // the status that was reacted to
+	Content         string    `validate:"required" bun:",nullzero,notnull"`                                    // the reaction itself: either a unicode emoji, or a custom emoji shortcode like :blobcat:
+	URI             string    `validate:"required,url" bun:",unique,nullzero,notnull"`                         // ActivityPub URI of this reaction
+}
@@ -31,4 +31,6 @@ type Tag struct {
 	Useable                bool      `validate:"-" bun:",notnull,default:true"`                                       // can our instance users use this tag?
 	Listable               bool      `validate:"-" bun:",notnull,default:true"`                                       // can our instance users look up this tag?
 	LastStatusAt           time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was this tag last used?
+	Trendable              bool      `validate:"-" bun:",notnull,default:false"`                                      // has an admin approved this tag to be shown in trends?
+	ReviewedAt             time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // when did an admin last approve or reject this tag for trends?
 }
@@ -0,0 +1,32 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// TagFollow represents one account following a hashtag, so that public statuses using the tag show up in its home timeline.
+type TagFollow struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`              // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`       // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`       // when was item last updated
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),unique:tagfollowsrctag,notnull,nullzero"` // id of the account that follows the tag
+	Account   *Account  `validate:"-" bun:"rel:belongs-to"`                                                    // pointer to the account specified by accountID
+	TagID     string    `validate:"required,ulid" bun:"type:CHAR(26),unique:tagfollowsrctag,notnull,nullzero"` // id of the followed tag
+	Tag       *Tag      `validate:"-" bun:"rel:belongs-to"`                                                    // pointer to the tag specified by tagID
+}
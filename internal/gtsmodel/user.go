@@ -43,6 +43,11 @@ type User struct {
 	ChosenLanguages        []string     `validate:"-" bun:",nullzero"`                                                   // What languages does this user want to see?
 	FilteredLanguages      []string     `validate:"-" bun:",nullzero"`                                                   // What languages does this user not want to see?
 	Locale                 string       `validate:"-" bun:",nullzero"`                                                   // In what timezone/locale is this user located?
+	ExpandMedia            string       `validate:"omitempty,oneof=default show_all hide_all" bun:",nullzero"`           // How should this user be shown media attachments: default (hide sensitive media), show_all, or hide_all?
+	ExpandSpoilers         bool         `validate:"-" bun:",notnull,default:false"`                                      // Should content warnings be expanded for this user by default?
+	StatusExpiryDays       int          `validate:"min=0" bun:",notnull,default:0"`                                      // After how many days should this user's statuses be deleted automatically? 0 means they're kept forever.
+	StatusExpiryKeepPinned bool         `validate:"-" bun:",notnull,default:false"`                                      // Should pinned statuses be kept when this user's old statuses are deleted?
+	StatusExpiryKeepFaved  bool         `validate:"-" bun:",notnull,default:false"`                                      // Should statuses this user has faved themselves be kept when this user's old statuses are deleted?
 	CreatedByApplicationID string       `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // Which application id created this user? See gtsmodel.Application
 	CreatedByApplication   *Application `validate:"-" bun:"rel:belongs-to"`                                              // Pointer to the application corresponding to createdbyapplicationID.
 	LastEmailedAt          time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When was this user last contacted by email.
@@ -54,6 +59,16 @@ type User struct {
 	Admin                  bool         `validate:"-" bun:",notnull,default:false"`                                      // Is this user an admin?
 	Disabled               bool         `validate:"-" bun:",notnull,default:false"`                                      // Is this user disabled from posting?
 	Approved               bool         `validate:"-" bun:",notnull,default:false"`                                      // Has this user been approved by a moderator?
+	RulesAcknowledgedAt    time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When did this user acknowledge the rules of the instance while signing up? Zero if there were no rules to acknowledge.
 	ResetPasswordToken     string       `validate:"required_with=ResetPasswordSentAt" bun:",nullzero"`                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `validate:"required_with=ResetPasswordToken" bun:"type:timestamptz,nullzero"`    // When did we email the user their reset-password email?
 }
+
+const (
+	// ExpandMediaDefault means media marked as sensitive is hidden from the user, and other media is shown.
+	ExpandMediaDefault = "default"
+	// ExpandMediaShowAll means all media is shown to the user, regardless of sensitivity.
+	ExpandMediaShowAll = "show_all"
+	// ExpandMediaHideAll means all media is hidden from the user, regardless of sensitivity.
+	ExpandMediaHideAll = "hide_all"
+)
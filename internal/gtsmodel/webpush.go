@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents a subscription by a client application to receive push notifications
+// for an account, delivered to a push endpoint using the Web Push protocol.
+type WebPushSubscription struct {
+	ID                 string        `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`           // id of this item in the database
+	CreatedAt          time.Time     `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`    // when was item created
+	UpdatedAt          time.Time     `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`    // when was item last updated
+	AccountID          string        `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                     // id of the account that notifications are pushed for
+	TokenID            string        `validate:"required,ulid" bun:"type:CHAR(26),unique,nullzero,notnull"`              // id of the oauth token the subscription was created with; each token has at most one subscription
+	Endpoint           string        `validate:"required,url" bun:",nullzero,notnull"`                                   // url to POST push messages to
+	Auth               string        `validate:"required" bun:",nullzero,notnull"`                                       // base64url encoded auth secret from the client, for encrypting push messages
+	P256dh             string        `validate:"required" bun:",nullzero,notnull"`                                       // base64url encoded P-256 public key from the client, for encrypting push messages
+	AlertFollow        bool          `validate:"-" bun:",notnull,default:false"`                                         // push follow notifications?
+	AlertFollowRequest bool          `validate:"-" bun:",notnull,default:false"`                                         // push follow request notifications?
+	AlertFavourite     bool          `validate:"-" bun:",notnull,default:false"`                                         // push favourite notifications?
+	AlertMention       bool          `validate:"-" bun:",notnull,default:false"`                                         // push mention notifications?
+	AlertReblog        bool          `validate:"-" bun:",notnull,default:false"`                                         // push reblog notifications?
+	AlertPoll          bool          `validate:"-" bun:",notnull,default:false"`                                         // push poll notifications?
+	AlertStatus        bool          `validate:"-" bun:",notnull,default:false"`                                         // push new status notifications?
+	Policy             WebPushPolicy `validate:"oneof=all followed follower none" bun:",nullzero,notnull,default:'all'"` // whose notifications to push
+}
+
+// WebPushPolicy describes whose notifications are pushed to a web push subscription.
+type WebPushPolicy string
+
+const (
+	// WebPushPolicyAll means notifications from anyone are pushed.
+	WebPushPolicyAll WebPushPolicy = "all"
+	// WebPushPolicyFollowed means only notifications from accounts the subscriber follows are pushed.
+	WebPushPolicyFollowed WebPushPolicy = "followed"
+	// WebPushPolicyFollower means only notifications from accounts following the subscriber are pushed.
+	WebPushPolicyFollower WebPushPolicy = "follower"
+	// WebPushPolicyNone means no notifications are pushed.
+	WebPushPolicyNone WebPushPolicy = "none"
+)
+
+// VAPIDKeyPair is the instance's key pair for identifying itself to push services, as described in RFC 8292.
+// There is only ever one of these, created the first time it's needed.
+type VAPIDKeyPair struct {
+	ID         string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt  time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	PublicKey  string    `validate:"required" bun:",nullzero,notnull"`                                    // base64url encoded uncompressed P-256 public key
+	PrivateKey string    `validate:"required" bun:",nullzero,notnull"`                                    // base64url encoded P-256 private key
+}
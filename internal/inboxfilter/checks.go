@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package inboxfilter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/spam"
+)
+
+// incomingStatus returns the status created by the given activity, or nil if it doesn't create a status.
+func incomingStatus(msg messages.FromFederator) *gtsmodel.Status {
+	if msg.APActivityType != ap.ActivityCreate || msg.APObjectType != ap.ObjectNote {
+		return nil
+	}
+	status, ok := msg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return nil
+	}
+	return status
+}
+
+type mentionCheck struct {
+	max int
+}
+
+// NewMentionCheck returns a check which rejects incoming statuses that mention more than max accounts.
+func NewMentionCheck(max int) Check {
+	return &mentionCheck{
+		max: max,
+	}
+}
+
+func (c *mentionCheck) Name() string {
+	return "mentions"
+}
+
+func (c *mentionCheck) Check(ctx context.Context, msg messages.FromFederator) (string, error) {
+	status := incomingStatus(msg)
+	if status == nil {
+		return "", nil
+	}
+
+	mentions := len(status.Mentions)
+	if len(status.MentionIDs) > mentions {
+		mentions = len(status.MentionIDs)
+	}
+	if mentions <= c.max {
+		return "", nil
+	}
+	return fmt.Sprintf("mentions %d accounts, but the limit is %d", mentions, c.max), nil
+}
+
+type statusRuleCheck struct {
+	name string
+	rule spam.Rule
+}
+
+// NewStatusRuleCheck returns a check which rejects any incoming status that the given spam rule gives a score to,
+// so that the spam rules can be used to reject statuses outright rather than just scoring them.
+func NewStatusRuleCheck(name string, rule spam.Rule) Check {
+	return &statusRuleCheck{
+		name: name,
+		rule: rule,
+	}
+}
+
+func (c *statusRuleCheck) Name() string {
+	return c.name
+}
+
+func (c *statusRuleCheck) Check(ctx context.Context, msg messages.FromFederator) (string, error) {
+	status := incomingStatus(msg)
+	if status == nil {
+		return "", nil
+	}
+
+	score, reason, err := c.rule.Score(ctx, status)
+	if err != nil {
+		return "", err
+	}
+	if score == 0 {
+		return "", nil
+	}
+	return reason, nil
+}
@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package inboxfilter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/spam"
+)
+
+// Check inspects one incoming federated activity before it's processed, and can veto it.
+type Check interface {
+	// Name returns a short name for the check, which is used in logs and reports.
+	Name() string
+	// Check returns a short human-readable reason if the activity should be rejected,
+	// or an empty string if the check is happy to let it through.
+	Check(ctx context.Context, msg messages.FromFederator) (string, error)
+}
+
+// Veto describes an incoming activity that was rejected by one of the checks of a chain.
+type Veto struct {
+	// Check is the name of the check that rejected the activity.
+	Check string
+	// Reason is the reason the check gave for rejecting it.
+	Reason string
+}
+
+// Chain runs incoming federated activities through a series of checks.
+type Chain interface {
+	// Filter runs the activity through each check in turn, stopping at the first one which vetoes it.
+	// If none of the checks veto the activity, then nil will be returned.
+	Filter(ctx context.Context, msg messages.FromFederator) (*Veto, error)
+	// Use adds the given checks to the end of the chain.
+	Use(checks ...Check)
+}
+
+type chain struct {
+	checks []Check
+}
+
+// New returns a new Chain using the built-in checks which are enabled in the given config.
+// Further checks can be plugged in with Use.
+func New(c *config.Config) Chain {
+	return NewChain(DefaultChecks(c.InboxFilterConfig)...)
+}
+
+// NewChain returns a new Chain which runs activities through the given checks, in order.
+func NewChain(checks ...Check) Chain {
+	return &chain{
+		checks: checks,
+	}
+}
+
+// DefaultChecks returns the built-in checks which are enabled in the given config.
+func DefaultChecks(c *config.InboxFilterConfig) []Check {
+	checks := []Check{}
+	if c.MaxMentions > 0 {
+		checks = append(checks, NewMentionCheck(c.MaxMentions))
+	}
+	if c.RejectLinkOnly {
+		checks = append(checks, NewStatusRuleCheck("link-only", spam.NewLinkOnlyRule(c.NewAccountHours, 1)))
+	}
+	if len(c.RejectKeywords) != 0 {
+		checks = append(checks, NewStatusRuleCheck("keyword", spam.NewKeywordRule(c.RejectKeywords, 1)))
+	}
+	return checks
+}
+
+func (c *chain) Filter(ctx context.Context, msg messages.FromFederator) (*Veto, error) {
+	for _, check := range c.checks {
+		reason, err := check.Check(ctx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("error running %s check: %s", check.Name(), err)
+		}
+		if reason != "" {
+			return &Veto{
+				Check:  check.Name(),
+				Reason: reason,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *chain) Use(checks ...Check) {
+	c.checks = append(c.checks, checks...)
+}
@@ -0,0 +1,115 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package inboxfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+type InboxFilterTestSuite struct {
+	suite.Suite
+}
+
+type fixedCheck string
+
+func (c fixedCheck) Name() string {
+	return "fixed"
+}
+
+func (c fixedCheck) Check(ctx context.Context, msg messages.FromFederator) (string, error) {
+	return string(c), nil
+}
+
+func createNote(status *gtsmodel.Status) messages.FromFederator {
+	return messages.FromFederator{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       status,
+	}
+}
+
+func (suite *InboxFilterTestSuite) TestChainStopsAtFirstVeto() {
+	chain := NewChain(fixedCheck(""), fixedCheck("first"))
+	chain.Use(fixedCheck("second"))
+
+	veto, err := chain.Filter(context.Background(), createNote(&gtsmodel.Status{}))
+	suite.NoError(err)
+	suite.NotNil(veto)
+	suite.Equal("fixed", veto.Check)
+	suite.Equal("first", veto.Reason)
+
+	// a chain with no checks lets everything through
+	veto, err = NewChain().Filter(context.Background(), createNote(&gtsmodel.Status{}))
+	suite.NoError(err)
+	suite.Nil(veto)
+}
+
+func (suite *InboxFilterTestSuite) TestMentions() {
+	c := NewMentionCheck(2)
+	status := &gtsmodel.Status{
+		Mentions: []*gtsmodel.Mention{{}, {}},
+	}
+
+	reason, err := c.Check(context.Background(), createNote(status))
+	suite.NoError(err)
+	suite.Empty(reason)
+
+	status.Mentions = append(status.Mentions, &gtsmodel.Mention{})
+	reason, err = c.Check(context.Background(), createNote(status))
+	suite.NoError(err)
+	suite.Equal("mentions 3 accounts, but the limit is 2", reason)
+
+	// only incoming statuses are checked
+	reason, err = c.Check(context.Background(), messages.FromFederator{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+	})
+	suite.NoError(err)
+	suite.Empty(reason)
+}
+
+func (suite *InboxFilterTestSuite) TestDefaultChecks() {
+	c := &config.InboxFilterConfig{}
+	suite.Empty(DefaultChecks(c))
+
+	c.MaxMentions = 10
+	c.RejectLinkOnly = true
+	c.NewAccountHours = 24
+	c.RejectKeywords = []string{"cheap followers"}
+	checks := DefaultChecks(c)
+	suite.Len(checks, 3)
+
+	veto, err := NewChain(checks...).Filter(context.Background(), createNote(&gtsmodel.Status{Content: "<p>get CHEAP FOLLOWERS here</p>"}))
+	suite.NoError(err)
+	suite.NotNil(veto)
+	suite.Equal("keyword", veto.Check)
+	suite.Equal("contains spam keyword(s): cheap followers", veto.Reason)
+}
+
+func TestInboxFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(InboxFilterTestSuite))
+}
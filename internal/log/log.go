@@ -20,19 +20,23 @@ package log
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 
 	"github.com/sirupsen/logrus"
 )
 
-// New returns a new logrus logger with the specified level,
-// or an error if that level can't be parsed. It also sets
-// the output to log.outputSplitter, so you get error logs
-// on stderr and normal logs on stdout.
-func New(level string) (*logrus.Logger, error) {
+// New returns a new logrus logger with the specified level and format,
+// or an error if either can't be parsed. The format can be "text" or "json".
+//
+// It also sets the output to log.outputSplitter, so you get error logs
+// on stderr and normal logs on stdout, and adds a hook that includes
+// the request id as a field on entries logged with a request context.
+func New(level string, format string) (*logrus.Logger, error) {
 	log := logrus.New()
 
 	log.SetOutput(&outputSplitter{})
+	log.AddHook(&requestIDHook{})
 
 	logLevel, err := logrus.ParseLevel(level)
 	if err != nil {
@@ -44,11 +48,18 @@ func New(level string) (*logrus.Logger, error) {
 		log.SetReportCaller(true)
 	}
 
-	log.SetFormatter(&logrus.TextFormatter{
-		DisableColors: true,
-		ForceQuote:    true,
-		FullTimestamp: true,
-	})
+	switch format {
+	case "text":
+		log.SetFormatter(&logrus.TextFormatter{
+			DisableColors: true,
+			ForceQuote:    true,
+			FullTimestamp: true,
+		})
+	case "json":
+		log.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return nil, fmt.Errorf("log format %s not recognised, must be one of text or json", format)
+	}
 
 	return log, nil
 }
@@ -59,7 +70,7 @@ func New(level string) (*logrus.Logger, error) {
 type outputSplitter struct{}
 
 func (splitter *outputSplitter) Write(p []byte) (n int, err error) {
-	if bytes.Contains(p, []byte("level=error")) {
+	if bytes.Contains(p, []byte("level=error")) || bytes.Contains(p, []byte(`level="error"`)) || bytes.Contains(p, []byte(`"level":"error"`)) {
 		return os.Stderr.Write(p)
 	}
 	return os.Stdout.Write(p)
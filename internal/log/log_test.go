@@ -0,0 +1,74 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+type LogTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LogTestSuite) TestNewBadFormat() {
+	_, err := log.New("info", "xml")
+	suite.EqualError(err, "log format xml not recognised, must be one of text or json")
+}
+
+func (suite *LogTestSuite) TestRequestIDJSON() {
+	logger, err := log.New("info", "json")
+	suite.NoError(err)
+	b := &bytes.Buffer{}
+	logger.SetOutput(b)
+
+	ctx := log.WithRequestID(context.Background(), "01FJ6V6NXKHWJQF6RB9BZZ4YWR")
+	suite.Equal("01FJ6V6NXKHWJQF6RB9BZZ4YWR", log.RequestID(ctx))
+
+	logger.WithContext(ctx).WithField("func", "TestRequestIDJSON").Info("hello")
+
+	entry := map[string]interface{}{}
+	suite.NoError(json.Unmarshal(b.Bytes(), &entry))
+	suite.Equal("01FJ6V6NXKHWJQF6RB9BZZ4YWR", entry["requestID"])
+	suite.Equal("TestRequestIDJSON", entry["func"])
+	suite.Equal("hello", entry["msg"])
+	suite.Equal("info", entry["level"])
+}
+
+func (suite *LogTestSuite) TestNoRequestID() {
+	logger, err := log.New("info", "text")
+	suite.NoError(err)
+	b := &bytes.Buffer{}
+	logger.SetOutput(b)
+
+	suite.Empty(log.RequestID(context.Background()))
+
+	logger.WithContext(context.Background()).Info("hello")
+	logger.Info("hello again")
+	suite.NotContains(b.String(), "requestID")
+}
+
+func TestLogTestSuite(t *testing.T) {
+	suite.Run(t, &LogTestSuite{})
+}
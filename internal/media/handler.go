@@ -60,6 +60,9 @@ const (
 
 	// EmojiMaxBytes is the maximum permitted bytes of an emoji upload (50kb)
 	EmojiMaxBytes = 51200
+	// RemoteEmojiMaxBytes is the maximum permitted bytes of an emoji fetched from a remote instance (256kb),
+	// which is as big as Mastodon allows its own custom emoji uploads to be
+	RemoteEmojiMaxBytes = 262144
 )
 
 // Handler provides an interface for parsing, storing, and retrieving media objects like photos, videos, and gifs.
@@ -80,6 +83,11 @@ type Handler interface {
 	// in the database.
 	ProcessLocalEmoji(ctx context.Context, emojiBytes []byte, shortcode string) (*gtsmodel.Emoji, error)
 
+	// ProcessRemoteEmoji takes the bytes of an emoji fetched from a remote instance, and an emoji with at least the
+	// URI, Shortcode, Domain and ImageRemoteURL set on it, cleans up the image, puts it in storage, and returns a new
+	// *gts.Emoji for it. It's the caller's responsibility to put the returned struct in the database.
+	ProcessRemoteEmoji(ctx context.Context, emojiBytes []byte, minEmoji *gtsmodel.Emoji) (*gtsmodel.Emoji, error)
+
 	ProcessRemoteHeaderOrAvatar(ctx context.Context, t transport.Transport, currentAttachment *gtsmodel.MediaAttachment, accountID string) (*gtsmodel.MediaAttachment, error)
 }
 
@@ -108,7 +116,7 @@ func New(config *config.Config, database db.DB, storage *kv.KVStore, log *logrus
 // puts it in whatever storage backend we're using, sets the relevant fields in the database for the new image,
 // and then returns information to the caller about the new header.
 func (mh *mediaHandler) ProcessHeaderOrAvatar(ctx context.Context, attachment []byte, accountID string, mediaType Type, remoteURL string) (*gtsmodel.MediaAttachment, error) {
-	l := mh.log.WithField("func", "SetHeaderForAccountID")
+	l := mh.log.WithContext(ctx).WithField("func", "SetHeaderForAccountID")
 
 	if mediaType != Header && mediaType != Avatar {
 		return nil, errors.New("header or avatar not selected")
@@ -126,7 +134,7 @@ func (mh *mediaHandler) ProcessHeaderOrAvatar(ctx context.Context, attachment []
 	if len(attachment) == 0 {
 		return nil, fmt.Errorf("passed reader was of size 0")
 	}
-	l.Tracef("read %d bytes of file", len(attachment))
+	l.WithField("bytes", len(attachment)).Trace("read file")
 
 	// process it
 	ma, err := mh.processHeaderOrAvi(attachment, contentType, mediaType, accountID, remoteURL)
@@ -155,14 +163,14 @@ func (mh *mediaHandler) ProcessAttachment(ctx context.Context, attachmentBytes [
 
 	mainType := strings.Split(contentType, "/")[0]
 	switch mainType {
-	// case MIMEVideo:
-	// 	if !SupportedVideoType(contentType) {
-	// 		return nil, fmt.Errorf("video type %s not supported", contentType)
-	// 	}
-	// 	if len(attachment) == 0 {
-	// 		return nil, errors.New("video was of size 0")
-	// 	}
-	// 	return mh.processVideoAttachment(attachment, accountID, contentType, remoteURL)
+	case MIMEVideo:
+		if !SupportedVideoType(contentType) {
+			return nil, fmt.Errorf("video type %s not supported", contentType)
+		}
+		if len(attachmentBytes) == 0 {
+			return nil, errors.New("video was of size 0")
+		}
+		return mh.processVideoAttachment(ctx, attachmentBytes, minAttachment)
 	case MIMEImage:
 		if !SupportedImageType(contentType) {
 			return nil, fmt.Errorf("image type %s not supported", contentType)
@@ -181,6 +189,46 @@ func (mh *mediaHandler) ProcessAttachment(ctx context.Context, attachmentBytes [
 // *gts.Emoji for it, then returns it to the caller. It's the caller's responsibility to put the returned struct
 // in the database.
 func (mh *mediaHandler) ProcessLocalEmoji(ctx context.Context, emojiBytes []byte, shortcode string) (*gtsmodel.Emoji, error) {
+	e, err := mh.storeEmoji(ctx, emojiBytes, EmojiMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// webfinger uri for the emoji -- unrelated to actually serving the image
+	// will be something like https://example.org/emoji/70a7f3d7-7e35-4098-8ce3-9b5e8203bb9c
+	e.URI = fmt.Sprintf("%s://%s/%s/%s", mh.config.Protocol, mh.config.Host, Emoji, e.ID)
+	e.Shortcode = shortcode
+	e.VisibleInPicker = true
+	return e, nil
+}
+
+// ProcessRemoteEmoji takes the bytes of an emoji fetched from a remote instance, and an emoji with at least the
+// URI, Shortcode, Domain and ImageRemoteURL set on it, cleans up the image, puts it in storage, and returns a new
+// *gts.Emoji for it. It's the caller's responsibility to put the returned struct in the database.
+func (mh *mediaHandler) ProcessRemoteEmoji(ctx context.Context, emojiBytes []byte, minEmoji *gtsmodel.Emoji) (*gtsmodel.Emoji, error) {
+	if minEmoji.URI == "" || minEmoji.Shortcode == "" || minEmoji.Domain == "" || minEmoji.ImageRemoteURL == "" {
+		return nil, errors.New("remote emoji was missing uri, shortcode, domain or image url")
+	}
+
+	e, err := mh.storeEmoji(ctx, emojiBytes, RemoteEmojiMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	e.URI = minEmoji.URI
+	e.Shortcode = minEmoji.Shortcode
+	e.Domain = minEmoji.Domain
+	e.ImageRemoteURL = minEmoji.ImageRemoteURL
+	// remote instances don't give us a separate static version, so just point at the original
+	e.ImageStaticRemoteURL = minEmoji.ImageRemoteURL
+	// nobody here can use a remote emoji in their own posts, so it shouldn't be offered in the picker
+	e.VisibleInPicker = false
+	return e, nil
+}
+
+// storeEmoji checks the given emoji image, cleans it up, and puts it and a static version of it in storage.
+// It returns a new emoji with an ID and the image fields set; the rest is up to the caller.
+func (mh *mediaHandler) storeEmoji(ctx context.Context, emojiBytes []byte, maxBytes int) (*gtsmodel.Emoji, error) {
 	var clean []byte
 	var err error
 	var original *imageAndMeta
@@ -198,8 +246,8 @@ func (mh *mediaHandler) ProcessLocalEmoji(ctx context.Context, emojiBytes []byte
 	if len(emojiBytes) == 0 {
 		return nil, errors.New("emoji was of size 0")
 	}
-	if len(emojiBytes) > EmojiMaxBytes {
-		return nil, fmt.Errorf("emoji size %d bytes exceeded max emoji size of %d bytes", len(emojiBytes), EmojiMaxBytes)
+	if len(emojiBytes) > maxBytes {
+		return nil, fmt.Errorf("emoji size %d bytes exceeded max emoji size of %d bytes", len(emojiBytes), maxBytes)
 	}
 
 	// clean any exif data from png but leave gifs alone
@@ -244,10 +292,6 @@ func (mh *mediaHandler) ProcessLocalEmoji(ctx context.Context, emojiBytes []byte
 		return nil, err
 	}
 
-	// webfinger uri for the emoji -- unrelated to actually serving the image
-	// will be something like https://example.org/emoji/70a7f3d7-7e35-4098-8ce3-9b5e8203bb9c
-	emojiURI := fmt.Sprintf("%s://%s/%s/%s", mh.config.Protocol, mh.config.Host, Emoji, newEmojiID)
-
 	// serve url and storage path for the original emoji -- can be png or gif
 	emojiURL := fmt.Sprintf("%s/%s/%s/%s/%s.%s", URLbase, instanceAccount.ID, Emoji, Original, newEmojiID, extension)
 	emojiPath := fmt.Sprintf("%s/%s/%s/%s.%s", instanceAccount.ID, Emoji, Original, newEmojiID, extension)
@@ -269,12 +313,8 @@ func (mh *mediaHandler) ProcessLocalEmoji(ctx context.Context, emojiBytes []byte
 	// and finally return the new emoji data to the caller -- it's up to them what to do with it
 	e := &gtsmodel.Emoji{
 		ID:                     newEmojiID,
-		Shortcode:              shortcode,
-		Domain:                 "", // empty because this is a local emoji
 		CreatedAt:              time.Now(),
 		UpdatedAt:              time.Now(),
-		ImageRemoteURL:         "", // empty because this is a local emoji
-		ImageStaticRemoteURL:   "", // empty because this is a local emoji
 		ImageURL:               emojiURL,
 		ImageStaticURL:         emojiStaticURL,
 		ImagePath:              emojiPath,
@@ -285,8 +325,6 @@ func (mh *mediaHandler) ProcessLocalEmoji(ctx context.Context, emojiBytes []byte
 		ImageStaticFileSize:    len(static.image),
 		ImageUpdatedAt:         time.Now(),
 		Disabled:               false,
-		URI:                    emojiURI,
-		VisibleInPicker:        true,
 		CategoryID:             "", // empty because this is a new emoji -- no category yet
 	}
 	return e, nil
@@ -18,6 +18,414 @@
 
 package media
 
-// func (mh *mediaHandler) processVideoAttachment(data []byte, accountID string, contentType string, remoteURL string) (*gtsmodel.MediaAttachment, error) {
-// 	return nil, nil
-// }
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+const (
+	// videoProbeTimeout is how long ffprobe gets to read a video before we give up on it.
+	videoProbeTimeout = 30 * time.Second
+	// videoTranscodeTimeout is how long ffmpeg gets to transcode a video, or take a thumbnail from it, before we give up on it.
+	videoTranscodeTimeout = 5 * time.Minute
+)
+
+// videoDemuxers maps the content types of videos that we process to the ffmpeg demuxer that reads them.
+//
+// The demuxer is always forced rather than left for ffmpeg to guess from the file contents, since
+// formats like hls playlists and ffconcat scripts make ffmpeg open other files and urls.
+var videoDemuxers = map[string]string{
+	MIMEMp4:       "mov",
+	MIMEQuicktime: "mov",
+	MIMEWebm:      "matroska",
+}
+
+// videoProbeFormats are the format names that ffprobe reports for the demuxers in videoDemuxers.
+var videoProbeFormats = map[string]bool{
+	"mov,mp4,m4a,3gp,3g2,mj2": true,
+	"matroska,webm":           true,
+}
+
+// videoMeta is the information about a video that we care about, as read by ffprobe.
+type videoMeta struct {
+	width      int
+	height     int
+	rotated    bool
+	duration   float64
+	framerate  float64
+	bitrate    uint64
+	videoCodec string
+	pixFmt     string
+	audioCodec string
+}
+
+// probeOutput is the subset of `ffprobe -print_format json -show_format -show_streams` output that we parse.
+type probeOutput struct {
+	Streams []struct {
+		CodecType    string            `json:"codec_type"`
+		CodecName    string            `json:"codec_name"`
+		PixFmt       string            `json:"pix_fmt"`
+		Width        int               `json:"width"`
+		Height       int               `json:"height"`
+		AvgFrameRate string            `json:"avg_frame_rate"`
+		RFrameRate   string            `json:"r_frame_rate"`
+		Duration     string            `json:"duration"`
+		Tags         map[string]string `json:"tags"`
+		SideDataList []struct {
+			Rotation int `json:"rotation"`
+		} `json:"side_data_list"`
+	} `json:"streams"`
+	Format struct {
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+		BitRate    string `json:"bit_rate"`
+	} `json:"format"`
+}
+
+// parseProbeOutput parses the json output of ffprobe into a videoMeta.
+// Only the first video stream and the first audio stream are taken into account.
+func parseProbeOutput(b []byte) (*videoMeta, error) {
+	probe := &probeOutput{}
+	if err := json.Unmarshal(b, probe); err != nil {
+		return nil, fmt.Errorf("error parsing ffprobe output: %s", err)
+	}
+
+	if !videoProbeFormats[probe.Format.FormatName] {
+		return nil, fmt.Errorf("video format %s not supported", probe.Format.FormatName)
+	}
+
+	meta := &videoMeta{}
+	var foundVideo bool
+	var streamDuration float64
+	for _, s := range probe.Streams {
+		switch s.CodecType {
+		case "video":
+			if foundVideo {
+				continue
+			}
+			foundVideo = true
+			meta.videoCodec = s.CodecName
+			meta.pixFmt = s.PixFmt
+			meta.width = s.Width
+			meta.height = s.Height
+			meta.framerate = parseFrameRate(s.AvgFrameRate)
+			if meta.framerate == 0 {
+				meta.framerate = parseFrameRate(s.RFrameRate)
+			}
+			streamDuration, _ = strconv.ParseFloat(s.Duration, 64)
+
+			rotation, _ := strconv.Atoi(s.Tags["rotate"])
+			for _, sd := range s.SideDataList {
+				if sd.Rotation != 0 {
+					rotation = sd.Rotation
+				}
+			}
+			if rotation%360 != 0 {
+				meta.rotated = true
+				if rotation%180 != 0 {
+					// the video will be displayed on its side, so swap the dimensions
+					meta.width, meta.height = meta.height, meta.width
+				}
+			}
+		case "audio":
+			if meta.audioCodec == "" {
+				meta.audioCodec = s.CodecName
+			}
+		}
+	}
+
+	if !foundVideo {
+		return nil, errors.New("no video stream found")
+	}
+	if meta.width <= 0 || meta.height <= 0 {
+		return nil, fmt.Errorf("invalid video dimensions %dx%d", meta.width, meta.height)
+	}
+
+	meta.duration, _ = strconv.ParseFloat(probe.Format.Duration, 64)
+	if meta.duration == 0 {
+		meta.duration = streamDuration
+	}
+	meta.bitrate, _ = strconv.ParseUint(probe.Format.BitRate, 10, 64)
+
+	return meta, nil
+}
+
+// parseFrameRate parses an ffprobe frame rate like "30000/1001" or "25/1", returning 0 if it can't be parsed.
+func parseFrameRate(s string) float64 {
+	parts := strings.Split(s, "/")
+	num, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0
+	}
+	if len(parts) == 1 {
+		return num
+	}
+	den, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || den == 0 {
+		return 0
+	}
+	return num / den
+}
+
+// videoTarget works out which content type the given video should be stored as,
+// and whether it can just be remuxed into that container (ie., without re-encoding).
+//
+// Videos that are already h264/aac or vp8/vp9/av1 with opus/vorbis play in browsers as they are,
+// so those are kept, anything else is transcoded into h264/aac mp4.
+func videoTarget(meta *videoMeta) (contentType string, remux bool) {
+	if meta.rotated {
+		// the rotation has to be applied by transcoding, since not all players respect rotation metadata
+		return MIMEMp4, false
+	}
+
+	switch meta.videoCodec {
+	case "h264":
+		if meta.pixFmt == "yuv420p" && (meta.audioCodec == "" || meta.audioCodec == "aac") {
+			return MIMEMp4, true
+		}
+	case "vp8", "vp9", "av1":
+		if meta.audioCodec == "" || meta.audioCodec == "opus" || meta.audioCodec == "vorbis" {
+			return MIMEWebm, true
+		}
+	}
+	return MIMEMp4, false
+}
+
+// ffInputArgs returns the arguments for reading the file at in with the given demuxer.
+// Only local files may be opened, so that nothing in the file can point ffmpeg at a url.
+func ffInputArgs(demuxer string, in string) []string {
+	return []string{"-protocol_whitelist", "file", "-f", demuxer, "-i", in}
+}
+
+// videoProbeArgs returns the ffprobe arguments for reading the streams and format of the video at in.
+func videoProbeArgs(demuxer string, in string) []string {
+	return append([]string{"-v", "error", "-print_format", "json", "-show_format", "-show_streams"}, ffInputArgs(demuxer, in)...)
+}
+
+// videoTranscodeArgs returns the ffmpeg arguments for turning the video at in into a web-friendly video at out.
+// Metadata is always stripped from the output, since it can contain things like location.
+func videoTranscodeArgs(meta *videoMeta, demuxer string, in string, out string, contentType string, remux bool) []string {
+	args := append([]string{"-v", "error", "-y"}, ffInputArgs(demuxer, in)...)
+	args = append(args, "-map_metadata", "-1", "-map", "0:v:0")
+	if meta.audioCodec != "" {
+		args = append(args, "-map", "0:a:0")
+	}
+
+	if remux {
+		args = append(args, "-c", "copy")
+	} else {
+		args = append(args,
+			"-c:v", "libx264",
+			"-preset", "veryfast",
+			"-crf", "23",
+			"-pix_fmt", "yuv420p",
+			// h264 with yuv420p needs even dimensions
+			"-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+		)
+		if meta.audioCodec != "" {
+			args = append(args, "-c:a", "aac", "-b:a", "128k")
+		}
+	}
+
+	if meta.audioCodec == "" {
+		args = append(args, "-an")
+	}
+
+	if contentType == MIMEMp4 {
+		// put the index at the start of the file so browsers can start playing before the whole thing is downloaded
+		args = append(args, "-movflags", "+faststart", "-f", "mp4")
+	} else {
+		args = append(args, "-f", "webm")
+	}
+
+	return append(args, out)
+}
+
+// videoThumbnailArgs returns the ffmpeg arguments for taking a single jpeg frame from the video at in, and writing it to out.
+func videoThumbnailArgs(meta *videoMeta, demuxer string, in string, out string) []string {
+	// take a frame from a little way into the video, since the very first one is often black
+	offset := meta.duration / 2
+	if offset > 1 {
+		offset = 1
+	}
+	args := append([]string{"-v", "error", "-y", "-ss", strconv.FormatFloat(offset, 'f', 3, 64)}, ffInputArgs(demuxer, in)...)
+	return append(args, "-frames:v", "1", "-f", "image2", "-c:v", "mjpeg", out)
+}
+
+// runFF runs the given ffmpeg/ffprobe binary with args, returning stdout.
+// The process is killed if it hasn't finished by the time timeout has passed.
+func runFF(ctx context.Context, timeout time.Duration, path string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("error running %s: timed out after %s", path, timeout)
+		}
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("error running %s: %s: %s", path, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("error running %s: %s", path, err)
+	}
+	return out, nil
+}
+
+func (mh *mediaHandler) processVideoAttachment(ctx context.Context, data []byte, minAttachment *gtsmodel.MediaAttachment) (*gtsmodel.MediaAttachment, error) {
+	mediaConfig := mh.config.MediaConfig
+
+	if len(data) > mediaConfig.MaxVideoSize {
+		return nil, fmt.Errorf("video size %d bytes exceeds limit of %d bytes", len(data), mediaConfig.MaxVideoSize)
+	}
+
+	demuxer, ok := videoDemuxers[minAttachment.File.ContentType]
+	if !ok {
+		return nil, fmt.Errorf("video type %s can't be processed", minAttachment.File.ContentType)
+	}
+
+	// ffmpeg wants to work with files, so do everything in a scratch dir that we clean up afterwards
+	dir, err := os.MkdirTemp("", "gotosocial-video-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "in")
+	if err := os.WriteFile(in, data, 0600); err != nil {
+		return nil, fmt.Errorf("error writing temp file: %s", err)
+	}
+
+	probe, err := runFF(ctx, videoProbeTimeout, mediaConfig.FFprobePath, videoProbeArgs(demuxer, in)...)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := parseProbeOutput(probe)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.duration > float64(mediaConfig.MaxVideoDuration) {
+		return nil, fmt.Errorf("video duration %.2fs exceeds limit of %ds", meta.duration, mediaConfig.MaxVideoDuration)
+	}
+
+	contentType, remux := videoTarget(meta)
+	extension := strings.Split(contentType, "/")[1]
+	out := filepath.Join(dir, "out."+extension)
+	if _, err := runFF(ctx, videoTranscodeTimeout, mediaConfig.FFmpegPath, videoTranscodeArgs(meta, demuxer, in, out, contentType, remux)...); err != nil {
+		return nil, fmt.Errorf("error transcoding video: %s", err)
+	}
+
+	original, err := os.ReadFile(out)
+	if err != nil {
+		return nil, fmt.Errorf("error reading transcoded video: %s", err)
+	}
+	if len(original) > mediaConfig.MaxVideoSize {
+		return nil, fmt.Errorf("transcoded video size %d bytes exceeds limit of %d bytes", len(original), mediaConfig.MaxVideoSize)
+	}
+
+	// the output is always upright, so the thumbnail can be taken from that
+	frame := filepath.Join(dir, "frame.jpeg")
+	if _, err := runFF(ctx, videoTranscodeTimeout, mediaConfig.FFmpegPath, videoThumbnailArgs(meta, videoDemuxers[contentType], out, frame)...); err != nil {
+		return nil, fmt.Errorf("error taking video thumbnail: %s", err)
+	}
+	frameBytes, err := os.ReadFile(frame)
+	if err != nil {
+		return nil, fmt.Errorf("error reading video thumbnail: %s", err)
+	}
+	small, err := deriveThumbnail(frameBytes, MIMEJpeg, 512, 512)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving thumbnail: %s", err)
+	}
+
+	// now put it in storage, take a new id for the name of the file so we don't store any unnecessary info about it
+	newMediaID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+
+	URLbase := fmt.Sprintf("%s://%s%s", mh.config.StorageConfig.ServeProtocol, mh.config.StorageConfig.ServeHost, mh.config.StorageConfig.ServeBasePath)
+	originalURL := fmt.Sprintf("%s/%s/attachment/original/%s.%s", URLbase, minAttachment.AccountID, newMediaID, extension)
+	smallURL := fmt.Sprintf("%s/%s/attachment/small/%s.jpeg", URLbase, minAttachment.AccountID, newMediaID) // all thumbnails/smalls are encoded as jpeg
+
+	// we store the original...
+	originalPath := fmt.Sprintf("%s/%s/%s/%s.%s", minAttachment.AccountID, Attachment, Original, newMediaID, extension)
+	if err := mh.storage.Put(originalPath, original); err != nil {
+		return nil, fmt.Errorf("storage error: %s", err)
+	}
+
+	// and a thumbnail...
+	smallPath := fmt.Sprintf("%s/%s/%s/%s.jpeg", minAttachment.AccountID, Attachment, Small, newMediaID) // all thumbnails/smalls are encoded as jpeg
+	if err := mh.storage.Put(smallPath, small.image); err != nil {
+		return nil, fmt.Errorf("storage error: %s", err)
+	}
+
+	minAttachment.FileMeta.Original = gtsmodel.Original{
+		Width:     meta.width,
+		Height:    meta.height,
+		Size:      meta.width * meta.height,
+		Aspect:    float64(meta.width) / float64(meta.height),
+		Duration:  float32(meta.duration),
+		Framerate: float32(meta.framerate),
+		Bitrate:   meta.bitrate,
+	}
+
+	minAttachment.FileMeta.Small = gtsmodel.Small{
+		Width:  small.width,
+		Height: small.height,
+		Size:   small.size,
+		Aspect: small.aspect,
+	}
+
+	// soundless videos are shown like gifs: looping and without controls
+	fileType := gtsmodel.FileTypeVideo
+	if meta.audioCodec == "" {
+		fileType = gtsmodel.FileTypeGif
+	}
+
+	attachment := &gtsmodel.MediaAttachment{
+		ID:                newMediaID,
+		StatusID:          minAttachment.StatusID,
+		URL:               originalURL,
+		RemoteURL:         minAttachment.RemoteURL,
+		CreatedAt:         minAttachment.CreatedAt,
+		UpdatedAt:         minAttachment.UpdatedAt,
+		Type:              fileType,
+		FileMeta:          minAttachment.FileMeta,
+		AccountID:         minAttachment.AccountID,
+		Description:       minAttachment.Description,
+		ScheduledStatusID: minAttachment.ScheduledStatusID,
+		Blurhash:          small.blurhash,
+		Processing:        2,
+		File: gtsmodel.File{
+			Path:        originalPath,
+			ContentType: contentType,
+			FileSize:    len(original),
+			UpdatedAt:   time.Now(),
+		},
+		Thumbnail: gtsmodel.Thumbnail{
+			Path:        smallPath,
+			ContentType: MIMEJpeg, // all thumbnails/smalls are encoded as jpeg
+			FileSize:    len(small.image),
+			UpdatedAt:   time.Now(),
+			URL:         smallURL,
+			RemoteURL:   minAttachment.Thumbnail.RemoteURL,
+		},
+		Avatar: minAttachment.Avatar,
+		Header: minAttachment.Header,
+	}
+
+	return attachment, nil
+}
@@ -0,0 +1,147 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package media
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProcessVideoTestSuite struct {
+	suite.Suite
+}
+
+const probeH264AAC = `{
+	"streams": [
+		{"codec_type": "video", "codec_name": "h264", "pix_fmt": "yuv420p", "width": 1280, "height": 720, "avg_frame_rate": "30000/1001", "r_frame_rate": "30000/1001", "duration": "12.012000"},
+		{"codec_type": "audio", "codec_name": "aac"}
+	],
+	"format": {"format_name": "mov,mp4,m4a,3gp,3g2,mj2", "duration": "12.034000", "bit_rate": "1543210"}
+}`
+
+const probeRotatedHEVC = `{
+	"streams": [
+		{"codec_type": "video", "codec_name": "hevc", "pix_fmt": "yuv420p", "width": 1920, "height": 1080, "avg_frame_rate": "30/1", "side_data_list": [{"rotation": -90}]},
+		{"codec_type": "audio", "codec_name": "aac"}
+	],
+	"format": {"format_name": "mov,mp4,m4a,3gp,3g2,mj2", "duration": "3.500000", "bit_rate": "9000000"}
+}`
+
+const probeSilentVP9 = `{
+	"streams": [
+		{"codec_type": "video", "codec_name": "vp9", "pix_fmt": "yuv420p", "width": 640, "height": 480, "avg_frame_rate": "0/0", "r_frame_rate": "25/1"}
+	],
+	"format": {"format_name": "matroska,webm", "duration": "2.000000", "bit_rate": "500000"}
+}`
+
+func (suite *ProcessVideoTestSuite) TestParseProbeOutput() {
+	meta, err := parseProbeOutput([]byte(probeH264AAC))
+	suite.NoError(err)
+	suite.Equal(1280, meta.width)
+	suite.Equal(720, meta.height)
+	suite.False(meta.rotated)
+	suite.InDelta(12.034, meta.duration, 0.0001)
+	suite.InDelta(29.97, meta.framerate, 0.01)
+	suite.EqualValues(1543210, meta.bitrate)
+	suite.Equal("h264", meta.videoCodec)
+	suite.Equal("aac", meta.audioCodec)
+}
+
+func (suite *ProcessVideoTestSuite) TestParseProbeOutputRotated() {
+	meta, err := parseProbeOutput([]byte(probeRotatedHEVC))
+	suite.NoError(err)
+	suite.True(meta.rotated)
+	suite.Equal(1080, meta.width)
+	suite.Equal(1920, meta.height)
+}
+
+func (suite *ProcessVideoTestSuite) TestParseProbeOutputNoVideo() {
+	_, err := parseProbeOutput([]byte(`{"streams": [{"codec_type": "audio", "codec_name": "mp3"}], "format": {"format_name": "mov,mp4,m4a,3gp,3g2,mj2", "duration": "60.0"}}`))
+	suite.EqualError(err, "no video stream found")
+}
+
+func (suite *ProcessVideoTestSuite) TestParseProbeOutputUnsupportedFormat() {
+	_, err := parseProbeOutput([]byte(`{"streams": [{"codec_type": "video", "codec_name": "h264", "width": 1280, "height": 720}], "format": {"format_name": "hls", "duration": "60.0"}}`))
+	suite.EqualError(err, "video format hls not supported")
+}
+
+func (suite *ProcessVideoTestSuite) TestVideoTarget() {
+	meta, err := parseProbeOutput([]byte(probeH264AAC))
+	suite.NoError(err)
+	contentType, remux := videoTarget(meta)
+	suite.Equal(MIMEMp4, contentType)
+	suite.True(remux)
+
+	meta, err = parseProbeOutput([]byte(probeSilentVP9))
+	suite.NoError(err)
+	suite.InDelta(25, meta.framerate, 0.01)
+	contentType, remux = videoTarget(meta)
+	suite.Equal(MIMEWebm, contentType)
+	suite.True(remux)
+
+	meta, err = parseProbeOutput([]byte(probeRotatedHEVC))
+	suite.NoError(err)
+	contentType, remux = videoTarget(meta)
+	suite.Equal(MIMEMp4, contentType)
+	suite.False(remux)
+}
+
+func (suite *ProcessVideoTestSuite) TestVideoTranscodeArgs() {
+	meta, err := parseProbeOutput([]byte(probeH264AAC))
+	suite.NoError(err)
+	suite.Equal([]string{
+		"-v", "error", "-y", "-protocol_whitelist", "file", "-f", "mov", "-i", "in", "-map_metadata", "-1", "-map", "0:v:0", "-map", "0:a:0",
+		"-c", "copy",
+		"-movflags", "+faststart", "-f", "mp4", "out.mp4",
+	}, videoTranscodeArgs(meta, "mov", "in", "out.mp4", MIMEMp4, true))
+
+	meta, err = parseProbeOutput([]byte(probeRotatedHEVC))
+	suite.NoError(err)
+	suite.Equal([]string{
+		"-v", "error", "-y", "-protocol_whitelist", "file", "-f", "mov", "-i", "in", "-map_metadata", "-1", "-map", "0:v:0", "-map", "0:a:0",
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-pix_fmt", "yuv420p", "-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+		"-c:a", "aac", "-b:a", "128k",
+		"-movflags", "+faststart", "-f", "mp4", "out.mp4",
+	}, videoTranscodeArgs(meta, "mov", "in", "out.mp4", MIMEMp4, false))
+
+	meta, err = parseProbeOutput([]byte(probeSilentVP9))
+	suite.NoError(err)
+	suite.Equal([]string{
+		"-v", "error", "-y", "-protocol_whitelist", "file", "-f", "matroska", "-i", "in", "-map_metadata", "-1", "-map", "0:v:0",
+		"-c", "copy",
+		"-an", "-f", "webm", "out.webm",
+	}, videoTranscodeArgs(meta, "matroska", "in", "out.webm", MIMEWebm, true))
+}
+
+func (suite *ProcessVideoTestSuite) TestVideoProbeArgs() {
+	suite.Equal([]string{"-v", "error", "-print_format", "json", "-show_format", "-show_streams", "-protocol_whitelist", "file", "-f", "matroska", "-i", "in"},
+		videoProbeArgs("matroska", "in"))
+}
+
+func (suite *ProcessVideoTestSuite) TestVideoThumbnailArgs() {
+	suite.Equal([]string{"-v", "error", "-y", "-ss", "1.000", "-protocol_whitelist", "file", "-f", "mov", "-i", "out.mp4", "-frames:v", "1", "-f", "image2", "-c:v", "mjpeg", "frame.jpeg"},
+		videoThumbnailArgs(&videoMeta{duration: 12}, "mov", "out.mp4", "frame.jpeg"))
+	suite.Equal([]string{"-v", "error", "-y", "-ss", "0.250", "-protocol_whitelist", "file", "-f", "mov", "-i", "out.mp4", "-frames:v", "1", "-f", "image2", "-c:v", "mjpeg", "frame.jpeg"},
+		videoThumbnailArgs(&videoMeta{duration: 0.5}, "mov", "out.mp4", "frame.jpeg"))
+}
+
+func TestProcessVideoTestSuite(t *testing.T) {
+	suite.Run(t, &ProcessVideoTestSuite{})
+}
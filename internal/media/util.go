@@ -51,6 +51,8 @@ const (
 	MIMEMpeg = "video/mpeg"
 	// MIMEWebm is the webm video mime type
 	MIMEWebm = "video/webm"
+	// MIMEQuicktime is the quicktime (mov) video mime type
+	MIMEQuicktime = "video/quicktime"
 )
 
 // parseContentType parses the MIME content type from a file, returning it as a string in the form (eg., "image/jpeg").
@@ -74,15 +76,24 @@ func parseContentType(content []byte) (string, error) {
 	return kind.MIME.Value, nil
 }
 
+// SupportedImageTypes are the mime types of images that can be uploaded.
+var SupportedImageTypes = []string{
+	MIMEJpeg,
+	MIMEGif,
+	MIMEPng,
+}
+
+// SupportedVideoTypes are the mime types of videos that can be uploaded.
+var SupportedVideoTypes = []string{
+	MIMEMp4,
+	MIMEWebm,
+	MIMEQuicktime,
+}
+
 // SupportedImageType checks mime type of an image against a slice of accepted types,
 // and returns True if the mime type is accepted.
 func SupportedImageType(mimeType string) bool {
-	acceptedImageTypes := []string{
-		MIMEJpeg,
-		MIMEGif,
-		MIMEPng,
-	}
-	for _, accepted := range acceptedImageTypes {
+	for _, accepted := range SupportedImageTypes {
 		if mimeType == accepted {
 			return true
 		}
@@ -93,12 +104,7 @@ func SupportedImageType(mimeType string) bool {
 // SupportedVideoType checks mime type of a video against a slice of accepted types,
 // and returns True if the mime type is accepted.
 func SupportedVideoType(mimeType string) bool {
-	acceptedVideoTypes := []string{
-		MIMEMp4,
-		MIMEMpeg,
-		MIMEWebm,
-	}
-	for _, accepted := range acceptedVideoTypes {
+	for _, accepted := range SupportedVideoTypes {
 		if mimeType == accepted {
 			return true
 		}
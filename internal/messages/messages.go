@@ -18,7 +18,11 @@
 
 package messages
 
-import "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+import (
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
 
 // FromClientAPI wraps a message that travels from the client API into the processor.
 type FromClientAPI struct {
@@ -27,6 +31,7 @@ type FromClientAPI struct {
 	GTSModel       interface{}
 	OriginAccount  *gtsmodel.Account
 	TargetAccount  *gtsmodel.Account
+	RequestID      string
 }
 
 // FromFederator wraps a message that travels from the federator into the processor.
@@ -34,5 +39,7 @@ type FromFederator struct {
 	APObjectType     string
 	APActivityType   string
 	GTSModel         interface{}
+	APIri            *url.URL // IRI of a further object of the activity that still needs dereferencing, eg., the target of a Move
 	ReceivingAccount *gtsmodel.Account
+	RequestID        string
 }
@@ -0,0 +1,300 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ScopeRead grants read access to everything but admin endpoints.
+	ScopeRead = "read"
+	// ScopeWrite grants write access to everything but admin endpoints.
+	ScopeWrite = "write"
+	// ScopeFollow is the legacy scope for reading and changing follows, blocks and mutes.
+	ScopeFollow = "follow"
+	// ScopePush grants access to web push subscriptions.
+	ScopePush = "push"
+	// ScopeAdmin grants read and write access to admin endpoints.
+	ScopeAdmin = "admin"
+	// ScopeAdminRead grants read access to admin endpoints.
+	ScopeAdminRead = "admin:read"
+	// ScopeAdminWrite grants write access to admin endpoints.
+	ScopeAdminWrite = "admin:write"
+)
+
+// scopeCategories are the categories that read and write scopes can be narrowed to, eg., read:statuses.
+var scopeCategories = []string{
+	"accounts",
+	"blocks",
+	"bookmarks",
+	"favourites",
+	"filters",
+	"follows",
+	"lists",
+	"media",
+	"mutes",
+	"notifications",
+	"reports",
+	"search",
+	"statuses",
+}
+
+// adminScopeCategories are the categories that admin:read and admin:write scopes can be narrowed to, eg., admin:read:accounts.
+var adminScopeCategories = []string{
+	"accounts",
+	"domain_allows",
+	"domain_blocks",
+	"reports",
+}
+
+// followScopes are the scopes that the legacy follow scope stands for.
+var followScopes = []string{
+	"read:follows", "write:follows",
+	"read:blocks", "write:blocks",
+	"read:mutes", "write:mutes",
+}
+
+// ScopeDescription pairs a scope with a human readable description of what it allows, for showing on the authorize page.
+type ScopeDescription struct {
+	Scope       string
+	Description string
+}
+
+// DescribeScopes returns a description of each of the given space separated scopes.
+func DescribeScopes(scopes string) []ScopeDescription {
+	descriptions := []ScopeDescription{}
+	for _, scope := range strings.Fields(scopes) {
+		descriptions = append(descriptions, ScopeDescription{
+			Scope:       scope,
+			Description: describeScope(scope),
+		})
+	}
+	return descriptions
+}
+
+func describeScope(scope string) string {
+	switch scope {
+	case ScopeRead:
+		return "read all your account data"
+	case ScopeWrite:
+		return "modify all your account data"
+	case ScopeFollow:
+		return "read and change your follows, blocks and mutes"
+	case ScopePush:
+		return "receive your push notifications"
+	case ScopeAdmin:
+		return "read and change all data on the instance"
+	case ScopeAdminRead:
+		return "read all data on the instance"
+	case ScopeAdminWrite:
+		return "perform moderation actions on the instance"
+	}
+
+	parts := strings.Split(scope, ":")
+	category := strings.ReplaceAll(parts[len(parts)-1], "_", " ")
+	switch {
+	case strings.HasPrefix(scope, ScopeAdminRead+":"):
+		return "read instance " + category
+	case strings.HasPrefix(scope, ScopeAdminWrite+":"):
+		return "perform moderation actions on instance " + category
+	case strings.HasPrefix(scope, ScopeRead+":"):
+		return "read your " + category
+	case strings.HasPrefix(scope, ScopeWrite+":"):
+		return "modify your " + category
+	}
+
+	return scope
+}
+
+// ValidateScopes returns an error if any of the space separated scopes isn't one this server knows.
+func ValidateScopes(scopes string) error {
+	for _, scope := range strings.Fields(scopes) {
+		if !knownScope(scope) {
+			return fmt.Errorf("unknown scope %s", scope)
+		}
+	}
+	return nil
+}
+
+func knownScope(scope string) bool {
+	switch scope {
+	case ScopeRead, ScopeWrite, ScopeFollow, ScopePush, ScopeAdmin, ScopeAdminRead, ScopeAdminWrite:
+		return true
+	}
+
+	for _, category := range scopeCategories {
+		if scope == ScopeRead+":"+category || scope == ScopeWrite+":"+category {
+			return true
+		}
+	}
+
+	for _, category := range adminScopeCategories {
+		if scope == ScopeAdminRead+":"+category || scope == ScopeAdminWrite+":"+category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScopesGranted returns true if every one of the space separated requested scopes is covered by the space separated granted scopes.
+func ScopesGranted(granted string, requested string) bool {
+	for _, scope := range strings.Fields(requested) {
+		if !ScopeGranted(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// ScopeGranted returns true if the required scope is covered by the space separated granted scopes. A scope covers
+// itself and any scope narrowed from it, so read covers read:statuses, and admin covers admin:read:accounts. An empty
+// required scope is always covered.
+func ScopeGranted(granted string, required string) bool {
+	if required == "" {
+		return true
+	}
+
+	for _, scope := range strings.Fields(granted) {
+		if scope == required || strings.HasPrefix(required, scope+":") {
+			return true
+		}
+
+		if scope == ScopeFollow {
+			for _, followScope := range followScopes {
+				if followScope == required {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// RequiredScope returns the scope a token needs for a request with the given method to the client API route
+// with the given path, eg., /api/v1/accounts/:id/follow. Requests that don't need any particular scope, such
+// as fetching public instance information, get an empty string.
+func RequiredScope(method string, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	// only the client API is covered by scopes, eg., /api/v1/statuses
+	if len(segments) < 3 || segments[0] != "api" || !strings.HasPrefix(segments[1], "v") {
+		return ""
+	}
+	resource := segments[2:]
+
+	access := ScopeWrite
+	if method == http.MethodGet || method == http.MethodHead {
+		access = ScopeRead
+	}
+
+	switch resource[0] {
+	case "instance":
+		// instance information is public, but changing it is for admins only
+		if access == ScopeRead {
+			return ""
+		}
+		return ScopeAdminWrite
+	case "custom_emojis":
+		if access == ScopeRead {
+			return ""
+		}
+	case "apps":
+		return appsScope(method, access, resource)
+	case "streaming":
+		return access + ":statuses"
+	case "admin":
+		if len(resource) > 1 && contains(adminScopeCategories, resource[1]) {
+			return "admin:" + access + ":" + resource[1]
+		}
+		return "admin:" + access
+	case "push":
+		return ScopePush
+	case "follow_requests":
+		return access + ":follows"
+	case "blocks", "mutes", "bookmarks", "favourites", "filters", "lists", "media", "notifications", "reports", "search", "statuses":
+		return access + ":" + resource[0]
+	case "timelines", "polls":
+		return access + ":statuses"
+	case "preferences", "emails", "user", "suggestions", "sessions":
+		return access + ":accounts"
+	case "tags":
+		return access + ":follows"
+	case "accounts":
+		return accountsScope(method, access, resource)
+	}
+
+	return access
+}
+
+// appsScope works out the scope for routes under /api/v1/apps.
+func appsScope(method string, access string, resource []string) string {
+	// registering an app is done before there's any token at all
+	if len(resource) == 1 && method == http.MethodPost {
+		return ""
+	}
+
+	// the authorized apps are the user's sessions, which are part of their account
+	if len(resource) > 1 && resource[1] == "authorized" {
+		return access + ":accounts"
+	}
+
+	// checking the credentials of the app that owns the token
+	if len(resource) > 1 && resource[1] == "verify_credentials" && access == ScopeRead {
+		return ""
+	}
+
+	return access
+}
+
+// accountsScope works out the scope for routes under /api/v1/accounts, which cover relationships as well as accounts.
+func accountsScope(method string, access string, resource []string) string {
+	// signing up is done with an app token, before the user has a token of their own
+	if len(resource) == 1 && method == http.MethodPost {
+		return ScopeWrite + ":accounts"
+	}
+
+	switch resource[len(resource)-1] {
+	case "follow", "unfollow", "relationships":
+		return access + ":follows"
+	case "block", "unblock":
+		return access + ":blocks"
+	case "mute", "unmute":
+		return access + ":mutes"
+	case "lists":
+		return access + ":lists"
+	case "statuses":
+		return access + ":statuses"
+	}
+
+	return access + ":accounts"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
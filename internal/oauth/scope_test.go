@@ -0,0 +1,111 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ScopeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopeTestSuite) TestValidateScopes() {
+	suite.NoError(oauth.ValidateScopes("read write follow push"))
+	suite.NoError(oauth.ValidateScopes("read:statuses write:media admin:read:accounts"))
+	suite.NoError(oauth.ValidateScopes(""))
+	suite.EqualError(oauth.ValidateScopes("read everything"), "unknown scope everything")
+	suite.Error(oauth.ValidateScopes("read:nonsense"))
+}
+
+func (suite *ScopeTestSuite) TestScopeGranted() {
+	suite.True(oauth.ScopeGranted("read write", "read:statuses"))
+	suite.True(oauth.ScopeGranted("read:statuses", "read:statuses"))
+	suite.True(oauth.ScopeGranted("follow", "write:blocks"))
+	suite.True(oauth.ScopeGranted("admin", "admin:write:accounts"))
+	suite.True(oauth.ScopeGranted("read", ""))
+	suite.False(oauth.ScopeGranted("read", "write:statuses"))
+	suite.False(oauth.ScopeGranted("read:accounts", "read:statuses"))
+	suite.False(oauth.ScopeGranted("read write follow push", "admin:read"))
+	suite.False(oauth.ScopeGranted("follow", "write:statuses"))
+}
+
+func (suite *ScopeTestSuite) TestScopesGranted() {
+	suite.True(oauth.ScopesGranted("read write follow push", "read write"))
+	suite.True(oauth.ScopesGranted("read", "read:statuses read:accounts"))
+	suite.False(oauth.ScopesGranted("read", "read write"))
+}
+
+func (suite *ScopeTestSuite) TestRequiredScope() {
+	suite.Equal("read:statuses", oauth.RequiredScope(http.MethodGet, "/api/v1/statuses/:id"))
+	suite.Equal("write:statuses", oauth.RequiredScope(http.MethodPost, "/api/v1/statuses"))
+	suite.Equal("read:statuses", oauth.RequiredScope(http.MethodGet, "/api/v1/timelines/home"))
+	suite.Equal("write:follows", oauth.RequiredScope(http.MethodPost, "/api/v1/accounts/:id/follow"))
+	suite.Equal("write:blocks", oauth.RequiredScope(http.MethodPost, "/api/v1/accounts/:id/block"))
+	suite.Equal("read:accounts", oauth.RequiredScope(http.MethodGet, "/api/v1/accounts/verify_credentials"))
+	suite.Equal("write:accounts", oauth.RequiredScope(http.MethodPost, "/api/v1/accounts"))
+	suite.Equal("admin:write:domain_blocks", oauth.RequiredScope(http.MethodPost, "/api/v1/admin/domain_blocks"))
+	suite.Equal("admin:read", oauth.RequiredScope(http.MethodGet, "/api/v1/admin/measures"))
+	suite.Equal("push", oauth.RequiredScope(http.MethodPost, "/api/v1/push/subscription"))
+	suite.Equal("", oauth.RequiredScope(http.MethodGet, "/api/v1/instance"))
+	suite.Equal("", oauth.RequiredScope(http.MethodGet, "/users/:username"))
+}
+
+func (suite *ScopeTestSuite) TestRequiredScopeExemptions() {
+	for _, test := range []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{http.MethodGet, "/api/v1/instance", ""},
+		{http.MethodGet, "/api/v2/instance", ""},
+		{http.MethodGet, "/api/v1/instance/rules", ""},
+		{http.MethodPatch, "/api/v1/instance", "admin:write"},
+		{http.MethodPost, "/api/v1/apps", ""},
+		{http.MethodGet, "/api/v1/apps/verify_credentials", ""},
+		{http.MethodGet, "/api/v1/apps/authorized", "read:accounts"},
+		{http.MethodDelete, "/api/v1/apps/authorized/:id", "write:accounts"},
+		{http.MethodGet, "/api/v1/custom_emojis", ""},
+		{http.MethodPost, "/api/v1/custom_emojis", "write"},
+		{http.MethodGet, "/api/v1/streaming", "read:statuses"},
+	} {
+		suite.Equal(test.expected, oauth.RequiredScope(test.method, test.path), test.method+" "+test.path)
+	}
+
+	// a read only token can't revoke the user's other apps or change instance settings
+	suite.False(oauth.ScopeGranted("read", oauth.RequiredScope(http.MethodDelete, "/api/v1/apps/authorized/:id")))
+	suite.False(oauth.ScopeGranted("read", oauth.RequiredScope(http.MethodPatch, "/api/v1/instance")))
+	suite.False(oauth.ScopeGranted("read write", oauth.RequiredScope(http.MethodPatch, "/api/v1/instance")))
+}
+
+func (suite *ScopeTestSuite) TestDescribeScopes() {
+	descriptions := oauth.DescribeScopes("read write:media admin:read:reports")
+	suite.Len(descriptions, 3)
+	suite.Equal("read all your account data", descriptions[0].Description)
+	suite.Equal("modify your media", descriptions[1].Description)
+	suite.Equal("read instance reports", descriptions[2].Description)
+}
+
+func TestScopeTestSuite(t *testing.T) {
+	suite.Run(t, new(ScopeTestSuite))
+}
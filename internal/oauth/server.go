@@ -25,6 +25,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/oauth2/v4"
 	"github.com/superseriousbusiness/oauth2/v4/errors"
 	"github.com/superseriousbusiness/oauth2/v4/manage"
@@ -95,12 +96,12 @@ func New(database db.Basic, log *logrus.Logger) Server {
 
 	srv := server.NewServer(sc, manager)
 	srv.SetInternalErrorHandler(func(err error) *errors.Response {
-		log.Errorf("internal oauth error: %s", err)
+		log.WithError(err).Error("internal oauth error")
 		return nil
 	})
 
 	srv.SetResponseErrorHandler(func(re *errors.Response) {
-		log.Errorf("internal response error: %s", re.Error)
+		log.WithError(re.Error).Error("internal response error")
 	})
 
 	srv.SetUserAuthorizationHandler(func(w http.ResponseWriter, r *http.Request) (string, error) {
@@ -111,6 +112,23 @@ func New(database db.Basic, log *logrus.Logger) Server {
 		return userID, nil
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
+
+	srv.SetClientScopeHandler(func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		app := &gtsmodel.Application{}
+		if err := database.GetWhere(context.Background(), []db.Where{{Key: "client_id", Value: tgr.ClientID}}, app); err != nil {
+			return false, fmt.Errorf("error getting application for client id %s: %s", tgr.ClientID, err)
+		}
+
+		// a client credentials request without a scope gets everything the application registered for
+		if tgr.Scope == "" && tgr.UserID == "" {
+			tgr.Scope = app.Scopes
+		}
+
+		if err := ValidateScopes(tgr.Scope); err != nil {
+			return false, nil
+		}
+		return ScopesGranted(app.Scopes, tgr.Scope), nil
+	})
 	return &s{
 		server: srv,
 		log:    log,
@@ -153,7 +171,7 @@ func (s *s) GenerateUserAccessToken(ti oauth2.TokenInfo, clientSecret string, us
 	if authToken == nil {
 		return nil, errors.New("generated auth token was empty")
 	}
-	s.log.Tracef("obtained auth token: %+v", authToken)
+	s.log.WithField("authToken", authToken).Trace("obtained auth token")
 
 	accessToken, err := s.server.Manager.GenerateAccessToken(context.Background(), oauth2.AuthorizationCode, &oauth2.TokenGenerateRequest{
 		ClientID:     authToken.GetClientID(),
@@ -169,7 +187,7 @@ func (s *s) GenerateUserAccessToken(ti oauth2.TokenInfo, clientSecret string, us
 	if accessToken == nil {
 		return nil, errors.New("generated user-level access token was empty")
 	}
-	s.log.Tracef("obtained user-level access token: %+v", accessToken)
+	s.log.WithField("accessToken", accessToken).Trace("obtained user-level access token")
 	return accessToken, nil
 }
 
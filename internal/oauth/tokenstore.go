@@ -60,7 +60,7 @@ func newTokenStore(ctx context.Context, db db.Basic, log *logrus.Logger) oauth2.
 			case <-time.After(1 * time.Minute):
 				log.Trace("sweeping out old oauth entries broom broom")
 				if err := ts.sweep(ctx); err != nil {
-					log.Errorf("error while sweeping oauth entries: %s", err)
+					log.WithError(err).Error("error while sweeping oauth entries")
 				}
 			}
 		}
@@ -18,10 +18,46 @@
 
 package oidc
 
+import "fmt"
+
 // Claims represents claims as found in an id_token returned from an OIDC flow.
+//
+// Which claims of the id_token the email, groups and name are taken from can be configured,
+// so the claims are first parsed into a map and then picked out using claimString and claimStrings.
 type Claims struct {
-	Email         string   `json:"email"`
-	EmailVerified bool     `json:"email_verified"`
-	Groups        []string `json:"groups"`
-	Name          string   `json:"name"`
+	Email         string
+	EmailVerified bool
+	Groups        []string
+	Name          string
+}
+
+// claimString returns the value of the given claim as a string, or an empty string if the claim isn't set.
+func claimString(raw map[string]interface{}, claim string) string {
+	switch v := raw[claim].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// claimStrings returns the value of the given claim as a slice of strings. Some providers return a single
+// group as a plain string rather than an array, so that's accepted too.
+func claimStrings(raw map[string]interface{}, claim string) []string {
+	switch v := raw[claim].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := []string{}
+		for _, i := range v {
+			if s, ok := i.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
 }
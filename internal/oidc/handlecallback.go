@@ -25,7 +25,7 @@ import (
 )
 
 func (i *idp) HandleCallback(ctx context.Context, code string) (*Claims, error) {
-	l := i.log.WithField("func", "HandleCallback")
+	l := i.log.WithContext(ctx).WithField("func", "HandleCallback")
 	if code == "" {
 		return nil, errors.New("code was empty string")
 	}
@@ -41,7 +41,7 @@ func (i *idp) HandleCallback(ctx context.Context, code string) (*Claims, error)
 	if !ok {
 		return nil, errors.New("no id_token in oauth2token")
 	}
-	l.Debugf("raw id token: %s", rawIDToken)
+	l.WithField("rawIDToken", rawIDToken).Debug("raw id token")
 
 	// Parse and verify ID Token payload.
 	l.Debug("verifying id_token")
@@ -52,12 +52,18 @@ func (i *idp) HandleCallback(ctx context.Context, code string) (*Claims, error)
 	}
 
 	l.Debug("extracting claims from id_token")
-	claims := &Claims{}
-	if err := idToken.Claims(claims); err != nil {
+	raw := map[string]interface{}{}
+	if err := idToken.Claims(&raw); err != nil {
 		return nil, fmt.Errorf("could not parse claims from idToken: %s", err)
 	}
 
-	return claims, nil
+	emailVerified, _ := raw["email_verified"].(bool)
+	return &Claims{
+		Email:         claimString(raw, i.emailClaim),
+		EmailVerified: emailVerified,
+		Groups:        claimStrings(raw, i.groupsClaim),
+		Name:          claimString(raw, i.usernameClaim),
+	}, nil
 }
 
 func (i *idp) AuthCodeURL(state string) string {
@@ -48,8 +48,11 @@ type IDP interface {
 type idp struct {
 	oauth2Config oauth2.Config
 	provider     *oidc.Provider
-	oidcConf     *oidc.Config
-	log          *logrus.Logger
+	oidcConf      *oidc.Config
+	usernameClaim string
+	emailClaim    string
+	groupsClaim   string
+	log           *logrus.Logger
 }
 
 // NewIDP returns a new IDP configured with the given config and logger.
@@ -79,6 +82,15 @@ func NewIDP(config *config.Config, log *logrus.Logger) (IDP, error) {
 	if len(config.OIDCConfig.Scopes) == 0 {
 		return nil, fmt.Errorf("not set: Scopes")
 	}
+	if config.OIDCConfig.UsernameClaim == "" {
+		return nil, fmt.Errorf("not set: UsernameClaim")
+	}
+	if config.OIDCConfig.EmailClaim == "" {
+		return nil, fmt.Errorf("not set: EmailClaim")
+	}
+	if !validUsernameConflict(config.OIDCConfig.UsernameConflict) {
+		return nil, fmt.Errorf("invalid UsernameConflict %s", config.OIDCConfig.UsernameConflict)
+	}
 
 	provider, err := oidc.NewProvider(context.Background(), config.OIDCConfig.Issuer)
 	if err != nil {
@@ -113,9 +125,21 @@ func NewIDP(config *config.Config, log *logrus.Logger) (IDP, error) {
 	}
 
 	return &idp{
-		oauth2Config: oauth2Config,
-		oidcConf:     oidcConf,
-		provider:     provider,
-		log:          log,
+		oauth2Config:  oauth2Config,
+		oidcConf:      oidcConf,
+		provider:      provider,
+		usernameClaim: config.OIDCConfig.UsernameClaim,
+		emailClaim:    config.OIDCConfig.EmailClaim,
+		groupsClaim:   config.OIDCConfig.GroupsClaim,
+		log:           log,
 	}, nil
 }
+
+// validUsernameConflict returns true if the given value is a known way of handling username conflicts.
+func validUsernameConflict(usernameConflict string) bool {
+	switch usernameConflict {
+	case "", config.OIDCUsernameConflictIncrement, config.OIDCUsernameConflictReject:
+		return true
+	}
+	return false
+}
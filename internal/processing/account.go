@@ -22,24 +22,76 @@ import (
 	"context"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/feed"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
+func (p *processor) AccountAlias(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.Alias(ctx, authed.Account, form)
+}
+
 func (p *processor) AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, error) {
 	return p.accountProcessor.Create(ctx, authed.Token, authed.Application, form)
 }
 
+func (p *processor) AccountDeleteLocal(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountDeleteRequest) gtserror.WithCode {
+	return p.accountProcessor.DeleteLocal(ctx, authed.User, authed.Account, form)
+}
+
 func (p *processor) AccountGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Account, error) {
 	return p.accountProcessor.Get(ctx, authed.Account, targetAccountID)
 }
 
+func (p *processor) AccountGetLocalByUsername(ctx context.Context, authed *oauth.Auth, username string) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.GetLocalByUsername(ctx, authed.Account, username)
+}
+
+func (p *processor) AccountLookup(ctx context.Context, authed *oauth.Auth, acct string) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.Lookup(ctx, authed.Account, acct)
+}
+
+func (p *processor) AccountMove(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMoveRequest) gtserror.WithCode {
+	return p.accountProcessor.Move(ctx, authed.User, authed.Account, form)
+}
+
 func (p *processor) AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error) {
 	return p.accountProcessor.Update(ctx, authed.Account, form)
 }
 
-func (p *processor) AccountStatusesGet(ctx context.Context, authed *oauth.Auth, targetAccountID string, limit int, excludeReplies bool, maxID string, pinnedOnly bool, mediaOnly bool) ([]apimodel.Status, gtserror.WithCode) {
-	return p.accountProcessor.StatusesGet(ctx, authed.Account, targetAccountID, limit, excludeReplies, maxID, pinnedOnly, mediaOnly)
+func (p *processor) AccountRotateKeys(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode) {
+	account, errWithCode := p.accountProcessor.RotateKeys(ctx, authed.Account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	acctSensitive, err := p.tc.AccountToMastoSensitive(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return acctSensitive, nil
+}
+
+func (p *processor) AccountStatusesGet(ctx context.Context, authed *oauth.Auth, targetAccountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, sinceID string, minID string, pinnedOnly bool, mediaOnly bool, tagged string) ([]apimodel.Status, gtserror.WithCode) {
+	statuses, errWithCode := p.accountProcessor.StatusesGet(ctx, authed.Account, targetAccountID, limit, excludeReplies, excludeReblogs, maxID, sinceID, minID, pinnedOnly, mediaOnly, tagged)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.filterStatusValues(ctx, authed.Account, gtsmodel.FilterContextAccount, statuses), nil
+}
+
+func (p *processor) AccountWebStatusesGet(ctx context.Context, targetAccountID string, limit int, maxID string) ([]apimodel.Status, gtserror.WithCode) {
+	return p.accountProcessor.WebStatusesGet(ctx, targetAccountID, limit, maxID)
+}
+
+func (p *processor) AccountPinnedWebStatusesGet(ctx context.Context, targetAccountID string) ([]apimodel.Status, gtserror.WithCode) {
+	return p.accountProcessor.PinnedWebStatusesGet(ctx, targetAccountID)
+}
+
+func (p *processor) AccountFeedGet(ctx context.Context, username string) (*feed.Feed, gtserror.WithCode) {
+	return p.accountProcessor.FeedGet(ctx, username)
 }
 
 func (p *processor) AccountFollowersGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]apimodel.Account, gtserror.WithCode) {
@@ -54,6 +106,10 @@ func (p *processor) AccountRelationshipGet(ctx context.Context, authed *oauth.Au
 	return p.accountProcessor.RelationshipGet(ctx, authed.Account, targetAccountID)
 }
 
+func (p *processor) AccountRelationshipsGet(ctx context.Context, authed *oauth.Auth, targetAccountIDs []string) ([]apimodel.Relationship, gtserror.WithCode) {
+	return p.accountProcessor.RelationshipsGet(ctx, authed.Account, targetAccountIDs)
+}
+
 func (p *processor) AccountFollowCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountFollowRequest) (*apimodel.Relationship, gtserror.WithCode) {
 	return p.accountProcessor.FollowCreate(ctx, authed.Account, form)
 }
@@ -69,3 +125,15 @@ func (p *processor) AccountBlockCreate(ctx context.Context, authed *oauth.Auth,
 func (p *processor) AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	return p.accountProcessor.BlockRemove(ctx, authed.Account, targetAccountID)
 }
+
+func (p *processor) AccountMuteCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	return p.accountProcessor.MuteCreate(ctx, authed.Account, form)
+}
+
+func (p *processor) AccountMuteRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	return p.accountProcessor.MuteRemove(ctx, authed.Account, targetAccountID)
+}
+
+func (p *processor) AccountNoteSet(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountNoteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	return p.accountProcessor.NoteSet(ctx, authed.Account, form)
+}
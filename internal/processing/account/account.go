@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/feed"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -40,24 +41,51 @@ import (
 
 // Processor wraps a bunch of functions for processing account actions.
 type Processor interface {
+	// Alias replaces the alsoKnownAs aliases of the given account with the ones in the form, and federates
+	// an update of the account so that remotes can verify a move to it.
+	Alias(ctx context.Context, account *gtsmodel.Account, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode)
 	// Create processes the given form for creating a new account, returning an oauth token for that account if successful.
 	Create(ctx context.Context, applicationToken oauth2.TokenInfo, application *gtsmodel.Application, form *apimodel.AccountCreateRequest) (*apimodel.Token, error)
 	// Delete deletes an account, and all of that account's statuses, media, follows, notifications, etc etc etc.
 	// The origin passed here should be either the ID of the account doing the delete (can be itself), or the ID of a domain block.
 	Delete(ctx context.Context, account *gtsmodel.Account, origin string) error
+	// DeleteLocal handles a request by a local user to delete their own account. The user's password must be given
+	// for confirmation; if it matches, the user is disabled right away and the rest of the delete happens asynchronously.
+	DeleteLocal(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account, form *apimodel.AccountDeleteRequest) gtserror.WithCode
 	// Get processes the given request for account information.
 	Get(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Account, error)
+	// GetLocalByUsername processes the given request for information about the local account with the given username.
+	GetLocalByUsername(ctx context.Context, requestingAccount *gtsmodel.Account, username string) (*apimodel.Account, gtserror.WithCode)
+	// Lookup processes the given request for information about the account with the given acct, like someone or someone@example.org.
+	// Only accounts already known to this instance are looked up; remote accounts are not dereferenced.
+	Lookup(ctx context.Context, requestingAccount *gtsmodel.Account, acct string) (*apimodel.Account, gtserror.WithCode)
 	// Update processes the update of an account with the given form
 	Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
+	// Move handles a request by a local user to move their account to another account, which must list the
+	// moving account as an alias. The user's password must be given for confirmation; followers are moved asynchronously.
+	Move(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account, form *apimodel.AccountMoveRequest) gtserror.WithCode
+	// RotateKeys replaces the rsa keypair of the given local account with a new one, and federates an update
+	// of the account so that remote instances pick up the new public key. The updated account is returned.
+	RotateKeys(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.Account, gtserror.WithCode)
 	// StatusesGet fetches a number of statuses (in time descending order) from the given account, filtered by visibility for
-	// the account given in authed.
-	StatusesGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, limit int, excludeReplies bool, maxID string, pinned bool, mediaOnly bool) ([]apimodel.Status, gtserror.WithCode)
+	// the account given in authed. If tagged is set, only statuses using the hashtag with that name are returned.
+	StatusesGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, sinceID string, minID string, pinned bool, mediaOnly bool, tagged string) ([]apimodel.Status, gtserror.WithCode)
+	// WebStatusesGet fetches a page of statuses from the given account that are suitable for showing on its public
+	// web profile: public, top-level posts that aren't boosts, newest first.
+	WebStatusesGet(ctx context.Context, targetAccountID string, limit int, maxID string) ([]apimodel.Status, gtserror.WithCode)
+	// PinnedWebStatusesGet fetches the public pinned statuses of the given account, for showing on its public web profile.
+	PinnedWebStatusesGet(ctx context.Context, targetAccountID string) ([]apimodel.Status, gtserror.WithCode)
+	// FeedGet builds an RSS/Atom feed of the public posts of the local account with the given username.
+	// A not found error is returned if the account doesn't exist or hasn't enabled its feed.
+	FeedGet(ctx context.Context, username string) (*feed.Feed, gtserror.WithCode)
 	// FollowersGet fetches a list of the target account's followers.
 	FollowersGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
 	// FollowingGet fetches a list of the accounts that target account is following.
 	FollowingGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
 	// RelationshipGet returns a relationship model describing the relationship of the targetAccount to the Authed account.
 	RelationshipGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// RelationshipsGet returns relationship models describing the relationships of each of the target accounts to the requesting account, in the same order.
+	RelationshipsGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountIDs []string) ([]apimodel.Relationship, gtserror.WithCode)
 	// FollowCreate handles a follow request to an account, either remote or local.
 	FollowCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountFollowRequest) (*apimodel.Relationship, gtserror.WithCode)
 	// FollowRemove handles the removal of a follow/follow request to an account, either remote or local.
@@ -66,6 +94,12 @@ type Processor interface {
 	BlockCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// BlockRemove handles the removal of a block from requestingAccount to targetAccountID, either remote or local.
 	BlockRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// MuteCreate handles the creation or updating of a mute from requestingAccount to the account specified in the form.
+	MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode)
+	// MuteRemove handles the removal of a mute from requestingAccount to targetAccountID.
+	MuteRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// NoteSet sets, replaces or removes the private note written by requestingAccount about the account specified in the form.
+	NoteSet(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountNoteRequest) (*apimodel.Relationship, gtserror.WithCode)
 
 	// UpdateHeader does the dirty work of checking the header part of an account update form,
 	// parsing and checking the image, and doing the necessary updates in the database for this to become
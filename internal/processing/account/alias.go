@@ -0,0 +1,82 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// maximumAliases is the most alsoKnownAs aliases that one account can declare.
+const maximumAliases = 5
+
+func (p *processor) Alias(ctx context.Context, account *gtsmodel.Account, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode) {
+	if len(form.AlsoKnownAsURIs) > maximumAliases {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("Alias: %d aliases provided", len(form.AlsoKnownAsURIs)), fmt.Sprintf("at most %d aliases can be set", maximumAliases))
+	}
+
+	aliases := []string{}
+	seen := make(map[string]bool, len(form.AlsoKnownAsURIs))
+	for _, alias := range form.AlsoKnownAsURIs {
+		aliasURI, err := url.Parse(alias)
+		if err != nil || (aliasURI.Scheme != "http" && aliasURI.Scheme != "https") || aliasURI.Host == "" {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("Alias: alias %s is not a valid uri", alias), fmt.Sprintf("alias %s is not a valid http(s) uri", alias))
+		}
+
+		if alias == account.URI {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("Alias: account %s tried to alias itself", account.ID), "an account can't be an alias of itself")
+		}
+
+		if seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+	}
+
+	account.AlsoKnownAsURIs = aliases
+	updatedAccount, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Alias: error updating account %s: %s", account.ID, err))
+	}
+
+	// remotes need to see the new aliases before a move from one of them to this account will be accepted
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       updatedAccount,
+		OriginAccount:  updatedAccount,
+	}
+
+	acctSensitive, err := p.tc.AccountToMastoSensitive(ctx, updatedAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("Alias: error converting account into apisensitive account: %s", err))
+	}
+
+	return acctSensitive, nil
+}
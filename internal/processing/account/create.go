@@ -21,15 +21,21 @@ package account
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
 func (p *processor) Create(ctx context.Context, applicationToken oauth2.TokenInfo, application *gtsmodel.Application, form *apimodel.AccountCreateRequest) (*apimodel.Token, error) {
-	l := p.log.WithField("func", "accountCreate")
+	l := p.log.WithContext(ctx).WithField("func", "accountCreate")
 
 	emailAvailable, err := p.db.IsEmailAvailable(ctx, form.Email)
 	if err != nil {
@@ -47,19 +53,47 @@ func (p *processor) Create(ctx context.Context, applicationToken oauth2.TokenInf
 		return nil, fmt.Errorf("username %s in use", form.Username)
 	}
 
-	// don't store a reason if we don't require one
+	// when registration is closed, sign ups are still taken but they always wait for an admin
+	requireApproval := p.config.AccountsConfig.RequireApproval || !p.config.AccountsConfig.OpenRegistration
+
+	// don't store a reason if we don't require one, and there's no admin who'll read it
 	reason := form.Reason
-	if !p.config.AccountsConfig.ReasonRequired {
+	if !p.config.AccountsConfig.ReasonRequired && !requireApproval {
 		reason = ""
 	}
 
 	l.Trace("creating new username and account")
-	user, err := p.db.NewSignup(ctx, form.Username, text.RemoveHTML(reason), p.config.AccountsConfig.RequireApproval, form.Email, form.Password, form.IP, form.Locale, application.ID, false, false)
+	user, err := p.db.NewSignup(ctx, form.Username, text.RemoveHTML(reason), requireApproval, form.Email, form.Password, form.IP, form.Locale, application.ID, false, false)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new signup in the database: %s", err)
 	}
 
-	l.Tracef("generating a token for user %s with account %s and application %s", user.ID, user.AccountID, application.ID)
+	// agreeing to the terms when signing up covers the rules too, so record when they
+	// were acknowledged: moderators can compare that with when the rules last changed
+	rules, err := p.db.GetInstanceRules(ctx)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("error getting instance rules: %s", err)
+	}
+	if len(rules) != 0 {
+		user.RulesAcknowledgedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+			return nil, fmt.Errorf("error updating user %s: %s", user.ID, err)
+		}
+	}
+
+	// let admins know about the sign up asynchronously
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       user,
+	}
+
+	l.WithFields(logrus.Fields{
+		"userID":        user.ID,
+		"accountID":     user.AccountID,
+		"applicationID": application.ID,
+	}).Trace("generating a token for user")
 	accessToken, err := p.oauthServer.GenerateUserAccessToken(applicationToken, application.ClientSecret, user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new access token for user %s: %s", user.ID, err)
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -114,6 +115,7 @@ func (p *processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 	// follow request status changed so send the UNDO activity to the channel for async processing
 	if frChanged {
 		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
 			APObjectType:   ap.ActivityFollow,
 			APActivityType: ap.ActivityUndo,
 			GTSModel: &gtsmodel.Follow{
@@ -129,6 +131,7 @@ func (p *processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 	// follow status changed so send the UNDO activity to the channel for async processing
 	if fChanged {
 		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
 			APObjectType:   ap.ActivityFollow,
 			APActivityType: ap.ActivityUndo,
 			GTSModel: &gtsmodel.Follow{
@@ -143,6 +146,7 @@ func (p *processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 
 	// handle the rest of the block process asynchronously
 	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
 		APObjectType:   ap.ActivityBlock,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       block,
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -102,6 +103,7 @@ func (p *processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 
 	// otherwise we leave the follow request as it is and we handle the rest of the process asynchronously
 	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
 		APObjectType:   ap.ActivityFollow,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       fr,
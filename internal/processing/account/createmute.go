@@ -0,0 +1,105 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+func (p *processor) MuteCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	if form.ID == requestingAccount.ID {
+		return nil, gtserror.NewErrorBadRequest(errors.New("MuteCreate: account cannot mute itself"), "you cannot mute yourself")
+	}
+
+	if form.Duration < 0 {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("MuteCreate: negative duration %d", form.Duration), "duration must not be negative")
+	}
+
+	// make sure the target account actually exists in our db
+	targetAccount, err := p.db.GetAccountByID(ctx, form.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("MuteCreate: error getting account %s from the db: %s", form.ID, err))
+	}
+
+	// notifications are muted too unless the caller says otherwise
+	notifications := true
+	if form.Notifications != nil {
+		notifications = *form.Notifications
+	}
+
+	var expiresAt time.Time
+	if form.Duration > 0 {
+		expiresAt = time.Now().Add(time.Duration(form.Duration) * time.Second)
+	}
+
+	mute, err := p.db.GetMute(ctx, requestingAccount.ID, targetAccount.ID)
+	switch err {
+	case nil:
+		// there's already a mute (maybe an expired one) so just update it in place
+		mute.UpdatedAt = time.Now()
+		mute.ExpiresAt = expiresAt
+		mute.Notifications = notifications
+		if err := p.db.UpdateByPrimaryKey(ctx, mute); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error updating mute in db: %s", err))
+		}
+	case db.ErrNoEntries:
+		newMuteID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mute = &gtsmodel.AccountMute{
+			ID:              newMuteID,
+			ExpiresAt:       expiresAt,
+			AccountID:       requestingAccount.ID,
+			Account:         requestingAccount,
+			TargetAccountID: targetAccount.ID,
+			TargetAccount:   targetAccount,
+			Notifications:   notifications,
+		}
+		if err := p.db.Put(ctx, mute); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error creating mute in db: %s", err))
+		}
+	default:
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("MuteCreate: error checking existence of mute: %s", err))
+	}
+
+	// mutes aren't federated, but the muted account's statuses still need to be cleared from timelines
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActivityIgnore,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       mute,
+		OriginAccount:  requestingAccount,
+		TargetAccount:  targetAccount,
+	}
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+}
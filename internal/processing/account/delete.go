@@ -20,13 +20,19 @@ package account
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Delete handles the complete deletion of an account.
@@ -58,7 +64,7 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	if account.Domain != "" {
 		fields["domain"] = account.Domain
 	}
-	l := p.log.WithFields(fields)
+	l := p.log.WithContext(ctx).WithFields(fields)
 
 	l.Debug("beginning account delete process")
 
@@ -75,15 +81,19 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 				for _, t := range tokens {
 					// delete client(s) associated with this token
 					if err := p.db.DeleteByID(ctx, t.ClientID, &gtsmodel.Client{}); err != nil {
-						l.Errorf("error deleting oauth client: %s", err)
+						l.WithError(err).Error("error deleting oauth client")
 					}
 					// delete application(s) associated with this token
 					if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "client_id", Value: t.ClientID}}, &gtsmodel.Application{}); err != nil {
-						l.Errorf("error deleting application: %s", err)
+						l.WithError(err).Error("error deleting application")
+					}
+					// delete the push subscription made with this token, if there is one
+					if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "token_id", Value: t.ID}}, &gtsmodel.WebPushSubscription{}); err != nil && err != db.ErrNoEntries {
+						l.WithError(err).Error("error deleting push subscription")
 					}
 					// delete the token itself
 					if err := p.db.DeleteByID(ctx, t.ID, t); err != nil {
-						l.Errorf("error deleting oauth token: %s", err)
+						l.WithError(err).Error("error deleting oauth token")
 					}
 				}
 			}
@@ -94,12 +104,12 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	l.Debug("deleting account blocks")
 	// first delete any blocks that this account created
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.Block{}); err != nil {
-		l.Errorf("error deleting blocks created by account: %s", err)
+		l.WithError(err).Error("error deleting blocks created by account")
 	}
 
 	// now delete any blocks that target this account
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.Block{}); err != nil {
-		l.Errorf("error deleting blocks targeting account: %s", err)
+		l.WithError(err).Error("error deleting blocks targeting account")
 	}
 
 	// 3. Delete account's emoji
@@ -110,12 +120,12 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	l.Debug("deleting account follow requests")
 	// first delete any follow requests that this account created
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.FollowRequest{}); err != nil {
-		l.Errorf("error deleting follow requests created by account: %s", err)
+		l.WithError(err).Error("error deleting follow requests created by account")
 	}
 
 	// now delete any follow requests that target this account
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.FollowRequest{}); err != nil {
-		l.Errorf("error deleting follow requests targeting account: %s", err)
+		l.WithError(err).Error("error deleting follow requests targeting account")
 	}
 
 	// 5. Delete account's follows
@@ -123,12 +133,28 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	l.Debug("deleting account follows")
 	// first delete any follows that this account created
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.Follow{}); err != nil {
-		l.Errorf("error deleting follows created by account: %s", err)
+		l.WithError(err).Error("error deleting follows created by account")
 	}
 
 	// now delete any follows that target this account
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.Follow{}); err != nil {
-		l.Errorf("error deleting follows targeting account: %s", err)
+		l.WithError(err).Error("error deleting follows targeting account")
+	}
+
+	// the account can't be in anyone's lists anymore, and it won't need its own lists
+	l.Debug("deleting account lists")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.ListEntry{}); err != nil {
+		l.WithError(err).Error("error deleting list entries targeting account")
+	}
+
+	if lists, err := p.db.GetListsForAccountID(ctx, account.ID); err != nil {
+		l.WithError(err).Error("error getting lists owned by account")
+	} else {
+		for _, list := range lists {
+			if err := p.db.DeleteListByID(ctx, list.ID); err != nil {
+				l.WithError(err).Error("error deleting list owned by account")
+			}
+		}
 	}
 
 	// 6. Delete account's statuses
@@ -139,15 +165,15 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	var maxID string
 selectStatusesLoop:
 	for {
-		statuses, err := p.db.GetAccountStatuses(ctx, account.ID, 20, false, maxID, false, false)
+		statuses, err := p.db.GetAccountStatuses(ctx, account.ID, 20, false, false, maxID, "", "", false, false, "")
 		if err != nil {
 			if err == db.ErrNoEntries {
 				// no statuses left for this instance so we're done
-				l.Infof("Delete: done iterating through statuses for account %s", account.Username)
+				l.WithField("username", account.Username).Info("Delete: done iterating through statuses for account")
 				break selectStatusesLoop
 			}
 			// an actual error has occurred
-			l.Errorf("Delete: db error selecting statuses for account %s: %s", account.Username, err)
+			l.WithError(err).WithField("username", account.Username).Error("Delete: db error selecting statuses for account")
 			break selectStatusesLoop
 		}
 
@@ -156,6 +182,7 @@ selectStatusesLoop:
 			s.Account = account
 			l.Debug("putting status in the client api channel")
 			p.fromClientAPI <- messages.FromClientAPI{
+				RequestID:      log.RequestID(ctx),
 				APObjectType:   ap.ObjectNote,
 				APActivityType: ap.ActivityDelete,
 				GTSModel:       s,
@@ -166,7 +193,10 @@ selectStatusesLoop:
 			if err := p.db.DeleteByID(ctx, s.ID, s); err != nil {
 				if err != db.ErrNoEntries {
 					// actual error has occurred
-					l.Errorf("Delete: db error status %s for account %s: %s", s.ID, account.Username, err)
+					l.WithError(err).WithFields(logrus.Fields{
+						"statusID": s.ID,
+						"username": account.Username,
+					}).Error("Delete: db error deleting status for account")
 					break selectStatusesLoop
 				}
 			}
@@ -176,7 +206,10 @@ selectStatusesLoop:
 			if err := p.db.GetWhere(ctx, []db.Where{{Key: "boost_of_id", Value: s.ID}}, &boosts); err != nil {
 				if err != db.ErrNoEntries {
 					// an actual error has occurred
-					l.Errorf("Delete: db error selecting boosts of status %s for account %s: %s", s.ID, account.Username, err)
+					l.WithError(err).WithFields(logrus.Fields{
+						"statusID": s.ID,
+						"username": account.Username,
+					}).Error("Delete: db error selecting boosts of status for account")
 					break selectStatusesLoop
 				}
 			}
@@ -192,6 +225,7 @@ selectStatusesLoop:
 
 				l.Debug("putting boost undo in the client api channel")
 				p.fromClientAPI <- messages.FromClientAPI{
+					RequestID:      log.RequestID(ctx),
 					APObjectType:   ap.ActivityAnnounce,
 					APActivityType: ap.ActivityUndo,
 					GTSModel:       s,
@@ -202,7 +236,7 @@ selectStatusesLoop:
 				if err := p.db.DeleteByID(ctx, b.ID, b); err != nil {
 					if err != db.ErrNoEntries {
 						// actual error has occurred
-						l.Errorf("Delete: db error deleting boost with id %s: %s", b.ID, err)
+						l.WithError(err).WithField("boostID", b.ID).Error("Delete: db error deleting boost")
 						break selectStatusesLoop
 					}
 				}
@@ -220,38 +254,67 @@ selectStatusesLoop:
 	l.Debug("deleting account notifications")
 	// first notifications created by account
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "origin_account_id", Value: account.ID}}, &[]*gtsmodel.Notification{}); err != nil {
-		l.Errorf("error deleting notifications created by account: %s", err)
+		l.WithError(err).Error("error deleting notifications created by account")
 	}
 
 	// now notifications targeting account
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.Notification{}); err != nil {
-		l.Errorf("error deleting notifications targeting account: %s", err)
+		l.WithError(err).Error("error deleting notifications targeting account")
+	}
+
+	// and the account's notification preferences
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.NotificationPreferences{}); err != nil {
+		l.WithError(err).Error("error deleting notification preferences of account")
 	}
 
 	// 11. Delete account's bookmarks
 	l.Debug("deleting account bookmarks")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.StatusBookmark{}); err != nil {
-		l.Errorf("error deleting bookmarks created by account: %s", err)
+		l.WithError(err).Error("error deleting bookmarks created by account")
 	}
 
 	// 12. Delete account's faves
 	// TODO: federate these if necessary
 	l.Debug("deleting account faves")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.StatusFave{}); err != nil {
-		l.Errorf("error deleting faves created by account: %s", err)
+		l.WithError(err).Error("error deleting faves created by account")
+	}
+
+	// and any votes it cast in polls
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.PollVote{}); err != nil {
+		l.WithError(err).Error("error deleting poll votes created by account")
 	}
 
 	// 13. Delete account's mutes
 	l.Debug("deleting account mutes")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.StatusMute{}); err != nil {
-		l.Errorf("error deleting status mutes created by account: %s", err)
+		l.WithError(err).Error("error deleting status mutes created by account")
+	}
+
+	// now delete account mutes created by or targeting this account
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.AccountMute{}); err != nil {
+		l.WithError(err).Error("error deleting account mutes created by account")
+	}
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.AccountMute{}); err != nil {
+		l.WithError(err).Error("error deleting account mutes targeting account")
+	}
+
+	// and any notes written by or about this account
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.AccountNote{}); err != nil {
+		l.WithError(err).Error("error deleting account notes created by account")
+	}
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "target_account_id", Value: account.ID}}, &[]*gtsmodel.AccountNote{}); err != nil {
+		l.WithError(err).Error("error deleting account notes about account")
 	}
 
 	// 14. Delete account's streams
 	// TODO
 
 	// 15. Delete account's tags
-	// TODO
+	l.Debug("deleting account tag follows")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.TagFollow{}); err != nil {
+		l.WithError(err).Error("error deleting tag follows created by account")
+	}
 
 	// 16. Delete account's user
 	l.Debug("deleting account user")
@@ -285,6 +348,36 @@ selectStatusesLoop:
 		return err
 	}
 
-	l.Infof("deleted account with username %s from domain %s", account.Username, account.Domain)
+	l.WithFields(logrus.Fields{
+		"username": account.Username,
+		"domain":   account.Domain,
+	}).Info("deleted account")
+	return nil
+}
+
+func (p *processor) DeleteLocal(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account, form *apimodel.AccountDeleteRequest) gtserror.WithCode {
+	if form.Password == "" {
+		return gtserror.NewErrorBadRequest(errors.New("DeleteLocal: no password provided"), "password must be provided to delete your account")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(form.Password)); err != nil {
+		return gtserror.NewErrorForbidden(fmt.Errorf("DeleteLocal: password didn't match for user %s", user.ID), "password was incorrect")
+	}
+
+	// disable the user straight away so that it can't be used while the rest of the delete is processed
+	user.Disabled = true
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("DeleteLocal: error disabling user %s: %s", user.ID, err))
+	}
+
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       account,
+		OriginAccount:  account,
+		TargetAccount:  account,
+	}
+
 	return nil
 }
@@ -0,0 +1,80 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountDeleteTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountDeleteTestSuite) TestDeleteLocal() {
+	ctx := context.Background()
+	testUser := suite.testUsers["local_account_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+
+	errWithCode := suite.accountProcessor.DeleteLocal(ctx, testUser, testAccount, &apimodel.AccountDeleteRequest{
+		Password: "password",
+	})
+	suite.NoError(errWithCode)
+
+	// the user should be disabled straight away
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetByID(ctx, testUser.ID, dbUser)
+	suite.NoError(err)
+	suite.True(dbUser.Disabled)
+
+	// and the rest of the delete should be queued up
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityDelete, msg.APActivityType)
+	suite.Equal(ap.ActorPerson, msg.APObjectType)
+	suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+	suite.Equal(testAccount.ID, msg.TargetAccount.ID)
+}
+
+func (suite *AccountDeleteTestSuite) TestDeleteLocalWrongPassword() {
+	ctx := context.Background()
+	testUser := suite.testUsers["local_account_1"]
+
+	errWithCode := suite.accountProcessor.DeleteLocal(ctx, testUser, suite.testAccounts["local_account_1"], &apimodel.AccountDeleteRequest{
+		Password: "not the password",
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// nothing should have happened to the user
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetByID(ctx, testUser.ID, dbUser)
+	suite.NoError(err)
+	suite.False(dbUser.Disabled)
+	suite.Empty(suite.fromClientAPIChan)
+}
+
+func TestAccountDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &AccountDeleteTestSuite{})
+}
@@ -22,12 +22,66 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+func (p *processor) GetLocalByUsername(ctx context.Context, requestingAccount *gtsmodel.Account, username string) (*apimodel.Account, gtserror.WithCode) {
+	targetAccount, err := p.db.GetLocalAccountByUsername(ctx, username)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %s", err))
+	}
+
+	apiAccount, err := p.Get(ctx, requestingAccount, targetAccount.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
+
+func (p *processor) Lookup(ctx context.Context, requestingAccount *gtsmodel.Account, acct string) (*apimodel.Account, gtserror.WithCode) {
+	username, domain := strings.TrimPrefix(acct, "@"), ""
+	if i := strings.Index(username, "@"); i != -1 {
+		username, domain = username[:i], username[i+1:]
+	}
+	if username == "" {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("couldn't parse acct %s", acct), "acct should be like someone or someone@example.org")
+	}
+
+	var targetAccount *gtsmodel.Account
+	var err error
+	if domain == "" || strings.EqualFold(domain, p.config.Host) || strings.EqualFold(domain, p.config.AccountDomain) {
+		targetAccount, err = p.db.GetLocalAccountByUsername(ctx, username)
+	} else {
+		targetAccount = &gtsmodel.Account{}
+		err = p.db.GetWhere(ctx, []db.Where{
+			{Key: "username", Value: username, CaseInsensitive: true},
+			{Key: "domain", Value: domain, CaseInsensitive: true},
+		}, targetAccount)
+	}
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %s", err))
+	}
+
+	apiAccount, err := p.Get(ctx, requestingAccount, targetAccount.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
+
 func (p *processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Account, error) {
 	targetAccount, err := p.db.GetAccountByID(ctx, targetAccountID)
 	if err != nil {
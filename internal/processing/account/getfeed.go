@@ -0,0 +1,137 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/feed"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	// feedLength is the maximum number of posts to include in an account feed.
+	feedLength = 20
+	// feedTitleLength is the maximum number of characters of a post to use as a feed item title.
+	feedTitleLength = 64
+)
+
+func (p *processor) FeedGet(ctx context.Context, username string) (*feed.Feed, gtserror.WithCode) {
+	account, err := p.db.GetLocalAccountByUsername(ctx, username)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error: %s", err))
+	}
+
+	// don't let on whether the account exists if there's no feed to serve
+	if !account.EnableRSS || !account.SuspendedAt.IsZero() {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s does not have a feed", account.ID))
+	}
+
+	displayName := account.DisplayName
+	if displayName == "" {
+		displayName = account.Username
+	}
+
+	f := &feed.Feed{
+		Title:       fmt.Sprintf("Posts from @%s@%s", account.Username, p.config.AccountDomain),
+		Link:        account.URL,
+		Description: fmt.Sprintf("Public posts from %s (@%s@%s)", displayName, account.Username, p.config.AccountDomain),
+		Author:      displayName,
+		Updated:     account.UpdatedAt,
+	}
+
+	if account.AvatarMediaAttachmentID != "" {
+		if avatar, err := p.db.GetAttachmentByID(ctx, account.AvatarMediaAttachmentID); err == nil {
+			f.Image = avatar.Thumbnail.URL
+		}
+	}
+
+	statuses, err := p.db.GetAccountWebStatuses(ctx, account.ID, feedLength, "")
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting statuses for account %s: %s", account.ID, err))
+	}
+
+	for _, s := range statuses {
+		item, err := p.statusToFeedItem(ctx, account, s)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		f.Items = append(f.Items, item)
+
+		if s.CreatedAt.After(f.Updated) {
+			f.Updated = s.CreatedAt
+		}
+	}
+
+	return f, nil
+}
+
+func (p *processor) statusToFeedItem(ctx context.Context, account *gtsmodel.Account, s *gtsmodel.Status) (*feed.Item, error) {
+	item := &feed.Item{
+		ID:          s.URI,
+		Title:       feedItemTitle(account, s),
+		Link:        s.URL,
+		Description: s.Content,
+		Created:     s.CreatedAt,
+	}
+
+	for _, id := range s.AttachmentIDs {
+		a, err := p.db.GetAttachmentByID(ctx, id)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				continue
+			}
+			return nil, fmt.Errorf("error getting attachment %s for status %s: %s", id, s.ID, err)
+		}
+		item.Enclosures = append(item.Enclosures, &feed.Enclosure{
+			URL:    a.URL,
+			Type:   a.File.ContentType,
+			Length: a.File.FileSize,
+		})
+	}
+
+	return item, nil
+}
+
+// feedItemTitle picks a title for the given status: its content warning if it has one,
+// otherwise the start of its text, otherwise a generic description.
+func feedItemTitle(account *gtsmodel.Account, s *gtsmodel.Status) string {
+	if s.ContentWarning != "" {
+		return s.ContentWarning
+	}
+
+	title := strings.Join(strings.Fields(s.Text), " ")
+	if title == "" {
+		return fmt.Sprintf("New post by @%s", account.Username)
+	}
+
+	if utf8.RuneCountInString(title) > feedTitleLength {
+		title = string([]rune(title)[:feedTitleLength-1]) + "…"
+	}
+	return title
+}
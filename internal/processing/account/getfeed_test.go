@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FeedGetTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FeedGetTestSuite) TestFeedGetNotEnabled() {
+	f, errWithCode := suite.accountProcessor.FeedGet(context.Background(), "admin")
+	suite.Nil(f)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *FeedGetTestSuite) TestFeedGetNoSuchAccount() {
+	f, errWithCode := suite.accountProcessor.FeedGet(context.Background(), "nobody_here")
+	suite.Nil(f)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *FeedGetTestSuite) TestFeedGet() {
+	testAccount := suite.testAccounts["admin_account"]
+	testAccount.EnableRSS = true
+	_, err := suite.db.UpdateAccount(context.Background(), testAccount)
+	suite.NoError(err)
+
+	f, errWithCode := suite.accountProcessor.FeedGet(context.Background(), testAccount.Username)
+	suite.NoError(errWithCode)
+	suite.Equal("Posts from @admin@localhost:8080", f.Title)
+	suite.Equal(testAccount.URL, f.Link)
+
+	// the reply shouldn't be included, just the two public top-level posts, newest first
+	suite.Len(f.Items, 2)
+
+	cwPost := suite.testStatuses["admin_account_status_2"]
+	suite.Equal(cwPost.URI, f.Items[0].ID)
+	suite.Equal(cwPost.URL, f.Items[0].Link)
+	suite.Equal("open to see some puppies", f.Items[0].Title)
+	suite.Empty(f.Items[0].Enclosures)
+	suite.False(f.Updated.Before(cwPost.CreatedAt))
+
+	mediaPost := suite.testStatuses["admin_account_status_1"]
+	suite.Equal(mediaPost.URI, f.Items[1].ID)
+	suite.Equal("New post by @admin", f.Items[1].Title)
+	suite.Equal(mediaPost.Content, f.Items[1].Description)
+	suite.Len(f.Items[1].Enclosures, 1)
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	suite.Equal(attachment.URL, f.Items[1].Enclosures[0].URL)
+	suite.Equal("image/jpeg", f.Items[1].Enclosures[0].Type)
+	suite.Equal(attachment.File.FileSize, f.Items[1].Enclosures[0].Length)
+}
+
+func TestFeedGetTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedGetTestSuite))
+}
@@ -28,6 +28,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+// maximumRelationships is how many relationships can be requested at once.
+const maximumRelationships = 100
+
 func (p *processor) RelationshipGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	if requestingAccount == nil {
 		return nil, gtserror.NewErrorForbidden(errors.New("not authed"))
@@ -45,3 +48,30 @@ func (p *processor) RelationshipGet(ctx context.Context, requestingAccount *gtsm
 
 	return r, nil
 }
+
+func (p *processor) RelationshipsGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountIDs []string) ([]apimodel.Relationship, gtserror.WithCode) {
+	if requestingAccount == nil {
+		return nil, gtserror.NewErrorForbidden(errors.New("not authed"))
+	}
+
+	if len(targetAccountIDs) > maximumRelationships {
+		err := fmt.Errorf("no more than %d account ids can be given at once", maximumRelationships)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	gtsRs, err := p.db.GetRelationships(ctx, requestingAccount.ID, targetAccountIDs)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting relationships: %s", err))
+	}
+
+	rs := make([]apimodel.Relationship, 0, len(gtsRs))
+	for _, gtsR := range gtsRs {
+		r, err := p.tc.RelationshipToMasto(ctx, gtsR)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting relationship: %s", err))
+		}
+		rs = append(rs, *r)
+	}
+
+	return rs, nil
+}
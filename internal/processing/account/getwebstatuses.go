@@ -0,0 +1,75 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) WebStatusesGet(ctx context.Context, targetAccountID string, limit int, maxID string) ([]apimodel.Status, gtserror.WithCode) {
+	statuses, err := p.db.GetAccountWebStatuses(ctx, targetAccountID, limit, maxID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.webStatusesToMasto(ctx, statuses)
+}
+
+func (p *processor) PinnedWebStatusesGet(ctx context.Context, targetAccountID string) ([]apimodel.Status, gtserror.WithCode) {
+	statuses, err := p.db.GetAccountStatuses(ctx, targetAccountID, 0, false, false, "", "", "", true, false, "")
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	public := []*gtsmodel.Status{}
+	for _, s := range statuses {
+		if s.Visibility == gtsmodel.VisibilityPublic {
+			public = append(public, s)
+		}
+	}
+
+	return p.webStatusesToMasto(ctx, public)
+}
+
+func (p *processor) webStatusesToMasto(ctx context.Context, statuses []*gtsmodel.Status) ([]apimodel.Status, gtserror.WithCode) {
+	apiStatuses := []apimodel.Status{}
+
+	for _, s := range statuses {
+		// nobody is logged in on the web view, so check visibility as if for an anonymous viewer
+		visible, err := p.filter.StatusVisible(ctx, s, nil)
+		if err != nil || !visible {
+			continue
+		}
+
+		apiStatus, err := p.tc.StatusToMasto(ctx, s, nil)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status to masto: %s", err))
+		}
+
+		apiStatuses = append(apiStatuses, *apiStatus)
+	}
+
+	return apiStatuses, nil
+}
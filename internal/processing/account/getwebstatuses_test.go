@@ -0,0 +1,97 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WebStatusesGetTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *WebStatusesGetTestSuite) TestGetLocalByUsername() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	apiAccount, errWithCode := suite.accountProcessor.GetLocalByUsername(context.Background(), nil, testAccount.Username)
+	suite.NoError(errWithCode)
+	suite.Equal(testAccount.ID, apiAccount.ID)
+	suite.Equal(testAccount.URL, apiAccount.URL)
+}
+
+func (suite *WebStatusesGetTestSuite) TestGetLocalByUsernameRemote() {
+	// remote accounts don't have a profile page here
+	testAccount := suite.testAccounts["remote_account_1"]
+
+	apiAccount, errWithCode := suite.accountProcessor.GetLocalByUsername(context.Background(), nil, testAccount.Username)
+	suite.Nil(apiAccount)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *WebStatusesGetTestSuite) TestWebStatusesGet() {
+	testAccount := suite.testAccounts["admin_account"]
+
+	statuses, errWithCode := suite.accountProcessor.WebStatusesGet(context.Background(), testAccount.ID, 20, "")
+	suite.NoError(errWithCode)
+
+	// the reply shouldn't be included, just the two public top-level posts, newest first
+	suite.Len(statuses, 2)
+	suite.Equal(suite.testStatuses["admin_account_status_2"].ID, statuses[0].ID)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[1].ID)
+}
+
+func (suite *WebStatusesGetTestSuite) TestWebStatusesGetPaged() {
+	testAccount := suite.testAccounts["admin_account"]
+
+	statuses, errWithCode := suite.accountProcessor.WebStatusesGet(context.Background(), testAccount.ID, 20, suite.testStatuses["admin_account_status_2"].ID)
+	suite.NoError(errWithCode)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+
+	statuses, errWithCode = suite.accountProcessor.WebStatusesGet(context.Background(), testAccount.ID, 20, statuses[0].ID)
+	suite.NoError(errWithCode)
+	suite.Empty(statuses)
+}
+
+func (suite *WebStatusesGetTestSuite) TestPinnedWebStatusesGet() {
+	testAccount := suite.testAccounts["admin_account"]
+
+	statuses, errWithCode := suite.accountProcessor.PinnedWebStatusesGet(context.Background(), testAccount.ID)
+	suite.NoError(errWithCode)
+	suite.Empty(statuses)
+
+	pinned := suite.testStatuses["admin_account_status_1"]
+	pinned.Pinned = true
+	err := suite.db.UpdateByPrimaryKey(context.Background(), pinned)
+	suite.NoError(err)
+
+	statuses, errWithCode = suite.accountProcessor.PinnedWebStatusesGet(context.Background(), testAccount.ID)
+	suite.NoError(errWithCode)
+	suite.Len(statuses, 1)
+	suite.Equal(pinned.ID, statuses[0].ID)
+}
+
+func TestWebStatusesGetTestSuite(t *testing.T) {
+	suite.Run(t, new(WebStatusesGetTestSuite))
+}
@@ -0,0 +1,102 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (p *processor) Move(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account, form *apimodel.AccountMoveRequest) gtserror.WithCode {
+	if form.Password == "" {
+		return gtserror.NewErrorBadRequest(errors.New("Move: no password provided"), "password must be provided to move your account")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(form.Password)); err != nil {
+		return gtserror.NewErrorForbidden(fmt.Errorf("Move: password didn't match for user %s", user.ID), "password was incorrect")
+	}
+
+	targetIRI, err := url.Parse(form.MovedToURI)
+	if err != nil || (targetIRI.Scheme != "http" && targetIRI.Scheme != "https") || targetIRI.Host == "" {
+		return gtserror.NewErrorBadRequest(fmt.Errorf("Move: moved_to_uri %s is not a valid uri", form.MovedToURI), "moved_to_uri must be a valid http(s) uri")
+	}
+
+	if targetIRI.String() == account.URI {
+		return gtserror.NewErrorBadRequest(fmt.Errorf("Move: account %s tried to move to itself", account.ID), "an account can't be moved to itself")
+	}
+
+	var targetAccount *gtsmodel.Account
+	if targetIRI.Host == p.config.Host {
+		a, err := p.db.GetAccountByURI(ctx, targetIRI.String())
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return gtserror.NewErrorNotFound(fmt.Errorf("Move: local account %s not found", targetIRI), "account to move to not found")
+			}
+			return gtserror.NewErrorInternalError(fmt.Errorf("Move: error getting local account %s: %s", targetIRI, err))
+		}
+		targetAccount = a
+	} else {
+		// always refresh, since the user has probably only just added the alias on the remote side
+		a, _, err := p.federator.GetRemoteAccount(ctx, account.Username, targetIRI, true)
+		if err != nil {
+			return gtserror.NewErrorNotFound(fmt.Errorf("Move: error dereferencing account %s: %s", targetIRI, err), "account to move to could not be fetched")
+		}
+		targetAccount = a
+	}
+
+	// check the target acknowledges us, remote instances will refuse the move otherwise
+	var acknowledged bool
+	for _, alias := range targetAccount.AlsoKnownAsURIs {
+		if alias == account.URI {
+			acknowledged = true
+			break
+		}
+	}
+	if !acknowledged {
+		return gtserror.NewErrorBadRequest(fmt.Errorf("Move: account %s doesn't list %s as an alias", targetAccount.URI, account.URI), "the account to move to must list this account as an alias first")
+	}
+
+	account.MovedToAccountID = targetAccount.ID
+	if _, err := p.db.UpdateAccount(ctx, account); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("Move: error updating account %s: %s", account.ID, err))
+	}
+
+	// moving followers might take a while so do it asynchronously
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityMove,
+		GTSModel:       account,
+		OriginAccount:  account,
+		TargetAccount:  targetAccount,
+	}
+
+	return nil
+}
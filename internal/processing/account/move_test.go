@@ -0,0 +1,126 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountMoveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountMoveTestSuite) TestAlias() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_2"]
+
+	_, errWithCode := suite.accountProcessor.Alias(ctx, testAccount, &apimodel.AccountAliasRequest{
+		AlsoKnownAsURIs: []string{
+			suite.testAccounts["local_account_1"].URI,
+			suite.testAccounts["local_account_1"].URI,
+			suite.testAccounts["remote_account_1"].URI,
+		},
+	})
+	suite.NoError(errWithCode)
+
+	// duplicates should have been dropped
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal([]string{suite.testAccounts["local_account_1"].URI, suite.testAccounts["remote_account_1"].URI}, dbAccount.AlsoKnownAsURIs)
+
+	// the new aliases should be federated
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+}
+
+func (suite *AccountMoveTestSuite) TestAliasInvalid() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_2"]
+
+	for _, aliases := range [][]string{
+		{"not a uri"},
+		{"ftp://example.org/users/someone"},
+		{testAccount.URI},
+		{"https://a.example.org/1", "https://a.example.org/2", "https://a.example.org/3", "https://a.example.org/4", "https://a.example.org/5", "https://a.example.org/6"},
+	} {
+		_, errWithCode := suite.accountProcessor.Alias(ctx, testAccount, &apimodel.AccountAliasRequest{AlsoKnownAsURIs: aliases})
+		suite.Error(errWithCode)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+	suite.Empty(suite.fromClientAPIChan)
+}
+
+func (suite *AccountMoveTestSuite) TestMove() {
+	ctx := context.Background()
+	testUser := suite.testUsers["local_account_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// the target doesn't acknowledge the move yet
+	errWithCode := suite.accountProcessor.Move(ctx, testUser, testAccount, &apimodel.AccountMoveRequest{
+		Password:   "password",
+		MovedToURI: targetAccount.URI,
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.accountProcessor.Alias(ctx, targetAccount, &apimodel.AccountAliasRequest{AlsoKnownAsURIs: []string{testAccount.URI}})
+	suite.NoError(errWithCode)
+	<-suite.fromClientAPIChan
+
+	errWithCode = suite.accountProcessor.Move(ctx, testUser, testAccount, &apimodel.AccountMoveRequest{
+		Password:   "password",
+		MovedToURI: targetAccount.URI,
+	})
+	suite.NoError(errWithCode)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(targetAccount.ID, dbAccount.MovedToAccountID)
+
+	// moving the followers should be queued up
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityMove, msg.APActivityType)
+	suite.Equal(ap.ActorPerson, msg.APObjectType)
+	suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+	suite.Equal(targetAccount.ID, msg.TargetAccount.ID)
+}
+
+func (suite *AccountMoveTestSuite) TestMoveWrongPassword() {
+	ctx := context.Background()
+
+	errWithCode := suite.accountProcessor.Move(ctx, suite.testUsers["local_account_1"], suite.testAccounts["local_account_1"], &apimodel.AccountMoveRequest{
+		Password:   "not the password",
+		MovedToURI: suite.testAccounts["local_account_2"].URI,
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Empty(suite.fromClientAPIChan)
+}
+
+func TestAccountMoveTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountMoveTestSuite))
+}
@@ -0,0 +1,115 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountMuteTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountMuteTestSuite) TestMuteUnmute() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	relationship, errWithCode := suite.accountProcessor.MuteCreate(ctx, requestingAccount, &apimodel.AccountMuteRequest{
+		ID: targetAccount.ID,
+	})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Muting)
+	suite.True(relationship.MutingNotifications)
+
+	// we should have a mute in the client api channel
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Equal(ap.ActivityIgnore, msg.APObjectType)
+
+	// muting again should update the existing mute
+	notifications := false
+	relationship, errWithCode = suite.accountProcessor.MuteCreate(ctx, requestingAccount, &apimodel.AccountMuteRequest{
+		ID:            targetAccount.ID,
+		Notifications: &notifications,
+		Duration:      3600,
+	})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+
+	mute, err := suite.db.GetMute(ctx, requestingAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.WithinDuration(time.Now().Add(time.Hour), mute.ExpiresAt, time.Minute)
+
+	mutedNotifications, err := suite.db.IsMuted(ctx, requestingAccount.ID, targetAccount.ID, true)
+	suite.NoError(err)
+	suite.False(mutedNotifications)
+
+	relationship, errWithCode = suite.accountProcessor.MuteRemove(ctx, requestingAccount, targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Muting)
+	suite.False(relationship.MutingNotifications)
+}
+
+func (suite *AccountMuteTestSuite) TestMuteExpired() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	_, errWithCode := suite.accountProcessor.MuteCreate(ctx, requestingAccount, &apimodel.AccountMuteRequest{
+		ID:       targetAccount.ID,
+		Duration: 60,
+	})
+	suite.NoError(errWithCode)
+
+	// pretend the mute ran out already
+	mute, err := suite.db.GetMute(ctx, requestingAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	mute.ExpiresAt = time.Now().Add(-1 * time.Minute)
+	suite.NoError(suite.db.UpdateByPrimaryKey(ctx, mute))
+
+	muted, err := suite.db.IsMuted(ctx, requestingAccount.ID, targetAccount.ID, false)
+	suite.NoError(err)
+	suite.False(muted)
+
+	relationship, errWithCode := suite.accountProcessor.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Muting)
+}
+
+func (suite *AccountMuteTestSuite) TestMuteSelf() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	relationship, errWithCode := suite.accountProcessor.MuteCreate(context.Background(), requestingAccount, &apimodel.AccountMuteRequest{
+		ID: requestingAccount.ID,
+	})
+	suite.Error(errWithCode)
+	suite.Nil(relationship)
+}
+
+func TestAccountMuteTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountMuteTestSuite))
+}
@@ -0,0 +1,83 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type AccountNoteTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountNoteTestSuite) TestSetNote() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	relationship, errWithCode := suite.accountProcessor.NoteSet(ctx, requestingAccount, &apimodel.AccountNoteRequest{
+		ID:      targetAccount.ID,
+		Comment: "met them at the <b>conference</b>",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("met them at the conference", relationship.Note)
+
+	// setting it again replaces the existing note
+	relationship, errWithCode = suite.accountProcessor.NoteSet(ctx, requestingAccount, &apimodel.AccountNoteRequest{
+		ID:      targetAccount.ID,
+		Comment: "actually it was a meetup",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("actually it was a meetup", relationship.Note)
+
+	// the note is private to the account that wrote it
+	relationship, errWithCode = suite.accountProcessor.RelationshipGet(ctx, suite.testAccounts["local_account_2"], targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.Empty(relationship.Note)
+
+	// an empty comment removes the note
+	relationship, errWithCode = suite.accountProcessor.NoteSet(ctx, requestingAccount, &apimodel.AccountNoteRequest{
+		ID: targetAccount.ID,
+	})
+	suite.NoError(errWithCode)
+	suite.Empty(relationship.Note)
+
+	_, err := suite.db.GetNote(ctx, requestingAccount.ID, targetAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AccountNoteTestSuite) TestSetNoteTooLong() {
+	_, errWithCode := suite.accountProcessor.NoteSet(context.Background(), suite.testAccounts["local_account_1"], &apimodel.AccountNoteRequest{
+		ID:      suite.testAccounts["remote_account_1"].ID,
+		Comment: strings.Repeat("a", 2001),
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestAccountNoteTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountNoteTestSuite))
+}
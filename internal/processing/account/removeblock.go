@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
@@ -55,6 +56,7 @@ func (p *processor) BlockRemove(ctx context.Context, requestingAccount *gtsmodel
 	// block status changed so send the UNDO activity to the channel for async processing
 	if blockChanged {
 		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
 			APObjectType:   ap.ActivityBlock,
 			APActivityType: ap.ActivityUndo,
 			GTSModel:       block,
@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
@@ -81,6 +82,7 @@ func (p *processor) FollowRemove(ctx context.Context, requestingAccount *gtsmode
 	// follow request status changed so send the UNDO activity to the channel for async processing
 	if frChanged {
 		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
 			APObjectType:   ap.ActivityFollow,
 			APActivityType: ap.ActivityUndo,
 			GTSModel: &gtsmodel.Follow{
@@ -96,6 +98,7 @@ func (p *processor) FollowRemove(ctx context.Context, requestingAccount *gtsmode
 	// follow status changed so send the UNDO activity to the channel for async processing
 	if fChanged {
 		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
 			APObjectType:   ap.ActivityFollow,
 			APActivityType: ap.ActivityUndo,
 			GTSModel: &gtsmodel.Follow{
@@ -0,0 +1,70 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type AccountRotateKeysTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountRotateKeysTestSuite) TestRotateKeys() {
+	testAccount := suite.testAccounts["local_account_1"]
+	oldPublicKey := testAccount.PublicKey
+	oldPublicKeyURI := testAccount.PublicKeyURI
+
+	account, errWithCode := suite.accountProcessor.RotateKeys(context.Background(), testAccount)
+	suite.NoError(errWithCode)
+	suite.NotNil(account)
+
+	// the key should be new but still have the same id
+	suite.False(oldPublicKey.Equal(account.PublicKey))
+	suite.True(account.PrivateKey.PublicKey.Equal(account.PublicKey))
+	suite.Equal(oldPublicKeyURI, account.PublicKeyURI)
+
+	// we should have an update in the client api channel
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+	suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+
+	// the new key should be in the database as well
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.True(account.PublicKey.Equal(dbAccount.PublicKey))
+	suite.True(account.PrivateKey.Equal(dbAccount.PrivateKey))
+}
+
+func (suite *AccountRotateKeysTestSuite) TestRotateKeysRemoteAccount() {
+	testAccount := suite.testAccounts["remote_account_1"]
+
+	account, errWithCode := suite.accountProcessor.RotateKeys(context.Background(), testAccount)
+	suite.Error(errWithCode)
+	suite.Nil(account)
+}
+
+func TestAccountRotateKeysTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountRotateKeysTestSuite))
+}
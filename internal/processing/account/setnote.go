@@ -0,0 +1,89 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *processor) NoteSet(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountNoteRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	if form.ID == requestingAccount.ID {
+		return nil, gtserror.NewErrorBadRequest(errors.New("NoteSet: account cannot write a note about itself"), "you cannot write a note about yourself")
+	}
+
+	comment := strings.TrimSpace(text.RemoveHTML(form.Comment))
+	if err := validate.AccountNote(comment); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// make sure the target account actually exists in our db
+	targetAccount, err := p.db.GetAccountByID(ctx, form.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("NoteSet: error getting account %s from the db: %s", form.ID, err))
+	}
+
+	note, err := p.db.GetNote(ctx, requestingAccount.ID, targetAccount.ID)
+	switch {
+	case err == nil && comment == "":
+		// an empty comment removes the note
+		if err := p.db.DeleteByID(ctx, note.ID, &gtsmodel.AccountNote{}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("NoteSet: error deleting note from db: %s", err))
+		}
+	case err == nil:
+		note.UpdatedAt = time.Now()
+		note.Comment = comment
+		if err := p.db.UpdateByPrimaryKey(ctx, note); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("NoteSet: error updating note in db: %s", err))
+		}
+	case err == db.ErrNoEntries && comment == "":
+		// nothing to remove
+	case err == db.ErrNoEntries:
+		newNoteID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		note = &gtsmodel.AccountNote{
+			ID:              newNoteID,
+			AccountID:       requestingAccount.ID,
+			Account:         requestingAccount,
+			TargetAccountID: targetAccount.ID,
+			TargetAccount:   targetAccount,
+			Comment:         comment,
+		}
+		if err := p.db.Put(ctx, note); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("NoteSet: error creating note in db: %s", err))
+		}
+	default:
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("NoteSet: error checking existence of note: %s", err))
+	}
+
+	return p.RelationshipGet(ctx, requestingAccount, targetAccount.ID)
+}
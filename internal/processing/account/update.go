@@ -25,10 +25,14 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"strings"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
@@ -37,7 +41,7 @@ import (
 )
 
 func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error) {
-	l := p.log.WithField("func", "AccountUpdate")
+	l := p.log.WithContext(ctx).WithField("func", "AccountUpdate")
 
 	if form.Discoverable != nil {
 		account.Discoverable = *form.Discoverable
@@ -65,6 +69,18 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.Note = note
 	}
 
+	if form.DisplayName != nil || form.Note != nil {
+		emojis, err := p.processEmojis(ctx, account.DisplayName, account.Note)
+		if err != nil {
+			return nil, err
+		}
+		account.Emojis = emojis
+		account.EmojiIDs = []string{}
+		for _, e := range emojis {
+			account.EmojiIDs = append(account.EmojiIDs, e.ID)
+		}
+	}
+
 	if form.Avatar != nil && form.Avatar.Size != 0 {
 		avatarInfo, err := p.UpdateAvatar(ctx, form.Avatar, account.ID)
 		if err != nil {
@@ -72,7 +88,10 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		}
 		account.AvatarMediaAttachmentID = avatarInfo.ID
 		account.AvatarMediaAttachment = avatarInfo
-		l.Tracef("new avatar info for account %s is %+v", account.ID, avatarInfo)
+		l.WithFields(logrus.Fields{
+			"accountID":  account.ID,
+			"avatarInfo": avatarInfo,
+		}).Trace("new avatar info for account")
 	}
 
 	if form.Header != nil && form.Header.Size != 0 {
@@ -82,13 +101,37 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		}
 		account.HeaderMediaAttachmentID = headerInfo.ID
 		account.HeaderMediaAttachment = headerInfo
-		l.Tracef("new header info for account %s is %+v", account.ID, headerInfo)
+		l.WithFields(logrus.Fields{
+			"accountID":  account.ID,
+			"headerInfo": headerInfo,
+		}).Trace("new header info for account")
 	}
 
 	if form.Locked != nil {
 		account.Locked = *form.Locked
 	}
 
+	if form.EnableRSS != nil {
+		account.EnableRSS = *form.EnableRSS
+	}
+
+	if form.CustomCSS != nil {
+		if !p.config.AccountsConfig.AllowCustomCSS {
+			return nil, errors.New("custom css is not enabled on this instance")
+		}
+		if err := validate.CustomCSS(*form.CustomCSS, p.config.AccountsConfig.CustomCSSLength); err != nil {
+			return nil, err
+		}
+		account.CustomCSS = *form.CustomCSS
+	}
+
+	if form.FieldsAttributes != nil {
+		if err := validate.ProfileFields(*form.FieldsAttributes); err != nil {
+			return nil, err
+		}
+		account.Fields = updateFields(account.Fields, *form.FieldsAttributes)
+	}
+
 	if form.Source != nil {
 		if form.Source.Language != nil {
 			if err := validate.Language(*form.Source.Language); err != nil {
@@ -101,6 +144,10 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 			account.Sensitive = *form.Source.Sensitive
 		}
 
+		if form.Source.PrivateBlocks != nil {
+			account.PrivateBlocks = *form.Source.PrivateBlocks
+		}
+
 		if form.Source.Privacy != nil {
 			if err := validate.Privacy(*form.Source.Privacy); err != nil {
 				return nil, err
@@ -116,6 +163,7 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 	}
 
 	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
 		APObjectType:   ap.ObjectProfile,
 		APActivityType: ap.ActivityUpdate,
 		GTSModel:       updatedAccount,
@@ -129,6 +177,37 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 	return acctSensitive, nil
 }
 
+// updateFields returns the profile fields described by the given update form fields. Fields with
+// neither a name nor a value are dropped. Fields whose value hasn't changed keep their verification,
+// the others will be verified again once the update has been processed.
+func updateFields(oldFields []gtsmodel.Field, updateFields []apimodel.UpdateField) []gtsmodel.Field {
+	verifiedAt := make(map[string]time.Time, len(oldFields))
+	for _, f := range oldFields {
+		verifiedAt[f.Value] = f.VerifiedAt
+	}
+
+	fields := []gtsmodel.Field{}
+	for _, f := range updateFields {
+		var name, value string
+		if f.Name != nil {
+			name = strings.TrimSpace(text.RemoveHTML(*f.Name))
+		}
+		if f.Value != nil {
+			value = strings.TrimSpace(text.RemoveHTML(*f.Value))
+		}
+		if name == "" && value == "" {
+			continue
+		}
+
+		fields = append(fields, gtsmodel.Field{
+			Name:       name,
+			Value:      value,
+			VerifiedAt: verifiedAt[value],
+		})
+	}
+	return fields
+}
+
 // UpdateAvatar does the dirty work of checking the avatar part of an account update form,
 // parsing and checking the image, and doing the necessary updates in the database for this to become
 // the account's new avatar image.
@@ -212,9 +291,11 @@ func (p *processor) processNote(ctx context.Context, note string, accountID stri
 		return "", err
 	}
 
-	// TODO: support emojis in account notes
-	// emojiStrings := util.DeriveEmojisFromText(note)
-	// emojis, err := p.db.EmojiStringsToEmojis(ctx, emojiStrings)
-
 	return p.formatter.FromPlain(ctx, note, mentions, tags), nil
 }
+
+// processEmojis returns the custom emojis of this instance that are used in the given display name and note.
+func (p *processor) processEmojis(ctx context.Context, displayName string, note string) ([]*gtsmodel.Emoji, error) {
+	emojiStrings := util.DeriveEmojisFromText(displayName + " " + note)
+	return p.db.EmojiStringsToEmojis(ctx, emojiStrings)
+}
@@ -21,10 +21,12 @@ package account_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type AccountUpdateTestSuite struct {
@@ -113,6 +115,108 @@ go check out @1happyturtle, they have a cool account!
 	suite.Equal(noteExpected, dbAccount.Note)
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateCustomCSSNotAllowed() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	customCSS := "body { color: pink; }"
+	form := &apimodel.UpdateCredentialsRequest{
+		CustomCSS: &customCSS,
+	}
+
+	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.EqualError(err, "custom css is not enabled on this instance")
+	suite.Nil(apiAccount)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateCustomCSS() {
+	suite.config.AccountsConfig.AllowCustomCSS = true
+	testAccount := suite.testAccounts["local_account_1"]
+
+	customCSS := "body { color: pink; }"
+	form := &apimodel.UpdateCredentialsRequest{
+		CustomCSS: &customCSS,
+	}
+
+	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.NoError(err)
+	suite.NotNil(apiAccount)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(customCSS, dbAccount.CustomCSS)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateCustomCSSTooLong() {
+	suite.config.AccountsConfig.AllowCustomCSS = true
+	suite.config.AccountsConfig.CustomCSSLength = 10
+	testAccount := suite.testAccounts["local_account_1"]
+
+	customCSS := "body { color: pink; }"
+	form := &apimodel.UpdateCredentialsRequest{
+		CustomCSS: &customCSS,
+	}
+
+	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.EqualError(err, "custom css should be no more than 10 chars but given css was 21")
+	suite.Nil(apiAccount)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateFields() {
+	testAccount := suite.testAccounts["local_account_1"]
+	verifiedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	testAccount.Fields = []gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/zork", VerifiedAt: verifiedAt},
+		{Name: "pronouns", Value: "they/them"},
+	}
+
+	website := "homepage"
+	websiteValue := "https://example.org/zork"
+	blank := ""
+	code := "code"
+	codeValue := "<b>https://example.org/zork/code</b>"
+	form := &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &[]apimodel.UpdateField{
+			{Name: &website, Value: &websiteValue},
+			{Name: &blank, Value: &blank},
+			{Name: &code, Value: &codeValue},
+		},
+	}
+
+	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.NoError(err)
+	suite.NotNil(apiAccount)
+
+	// the blank field should be dropped, and html removed
+	suite.Len(apiAccount.Fields, 2)
+	suite.Equal("homepage", apiAccount.Fields[0].Name)
+	suite.Equal("https://example.org/zork/code", apiAccount.Fields[1].Value)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Len(dbAccount.Fields, 2)
+
+	// the unchanged value keeps its verification, the new value isn't verified yet
+	suite.WithinDuration(verifiedAt, dbAccount.Fields[0].VerifiedAt, time.Second)
+	suite.True(dbAccount.Fields[1].VerifiedAt.IsZero())
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateTooManyFields() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	name := "field"
+	fields := []apimodel.UpdateField{}
+	for i := 0; i < 5; i++ {
+		fields = append(fields, apimodel.UpdateField{Name: &name, Value: &name})
+	}
+	form := &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &fields,
+	}
+
+	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.EqualError(err, "too many profile fields, 5 provided but limit is 4")
+	suite.Nil(apiAccount)
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountUpdateTestSuite))
 }
@@ -0,0 +1,379 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// accountExportContentType is the mime type of account export archives.
+	accountExportContentType = "application/zip"
+	// accountExportPageSize is how many statuses are selected at a time when building the outbox of an export.
+	accountExportPageSize = 50
+)
+
+func (p *processor) AccountExportsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AccountExport, gtserror.WithCode) {
+	exports := []*gtsmodel.AccountExport{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: authed.Account.ID}}, &exports); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountExportsGet: error getting exports: %s", err))
+	}
+
+	apiExports := make([]*apimodel.AccountExport, 0, len(exports))
+	for _, e := range exports {
+		apiExports = append(apiExports, p.accountExportToMasto(e))
+	}
+	return apiExports, nil
+}
+
+func (p *processor) AccountExportCreate(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountExport, gtserror.WithCode) {
+	account := authed.Account
+
+	exportID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// the archive can take a while to build for big accounts, so just record that it's been asked for here,
+	// and leave building it to the client api worker
+	export := &gtsmodel.AccountExport{
+		ID:          exportID,
+		AccountID:   account.ID,
+		Path:        fmt.Sprintf("%s/export/%s.zip", account.ID, exportID),
+		ContentType: accountExportContentType,
+	}
+
+	if err := p.db.Put(ctx, export); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountExportCreate: error putting export: %s", err))
+	}
+
+	// only the latest export is kept around, so clean up any older ones
+	previous := []*gtsmodel.AccountExport{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &previous); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountExportCreate: error getting previous exports: %s", err))
+	}
+	for _, e := range previous {
+		if e.ID == export.ID {
+			continue
+		}
+		p.deleteAccountExport(ctx, e)
+	}
+
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ObjectCollection,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       export,
+		OriginAccount:  account,
+	}
+
+	return p.accountExportToMasto(export), nil
+}
+
+func (p *processor) AccountExportFileGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.Content, gtserror.WithCode) {
+	export := &gtsmodel.AccountExport{}
+	if err := p.db.GetByID(ctx, id, export); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountExportFileGet: error getting export %s: %s", id, err))
+	}
+
+	if export.AccountID != authed.Account.ID {
+		// don't let on that the export exists at all
+		return nil, gtserror.NewErrorNotFound(errors.New("AccountExportFileGet: export not owned by requesting account"))
+	}
+
+	if export.ReadyAt.IsZero() {
+		return nil, gtserror.NewErrorAccepted(fmt.Errorf("AccountExportFileGet: export %s is still being built", id), "the export is still being built, try again later")
+	}
+
+	b, err := p.storage.Get(export.Path)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AccountExportFileGet: error getting archive from storage: %s", err))
+	}
+
+	return &apimodel.Content{
+		ContentType:   export.ContentType,
+		ContentLength: int64(len(b)),
+		Content:       b,
+	}, nil
+}
+
+func (p *processor) accountExportToMasto(export *gtsmodel.AccountExport) *apimodel.AccountExport {
+	return &apimodel.AccountExport{
+		ID:        export.ID,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+		Size:      export.FileSize,
+		Ready:     !export.ReadyAt.IsZero(),
+		URL:       fmt.Sprintf("%s://%s/api/v1/accounts/exports/%s", p.config.Protocol, p.config.Host, export.ID),
+	}
+}
+
+// deleteAccountExport removes the given export from storage and the database, logging any errors along the way.
+func (p *processor) deleteAccountExport(ctx context.Context, export *gtsmodel.AccountExport) {
+	l := p.log.WithContext(ctx).WithField("exportID", export.ID)
+
+	if err := p.storage.Delete(export.Path); err != nil {
+		l.WithError(err).Error("error deleting account export from storage")
+	}
+	if err := p.db.DeleteByID(ctx, export.ID, &gtsmodel.AccountExport{}); err != nil {
+		l.WithError(err).Error("error deleting account export")
+	}
+}
+
+// deleteAccountExports removes all exports of the given account.
+func (p *processor) deleteAccountExports(ctx context.Context, accountID string) {
+	exports := []*gtsmodel.AccountExport{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: accountID}}, &exports); err != nil && err != db.ErrNoEntries {
+		p.log.WithContext(ctx).WithError(err).Error("error getting account exports")
+		return
+	}
+	for _, e := range exports {
+		p.deleteAccountExport(ctx, e)
+	}
+}
+
+// buildAccountExport builds the archive of the given export, streaming it into storage as it's written,
+// then marks the export as ready. If anything goes wrong, the export is removed again.
+func (p *processor) buildAccountExport(ctx context.Context, export *gtsmodel.AccountExport) error {
+	account, err := p.db.GetAccountByID(ctx, export.AccountID)
+	if err != nil {
+		p.deleteAccountExport(ctx, export)
+		return fmt.Errorf("buildAccountExport: error getting account %s: %s", export.AccountID, err)
+	}
+
+	pr, pw := io.Pipe()
+	counter := &countingWriter{w: pw}
+	go func() {
+		pw.CloseWithError(p.writeAccountExport(ctx, account, counter))
+	}()
+
+	if err := p.storage.PutStream(export.Path, pr); err != nil {
+		// make sure the writer gives up, if storage stopped reading early
+		pr.CloseWithError(err)
+		p.deleteAccountExport(ctx, export)
+		return fmt.Errorf("buildAccountExport: error storing archive: %s", err)
+	}
+
+	// a newer export may have replaced this one while it was being built
+	if err := p.db.GetByID(ctx, export.ID, &gtsmodel.AccountExport{}); err != nil {
+		if err == db.ErrNoEntries {
+			p.deleteAccountExport(ctx, export)
+			return nil
+		}
+		return fmt.Errorf("buildAccountExport: error checking export: %s", err)
+	}
+
+	export.FileSize = counter.n
+	export.ReadyAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, export); err != nil {
+		p.deleteAccountExport(ctx, export)
+		return fmt.Errorf("buildAccountExport: error updating export: %s", err)
+	}
+
+	return nil
+}
+
+// writeAccountExport writes a zip archive to w containing the ActivityStreams representation of the given
+// account and its statuses, along with csv files of its follows, blocks, mutes and bookmarks in the
+// same format used by other fediverse software, so that they can be imported elsewhere.
+func (p *processor) writeAccountExport(ctx context.Context, account *gtsmodel.Account, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	person, err := p.tc.AccountToAS(ctx, account)
+	if err != nil {
+		return fmt.Errorf("error converting account: %s", err)
+	}
+	if err := writeExportJSON(zw, "actor.json", person); err != nil {
+		return err
+	}
+
+	statuses := []*gtsmodel.Status{}
+	var maxID string
+	for {
+		page, err := p.db.GetAccountStatuses(ctx, account.ID, accountExportPageSize, false, false, maxID, "", "", false, false, "")
+		if err != nil {
+			if err == db.ErrNoEntries {
+				break
+			}
+			return fmt.Errorf("error getting statuses: %s", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, s := range page {
+			// local-only statuses can't be represented in AS format
+			if s.Federated {
+				statuses = append(statuses, s)
+			}
+		}
+		maxID = page[len(page)-1].ID
+	}
+
+	outbox, err := p.tc.StatusesToASOutboxPage(ctx, account, "", "", statuses)
+	if err != nil {
+		return fmt.Errorf("error converting statuses: %s", err)
+	}
+	if err := writeExportJSON(zw, "outbox.json", outbox); err != nil {
+		return err
+	}
+
+	follows, err := p.db.GetAccountFollows(ctx, account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting follows: %s", err)
+	}
+	followRows := [][]string{{"Account address", "Show boosts", "Notify on new posts"}}
+	for _, f := range follows {
+		target, err := p.db.GetAccountByID(ctx, f.TargetAccountID)
+		if err != nil {
+			continue
+		}
+		followRows = append(followRows, []string{p.exportAddress(target), strconv.FormatBool(f.ShowReblogs), strconv.FormatBool(f.Notify)})
+	}
+	if err := writeExportCSV(zw, "following_accounts.csv", followRows); err != nil {
+		return err
+	}
+
+	blocks := []*gtsmodel.Block{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &blocks); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting blocks: %s", err)
+	}
+	blockRows := [][]string{}
+	for _, b := range blocks {
+		target, err := p.db.GetAccountByID(ctx, b.TargetAccountID)
+		if err != nil {
+			continue
+		}
+		blockRows = append(blockRows, []string{p.exportAddress(target)})
+	}
+	if err := writeExportCSV(zw, "blocked_accounts.csv", blockRows); err != nil {
+		return err
+	}
+
+	mutes := []*gtsmodel.AccountMute{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &mutes); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting mutes: %s", err)
+	}
+	muteRows := [][]string{{"Account address", "Hide notifications"}}
+	for _, m := range mutes {
+		target, err := p.db.GetAccountByID(ctx, m.TargetAccountID)
+		if err != nil {
+			continue
+		}
+		muteRows = append(muteRows, []string{p.exportAddress(target), strconv.FormatBool(m.Notifications)})
+	}
+	if err := writeExportCSV(zw, "muted_accounts.csv", muteRows); err != nil {
+		return err
+	}
+
+	bookmarks := []*gtsmodel.StatusBookmark{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &bookmarks); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting bookmarks: %s", err)
+	}
+	bookmarkRows := [][]string{}
+	for _, b := range bookmarks {
+		status, err := p.db.GetStatusByID(ctx, b.StatusID)
+		if err != nil {
+			continue
+		}
+		bookmarkRows = append(bookmarkRows, []string{status.URI})
+	}
+	if err := writeExportCSV(zw, "bookmarks.csv", bookmarkRows); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("error closing archive: %s", err)
+	}
+	return nil
+}
+
+// exportAddress returns the username@domain address of the given account, as used in csv exports.
+func (p *processor) exportAddress(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = p.config.AccountDomain
+	}
+	return account.Username + "@" + domain
+}
+
+func writeExportJSON(zw *zip.Writer, name string, t vocab.Type) error {
+	m, err := streams.Serialize(t)
+	if err != nil {
+		return fmt.Errorf("error serializing %s: %s", name, err)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshalling %s: %s", name, err)
+	}
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("error creating %s in archive: %s", name, err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func writeExportCSV(zw *zip.Writer, name string, rows [][]string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("error creating %s in archive: %s", name, err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("error writing %s: %s", name, err)
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += n
+	return n, err
+}
@@ -0,0 +1,157 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AccountExportTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AccountExportTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+// waitForExport waits for the export with the given id to be built, and returns its archive.
+func (suite *AccountExportTestSuite) waitForExport(authed *oauth.Auth, id string) *apimodel.Content {
+	var content *apimodel.Content
+	suite.Eventually(func() bool {
+		var errWithCode gtserror.WithCode
+		content, errWithCode = suite.processor.AccountExportFileGet(context.Background(), authed, id)
+		return errWithCode == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	return content
+}
+
+func (suite *AccountExportTestSuite) TestAccountExportCreate() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	export, errWithCode := suite.processor.AccountExportCreate(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(export.ID)
+	suite.False(export.Ready)
+	suite.Equal("http://localhost:8080/api/v1/accounts/exports/"+export.ID, export.URL)
+
+	content := suite.waitForExport(authed, export.ID)
+	suite.NotNil(content)
+	suite.Equal("application/zip", content.ContentType)
+
+	exports, errWithCode := suite.processor.AccountExportsGet(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.Len(exports, 1)
+	suite.True(exports[0].Ready)
+	export = exports[0]
+	suite.EqualValues(export.Size, content.ContentLength)
+
+	zr, err := zip.NewReader(bytes.NewReader(content.Content), content.ContentLength)
+	suite.NoError(err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		suite.NoError(err)
+		b, err := io.ReadAll(r)
+		suite.NoError(err)
+		r.Close()
+		files[f.Name] = string(b)
+	}
+
+	suite.Contains(files["actor.json"], `"preferredUsername":"the_mighty_zork"`)
+	suite.Contains(files["outbox.json"], `"type":"Create"`)
+	suite.True(strings.HasPrefix(files["following_accounts.csv"], "Account address,Show boosts,Notify on new posts\n"))
+	suite.Contains(files["following_accounts.csv"], "admin@localhost:8080,true,false\n")
+	suite.Contains(files["following_accounts.csv"], "1happyturtle@localhost:8080,true,false\n")
+	suite.Contains(files, "blocked_accounts.csv")
+	suite.Contains(files, "muted_accounts.csv")
+	suite.Contains(files, "bookmarks.csv")
+}
+
+func (suite *AccountExportTestSuite) TestAccountExportFileGetNotReady() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	export := &gtsmodel.AccountExport{
+		ID:          "01FSZ4AH7TCFKDFP9ZJ2J9QGDN",
+		AccountID:   account.ID,
+		Path:        account.ID + "/export/01FSZ4AH7TCFKDFP9ZJ2J9QGDN.zip",
+		ContentType: "application/zip",
+	}
+	suite.NoError(suite.db.Put(ctx, export))
+
+	_, errWithCode := suite.processor.AccountExportFileGet(ctx, suite.authed("local_account_1"), export.ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusAccepted, errWithCode.Code())
+}
+
+func (suite *AccountExportTestSuite) TestAccountExportReplacesPrevious() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	first, errWithCode := suite.processor.AccountExportCreate(ctx, authed)
+	suite.NoError(errWithCode)
+
+	second, errWithCode := suite.processor.AccountExportCreate(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.waitForExport(authed, second.ID)
+
+	exports, errWithCode := suite.processor.AccountExportsGet(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.Len(exports, 1)
+	suite.Equal(second.ID, exports[0].ID)
+
+	_, errWithCode = suite.processor.AccountExportFileGet(ctx, authed, first.ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *AccountExportTestSuite) TestAccountExportFileGetOtherAccount() {
+	ctx := context.Background()
+
+	export, errWithCode := suite.processor.AccountExportCreate(ctx, suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+	suite.waitForExport(suite.authed("local_account_1"), export.ID)
+
+	_, errWithCode = suite.processor.AccountExportFileGet(ctx, suite.authed("local_account_2"), export.ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestAccountExportTestSuite(t *testing.T) {
+	suite.Run(t, &AccountExportTestSuite{})
+}
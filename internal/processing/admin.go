@@ -22,20 +22,48 @@ import (
 	"context"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 func (p *processor) AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, error) {
-	return p.adminProcessor.EmojiCreate(ctx, authed.Account, authed.User, form)
+	emoji, err := p.adminProcessor.EmojiCreate(ctx, authed.Account, authed.User, form)
+	if err != nil {
+		return nil, err
+	}
+
+	// the api model of the emoji has no id, so look up the new local emoji by its shortcode to audit it
+	dbEmoji := &gtsmodel.Emoji{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "shortcode", Value: emoji.Shortcode}, {Key: "domain", Value: ""}}, dbEmoji); err != nil {
+		p.log.WithContext(ctx).Errorf("AdminEmojiCreate: error getting new emoji %s: %s", emoji.Shortcode, err)
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetEmoji, dbEmoji.ID, emoji.Shortcode)
+	return emoji, nil
 }
 
 func (p *processor) AdminDomainBlockCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainBlockCreateRequest) (*apimodel.DomainBlock, gtserror.WithCode) {
-	return p.adminProcessor.DomainBlockCreate(ctx, authed.Account, form.Domain, form.Obfuscate, form.PublicComment, form.PrivateComment, "")
+	domainBlock, errWithCode := p.adminProcessor.DomainBlockCreate(ctx, authed.Account, form, "")
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetDomainBlock, domainBlock.ID, domainBlock.Domain)
+	return domainBlock, nil
 }
 
 func (p *processor) AdminDomainBlocksImport(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainBlockCreateRequest) ([]*apimodel.DomainBlock, gtserror.WithCode) {
-	return p.adminProcessor.DomainBlocksImport(ctx, authed.Account, form.Domains)
+	domainBlocks, errWithCode := p.adminProcessor.DomainBlocksImport(ctx, authed.Account, form.Domains)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	for _, domainBlock := range domainBlocks {
+		p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetDomainBlock, domainBlock.ID, domainBlock.Domain)
+	}
+	return domainBlocks, nil
 }
 
 func (p *processor) AdminDomainBlocksGet(ctx context.Context, authed *oauth.Auth, export bool) ([]*apimodel.DomainBlock, gtserror.WithCode) {
@@ -46,6 +74,109 @@ func (p *processor) AdminDomainBlockGet(ctx context.Context, authed *oauth.Auth,
 	return p.adminProcessor.DomainBlockGet(ctx, authed.Account, id, export)
 }
 
+func (p *processor) AdminDomainBlocksExportCSV(ctx context.Context, authed *oauth.Auth) (*apimodel.Content, gtserror.WithCode) {
+	return p.adminProcessor.DomainBlocksExportCSV(ctx, authed.Account)
+}
+
+func (p *processor) AdminDomainBlockUpdate(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.DomainBlockUpdateRequest) (*apimodel.DomainBlock, gtserror.WithCode) {
+	domainBlock, errWithCode := p.adminProcessor.DomainBlockUpdate(ctx, authed.Account, id, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionUpdate, gtsmodel.AdminAuditLogTargetDomainBlock, domainBlock.ID, domainBlock.Domain)
+	return domainBlock, nil
+}
+
 func (p *processor) AdminDomainBlockDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainBlock, gtserror.WithCode) {
-	return p.adminProcessor.DomainBlockDelete(ctx, authed.Account, id)
+	domainBlock, errWithCode := p.adminProcessor.DomainBlockDelete(ctx, authed.Account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionDelete, gtsmodel.AdminAuditLogTargetDomainBlock, domainBlock.ID, domainBlock.Domain)
+	return domainBlock, nil
+}
+
+func (p *processor) AdminDomainAllowCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainAllowCreateRequest) (*apimodel.DomainAllow, gtserror.WithCode) {
+	domainAllow, errWithCode := p.adminProcessor.DomainAllowCreate(ctx, authed.Account, form.Domain, form.PublicComment, form.PrivateComment)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetDomainAllow, domainAllow.ID, domainAllow.Domain)
+	return domainAllow, nil
+}
+
+func (p *processor) AdminDomainAllowsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainAllow, gtserror.WithCode) {
+	return p.adminProcessor.DomainAllowsGet(ctx, authed.Account)
+}
+
+func (p *processor) AdminDomainAllowGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainAllow, gtserror.WithCode) {
+	return p.adminProcessor.DomainAllowGet(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminDomainAllowDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainAllow, gtserror.WithCode) {
+	domainAllow, errWithCode := p.adminProcessor.DomainAllowDelete(ctx, authed.Account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionDelete, gtsmodel.AdminAuditLogTargetDomainAllow, domainAllow.ID, domainAllow.Domain)
+	return domainAllow, nil
+}
+
+func (p *processor) AdminDomainPolicyCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainPolicyCreateRequest) (*apimodel.DomainPolicy, gtserror.WithCode) {
+	domainPolicy, errWithCode := p.adminProcessor.DomainPolicyCreate(ctx, authed.Account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetDomainPolicy, domainPolicy.ID, domainPolicy.Domain)
+	return domainPolicy, nil
+}
+
+func (p *processor) AdminDomainPoliciesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainPolicy, gtserror.WithCode) {
+	return p.adminProcessor.DomainPoliciesGet(ctx, authed.Account)
+}
+
+func (p *processor) AdminDomainPolicyGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainPolicy, gtserror.WithCode) {
+	return p.adminProcessor.DomainPolicyGet(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminDomainPolicyDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainPolicy, gtserror.WithCode) {
+	domainPolicy, errWithCode := p.adminProcessor.DomainPolicyDelete(ctx, authed.Account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionDelete, gtsmodel.AdminAuditLogTargetDomainPolicy, domainPolicy.ID, domainPolicy.Domain)
+	return domainPolicy, nil
+}
+
+func (p *processor) AdminDeliveriesGet(ctx context.Context, authed *oauth.Auth, activityURI string, statusID string) ([]*apimodel.DeliveryReceipt, gtserror.WithCode) {
+	return p.adminProcessor.DeliveriesGet(ctx, authed.Account, activityURI, statusID)
+}
+
+func (p *processor) AdminAccountRotateKeys(ctx context.Context, authed *oauth.Auth, accountID string) (*apimodel.Account, gtserror.WithCode) {
+	account, err := p.db.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	account, errWithCode := p.accountProcessor.RotateKeys(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionRotateKeys, gtsmodel.AdminAuditLogTargetAccount, account.ID, adminAuditAccountSummary(account))
+
+	mastoAccount, err := p.tc.AccountToMastoPublic(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return mastoAccount, nil
 }
@@ -35,11 +35,22 @@ import (
 
 // Processor wraps a bunch of functions for processing admin actions.
 type Processor interface {
-	DomainBlockCreate(ctx context.Context, account *gtsmodel.Account, domain string, obfuscate bool, publicComment string, privateComment string, subscriptionID string) (*apimodel.DomainBlock, gtserror.WithCode)
+	DomainBlockCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.DomainBlockCreateRequest, subscriptionID string) (*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlocksImport(ctx context.Context, account *gtsmodel.Account, domains *multipart.FileHeader) ([]*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlocksGet(ctx context.Context, account *gtsmodel.Account, export bool) ([]*apimodel.DomainBlock, gtserror.WithCode)
+	DomainBlocksExportCSV(ctx context.Context, account *gtsmodel.Account) (*apimodel.Content, gtserror.WithCode)
 	DomainBlockGet(ctx context.Context, account *gtsmodel.Account, id string, export bool) (*apimodel.DomainBlock, gtserror.WithCode)
+	DomainBlockUpdate(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.DomainBlockUpdateRequest) (*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlockDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainBlock, gtserror.WithCode)
+	DomainAllowCreate(ctx context.Context, account *gtsmodel.Account, domain string, publicComment string, privateComment string) (*apimodel.DomainAllow, gtserror.WithCode)
+	DomainAllowsGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.DomainAllow, gtserror.WithCode)
+	DomainAllowGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainAllow, gtserror.WithCode)
+	DomainAllowDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainAllow, gtserror.WithCode)
+	DomainPolicyCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.DomainPolicyCreateRequest) (*apimodel.DomainPolicy, gtserror.WithCode)
+	DomainPoliciesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.DomainPolicy, gtserror.WithCode)
+	DomainPolicyGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainPolicy, gtserror.WithCode)
+	DomainPolicyDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainPolicy, gtserror.WithCode)
+	DeliveriesGet(ctx context.Context, account *gtsmodel.Account, activityURI string, statusID string) ([]*apimodel.DeliveryReceipt, gtserror.WithCode)
 	EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, error)
 }
 
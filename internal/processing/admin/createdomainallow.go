@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) DomainAllowCreate(ctx context.Context, account *gtsmodel.Account, domain string, publicComment string, privateComment string) (*apimodel.DomainAllow, gtserror.WithCode) {
+	// first check if we already have an allow -- if err == nil we already had one so we can just return it
+	domainAllow := &gtsmodel.DomainAllow{}
+	err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: domain, CaseInsensitive: true}}, domainAllow)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			// something went wrong in the DB
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: db error checking for existence of domain allow %s: %s", domain, err))
+		}
+
+		// there's no allow for this domain yet so create one
+		allowID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: error creating id for new domain allow %s: %s", domain, err))
+		}
+
+		domainAllow = &gtsmodel.DomainAllow{
+			ID:                 allowID,
+			Domain:             domain,
+			CreatedByAccountID: account.ID,
+			PrivateComment:     text.RemoveHTML(privateComment),
+			PublicComment:      text.RemoveHTML(publicComment),
+		}
+
+		if err := p.db.Put(ctx, domainAllow); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: db error putting new domain allow %s: %s", domain, err))
+		}
+	}
+
+	mastoDomainAllow, err := p.tc.DomainAllowToMasto(ctx, domainAllow)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainAllowCreate: error converting domain allow to frontend representation %s: %s", domain, err))
+	}
+
+	return mastoDomainAllow, nil
+}
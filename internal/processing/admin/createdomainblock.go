@@ -30,11 +30,23 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
-func (p *processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Account, domain string, obfuscate bool, publicComment string, privateComment string, subscriptionID string) (*apimodel.DomainBlock, gtserror.WithCode) {
+func (p *processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.DomainBlockCreateRequest, subscriptionID string) (*apimodel.DomainBlock, gtserror.WithCode) {
+	domain := form.Domain
+
+	severity := gtsmodel.DomainBlockSeveritySuspend
+	if form.Severity != "" {
+		if err := validate.DomainBlockSeverity(form.Severity); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		severity = gtsmodel.DomainBlockSeverity(form.Severity)
+	}
+
 	// first check if we already have a block -- if err == nil we already had a block so we can skip a whole lot of work
 	domainBlock := &gtsmodel.DomainBlock{}
 	err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: domain, CaseInsensitive: true}}, domainBlock)
@@ -55,10 +67,13 @@ func (p *processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Acc
 			ID:                 blockID,
 			Domain:             domain,
 			CreatedByAccountID: account.ID,
-			PrivateComment:     text.RemoveHTML(privateComment),
-			PublicComment:      text.RemoveHTML(publicComment),
-			Obfuscate:          obfuscate,
+			PrivateComment:     text.RemoveHTML(form.PrivateComment),
+			PublicComment:      text.RemoveHTML(form.PublicComment),
+			Obfuscate:          form.Obfuscate,
 			SubscriptionID:     subscriptionID,
+			Severity:           severity,
+			RejectMedia:        form.RejectMedia,
+			RejectReports:      form.RejectReports,
 		}
 
 		// put the new block in the database
@@ -69,8 +84,11 @@ func (p *processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Acc
 			}
 		}
 
-		// process the side effects of the domain block asynchronously since it might take a while
-		go p.initiateDomainBlockSideEffects(ctx, account, domainBlock) // TODO: add this to a queuing system so it can retry/resume
+		// process the side effects of the domain block asynchronously since it might take a while;
+		// less severe blocks are enforced as they're checked, so they don't have any side effects
+		if severity == gtsmodel.DomainBlockSeveritySuspend {
+			go p.initiateDomainBlockSideEffects(ctx, account, domainBlock) // TODO: add this to a queuing system so it can retry/resume
+		}
 	}
 
 	mastoDomainBlock, err := p.tc.DomainBlockToMasto(ctx, domainBlock, false)
@@ -87,7 +105,7 @@ func (p *processor) DomainBlockCreate(ctx context.Context, account *gtsmodel.Acc
 // 2. Delete the instance account for that instance if it exists.
 // 3. Select all accounts from this instance and pass them through the delete functionality of the processor.
 func (p *processor) initiateDomainBlockSideEffects(ctx context.Context, account *gtsmodel.Account, block *gtsmodel.DomainBlock) {
-	l := p.log.WithFields(logrus.Fields{
+	l := p.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "domainBlockProcessSideEffects",
 		"domain": block.Domain,
 	})
@@ -109,14 +127,14 @@ func (p *processor) initiateDomainBlockSideEffects(ctx context.Context, account
 		instance.ContactAccountID = ""
 		instance.Version = ""
 		if err := p.db.UpdateByPrimaryKey(ctx, instance); err != nil {
-			l.Errorf("domainBlockProcessSideEffects: db error updating instance: %s", err)
+			l.WithError(err).Error("domainBlockProcessSideEffects: db error updating instance")
 		}
 		l.Debug("domainBlockProcessSideEffects: instance entry updated")
 	}
 
 	// if we have an instance account for this instance, delete it
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "username", Value: block.Domain, CaseInsensitive: true}}, &gtsmodel.Account{}); err != nil {
-		l.Errorf("domainBlockProcessSideEffects: db error removing instance account: %s", err)
+		l.WithError(err).Error("domainBlockProcessSideEffects: db error removing instance account")
 	}
 
 	// delete accounts through the normal account deletion system (which should also delete media + posts + remove posts from timelines)
@@ -130,19 +148,20 @@ selectAccountsLoop:
 		if err != nil {
 			if err == db.ErrNoEntries {
 				// no accounts left for this instance so we're done
-				l.Infof("domainBlockProcessSideEffects: done iterating through accounts for domain %s", block.Domain)
+				l.WithField("domain", block.Domain).Info("domainBlockProcessSideEffects: done iterating through accounts for domain")
 				break selectAccountsLoop
 			}
 			// an actual error has occurred
-			l.Errorf("domainBlockProcessSideEffects: db error selecting accounts for domain %s: %s", block.Domain, err)
+			l.WithError(err).WithField("domain", block.Domain).Error("domainBlockProcessSideEffects: db error selecting accounts for domain")
 			break selectAccountsLoop
 		}
 
 		for i, a := range accounts {
-			l.Debugf("putting delete for account %s in the clientAPI channel", a.Username)
+			l.WithField("username", a.Username).Debug("putting delete for account in the clientAPI channel")
 
 			// pass the account delete through the client api channel for processing
 			p.fromClientAPI <- messages.FromClientAPI{
+				RequestID:      log.RequestID(ctx),
 				APObjectType:   ap.ActorPerson,
 				APActivityType: ap.ActivityDelete,
 				GTSModel:       block,
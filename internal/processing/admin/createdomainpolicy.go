@@ -0,0 +1,77 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) DomainPolicyCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.DomainPolicyCreateRequest) (*apimodel.DomainPolicy, gtserror.WithCode) {
+	// first check if we already have a policy -- if err == nil we already had one so we just update it
+	domainPolicy := &gtsmodel.DomainPolicy{}
+	err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: form.Domain, CaseInsensitive: true}}, domainPolicy)
+	if err != nil && err != db.ErrNoEntries {
+		// something went wrong in the DB
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPolicyCreate: db error checking for existence of domain policy %s: %s", form.Domain, err))
+	}
+	exists := err == nil
+
+	if !exists {
+		// there's no policy for this domain yet so create one
+		policyID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPolicyCreate: error creating id for new domain policy %s: %s", form.Domain, err))
+		}
+		domainPolicy.ID = policyID
+		domainPolicy.Domain = form.Domain
+		domainPolicy.CreatedByAccountID = account.ID
+	}
+
+	domainPolicy.RejectMedia = form.RejectMedia
+	domainPolicy.SensitiveMedia = form.SensitiveMedia
+	domainPolicy.RejectBoosts = form.RejectBoosts
+	domainPolicy.Silence = form.Silence
+	domainPolicy.PrivateComment = text.RemoveHTML(form.PrivateComment)
+	domainPolicy.PublicComment = text.RemoveHTML(form.PublicComment)
+
+	if exists {
+		domainPolicy.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, domainPolicy); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPolicyCreate: db error updating domain policy %s: %s", form.Domain, err))
+		}
+	} else if err := p.db.Put(ctx, domainPolicy); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPolicyCreate: db error putting new domain policy %s: %s", form.Domain, err))
+	}
+
+	mastoDomainPolicy, err := p.tc.DomainPolicyToMasto(ctx, domainPolicy)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPolicyCreate: error converting domain policy to frontend representation %s: %s", form.Domain, err))
+	}
+
+	return mastoDomainPolicy, nil
+}
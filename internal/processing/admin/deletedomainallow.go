@@ -0,0 +1,55 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) DomainAllowDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainAllow, gtserror.WithCode) {
+	domainAllow := &gtsmodel.DomainAllow{}
+
+	if err := p.db.GetByID(ctx, id, domainAllow); err != nil {
+		if err != db.ErrNoEntries {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		// there are no entries for this ID
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	// prepare the domain allow to return
+	mastoDomainAllow, err := p.tc.DomainAllowToMasto(ctx, domainAllow)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// delete the domain allow; in allowlist mode this takes effect for the very next request to or from the domain
+	if err := p.db.DeleteByID(ctx, id, domainAllow); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoDomainAllow, nil
+}
@@ -52,16 +52,26 @@ func (p *processor) DomainBlockDelete(ctx context.Context, account *gtsmodel.Acc
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if err := p.liftDomainBlockSuspension(ctx, domainBlock); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoDomainBlock, nil
+}
+
+// liftDomainBlockSuspension removes the suspension of the instance and accounts
+// that were suspended as a side effect of the given domain block.
+func (p *processor) liftDomainBlockSuspension(ctx context.Context, domainBlock *gtsmodel.DomainBlock) error {
 	// remove the domain block reference from the instance, if we have an entry for it
 	i := &gtsmodel.Instance{}
 	if err := p.db.GetWhere(ctx, []db.Where{
 		{Key: "domain", Value: domainBlock.Domain, CaseInsensitive: true},
-		{Key: "domain_block_id", Value: id},
+		{Key: "domain_block_id", Value: domainBlock.ID},
 	}, i); err == nil {
 		i.SuspendedAt = time.Time{}
 		i.DomainBlockID = ""
 		if err := p.db.UpdateByPrimaryKey(ctx, i); err != nil {
-			return nil, gtserror.NewErrorInternalError(fmt.Errorf("couldn't update database entry for instance %s: %s", domainBlock.Domain, err))
+			return fmt.Errorf("couldn't update database entry for instance %s: %s", domainBlock.Domain, err)
 		}
 	}
 
@@ -70,15 +80,15 @@ func (p *processor) DomainBlockDelete(ctx context.Context, account *gtsmodel.Acc
 	if err := p.db.UpdateWhere(ctx, []db.Where{
 		{Key: "suspension_origin", Value: domainBlock.ID},
 	}, "suspended_at", nil, &[]*gtsmodel.Account{}); err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error removing suspended_at from accounts: %s", err))
+		return fmt.Errorf("database error removing suspended_at from accounts: %s", err)
 	}
 
 	// 2. remove the 'suspension_origin' entry from their accounts
 	if err := p.db.UpdateWhere(ctx, []db.Where{
 		{Key: "suspension_origin", Value: domainBlock.ID},
 	}, "suspension_origin", nil, &[]*gtsmodel.Account{}); err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error removing suspension_origin from accounts: %s", err))
+		return fmt.Errorf("database error removing suspension_origin from accounts: %s", err)
 	}
 
-	return mastoDomainBlock, nil
+	return nil
 }
@@ -31,7 +31,7 @@ import (
 )
 
 func (p *processor) EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, error) {
-	if user.Admin {
+	if !user.Admin {
 		return nil, fmt.Errorf("user %s not an admin", user.ID)
 	}
 
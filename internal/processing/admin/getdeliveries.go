@@ -0,0 +1,72 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) DeliveriesGet(ctx context.Context, account *gtsmodel.Account, activityURI string, statusID string) ([]*apimodel.DeliveryReceipt, gtserror.WithCode) {
+	receipts := []*gtsmodel.DeliveryReceipt{}
+
+	switch {
+	case activityURI != "":
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: "activity_uri", Value: activityURI}}, &receipts); err != nil && err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DeliveriesGet: db error getting receipts for activity %s: %s", activityURI, err))
+		}
+	case statusID != "":
+		status, err := p.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil, gtserror.NewErrorNotFound(fmt.Errorf("DeliveriesGet: no status with id %s", statusID))
+			}
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DeliveriesGet: db error getting status %s: %s", statusID, err))
+		}
+
+		// a status might have been delivered as the object of an activity (Create, Update, Delete etc),
+		// or, in the case of a boost, it might be the activity itself
+		for _, key := range []string{"object_uri", "activity_uri"} {
+			r := []*gtsmodel.DeliveryReceipt{}
+			if err := p.db.GetWhere(ctx, []db.Where{{Key: key, Value: status.URI}}, &r); err != nil && err != db.ErrNoEntries {
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("DeliveriesGet: db error getting receipts for status %s: %s", statusID, err))
+			}
+			receipts = append(receipts, r...)
+		}
+	default:
+		return nil, gtserror.NewErrorBadRequest(errors.New("DeliveriesGet: one of activity uri or status id must be set"), "one of activity_uri or status_id must be set")
+	}
+
+	mastoReceipts := make([]*apimodel.DeliveryReceipt, 0, len(receipts))
+	for _, r := range receipts {
+		mastoReceipt, err := p.tc.DeliveryReceiptToMasto(ctx, r)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoReceipts = append(mastoReceipts, mastoReceipt)
+	}
+
+	return mastoReceipts, nil
+}
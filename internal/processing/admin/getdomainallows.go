@@ -0,0 +1,50 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) DomainAllowsGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.DomainAllow, gtserror.WithCode) {
+	domainAllows := []*gtsmodel.DomainAllow{}
+
+	if err := p.db.GetAll(ctx, &domainAllows); err != nil {
+		if err != db.ErrNoEntries {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	mastoDomainAllows := []*apimodel.DomainAllow{}
+	for _, a := range domainAllows {
+		mastoDomainAllow, err := p.tc.DomainAllowToMasto(ctx, a)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoDomainAllows = append(mastoDomainAllows, mastoDomainAllow)
+	}
+
+	return mastoDomainAllows, nil
+}
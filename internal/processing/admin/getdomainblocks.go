@@ -19,7 +19,11 @@
 package admin
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -48,3 +52,36 @@ func (p *processor) DomainBlocksGet(ctx context.Context, account *gtsmodel.Accou
 
 	return mastoDomainBlocks, nil
 }
+
+// DomainBlocksExportCSV returns all domain blocks as a csv file that can be imported by Mastodon or GoToSocial.
+func (p *processor) DomainBlocksExportCSV(ctx context.Context, account *gtsmodel.Account) (*apimodel.Content, gtserror.WithCode) {
+	domainBlocks, errWithCode := p.DomainBlocksGet(ctx, account, true)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	records := [][]string{domainBlocksCSVHeader}
+	for _, b := range domainBlocks {
+		records = append(records, []string{
+			b.Domain,
+			b.Severity,
+			strconv.FormatBool(b.RejectMedia),
+			strconv.FormatBool(b.RejectReports),
+			b.PublicComment,
+			strconv.FormatBool(b.Obfuscate),
+		})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainBlocksExportCSV: error writing csv: %s", err))
+	}
+
+	return &apimodel.Content{
+		ContentType:   "text/csv",
+		ContentLength: int64(buf.Len()),
+		Content:       buf.Bytes(),
+	}, nil
+}
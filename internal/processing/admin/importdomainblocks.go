@@ -21,11 +21,14 @@ package admin
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"strconv"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -48,15 +51,34 @@ func (p *processor) DomainBlocksImport(ctx context.Context, account *gtsmodel.Ac
 		return nil, gtserror.NewErrorBadRequest(errors.New("DomainBlocksImport: could not read provided attachment: size 0 bytes"))
 	}
 
-	d := []apimodel.DomainBlock{}
-	if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
-		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("DomainBlocksImport: could not read provided attachment: %s", err))
+	var forms []*apimodel.DomainBlockCreateRequest
+	if bytes.HasPrefix(bytes.TrimSpace(buf.Bytes()), []byte("[")) {
+		// a json array of domain blocks, as exported by GoToSocial
+		d := []apimodel.DomainBlock{}
+		if err := json.Unmarshal(buf.Bytes(), &d); err != nil {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("DomainBlocksImport: could not read provided attachment: %s", err))
+		}
+		for _, d := range d {
+			forms = append(forms, &apimodel.DomainBlockCreateRequest{
+				Domain:        d.Domain,
+				Obfuscate:     d.Obfuscate,
+				PublicComment: d.PublicComment,
+				Severity:      d.Severity,
+				RejectMedia:   d.RejectMedia,
+				RejectReports: d.RejectReports,
+			})
+		}
+	} else {
+		// anything else is treated as a blocklist csv, as exported by Mastodon
+		forms, err = parseDomainBlocksCSV(buf)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("DomainBlocksImport: could not read provided attachment: %s", err))
+		}
 	}
 
 	blocks := []*apimodel.DomainBlock{}
-	for _, d := range d {
-		block, err := p.DomainBlockCreate(ctx, account, d.Domain, false, d.PublicComment, "", "")
-
+	for _, form := range forms {
+		block, err := p.DomainBlockCreate(ctx, account, form, "")
 		if err != nil {
 			return nil, err
 		}
@@ -66,3 +88,70 @@ func (p *processor) DomainBlocksImport(ctx context.Context, account *gtsmodel.Ac
 
 	return blocks, nil
 }
+
+// domainBlocksCSVHeader is the header row of the blocklist csv that Mastodon exports;
+// the columns are expected in this order if the file has no header row.
+var domainBlocksCSVHeader = []string{"#domain", "#severity", "#reject_media", "#reject_reports", "#public_comment", "#obfuscate"}
+
+// parseDomainBlocksCSV parses a blocklist csv, with or without a header row, into domain block forms.
+func parseDomainBlocksCSV(r io.Reader) ([]*apimodel.DomainBlockCreateRequest, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// map of column name (without the leading #) to the index of that column
+	columns := map[string]int{}
+	for i, c := range domainBlocksCSVHeader {
+		columns[strings.TrimPrefix(c, "#")] = i
+	}
+
+	if len(records) != 0 {
+		first := records[0]
+		if len(first) != 0 && strings.TrimPrefix(strings.TrimSpace(first[0]), "#") == "domain" {
+			// this is a header row, so take the column order from it
+			columns = map[string]int{}
+			for i, c := range first {
+				columns[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(c), "#"))] = i
+			}
+			records = records[1:]
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	forms := []*apimodel.DomainBlockCreateRequest{}
+	for _, record := range records {
+		domain := field(record, "domain")
+		if domain == "" || strings.HasPrefix(domain, "#") {
+			// skip empty lines and comments
+			continue
+		}
+
+		forms = append(forms, &apimodel.DomainBlockCreateRequest{
+			Domain:        domain,
+			Severity:      strings.ToLower(field(record, "severity")),
+			RejectMedia:   parseCSVBool(field(record, "reject_media")),
+			RejectReports: parseCSVBool(field(record, "reject_reports")),
+			PublicComment: field(record, "public_comment"),
+			Obfuscate:     parseCSVBool(field(record, "obfuscate")),
+		})
+	}
+
+	return forms, nil
+}
+
+func parseCSVBool(s string) bool {
+	b, err := strconv.ParseBool(s)
+	return err == nil && b
+}
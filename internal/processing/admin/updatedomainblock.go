@@ -0,0 +1,101 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *processor) DomainBlockUpdate(ctx context.Context, account *gtsmodel.Account, id string, form *apimodel.DomainBlockUpdateRequest) (*apimodel.DomainBlock, gtserror.WithCode) {
+	domainBlock := &gtsmodel.DomainBlock{}
+
+	if err := p.db.GetByID(ctx, id, domainBlock); err != nil {
+		if err != db.ErrNoEntries {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		// there are no entries for this ID
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	oldSeverity := domainBlock.Severity
+	if oldSeverity == "" {
+		oldSeverity = gtsmodel.DomainBlockSeveritySuspend
+	}
+
+	newSeverity := oldSeverity
+	if form.Severity != nil {
+		if err := validate.DomainBlockSeverity(*form.Severity); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		newSeverity = gtsmodel.DomainBlockSeverity(*form.Severity)
+	}
+	domainBlock.Severity = newSeverity
+
+	if form.Obfuscate != nil {
+		domainBlock.Obfuscate = *form.Obfuscate
+	}
+
+	if form.RejectMedia != nil {
+		domainBlock.RejectMedia = *form.RejectMedia
+	}
+
+	if form.RejectReports != nil {
+		domainBlock.RejectReports = *form.RejectReports
+	}
+
+	if form.PrivateComment != nil {
+		domainBlock.PrivateComment = text.RemoveHTML(*form.PrivateComment)
+	}
+
+	if form.PublicComment != nil {
+		domainBlock.PublicComment = text.RemoveHTML(*form.PublicComment)
+	}
+
+	if err := p.db.UpdateByPrimaryKey(ctx, domainBlock); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainBlockUpdate: error updating domain block %s: %s", id, err))
+	}
+
+	switch {
+	case oldSeverity != gtsmodel.DomainBlockSeveritySuspend && newSeverity == gtsmodel.DomainBlockSeveritySuspend:
+		// the block has been escalated to a suspension, so process the side effects just like a new block
+		go p.initiateDomainBlockSideEffects(ctx, account, domainBlock) // TODO: add this to a queuing system so it can retry/resume
+	case oldSeverity == gtsmodel.DomainBlockSeveritySuspend && newSeverity != gtsmodel.DomainBlockSeveritySuspend:
+		// the block has been downgraded, so lift the suspension of the instance and its accounts;
+		// statuses and follows that were removed by the suspension can't be brought back
+		if err := p.liftDomainBlockSuspension(ctx, domainBlock); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	mastoDomainBlock, err := p.tc.DomainBlockToMasto(ctx, domainBlock, false)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoDomainBlock, nil
+}
@@ -0,0 +1,381 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+const (
+	// adminAccountActionNone only gives a local account a warning.
+	adminAccountActionNone = "none"
+	// adminAccountActionSilence hides an account's statuses from the public timelines.
+	adminAccountActionSilence = "silence"
+	// adminAccountActionSuspend removes an account's content and stops it from interacting with this instance.
+	adminAccountActionSuspend = "suspend"
+
+	// defaultAdminAccountsLimit is how many accounts are returned when no limit is given.
+	defaultAdminAccountsLimit = 100
+)
+
+// adminAccountActionComments are recorded on reports that are resolved by taking an action against the reported account.
+var adminAccountActionComments = map[string]string{
+	adminAccountActionNone:    "The account was warned.",
+	adminAccountActionSilence: "The account was silenced.",
+	adminAccountActionSuspend: "The account was suspended.",
+}
+
+func (p *processor) AdminAccountsGet(ctx context.Context, authed *oauth.Auth, local bool, remote bool, pending bool, suspended bool, silenced bool, maxID string, limit int) ([]*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	if local && remote {
+		return nil, gtserror.NewErrorBadRequest(errors.New("both local and remote were set"), "local and remote can't both be set")
+	}
+
+	if limit <= 0 || limit > defaultAdminAccountsLimit {
+		limit = defaultAdminAccountsLimit
+	}
+
+	accounts, err := p.db.GetAdminAccounts(ctx, local, remote, pending, suspended, silenced, maxID, limit)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoAccounts := make([]*apimodel.AdminAccountInfo, 0, len(accounts))
+	for _, a := range accounts {
+		mastoAccount, err := p.tc.AccountToAdminMasto(ctx, a)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoAccounts = append(mastoAccounts, mastoAccount)
+	}
+
+	return mastoAccounts, nil
+}
+
+func (p *processor) AdminAccountGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, err := p.db.GetAccountByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.adminAccountToMasto(ctx, account)
+}
+
+func (p *processor) AdminAccountAction(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.AdminAccountActionRequest) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	if form.Type != adminAccountActionNone && form.Type != adminAccountActionSilence && form.Type != adminAccountActionSuspend {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("unknown action type %s", form.Type), "action type must be one of: none, silence, suspend")
+	}
+
+	if err := validate.AccountWarningText(form.Text); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	account, errWithCode := p.adminActionTarget(ctx, authed, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// the user of a local account is needed to email them about the action,
+	// and to make sure that admins can't lock each other out of the instance
+	var user *gtsmodel.User
+	if account.Domain == "" {
+		user = &gtsmodel.User{}
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, user); err != nil {
+			if err != db.ErrNoEntries {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			user = nil
+		}
+		if user != nil && user.Admin {
+			return nil, gtserror.NewErrorForbidden(fmt.Errorf("account %s belongs to an admin", account.ID), "you cannot take action against an admin account")
+		}
+	} else if form.Type == adminAccountActionNone {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is remote", account.ID), "only local accounts can be given a warning")
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is already suspended", account.ID), "account is already suspended")
+	}
+
+	// if the action is being taken because of a report, make sure that report can be resolved by it
+	var report *gtsmodel.Report
+	if form.ReportID != "" {
+		report, errWithCode = p.adminLinkedReport(ctx, form.ReportID, account)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		if report.ActionTaken {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("report %s is already resolved", report.ID), "report is already resolved")
+		}
+	}
+
+	var auditAction gtsmodel.AdminAuditLogAction
+	switch form.Type {
+	case adminAccountActionNone:
+		auditAction = gtsmodel.AdminAuditLogActionWarn
+	case adminAccountActionSilence:
+		if !account.SilencedAt.IsZero() {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is already silenced", account.ID), "account is already silenced")
+		}
+		account.SilencedAt = time.Now()
+		auditAction = gtsmodel.AdminAuditLogActionSilence
+	case adminAccountActionSuspend:
+		// mark the account as suspended straight away so that it can't be used while the delete is processed
+		account.SuspendedAt = time.Now()
+		account.SuspensionOrigin = authed.Account.ID
+		auditAction = gtsmodel.AdminAuditLogActionSuspend
+	}
+
+	if form.Type != adminAccountActionNone {
+		var err error
+		account, err = p.db.UpdateAccount(ctx, account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+	p.adminAudit(ctx, authed.Account, auditAction, gtsmodel.AdminAuditLogTargetAccount, account.ID, adminAuditAccountSummary(account))
+
+	// actions against local accounts are recorded as warnings, so that moderators can see the account's history
+	if account.Domain == "" {
+		warning, err := p.createAccountWarning(ctx, authed.Account, account, report, gtsmodel.AccountWarningAction(form.Type), text.RemoveHTML(form.Text), form.SendEmailNotification && user != nil && user.Email != "")
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if warning.Emailed {
+			// the user of a suspended account is removed in the background, so don't wait for the queue to send this
+			go p.emailAccountWarning(user, account, warning)
+		}
+	}
+
+	if report != nil {
+		if err := p.resolveReport(ctx, authed.Account, report, adminAccountActionComments[form.Type]); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// convert before queueing anything, since the delete will go on to modify the account
+	mastoAccount, errWithCode := p.adminAccountToMasto(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Type == adminAccountActionSuspend {
+		// remove the account's content through the same process as a domain block
+		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityDelete,
+			OriginAccount:  authed.Account,
+			TargetAccount:  account,
+		}
+	}
+
+	return mastoAccount, nil
+}
+
+func (p *processor) AdminAccountApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, user, errWithCode := p.adminPendingTarget(ctx, authed, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	user.Approved = true
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionApprove, gtsmodel.AdminAuditLogTargetAccount, account.ID, adminAuditAccountSummary(account))
+
+	// email the new user asynchronously
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       user,
+		OriginAccount:  authed.Account,
+		TargetAccount:  account,
+	}
+
+	return p.adminAccountToMasto(ctx, account)
+}
+
+func (p *processor) AdminAccountReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, user, errWithCode := p.adminPendingTarget(ctx, authed, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// convert before removing anything, since the user is needed for the admin view
+	mastoAccount, errWithCode := p.adminAccountToMasto(ctx, account)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// a pending account hasn't been able to do anything yet, so there's nothing to
+	// clean up or federate: just remove it so that the username can be used again
+	if err := p.db.DeleteByID(ctx, user.ID, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteByID(ctx, account.ID, &gtsmodel.Account{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// the sign up has been dealt with, so admins don't need to be notified of it anymore
+	if err := p.db.DeleteWhere(ctx, []db.Where{
+		{Key: "origin_account_id", Value: account.ID},
+		{Key: "notification_type", Value: gtsmodel.NotificationAdminSignUp},
+	}, &gtsmodel.Notification{}); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionReject, gtsmodel.AdminAuditLogTargetAccount, account.ID, adminAuditAccountSummary(account))
+
+	// email the rejected user asynchronously
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       user,
+		OriginAccount:  authed.Account,
+		TargetAccount:  account,
+	}
+
+	return mastoAccount, nil
+}
+
+func (p *processor) AdminAccountUnsilence(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, errWithCode := p.adminActionTarget(ctx, authed, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if account.SilencedAt.IsZero() {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is not silenced", account.ID), "account is not silenced")
+	}
+
+	account.SilencedAt = time.Time{}
+	account, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionUnsilence, gtsmodel.AdminAuditLogTargetAccount, account.ID, adminAuditAccountSummary(account))
+
+	return p.adminAccountToMasto(ctx, account)
+}
+
+func (p *processor) AdminAccountUnsuspend(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, errWithCode := p.adminActionTarget(ctx, authed, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if account.SuspendedAt.IsZero() {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is not suspended", account.ID), "account is not suspended")
+	}
+
+	// the user and content of a local account are removed when it's suspended, so there's nothing left to restore
+	if account.Domain == "" {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is local", account.ID), "local accounts can't be unsuspended")
+	}
+
+	// like lifting a domain block, this just lets the account interact with us again; its content will be
+	// fetched fresh from its instance the next time it's dereferenced
+	account.SuspendedAt = time.Time{}
+	account.SuspensionOrigin = ""
+	account, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionUnsuspend, gtsmodel.AdminAuditLogTargetAccount, account.ID, adminAuditAccountSummary(account))
+
+	return p.adminAccountToMasto(ctx, account)
+}
+
+// adminActionTarget gets the account with the given id, making sure that it's one that
+// a moderator is allowed to act on: ie., not their own account or the instance account.
+func (p *processor) adminActionTarget(ctx context.Context, authed *oauth.Auth, id string) (*gtsmodel.Account, gtserror.WithCode) {
+	if id == authed.Account.ID {
+		return nil, gtserror.NewErrorBadRequest(errors.New("account tried to moderate itself"), "you cannot take action against your own account")
+	}
+
+	account, err := p.db.GetAccountByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account.Domain == "" && account.Username == p.config.Host {
+		return nil, gtserror.NewErrorBadRequest(errors.New("account tried to moderate the instance account"), "you cannot take action against the instance account")
+	}
+
+	return account, nil
+}
+
+// adminPendingTarget gets the account with the given id, along with its user, making
+// sure that it's a local account whose signup is still waiting to be approved.
+func (p *processor) adminPendingTarget(ctx context.Context, authed *oauth.Auth, id string) (*gtsmodel.Account, *gtsmodel.User, gtserror.WithCode) {
+	account, errWithCode := p.adminActionTarget(ctx, authed, id)
+	if errWithCode != nil {
+		return nil, nil, errWithCode
+	}
+
+	if account.Domain != "" {
+		return nil, nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is remote", account.ID), "only local accounts can be approved or rejected")
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, nil, gtserror.NewErrorNotFound(fmt.Errorf("no user for account %s", account.ID))
+		}
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user.Approved {
+		return nil, nil, gtserror.NewErrorBadRequest(fmt.Errorf("user %s is already approved", user.ID), "account is not pending approval")
+	}
+
+	return account, user, nil
+}
+
+func (p *processor) adminAccountToMasto(ctx context.Context, account *gtsmodel.Account) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	mastoAccount, err := p.tc.AccountToAdminMasto(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return mastoAccount, nil
+}
@@ -0,0 +1,307 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+)
+
+type AdminAccountTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AdminAccountTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["admin_account"],
+		Account:     suite.testAccounts["admin_account"],
+	}
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountsGet() {
+	ctx := context.Background()
+
+	pending, errWithCode := suite.processor.AdminAccountsGet(ctx, suite.authed(), false, false, true, false, false, "", 0)
+	suite.NoError(errWithCode)
+	suite.Len(pending, 1)
+	suite.Equal(suite.testAccounts["unconfirmed_account"].ID, pending[0].ID)
+	suite.Equal("weed_lord420@example.org", pending[0].Email)
+	suite.Equal("hi, please let me in! I'm looking for somewhere neato bombeato to hang out.", pending[0].InviteRequest)
+	suite.False(pending[0].Approved)
+	suite.False(pending[0].Confirmed)
+
+	remote, errWithCode := suite.processor.AdminAccountsGet(ctx, suite.authed(), false, true, false, false, false, "", 0)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(remote)
+	for _, a := range remote {
+		suite.NotEmpty(a.Domain)
+		suite.Empty(a.Email)
+	}
+
+	_, errWithCode = suite.processor.AdminAccountsGet(ctx, suite.authed(), true, true, false, false, false, "", 0)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountApprove() {
+	ctx := context.Background()
+	account := suite.testAccounts["unconfirmed_account"]
+
+	approved, errWithCode := suite.processor.AdminAccountApprove(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+	suite.True(approved.Approved)
+
+	user := &gtsmodel.User{}
+	err := suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, user)
+	suite.NoError(err)
+	suite.True(user.Approved)
+
+	// an account can only be approved once
+	_, errWithCode = suite.processor.AdminAccountApprove(ctx, suite.authed(), account.ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountReject() {
+	ctx := context.Background()
+	account := suite.testAccounts["unconfirmed_account"]
+
+	rejected, errWithCode := suite.processor.AdminAccountReject(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+	suite.Equal("weed_lord420", rejected.Username)
+
+	err := suite.db.GetByID(ctx, account.ID, &gtsmodel.Account{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &gtsmodel.User{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// zork has already been approved, so can't be rejected
+	_, errWithCode = suite.processor.AdminAccountReject(ctx, suite.authed(), suite.testAccounts["local_account_1"].ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountSignUpNotification() {
+	ctx := context.Background()
+	admin := suite.testAccounts["admin_account"]
+	account := suite.testAccounts["unconfirmed_account"]
+
+	// weed_lord420 signs up and is waiting for approval
+	err := suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       suite.testUsers["unconfirmed_account"],
+	})
+	suite.NoError(err)
+
+	notif := &gtsmodel.Notification{}
+	err = suite.db.GetWhere(ctx, []db.Where{
+		{Key: "notification_type", Value: gtsmodel.NotificationAdminSignUp},
+		{Key: "target_account_id", Value: admin.ID},
+	}, notif)
+	suite.NoError(err)
+	suite.Equal(account.ID, notif.OriginAccountID)
+
+	mastoNotif, err := suite.typeconverter.NotificationToMasto(ctx, notif)
+	suite.NoError(err)
+	suite.Equal("admin.sign_up", mastoNotif.Type)
+	suite.Equal("weed_lord420", mastoNotif.Account.Username)
+
+	// once the sign up is rejected, there's nothing left for the admin to look at
+	_, errWithCode := suite.processor.AdminAccountReject(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+
+	err = suite.db.GetByID(ctx, notif.ID, &gtsmodel.Notification{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountSignUpNotificationApproved() {
+	ctx := context.Background()
+
+	// zork was approved straight away, so admins don't need to hear about it
+	err := suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       suite.testUsers["local_account_1"],
+	})
+	suite.NoError(err)
+
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "notification_type", Value: gtsmodel.NotificationAdminSignUp}}, &gtsmodel.Notification{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountSilence() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	status := suite.testStatuses["local_account_1_status_1"]
+	filter := visibility.NewFilter(suite.db, suite.log)
+
+	silenced, errWithCode := suite.processor.AdminAccountAction(ctx, suite.authed(), account.ID, &apimodel.AdminAccountActionRequest{Type: "silence"})
+	suite.NoError(errWithCode)
+	suite.True(silenced.Silenced)
+	suite.False(silenced.Suspended)
+
+	// statuses of a silenced account are kept out of the public timeline...
+	timelineable, err := filter.StatusPublictimelineable(ctx, status, suite.testAccounts["local_account_2"])
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// ...but the account itself can still see them there
+	timelineable, err = filter.StatusPublictimelineable(ctx, status, account)
+	suite.NoError(err)
+	suite.True(timelineable)
+
+	unsilenced, errWithCode := suite.processor.AdminAccountUnsilence(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+	suite.False(unsilenced.Silenced)
+
+	timelineable, err = filter.StatusPublictimelineable(ctx, status, suite.testAccounts["local_account_2"])
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountSuspendRemote() {
+	ctx := context.Background()
+	account := suite.testAccounts["remote_account_1"]
+
+	suspended, errWithCode := suite.processor.AdminAccountAction(ctx, suite.authed(), account.ID, &apimodel.AdminAccountActionRequest{Type: "suspend"})
+	suite.NoError(errWithCode)
+	suite.True(suspended.Suspended)
+
+	// the account's relationships are removed in the background, by the same process as a domain block
+	suite.Eventually(func() bool {
+		blocked, err := suite.db.IsBlocked(ctx, suite.testAccounts["local_account_2"].ID, account.ID, false)
+		return err == nil && !blocked
+	}, 5*time.Second, 100*time.Millisecond)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+	suite.NoError(err)
+	suite.False(dbAccount.SuspendedAt.IsZero())
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbAccount.SuspensionOrigin)
+
+	unsuspended, errWithCode := suite.processor.AdminAccountUnsuspend(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+	suite.False(unsuspended.Suspended)
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountSuspendLocal() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_2"]
+
+	suspended, errWithCode := suite.processor.AdminAccountAction(ctx, suite.authed(), account.ID, &apimodel.AdminAccountActionRequest{Type: "suspend"})
+	suite.NoError(errWithCode)
+	suite.True(suspended.Suspended)
+
+	// the user is removed in the background, so the account can't be logged in to anymore
+	suite.Eventually(func() bool {
+		err := suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &gtsmodel.User{})
+		return err == db.ErrNoEntries
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// there's nothing left to restore for a local account
+	_, errWithCode = suite.processor.AdminAccountUnsuspend(ctx, suite.authed(), account.ID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountWarn() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	warned, errWithCode := suite.processor.AdminAccountAction(ctx, suite.authed(), account.ID, &apimodel.AdminAccountActionRequest{
+		Type: "none",
+		Text: "please stop posting <b>cat pictures</b>",
+	})
+	suite.NoError(errWithCode)
+	suite.False(warned.Silenced)
+	suite.False(warned.Suspended)
+	suite.Len(warned.Warnings, 1)
+	suite.Equal("none", warned.Warnings[0].Action)
+	suite.Equal("please stop posting cat pictures", warned.Warnings[0].Text)
+	suite.False(warned.Warnings[0].Emailed)
+
+	// silencing the account afterwards adds to its history
+	_, errWithCode = suite.processor.AdminAccountAction(ctx, suite.authed(), account.ID, &apimodel.AdminAccountActionRequest{Type: "silence"})
+	suite.NoError(errWithCode)
+
+	got, errWithCode := suite.processor.AdminAccountGet(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+	suite.Len(got.Warnings, 2)
+
+	// remote accounts can't be warned
+	_, errWithCode = suite.processor.AdminAccountAction(ctx, suite.authed(), suite.testAccounts["remote_account_1"].ID, &apimodel.AdminAccountActionRequest{Type: "none"})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminAccountTestSuite) TestAdminModerationNotes() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	note, errWithCode := suite.processor.AdminModerationNoteCreate(ctx, suite.authed(), account.ID, &apimodel.AdminModerationNoteCreateRequest{Content: "keep an eye on this one"})
+	suite.NoError(errWithCode)
+	suite.Equal("keep an eye on this one", note.Content)
+	suite.Equal(suite.testAccounts["admin_account"].ID, note.Account.ID)
+
+	got, errWithCode := suite.processor.AdminAccountGet(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+	suite.Len(got.ModerationNotes, 1)
+	suite.Equal(note.ID, got.ModerationNotes[0].ID)
+
+	// notes can only be deleted through the account they're about
+	_, errWithCode = suite.processor.AdminModerationNoteDelete(ctx, suite.authed(), suite.testAccounts["local_account_2"].ID, note.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AdminModerationNoteDelete(ctx, suite.authed(), account.ID, note.ID)
+	suite.NoError(errWithCode)
+
+	got, errWithCode = suite.processor.AdminAccountGet(ctx, suite.authed(), account.ID)
+	suite.NoError(errWithCode)
+	suite.Empty(got.ModerationNotes)
+
+	_, errWithCode = suite.processor.AdminModerationNoteCreate(ctx, suite.authed(), account.ID, &apimodel.AdminModerationNoteCreateRequest{})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminAccountTestSuite) TestAdminAccountActionNotAllowed() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.processor.AdminAccountAction(ctx, suite.authed(), suite.testAccounts["admin_account"].ID, &apimodel.AdminAccountActionRequest{Type: "suspend"})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	instanceAccount, err := suite.db.GetInstanceAccount(ctx, "")
+	suite.NoError(err)
+	_, errWithCode = suite.processor.AdminAccountAction(ctx, suite.authed(), instanceAccount.ID, &apimodel.AdminAccountActionRequest{Type: "silence"})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AdminAccountAction(ctx, suite.authed(), suite.testAccounts["local_account_1"].ID, &apimodel.AdminAccountActionRequest{Type: "disable"})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestAdminAccountTestSuite(t *testing.T) {
+	suite.Run(t, &AdminAccountTestSuite{})
+}
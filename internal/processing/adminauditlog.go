@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// defaultAdminAuditLogsLimit is how many audit log entries are returned when no limit is given.
+const defaultAdminAuditLogsLimit = 100
+
+func (p *processor) AdminAuditLogsGet(ctx context.Context, authed *oauth.Auth, accountID string, maxID string, limit int) ([]*apimodel.AdminAuditLog, gtserror.WithCode) {
+	if limit <= 0 || limit > defaultAdminAuditLogsLimit {
+		limit = defaultAdminAuditLogsLimit
+	}
+
+	auditLogs, err := p.db.GetAdminAuditLogs(ctx, accountID, maxID, limit)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoAuditLogs := make([]*apimodel.AdminAuditLog, 0, len(auditLogs))
+	for _, l := range auditLogs {
+		mastoAuditLog, err := p.tc.AdminAuditLogToMasto(ctx, l)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoAuditLogs = append(mastoAuditLogs, mastoAuditLog)
+	}
+
+	return mastoAuditLogs, nil
+}
+
+// adminAudit records that the given admin account took an action in the admin audit log.
+// The action has already been taken by the time this is called, so failing to record it is logged rather than returned.
+func (p *processor) adminAudit(ctx context.Context, account *gtsmodel.Account, action gtsmodel.AdminAuditLogAction, targetType gtsmodel.AdminAuditLogTarget, targetID string, summary string) {
+	if err := p.db.CreateAdminAuditLog(ctx, account.ID, action, targetType, targetID, summary); err != nil {
+		p.log.WithContext(ctx).Errorf("adminAudit: error recording %s of %s %s by account %s: %s", action, targetType, targetID, account.ID, err)
+	}
+}
+
+// adminAuditAccountSummary returns the username of the given account, including its domain if it's remote.
+func adminAuditAccountSummary(account *gtsmodel.Account) string {
+	if account.Domain == "" {
+		return "@" + account.Username
+	}
+	return "@" + account.Username + "@" + account.Domain
+}
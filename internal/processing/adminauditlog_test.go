@@ -0,0 +1,126 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AdminAuditLogTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AdminAuditLogTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["admin_account"],
+		Account:     suite.testAccounts["admin_account"],
+	}
+}
+
+func (suite *AdminAuditLogTestSuite) TestActionsAreRecorded() {
+	ctx := context.Background()
+
+	// entry ids are ulids with millisecond resolution, so make sure each action gets its own millisecond
+	rule, errWithCode := suite.processor.AdminRuleCreate(ctx, suite.authed(), &apimodel.RuleCreateRequest{Text: "be excellent to each other"})
+	suite.NoError(errWithCode)
+	time.Sleep(2 * time.Millisecond)
+
+	account := suite.testAccounts["remote_account_1"]
+	_, errWithCode = suite.processor.AdminAccountAction(ctx, suite.authed(), account.ID, &apimodel.AdminAccountActionRequest{Type: "silence"})
+	suite.NoError(errWithCode)
+	time.Sleep(2 * time.Millisecond)
+
+	domainBlock, errWithCode := suite.processor.AdminDomainBlockCreate(ctx, suite.authed(), &apimodel.DomainBlockCreateRequest{Domain: "silenced.example.org", Severity: "silence"})
+	suite.NoError(errWithCode)
+
+	// a failed action isn't recorded
+	_, errWithCode = suite.processor.AdminAccountUnsilence(ctx, suite.authed(), suite.testAccounts["local_account_2"].ID)
+	suite.Error(errWithCode)
+
+	auditLogs, errWithCode := suite.processor.AdminAuditLogsGet(ctx, suite.authed(), "", "", 0)
+	suite.NoError(errWithCode)
+	suite.Len(auditLogs, 3)
+
+	// newest first
+	suite.Equal("create", auditLogs[0].Action)
+	suite.Equal("domain_block", auditLogs[0].TargetType)
+	suite.Equal(domainBlock.ID, auditLogs[0].TargetID)
+	suite.Equal("silenced.example.org", auditLogs[0].Summary)
+	suite.Equal("admin", auditLogs[0].Account.Username)
+
+	suite.Equal("silence", auditLogs[1].Action)
+	suite.Equal("account", auditLogs[1].TargetType)
+	suite.Equal(account.ID, auditLogs[1].TargetID)
+	suite.Equal("@"+account.Username+"@"+account.Domain, auditLogs[1].Summary)
+
+	suite.Equal("create", auditLogs[2].Action)
+	suite.Equal("rule", auditLogs[2].TargetType)
+	suite.Equal(rule.ID, auditLogs[2].TargetID)
+
+	// page back from the newest entry
+	older, errWithCode := suite.processor.AdminAuditLogsGet(ctx, suite.authed(), "", auditLogs[0].ID, 1)
+	suite.NoError(errWithCode)
+	suite.Len(older, 1)
+	suite.Equal(auditLogs[1].ID, older[0].ID)
+
+	// nothing was done by anyone else
+	byOther, errWithCode := suite.processor.AdminAuditLogsGet(ctx, suite.authed(), suite.testAccounts["local_account_1"].ID, "", 0)
+	suite.NoError(errWithCode)
+	suite.Empty(byOther)
+}
+
+func (suite *AdminAuditLogTestSuite) TestEmojiCreateIsRecorded() {
+	ctx := context.Background()
+
+	body, w, err := testrig.CreateMultipartFormData("image", "../../testrig/media/rainbow-original.png", nil)
+	suite.NoError(err)
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	suite.NoError(err)
+
+	_, err = suite.processor.AdminEmojiCreate(ctx, suite.authed(), &apimodel.EmojiCreateRequest{
+		Shortcode: "rainbow_two",
+		Image:     form.File["image"][0],
+	})
+	suite.NoError(err)
+
+	emoji := &gtsmodel.Emoji{}
+	suite.NoError(suite.db.GetWhere(ctx, []db.Where{{Key: "shortcode", Value: "rainbow_two"}}, emoji))
+
+	auditLogs, errWithCode := suite.processor.AdminAuditLogsGet(ctx, suite.authed(), "", "", 0)
+	suite.NoError(errWithCode)
+	suite.Len(auditLogs, 1)
+	suite.Equal("emoji", auditLogs[0].TargetType)
+	suite.Equal(emoji.ID, auditLogs[0].TargetID)
+	suite.Equal("rainbow_two", auditLogs[0].Summary)
+}
+
+func TestAdminAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, &AdminAuditLogTestSuite{})
+}
@@ -0,0 +1,150 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AdminDomainBlockTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AdminDomainBlockTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["admin_account"],
+		Account:     suite.testAccounts["admin_account"],
+	}
+}
+
+func (suite *AdminDomainBlockTestSuite) TestCreateAndUpdateSeverity() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.processor.AdminDomainBlockCreate(ctx, suite.authed(), &apimodel.DomainBlockCreateRequest{
+		Domain:   "silenced.example.org",
+		Severity: "ban",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	block, errWithCode := suite.processor.AdminDomainBlockCreate(ctx, suite.authed(), &apimodel.DomainBlockCreateRequest{
+		Domain:      "silenced.example.org",
+		Severity:    "silence",
+		RejectMedia: true,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("silence", block.Severity)
+	suite.True(block.RejectMedia)
+	suite.False(block.RejectReports)
+
+	// a silenced domain is not blocked, but it does have a policy
+	blocked, err := suite.db.IsDomainBlocked(ctx, "silenced.example.org")
+	suite.NoError(err)
+	suite.False(blocked)
+
+	policy, err := suite.db.GetDomainPolicy(ctx, "silenced.example.org")
+	suite.NoError(err)
+	suite.True(policy.Silence)
+	suite.True(policy.RejectMedia)
+
+	severity := "noop"
+	rejectReports := true
+	updated, errWithCode := suite.processor.AdminDomainBlockUpdate(ctx, suite.authed(), block.ID, &apimodel.DomainBlockUpdateRequest{
+		Severity:      &severity,
+		RejectReports: &rejectReports,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("noop", updated.Severity)
+	suite.True(updated.RejectMedia)
+	suite.True(updated.RejectReports)
+
+	policy, err = suite.db.GetDomainPolicy(ctx, "silenced.example.org")
+	suite.NoError(err)
+	suite.False(policy.Silence)
+	suite.True(policy.RejectMedia)
+}
+
+func (suite *AdminDomainBlockTestSuite) TestExportCSV() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.processor.AdminDomainBlockCreate(ctx, suite.authed(), &apimodel.DomainBlockCreateRequest{
+		Domain:        "silenced.example.org",
+		Severity:      "silence",
+		PublicComment: "posts spam, sometimes",
+	})
+	suite.NoError(errWithCode)
+
+	content, errWithCode := suite.processor.AdminDomainBlocksExportCSV(ctx, suite.authed())
+	suite.NoError(errWithCode)
+	suite.Equal("text/csv", content.ContentType)
+
+	lines := strings.Split(strings.TrimSpace(string(content.Content)), "\n")
+	suite.Equal("#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate", lines[0])
+	suite.Contains(lines, "replyguys.com,suspend,false,false,reply-guying to tech posts,false")
+	suite.Contains(lines, "silenced.example.org,silence,false,false,\"posts spam, sometimes\",false")
+}
+
+func (suite *AdminDomainBlockTestSuite) TestImportCSV() {
+	ctx := context.Background()
+
+	csv := "domain,severity,reject_media,reject_reports,public_comment,obfuscate\n" +
+		"silenced.example.org,silence,true,false,rude,false\n" +
+		"\n" +
+		"noop.example.org,noop,false,true,,true\n"
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	fw, err := w.CreateFormFile("domains", "blocklist.csv")
+	suite.NoError(err)
+	_, err = fw.Write([]byte(csv))
+	suite.NoError(err)
+	suite.NoError(w.Close())
+
+	form, err := multipart.NewReader(buf, w.Boundary()).ReadForm(1 << 20)
+	suite.NoError(err)
+
+	blocks, errWithCode := suite.processor.AdminDomainBlocksImport(ctx, suite.authed(), &apimodel.DomainBlockCreateRequest{
+		Domains: form.File["domains"][0],
+	})
+	suite.NoError(errWithCode)
+	suite.Len(blocks, 2)
+
+	suite.Equal("silenced.example.org", blocks[0].Domain)
+	suite.Equal("silence", blocks[0].Severity)
+	suite.True(blocks[0].RejectMedia)
+	suite.Equal("rude", blocks[0].PublicComment)
+
+	suite.Equal("noop.example.org", blocks[1].Domain)
+	suite.Equal("noop", blocks[1].Severity)
+	suite.True(blocks[1].RejectReports)
+	suite.True(blocks[1].Obfuscate)
+}
+
+func TestAdminDomainBlockTestSuite(t *testing.T) {
+	suite.Run(t, &AdminDomainBlockTestSuite{})
+}
@@ -0,0 +1,171 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *processor) AdminModerationNoteCreate(ctx context.Context, authed *oauth.Auth, accountID string, form *apimodel.AdminModerationNoteCreateRequest) (*apimodel.AdminModerationNote, gtserror.WithCode) {
+	if err := validate.ModerationNote(form.Content); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	account, errWithCode := p.adminActionTarget(ctx, authed, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if account.Domain != "" {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("account %s is remote", account.ID), "only local accounts can have moderation notes")
+	}
+
+	var reportID string
+	if form.ReportID != "" {
+		report, errWithCode := p.adminLinkedReport(ctx, form.ReportID, account)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		reportID = report.ID
+	}
+
+	noteID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	note := &gtsmodel.ModerationNote{
+		ID:              noteID,
+		AccountID:       authed.Account.ID,
+		Account:         authed.Account,
+		TargetAccountID: account.ID,
+		TargetAccount:   account,
+		ReportID:        reportID,
+		Content:         text.RemoveHTML(form.Content),
+	}
+
+	if err := p.db.Put(ctx, note); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetModerationNote, note.ID, adminAuditAccountSummary(account))
+
+	mastoNote, err := p.tc.ModerationNoteToAdminMasto(ctx, note)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoNote, nil
+}
+
+func (p *processor) AdminModerationNoteDelete(ctx context.Context, authed *oauth.Auth, accountID string, noteID string) (*apimodel.AdminModerationNote, gtserror.WithCode) {
+	note := &gtsmodel.ModerationNote{}
+	if err := p.db.GetByID(ctx, noteID, note); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", noteID))
+	}
+
+	// make sure the note is looked up through the account it was written about
+	if note.TargetAccountID != accountID {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("note %s is not about account %s", note.ID, accountID))
+	}
+
+	// convert before deleting so that the note's accounts can still be fetched
+	mastoNote, err := p.tc.ModerationNoteToAdminMasto(ctx, note)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteByID(ctx, note.ID, note); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionDelete, gtsmodel.AdminAuditLogTargetModerationNote, note.ID, "")
+
+	return mastoNote, nil
+}
+
+// adminLinkedReport gets the report with the given id, making sure that it's
+// about the given account so that moderation records can refer back to it.
+func (p *processor) adminLinkedReport(ctx context.Context, reportID string, account *gtsmodel.Account) (*gtsmodel.Report, gtserror.WithCode) {
+	report, errWithCode := p.getAdminReport(ctx, reportID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if report.TargetAccountID != account.ID {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("report %s is not about account %s", report.ID, account.ID), "report is not about this account")
+	}
+
+	return report, nil
+}
+
+// createAccountWarning records that the given moderator gave the given local account a warning.
+func (p *processor) createAccountWarning(ctx context.Context, moderator *gtsmodel.Account, account *gtsmodel.Account, report *gtsmodel.Report, action gtsmodel.AccountWarningAction, warningText string, emailed bool) (*gtsmodel.AccountWarning, error) {
+	warningID, err := id.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	warning := &gtsmodel.AccountWarning{
+		ID:              warningID,
+		AccountID:       moderator.ID,
+		Account:         moderator,
+		TargetAccountID: account.ID,
+		TargetAccount:   account,
+		Action:          action,
+		Text:            warningText,
+		Emailed:         emailed,
+	}
+	if report != nil {
+		warning.ReportID = report.ID
+	}
+
+	if err := p.db.Put(ctx, warning); err != nil {
+		return nil, fmt.Errorf("error putting warning for account %s: %s", account.ID, err)
+	}
+
+	return warning, nil
+}
+
+// emailAccountWarning lets the owner of a local account know that it's been given a warning.
+func (p *processor) emailAccountWarning(user *gtsmodel.User, account *gtsmodel.Account, warning *gtsmodel.AccountWarning) {
+	data := email.WarningData{
+		Username:     account.Username,
+		InstanceHost: p.config.Host,
+		InstanceURL:  fmt.Sprintf("%s://%s", p.config.Protocol, p.config.Host),
+		Action:       string(warning.Action),
+		Text:         warning.Text,
+	}
+
+	if err := p.emailSender.SendAccountWarningEmail(user.Email, data); err != nil {
+		p.log.WithField("func", "emailAccountWarning").Errorf("error emailing warning %s: %s", warning.ID, err)
+	}
+}
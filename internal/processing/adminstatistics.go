@@ -0,0 +1,222 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// adminStatisticsDefaultDays is how many days of activity are counted if the request doesn't give a start.
+	adminStatisticsDefaultDays = 30
+	// adminStatisticsMaxDays is the longest period that can be counted in one request.
+	adminStatisticsMaxDays = 366
+	// adminDimensionDefaultLimit is how many items of each dimension are returned if the request doesn't say.
+	adminDimensionDefaultLimit = 10
+	// adminDimensionMaxLimit is the most items of each dimension that can be returned.
+	adminDimensionMaxLimit = 100
+)
+
+const (
+	// adminDimensionLanguages breaks down local statuses by the language they were written in.
+	adminDimensionLanguages = "languages"
+	// adminDimensionServers breaks down received statuses by the remote domain they came from.
+	adminDimensionServers = "servers"
+	// adminDimensionSoftwareVersions lists the versions of the software this instance is running on.
+	adminDimensionSoftwareVersions = "software_versions"
+)
+
+// adminMeasures are the measures that can be requested, by key.
+var adminMeasures = map[string]db.InstanceMeasure{
+	string(db.InstanceMeasureNewUsers):        db.InstanceMeasureNewUsers,
+	string(db.InstanceMeasureNewStatuses):     db.InstanceMeasureNewStatuses,
+	string(db.InstanceMeasureOpenedReports):   db.InstanceMeasureOpenedReports,
+	string(db.InstanceMeasureResolvedReports): db.InstanceMeasureResolvedReports,
+}
+
+func (p *processor) AdminMeasuresGet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminStatisticsRequest) ([]*apimodel.AdminMeasure, gtserror.WithCode) {
+	for _, key := range form.Keys {
+		if _, ok := adminMeasures[key]; !ok {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("unknown measure %s", key), fmt.Sprintf("unknown measure %s", key))
+		}
+	}
+
+	start, end, errWithCode := adminStatisticsPeriod(form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	days := int(end.Sub(start) / (24 * time.Hour))
+
+	measures := []*apimodel.AdminMeasure{}
+	for _, key := range form.Keys {
+		dayCounts, err := p.db.GetInstanceMeasureCounts(ctx, adminMeasures[key], start, end)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// the previous period is the same length as the requested one, and ends where it starts
+		previous, err := p.db.CountInstanceMeasure(ctx, adminMeasures[key], start.AddDate(0, 0, -days), start)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		counts := make(map[string]int, len(dayCounts))
+		total := 0
+		for _, dc := range dayCounts {
+			counts[dc.Day] = dc.Count
+			total += dc.Count
+		}
+
+		measure := &apimodel.AdminMeasure{
+			Key:           key,
+			Total:         strconv.Itoa(total),
+			PreviousTotal: strconv.Itoa(previous),
+			Data:          make([]apimodel.AdminMeasureData, 0, days),
+		}
+		for i := 0; i < days; i++ {
+			day := start.AddDate(0, 0, i)
+			measure.Data = append(measure.Data, apimodel.AdminMeasureData{
+				Date:  day.Format(time.RFC3339),
+				Value: strconv.Itoa(counts[day.Format("2006-01-02")]),
+			})
+		}
+		measures = append(measures, measure)
+	}
+
+	return measures, nil
+}
+
+func (p *processor) AdminDimensionsGet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminStatisticsRequest) ([]*apimodel.AdminDimension, gtserror.WithCode) {
+	for _, key := range form.Keys {
+		if key != adminDimensionLanguages && key != adminDimensionServers && key != adminDimensionSoftwareVersions {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("unknown dimension %s", key), fmt.Sprintf("unknown dimension %s", key))
+		}
+	}
+
+	start, end, errWithCode := adminStatisticsPeriod(form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	limit := form.Limit
+	if limit <= 0 {
+		limit = adminDimensionDefaultLimit
+	}
+	if limit > adminDimensionMaxLimit {
+		limit = adminDimensionMaxLimit
+	}
+
+	dimensions := []*apimodel.AdminDimension{}
+	for _, key := range form.Keys {
+		dimension := &apimodel.AdminDimension{
+			Key:  key,
+			Data: []apimodel.AdminDimensionData{},
+		}
+
+		switch key {
+		case adminDimensionLanguages:
+			counts, err := p.db.GetInstanceStatusLanguages(ctx, start, end, limit)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			for _, c := range counts {
+				dimension.Data = append(dimension.Data, apimodel.AdminDimensionData{
+					Key:      c.Language,
+					HumanKey: c.Language,
+					Value:    strconv.Itoa(c.Count),
+				})
+			}
+		case adminDimensionServers:
+			counts, err := p.db.GetInstanceStatusDomains(ctx, start, end, limit)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			for _, c := range counts {
+				dimension.Data = append(dimension.Data, apimodel.AdminDimensionData{
+					Key:      c.Domain,
+					HumanKey: c.Domain,
+					Value:    strconv.Itoa(c.Count),
+				})
+			}
+		case adminDimensionSoftwareVersions:
+			dimension.Data = append(dimension.Data,
+				apimodel.AdminDimensionData{Key: "gotosocial", HumanKey: "GoToSocial", Value: p.config.SoftwareVersion},
+				apimodel.AdminDimensionData{Key: "go", HumanKey: "Go", Value: runtime.Version()},
+				apimodel.AdminDimensionData{Key: "database", HumanKey: "Database", Value: p.config.DBConfig.Type},
+			)
+		}
+
+		dimensions = append(dimensions, dimension)
+	}
+
+	return dimensions, nil
+}
+
+// adminStatisticsPeriod works out the whole days, in UTC, that the given request asks for
+// statistics about. The returned end is exclusive: it's midnight at the end of the last day.
+func adminStatisticsPeriod(form *apimodel.AdminStatisticsRequest) (time.Time, time.Time, gtserror.WithCode) {
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	if form.EndAt != "" {
+		t, err := parseStatisticsDate(form.EndAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, gtserror.NewErrorBadRequest(err, "end_at must be a date or datetime")
+		}
+		end = t
+	}
+	end = end.AddDate(0, 0, 1)
+
+	start := end.AddDate(0, 0, -adminStatisticsDefaultDays)
+	if form.StartAt != "" {
+		t, err := parseStatisticsDate(form.StartAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, gtserror.NewErrorBadRequest(err, "start_at must be a date or datetime")
+		}
+		start = t
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, gtserror.NewErrorBadRequest(errors.New("start is after end"), "start_at must not be after end_at")
+	}
+	if end.Sub(start) > adminStatisticsMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, gtserror.NewErrorBadRequest(errors.New("period too long"), fmt.Sprintf("statistics can cover at most %d days", adminStatisticsMaxDays))
+	}
+
+	return start, end, nil
+}
+
+// parseStatisticsDate parses a date, or a datetime, into midnight UTC at the start of that day.
+func parseStatisticsDate(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return t.UTC().Truncate(24 * time.Hour), nil
+}
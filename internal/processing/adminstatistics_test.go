@@ -0,0 +1,126 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AdminStatisticsTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AdminStatisticsTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["admin_account"],
+		Account:     suite.testAccounts["admin_account"],
+	}
+}
+
+func (suite *AdminStatisticsTestSuite) TestMeasures() {
+	ctx := context.Background()
+	form := &apimodel.AdminStatisticsRequest{Keys: []string{"opened_reports", "new_users"}}
+
+	before, errWithCode := suite.processor.AdminMeasuresGet(ctx, suite.authed(), form)
+	suite.NoError(errWithCode)
+	suite.Len(before, 2)
+	suite.Equal("opened_reports", before[0].Key)
+	suite.Equal("new_users", before[1].Key)
+	suite.Len(before[0].Data, 30)
+
+	reporter := &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["local_account_2"],
+		Account:     suite.testAccounts["local_account_2"],
+	}
+	_, errWithCode = suite.processor.ReportCreate(ctx, reporter, &apimodel.ReportCreateRequest{AccountID: suite.testAccounts["remote_account_1"].ID})
+	suite.NoError(errWithCode)
+
+	// the new report is counted today, which is the last day of the default period
+	after, errWithCode := suite.processor.AdminMeasuresGet(ctx, suite.authed(), form)
+	suite.NoError(errWithCode)
+	suite.Equal(before[0].Data[29].Value, decrement(suite, after[0].Data[29].Value))
+	suite.Equal(before[0].Total, decrement(suite, after[0].Total))
+	suite.Equal(before[1].Total, after[1].Total)
+
+	_, errWithCode = suite.processor.AdminMeasuresGet(ctx, suite.authed(), &apimodel.AdminStatisticsRequest{Keys: []string{"interactions"}})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminStatisticsTestSuite) TestMeasuresPeriod() {
+	ctx := context.Background()
+
+	measures, errWithCode := suite.processor.AdminMeasuresGet(ctx, suite.authed(), &apimodel.AdminStatisticsRequest{
+		Keys:    []string{"new_statuses"},
+		StartAt: "2021-10-01",
+		EndAt:   "2021-10-07T12:00:00Z",
+	})
+	suite.NoError(errWithCode)
+	suite.Len(measures[0].Data, 7)
+	suite.Equal("2021-10-01T00:00:00Z", measures[0].Data[0].Date)
+	suite.Equal("2021-10-07T00:00:00Z", measures[0].Data[6].Date)
+
+	_, errWithCode = suite.processor.AdminMeasuresGet(ctx, suite.authed(), &apimodel.AdminStatisticsRequest{
+		Keys:    []string{"new_statuses"},
+		StartAt: "2021-10-07",
+		EndAt:   "2021-10-01",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AdminMeasuresGet(ctx, suite.authed(), &apimodel.AdminStatisticsRequest{
+		Keys:    []string{"new_statuses"},
+		StartAt: "2019-01-01",
+		EndAt:   "2021-10-01",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AdminStatisticsTestSuite) TestDimensions() {
+	ctx := context.Background()
+
+	dimensions, errWithCode := suite.processor.AdminDimensionsGet(ctx, suite.authed(), &apimodel.AdminStatisticsRequest{Keys: []string{"software_versions", "servers"}})
+	suite.NoError(errWithCode)
+	suite.Len(dimensions, 2)
+	suite.Equal("software_versions", dimensions[0].Key)
+	suite.Len(dimensions[0].Data, 3)
+	suite.Equal("servers", dimensions[1].Key)
+	suite.NotNil(dimensions[1].Data)
+
+	_, errWithCode = suite.processor.AdminDimensionsGet(ctx, suite.authed(), &apimodel.AdminStatisticsRequest{Keys: []string{"space_usage"}})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+// decrement returns the given integer string, minus one.
+func decrement(suite *AdminStatisticsTestSuite, s string) string {
+	i, err := strconv.Atoi(s)
+	suite.NoError(err)
+	return strconv.Itoa(i - 1)
+}
+
+func TestAdminStatisticsTestSuite(t *testing.T) {
+	suite.Run(t, &AdminStatisticsTestSuite{})
+}
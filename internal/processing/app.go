@@ -20,12 +20,19 @@ package processing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, error) {
@@ -36,6 +43,9 @@ func (p *processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 	} else {
 		scopes = form.Scopes
 	}
+	if err := oauth.ValidateScopes(scopes); err != nil {
+		return nil, err
+	}
 
 	// generate new IDs for this application and its associated client
 	clientID, err := id.NewRandomULID()
@@ -83,5 +93,91 @@ func (p *processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 		return nil, err
 	}
 
+	// give the app our vapid key so that it can subscribe to push notifications
+	vapidKey, err := p.webPushSender.VAPIDPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mastoApp.VapidKey = vapidKey
+
+	return mastoApp, nil
+}
+
+func (p *processor) AppVerifyCredentials(ctx context.Context, authed *oauth.Auth) (*apimodel.Application, gtserror.WithCode) {
+	if authed.Application == nil || authed.Application.ID == "" {
+		return nil, gtserror.NewErrorNotAuthorized(errors.New("no application found for token"), "the access token is invalid")
+	}
+
+	mastoApp, err := p.tc.AppToMastoPublic(ctx, authed.Application)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	vapidKey, err := p.webPushSender.VAPIDPublicKey(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	mastoApp.VapidKey = vapidKey
+
+	if authed.Token != nil {
+		mastoApp.Scopes = strings.Fields(authed.Token.GetScope())
+	}
+
 	return mastoApp, nil
 }
+
+func (p *processor) AppsAuthorizedGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AuthorizedApplication, gtserror.WithCode) {
+	tokens, err := p.db.GetTokensForUserID(ctx, authed.User.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting tokens for user: %s", err))
+	}
+
+	// tokens come newest first, so walk them backwards to get the
+	// time each application was first authorized by this user
+	authorized := []*apimodel.AuthorizedApplication{}
+	byClientID := make(map[string]*apimodel.AuthorizedApplication)
+	for i := len(tokens) - 1; i >= 0; i-- {
+		t := tokens[i]
+
+		a, ok := byClientID[t.ClientID]
+		if !ok {
+			app, err := p.db.GetApplicationByClientID(ctx, t.ClientID)
+			if err != nil {
+				if err == db.ErrNoEntries {
+					// the application was removed but the token lingers; nothing to show
+					continue
+				}
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting application for client %s: %s", t.ClientID, err))
+			}
+			a = &apimodel.AuthorizedApplication{
+				ID:        app.ID,
+				Name:      app.Name,
+				Website:   app.Website,
+				Scopes:    []string{},
+				CreatedAt: t.CreatedAt.Format(time.RFC3339),
+			}
+			byClientID[t.ClientID] = a
+			authorized = append(authorized, a)
+		}
+
+		a.Scopes = util.UniqueStrings(append(a.Scopes, strings.Fields(t.Scope)...))
+	}
+
+	return authorized, nil
+}
+
+func (p *processor) AppAuthorizedRevoke(ctx context.Context, authed *oauth.Auth, id string) gtserror.WithCode {
+	app := &gtsmodel.Application{}
+	if err := p.db.GetByID(ctx, id, app); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err)
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("error getting application %s: %s", id, err))
+	}
+
+	if err := p.db.DeleteTokensForClientIDAndUserID(ctx, app.ClientID, authed.User.ID); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error revoking tokens for application %s: %s", id, err))
+	}
+
+	return nil
+}
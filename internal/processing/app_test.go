@@ -0,0 +1,87 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AppTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AppTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Token:       oauth.DBTokenToToken(suite.testTokens[name]),
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+func (suite *AppTestSuite) TestAppVerifyCredentials() {
+	app, errWithCode := suite.processor.AppVerifyCredentials(context.Background(), suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+	suite.Equal("really cool gts application", app.Name)
+	suite.Equal([]string{"read", "write", "follow", "push"}, app.Scopes)
+	suite.Empty(app.ClientSecret)
+	suite.NotEmpty(app.VapidKey)
+}
+
+func (suite *AppTestSuite) TestAppsAuthorizedGet() {
+	apps, errWithCode := suite.processor.AppsAuthorizedGet(context.Background(), suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+	suite.Len(apps, 1)
+	suite.Equal(suite.testApplications["application_1"].ID, apps[0].ID)
+	suite.Equal([]string{"read", "write", "follow", "push"}, apps[0].Scopes)
+}
+
+func (suite *AppTestSuite) TestAppAuthorizedRevoke() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	errWithCode := suite.processor.AppAuthorizedRevoke(ctx, authed, suite.testApplications["application_1"].ID)
+	suite.NoError(errWithCode)
+
+	// no tokens should be left for the user
+	apps, errWithCode := suite.processor.AppsAuthorizedGet(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.Empty(apps)
+
+	// another user's tokens should be untouched
+	token := &gtsmodel.Token{}
+	err := suite.db.GetByID(ctx, suite.testTokens["local_account_2"].ID, token)
+	suite.NoError(err)
+}
+
+func (suite *AppTestSuite) TestAppAuthorizedRevokeNotFound() {
+	errWithCode := suite.processor.AppAuthorizedRevoke(context.Background(), suite.authed("local_account_1"), "01FXXXXXXXXXXXXXXXXXXXXXXX")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestAppTestSuite(t *testing.T) {
+	suite.Run(t, &AppTestSuite{})
+}
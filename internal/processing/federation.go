@@ -133,6 +133,71 @@ func (p *processor) GetFediFollowers(ctx context.Context, requestedUsername stri
 	return data, nil
 }
 
+func (p *processor) GetFediFollowersSynchronization(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode) {
+	// get the account the request is referring to
+	requestedAccount, err := p.db.GetLocalAccountByUsername(ctx, requestedUsername)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
+	}
+
+	// authenticate the request
+	requestingAccountURI, authenticated, err := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
+	if err != nil || !authenticated {
+		return nil, gtserror.NewErrorNotAuthorized(errors.New("not authorized"), "not authorized")
+	}
+
+	requestingAccount, _, err := p.federator.GetRemoteAccount(ctx, requestedUsername, requestingAccountURI, false)
+	if err != nil {
+		return nil, gtserror.NewErrorNotAuthorized(err)
+	}
+
+	blocked, err := p.db.IsBlocked(ctx, requestedAccount.ID, requestingAccount.ID, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("block exists between accounts %s and %s", requestedAccount.ID, requestingAccount.ID))
+	}
+
+	// only give the requester the followers on its own instance; the rest are none of its business
+	follows, err := p.db.GetAccountFollowersByDomain(ctx, requestedAccount.ID, requestingAccount.Domain)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching followers of account %s on %s: %s", requestedAccount.ID, requestingAccount.Domain, err))
+	}
+
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	collectionIDProp.SetIRI(&url.URL{
+		Scheme: p.config.Protocol,
+		Host:   p.config.Host,
+		Path:   requestURL.Path,
+	})
+	collection.SetJSONLDId(collectionIDProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(follows))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	orderedItemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, f := range follows {
+		followerURI, err := url.Parse(f.Account.URI)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error parsing url %s: %s", f.Account.URI, err))
+		}
+		orderedItemsProp.AppendIRI(followerURI)
+	}
+	collection.SetActivityStreamsOrderedItems(orderedItemsProp)
+
+	data, err := streams.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
+
 func (p *processor) GetFediFollowing(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode) {
 	// get the account the request is referring to
 	requestedAccount, err := p.db.GetLocalAccountByUsername(ctx, requestedUsername)
@@ -371,6 +436,140 @@ func (p *processor) GetFediStatusReplies(ctx context.Context, requestedUsername
 	return data, nil
 }
 
+func (p *processor) GetFediOutbox(ctx context.Context, requestedUsername string, page bool, maxID string, minID string, requestURL *url.URL) (interface{}, gtserror.WithCode) {
+	// get the account the request is referring to
+	requestedAccount, err := p.db.GetLocalAccountByUsername(ctx, requestedUsername)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
+	}
+
+	// authenticate the request
+	requestingAccountURI, authenticated, err := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
+	if err != nil || !authenticated {
+		return nil, gtserror.NewErrorNotAuthorized(errors.New("not authorized"), "not authorized")
+	}
+
+	requestingAccount, _, err := p.federator.GetRemoteAccount(ctx, requestedUsername, requestingAccountURI, false)
+	if err != nil {
+		return nil, gtserror.NewErrorNotAuthorized(err)
+	}
+
+	// authorize the request:
+	// 1. check if a block exists between the requester and the requestee
+	blocked, err := p.db.IsBlocked(ctx, requestedAccount.ID, requestingAccount.ID, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("block exists between accounts %s and %s", requestedAccount.ID, requestingAccount.ID))
+	}
+
+	var data map[string]interface{}
+
+	// now there are two scenarios:
+	// 1. we're asked for the whole collection and not a page -- we can just return the collection, with no items, but a link to 'first' page.
+	// 2. we're asked for a page, with max_id and/or min_id optionally set -- so we need to return some actual items!
+
+	if !page {
+		// scenario 1
+		collection, err := p.tc.OutboxToASCollection(ctx, requestedAccount)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		data, err = streams.Serialize(collection)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		return data, nil
+	}
+
+	// scenario 2
+	statuses, err := p.db.GetAccountOutboxStatuses(ctx, requestedAccount.ID, 20, maxID, minID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// only show statuses that the requester can see
+	visibleStatuses := []*gtsmodel.Status{}
+	for _, s := range statuses {
+		visible, err := p.filter.StatusVisible(ctx, s, requestingAccount)
+		if err != nil || !visible {
+			continue
+		}
+		visibleStatuses = append(visibleStatuses, s)
+	}
+
+	outboxPage, err := p.tc.StatusesToASOutboxPage(ctx, requestedAccount, maxID, minID, visibleStatuses)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err = streams.Serialize(outboxPage)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
+
+func (p *processor) GetFediFeaturedCollection(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode) {
+	// get the account the request is referring to
+	requestedAccount, err := p.db.GetLocalAccountByUsername(ctx, requestedUsername)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
+	}
+
+	// authenticate the request
+	requestingAccountURI, authenticated, err := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
+	if err != nil || !authenticated {
+		return nil, gtserror.NewErrorNotAuthorized(errors.New("not authorized"), "not authorized")
+	}
+
+	requestingAccount, _, err := p.federator.GetRemoteAccount(ctx, requestedUsername, requestingAccountURI, false)
+	if err != nil {
+		return nil, gtserror.NewErrorNotAuthorized(err)
+	}
+
+	blocked, err := p.db.IsBlocked(ctx, requestedAccount.ID, requestingAccount.ID, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("block exists between accounts %s and %s", requestedAccount.ID, requestingAccount.ID))
+	}
+
+	pinned, err := p.db.GetAccountStatuses(ctx, requestedAccount.ID, 0, false, false, "", "", "", true, false, "")
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// only show pinned statuses that the requester can see
+	visiblePinned := []*gtsmodel.Status{}
+	for _, s := range pinned {
+		visible, err := p.filter.StatusVisible(ctx, s, requestingAccount)
+		if err != nil || !visible {
+			continue
+		}
+		visiblePinned = append(visiblePinned, s)
+	}
+
+	collection, err := p.tc.StatusesToASFeaturedCollection(ctx, requestedAccount, visiblePinned)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := streams.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
+
 func (p *processor) GetWebfingerAccount(ctx context.Context, requestedUsername string) (*apimodel.WellKnownResponse, gtserror.WithCode) {
 	// get the account the request is referring to
 	requestedAccount, err := p.db.GetLocalAccountByUsername(ctx, requestedUsername)
@@ -400,6 +599,19 @@ func (p *processor) GetWebfingerAccount(ctx context.Context, requestedUsername s
 	}, nil
 }
 
+func (p *processor) GetHostMeta(ctx context.Context) (*apimodel.HostMeta, gtserror.WithCode) {
+	return &apimodel.HostMeta{
+		XMLNS: "http://docs.oasis-open.org/ns/xri/xrd-1.0",
+		Links: []apimodel.Link{
+			{
+				Rel:      "lrdd",
+				Type:     "application/jrd+json",
+				Template: fmt.Sprintf("%s://%s/.well-known/webfinger?resource={uri}", p.config.Protocol, p.config.Host),
+			},
+		},
+	}, nil
+}
+
 func (p *processor) GetNodeInfoRel(ctx context.Context, request *http.Request) (*apimodel.WellKnownResponse, gtserror.WithCode) {
 	return &apimodel.WellKnownResponse{
 		Links: []apimodel.Link{
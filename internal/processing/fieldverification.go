@@ -0,0 +1,155 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	// fieldVerificationInterval is how often the URL profile fields of local accounts are verified again,
+	// so that verification is dropped once a page no longer links back to the profile.
+	fieldVerificationInterval = 24 * time.Hour
+	// fieldVerificationBatchSize is how many local accounts to select for re-verification at a time.
+	fieldVerificationBatchSize = 100
+)
+
+// verifyAccountFields checks each URL profile field of the given local account for a rel="me" link back
+// to the account, and stores the results. Fields that are already verified are only checked again if
+// reverify is true. Pages that can't be fetched leave a field's verification as it is.
+func (p *processor) verifyAccountFields(ctx context.Context, accountID string, reverify bool) error {
+	// get a fresh copy of the account so we don't overwrite any changes made in the meantime
+	account, err := p.db.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("verifyAccountFields: error getting account %s: %s", accountID, err)
+	}
+
+	if account.Domain != "" || len(account.Fields) == 0 {
+		return nil
+	}
+
+	fields := make([]gtsmodel.Field, len(account.Fields))
+	copy(fields, account.Fields)
+
+	changed := false
+	for i, field := range fields {
+		// values are stored with html escaped
+		value := html.UnescapeString(field.Value)
+		if !isHTTPURL(value) {
+			if !field.VerifiedAt.IsZero() {
+				fields[i].VerifiedAt = time.Time{}
+				changed = true
+			}
+			continue
+		}
+
+		if !field.VerifiedAt.IsZero() && !reverify {
+			continue
+		}
+
+		verified, err := p.fieldVerifier.Verify(ctx, value, account.URL, account.URI)
+		if err != nil {
+			p.log.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"func":      "verifyAccountFields",
+				"accountID": account.ID,
+			}).Debug("could not verify profile field")
+			continue
+		}
+
+		switch {
+		case verified && field.VerifiedAt.IsZero():
+			fields[i].VerifiedAt = time.Now()
+			changed = true
+		case !verified && !field.VerifiedAt.IsZero():
+			fields[i].VerifiedAt = time.Time{}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	account.Fields = fields
+	if _, err := p.db.UpdateAccount(ctx, account); err != nil {
+		return fmt.Errorf("verifyAccountFields: error updating account %s: %s", account.ID, err)
+	}
+
+	return nil
+}
+
+// sweepFieldVerifications periodically verifies the URL profile fields of all local accounts again, until the processor is stopped.
+func (p *processor) sweepFieldVerifications(ctx context.Context) {
+	ticker := time.NewTicker(fieldVerificationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.reverifyAccountFields(ctx)
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// reverifyAccountFields works through all local accounts in batches, verifying their URL profile fields again.
+func (p *processor) reverifyAccountFields(ctx context.Context) {
+	l := p.log.WithContext(ctx).WithField("func", "reverifyAccountFields")
+
+	sinceID := ""
+	for {
+		accounts, err := p.db.GetLocalAccounts(ctx, sinceID, fieldVerificationBatchSize)
+		if err != nil && err != db.ErrNoEntries {
+			l.WithError(err).Error("error getting local accounts")
+			return
+		}
+		if len(accounts) == 0 {
+			return
+		}
+
+		for _, account := range accounts {
+			if len(account.Fields) == 0 {
+				continue
+			}
+			if err := p.verifyAccountFields(ctx, account.ID, true); err != nil {
+				l.WithError(err).WithField("accountID", account.ID).Error("error verifying profile fields")
+			}
+		}
+
+		sinceID = accounts[len(accounts)-1].ID
+	}
+}
+
+// isHTTPURL returns true if the given string is an absolute http or https URL.
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,95 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type FieldVerificationTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *FieldVerificationTestSuite) TestVerifyFieldsOnUpdate() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/linked", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, `<html><body><a rel="me" href="%s">me on the fediverse</a></body></html>`, account.URL)
+	})
+	mux.HandleFunc("/unlinked", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, `<html><body><a href="%s">someone on the fediverse</a></body></html>`, account.URL)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	linkedName := "linked"
+	linkedValue := server.URL + "/linked"
+	unlinkedName := "unlinked"
+	unlinkedValue := server.URL + "/unlinked"
+	authed := &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["local_account_1"],
+		Account:     account,
+	}
+
+	apiAccount, err := suite.processor.AccountUpdate(ctx, authed, &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &[]apimodel.UpdateField{
+			{Name: &linkedName, Value: &linkedValue},
+			{Name: &unlinkedName, Value: &unlinkedValue},
+		},
+	})
+	suite.NoError(err)
+	suite.Len(apiAccount.Fields, 2)
+
+	// only the page that links back should be verified, once the update has been processed
+	suite.Eventually(func() bool {
+		dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+		if err != nil || len(dbAccount.Fields) != 2 {
+			return false
+		}
+		return !dbAccount.Fields[0].VerifiedAt.IsZero()
+	}, 5*time.Second, 100*time.Millisecond)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, account.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.Fields[1].VerifiedAt.IsZero())
+
+	// the verification time should be exposed through the api
+	apiAccount, errWithCode := suite.processor.AccountGet(ctx, authed, account.ID)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(apiAccount.Fields[0].VerifiedAt)
+	suite.Empty(apiAccount.Fields[1].VerifiedAt)
+}
+
+func TestFieldVerificationTestSuite(t *testing.T) {
+	suite.Run(t, new(FieldVerificationTestSuite))
+}
@@ -0,0 +1,501 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// getOwnFilter returns the filter with the given ID, if it's owned by the given account.
+func (p *processor) getOwnFilter(ctx context.Context, account *gtsmodel.Account, filterID string) (*gtsmodel.Filter, gtserror.WithCode) {
+	filter, err := p.db.GetFilterByID(ctx, filterID)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no filter with id %s", filterID))
+	}
+
+	if filter.AccountID != account.ID {
+		// don't let on that the filter exists
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("filter %s is not owned by account %s", filterID, account.ID))
+	}
+
+	return filter, nil
+}
+
+// getOwnFilterKeyword returns the filter keyword with the given ID, if it's owned by the given account.
+func (p *processor) getOwnFilterKeyword(ctx context.Context, account *gtsmodel.Account, keywordID string) (*gtsmodel.FilterKeyword, gtserror.WithCode) {
+	keyword, err := p.db.GetFilterKeywordByID(ctx, keywordID)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no filter keyword with id %s", keywordID))
+	}
+
+	if keyword.AccountID != account.ID {
+		// don't let on that the keyword exists
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("filter keyword %s is not owned by account %s", keywordID, account.ID))
+	}
+
+	return keyword, nil
+}
+
+func (p *processor) FiltersGetV1(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Filter, gtserror.WithCode) {
+	filters, err := p.db.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// version 1 of the api only knows about keywords, so flatten the filters
+	mastoFilters := []*apimodel.Filter{}
+	for _, filter := range filters {
+		for _, keyword := range filter.Keywords {
+			keyword.Filter = filter
+			mastoFilter, err := p.tc.FilterToMastoV1(ctx, keyword)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			mastoFilters = append(mastoFilters, mastoFilter)
+		}
+	}
+
+	return mastoFilters, nil
+}
+
+func (p *processor) FilterGetV1(ctx context.Context, authed *oauth.Auth, keywordID string) (*apimodel.Filter, gtserror.WithCode) {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed.Account, keywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoFilter, err := p.tc.FilterToMastoV1(ctx, keyword)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoFilter, nil
+}
+
+func (p *processor) FilterCreateV1(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterCreateUpdateRequestV1) (*apimodel.Filter, gtserror.WithCode) {
+	if errWithCode := validateFilterFormV1(form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	keywordID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	filter := &gtsmodel.Filter{
+		ID:        filterID,
+		AccountID: authed.Account.ID,
+		Title:     text.RemoveHTML(form.Phrase),
+	}
+	applyFilterFormV1(filter, form)
+
+	keyword := &gtsmodel.FilterKeyword{
+		ID:        keywordID,
+		AccountID: authed.Account.ID,
+		FilterID:  filterID,
+		Filter:    filter,
+		Keyword:   form.Phrase,
+		WholeWord: form.WholeWord,
+	}
+	filter.Keywords = []*gtsmodel.FilterKeyword{keyword}
+
+	if err := p.db.PutFilter(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoFilter, err := p.tc.FilterToMastoV1(ctx, keyword)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoFilter, nil
+}
+
+func (p *processor) FilterUpdateV1(ctx context.Context, authed *oauth.Auth, keywordID string, form *apimodel.FilterCreateUpdateRequestV1) (*apimodel.Filter, gtserror.WithCode) {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed.Account, keywordID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := validateFilterFormV1(form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filter, errWithCode := p.getOwnFilter(ctx, authed.Account, keyword.FilterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if len(filter.Keywords) == 1 {
+		// the filter is just this keyword, so keep the title in step with it
+		filter.Title = text.RemoveHTML(form.Phrase)
+	}
+	applyFilterFormV1(filter, form)
+	filter.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	keyword.Keyword = form.Phrase
+	keyword.WholeWord = form.WholeWord
+	keyword.UpdatedAt = time.Now()
+	keyword.Filter = filter
+	if err := p.db.UpdateByPrimaryKey(ctx, keyword); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoFilter, err := p.tc.FilterToMastoV1(ctx, keyword)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoFilter, nil
+}
+
+func (p *processor) FilterDeleteV1(ctx context.Context, authed *oauth.Auth, keywordID string) gtserror.WithCode {
+	keyword, errWithCode := p.getOwnFilterKeyword(ctx, authed.Account, keywordID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	filter, errWithCode := p.getOwnFilter(ctx, authed.Account, keyword.FilterID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if len(filter.Keywords) <= 1 {
+		// this is the last keyword of the filter, so the filter can go too
+		if err := p.db.DeleteFilterByID(ctx, filter.ID); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+		return nil
+	}
+
+	if err := p.db.DeleteByID(ctx, keyword.ID, &gtsmodel.FilterKeyword{}); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) FiltersGetV2(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FilterV2, gtserror.WithCode) {
+	filters, err := p.db.GetFiltersForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoFilters := make([]*apimodel.FilterV2, 0, len(filters))
+	for _, filter := range filters {
+		mastoFilter, err := p.tc.FilterToMastoV2(ctx, filter)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoFilters = append(mastoFilters, mastoFilter)
+	}
+
+	return mastoFilters, nil
+}
+
+func (p *processor) FilterGetV2(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.FilterV2, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnFilter(ctx, authed.Account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoFilter, err := p.tc.FilterToMastoV2(ctx, filter)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoFilter, nil
+}
+
+func (p *processor) FilterCreateV2(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterCreateUpdateRequestV2) (*apimodel.FilterV2, gtserror.WithCode) {
+	if form.FilterAction == "" {
+		form.FilterAction = string(gtsmodel.FilterActionWarn)
+	}
+
+	if err := validate.FilterTitle(form.Title); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.FilterContexts(form.Context); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if errWithCode := validateFilterFormV2(form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	filterID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	filter := &gtsmodel.Filter{
+		ID:        filterID,
+		AccountID: authed.Account.ID,
+		Title:     text.RemoveHTML(form.Title),
+		Action:    gtsmodel.FilterAction(form.FilterAction),
+		Keywords:  []*gtsmodel.FilterKeyword{},
+	}
+	setFilterContexts(filter, form.Context)
+	if form.ExpiresIn != nil && *form.ExpiresIn > 0 {
+		filter.ExpiresAt = time.Now().Add(time.Duration(*form.ExpiresIn) * time.Second)
+	}
+
+	for _, k := range form.KeywordsAttributes {
+		if k.Destroy {
+			continue
+		}
+
+		keywordID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		filter.Keywords = append(filter.Keywords, &gtsmodel.FilterKeyword{
+			ID:        keywordID,
+			AccountID: authed.Account.ID,
+			FilterID:  filterID,
+			Keyword:   k.Keyword,
+			WholeWord: k.WholeWord,
+		})
+	}
+
+	if err := p.db.PutFilter(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoFilter, err := p.tc.FilterToMastoV2(ctx, filter)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoFilter, nil
+}
+
+func (p *processor) FilterUpdateV2(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterCreateUpdateRequestV2) (*apimodel.FilterV2, gtserror.WithCode) {
+	filter, errWithCode := p.getOwnFilter(ctx, authed.Account, filterID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := validateFilterFormV2(form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// only change the fields that were actually given
+	if form.Title != "" {
+		if err := validate.FilterTitle(form.Title); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		filter.Title = text.RemoveHTML(form.Title)
+	}
+
+	if len(form.Context) != 0 {
+		if err := validate.FilterContexts(form.Context); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		setFilterContexts(filter, form.Context)
+	}
+
+	if form.FilterAction != "" {
+		filter.Action = gtsmodel.FilterAction(form.FilterAction)
+	}
+
+	if form.ExpiresIn != nil {
+		if *form.ExpiresIn > 0 {
+			filter.ExpiresAt = time.Now().Add(time.Duration(*form.ExpiresIn) * time.Second)
+		} else {
+			filter.ExpiresAt = time.Time{}
+		}
+	}
+
+	// make sure all the keywords being changed actually belong to this filter before changing anything
+	existing := make(map[string]*gtsmodel.FilterKeyword, len(filter.Keywords))
+	for _, k := range filter.Keywords {
+		existing[k.ID] = k
+	}
+	for _, k := range form.KeywordsAttributes {
+		if k.ID == "" {
+			continue
+		}
+		if _, ok := existing[k.ID]; !ok {
+			err := fmt.Errorf("keyword %s is not part of filter %s", k.ID, filter.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	filter.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, filter); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, k := range form.KeywordsAttributes {
+		switch {
+		case k.ID != "" && k.Destroy:
+			if err := p.db.DeleteByID(ctx, k.ID, &gtsmodel.FilterKeyword{}); err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		case k.ID != "":
+			keyword := existing[k.ID]
+			keyword.Keyword = k.Keyword
+			keyword.WholeWord = k.WholeWord
+			keyword.UpdatedAt = time.Now()
+			if err := p.db.UpdateByPrimaryKey(ctx, keyword); err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		case !k.Destroy:
+			keywordID, err := id.NewULID()
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			if err := p.db.Put(ctx, &gtsmodel.FilterKeyword{
+				ID:        keywordID,
+				AccountID: authed.Account.ID,
+				FilterID:  filter.ID,
+				Keyword:   k.Keyword,
+				WholeWord: k.WholeWord,
+			}); err != nil {
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+	}
+
+	// fetch the filter again to get the new set of keywords
+	return p.FilterGetV2(ctx, authed, filter.ID)
+}
+
+func (p *processor) FilterDeleteV2(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode {
+	filter, errWithCode := p.getOwnFilter(ctx, authed.Account, filterID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteFilterByID(ctx, filter.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// applyFilterFormV1 sets the action, contexts, and expiry of the given filter from the given version 1 form.
+func applyFilterFormV1(filter *gtsmodel.Filter, form *apimodel.FilterCreateUpdateRequestV1) {
+	filter.Action = gtsmodel.FilterActionWarn
+	if form.Irreversible {
+		filter.Action = gtsmodel.FilterActionHide
+	}
+
+	setFilterContexts(filter, form.Context)
+
+	filter.ExpiresAt = time.Time{}
+	if form.ExpiresIn > 0 {
+		filter.ExpiresAt = time.Now().Add(time.Duration(form.ExpiresIn) * time.Second)
+	}
+}
+
+// setFilterContexts sets the contexts of the given filter to exactly the given contexts.
+func setFilterContexts(filter *gtsmodel.Filter, contexts []string) {
+	filter.ContextHome = false
+	filter.ContextNotifications = false
+	filter.ContextPublic = false
+	filter.ContextThread = false
+	filter.ContextAccount = false
+
+	for _, c := range contexts {
+		switch gtsmodel.FilterContext(c) {
+		case gtsmodel.FilterContextHome:
+			filter.ContextHome = true
+		case gtsmodel.FilterContextNotifications:
+			filter.ContextNotifications = true
+		case gtsmodel.FilterContextPublic:
+			filter.ContextPublic = true
+		case gtsmodel.FilterContextThread:
+			filter.ContextThread = true
+		case gtsmodel.FilterContextAccount:
+			filter.ContextAccount = true
+		}
+	}
+}
+
+func validateFilterFormV1(form *apimodel.FilterCreateUpdateRequestV1) gtserror.WithCode {
+	if err := validate.FilterKeyword(form.Phrase); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.FilterContexts(form.Context); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.ExpiresIn < 0 {
+		err := errors.New("expires_in must not be negative")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return nil
+}
+
+// validateFilterFormV2 validates the parts of the given version 2 form that are checked the same way on create and update.
+func validateFilterFormV2(form *apimodel.FilterCreateUpdateRequestV2) gtserror.WithCode {
+	if form.FilterAction != "" {
+		if err := validate.FilterAction(form.FilterAction); err != nil {
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if form.ExpiresIn != nil && *form.ExpiresIn < 0 {
+		err := errors.New("expires_in must not be negative")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	for _, k := range form.KeywordsAttributes {
+		if k.Destroy {
+			continue
+		}
+		if err := validate.FilterKeyword(k.Keyword); err != nil {
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type FilterTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *FilterTestSuite) TestCreateUpdateDeleteFilterV1() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	filter, errWithCode := suite.processor.FilterCreateV1(ctx, authed, &apimodel.FilterCreateUpdateRequestV1{
+		Phrase:    "turtles",
+		Context:   []string{"home", "public"},
+		WholeWord: true,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("turtles", filter.Phrase)
+	suite.Equal([]string{"home", "public"}, filter.Context)
+	suite.True(filter.WholeWord)
+	suite.False(filter.Irreversible)
+
+	filter, errWithCode = suite.processor.FilterUpdateV1(ctx, authed, filter.ID, &apimodel.FilterCreateUpdateRequestV1{
+		Phrase:       "tortoises",
+		Context:      []string{"thread"},
+		Irreversible: true,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("tortoises", filter.Phrase)
+	suite.Equal([]string{"thread"}, filter.Context)
+	suite.False(filter.WholeWord)
+	suite.True(filter.Irreversible)
+
+	// the version 2 api should see the same filter, with the keyword in it
+	filtersV2, errWithCode := suite.processor.FiltersGetV2(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.Len(filtersV2, 1)
+	suite.Equal("tortoises", filtersV2[0].Title)
+	suite.Equal("hide", filtersV2[0].FilterAction)
+	suite.Len(filtersV2[0].Keywords, 1)
+	suite.Equal(filter.ID, filtersV2[0].Keywords[0].ID)
+
+	suite.NoError(suite.processor.FilterDeleteV1(ctx, authed, filter.ID))
+
+	// deleting the last keyword removes the whole filter
+	filtersV2, errWithCode = suite.processor.FiltersGetV2(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.Empty(filtersV2)
+}
+
+func (suite *FilterTestSuite) TestUpdateFilterV2Keywords() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	filter, errWithCode := suite.processor.FilterCreateV2(ctx, authed, &apimodel.FilterCreateUpdateRequestV2{
+		Title:   "reptiles",
+		Context: []string{"home"},
+		KeywordsAttributes: []apimodel.FilterKeywordCreateUpdateRequest{
+			{Keyword: "turtles"},
+			{Keyword: "snakes", WholeWord: true},
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("warn", filter.FilterAction)
+	suite.Len(filter.Keywords, 2)
+
+	filter, errWithCode = suite.processor.FilterUpdateV2(ctx, authed, filter.ID, &apimodel.FilterCreateUpdateRequestV2{
+		KeywordsAttributes: []apimodel.FilterKeywordCreateUpdateRequest{
+			{ID: filter.Keywords[0].ID, Destroy: true},
+			{ID: filter.Keywords[1].ID, Keyword: "lizards"},
+			{Keyword: "frogs"},
+		},
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("reptiles", filter.Title)
+	suite.Equal([]string{"home"}, filter.Context)
+	if suite.Len(filter.Keywords, 2) {
+		suite.Equal("lizards", filter.Keywords[0].Keyword)
+		suite.False(filter.Keywords[0].WholeWord)
+		suite.Equal("frogs", filter.Keywords[1].Keyword)
+	}
+
+	// keywords of other filters can't be changed through this one
+	_, errWithCode = suite.processor.FilterUpdateV2(ctx, authed, filter.ID, &apimodel.FilterCreateUpdateRequestV2{
+		KeywordsAttributes: []apimodel.FilterKeywordCreateUpdateRequest{
+			{ID: "01FBW21XJA09XYX51KV5JVBW0F", Keyword: "toads"},
+		},
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *FilterTestSuite) TestFilterHomeTimeline() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	turtleStatusID := suite.testStatuses["local_account_2_status_1"].ID
+
+	// a warn filter should mark the status
+	filter, errWithCode := suite.processor.FilterCreateV2(ctx, authed, &apimodel.FilterCreateUpdateRequestV2{
+		Title:   "reptiles",
+		Context: []string{"home"},
+		KeywordsAttributes: []apimodel.FilterKeywordCreateUpdateRequest{
+			{Keyword: "Turtles", WholeWord: true},
+		},
+	})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode := suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	var found bool
+	for _, s := range resp.Statuses {
+		if s.ID == turtleStatusID {
+			found = true
+			if suite.Len(s.Filtered, 1) {
+				suite.Equal(filter.ID, s.Filtered[0].Filter.ID)
+				suite.Equal([]string{"Turtles"}, s.Filtered[0].KeywordMatches)
+			}
+		} else {
+			suite.Empty(s.Filtered)
+		}
+	}
+	suite.True(found)
+
+	// a hide filter should drop it
+	_, errWithCode = suite.processor.FilterUpdateV2(ctx, authed, filter.ID, &apimodel.FilterCreateUpdateRequestV2{
+		FilterAction: "hide",
+	})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Statuses)
+	for _, s := range resp.Statuses {
+		suite.NotEqual(turtleStatusID, s.ID)
+	}
+
+	// whole word filters shouldn't match inside other words
+	_, errWithCode = suite.processor.FilterUpdateV2(ctx, authed, filter.ID, &apimodel.FilterCreateUpdateRequestV2{
+		KeywordsAttributes: []apimodel.FilterKeywordCreateUpdateRequest{
+			{Keyword: "turtle", WholeWord: true},
+			{ID: filter.Keywords[0].ID, Destroy: true},
+		},
+	})
+	suite.NoError(errWithCode)
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	found = false
+	for _, s := range resp.Statuses {
+		if s.ID == turtleStatusID {
+			found = true
+		}
+	}
+	suite.True(found)
+}
+
+func TestFilterTestSuite(t *testing.T) {
+	suite.Run(t, &FilterTestSuite{})
+}
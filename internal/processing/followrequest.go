@@ -20,24 +20,32 @@ package processing
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
-func (p *processor) FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]apimodel.Account, gtserror.WithCode) {
-	frs, err := p.db.GetAccountFollowRequests(ctx, auth.Account.ID)
+func (p *processor) FollowRequestsGet(ctx context.Context, auth *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.FollowRequestsResponse, gtserror.WithCode) {
+	frs, nextMaxID, prevMinID, err := p.db.GetAccountFollowRequests(ctx, auth.Account.ID, maxID, sinceID, minID, limit)
 	if err != nil {
-		if err != db.ErrNoEntries {
-			return nil, gtserror.NewErrorInternalError(err)
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.FollowRequestsResponse{
+				Accounts: []*apimodel.Account{},
+			}, nil
 		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	accts := []apimodel.Account{}
+	accts := []*apimodel.Account{}
 	for _, fr := range frs {
 		if fr.Account == nil {
 			frAcct, err := p.db.GetAccountByID(ctx, fr.AccountID)
@@ -51,9 +59,39 @@ func (p *processor) FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]
 		if err != nil {
 			return nil, gtserror.NewErrorInternalError(err)
 		}
-		accts = append(accts, *mastoAcct)
+		accts = append(accts, mastoAcct)
+	}
+
+	return p.packageFollowRequestsResponse(accts, "/api/v1/follow_requests", nextMaxID, prevMinID, limit)
+}
+
+func (p *processor) packageFollowRequestsResponse(accounts []*apimodel.Account, path string, nextMaxID string, prevMinID string, limit int) (*apimodel.FollowRequestsResponse, gtserror.WithCode) {
+	resp := &apimodel.FollowRequestsResponse{
+		Accounts: []*apimodel.Account{},
 	}
-	return accts, nil
+	resp.Accounts = accounts
+
+	// prepare the next and previous links
+	if len(accounts) != 0 {
+		nextLink := &url.URL{
+			Scheme:   p.config.Protocol,
+			Host:     p.config.Host,
+			Path:     path,
+			RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, nextMaxID),
+		}
+		next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+		prevLink := &url.URL{
+			Scheme:   p.config.Protocol,
+			Host:     p.config.Host,
+			Path:     path,
+			RawQuery: fmt.Sprintf("limit=%d&min_id=%s", limit, prevMinID),
+		}
+		prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+		resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+	}
+
+	return resp, nil
 }
 
 func (p *processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
@@ -79,6 +117,7 @@ func (p *processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, a
 	}
 
 	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
 		APObjectType:   ap.ActivityFollow,
 		APActivityType: ap.ActivityAccept,
 		GTSModel:       follow,
@@ -99,6 +138,49 @@ func (p *processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, a
 	return r, nil
 }
 
-func (p *processor) FollowRequestDeny(ctx context.Context, auth *oauth.Auth) gtserror.WithCode {
-	return nil
+func (p *processor) FollowRequestReject(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	followRequest, err := p.db.RejectFollowRequest(ctx, accountID, auth.Account.ID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if followRequest.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, followRequest.AccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		followRequest.Account = a
+	}
+
+	if followRequest.TargetAccount == nil {
+		a, err := p.db.GetAccountByID(ctx, followRequest.TargetAccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		followRequest.TargetAccount = a
+	}
+
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       followRequest,
+		OriginAccount:  followRequest.Account,
+		TargetAccount:  followRequest.TargetAccount,
+	}
+
+	gtsR, err := p.db.GetRelationship(ctx, auth.Account.ID, accountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	r, err := p.tc.RelationshipToMasto(ctx, gtsR)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return r, nil
 }
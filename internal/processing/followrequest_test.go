@@ -0,0 +1,110 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type FollowRequestTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *FollowRequestTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["local_account_1"],
+		User:        suite.testUsers["local_account_1"],
+		Account:     suite.testAccounts["local_account_1"],
+	}
+}
+
+func (suite *FollowRequestTestSuite) putFollowRequest(id string, requestingAccount *gtsmodel.Account) *gtsmodel.FollowRequest {
+	fr := &gtsmodel.FollowRequest{
+		ID:              id,
+		URI:             fmt.Sprintf("%s/follow/%s", requestingAccount.URI, id),
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: suite.testAccounts["local_account_1"].ID,
+		ShowReblogs:     true,
+	}
+	suite.NoError(suite.db.Put(context.Background(), fr))
+	return fr
+}
+
+func (suite *FollowRequestTestSuite) TestFollowRequestsGetPaged() {
+	ctx := context.Background()
+	older := suite.putFollowRequest("01FGRJH4ZHPK2M1WXK1Q1QE2R6", suite.testAccounts["local_account_2"])
+	newer := suite.putFollowRequest("01FGRJHCXS1ZF4Z4YMCXVVN3TX", suite.testAccounts["remote_account_1"])
+
+	resp, errWithCode := suite.processor.FollowRequestsGet(ctx, suite.authed(), "", "", "", 1)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Accounts, 1)
+	suite.Equal(newer.AccountID, resp.Accounts[0].ID)
+	suite.Equal(`<http://localhost:8080/api/v1/follow_requests?limit=1&max_id=01FGRJHCXS1ZF4Z4YMCXVVN3TX>; rel="next", <http://localhost:8080/api/v1/follow_requests?limit=1&min_id=01FGRJHCXS1ZF4Z4YMCXVVN3TX>; rel="prev"`, resp.LinkHeader)
+
+	resp, errWithCode = suite.processor.FollowRequestsGet(ctx, suite.authed(), newer.ID, "", "", 1)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Accounts, 1)
+	suite.Equal(older.AccountID, resp.Accounts[0].ID)
+
+	// nothing left after the last page
+	resp, errWithCode = suite.processor.FollowRequestsGet(ctx, suite.authed(), older.ID, "", "", 1)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Accounts)
+	suite.Empty(resp.LinkHeader)
+}
+
+func (suite *FollowRequestTestSuite) TestFollowRequestReject() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_2"]
+	suite.putFollowRequest("01FGRJH4ZHPK2M1WXK1Q1QE2R6", requestingAccount)
+
+	relationship, errWithCode := suite.processor.FollowRequestReject(ctx, suite.authed(), requestingAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.FollowedBy)
+
+	// the request is gone
+	_, _, _, err := suite.db.GetAccountFollowRequests(ctx, suite.testAccounts["local_account_1"].ID, "", "", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// and the requester is told about the rejection
+	suite.Eventually(func() bool {
+		err := suite.db.GetWhere(ctx, []db.Where{
+			{Key: "notification_type", Value: gtsmodel.NotificationFollowReject},
+			{Key: "target_account_id", Value: requestingAccount.ID},
+		}, &gtsmodel.Notification{})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// rejecting again is a not found
+	_, errWithCode = suite.processor.FollowRequestReject(ctx, suite.authed(), requestingAccount.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestFollowRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowRequestTestSuite))
+}
@@ -51,9 +51,29 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 				return err
 			}
 
-			if status.Federated {
-				return p.federateStatus(ctx, status)
+			return p.federateStatus(ctx, status)
+		case ap.ActorPerson:
+			// CREATE ACCOUNT (SIGN UP)
+			user, ok := clientMsg.GTSModel.(*gtsmodel.User)
+			if !ok {
+				return errors.New("account was not parseable as *gtsmodel.User")
+			}
+
+			account, err := p.db.GetAccountByID(ctx, user.AccountID)
+			if err != nil {
+				return err
 			}
+
+			if err := p.emailConfirm(ctx, user, account); err != nil {
+				return err
+			}
+
+			// approved sign ups don't need any attention
+			if user.Approved {
+				return nil
+			}
+
+			return p.notifyAdminSignUp(ctx, user, account)
 		case ap.ActivityFollow:
 			// CREATE FOLLOW REQUEST
 			followRequest, ok := clientMsg.GTSModel.(*gtsmodel.FollowRequest)
@@ -109,10 +129,51 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 				return err
 			}
 
+			// the block removed any follows between the accounts, so they can't be in each other's lists anymore
+			if err := p.removeFromLists(ctx, block.AccountID, block.TargetAccountID); err != nil {
+				return err
+			}
+			if err := p.removeFromLists(ctx, block.TargetAccountID, block.AccountID); err != nil {
+				return err
+			}
+
 			// TODO: same with notifications
 			// TODO: same with bookmarks
 
 			return p.federateBlock(ctx, block)
+		case ap.ObjectCollection:
+			// CREATE ACCOUNT EXPORT
+			export, ok := clientMsg.GTSModel.(*gtsmodel.AccountExport)
+			if !ok {
+				return errors.New("export was not parseable as *gtsmodel.AccountExport")
+			}
+
+			return p.buildAccountExport(ctx, export)
+		case ap.ActivityIgnore:
+			// CREATE MUTE
+			mute, ok := clientMsg.GTSModel.(*gtsmodel.AccountMute)
+			if !ok {
+				return errors.New("mute was not parseable as *gtsmodel.AccountMute")
+			}
+
+			// mutes are private, so there's nothing to federate; just remove the muted account's statuses from the muting account's timelines
+			return p.timelineManager.WipeStatusesFromAccountID(ctx, mute.AccountID, mute.TargetAccountID)
+		}
+	case ap.ActivityFlag:
+		// FLAG
+		switch clientMsg.APObjectType {
+		case ap.ObjectProfile, ap.ActorPerson:
+			// FLAG ACCOUNT
+			report, ok := clientMsg.GTSModel.(*gtsmodel.Report)
+			if !ok {
+				return errors.New("report was not parseable as *gtsmodel.Report")
+			}
+
+			if err := p.notifyReport(ctx, report); err != nil {
+				return err
+			}
+
+			return p.federateReport(ctx, report)
 		}
 	case ap.ActivityUpdate:
 		// UPDATE
@@ -124,7 +185,63 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 				return errors.New("account was not parseable as *gtsmodel.Account")
 			}
 
+			// verify any new URL profile fields; a failure here shouldn't stop the update from federating
+			if err := p.verifyAccountFields(ctx, account.ID, false); err != nil {
+				p.log.WithContext(ctx).WithError(err).Error("error verifying profile fields")
+			}
+
 			return p.federateAccountUpdate(ctx, account, clientMsg.OriginAccount)
+		case ap.ObjectNote:
+			// UPDATE STATUS/NOTE
+			status, ok := clientMsg.GTSModel.(*gtsmodel.Status)
+			if !ok {
+				return errors.New("note was not parseable as *gtsmodel.Status")
+			}
+
+			if err := p.refreshStatusInTimelines(ctx, status); err != nil {
+				return err
+			}
+
+			// accounts that are newly mentioned in the edit should be notified about it
+			if err := p.notifyStatus(ctx, status); err != nil {
+				return err
+			}
+
+			return p.federateStatusUpdate(ctx, status)
+		case ap.ActivityFlag:
+			// UPDATE REPORT
+			report, ok := clientMsg.GTSModel.(*gtsmodel.Report)
+			if !ok {
+				return errors.New("report was not parseable as *gtsmodel.Report")
+			}
+
+			// reports are only updated by moderators, so the only thing to do is let the reporter
+			// know once their report has been resolved
+			if !report.ActionTaken {
+				return nil
+			}
+
+			return p.notifyReportResolved(ctx, report)
+		}
+	case ap.ActivityMove:
+		// MOVE
+		switch clientMsg.APObjectType {
+		case ap.ObjectProfile, ap.ActorPerson:
+			// MOVE ACCOUNT/PROFILE
+			account, ok := clientMsg.GTSModel.(*gtsmodel.Account)
+			if !ok {
+				return errors.New("account was not parseable as *gtsmodel.Account")
+			}
+
+			if clientMsg.TargetAccount == nil {
+				return errors.New("move had no target account")
+			}
+
+			if err := p.moveFollowers(ctx, account, clientMsg.TargetAccount); err != nil {
+				return err
+			}
+
+			return p.federateMove(ctx, account, clientMsg.TargetAccount)
 		}
 	case ap.ActivityAccept:
 		// ACCEPT
@@ -141,6 +258,38 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 			}
 
 			return p.federateAcceptFollowRequest(ctx, follow, clientMsg.OriginAccount, clientMsg.TargetAccount)
+		case ap.ActorPerson:
+			// ACCEPT SIGN UP
+			user, ok := clientMsg.GTSModel.(*gtsmodel.User)
+			if !ok {
+				return errors.New("accept was not parseable as *gtsmodel.User")
+			}
+
+			return p.emailSignupApproved(user, clientMsg.TargetAccount)
+		}
+	case ap.ActivityReject:
+		// REJECT
+		switch clientMsg.APObjectType {
+		case ap.ActivityFollow:
+			// REJECT FOLLOW
+			followRequest, ok := clientMsg.GTSModel.(*gtsmodel.FollowRequest)
+			if !ok {
+				return errors.New("reject was not parseable as *gtsmodel.FollowRequest")
+			}
+
+			if err := p.notifyFollowReject(ctx, followRequest); err != nil {
+				return err
+			}
+
+			return p.federateRejectFollowRequest(ctx, followRequest, clientMsg.OriginAccount, clientMsg.TargetAccount)
+		case ap.ActorPerson:
+			// REJECT SIGN UP
+			user, ok := clientMsg.GTSModel.(*gtsmodel.User)
+			if !ok {
+				return errors.New("reject was not parseable as *gtsmodel.User")
+			}
+
+			return p.emailSignupRejected(user, clientMsg.TargetAccount)
 		}
 	case ap.ActivityUndo:
 		// UNDO
@@ -151,6 +300,12 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 			if !ok {
 				return errors.New("undo was not parseable as *gtsmodel.Follow")
 			}
+
+			// only followed accounts can be in lists
+			if err := p.removeFromLists(ctx, follow.AccountID, follow.TargetAccountID); err != nil {
+				return err
+			}
+
 			return p.federateUnfollow(ctx, follow, clientMsg.OriginAccount, clientMsg.TargetAccount)
 		case ap.ActivityBlock:
 			// UNDO BLOCK
@@ -212,6 +367,18 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 				return err
 			}
 
+			// delete the poll attached to this status, and its votes, if it has one
+			if poll, err := p.db.GetStatusPoll(ctx, statusToDelete.ID); err == nil {
+				if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "poll_id", Value: poll.ID}}, &[]*gtsmodel.PollVote{}); err != nil {
+					return err
+				}
+				if err := p.db.DeleteByID(ctx, poll.ID, &gtsmodel.Poll{}); err != nil {
+					return err
+				}
+			} else if err != db.ErrNoEntries {
+				return err
+			}
+
 			// delete this status from any and all timelines
 			if err := p.deleteStatusFromTimelines(ctx, statusToDelete); err != nil {
 				return err
@@ -230,6 +397,12 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 				// origin is whichever account caused this message
 				origin = clientMsg.OriginAccount.ID
 			}
+
+			// exports are kept in storage, which the account processor doesn't have access to
+			if clientMsg.TargetAccount.Domain == "" {
+				p.deleteAccountExports(ctx, clientMsg.TargetAccount.ID)
+			}
+
 			return p.accountProcessor.Delete(ctx, clientMsg.TargetAccount, origin)
 		}
 	}
@@ -239,6 +412,11 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 // TODO: move all the below functions into federation.Federator
 
 func (p *processor) federateStatus(ctx context.Context, status *gtsmodel.Status) error {
+	// local-only statuses never leave this instance
+	if !status.Federated {
+		return nil
+	}
+
 	if status.Account == nil {
 		statusAccount, err := p.db.GetAccountByID(ctx, status.AccountID)
 		if err != nil {
@@ -266,7 +444,50 @@ func (p *processor) federateStatus(ctx context.Context, status *gtsmodel.Status)
 	return err
 }
 
+func (p *processor) federateStatusUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	// local-only statuses never leave this instance
+	if !status.Federated {
+		return nil
+	}
+
+	if status.Account == nil {
+		statusAccount, err := p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("federateStatusUpdate: error fetching status author account: %s", err)
+		}
+		status.Account = statusAccount
+	}
+
+	// do nothing if this isn't our status
+	if status.Account.Domain != "" {
+		return nil
+	}
+
+	asStatus, err := p.tc.StatusToAS(ctx, status)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error converting status to as format: %s", err)
+	}
+
+	update, err := p.tc.WrapNoteInUpdate(asStatus, status.Account)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error wrapping note in update: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(status.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, update)
+	return err
+}
+
 func (p *processor) federateStatusDelete(ctx context.Context, status *gtsmodel.Status) error {
+	// local-only statuses never leave this instance
+	if !status.Federated {
+		return nil
+	}
+
 	if status.Account == nil {
 		statusAccount, err := p.db.GetAccountByID(ctx, status.AccountID)
 		if err != nil {
@@ -419,7 +640,7 @@ func (p *processor) federateUnfave(ctx context.Context, fave *gtsmodel.StatusFav
 }
 
 func (p *processor) federateUnannounce(ctx context.Context, boost *gtsmodel.Status, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
-	if originAccount.Domain != "" {
+	if originAccount.Domain != "" || !boost.Federated {
 		// nothing to do here
 		return nil
 	}
@@ -503,6 +724,121 @@ func (p *processor) federateAcceptFollowRequest(ctx context.Context, follow *gts
 	return err
 }
 
+func (p *processor) federateRejectFollowRequest(ctx context.Context, followRequest *gtsmodel.FollowRequest, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	// if both accounts are local there's nothing to do here
+	if originAccount.Domain == "" && targetAccount.Domain == "" {
+		return nil
+	}
+
+	// recreate the AS follow
+	follow := p.tc.FollowRequestToFollow(ctx, followRequest)
+	asFollow, err := p.tc.FollowToAS(ctx, follow, originAccount, targetAccount)
+	if err != nil {
+		return fmt.Errorf("federateRejectFollowRequest: error converting follow to as format: %s", err)
+	}
+
+	rejectingAccountURI, err := url.Parse(targetAccount.URI)
+	if err != nil {
+		return fmt.Errorf("federateRejectFollowRequest: error parsing uri %s: %s", targetAccount.URI, err)
+	}
+
+	requestingAccountURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return fmt.Errorf("federateRejectFollowRequest: error parsing uri %s: %s", originAccount.URI, err)
+	}
+
+	// create a Reject
+	reject := streams.NewActivityStreamsReject()
+
+	// set the rejecting actor on it
+	rejectActorProp := streams.NewActivityStreamsActorProperty()
+	rejectActorProp.AppendIRI(rejectingAccountURI)
+	reject.SetActivityStreamsActor(rejectActorProp)
+
+	// Set the recreated follow as the 'object' property.
+	rejectObject := streams.NewActivityStreamsObjectProperty()
+	rejectObject.AppendActivityStreamsFollow(asFollow)
+	reject.SetActivityStreamsObject(rejectObject)
+
+	// Set the To of the reject as the originator of the follow
+	rejectTo := streams.NewActivityStreamsToProperty()
+	rejectTo.AppendIRI(requestingAccountURI)
+	reject.SetActivityStreamsTo(rejectTo)
+
+	outboxIRI, err := url.Parse(targetAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateRejectFollowRequest: error parsing outboxURI %s: %s", targetAccount.OutboxURI, err)
+	}
+
+	// send off the reject using the rejecter's outbox
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, reject)
+	return err
+}
+
+func (p *processor) federateRejectReply(ctx context.Context, reply *gtsmodel.Status) error {
+	if reply.InReplyToAccount == nil {
+		a, err := p.db.GetAccountByID(ctx, reply.InReplyToAccountID)
+		if err != nil {
+			return fmt.Errorf("federateRejectReply: error getting replied-to account from database: %s", err)
+		}
+		reply.InReplyToAccount = a
+	}
+
+	if reply.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, reply.AccountID)
+		if err != nil {
+			return fmt.Errorf("federateRejectReply: error getting reply account from database: %s", err)
+		}
+		reply.Account = a
+	}
+
+	// only reject replies from remote accounts to local ones
+	if reply.InReplyToAccount.Domain != "" || reply.Account.Domain == "" {
+		return nil
+	}
+
+	rejectingAccountURI, err := url.Parse(reply.InReplyToAccount.URI)
+	if err != nil {
+		return fmt.Errorf("federateRejectReply: error parsing uri %s: %s", reply.InReplyToAccount.URI, err)
+	}
+
+	replyingAccountURI, err := url.Parse(reply.Account.URI)
+	if err != nil {
+		return fmt.Errorf("federateRejectReply: error parsing uri %s: %s", reply.Account.URI, err)
+	}
+
+	replyURI, err := url.Parse(reply.URI)
+	if err != nil {
+		return fmt.Errorf("federateRejectReply: error parsing uri %s: %s", reply.URI, err)
+	}
+
+	// create a Reject
+	reject := streams.NewActivityStreamsReject()
+
+	// set the rejecting actor on it
+	rejectActorProp := streams.NewActivityStreamsActorProperty()
+	rejectActorProp.AppendIRI(rejectingAccountURI)
+	reject.SetActivityStreamsActor(rejectActorProp)
+
+	// Set the reply as the 'object' property.
+	rejectObject := streams.NewActivityStreamsObjectProperty()
+	rejectObject.AppendIRI(replyURI)
+	reject.SetActivityStreamsObject(rejectObject)
+
+	// Set the To of the reject as the author of the reply
+	rejectTo := streams.NewActivityStreamsToProperty()
+	rejectTo.AppendIRI(replyingAccountURI)
+	reject.SetActivityStreamsTo(rejectTo)
+
+	outboxIRI, err := url.Parse(reply.InReplyToAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateRejectReply: error parsing outboxURI %s: %s", reply.InReplyToAccount.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, reject)
+	return err
+}
+
 func (p *processor) federateFave(ctx context.Context, fave *gtsmodel.StatusFave, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
 	// if both accounts are local there's nothing to do here
 	if originAccount.Domain == "" && targetAccount.Domain == "" {
@@ -524,6 +860,11 @@ func (p *processor) federateFave(ctx context.Context, fave *gtsmodel.StatusFave,
 }
 
 func (p *processor) federateAnnounce(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) error {
+	// boosts of local-only statuses are local-only too
+	if !boostWrapperStatus.Federated {
+		return nil
+	}
+
 	announce, err := p.tc.BoostToAS(ctx, boostWrapperStatus, boostingAccount, boostedAccount)
 	if err != nil {
 		return fmt.Errorf("federateAnnounce: error converting status to announce: %s", err)
@@ -580,6 +921,12 @@ func (p *processor) federateBlock(ctx context.Context, block *gtsmodel.Block) er
 		return nil
 	}
 
+	// the instance or the blocking account may not want the target to find out about blocks;
+	// that's fine, since blocks are enforced locally whether they're federated or not
+	if !p.config.FederationConfig.SendBlocks || block.Account.PrivateBlocks {
+		return nil
+	}
+
 	asBlock, err := p.tc.BlockToAS(ctx, block)
 	if err != nil {
 		return fmt.Errorf("federateBlock: error converting block to AS format: %s", err)
@@ -616,6 +963,12 @@ func (p *processor) federateUnblock(ctx context.Context, block *gtsmodel.Block)
 		return nil
 	}
 
+	// the instance or the blocking account may not want the target to find out about blocks;
+	// that's fine, since blocks are enforced locally whether they're federated or not
+	if !p.config.FederationConfig.SendBlocks || block.Account.PrivateBlocks {
+		return nil
+	}
+
 	asBlock, err := p.tc.BlockToAS(ctx, block)
 	if err != nil {
 		return fmt.Errorf("federateUnblock: error converting block to AS format: %s", err)
@@ -647,3 +1000,64 @@ func (p *processor) federateUnblock(ctx context.Context, block *gtsmodel.Block)
 	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, undo)
 	return err
 }
+
+func (p *processor) federateMove(ctx context.Context, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	// do nothing if this isn't our account
+	if originAccount.Domain != "" {
+		return nil
+	}
+
+	move, err := p.tc.MoveToAS(ctx, originAccount, targetAccount)
+	if err != nil {
+		return fmt.Errorf("federateMove: error converting move to as format: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(originAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateMove: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, move)
+	return err
+}
+
+func (p *processor) federateReport(ctx context.Context, report *gtsmodel.Report) error {
+	// the reporter didn't want this forwarded, so the report stays with our moderators
+	if !report.Forwarded {
+		return nil
+	}
+
+	if report.TargetAccount == nil {
+		reportTargetAccount, err := p.db.GetAccountByID(ctx, report.TargetAccountID)
+		if err != nil {
+			return fmt.Errorf("federateReport: error getting report target account from database: %s", err)
+		}
+		report.TargetAccount = reportTargetAccount
+	}
+
+	// if the target account is local there's nobody to forward the report to
+	if report.TargetAccount.Domain == "" {
+		return nil
+	}
+
+	if report.Account == nil {
+		reportAccount, err := p.db.GetAccountByID(ctx, report.AccountID)
+		if err != nil {
+			return fmt.Errorf("federateReport: error getting report account from database: %s", err)
+		}
+		report.Account = reportAccount
+	}
+
+	flag, err := p.tc.ReportToASFlag(ctx, report)
+	if err != nil {
+		return fmt.Errorf("federateReport: error converting report to AS format: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(report.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateReport: error parsing outboxURI %s: %s", report.Account.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, flag)
+	return err
+}
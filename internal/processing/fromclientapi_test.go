@@ -0,0 +1,99 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+type FromClientAPITestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *FromClientAPITestSuite) TestProcessBlock() {
+	ctx := context.Background()
+	block := *suite.testBlocks["local_account_2_block_remote_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	err := suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityBlock,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       &block,
+		OriginAccount:  suite.testAccounts["local_account_2"],
+		TargetAccount:  targetAccount,
+	})
+	suite.NoError(err)
+
+	// the block should be sent to satan's inbox
+	suite.Len(suite.sentHTTPRequests, 1)
+	suite.Contains(string(suite.sentHTTPRequests[targetAccount.InboxURI]), "Block")
+}
+
+func (suite *FromClientAPITestSuite) TestProcessPrivateBlock() {
+	ctx := context.Background()
+	block := *suite.testBlocks["local_account_2_block_remote_account_1"]
+
+	// the blocking account wants to keep its blocks to itself
+	blockingAccount := *suite.testAccounts["local_account_2"]
+	blockingAccount.PrivateBlocks = true
+	_, err := suite.db.UpdateAccount(ctx, &blockingAccount)
+	suite.NoError(err)
+	block.Account = &blockingAccount
+
+	err = suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityBlock,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       &block,
+		OriginAccount:  &blockingAccount,
+		TargetAccount:  suite.testAccounts["remote_account_1"],
+	})
+	suite.NoError(err)
+
+	// nothing should have been sent
+	suite.Empty(suite.sentHTTPRequests)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessBlockNotSentByInstance() {
+	ctx := context.Background()
+	block := *suite.testBlocks["local_account_2_block_remote_account_1"]
+
+	// this instance doesn't send blocks at all
+	suite.config.FederationConfig.SendBlocks = false
+
+	err := suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityBlock,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       &block,
+		OriginAccount:  suite.testAccounts["local_account_2"],
+		TargetAccount:  suite.testAccounts["remote_account_1"],
+	})
+	suite.NoError(err)
+
+	// nothing should have been sent
+	suite.Empty(suite.sentHTTPRequests)
+}
+
+func TestFromClientAPITestSuite(t *testing.T) {
+	suite.Run(t, &FromClientAPITestSuite{})
+}
@@ -20,13 +20,20 @@ package processing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) error {
@@ -60,6 +67,25 @@ func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) e
 			continue
 		}
 
+		if silenced, err := p.silencedFor(ctx, status.AccountID, m.TargetAccount); err != nil {
+			return fmt.Errorf("notifyStatus: %s", err)
+		} else if silenced {
+			continue
+		}
+
+		// don't notify about threads the target account has muted
+		if muted, err := p.db.IsStatusMutedBy(ctx, status, m.TargetAccountID); err != nil {
+			return fmt.Errorf("notifyStatus: error checking status mute: %s", err)
+		} else if muted {
+			continue
+		}
+
+		if wanted, err := p.notificationWanted(ctx, m.TargetAccountID, gtsmodel.NotificationMention); err != nil {
+			return fmt.Errorf("notifyStatus: %s", err)
+		} else if !wanted {
+			continue
+		}
+
 		// make sure a notif doesn't already exist for this mention
 		if err := p.db.GetWhere(ctx, []db.Where{
 			{Key: "notification_type", Value: gtsmodel.NotificationMention},
@@ -101,14 +127,63 @@ func (p *processor) notifyStatus(ctx context.Context, status *gtsmodel.Status) e
 			return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 		}
 
+		mastoNotif, ok := p.filterNotification(ctx, m.TargetAccount, mastoNotif)
+		if !ok {
+			continue
+		}
+
 		if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, m.TargetAccount); err != nil {
 			return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 		}
+
+		if err := p.webPushSender.Send(ctx, m.TargetAccount, mastoNotif); err != nil {
+			return fmt.Errorf("notifyStatus: error pushing notification to account: %s", err)
+		}
 	}
 
 	return nil
 }
 
+// silencedFor returns true if notifications from the given origin account to the given local target account
+// should be dropped, because the target has muted notifications from the origin account, or because the
+// origin account is on a silenced domain and the target doesn't follow it.
+func (p *processor) silencedFor(ctx context.Context, originAccountID string, targetAccount *gtsmodel.Account) (bool, error) {
+	muted, err := p.db.IsMuted(ctx, targetAccount.ID, originAccountID, true)
+	if err != nil {
+		return false, fmt.Errorf("error checking mute from %s to %s: %s", targetAccount.ID, originAccountID, err)
+	}
+	if muted {
+		return true, nil
+	}
+
+	originAccount, err := p.db.GetAccountByID(ctx, originAccountID)
+	if err != nil {
+		return false, fmt.Errorf("error getting account with id %s: %s", originAccountID, err)
+	}
+	if originAccount.Domain == "" {
+		// local accounts are never silenced
+		return false, nil
+	}
+
+	originURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return false, fmt.Errorf("error parsing account uri %s: %s", originAccount.URI, err)
+	}
+	policy, err := p.db.GetDomainPolicy(ctx, originURI.Hostname())
+	if err != nil {
+		return false, fmt.Errorf("error getting domain policy for %s: %s", originURI.Host, err)
+	}
+	if !policy.Silence {
+		return false, nil
+	}
+
+	following, err := p.db.IsFollowing(ctx, targetAccount, originAccount)
+	if err != nil {
+		return false, fmt.Errorf("error checking follow from %s to %s: %s", targetAccount.ID, originAccount.ID, err)
+	}
+	return !following, nil
+}
+
 func (p *processor) notifyFollowRequest(ctx context.Context, followRequest *gtsmodel.FollowRequest) error {
 	// make sure we have the target account pinned on the follow request
 	if followRequest.TargetAccount == nil {
@@ -126,6 +201,12 @@ func (p *processor) notifyFollowRequest(ctx context.Context, followRequest *gtsm
 		return nil
 	}
 
+	if wanted, err := p.notificationWanted(ctx, targetAccount.ID, gtsmodel.NotificationFollowRequest); err != nil {
+		return fmt.Errorf("notifyFollowRequest: %s", err)
+	} else if !wanted {
+		return nil
+	}
+
 	notifID, err := id.NewULID()
 	if err != nil {
 		return err
@@ -152,6 +233,10 @@ func (p *processor) notifyFollowRequest(ctx context.Context, followRequest *gtsm
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
+	if err := p.webPushSender.Send(ctx, targetAccount, mastoNotif); err != nil {
+		return fmt.Errorf("notifyStatus: error pushing notification to account: %s", err)
+	}
+
 	return nil
 }
 
@@ -170,6 +255,12 @@ func (p *processor) notifyFollow(ctx context.Context, follow *gtsmodel.Follow, t
 		return fmt.Errorf("notifyFollow: error removing old follow request notification from database: %s", err)
 	}
 
+	if wanted, err := p.notificationWanted(ctx, targetAccount.ID, gtsmodel.NotificationFollow); err != nil {
+		return fmt.Errorf("notifyFollow: %s", err)
+	} else if !wanted {
+		return nil
+	}
+
 	// now create the new follow notification
 	notifID, err := id.NewULID()
 	if err != nil {
@@ -198,6 +289,66 @@ func (p *processor) notifyFollow(ctx context.Context, follow *gtsmodel.Follow, t
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
+	if err := p.webPushSender.Send(ctx, targetAccount, mastoNotif); err != nil {
+		return fmt.Errorf("notifyStatus: error pushing notification to account: %s", err)
+	}
+
+	return nil
+}
+
+func (p *processor) notifyFollowReject(ctx context.Context, followRequest *gtsmodel.FollowRequest) error {
+	// make sure we have the requesting account pinned on the follow request
+	if followRequest.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, followRequest.AccountID)
+		if err != nil {
+			return err
+		}
+		followRequest.Account = a
+	}
+	requestingAccount := followRequest.Account
+
+	// return if this isn't a local account
+	if requestingAccount.Domain != "" {
+		return nil
+	}
+
+	if wanted, err := p.notificationWanted(ctx, requestingAccount.ID, gtsmodel.NotificationFollowReject); err != nil {
+		return fmt.Errorf("notifyFollowReject: %s", err)
+	} else if !wanted {
+		return nil
+	}
+
+	notifID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	notif := &gtsmodel.Notification{
+		ID:               notifID,
+		NotificationType: gtsmodel.NotificationFollowReject,
+		TargetAccountID:  followRequest.AccountID,
+		TargetAccount:    requestingAccount,
+		OriginAccountID:  followRequest.TargetAccountID,
+		OriginAccount:    followRequest.TargetAccount,
+	}
+	if err := p.db.Put(ctx, notif); err != nil {
+		return fmt.Errorf("notifyFollowReject: error putting notification in database: %s", err)
+	}
+
+	// now stream the notification to the user
+	mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
+	if err != nil {
+		return fmt.Errorf("notifyFollowReject: error converting notification to masto representation: %s", err)
+	}
+
+	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, requestingAccount); err != nil {
+		return fmt.Errorf("notifyFollowReject: error streaming notification to account: %s", err)
+	}
+
+	if err := p.webPushSender.Send(ctx, requestingAccount, mastoNotif); err != nil {
+		return fmt.Errorf("notifyFollowReject: error pushing notification to account: %s", err)
+	}
+
 	return nil
 }
 
@@ -216,6 +367,32 @@ func (p *processor) notifyFave(ctx context.Context, fave *gtsmodel.StatusFave) e
 		return nil
 	}
 
+	if silenced, err := p.silencedFor(ctx, fave.AccountID, targetAccount); err != nil {
+		return fmt.Errorf("notifyFave: %s", err)
+	} else if silenced {
+		return nil
+	}
+
+	if fave.Status == nil {
+		s, err := p.db.GetStatusByID(ctx, fave.StatusID)
+		if err != nil {
+			return fmt.Errorf("notifyFave: error getting status with id %s: %s", fave.StatusID, err)
+		}
+		fave.Status = s
+	}
+
+	if muted, err := p.db.IsStatusMutedBy(ctx, fave.Status, targetAccount.ID); err != nil {
+		return fmt.Errorf("notifyFave: error checking status mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
+	if wanted, err := p.notificationWanted(ctx, targetAccount.ID, gtsmodel.NotificationFave); err != nil {
+		return fmt.Errorf("notifyFave: %s", err)
+	} else if !wanted {
+		return nil
+	}
+
 	notifID, err := id.NewULID()
 	if err != nil {
 		return err
@@ -242,10 +419,117 @@ func (p *processor) notifyFave(ctx context.Context, fave *gtsmodel.StatusFave) e
 		return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 	}
 
+	mastoNotif, ok := p.filterNotification(ctx, targetAccount, mastoNotif)
+	if !ok {
+		return nil
+	}
+
 	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, targetAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
+	if err := p.webPushSender.Send(ctx, targetAccount, mastoNotif); err != nil {
+		return fmt.Errorf("notifyStatus: error pushing notification to account: %s", err)
+	}
+
+	return nil
+}
+
+// notifyPollEnded lets the author of the given poll, and every local account that voted in it, know that it has ended.
+func (p *processor) notifyPollEnded(ctx context.Context, poll *gtsmodel.Poll) error {
+	if poll.Status == nil {
+		s, err := p.db.GetStatusByID(ctx, poll.StatusID)
+		if err != nil {
+			return fmt.Errorf("notifyPollEnded: error getting status with id %s: %s", poll.StatusID, err)
+		}
+		poll.Status = s
+	}
+	status := poll.Status
+
+	votes, err := p.db.GetPollVotes(ctx, poll.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("notifyPollEnded: error getting votes: %s", err)
+	}
+
+	targetAccountIDs := []string{status.AccountID}
+	seen := map[string]bool{status.AccountID: true}
+	for _, v := range votes {
+		if !seen[v.AccountID] {
+			seen[v.AccountID] = true
+			targetAccountIDs = append(targetAccountIDs, v.AccountID)
+		}
+	}
+
+	for _, targetAccountID := range targetAccountIDs {
+		targetAccount, err := p.db.GetAccountByID(ctx, targetAccountID)
+		if err != nil {
+			return fmt.Errorf("notifyPollEnded: error getting account with id %s: %s", targetAccountID, err)
+		}
+
+		// just skip if target isn't a local account
+		if targetAccount.Domain != "" {
+			continue
+		}
+
+		if targetAccount.ID != status.AccountID {
+			if silenced, err := p.silencedFor(ctx, status.AccountID, targetAccount); err != nil {
+				return fmt.Errorf("notifyPollEnded: %s", err)
+			} else if silenced {
+				continue
+			}
+		}
+
+		if muted, err := p.db.IsStatusMutedBy(ctx, status, targetAccount.ID); err != nil {
+			return fmt.Errorf("notifyPollEnded: error checking status mute: %s", err)
+		} else if muted {
+			continue
+		}
+
+		if wanted, err := p.notificationWanted(ctx, targetAccount.ID, gtsmodel.NotificationPoll); err != nil {
+			return fmt.Errorf("notifyPollEnded: %s", err)
+		} else if !wanted {
+			continue
+		}
+
+		notifID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		notif := &gtsmodel.Notification{
+			ID:               notifID,
+			NotificationType: gtsmodel.NotificationPoll,
+			TargetAccountID:  targetAccount.ID,
+			TargetAccount:    targetAccount,
+			OriginAccountID:  status.AccountID,
+			StatusID:         status.ID,
+			Status:           status,
+		}
+
+		if err := p.db.Put(ctx, notif); err != nil {
+			return fmt.Errorf("notifyPollEnded: error putting notification in database: %s", err)
+		}
+
+		// now stream the notification to the user
+		mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
+		if err != nil {
+			return fmt.Errorf("notifyPollEnded: error converting notification to masto representation: %s", err)
+		}
+
+		mastoNotif, ok := p.filterNotification(ctx, targetAccount, mastoNotif)
+		if !ok {
+			continue
+		}
+
+		if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, targetAccount); err != nil {
+			return fmt.Errorf("notifyPollEnded: error streaming notification to account: %s", err)
+		}
+
+		if err := p.webPushSender.Send(ctx, targetAccount, mastoNotif); err != nil {
+			return fmt.Errorf("notifyPollEnded: error pushing notification to account: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -282,6 +566,24 @@ func (p *processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		return nil
 	}
 
+	if silenced, err := p.silencedFor(ctx, status.AccountID, status.BoostOfAccount); err != nil {
+		return fmt.Errorf("notifyAnnounce: %s", err)
+	} else if silenced {
+		return nil
+	}
+
+	if muted, err := p.db.IsStatusMutedBy(ctx, status.BoostOf, status.BoostOfAccountID); err != nil {
+		return fmt.Errorf("notifyAnnounce: error checking status mute: %s", err)
+	} else if muted {
+		return nil
+	}
+
+	if wanted, err := p.notificationWanted(ctx, status.BoostOfAccountID, gtsmodel.NotificationReblog); err != nil {
+		return fmt.Errorf("notifyAnnounce: %s", err)
+	} else if !wanted {
+		return nil
+	}
+
 	// make sure a notif doesn't already exist for this announce
 	err := p.db.GetWhere(ctx, []db.Where{
 		{Key: "notification_type", Value: gtsmodel.NotificationReblog},
@@ -321,10 +623,19 @@ func (p *processor) notifyAnnounce(ctx context.Context, status *gtsmodel.Status)
 		return fmt.Errorf("notifyStatus: error converting notification to masto representation: %s", err)
 	}
 
+	mastoNotif, ok := p.filterNotification(ctx, status.BoostOfAccount, mastoNotif)
+	if !ok {
+		return nil
+	}
+
 	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, status.BoostOfAccount); err != nil {
 		return fmt.Errorf("notifyStatus: error streaming notification to account: %s", err)
 	}
 
+	if err := p.webPushSender.Send(ctx, status.BoostOfAccount, mastoNotif); err != nil {
+		return fmt.Errorf("notifyStatus: error pushing notification to account: %s", err)
+	}
+
 	return nil
 }
 
@@ -352,6 +663,26 @@ func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status)
 		})
 	}
 
+	// public statuses are also injected into the home timelines of accounts that follow any of the hashtags they use
+	if status.Visibility == gtsmodel.VisibilityPublic && status.BoostOfID == "" && len(status.TagIDs) != 0 {
+		tagFollowerIDs, err := p.db.GetTagFollowerIDs(ctx, status.TagIDs)
+		if err != nil {
+			return fmt.Errorf("timelineStatus: error getting tag followers for status id %s: %s", status.ID, err)
+		}
+
+		alreadyIncluded := make(map[string]bool, len(follows))
+		for _, f := range follows {
+			alreadyIncluded[f.AccountID] = true
+		}
+
+		for _, accountID := range tagFollowerIDs {
+			if !alreadyIncluded[accountID] {
+				alreadyIncluded[accountID] = true
+				follows = append(follows, &gtsmodel.Follow{AccountID: accountID})
+			}
+		}
+	}
+
 	wg := sync.WaitGroup{}
 	wg.Add(len(follows))
 	errors := make(chan error, len(follows))
@@ -412,14 +743,21 @@ func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmod
 		return
 	}
 
+	// put the status in any lists of the account that the status author is a member of
+	if err := p.timelineStatusForLists(ctx, status, timelineAccount); err != nil {
+		errors <- fmt.Errorf("timelineStatusForAccount: error ingesting status %s into lists: %s", status.ID, err)
+	}
+
 	// the status was inserted to stream it to the user
 	if inserted {
 		mastoStatus, err := p.tc.StatusToMasto(ctx, status, timelineAccount)
 		if err != nil {
 			errors <- fmt.Errorf("timelineStatusForAccount: error converting status %s to frontend representation: %s", status.ID, err)
 		} else {
-			if err := p.streamingProcessor.StreamStatusToAccount(mastoStatus, timelineAccount); err != nil {
-				errors <- fmt.Errorf("timelineStatusForAccount: error streaming status %s: %s", status.ID, err)
+			for _, s := range p.filterStatuses(ctx, timelineAccount, gtsmodel.FilterContextHome, []*apimodel.Status{mastoStatus}) {
+				if err := p.streamingProcessor.StreamStatusToAccount(s, timelineAccount); err != nil {
+					errors <- fmt.Errorf("timelineStatusForAccount: error streaming status %s: %s", status.ID, err)
+				}
 			}
 		}
 	}
@@ -428,10 +766,45 @@ func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmod
 	if err != nil {
 		errors <- fmt.Errorf("timelineStatusForAccount: error converting status %s to frontend representation: %s", status.ID, err)
 	} else {
-		if err := p.streamingProcessor.StreamStatusToAccount(mastoStatus, timelineAccount); err != nil {
-			errors <- fmt.Errorf("timelineStatusForAccount: error streaming status %s: %s", status.ID, err)
+		for _, s := range p.filterStatuses(ctx, timelineAccount, gtsmodel.FilterContextHome, []*apimodel.Status{mastoStatus}) {
+			if err := p.streamingProcessor.StreamStatusToAccount(s, timelineAccount); err != nil {
+				errors <- fmt.Errorf("timelineStatusForAccount: error streaming status %s: %s", status.ID, err)
+			}
+		}
+	}
+}
+
+// timelineStatusForLists puts the status in the timelines of the lists owned by timelineAccount that the status author is a member of.
+func (p *processor) timelineStatusForLists(ctx context.Context, status *gtsmodel.Status, timelineAccount *gtsmodel.Account) error {
+	if status.AccountID == timelineAccount.ID {
+		// accounts can't be members of their own lists
+		return nil
+	}
+
+	lists, err := p.db.GetListsContainingAccount(ctx, timelineAccount.ID, status.AccountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return err
+	}
+
+	for _, list := range lists {
+		timelineable, err := p.filter.StatusListtimelineable(ctx, status, list)
+		if err != nil {
+			return fmt.Errorf("error getting timelineability for status for list %s: %s", list.ID, err)
+		}
+
+		if !timelineable {
+			continue
+		}
+
+		if _, err := p.timelineManager.IngestAndPrepareIntoList(ctx, status, list.ID); err != nil {
+			return fmt.Errorf("error ingesting status into list %s: %s", list.ID, err)
 		}
 	}
+
+	return nil
 }
 
 func (p *processor) deleteStatusFromTimelines(ctx context.Context, status *gtsmodel.Status) error {
@@ -441,3 +814,497 @@ func (p *processor) deleteStatusFromTimelines(ctx context.Context, status *gtsmo
 
 	return p.streamingProcessor.StreamDelete(status.ID)
 }
+
+func (p *processor) refreshStatusInTimelines(ctx context.Context, status *gtsmodel.Status) error {
+	if err := p.timelineManager.RefreshStatusInAllTimelines(ctx, status.ID); err != nil {
+		return err
+	}
+
+	return p.streamStatusUpdate(ctx, status)
+}
+
+// streamStatusUpdate streams the edited version of status to the local accounts most likely to have it on screen:
+// local followers of the author, locally mentioned accounts, and the author themself if they're local.
+func (p *processor) streamStatusUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	follows, err := p.db.GetAccountFollowedBy(ctx, status.AccountID, true)
+	if err != nil {
+		return fmt.Errorf("streamStatusUpdate: error getting followers for account id %s: %s", status.AccountID, err)
+	}
+
+	accountIDs := []string{status.AccountID}
+	for _, f := range follows {
+		accountIDs = append(accountIDs, f.AccountID)
+	}
+
+	if len(status.MentionIDs) != 0 {
+		mentions, err := p.db.GetMentions(ctx, status.MentionIDs)
+		if err != nil {
+			return fmt.Errorf("streamStatusUpdate: error getting mentions for status %s: %s", status.ID, err)
+		}
+		for _, m := range mentions {
+			accountIDs = append(accountIDs, m.TargetAccountID)
+		}
+	}
+
+	errs := []string{}
+	streamed := make(map[string]bool, len(accountIDs))
+	for _, accountID := range accountIDs {
+		if streamed[accountID] {
+			continue
+		}
+		streamed[accountID] = true
+
+		account, err := p.db.GetAccountByID(ctx, accountID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error getting account %s: %s", accountID, err))
+			continue
+		}
+
+		if account.Domain != "" {
+			// only local accounts have streams
+			continue
+		}
+
+		visible, err := p.filter.StatusVisible(ctx, status, account)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error checking visibility of status %s for account %s: %s", status.ID, accountID, err))
+			continue
+		}
+		if !visible {
+			continue
+		}
+
+		mastoStatus, err := p.tc.StatusToMasto(ctx, status, account)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error converting status %s to frontend representation: %s", status.ID, err))
+			continue
+		}
+
+		if err := p.streamingProcessor.StreamStatusUpdateToAccount(mastoStatus, account); err != nil {
+			errs = append(errs, fmt.Sprintf("error streaming status update %s: %s", status.ID, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("streamStatusUpdate: one or more errors streaming status update: %s", strings.Join(errs, ";"))
+	}
+
+	return nil
+}
+
+// moveFollowers makes local followers of originAccount follow targetAccount instead, once originAccount has moved to targetAccount.
+// Remote followers are taken care of by their own instances when they receive the Move.
+func (p *processor) moveFollowers(ctx context.Context, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	follows, err := p.db.GetAccountFollowedBy(ctx, originAccount.ID, true)
+	if err != nil {
+		return fmt.Errorf("moveFollowers: error getting local followers of account %s: %s", originAccount.ID, err)
+	}
+
+	errs := []string{}
+	for _, follow := range follows {
+		if follow.AccountID == targetAccount.ID {
+			// the new account can't follow itself
+			continue
+		}
+
+		follower, err := p.db.GetAccountByID(ctx, follow.AccountID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error getting follower account %s: %s", follow.AccountID, err))
+			continue
+		}
+
+		// follow the new account before unfollowing the old one, so that if
+		// the new follow fails the follower at least keeps what they had
+		showReblogs := follow.ShowReblogs
+		notify := follow.Notify
+		if _, errWithCode := p.accountProcessor.FollowCreate(ctx, follower, &apimodel.AccountFollowRequest{
+			ID:      targetAccount.ID,
+			Reblogs: &showReblogs,
+			Notify:  &notify,
+		}); errWithCode != nil {
+			errs = append(errs, fmt.Sprintf("error following account %s for follower %s: %s", targetAccount.ID, follower.ID, errWithCode))
+			continue
+		}
+
+		if _, errWithCode := p.accountProcessor.FollowRemove(ctx, follower, originAccount.ID); errWithCode != nil {
+			errs = append(errs, fmt.Sprintf("error unfollowing account %s for follower %s: %s", originAccount.ID, follower.ID, errWithCode))
+			continue
+		}
+
+		if err := p.notifyMove(ctx, originAccount, follower); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("moveFollowers: one or more errors moving followers of account %s to account %s: %s", originAccount.ID, targetAccount.ID, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// notifyMove lets a local follower of originAccount know that their follow has been moved to the account it moved to.
+func (p *processor) notifyMove(ctx context.Context, originAccount *gtsmodel.Account, follower *gtsmodel.Account) error {
+	if wanted, err := p.notificationWanted(ctx, follower.ID, gtsmodel.NotificationMove); err != nil {
+		return fmt.Errorf("notifyMove: %s", err)
+	} else if !wanted {
+		return nil
+	}
+
+	notifID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	notif := &gtsmodel.Notification{
+		ID:               notifID,
+		NotificationType: gtsmodel.NotificationMove,
+		TargetAccountID:  follower.ID,
+		TargetAccount:    follower,
+		OriginAccountID:  originAccount.ID,
+		OriginAccount:    originAccount,
+	}
+	if err := p.db.Put(ctx, notif); err != nil {
+		return fmt.Errorf("notifyMove: error putting notification in database: %s", err)
+	}
+
+	mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
+	if err != nil {
+		return fmt.Errorf("notifyMove: error converting notification to masto representation: %s", err)
+	}
+
+	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, follower); err != nil {
+		return fmt.Errorf("notifyMove: error streaming notification to account: %s", err)
+	}
+
+	if err := p.webPushSender.Send(ctx, follower, mastoNotif); err != nil {
+		return fmt.Errorf("notifyMove: error pushing notification to account: %s", err)
+	}
+
+	return nil
+}
+
+// notifyReport notifies all local admins of a new report.
+func (p *processor) notifyReport(ctx context.Context, report *gtsmodel.Report) error {
+	if err := p.notifyAdmins(ctx, &gtsmodel.Notification{
+		NotificationType: gtsmodel.NotificationAdminReport,
+		OriginAccountID:  report.AccountID,
+		OriginAccount:    report.Account,
+		ReportID:         report.ID,
+		Report:           report,
+	}); err != nil {
+		return fmt.Errorf("notifyReport: error notifying admins of report %s: %s", report.ID, err)
+	}
+
+	if err := p.emailNewReport(ctx, report); err != nil {
+		return fmt.Errorf("notifyReport: error emailing moderators about report %s: %s", report.ID, err)
+	}
+	return nil
+}
+
+// notifyAdminSignUp notifies all local admins of a sign up that's waiting for approval.
+func (p *processor) notifyAdminSignUp(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) error {
+	if err := p.notifyAdmins(ctx, &gtsmodel.Notification{
+		NotificationType: gtsmodel.NotificationAdminSignUp,
+		OriginAccountID:  account.ID,
+		OriginAccount:    account,
+	}); err != nil {
+		return fmt.Errorf("notifyAdminSignUp: error notifying admins of sign up by account %s: %s", account.ID, err)
+	}
+
+	if err := p.emailNewSignup(ctx, user, account); err != nil {
+		return fmt.Errorf("notifyAdminSignUp: error emailing moderators about sign up by account %s: %s", account.ID, err)
+	}
+	return nil
+}
+
+// notifyAdmins gives every admin who wants it a copy of the given notification. ID and target
+// are set for each admin, everything else is taken from the template as it is.
+func (p *processor) notifyAdmins(ctx context.Context, template *gtsmodel.Notification) error {
+	admins := []*gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "admin", Value: true}}, &admins); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting admin users: %s", err)
+	}
+
+	errs := []string{}
+	for _, admin := range admins {
+		adminAccount, err := p.db.GetAccountByID(ctx, admin.AccountID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error getting account %s: %s", admin.AccountID, err))
+			continue
+		}
+
+		if wanted, err := p.notificationWanted(ctx, adminAccount.ID, template.NotificationType); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		} else if !wanted {
+			continue
+		}
+
+		notifID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+
+		notif := *template
+		notif.ID = notifID
+		notif.TargetAccountID = adminAccount.ID
+		notif.TargetAccount = adminAccount
+
+		if err := p.db.Put(ctx, &notif); err != nil {
+			errs = append(errs, fmt.Sprintf("error putting notification for account %s: %s", adminAccount.ID, err))
+			continue
+		}
+
+		mastoNotif, err := p.tc.NotificationToMasto(ctx, &notif)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error converting notification for account %s: %s", adminAccount.ID, err))
+			continue
+		}
+
+		if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, adminAccount); err != nil {
+			errs = append(errs, fmt.Sprintf("error streaming notification to account %s: %s", adminAccount.ID, err))
+		}
+
+		if err := p.webPushSender.Send(ctx, adminAccount, mastoNotif); err != nil {
+			errs = append(errs, fmt.Sprintf("error pushing notification to account %s: %s", adminAccount.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (p *processor) notifyReportResolved(ctx context.Context, report *gtsmodel.Report) error {
+	// make sure we have the reporting account pinned on the report
+	if report.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, report.AccountID)
+		if err != nil {
+			return err
+		}
+		report.Account = a
+	}
+	reportingAccount := report.Account
+
+	// return if this isn't a local account
+	if reportingAccount.Domain != "" {
+		return nil
+	}
+
+	if wanted, err := p.notificationWanted(ctx, reportingAccount.ID, gtsmodel.NotificationReportResolved); err != nil {
+		return fmt.Errorf("notifyReportResolved: %s", err)
+	} else if !wanted {
+		return nil
+	}
+
+	// the notification comes from the instance rather than the moderator who resolved
+	// the report, so that the reporter doesn't learn who on the team handled it
+	instanceAccount, err := p.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return fmt.Errorf("notifyReportResolved: error getting instance account: %s", err)
+	}
+
+	notifID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	notif := &gtsmodel.Notification{
+		ID:               notifID,
+		NotificationType: gtsmodel.NotificationReportResolved,
+		TargetAccountID:  reportingAccount.ID,
+		TargetAccount:    reportingAccount,
+		OriginAccountID:  instanceAccount.ID,
+		OriginAccount:    instanceAccount,
+		ReportID:         report.ID,
+		Report:           report,
+	}
+	if err := p.db.Put(ctx, notif); err != nil {
+		return fmt.Errorf("notifyReportResolved: error putting notification in database: %s", err)
+	}
+
+	// now stream the notification to the user
+	mastoNotif, err := p.tc.NotificationToMasto(ctx, notif)
+	if err != nil {
+		return fmt.Errorf("notifyReportResolved: error converting notification to masto representation: %s", err)
+	}
+
+	if err := p.streamingProcessor.StreamNotificationToAccount(mastoNotif, reportingAccount); err != nil {
+		return fmt.Errorf("notifyReportResolved: error streaming notification to account: %s", err)
+	}
+
+	if err := p.webPushSender.Send(ctx, reportingAccount, mastoNotif); err != nil {
+		return fmt.Errorf("notifyReportResolved: error pushing notification to account: %s", err)
+	}
+
+	return nil
+}
+
+// emailSignupApproved lets the owner of a newly approved account know that they can log in now.
+func (p *processor) emailSignupApproved(user *gtsmodel.User, account *gtsmodel.Account) error {
+	if err := p.emailSender.SendSignupApprovedEmail(signupEmailAddress(user), p.signupEmailData(account)); err != nil {
+		return fmt.Errorf("emailSignupApproved: %s", err)
+	}
+	return nil
+}
+
+// emailSignupRejected lets someone know that their sign up request was rejected. The user and account are
+// already gone from the database by the time this is called, so the removed models are passed in.
+func (p *processor) emailSignupRejected(user *gtsmodel.User, account *gtsmodel.Account) error {
+	if err := p.emailSender.SendSignupRejectedEmail(signupEmailAddress(user), p.signupEmailData(account)); err != nil {
+		return fmt.Errorf("emailSignupRejected: %s", err)
+	}
+	return nil
+}
+
+func (p *processor) signupEmailData(account *gtsmodel.Account) email.SignupData {
+	return email.SignupData{
+		Username:     account.Username,
+		InstanceHost: p.config.Host,
+		InstanceURL:  p.instanceURL(),
+	}
+}
+
+// signupEmailAddress returns the address to email about a sign up request: people don't
+// necessarily confirm their email address while they're waiting to be approved.
+func signupEmailAddress(user *gtsmodel.User) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	return user.UnconfirmedEmail
+}
+
+// emailConfirm gives the user a fresh confirmation token, and emails them a link with it
+// so that they can confirm the email address they signed up with.
+func (p *processor) emailConfirm(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) error {
+	// nothing to do if the address is already confirmed, eg., for users created by an admin
+	if user.UnconfirmedEmail == "" || user.UnconfirmedEmail == user.Email {
+		return nil
+	}
+
+	user.ConfirmationToken = uuid.NewString()
+	user.ConfirmationSentAt = time.Now()
+	user.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return fmt.Errorf("emailConfirm: error updating user %s: %s", user.ID, err)
+	}
+
+	if err := p.emailSender.SendConfirmEmail(user.UnconfirmedEmail, email.ConfirmData{
+		Username:     account.Username,
+		InstanceHost: p.config.Host,
+		InstanceURL:  p.instanceURL(),
+		ConfirmLink:  util.GenerateURLForConfirmEmail(p.config.Protocol, p.config.Host, user.ConfirmationToken),
+	}); err != nil {
+		return fmt.Errorf("emailConfirm: %s", err)
+	}
+	return nil
+}
+
+// emailNewReport lets moderators know by email that a new report is waiting to be handled.
+func (p *processor) emailNewReport(ctx context.Context, report *gtsmodel.Report) error {
+	if report.Account == nil {
+		a, err := p.db.GetAccountByID(ctx, report.AccountID)
+		if err != nil {
+			return err
+		}
+		report.Account = a
+	}
+
+	if report.TargetAccount == nil {
+		a, err := p.db.GetAccountByID(ctx, report.TargetAccountID)
+		if err != nil {
+			return err
+		}
+		report.TargetAccount = a
+	}
+
+	return p.emailModerators(ctx, gtsmodel.NotificationAdminReport, func(moderator *gtsmodel.User, moderatorAccount *gtsmodel.Account) error {
+		return p.emailSender.SendNewReportEmail(moderator.Email, email.NewReportData{
+			Username:     moderatorAccount.Username,
+			InstanceHost: p.config.Host,
+			InstanceURL:  p.instanceURL(),
+			ReporterAcct: emailAcct(report.Account),
+			TargetAcct:   emailAcct(report.TargetAccount),
+			Comment:      report.Comment,
+			AdminURL:     p.instanceURL() + "/admin",
+		})
+	})
+}
+
+// emailNewSignup lets moderators know by email that someone signed up and is waiting for approval.
+func (p *processor) emailNewSignup(ctx context.Context, user *gtsmodel.User, account *gtsmodel.Account) error {
+	return p.emailModerators(ctx, gtsmodel.NotificationAdminSignUp, func(moderator *gtsmodel.User, moderatorAccount *gtsmodel.Account) error {
+		return p.emailSender.SendNewSignupEmail(moderator.Email, email.NewSignupData{
+			Username:       moderatorAccount.Username,
+			InstanceHost:   p.config.Host,
+			InstanceURL:    p.instanceURL(),
+			SignupUsername: account.Username,
+			SignupEmail:    signupEmailAddress(user),
+			SignupReason:   account.Reason,
+			AdminURL:       p.instanceURL() + "/admin",
+		})
+	})
+}
+
+// emailModerators calls send for every local admin and moderator who has a confirmed email address,
+// and who hasn't turned off notifications of the given type.
+func (p *processor) emailModerators(ctx context.Context, notificationType gtsmodel.NotificationType, send func(moderator *gtsmodel.User, moderatorAccount *gtsmodel.Account) error) error {
+	admins := []*gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "admin", Value: true}}, &admins); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting admin users: %s", err)
+	}
+
+	moderators := []*gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "moderator", Value: true}}, &moderators); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting moderator users: %s", err)
+	}
+
+	// admins are usually moderators too, so make sure nobody gets the same email twice
+	emailed := make(map[string]bool)
+	errs := []string{}
+	for _, moderator := range append(admins, moderators...) {
+		if emailed[moderator.ID] || moderator.Email == "" || moderator.ConfirmedAt.IsZero() {
+			continue
+		}
+		emailed[moderator.ID] = true
+
+		moderatorAccount, err := p.db.GetAccountByID(ctx, moderator.AccountID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error getting account %s: %s", moderator.AccountID, err))
+			continue
+		}
+
+		if wanted, err := p.notificationWanted(ctx, moderatorAccount.ID, notificationType); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		} else if !wanted {
+			continue
+		}
+
+		if err := send(moderator, moderatorAccount); err != nil {
+			errs = append(errs, fmt.Sprintf("error emailing user %s: %s", moderator.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// instanceURL returns the URL of this instance, eg., https://example.org.
+func (p *processor) instanceURL() string {
+	return fmt.Sprintf("%s://%s", p.config.Protocol, p.config.Host)
+}
+
+// emailAcct returns the account in the username@domain form people know from their clients, or just the username for local accounts.
+func emailAcct(account *gtsmodel.Account) string {
+	if account.Domain == "" {
+		return account.Username
+	}
+	return account.Username + "@" + account.Domain
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -32,13 +33,18 @@ import (
 )
 
 func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
-	l := p.log.WithFields(logrus.Fields{
+	l := p.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":         "processFromFederator",
 		"federatorMsg": fmt.Sprintf("%+v", federatorMsg),
 	})
 
 	l.Trace("entering function PROCESS FROM FEDERATOR")
 
+	// give the inbox filter a chance to veto the activity before anything else happens with it
+	if vetoed, err := p.filterInbound(ctx, federatorMsg); err != nil || vetoed {
+		return err
+	}
+
 	switch federatorMsg.APActivityType {
 	case ap.ActivityCreate:
 		// CREATE
@@ -55,6 +61,10 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 				return err
 			}
 
+			if stop, err := p.checkSpam(ctx, status); err != nil || stop {
+				return err
+			}
+
 			if err := p.timelineStatus(ctx, status); err != nil {
 				return err
 			}
@@ -127,6 +137,18 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 				return fmt.Errorf("error dereferencing announce from federator: %s", err)
 			}
 
+			// boosts of our statuses have to respect the boost policy of the status
+			if incomingAnnounce.BoostOf.Local {
+				boostable, err := p.filter.StatusBoostable(ctx, incomingAnnounce.BoostOf, incomingAnnounce.Account)
+				if err != nil {
+					return fmt.Errorf("error checking if status is boostable: %s", err)
+				}
+				if !boostable {
+					l.WithField("announceURI", incomingAnnounce.URI).Debug("announce violates boost policy, ignoring it")
+					return nil
+				}
+			}
+
 			incomingAnnounceID, err := id.NewULIDFromTime(incomingAnnounce.CreatedAt)
 			if err != nil {
 				return err
@@ -158,6 +180,11 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 			if err := p.timelineManager.WipeStatusesFromAccountID(ctx, block.TargetAccountID, block.AccountID); err != nil {
 				return err
 			}
+
+			// the block removed any follows between the accounts, so the blocking account can't be in lists anymore
+			if err := p.removeFromLists(ctx, block.TargetAccountID, block.AccountID); err != nil {
+				return err
+			}
 			// TODO: same with notifications
 			// TODO: same with bookmarks
 		}
@@ -174,6 +201,52 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 			if _, err := p.federator.EnrichRemoteAccount(ctx, federatorMsg.ReceivingAccount.Username, incomingAccount); err != nil {
 				return fmt.Errorf("error enriching updated account from federator: %s", err)
 			}
+		case ap.ObjectNote:
+			// UPDATE A STATUS
+			// the status itself was already updated when the activity came in, so just dereference any
+			// new attachments or mentions, and make sure timelines and streams show the new version
+			updatedStatus, ok := federatorMsg.GTSModel.(*gtsmodel.Status)
+			if !ok {
+				return errors.New("note was not parseable as *gtsmodel.Status")
+			}
+
+			updatedStatus, err := p.federator.EnrichRemoteStatus(ctx, federatorMsg.ReceivingAccount.Username, updatedStatus, false)
+			if err != nil {
+				return fmt.Errorf("error enriching updated status from federator: %s", err)
+			}
+
+			if err := p.refreshStatusInTimelines(ctx, updatedStatus); err != nil {
+				return err
+			}
+		}
+	case ap.ActivityMove:
+		// MOVE
+		switch federatorMsg.APObjectType {
+		case ap.ObjectProfile:
+			// MOVE AN ACCOUNT
+			originAccount, ok := federatorMsg.GTSModel.(*gtsmodel.Account)
+			if !ok {
+				return errors.New("profile was not parseable as *gtsmodel.Account")
+			}
+
+			if federatorMsg.APIri == nil {
+				return errors.New("move had no target")
+			}
+
+			return p.processRemoteMove(ctx, federatorMsg.ReceivingAccount, originAccount, federatorMsg.APIri)
+		}
+	case ap.ActivityFlag:
+		// FLAG
+		switch federatorMsg.APObjectType {
+		case ap.ObjectProfile:
+			// FLAG AN ACCOUNT
+			// the report was already stored when the activity came in, so just let the admins know about it
+			report, ok := federatorMsg.GTSModel.(*gtsmodel.Report)
+			if !ok {
+				return errors.New("flag was not parseable as *gtsmodel.Report")
+			}
+
+			return p.notifyReport(ctx, report)
 		}
 	case ap.ActivityDelete:
 		// DELETE
@@ -227,7 +300,71 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 			// ACCEPT A FOLLOW
 			// nothing to do here
 		}
+	case ap.ActivityReject:
+		// REJECT
+		switch federatorMsg.APObjectType {
+		case ap.ActivityFollow:
+			// REJECT A FOLLOW
+			// our follow request has already been removed, so just let the requester know
+			followRequest, ok := federatorMsg.GTSModel.(*gtsmodel.FollowRequest)
+			if !ok {
+				return errors.New("reject was not parseable as *gtsmodel.FollowRequest")
+			}
+
+			return p.notifyFollowReject(ctx, followRequest)
+		case ap.ObjectNote:
+			// REJECT A REPLY
+			// the reply wasn't permitted by the reply policy of the status it replied to, so let the replier know
+			reply, ok := federatorMsg.GTSModel.(*gtsmodel.Status)
+			if !ok {
+				return errors.New("reply was not parseable as *gtsmodel.Status")
+			}
+
+			return p.federateRejectReply(ctx, reply)
+		}
 	}
 
 	return nil
 }
+
+// processRemoteMove handles the move of a remote account to the account at targetIRI, re-pointing any local followers.
+func (p *processor) processRemoteMove(ctx context.Context, receivingAccount *gtsmodel.Account, originAccount *gtsmodel.Account, targetIRI *url.URL) error {
+	var targetAccount *gtsmodel.Account
+	if targetIRI.Host == p.config.Host {
+		// the account is moving to us!
+		a, err := p.db.GetAccountByURI(ctx, targetIRI.String())
+		if err != nil {
+			return fmt.Errorf("processRemoteMove: error getting local target account %s: %s", targetIRI, err)
+		}
+		targetAccount = a
+	} else {
+		// always refresh the target, since an alias may have been added to it just before the move
+		a, _, err := p.federator.GetRemoteAccount(ctx, receivingAccount.Username, targetIRI, true)
+		if err != nil {
+			return fmt.Errorf("processRemoteMove: error dereferencing target account %s: %s", targetIRI, err)
+		}
+		targetAccount = a
+	}
+
+	// a move is only valid if the target account acknowledges the origin account as an alias,
+	// otherwise anyone could steal the followers of any other account they have control of
+	var acknowledged bool
+	for _, alias := range targetAccount.AlsoKnownAsURIs {
+		if alias == originAccount.URI {
+			acknowledged = true
+			break
+		}
+	}
+	if !acknowledged {
+		return fmt.Errorf("processRemoteMove: target account %s doesn't list %s as an alias, refusing move", targetAccount.URI, originAccount.URI)
+	}
+
+	if originAccount.MovedToAccountID != targetAccount.ID {
+		originAccount.MovedToAccountID = targetAccount.ID
+		if _, err := p.db.UpdateAccount(ctx, originAccount); err != nil {
+			return fmt.Errorf("processRemoteMove: error updating moved account %s: %s", originAccount.ID, err)
+		}
+	}
+
+	return p.moveFollowers(ctx, originAccount, targetAccount)
+}
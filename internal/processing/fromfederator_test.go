@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type FromFederatorTestSuite struct {
@@ -215,6 +216,53 @@ func (suite *FromFederatorTestSuite) TestProcessFave() {
 	suite.EqualValues([]string{"user"}, msg.Stream)
 }
 
+// TestProcessFaveFromSilencedDomain ensures that faves from accounts on a silenced domain don't
+// notify local accounts that don't follow the faving account.
+func (suite *FromFederatorTestSuite) TestProcessFaveFromSilencedDomain() {
+	favedAccount := suite.testAccounts["local_account_1"]
+	favedStatus := suite.testStatuses["local_account_1_status_1"]
+	favingAccount := testrig.NewTestAccounts()["remote_account_1"]
+
+	err := suite.db.Put(context.Background(), &gtsmodel.DomainPolicy{
+		ID:                 "01FQNB3J9YQ8VZ7V4A2DN5XW7C",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Silence:            true,
+	})
+	suite.NoError(err)
+
+	fave := &gtsmodel.StatusFave{
+		ID:              "01FGKJPXFTVQPG9YSSZ95ADS7Q",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       favingAccount.ID,
+		Account:         favingAccount,
+		TargetAccountID: favedAccount.ID,
+		TargetAccount:   favedAccount,
+		StatusID:        favedStatus.ID,
+		Status:          favedStatus,
+		URI:             favingAccount.URI + "/faves/aaaaaaaaaaaa",
+	}
+
+	err = suite.db.Put(context.Background(), fave)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromFederator(context.Background(), messages.FromFederator{
+		APObjectType:     ap.ActivityLike,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         fave,
+		ReceivingAccount: favedAccount,
+	})
+	suite.NoError(err)
+
+	// no notification should exist for the fave
+	err = suite.db.GetWhere(context.Background(), []db.Where{
+		{Key: "status_id", Value: favedStatus.ID},
+		{Key: "origin_account_id", Value: favingAccount.ID},
+	}, &gtsmodel.Notification{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 // TestProcessFaveWithDifferentReceivingAccount ensures that when an account receives a fave that's for
 // another account in their AP inbox, a notification isn't streamed to the receiving account.
 //
@@ -338,7 +386,7 @@ func (suite *FromFederatorTestSuite) TestProcessAccountDelete() {
 	suite.False(zorkFollowsSatan)
 
 	// no statuses from foss satan should be left in the database
-	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, "", false, false)
+	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", "", false, false, "")
 	suite.ErrorIs(err, db.ErrNoEntries)
 	suite.Empty(dbStatuses)
 
@@ -486,6 +534,119 @@ func (suite *FromFederatorTestSuite) TestProcessFollowRequestUnlocked() {
 	suite.Equal("Accept", accept.Type)
 }
 
+func (suite *FromFederatorTestSuite) TestProcessFollowReject() {
+	ctx := context.Background()
+
+	// local_account_1 has requested to follow remote_account_1
+	requestingAccount := suite.testAccounts["local_account_1"]
+	rejectingAccount := suite.testAccounts["remote_account_1"]
+
+	stream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), requestingAccount, "user")
+	suite.NoError(errWithCode)
+
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01FQ3D9Z5V1V6N4Y0M3CQ0TC2E",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: rejectingAccount.ID,
+		ShowReblogs:     true,
+		URI:             fmt.Sprintf("%s/follow/01FQ3D9Z5V1V6N4Y0M3CQ0TC2E", requestingAccount.URI),
+	}
+	suite.NoError(suite.db.Put(ctx, followRequest))
+
+	// reject the follow request as though the reject had passed through the federating db already
+	rejected, err := suite.db.RejectFollowRequest(ctx, requestingAccount.ID, rejectingAccount.ID)
+	suite.NoError(err)
+	suite.Equal(followRequest.ID, rejected.ID)
+
+	requested, err := suite.db.IsFollowRequested(ctx, requestingAccount, rejectingAccount)
+	suite.NoError(err)
+	suite.False(requested)
+
+	err = suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ActivityFollow,
+		APActivityType:   ap.ActivityReject,
+		GTSModel:         rejected,
+		ReceivingAccount: requestingAccount,
+	})
+	suite.NoError(err)
+
+	// the requester should be notified of the rejection
+	msg := <-stream.Messages
+	suite.Equal("notification", msg.Event)
+	notif := &model.Notification{}
+	err = json.Unmarshal([]byte(msg.Payload), notif)
+	suite.NoError(err)
+	suite.Equal("follow_reject", notif.Type)
+	suite.Equal(rejectingAccount.ID, notif.Account.ID)
+
+	// a second reject of the same request has nothing left to remove
+	_, err = suite.db.RejectFollowRequest(ctx, requestingAccount.ID, rejectingAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *FromFederatorTestSuite) TestProcessStatusUpdate() {
+	ctx := context.Background()
+
+	followingAccount := suite.testAccounts["local_account_1"]
+
+	// use a fresh copy of the editing account, since the shared one is deleted by TestProcessAccountDelete
+	editingAccount, err := suite.db.UpdateAccount(ctx, testrig.NewTestAccounts()["remote_account_1"])
+	suite.NoError(err)
+
+	stream, errWithCode := suite.processor.OpenStreamForAccount(ctx, followingAccount, "user")
+	suite.NoError(errWithCode)
+
+	follow := &gtsmodel.Follow{
+		ID:              "01FQ8M4S4Q0YRHZ8A7PBFTKVXE",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       followingAccount.ID,
+		TargetAccountID: editingAccount.ID,
+		ShowReblogs:     true,
+		URI:             fmt.Sprintf("%s/follow/01FQ8M4S4Q0YRHZ8A7PBFTKVXE", followingAccount.URI),
+	}
+	suite.NoError(suite.db.Put(ctx, follow))
+
+	// an edited status, as though the update had passed through the federating db already
+	editedStatus := &gtsmodel.Status{
+		ID:                  "01FQ8M6Y0XKM5X4AKR7NAJ1DNH",
+		URI:                 "https://fossbros-anonymous.io/users/foss_satan/statuses/01FQ8M6Y0XKM5X4AKR7NAJ1DNH",
+		URL:                 "https://fossbros-anonymous.io/@foss_satan/statuses/01FQ8M6Y0XKM5X4AKR7NAJ1DNH",
+		Content:             "this is the edited version",
+		CreatedAt:           time.Now().Add(-1 * time.Hour),
+		UpdatedAt:           time.Now(),
+		EditedAt:            time.Now(),
+		AccountID:           editingAccount.ID,
+		AccountURI:          editingAccount.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           true,
+		Boostable:           true,
+		Replyable:           true,
+		Likeable:            true,
+	}
+	suite.NoError(suite.db.PutStatus(ctx, editedStatus))
+
+	err = suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityUpdate,
+		GTSModel:         editedStatus,
+		ReceivingAccount: followingAccount,
+	})
+	suite.NoError(err)
+
+	// the follower should be sent the new version of the status
+	msg := <-stream.Messages
+	suite.Equal("status.update", msg.Event)
+	apiStatus := &model.Status{}
+	err = json.Unmarshal([]byte(msg.Payload), apiStatus)
+	suite.NoError(err)
+	suite.Equal(editedStatus.ID, apiStatus.ID)
+	suite.Equal("this is the edited version", apiStatus.Content)
+}
+
 func TestFromFederatorTestSuite(t *testing.T) {
 	suite.Run(t, &FromFederatorTestSuite{})
 }
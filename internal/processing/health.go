@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+const (
+	// healthStorageKey is written to, read back and deleted from storage to check that it's usable.
+	healthStorageKey = "health/readyz"
+	// healthQueueThreshold is the fraction of a work queue's capacity above which the queue counts as backed up.
+	healthQueueThreshold = 0.9
+)
+
+const (
+	healthCheckDB      = "db"
+	healthCheckStorage = "storage"
+	healthCheckQueues  = "queues"
+)
+
+func (p *processor) HealthReadyGet(ctx context.Context) *apimodel.Readiness {
+	checks := map[string]error{
+		healthCheckDB:      p.db.IsHealthy(ctx),
+		healthCheckStorage: p.checkStorage(),
+		healthCheckQueues:  p.checkQueues(),
+	}
+
+	readiness := &apimodel.Readiness{
+		Ready:  true,
+		Checks: make(map[string]string, len(checks)),
+	}
+	for name, err := range checks {
+		if err != nil {
+			p.log.WithContext(ctx).WithError(err).Warnf("readiness check %s failed", name)
+			readiness.Ready = false
+			readiness.Checks[name] = err.Error()
+			continue
+		}
+		readiness.Checks[name] = "ok"
+	}
+
+	return readiness
+}
+
+// checkStorage makes sure that the storage backend can be written to and read from.
+func (p *processor) checkStorage() error {
+	probe := []byte("ok")
+	if err := p.storage.Put(healthStorageKey, probe); err != nil {
+		return fmt.Errorf("error writing to storage: %s", err)
+	}
+
+	b, err := p.storage.Get(healthStorageKey)
+	if err != nil {
+		return fmt.Errorf("error reading from storage: %s", err)
+	}
+	if !bytes.Equal(b, probe) {
+		return errors.New("storage returned something other than what was written")
+	}
+
+	if err := p.storage.Delete(healthStorageKey); err != nil {
+		return fmt.Errorf("error deleting from storage: %s", err)
+	}
+
+	return nil
+}
+
+// checkQueues makes sure that the processor is still taking messages off its queues, and that they're not backed up.
+func (p *processor) checkQueues() error {
+	select {
+	case <-p.stop:
+		return errors.New("processor is stopped")
+	default:
+	}
+
+	if l, c := len(p.fromClientAPI), cap(p.fromClientAPI); float64(l) >= float64(c)*healthQueueThreshold {
+		return fmt.Errorf("client api queue is backed up: %d of %d slots in use", l, c)
+	}
+
+	if l, c := len(p.fromFederator), cap(p.fromFederator); float64(l) >= float64(c)*healthQueueThreshold {
+		return fmt.Errorf("federator queue is backed up: %d of %d slots in use", l, c)
+	}
+
+	return nil
+}
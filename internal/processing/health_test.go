@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type HealthTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *HealthTestSuite) TestHealthReady() {
+	readiness := suite.processor.HealthReadyGet(context.Background())
+	suite.True(readiness.Ready)
+	suite.Equal(map[string]string{
+		"db":      "ok",
+		"storage": "ok",
+		"queues":  "ok",
+	}, readiness.Checks)
+
+	// the storage probe should have been cleaned up after itself
+	has, err := suite.storage.Has("health/readyz")
+	suite.NoError(err)
+	suite.False(has)
+}
+
+func (suite *HealthTestSuite) TestHealthReadyProcessorStopped() {
+	processor := processing.NewProcessor(suite.config, suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaHandler, suite.storage, suite.timelineManager, suite.db, testrig.NewTestFieldVerifier(), testrig.NewTestWebPushSender(suite.db), suite.log)
+	suite.NoError(processor.Start(context.Background()))
+	suite.NoError(processor.Stop())
+
+	readiness := processor.HealthReadyGet(context.Background())
+	suite.False(readiness.Ready)
+	suite.Equal("ok", readiness.Checks["db"])
+	suite.Equal("ok", readiness.Checks["storage"])
+	suite.Equal("processor is stopped", readiness.Checks["queues"])
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
@@ -0,0 +1,138 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/inboxfilter"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// filterInbound runs an incoming federated activity through the inbox filter chain, before any of its side effects
+// are processed. It returns true if the activity was vetoed by one of the checks, in which case anything it already
+// put in the database has been removed again, and it shouldn't be processed any further.
+//
+// If the activity can't be checked, it's let through rather than being held up by a broken check.
+func (p *processor) filterInbound(ctx context.Context, federatorMsg messages.FromFederator) (bool, error) {
+	l := p.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":           "filterInbound",
+		"apObjectType":   federatorMsg.APObjectType,
+		"apActivityType": federatorMsg.APActivityType,
+	})
+
+	veto, err := p.inboxFilter.Filter(ctx, federatorMsg)
+	if err != nil {
+		l.WithError(err).Error("error filtering incoming activity; letting it through")
+		return false, nil
+	}
+
+	if veto == nil {
+		return false, nil
+	}
+
+	originAccountID := inboundOriginAccountID(federatorMsg)
+	l.WithFields(logrus.Fields{
+		"check":           veto.Check,
+		"reason":          veto.Reason,
+		"originAccountID": originAccountID,
+	}).Info("rejected incoming activity")
+
+	if err := p.removeInbound(ctx, federatorMsg); err != nil {
+		return true, fmt.Errorf("filterInbound: error removing rejected activity: %s", err)
+	}
+
+	if !p.config.InboxFilterConfig.ReportRejections || originAccountID == "" {
+		return true, nil
+	}
+
+	return true, p.reportInbound(ctx, federatorMsg, veto, originAccountID)
+}
+
+// removeInbound removes whatever an incoming activity has already created in the database,
+// before it could be processed.
+func (p *processor) removeInbound(ctx context.Context, federatorMsg messages.FromFederator) error {
+	switch m := federatorMsg.GTSModel.(type) {
+	case *gtsmodel.Status:
+		return p.deleteUnprocessedStatus(ctx, m)
+	case *gtsmodel.FollowRequest:
+		return p.db.DeleteByID(ctx, m.ID, &gtsmodel.FollowRequest{})
+	case *gtsmodel.StatusFave:
+		return p.db.DeleteByID(ctx, m.ID, &gtsmodel.StatusFave{})
+	case *gtsmodel.Block:
+		return p.db.DeleteByID(ctx, m.ID, &gtsmodel.Block{})
+	}
+	return nil
+}
+
+// reportInbound files a report from the instance account against the origin of a rejected activity,
+// so that moderators know about it.
+func (p *processor) reportInbound(ctx context.Context, federatorMsg messages.FromFederator, veto *inboxfilter.Veto, originAccountID string) error {
+	instanceAccount, err := p.db.GetLocalAccountByUsername(ctx, p.config.Host)
+	if err != nil {
+		return fmt.Errorf("reportInbound: error getting instance account: %s", err)
+	}
+
+	reportID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             util.GenerateURIForReport(p.config.Protocol, p.config.Host, reportID),
+		AccountID:       instanceAccount.ID,
+		Account:         instanceAccount,
+		TargetAccountID: originAccountID,
+		StatusIDs:       []string{},
+		Comment:         fmt.Sprintf("Incoming %s %s was rejected by the %s check: %s", federatorMsg.APActivityType, federatorMsg.APObjectType, veto.Check, veto.Reason),
+		Category:        gtsmodel.ReportCategorySpam,
+	}
+	if err := p.db.Put(ctx, report); err != nil {
+		return fmt.Errorf("reportInbound: error putting report: %s", err)
+	}
+
+	return p.notifyReport(ctx, report)
+}
+
+// inboundOriginAccountID returns the ID of the account that an incoming activity came from, if it can be worked out.
+func inboundOriginAccountID(federatorMsg messages.FromFederator) string {
+	switch m := federatorMsg.GTSModel.(type) {
+	case *gtsmodel.Status:
+		return m.AccountID
+	case *gtsmodel.FollowRequest:
+		return m.AccountID
+	case *gtsmodel.Follow:
+		return m.AccountID
+	case *gtsmodel.StatusFave:
+		return m.AccountID
+	case *gtsmodel.Block:
+		return m.AccountID
+	case *gtsmodel.Report:
+		return m.AccountID
+	case *gtsmodel.Account:
+		return m.ID
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InboxFilterTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *InboxFilterTestSuite) SetupTest() {
+	suite.ProcessingStandardTestSuite.SetupTest()
+	suite.config.InboxFilterConfig.RejectKeywords = []string{"cheap followers"}
+
+	// swap in a new processor, so that it picks up the inbox filter config
+	if err := suite.processor.Stop(); err != nil {
+		panic(err)
+	}
+	suite.processor = processing.NewProcessor(suite.config, suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaHandler, suite.storage, suite.timelineManager, suite.db, testrig.NewTestFieldVerifier(), testrig.NewTestWebPushSender(suite.db), suite.log)
+	if err := suite.processor.Start(context.Background()); err != nil {
+		panic(err)
+	}
+}
+
+func (suite *InboxFilterTestSuite) putRemoteStatus(text string) *gtsmodel.Status {
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	status := &gtsmodel.Status{
+		ID:                  "01FQ4R3XW6N8JBN4PPJ8XKBDN6",
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		URI:                 "http://fossbros-anonymous.io/users/foss_satan/statuses/01FQ4R3XW6N8JBN4PPJ8XKBDN6",
+		URL:                 "http://fossbros-anonymous.io/@foss_satan/01FQ4R3XW6N8JBN4PPJ8XKBDN6",
+		Content:             "<p>" + text + "</p>",
+		AccountID:           remoteAccount.ID,
+		AccountURI:          remoteAccount.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           true,
+		Boostable:           true,
+		Replyable:           true,
+		Likeable:            true,
+	}
+	suite.NoError(suite.db.PutStatus(context.Background(), status))
+
+	err := suite.processor.ProcessFromFederator(context.Background(), messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         status,
+		ReceivingAccount: suite.testAccounts["local_account_1"],
+	})
+	suite.NoError(err)
+
+	return status
+}
+
+func (suite *InboxFilterTestSuite) TestStatusAllowed() {
+	status := suite.putRemoteStatus("hello there")
+
+	suite.NoError(suite.db.GetByID(context.Background(), status.ID, &gtsmodel.Status{}))
+	err := suite.db.GetWhere(context.Background(), []db.Where{{Key: "target_account_id", Value: status.AccountID}}, &gtsmodel.Report{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *InboxFilterTestSuite) TestStatusRejectedAndReported() {
+	status := suite.putRemoteStatus("get CHEAP followers here")
+
+	// the status should be gone again
+	err := suite.db.GetByID(context.Background(), status.ID, &gtsmodel.Status{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// and the moderators should have a report about it from the instance account
+	report := &gtsmodel.Report{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "target_account_id", Value: status.AccountID}}, report))
+	instanceAccount, err := suite.db.GetLocalAccountByUsername(context.Background(), suite.config.Host)
+	suite.NoError(err)
+	suite.Equal(instanceAccount.ID, report.AccountID)
+	suite.Contains(report.Comment, "keyword")
+	suite.Contains(report.Comment, "cheap followers")
+}
+
+func (suite *InboxFilterTestSuite) TestStatusRejectedNotReported() {
+	suite.config.InboxFilterConfig.ReportRejections = false
+	status := suite.putRemoteStatus("get cheap followers here")
+
+	err := suite.db.GetByID(context.Background(), status.ID, &gtsmodel.Status{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "target_account_id", Value: status.AccountID}}, &gtsmodel.Report{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestInboxFilterTestSuite(t *testing.T) {
+	suite.Run(t, &InboxFilterTestSuite{})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
@@ -44,7 +45,21 @@ func (p *processor) InstanceGet(ctx context.Context, domain string) (*apimodel.I
 	return ai, nil
 }
 
-func (p *processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.Instance, gtserror.WithCode) {
+func (p *processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gtserror.WithCode) {
+	i := &gtsmodel.Instance{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: p.config.Host}}, i); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance %s: %s", p.config.Host, err))
+	}
+
+	ai, err := p.tc.InstanceToMastoV2(ctx, i)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
+	}
+
+	return ai, nil
+}
+
+func (p *processor) InstancePatch(ctx context.Context, authed *oauth.Auth, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.Instance, gtserror.WithCode) {
 	// fetch the instance entry from the db for processing
 	i := &gtsmodel.Instance{}
 	if err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: p.config.Host}}, i); err != nil {
@@ -131,6 +146,14 @@ func (p *processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		i.Terms = text.SanitizeHTML(*form.Terms) // html is OK in site terms, but we should sanitize it
 	}
 
+	// validate & update site custom css if it's set on the form
+	if form.CustomCSS != nil {
+		if err := validate.SiteCustomCSS(*form.CustomCSS); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		i.CustomCSS = *form.CustomCSS
+	}
+
 	// process avatar if provided
 	if form.Avatar != nil && form.Avatar.Size != 0 {
 		_, err := p.accountProcessor.UpdateAvatar(ctx, form.Avatar, ia.ID)
@@ -147,9 +170,18 @@ func (p *processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		}
 	}
 
+	// process thumbnail if provided; the thumbnail is the instance header, so this replaces it
+	if form.Thumbnail != nil && form.Thumbnail.Size != 0 {
+		_, err := p.accountProcessor.UpdateHeader(ctx, form.Thumbnail, ia.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, "error processing thumbnail")
+		}
+	}
+
 	if err := p.db.UpdateByPrimaryKey(ctx, i); err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating instance %s: %s", p.config.Host, err))
 	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionUpdate, gtsmodel.AdminAuditLogTargetInstance, i.ID, i.Domain)
 
 	ai, err := p.tc.InstanceToMasto(ctx, i)
 	if err != nil {
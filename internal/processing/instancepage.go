@@ -0,0 +1,172 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *processor) getInstancePage(ctx context.Context, slug string) (*gtsmodel.InstancePage, gtserror.WithCode) {
+	page := &gtsmodel.InstancePage{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "slug", Value: slug}}, page); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no page with slug %s", slug))
+	}
+	return page, nil
+}
+
+func (p *processor) InstancePageGet(ctx context.Context, slug string) (*apimodel.InstancePage, gtserror.WithCode) {
+	page, errWithCode := p.getInstancePage(ctx, slug)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoPage, err := p.tc.InstancePageToMasto(ctx, page, false)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoPage, nil
+}
+
+func (p *processor) AdminInstancePagesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.InstancePage, gtserror.WithCode) {
+	pages := []*gtsmodel.InstancePage{}
+	if err := p.db.GetAll(ctx, &pages); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	sort.Slice(pages, func(i, j int) bool { return pages[i].Slug < pages[j].Slug })
+
+	mastoPages := make([]*apimodel.InstancePage, 0, len(pages))
+	for _, page := range pages {
+		mastoPage, err := p.tc.InstancePageToMasto(ctx, page, true)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoPages = append(mastoPages, mastoPage)
+	}
+
+	return mastoPages, nil
+}
+
+func (p *processor) AdminInstancePagePut(ctx context.Context, authed *oauth.Auth, slug string, form *apimodel.InstancePageUpdateRequest) (*apimodel.InstancePage, gtserror.WithCode) {
+	if err := validate.InstancePageSlug(slug); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.InstancePageTitle(form.Title); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.InstancePageContent(form.Content); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// render the content into html now, so it doesn't have to be done every time the page is viewed
+	var content string
+	switch form.ContentType {
+	case "", "text/markdown":
+		form.ContentType = "text/markdown"
+		content = p.formatter.FromMarkdown(ctx, form.Content, nil, nil)
+	case "text/html":
+		content = text.SanitizeHTML(form.Content)
+	default:
+		err := errors.New("content type must be one of text/markdown or text/html")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	page, errWithCode := p.getInstancePage(ctx, slug)
+	if errWithCode != nil && errWithCode.Code() != http.StatusNotFound {
+		return nil, errWithCode
+	}
+
+	if page == nil {
+		pageID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		page = &gtsmodel.InstancePage{
+			ID:          pageID,
+			Slug:        slug,
+			Title:       text.RemoveHTML(form.Title),
+			Source:      form.Content,
+			ContentType: form.ContentType,
+			Content:     content,
+		}
+		if err := p.db.Put(ctx, page); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetInstancePage, page.ID, page.Slug)
+	} else {
+		page.UpdatedAt = time.Now()
+		page.Title = text.RemoveHTML(form.Title)
+		page.Source = form.Content
+		page.ContentType = form.ContentType
+		page.Content = content
+		if err := p.db.UpdateByPrimaryKey(ctx, page); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionUpdate, gtsmodel.AdminAuditLogTargetInstancePage, page.ID, page.Slug)
+	}
+
+	mastoPage, err := p.tc.InstancePageToMasto(ctx, page, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoPage, nil
+}
+
+func (p *processor) AdminInstancePageDelete(ctx context.Context, authed *oauth.Auth, slug string) (*apimodel.InstancePage, gtserror.WithCode) {
+	page, errWithCode := p.getInstancePage(ctx, slug)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoPage, err := p.tc.InstancePageToMasto(ctx, page, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteByID(ctx, page.ID, &gtsmodel.InstancePage{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionDelete, gtsmodel.AdminAuditLogTargetInstancePage, page.ID, page.Slug)
+
+	return mastoPage, nil
+}
@@ -0,0 +1,124 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type InstancePageTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *InstancePageTestSuite) adminAuth() *oauth.Auth {
+	return &oauth.Auth{
+		User:    suite.testUsers["admin_account"],
+		Account: suite.testAccounts["admin_account"],
+	}
+}
+
+func (suite *InstancePageTestSuite) TestPutMarkdownPage() {
+	ctx := context.Background()
+
+	page, errWithCode := suite.processor.AdminInstancePagePut(ctx, suite.adminAuth(), "privacy", &apimodel.InstancePageUpdateRequest{
+		Title:   "Privacy <b>Policy</b>",
+		Content: "## What we store\n\nNot much. <script>alert('hi')</script>",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("privacy", page.Slug)
+	suite.Equal("Privacy Policy", page.Title)
+	suite.Equal("http://localhost:8080/privacy", page.URL)
+	suite.Equal("text/markdown", page.ContentType)
+	suite.Equal("<h2>What we store</h2><p>Not much.</p>", page.Content)
+
+	// replacing the page keeps the slug, but swaps everything else
+	page, errWithCode = suite.processor.AdminInstancePagePut(ctx, suite.adminAuth(), "privacy", &apimodel.InstancePageUpdateRequest{
+		Title:       "Privacy Policy",
+		Content:     "<p>We store <em>nothing</em>.</p>",
+		ContentType: "text/html",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("text/html", page.ContentType)
+	suite.Equal("<p>We store <em>nothing</em>.</p>", page.Content)
+
+	// the public view of the page leaves out the source
+	publicPage, errWithCode := suite.processor.InstancePageGet(ctx, "privacy")
+	suite.NoError(errWithCode)
+	suite.Equal(page.Content, publicPage.Content)
+	suite.Empty(publicPage.Source)
+	suite.Empty(publicPage.ContentType)
+
+	// and the page is linked from the instance
+	instance, errWithCode := suite.processor.InstanceGet(ctx, suite.config.Host)
+	suite.NoError(errWithCode)
+	suite.Equal([]apimodel.InstancePageLink{{Slug: "privacy", Title: "Privacy Policy", URL: "http://localhost:8080/privacy"}}, instance.Pages)
+}
+
+func (suite *InstancePageTestSuite) TestPutCustomPage() {
+	ctx := context.Background()
+
+	page, errWithCode := suite.processor.AdminInstancePagePut(ctx, suite.adminAuth(), "code-of-conduct", &apimodel.InstancePageUpdateRequest{
+		Title:   "Code of Conduct",
+		Content: "Be nice.",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("http://localhost:8080/pages/code-of-conduct", page.URL)
+
+	pages, errWithCode := suite.processor.AdminInstancePagesGet(ctx, suite.adminAuth())
+	suite.NoError(errWithCode)
+	suite.Len(pages, 1)
+	suite.Equal("Be nice.", pages[0].Source)
+
+	deleted, errWithCode := suite.processor.AdminInstancePageDelete(ctx, suite.adminAuth(), "code-of-conduct")
+	suite.NoError(errWithCode)
+	suite.Equal("code-of-conduct", deleted.Slug)
+
+	_, errWithCode = suite.processor.InstancePageGet(ctx, "code-of-conduct")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *InstancePageTestSuite) TestPutInvalidPage() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.processor.AdminInstancePagePut(ctx, suite.adminAuth(), "Code_Of_Conduct", &apimodel.InstancePageUpdateRequest{
+		Title: "Code of Conduct",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AdminInstancePagePut(ctx, suite.adminAuth(), "rules", &apimodel.InstancePageUpdateRequest{
+		Content: "No title.",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AdminInstancePagePut(ctx, suite.adminAuth(), "rules", &apimodel.InstancePageUpdateRequest{
+		Title:       "Rules",
+		ContentType: "text/plain",
+	})
+	suite.EqualError(errWithCode, "content type must be one of text/markdown or text/html")
+}
+
+func TestInstancePageTestSuite(t *testing.T) {
+	suite.Run(t, new(InstancePageTestSuite))
+}
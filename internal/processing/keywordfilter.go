@@ -0,0 +1,270 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// htmlTag matches a single html tag in already-sanitized status content.
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// keywordFilter is a filter that's ready to be applied, with its keywords compiled to regular expressions.
+type keywordFilter struct {
+	filter   *gtsmodel.Filter
+	keywords []*regexp.Regexp // same order as filter.Keywords
+	masto    *apimodel.FilterV2
+}
+
+// getKeywordFilters returns the unexpired filters owned by the given account that apply in the given context.
+func (p *processor) getKeywordFilters(ctx context.Context, account *gtsmodel.Account, filterContext gtsmodel.FilterContext) ([]*keywordFilter, error) {
+	if account == nil {
+		return nil, nil
+	}
+
+	filters, err := p.db.GetFiltersForAccountID(ctx, account.ID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	keywordFilters := []*keywordFilter{}
+	for _, filter := range filters {
+		if !filterAppliesIn(filter, filterContext, now) || len(filter.Keywords) == 0 {
+			continue
+		}
+
+		kf := &keywordFilter{
+			filter:   filter,
+			keywords: make([]*regexp.Regexp, 0, len(filter.Keywords)),
+		}
+		for _, keyword := range filter.Keywords {
+			re, err := keywordRegexp(keyword)
+			if err != nil {
+				return nil, err
+			}
+			kf.keywords = append(kf.keywords, re)
+		}
+
+		kf.masto, err = p.tc.FilterToMastoV2(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		keywordFilters = append(keywordFilters, kf)
+	}
+
+	return keywordFilters, nil
+}
+
+// applyKeywordFilters checks the given status against the given filters, on behalf of the given account.
+//
+// If the status matches a filter with the hide action, false is returned and the status shouldn't be shown at all.
+// Otherwise, the returned status is a copy of the given status, marked with any warn filters that it matched, so
+// that statuses held in the timeline manager are never changed.
+func applyKeywordFilters(account *gtsmodel.Account, filters []*keywordFilter, status *apimodel.Status) (*apimodel.Status, bool) {
+	if len(filters) == 0 || status == nil {
+		return status, true
+	}
+
+	target := status
+	if status.Reblog != nil && status.Reblog.Status != nil {
+		target = status.Reblog.Status
+	}
+
+	if target.Account != nil && target.Account.ID == account.ID {
+		// people aren't filtered from their own statuses
+		return status, true
+	}
+
+	statusText := filterableText(target)
+	results := []apimodel.FilterResult{}
+	for _, kf := range filters {
+		matches := []string{}
+		for i, re := range kf.keywords {
+			if re.MatchString(statusText) {
+				matches = append(matches, kf.filter.Keywords[i].Keyword)
+			}
+		}
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		if kf.filter.Action == gtsmodel.FilterActionHide {
+			return nil, false
+		}
+
+		results = append(results, apimodel.FilterResult{
+			Filter:         *kf.masto,
+			KeywordMatches: matches,
+			StatusMatches:  []string{},
+		})
+	}
+
+	if len(results) == 0 {
+		return status, true
+	}
+
+	filtered := *status
+	filtered.Filtered = results
+	if status.Reblog != nil && status.Reblog.Status != nil {
+		reblogged := *status.Reblog.Status
+		reblogged.Filtered = results
+		filtered.Reblog = &apimodel.StatusReblogged{Status: &reblogged}
+	}
+	return &filtered, true
+}
+
+// filterStatuses applies the filters of the given account for the given context to the given statuses,
+// dropping hidden statuses and marking the rest. If the filters can't be loaded, the statuses are returned unchanged.
+func (p *processor) filterStatuses(ctx context.Context, account *gtsmodel.Account, filterContext gtsmodel.FilterContext, statuses []*apimodel.Status) []*apimodel.Status {
+	filters, err := p.getKeywordFilters(ctx, account, filterContext)
+	if err != nil {
+		p.log.WithContext(ctx).WithError(err).WithField("func", "filterStatuses").Error("error getting keyword filters")
+		return statuses
+	}
+
+	if len(filters) == 0 {
+		return statuses
+	}
+
+	filtered := make([]*apimodel.Status, 0, len(statuses))
+	for _, s := range statuses {
+		if s, ok := applyKeywordFilters(account, filters, s); ok {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// filterStatusValues is like filterStatuses, but for the slices of status values used by threads and account profiles.
+func (p *processor) filterStatusValues(ctx context.Context, account *gtsmodel.Account, filterContext gtsmodel.FilterContext, statuses []apimodel.Status) []apimodel.Status {
+	pointers := make([]*apimodel.Status, 0, len(statuses))
+	for i := range statuses {
+		pointers = append(pointers, &statuses[i])
+	}
+
+	filtered := []apimodel.Status{}
+	for _, s := range p.filterStatuses(ctx, account, filterContext, pointers) {
+		filtered = append(filtered, *s)
+	}
+	return filtered
+}
+
+// filterAppliesIn returns true if the given filter should be applied in the given context at the given time.
+func filterAppliesIn(filter *gtsmodel.Filter, filterContext gtsmodel.FilterContext, now time.Time) bool {
+	if !filter.ExpiresAt.IsZero() && !filter.ExpiresAt.After(now) {
+		return false
+	}
+
+	switch filterContext {
+	case gtsmodel.FilterContextHome:
+		return filter.ContextHome
+	case gtsmodel.FilterContextNotifications:
+		return filter.ContextNotifications
+	case gtsmodel.FilterContextPublic:
+		return filter.ContextPublic
+	case gtsmodel.FilterContextThread:
+		return filter.ContextThread
+	case gtsmodel.FilterContextAccount:
+		return filter.ContextAccount
+	}
+	return false
+}
+
+// keywordRegexp compiles the given keyword into a case-insensitive regular expression. For whole word keywords,
+// a word boundary is only demanded at the ends of the keyword that are themselves word characters, the same
+// way that Mastodon does it, so that eg. a keyword like "#tag" still matches "a #tag!".
+func keywordRegexp(keyword *gtsmodel.FilterKeyword) (*regexp.Regexp, error) {
+	const nonWord = `[^\p{L}\p{M}\p{N}_]`
+
+	pattern := regexp.QuoteMeta(keyword.Keyword)
+	if keyword.WholeWord {
+		if first, _ := utf8.DecodeRuneInString(keyword.Keyword); isWordRune(first) {
+			pattern = `(?:^|` + nonWord + `)` + pattern
+		}
+		if last, _ := utf8.DecodeLastRuneInString(keyword.Keyword); isWordRune(last) {
+			pattern = pattern + `(?:$|` + nonWord + `)`
+		}
+	}
+
+	return regexp.Compile(`(?i)` + pattern)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// filterableText returns the plain text of the given status that keywords are matched against:
+// its content warning, content, media descriptions, and poll options.
+func filterableText(status *apimodel.Status) string {
+	parts := []string{
+		status.SpoilerText,
+		// replace tags with spaces rather than removing them, so that paragraphs don't run together
+		html.UnescapeString(htmlTag.ReplaceAllString(status.Content, " ")),
+	}
+
+	for _, a := range status.MediaAttachments {
+		parts = append(parts, a.Description)
+	}
+
+	if status.Poll != nil {
+		for _, o := range status.Poll.Options {
+			parts = append(parts, o.Title)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// filterNotification applies the notifications filters of the given account to the status of the given notification.
+// It returns false if the notification shouldn't be delivered at all, because its status is hidden by a filter.
+func (p *processor) filterNotification(ctx context.Context, account *gtsmodel.Account, notification *apimodel.Notification) (*apimodel.Notification, bool) {
+	if notification.Status == nil {
+		return notification, true
+	}
+
+	filters, err := p.getKeywordFilters(ctx, account, gtsmodel.FilterContextNotifications)
+	if err != nil {
+		p.log.WithContext(ctx).WithError(err).WithField("func", "filterNotification").Error("error getting keyword filters")
+		return notification, true
+	}
+
+	status, ok := applyKeywordFilters(account, filters, notification.Status)
+	if !ok {
+		return nil, false
+	}
+
+	notification.Status = status
+	return notification, true
+}
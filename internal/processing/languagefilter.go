@@ -0,0 +1,53 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// filterLanguages removes statuses that aren't written in one of the languages the user has chosen
+// to see from the given slice. If the user hasn't chosen any languages, all statuses are kept.
+//
+// Statuses with no known language are always kept, as are the user's own statuses.
+func filterLanguages(user *gtsmodel.User, statuses []*apimodel.Status) []*apimodel.Status {
+	if user == nil || len(user.ChosenLanguages) == 0 {
+		return statuses
+	}
+
+	chosen := make(map[string]bool, len(user.ChosenLanguages))
+	for _, lang := range user.ChosenLanguages {
+		chosen[lang] = true
+	}
+
+	filtered := make([]*apimodel.Status, 0, len(statuses))
+	for _, s := range statuses {
+		// a boost is shown if the boosted status is in a chosen language
+		original := s
+		if s.Reblog != nil && s.Reblog.Status != nil {
+			original = s.Reblog.Status
+		}
+
+		if original.Language == "" || chosen[original.Language] || (s.Account != nil && s.Account.ID == user.AccountID) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,343 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// getOwnList returns the list with the given ID, if it's owned by the given account.
+func (p *processor) getOwnList(ctx context.Context, account *gtsmodel.Account, listID string) (*gtsmodel.List, gtserror.WithCode) {
+	list, err := p.db.GetListByID(ctx, listID)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no list with id %s", listID))
+	}
+
+	if list.AccountID != account.ID {
+		// don't let on that the list exists
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("list %s is not owned by account %s", listID, account.ID))
+	}
+
+	return list, nil
+}
+
+func (p *processor) listsToMasto(ctx context.Context, lists []*gtsmodel.List) ([]*apimodel.List, gtserror.WithCode) {
+	mastoLists := make([]*apimodel.List, 0, len(lists))
+	for _, list := range lists {
+		mastoList, err := p.tc.ListToMasto(ctx, list)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoLists = append(mastoLists, mastoList)
+	}
+	return mastoLists, nil
+}
+
+func (p *processor) ListsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.List, gtserror.WithCode) {
+	lists, err := p.db.GetListsForAccountID(ctx, authed.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.listsToMasto(ctx, lists)
+}
+
+func (p *processor) ListGet(ctx context.Context, authed *oauth.Auth, listID string) (*apimodel.List, gtserror.WithCode) {
+	list, errWithCode := p.getOwnList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoList, err := p.tc.ListToMasto(ctx, list)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoList, nil
+}
+
+func (p *processor) ListCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode) {
+	if form.RepliesPolicy == "" {
+		form.RepliesPolicy = string(gtsmodel.ListRepliesPolicyFollowed)
+	}
+
+	if errWithCode := validateListForm(form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	listID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	list := &gtsmodel.List{
+		ID:            listID,
+		Title:         text.RemoveHTML(form.Title),
+		AccountID:     authed.Account.ID,
+		Account:       authed.Account,
+		RepliesPolicy: gtsmodel.ListRepliesPolicy(form.RepliesPolicy),
+	}
+	if err := p.db.Put(ctx, list); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoList, err := p.tc.ListToMasto(ctx, list)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoList, nil
+}
+
+func (p *processor) ListUpdate(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode) {
+	list, errWithCode := p.getOwnList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.RepliesPolicy == "" {
+		form.RepliesPolicy = string(list.RepliesPolicy)
+	}
+
+	if errWithCode := validateListForm(form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	policyChanged := list.RepliesPolicy != gtsmodel.ListRepliesPolicy(form.RepliesPolicy)
+	list.Title = text.RemoveHTML(form.Title)
+	list.RepliesPolicy = gtsmodel.ListRepliesPolicy(form.RepliesPolicy)
+	if err := p.db.UpdateByPrimaryKey(ctx, list); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policyChanged {
+		// different replies belong in the list timeline now
+		p.timelineManager.RemoveListTimeline(ctx, list.ID)
+	}
+
+	mastoList, err := p.tc.ListToMasto(ctx, list)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoList, nil
+}
+
+func (p *processor) ListDelete(ctx context.Context, authed *oauth.Auth, listID string) gtserror.WithCode {
+	list, errWithCode := p.getOwnList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteListByID(ctx, list.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.timelineManager.RemoveListTimeline(ctx, list.ID)
+	return nil
+}
+
+func (p *processor) ListAccountsGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, limit int) (*apimodel.ListAccountsResponse, gtserror.WithCode) {
+	list, errWithCode := p.getOwnList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	accounts, nextMaxID, prevMinID, err := p.db.GetListAccounts(ctx, list.ID, maxID, sinceID, limit)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries
+			return &apimodel.ListAccountsResponse{
+				Accounts: []*apimodel.Account{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := []*apimodel.Account{}
+	for _, a := range accounts {
+		apiAccount, err := p.tc.AccountToMastoPublic(ctx, a)
+		if err != nil {
+			continue
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	resp := &apimodel.ListAccountsResponse{
+		Accounts: apiAccounts,
+	}
+
+	// only include the links if the results are paged
+	if limit > 0 && len(apiAccounts) != 0 {
+		path := "/api/v1/lists/" + list.ID + "/accounts"
+		nextLink := &url.URL{
+			Scheme:   p.config.Protocol,
+			Host:     p.config.Host,
+			Path:     path,
+			RawQuery: fmt.Sprintf("limit=%d&max_id=%s", limit, nextMaxID),
+		}
+		next := fmt.Sprintf("<%s>; rel=\"next\"", nextLink.String())
+
+		prevLink := &url.URL{
+			Scheme:   p.config.Protocol,
+			Host:     p.config.Host,
+			Path:     path,
+			RawQuery: fmt.Sprintf("limit=%d&since_id=%s", limit, prevMinID),
+		}
+		prev := fmt.Sprintf("<%s>; rel=\"prev\"", prevLink.String())
+		resp.LinkHeader = fmt.Sprintf("%s, %s", next, prev)
+	}
+
+	return resp, nil
+}
+
+func (p *processor) ListAccountsAdd(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode {
+	list, errWithCode := p.getOwnList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if len(form.AccountIDs) == 0 {
+		err := fmt.Errorf("no account ids provided")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// check all the accounts first, so that we don't add only some of them
+	entries := []*gtsmodel.ListEntry{}
+	for _, accountID := range form.AccountIDs {
+		targetAccount, err := p.db.GetAccountByID(ctx, accountID)
+		if err != nil {
+			if err != db.ErrNoEntries {
+				return gtserror.NewErrorInternalError(err)
+			}
+			return gtserror.NewErrorNotFound(fmt.Errorf("no account with id %s", accountID))
+		}
+
+		follows, err := p.db.IsFollowing(ctx, authed.Account, targetAccount)
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+		if !follows {
+			err := fmt.Errorf("account %s must be followed before it can be added to a list", accountID)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		inList, err := p.db.IsAccountInList(ctx, list.ID, targetAccount.ID)
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+		if inList {
+			continue
+		}
+
+		entryID, err := id.NewULID()
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+		entries = append(entries, &gtsmodel.ListEntry{
+			ID:        entryID,
+			ListID:    list.ID,
+			AccountID: targetAccount.ID,
+		})
+	}
+
+	for _, entry := range entries {
+		if err := p.db.Put(ctx, entry); err != nil && err != db.ErrAlreadyExists {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// the list timeline will be built again with statuses from the new members
+	p.timelineManager.RemoveListTimeline(ctx, list.ID)
+	return nil
+}
+
+func (p *processor) ListAccountsRemove(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode {
+	list, errWithCode := p.getOwnList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if len(form.AccountIDs) == 0 {
+		err := fmt.Errorf("no account ids provided")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	for _, accountID := range form.AccountIDs {
+		if err := p.db.DeleteWhere(ctx, []db.Where{
+			{Key: "list_id", Value: list.ID},
+			{Key: "account_id", Value: accountID},
+		}, &gtsmodel.ListEntry{}); err != nil && err != db.ErrNoEntries {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// the list timeline will be built again without statuses from the removed members
+	p.timelineManager.RemoveListTimeline(ctx, list.ID)
+	return nil
+}
+
+func (p *processor) AccountListsGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]*apimodel.List, gtserror.WithCode) {
+	lists, err := p.db.GetListsContainingAccount(ctx, authed.Account.ID, targetAccountID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.listsToMasto(ctx, lists)
+}
+
+// removeFromLists removes targetAccountID from all lists owned by accountID, eg., after an unfollow.
+func (p *processor) removeFromLists(ctx context.Context, accountID string, targetAccountID string) error {
+	listIDs, err := p.db.DeleteListEntriesForAccount(ctx, accountID, targetAccountID)
+	if err != nil {
+		return fmt.Errorf("removeFromLists: error removing account %s from lists of account %s: %s", targetAccountID, accountID, err)
+	}
+
+	for _, listID := range listIDs {
+		p.timelineManager.RemoveListTimeline(ctx, listID)
+	}
+	return nil
+}
+
+func validateListForm(form *apimodel.ListCreateRequest) gtserror.WithCode {
+	if err := validate.ListTitle(form.Title); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.ListRepliesPolicy(form.RepliesPolicy); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ListTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ListTestSuite) createList(title string) *apimodel.List {
+	list, errWithCode := suite.processor.ListCreate(context.Background(), suite.testAutheds["local_account_1"], &apimodel.ListCreateRequest{
+		Title: title,
+	})
+	suite.NoError(errWithCode)
+	suite.NotNil(list)
+	return list
+}
+
+func (suite *ListTestSuite) TestCreateUpdateDeleteList() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	list := suite.createList("<p>cool people</p>")
+	suite.Equal("cool people", list.Title)
+	suite.Equal(string(gtsmodel.ListRepliesPolicyFollowed), list.RepliesPolicy)
+
+	updated, errWithCode := suite.processor.ListUpdate(ctx, authed, list.ID, &apimodel.ListCreateRequest{
+		Title:         "cooler people",
+		RepliesPolicy: string(gtsmodel.ListRepliesPolicyNone),
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("cooler people", updated.Title)
+	suite.Equal(string(gtsmodel.ListRepliesPolicyNone), updated.RepliesPolicy)
+
+	// someone else shouldn't be able to see the list
+	_, errWithCode = suite.processor.ListGet(ctx, &oauth.Auth{
+		Application: suite.testApplications["local_account_2"],
+		User:        suite.testUsers["local_account_2"],
+		Account:     suite.testAccounts["local_account_2"],
+	}, list.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	suite.NoError(suite.processor.ListDelete(ctx, authed, list.ID))
+	_, errWithCode = suite.processor.ListGet(ctx, authed, list.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ListTestSuite) TestAddAccounts() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	list := suite.createList("cool people")
+
+	// local_account_1 doesn't follow remote_account_1, so this should fail
+	errWithCode := suite.processor.ListAccountsAdd(ctx, authed, list.ID, &apimodel.ListAccountsChangeRequest{
+		AccountIDs: []string{suite.testAccounts["local_account_2"].ID, suite.testAccounts["remote_account_1"].ID},
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// nothing should have been added
+	accounts, errWithCode := suite.processor.ListAccountsGet(ctx, authed, list.ID, "", "", 0)
+	suite.NoError(errWithCode)
+	suite.Empty(accounts.Accounts)
+
+	suite.NoError(suite.processor.ListAccountsAdd(ctx, authed, list.ID, &apimodel.ListAccountsChangeRequest{
+		AccountIDs: []string{suite.testAccounts["local_account_2"].ID},
+	}))
+
+	accounts, errWithCode = suite.processor.ListAccountsGet(ctx, authed, list.ID, "", "", 0)
+	suite.NoError(errWithCode)
+	suite.Len(accounts.Accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, accounts.Accounts[0].ID)
+
+	lists, errWithCode := suite.processor.AccountListsGet(ctx, authed, suite.testAccounts["local_account_2"].ID)
+	suite.NoError(errWithCode)
+	suite.Len(lists, 1)
+	suite.Equal(list.ID, lists[0].ID)
+}
+
+func (suite *ListTestSuite) TestListTimeline() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	list := suite.createList("cool people")
+
+	suite.NoError(suite.processor.ListAccountsAdd(ctx, authed, list.ID, &apimodel.ListAccountsChangeRequest{
+		AccountIDs: []string{suite.testAccounts["local_account_2"].ID},
+	}))
+
+	resp, errWithCode := suite.processor.ListTimelineGet(ctx, authed, list.ID, "", "", "", 20)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Statuses)
+	for _, s := range resp.Statuses {
+		suite.Equal(suite.testAccounts["local_account_2"].ID, s.Account.ID)
+	}
+}
+
+func (suite *ListTestSuite) TestUnfollowRemovesFromList() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	list := suite.createList("cool people")
+
+	suite.NoError(suite.processor.ListAccountsAdd(ctx, authed, list.ID, &apimodel.ListAccountsChangeRequest{
+		AccountIDs: []string{suite.testAccounts["local_account_2"].ID},
+	}))
+
+	_, errWithCode := suite.processor.AccountFollowRemove(ctx, authed, suite.testAccounts["local_account_2"].ID)
+	suite.NoError(errWithCode)
+
+	// the unfollow is processed asynchronously, so give it a moment
+	suite.Eventually(func() bool {
+		accounts, errWithCode := suite.processor.ListAccountsGet(ctx, authed, list.ID, "", "", 0)
+		return errWithCode == nil && len(accounts.Accounts) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestListTestSuite(t *testing.T) {
+	suite.Run(t, &ListTestSuite{})
+}
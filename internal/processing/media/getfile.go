@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -29,6 +30,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
+// presignExpiry is how long presigned urls handed out for media stay valid.
+const presignExpiry = time.Hour
+
 func (p *processor) GetFile(ctx context.Context, account *gtsmodel.Account, form *apimodel.GetContentRequestForm) (*apimodel.Content, error) {
 	// parse the form fields
 	mediaSize, err := media.ParseMediaSize(form.MediaSize)
@@ -110,6 +114,16 @@ func (p *processor) GetFile(ctx context.Context, account *gtsmodel.Account, form
 		}
 	}
 
+	// let the requester fetch the file straight from storage if we can
+	if p.presigner != nil {
+		url, err := p.presigner.PresignGet(storagePath, presignExpiry)
+		if err != nil {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("error presigning %s: %s", storagePath, err))
+		}
+		content.URL = url
+		return content, nil
+	}
+
 	bytes, err := p.storage.Get(storagePath)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error retrieving from storage: %s", err))
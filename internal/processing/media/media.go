@@ -29,6 +29,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
@@ -40,26 +42,38 @@ type Processor interface {
 	Delete(ctx context.Context, mediaAttachmentID string) gtserror.WithCode
 	GetFile(ctx context.Context, account *gtsmodel.Account, form *apimodel.GetContentRequestForm) (*apimodel.Content, error)
 	GetMedia(ctx context.Context, account *gtsmodel.Account, mediaAttachmentID string) (*apimodel.Attachment, gtserror.WithCode)
+	// Update updates the description and focus of the media attachment with the given ID, which must belong to the given account.
+	// If the attachment is already part of a status, an update of that status is federated so that remotes see the changes.
 	Update(ctx context.Context, account *gtsmodel.Account, mediaAttachmentID string, form *apimodel.AttachmentUpdateRequest) (*apimodel.Attachment, gtserror.WithCode)
 }
 
 type processor struct {
-	tc           typeutils.TypeConverter
-	config       *config.Config
-	mediaHandler media.Handler
-	storage      *kv.KVStore
-	db           db.DB
-	log          *logrus.Logger
+	tc            typeutils.TypeConverter
+	config        *config.Config
+	mediaHandler  media.Handler
+	fromClientAPI chan messages.FromClientAPI
+	storage       *kv.KVStore
+	presigner     gtsstorage.Presigner
+	db            db.DB
+	log           *logrus.Logger
 }
 
 // New returns a new media processor.
-func New(db db.DB, tc typeutils.TypeConverter, mediaHandler media.Handler, storage *kv.KVStore, config *config.Config, log *logrus.Logger) Processor {
+func New(db db.DB, tc typeutils.TypeConverter, mediaHandler media.Handler, fromClientAPI chan messages.FromClientAPI, storage *kv.KVStore, config *config.Config, log *logrus.Logger) Processor {
+	// if media can't be presigned, it's just proxied through the fileserver as usual
+	presigner, err := gtsstorage.NewPresigner(config)
+	if err != nil {
+		log.WithError(err).Warn("couldn't set up presigning of media urls, media will be proxied")
+	}
+
 	return &processor{
-		tc:           tc,
-		config:       config,
-		mediaHandler: mediaHandler,
-		storage:      storage,
-		db:           db,
-		log:          log,
+		tc:            tc,
+		config:        config,
+		mediaHandler:  mediaHandler,
+		fromClientAPI: fromClientAPI,
+		storage:       storage,
+		presigner:     presigner,
+		db:            db,
+		log:           log,
 	}
 }
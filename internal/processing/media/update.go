@@ -22,11 +22,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
@@ -46,9 +50,6 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, media
 
 	if form.Description != nil {
 		attachment.Description = text.RemoveHTML(*form.Description)
-		if err := p.db.UpdateByPrimaryKey(ctx, attachment); err != nil {
-			return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error updating description: %s", err))
-		}
 	}
 
 	if form.Focus != nil {
@@ -58,8 +59,26 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, media
 		}
 		attachment.FileMeta.Focus.X = focusx
 		attachment.FileMeta.Focus.Y = focusy
-		if err := p.db.UpdateByPrimaryKey(ctx, attachment); err != nil {
-			return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error updating focus: %s", err))
+	}
+
+	attachment.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, attachment); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error updating attachment: %s", err))
+	}
+
+	// if the attachment has already been posted, make sure remotes see the new description and focus too
+	if attachment.StatusID != "" {
+		status, err := p.db.GetStatusByID(ctx, attachment.StatusID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting status %s of attachment: %s", attachment.StatusID, err))
+		}
+
+		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       status,
+			OriginAccount:  account,
 		}
 	}
 
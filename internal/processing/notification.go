@@ -20,16 +20,27 @@ package processing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
-func (p *processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode) {
-	l := p.log.WithField("func", "NotificationsGet")
+func (p *processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, types []string, excludeTypes []string, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode) {
+	l := p.log.WithContext(ctx).WithField("func", "NotificationsGet")
 
-	notifs, err := p.db.GetNotifications(ctx, authed.Account.ID, limit, maxID, sinceID)
+	notifs, err := p.db.GetNotifications(ctx, authed.Account.ID, types, excludeTypes, limit, maxID, sinceID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	filters, err := p.getKeywordFilters(ctx, authed.Account, gtsmodel.FilterContextNotifications)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -38,11 +49,179 @@ func (p *processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, li
 	for _, n := range notifs {
 		mastoNotif, err := p.tc.NotificationToMasto(ctx, n)
 		if err != nil {
-			l.Debugf("got an error converting a notification to masto, will skip it: %s", err)
+			l.WithError(err).Debug("got an error converting a notification to masto, will skip it")
 			continue
 		}
+
+		if mastoNotif.Status != nil {
+			status, ok := applyKeywordFilters(authed.Account, filters, mastoNotif.Status)
+			if !ok {
+				// the status is hidden by a filter, so the notification is too
+				continue
+			}
+			mastoNotif.Status = status
+		}
+
 		mastoNotifs = append(mastoNotifs, mastoNotif)
 	}
 
 	return mastoNotifs, nil
 }
+
+func (p *processor) NotificationDismiss(ctx context.Context, authed *oauth.Auth, notificationID string) gtserror.WithCode {
+	notif, err := p.db.GetNotification(ctx, notificationID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err)
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if notif.TargetAccountID != authed.Account.ID {
+		return gtserror.NewErrorNotFound(errors.New("notification does not belong to the requesting account"))
+	}
+
+	if err := p.db.DeleteNotification(ctx, notif.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) NotificationsClear(ctx context.Context, authed *oauth.Auth) gtserror.WithCode {
+	if err := p.db.ClearNotifications(ctx, authed.Account.ID); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) NotificationPreferencesGet(ctx context.Context, authed *oauth.Auth) (*apimodel.NotificationPreferences, gtserror.WithCode) {
+	prefs, err := p.getNotificationPreferences(ctx, authed.Account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPrefs, err := p.tc.NotificationPreferencesToMasto(ctx, prefs)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPrefs, nil
+}
+
+func (p *processor) NotificationPreferencesUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.NotificationPreferencesUpdateRequest) (*apimodel.NotificationPreferences, gtserror.WithCode) {
+	prefs, err := p.getNotificationPreferences(ctx, authed.Account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, pref := range []struct {
+		set   *bool
+		value *bool
+	}{
+		{form.Follow, &prefs.Follow},
+		{form.FollowRequest, &prefs.FollowRequest},
+		{form.FollowReject, &prefs.FollowReject},
+		{form.Mention, &prefs.Mention},
+		{form.Reblog, &prefs.Reblog},
+		{form.Favourite, &prefs.Favourite},
+		{form.Poll, &prefs.Poll},
+		{form.Status, &prefs.Status},
+		{form.AdminReport, &prefs.AdminReport},
+		{form.ReportResolved, &prefs.ReportResolved},
+		{form.AdminSignUp, &prefs.AdminSignUp},
+	} {
+		if pref.set != nil {
+			*pref.value = *pref.set
+		}
+	}
+
+	if prefs.ID == "" {
+		// these are the defaults, so they haven't been stored yet
+		prefsID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		prefs.ID = prefsID
+
+		if err := p.db.Put(ctx, prefs); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting notification preferences in the db: %s", err))
+		}
+	} else {
+		prefs.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, prefs); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating notification preferences in the db: %s", err))
+		}
+	}
+
+	apiPrefs, err := p.tc.NotificationPreferencesToMasto(ctx, prefs)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPrefs, nil
+}
+
+// getNotificationPreferences returns the notification preferences stored for the given account,
+// or default preferences with every notification type turned on if the account hasn't stored any.
+// Default preferences have no ID.
+func (p *processor) getNotificationPreferences(ctx context.Context, accountID string) (*gtsmodel.NotificationPreferences, error) {
+	prefs, err := p.db.GetNotificationPreferences(ctx, accountID)
+	if err == nil {
+		return prefs, nil
+	}
+	if err != db.ErrNoEntries {
+		return nil, fmt.Errorf("error getting notification preferences for account %s: %s", accountID, err)
+	}
+
+	return &gtsmodel.NotificationPreferences{
+		AccountID:      accountID,
+		Follow:         true,
+		FollowRequest:  true,
+		FollowReject:   true,
+		Mention:        true,
+		Reblog:         true,
+		Favourite:      true,
+		Poll:           true,
+		Status:         true,
+		AdminReport:    true,
+		ReportResolved: true,
+		AdminSignUp:    true,
+	}, nil
+}
+
+// notificationWanted returns true if the given local account wants to receive notifications of the given type.
+func (p *processor) notificationWanted(ctx context.Context, accountID string, notificationType gtsmodel.NotificationType) (bool, error) {
+	prefs, err := p.getNotificationPreferences(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	switch notificationType {
+	case gtsmodel.NotificationFollow:
+		return prefs.Follow, nil
+	case gtsmodel.NotificationFollowRequest:
+		return prefs.FollowRequest, nil
+	case gtsmodel.NotificationFollowReject:
+		return prefs.FollowReject, nil
+	case gtsmodel.NotificationMention:
+		return prefs.Mention, nil
+	case gtsmodel.NotificationReblog:
+		return prefs.Reblog, nil
+	case gtsmodel.NotificationFave:
+		return prefs.Favourite, nil
+	case gtsmodel.NotificationPoll:
+		return prefs.Poll, nil
+	case gtsmodel.NotificationStatus:
+		return prefs.Status, nil
+	case gtsmodel.NotificationAdminReport:
+		return prefs.AdminReport, nil
+	case gtsmodel.NotificationReportResolved:
+		return prefs.ReportResolved, nil
+	case gtsmodel.NotificationAdminSignUp:
+		return prefs.AdminSignUp, nil
+	}
+
+	return true, nil
+}
@@ -20,9 +20,16 @@ package processing_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type NotificationTestSuite struct {
@@ -32,7 +39,7 @@ type NotificationTestSuite struct {
 // get a notification where someone has liked our status
 func (suite *NotificationTestSuite) TestGetNotifications() {
 	receivingAccount := suite.testAccounts["local_account_1"]
-	notifs, err := suite.processor.NotificationsGet(context.Background(), suite.testAutheds["local_account_1"], 10, "", "")
+	notifs, err := suite.processor.NotificationsGet(context.Background(), suite.testAutheds["local_account_1"], nil, nil, 10, "", "")
 	suite.NoError(err)
 	suite.Len(notifs, 1)
 	notif := notifs[0]
@@ -42,6 +49,99 @@ func (suite *NotificationTestSuite) TestGetNotifications() {
 	suite.Equal(receivingAccount.ID, notif.Status.Account.ID)
 }
 
+func (suite *NotificationTestSuite) TestGetNotificationsByType() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	notifs, err := suite.processor.NotificationsGet(ctx, authed, []string{"favourite"}, nil, 10, "", "")
+	suite.NoError(err)
+	suite.Len(notifs, 1)
+
+	notifs, err = suite.processor.NotificationsGet(ctx, authed, []string{"mention", "reblog"}, nil, 10, "", "")
+	suite.NoError(err)
+	suite.Empty(notifs)
+
+	notifs, err = suite.processor.NotificationsGet(ctx, authed, nil, []string{"favourite"}, 10, "", "")
+	suite.NoError(err)
+	suite.Empty(notifs)
+}
+
+func (suite *NotificationTestSuite) TestDismissAndClearNotifications() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+	notif := testrig.NewTestNotifications()["local_account_1_like"]
+
+	// someone else can't dismiss the notification
+	errWithCode := suite.processor.NotificationDismiss(ctx, &oauth.Auth{
+		Application: suite.testApplications["local_account_2"],
+		User:        suite.testUsers["local_account_2"],
+		Account:     suite.testAccounts["local_account_2"],
+	}, notif.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.processor.NotificationDismiss(ctx, authed, notif.ID)
+	suite.Nil(errWithCode)
+
+	notifs, errWithCode := suite.processor.NotificationsGet(ctx, authed, nil, nil, 10, "", "")
+	suite.Nil(errWithCode)
+	suite.Empty(notifs)
+
+	// clearing an already empty set of notifications is fine too
+	errWithCode = suite.processor.NotificationsClear(ctx, authed)
+	suite.Nil(errWithCode)
+}
+
+func (suite *NotificationTestSuite) TestNotificationPreferences() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	prefs, errWithCode := suite.processor.NotificationPreferencesGet(ctx, authed)
+	suite.Nil(errWithCode)
+	suite.True(prefs.Favourite)
+	suite.True(prefs.Mention)
+
+	favourite := false
+	prefs, errWithCode = suite.processor.NotificationPreferencesUpdate(ctx, authed, &apimodel.NotificationPreferencesUpdateRequest{
+		Favourite: &favourite,
+	})
+	suite.Nil(errWithCode)
+	suite.False(prefs.Favourite)
+	suite.True(prefs.Mention)
+
+	// a new fave of one of our statuses shouldn't create a notification now
+	faver := suite.testAccounts["local_account_2"]
+	status := suite.testStatuses["local_account_1_status_1"]
+	err := suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityLike,
+		APActivityType: ap.ActivityCreate,
+		GTSModel: &gtsmodel.StatusFave{
+			ID:              "01FQXMNJFZ6Y7SYS5FJGZT6XZ7",
+			AccountID:       faver.ID,
+			Account:         faver,
+			TargetAccountID: authed.Account.ID,
+			TargetAccount:   authed.Account,
+			StatusID:        status.ID,
+			Status:          status,
+			URI:             "http://localhost:8080/users/1happyturtle/liked/01FQXMNJFZ6Y7SYS5FJGZT6XZ7",
+		},
+		OriginAccount: faver,
+		TargetAccount: authed.Account,
+	})
+	suite.NoError(err)
+
+	notifs, errWithCode := suite.processor.NotificationsGet(ctx, authed, nil, nil, 10, "", "")
+	suite.Nil(errWithCode)
+	suite.Len(notifs, 1)
+
+	// stored preferences are updated in place
+	favourite = true
+	prefs, errWithCode = suite.processor.NotificationPreferencesUpdate(ctx, authed, &apimodel.NotificationPreferencesUpdateRequest{
+		Favourite: &favourite,
+	})
+	suite.Nil(errWithCode)
+	suite.True(prefs.Favourite)
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, &NotificationTestSuite{})
 }
@@ -0,0 +1,108 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// oEmbedDefaultWidth is the width of embedded statuses when the consumer doesn't ask for anything narrower.
+	oEmbedDefaultWidth = 400
+	// oEmbedCacheAge is how long, in seconds, consumers may cache an oEmbed response.
+	oEmbedCacheAge = 86400
+)
+
+func (p *processor) OEmbedGet(ctx context.Context, form *apimodel.OEmbedRequest) (*apimodel.OEmbed, gtserror.WithCode) {
+	if form.Format != "" && form.Format != "json" {
+		return nil, gtserror.NewErrorNotImplemented(fmt.Errorf("format %s not supported", form.Format), "only json format is supported")
+	}
+
+	statusURL, err := url.Parse(form.URL)
+	if err != nil || statusURL.Host != p.config.Host {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("url %s is not a status on this instance", form.URL))
+	}
+
+	// accept both the web url and the activitypub uri of a status
+	username, statusID, err := util.ParseStatusesWebPath(statusURL)
+	if err != nil {
+		username, statusID, err = util.ParseStatusesPath(statusURL)
+		if err != nil {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("url %s is not a status on this instance", form.URL))
+		}
+	}
+
+	status, err := p.db.GetStatusByID(ctx, statusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", statusID, err))
+	}
+	if status.Account == nil || status.Account.Username != username || !status.Local {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("status %s does not belong to local account %s", statusID, username))
+	}
+
+	// only statuses that anyone could see are embeddable
+	visible, err := p.filter.StatusVisible(ctx, status, nil)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", status.ID, err))
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
+	}
+
+	width := oEmbedDefaultWidth
+	if form.MaxWidth > 0 && form.MaxWidth < width {
+		width = form.MaxWidth
+	}
+
+	var height *int
+	var heightAttr string
+	if form.MaxHeight > 0 {
+		height = &form.MaxHeight
+		heightAttr = fmt.Sprintf(` height="%d"`, form.MaxHeight)
+	}
+
+	embedURL := fmt.Sprintf("%s://%s/embed/%s", p.config.Protocol, p.config.Host, status.ID)
+
+	authorName := status.Account.DisplayName
+	if authorName == "" {
+		authorName = status.Account.Username
+	}
+
+	return &apimodel.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        fmt.Sprintf("New post by %s", authorName),
+		AuthorName:   authorName,
+		AuthorURL:    status.Account.URL,
+		ProviderName: p.config.Host,
+		ProviderURL:  fmt.Sprintf("%s://%s", p.config.Protocol, p.config.Host),
+		CacheAge:     oEmbedCacheAge,
+		HTML:         fmt.Sprintf(`<iframe src="%s" class="gotosocial-embed" style="max-width: 100%%; border: 0" width="%d"%s allowfullscreen="allowfullscreen"></iframe>`, html.EscapeString(embedURL), width, heightAttr),
+		Width:        width,
+		Height:       height,
+	}, nil
+}
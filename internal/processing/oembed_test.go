@@ -0,0 +1,97 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type OEmbedTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *OEmbedTestSuite) TestOEmbedWebURL() {
+	status := suite.testStatuses["admin_account_status_1"]
+
+	oEmbed, errWithCode := suite.processor.OEmbedGet(context.Background(), &apimodel.OEmbedRequest{URL: status.URL})
+	suite.NoError(errWithCode)
+	suite.Equal("rich", oEmbed.Type)
+	suite.Equal("1.0", oEmbed.Version)
+	suite.Equal("New post by "+suite.testAccounts["admin_account"].Username, oEmbed.Title)
+	suite.Equal(suite.testAccounts["admin_account"].URL, oEmbed.AuthorURL)
+	suite.Equal("localhost:8080", oEmbed.ProviderName)
+	suite.Equal("http://localhost:8080", oEmbed.ProviderURL)
+	suite.Equal(400, oEmbed.Width)
+	suite.Nil(oEmbed.Height)
+	suite.Equal(`<iframe src="http://localhost:8080/embed/`+status.ID+`" class="gotosocial-embed" style="max-width: 100%; border: 0" width="400" allowfullscreen="allowfullscreen"></iframe>`, oEmbed.HTML)
+}
+
+func (suite *OEmbedTestSuite) TestOEmbedURIWithSize() {
+	status := suite.testStatuses["admin_account_status_1"]
+
+	oEmbed, errWithCode := suite.processor.OEmbedGet(context.Background(), &apimodel.OEmbedRequest{
+		URL:       status.URI,
+		MaxWidth:  300,
+		MaxHeight: 200,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(300, oEmbed.Width)
+	suite.Equal(200, *oEmbed.Height)
+	suite.Contains(oEmbed.HTML, `width="300" height="200"`)
+}
+
+func (suite *OEmbedTestSuite) TestOEmbedNotPublic() {
+	// followers/mutuals only status can't be embedded
+	status := suite.testStatuses["local_account_1_status_3"]
+
+	oEmbed, errWithCode := suite.processor.OEmbedGet(context.Background(), &apimodel.OEmbedRequest{URL: status.URL})
+	suite.Nil(oEmbed)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *OEmbedTestSuite) TestOEmbedWrongUser() {
+	status := suite.testStatuses["admin_account_status_1"]
+
+	oEmbed, errWithCode := suite.processor.OEmbedGet(context.Background(), &apimodel.OEmbedRequest{URL: "http://localhost:8080/@the_mighty_zork/statuses/" + status.ID})
+	suite.Nil(oEmbed)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *OEmbedTestSuite) TestOEmbedOtherHost() {
+	oEmbed, errWithCode := suite.processor.OEmbedGet(context.Background(), &apimodel.OEmbedRequest{URL: "https://example.org/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R"})
+	suite.Nil(oEmbed)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *OEmbedTestSuite) TestOEmbedXMLFormat() {
+	status := suite.testStatuses["admin_account_status_1"]
+
+	oEmbed, errWithCode := suite.processor.OEmbedGet(context.Background(), &apimodel.OEmbedRequest{URL: status.URL, Format: "xml"})
+	suite.Nil(oEmbed)
+	suite.Equal(http.StatusNotImplemented, errWithCode.Code())
+}
+
+func TestOEmbedTestSuite(t *testing.T) {
+	suite.Run(t, &OEmbedTestSuite{})
+}
@@ -0,0 +1,193 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordResetValidity is how long the link in a password reset email keeps working.
+const passwordResetValidity = time.Hour
+
+func (p *processor) UserPasswordChange(ctx context.Context, authed *oauth.Auth, form *apimodel.PasswordChangeRequest) gtserror.WithCode {
+	user := authed.User
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(form.OldPassword)); err != nil {
+		return gtserror.NewErrorBadRequest(err, "old password was incorrect")
+	}
+
+	if form.NewPassword == form.OldPassword {
+		return gtserror.NewErrorBadRequest(errors.New("new password is the same as the old one"), "new password cannot be the same as the old one")
+	}
+
+	if err := p.setPassword(ctx, user, form.NewPassword); err != nil {
+		return err
+	}
+
+	// whoever else might know the old password shouldn't stay signed in, but
+	// don't sign out the app the password was changed from
+	if err := p.revokeUserTokens(ctx, user.ID, authed.Token.GetAccess()); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) UserPasswordResetRequest(ctx context.Context, form *apimodel.PasswordResetRequest) gtserror.WithCode {
+	l := p.log.WithContext(ctx).WithField("func", "UserPasswordResetRequest")
+
+	if form.Email == "" {
+		return gtserror.NewErrorBadRequest(errors.New("no email address given"), "no email address given")
+	}
+
+	// don't let on whether there's a user with this address: only the owner of the address should find out
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "email", Value: form.Email}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			l.Debug("no user with the given email address, not sending a password reset email")
+			return nil
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if user.Disabled || user.ConfirmedAt.IsZero() {
+		l.WithField("userID", user.ID).Debug("user is disabled or unconfirmed, not sending a password reset email")
+		return nil
+	}
+
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error getting account %s: %s", user.AccountID, err))
+	}
+
+	// only the most recent link works
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: user.ID}}, &[]*gtsmodel.PasswordReset{}); err != nil && err != db.ErrNoEntries {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error deleting old password resets: %s", err))
+	}
+
+	resetID, err := id.NewRandomULID()
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	token := uuid.NewString()
+	reset := &gtsmodel.PasswordReset{
+		ID:        resetID,
+		UserID:    user.ID,
+		TokenHash: passwordResetTokenHash(token),
+		ExpiresAt: time.Now().Add(passwordResetValidity),
+	}
+	if err := p.db.Put(ctx, reset); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error putting password reset: %s", err))
+	}
+
+	if err := p.emailSender.SendResetPasswordEmail(user.Email, email.ResetPasswordData{
+		Username:     account.Username,
+		InstanceHost: p.config.Host,
+		InstanceURL:  p.instanceURL(),
+		ResetLink:    util.GenerateURLForResetPassword(p.config.Protocol, p.config.Host, token),
+	}); err != nil {
+		// an error here would let on that there's a user with this address, so only the logs get to know
+		l.WithError(err).WithField("userID", user.ID).Error("error sending password reset email")
+	}
+
+	return nil
+}
+
+func (p *processor) UserPasswordReset(ctx context.Context, form *apimodel.PasswordResetConfirmRequest) gtserror.WithCode {
+	if form.Token == "" {
+		return gtserror.NewErrorNotFound(errors.New("no password reset token given"))
+	}
+
+	reset := &gtsmodel.PasswordReset{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "token_hash", Value: passwordResetTokenHash(form.Token)}}, reset); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err, "this password reset link doesn't exist, or has already been used")
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if time.Now().After(reset.ExpiresAt) {
+		return gtserror.NewErrorForbidden(fmt.Errorf("password reset %s expired", reset.ID), "this password reset link has expired, please ask for a new one")
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetByID(ctx, reset.UserID, user); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error getting user %s: %s", reset.UserID, err))
+	}
+
+	if err := p.setPassword(ctx, user, form.NewPassword); err != nil {
+		return err
+	}
+
+	// links can only be used once
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "user_id", Value: user.ID}}, &[]*gtsmodel.PasswordReset{}); err != nil && err != db.ErrNoEntries {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error deleting password resets: %s", err))
+	}
+
+	// the password was probably reset because it was forgotten or leaked, so sign out everywhere
+	if err := p.revokeUserTokens(ctx, user.ID, ""); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// setPassword checks that the given password is strong enough, and stores its hash as the password of the user.
+func (p *processor) setPassword(ctx context.Context, user *gtsmodel.User, password string) gtserror.WithCode {
+	if err := validate.NewPassword(password); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	encryptedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error hashing password: %s", err))
+	}
+
+	user.EncryptedPassword = string(encryptedPassword)
+	user.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error updating user %s: %s", user.ID, err))
+	}
+
+	return nil
+}
+
+// passwordResetTokenHash returns the hash that's stored in place of the given password reset token.
+func passwordResetTokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,203 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *PasswordTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Token:       oauth.DBTokenToToken(suite.testTokens["local_account_1"]),
+		Application: suite.testApplications["local_account_1"],
+		User:        suite.testUsers["local_account_1"],
+		Account:     suite.testAccounts["local_account_1"],
+	}
+}
+
+// putOtherToken stores a second token for local_account_1, as if they were signed in to another app too.
+func (suite *PasswordTestSuite) putOtherToken() *gtsmodel.Token {
+	token := &gtsmodel.Token{
+		ID:              "01FSV3QKJQ2XSQ0ZP4ZJ4MBP6N",
+		ClientID:        "01F8MGV8AC3NGSJW0FE8W1BV70",
+		UserID:          "01F8MGVGPHQ2D3P3X0454H54Z5",
+		RedirectURI:     "http://localhost:8080",
+		Scope:           "read",
+		Access:          "OTHERAPPTOKENOTHERAPPTOKENOTHERAPPTOKENOTHERAPP1",
+		AccessCreateAt:  time.Now(),
+		AccessExpiresAt: time.Now().Add(72 * time.Hour),
+	}
+	suite.Require().NoError(suite.db.Put(context.Background(), token))
+	return token
+}
+
+func (suite *PasswordTestSuite) TestPasswordChange() {
+	ctx := context.Background()
+	otherToken := suite.putOtherToken()
+
+	errWithCode := suite.processor.UserPasswordChange(ctx, suite.authed(), &apimodel.PasswordChangeRequest{
+		OldPassword: "password",
+		NewPassword: "verygoodnewpassword!!!",
+	})
+	suite.NoError(errWithCode)
+
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetByID(ctx, suite.testUsers["local_account_1"].ID, dbUser)
+	suite.NoError(err)
+	suite.NoError(bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("verygoodnewpassword!!!")))
+
+	// the other app should be signed out, but not the one the password was changed from
+	err = suite.db.GetByID(ctx, otherToken.ID, &gtsmodel.Token{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+	err = suite.db.GetByID(ctx, suite.testTokens["local_account_1"].ID, &gtsmodel.Token{})
+	suite.NoError(err)
+}
+
+func (suite *PasswordTestSuite) TestPasswordChangeWrongOldPassword() {
+	errWithCode := suite.processor.UserPasswordChange(context.Background(), suite.authed(), &apimodel.PasswordChangeRequest{
+		OldPassword: "notmypassword",
+		NewPassword: "verygoodnewpassword!!!",
+	})
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *PasswordTestSuite) TestPasswordChangeWeakNewPassword() {
+	errWithCode := suite.processor.UserPasswordChange(context.Background(), suite.authed(), &apimodel.PasswordChangeRequest{
+		OldPassword: "password",
+		NewPassword: "123",
+	})
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *PasswordTestSuite) TestPasswordResetRequest() {
+	ctx := context.Background()
+
+	errWithCode := suite.processor.UserPasswordResetRequest(ctx, &apimodel.PasswordResetRequest{Email: "zork@example.org"})
+	suite.NoError(errWithCode)
+
+	resets := []*gtsmodel.PasswordReset{}
+	err := suite.db.GetWhere(ctx, []db.Where{{Key: "user_id", Value: suite.testUsers["local_account_1"].ID}}, &resets)
+	suite.NoError(err)
+	suite.Len(resets, 1)
+	suite.True(resets[0].ExpiresAt.After(time.Now()))
+
+	// unknown addresses look just the same to the caller
+	errWithCode = suite.processor.UserPasswordResetRequest(ctx, &apimodel.PasswordResetRequest{Email: "nobody@example.org"})
+	suite.NoError(errWithCode)
+}
+
+func (suite *PasswordTestSuite) TestPasswordResetRequestEmailFailure() {
+	ctx := context.Background()
+
+	// point smtp at a port that nothing is listening on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.NoError(err)
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	suite.config.SMTPConfig.Host = "127.0.0.1"
+	suite.config.SMTPConfig.Port = port
+	defer func() {
+		suite.config.SMTPConfig.Host = ""
+	}()
+
+	// failing to send the email mustn't look any different from there not being a user with the address
+	errWithCode := suite.processor.UserPasswordResetRequest(ctx, &apimodel.PasswordResetRequest{Email: "zork@example.org"})
+	suite.NoError(errWithCode)
+}
+
+func (suite *PasswordTestSuite) TestPasswordReset() {
+	ctx := context.Background()
+	token := "8e3b57b4-1e4f-4f4a-a3f5-3d9e3c3ff1d0"
+	hash := sha256.Sum256([]byte(token))
+
+	err := suite.db.Put(ctx, &gtsmodel.PasswordReset{
+		ID:        "01FSV3WZ3N6T8PXZ0B4KQ4R5V2",
+		UserID:    suite.testUsers["local_account_1"].ID,
+		TokenHash: hex.EncodeToString(hash[:]),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	suite.Require().NoError(err)
+
+	errWithCode := suite.processor.UserPasswordReset(ctx, &apimodel.PasswordResetConfirmRequest{
+		Token:       token,
+		NewPassword: "verygoodnewpassword!!!",
+	})
+	suite.NoError(errWithCode)
+
+	dbUser := &gtsmodel.User{}
+	err = suite.db.GetByID(ctx, suite.testUsers["local_account_1"].ID, dbUser)
+	suite.NoError(err)
+	suite.NoError(bcrypt.CompareHashAndPassword([]byte(dbUser.EncryptedPassword), []byte("verygoodnewpassword!!!")))
+
+	// every app should be signed out
+	err = suite.db.GetByID(ctx, suite.testTokens["local_account_1"].ID, &gtsmodel.Token{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// and the link can't be used again
+	errWithCode = suite.processor.UserPasswordReset(ctx, &apimodel.PasswordResetConfirmRequest{
+		Token:       token,
+		NewPassword: "anotherverygoodpassword!!!",
+	})
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *PasswordTestSuite) TestPasswordResetExpired() {
+	ctx := context.Background()
+	token := "8e3b57b4-1e4f-4f4a-a3f5-3d9e3c3ff1d0"
+	hash := sha256.Sum256([]byte(token))
+
+	err := suite.db.Put(ctx, &gtsmodel.PasswordReset{
+		ID:        "01FSV3WZ3N6T8PXZ0B4KQ4R5V2",
+		UserID:    suite.testUsers["local_account_1"].ID,
+		TokenHash: hex.EncodeToString(hash[:]),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	suite.Require().NoError(err)
+
+	errWithCode := suite.processor.UserPasswordReset(ctx, &apimodel.PasswordResetConfirmRequest{
+		Token:       token,
+		NewPassword: "verygoodnewpassword!!!",
+	})
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func TestPasswordTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordTestSuite{})
+}
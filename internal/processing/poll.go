@@ -0,0 +1,200 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// pollSweepInterval is how often to check for polls that have ended, so that their voters can be notified.
+	pollSweepInterval = time.Minute
+	// pollBatchSize is how many ended polls to select for closing at a time.
+	pollBatchSize = 100
+)
+
+func (p *processor) PollGet(ctx context.Context, authed *oauth.Auth, pollID string) (*apimodel.Poll, gtserror.WithCode) {
+	poll, errWithCode := p.getVisiblePoll(ctx, authed.Account, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiPoll, err := p.tc.PollToMasto(ctx, poll, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPoll, nil
+}
+
+func (p *processor) PollVote(ctx context.Context, authed *oauth.Auth, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode) {
+	poll, errWithCode := p.getVisiblePoll(ctx, authed.Account, pollID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if poll.Status.AccountID == authed.Account.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("can't vote in own poll"), "you can't vote in your own poll")
+	}
+
+	if poll.Expired() {
+		return nil, gtserror.NewErrorBadRequest(errors.New("poll has ended"), "the poll has already ended")
+	}
+
+	// drop any duplicate choices
+	chosen := map[int]bool{}
+	uniqueChoices := []int{}
+	for _, c := range choices {
+		if c < 0 || c >= len(poll.Options) {
+			return nil, gtserror.NewErrorBadRequest(fmt.Errorf("choice %d out of range", c), fmt.Sprintf("choice %d is not one of the poll's options", c))
+		}
+		if !chosen[c] {
+			chosen[c] = true
+			uniqueChoices = append(uniqueChoices, c)
+		}
+	}
+
+	if len(uniqueChoices) == 0 {
+		return nil, gtserror.NewErrorBadRequest(errors.New("no choices"), "at least one choice must be given")
+	}
+	if len(uniqueChoices) > 1 && !poll.Multiple {
+		return nil, gtserror.NewErrorBadRequest(errors.New("multiple choices in single choice poll"), "only one choice can be given in this poll")
+	}
+
+	votes, err := p.db.GetPollVotes(ctx, poll.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	for _, v := range votes {
+		if v.AccountID == authed.Account.ID {
+			return nil, gtserror.NewErrorBadRequest(errors.New("already voted"), "you have already voted in this poll")
+		}
+	}
+
+	newVotes := []*gtsmodel.PollVote{}
+	for _, c := range uniqueChoices {
+		voteID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		newVotes = append(newVotes, &gtsmodel.PollVote{
+			ID:        voteID,
+			PollID:    poll.ID,
+			AccountID: authed.Account.ID,
+			Choice:    c,
+		})
+	}
+
+	if err := p.db.PutPollVotes(ctx, newVotes); err != nil {
+		if err == db.ErrAlreadyExists {
+			return nil, gtserror.NewErrorBadRequest(err, "you have already voted in this poll")
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting poll votes in the db: %s", err))
+	}
+
+	apiPoll, err := p.tc.PollToMasto(ctx, poll, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPoll, nil
+}
+
+// getVisiblePoll returns the poll with the given ID, with its status populated, if the status is visible to the given account.
+func (p *processor) getVisiblePoll(ctx context.Context, account *gtsmodel.Account, pollID string) (*gtsmodel.Poll, gtserror.WithCode) {
+	poll := &gtsmodel.Poll{}
+	if err := p.db.GetByID(ctx, pollID, poll); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("poll %s not found", pollID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	status, err := p.db.GetStatusByID(ctx, poll.StatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s of poll %s: %s", poll.StatusID, pollID, err))
+	}
+	poll.Status = status
+
+	visible, err := p.filter.StatusVisible(ctx, status, account)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", status.ID, err))
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
+	}
+
+	return poll, nil
+}
+
+// sweepPolls periodically closes polls that have ended, until the processor is stopped.
+func (p *processor) sweepPolls(ctx context.Context) {
+	ticker := time.NewTicker(pollSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.closeExpiredPolls(ctx)
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// closeExpiredPolls marks every poll that has ended as closed, and lets its author and voters know that it's ended.
+func (p *processor) closeExpiredPolls(ctx context.Context) {
+	l := p.log.WithContext(ctx).WithField("func", "closeExpiredPolls")
+
+	// closed polls drop out of the selection, so just keep selecting until there are none left
+	for {
+		polls, err := p.db.GetExpiredPolls(ctx, pollBatchSize)
+		if err != nil && err != db.ErrNoEntries {
+			l.WithError(err).Error("error getting expired polls")
+			return
+		}
+		if len(polls) == 0 {
+			return
+		}
+
+		for _, poll := range polls {
+			poll.ClosedAt = time.Now()
+			poll.UpdatedAt = time.Now()
+			if err := p.db.UpdateByPrimaryKey(ctx, poll); err != nil {
+				// bail rather than selecting the same poll over and over again
+				l.WithError(err).WithField("pollID", poll.ID).Error("error closing poll")
+				return
+			}
+
+			if err := p.notifyPollEnded(ctx, poll); err != nil {
+				l.WithError(err).WithField("pollID", poll.ID).Error("error notifying poll end")
+			}
+		}
+	}
+}
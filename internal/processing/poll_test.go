@@ -0,0 +1,135 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type PollTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *PollTestSuite) createPoll(multiple bool) *apimodel.Poll {
+	status, err := suite.processor.StatusCreate(context.Background(), suite.authed("local_account_1"), &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:     "which is the best animal?",
+			Visibility: apimodel.VisibilityPublic,
+			Poll: &apimodel.PollRequest{
+				Options:   []string{"turtle", "zork", "cat"},
+				ExpiresIn: 3600,
+				Multiple:  multiple,
+			},
+		},
+	})
+	suite.NoError(err)
+	suite.NotNil(status.Poll)
+	return status.Poll
+}
+
+func (suite *PollTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+func (suite *PollTestSuite) TestPollCreateAndGet() {
+	created := suite.createPoll(false)
+	suite.False(created.Expired)
+	suite.False(created.Multiple)
+	suite.NotEmpty(created.ExpiresAt)
+	suite.Len(created.Options, 3)
+	suite.Equal("zork", created.Options[1].Title)
+	suite.Equal(0, *created.Options[1].VotesCount)
+
+	poll, errWithCode := suite.processor.PollGet(context.Background(), suite.authed("local_account_2"), created.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(created.ID, poll.ID)
+	suite.False(poll.Voted)
+}
+
+func (suite *PollTestSuite) TestPollVote() {
+	ctx := context.Background()
+	created := suite.createPoll(true)
+
+	poll, errWithCode := suite.processor.PollVote(ctx, suite.authed("local_account_2"), created.ID, []int{2, 0, 2})
+	suite.NoError(errWithCode)
+	suite.True(poll.Voted)
+	suite.Equal([]int{0, 2}, poll.OwnVotes)
+	suite.Equal(2, poll.VotesCount)
+	suite.Equal(1, poll.VotersCount)
+	suite.Equal(1, *poll.Options[0].VotesCount)
+	suite.Equal(0, *poll.Options[1].VotesCount)
+	suite.Equal(1, *poll.Options[2].VotesCount)
+
+	// can't vote twice
+	_, errWithCode = suite.processor.PollVote(ctx, suite.authed("local_account_2"), created.ID, []int{1})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// or in your own poll
+	_, errWithCode = suite.processor.PollVote(ctx, suite.authed("local_account_1"), created.ID, []int{1})
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// or for an option that doesn't exist
+	_, errWithCode = suite.processor.PollVote(ctx, suite.authed("admin_account"), created.ID, []int{3})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *PollTestSuite) TestPollVoteSingleChoice() {
+	created := suite.createPoll(false)
+
+	_, errWithCode := suite.processor.PollVote(context.Background(), suite.authed("local_account_2"), created.ID, []int{0, 1})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *PollTestSuite) TestPollVoteExpired() {
+	ctx := context.Background()
+	created := suite.createPoll(false)
+
+	poll := &gtsmodel.Poll{}
+	suite.NoError(suite.db.GetByID(ctx, created.ID, poll))
+	poll.ExpiresAt = time.Now().Add(-time.Minute)
+	suite.NoError(suite.db.UpdateByPrimaryKey(ctx, poll))
+
+	apiPoll, errWithCode := suite.processor.PollGet(ctx, suite.authed("local_account_2"), created.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiPoll.Expired)
+
+	_, errWithCode = suite.processor.PollVote(ctx, suite.authed("local_account_2"), created.ID, []int{0})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	expired, err := suite.db.GetExpiredPolls(ctx, 0)
+	suite.NoError(err)
+	suite.Len(expired, 1)
+	suite.Equal(created.ID, expired[0].ID)
+}
+
+func TestPollTestSuite(t *testing.T) {
+	suite.Run(t, &PollTestSuite{})
+}
@@ -0,0 +1,138 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+	"golang.org/x/text/language"
+)
+
+func (p *processor) PreferencesGet(ctx context.Context, authed *oauth.Auth) (*apimodel.Preferences, gtserror.WithCode) {
+	expandMedia := authed.User.ExpandMedia
+	if expandMedia == "" {
+		expandMedia = gtsmodel.ExpandMediaDefault
+	}
+
+	languages := authed.User.ChosenLanguages
+	if languages == nil {
+		languages = []string{}
+	}
+
+	return &apimodel.Preferences{
+		PostingDefaultVisibility: string(p.tc.VisToMasto(ctx, authed.Account.Privacy)),
+		PostingDefaultSensitive:  authed.Account.Sensitive,
+		PostingDefaultLanguage:   authed.Account.Language,
+		ReadingExpandMedia:       expandMedia,
+		ReadingExpandSpoilers:    authed.User.ExpandSpoilers,
+		ReadingLanguages:         languages,
+		PostingExpiryDays:        authed.User.StatusExpiryDays,
+		PostingExpiryKeepPinned:  authed.User.StatusExpiryKeepPinned,
+		PostingExpiryKeepFaved:   authed.User.StatusExpiryKeepFaved,
+	}, nil
+}
+
+func (p *processor) PreferencesUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.PreferencesUpdateRequest) (*apimodel.Preferences, gtserror.WithCode) {
+	account := authed.Account
+	user := authed.User
+
+	// posting defaults are stored on the account, since they're also shown in the account source
+	if form.PostingDefaultVisibility != nil || form.PostingDefaultSensitive != nil || form.PostingDefaultLanguage != nil {
+		if form.PostingDefaultVisibility != nil {
+			if err := validate.Privacy(*form.PostingDefaultVisibility); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			account.Privacy = p.tc.MastoVisToVis(apimodel.Visibility(*form.PostingDefaultVisibility))
+		}
+
+		if form.PostingDefaultSensitive != nil {
+			account.Sensitive = *form.PostingDefaultSensitive
+		}
+
+		if form.PostingDefaultLanguage != nil {
+			if err := validate.Language(*form.PostingDefaultLanguage); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			account.Language = *form.PostingDefaultLanguage
+		}
+
+		if _, err := p.db.UpdateAccount(ctx, account); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("PreferencesUpdate: error updating account: %s", err))
+		}
+	}
+
+	// reading and expiry preferences are stored on the user, since they only matter to this instance
+	if form.ReadingExpandMedia != nil || form.ReadingExpandSpoilers != nil || form.ReadingLanguages != nil ||
+		form.PostingExpiryDays != nil || form.PostingExpiryKeepPinned != nil || form.PostingExpiryKeepFaved != nil {
+		if form.ReadingExpandMedia != nil {
+			if err := validate.ExpandMedia(*form.ReadingExpandMedia); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			user.ExpandMedia = *form.ReadingExpandMedia
+		}
+
+		if form.ReadingExpandSpoilers != nil {
+			user.ExpandSpoilers = *form.ReadingExpandSpoilers
+		}
+
+		if form.ReadingLanguages != nil {
+			languages := []string{}
+			for _, lang := range *form.ReadingLanguages {
+				if lang == "" {
+					continue
+				}
+				base, err := language.ParseBase(lang)
+				if err != nil {
+					return nil, gtserror.NewErrorBadRequest(err, fmt.Sprintf("language %s not recognized", lang))
+				}
+				languages = append(languages, base.String())
+			}
+			user.ChosenLanguages = languages
+		}
+
+		if form.PostingExpiryDays != nil {
+			if err := validate.StatusExpiryDays(*form.PostingExpiryDays); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			user.StatusExpiryDays = *form.PostingExpiryDays
+		}
+
+		if form.PostingExpiryKeepPinned != nil {
+			user.StatusExpiryKeepPinned = *form.PostingExpiryKeepPinned
+		}
+
+		if form.PostingExpiryKeepFaved != nil {
+			user.StatusExpiryKeepFaved = *form.PostingExpiryKeepFaved
+		}
+
+		user.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("PreferencesUpdate: error updating user: %s", err))
+		}
+	}
+
+	return p.PreferencesGet(ctx, authed)
+}
@@ -0,0 +1,194 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type PreferencesTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *PreferencesTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+func (suite *PreferencesTestSuite) TestPreferencesGet() {
+	preferences, errWithCode := suite.processor.PreferencesGet(context.Background(), suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+	suite.Equal("public", preferences.PostingDefaultVisibility)
+	suite.False(preferences.PostingDefaultSensitive)
+	suite.Equal("en", preferences.PostingDefaultLanguage)
+	suite.Equal("default", preferences.ReadingExpandMedia)
+	suite.False(preferences.ReadingExpandSpoilers)
+	suite.Equal([]string{"en"}, preferences.ReadingLanguages)
+}
+
+func (suite *PreferencesTestSuite) TestPreferencesUpdate() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	visibility := "private"
+	sensitive := true
+	expandMedia := "show_all"
+	expandSpoilers := true
+	preferences, errWithCode := suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		PostingDefaultVisibility: &visibility,
+		PostingDefaultSensitive:  &sensitive,
+		ReadingExpandMedia:       &expandMedia,
+		ReadingExpandSpoilers:    &expandSpoilers,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("private", preferences.PostingDefaultVisibility)
+	suite.True(preferences.PostingDefaultSensitive)
+	suite.Equal("en", preferences.PostingDefaultLanguage)
+	suite.Equal("show_all", preferences.ReadingExpandMedia)
+	suite.True(preferences.ReadingExpandSpoilers)
+
+	// the preferences should have been stored
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: authed.Account.ID}}, dbUser)
+	suite.NoError(err)
+	suite.Equal("show_all", dbUser.ExpandMedia)
+	suite.True(dbUser.ExpandSpoilers)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, authed.Account.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.Sensitive)
+
+	// statuses created without visibility or sensitivity use the new defaults
+	status, err := suite.processor.StatusCreate(ctx, authed, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status: "hello, this should be sensitive and followers only",
+		},
+	})
+	suite.NoError(err)
+	suite.True(status.Sensitive)
+	suite.Equal(apimodel.VisibilityPrivate, status.Visibility)
+
+	// but they can still be overridden
+	notSensitive := false
+	status, err = suite.processor.StatusCreate(ctx, authed, &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:    "hello, this should not be sensitive",
+			Sensitive: &notSensitive,
+		},
+	})
+	suite.NoError(err)
+	suite.False(status.Sensitive)
+}
+
+func (suite *PreferencesTestSuite) TestPreferencesUpdateLanguages() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_2")
+
+	languages := []string{"DEU", "fr"}
+	preferences, errWithCode := suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		ReadingLanguages: &languages,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"de", "fr"}, preferences.ReadingLanguages)
+
+	// statuses in other languages are left out of the public timeline, apart from our own
+	timeline, errWithCode := suite.processor.PublicTimelineGet(ctx, authed, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(timeline.Statuses)
+	for _, s := range timeline.Statuses {
+		if s.Language == "en" {
+			suite.Equal(authed.Account.ID, s.Account.ID)
+		}
+	}
+
+	// an empty language shows everything again
+	languages = []string{""}
+	preferences, errWithCode = suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		ReadingLanguages: &languages,
+	})
+	suite.NoError(errWithCode)
+	suite.Empty(preferences.ReadingLanguages)
+
+	languages = []string{"not a language"}
+	_, errWithCode = suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		ReadingLanguages: &languages,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *PreferencesTestSuite) TestPreferencesUpdateExpiry() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	days := 30
+	keepPinned := true
+	preferences, errWithCode := suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		PostingExpiryDays:       &days,
+		PostingExpiryKeepPinned: &keepPinned,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(30, preferences.PostingExpiryDays)
+	suite.True(preferences.PostingExpiryKeepPinned)
+	suite.False(preferences.PostingExpiryKeepFaved)
+
+	// the user should now be picked up for status expiry
+	users, err := suite.db.GetStatusExpiryUsers(ctx)
+	suite.NoError(err)
+	suite.Len(users, 1)
+	suite.Equal(authed.Account.ID, users[0].AccountID)
+	suite.True(users[0].StatusExpiryKeepPinned)
+
+	days = -1
+	_, errWithCode = suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		PostingExpiryDays: &days,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *PreferencesTestSuite) TestPreferencesUpdateInvalid() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	expandMedia := "show_some"
+	_, errWithCode := suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		ReadingExpandMedia: &expandMedia,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	visibility := "everyone"
+	_, errWithCode = suite.processor.PreferencesUpdate(ctx, authed, &apimodel.PreferencesUpdateRequest{
+		PostingDefaultVisibility: &visibility,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestPreferencesTestSuite(t *testing.T) {
+	suite.Run(t, &PreferencesTestSuite{})
+}
@@ -28,9 +28,13 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/feed"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/inboxfilter"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -39,10 +43,15 @@ import (
 	mediaProcessor "github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/relme"
+	"github.com/superseriousbusiness/gotosocial/internal/spam"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Processor should be passed to api modules (see internal/apimodule/...). It is used for
@@ -69,21 +78,50 @@ type Processor interface {
 		response, pass work to the processor using a channel instead.
 	*/
 
+	// AccountAlias sets the alsoKnownAs aliases of the authed account to the ones in the given form, and federates them.
+	AccountAlias(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountAliasRequest) (*apimodel.Account, gtserror.WithCode)
 	// AccountCreate processes the given form for creating a new account, returning an oauth token for that account if successful.
 	AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, error)
+	// AccountDeleteLocal checks the password in the given form and, if it's correct, deletes the authed account.
+	AccountDeleteLocal(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountDeleteRequest) gtserror.WithCode
+	// AccountExportsGet returns the downloadable data exports of the authed account.
+	AccountExportsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AccountExport, gtserror.WithCode)
+	// AccountExportCreate builds a new archive of the authed account's data, replacing any previous one.
+	AccountExportCreate(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountExport, gtserror.WithCode)
+	// AccountExportFileGet returns the archive of the export with the given ID, if it belongs to the authed account.
+	AccountExportFileGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.Content, gtserror.WithCode)
 	// AccountGet processes the given request for account information.
 	AccountGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Account, error)
+	// AccountGetLocalByUsername processes the given request for information about the local account with the given username.
+	AccountGetLocalByUsername(ctx context.Context, authed *oauth.Auth, username string) (*apimodel.Account, gtserror.WithCode)
+	// AccountLookup processes the given request for information about the account with the given acct, like someone or someone@example.org.
+	AccountLookup(ctx context.Context, authed *oauth.Auth, acct string) (*apimodel.Account, gtserror.WithCode)
 	// AccountUpdate processes the update of an account with the given form
 	AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
+	// AccountListsGet returns the lists owned by the requesting account that the target account is a member of.
+	AccountListsGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]*apimodel.List, gtserror.WithCode)
+	// AccountRotateKeys replaces the keypair of the authed account with a new one, and federates the new public key.
+	AccountRotateKeys(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode)
+	// AccountMove checks the password in the given form and, if it's correct, moves the authed account and its
+	// followers to the account in the form. The target account must already list the authed account as an alias.
+	AccountMove(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMoveRequest) gtserror.WithCode
 	// AccountStatusesGet fetches a number of statuses (in time descending order) from the given account, filtered by visibility for
-	// the account given in authed.
-	AccountStatusesGet(ctx context.Context, authed *oauth.Auth, targetAccountID string, limit int, excludeReplies bool, maxID string, pinned bool, mediaOnly bool) ([]apimodel.Status, gtserror.WithCode)
+	// the account given in authed. If tagged is set, only statuses using the hashtag with that name are returned.
+	AccountStatusesGet(ctx context.Context, authed *oauth.Auth, targetAccountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, sinceID string, minID string, pinned bool, mediaOnly bool, tagged string) ([]apimodel.Status, gtserror.WithCode)
+	// AccountWebStatusesGet fetches a page of public, top-level statuses from the given account, for its web profile.
+	AccountWebStatusesGet(ctx context.Context, targetAccountID string, limit int, maxID string) ([]apimodel.Status, gtserror.WithCode)
+	// AccountPinnedWebStatusesGet fetches the public pinned statuses of the given account, for its web profile.
+	AccountPinnedWebStatusesGet(ctx context.Context, targetAccountID string) ([]apimodel.Status, gtserror.WithCode)
+	// AccountFeedGet builds an RSS/Atom feed of the public posts of the local account with the given username.
+	AccountFeedGet(ctx context.Context, username string) (*feed.Feed, gtserror.WithCode)
 	// AccountFollowersGet fetches a list of the target account's followers.
 	AccountFollowersGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
 	// AccountFollowingGet fetches a list of the accounts that target account is following.
 	AccountFollowingGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
 	// AccountRelationshipGet returns a relationship model describing the relationship of the targetAccount to the Authed account.
 	AccountRelationshipGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountRelationshipsGet returns relationship models describing the relationships of each of the target accounts to the Authed account, in the same order.
+	AccountRelationshipsGet(ctx context.Context, authed *oauth.Auth, targetAccountIDs []string) ([]apimodel.Relationship, gtserror.WithCode)
 	// AccountFollowCreate handles a follow request to an account, either remote or local.
 	AccountFollowCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountFollowRequest) (*apimodel.Relationship, gtserror.WithCode)
 	// AccountFollowRemove handles the removal of a follow/follow request to an account, either remote or local.
@@ -92,6 +130,12 @@ type Processor interface {
 	AccountBlockCreate(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// AccountBlockRemove handles the removal of a block from authed account to target account, either remote or local.
 	AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountMuteCreate handles the creation or updating of a mute from authed account to target account.
+	AccountMuteCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountMuteRequest) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountMuteRemove handles the removal of a mute from authed account to target account.
+	AccountMuteRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountNoteSet sets, replaces or removes the authed account's private note about the target account.
+	AccountNoteSet(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountNoteRequest) (*apimodel.Relationship, gtserror.WithCode)
 
 	// AdminEmojiCreate handles the creation of a new instance emoji by an admin, using the given form.
 	AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, error)
@@ -103,11 +147,112 @@ type Processor interface {
 	AdminDomainBlocksGet(ctx context.Context, authed *oauth.Auth, export bool) ([]*apimodel.DomainBlock, gtserror.WithCode)
 	// AdminDomainBlockGet returns one domain block, specified by ID.
 	AdminDomainBlockGet(ctx context.Context, authed *oauth.Auth, id string, export bool) (*apimodel.DomainBlock, gtserror.WithCode)
+	// AdminDomainBlocksExportCSV returns all domain blocks as a csv file in the format used by Mastodon, so they can be shared with other instances.
+	AdminDomainBlocksExportCSV(ctx context.Context, authed *oauth.Auth) (*apimodel.Content, gtserror.WithCode)
+	// AdminDomainBlockUpdate changes the severity, comments or flags of one domain block, specified by ID, returning the updated domain block.
+	AdminDomainBlockUpdate(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.DomainBlockUpdateRequest) (*apimodel.DomainBlock, gtserror.WithCode)
 	// AdminDomainBlockDelete deletes one domain block, specified by ID, returning the deleted domain block.
 	AdminDomainBlockDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainBlock, gtserror.WithCode)
+	// AdminDomainAllowCreate handles the creation of a new domain allow by an admin, using the given form.
+	AdminDomainAllowCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainAllowCreateRequest) (*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDomainAllowsGet returns a list of currently allowed domains.
+	AdminDomainAllowsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDomainAllowGet returns one domain allow, specified by ID.
+	AdminDomainAllowGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDomainAllowDelete deletes one domain allow, specified by ID, returning the deleted domain allow.
+	AdminDomainAllowDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainAllow, gtserror.WithCode)
+	// AdminDomainPolicyCreate handles the creation (or update) of a domain policy by an admin, using the given form.
+	AdminDomainPolicyCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainPolicyCreateRequest) (*apimodel.DomainPolicy, gtserror.WithCode)
+	// AdminDomainPoliciesGet returns a list of current domain policies.
+	AdminDomainPoliciesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainPolicy, gtserror.WithCode)
+	// AdminDomainPolicyGet returns one domain policy, specified by ID.
+	AdminDomainPolicyGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainPolicy, gtserror.WithCode)
+	// AdminDomainPolicyDelete deletes one domain policy, specified by ID, returning the deleted domain policy.
+	AdminDomainPolicyDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainPolicy, gtserror.WithCode)
+	// AdminDeliveriesGet returns the delivery receipts for the given outgoing activity, or for all activities about the given status.
+	AdminDeliveriesGet(ctx context.Context, authed *oauth.Auth, activityURI string, statusID string) ([]*apimodel.DeliveryReceipt, gtserror.WithCode)
+	// AdminAccountRotateKeys replaces the keypair of the local account with the given ID with a new one, and federates the new public key.
+	AdminAccountRotateKeys(ctx context.Context, authed *oauth.Auth, accountID string) (*apimodel.Account, gtserror.WithCode)
+	// AdminSpamFlagsGet returns all incoming statuses that were flagged by the spam checks, newest first.
+	AdminSpamFlagsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.SpamFlag, gtserror.WithCode)
+	// AdminSpamFlagGet returns one spam flag, specified by ID.
+	AdminSpamFlagGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.SpamFlag, gtserror.WithCode)
+	// AdminSpamFlagRelease marks one spam flag, specified by ID, as not spam. If the flagged status was held,
+	// it is put into timelines and notified as if it had just arrived. The flag is removed, and returned.
+	AdminSpamFlagRelease(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.SpamFlag, gtserror.WithCode)
+	// AdminSpamFlagDelete marks one spam flag, specified by ID, as spam. If the flagged status was held, it is
+	// deleted; otherwise the flag is just dismissed. The flag is removed, and returned.
+	AdminSpamFlagDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.SpamFlag, gtserror.WithCode)
+	// AdminTrendingTagsGet returns the hashtags that are currently trending, whether or not they've been approved to be shown in trends.
+	AdminTrendingTagsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AdminTag, gtserror.WithCode)
+	// AdminTrendingTagApprove allows the hashtag with the given ID to be shown in trends.
+	AdminTrendingTagApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminTag, gtserror.WithCode)
+	// AdminTrendingTagReject stops the hashtag with the given ID from being shown in trends.
+	AdminTrendingTagReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminTag, gtserror.WithCode)
+	// AdminReportsGet returns all reports, newest first. If resolved is false, only reports that are still waiting
+	// for a moderator are returned; otherwise only reports that have already been resolved are returned.
+	// If accountID or targetAccountID are set, only reports filed by or against that account are returned.
+	AdminReportsGet(ctx context.Context, authed *oauth.Auth, resolved bool, accountID string, targetAccountID string) ([]*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportGet returns one report, specified by ID.
+	AdminReportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportAssign assigns the report with the given ID to the requesting moderator, to show that they're handling it.
+	AdminReportAssign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportUnassign removes any moderator assigned to the report with the given ID.
+	AdminReportUnassign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportResolve marks the report with the given ID as resolved, recording what was done about it,
+	// and notifies the reporter if they're local.
+	AdminReportResolve(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.AdminReportResolveRequest) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminReportReopen marks the resolved report with the given ID as waiting for a moderator again.
+	AdminReportReopen(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode)
+	// AdminAccountsGet returns accounts known to this instance, newest first, narrowed down by any of the given filters that are set.
+	AdminAccountsGet(ctx context.Context, authed *oauth.Auth, local bool, remote bool, pending bool, suspended bool, silenced bool, maxID string, limit int) ([]*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountGet returns the admin view of one account, specified by ID.
+	AdminAccountGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountAction warns, silences or suspends the account with the given ID. Suspending an account removes its
+	// content in the same way as a domain block does for the accounts of the blocked domain. If the form
+	// gives a report about the account, that report is resolved by the action. Actions against local accounts
+	// are recorded as warnings, which the owner of the account can optionally be emailed about.
+	AdminAccountAction(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.AdminAccountActionRequest) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountApprove approves the pending signup of the local account with the given ID, so that it can be logged in to.
+	AdminAccountApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountReject rejects the pending signup of the local account with the given ID, removing the account and its user.
+	AdminAccountReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountUnsilence lifts the silence on the account with the given ID.
+	AdminAccountUnsilence(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminAccountUnsuspend lifts the suspension of the remote account with the given ID.
+	AdminAccountUnsuspend(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminAccountInfo, gtserror.WithCode)
+	// AdminModerationNoteCreate writes a note about the local account with the given ID, which only moderators can see.
+	AdminModerationNoteCreate(ctx context.Context, authed *oauth.Auth, accountID string, form *apimodel.AdminModerationNoteCreateRequest) (*apimodel.AdminModerationNote, gtserror.WithCode)
+	// AdminModerationNoteDelete deletes the moderation note with the given ID from the account with the given ID, returning the deleted note.
+	AdminModerationNoteDelete(ctx context.Context, authed *oauth.Auth, accountID string, noteID string) (*apimodel.AdminModerationNote, gtserror.WithCode)
+	// AdminInstancePagesGet returns all static pages set by the admin of this instance, ordered by slug.
+	AdminInstancePagesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.InstancePage, gtserror.WithCode)
+	// AdminInstancePagePut creates the static instance page with the given slug using the given form, or replaces
+	// it if it already exists. The content is rendered to sanitized html straight away, and the page is returned.
+	AdminInstancePagePut(ctx context.Context, authed *oauth.Auth, slug string, form *apimodel.InstancePageUpdateRequest) (*apimodel.InstancePage, gtserror.WithCode)
+	// AdminInstancePageDelete deletes the static instance page with the given slug, returning the deleted page.
+	AdminInstancePageDelete(ctx context.Context, authed *oauth.Auth, slug string) (*apimodel.InstancePage, gtserror.WithCode)
+	// AdminRuleCreate creates a new instance rule using the given form.
+	AdminRuleCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.RuleCreateRequest) (*apimodel.Rule, gtserror.WithCode)
+	// AdminRuleUpdate updates the instance rule with the given ID using the given form.
+	AdminRuleUpdate(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.RuleUpdateRequest) (*apimodel.Rule, gtserror.WithCode)
+	// AdminRuleDelete deletes the instance rule with the given ID, returning the deleted rule.
+	AdminRuleDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.Rule, gtserror.WithCode)
+	// AdminAuditLogsGet returns entries of the admin audit log, newest first, optionally only those for actions taken by the account with the given ID.
+	AdminAuditLogsGet(ctx context.Context, authed *oauth.Auth, accountID string, maxID string, limit int) ([]*apimodel.AdminAuditLog, gtserror.WithCode)
+	// AdminMeasuresGet counts events on this instance, such as new users or opened reports, for each day of the requested period.
+	AdminMeasuresGet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminStatisticsRequest) ([]*apimodel.AdminMeasure, gtserror.WithCode)
+	// AdminDimensionsGet breaks down activity on this instance over the requested period, such as by language or by remote server.
+	AdminDimensionsGet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminStatisticsRequest) ([]*apimodel.AdminDimension, gtserror.WithCode)
 
 	// AppCreate processes the creation of a new API application
 	AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, error)
+	// AppVerifyCredentials returns the application that owns the token used to make the request, along with the scopes granted to that token.
+	AppVerifyCredentials(ctx context.Context, authed *oauth.Auth) (*apimodel.Application, gtserror.WithCode)
+	// AppsAuthorizedGet returns all applications that the requesting user has granted access to their account.
+	AppsAuthorizedGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AuthorizedApplication, gtserror.WithCode)
+	// AppAuthorizedRevoke revokes every token that the requesting user has granted to the application with the given ID.
+	AppAuthorizedRevoke(ctx context.Context, authed *oauth.Auth, id string) gtserror.WithCode
 
 	// BlocksGet returns a list of accounts blocked by the requesting account.
 	BlocksGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.BlocksResponse, gtserror.WithCode)
@@ -115,17 +260,66 @@ type Processor interface {
 	// FileGet handles the fetching of a media attachment file via the fileserver.
 	FileGet(ctx context.Context, authed *oauth.Auth, form *apimodel.GetContentRequestForm) (*apimodel.Content, error)
 
-	// FollowRequestsGet handles the getting of the authed account's incoming follow requests
-	FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]apimodel.Account, gtserror.WithCode)
+	// FiltersGetV1 returns all keywords of all filters owned by the requesting account, as version 1 filters.
+	FiltersGetV1(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Filter, gtserror.WithCode)
+	// FilterGetV1 returns the filter keyword with the given ID as a version 1 filter, if it's owned by the requesting account.
+	FilterGetV1(ctx context.Context, authed *oauth.Auth, keywordID string) (*apimodel.Filter, gtserror.WithCode)
+	// FilterCreateV1 creates a new filter with a single keyword, using the given version 1 form.
+	FilterCreateV1(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterCreateUpdateRequestV1) (*apimodel.Filter, gtserror.WithCode)
+	// FilterUpdateV1 updates the filter keyword with the given ID, and the filter it belongs to, using the given version 1 form.
+	FilterUpdateV1(ctx context.Context, authed *oauth.Auth, keywordID string, form *apimodel.FilterCreateUpdateRequestV1) (*apimodel.Filter, gtserror.WithCode)
+	// FilterDeleteV1 deletes the filter keyword with the given ID, and the filter it belongs to if no keywords are left.
+	FilterDeleteV1(ctx context.Context, authed *oauth.Auth, keywordID string) gtserror.WithCode
+	// FiltersGetV2 returns all filters owned by the requesting account.
+	FiltersGetV2(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FilterV2, gtserror.WithCode)
+	// FilterGetV2 returns the filter with the given ID, if it's owned by the requesting account.
+	FilterGetV2(ctx context.Context, authed *oauth.Auth, filterID string) (*apimodel.FilterV2, gtserror.WithCode)
+	// FilterCreateV2 creates a new filter owned by the requesting account, using the given form.
+	FilterCreateV2(ctx context.Context, authed *oauth.Auth, form *apimodel.FilterCreateUpdateRequestV2) (*apimodel.FilterV2, gtserror.WithCode)
+	// FilterUpdateV2 updates the filter with the given ID, and adds, changes, or removes its keywords, using the given form.
+	FilterUpdateV2(ctx context.Context, authed *oauth.Auth, filterID string, form *apimodel.FilterCreateUpdateRequestV2) (*apimodel.FilterV2, gtserror.WithCode)
+	// FilterDeleteV2 deletes the filter with the given ID, along with its keywords.
+	FilterDeleteV2(ctx context.Context, authed *oauth.Auth, filterID string) gtserror.WithCode
+
+	// FollowRequestsGet handles the getting of a page of the authed account's incoming follow requests
+	FollowRequestsGet(ctx context.Context, auth *oauth.Auth, maxID string, sinceID string, minID string, limit int) (*apimodel.FollowRequestsResponse, gtserror.WithCode)
 	// FollowRequestAccept handles the acceptance of a follow request from the given account ID
 	FollowRequestAccept(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// FollowRequestReject handles the rejection of a follow request from the given account ID
+	FollowRequestReject(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode)
+
+	// HealthReadyGet checks whether the database, storage and work queues are usable, for deciding whether this instance is ready to serve requests.
+	HealthReadyGet(ctx context.Context) *apimodel.Readiness
 
 	// InstanceGet retrieves instance information for serving at api/v1/instance
 	InstanceGet(ctx context.Context, domain string) (*apimodel.Instance, gtserror.WithCode)
+	// InstanceGetV2 retrieves information about this instance for serving at api/v2/instance
+	InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gtserror.WithCode)
 	// InstancePatch updates this instance according to the given form.
 	//
 	// It should already be ascertained that the requesting account is authenticated and an admin.
-	InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.Instance, gtserror.WithCode)
+	InstancePatch(ctx context.Context, authed *oauth.Auth, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.Instance, gtserror.WithCode)
+	// InstancePageGet retrieves the static instance page with the given slug, for serving on the web.
+	InstancePageGet(ctx context.Context, slug string) (*apimodel.InstancePage, gtserror.WithCode)
+	// InstanceRulesGet returns the rules of this instance, in the order they should be shown.
+	InstanceRulesGet(ctx context.Context) ([]*apimodel.Rule, gtserror.WithCode)
+
+	// ListsGet returns all lists owned by the requesting account.
+	ListsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.List, gtserror.WithCode)
+	// ListGet returns the list with the given ID, if it's owned by the requesting account.
+	ListGet(ctx context.Context, authed *oauth.Auth, listID string) (*apimodel.List, gtserror.WithCode)
+	// ListCreate creates a new list owned by the requesting account, using the given form.
+	ListCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode)
+	// ListUpdate updates the title and replies policy of the list with the given ID, using the given form.
+	ListUpdate(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListCreateRequest) (*apimodel.List, gtserror.WithCode)
+	// ListDelete deletes the list with the given ID, along with its members.
+	ListDelete(ctx context.Context, authed *oauth.Auth, listID string) gtserror.WithCode
+	// ListAccountsGet returns the accounts that are members of the list with the given ID.
+	ListAccountsGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, limit int) (*apimodel.ListAccountsResponse, gtserror.WithCode)
+	// ListAccountsAdd adds the accounts in the given form to the list with the given ID. The accounts must be followed by the requesting account.
+	ListAccountsAdd(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode
+	// ListAccountsRemove removes the accounts in the given form from the list with the given ID.
+	ListAccountsRemove(ctx context.Context, authed *oauth.Auth, listID string, form *apimodel.ListAccountsChangeRequest) gtserror.WithCode
 
 	// MediaCreate handles the creation of a media attachment, using the given form.
 	MediaCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AttachmentRequest) (*apimodel.Attachment, error)
@@ -134,12 +328,56 @@ type Processor interface {
 	// MediaUpdate handles the PUT of a media attachment with the given ID and form
 	MediaUpdate(ctx context.Context, authed *oauth.Auth, attachmentID string, form *apimodel.AttachmentUpdateRequest) (*apimodel.Attachment, gtserror.WithCode)
 
-	// NotificationsGet
-	NotificationsGet(ctx context.Context, authed *oauth.Auth, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode)
-
-	// SearchGet performs a search with the given params, resolving/dereferencing remotely as desired
+	// MutesGet returns a list of accounts muted by the requesting account.
+	MutesGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, limit int) (*apimodel.MutesResponse, gtserror.WithCode)
+
+	// OEmbedGet returns the oEmbed representation of the public status at the url given in the form.
+	OEmbedGet(ctx context.Context, form *apimodel.OEmbedRequest) (*apimodel.OEmbed, gtserror.WithCode)
+
+	// NotificationsGet returns notifications for the requesting account, optionally limited to the given types and excluding the given excludeTypes.
+	NotificationsGet(ctx context.Context, authed *oauth.Auth, types []string, excludeTypes []string, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode)
+	// NotificationDismiss deletes the notification with the given ID, if it belongs to the requesting account.
+	NotificationDismiss(ctx context.Context, authed *oauth.Auth, notificationID string) gtserror.WithCode
+	// NotificationsClear deletes all notifications belonging to the requesting account.
+	NotificationsClear(ctx context.Context, authed *oauth.Auth) gtserror.WithCode
+	// NotificationPreferencesGet returns which types of notification the requesting account wants to receive.
+	NotificationPreferencesGet(ctx context.Context, authed *oauth.Auth) (*apimodel.NotificationPreferences, gtserror.WithCode)
+	// NotificationPreferencesUpdate changes which types of notification the requesting account wants to receive, using the given form.
+	NotificationPreferencesUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.NotificationPreferencesUpdateRequest) (*apimodel.NotificationPreferences, gtserror.WithCode)
+
+	// PollGet returns the poll with the given ID, if the status it's attached to is visible to the requesting account.
+	PollGet(ctx context.Context, authed *oauth.Auth, pollID string) (*apimodel.Poll, gtserror.WithCode)
+	// PollVote casts the requesting account's vote in the poll with the given ID, choosing the options with the given indexes.
+	PollVote(ctx context.Context, authed *oauth.Auth, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode)
+
+	// PreferencesGet returns the posting and reading preferences of the requesting user.
+	PreferencesGet(ctx context.Context, authed *oauth.Auth) (*apimodel.Preferences, gtserror.WithCode)
+	// PreferencesUpdate updates the posting and reading preferences of the requesting user using the given form.
+	PreferencesUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.PreferencesUpdateRequest) (*apimodel.Preferences, gtserror.WithCode)
+
+	// PushSubscriptionCreate subscribes the token the request was made with to push notifications, replacing any subscription it already had.
+	PushSubscriptionCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionCreateRequest) (*apimodel.PushSubscription, gtserror.WithCode)
+	// PushSubscriptionGet returns the push subscription of the token the request was made with.
+	PushSubscriptionGet(ctx context.Context, authed *oauth.Auth) (*apimodel.PushSubscription, gtserror.WithCode)
+	// PushSubscriptionUpdate changes the alerts and policy of the push subscription of the token the request was made with.
+	PushSubscriptionUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionUpdateRequest) (*apimodel.PushSubscription, gtserror.WithCode)
+	// PushSubscriptionDelete removes the push subscription of the token the request was made with, if it has one.
+	PushSubscriptionDelete(ctx context.Context, authed *oauth.Auth) gtserror.WithCode
+
+	// ReportCreate files a report by the requesting account against the account given in the form, optionally forwarding it to the reported account's instance.
+	ReportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ReportCreateRequest) (*apimodel.Report, gtserror.WithCode)
+
+	// SearchGet performs a search with the given params, resolving/dereferencing remotely as desired.
+	// If nothing can be found by mention or URI, accounts, hashtags and statuses are searched for by text.
 	SearchGet(ctx context.Context, authed *oauth.Auth, searchQuery *apimodel.SearchQuery) (*apimodel.SearchResult, gtserror.WithCode)
 
+	// SessionsGet returns the oauth tokens that the requesting user holds, newest first, with when and where each was last used.
+	SessionsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Session, gtserror.WithCode)
+	// SessionRevoke revokes the requesting user's oauth token with the given ID.
+	SessionRevoke(ctx context.Context, authed *oauth.Auth, id string) gtserror.WithCode
+	// SessionsRevoke revokes all of the requesting user's oauth tokens except the one used to make the request.
+	SessionsRevoke(ctx context.Context, authed *oauth.Auth) gtserror.WithCode
+
 	// StatusCreate processes the given form to create a new status, returning the api model representation of that status if it's OK.
 	StatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, error)
 	// StatusDelete processes the delete of a given status, returning the deleted status if the delete goes through.
@@ -160,11 +398,57 @@ type Processor interface {
 	StatusUnfave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
 	// StatusGetContext returns the context (previous and following posts) from the given status ID
 	StatusGetContext(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Context, gtserror.WithCode)
+	// StatusMute mutes the thread that the given status is part of, so that the authed account no longer gets notifications about it.
+	StatusMute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnmute undoes a mute of the thread that the given status is part of.
+	StatusUnmute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// StatusEdit processes the edit of a given status, returning the edited status if the edit goes through.
+	StatusEdit(ctx context.Context, authed *oauth.Auth, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode)
+	// StatusHistoryGet returns all versions of the given status, oldest first, taking account of privacy settings and blocks etc.
+	StatusHistoryGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode)
+	// StatusSourceGet returns the plain source of the given status, so that its author can edit it.
+	StatusSourceGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode)
+
+	// SuggestionsGet returns accounts that the requesting account might want to follow, most relevant first.
+	SuggestionsGet(ctx context.Context, authed *oauth.Auth, limit int) ([]*apimodel.Suggestion, gtserror.WithCode)
+	// SuggestionDismiss stops the account with the given ID from being suggested to the requesting account.
+	SuggestionDismiss(ctx context.Context, authed *oauth.Auth, targetAccountID string) gtserror.WithCode
+
+	// TagGet returns the hashtag with the given name, and whether the requesting account follows it.
+	TagGet(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+	// TagFollow makes the requesting account follow the hashtag with the given name, creating the tag if nobody has used it yet.
+	TagFollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+	// TagUnfollow makes the requesting account stop following the hashtag with the given name.
+	TagUnfollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode)
+
+	// TrendingTagsGet returns the approved hashtags that are currently trending on this instance, skipping the first offset of them.
+	TrendingTagsGet(ctx context.Context, authed *oauth.Auth, limit int, offset int) ([]*apimodel.Tag, gtserror.WithCode)
+	// TrendingStatusesGet returns the statuses that are currently trending on this instance, skipping the first offset of them.
+	TrendingStatusesGet(ctx context.Context, authed *oauth.Auth, limit int, offset int) ([]*apimodel.Status, gtserror.WithCode)
+	// TrendingLinksGet returns the links that are currently being shared a lot on this instance, skipping the first offset of them.
+	TrendingLinksGet(ctx context.Context, authed *oauth.Auth, limit int, offset int) ([]*apimodel.TrendsLink, gtserror.WithCode)
+
+	// UserConfirmEmail confirms the email address of the user who was sent the given confirmation token, so that they can sign in with it.
+	// The returned user has its account set.
+	UserConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode)
+	// UserConfirmationResend sends the requesting user a new confirmation email, if their email address isn't confirmed yet.
+	UserConfirmationResend(ctx context.Context, authed *oauth.Auth) gtserror.WithCode
+	// UserPasswordChange changes the password of the requesting user, and signs them out of every other app.
+	UserPasswordChange(ctx context.Context, authed *oauth.Auth, form *apimodel.PasswordChangeRequest) gtserror.WithCode
+	// UserPasswordResetRequest emails a password reset link to the user with the given email address, if there is one.
+	// Whether or not there is, nil is returned, so that callers can't find out which addresses are signed up.
+	UserPasswordResetRequest(ctx context.Context, form *apimodel.PasswordResetRequest) gtserror.WithCode
+	// UserPasswordReset sets the password of the user who was emailed the given reset token, and signs them out everywhere.
+	UserPasswordReset(ctx context.Context, form *apimodel.PasswordResetConfirmRequest) gtserror.WithCode
 
 	// HomeTimelineGet returns statuses from the home timeline, with the given filters/parameters.
 	HomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// ListTimelineGet returns statuses from the timeline of the given list, with the given filters/parameters.
+	ListTimelineGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// PublicTimelineGet returns statuses from the public/local timeline, with the given filters/parameters.
 	PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// TagTimelineGet returns public statuses that use the given hashtag, with the given filters/parameters.
+	TagTimelineGet(ctx context.Context, authed *oauth.Auth, tagName string, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// FavedTimelineGet returns faved statuses, with the given filters/parameters.
 	FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 
@@ -189,6 +473,11 @@ type Processor interface {
 	// authentication before returning a JSON serializable interface to the caller.
 	GetFediFollowers(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
 
+	// GetFediFollowersSynchronization handles the getting of the followers of a user/account that are on the instance of the
+	// requester, for follower synchronization, performing appropriate authentication before returning a JSON serializable
+	// interface to the caller.
+	GetFediFollowersSynchronization(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
+
 	// GetFediFollowing handles the getting of a fedi/activitypub representation of a user/account's following, performing appropriate
 	// authentication before returning a JSON serializable interface to the caller.
 	GetFediFollowing(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
@@ -201,9 +490,20 @@ type Processor interface {
 	// authentication before returning a JSON serializable interface to the caller.
 	GetFediStatusReplies(ctx context.Context, requestedUsername string, requestedStatusID string, page bool, onlyOtherAccounts bool, minID string, requestURL *url.URL) (interface{}, gtserror.WithCode)
 
+	// GetFediFeaturedCollection handles the getting of a fedi/activitypub representation of the pinned statuses of a user/account,
+	// performing appropriate authentication before returning a JSON serializable interface to the caller.
+	GetFediFeaturedCollection(ctx context.Context, requestedUsername string, requestURL *url.URL) (interface{}, gtserror.WithCode)
+
+	// GetFediOutbox handles the getting of a fedi/activitypub representation of the outbox of a user/account, performing appropriate
+	// authentication before returning a JSON serializable interface to the caller.
+	GetFediOutbox(ctx context.Context, requestedUsername string, page bool, maxID string, minID string, requestURL *url.URL) (interface{}, gtserror.WithCode)
+
 	// GetWebfingerAccount handles the GET for a webfinger resource. Most commonly, it will be used for returning account lookups.
 	GetWebfingerAccount(ctx context.Context, requestedUsername string) (*apimodel.WellKnownResponse, gtserror.WithCode)
 
+	// GetHostMeta returns the host-meta document of this instance, which points remote servers towards our webfinger endpoint.
+	GetHostMeta(ctx context.Context) (*apimodel.HostMeta, gtserror.WithCode)
+
 	// GetNodeInfoRel returns a well known response giving the path to node info.
 	GetNodeInfoRel(ctx context.Context, request *http.Request) (*apimodel.WellKnownResponse, gtserror.WithCode)
 
@@ -238,6 +538,13 @@ type processor struct {
 	timelineManager timeline.Manager
 	db              db.DB
 	filter          visibility.Filter
+	spamFilter      spam.Filter
+	inboxFilter     inboxfilter.Chain
+	webPushSender   webpush.Sender
+	emailSender     email.Sender
+	fieldVerifier   relme.Verifier
+	formatter       text.Formatter
+	trends          trends.Trends
 
 	/*
 		SUB-PROCESSORS
@@ -250,16 +557,20 @@ type processor struct {
 	mediaProcessor     mediaProcessor.Processor
 }
 
-// NewProcessor returns a new Processor that uses the given federator and logger
-func NewProcessor(config *config.Config, tc typeutils.TypeConverter, federator federation.Federator, oauthServer oauth.Server, mediaHandler media.Handler, storage *kv.KVStore, timelineManager timeline.Manager, db db.DB, log *logrus.Logger) Processor {
+// NewProcessor returns a new Processor that uses the given federator and logger.
+//
+// The field verifier fetches pages that users link to from their profile fields, and the web push sender posts
+// to endpoints that users give us when subscribing, so outside of tests they should both use a client that
+// can't reach private addresses, like the one from transport.NewPublicClient.
+func NewProcessor(config *config.Config, tc typeutils.TypeConverter, federator federation.Federator, oauthServer oauth.Server, mediaHandler media.Handler, storage *kv.KVStore, timelineManager timeline.Manager, db db.DB, fieldVerifier relme.Verifier, webPushSender webpush.Sender, log *logrus.Logger) Processor {
 	fromClientAPI := make(chan messages.FromClientAPI, 1000)
 	fromFederator := make(chan messages.FromFederator, 1000)
 
-	statusProcessor := status.New(db, tc, config, fromClientAPI, log)
+	statusProcessor := status.New(db, tc, config, fromClientAPI, federator, log)
 	streamingProcessor := streaming.New(db, tc, oauthServer, config, log)
 	accountProcessor := account.New(db, tc, mediaHandler, oauthServer, fromClientAPI, federator, config, log)
 	adminProcessor := admin.New(db, tc, mediaHandler, fromClientAPI, config, log)
-	mediaProcessor := mediaProcessor.New(db, tc, mediaHandler, storage, config, log)
+	mediaProcessor := mediaProcessor.New(db, tc, mediaHandler, fromClientAPI, storage, config, log)
 
 	return &processor{
 		fromClientAPI:   fromClientAPI,
@@ -275,6 +586,13 @@ func NewProcessor(config *config.Config, tc typeutils.TypeConverter, federator f
 		timelineManager: timelineManager,
 		db:              db,
 		filter:          visibility.NewFilter(db, log),
+		spamFilter:      spam.New(config, db),
+		inboxFilter:     inboxfilter.New(config),
+		webPushSender:   webPushSender,
+		emailSender:     email.NewSender(config, log),
+		fieldVerifier:   fieldVerifier,
+		formatter:       text.NewFormatter(config, db, log),
+		trends:          trends.New(db, log),
 
 		accountProcessor:   accountProcessor,
 		adminProcessor:     adminProcessor,
@@ -291,17 +609,25 @@ func (p *processor) Start(ctx context.Context) error {
 		for {
 			select {
 			case clientMsg := <-p.fromClientAPI:
-				p.log.Tracef("received message FROM client API: %+v", clientMsg)
+				msgCtx := log.WithRequestID(ctx, clientMsg.RequestID)
+				p.log.WithContext(msgCtx).WithFields(logrus.Fields{
+					"apObjectType":   clientMsg.APObjectType,
+					"apActivityType": clientMsg.APActivityType,
+				}).Trace("received message from client API")
 				go func() {
-					if err := p.ProcessFromClientAPI(ctx, clientMsg); err != nil {
-						p.log.Error(err)
+					if err := p.ProcessFromClientAPI(msgCtx, clientMsg); err != nil {
+						p.log.WithContext(msgCtx).WithError(err).Error("error processing message from client API")
 					}
 				}()
 			case federatorMsg := <-p.fromFederator:
-				p.log.Tracef("received message FROM federator: %+v", federatorMsg)
+				msgCtx := log.WithRequestID(ctx, federatorMsg.RequestID)
+				p.log.WithContext(msgCtx).WithFields(logrus.Fields{
+					"apObjectType":   federatorMsg.APObjectType,
+					"apActivityType": federatorMsg.APActivityType,
+				}).Trace("received message from federator")
 				go func() {
-					if err := p.ProcessFromFederator(ctx, federatorMsg); err != nil {
-						p.log.Error(err)
+					if err := p.ProcessFromFederator(msgCtx, federatorMsg); err != nil {
+						p.log.WithContext(msgCtx).WithError(err).Error("error processing message from federator")
 					}
 				}()
 			case <-p.stop:
@@ -309,6 +635,15 @@ func (p *processor) Start(ctx context.Context) error {
 			}
 		}
 	}()
+
+	if p.config.RetentionConfig.RemoteStatusDays > 0 || p.config.RetentionConfig.RemoteAccountDays > 0 || p.config.RetentionConfig.InboxActivityDays > 0 || p.config.RetentionConfig.FailedDeliveryDays > 0 {
+		go p.sweepRemoteContent(ctx)
+	}
+
+	go p.sweepPolls(ctx)
+	go p.updateTrends(ctx)
+	go p.sweepFieldVerifications(ctx)
+	go p.sweepExpiredStatuses(ctx)
 	return nil
 }
 
@@ -171,6 +171,8 @@ func (suite *ProcessingStandardTestSuite) SetupTest() {
 		suite.storage,
 		suite.timelineManager,
 		suite.db,
+		testrig.NewTestFieldVerifier(),
+		testrig.NewTestWebPushSender(suite.db),
 		suite.log)
 
 	testrig.StandardDBSetup(suite.db, suite.testAccounts)
@@ -0,0 +1,222 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+func (p *processor) PushSubscriptionCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionCreateRequest) (*apimodel.PushSubscription, gtserror.WithCode) {
+	if form.Subscription == nil || form.Subscription.Keys == nil {
+		return nil, gtserror.NewErrorBadRequest(errors.New("no subscription given"), "subscription[endpoint], subscription[keys][p256dh] and subscription[keys][auth] must be set")
+	}
+
+	endpoint, err := url.Parse(form.Subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("bad endpoint %s", form.Subscription.Endpoint), "subscription[endpoint] must be an https url")
+	}
+
+	// we'll be posting to the endpoint on every notification, so it mustn't point into the network we run in
+	if err := transport.CheckPublicHost(ctx, endpoint.Hostname()); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, "subscription[endpoint] must be a public address")
+	}
+
+	if err := webpush.ValidateSubscriptionKeys(form.Subscription.Keys.P256dh, form.Subscription.Keys.Auth); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	token, errWithCode := p.authedToken(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	subID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	sub := &gtsmodel.WebPushSubscription{
+		ID:        subID,
+		AccountID: authed.Account.ID,
+		TokenID:   token.ID,
+		Endpoint:  form.Subscription.Endpoint,
+		Auth:      form.Subscription.Keys.Auth,
+		P256dh:    form.Subscription.Keys.P256dh,
+		Policy:    gtsmodel.WebPushPolicyAll,
+	}
+	if errWithCode := applyPushSubscriptionData(sub, form.Data); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// each token has at most one subscription, so a new one replaces whatever was there before
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "token_id", Value: token.ID}}, &gtsmodel.WebPushSubscription{}); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting old push subscription: %s", err))
+	}
+
+	if err := p.db.Put(ctx, sub); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting push subscription in the db: %s", err))
+	}
+
+	return p.pushSubscriptionToMasto(ctx, sub)
+}
+
+func (p *processor) PushSubscriptionGet(ctx context.Context, authed *oauth.Auth) (*apimodel.PushSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getPushSubscription(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.pushSubscriptionToMasto(ctx, sub)
+}
+
+func (p *processor) PushSubscriptionUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.PushSubscriptionUpdateRequest) (*apimodel.PushSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getPushSubscription(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Policy != "" {
+		if form.Data == nil {
+			form.Data = &apimodel.PushSubscriptionRequestData{}
+		}
+		if form.Data.Policy == "" {
+			form.Data.Policy = form.Policy
+		}
+	}
+	if errWithCode := applyPushSubscriptionData(sub, form.Data); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	sub.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, sub); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating push subscription in the db: %s", err))
+	}
+
+	return p.pushSubscriptionToMasto(ctx, sub)
+}
+
+func (p *processor) PushSubscriptionDelete(ctx context.Context, authed *oauth.Auth) gtserror.WithCode {
+	token, errWithCode := p.authedToken(ctx, authed)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "token_id", Value: token.ID}}, &gtsmodel.WebPushSubscription{}); err != nil && err != db.ErrNoEntries {
+		return gtserror.NewErrorInternalError(fmt.Errorf("error deleting push subscription: %s", err))
+	}
+
+	return nil
+}
+
+// authedToken returns the stored oauth token that the request was made with.
+func (p *processor) authedToken(ctx context.Context, authed *oauth.Auth) (*gtsmodel.Token, gtserror.WithCode) {
+	token := &gtsmodel.Token{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "access", Value: authed.Token.GetAccess()}}, token); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotAuthorized(errors.New("token not found"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting token: %s", err))
+	}
+	return token, nil
+}
+
+// getPushSubscription returns the push subscription of the token that the request was made with.
+func (p *processor) getPushSubscription(ctx context.Context, authed *oauth.Auth) (*gtsmodel.WebPushSubscription, gtserror.WithCode) {
+	token, errWithCode := p.authedToken(ctx, authed)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	sub := &gtsmodel.WebPushSubscription{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "token_id", Value: token.ID}}, sub); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(errors.New("no push subscription for this token"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting push subscription: %s", err))
+	}
+	return sub, nil
+}
+
+// pushSubscriptionToMasto converts the given subscription to its frontend representation, with our VAPID key.
+func (p *processor) pushSubscriptionToMasto(ctx context.Context, sub *gtsmodel.WebPushSubscription) (*apimodel.PushSubscription, gtserror.WithCode) {
+	apiSub, err := p.tc.WebPushSubscriptionToMasto(ctx, sub)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	vapidKey, err := p.webPushSender.VAPIDPublicKey(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	apiSub.ServerKey = vapidKey
+
+	return apiSub, nil
+}
+
+// applyPushSubscriptionData sets the alerts and policy given in data on the subscription.
+// Alerts and policy that aren't given are left as they are.
+func applyPushSubscriptionData(sub *gtsmodel.WebPushSubscription, data *apimodel.PushSubscriptionRequestData) gtserror.WithCode {
+	if data == nil {
+		return nil
+	}
+
+	switch gtsmodel.WebPushPolicy(data.Policy) {
+	case "":
+	case gtsmodel.WebPushPolicyAll, gtsmodel.WebPushPolicyFollowed, gtsmodel.WebPushPolicyFollower, gtsmodel.WebPushPolicyNone:
+		sub.Policy = gtsmodel.WebPushPolicy(data.Policy)
+	default:
+		return gtserror.NewErrorBadRequest(fmt.Errorf("bad policy %s", data.Policy), "policy must be one of all, followed, follower or none")
+	}
+
+	if data.Alerts == nil {
+		return nil
+	}
+
+	for _, alert := range []struct {
+		set   *bool
+		value *bool
+	}{
+		{data.Alerts.Follow, &sub.AlertFollow},
+		{data.Alerts.FollowRequest, &sub.AlertFollowRequest},
+		{data.Alerts.Favourite, &sub.AlertFavourite},
+		{data.Alerts.Mention, &sub.AlertMention},
+		{data.Alerts.Reblog, &sub.AlertReblog},
+		{data.Alerts.Poll, &sub.AlertPoll},
+		{data.Alerts.Status, &sub.AlertStatus},
+	} {
+		if alert.set != nil {
+			*alert.value = *alert.set
+		}
+	}
+
+	return nil
+}
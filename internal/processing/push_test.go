@@ -0,0 +1,147 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+type PushTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *PushTestSuite) authed() *oauth.Auth {
+	return &oauth.Auth{
+		Token:       oauth.DBTokenToToken(suite.testTokens["local_account_1"]),
+		Application: suite.testApplications["local_account_1"],
+		User:        suite.testUsers["local_account_1"],
+		Account:     suite.testAccounts["local_account_1"],
+	}
+}
+
+func (suite *PushTestSuite) createForm() *apimodel.PushSubscriptionCreateRequest {
+	p256dh, _, err := webpush.GenerateVAPIDKeys()
+	suite.NoError(err)
+	yes := true
+
+	return &apimodel.PushSubscriptionCreateRequest{
+		Subscription: &apimodel.PushSubscriptionRequestSubscription{
+			Endpoint: "https://push.example.org/send/abcdef",
+			Keys: &apimodel.PushSubscriptionRequestKeys{
+				P256dh: p256dh,
+				Auth:   "AAAAAAAAAAAAAAAAAAAAAA",
+			},
+		},
+		Data: &apimodel.PushSubscriptionRequestData{
+			Alerts: &apimodel.PushSubscriptionRequestAlerts{
+				Mention: &yes,
+			},
+		},
+	}
+}
+
+func (suite *PushTestSuite) TestPushSubscriptionLifecycle() {
+	ctx := context.Background()
+	authed := suite.authed()
+
+	// nothing to get yet
+	_, errWithCode := suite.processor.PushSubscriptionGet(ctx, authed)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	created, errWithCode := suite.processor.PushSubscriptionCreate(ctx, authed, suite.createForm())
+	suite.NoError(errWithCode)
+	suite.Equal("https://push.example.org/send/abcdef", created.Endpoint)
+	suite.NotEmpty(created.ServerKey)
+	suite.Equal("all", created.Policy)
+	suite.True(created.Alerts.Mention)
+	suite.False(created.Alerts.Follow)
+
+	got, errWithCode := suite.processor.PushSubscriptionGet(ctx, authed)
+	suite.NoError(errWithCode)
+	suite.Equal(created, got)
+
+	// only the given alerts and policy are changed
+	yes := true
+	updated, errWithCode := suite.processor.PushSubscriptionUpdate(ctx, authed, &apimodel.PushSubscriptionUpdateRequest{
+		Data: &apimodel.PushSubscriptionRequestData{
+			Alerts: &apimodel.PushSubscriptionRequestAlerts{
+				Follow: &yes,
+			},
+		},
+		Policy: "followed",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(created.ID, updated.ID)
+	suite.True(updated.Alerts.Mention)
+	suite.True(updated.Alerts.Follow)
+	suite.Equal("followed", updated.Policy)
+
+	// subscribing again replaces the subscription
+	replaced, errWithCode := suite.processor.PushSubscriptionCreate(ctx, authed, suite.createForm())
+	suite.NoError(errWithCode)
+	suite.NotEqual(created.ID, replaced.ID)
+	suite.False(replaced.Alerts.Follow)
+
+	suite.NoError(suite.processor.PushSubscriptionDelete(ctx, authed))
+	_, errWithCode = suite.processor.PushSubscriptionGet(ctx, authed)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *PushTestSuite) TestPushSubscriptionCreateInvalid() {
+	ctx := context.Background()
+	authed := suite.authed()
+
+	form := suite.createForm()
+	form.Subscription.Endpoint = "http://push.example.org/send/abcdef"
+	_, errWithCode := suite.processor.PushSubscriptionCreate(ctx, authed, form)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	for _, endpoint := range []string{
+		"https://localhost:8443/send/abcdef",
+		"https://10.0.0.1/send/abcdef",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/send/abcdef",
+	} {
+		form = suite.createForm()
+		form.Subscription.Endpoint = endpoint
+		_, errWithCode = suite.processor.PushSubscriptionCreate(ctx, authed, form)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code(), endpoint)
+	}
+
+	form = suite.createForm()
+	form.Subscription.Keys.Auth = "AAAA"
+	_, errWithCode = suite.processor.PushSubscriptionCreate(ctx, authed, form)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	form = suite.createForm()
+	form.Data.Policy = "friends"
+	_, errWithCode = suite.processor.PushSubscriptionCreate(ctx, authed, form)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestPushTestSuite(t *testing.T) {
+	suite.Run(t, &PushTestSuite{})
+}
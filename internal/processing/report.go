@@ -0,0 +1,320 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *processor) AdminReportsGet(ctx context.Context, authed *oauth.Auth, resolved bool, accountID string, targetAccountID string) ([]*apimodel.AdminReportInfo, gtserror.WithCode) {
+	where := []db.Where{{Key: "action_taken", Value: resolved}}
+	if accountID != "" {
+		where = append(where, db.Where{Key: "account_id", Value: accountID})
+	}
+	if targetAccountID != "" {
+		where = append(where, db.Where{Key: "target_account_id", Value: targetAccountID})
+	}
+
+	reports := []*gtsmodel.Report{}
+	if err := p.db.GetWhere(ctx, where, &reports); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// ids are ulids, so going backwards through them gives newest first
+	mastoReports := make([]*apimodel.AdminReportInfo, 0, len(reports))
+	for i := len(reports) - 1; i >= 0; i-- {
+		mastoReport, err := p.tc.ReportToAdminMasto(ctx, reports[i], authed.Account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoReports = append(mastoReports, mastoReport)
+	}
+
+	return mastoReports, nil
+}
+
+func (p *processor) AdminReportGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report := &gtsmodel.Report{}
+	if err := p.db.GetByID(ctx, id, report); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	mastoReport, err := p.tc.ReportToAdminMasto(ctx, report, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoReport, nil
+}
+
+func (p *processor) AdminReportAssign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getAdminReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.AssignedAccountID = authed.Account.ID
+	return p.updateAdminReport(ctx, authed, report, gtsmodel.AdminAuditLogActionAssign)
+}
+
+func (p *processor) AdminReportUnassign(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getAdminReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	report.AssignedAccountID = ""
+	return p.updateAdminReport(ctx, authed, report, gtsmodel.AdminAuditLogActionUnassign)
+}
+
+func (p *processor) AdminReportResolve(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.AdminReportResolveRequest) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getAdminReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if report.ActionTaken {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("report %s is already resolved", report.ID), "report is already resolved")
+	}
+
+	if err := validate.ReportComment(form.ActionTakenComment); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.resolveReport(ctx, authed.Account, report, text.RemoveHTML(form.ActionTakenComment)); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoReport, err := p.tc.ReportToAdminMasto(ctx, report, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoReport, nil
+}
+
+func (p *processor) AdminReportReopen(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report, errWithCode := p.getAdminReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !report.ActionTaken {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("report %s is not resolved", report.ID), "report is not resolved")
+	}
+
+	report.ActionTaken = false
+	report.ActionTakenAt = time.Time{}
+	report.ActionTakenByAccountID = ""
+	report.ActionTakenComment = ""
+	return p.updateAdminReport(ctx, authed, report, gtsmodel.AdminAuditLogActionReopen)
+}
+
+// resolveReport marks the given report as resolved by the given moderator account, with the given
+// note about what was done, and lets the reporter know that their report has been dealt with.
+func (p *processor) resolveReport(ctx context.Context, moderator *gtsmodel.Account, report *gtsmodel.Report, comment string) error {
+	report.ActionTaken = true
+	report.ActionTakenAt = time.Now()
+	report.ActionTakenByAccountID = moderator.ID
+	report.ActionTakenComment = comment
+	report.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, report); err != nil {
+		return fmt.Errorf("error updating report %s: %s", report.ID, err)
+	}
+	p.adminAudit(ctx, moderator, gtsmodel.AdminAuditLogActionResolve, gtsmodel.AdminAuditLogTargetReport, report.ID, "")
+
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActivityFlag,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       report,
+		OriginAccount:  moderator,
+	}
+
+	return nil
+}
+
+func (p *processor) getAdminReport(ctx context.Context, id string) (*gtsmodel.Report, gtserror.WithCode) {
+	report := &gtsmodel.Report{}
+	if err := p.db.GetByID(ctx, id, report); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+	return report, nil
+}
+
+func (p *processor) updateAdminReport(ctx context.Context, authed *oauth.Auth, report *gtsmodel.Report, auditAction gtsmodel.AdminAuditLogAction) (*apimodel.AdminReportInfo, gtserror.WithCode) {
+	report.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, report); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, auditAction, gtsmodel.AdminAuditLogTargetReport, report.ID, "")
+
+	mastoReport, err := p.tc.ReportToAdminMasto(ctx, report, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoReport, nil
+}
+
+func (p *processor) ReportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ReportCreateRequest) (*apimodel.Report, gtserror.WithCode) {
+	if form.AccountID == "" {
+		return nil, gtserror.NewErrorBadRequest(errors.New("no account id provided"), "no account id provided")
+	}
+
+	if form.AccountID == authed.Account.ID {
+		return nil, gtserror.NewErrorBadRequest(errors.New("account tried to report itself"), "you cannot report yourself")
+	}
+
+	// a report that points at broken rules is about a rule violation
+	if form.Category == "" && len(form.RuleIDs) != 0 {
+		form.Category = string(gtsmodel.ReportCategoryViolation)
+	}
+
+	if form.Category == "" {
+		form.Category = string(gtsmodel.ReportCategoryOther)
+	}
+
+	if err := validate.ReportCategory(form.Category); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(form.RuleIDs) != 0 && form.Category != string(gtsmodel.ReportCategoryViolation) {
+		err := fmt.Errorf("rule ids can only be given with category %s", gtsmodel.ReportCategoryViolation)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.ReportComment(form.Comment); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetAccount, err := p.db.GetAccountByID(ctx, form.AccountID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("account %s not found", form.AccountID))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// only statuses by the reported account can be attached to the report
+	statusIDs := []string{}
+	seen := map[string]bool{}
+	for _, statusID := range form.StatusIDs {
+		if seen[statusID] {
+			continue
+		}
+		seen[statusID] = true
+
+		status, err := p.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil, gtserror.NewErrorNotFound(fmt.Errorf("status %s not found", statusID))
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if status.AccountID != targetAccount.ID {
+			err := fmt.Errorf("status %s doesn't belong to account %s", statusID, targetAccount.ID)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		statusIDs = append(statusIDs, status.ID)
+	}
+
+	ruleIDs := []string{}
+	seen = map[string]bool{}
+	for _, ruleID := range form.RuleIDs {
+		if seen[ruleID] {
+			continue
+		}
+		seen[ruleID] = true
+
+		rule := &gtsmodel.Rule{}
+		if err := p.db.GetByID(ctx, ruleID, rule); err != nil {
+			if err == db.ErrNoEntries {
+				return nil, gtserror.NewErrorNotFound(fmt.Errorf("rule %s not found", ruleID))
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+
+	reportID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             util.GenerateURIForReport(p.config.Protocol, p.config.Host, reportID),
+		AccountID:       authed.Account.ID,
+		Account:         authed.Account,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		StatusIDs:       statusIDs,
+		RuleIDs:         ruleIDs,
+		Comment:         text.RemoveHTML(form.Comment),
+		Category:        gtsmodel.ReportCategory(form.Category),
+		// there's nobody to forward a report on a local account to
+		Forwarded: form.Forward && targetAccount.Domain != "",
+	}
+	if err := p.db.Put(ctx, report); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// notify our moderators and, if asked, forward the report to the reported account's instance
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityFlag,
+		GTSModel:       report,
+		OriginAccount:  authed.Account,
+		TargetAccount:  targetAccount,
+	}
+
+	mastoReport, err := p.tc.ReportToMasto(ctx, report)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoReport, nil
+}
@@ -0,0 +1,271 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ReportTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ReportTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+func (suite *ReportTestSuite) TestReportCreate() {
+	ctx := context.Background()
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	report, errWithCode := suite.processor.ReportCreate(ctx, suite.authed("local_account_2"), &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		StatusIDs: []string{targetStatus.ID, targetStatus.ID},
+		Comment:   "this is <b>spam</b>",
+		Forward:   true,
+		Category:  "spam",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("spam", report.Category)
+	suite.Equal("this is spam", report.Comment)
+	suite.Equal([]string{targetStatus.ID}, report.StatusIDs)
+	suite.Equal(targetAccount.ID, report.TargetAccount.ID)
+	suite.False(report.ActionTaken)
+
+	// zork is local, so there's nowhere to forward the report to
+	suite.False(report.Forwarded)
+
+	dbReport := &gtsmodel.Report{}
+	err := suite.db.GetByID(ctx, report.ID, dbReport)
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, dbReport.AccountID)
+	suite.Equal(gtsmodel.ReportCategorySpam, dbReport.Category)
+	suite.Equal("http://localhost:8080/reports/"+report.ID, dbReport.URI)
+
+	// the report should be waiting for moderators
+	reports, errWithCode := suite.processor.AdminReportsGet(ctx, suite.authed("admin_account"), false, "", "")
+	suite.NoError(errWithCode)
+	suite.Len(reports, 1)
+	suite.Equal(report.ID, reports[0].ID)
+	suite.Equal("spam", reports[0].Category)
+}
+
+func (suite *ReportTestSuite) TestReportCreateRemoteForwarded() {
+	report, errWithCode := suite.processor.ReportCreate(context.Background(), suite.authed("local_account_1"), &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["remote_account_1"].ID,
+		Forward:   true,
+	})
+	suite.NoError(errWithCode)
+	suite.True(report.Forwarded)
+	suite.Equal("other", report.Category)
+	suite.Empty(report.StatusIDs)
+}
+
+func (suite *ReportTestSuite) TestReportCreateInvalid() {
+	ctx := context.Background()
+	zork := suite.authed("local_account_1")
+
+	_, errWithCode := suite.processor.ReportCreate(ctx, zork, &apimodel.ReportCreateRequest{AccountID: zork.Account.ID})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.ReportCreate(ctx, zork, &apimodel.ReportCreateRequest{AccountID: "01FQWN3Z5RZB4D9V2QBT9ZNM9P"})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	_, errWithCode = suite.processor.ReportCreate(ctx, zork, &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["local_account_2"].ID,
+		Category:  "rudeness",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// admin's status can't be attached to a report on turtle
+	_, errWithCode = suite.processor.ReportCreate(ctx, zork, &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["local_account_2"].ID,
+		StatusIDs: []string{suite.testStatuses["admin_account_status_1"].ID},
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *ReportTestSuite) TestReportCreateRuleViolations() {
+	ctx := context.Background()
+	admin := suite.authed("admin_account")
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	niceRule, errWithCode := suite.processor.AdminRuleCreate(ctx, admin, &apimodel.RuleCreateRequest{Text: "Be nice."})
+	suite.NoError(errWithCode)
+	spamRule, errWithCode := suite.processor.AdminRuleCreate(ctx, admin, &apimodel.RuleCreateRequest{Text: "No spam."})
+	suite.NoError(errWithCode)
+
+	// rules can only be given for a rule violation
+	_, errWithCode = suite.processor.ReportCreate(ctx, suite.authed("local_account_2"), &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		RuleIDs:   []string{niceRule.ID},
+		Category:  "spam",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.ReportCreate(ctx, suite.authed("local_account_2"), &apimodel.ReportCreateRequest{
+		AccountID: targetAccount.ID,
+		RuleIDs:   []string{"01FQWN3Z5RZB4D9V2QBT9ZNM9P"},
+	})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// zork breaks the same rule twice, and gets reported for it each time
+	for i := 0; i < 2; i++ {
+		report, errWithCode := suite.processor.ReportCreate(ctx, suite.authed("local_account_2"), &apimodel.ReportCreateRequest{
+			AccountID: targetAccount.ID,
+			RuleIDs:   []string{niceRule.ID, niceRule.ID},
+		})
+		suite.NoError(errWithCode)
+		suite.Equal("violation", report.Category)
+		suite.Equal([]string{niceRule.ID}, report.RuleIDs)
+
+		adminReport, errWithCode := suite.processor.AdminReportGet(ctx, admin, report.ID)
+		suite.NoError(errWithCode)
+		suite.Len(adminReport.Rules, 1)
+		suite.Equal("Be nice.", adminReport.Rules[0].Text)
+
+		// earlier violations show up on the report, so moderators can tell this is a repeat
+		suite.Len(adminReport.TargetAccountViolations, i)
+
+		_, errWithCode = suite.processor.AdminReportResolve(ctx, admin, report.ID, &apimodel.AdminReportResolveRequest{})
+		suite.NoError(errWithCode)
+	}
+
+	account, errWithCode := suite.processor.AdminAccountGet(ctx, admin, targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.Len(account.RuleViolations, 1)
+	suite.Equal(niceRule.ID, account.RuleViolations[0].Rule.ID)
+	suite.Equal(2, account.RuleViolations[0].Count)
+	suite.NotEmpty(account.RuleViolations[0].LastViolatedAt)
+
+	// the spam rule was never broken, so it's not listed
+	suite.NotEqual(spamRule.ID, account.RuleViolations[0].Rule.ID)
+}
+
+func (suite *ReportTestSuite) TestAdminReportResolve() {
+	ctx := context.Background()
+	admin := suite.authed("admin_account")
+	turtle := suite.authed("local_account_2")
+
+	report, errWithCode := suite.processor.ReportCreate(ctx, turtle, &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["local_account_1"].ID,
+		Comment:   "zork is being rude",
+	})
+	suite.NoError(errWithCode)
+
+	assigned, errWithCode := suite.processor.AdminReportAssign(ctx, admin, report.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(admin.Account.ID, assigned.AssignedAccount.ID)
+
+	resolved, errWithCode := suite.processor.AdminReportResolve(ctx, admin, report.ID, &apimodel.AdminReportResolveRequest{
+		ActionTakenComment: "had a <b>word</b> with zork",
+	})
+	suite.NoError(errWithCode)
+	suite.True(resolved.ActionTaken)
+	suite.NotEmpty(resolved.ActionTakenAt)
+	suite.Equal("had a word with zork", resolved.ActionTakenComment)
+	suite.Equal(admin.Account.ID, resolved.ActionTakenByAccount.ID)
+	suite.Equal(admin.Account.ID, resolved.AssignedAccount.ID)
+
+	// a report can only be resolved once
+	_, errWithCode = suite.processor.AdminReportResolve(ctx, admin, report.ID, &apimodel.AdminReportResolveRequest{})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// turtle should be told that their report was dealt with, without being told who by
+	notif := &gtsmodel.Notification{}
+	suite.Eventually(func() bool {
+		err := suite.db.GetWhere(ctx, []db.Where{
+			{Key: "notification_type", Value: gtsmodel.NotificationReportResolved},
+			{Key: "target_account_id", Value: turtle.Account.ID},
+		}, notif)
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	suite.Equal(report.ID, notif.ReportID)
+	suite.NotEqual(admin.Account.ID, notif.OriginAccountID)
+
+	mastoNotif, err := suite.typeconverter.NotificationToMasto(ctx, notif)
+	suite.NoError(err)
+	suite.Nil(mastoNotif.Report)
+	suite.Equal(report.ID, mastoNotif.ResolvedReport.ID)
+	suite.True(mastoNotif.ResolvedReport.ActionTaken)
+
+	resolvedReports, errWithCode := suite.processor.AdminReportsGet(ctx, admin, true, turtle.Account.ID, "")
+	suite.NoError(errWithCode)
+	suite.Len(resolvedReports, 1)
+
+	reopened, errWithCode := suite.processor.AdminReportReopen(ctx, admin, report.ID)
+	suite.NoError(errWithCode)
+	suite.False(reopened.ActionTaken)
+	suite.Empty(reopened.ActionTakenComment)
+	suite.Nil(reopened.ActionTakenByAccount)
+
+	unassigned, errWithCode := suite.processor.AdminReportUnassign(ctx, admin, report.ID)
+	suite.NoError(errWithCode)
+	suite.Nil(unassigned.AssignedAccount)
+}
+
+func (suite *ReportTestSuite) TestAdminReportResolveByAccountAction() {
+	ctx := context.Background()
+	admin := suite.authed("admin_account")
+
+	report, errWithCode := suite.processor.ReportCreate(ctx, suite.authed("local_account_2"), &apimodel.ReportCreateRequest{
+		AccountID: suite.testAccounts["local_account_1"].ID,
+	})
+	suite.NoError(errWithCode)
+
+	// a report can only be resolved by an action against the reported account
+	_, errWithCode = suite.processor.AdminAccountAction(ctx, admin, suite.testAccounts["local_account_2"].ID, &apimodel.AdminAccountActionRequest{
+		Type:     "silence",
+		ReportID: report.ID,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.processor.AdminAccountAction(ctx, admin, suite.testAccounts["local_account_1"].ID, &apimodel.AdminAccountActionRequest{
+		Type:     "silence",
+		ReportID: report.ID,
+	})
+	suite.NoError(errWithCode)
+
+	resolved, errWithCode := suite.processor.AdminReportGet(ctx, admin, report.ID)
+	suite.NoError(errWithCode)
+	suite.True(resolved.ActionTaken)
+	suite.Equal("The account was silenced.", resolved.ActionTakenComment)
+
+	unresolved, errWithCode := suite.processor.AdminReportsGet(ctx, admin, false, "", suite.testAccounts["local_account_1"].ID)
+	suite.NoError(errWithCode)
+	suite.Empty(unresolved)
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, &ReportTestSuite{})
+}
@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/retention"
+)
+
+// retentionBatchSize is how many statuses or accounts to select for pruning at a time.
+const retentionBatchSize = 100
+
+// sweepRemoteContent periodically removes remote statuses and accounts that have outlived the configured
+// retention, until the processor is stopped. Anything removed will just be dereferenced again if it's needed.
+func (p *processor) sweepRemoteContent(ctx context.Context) {
+	interval := time.Duration(p.config.RetentionConfig.SweepIntervalMinutes) * time.Minute
+	p.log.WithField("func", "sweepRemoteContent").Infof("sweeping remote content every %s", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.sweepRemoteContentOnce(ctx)
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// sweepRemoteContentOnce does one sweep of remote statuses, remote accounts, records of received inbox activities, and then failed deliveries.
+func (p *processor) sweepRemoteContentOnce(ctx context.Context) {
+	l := p.log.WithContext(ctx).WithField("func", "sweepRemoteContentOnce")
+
+	if days := p.config.RetentionConfig.RemoteStatusDays; days > 0 {
+		olderThan := time.Now().Add(time.Duration(-days) * 24 * time.Hour)
+		statuses, media := 0, 0
+
+		// pruned statuses drop out of the selection, so just keep selecting until there are none left
+	StatusLoop:
+		for {
+			prunable, err := p.db.GetPrunableRemoteStatuses(ctx, olderThan, retentionBatchSize)
+			if err != nil && err != db.ErrNoEntries {
+				l.WithError(err).Error("error getting remote statuses to prune")
+				break
+			}
+			if len(prunable) == 0 {
+				break
+			}
+
+			for _, s := range prunable {
+				if err := p.timelineManager.WipeStatusFromAllTimelines(ctx, s.ID); err != nil {
+					l.WithError(err).WithField("statusID", s.ID).Error("error wiping status from timelines")
+				}
+				m, err := retention.PruneStatus(ctx, p.db, p.storage, s, p.log)
+				if err != nil {
+					// bail rather than selecting the same status over and over again
+					l.WithError(err).WithField("statusID", s.ID).Error("error pruning status")
+					break StatusLoop
+				}
+				statuses++
+				media = media + m
+			}
+		}
+
+		l.WithFields(logrus.Fields{
+			"statuses": statuses,
+			"media":    media,
+		}).Info("pruned remote statuses")
+	}
+
+	if days := p.config.RetentionConfig.RemoteAccountDays; days > 0 {
+		olderThan := time.Now().Add(time.Duration(-days) * 24 * time.Hour)
+		accounts, media := 0, 0
+
+	AccountLoop:
+		for {
+			prunable, err := p.db.GetPrunableRemoteAccounts(ctx, olderThan, retentionBatchSize)
+			if err != nil && err != db.ErrNoEntries {
+				l.WithError(err).Error("error getting remote accounts to prune")
+				break
+			}
+			if len(prunable) == 0 {
+				break
+			}
+
+			for _, a := range prunable {
+				m, err := retention.PruneAccount(ctx, p.db, p.storage, a, p.log)
+				if err != nil {
+					l.WithError(err).WithField("accountID", a.ID).Error("error pruning account")
+					break AccountLoop
+				}
+				accounts++
+				media = media + m
+			}
+		}
+
+		l.WithFields(logrus.Fields{
+			"accounts": accounts,
+			"media":    media,
+		}).Info("pruned remote accounts")
+	}
+
+	if days := p.config.RetentionConfig.InboxActivityDays; days > 0 {
+		olderThan := time.Now().Add(time.Duration(-days) * 24 * time.Hour)
+		pruned, err := p.db.PruneInboxActivities(ctx, olderThan)
+		if err != nil {
+			l.WithError(err).Error("error pruning inbox activities")
+		} else {
+			l.WithField("inboxActivities", pruned).Info("pruned inbox activities")
+		}
+	}
+
+	if days := p.config.RetentionConfig.FailedDeliveryDays; days > 0 {
+		olderThan := time.Now().Add(time.Duration(-days) * 24 * time.Hour)
+		pruned, err := p.db.PruneFailedDeliveries(ctx, olderThan)
+		if err != nil {
+			l.WithError(err).Error("error pruning failed deliveries")
+		} else {
+			l.WithField("failedDeliveries", pruned).Info("pruned failed deliveries")
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *processor) getRule(ctx context.Context, id string) (*gtsmodel.Rule, gtserror.WithCode) {
+	rule := &gtsmodel.Rule{}
+	if err := p.db.GetByID(ctx, id, rule); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no rule with id %s", id))
+	}
+	return rule, nil
+}
+
+func (p *processor) InstanceRulesGet(ctx context.Context) ([]*apimodel.Rule, gtserror.WithCode) {
+	rules, err := p.db.GetInstanceRules(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	mastoRules := make([]*apimodel.Rule, 0, len(rules))
+	for _, rule := range rules {
+		mastoRule, err := p.tc.RuleToMasto(ctx, rule)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoRules = append(mastoRules, mastoRule)
+	}
+
+	return mastoRules, nil
+}
+
+func (p *processor) AdminRuleCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.RuleCreateRequest) (*apimodel.Rule, gtserror.WithCode) {
+	if err := validate.RuleText(form.Text); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := validate.RuleHint(form.Hint); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	var priority int
+	if form.Priority != nil {
+		if *form.Priority < 0 {
+			err := errors.New("priority must not be negative")
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		priority = *form.Priority
+	} else {
+		// put the new rule after all the existing ones
+		rules, err := p.db.GetInstanceRules(ctx)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if len(rules) != 0 {
+			priority = rules[len(rules)-1].Priority + 1
+		}
+	}
+
+	ruleID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	rule := &gtsmodel.Rule{
+		ID:       ruleID,
+		Text:     text.RemoveHTML(form.Text),
+		Hint:     text.RemoveHTML(form.Hint),
+		Priority: priority,
+	}
+	if err := p.db.Put(ctx, rule); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionCreate, gtsmodel.AdminAuditLogTargetRule, rule.ID, rule.Text)
+
+	mastoRule, err := p.tc.RuleToMasto(ctx, rule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoRule, nil
+}
+
+func (p *processor) AdminRuleUpdate(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.RuleUpdateRequest) (*apimodel.Rule, gtserror.WithCode) {
+	rule, errWithCode := p.getRule(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Text != nil {
+		if err := validate.RuleText(*form.Text); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		rule.Text = text.RemoveHTML(*form.Text)
+	}
+
+	if form.Hint != nil {
+		if err := validate.RuleHint(*form.Hint); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		rule.Hint = text.RemoveHTML(*form.Hint)
+	}
+
+	if form.Priority != nil {
+		if *form.Priority < 0 {
+			err := errors.New("priority must not be negative")
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		rule.Priority = *form.Priority
+	}
+
+	rule.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, rule); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionUpdate, gtsmodel.AdminAuditLogTargetRule, rule.ID, rule.Text)
+
+	mastoRule, err := p.tc.RuleToMasto(ctx, rule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoRule, nil
+}
+
+func (p *processor) AdminRuleDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.Rule, gtserror.WithCode) {
+	rule, errWithCode := p.getRule(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoRule, err := p.tc.RuleToMasto(ctx, rule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteByID(ctx, rule.ID, &gtsmodel.Rule{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionDelete, gtsmodel.AdminAuditLogTargetRule, rule.ID, rule.Text)
+
+	return mastoRule, nil
+}
@@ -0,0 +1,130 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type RuleTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *RuleTestSuite) adminAuth() *oauth.Auth {
+	return &oauth.Auth{
+		User:    suite.testUsers["admin_account"],
+		Account: suite.testAccounts["admin_account"],
+	}
+}
+
+func (suite *RuleTestSuite) TestCreateUpdateDeleteRules() {
+	ctx := context.Background()
+
+	first, errWithCode := suite.processor.AdminRuleCreate(ctx, suite.adminAuth(), &apimodel.RuleCreateRequest{
+		Text: "Be <b>nice</b>.",
+		Hint: "Do not be mean to people.",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("Be nice.", first.Text)
+
+	// without a priority, the new rule goes after the existing ones
+	second, errWithCode := suite.processor.AdminRuleCreate(ctx, suite.adminAuth(), &apimodel.RuleCreateRequest{
+		Text: "No spam.",
+	})
+	suite.NoError(errWithCode)
+
+	rules, errWithCode := suite.processor.InstanceRulesGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Len(rules, 2)
+	suite.Equal(first.ID, rules[0].ID)
+	suite.Equal(second.ID, rules[1].ID)
+
+	text := "No spam, please."
+	updated, errWithCode := suite.processor.AdminRuleUpdate(ctx, suite.adminAuth(), second.ID, &apimodel.RuleUpdateRequest{
+		Text: &text,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal(text, updated.Text)
+
+	// move the first rule to the bottom
+	priority := 5
+	_, errWithCode = suite.processor.AdminRuleUpdate(ctx, suite.adminAuth(), first.ID, &apimodel.RuleUpdateRequest{
+		Priority: &priority,
+	})
+	suite.NoError(errWithCode)
+
+	// the rules show up in the instance information
+	instance, errWithCode := suite.processor.InstanceGetV2(ctx)
+	suite.NoError(errWithCode)
+	suite.Len(instance.Rules, 2)
+	suite.Equal(second.ID, instance.Rules[0].ID)
+	suite.Equal(first.ID, instance.Rules[1].ID)
+	suite.Equal("Do not be mean to people.", instance.Rules[1].Hint)
+
+	deleted, errWithCode := suite.processor.AdminRuleDelete(ctx, suite.adminAuth(), first.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(first.ID, deleted.ID)
+
+	rules, errWithCode = suite.processor.InstanceRulesGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Len(rules, 1)
+	suite.Equal(second.ID, rules[0].ID)
+
+	_, errWithCode = suite.processor.AdminRuleDelete(ctx, suite.adminAuth(), first.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *RuleTestSuite) TestCreateRuleInvalid() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.processor.AdminRuleCreate(ctx, suite.adminAuth(), &apimodel.RuleCreateRequest{})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	priority := -1
+	_, errWithCode = suite.processor.AdminRuleCreate(ctx, suite.adminAuth(), &apimodel.RuleCreateRequest{
+		Text:     "No spam.",
+		Priority: &priority,
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *RuleTestSuite) TestInstanceGetV2Configuration() {
+	instance, errWithCode := suite.processor.InstanceGetV2(context.Background())
+	suite.NoError(errWithCode)
+
+	suite.Equal("localhost:8080", instance.Domain)
+	suite.Empty(instance.Rules)
+	suite.Equal("wss://localhost:8080", instance.Configuration.URLs.Streaming)
+	suite.Equal(suite.config.StatusesConfig.MaxChars, instance.Configuration.Statuses.MaxCharacters)
+	suite.Equal(suite.config.StatusesConfig.MaxMediaFiles, instance.Configuration.Statuses.MaxMediaAttachments)
+	suite.Equal(suite.config.MediaConfig.MaxImageSize, instance.Configuration.MediaAttachments.ImageSizeLimit)
+	suite.Equal(suite.config.MediaConfig.MaxVideoSize, instance.Configuration.MediaAttachments.VideoSizeLimit)
+	suite.Contains(instance.Configuration.MediaAttachments.SupportedMimeTypes, "image/png")
+	suite.Equal(suite.config.StatusesConfig.PollMaxOptions, instance.Configuration.Polls.MaxOptions)
+}
+
+func TestRuleTestSuite(t *testing.T) {
+	suite.Run(t, &RuleTestSuite{})
+}
@@ -30,11 +30,18 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+const (
+	searchTypeAccounts = "accounts"
+	searchTypeHashtags = "hashtags"
+	searchTypeStatuses = "statuses"
+)
+
 func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQuery *apimodel.SearchQuery) (*apimodel.SearchResult, gtserror.WithCode) {
-	l := p.log.WithFields(logrus.Fields{
+	l := p.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":  "SearchGet",
 		"query": searchQuery.Query,
 	})
@@ -46,16 +53,30 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 	}
 	foundAccounts := []*gtsmodel.Account{}
 	foundStatuses := []*gtsmodel.Status{}
-	// foundHashtags := []*gtsmodel.Tag{}
+	foundHashtags := []*gtsmodel.Tag{}
+
+	// wants returns true if the search should include results of the given type
+	wants := func(searchType string) bool {
+		return searchQuery.Type == "" || searchQuery.Type == searchType
+	}
 
-	// convert the query to lowercase and trim leading/trailing spaces
-	query := strings.ToLower(strings.TrimSpace(searchQuery.Query))
+	// trim leading/trailing spaces from the query
+	query := strings.TrimSpace(searchQuery.Query)
+
+	// only resolve remote things on the first page of results, there's no point doing it again for later pages
+	resolve := searchQuery.Resolve && searchQuery.Offset == 0
+
+	// queries like whatever_username@example.org should be treated like @whatever_username@example.org
+	mention := query
+	if !strings.HasPrefix(mention, "@") && strings.Contains(mention, "@") {
+		mention = "@" + mention
+	}
 
 	var foundOne bool
 	// check if the query is something like @whatever_username@example.org -- this means it's a remote account
-	if !foundOne && util.IsMention(searchQuery.Query) {
+	if wants(searchTypeAccounts) && util.IsMention(mention) {
 		l.Debug("search term is a mention, looking it up...")
-		foundAccount, err := p.searchAccountByMention(ctx, authed, searchQuery.Query, searchQuery.Resolve)
+		foundAccount, err := p.searchAccountByMention(ctx, authed, mention, resolve)
 		if err == nil && foundAccount != nil {
 			foundAccounts = append(foundAccounts, foundAccount)
 			foundOne = true
@@ -64,25 +85,61 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 	}
 
 	// check if the query is a URI and just do a lookup for that, straight up
-	if uri, err := url.Parse(query); err == nil && !foundOne {
+	if uri, err := url.Parse(query); err == nil && !foundOne && (uri.Scheme == "https" || uri.Scheme == "http") && uri.Host != "" {
 		// 1. check if it's a status
-		if foundStatus, err := p.searchStatusByURI(ctx, authed, uri, searchQuery.Resolve); err == nil && foundStatus != nil {
-			foundStatuses = append(foundStatuses, foundStatus)
-			foundOne = true
-			l.Debug("got a status by searching by URI")
+		if wants(searchTypeStatuses) {
+			if foundStatus, err := p.searchStatusByURI(ctx, authed, uri, resolve); err == nil && foundStatus != nil {
+				foundStatuses = append(foundStatuses, foundStatus)
+				foundOne = true
+				l.Debug("got a status by searching by URI")
+			}
 		}
 
 		// 2. check if it's an account
-		if foundAccount, err := p.searchAccountByURI(ctx, authed, uri, searchQuery.Resolve); err == nil && foundAccount != nil {
-			foundAccounts = append(foundAccounts, foundAccount)
-			foundOne = true
-			l.Debug("got an account by searching by URI")
+		if wants(searchTypeAccounts) && !foundOne {
+			if foundAccount, err := p.searchAccountByURI(ctx, authed, uri, resolve); err == nil && foundAccount != nil {
+				foundAccounts = append(foundAccounts, foundAccount)
+				foundOne = true
+				l.Debug("got an account by searching by URI")
+			}
 		}
+
+		// either way, there's no point searching for a URI as text
+		foundOne = true
 	}
 
 	if !foundOne {
 		// we haven't found anything yet so search for text now
 		l.Debug("nothing found by mention or by URI, will fall back to searching by text now")
+
+		if wants(searchTypeAccounts) {
+			username, domain := strings.TrimPrefix(query, "@"), ""
+			if util.IsMention(mention) {
+				username, domain, _ = util.ExtractMentionParts(mention)
+			}
+
+			accounts, err := p.db.SearchForAccounts(ctx, authed.Account.ID, username, domain, searchQuery.Following, searchQuery.Limit, searchQuery.Offset)
+			if err != nil && err != db.ErrNoEntries {
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("SearchGet: error searching for accounts: %s", err))
+			}
+			foundAccounts = append(foundAccounts, accounts...)
+		}
+
+		if tagName := strings.TrimPrefix(query, "#"); wants(searchTypeHashtags) && regexes.HashtagName.MatchString(tagName) {
+			tags, err := p.db.SearchForTags(ctx, tagName, searchQuery.ExcludeUnreviewed, searchQuery.Limit, searchQuery.Offset)
+			if err != nil && err != db.ErrNoEntries {
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("SearchGet: error searching for hashtags: %s", err))
+			}
+			foundHashtags = append(foundHashtags, tags...)
+		}
+
+		if wants(searchTypeStatuses) {
+			statuses, err := p.db.SearchForStatuses(ctx, authed.Account.ID, query, searchQuery.AccountID, searchQuery.MaxID, searchQuery.MinID, searchQuery.Limit, searchQuery.Offset)
+			if err != nil && err != db.ErrNoEntries {
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("SearchGet: error searching for statuses: %s", err))
+			}
+			foundStatuses = append(foundStatuses, statuses...)
+		}
 	}
 
 	/*
@@ -112,11 +169,25 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 		results.Statuses = append(results.Statuses, *statusMasto)
 	}
 
+	for _, foundHashtag := range foundHashtags {
+		_, err := p.db.GetTagFollow(ctx, authed.Account.ID, foundHashtag.ID)
+		if err != nil && err != db.ErrNoEntries {
+			continue
+		}
+
+		tagMasto, errWithCode := p.tagToMasto(ctx, foundHashtag, err == nil)
+		if errWithCode != nil {
+			continue
+		}
+
+		results.Hashtags = append(results.Hashtags, *tagMasto)
+	}
+
 	return results, nil
 }
 
 func (p *processor) searchStatusByURI(ctx context.Context, authed *oauth.Auth, uri *url.URL, resolve bool) (*gtsmodel.Status, error) {
-	l := p.log.WithFields(logrus.Fields{
+	l := p.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":    "searchStatusByURI",
 		"uri":     uri.String(),
 		"resolve": resolve,
@@ -134,7 +205,7 @@ func (p *processor) searchStatusByURI(ctx context.Context, authed *oauth.Auth, u
 		if err == nil {
 			if err := p.federator.DereferenceRemoteThread(ctx, authed.Account.Username, uri); err != nil {
 				// try to deref the thread while we're here
-				l.Debugf("searchStatusByURI: error dereferencing remote thread: %s", err)
+				l.WithError(err).Debug("searchStatusByURI: error dereferencing remote thread")
 			}
 			return status, nil
 		}
@@ -169,7 +240,7 @@ func (p *processor) searchAccountByMention(ctx context.Context, authed *oauth.Au
 
 	// if it's a local account we can skip a whole bunch of stuff
 	maybeAcct := &gtsmodel.Account{}
-	if domain == p.config.Host {
+	if domain == "" || domain == p.config.Host {
 		maybeAcct, err = p.db.GetLocalAccountByUsername(ctx, username)
 		if err != nil {
 			return nil, fmt.Errorf("searchAccountByMention: error getting local account by username: %s", err)
@@ -0,0 +1,122 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type SearchTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *SearchTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchText() {
+	results, errWithCode := suite.processor.SearchGet(context.Background(), suite.authed("local_account_1"), &apimodel.SearchQuery{
+		Query: "turtle",
+		Limit: 20,
+	})
+	suite.NoError(errWithCode)
+
+	// display name of local_account_2 is "happy little turtle :3"
+	suite.Len(results.Accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, results.Accounts[0].ID)
+
+	// local_account_1 follows local_account_2 so its statuses are searchable
+	suite.Len(results.Statuses, 1)
+	suite.Equal(suite.testStatuses["local_account_2_status_1"].ID, results.Statuses[0].ID)
+
+	suite.Empty(results.Hashtags)
+}
+
+func (suite *SearchTestSuite) TestSearchType() {
+	results, errWithCode := suite.processor.SearchGet(context.Background(), suite.authed("local_account_1"), &apimodel.SearchQuery{
+		Query: "#welc",
+		Type:  "hashtags",
+		Limit: 20,
+	})
+	suite.NoError(errWithCode)
+
+	suite.Empty(results.Accounts)
+	suite.Empty(results.Statuses)
+	suite.Len(results.Hashtags, 1)
+	suite.Equal("welcome", results.Hashtags[0].Name)
+	suite.False(*results.Hashtags[0].Following)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesNotFollowing() {
+	// local_account_2 doesn't follow anyone, so statuses by other accounts aren't searchable
+	results, errWithCode := suite.processor.SearchGet(context.Background(), suite.authed("local_account_2"), &apimodel.SearchQuery{
+		Query: "hello",
+		Type:  "statuses",
+		Limit: 20,
+	})
+	suite.NoError(errWithCode)
+	suite.Empty(results.Statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchAccountsFollowing() {
+	authed := suite.authed("local_account_2")
+
+	results, errWithCode := suite.processor.SearchGet(context.Background(), authed, &apimodel.SearchQuery{
+		Query: "zork",
+		Type:  "accounts",
+		Limit: 20,
+	})
+	suite.NoError(errWithCode)
+	suite.Len(results.Accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, results.Accounts[0].ID)
+
+	results, errWithCode = suite.processor.SearchGet(context.Background(), authed, &apimodel.SearchQuery{
+		Query:     "zork",
+		Type:      "accounts",
+		Limit:     20,
+		Following: true,
+	})
+	suite.NoError(errWithCode)
+	suite.Empty(results.Accounts)
+}
+
+func (suite *SearchTestSuite) TestSearchLocalMention() {
+	results, errWithCode := suite.processor.SearchGet(context.Background(), suite.authed("local_account_2"), &apimodel.SearchQuery{
+		Query: "the_mighty_zork@localhost:8080",
+		Limit: 20,
+	})
+	suite.NoError(errWithCode)
+	suite.Len(results.Accounts, 1)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, results.Accounts[0].ID)
+	suite.Empty(results.Statuses)
+	suite.Empty(results.Hashtags)
+}
+
+func TestSearchTestSuite(t *testing.T) {
+	suite.Run(t, &SearchTestSuite{})
+}
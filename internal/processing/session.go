@@ -0,0 +1,114 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+func (p *processor) SessionsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.Session, gtserror.WithCode) {
+	tokens, err := p.db.GetTokensForUserID(ctx, authed.User.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting tokens for user: %s", err))
+	}
+
+	sessions := []*apimodel.Session{}
+	apps := make(map[string]*gtsmodel.Application)
+	for _, t := range tokens {
+		// authorization codes that were never exchanged for an access token aren't sessions
+		if t.Access == "" {
+			continue
+		}
+
+		app, ok := apps[t.ClientID]
+		if !ok {
+			app, err = p.db.GetApplicationByClientID(ctx, t.ClientID)
+			if err != nil {
+				if err == db.ErrNoEntries {
+					// the application was removed but the token lingers; nothing to show
+					continue
+				}
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting application for client %s: %s", t.ClientID, err))
+			}
+			apps[t.ClientID] = app
+		}
+
+		session := &apimodel.Session{
+			ID:          t.ID,
+			Application: app.Name,
+			Website:     app.Website,
+			Scopes:      strings.Fields(t.Scope),
+			CreatedAt:   t.CreatedAt.Format(time.RFC3339),
+			Current:     authed.Token != nil && t.Access == authed.Token.GetAccess(),
+		}
+		if !t.LastUsedAt.IsZero() {
+			session.LastUsedAt = t.LastUsedAt.Format(time.RFC3339)
+		}
+		if t.LastUsedIP != nil {
+			session.IP = t.LastUsedIP.String()
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (p *processor) SessionRevoke(ctx context.Context, authed *oauth.Auth, id string) gtserror.WithCode {
+	token := &gtsmodel.Token{}
+	if err := p.db.GetByID(ctx, id, token); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(err)
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("error getting token %s: %s", id, err))
+	}
+
+	// don't give away whether other users' sessions exist
+	if token.UserID != authed.User.ID {
+		return gtserror.NewErrorNotFound(errors.New("session doesn't belong to requesting user"))
+	}
+
+	if err := p.revokeToken(ctx, token); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func (p *processor) SessionsRevoke(ctx context.Context, authed *oauth.Auth) gtserror.WithCode {
+	var exceptAccess string
+	if authed.Token != nil {
+		exceptAccess = authed.Token.GetAccess()
+	}
+
+	if err := p.revokeUserTokens(ctx, authed.User.ID, exceptAccess); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type SessionTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *SessionTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Token:       oauth.DBTokenToToken(suite.testTokens[name]),
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+// putOtherToken stores a second token for local_account_1, as if they were signed in on another device too.
+func (suite *SessionTestSuite) putOtherToken() *gtsmodel.Token {
+	token := &gtsmodel.Token{
+		ID:              "01FSV3QKJQ2XSQ0ZP4ZJ4MBP6N",
+		ClientID:        "01F8MGV8AC3NGSJW0FE8W1BV70",
+		UserID:          "01F8MGVGPHQ2D3P3X0454H54Z5",
+		RedirectURI:     "http://localhost:8080",
+		Scope:           "read",
+		Access:          "OTHERAPPTOKENOTHERAPPTOKENOTHERAPPTOKENOTHERAPP1",
+		AccessCreateAt:  time.Now(),
+		AccessExpiresAt: time.Now().Add(72 * time.Hour),
+	}
+	suite.Require().NoError(suite.db.Put(context.Background(), token))
+	return token
+}
+
+func (suite *SessionTestSuite) TestSessionsGet() {
+	ctx := context.Background()
+	otherToken := suite.putOtherToken()
+
+	sessions, errWithCode := suite.processor.SessionsGet(ctx, suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+	suite.Len(sessions, 2)
+
+	for _, s := range sessions {
+		suite.Equal("really cool gts application", s.Application)
+		suite.Equal(s.ID == suite.testTokens["local_account_1"].ID, s.Current)
+		if s.ID == otherToken.ID {
+			suite.Equal([]string{"read"}, s.Scopes)
+			suite.Empty(s.LastUsedAt)
+			suite.Empty(s.IP)
+		}
+	}
+}
+
+func (suite *SessionTestSuite) TestSessionsGetLastUsed() {
+	ctx := context.Background()
+	otherToken := suite.putOtherToken()
+
+	usedAt := time.Now().Add(-time.Hour)
+	err := suite.db.UpdateTokenLastUsed(ctx, otherToken.Access, net.ParseIP("192.0.2.1"), usedAt, usedAt.Add(-time.Minute))
+	suite.NoError(err)
+
+	// a use within the interval isn't recorded
+	err = suite.db.UpdateTokenLastUsed(ctx, otherToken.Access, net.ParseIP("192.0.2.2"), usedAt.Add(30*time.Second), usedAt.Add(-30*time.Second))
+	suite.NoError(err)
+
+	sessions, errWithCode := suite.processor.SessionsGet(ctx, suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+
+	var found bool
+	for _, s := range sessions {
+		if s.ID == otherToken.ID {
+			found = true
+			suite.Equal(usedAt.Format(time.RFC3339), s.LastUsedAt)
+			suite.Equal("192.0.2.1", s.IP)
+		}
+	}
+	suite.True(found)
+}
+
+func (suite *SessionTestSuite) TestSessionRevoke() {
+	ctx := context.Background()
+	otherToken := suite.putOtherToken()
+
+	errWithCode := suite.processor.SessionRevoke(ctx, suite.authed("local_account_1"), otherToken.ID)
+	suite.NoError(errWithCode)
+
+	sessions, errWithCode := suite.processor.SessionsGet(ctx, suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+	suite.Len(sessions, 1)
+	suite.True(sessions[0].Current)
+}
+
+func (suite *SessionTestSuite) TestSessionRevokeOtherUser() {
+	ctx := context.Background()
+
+	errWithCode := suite.processor.SessionRevoke(ctx, suite.authed("local_account_1"), suite.testTokens["local_account_2"].ID)
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// the other user's token should be untouched
+	token := &gtsmodel.Token{}
+	suite.NoError(suite.db.GetByID(ctx, suite.testTokens["local_account_2"].ID, token))
+}
+
+func (suite *SessionTestSuite) TestSessionsRevoke() {
+	ctx := context.Background()
+	otherToken := suite.putOtherToken()
+
+	errWithCode := suite.processor.SessionsRevoke(ctx, suite.authed("local_account_1"))
+	suite.NoError(errWithCode)
+
+	// the token the request was made with survives
+	token := &gtsmodel.Token{}
+	suite.NoError(suite.db.GetByID(ctx, suite.testTokens["local_account_1"].ID, token))
+	suite.Error(suite.db.GetByID(ctx, otherToken.ID, &gtsmodel.Token{}))
+}
+
+func TestSessionTestSuite(t *testing.T) {
+	suite.Run(t, &SessionTestSuite{})
+}
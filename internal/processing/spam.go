@@ -0,0 +1,206 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// checkSpam runs an incoming remote status through the spam filter, if spam checks are enabled, and acts on the
+// verdict. It returns true if the status has been held or dropped, and so shouldn't be processed any further.
+//
+// If the status can't be checked, it's let through rather than being held up by a broken rule.
+func (p *processor) checkSpam(ctx context.Context, status *gtsmodel.Status) (bool, error) {
+	if p.spamFilter == nil {
+		return false, nil
+	}
+
+	l := p.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":     "checkSpam",
+		"statusID": status.ID,
+	})
+
+	verdict, err := p.spamFilter.Check(ctx, status)
+	if err != nil {
+		l.WithError(err).Error("error checking status for spam; letting it through")
+		return false, nil
+	}
+
+	if verdict.Action == gtsmodel.SpamActionNone {
+		return false, nil
+	}
+
+	l = l.WithFields(logrus.Fields{
+		"score":   verdict.Score,
+		"reasons": verdict.Reasons,
+		"action":  verdict.Action,
+	})
+
+	if verdict.Action == gtsmodel.SpamActionDrop {
+		l.Info("dropping incoming status as spam")
+		return true, p.deleteUnprocessedStatus(ctx, status)
+	}
+
+	flagID, err := id.NewULID()
+	if err != nil {
+		return false, err
+	}
+
+	flag := &gtsmodel.SpamFlag{
+		ID:        flagID,
+		StatusID:  status.ID,
+		AccountID: status.AccountID,
+		Score:     verdict.Score,
+		Reasons:   verdict.Reasons,
+		Action:    verdict.Action,
+	}
+	if err := p.db.Put(ctx, flag); err != nil {
+		return false, fmt.Errorf("checkSpam: error putting spam flag for status %s: %s", status.ID, err)
+	}
+
+	l.WithField("spamFlagID", flagID).Info("flagged incoming status as spam")
+	return verdict.Action == gtsmodel.SpamActionHold, nil
+}
+
+// deleteUnprocessedStatus deletes a status, along with its mentions and attachments, which hasn't been put in
+// any timelines or notified yet.
+func (p *processor) deleteUnprocessedStatus(ctx context.Context, status *gtsmodel.Status) error {
+	for _, a := range status.AttachmentIDs {
+		if err := p.mediaProcessor.Delete(ctx, a); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range status.MentionIDs {
+		if err := p.db.DeleteByID(ctx, m, &gtsmodel.Mention{}); err != nil {
+			return err
+		}
+	}
+
+	return p.db.DeleteByID(ctx, status.ID, &gtsmodel.Status{})
+}
+
+func (p *processor) getSpamFlag(ctx context.Context, id string) (*gtsmodel.SpamFlag, gtserror.WithCode) {
+	flag := &gtsmodel.SpamFlag{}
+	if err := p.db.GetByID(ctx, id, flag); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+	return flag, nil
+}
+
+func (p *processor) AdminSpamFlagsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.SpamFlag, gtserror.WithCode) {
+	flags := []*gtsmodel.SpamFlag{}
+	if err := p.db.GetAll(ctx, &flags); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// ids are ulids, so going backwards through them gives newest first
+	mastoFlags := make([]*apimodel.SpamFlag, 0, len(flags))
+	for i := len(flags) - 1; i >= 0; i-- {
+		mastoFlag, err := p.tc.SpamFlagToMasto(ctx, flags[i], authed.Account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoFlags = append(mastoFlags, mastoFlag)
+	}
+
+	return mastoFlags, nil
+}
+
+func (p *processor) AdminSpamFlagGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.SpamFlag, gtserror.WithCode) {
+	flag, errWithCode := p.getSpamFlag(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoFlag, err := p.tc.SpamFlagToMasto(ctx, flag, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return mastoFlag, nil
+}
+
+func (p *processor) AdminSpamFlagRelease(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.SpamFlag, gtserror.WithCode) {
+	flag, errWithCode := p.getSpamFlag(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoFlag, err := p.tc.SpamFlagToMasto(ctx, flag, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteByID(ctx, flag.ID, &gtsmodel.SpamFlag{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionApprove, gtsmodel.AdminAuditLogTargetSpamFlag, flag.ID, "")
+
+	if flag.Action == gtsmodel.SpamActionHold {
+		// the status never made it into timelines or notifications, so do that now
+		if err := p.timelineStatus(ctx, flag.Status); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if err := p.notifyStatus(ctx, flag.Status); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return mastoFlag, nil
+}
+
+func (p *processor) AdminSpamFlagDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.SpamFlag, gtserror.WithCode) {
+	flag, errWithCode := p.getSpamFlag(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	mastoFlag, err := p.tc.SpamFlagToMasto(ctx, flag, authed.Account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.db.DeleteByID(ctx, flag.ID, &gtsmodel.SpamFlag{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	p.adminAudit(ctx, authed.Account, gtsmodel.AdminAuditLogActionDelete, gtsmodel.AdminAuditLogTargetSpamFlag, flag.ID, "")
+
+	if flag.Action == gtsmodel.SpamActionHold {
+		if err := p.deleteUnprocessedStatus(ctx, flag.Status); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return mastoFlag, nil
+}
@@ -0,0 +1,173 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SpamTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *SpamTestSuite) SetupTest() {
+	suite.ProcessingStandardTestSuite.SetupTest()
+	suite.config.SpamConfig.Enabled = true
+	suite.config.SpamConfig.Keywords = []string{"cheap followers"}
+	suite.restartProcessor()
+}
+
+// restartProcessor swaps in a new processor, so that it picks up changes to the spam config
+func (suite *SpamTestSuite) restartProcessor() {
+	if err := suite.processor.Stop(); err != nil {
+		panic(err)
+	}
+	suite.processor = processing.NewProcessor(suite.config, suite.typeconverter, suite.federator, suite.oauthServer, suite.mediaHandler, suite.storage, suite.timelineManager, suite.db, testrig.NewTestFieldVerifier(), testrig.NewTestWebPushSender(suite.db), suite.log)
+	if err := suite.processor.Start(context.Background()); err != nil {
+		panic(err)
+	}
+}
+
+// putRemoteStatus puts a status from remote_account_1 which mentions local_account_1 out of the blue
+func (suite *SpamTestSuite) putRemoteStatus(text string) *gtsmodel.Status {
+	mentionedAccount := suite.testAccounts["local_account_1"]
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	status := &gtsmodel.Status{
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		URI:       "http://fossbros-anonymous.io/users/foss_satan/statuses/106221634728637999",
+		URL:       "http://fossbros-anonymous.io/@foss_satan/106221634728637999",
+		Content:   `<p><span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> ` + text + `</p>`,
+		Mentions: []*gtsmodel.Mention{
+			{
+				TargetAccountURI: mentionedAccount.URI,
+				NameString:       "@the_mighty_zork@localhost:8080",
+			},
+		},
+		AccountID:           remoteAccount.ID,
+		AccountURI:          remoteAccount.URI,
+		Visibility:          gtsmodel.VisibilityUnlocked,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           true,
+		Boostable:           true,
+		Replyable:           true,
+		Likeable:            true,
+	}
+
+	statusID, err := id.NewULIDFromTime(status.CreatedAt)
+	suite.NoError(err)
+	status.ID = statusID
+
+	suite.NoError(suite.db.PutStatus(context.Background(), status))
+
+	err = suite.processor.ProcessFromFederator(context.Background(), messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         status,
+		ReceivingAccount: mentionedAccount,
+	})
+	suite.NoError(err)
+
+	return status
+}
+
+func (suite *SpamTestSuite) notified(status *gtsmodel.Status) bool {
+	err := suite.db.GetWhere(context.Background(), []db.Where{{Key: "status_id", Value: status.ID}}, &gtsmodel.Notification{})
+	if err == db.ErrNoEntries {
+		return false
+	}
+	suite.NoError(err)
+	return true
+}
+
+func (suite *SpamTestSuite) TestFirstContactReported() {
+	status := suite.putRemoteStatus("hey there")
+
+	// first contact mention (30) isn't enough to report (50), so the status goes through as normal
+	suite.True(suite.notified(status))
+	err := suite.db.GetWhere(context.Background(), []db.Where{{Key: "status_id", Value: status.ID}}, &gtsmodel.SpamFlag{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *SpamTestSuite) TestHoldAndRelease() {
+	status := suite.putRemoteStatus("get CHEAP followers here")
+
+	// first contact mention (30) and keyword (100) is enough to hold (100)
+	flag := &gtsmodel.SpamFlag{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "status_id", Value: status.ID}}, flag))
+	suite.Equal(gtsmodel.SpamActionHold, flag.Action)
+	suite.Equal(130, flag.Score)
+	suite.Len(flag.Reasons, 2)
+	suite.False(suite.notified(status))
+
+	authed := &oauth.Auth{Account: suite.testAccounts["admin_account"]}
+	flags, errWithCode := suite.processor.AdminSpamFlagsGet(context.Background(), authed)
+	suite.NoError(errWithCode)
+	suite.Len(flags, 1)
+	suite.Equal("hold", flags[0].Action)
+	suite.Equal(status.ID, flags[0].Status.ID)
+
+	// releasing the status notifies it and removes the flag
+	_, errWithCode = suite.processor.AdminSpamFlagRelease(context.Background(), authed, flag.ID)
+	suite.NoError(errWithCode)
+	suite.True(suite.notified(status))
+	err := suite.db.GetByID(context.Background(), flag.ID, &gtsmodel.SpamFlag{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *SpamTestSuite) TestHoldAndDelete() {
+	status := suite.putRemoteStatus("get cheap followers here")
+
+	flag := &gtsmodel.SpamFlag{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "status_id", Value: status.ID}}, flag))
+
+	// deleting the flag deletes the held status along with it
+	authed := &oauth.Auth{Account: suite.testAccounts["admin_account"]}
+	_, errWithCode := suite.processor.AdminSpamFlagDelete(context.Background(), authed, flag.ID)
+	suite.NoError(errWithCode)
+	err := suite.db.GetByID(context.Background(), status.ID, &gtsmodel.Status{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.False(suite.notified(status))
+}
+
+func (suite *SpamTestSuite) TestDrop() {
+	suite.config.SpamConfig.DropScore = 100
+	suite.restartProcessor()
+	status := suite.putRemoteStatus("get cheap followers here")
+
+	err := suite.db.GetByID(context.Background(), status.ID, &gtsmodel.Status{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestSpamTestSuite(t *testing.T) {
+	suite.Run(t, &SpamTestSuite{})
+}
@@ -23,6 +23,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -63,5 +64,32 @@ func (p *processor) StatusUnfave(ctx context.Context, authed *oauth.Auth, target
 }
 
 func (p *processor) StatusGetContext(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
-	return p.statusProcessor.Context(ctx, authed.Account, targetStatusID)
+	context, errWithCode := p.statusProcessor.Context(ctx, authed.Account, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	context.Ancestors = p.filterStatusValues(ctx, authed.Account, gtsmodel.FilterContextThread, context.Ancestors)
+	context.Descendants = p.filterStatusValues(ctx, authed.Account, gtsmodel.FilterContextThread, context.Descendants)
+	return context, nil
+}
+
+func (p *processor) StatusMute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	return p.statusProcessor.Mute(ctx, authed.Account, targetStatusID)
+}
+
+func (p *processor) StatusUnmute(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	return p.statusProcessor.Unmute(ctx, authed.Account, targetStatusID)
+}
+
+func (p *processor) StatusEdit(ctx context.Context, authed *oauth.Auth, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode) {
+	return p.statusProcessor.Edit(ctx, authed.Account, targetStatusID, form)
+}
+
+func (p *processor) StatusHistoryGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
+	return p.statusProcessor.History(ctx, authed.Account, targetStatusID)
+}
+
+func (p *processor) StatusSourceGet(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode) {
+	return p.statusProcessor.Source(ctx, authed.Account, targetStatusID)
 }
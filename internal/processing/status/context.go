@@ -22,8 +22,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"sort"
 
+	"github.com/sirupsen/logrus"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -46,6 +48,10 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
 	}
 
+	if !targetStatus.Local {
+		p.backfillThread(ctx, requestingAccount, targetStatus)
+	}
+
 	context := &apimodel.Context{
 		Ancestors:   []apimodel.Status{},
 		Descendants: []apimodel.Status{},
@@ -85,3 +91,30 @@ func (p *processor) Context(ctx context.Context, requestingAccount *gtsmodel.Acc
 
 	return context, nil
 }
+
+// backfillThread kicks off a backfill of the ancestors and replies of the given remote status in the
+// background, so that they can be shown the next time someone asks for the context of the status.
+func (p *processor) backfillThread(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatus *gtsmodel.Status) {
+	l := p.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":     "backfillThread",
+		"statusID": targetStatus.ID,
+	})
+
+	statusURI, err := url.Parse(targetStatus.URI)
+	if err != nil {
+		l.WithError(err).Error("error parsing status uri")
+		return
+	}
+
+	// dereference as the requesting account if there is one, or as the instance account otherwise
+	var username string
+	if requestingAccount != nil {
+		username = requestingAccount.Username
+	}
+
+	go func() {
+		if err := p.federator.BackfillRemoteThread(context.Background(), username, statusURI); err != nil {
+			l.WithError(err).Debug("error backfilling thread")
+		}
+	}()
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -53,7 +54,6 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		AccountURI:               account.URI,
 		ContentWarning:           text.RemoveHTML(form.SpoilerText),
 		ActivityStreamsType:      ap.ObjectNote,
-		Sensitive:                form.Sensitive,
 		Language:                 form.Language,
 		CreatedWithApplicationID: application.ID,
 		Text:                     form.Status,
@@ -67,6 +67,10 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if err := p.ProcessPoll(ctx, form, newStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	if err := p.ProcessVisibility(ctx, form, account.Privacy, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -75,6 +79,10 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if err := p.ProcessSensitive(ctx, form, account.Sensitive, newStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	if err := p.ProcessMentions(ctx, form, account.ID, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -98,6 +106,7 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 
 	// send it back to the processor for async processing
 	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
 		APObjectType:   ap.ObjectNote,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       newStatus,
@@ -0,0 +1,259 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+func (p *processor) Edit(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, form *apimodel.StatusEditRequest) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+	if targetStatus.Account == nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no status owner for status %s", targetStatusID))
+	}
+
+	if targetStatus.AccountID != requestingAccount.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("status doesn't belong to requesting account"))
+	}
+
+	if targetStatus.BoostOfID != "" {
+		return nil, gtserror.NewErrorBadRequest(errors.New("boosts can't be edited"), "boosts can't be edited")
+	}
+
+	if len(form.MediaIDs) != 0 {
+		// statuses can't have both media and a poll, just like when they're created
+		if _, err := p.db.GetStatusPoll(ctx, targetStatus.ID); err == nil {
+			err := errors.New("can't attach media to a status with a poll")
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		} else if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching poll of status %s: %s", targetStatus.ID, err))
+		}
+	}
+
+	// snapshot the current version before we change anything, so it ends up in the edit history
+	previous, err := p.tc.StatusToStatusEdit(ctx, targetStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error creating status edit: %s", err))
+	}
+
+	newAttachments, err := p.editMediaIDs(ctx, form, requestingAccount.ID, targetStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.Status == "" && len(targetStatus.AttachmentIDs) == 0 {
+		err := errors.New("no status text or media provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetStatus.Text = form.Status
+	targetStatus.ContentWarning = text.RemoveHTML(form.SpoilerText)
+	targetStatus.Sensitive = form.Sensitive
+	if form.Language != "" {
+		if err := validate.Language(form.Language); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		targetStatus.Language = normalizeLanguage(form.Language)
+	}
+
+	createForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status: form.Status,
+			Format: form.Format,
+		},
+	}
+
+	droppedMentionIDs, err := p.editMentions(ctx, createForm, requestingAccount.ID, targetStatus)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessTags(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessEmojis(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ProcessContent(ctx, createForm, requestingAccount.ID, targetStatus); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	now := time.Now()
+	targetStatus.UpdatedAt = now
+	targetStatus.EditedAt = now
+
+	if err := p.db.EditStatus(ctx, targetStatus, previous); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating status in the database: %s", err))
+	}
+
+	for _, mentionID := range droppedMentionIDs {
+		if err := p.db.DeleteByID(ctx, mentionID, &gtsmodel.Mention{}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting mention %s: %s", mentionID, err))
+		}
+	}
+
+	for _, a := range newAttachments {
+		a.StatusID = targetStatus.ID
+		a.UpdatedAt = now
+		if err := p.db.UpdateByPrimaryKey(ctx, a); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error attaching media %s: %s", a.ID, err))
+		}
+	}
+
+	// send it back to the processor for async processing
+	p.fromClientAPI <- messages.FromClientAPI{
+		RequestID:      log.RequestID(ctx),
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       targetStatus,
+		OriginAccount:  requestingAccount,
+	}
+
+	mastoStatus, err := p.tc.StatusToMasto(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return mastoStatus, nil
+}
+
+// editMediaIDs replaces the attachments of the given status with the ones in the edit form, if the form has any.
+// Attachments can either be ones that the status already has, or unused media belonging to the requesting account.
+// Media that's newly attached to the status in this edit is returned, so that it can be linked to the status once
+// the edit has been stored.
+func (p *processor) editMediaIDs(ctx context.Context, form *apimodel.StatusEditRequest, thisAccountID string, status *gtsmodel.Status) ([]*gtsmodel.MediaAttachment, error) {
+	newAttachments := []*gtsmodel.MediaAttachment{}
+	if form.MediaIDs == nil {
+		return newAttachments, nil
+	}
+
+	gtsMediaAttachments := []*gtsmodel.MediaAttachment{}
+	attachments := []string{}
+	for _, mediaID := range form.MediaIDs {
+		// check these attachments exist
+		a := &gtsmodel.MediaAttachment{}
+		if err := p.db.GetByID(ctx, mediaID, a); err != nil {
+			return nil, fmt.Errorf("invalid media type or media not found for media id %s", mediaID)
+		}
+		// check they belong to the requesting account id
+		if a.AccountID != thisAccountID {
+			return nil, fmt.Errorf("media with id %s does not belong to account %s", mediaID, thisAccountID)
+		}
+		// check they're not already used in another status
+		if a.StatusID != status.ID {
+			if a.StatusID != "" || a.ScheduledStatusID != "" {
+				return nil, fmt.Errorf("media with id %s is already attached to a status", mediaID)
+			}
+			newAttachments = append(newAttachments, a)
+		}
+		gtsMediaAttachments = append(gtsMediaAttachments, a)
+		attachments = append(attachments, a.ID)
+	}
+	status.Attachments = gtsMediaAttachments
+	status.AttachmentIDs = attachments
+	return newAttachments, nil
+}
+
+// editMentions works out the mentions of the given status from the new text in the form. Accounts that were already
+// mentioned by the status keep their existing mention, so they aren't notified again, while mentions of newly mentioned
+// accounts are stored. The IDs of mentions that were dropped in this edit are returned, so that they can be removed once
+// the edit has been stored.
+func (p *processor) editMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) ([]string, error) {
+	existing := []*gtsmodel.Mention{}
+	if len(status.MentionIDs) != 0 {
+		var err error
+		existing, err = p.db.GetMentions(ctx, status.MentionIDs)
+		if err != nil {
+			return nil, fmt.Errorf("error getting mentions of status %s: %s", status.ID, err)
+		}
+	}
+
+	mentionStrings := util.DeriveMentionsFromText(form.Status)
+	gtsMenchies, err := p.db.MentionStringsToMentions(ctx, mentionStrings, accountID, status.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error generating mentions from status: %s", err)
+	}
+	gtsMenchies = append(gtsMenchies, p.resolveMentions(ctx, mentionStrings, gtsMenchies, accountID, status.ID)...)
+
+	kept := make(map[string]bool, len(existing))
+	mentions := []*gtsmodel.Mention{}
+	menchies := []string{}
+	for _, menchie := range gtsMenchies {
+		if e := mentionOfAccount(menchie.TargetAccountID, existing); e != nil {
+			if !kept[e.ID] {
+				kept[e.ID] = true
+				mentions = append(mentions, e)
+				menchies = append(menchies, e.ID)
+			}
+			continue
+		}
+
+		menchieID, err := id.NewRandomULID()
+		if err != nil {
+			return nil, err
+		}
+		menchie.ID = menchieID
+
+		if err := p.db.Put(ctx, menchie); err != nil {
+			return nil, fmt.Errorf("error putting mentions in db: %s", err)
+		}
+		mentions = append(mentions, menchie)
+		menchies = append(menchies, menchie.ID)
+	}
+
+	dropped := []string{}
+	for _, e := range existing {
+		if !kept[e.ID] {
+			dropped = append(dropped, e.ID)
+		}
+	}
+
+	status.Mentions = mentions
+	status.MentionIDs = menchies
+	return dropped, nil
+}
+
+func mentionOfAccount(targetAccountID string, mentions []*gtsmodel.Mention) *gtsmodel.Mention {
+	for _, m := range mentions {
+		if m.TargetAccountID == targetAccountID {
+			return m
+		}
+	}
+	return nil
+}
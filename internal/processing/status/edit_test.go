@@ -0,0 +1,144 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *StatusEditTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.typeConverter = testrig.NewTestTypeConverter(suite.db)
+	suite.fromClientAPIChan = make(chan messages.FromClientAPI, 100)
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), testrig.NewTestStorage())
+	suite.status = status.New(suite.db, suite.typeConverter, suite.config, suite.fromClientAPIChan, suite.federator, suite.log)
+
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *StatusEditTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *StatusEditTestSuite) TestSource() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	source, errWithCode := suite.status.Source(ctx, suite.testAccounts["local_account_1"], targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(targetStatus.ID, source.ID)
+	suite.Equal(targetStatus.Text, source.Text)
+	suite.Equal(targetStatus.ContentWarning, source.SpoilerText)
+
+	// nobody else gets to see the source
+	_, errWithCode = suite.status.Source(ctx, suite.testAccounts["local_account_2"], targetStatus.ID)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *StatusEditTestSuite) TestEditMentionsTagsAndMedia() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, requestingAccount, targetStatus.ID, &apimodel.StatusEditRequest{
+		Status:   "hello @1happyturtle, look at this #welcome",
+		Format:   apimodel.StatusFormatPlain,
+		MediaIDs: []string{attachment.ID},
+		Language: "de",
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("de", apiStatus.Language)
+	suite.Len(apiStatus.Mentions, 1)
+	suite.Equal(suite.testAccounts["local_account_2"].ID, apiStatus.Mentions[0].ID)
+	suite.Len(apiStatus.Tags, 1)
+	suite.Equal("welcome", apiStatus.Tags[0].Name)
+	suite.Len(apiStatus.MediaAttachments, 1)
+	suite.Equal(attachment.ID, apiStatus.MediaAttachments[0].ID)
+
+	// the media should now be attached to the status
+	dbAttachment := &gtsmodel.MediaAttachment{}
+	suite.NoError(suite.db.GetByID(ctx, attachment.ID, dbAttachment))
+	suite.Equal(targetStatus.ID, dbAttachment.StatusID)
+
+	// the edit should have been sent on for federating
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+
+	// editing again without the mention keeps the media, but drops the mention
+	apiStatus, errWithCode = suite.status.Edit(ctx, requestingAccount, targetStatus.ID, &apimodel.StatusEditRequest{
+		Status: "hello everyone, look at this #welcome",
+		Format: apimodel.StatusFormatPlain,
+	})
+	suite.NoError(errWithCode)
+	suite.Empty(apiStatus.Mentions)
+	suite.Len(apiStatus.MediaAttachments, 1)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.Empty(dbStatus.MentionIDs)
+}
+
+func (suite *StatusEditTestSuite) TestEditMediaOfOtherStatus() {
+	ctx := context.Background()
+
+	// this attachment already belongs to another status
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	_, errWithCode := suite.status.Edit(ctx, suite.testAccounts["admin_account"], suite.testStatuses["admin_account_status_2"].ID, &apimodel.StatusEditRequest{
+		Status:   "this isn't mine",
+		MediaIDs: []string{attachment.ID},
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
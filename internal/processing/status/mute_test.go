@@ -0,0 +1,97 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusMuteTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusMuteTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAttachments = testrig.NewTestAttachments()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+	suite.testMentions = testrig.NewTestMentions()
+}
+
+func (suite *StatusMuteTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	suite.typeConverter = testrig.NewTestTypeConverter(suite.db)
+	suite.fromClientAPIChan = make(chan messages.FromClientAPI, 100)
+	suite.federator = testrig.NewTestFederator(suite.db, testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db), testrig.NewTestStorage())
+	suite.status = status.New(suite.db, suite.typeConverter, suite.config, suite.fromClientAPIChan, suite.federator, suite.log)
+
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *StatusMuteTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *StatusMuteTestSuite) TestMuteUnmuteThread() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	// admin_account_status_3 and local_account_2_status_5 are both replies to local_account_1_status_1
+	reply := suite.testStatuses["admin_account_status_3"]
+	otherReply := suite.testStatuses["local_account_2_status_5"]
+
+	apiStatus, errWithCode := suite.status.Mute(ctx, requestingAccount, reply.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Muted)
+
+	// muting one reply should have muted the whole thread
+	muted, err := suite.db.IsStatusMutedBy(ctx, otherReply, requestingAccount.ID)
+	suite.NoError(err)
+	suite.True(muted)
+
+	// muting again should be a no-op
+	apiStatus, errWithCode = suite.status.Mute(ctx, requestingAccount, otherReply.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.Muted)
+
+	// unmuting from any status in the thread should unmute the whole thread
+	apiStatus, errWithCode = suite.status.Unmute(ctx, requestingAccount, otherReply.ID)
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.Muted)
+
+	muted, err = suite.db.IsStatusMutedBy(ctx, reply, requestingAccount.ID)
+	suite.NoError(err)
+	suite.False(muted)
+}
+
+func TestStatusMuteTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusMuteTestSuite))
+}
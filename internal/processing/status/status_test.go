@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
@@ -37,6 +38,7 @@ type StatusStandardTestSuite struct {
 	log               *logrus.Logger
 	typeConverter     typeutils.TypeConverter
 	fromClientAPIChan chan messages.FromClientAPI
+	federator         federation.Federator
 
 	// standard suite models
 	testTokens       map[string]*gtsmodel.Token
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
@@ -92,6 +93,7 @@ func (p *processor) Unboost(ctx context.Context, requestingAccount *gtsmodel.Acc
 
 		// send it back to the processor for async processing
 		p.fromClientAPI <- messages.FromClientAPI{
+			RequestID:      log.RequestID(ctx),
 			APObjectType:   ap.ActivityAnnounce,
 			APActivityType: ap.ActivityUndo,
 			GTSModel:       gtsBoost,
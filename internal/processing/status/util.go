@@ -22,6 +22,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -29,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/text/language"
 )
 
 func (p *processor) ProcessVisibility(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultVis gtsmodel.Visibility, status *gtsmodel.Status) error {
@@ -51,8 +54,10 @@ func (p *processor) ProcessVisibility(ctx context.Context, form *apimodel.Advanc
 
 	switch vis {
 	case gtsmodel.VisibilityPublic:
-		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out
-		break
+		// for public, federation is the only advanced flag that can be changed; the others stay true regardless of what the user filled out
+		if form.Federated != nil {
+			federated = *form.Federated
+		}
 	case gtsmodel.VisibilityUnlocked:
 		// for unlocked the user can set any combination of flags they like so look at them all to see if they're set and then apply them
 		if form.Federated != nil {
@@ -95,14 +100,59 @@ func (p *processor) ProcessVisibility(ctx context.Context, form *apimodel.Advanc
 		likeable = true
 	}
 
+	if vis != gtsmodel.VisibilityDirect {
+		// local_only is the flag that other fediverse software uses for this, so it takes precedence over federated
+		if form.LocalOnly != nil {
+			federated = !*form.LocalOnly
+		}
+
+		// a reply to a local-only status stays local-only too, so that the conversation doesn't leak off this instance
+		if federated && status.InReplyToID != "" {
+			repliedStatus, err := p.db.GetStatusByID(ctx, status.InReplyToID)
+			if err != nil {
+				return fmt.Errorf("error getting replied status %s: %s", status.InReplyToID, err)
+			}
+			if !repliedStatus.Federated {
+				federated = false
+			}
+		}
+	}
+
+	// direct statuses can only be interacted with by the accounts they mention anyway
+	var replyPolicy, boostPolicy, likePolicy gtsmodel.InteractionPolicy
+	if vis != gtsmodel.VisibilityDirect {
+		var err error
+		if replyPolicy, err = parseInteractionPolicy("reply", form.ReplyPolicy); err != nil {
+			return err
+		}
+		if boostPolicy, err = parseInteractionPolicy("boost", form.BoostPolicy); err != nil {
+			return err
+		}
+		if likePolicy, err = parseInteractionPolicy("like", form.LikePolicy); err != nil {
+			return err
+		}
+	}
+
 	status.Visibility = vis
 	status.Federated = federated
 	status.Boostable = boostable
+	status.BoostPolicy = boostPolicy
 	status.Replyable = replyable
+	status.ReplyPolicy = replyPolicy
 	status.Likeable = likeable
+	status.LikePolicy = likePolicy
 	return nil
 }
 
+// parseInteractionPolicy parses the given reply, boost or like policy from a status form. Empty is fine, and means anyone can interact.
+func parseInteractionPolicy(kind string, policy string) (gtsmodel.InteractionPolicy, error) {
+	switch ip := gtsmodel.InteractionPolicy(policy); ip {
+	case "", gtsmodel.InteractionPolicyPublic, gtsmodel.InteractionPolicyFollowers, gtsmodel.InteractionPolicyMentioned:
+		return ip, nil
+	}
+	return "", fmt.Errorf("%s policy %s not recognized", kind, policy)
+}
+
 func (p *processor) ProcessReplyToID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error {
 	if form.InReplyToID == "" {
 		return nil
@@ -124,8 +174,14 @@ func (p *processor) ProcessReplyToID(ctx context.Context, form *apimodel.Advance
 		}
 		return fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err)
 	}
-	if !repliedStatus.Replyable {
-		return fmt.Errorf("status with id %s is marked as not replyable", form.InReplyToID)
+	thisAccount, err := p.db.GetAccountByID(ctx, thisAccountID)
+	if err != nil {
+		return fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err)
+	}
+	if replyable, err := p.filter.StatusReplyable(ctx, repliedStatus, thisAccount); err != nil {
+		return fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err)
+	} else if !replyable {
+		return fmt.Errorf("status with id %s is not replyable by account %s", form.InReplyToID, thisAccountID)
 	}
 
 	// check replied account is known to us
@@ -178,24 +234,77 @@ func (p *processor) ProcessMediaIDs(ctx context.Context, form *apimodel.Advanced
 	return nil
 }
 
+func (p *processor) ProcessPoll(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) error {
+	if form.Poll == nil {
+		return nil
+	}
+
+	pollID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	options := []string{}
+	for _, o := range form.Poll.Options {
+		options = append(options, text.RemoveHTML(o))
+	}
+
+	status.Poll = &gtsmodel.Poll{
+		ID:         pollID,
+		StatusID:   status.ID,
+		Status:     status,
+		Options:    options,
+		Multiple:   form.Poll.Multiple,
+		HideCounts: form.Poll.HideTotals,
+		ExpiresAt:  time.Now().Add(time.Duration(form.Poll.ExpiresIn) * time.Second),
+	}
+	return nil
+}
+
 func (p *processor) ProcessLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
-	if form.Language != "" {
-		status.Language = form.Language
-	} else {
+	status.Language = form.Language
+	if status.Language == "" {
+		// the language wasn't given, so make a guess based on what was written
+		status.Language = text.DetectLanguage(form.Status)
+	}
+	if status.Language == "" {
 		status.Language = accountDefaultLanguage
 	}
 	if status.Language == "" {
 		return errors.New("no language given either in status create form or account default")
 	}
+	status.Language = normalizeLanguage(status.Language)
+	return nil
+}
+
+// normalizeLanguage returns the ISO 639-1 code of the given language, if it has one,
+// so that the same language is always stored the same way. For example, 'ENG' becomes 'en'.
+func normalizeLanguage(lang string) string {
+	base, err := language.ParseBase(lang)
+	if err != nil {
+		return lang
+	}
+	return base.String()
+}
+
+func (p *processor) ProcessSensitive(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultSensitive bool, status *gtsmodel.Status) error {
+	if form.Sensitive != nil {
+		status.Sensitive = *form.Sensitive
+	} else {
+		status.Sensitive = accountDefaultSensitive
+	}
 	return nil
 }
 
 func (p *processor) ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error {
 	menchies := []string{}
-	gtsMenchies, err := p.db.MentionStringsToMentions(ctx, util.DeriveMentionsFromText(form.Status), accountID, status.ID)
+	mentionStrings := util.DeriveMentionsFromText(form.Status)
+	gtsMenchies, err := p.db.MentionStringsToMentions(ctx, mentionStrings, accountID, status.ID)
 	if err != nil {
 		return fmt.Errorf("error generating mentions from status: %s", err)
 	}
+	// try to resolve any remote accounts we've never seen before, so that they still get mentioned
+	gtsMenchies = append(gtsMenchies, p.resolveMentions(ctx, mentionStrings, gtsMenchies, accountID, status.ID)...)
 	for _, menchie := range gtsMenchies {
 		menchieID, err := id.NewRandomULID()
 		if err != nil {
@@ -279,3 +388,75 @@ func (p *processor) ProcessContent(ctx context.Context, form *apimodel.AdvancedS
 	status.Content = formatted
 	return nil
 }
+
+// mentionResolveTimeout is the longest we'll spend, in total, resolving previously unseen
+// remote accounts mentioned in a new status.
+const mentionResolveTimeout = 10 * time.Second
+
+// resolveMentions tries to webfinger and dereference remote accounts which are mentioned in mentionStrings,
+// but which aren't in the known mentions because we've never seen them before. A mention is returned for
+// every account that could be resolved; any that couldn't be resolved in time are just left out, so that
+// they'll end up as plain text in the status rather than holding up its creation.
+func (p *processor) resolveMentions(ctx context.Context, mentionStrings []string, known []*gtsmodel.Mention, originAccountID string, statusID string) []*gtsmodel.Mention {
+	resolved := []*gtsmodel.Mention{}
+
+	knownNames := make(map[string]bool, len(known))
+	for _, m := range known {
+		knownNames[strings.ToLower(m.NameString)] = true
+	}
+
+	unknown := []string{}
+	for _, mentionString := range mentionStrings {
+		if !knownNames[strings.ToLower(mentionString)] && util.IsMention(mentionString) {
+			unknown = append(unknown, mentionString)
+		}
+	}
+	if len(unknown) == 0 {
+		return resolved
+	}
+
+	l := p.log.WithContext(ctx).WithField("func", "resolveMentions")
+
+	originAccount, err := p.db.GetAccountByID(ctx, originAccountID)
+	if err != nil {
+		l.WithError(err).Error("error getting origin account")
+		return resolved
+	}
+
+	// don't let slow or unresponsive remote instances hold up status creation indefinitely
+	ctx, cancel := context.WithTimeout(ctx, mentionResolveTimeout)
+	defer cancel()
+
+	for _, mentionString := range unknown {
+		username, domain, err := util.ExtractMentionParts(mentionString)
+		if err != nil || strings.EqualFold(domain, p.config.Host) || strings.EqualFold(domain, p.config.AccountDomain) {
+			// local accounts we don't know about just don't exist
+			continue
+		}
+
+		acctURI, err := p.federator.FingerRemoteAccount(ctx, originAccount.Username, username, domain)
+		if err != nil {
+			l.WithError(err).WithField("mention", mentionString).Debug("couldn't finger mentioned account, leaving it out")
+			continue
+		}
+
+		targetAccount, _, err := p.federator.GetRemoteAccount(ctx, originAccount.Username, acctURI, false)
+		if err != nil {
+			l.WithError(err).WithField("mention", mentionString).Debug("couldn't dereference mentioned account, leaving it out")
+			continue
+		}
+
+		resolved = append(resolved, &gtsmodel.Mention{
+			StatusID:         statusID,
+			OriginAccountID:  originAccount.ID,
+			OriginAccountURI: originAccount.URI,
+			TargetAccountID:  targetAccount.ID,
+			TargetAccount:    targetAccount,
+			NameString:       mentionString,
+			TargetAccountURI: targetAccount.URI,
+			TargetAccountURL: targetAccount.URL,
+		})
+	}
+
+	return resolved
+}
@@ -19,16 +19,24 @@
 package status_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/go-fed/activity/streams"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -70,7 +78,8 @@ func (suite *UtilTestSuite) SetupTest() {
 	suite.log = testrig.NewTestLog()
 	suite.typeConverter = testrig.NewTestTypeConverter(suite.db)
 	suite.fromClientAPIChan = make(chan messages.FromClientAPI, 100)
-	suite.status = status.New(suite.db, suite.typeConverter, suite.config, suite.fromClientAPIChan, suite.log)
+	suite.federator = testrig.NewTestFederator(suite.db, suite.mockTransportController(), testrig.NewTestStorage())
+	suite.status = status.New(suite.db, suite.typeConverter, suite.config, suite.fromClientAPIChan, suite.federator, suite.log)
 
 	testrig.StandardDBSetup(suite.db, nil)
 }
@@ -89,7 +98,7 @@ func (suite *UtilTestSuite) TestProcessMentions1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   nil,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -139,7 +148,7 @@ func (suite *UtilTestSuite) TestProcessContentFull1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   nil,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -188,7 +197,7 @@ func (suite *UtilTestSuite) TestProcessContentPartial1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   nil,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -230,7 +239,7 @@ func (suite *UtilTestSuite) TestProcessMentions2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   nil,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -267,6 +276,83 @@ func (suite *UtilTestSuite) TestProcessMentions2() {
 	assert.Equal(suite.T(), newMention.ID, status.MentionIDs[0])
 }
 
+func (suite *UtilTestSuite) TestProcessMentionsResolvesUnknownAccount() {
+	creatingAccount := suite.testAccounts["local_account_1"]
+
+	form := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "hello @brand_new_person@unknown-instance.com and @nobody@does-not-exist.example.org",
+			Visibility: model.VisibilityPublic,
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	status := &gtsmodel.Status{
+		ID: "01FCTDD78JJMX3K9KPXQ7ZQ8BJ",
+	}
+
+	err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
+	suite.NoError(err)
+
+	// the account we'd never seen should have been resolved, and the one that doesn't exist left out
+	suite.Len(status.Mentions, 1)
+	newMention := status.Mentions[0]
+	suite.Equal("@brand_new_person@unknown-instance.com", newMention.NameString)
+	suite.Equal("https://unknown-instance.com/users/brand_new_person", newMention.TargetAccountURI)
+	suite.Equal(creatingAccount.ID, newMention.OriginAccountID)
+	suite.NotEmpty(newMention.ID)
+	suite.Equal([]string{newMention.ID}, status.MentionIDs)
+
+	// the mentioned account should be stored now
+	mentionedAccount, err := suite.db.GetAccountByURI(context.Background(), newMention.TargetAccountURI)
+	suite.NoError(err)
+	suite.Equal(mentionedAccount.ID, newMention.TargetAccountID)
+}
+
+// mockTransportController returns a transport controller which answers webfinger and
+// actor requests for the remote people in testrig, and 404s for anything else.
+func (suite *UtilTestSuite) mockTransportController() transport.Controller {
+	people := testrig.NewTestFediPeople()
+	return testrig.NewTestTransportController(testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		var body []byte
+		contentType := "application/activity+json"
+
+		if person, ok := people[req.URL.String()]; ok {
+			personI, err := streams.Serialize(person)
+			if err != nil {
+				return nil, err
+			}
+			if body, err = json.Marshal(personI); err != nil {
+				return nil, err
+			}
+		} else if req.URL.Path == "/.well-known/webfinger" {
+			username, domain, err := util.ExtractMentionParts("@" + strings.TrimPrefix(req.URL.Query().Get("resource"), "acct:"))
+			if err == nil {
+				uri := fmt.Sprintf("https://%s/users/%s", domain, username)
+				if _, ok := people[uri]; ok {
+					contentType = "application/jrd+json"
+					body = []byte(fmt.Sprintf(`{"subject":"acct:%s@%s","links":[{"rel":"self","type":"application/activity+json","href":"%s"}]}`, username, domain, uri))
+				}
+			}
+		}
+
+		if body == nil {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Status:     "404 Not Found",
+				Body:       io.NopCloser(bytes.NewReader([]byte{})),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Header:        http.Header{"Content-Type": {contentType}},
+		}, nil
+	}), suite.db)
+}
+
 func (suite *UtilTestSuite) TestProcessContentFull2() {
 
 	/*
@@ -280,7 +366,7 @@ func (suite *UtilTestSuite) TestProcessContentFull2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   nil,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -330,7 +416,7 @@ func (suite *UtilTestSuite) TestProcessContentPartial2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
+			Sensitive:   nil,
 			SpoilerText: "",
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
@@ -364,6 +450,164 @@ func (suite *UtilTestSuite) TestProcessContentPartial2() {
 	// assert.Equal(suite.T(), statusText2ExpectedPartial, status.Content)
 }
 
+func (suite *UtilTestSuite) TestProcessReplyToIDReplyPolicy() {
+	repliedStatus := suite.testStatuses["local_account_1_status_1"]
+	replyingAccount := suite.testAccounts["local_account_2"]
+
+	form := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "hey, what's up?",
+			InReplyToID: repliedStatus.ID,
+			Visibility:  model.VisibilityPublic,
+		},
+	}
+
+	// anyone can reply by default
+	err := suite.status.ProcessReplyToID(context.Background(), form, replyingAccount.ID, &gtsmodel.Status{})
+	suite.NoError(err)
+
+	// restrict replies to mentioned accounts only
+	repliedStatus.ReplyPolicy = gtsmodel.InteractionPolicyMentioned
+	err = suite.db.UpdateByPrimaryKey(context.Background(), repliedStatus)
+	suite.NoError(err)
+
+	err = suite.status.ProcessReplyToID(context.Background(), form, replyingAccount.ID, &gtsmodel.Status{})
+	suite.Error(err)
+
+	// the author can still reply to themself
+	status := &gtsmodel.Status{}
+	err = suite.status.ProcessReplyToID(context.Background(), form, repliedStatus.AccountID, status)
+	suite.NoError(err)
+	suite.Equal(repliedStatus.ID, status.InReplyToID)
+}
+
+func (suite *UtilTestSuite) TestProcessVisibilityLocalOnly() {
+	localOnly := true
+	form := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "only for the locals",
+			Visibility: model.VisibilityPublic,
+		},
+		AdvancedVisibilityFlagsForm: model.AdvancedVisibilityFlagsForm{
+			LocalOnly: &localOnly,
+		},
+	}
+
+	status := &gtsmodel.Status{}
+	err := suite.status.ProcessVisibility(context.Background(), form, "", status)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+	suite.False(status.Federated)
+	suite.True(status.Boostable)
+
+	// direct statuses always federate, so that remote mentions can be delivered
+	form.Visibility = model.VisibilityDirect
+	status = &gtsmodel.Status{}
+	err = suite.status.ProcessVisibility(context.Background(), form, "", status)
+	suite.NoError(err)
+	suite.True(status.Federated)
+}
+
+func (suite *UtilTestSuite) TestProcessVisibilityReplyToLocalOnly() {
+	form := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "replying to a local-only status",
+			Visibility: model.VisibilityPublic,
+		},
+	}
+
+	// local_account_1_status_2 is local-only, so the reply should be too
+	status := &gtsmodel.Status{InReplyToID: suite.testStatuses["local_account_1_status_2"].ID}
+	err := suite.status.ProcessVisibility(context.Background(), form, "", status)
+	suite.NoError(err)
+	suite.False(status.Federated)
+
+	status = &gtsmodel.Status{InReplyToID: suite.testStatuses["local_account_1_status_1"].ID}
+	err = suite.status.ProcessVisibility(context.Background(), form, "", status)
+	suite.NoError(err)
+	suite.True(status.Federated)
+}
+
+func (suite *UtilTestSuite) TestProcessLanguage() {
+	for _, test := range []struct {
+		text     string
+		language string
+		expected string
+	}{
+		// a given language is used as is, just normalized
+		{text: "Ich habe heute keine Zeit, aber morgen bin ich dabei", language: "ENG", expected: "en"},
+		// otherwise it's detected from the text
+		{text: "Ich habe heute keine Zeit, aber morgen bin ich dabei", expected: "de"},
+		// and if that doesn't work the account default is used
+		{text: "lol", expected: "fr"},
+	} {
+		form := &model.AdvancedStatusCreateForm{
+			StatusCreateRequest: model.StatusCreateRequest{
+				Status:   test.text,
+				Language: test.language,
+			},
+		}
+
+		status := &gtsmodel.Status{}
+		err := suite.status.ProcessLanguage(context.Background(), form, "fr", status)
+		suite.NoError(err)
+		suite.Equal(test.expected, status.Language)
+	}
+}
+
+func (suite *UtilTestSuite) TestInteractionPolicies() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+	zork := suite.testAccounts["local_account_1"]
+	turtle := suite.testAccounts["local_account_2"]
+	application := suite.testApplications["application_1"]
+
+	// only followers of admin may boost, and only mentioned accounts may like
+	targetStatus.BoostPolicy = gtsmodel.InteractionPolicyFollowers
+	targetStatus.LikePolicy = gtsmodel.InteractionPolicyMentioned
+	err := suite.db.UpdateByPrimaryKey(ctx, targetStatus)
+	suite.NoError(err)
+
+	// turtle doesn't follow admin
+	_, errWithCode := suite.status.Boost(ctx, turtle, application, targetStatus.ID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// zork does
+	_, errWithCode = suite.status.Boost(ctx, zork, application, targetStatus.ID)
+	suite.NoError(errWithCode)
+
+	// nobody is mentioned, so nobody but admin can like it
+	_, errWithCode = suite.status.Fave(ctx, zork, targetStatus.ID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	_, errWithCode = suite.status.Fave(ctx, suite.testAccounts["admin_account"], targetStatus.ID)
+	suite.NoError(errWithCode)
+}
+
+func (suite *UtilTestSuite) TestProcessVisibilityInteractionPolicies() {
+	form := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "boosts from followers only please",
+			Visibility: model.VisibilityPublic,
+		},
+		AdvancedVisibilityFlagsForm: model.AdvancedVisibilityFlagsForm{
+			BoostPolicy: "followers",
+			LikePolicy:  "mentioned",
+		},
+	}
+
+	status := &gtsmodel.Status{}
+	err := suite.status.ProcessVisibility(context.Background(), form, "", status)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.InteractionPolicyFollowers, status.BoostPolicy)
+	suite.Equal(gtsmodel.InteractionPolicyMentioned, status.LikePolicy)
+	suite.Empty(status.ReplyPolicy)
+
+	form.LikePolicy = "friends"
+	err = suite.status.ProcessVisibility(context.Background(), form, "", &gtsmodel.Status{})
+	suite.EqualError(err, "like policy friends not recognized")
+}
+
 func TestUtilTestSuite(t *testing.T) {
 	suite.Run(t, new(UtilTestSuite))
 }
@@ -0,0 +1,103 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+const (
+	// statusExpiryInterval is how often to check for statuses that local users have chosen to have deleted automatically.
+	statusExpiryInterval = time.Hour
+	// statusExpiryBatchSize is how many expired statuses of one user to select for deletion at a time.
+	statusExpiryBatchSize = 100
+)
+
+// sweepExpiredStatuses periodically deletes the statuses of local users that have outlived their chosen expiry, until the processor is stopped.
+func (p *processor) sweepExpiredStatuses(ctx context.Context) {
+	ticker := time.NewTicker(statusExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.deleteExpiredStatuses(ctx)
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// deleteExpiredStatuses deletes the expired statuses of every local user who has chosen a status expiry. Statuses
+// are deleted through the same path as a user deleting them through the client API, so that the deletes federate.
+func (p *processor) deleteExpiredStatuses(ctx context.Context) {
+	l := p.log.WithContext(ctx).WithField("func", "deleteExpiredStatuses")
+
+	users, err := p.db.GetStatusExpiryUsers(ctx)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			l.WithError(err).Error("error getting users with a status expiry")
+		}
+		return
+	}
+
+	for _, user := range users {
+		account, err := p.db.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			l.WithError(err).WithField("accountID", user.AccountID).Error("error getting account")
+			continue
+		}
+
+		olderThan := time.Now().Add(time.Duration(-user.StatusExpiryDays) * 24 * time.Hour)
+		deleted := 0
+
+		// deleted statuses drop out of the selection, so just keep selecting until there are none left
+	StatusLoop:
+		for {
+			statuses, err := p.db.GetExpiredStatuses(ctx, account.ID, olderThan, user.StatusExpiryKeepPinned, user.StatusExpiryKeepFaved, statusExpiryBatchSize)
+			if err != nil && err != db.ErrNoEntries {
+				l.WithError(err).WithField("accountID", account.ID).Error("error getting expired statuses")
+				break
+			}
+			if len(statuses) == 0 {
+				break
+			}
+
+			for _, s := range statuses {
+				if _, errWithCode := p.statusProcessor.Delete(ctx, account, s.ID); errWithCode != nil {
+					// bail rather than selecting the same status over and over again
+					l.WithError(errWithCode).WithField("statusID", s.ID).Error("error deleting expired status")
+					break StatusLoop
+				}
+				deleted++
+			}
+		}
+
+		if deleted != 0 {
+			l.WithFields(logrus.Fields{
+				"accountID": account.ID,
+				"statuses":  deleted,
+			}).Info("deleted expired statuses")
+		}
+	}
+}
@@ -38,7 +38,7 @@ func (p *processor) StreamNotificationToAccount(n *apimodel.Notification, accoun
 		s.Lock()
 		defer s.Unlock()
 		if s.Connected {
-			l.Debugf("streaming notification to stream id %s", s.ID)
+			l.WithField("streamID", s.ID).Debug("streaming notification to stream")
 			s.Messages <- &stream.Message{
 				Stream:  []string{s.Type},
 				Event:   "notification",
@@ -38,7 +38,7 @@ func (p *processor) StreamStatusToAccount(s *apimodel.Status, account *gtsmodel.
 		s.Lock()
 		defer s.Unlock()
 		if s.Connected {
-			l.Debugf("streaming status to stream id %s", s.ID)
+			l.WithField("streamID", s.ID).Debug("streaming status to stream")
 			s.Messages <- &stream.Message{
 				Stream:  []string{s.Type},
 				Event:   "update",
@@ -0,0 +1,154 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	suggestionSourceFriendsOfFriends = "friends_of_friends"
+	suggestionSourcePastInteractions = "past_interactions"
+	suggestionSourceMostFollowed     = "most_followed"
+	// suggestionSourceGlobal is the source given to older clients for suggestions that don't come from past interactions
+	suggestionSourceGlobal = "global"
+)
+
+func (p *processor) SuggestionsGet(ctx context.Context, authed *oauth.Auth, limit int) ([]*apimodel.Suggestion, gtserror.WithCode) {
+	// sources are tried in order, so that accounts which are
+	// closest to the requesting account are suggested first
+	sources := []struct {
+		name string
+		get  func(ctx context.Context, accountID string, limit int) ([]*db.AccountCount, db.Error)
+	}{
+		{name: suggestionSourceFriendsOfFriends, get: p.db.GetFriendsOfFriendsSuggestions},
+		{name: suggestionSourcePastInteractions, get: p.db.GetPastInteractionSuggestions},
+		{name: suggestionSourceMostFollowed, get: p.db.GetMostFollowedSuggestions},
+	}
+
+	suggestions := []*apimodel.Suggestion{}
+	byAccountID := make(map[string]*apimodel.Suggestion)
+	skipped := make(map[string]bool)
+	for _, source := range sources {
+		counts, err := source.get(ctx, authed.Account.ID, limit)
+		if err != nil && err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: error getting %s suggestions: %s", source.name, err))
+		}
+
+		for _, c := range counts {
+			if suggestion, ok := byAccountID[c.AccountID]; ok {
+				suggestion.Sources = append(suggestion.Sources, source.name)
+				continue
+			}
+
+			if skipped[c.AccountID] || len(suggestions) >= limit {
+				continue
+			}
+
+			suggestion, err := p.suggestion(ctx, authed.Account, c.AccountID, source.name)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: error creating suggestion: %s", err))
+			}
+			if suggestion == nil {
+				skipped[c.AccountID] = true
+				continue
+			}
+
+			byAccountID[c.AccountID] = suggestion
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	return suggestions, nil
+}
+
+func (p *processor) SuggestionDismiss(ctx context.Context, authed *oauth.Auth, targetAccountID string) gtserror.WithCode {
+	if _, err := p.db.GetAccountByID(ctx, targetAccountID); err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(fmt.Errorf("SuggestionDismiss: account %s not found", targetAccountID))
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("SuggestionDismiss: error getting account %s: %s", targetAccountID, err))
+	}
+
+	dismissalID, err := id.NewRandomULID()
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	dismissal := &gtsmodel.SuggestionDismissal{
+		ID:              dismissalID,
+		AccountID:       authed.Account.ID,
+		TargetAccountID: targetAccountID,
+	}
+
+	if err := p.db.Put(ctx, dismissal); err != nil && err != db.ErrAlreadyExists {
+		return gtserror.NewErrorInternalError(fmt.Errorf("SuggestionDismiss: error putting dismissal: %s", err))
+	}
+
+	return nil
+}
+
+// suggestion returns a suggestion for requestingAccount to follow the account with the given ID,
+// or nil if the account shouldn't be suggested because of a block or mute between the two.
+func (p *processor) suggestion(ctx context.Context, requestingAccount *gtsmodel.Account, accountID string, source string) (*apimodel.Suggestion, error) {
+	blocked, err := p.db.IsBlocked(ctx, requestingAccount.ID, accountID, true)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, nil
+	}
+
+	muted, err := p.db.IsMuted(ctx, requestingAccount.ID, accountID, false)
+	if err != nil {
+		return nil, err
+	}
+	if muted {
+		return nil, nil
+	}
+
+	account, err := p.db.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiAccount, err := p.tc.AccountToMastoPublic(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	legacySource := suggestionSourceGlobal
+	if source == suggestionSourcePastInteractions {
+		legacySource = suggestionSourcePastInteractions
+	}
+
+	return &apimodel.Suggestion{
+		Source:  legacySource,
+		Sources: []string{source},
+		Account: apiAccount,
+	}, nil
+}
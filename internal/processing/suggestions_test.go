@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type SuggestionsTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *SuggestionsTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsMostFollowed() {
+	// turtle doesn't follow anyone, but has interacted with zork's statuses
+	suggestions, errWithCode := suite.processor.SuggestionsGet(context.Background(), suite.authed("local_account_2"), 40)
+	suite.NoError(errWithCode)
+	suite.Len(suggestions, 2)
+
+	suite.Equal(suite.testAccounts["local_account_1"].ID, suggestions[0].Account.ID)
+	suite.Equal("past_interactions", suggestions[0].Source)
+	suite.Equal([]string{"past_interactions"}, suggestions[0].Sources)
+
+	// admin is followed by zork, so it's the most followed local account that turtle doesn't follow yet
+	suite.Equal(suite.testAccounts["admin_account"].ID, suggestions[1].Account.ID)
+	suite.Equal("global", suggestions[1].Source)
+	suite.Equal([]string{"most_followed"}, suggestions[1].Sources)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsPastInteractions() {
+	// admin has faved a status of zork's
+	suggestions, errWithCode := suite.processor.SuggestionsGet(context.Background(), suite.authed("admin_account"), 40)
+	suite.NoError(errWithCode)
+	suite.Len(suggestions, 1)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, suggestions[0].Account.ID)
+	suite.Equal("past_interactions", suggestions[0].Source)
+	suite.Equal([]string{"past_interactions"}, suggestions[0].Sources)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionsFriendsOfFriends() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	// zork follows admin, so if admin follows a remote account, zork should be suggested it
+	err := suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01FQWMC4EJGNMEW3G1M4V8GE5Z",
+		URI:             "http://localhost:8080/users/admin/follow/01FQWMC4EJGNMEW3G1M4V8GE5Z",
+		AccountID:       adminAccount.ID,
+		TargetAccountID: remoteAccount.ID,
+	})
+	suite.NoError(err)
+
+	suggestions, errWithCode := suite.processor.SuggestionsGet(ctx, suite.authed("local_account_1"), 40)
+	suite.NoError(errWithCode)
+	suite.Len(suggestions, 1)
+	suite.Equal(remoteAccount.ID, suggestions[0].Account.ID)
+	suite.Equal("global", suggestions[0].Source)
+	suite.Equal([]string{"friends_of_friends"}, suggestions[0].Sources)
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionDismiss() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_2")
+
+	errWithCode := suite.processor.SuggestionDismiss(ctx, authed, suite.testAccounts["admin_account"].ID)
+	suite.NoError(errWithCode)
+
+	// dismissing twice is fine
+	errWithCode = suite.processor.SuggestionDismiss(ctx, authed, suite.testAccounts["admin_account"].ID)
+	suite.NoError(errWithCode)
+
+	suggestions, errWithCode := suite.processor.SuggestionsGet(ctx, authed, 40)
+	suite.NoError(errWithCode)
+	for _, s := range suggestions {
+		suite.NotEqual(suite.testAccounts["admin_account"].ID, s.Account.ID)
+	}
+}
+
+func (suite *SuggestionsTestSuite) TestSuggestionDismissNotFound() {
+	errWithCode := suite.processor.SuggestionDismiss(context.Background(), suite.authed("local_account_2"), "01FQWMDHX7FB6W5KF1RX5NE3TZ")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestSuggestionsTestSuite(t *testing.T) {
+	suite.Run(t, &SuggestionsTestSuite{})
+}
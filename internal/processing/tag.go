@@ -0,0 +1,153 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+)
+
+func (p *processor) TagGet(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getListableTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	following := false
+	if _, err := p.db.GetTagFollow(ctx, authed.Account.ID, tag.ID); err == nil {
+		following = true
+	} else if err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.tagToMasto(ctx, tag, following)
+}
+
+func (p *processor) TagFollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tagName = strings.ToLower(tagName)
+	if !regexes.HashtagName.MatchString(tagName) {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("invalid tag name %s", tagName), "tag name is not a valid hashtag")
+	}
+
+	tag, err := p.db.GetTagByName(ctx, tagName)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// nobody has used the tag yet, so create it
+		tags, err := p.db.TagStringsToTags(ctx, []string{tagName}, authed.Account.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if len(tags) == 0 {
+			return nil, gtserror.NewErrorForbidden(fmt.Errorf("tag %s is not useable", tagName))
+		}
+		tag = tags[0]
+
+		if err := p.db.Put(ctx, tag); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if !tag.Listable {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("tag %s is not listable", tagName))
+	}
+
+	if _, err := p.db.GetTagFollow(ctx, authed.Account.ID, tag.ID); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		tagFollowID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		tagFollow := &gtsmodel.TagFollow{
+			ID:        tagFollowID,
+			AccountID: authed.Account.ID,
+			TagID:     tag.ID,
+		}
+		if err := p.db.Put(ctx, tagFollow); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.tagToMasto(ctx, tag, true)
+}
+
+func (p *processor) TagUnfollow(ctx context.Context, authed *oauth.Auth, tagName string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getListableTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tagFollow, err := p.db.GetTagFollow(ctx, authed.Account.ID, tag.ID)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		// not following the tag anyway, nothing to do
+		return p.tagToMasto(ctx, tag, false)
+	}
+
+	if err := p.db.DeleteByID(ctx, tagFollow.ID, &gtsmodel.TagFollow{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.tagToMasto(ctx, tag, false)
+}
+
+// getListableTag returns the tag with the given name, or a 404 if it doesn't exist or can't be looked up by our users.
+func (p *processor) getListableTag(ctx context.Context, tagName string) (*gtsmodel.Tag, gtserror.WithCode) {
+	tag, err := p.db.GetTagByName(ctx, tagName)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("tag %s not found", tagName))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !tag.Listable {
+		return nil, gtserror.NewErrorNotFound(errors.New("tag is not listable"))
+	}
+
+	return tag, nil
+}
+
+func (p *processor) tagToMasto(ctx context.Context, tag *gtsmodel.Tag, following bool) (*apimodel.Tag, gtserror.WithCode) {
+	mastoTag, err := p.tc.TagToMasto(ctx, tag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	mastoTag.Following = &following
+
+	return &mastoTag, nil
+}
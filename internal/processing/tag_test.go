@@ -0,0 +1,130 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type TagTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *TagTestSuite) authed(name string) *oauth.Auth {
+	return &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers[name],
+		Account:     suite.testAccounts[name],
+	}
+}
+
+func (suite *TagTestSuite) TestTagFollowUnfollow() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	tag, errWithCode := suite.processor.TagGet(ctx, authed, "Welcome")
+	suite.NoError(errWithCode)
+	suite.Equal("welcome", tag.Name)
+	suite.False(*tag.Following)
+
+	tag, errWithCode = suite.processor.TagFollow(ctx, authed, "welcome")
+	suite.NoError(errWithCode)
+	suite.True(*tag.Following)
+
+	// following again is fine
+	tag, errWithCode = suite.processor.TagFollow(ctx, authed, "welcome")
+	suite.NoError(errWithCode)
+	suite.True(*tag.Following)
+
+	tag, errWithCode = suite.processor.TagGet(ctx, authed, "welcome")
+	suite.NoError(errWithCode)
+	suite.True(*tag.Following)
+
+	tag, errWithCode = suite.processor.TagUnfollow(ctx, authed, "welcome")
+	suite.NoError(errWithCode)
+	suite.False(*tag.Following)
+
+	tag, errWithCode = suite.processor.TagGet(ctx, authed, "welcome")
+	suite.NoError(errWithCode)
+	suite.False(*tag.Following)
+}
+
+func (suite *TagTestSuite) TestTagFollowNewTag() {
+	ctx := context.Background()
+	authed := suite.authed("local_account_1")
+
+	_, errWithCode := suite.processor.TagGet(ctx, authed, "brandnewtag")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	tag, errWithCode := suite.processor.TagFollow(ctx, authed, "BrandNewTag")
+	suite.NoError(errWithCode)
+	suite.Equal("brandnewtag", tag.Name)
+	suite.True(*tag.Following)
+
+	_, errWithCode = suite.processor.TagFollow(ctx, authed, "not a tag")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *TagTestSuite) TestFollowedTagInHomeTimeline() {
+	ctx := context.Background()
+	follower := suite.authed("local_account_2")
+
+	_, errWithCode := suite.processor.TagFollow(ctx, follower, "tagfollowtest")
+	suite.NoError(errWithCode)
+
+	// load the follower's home timeline so the new status has to be injected into it
+	_, errWithCode = suite.processor.HomeTimelineGet(ctx, follower, "", "", "", 20, false)
+	suite.NoError(errWithCode)
+
+	// local_account_2 doesn't follow local_account_1
+	status, err := suite.processor.StatusCreate(ctx, suite.authed("local_account_1"), &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:     "this status uses #tagfollowtest",
+			Visibility: apimodel.VisibilityPublic,
+		},
+	})
+	suite.NoError(err)
+
+	inHomeTimeline := func() bool {
+		resp, errWithCode := suite.processor.HomeTimelineGet(ctx, follower, "", "", "", 20, false)
+		if errWithCode != nil {
+			return false
+		}
+		for _, s := range resp.Statuses {
+			if s.ID == status.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the status is timelined asynchronously, so give it a moment
+	suite.Eventually(inHomeTimeline, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, &TagTestSuite{})
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/sirupsen/logrus"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -37,7 +38,7 @@ func (p *processor) packageStatusResponse(statuses []*apimodel.Status, path stri
 	resp.Statuses = statuses
 
 	// prepare the next and previous links
-	if len(statuses) != 0 {
+	if nextMaxID != "" && prevMinID != "" {
 		nextLink := &url.URL{
 			Scheme:   p.config.Protocol,
 			Host:     p.config.Host,
@@ -71,7 +72,35 @@ func (p *processor) HomeTimelineGet(ctx context.Context, authed *oauth.Auth, max
 		}, nil
 	}
 
-	return p.packageStatusResponse(statuses, "api/v1/timelines/home", statuses[len(statuses)-1].ID, statuses[0].ID, limit)
+	// take the paging ids before filtering, so that paging carries on past filtered statuses
+	nextMaxID, prevMinID := statuses[len(statuses)-1].ID, statuses[0].ID
+	statuses = p.filterStatuses(ctx, authed.Account, gtsmodel.FilterContextHome, statuses)
+	statuses = filterLanguages(authed.User, statuses)
+
+	return p.packageStatusResponse(statuses, "api/v1/timelines/home", nextMaxID, prevMinID, limit)
+}
+
+func (p *processor) ListTimelineGet(ctx context.Context, authed *oauth.Auth, listID string, maxID string, sinceID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	list, errWithCode := p.getOwnList(ctx, authed.Account, listID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	statuses, err := p.timelineManager.ListTimeline(ctx, list.ID, maxID, sinceID, minID, limit)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(statuses) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	nextMaxID, prevMinID := statuses[len(statuses)-1].ID, statuses[0].ID
+	statuses = p.filterStatuses(ctx, authed.Account, gtsmodel.FilterContextHome, statuses)
+
+	return p.packageStatusResponse(statuses, "api/v1/timelines/list/"+list.ID, nextMaxID, prevMinID, limit)
 }
 
 func (p *processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
@@ -98,7 +127,41 @@ func (p *processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 		}, nil
 	}
 
-	return p.packageStatusResponse(s, "api/v1/timelines/public", s[len(s)-1].ID, s[0].ID, limit)
+	nextMaxID, prevMinID := s[len(s)-1].ID, s[0].ID
+	s = p.filterStatuses(ctx, authed.Account, gtsmodel.FilterContextPublic, s)
+	s = filterLanguages(authed.User, s)
+
+	return p.packageStatusResponse(s, "api/v1/timelines/public", nextMaxID, prevMinID, limit)
+}
+
+func (p *processor) TagTimelineGet(ctx context.Context, authed *oauth.Auth, tagName string, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	statuses, err := p.db.GetTagTimeline(ctx, authed.Account.ID, tagName, maxID, sinceID, minID, limit, local)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries left
+			return &apimodel.StatusTimelineResponse{
+				Statuses: []*apimodel.Status{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	s, err := p.filterPublicStatuses(ctx, authed, statuses)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(s) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	nextMaxID, prevMinID := s[len(s)-1].ID, s[0].ID
+	s = p.filterStatuses(ctx, authed.Account, gtsmodel.FilterContextPublic, s)
+
+	return p.packageStatusResponse(s, "api/v1/timelines/tag/"+tagName, nextMaxID, prevMinID, limit)
 }
 
 func (p *processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
@@ -129,14 +192,17 @@ func (p *processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, ma
 }
 
 func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth, statuses []*gtsmodel.Status) ([]*apimodel.Status, error) {
-	l := p.log.WithField("func", "filterPublicStatuses")
+	l := p.log.WithContext(ctx).WithField("func", "filterPublicStatuses")
 
 	apiStatuses := []*apimodel.Status{}
 	for _, s := range statuses {
 		targetAccount := &gtsmodel.Account{}
 		if err := p.db.GetByID(ctx, s.AccountID, targetAccount); err != nil {
 			if err == db.ErrNoEntries {
-				l.Debugf("filterPublicStatuses: skipping status %s because account %s can't be found in the db", s.ID, s.AccountID)
+				l.WithFields(logrus.Fields{
+					"statusID":  s.ID,
+					"accountID": s.AccountID,
+				}).Debug("filterPublicStatuses: skipping status because account can't be found in the db")
 				continue
 			}
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("filterPublicStatuses: error getting status author: %s", err))
@@ -144,7 +210,7 @@ func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth
 
 		timelineable, err := p.filter.StatusPublictimelineable(ctx, s, authed.Account)
 		if err != nil {
-			l.Debugf("filterPublicStatuses: skipping status %s because of an error checking status visibility: %s", s.ID, err)
+			l.WithError(err).WithField("statusID", s.ID).Debug("filterPublicStatuses: skipping status because of an error checking status visibility")
 			continue
 		}
 		if !timelineable {
@@ -153,7 +219,7 @@ func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth
 
 		apiStatus, err := p.tc.StatusToMasto(ctx, s, authed.Account)
 		if err != nil {
-			l.Debugf("filterPublicStatuses: skipping status %s because it couldn't be converted to its mastodon representation: %s", s.ID, err)
+			l.WithError(err).WithField("statusID", s.ID).Debug("filterPublicStatuses: skipping status because it couldn't be converted to its mastodon representation")
 			continue
 		}
 
@@ -164,14 +230,17 @@ func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth
 }
 
 func (p *processor) filterFavedStatuses(ctx context.Context, authed *oauth.Auth, statuses []*gtsmodel.Status) ([]*apimodel.Status, error) {
-	l := p.log.WithField("func", "filterFavedStatuses")
+	l := p.log.WithContext(ctx).WithField("func", "filterFavedStatuses")
 
 	apiStatuses := []*apimodel.Status{}
 	for _, s := range statuses {
 		targetAccount := &gtsmodel.Account{}
 		if err := p.db.GetByID(ctx, s.AccountID, targetAccount); err != nil {
 			if err == db.ErrNoEntries {
-				l.Debugf("filterFavedStatuses: skipping status %s because account %s can't be found in the db", s.ID, s.AccountID)
+				l.WithFields(logrus.Fields{
+					"statusID":  s.ID,
+					"accountID": s.AccountID,
+				}).Debug("filterFavedStatuses: skipping status because account can't be found in the db")
 				continue
 			}
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("filterPublicStatuses: error getting status author: %s", err))
@@ -179,7 +248,7 @@ func (p *processor) filterFavedStatuses(ctx context.Context, authed *oauth.Auth,
 
 		timelineable, err := p.filter.StatusVisible(ctx, s, authed.Account)
 		if err != nil {
-			l.Debugf("filterFavedStatuses: skipping status %s because of an error checking status visibility: %s", s.ID, err)
+			l.WithError(err).WithField("statusID", s.ID).Debug("filterFavedStatuses: skipping status because of an error checking status visibility")
 			continue
 		}
 		if !timelineable {
@@ -188,7 +257,7 @@ func (p *processor) filterFavedStatuses(ctx context.Context, authed *oauth.Auth,
 
 		apiStatus, err := p.tc.StatusToMasto(ctx, s, authed.Account)
 		if err != nil {
-			l.Debugf("filterFavedStatuses: skipping status %s because it couldn't be converted to its mastodon representation: %s", s.ID, err)
+			l.WithError(err).WithField("statusID", s.ID).Debug("filterFavedStatuses: skipping status because it couldn't be converted to its mastodon representation")
 			continue
 		}
 
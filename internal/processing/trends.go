@@ -0,0 +1,259 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/trends"
+)
+
+// trendsUpdateInterval is how often to work out again what's trending.
+const trendsUpdateInterval = 15 * time.Minute
+
+func (p *processor) TrendingTagsGet(ctx context.Context, authed *oauth.Auth, limit int, offset int) ([]*apimodel.Tag, gtserror.WithCode) {
+	l := p.log.WithContext(ctx).WithField("func", "TrendingTagsGet")
+
+	mastoTags := []*apimodel.Tag{}
+	for _, t := range p.trends.Tags() {
+		if len(mastoTags) >= offset+limit {
+			break
+		}
+
+		tag, err := p.db.GetTagByID(ctx, t.TagID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				l.WithField("tagID", t.TagID).Debug("skipping trending tag that no longer exists")
+				continue
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// only tags that an admin has approved are shown in trends
+		if !tag.Trendable || !tag.Listable {
+			continue
+		}
+
+		mastoTag, err := p.tc.TagToMasto(ctx, tag)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		mastoTag.History = trendHistoryToMasto(t.History)
+
+		mastoTags = append(mastoTags, &mastoTag)
+	}
+
+	if offset >= len(mastoTags) {
+		return []*apimodel.Tag{}, nil
+	}
+	return mastoTags[offset:], nil
+}
+
+func (p *processor) TrendingStatusesGet(ctx context.Context, authed *oauth.Auth, limit int, offset int) ([]*apimodel.Status, gtserror.WithCode) {
+	l := p.log.WithContext(ctx).WithField("func", "TrendingStatusesGet")
+
+	mastoStatuses := []*apimodel.Status{}
+	for _, statusID := range p.trends.StatusIDs() {
+		if len(mastoStatuses) >= offset+limit {
+			break
+		}
+
+		status, err := p.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				l.WithField("statusID", statusID).Debug("skipping trending status that no longer exists")
+				continue
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		visible, err := p.filter.StatusPublictimelineable(ctx, status, authed.Account)
+		if err != nil {
+			l.WithError(err).WithField("statusID", statusID).Debug("skipping trending status because of an error checking its visibility")
+			continue
+		}
+		if !visible {
+			continue
+		}
+
+		mastoStatus, err := p.tc.StatusToMasto(ctx, status, authed.Account)
+		if err != nil {
+			l.WithError(err).WithField("statusID", statusID).Debug("skipping trending status because it couldn't be converted to its mastodon representation")
+			continue
+		}
+
+		mastoStatuses = append(mastoStatuses, p.filterStatuses(ctx, authed.Account, gtsmodel.FilterContextPublic, []*apimodel.Status{mastoStatus})...)
+	}
+
+	if offset >= len(mastoStatuses) {
+		return []*apimodel.Status{}, nil
+	}
+	return mastoStatuses[offset:], nil
+}
+
+func (p *processor) TrendingLinksGet(ctx context.Context, authed *oauth.Auth, limit int, offset int) ([]*apimodel.TrendsLink, gtserror.WithCode) {
+	mastoLinks := []*apimodel.TrendsLink{}
+	for _, link := range p.trends.Links() {
+		if len(mastoLinks) >= offset+limit {
+			break
+		}
+
+		u, err := url.Parse(link.URL)
+		if err != nil {
+			continue
+		}
+
+		mastoLinks = append(mastoLinks, &apimodel.TrendsLink{
+			Card: apimodel.Card{
+				URL:          link.URL,
+				Title:        link.URL,
+				Type:         "link",
+				ProviderName: u.Host,
+				ProviderURL:  fmt.Sprintf("%s://%s", u.Scheme, u.Host),
+			},
+			History: trendHistoryToMasto(link.History),
+		})
+	}
+
+	if offset >= len(mastoLinks) {
+		return []*apimodel.TrendsLink{}, nil
+	}
+	return mastoLinks[offset:], nil
+}
+
+func (p *processor) AdminTrendingTagsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AdminTag, gtserror.WithCode) {
+	l := p.log.WithContext(ctx).WithField("func", "AdminTrendingTagsGet")
+
+	adminTags := []*apimodel.AdminTag{}
+	for _, t := range p.trends.Tags() {
+		tag, err := p.db.GetTagByID(ctx, t.TagID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				l.WithField("tagID", t.TagID).Debug("skipping trending tag that no longer exists")
+				continue
+			}
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		adminTag, errWithCode := p.tagToAdminTag(ctx, tag)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		adminTags = append(adminTags, adminTag)
+	}
+
+	return adminTags, nil
+}
+
+func (p *processor) AdminTrendingTagApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminTag, gtserror.WithCode) {
+	return p.reviewTrendingTag(ctx, authed, id, true)
+}
+
+func (p *processor) AdminTrendingTagReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminTag, gtserror.WithCode) {
+	return p.reviewTrendingTag(ctx, authed, id, false)
+}
+
+// reviewTrendingTag records an admin's decision about whether the tag with the given ID may be shown in trends.
+func (p *processor) reviewTrendingTag(ctx context.Context, authed *oauth.Auth, id string, trendable bool) (*apimodel.AdminTag, gtserror.WithCode) {
+	tag, err := p.db.GetTagByID(ctx, id)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("tag %s not found", id))
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	tag.Trendable = trendable
+	tag.ReviewedAt = time.Now()
+	tag.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, tag); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	auditAction := gtsmodel.AdminAuditLogActionReject
+	if trendable {
+		auditAction = gtsmodel.AdminAuditLogActionApprove
+	}
+	p.adminAudit(ctx, authed.Account, auditAction, gtsmodel.AdminAuditLogTargetTag, tag.ID, "#"+tag.Name)
+
+	return p.tagToAdminTag(ctx, tag)
+}
+
+func (p *processor) tagToAdminTag(ctx context.Context, tag *gtsmodel.Tag) (*apimodel.AdminTag, gtserror.WithCode) {
+	mastoTag, err := p.tc.TagToMasto(ctx, tag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, t := range p.trends.Tags() {
+		if t.TagID == tag.ID {
+			mastoTag.History = trendHistoryToMasto(t.History)
+			break
+		}
+	}
+
+	return &apimodel.AdminTag{
+		Tag:            mastoTag,
+		ID:             tag.ID,
+		Trendable:      tag.Trendable,
+		Usable:         tag.Useable,
+		RequiresReview: tag.ReviewedAt.IsZero(),
+	}, nil
+}
+
+func trendHistoryToMasto(history []trends.Day) []apimodel.TagHistory {
+	mastoHistory := make([]apimodel.TagHistory, 0, len(history))
+	for _, d := range history {
+		mastoHistory = append(mastoHistory, apimodel.TagHistory{
+			Day:      strconv.FormatInt(d.Day.Unix(), 10),
+			Uses:     strconv.Itoa(d.Uses),
+			Accounts: strconv.Itoa(d.Accounts),
+		})
+	}
+	return mastoHistory
+}
+
+// updateTrends periodically works out what's trending, until the processor is stopped.
+func (p *processor) updateTrends(ctx context.Context) {
+	l := p.log.WithContext(ctx).WithField("func", "updateTrends")
+
+	ticker := time.NewTicker(trendsUpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.trends.Update(ctx); err != nil {
+			l.WithError(err).Error("error updating trends")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+	}
+}
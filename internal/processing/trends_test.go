@@ -0,0 +1,82 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type TrendsTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *TrendsTestSuite) TestReviewTrendingTag() {
+	ctx := context.Background()
+	authed := &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["admin_account"],
+		Account:     suite.testAccounts["admin_account"],
+	}
+	tagID := suite.testTags["welcome"].ID
+
+	tag, errWithCode := suite.processor.AdminTrendingTagApprove(ctx, authed, tagID)
+	suite.NoError(errWithCode)
+	suite.Equal("welcome", tag.Name)
+	suite.True(tag.Trendable)
+	suite.False(tag.RequiresReview)
+
+	tag, errWithCode = suite.processor.AdminTrendingTagReject(ctx, authed, tagID)
+	suite.NoError(errWithCode)
+	suite.False(tag.Trendable)
+	suite.False(tag.RequiresReview)
+
+	dbTag, err := suite.db.GetTagByID(ctx, tagID)
+	suite.NoError(err)
+	suite.False(dbTag.Trendable)
+	suite.False(dbTag.ReviewedAt.IsZero())
+
+	_, errWithCode = suite.processor.AdminTrendingTagApprove(ctx, authed, "01FRDXQ4C4B0M8KX6BD0F8ZZ0M")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TrendsTestSuite) TestNothingTrending() {
+	ctx := context.Background()
+	authed := suite.testAutheds["local_account_1"]
+
+	tags, errWithCode := suite.processor.TrendingTagsGet(ctx, authed, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(tags)
+
+	statuses, errWithCode := suite.processor.TrendingStatusesGet(ctx, authed, 20, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(statuses)
+
+	links, errWithCode := suite.processor.TrendingLinksGet(ctx, authed, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(links)
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, &TrendsTestSuite{})
+}
@@ -0,0 +1,113 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// confirmationTokenValidity is how long the link in a confirmation email keeps working.
+const confirmationTokenValidity = 7 * 24 * time.Hour
+
+func (p *processor) UserConfirmEmail(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		return nil, gtserror.NewErrorNotFound(errors.New("no confirmation token given"))
+	}
+
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "confirmation_token", Value: token}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err, "this confirmation link doesn't exist, or has already been used")
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if time.Since(user.ConfirmationSentAt) > confirmationTokenValidity {
+		return nil, gtserror.NewErrorForbidden(fmt.Errorf("confirmation token for user %s expired", user.ID), "this confirmation link has expired, please ask for a new one")
+	}
+
+	user.Email = user.UnconfirmedEmail
+	user.ConfirmedAt = time.Now()
+	user.ConfirmationToken = ""
+	user.ConfirmationSentAt = time.Time{}
+	user.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error updating user %s: %s", user.ID, err))
+	}
+
+	account, err := p.db.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting account %s: %s", user.AccountID, err))
+	}
+	user.Account = account
+
+	return user, nil
+}
+
+func (p *processor) UserConfirmationResend(ctx context.Context, authed *oauth.Auth) gtserror.WithCode {
+	user := authed.User
+	if user.UnconfirmedEmail == "" || user.UnconfirmedEmail == user.Email {
+		return gtserror.NewErrorBadRequest(fmt.Errorf("user %s has no email address to confirm", user.ID), "your email address is already confirmed")
+	}
+
+	if err := p.emailConfirm(ctx, user, authed.Account); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// revokeUserTokens deletes the oauth tokens of the given user, along with any push subscriptions made with them,
+// so that every app the user is signed in to has to sign in again. The token with access exceptAccess is kept, if set.
+func (p *processor) revokeUserTokens(ctx context.Context, userID string, exceptAccess string) error {
+	tokens, err := p.db.GetTokensForUserID(ctx, userID)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error getting tokens of user %s: %s", userID, err)
+	}
+
+	for _, t := range tokens {
+		if exceptAccess != "" && t.Access == exceptAccess {
+			continue
+		}
+		if err := p.revokeToken(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokeToken deletes the given oauth token along with any push subscription made with it.
+func (p *processor) revokeToken(ctx context.Context, token *gtsmodel.Token) error {
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "token_id", Value: token.ID}}, &gtsmodel.WebPushSubscription{}); err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("error deleting push subscription of token %s: %s", token.ID, err)
+	}
+	if err := p.db.DeleteByID(ctx, token.ID, &gtsmodel.Token{}); err != nil {
+		return fmt.Errorf("error revoking token %s: %s", token.ID, err)
+	}
+	return nil
+}
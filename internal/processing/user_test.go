@@ -0,0 +1,105 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type UserTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *UserTestSuite) TestConfirmEmail() {
+	ctx := context.Background()
+	testUser := suite.testUsers["unconfirmed_account"]
+
+	user, errWithCode := suite.processor.UserConfirmEmail(ctx, testUser.ConfirmationToken)
+	suite.NoError(errWithCode)
+	suite.Equal("weed_lord420@example.org", user.Email)
+	suite.Equal("weed_lord420", user.Account.Username)
+	suite.NotZero(user.ConfirmedAt)
+	suite.Empty(user.ConfirmationToken)
+
+	// the confirmation should have been stored
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetWhere(ctx, []db.Where{{Key: "id", Value: testUser.ID}}, dbUser)
+	suite.NoError(err)
+	suite.Equal("weed_lord420@example.org", dbUser.Email)
+	suite.NotZero(dbUser.ConfirmedAt)
+
+	// the token can only be used once
+	_, errWithCode = suite.processor.UserConfirmEmail(ctx, testUser.ConfirmationToken)
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *UserTestSuite) TestConfirmEmailExpired() {
+	ctx := context.Background()
+	testUser := suite.testUsers["unconfirmed_account"]
+
+	testUser.ConfirmationSentAt = time.Now().Add(-8 * 24 * time.Hour)
+	testUser.UpdatedAt = time.Now()
+	err := suite.db.UpdateByPrimaryKey(ctx, testUser)
+	suite.Require().NoError(err)
+
+	_, errWithCode := suite.processor.UserConfirmEmail(ctx, testUser.ConfirmationToken)
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *UserTestSuite) TestConfirmationResend() {
+	ctx := context.Background()
+	testUser := suite.testUsers["unconfirmed_account"]
+	oldToken := testUser.ConfirmationToken
+
+	errWithCode := suite.processor.UserConfirmationResend(ctx, &oauth.Auth{
+		User:    testUser,
+		Account: suite.testAccounts["unconfirmed_account"],
+	})
+	suite.NoError(errWithCode)
+
+	// the old link should have stopped working
+	dbUser := &gtsmodel.User{}
+	err := suite.db.GetWhere(ctx, []db.Where{{Key: "id", Value: testUser.ID}}, dbUser)
+	suite.NoError(err)
+	suite.NotEmpty(dbUser.ConfirmationToken)
+	suite.NotEqual(oldToken, dbUser.ConfirmationToken)
+}
+
+func (suite *UserTestSuite) TestConfirmationResendAlreadyConfirmed() {
+	errWithCode := suite.processor.UserConfirmationResend(context.Background(), &oauth.Auth{
+		User:    suite.testUsers["local_account_1"],
+		Account: suite.testAccounts["local_account_1"],
+	})
+	suite.Require().NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestUserTestSuite(t *testing.T) {
+	suite.Run(t, &UserTestSuite{})
+}
@@ -63,6 +63,11 @@ var (
 	// It returns just the string part of the hashtag, not the # symbol.
 	HashtagFinder = regexp.MustCompile(hashtagFinder)
 
+	hashtagName = fmt.Sprintf(`^[a-zA-Z0-9]{1,%d}$`, maximumHashtagLength)
+	// HashtagName validates the name of a hashtag, without the # symbol,
+	// such as one found on an incoming federated status.
+	HashtagName = regexp.MustCompile(hashtagName)
+
 	emojiShortcode = fmt.Sprintf(`\w{2,%d}`, maximumEmojiShortcodeLength)
 	// EmojiShortcode validates an emoji name.
 	EmojiShortcode = regexp.MustCompile(fmt.Sprintf("^%s$", emojiShortcode))
@@ -129,8 +134,16 @@ var (
 	// The regex can be played with here: https://regex101.com/r/G9zuxQ/1
 	StatusesPath = regexp.MustCompile(statusesPath)
 
+	statusesWebPath = fmt.Sprintf(`^/?@(%s)/%s/(%s)$`, usernameString, statuses, ulid)
+	// StatusesWebPath parses a path that validates and captures the username part and the ulid part
+	// from the web url of a status, eg /@example_username/statuses/01F7XT5JZW1WMVSW1KADS8PVDH
+	StatusesWebPath = regexp.MustCompile(statusesWebPath)
+
 	blockPath = fmt.Sprintf(`^/?%s/(%s)/%s/(%s)$`, users, usernameString, blocks, ulid)
 	// BlockPath parses a path that validates and captures the username part and the ulid part
 	// from eg /users/example_username/blocks/01F7XT5JZW1WMVSW1KADS8PVDH
 	BlockPath = regexp.MustCompile(blockPath)
+
+	// InstancePageSlug validates the slug of a static instance page, eg. privacy or code-of-conduct
+	InstancePageSlug = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
 )
@@ -0,0 +1,140 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package relme verifies links between web pages and profiles, using the rel="me" microformat.
+//
+// See http://microformats.org/wiki/rel-me
+package relme
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxPageSize is how much of a page to read when looking for rel="me" links.
+const maxPageSize = 1 << 20
+
+// Verifier checks whether web pages link back to a profile.
+type Verifier interface {
+	// Verify fetches the page at pageURL, and returns true if it contains an 'a' or 'link' element
+	// with rel="me" that points to one of the given profile URLs. An error is returned if the page
+	// couldn't be fetched or isn't html.
+	Verify(ctx context.Context, pageURL string, profileURLs ...string) (bool, error)
+}
+
+type verifier struct {
+	client *http.Client
+}
+
+// NewVerifier returns a new Verifier which uses the given http client to fetch pages.
+func NewVerifier(client *http.Client) Verifier {
+	return &verifier{
+		client: client,
+	}
+}
+
+func (v *verifier) Verify(ctx context.Context, pageURL string, profileURLs ...string) (bool, error) {
+	page, err := url.Parse(pageURL)
+	if err != nil || (page.Scheme != "http" && page.Scheme != "https") {
+		return false, fmt.Errorf("Verify: %s is not an http(s) url", pageURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page.String(), nil)
+	if err != nil {
+		return false, fmt.Errorf("Verify: error creating request for %s: %s", pageURL, err)
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("Verify: error fetching %s: %s", pageURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("Verify: fetching %s returned status %s", pageURL, resp.Status)
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err != nil || mediaType != "text/html" {
+		return false, fmt.Errorf("Verify: %s is not an html page", pageURL)
+	}
+
+	wanted := make(map[string]bool, len(profileURLs))
+	for _, u := range profileURLs {
+		wanted[normalize(u)] = true
+	}
+
+	tokenizer := html.NewTokenizer(io.LimitReader(resp.Body, maxPageSize))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// either the end of the page, or a page we can't read any further
+			return false, nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom != atom.A && token.DataAtom != atom.Link {
+				continue
+			}
+
+			var rel, href string
+			for _, attr := range token.Attr {
+				switch attr.Key {
+				case "rel":
+					rel = attr.Val
+				case "href":
+					href = attr.Val
+				}
+			}
+			if !isRelMe(rel) || href == "" {
+				continue
+			}
+
+			// hrefs can be relative to the page
+			target, err := page.Parse(href)
+			if err != nil {
+				continue
+			}
+			if wanted[normalize(target.String())] {
+				return true, nil
+			}
+		}
+	}
+}
+
+// isRelMe returns true if the given rel attribute contains the 'me' link type.
+func isRelMe(rel string) bool {
+	for _, linkType := range strings.Fields(strings.ToLower(rel)) {
+		if linkType == "me" {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize makes small differences between urls that point to the same place, like a trailing slash, go away.
+func normalize(u string) string {
+	return strings.TrimSuffix(strings.ToLower(u), "/")
+}
@@ -0,0 +1,85 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package relme_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/relme"
+)
+
+type VerifierTestSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	verifier relme.Verifier
+}
+
+func (suite *VerifierTestSuite) SetupTest() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/verified", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><link rel="stylesheet" href="/style.css"></head><body><a rel="nofollow ME" href="http://localhost:8080/@the_mighty_zork/">my fedi</a></body></html>`))
+	})
+	mux.HandleFunc("/unverified", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body><a href="http://localhost:8080/@the_mighty_zork">my fedi, but no rel=me</a><a rel="me" href="https://example.org/@someone_else">not me</a></body></html>`))
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"rel": "me"}`))
+	})
+	suite.server = httptest.NewServer(mux)
+	suite.verifier = relme.NewVerifier(suite.server.Client())
+}
+
+func (suite *VerifierTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *VerifierTestSuite) TestVerify() {
+	verified, err := suite.verifier.Verify(context.Background(), suite.server.URL+"/verified", "http://localhost:8080/users/the_mighty_zork", "http://localhost:8080/@the_mighty_zork")
+	suite.NoError(err)
+	suite.True(verified)
+}
+
+func (suite *VerifierTestSuite) TestVerifyNoLinkBack() {
+	verified, err := suite.verifier.Verify(context.Background(), suite.server.URL+"/unverified", "http://localhost:8080/users/the_mighty_zork", "http://localhost:8080/@the_mighty_zork")
+	suite.NoError(err)
+	suite.False(verified)
+}
+
+func (suite *VerifierTestSuite) TestVerifyNotHTML() {
+	verified, err := suite.verifier.Verify(context.Background(), suite.server.URL+"/json", "http://localhost:8080/@the_mighty_zork")
+	suite.Error(err)
+	suite.False(verified)
+}
+
+func (suite *VerifierTestSuite) TestVerifyNotFound() {
+	verified, err := suite.verifier.Verify(context.Background(), suite.server.URL+"/nothing_here", "http://localhost:8080/@the_mighty_zork")
+	suite.Error(err)
+	suite.False(verified)
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifierTestSuite))
+}
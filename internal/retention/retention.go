@@ -0,0 +1,139 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package retention removes cached remote content that nobody on this instance is interacting with,
+// so that the database and storage don't grow without bound. Nothing removed here is federated or
+// tombstoned, so if the content turns up again later, it'll just be dereferenced fresh.
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// PruneAccount removes the given remote account from the database, along with its statuses, faves,
+// mentions, and cached media, and returns the number of media attachments removed.
+//
+// Unlike a suspension, nothing is federated and no stub is left behind: if the account
+// turns up again later, it'll just be dereferenced fresh.
+func PruneAccount(ctx context.Context, dbConn db.DB, storage *kv.KVStore, account *gtsmodel.Account, log *logrus.Logger) (int, error) {
+	if account.Domain == "" {
+		return 0, errors.New("refusing to prune a local account")
+	}
+
+	attachments := []*gtsmodel.MediaAttachment{}
+	if err := dbConn.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &attachments); err != nil && err != db.ErrNoEntries {
+		return 0, fmt.Errorf("error getting media attachments: %s", err)
+	}
+
+	if err := removeAttachments(ctx, dbConn, storage, attachments, log); err != nil {
+		return 0, err
+	}
+
+	for _, w := range []struct {
+		key   string
+		model interface{}
+	}{
+		{"account_id", &[]*gtsmodel.Status{}},
+		{"boost_of_account_id", &[]*gtsmodel.Status{}},
+		{"origin_account_id", &[]*gtsmodel.Mention{}},
+		{"target_account_id", &[]*gtsmodel.Mention{}},
+		{"account_id", &[]*gtsmodel.StatusFave{}},
+	} {
+		if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: w.key, Value: account.ID}}, w.model); err != nil {
+			return 0, fmt.Errorf("error deleting %T by %s: %s", w.model, w.key, err)
+		}
+	}
+
+	if err := dbConn.DeleteByID(ctx, account.ID, &gtsmodel.Account{}); err != nil {
+		return 0, fmt.Errorf("error deleting account: %s", err)
+	}
+
+	return len(attachments), nil
+}
+
+// PruneStatus removes the given remote status from the database, along with its mentions, faves,
+// boosts, and cached media, and returns the number of media attachments removed.
+//
+// As with PruneAccount, nothing is federated and no tombstone is left behind, so the status can
+// be dereferenced again if it's needed later.
+func PruneStatus(ctx context.Context, dbConn db.DB, storage *kv.KVStore, status *gtsmodel.Status, log *logrus.Logger) (int, error) {
+	if status.Local {
+		return 0, errors.New("refusing to prune a local status")
+	}
+
+	attachments := []*gtsmodel.MediaAttachment{}
+	if err := dbConn.GetWhere(ctx, []db.Where{{Key: "status_id", Value: status.ID}}, &attachments); err != nil && err != db.ErrNoEntries {
+		return 0, fmt.Errorf("error getting media attachments: %s", err)
+	}
+
+	if err := removeAttachments(ctx, dbConn, storage, attachments, log); err != nil {
+		return 0, err
+	}
+
+	for _, w := range []struct {
+		key   string
+		model interface{}
+	}{
+		{"boost_of_id", &[]*gtsmodel.Status{}},
+		{"status_id", &[]*gtsmodel.Mention{}},
+		{"status_id", &[]*gtsmodel.StatusFave{}},
+		{"status_id", &[]*gtsmodel.StatusReaction{}},
+		{"status_id", &[]*gtsmodel.StatusEdit{}},
+		{"status_id", &[]*gtsmodel.StatusToTag{}},
+		{"status_id", &[]*gtsmodel.StatusToEmoji{}},
+	} {
+		if err := dbConn.DeleteWhere(ctx, []db.Where{{Key: w.key, Value: status.ID}}, w.model); err != nil {
+			return 0, fmt.Errorf("error deleting %T by %s: %s", w.model, w.key, err)
+		}
+	}
+
+	if err := dbConn.DeleteByID(ctx, status.ID, &gtsmodel.Status{}); err != nil {
+		return 0, fmt.Errorf("error deleting status: %s", err)
+	}
+
+	return len(attachments), nil
+}
+
+// removeAttachments removes the given media attachments from storage and the database.
+func removeAttachments(ctx context.Context, dbConn db.DB, storage *kv.KVStore, attachments []*gtsmodel.MediaAttachment, log *logrus.Logger) error {
+	for _, a := range attachments {
+		for _, path := range []string{a.File.Path, a.Thumbnail.Path} {
+			if path == "" {
+				continue
+			}
+			// media might not have been cached at all, so a missing file is fine
+			if has, err := storage.Has(path); err != nil || !has {
+				continue
+			}
+			if err := storage.Delete(path); err != nil {
+				log.WithError(err).WithField("path", path).Error("error removing file from storage")
+			}
+		}
+		if err := dbConn.DeleteByID(ctx, a.ID, &gtsmodel.MediaAttachment{}); err != nil {
+			return fmt.Errorf("error deleting media attachment %s: %s", a.ID, err)
+		}
+	}
+	return nil
+}
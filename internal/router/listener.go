@@ -0,0 +1,143 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// listenFDsStart is the first file descriptor passed to a process by systemd socket activation.
+// See https://www.freedesktop.org/software/systemd/man/sd_listen_fds.html
+const listenFDsStart = 3
+
+// unixPeerAddr is the address reported for clients that connect over a unix socket. They don't have an ip
+// address of their own, and without one gin can't work out the client ip at all, not even from the
+// X-Forwarded-For header, so every client would look the same to rate limits and throttles.
+// Anything that connects over a unix socket is running on this machine, so loopback is what it's given.
+var unixPeerAddr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+
+// unixListener wraps a unix socket listener so that its connections report unixPeerAddr as their remote address.
+type unixListener struct {
+	net.Listener
+}
+
+func (l *unixListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &unixConn{Conn: conn}, nil
+}
+
+type unixConn struct {
+	net.Conn
+}
+
+func (c *unixConn) RemoteAddr() net.Addr {
+	return unixPeerAddr
+}
+
+// isUnixListener returns true if l listens on a unix socket.
+func isUnixListener(l net.Listener) bool {
+	return l.Addr().Network() == "unix"
+}
+
+// trustedProxies returns the proxies whose forwarded headers should be trusted when working out client ips.
+// When listening on a unix socket, whatever's on the other end of it is a reverse proxy on this machine,
+// so its headers are trusted as well as those of the configured proxies.
+func trustedProxies(cfg *config.Config, l net.Listener) []string {
+	proxies := append([]string{}, cfg.TrustedProxies...)
+	if isUnixListener(l) {
+		proxies = append(proxies, unixPeerAddr.IP.String()+"/32")
+	}
+	return proxies
+}
+
+// newListener returns the listener that the server should accept connections on.
+//
+// If the process was started using systemd socket activation, the first inherited socket is used.
+// Otherwise, if a unix socket path is configured, a unix domain socket is created at that path.
+// If neither is the case, we just listen on the configured tcp port.
+//
+// Connections accepted over a unix socket report unixPeerAddr as their remote address.
+func newListener(cfg *config.Config) (net.Listener, error) {
+	l, err := systemdListener()
+	if err != nil {
+		return nil, err
+	}
+	if l != nil {
+		if isUnixListener(l) {
+			return &unixListener{Listener: l}, nil
+		}
+		return l, nil
+	}
+
+	if cfg.UnixSocket != "" {
+		// remove any stale socket left behind by a previous run, but don't clobber anything that isn't a socket
+		if info, err := os.Stat(cfg.UnixSocket); err == nil {
+			if info.Mode()&os.ModeSocket == 0 {
+				return nil, fmt.Errorf("unix socket path %s exists and is not a socket", cfg.UnixSocket)
+			}
+			if err := os.Remove(cfg.UnixSocket); err != nil {
+				return nil, fmt.Errorf("error removing stale unix socket %s: %s", cfg.UnixSocket, err)
+			}
+		}
+		l, err := net.Listen("unix", cfg.UnixSocket)
+		if err != nil {
+			return nil, err
+		}
+		return &unixListener{Listener: l}, nil
+	}
+
+	return net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
+}
+
+// systemdListener returns a listener for the first socket passed in by systemd socket activation,
+// or nil if the process wasn't started that way.
+func systemdListener() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, nil
+	}
+
+	// make sure these aren't inherited by any child processes
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	f := os.NewFile(uintptr(listenFDsStart), "LISTEN_FD_3")
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("error using socket passed in by systemd: %s", err)
+	}
+
+	// net.FileListener dups the file descriptor, so we can close our copy
+	f.Close()
+	return l, nil
+}
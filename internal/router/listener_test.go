@@ -0,0 +1,150 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type ListenerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ListenerTestSuite) TestUnixSocket() {
+	cfg := &config.Config{
+		UnixSocket: filepath.Join(suite.T().TempDir(), "gotosocial.sock"),
+	}
+
+	l, err := newListener(cfg)
+	suite.NoError(err)
+	suite.Equal("unix", l.Addr().Network())
+
+	conn, err := net.Dial("unix", cfg.UnixSocket)
+	suite.NoError(err)
+	conn.Close()
+	l.Close()
+}
+
+func (suite *ListenerTestSuite) TestUnixSocketStale() {
+	cfg := &config.Config{
+		UnixSocket: filepath.Join(suite.T().TempDir(), "gotosocial.sock"),
+	}
+
+	// leave a stale socket file behind
+	stale, err := net.Listen("unix", cfg.UnixSocket)
+	suite.NoError(err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	l, err := newListener(cfg)
+	suite.NoError(err)
+	l.Close()
+}
+
+func (suite *ListenerTestSuite) TestUnixSocketNotASocket() {
+	cfg := &config.Config{
+		UnixSocket: filepath.Join(suite.T().TempDir(), "gotosocial.sock"),
+	}
+	suite.NoError(os.WriteFile(cfg.UnixSocket, []byte("hello"), 0600))
+
+	_, err := newListener(cfg)
+	suite.EqualError(err, "unix socket path "+cfg.UnixSocket+" exists and is not a socket")
+}
+
+func (suite *ListenerTestSuite) TestUnixSocketClientIP() {
+	cfg := &config.Config{
+		UnixSocket:     filepath.Join(suite.T().TempDir(), "gotosocial.sock"),
+		TrustedProxies: []string{"172.20.0.1/32"},
+	}
+
+	l, err := newListener(cfg)
+	suite.NoError(err)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	suite.NoError(engine.SetTrustedProxies(trustedProxies(cfg, l)))
+	engine.GET("/ip", func(c *gin.Context) {
+		c.String(http.StatusOK, c.ClientIP())
+	})
+
+	srv := &http.Server{Handler: engine}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", cfg.UnixSocket)
+			},
+		},
+	}
+
+	get := func(forwardedFor string) string {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost/ip", nil)
+		suite.NoError(err)
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		resp, err := client.Do(req)
+		suite.NoError(err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		suite.NoError(err)
+		return string(b)
+	}
+
+	// the proxy on the other end of the socket is trusted to say who the client is
+	suite.Equal("203.0.113.7", get("203.0.113.7"))
+	suite.Equal("198.51.100.20", get("198.51.100.20"))
+
+	// and without a header, the client is the proxy itself
+	suite.Equal("127.0.0.1", get(""))
+}
+
+func (suite *ListenerTestSuite) TestTrustedProxies() {
+	cfg := &config.Config{
+		TrustedProxies: []string{"172.20.0.1/32"},
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.NoError(err)
+	defer l.Close()
+	suite.Equal([]string{"172.20.0.1/32"}, trustedProxies(cfg, l))
+
+	cfg.UnixSocket = filepath.Join(suite.T().TempDir(), "gotosocial.sock")
+	ul, err := newListener(cfg)
+	suite.NoError(err)
+	defer ul.Close()
+	suite.Equal([]string{"172.20.0.1/32", "127.0.0.1/32"}, trustedProxies(cfg, ul))
+	suite.Equal([]string{"172.20.0.1/32"}, cfg.TrustedProxies)
+}
+
+func TestListenerTestSuite(t *testing.T) {
+	suite.Run(t, &ListenerTestSuite{})
+}
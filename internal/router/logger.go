@@ -52,19 +52,26 @@ func loggerWithConfig(log *logrus.Logger) gin.HandlerFunc {
 				path = path + "?" + raw
 			}
 
-			l := log.WithFields(logrus.Fields{
+			l := log.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 				"latency":    latency,
 				"clientIP":   clientIP,
 				"userAgent":  userAgent,
 				"method":     method,
 				"statusCode": statusCode,
 				"path":       path,
+				"bytes":      bodySize,
 			})
 
-			if errorMessage == "" {
-				l.Infof("%s: wrote %d bytes in %v", http.StatusText(statusCode), bodySize, latency)
+			if errorMessage != "" {
+				l = l.WithField("error", errorMessage)
+			}
+
+			// only server errors are logged as errors, so that they reach any error reporting hooks;
+			// a client getting something wrong isn't something the admin needs to know about
+			if statusCode >= http.StatusInternalServerError {
+				l.Error(http.StatusText(statusCode))
 			} else {
-				l.Errorf("%s: %s", http.StatusText(statusCode), errorMessage)
+				l.Info(http.StatusText(statusCode))
 			}
 		}
 	}
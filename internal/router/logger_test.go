@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggerTestSuite) TestLevels() {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	recorder := &entryRecorder{}
+	logger.AddHook(recorder)
+
+	engine := gin.New()
+	engine.Use(loggerWithConfig(logger))
+	engine.GET("/ok", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/missing", func(c *gin.Context) {
+		c.Error(errors.New("no entry for ID 123"))
+		c.Status(http.StatusNotFound)
+	})
+	engine.GET("/broken", func(c *gin.Context) {
+		c.Error(errors.New("db error"))
+		c.Status(http.StatusInternalServerError)
+	})
+
+	for _, path := range []string{"/ok", "/missing", "/broken"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	suite.Len(recorder.entries, 3)
+
+	suite.Equal(logrus.InfoLevel, recorder.entries[0].Level)
+	suite.NotContains(recorder.entries[0].Data, "error")
+
+	// client errors are kept out of error reports, but the error is still logged
+	suite.Equal(logrus.InfoLevel, recorder.entries[1].Level)
+	suite.Equal("Error #01: no entry for ID 123\n", recorder.entries[1].Data["error"])
+
+	suite.Equal(logrus.ErrorLevel, recorder.entries[2].Level)
+	suite.Equal("Error #01: db error\n", recorder.entries[2].Data["error"])
+}
+
+func TestLoggerTestSuite(t *testing.T) {
+	suite.Run(t, new(LoggerTestSuite))
+}
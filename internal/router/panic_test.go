@@ -0,0 +1,80 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+// entryRecorder is a logrus hook that keeps hold of every entry logged.
+type entryRecorder struct {
+	entries []*logrus.Entry
+}
+
+func (r *entryRecorder) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *entryRecorder) Fire(entry *logrus.Entry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+type PanicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PanicTestSuite) TestReportPanics() {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	recorder := &entryRecorder{}
+	logger.AddHook(recorder)
+
+	engine := gin.New()
+	engine.Use(gin.RecoveryWithWriter(ioutil.Discard))
+	engine.Use(reportPanics(logger))
+	engine.GET("/boom", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	// gin's recovery still writes the response
+	suite.Equal(http.StatusInternalServerError, w.Code)
+
+	suite.Len(recorder.entries, 1)
+	entry := recorder.entries[0]
+	suite.Equal(logrus.ErrorLevel, entry.Level)
+	suite.Equal("recovered from panic while handling request", entry.Message)
+	suite.Equal("boom", entry.Data["panic"])
+	suite.Equal("/boom", entry.Data["path"])
+	suite.Contains(entry.Data["stack"], "panic_test.go")
+}
+
+func TestPanicTestSuite(t *testing.T) {
+	suite.Run(t, new(PanicTestSuite))
+}
@@ -0,0 +1,62 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// RequestIDHeader is the header used to accept and return the id of a request.
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDKey is the gin context key under which the request id is stored.
+const requestIDKey = "requestID"
+
+// requestIDRegex matches request ids we're willing to take from an incoming request header.
+var requestIDRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,128}$`)
+
+// requestID returns a handler that attaches an id to every request, by setting it on the
+// request context (see log.WithRequestID), the gin context, and the response headers.
+//
+// A well-formed id given in the X-Request-Id header of the request is reused, so that
+// requests can be traced through a reverse proxy; otherwise a new id is generated.
+func requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid := c.GetHeader(RequestIDHeader)
+		if !requestIDRegex.MatchString(rid) {
+			var err error
+			rid, err = id.NewRandomULID()
+			if err != nil {
+				// very unlikely, but don't fail the request just because we can't trace it
+				c.Next()
+				return
+			}
+		}
+
+		c.Request = c.Request.WithContext(log.WithRequestID(c.Request.Context(), rid))
+		c.Set(requestIDKey, rid)
+		c.Header(RequestIDHeader, rid)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+type RequestIDTestSuite struct {
+	suite.Suite
+}
+
+// serve runs a request through the requestID handler, returning the id seen
+// on the request context and the recorded response.
+func (suite *RequestIDTestSuite) serve(header string) (string, *httptest.ResponseRecorder) {
+	var seen string
+	engine := gin.New()
+	engine.Use(requestID())
+	engine.GET("/", func(c *gin.Context) {
+		seen = log.RequestID(c.Request.Context())
+		suite.Equal(seen, c.GetString(requestIDKey))
+		c.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		request.Header.Set(RequestIDHeader, header)
+	}
+	engine.ServeHTTP(recorder, request)
+	return seen, recorder
+}
+
+func (suite *RequestIDTestSuite) TestGenerated() {
+	seen, recorder := suite.serve("")
+	suite.Len(seen, 26)
+	suite.Equal(seen, recorder.Header().Get(RequestIDHeader))
+}
+
+func (suite *RequestIDTestSuite) TestIncoming() {
+	seen, recorder := suite.serve("proxy-1234.abc")
+	suite.Equal("proxy-1234.abc", seen)
+	suite.Equal("proxy-1234.abc", recorder.Header().Get(RequestIDHeader))
+}
+
+func (suite *RequestIDTestSuite) TestIncomingInvalid() {
+	seen, recorder := suite.serve("<script>alert(1)</script>")
+	suite.Len(seen, 26)
+	suite.Equal(seen, recorder.Header().Get(RequestIDHeader))
+}
+
+func TestRequestIDTestSuite(t *testing.T) {
+	suite.Run(t, &RequestIDTestSuite{})
+}
@@ -20,23 +20,23 @@ package router
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/acmedns"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"golang.org/x/crypto/acme/autocert"
 )
 
-var (
-	readTimeout       = 60 * time.Second
-	writeTimeout      = 30 * time.Second
-	idleTimeout       = 30 * time.Second
-	readHeaderTimeout = 30 * time.Second
-)
+// writeTimeoutMargin is how much longer than the longest request timeout the server allows for writing
+// a response, so that there's still time to tell the client when their request has timed out.
+const writeTimeoutMargin = 10 * time.Second
 
 // Router provides the REST interface for gotosocial, using gin.
 type Router interface {
@@ -59,8 +59,11 @@ type router struct {
 	logger      *logrus.Logger
 	engine      *gin.Engine
 	srv         *http.Server
+	listener    net.Listener
 	config      *config.Config
 	certManager *autocert.Manager
+	dnsManager  *acmedns.Manager
+	stopDNS     context.CancelFunc
 }
 
 // Add Gin StaticFile handler
@@ -68,27 +71,38 @@ func (r *router) AttachStaticFS(relativePath string, fs http.FileSystem) {
 	r.engine.StaticFS(relativePath, fs)
 }
 
-// Start starts the router nicely. It will serve two handlers if letsencrypt is enabled, and only the web/API handler if letsencrypt is not enabled.
+// Start starts the router nicely. It will serve two handlers if letsencrypt is enabled with the http-01 challenge, and only the web/API handler otherwise.
 func (r *router) Start() {
-	if r.config.LetsEncryptConfig.Enabled {
+	if r.dnsManager != nil {
+		// certificates are obtained over dns, so we only need to keep them renewed and serve the TLS handler
+		ctx, cancel := context.WithCancel(context.Background())
+		r.stopDNS = cancel
+		go r.dnsManager.Run(ctx)
+
+		go func() {
+			if err := r.srv.ServeTLS(r.listener, "", ""); err != nil && err != http.ErrServerClosed {
+				r.logger.WithError(err).Fatal("listen")
+			}
+		}()
+	} else if r.config.LetsEncryptConfig.Enabled {
 		// serve the http handler on the selected letsencrypt port, for receiving letsencrypt requests and solving their devious riddles
 		go func() {
 			if err := http.ListenAndServe(fmt.Sprintf(":%d", r.config.LetsEncryptConfig.Port), r.certManager.HTTPHandler(http.HandlerFunc(httpsRedirect))); err != nil && err != http.ErrServerClosed {
-				r.logger.Fatalf("listen: %s", err)
+				r.logger.WithError(err).Fatal("listen")
 			}
 		}()
 
 		// and serve the actual TLS handler
 		go func() {
-			if err := r.srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
-				r.logger.Fatalf("listen: %s", err)
+			if err := r.srv.ServeTLS(r.listener, "", ""); err != nil && err != http.ErrServerClosed {
+				r.logger.WithError(err).Fatal("listen")
 			}
 		}()
 	} else {
 		// no tls required
 		go func() {
-			if err := r.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-				r.logger.Fatalf("listen: %s", err)
+			if err := r.srv.Serve(r.listener); err != nil && err != http.ErrServerClosed {
+				r.logger.WithError(err).Fatal("listen")
 			}
 		}()
 	}
@@ -96,6 +110,9 @@ func (r *router) Start() {
 
 // Stop shuts down the router nicely
 func (r *router) Stop(ctx context.Context) error {
+	if r.stopDNS != nil {
+		r.stopDNS()
+	}
 	return r.srv.Shutdown(ctx)
 }
 
@@ -109,17 +126,14 @@ func New(ctx context.Context, cfg *config.Config, db db.DB, logger *logrus.Logge
 	// create the actual engine here -- this is the core request routing handler for gts
 	engine := gin.New()
 
+	engine.Use(requestID())
 	engine.Use(gin.RecoveryWithWriter(logger.Writer()))
+	engine.Use(reportPanics(logger))
 	engine.Use(loggerWithConfig(logger))
 
 	// 8 MiB
 	engine.MaxMultipartMemory = 8 << 20
 
-	// set up IP forwarding via x-forward-* headers.
-	if err := engine.SetTrustedProxies(cfg.TrustedProxies); err != nil {
-		return nil, err
-	}
-
 	// enable cors on the engine
 	if err := useCors(cfg, engine); err != nil {
 		return nil, err
@@ -142,17 +156,29 @@ func New(ctx context.Context, cfg *config.Config, db db.DB, logger *logrus.Logge
 	s := &http.Server{
 		Addr:              fmt.Sprintf(":%d", cfg.Port),
 		Handler:           engine,
-		ReadTimeout:       readTimeout,
-		WriteTimeout:      writeTimeout,
-		IdleTimeout:       idleTimeout,
-		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       time.Duration(cfg.RequestLimitsConfig.ReadTimeout) * time.Second,
+		WriteTimeout:      writeTimeout(cfg.RequestLimitsConfig),
+		IdleTimeout:       time.Duration(cfg.RequestLimitsConfig.IdleTimeout) * time.Second,
+		ReadHeaderTimeout: time.Duration(cfg.RequestLimitsConfig.ReadHeaderTimeout) * time.Second,
 	}
 
 	// We need to spawn the underlying server slightly differently depending on whether lets encrypt is enabled or not.
 	// In either case, the gin engine will still be used for routing requests.
 
 	var m *autocert.Manager
-	if cfg.LetsEncryptConfig.Enabled {
+	var dm *acmedns.Manager
+	if cfg.LetsEncryptConfig.Enabled && cfg.LetsEncryptConfig.Challenge == config.LetsEncryptChallengeDNS01 {
+		// le IS enabled with dns challenges, which autocert can't do, so use our own manager instead
+		var err error
+		dm, err = acmedns.NewManager(cfg, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error creating dns-01 certificate manager: %s", err)
+		}
+		s.TLSConfig = &tls.Config{
+			GetCertificate: dm.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		}
+	} else if cfg.LetsEncryptConfig.Enabled {
 		// le IS enabled, so roll up an autocert manager for handling letsencrypt requests
 		m = &autocert.Manager{
 			Prompt:     autocert.AcceptTOS,
@@ -163,12 +189,26 @@ func New(ctx context.Context, cfg *config.Config, db db.DB, logger *logrus.Logge
 		s.TLSConfig = m.TLSConfig()
 	}
 
+	// create the listener now rather than when starting, so that we can fail early if it's not usable
+	l, err := newListener(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating listener: %s", err)
+	}
+
+	// set up IP forwarding via x-forward-* headers, which depends on what we're listening on
+	if err := engine.SetTrustedProxies(trustedProxies(cfg, l)); err != nil {
+		l.Close()
+		return nil, err
+	}
+
 	return &router{
 		logger:      logger,
 		engine:      engine,
 		srv:         s,
+		listener:    l,
 		config:      cfg,
 		certManager: m,
+		dnsManager:  dm,
 	}, nil
 }
 
@@ -181,3 +221,19 @@ func httpsRedirect(w http.ResponseWriter, req *http.Request) {
 
 	http.Redirect(w, req, target, http.StatusTemporaryRedirect)
 }
+
+// writeTimeout returns how long the server allows for writing a response, which has
+// to be longer than any request is allowed to take. 0 means no limit.
+func writeTimeout(c *config.RequestLimitsConfig) time.Duration {
+	longest := 0
+	for _, t := range []int{c.Timeout, c.MediaTimeout, c.InboxTimeout} {
+		if t == 0 {
+			// some requests are allowed to take as long as they like
+			return 0
+		}
+		if t > longest {
+			longest = t
+		}
+	}
+	return time.Duration(longest)*time.Second + writeTimeoutMargin
+}
@@ -0,0 +1,179 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package spam
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+var (
+	anchorRegex = regexp.MustCompile(`(?is)<a\s[^>]*>.*?</a>`)
+	classRegex  = regexp.MustCompile(`(?i)class="[^"]*\b(mention|hashtag)\b[^"]*"`)
+	tagRegex    = regexp.MustCompile(`<[^>]*>`)
+)
+
+type firstContactMentionRule struct {
+	db    db.DB
+	score int
+}
+
+// NewFirstContactMentionRule returns a rule which scores a status for every local account that it mentions,
+// where the local account doesn't follow the author of the status, and the status isn't a reply to them.
+// This catches spammers who mention lots of people out of the blue.
+func NewFirstContactMentionRule(db db.DB, score int) Rule {
+	return &firstContactMentionRule{
+		db:    db,
+		score: score,
+	}
+}
+
+func (r *firstContactMentionRule) Score(ctx context.Context, status *gtsmodel.Status) (int, string, error) {
+	author, err := r.author(ctx, status)
+	if err != nil {
+		return 0, "", err
+	}
+
+	strangers := 0
+	for _, m := range status.Mentions {
+		target := m.TargetAccount
+		if target == nil {
+			a, err := r.db.GetAccountByID(ctx, m.TargetAccountID)
+			if err != nil {
+				return 0, "", fmt.Errorf("error getting mentioned account %s: %s", m.TargetAccountID, err)
+			}
+			target = a
+		}
+
+		if target.Domain != "" || target.ID == author.ID || target.ID == status.InReplyToAccountID {
+			// only first contact with local accounts counts, and replies are expected to mention the replied-to account
+			continue
+		}
+
+		follows, err := r.db.IsFollowing(ctx, target, author)
+		if err != nil {
+			return 0, "", fmt.Errorf("error checking follow from %s to %s: %s", target.ID, author.ID, err)
+		}
+		if !follows {
+			strangers++
+		}
+	}
+
+	if strangers == 0 {
+		return 0, "", nil
+	}
+	return strangers * r.score, fmt.Sprintf("mentions %d local account(s) that don't follow the author", strangers), nil
+}
+
+func (r *firstContactMentionRule) author(ctx context.Context, status *gtsmodel.Status) (*gtsmodel.Account, error) {
+	if status.Account != nil {
+		return status.Account, nil
+	}
+	author, err := r.db.GetAccountByID(ctx, status.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting status author %s: %s", status.AccountID, err)
+	}
+	return author, nil
+}
+
+type linkOnlyRule struct {
+	newAccountAge time.Duration
+	score         int
+	now           func() time.Time
+}
+
+// NewLinkOnlyRule returns a rule which scores statuses that contain links, but no text apart from links and
+// mentions, from accounts that were first seen less than newAccountHours ago. The author of the status must be
+// populated on the status.
+func NewLinkOnlyRule(newAccountHours int, score int) Rule {
+	return &linkOnlyRule{
+		newAccountAge: time.Duration(newAccountHours) * time.Hour,
+		score:         score,
+		now:           time.Now,
+	}
+}
+
+func (r *linkOnlyRule) Score(ctx context.Context, status *gtsmodel.Status) (int, string, error) {
+	if status.Account == nil || r.now().Sub(status.Account.CreatedAt) >= r.newAccountAge {
+		return 0, "", nil
+	}
+
+	links := 0
+	for _, a := range anchorRegex.FindAllString(status.Content, -1) {
+		if !classRegex.MatchString(a) {
+			links++
+		}
+	}
+	if links == 0 {
+		return 0, "", nil
+	}
+
+	text := tagRegex.ReplaceAllString(anchorRegex.ReplaceAllString(status.Content, ""), "")
+	if strings.TrimSpace(text) != "" {
+		return 0, "", nil
+	}
+
+	return r.score, "contains only links, and the author is a new account", nil
+}
+
+type keywordRule struct {
+	keywords []string
+	score    int
+}
+
+// NewKeywordRule returns a rule which scores a status for every one of the given keywords found in its
+// text or content warning. Keywords are matched case-insensitively.
+func NewKeywordRule(keywords []string, score int) Rule {
+	lower := make([]string, 0, len(keywords))
+	for _, k := range keywords {
+		if k = strings.TrimSpace(k); k != "" {
+			lower = append(lower, strings.ToLower(k))
+		}
+	}
+	return &keywordRule{
+		keywords: lower,
+		score:    score,
+	}
+}
+
+func (r *keywordRule) Score(ctx context.Context, status *gtsmodel.Status) (int, string, error) {
+	text := status.Text
+	if text == "" {
+		text = tagRegex.ReplaceAllString(status.Content, "")
+	}
+	text = strings.ToLower(status.ContentWarning + " " + text)
+
+	found := []string{}
+	for _, k := range r.keywords {
+		if strings.Contains(text, k) {
+			found = append(found, k)
+		}
+	}
+
+	if len(found) == 0 {
+		return 0, "", nil
+	}
+	return len(found) * r.score, fmt.Sprintf("contains spam keyword(s): %s", strings.Join(found, ", ")), nil
+}
@@ -0,0 +1,125 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package spam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Rule scores one aspect of an incoming status. The higher the score, the more likely the status is spam.
+type Rule interface {
+	// Score returns the score for the given status, along with a short human-readable reason
+	// for the score, which is shown to moderators. A score of 0 means the rule didn't match.
+	Score(ctx context.Context, status *gtsmodel.Status) (int, string, error)
+}
+
+// Verdict is the outcome of checking a status for spam.
+type Verdict struct {
+	// Score is the sum of the scores from every rule.
+	Score int
+	// Reasons contains the reason given by every rule that matched.
+	Reasons []string
+	// Action is what should be done with the status.
+	Action gtsmodel.SpamAction
+}
+
+// Filter checks incoming federated statuses for spam.
+type Filter interface {
+	// Check runs the status through every rule of the filter, and returns the combined verdict.
+	//
+	// The status should already have been enriched, ie., its account and mentions should be populated.
+	Check(ctx context.Context, status *gtsmodel.Status) (*Verdict, error)
+}
+
+type filter struct {
+	rules       []Rule
+	reportScore int
+	holdScore   int
+	dropScore   int
+}
+
+// New returns a new Filter using the rules and thresholds set in the given config.
+// If spam checks aren't enabled, then nil will be returned.
+func New(c *config.Config, db db.DB) Filter {
+	if c.SpamConfig == nil || !c.SpamConfig.Enabled {
+		return nil
+	}
+	return NewFilter(c.SpamConfig, DefaultRules(c.SpamConfig, db)...)
+}
+
+// NewFilter returns a new Filter which checks statuses against the given rules, and
+// decides what to do with them using the thresholds in the given config.
+func NewFilter(c *config.SpamConfig, rules ...Rule) Filter {
+	return &filter{
+		rules:       rules,
+		reportScore: c.ReportScore,
+		holdScore:   c.HoldScore,
+		dropScore:   c.DropScore,
+	}
+}
+
+// DefaultRules returns the built-in rules which are enabled in the given config.
+func DefaultRules(c *config.SpamConfig, db db.DB) []Rule {
+	rules := []Rule{}
+	if c.FirstContactMentionScore > 0 {
+		rules = append(rules, NewFirstContactMentionRule(db, c.FirstContactMentionScore))
+	}
+	if c.LinkOnlyScore > 0 {
+		rules = append(rules, NewLinkOnlyRule(c.NewAccountHours, c.LinkOnlyScore))
+	}
+	if len(c.Keywords) != 0 && c.KeywordScore > 0 {
+		rules = append(rules, NewKeywordRule(c.Keywords, c.KeywordScore))
+	}
+	return rules
+}
+
+func (f *filter) Check(ctx context.Context, status *gtsmodel.Status) (*Verdict, error) {
+	verdict := &Verdict{
+		Reasons: []string{},
+		Action:  gtsmodel.SpamActionNone,
+	}
+
+	for _, r := range f.rules {
+		score, reason, err := r.Score(ctx, status)
+		if err != nil {
+			return nil, fmt.Errorf("error checking status %s for spam: %s", status.ID, err)
+		}
+		if score == 0 {
+			continue
+		}
+		verdict.Score += score
+		verdict.Reasons = append(verdict.Reasons, reason)
+	}
+
+	switch {
+	case f.dropScore > 0 && verdict.Score >= f.dropScore:
+		verdict.Action = gtsmodel.SpamActionDrop
+	case f.holdScore > 0 && verdict.Score >= f.holdScore:
+		verdict.Action = gtsmodel.SpamActionHold
+	case f.reportScore > 0 && verdict.Score >= f.reportScore:
+		verdict.Action = gtsmodel.SpamActionReport
+	}
+
+	return verdict, nil
+}
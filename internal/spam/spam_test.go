@@ -0,0 +1,123 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package spam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type SpamTestSuite struct {
+	suite.Suite
+}
+
+type fixedRule int
+
+func (r fixedRule) Score(ctx context.Context, status *gtsmodel.Status) (int, string, error) {
+	return int(r), "fixed", nil
+}
+
+func (suite *SpamTestSuite) TestFilterThresholds() {
+	c := &config.SpamConfig{
+		ReportScore: 50,
+		HoldScore:   100,
+		DropScore:   200,
+	}
+
+	for score, action := range map[int]gtsmodel.SpamAction{
+		0:   gtsmodel.SpamActionNone,
+		49:  gtsmodel.SpamActionNone,
+		50:  gtsmodel.SpamActionReport,
+		100: gtsmodel.SpamActionHold,
+		250: gtsmodel.SpamActionDrop,
+	} {
+		// split the score over two rules to check that they're summed
+		verdict, err := NewFilter(c, fixedRule(score/2), fixedRule(score-score/2)).Check(context.Background(), &gtsmodel.Status{})
+		suite.NoError(err)
+		suite.Equal(score, verdict.Score)
+		suite.Equal(action, verdict.Action, "score %d", score)
+	}
+
+	// a threshold of 0 turns that action off
+	c.DropScore = 0
+	verdict, err := NewFilter(c, fixedRule(250)).Check(context.Background(), &gtsmodel.Status{})
+	suite.NoError(err)
+	suite.Equal(gtsmodel.SpamActionHold, verdict.Action)
+}
+
+func (suite *SpamTestSuite) TestLinkOnly() {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	r := NewLinkOnlyRule(24, 60).(*linkOnlyRule)
+	r.now = func() time.Time { return now }
+
+	newAccount := &gtsmodel.Account{CreatedAt: now.Add(-time.Hour)}
+	oldAccount := &gtsmodel.Account{CreatedAt: now.Add(-48 * time.Hour)}
+	linkOnly := `<p><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a> <a href="https://spam.example.org/buy" rel="nofollow">https://spam.example.org/buy</a></p>`
+
+	score, reason, err := r.Score(context.Background(), &gtsmodel.Status{Account: newAccount, Content: linkOnly})
+	suite.NoError(err)
+	suite.Equal(60, score)
+	suite.Equal("contains only links, and the author is a new account", reason)
+
+	// old accounts can post links all they like
+	score, _, err = r.Score(context.Background(), &gtsmodel.Status{Account: oldAccount, Content: linkOnly})
+	suite.NoError(err)
+	suite.Equal(0, score)
+
+	// some text means it's not link only
+	score, _, err = r.Score(context.Background(), &gtsmodel.Status{Account: newAccount, Content: `<p>look at this: <a href="https://example.org">https://example.org</a></p>`})
+	suite.NoError(err)
+	suite.Equal(0, score)
+
+	// mentions and hashtags don't count as links
+	score, _, err = r.Score(context.Background(), &gtsmodel.Status{Account: newAccount, Content: `<p><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@the_mighty_zork</a> <a href="http://localhost:8080/tags/hi" class="mention hashtag">#hi</a></p>`})
+	suite.NoError(err)
+	suite.Equal(0, score)
+}
+
+func (suite *SpamTestSuite) TestKeywords() {
+	r := NewKeywordRule([]string{"Cheap Followers", " crypto ", ""}, 100)
+
+	score, reason, err := r.Score(context.Background(), &gtsmodel.Status{Text: "get cheap followers and CRYPTO"})
+	suite.NoError(err)
+	suite.Equal(200, score)
+	suite.Equal("contains spam keyword(s): cheap followers, crypto", reason)
+
+	// content warnings and html content are checked too
+	score, _, err = r.Score(context.Background(), &gtsmodel.Status{ContentWarning: "crypto", Content: "<p>hello</p>"})
+	suite.NoError(err)
+	suite.Equal(100, score)
+
+	score, _, err = r.Score(context.Background(), &gtsmodel.Status{Text: "nothing to see here"})
+	suite.NoError(err)
+	suite.Equal(0, score)
+}
+
+func (suite *SpamTestSuite) TestNewDisabled() {
+	suite.Nil(New(config.TestDefault(), nil))
+}
+
+func TestSpamTestSuite(t *testing.T) {
+	suite.Run(t, &SpamTestSuite{})
+}
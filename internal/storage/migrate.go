@@ -0,0 +1,112 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/sirupsen/logrus"
+)
+
+// MigrateResult counts what happened to the keys seen during a migration.
+type MigrateResult struct {
+	// Copied is the number of keys copied and verified
+	Copied int `json:"copied"`
+	// Skipped is the number of keys that were already present with the same value in the destination
+	Skipped int `json:"skipped"`
+}
+
+// Migrate copies every key in from over to to, reading each value back out of to
+// afterwards to make sure it arrived intact.
+//
+// Keys that are already in to with an identical value are skipped, so an interrupted
+// migration can be resumed by just running it again. Nothing is removed from from.
+func Migrate(from *kv.KVStore, to *kv.KVStore, log *logrus.Logger) (*MigrateResult, error) {
+	keys, err := keys(from)
+	if err != nil {
+		return nil, fmt.Errorf("error listing keys to migrate: %s", err)
+	}
+
+	result := &MigrateResult{}
+	for i, key := range keys {
+		l := log.WithField("key", key)
+
+		value, err := from.Get(key)
+		if err != nil {
+			return result, fmt.Errorf("error reading %s: %s", key, err)
+		}
+
+		has, err := to.Has(key)
+		if err != nil {
+			return result, fmt.Errorf("error checking destination for %s: %s", key, err)
+		}
+		if has {
+			existing, err := to.Get(key)
+			if err != nil {
+				return result, fmt.Errorf("error reading %s from destination: %s", key, err)
+			}
+			if bytes.Equal(existing, value) {
+				l.Debug("already migrated, skipping")
+				result.Skipped++
+				continue
+			}
+			// a partial or stale copy from an earlier run, so replace it
+			if err := to.Delete(key); err != nil {
+				return result, fmt.Errorf("error removing stale %s from destination: %s", key, err)
+			}
+		}
+
+		if err := to.Put(key, value); err != nil {
+			return result, fmt.Errorf("error writing %s: %s", key, err)
+		}
+
+		written, err := to.Get(key)
+		if err != nil {
+			return result, fmt.Errorf("error reading back %s: %s", key, err)
+		}
+		if !bytes.Equal(written, value) {
+			return result, fmt.Errorf("verification of %s failed: value read back from destination does not match source", key)
+		}
+
+		result.Copied++
+		l.WithFields(logrus.Fields{
+			"key":   i + 1,
+			"total": len(keys),
+		}).Debug("migrated key")
+	}
+
+	return result, nil
+}
+
+// keys gathers up all the keys in st, so that the iterator's read lock isn't held while we copy.
+func keys(st *kv.KVStore) ([]string, error) {
+	iter, err := st.Iterator(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	keys := []string{}
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	return keys, nil
+}
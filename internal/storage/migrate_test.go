@@ -0,0 +1,80 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MigrateTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MigrateTestSuite) TestMigrate() {
+	from := testrig.NewTestStorage()
+	testrig.StandardStorageSetup(from, "../../testrig/media")
+	defer testrig.StandardStorageTeardown(from)
+
+	to := testrig.NewTestStorage()
+	defer testrig.StandardStorageTeardown(to)
+
+	// pretend an earlier run got as far as one key already
+	iter, err := from.Iterator(nil)
+	suite.NoError(err)
+	suite.True(iter.Next())
+	done := iter.Key()
+	iter.Release()
+	b, err := from.Get(done)
+	suite.NoError(err)
+	suite.NoError(to.Put(done, b))
+
+	result, err := storage.Migrate(from, to, logrus.New())
+	suite.NoError(err)
+	suite.Equal(1, result.Skipped)
+	suite.Greater(result.Copied, 0)
+
+	iter, err = from.Iterator(nil)
+	suite.NoError(err)
+	defer iter.Release()
+	count := 0
+	for iter.Next() {
+		expected, err := from.Get(iter.Key())
+		suite.NoError(err)
+		actual, err := to.Get(iter.Key())
+		suite.NoError(err)
+		suite.Equal(expected, actual)
+		count++
+	}
+	suite.Equal(count, result.Copied+result.Skipped)
+
+	// running it again shouldn't copy anything
+	result, err = storage.Migrate(from, to, logrus.New())
+	suite.NoError(err)
+	suite.Equal(0, result.Copied)
+	suite.Equal(count, result.Skipped)
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, &MigrateTestSuite{})
+}
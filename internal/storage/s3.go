@@ -0,0 +1,365 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.iim.gay/grufwub/go-store/storage"
+)
+
+const (
+	s3DateFormat    = "20060102T150405Z"
+	s3Service       = "s3"
+	s3SigningScheme = "AWS4-HMAC-SHA256"
+)
+
+// s3Storage implements storage.Storage on top of an s3-compatible object store.
+//
+// Requests are signed with AWS signature version 4, and the bucket is always
+// addressed path-style, so that the same code works for AWS, MinIO, etc.
+type s3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// s3Entry is a key found while walking a bucket.
+type s3Entry string
+
+func (e s3Entry) Key() string {
+	return string(e)
+}
+
+// s3ListResult is the subset of a ListObjectsV2 response that we care about.
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func newS3Storage(endpoint string, region string, bucket string, accessKey string, secretKey string, client *http.Client) (*s3Storage, error) {
+	if endpoint == "" || bucket == "" {
+		return nil, errors.New("s3 storage needs both an endpoint and a bucket")
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing s3 endpoint %s: %s", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("s3 endpoint %s must start with http:// or https://", endpoint)
+	}
+
+	return &s3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    client,
+		now:       time.Now,
+	}, nil
+}
+
+// Clean is a no-op: an object store has no empty folders to tidy up.
+func (s *s3Storage) Clean() error {
+	return nil
+}
+
+func (s *s3Storage) ReadBytes(key string) ([]byte, error) {
+	rc, err := s.ReadStream(key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func (s *s3Storage) ReadStream(key string) (io.ReadCloser, error) {
+	resp, err := s.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, storage.ErrNotFound
+	}
+	if err := s3StatusError(resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Storage) WriteBytes(key string, value []byte) error {
+	resp, err := s.do(http.MethodPut, key, nil, value)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return s3StatusError(resp)
+}
+
+// WriteStream reads all of r before uploading it, since a signed PUT needs to know
+// the hash of the payload up front.
+func (s *s3Storage) WriteStream(key string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return s.WriteBytes(key, b)
+}
+
+func (s *s3Storage) Stat(key string) (bool, error) {
+	resp, err := s.do(http.MethodHead, key, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err := s3StatusError(resp); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *s3Storage) Remove(key string) error {
+	resp, err := s.do(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return storage.ErrNotFound
+	}
+	return s3StatusError(resp)
+}
+
+func (s *s3Storage) WalkKeys(opts *storage.WalkKeysOptions) error {
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		resp, err := s.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return err
+		}
+		if err := s3StatusError(resp); err != nil {
+			return err
+		}
+
+		result := &s3ListResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error decoding bucket listing: %s", err)
+		}
+
+		for _, c := range result.Contents {
+			opts.WalkFn(s3Entry(c.Key))
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// do performs a signed request against key in the bucket; an empty key means the bucket itself.
+func (s *s3Storage) do(method string, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := s.objectURL(key)
+	u.RawQuery = s3Query(query)
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		req.Body = http.NoBody
+	}
+	req.ContentLength = int64(len(body))
+
+	s.sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error doing %s request for %s: %s", method, key, err)
+	}
+	return resp, nil
+}
+
+// sign adds an AWS signature version 4 Authorization header to req.
+func (s *s3Storage) sign(req *http.Request, body []byte) {
+	t := s.now().UTC()
+	date := t.Format(s3DateFormat)
+	day := date[:8]
+
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("x-amz-date", date)
+	req.Header.Set("x-amz-content-sha256", hex.EncodeToString(payloadHash[:]))
+
+	// canonical headers are host plus everything else we've set, lowercased and sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	canonicalHeaders := &strings.Builder{}
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := s.scope(day)
+	signature := s.signature(date, scope, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3SigningScheme, s.accessKey, scope, signedHeaders, signature))
+}
+
+// PresignGet returns a url at which key can be fetched without any further credentials until
+// expiry has passed, by putting an AWS signature version 4 in the query of the url.
+func (s *s3Storage) PresignGet(key string, expiry time.Duration) (string, error) {
+	if key == "" {
+		return "", errors.New("can't presign an empty key")
+	}
+	u := s.objectURL(key)
+	s.presign(u, expiry)
+	return u.String(), nil
+}
+
+// presign adds a signature for a GET request of u, valid for expiry, to the query of u.
+func (s *s3Storage) presign(u *url.URL, expiry time.Duration) {
+	t := s.now().UTC()
+	date := t.Format(s3DateFormat)
+	scope := s.scope(date[:8])
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", s3SigningScheme)
+	query.Set("X-Amz-Credential", s.accessKey+"/"+scope)
+	query.Set("X-Amz-Date", date)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	// the payload of a presigned request isn't known in advance, so it's left unsigned
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		s3Query(query),
+		"host:" + u.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(date, scope, canonicalRequest))
+	u.RawQuery = s3Query(query)
+}
+
+// objectURL returns the path-style url of key in the bucket; an empty key means the bucket itself.
+func (s *s3Storage) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + s.bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+	return &u
+}
+
+// scope returns the credential scope of signatures made on the given day, formatted as 20060102.
+func (s *s3Storage) scope(day string) string {
+	return day + "/" + s.region + "/" + s3Service + "/aws4_request"
+}
+
+// signature signs canonicalRequest with a key derived from the secret key for the day of date.
+func (s *s3Storage) signature(date string, scope string, canonicalRequest string) string {
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3SigningScheme,
+		date,
+		scope,
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.secretKey), date[:8])
+	key = s3HMAC(key, s.region)
+	key = s3HMAC(key, s3Service)
+	key = s3HMAC(key, "aws4_request")
+	return hex.EncodeToString(s3HMAC(key, stringToSign))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Query encodes query the way signature version 4 expects: sorted by key, with
+// spaces as %20 rather than +.
+func s3Query(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// s3StatusError returns an error including the response body if resp wasn't a success.
+func s3StatusError(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 request failed with status %s: %s", resp.Status, strings.TrimSpace(string(b)))
+}
@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package storage opens the media storage backends that gotosocial supports, and
+// moves stored media between them.
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+const (
+	// BackendLocal stores media on the local disk, under the storage base path.
+	BackendLocal = "local"
+	// BackendS3 stores media in an s3-compatible object store.
+	BackendS3 = "s3"
+)
+
+// New opens the given storage backend, using the settings from the storage section of c.
+func New(c *config.Config, backend string) (*kv.KVStore, error) {
+	switch backend {
+	case BackendLocal:
+		return kv.OpenFile(c.StorageConfig.BasePath, nil)
+	case BackendS3:
+		client, err := transport.NewClient(c)
+		if err != nil {
+			return nil, fmt.Errorf("error creating http client: %s", err)
+		}
+		s3, err := newS3Storage(c.StorageConfig.S3Endpoint, c.StorageConfig.S3Region, c.StorageConfig.S3Bucket, c.StorageConfig.S3AccessKey, c.StorageConfig.S3SecretKey, client)
+		if err != nil {
+			return nil, err
+		}
+		return kv.OpenStorage(s3)
+	default:
+		return nil, fmt.Errorf("storage backend %s not recognised, must be one of %s or %s", backend, BackendLocal, BackendS3)
+	}
+}
+
+// Presigner hands out urls at which stored media can be fetched straight from the storage backend.
+type Presigner interface {
+	// PresignGet returns a url at which key can be fetched without any further credentials until expiry has passed.
+	PresignGet(key string, expiry time.Duration) (string, error)
+}
+
+// NewPresigner returns a Presigner for the storage backend configured in c, or nil if media
+// shouldn't be served through presigned urls, ie., the backend isn't s3 or presigning is turned off.
+func NewPresigner(c *config.Config) (Presigner, error) {
+	if c.StorageConfig.Backend != BackendS3 || !c.StorageConfig.S3Presign {
+		return nil, nil
+	}
+
+	client, err := transport.NewClient(c)
+	if err != nil {
+		return nil, fmt.Errorf("error creating http client: %s", err)
+	}
+	s3, err := newS3Storage(c.StorageConfig.S3Endpoint, c.StorageConfig.S3Region, c.StorageConfig.S3Bucket, c.StorageConfig.S3AccessKey, c.StorageConfig.S3SecretKey, client)
+	if err != nil {
+		return nil, err
+	}
+	return s3, nil
+}
@@ -100,7 +100,7 @@ func (f *formatter) ReplaceMentions(ctx context.Context, in string, mentions []*
 				if menchie.TargetAccount == nil {
 					a, err := f.db.GetAccountByID(ctx, menchie.TargetAccountID)
 					if err != nil {
-						f.log.Errorf("error getting account with id %s from the db: %s", menchie.TargetAccountID, err)
+						f.log.WithContext(ctx).WithError(err).WithField("accountID", menchie.TargetAccountID).Error("error getting mentioned account from the db")
 						return match
 					}
 					menchie.TargetAccount = a
@@ -0,0 +1,139 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package text
+
+import (
+	"strings"
+	"unicode"
+
+	"mvdan.cc/xurls/v2"
+)
+
+// languageStopwords contains some of the most common short words of languages written in latin script.
+// Counting how many of these appear in a text is a crude but cheap way of telling those languages apart.
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "was", "were", "to", "of", "in", "that", "it", "this", "with", "for", "you", "have", "not", "be", "on", "but", "what", "they", "my", "just"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "sie", "es", "mit", "ein", "eine", "auf", "zu", "den", "dem", "auch", "sich", "wir", "aber", "wie", "noch", "für"},
+	"fr": {"le", "la", "les", "et", "est", "une", "des", "du", "je", "pas", "que", "qui", "dans", "pour", "sur", "avec", "ce", "il", "elle", "nous", "mais", "au", "ne"},
+	"es": {"el", "la", "los", "las", "y", "es", "que", "de", "en", "un", "una", "por", "para", "con", "no", "pero", "muy", "está", "como", "yo", "lo", "del", "se"},
+	"it": {"il", "lo", "la", "gli", "le", "e", "è", "che", "di", "un", "una", "per", "non", "sono", "con", "mi", "ma", "anche", "come", "del", "della", "questo"},
+	"nl": {"de", "het", "een", "en", "is", "van", "niet", "dat", "ik", "je", "op", "met", "zijn", "voor", "maar", "ook", "wat", "er", "naar", "dit", "wel"},
+	"pt": {"o", "a", "os", "as", "e", "é", "que", "de", "em", "um", "uma", "não", "para", "com", "por", "mas", "muito", "eu", "você", "do", "da", "isso"},
+}
+
+// DetectLanguage makes a best guess at the language of the given plain text, returning
+// an ISO 639-1 two-letter code. If the text is too short or ambiguous to make a
+// reasonable guess, an empty string is returned instead.
+//
+// Languages with their own script are recognized by the script alone, while a handful of
+// common languages written in latin script are told apart by counting common words.
+func DetectLanguage(in string) string {
+	// links don't tell us anything about the language, and neither do mentions, hashtags and emojis
+	if rxStrict, err := xurls.StrictMatchingScheme(schemes); err == nil {
+		in = rxStrict.ReplaceAllString(in, " ")
+	}
+
+	words := []string{}
+	for _, word := range strings.Fields(strings.ToLower(in)) {
+		if strings.HasPrefix(word, "@") || strings.HasPrefix(word, "#") || strings.HasPrefix(word, ":") {
+			continue
+		}
+		words = append(words, strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }))
+	}
+
+	scripts := map[*unicode.RangeTable]int{}
+	var letters int
+	for _, word := range words {
+		for _, r := range word {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			letters++
+			for _, script := range []*unicode.RangeTable{unicode.Latin, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul, unicode.Cyrillic, unicode.Arabic, unicode.Greek, unicode.Hebrew, unicode.Thai, unicode.Devanagari} {
+				if unicode.Is(script, r) {
+					scripts[script]++
+					break
+				}
+			}
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	// a script counts as dominant if more than half of the letters are written in it
+	dominant := func(count int) bool { return count*2 > letters }
+	kana := scripts[unicode.Hiragana] + scripts[unicode.Katakana]
+	switch {
+	case kana > 0 && dominant(kana+scripts[unicode.Han]):
+		return "ja"
+	case dominant(scripts[unicode.Han]):
+		return "zh"
+	case dominant(scripts[unicode.Hangul]):
+		return "ko"
+	case dominant(scripts[unicode.Cyrillic]):
+		if strings.ContainsAny(in, "іїєґІЇЄҐ") {
+			return "uk"
+		}
+		return "ru"
+	case dominant(scripts[unicode.Arabic]):
+		if strings.ContainsAny(in, "پچژگ") {
+			return "fa"
+		}
+		return "ar"
+	case dominant(scripts[unicode.Greek]):
+		return "el"
+	case dominant(scripts[unicode.Hebrew]):
+		return "he"
+	case dominant(scripts[unicode.Thai]):
+		return "th"
+	case dominant(scripts[unicode.Devanagari]):
+		return "hi"
+	case !dominant(scripts[unicode.Latin]):
+		return ""
+	}
+
+	scores := map[string]int{}
+	for _, word := range words {
+		for lang, stopwords := range languageStopwords {
+			for _, stopword := range stopwords {
+				if word == stopword {
+					scores[lang]++
+					break
+				}
+			}
+		}
+	}
+
+	// only go with the best scoring language if it's a clear winner
+	var best, runnerUp int
+	var bestLang string
+	for lang, score := range scores {
+		switch {
+		case score > best:
+			best, runnerUp, bestLang = score, best, lang
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if best < 2 || best == runnerUp {
+		return ""
+	}
+	return bestLang
+}
@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package text_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+type LanguageTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LanguageTestSuite) TestDetectLanguage() {
+	for _, test := range [][2]string{
+		{"hello @someone, this is a post that was written in english, with a link https://example.org/die/der/das", "en"},
+		{"Ich habe heute keine Zeit, aber morgen bin ich auf jeden Fall mit dabei und freue mich auch", "de"},
+		{"Je ne sais pas ce que je vais faire ce soir, mais il fait beau dans la ville", "fr"},
+		{"No tengo tiempo para eso, pero el sábado estoy libre y muy contento con la idea", "es"},
+		{"Ik weet niet wat ik vanavond ga doen, maar het is mooi weer", "nl"},
+		{"今日はとても良い天気ですね。", "ja"},
+		{"今天天气很好", "zh"},
+		{"오늘은 날씨가 정말 좋네요", "ko"},
+		{"Сегодня очень хорошая погода", "ru"},
+		{"Сьогодні дуже гарна погода, і я йду гуляти", "uk"},
+		{"Σήμερα ο καιρός είναι πολύ ωραίος", "el"},
+	} {
+		suite.Equal(test[1], text.DetectLanguage(test[0]), test[0])
+	}
+}
+
+func (suite *LanguageTestSuite) TestDetectLanguageUnsure() {
+	for _, in := range []string{
+		"",
+		"lol",
+		"🎉🎉🎉 #party :blobcat:",
+		"https://example.org/some/link",
+	} {
+		suite.Empty(text.DetectLanguage(in), in)
+	}
+}
+
+func TestLanguageTestSuite(t *testing.T) {
+	suite.Run(t, new(LanguageTestSuite))
+}
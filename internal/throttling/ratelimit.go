@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package throttling
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter limits how many requests each key, like an ip address or an oauth token, can make
+// in fixed windows of time. Unlike a Throttle's per-ip limit, requests aren't smoothed out:
+// a key can use its whole budget at once, and gets all of it back when the next window starts.
+//
+// A Limiter is safe for concurrent use.
+type Limiter struct {
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]int
+	now    func() time.Time
+}
+
+// NewLimiter returns a new Limiter that allows at most limit requests per key in each window.
+// A limit of 0 means no limit.
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:  limit,
+		window: window,
+		counts: make(map[string]int),
+		now:    time.Now,
+	}
+}
+
+// Limit returns how many requests each key can make per window, or 0 if there's no limit.
+func (l *Limiter) Limit() int {
+	return l.limit
+}
+
+// Take records a request from the given key, returning true if it's within the limit. It also returns
+// how many more requests the key can make in the current window, and when the next window starts.
+func (l *Limiter) Take(key string) (bool, int, time.Time) {
+	if l.limit <= 0 {
+		return true, 0, time.Time{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// every key gets a fresh budget in a new window, so there's no need to remember the old counts
+	now := l.now()
+	if start := now.Truncate(l.window); !start.Equal(l.start) {
+		l.start = start
+		l.counts = make(map[string]int)
+	}
+	reset := l.start.Add(l.window)
+
+	count := l.counts[key]
+	if count >= l.limit {
+		return false, 0, reset
+	}
+	l.counts[key] = count + 1
+	return true, l.limit - count - 1, reset
+}
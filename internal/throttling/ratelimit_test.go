@@ -0,0 +1,73 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LimiterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LimiterTestSuite) TestTake() {
+	now := time.Date(2021, 10, 1, 12, 1, 0, 0, time.UTC)
+	l := NewLimiter(3, 5*time.Minute)
+	l.now = func() time.Time { return now }
+
+	// the window is aligned to the clock, so it resets at 12:05
+	reset := time.Date(2021, 10, 1, 12, 5, 0, 0, time.UTC)
+	for remaining := 2; remaining >= 0; remaining-- {
+		ok, left, r := l.Take("127.0.0.1")
+		suite.True(ok)
+		suite.Equal(remaining, left)
+		suite.Equal(reset, r)
+	}
+
+	ok, left, _ := l.Take("127.0.0.1")
+	suite.False(ok)
+	suite.Equal(0, left)
+
+	// other keys have their own budget
+	ok, _, _ = l.Take("192.168.0.1")
+	suite.True(ok)
+
+	// the whole budget is back in the next window
+	now = reset
+	ok, left, r := l.Take("127.0.0.1")
+	suite.True(ok)
+	suite.Equal(2, left)
+	suite.Equal(reset.Add(5*time.Minute), r)
+	suite.Len(l.counts, 1)
+}
+
+func (suite *LimiterTestSuite) TestNoLimit() {
+	l := NewLimiter(0, 5*time.Minute)
+	for i := 0; i < 1000; i++ {
+		ok, _, _ := l.Take("127.0.0.1")
+		suite.True(ok)
+	}
+}
+
+func TestLimiterTestSuite(t *testing.T) {
+	suite.Run(t, &LimiterTestSuite{})
+}
@@ -0,0 +1,125 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package throttling provides limits for how often, and how many at once, requests of a certain kind can be handled.
+package throttling
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle per-ip buckets are cleaned out.
+const sweepInterval = time.Minute
+
+// Throttle limits both how many requests of one kind can be handled at once,
+// and how many requests of that kind one ip address can make per minute.
+//
+// A Throttle is safe for concurrent use.
+type Throttle struct {
+	slots chan struct{}
+	perIP int
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// bucket is a token bucket for one ip address.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// New returns a new Throttle that allows at most concurrency requests at once, and at most perIPPerMinute
+// requests per minute from any one ip address. A value of 0 for either means no limit of that kind.
+func New(concurrency int, perIPPerMinute int) *Throttle {
+	t := &Throttle{
+		perIP:   perIPPerMinute,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+	if concurrency > 0 {
+		t.slots = make(chan struct{}, concurrency)
+	}
+	return t
+}
+
+// Allow records a request from the given ip address, returning true if it's within the per-ip limit.
+// If it isn't, Allow returns false and how long the ip address should wait before trying again.
+func (t *Throttle) Allow(ip string) (bool, time.Duration) {
+	if t.perIP <= 0 {
+		return true, 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.sweep(now)
+
+	b, ok := t.buckets[ip]
+	if !ok {
+		b = &bucket{tokens: float64(t.perIP), last: now}
+		t.buckets[ip] = b
+	}
+
+	// refill at a steady rate of perIP tokens per minute, up to a max of perIP
+	perSecond := float64(t.perIP) / 60
+	b.tokens = math.Min(float64(t.perIP), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Acquire takes one of the concurrency slots, returning true and a function that must be called
+// to give the slot back when the request is done, or false if all slots are currently taken.
+func (t *Throttle) Acquire() (bool, func()) {
+	if t.slots == nil {
+		return true, func() {}
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+		return true, func() { <-t.slots }
+	default:
+		return false, nil
+	}
+}
+
+// sweep removes buckets that have been idle long enough to be full again, since they're
+// no different from a new bucket. It only does this once per sweepInterval. Must be called with mu held.
+func (t *Throttle) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < sweepInterval {
+		return
+	}
+	t.lastSweep = now
+
+	for ip, b := range t.buckets {
+		if now.Sub(b.last) >= time.Minute {
+			delete(t.buckets, ip)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ThrottlingTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ThrottlingTestSuite) TestAllowPerIP() {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	t := New(0, 60)
+	t.now = func() time.Time { return now }
+
+	// a full bucket lets a burst of 60 through...
+	for i := 0; i < 60; i++ {
+		ok, _ := t.Allow("127.0.0.1")
+		suite.True(ok)
+	}
+
+	// ...but not the 61st
+	ok, wait := t.Allow("127.0.0.1")
+	suite.False(ok)
+	suite.Equal(time.Second, wait)
+
+	// other ip addresses have their own bucket
+	ok, _ = t.Allow("192.168.0.1")
+	suite.True(ok)
+
+	// a token is back after a second at 60 per minute
+	now = now.Add(time.Second)
+	ok, _ = t.Allow("127.0.0.1")
+	suite.True(ok)
+	ok, _ = t.Allow("127.0.0.1")
+	suite.False(ok)
+}
+
+func (suite *ThrottlingTestSuite) TestSweep() {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	t := New(0, 10)
+	t.now = func() time.Time { return now }
+
+	t.Allow("127.0.0.1")
+	t.Allow("192.168.0.1")
+	suite.Len(t.buckets, 2)
+
+	now = now.Add(2 * time.Minute)
+	t.Allow("10.0.0.1")
+	suite.Len(t.buckets, 1)
+}
+
+func (suite *ThrottlingTestSuite) TestAcquire() {
+	t := New(2, 0)
+
+	ok, release1 := t.Acquire()
+	suite.True(ok)
+	ok, release2 := t.Acquire()
+	suite.True(ok)
+
+	ok, _ = t.Acquire()
+	suite.False(ok)
+
+	release1()
+	ok, release3 := t.Acquire()
+	suite.True(ok)
+
+	release2()
+	release3()
+}
+
+func (suite *ThrottlingTestSuite) TestNoLimits() {
+	t := New(0, 0)
+	for i := 0; i < 1000; i++ {
+		ok, _ := t.Allow("127.0.0.1")
+		suite.True(ok)
+		ok, _ = t.Acquire()
+		suite.True(ok)
+	}
+}
+
+func TestThrottlingTestSuite(t *testing.T) {
+	suite.Run(t, &ThrottlingTestSuite{})
+}
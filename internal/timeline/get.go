@@ -31,7 +31,7 @@ import (
 const retries = 5
 
 func (t *timeline) Get(ctx context.Context, amount int, maxID string, sinceID string, minID string, prepareNext bool) ([]*apimodel.Status, error) {
-	l := t.log.WithFields(logrus.Fields{
+	l := t.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "Get",
 		"accountID": t.accountID,
 		"amount":    amount,
@@ -56,7 +56,7 @@ func (t *timeline) Get(ctx context.Context, amount int, maxID string, sinceID st
 				go func() {
 					// use context.Background() because we don't want the query to abort when the request finishes
 					if err := t.prepareNextQuery(context.Background(), amount, nextMaxID, "", ""); err != nil {
-						l.Errorf("error preparing next query: %s", err)
+						l.WithError(err).Error("error preparing next query")
 					}
 				}()
 			}
@@ -76,7 +76,7 @@ func (t *timeline) Get(ctx context.Context, amount int, maxID string, sinceID st
 				go func() {
 					// use context.Background() because we don't want the query to abort when the request finishes
 					if err := t.prepareNextQuery(context.Background(), amount, nextMaxID, "", ""); err != nil {
-						l.Errorf("error preparing next query: %s", err)
+						l.WithError(err).Error("error preparing next query")
 					}
 				}()
 			}
@@ -137,7 +137,7 @@ func (t *timeline) GetXFromTop(ctx context.Context, amount int) ([]*apimodel.Sta
 }
 
 func (t *timeline) GetXBehindID(ctx context.Context, amount int, behindID string, attempts *int) ([]*apimodel.Status, error) {
-	l := t.log.WithFields(logrus.Fields{
+	l := t.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":     "GetXBehindID",
 		"amount":   amount,
 		"behindID": behindID,
@@ -185,15 +185,18 @@ findMarkLoop:
 			return nil, err
 		}
 		if oldestID == "" {
-			l.Tracef("oldestID is empty so we can't return behindID %s", behindID)
+			l.WithField("behindID", behindID).Trace("oldestID is empty so we can't return behindID")
 			return statuses, nil
 		}
 		if oldestID == behindID {
-			l.Tracef("given behindID %s is the same as oldestID %s so there's nothing to return behind it", behindID, oldestID)
+			l.WithFields(logrus.Fields{
+				"behindID": behindID,
+				"oldestID": oldestID,
+			}).Trace("given behindID is the same as oldestID so there's nothing to return behind it")
 			return statuses, nil
 		}
 		if *attempts > retries {
-			l.Tracef("exceeded retries looking for behindID %s", behindID)
+			l.WithField("behindID", behindID).Trace("exceeded retries looking for behindID")
 			return statuses, nil
 		}
 		l.Trace("trying GetXBehindID again")
@@ -51,7 +51,7 @@ func (t *timeline) IndexBefore(ctx context.Context, statusID string, include boo
 	i := 0
 grabloop:
 	for ; len(filtered) < amount && i < 5; i = i + 1 { // try the grabloop 5 times only
-		statuses, err := t.db.GetHomeTimeline(ctx, t.accountID, "", "", offsetStatus, amount, false)
+		statuses, err := t.getStatuses(ctx, "", "", offsetStatus, amount)
 		if err != nil {
 			if err == db.ErrNoEntries {
 				break grabloop // we just don't have enough statuses left in the db so index what we've got and then bail
@@ -60,7 +60,7 @@ grabloop:
 		}
 
 		for _, s := range statuses {
-			timelineable, err := t.filter.StatusHometimelineable(ctx, s, t.account)
+			timelineable, err := t.timelineable(ctx, s)
 			if err != nil {
 				continue
 			}
@@ -81,7 +81,7 @@ grabloop:
 }
 
 func (t *timeline) IndexBehind(ctx context.Context, statusID string, include bool, amount int) error {
-	l := t.log.WithFields(logrus.Fields{
+	l := t.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":    "IndexBehind",
 		"include": include,
 		"amount":  amount,
@@ -130,20 +130,23 @@ positionLoop:
 	i := 0
 grabloop:
 	for ; len(filtered) < amount && i < 5; i = i + 1 { // try the grabloop 5 times only
-		l.Tracef("entering grabloop; i is %d; len(filtered) is %d", i, len(filtered))
-		statuses, err := t.db.GetHomeTimeline(ctx, t.accountID, offsetStatus, "", "", amount, false)
+		l.WithFields(logrus.Fields{
+			"i":        i,
+			"filtered": len(filtered),
+		}).Trace("entering grabloop")
+		statuses, err := t.getStatuses(ctx, offsetStatus, "", "", amount)
 		if err != nil {
 			if err == db.ErrNoEntries {
 				break grabloop // we just don't have enough statuses left in the db so index what we've got and then bail
 			}
 			return fmt.Errorf("IndexBehind: error getting statuses from db: %s", err)
 		}
-		l.Tracef("got %d statuses", len(statuses))
+		l.WithField("statuses", len(statuses)).Trace("got statuses")
 
 		for _, s := range statuses {
-			timelineable, err := t.filter.StatusHometimelineable(ctx, s, t.account)
+			timelineable, err := t.timelineable(ctx, s)
 			if err != nil {
-				l.Tracef("status was not hometimelineable: %s", err)
+				l.WithError(err).Trace("status was not hometimelineable")
 				continue
 			}
 			if timelineable {
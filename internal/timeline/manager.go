@@ -67,6 +67,16 @@ type Manager interface {
 	// HomeTimeline returns limit n amount of entries from the home timeline of the given account ID, in descending chronological order.
 	// If maxID is provided, it will return entries from that maxID onwards, inclusive.
 	HomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*apimodel.Status, error)
+	// IngestAndPrepareIntoList takes one status and indexes it into the timeline of the given list, and then immediately prepares it for serving.
+	//
+	// It should already be established before calling this function that the status/post actually belongs in the list timeline!
+	IngestAndPrepareIntoList(ctx context.Context, status *gtsmodel.Status, listID string) (bool, error)
+	// ListTimeline returns limit n amount of entries from the timeline of the given list ID, in descending chronological order.
+	// If maxID is provided, it will return entries from that maxID onwards, inclusive.
+	ListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*apimodel.Status, error)
+	// RemoveListTimeline drops the timeline of the given list ID, eg., after the list was changed or deleted.
+	// If the list still exists, its timeline will be built again from the database the next time it's needed.
+	RemoveListTimeline(ctx context.Context, listID string)
 	// GetIndexedLength returns the amount of posts/statuses that have been *indexed* for the given account ID.
 	GetIndexedLength(ctx context.Context, timelineAccountID string) int
 	// GetDesiredIndexLength returns the amount of posts that we, ideally, index for each user.
@@ -79,14 +89,17 @@ type Manager interface {
 	Remove(ctx context.Context, timelineAccountID string, statusID string) (int, error)
 	// WipeStatusFromAllTimelines removes one status from the index and prepared posts of all timelines
 	WipeStatusFromAllTimelines(ctx context.Context, statusID string) error
-	// WipeStatusesFromAccountID removes all statuses by the given accountID from the timelineAccountID's timelines.
+	// WipeStatusesFromAccountID removes all statuses by the given accountID from the timelineAccountID's timelines, including its list timelines.
 	WipeStatusesFromAccountID(ctx context.Context, timelineAccountID string, accountID string) error
+	// RefreshStatusInAllTimelines prepares one status again in all timelines that have it prepared, eg., after it was edited.
+	RefreshStatusInAllTimelines(ctx context.Context, statusID string) error
 }
 
 // NewManager returns a new timeline manager with the given database, typeconverter, config, and log.
 func NewManager(db db.DB, tc typeutils.TypeConverter, config *config.Config, log *logrus.Logger) Manager {
 	return &manager{
 		accountTimelines: sync.Map{},
+		listTimelines:    sync.Map{},
 		db:               db,
 		tc:               tc,
 		config:           config,
@@ -96,6 +109,7 @@ func NewManager(db db.DB, tc typeutils.TypeConverter, config *config.Config, log
 
 type manager struct {
 	accountTimelines sync.Map
+	listTimelines    sync.Map
 	db               db.DB
 	tc               typeutils.TypeConverter
 	config           *config.Config
@@ -103,7 +117,7 @@ type manager struct {
 }
 
 func (m *manager) Ingest(ctx context.Context, status *gtsmodel.Status, timelineAccountID string) (bool, error) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "Ingest",
 		"timelineAccountID": timelineAccountID,
 		"statusID":          status.ID,
@@ -119,7 +133,7 @@ func (m *manager) Ingest(ctx context.Context, status *gtsmodel.Status, timelineA
 }
 
 func (m *manager) IngestAndPrepare(ctx context.Context, status *gtsmodel.Status, timelineAccountID string) (bool, error) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "IngestAndPrepare",
 		"timelineAccountID": timelineAccountID,
 		"statusID":          status.ID,
@@ -134,8 +148,24 @@ func (m *manager) IngestAndPrepare(ctx context.Context, status *gtsmodel.Status,
 	return t.IndexAndPrepareOne(ctx, status.CreatedAt, status.ID, status.BoostOfID, status.AccountID, status.BoostOfAccountID)
 }
 
+func (m *manager) IngestAndPrepareIntoList(ctx context.Context, status *gtsmodel.Status, listID string) (bool, error) {
+	l := m.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":     "IngestAndPrepareIntoList",
+		"listID":   listID,
+		"statusID": status.ID,
+	})
+
+	t, err := m.getOrCreateListTimeline(ctx, listID)
+	if err != nil {
+		return false, err
+	}
+
+	l.Trace("ingesting status")
+	return t.IndexAndPrepareOne(ctx, status.CreatedAt, status.ID, status.BoostOfID, status.AccountID, status.BoostOfAccountID)
+}
+
 func (m *manager) Remove(ctx context.Context, timelineAccountID string, statusID string) (int, error) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "Remove",
 		"timelineAccountID": timelineAccountID,
 		"statusID":          statusID,
@@ -151,7 +181,7 @@ func (m *manager) Remove(ctx context.Context, timelineAccountID string, statusID
 }
 
 func (m *manager) HomeTimeline(ctx context.Context, timelineAccountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*apimodel.Status, error) {
-	l := m.log.WithFields(logrus.Fields{
+	l := m.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "HomeTimelineGet",
 		"timelineAccountID": timelineAccountID,
 	})
@@ -163,11 +193,33 @@ func (m *manager) HomeTimeline(ctx context.Context, timelineAccountID string, ma
 
 	statuses, err := t.Get(ctx, limit, maxID, sinceID, minID, true)
 	if err != nil {
-		l.Errorf("error getting statuses: %s", err)
+		l.WithError(err).Error("error getting statuses")
+	}
+	return statuses, nil
+}
+
+func (m *manager) ListTimeline(ctx context.Context, listID string, maxID string, sinceID string, minID string, limit int) ([]*apimodel.Status, error) {
+	l := m.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":   "ListTimeline",
+		"listID": listID,
+	})
+
+	t, err := m.getOrCreateListTimeline(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := t.Get(ctx, limit, maxID, sinceID, minID, true)
+	if err != nil {
+		l.WithError(err).Error("error getting statuses")
 	}
 	return statuses, nil
 }
 
+func (m *manager) RemoveListTimeline(ctx context.Context, listID string) {
+	m.listTimelines.Delete(listID)
+}
+
 func (m *manager) GetIndexedLength(ctx context.Context, timelineAccountID string) int {
 	t, err := m.getOrCreateTimeline(ctx, timelineAccountID)
 	if err != nil {
@@ -201,17 +253,10 @@ func (m *manager) PrepareXFromTop(ctx context.Context, timelineAccountID string,
 
 func (m *manager) WipeStatusFromAllTimelines(ctx context.Context, statusID string) error {
 	errors := []string{}
-	m.accountTimelines.Range(func(k interface{}, i interface{}) bool {
-		t, ok := i.(Timeline)
-		if !ok {
-			panic("couldn't parse entry as Timeline, this should never happen so panic")
-		}
-
+	m.rangeTimelines(func(t Timeline) {
 		if _, err := t.Remove(ctx, statusID); err != nil {
 			errors = append(errors, err.Error())
 		}
-
-		return true
 	})
 
 	var err error
@@ -222,13 +267,52 @@ func (m *manager) WipeStatusFromAllTimelines(ctx context.Context, statusID strin
 	return err
 }
 
+func (m *manager) RefreshStatusInAllTimelines(ctx context.Context, statusID string) error {
+	errors := []string{}
+	m.rangeTimelines(func(t Timeline) {
+		if _, err := t.Refresh(ctx, statusID); err != nil {
+			errors = append(errors, err.Error())
+		}
+	})
+
+	var err error
+	if len(errors) > 0 {
+		err = fmt.Errorf("one or more errors refreshing status %s in all timelines: %s", statusID, strings.Join(errors, ";"))
+	}
+
+	return err
+}
+
 func (m *manager) WipeStatusesFromAccountID(ctx context.Context, timelineAccountID string, accountID string) error {
 	t, err := m.getOrCreateTimeline(ctx, timelineAccountID)
 	if err != nil {
 		return err
 	}
 
-	_, err = t.RemoveAllBy(ctx, accountID)
+	if _, err := t.RemoveAllBy(ctx, accountID); err != nil {
+		return err
+	}
+
+	errors := []string{}
+	m.listTimelines.Range(func(k interface{}, i interface{}) bool {
+		t, ok := i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+
+		if t.AccountID() == timelineAccountID {
+			if _, err := t.RemoveAllBy(ctx, accountID); err != nil {
+				errors = append(errors, err.Error())
+			}
+		}
+
+		return true
+	})
+
+	if len(errors) > 0 {
+		err = fmt.Errorf("one or more errors removing statuses by account %s from list timelines: %s", accountID, strings.Join(errors, ";"))
+	}
+
 	return err
 }
 
@@ -251,3 +335,39 @@ func (m *manager) getOrCreateTimeline(ctx context.Context, timelineAccountID str
 
 	return t, nil
 }
+
+func (m *manager) getOrCreateListTimeline(ctx context.Context, listID string) (Timeline, error) {
+	var t Timeline
+	i, ok := m.listTimelines.Load(listID)
+	if !ok {
+		var err error
+		t, err = NewListTimeline(ctx, listID, m.db, m.tc, m.log)
+		if err != nil {
+			return nil, err
+		}
+		m.listTimelines.Store(listID, t)
+	} else {
+		t, ok = i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+	}
+
+	return t, nil
+}
+
+// rangeTimelines calls f for every account and list timeline held by the manager.
+func (m *manager) rangeTimelines(f func(t Timeline)) {
+	rangeFunc := func(k interface{}, i interface{}) bool {
+		t, ok := i.(Timeline)
+		if !ok {
+			panic("couldn't parse entry as Timeline, this should never happen so panic")
+		}
+
+		f(t)
+		return true
+	}
+
+	m.accountTimelines.Range(rangeFunc)
+	m.listTimelines.Range(rangeFunc)
+}
@@ -21,6 +21,7 @@ package timeline_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -138,6 +139,40 @@ func (suite *ManagerTestSuite) TestManagerIntegration() {
 	suite.False(ingested) // should be false since it's a duplicate
 }
 
+func (suite *ManagerTestSuite) TestRefreshStatusInAllTimelines() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// trigger status preparation
+	err := suite.manager.PrepareXFromTop(ctx, testAccount.ID, 20)
+	suite.NoError(err)
+
+	// edit one of the prepared statuses underneath the timeline
+	status, err := suite.db.GetStatusByID(ctx, "01F8MH75CBF9JFX4ZAD54N0W0R")
+	suite.NoError(err)
+	previous, err := suite.tc.StatusToStatusEdit(ctx, status)
+	suite.NoError(err)
+	status.Content = "this status has been edited"
+	status.EditedAt = time.Now()
+	suite.NoError(suite.db.EditStatus(ctx, status, previous))
+
+	// the timeline should still serve the old version until it's refreshed
+	statuses, err := suite.manager.HomeTimeline(ctx, testAccount.ID, "", "", "", 20, false)
+	suite.NoError(err)
+	suite.NotEqual("this status has been edited", statuses[len(statuses)-1].Content)
+
+	err = suite.manager.RefreshStatusInAllTimelines(ctx, status.ID)
+	suite.NoError(err)
+
+	statuses, err = suite.manager.HomeTimeline(ctx, testAccount.ID, "", "", "", 20, false)
+	suite.NoError(err)
+	suite.Len(statuses, 13)
+	edited := statuses[len(statuses)-1]
+	suite.Equal(status.ID, edited.ID)
+	suite.Equal("this status has been edited", edited.Content)
+	suite.NotEmpty(edited.EditedAt)
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, new(ManagerTestSuite))
 }
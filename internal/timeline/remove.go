@@ -27,7 +27,7 @@ import (
 )
 
 func (t *timeline) Remove(ctx context.Context, statusID string) (int, error) {
-	l := t.log.WithFields(logrus.Fields{
+	l := t.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":            "Remove",
 		"accountTimeline": t.accountID,
 		"statusID":        statusID,
@@ -74,12 +74,12 @@ func (t *timeline) Remove(ctx context.Context, statusID string) (int, error) {
 		removed = removed + 1
 	}
 
-	l.Debugf("removed %d entries", removed)
+	l.WithField("removed", removed).Debug("removed entries")
 	return removed, nil
 }
 
 func (t *timeline) RemoveAllBy(ctx context.Context, accountID string) (int, error) {
-	l := t.log.WithFields(logrus.Fields{
+	l := t.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":            "RemoveAllBy",
 		"accountTimeline": t.accountID,
 		"accountID":       accountID,
@@ -126,6 +126,6 @@ func (t *timeline) RemoveAllBy(ctx context.Context, accountID string) (int, erro
 		removed = removed + 1
 	}
 
-	l.Debugf("removed %d entries", removed)
+	l.WithField("removed", removed).Debug("removed entries")
 	return removed, nil
 }
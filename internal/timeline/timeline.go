@@ -104,6 +104,9 @@ type Timeline interface {
 		INFO FUNCTIONS
 	*/
 
+	// AccountID returns the ID of the account that owns the timeline.
+	AccountID() string
+
 	// ActualPostIndexLength returns the actual length of the post index at this point in time.
 	PostIndexLength(ctx context.Context) int
 
@@ -123,6 +126,11 @@ type Timeline interface {
 	//
 	// The returned int indicates the amount of entries that were removed.
 	RemoveAllBy(ctx context.Context, accountID string) (int, error)
+	// Refresh prepares again any prepared posts of the given status, or of boosts of it, so that changes
+	// to the status (eg., an edit) are reflected in the timeline. Indexed posts are left as they are.
+	//
+	// The returned int indicates the amount of entries that were refreshed.
+	Refresh(ctx context.Context, statusID string) (int, error)
 }
 
 // timeline fulfils the Timeline interface
@@ -131,6 +139,7 @@ type timeline struct {
 	preparedPosts *preparedPosts
 	accountID     string
 	account       *gtsmodel.Account
+	list          *gtsmodel.List
 	db            db.DB
 	filter        visibility.Filter
 	tc            typeutils.TypeConverter
@@ -157,6 +166,46 @@ func NewTimeline(ctx context.Context, accountID string, db db.DB, typeConverter
 	}, nil
 }
 
+// NewListTimeline returns a new Timeline for the list with the given ID, which is owned by the account that owns the list.
+func NewListTimeline(ctx context.Context, listID string, db db.DB, typeConverter typeutils.TypeConverter, log *logrus.Logger) (Timeline, error) {
+	list, err := db.GetListByID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timeline{
+		postIndex:     &postIndex{},
+		preparedPosts: &preparedPosts{},
+		accountID:     list.AccountID,
+		account:       list.Account,
+		list:          list,
+		db:            db,
+		filter:        visibility.NewFilter(db, log),
+		tc:            typeConverter,
+		log:           log,
+	}, nil
+}
+
+// getStatuses gets statuses for this timeline from the database, newest first.
+func (t *timeline) getStatuses(ctx context.Context, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error) {
+	if t.list != nil {
+		return t.db.GetListTimeline(ctx, t.list.ID, maxID, sinceID, minID, limit)
+	}
+	return t.db.GetHomeTimeline(ctx, t.accountID, maxID, sinceID, minID, limit, false)
+}
+
+// timelineable returns true if the given status should be in this timeline.
+func (t *timeline) timelineable(ctx context.Context, s *gtsmodel.Status) (bool, error) {
+	if t.list != nil {
+		return t.filter.StatusListtimelineable(ctx, s, t.list)
+	}
+	return t.filter.StatusHometimelineable(ctx, s, t.account)
+}
+
+func (t *timeline) AccountID() string {
+	return t.accountID
+}
+
 func (t *timeline) Reset() error {
 	return nil
 }
@@ -115,6 +115,24 @@ func (i *importer) instanceDecode(e transmodel.Entry) (*transmodel.Instance, err
 	return inst, nil
 }
 
+func (i *importer) statusDecode(e transmodel.Entry) (*transmodel.Status, error) {
+	s := &transmodel.Status{}
+	if err := i.simpleDecode(e, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (i *importer) statusFaveDecode(e transmodel.Entry) (*transmodel.StatusFave, error) {
+	f := &transmodel.StatusFave{}
+	if err := i.simpleDecode(e, f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
 func (i *importer) userDecode(e transmodel.Entry) (*transmodel.User, error) {
 	u := &transmodel.User{}
 	if err := i.simpleDecode(e, u); err != nil {
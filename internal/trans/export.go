@@ -221,3 +221,60 @@ func (e *exporter) exportUsers(ctx context.Context, file *os.File) ([]*transmode
 
 	return users, nil
 }
+
+func (e *exporter) exportUser(ctx context.Context, accountID string, file *os.File) (*transmodel.User, error) {
+	user := &transmodel.User{}
+
+	if err := e.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: accountID}}, user); err != nil {
+		return nil, fmt.Errorf("exportUser: error selecting user for account %s: %s", accountID, err)
+	}
+
+	user.Type = transmodel.TransUser
+	if err := e.simpleEncode(ctx, file, user, user.ID); err != nil {
+		return nil, fmt.Errorf("exportUser: error encoding user: %s", err)
+	}
+
+	return user, nil
+}
+
+func (e *exporter) exportStatuses(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.Status, error) {
+	statuses := []*transmodel.Status{}
+
+	// only export statuses owned by each given account
+	for _, a := range accounts {
+		owned := []*transmodel.Status{}
+		if err := e.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, &owned); err != nil {
+			return nil, fmt.Errorf("exportStatuses: error selecting statuses owned by account %s: %s", a.ID, err)
+		}
+		for _, s := range owned {
+			s.Type = transmodel.TransStatus
+			if err := e.simpleEncode(ctx, file, s, s.ID); err != nil {
+				return nil, fmt.Errorf("exportStatuses: error encoding status owned by account %s: %s", a.ID, err)
+			}
+		}
+		statuses = append(statuses, owned...)
+	}
+
+	return statuses, nil
+}
+
+func (e *exporter) exportStatusFaves(ctx context.Context, accounts []*transmodel.Account, file *os.File) ([]*transmodel.StatusFave, error) {
+	faves := []*transmodel.StatusFave{}
+
+	// only export faves created by each given account
+	for _, a := range accounts {
+		owned := []*transmodel.StatusFave{}
+		if err := e.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, &owned); err != nil {
+			return nil, fmt.Errorf("exportStatusFaves: error selecting faves owned by account %s: %s", a.ID, err)
+		}
+		for _, f := range owned {
+			f.Type = transmodel.TransStatusFave
+			if err := e.simpleEncode(ctx, file, f, f.ID); err != nil {
+				return nil, fmt.Errorf("exportStatusFaves: error encoding fave owned by account %s: %s", a.ID, err)
+			}
+		}
+		faves = append(faves, owned...)
+	}
+
+	return faves, nil
+}
@@ -0,0 +1,109 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+// ExportAccount exports only the rows belonging to the local account with the given username: its account
+// and user, its follows and follow requests (and the accounts on the other end of them), its statuses, and its faves.
+//
+// This is useful for moving a single user from one instance to another.
+func (e *exporter) ExportAccount(ctx context.Context, path string, username string) error {
+	if path == "" {
+		return errors.New("ExportAccount: path empty")
+	}
+
+	if username == "" {
+		return errors.New("ExportAccount: username empty")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: couldn't export to %s: %s", path, err)
+	}
+
+	// export the local account with this username
+	accounts, err := e.exportAccounts(ctx, []db.Where{{Key: "username", Value: username}, {Key: "domain", Value: nil}}, file)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: error exporting account: %s", err)
+	}
+	if len(accounts) != 1 {
+		return fmt.Errorf("ExportAccount: no local account found with username %s", username)
+	}
+
+	// export the user belonging to the account
+	if _, err := e.exportUser(ctx, accounts[0].ID, file); err != nil {
+		return fmt.Errorf("ExportAccount: error exporting user: %s", err)
+	}
+
+	// export all follows that relate to the account
+	follows, err := e.exportFollows(ctx, accounts, file)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: error exporting follows: %s", err)
+	}
+
+	// for each follow, make sure we've written out the account on the other end of it
+	for _, follow := range follows {
+		for _, accountID := range []string{follow.AccountID, follow.TargetAccountID} {
+			if _, alreadyWritten := e.writtenIDs[accountID]; alreadyWritten {
+				continue
+			}
+			if _, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: accountID}}, file); err != nil {
+				return fmt.Errorf("ExportAccount: error exporting follow account: %s", err)
+			}
+		}
+	}
+
+	// export all follow requests that relate to the account
+	followRequests, err := e.exportFollowRequests(ctx, accounts, file)
+	if err != nil {
+		return fmt.Errorf("ExportAccount: error exporting follow requests: %s", err)
+	}
+
+	// for each follow request, make sure we've written out the account on the other end of it
+	for _, fr := range followRequests {
+		for _, accountID := range []string{fr.AccountID, fr.TargetAccountID} {
+			if _, alreadyWritten := e.writtenIDs[accountID]; alreadyWritten {
+				continue
+			}
+			if _, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: accountID}}, file); err != nil {
+				return fmt.Errorf("ExportAccount: error exporting follow request account: %s", err)
+			}
+		}
+	}
+
+	// export all statuses created by the account
+	if _, err := e.exportStatuses(ctx, accounts, file); err != nil {
+		return fmt.Errorf("ExportAccount: error exporting statuses: %s", err)
+	}
+
+	// export all faves created by the account
+	if _, err := e.exportStatusFaves(ctx, accounts, file); err != nil {
+		return fmt.Errorf("ExportAccount: error exporting faves: %s", err)
+	}
+
+	return neatClose(file)
+}
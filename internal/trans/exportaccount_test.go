@@ -0,0 +1,102 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trans_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ExportAccountTestSuite struct {
+	TransTestSuite
+}
+
+func (suite *ExportAccountTestSuite) TestExportAccountOK() {
+	ctx := context.Background()
+	testAccount := testrig.NewTestAccounts()["local_account_1"]
+
+	// use a temporary file path that will be cleaned when the test is closed
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db, suite.log)
+	err := exporter.ExportAccount(ctx, tempFilePath, testAccount.Username)
+	suite.NoError(err)
+
+	// count up the types of entry we exported
+	file, err := os.Open(tempFilePath)
+	suite.NoError(err)
+	defer file.Close()
+
+	types := map[transmodel.Type]int{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := transmodel.Entry{}
+		suite.NoError(json.Unmarshal(scanner.Bytes(), &entry))
+		types[transmodel.Type(entry[transmodel.TypeKey].(string))]++
+
+		// statuses and faves should only ever belong to the exported account
+		t := transmodel.Type(entry[transmodel.TypeKey].(string))
+		if t == transmodel.TransStatus || t == transmodel.TransStatusFave {
+			suite.Equal(testAccount.ID, entry["accountId"])
+		}
+	}
+
+	suite.Equal(1, types[transmodel.TransUser])
+	suite.NotZero(types[transmodel.TransAccount])
+	suite.NotZero(types[transmodel.TransFollow])
+	suite.NotZero(types[transmodel.TransStatus])
+	suite.NotZero(types[transmodel.TransStatusFave])
+	suite.Zero(types[transmodel.TransDomainBlock])
+	suite.Zero(types[transmodel.TransInstance])
+
+	// the export should import cleanly into an empty database
+	testrig.StandardDBTeardown(suite.db)
+	newDB := testrig.NewTestDB()
+	testrig.CreateTestTables(newDB)
+
+	importer := trans.NewImporter(newDB, suite.log)
+	suite.NoError(importer.Import(ctx, tempFilePath))
+
+	statuses := []*gtsmodel.Status{}
+	suite.NoError(newDB.GetAll(ctx, &statuses))
+	suite.Equal(types[transmodel.TransStatus], len(statuses))
+}
+
+func (suite *ExportAccountTestSuite) TestExportAccountNotFound() {
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db, suite.log)
+	err := exporter.ExportAccount(context.Background(), tempFilePath, "nobody_here")
+	suite.EqualError(err, "ExportAccount: no local account found with username nobody_here")
+}
+
+func TestExportAccountTestSuite(t *testing.T) {
+	suite.Run(t, &ExportAccountTestSuite{})
+}
@@ -28,6 +28,7 @@ import (
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
 	ExportMinimal(ctx context.Context, path string) error
+	ExportAccount(ctx context.Context, path string, username string) error
 }
 
 type exporter struct {
@@ -26,6 +26,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/sirupsen/logrus"
 	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
 )
 
@@ -47,7 +48,7 @@ func (i *importer) Import(ctx context.Context, path string) error {
 		err := decoder.Decode(&entry)
 		if err != nil {
 			if err == io.EOF {
-				i.log.Infof("Import: reached end of file")
+				i.log.WithContext(ctx).WithField("func", "Import").Info("reached end of file")
 				return neatClose(file)
 			}
 			return fmt.Errorf("Import: error decoding in readLoop: %s", err)
@@ -73,7 +74,7 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, account); err != nil {
 			return fmt.Errorf("inputEntry: error adding account to database: %s", err)
 		}
-		i.log.Infof("inputEntry: added account with id %s", account.ID)
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "account", "id": account.ID}).Info("added entry")
 		return nil
 	case transmodel.TransBlock:
 		block, err := i.blockDecode(entry)
@@ -83,7 +84,7 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, block); err != nil {
 			return fmt.Errorf("inputEntry: error adding block to database: %s", err)
 		}
-		i.log.Infof("inputEntry: added block with id %s", block.ID)
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "block", "id": block.ID}).Info("added entry")
 		return nil
 	case transmodel.TransDomainBlock:
 		block, err := i.domainBlockDecode(entry)
@@ -93,7 +94,7 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, block); err != nil {
 			return fmt.Errorf("inputEntry: error adding domain block to database: %s", err)
 		}
-		i.log.Infof("inputEntry: added domain block with id %s", block.ID)
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "domain block", "id": block.ID}).Info("added entry")
 		return nil
 	case transmodel.TransFollow:
 		follow, err := i.followDecode(entry)
@@ -103,7 +104,7 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, follow); err != nil {
 			return fmt.Errorf("inputEntry: error adding follow to database: %s", err)
 		}
-		i.log.Infof("inputEntry: added follow with id %s", follow.ID)
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "follow", "id": follow.ID}).Info("added entry")
 		return nil
 	case transmodel.TransFollowRequest:
 		fr, err := i.followRequestDecode(entry)
@@ -113,7 +114,7 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, fr); err != nil {
 			return fmt.Errorf("inputEntry: error adding follow request to database: %s", err)
 		}
-		i.log.Infof("inputEntry: added follow request with id %s", fr.ID)
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "follow request", "id": fr.ID}).Info("added entry")
 		return nil
 	case transmodel.TransInstance:
 		inst, err := i.instanceDecode(entry)
@@ -123,7 +124,27 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, inst); err != nil {
 			return fmt.Errorf("inputEntry: error adding instance to database: %s", err)
 		}
-		i.log.Infof("inputEntry: added instance with id %s", inst.ID)
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "instance", "id": inst.ID}).Info("added entry")
+		return nil
+	case transmodel.TransStatus:
+		status, err := i.statusDecode(entry)
+		if err != nil {
+			return fmt.Errorf("inputEntry: error decoding entry into status: %s", err)
+		}
+		if err := i.putInDB(ctx, status); err != nil {
+			return fmt.Errorf("inputEntry: error adding status to database: %s", err)
+		}
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "status", "id": status.ID}).Info("added entry")
+		return nil
+	case transmodel.TransStatusFave:
+		fave, err := i.statusFaveDecode(entry)
+		if err != nil {
+			return fmt.Errorf("inputEntry: error decoding entry into status fave: %s", err)
+		}
+		if err := i.putInDB(ctx, fave); err != nil {
+			return fmt.Errorf("inputEntry: error adding status fave to database: %s", err)
+		}
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "status fave", "id": fave.ID}).Info("added entry")
 		return nil
 	case transmodel.TransUser:
 		user, err := i.userDecode(entry)
@@ -133,11 +154,11 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		if err := i.putInDB(ctx, user); err != nil {
 			return fmt.Errorf("inputEntry: error adding user to database: %s", err)
 		}
-		i.log.Infof("inputEntry: added user with id %s", user.ID)
+		i.log.WithContext(ctx).WithFields(logrus.Fields{"func": "inputEntry", "type": "user", "id": user.ID}).Info("added entry")
 		return nil
 	}
 
-	i.log.Errorf("inputEntry: didn't recognize transtype '%s', skipping it", t)
+	i.log.WithContext(ctx).WithField("func", "inputEntry").WithField("type", t).Error("didn't recognize transtype, skipping it")
 	return nil
 }
 
@@ -0,0 +1,50 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trans
+
+import "time"
+
+// Status represents a status as serialized in an exported file.
+type Status struct {
+	Type                     Type       `json:"type" bun:"-"`
+	ID                       string     `json:"id" bun:",nullzero"`
+	CreatedAt                *time.Time `json:"createdAt" bun:",nullzero"`
+	URI                      string     `json:"uri" bun:",nullzero"`
+	URL                      string     `json:"url,omitempty" bun:",nullzero"`
+	Content                  string     `json:"content,omitempty"`
+	Text                     string     `json:"text,omitempty"`
+	Local                    bool       `json:"local"`
+	AccountID                string     `json:"accountId" bun:",nullzero"`
+	AccountURI               string     `json:"accountUri" bun:",nullzero"`
+	InReplyToID              string     `json:"inReplyToId,omitempty" bun:",nullzero"`
+	InReplyToURI             string     `json:"inReplyToUri,omitempty" bun:",nullzero"`
+	InReplyToAccountID       string     `json:"inReplyToAccountId,omitempty" bun:",nullzero"`
+	BoostOfID                string     `json:"boostOfId,omitempty" bun:",nullzero"`
+	BoostOfAccountID         string     `json:"boostOfAccountId,omitempty" bun:",nullzero"`
+	ContentWarning           string     `json:"contentWarning,omitempty" bun:",nullzero"`
+	Visibility               string     `json:"visibility" bun:",nullzero"`
+	Sensitive                bool       `json:"sensitive"`
+	Language                 string     `json:"language,omitempty" bun:",nullzero"`
+	CreatedWithApplicationID string     `json:"createdWithApplicationId,omitempty" bun:",nullzero"`
+	ActivityStreamsType      string     `json:"activityStreamsType" bun:",nullzero"`
+	Federated                bool       `json:"federated"`
+	Boostable                bool       `json:"boostable"`
+	Replyable                bool       `json:"replyable"`
+	Likeable                 bool       `json:"likeable"`
+}
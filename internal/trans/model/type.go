@@ -33,6 +33,8 @@ const (
 	TransFollow           Type = "follow"
 	TransFollowRequest    Type = "followRequest"
 	TransInstance         Type = "instance"
+	TransStatus           Type = "status"
+	TransStatusFave       Type = "statusFave"
 	TransUser             Type = "user"
 )
 
@@ -0,0 +1,72 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-fed/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+// ErrDomainNotAllowed is returned when a request would be made to a domain that isn't
+// allowed to federate with this instance, because the instance is in allowlist mode.
+var ErrDomainNotAllowed = errors.New("domain is not allowed to federate with this instance")
+
+// allowlistClient wraps an http client so that, when the instance is in allowlist federation mode,
+// requests are only made to our own host and to domains that have been explicitly allowed.
+type allowlistClient struct {
+	client pub.HttpClient
+	config *config.Config
+	db     db.DB
+}
+
+// withAllowlist wraps the given client in an allowlistClient if the instance is in allowlist federation mode,
+// or just returns the client as it is otherwise.
+func withAllowlist(c *config.Config, db db.DB, client pub.HttpClient) pub.HttpClient {
+	if c.FederationConfig.Mode != config.FederationModeAllowlist {
+		return client
+	}
+
+	return &allowlistClient{
+		client: client,
+		config: c,
+		db:     db,
+	}
+}
+
+func (a *allowlistClient) Do(req *http.Request) (*http.Response, error) {
+	if strings.EqualFold(req.URL.Host, a.config.Host) {
+		return a.client.Do(req)
+	}
+
+	allowed, err := a.db.IsDomainAllowed(req.Context(), req.URL.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("error checking domain allow for %s: %s", req.URL.Hostname(), err)
+	}
+	if !allowed {
+		return nil, fmt.Errorf("request to %s not made: %w", req.URL.String(), ErrDomainNotAllowed)
+	}
+
+	return a.client.Do(req)
+}
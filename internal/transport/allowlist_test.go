@@ -0,0 +1,83 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AllowlistTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *AllowlistTestSuite) TestDeliverInAllowlistMode() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	payload := []byte(`{"type":"Create"}`)
+
+	requested := []string{}
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Host)
+		return testrig.NewMockHTTPClient(nil).Do(req)
+	})
+
+	suite.config.FederationConfig.Mode = config.FederationModeAllowlist
+	tc := transport.NewController(suite.config, suite.db, &federation.Clock{}, client, suite.log)
+	t, err := tc.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	to, err := url.Parse("http://fossbros-anonymous.io/users/foss_satan/inbox")
+	suite.NoError(err)
+
+	// the domain isn't allowed yet, so no request should be made and nothing queued for redelivery
+	err = t.Deliver(ctx, payload, to)
+	suite.ErrorIs(err, transport.ErrDomainNotAllowed)
+	suite.Empty(requested)
+
+	failedDeliveries := []*gtsmodel.FailedDelivery{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: "fossbros-anonymous.io"}}, &failedDeliveries)
+	suite.NoError(err)
+	suite.Empty(failedDeliveries)
+
+	// allow the domain and try again
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.DomainAllow{
+		ID:                 "01FQN8P4V3C6G1WF5YX2HZE1KT",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	err = t.Deliver(ctx, payload, to)
+	suite.NoError(err)
+	suite.Equal([]string{"fossbros-anonymous.io"}, requested)
+}
+
+func TestAllowlistTestSuite(t *testing.T) {
+	suite.Run(t, new(AllowlistTestSuite))
+}
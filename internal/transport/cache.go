@@ -0,0 +1,177 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ctxKey int
+
+const revalidateKey ctxKey = iota
+
+// WithRevalidate returns a copy of ctx that makes Dereference check back with the remote even if it
+// has a cached copy of the document that's still within the ttl, for when the caller has reason to
+// believe the document has changed. The remote can still answer that it hasn't.
+func WithRevalidate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey, true)
+}
+
+// revalidate returns true if ctx was returned from WithRevalidate.
+func revalidate(ctx context.Context) bool {
+	r, _ := ctx.Value(revalidateKey).(bool)
+	return r
+}
+
+// fetchCache holds actors and collections fetched from remote instances, so that the same followers,
+// following or outbox endpoint isn't dereferenced over and over again. It is shared by all the transports
+// of a controller, but entries are keyed by the key that signed the fetch as well as the IRI, because a
+// remote may well serve different things to different requesters.
+//
+// Within the ttl, a cached document is used as-is. After that, it is revalidated with the remote using the
+// ETag and Last-Modified headers it was served with, and only downloaded again if it has changed.
+type fetchCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// oldest fetch at the front, most recent at the back
+	order *list.List
+}
+
+type fetchCacheEntry struct {
+	key          string
+	body         []byte
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+}
+
+// newFetchCache returns a new fetch cache, or nil if maxEntries is 0 and so caching is disabled.
+func newFetchCache(ttl time.Duration, maxEntries int) *fetchCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+	return &fetchCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns a copy of the cached entry for the given key, or nil if there isn't one.
+func (c *fetchCache) get(key string) *fetchCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := *elem.Value.(*fetchCacheEntry)
+	return &entry
+}
+
+// fresh returns true if the given entry is still within the ttl, so it can be used without revalidating.
+func (c *fetchCache) fresh(entry *fetchCacheEntry, now time.Time) bool {
+	return now.Sub(entry.fetchedAt) < c.ttl
+}
+
+// put stores the given body under the given key, along with the validators from the response it came in,
+// dropping the entry that was fetched longest ago if the cache is full.
+func (c *fetchCache) put(key string, body []byte, header http.Header, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+
+	for c.order.Len() >= c.maxEntries {
+		oldest := c.order.Front()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*fetchCacheEntry).key)
+	}
+
+	c.entries[key] = c.order.PushBack(&fetchCacheEntry{
+		key:          key,
+		body:         body,
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		fetchedAt:    now,
+	})
+}
+
+// revalidated marks the entry under the given key as fetched at now, because the remote has
+// told us it hasn't changed.
+func (c *fetchCache) revalidated(key string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	elem.Value.(*fetchCacheEntry).fetchedAt = now
+	c.order.MoveToBack(elem)
+}
+
+// cacheable returns true if the given response body and header are for an actor or collection that
+// the remote hasn't asked us not to store.
+func cacheable(body []byte, header http.Header) bool {
+	if strings.Contains(strings.ToLower(header.Get("Cache-Control")), "no-store") {
+		return false
+	}
+
+	doc := struct {
+		Type interface{} `json:"type"`
+	}{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return false
+	}
+
+	// type can be a single string or an array of them
+	var types []interface{}
+	switch t := doc.Type.(type) {
+	case string:
+		types = []interface{}{t}
+	case []interface{}:
+		types = t
+	}
+
+	for _, t := range types {
+		switch t {
+		case ap.ActorApplication, ap.ActorGroup, ap.ActorOrganization, ap.ActorPerson, ap.ActorService,
+			ap.ObjectCollection, ap.ObjectCollectionPage, ap.ObjectOrderedCollection, ap.ObjectOrderedCollectionPage:
+			return true
+		}
+	}
+	return false
+}
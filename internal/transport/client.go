@@ -0,0 +1,385 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// NewClient returns an http client for making federation requests with.
+//
+// Requests to Tor onion services and I2P sites are sent through the socks5 proxies set in the hidden services
+// config. If there's no proxy set for one of these, requests to it fail straight away, rather than leaking the
+// hidden service address by trying to look it up in dns.
+//
+// Other requests are sent according to the outbound proxy config: a per-domain override takes precedence,
+// then the no proxy list, then the configured proxy url. If there's no proxy url configured, the usual
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used instead.
+func NewClient(c *config.Config) (*http.Client, error) {
+	torProxy, err := socks5URL(c.HiddenServicesConfig.TorProxy)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing tor proxy: %s", err)
+	}
+
+	i2pProxy, err := socks5URL(c.HiddenServicesConfig.I2PProxy)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing i2p proxy: %s", err)
+	}
+
+	rules, err := newProxyRules(c.OutboundProxyConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing outbound proxy config: %s", err)
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = func(r *http.Request) (*url.URL, error) {
+		switch {
+		case util.IsOnionHost(r.URL.Host):
+			if torProxy == nil {
+				return nil, errors.New("no tor proxy is configured, so onion services can't be reached")
+			}
+			return torProxy, nil
+		case util.IsI2PHost(r.URL.Host):
+			if i2pProxy == nil {
+				return nil, errors.New("no i2p proxy is configured, so i2p sites can't be reached")
+			}
+			return i2pProxy, nil
+		default:
+			return rules.proxy(r)
+		}
+	}
+
+	return &http.Client{Transport: t}, nil
+}
+
+// NewPublicClient returns an http client like the one from NewClient, with the given timeout, for fetching urls
+// that users have given us, like the links in their profile fields.
+//
+// It refuses to connect to loopback, private, link-local and other addresses that aren't publicly routable,
+// including when it's redirected to them, so it can't be used to make requests into the network that the
+// instance runs in. Connections to the configured proxies are still allowed, since they're set by the admin.
+func NewPublicClient(c *config.Config, timeout time.Duration) (*http.Client, error) {
+	client, err := NewClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	proxies, err := proxyAddresses(c)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	publicDialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialPublicOnly}
+
+	t := client.Transport.(*http.Transport)
+	t.DialContext = func(ctx context.Context, network string, address string) (net.Conn, error) {
+		if proxies[address] {
+			return dialer.DialContext(ctx, network, address)
+		}
+		// the address has been resolved by the time Control is called, so this also catches
+		// host names that resolve to non-public addresses
+		return publicDialer.DialContext(ctx, network, address)
+	}
+
+	client.Timeout = timeout
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+			return fmt.Errorf("refusing to follow redirect to %s: not an http(s) url", req.URL)
+		}
+		if ip := net.ParseIP(req.URL.Hostname()); ip != nil && !isPublicIP(ip) {
+			return fmt.Errorf("refusing to follow redirect to %s: not a public address", req.URL)
+		}
+		return nil
+	}
+
+	return client, nil
+}
+
+// dialPublicOnly is a net.Dialer Control func that refuses connections to anything but public addresses.
+func dialPublicOnly(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("refusing to connect to %s: not a public address", host)
+	}
+	return nil
+}
+
+// nonPublicNetworks are reserved ranges that aren't covered by the net.IP helper funcs.
+var nonPublicNetworks = func() []*net.IPNet {
+	cidrs := []string{
+		"0.0.0.0/8",       // "this" network
+		"100.64.0.0/10",   // carrier-grade nat
+		"192.0.0.0/24",    // ietf protocol assignments
+		"192.0.2.0/24",    // documentation
+		"198.18.0.0/15",   // benchmarking
+		"198.51.100.0/24", // documentation
+		"203.0.113.0/24",  // documentation
+		"240.0.0.0/4",     // reserved, and broadcast
+		"64:ff9b:1::/48",  // local-use ipv4/ipv6 translation
+		"100::/64",        // discard-only
+		"2001:db8::/32",   // documentation
+	}
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+// isPublicIP returns true if the given ip is a publicly routable unicast address.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPublicHost returns an error if host is an ip address that isn't publicly routable, or a hostname that
+// resolves to one. It's for checking urls that users give us before we store them to make requests to later.
+//
+// Hostnames that can't be resolved right now are let through, since the client from NewPublicClient
+// checks the addresses that it actually connects to anyway.
+func CheckPublicHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicIP(ip) {
+			return fmt.Errorf("%s is not a public address", host)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return fmt.Errorf("%s resolves to %s, which is not a public address", host, addr.IP)
+		}
+	}
+	return nil
+}
+
+// proxyAddresses returns the host:port addresses of all the proxies that the client from NewClient might connect to.
+func proxyAddresses(c *config.Config) (map[string]bool, error) {
+	proxies := []*url.URL{}
+
+	for _, address := range []string{c.HiddenServicesConfig.TorProxy, c.HiddenServicesConfig.I2PProxy} {
+		u, err := socks5URL(address)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, u)
+	}
+
+	rules, err := newProxyRules(c.OutboundProxyConfig)
+	if err != nil {
+		return nil, err
+	}
+	proxies = append(proxies, rules.defaultProxy)
+	for _, u := range rules.domains {
+		proxies = append(proxies, u)
+	}
+
+	// without a proxy url configured, the environment is used instead
+	for _, env := range []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy"} {
+		if v := os.Getenv(env); v != "" {
+			if u, err := url.Parse(v); err == nil {
+				proxies = append(proxies, u)
+			}
+		}
+	}
+
+	addresses := make(map[string]bool, len(proxies))
+	for _, u := range proxies {
+		if u == nil || u.Hostname() == "" {
+			continue
+		}
+		port := u.Port()
+		if port == "" {
+			switch u.Scheme {
+			case "https":
+				port = "443"
+			case "socks5":
+				port = "1080"
+			default:
+				port = "80"
+			}
+		}
+		addresses[net.JoinHostPort(u.Hostname(), port)] = true
+	}
+
+	return addresses, nil
+}
+
+// proxyRules decides which proxy, if any, to send a request for a regular host through.
+type proxyRules struct {
+	// proxy to use when nothing else matches, or nil to fall back to the environment
+	defaultProxy *url.URL
+	// domains to connect to directly
+	noProxy []string
+	// domains mapped to the proxy to use for them, or to nil to connect directly
+	domains map[string]*url.URL
+}
+
+func newProxyRules(c *config.OutboundProxyConfig) (*proxyRules, error) {
+	rules := &proxyRules{
+		domains: make(map[string]*url.URL),
+	}
+
+	if c.URL != "" {
+		u, err := parseProxyURL(c.URL)
+		if err != nil {
+			return nil, err
+		}
+		rules.defaultProxy = u
+	}
+
+	for _, d := range c.NoProxy {
+		rules.noProxy = append(rules.noProxy, normalizeDomain(d))
+	}
+
+	for _, d := range c.Domains {
+		domain, proxy, err := parseProxyDomain(d)
+		if err != nil {
+			return nil, err
+		}
+		rules.domains[domain] = proxy
+	}
+
+	return rules, nil
+}
+
+func (p *proxyRules) proxy(r *http.Request) (*url.URL, error) {
+	host := normalizeDomain(r.URL.Hostname())
+
+	// the most specific override wins, so check the host itself first, then each parent domain in turn
+	for d := host; d != ""; {
+		if proxy, ok := p.domains[d]; ok {
+			return proxy, nil
+		}
+		i := strings.Index(d, ".")
+		if i == -1 {
+			break
+		}
+		d = d[i+1:]
+	}
+
+	for _, d := range p.noProxy {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return nil, nil
+		}
+	}
+
+	if p.defaultProxy != nil {
+		return p.defaultProxy, nil
+	}
+
+	return http.ProxyFromEnvironment(r)
+}
+
+// parseProxyURL parses the given proxy url, checking that it's one that the http client knows how to use.
+func parseProxyURL(rawurl string) (*url.URL, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, fmt.Errorf("%s isn't a valid url: %s", rawurl, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" {
+		return nil, fmt.Errorf("%s must use one of http, https or socks5", rawurl)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%s has no host", rawurl)
+	}
+
+	return u, nil
+}
+
+// parseProxyDomain parses a per-domain proxy override in the form 'domain=proxy url' or 'domain=direct',
+// returning the domain and the proxy url to use for it, which is nil for direct connections.
+func parseProxyDomain(override string) (string, *url.URL, error) {
+	i := strings.Index(override, "=")
+	if i == -1 {
+		return "", nil, fmt.Errorf("%s must be in the form 'domain=proxy url' or 'domain=direct'", override)
+	}
+
+	domain := normalizeDomain(override[:i])
+	if domain == "" {
+		return "", nil, fmt.Errorf("%s has no domain", override)
+	}
+
+	target := strings.TrimSpace(override[i+1:])
+	if target == "direct" {
+		return domain, nil, nil
+	}
+
+	proxy, err := parseProxyURL(target)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return domain, proxy, nil
+}
+
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// socks5URL turns the given host:port address into a socks5 proxy url, or returns nil if the address is empty.
+// Go's socks5 client leaves it to the proxy to resolve host names, which is what hidden services need.
+func socks5URL(address string) (*url.URL, error) {
+	if address == "" {
+		return nil, nil
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("%s isn't a valid host:port address: %s", address, err)
+	}
+
+	return &url.URL{Scheme: "socks5", Host: address}, nil
+}
@@ -0,0 +1,186 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ClientTestSuite) proxyFor(client *http.Client, rawurl string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+	suite.NoError(err)
+
+	proxy, err := client.Transport.(*http.Transport).Proxy(req)
+	if err != nil || proxy == nil {
+		return "", err
+	}
+	return proxy.String(), nil
+}
+
+func (suite *ClientTestSuite) TestHiddenServiceProxies() {
+	c := testrig.NewTestConfig()
+	c.HiddenServicesConfig.TorProxy = "127.0.0.1:9050"
+	c.HiddenServicesConfig.I2PProxy = "127.0.0.1:4447"
+
+	client, err := transport.NewClient(c)
+	suite.NoError(err)
+
+	proxy, err := suite.proxyFor(client, "http://gtsexampleonionaddress.onion/users/someone")
+	suite.NoError(err)
+	suite.Equal("socks5://127.0.0.1:9050", proxy)
+
+	proxy, err = suite.proxyFor(client, "http://example.i2p/users/someone")
+	suite.NoError(err)
+	suite.Equal("socks5://127.0.0.1:4447", proxy)
+}
+
+func (suite *ClientTestSuite) TestNoHiddenServiceProxies() {
+	client, err := transport.NewClient(testrig.NewTestConfig())
+	suite.NoError(err)
+
+	_, err = suite.proxyFor(client, "http://gtsexampleonionaddress.onion/users/someone")
+	suite.EqualError(err, "no tor proxy is configured, so onion services can't be reached")
+
+	_, err = suite.proxyFor(client, "http://example.i2p/users/someone")
+	suite.EqualError(err, "no i2p proxy is configured, so i2p sites can't be reached")
+}
+
+func (suite *ClientTestSuite) TestInvalidProxy() {
+	c := testrig.NewTestConfig()
+	c.HiddenServicesConfig.TorProxy = "localhost"
+
+	_, err := transport.NewClient(c)
+	suite.EqualError(err, "error parsing tor proxy: localhost isn't a valid host:port address: address localhost: missing port in address")
+}
+
+func (suite *ClientTestSuite) TestOutboundProxy() {
+	c := testrig.NewTestConfig()
+	c.OutboundProxyConfig.URL = "http://proxy.example.org:3128"
+	c.OutboundProxyConfig.NoProxy = []string{"internal.example.org"}
+	c.OutboundProxyConfig.Domains = []string{
+		"example.com=socks5://127.0.0.1:1080",
+		"direct.example.com=direct",
+		"Special.Example.Net.=https://special-proxy.example.org",
+	}
+
+	client, err := transport.NewClient(c)
+	suite.NoError(err)
+
+	for rawurl, expected := range map[string]string{
+		"https://fossbros-anonymous.io/users/foss_satan":  "http://proxy.example.org:3128",
+		"https://internal.example.org/users/someone":      "",
+		"https://sub.internal.example.org/users/someone":  "",
+		"https://notinternal.example.org/users/someone":   "http://proxy.example.org:3128",
+		"https://example.com/users/someone":               "socks5://127.0.0.1:1080",
+		"https://www.example.com:8443/users/someone":      "socks5://127.0.0.1:1080",
+		"https://direct.example.com/users/someone":        "",
+		"https://deeper.direct.example.com/users/someone": "",
+		"https://special.example.net/users/someone":       "https://special-proxy.example.org",
+		"https://not-special.example.net/users/someone":   "http://proxy.example.org:3128",
+	} {
+		proxy, err := suite.proxyFor(client, rawurl)
+		suite.NoError(err)
+		suite.Equal(expected, proxy, rawurl)
+	}
+}
+
+func (suite *ClientTestSuite) TestInvalidOutboundProxy() {
+	c := testrig.NewTestConfig()
+	c.OutboundProxyConfig.URL = "ftp://proxy.example.org"
+
+	_, err := transport.NewClient(c)
+	suite.EqualError(err, "error parsing outbound proxy config: ftp://proxy.example.org must use one of http, https or socks5")
+
+	c.OutboundProxyConfig.URL = ""
+	c.OutboundProxyConfig.Domains = []string{"example.org"}
+
+	_, err = transport.NewClient(c)
+	suite.EqualError(err, "error parsing outbound proxy config: example.org must be in the form 'domain=proxy url' or 'domain=direct'")
+}
+
+func (suite *ClientTestSuite) TestPublicClientRefusesLoopback() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := transport.NewPublicClient(testrig.NewTestConfig(), 10*time.Second)
+	suite.NoError(err)
+
+	resp, err := client.Get(server.URL)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	suite.Error(err)
+	suite.Contains(err.Error(), "refusing to connect to 127.0.0.1: not a public address")
+
+	// the regular client can still reach it
+	client, err = transport.NewClient(testrig.NewTestConfig())
+	suite.NoError(err)
+	resp, err = client.Get(server.URL)
+	suite.NoError(err)
+	resp.Body.Close()
+}
+
+func (suite *ClientTestSuite) TestPublicClientRefusesPrivateRedirects() {
+	client, err := transport.NewPublicClient(testrig.NewTestConfig(), 10*time.Second)
+	suite.NoError(err)
+
+	for rawurl, expected := range map[string]string{
+		"http://169.254.169.254/latest/meta-data/": "refusing to follow redirect to http://169.254.169.254/latest/meta-data/: not a public address",
+		"http://[::1]:8080/admin":                  "refusing to follow redirect to http://[::1]:8080/admin: not a public address",
+		"http://10.0.0.1/":                         "refusing to follow redirect to http://10.0.0.1/: not a public address",
+		"file:///etc/passwd":                       "refusing to follow redirect to file:///etc/passwd: not an http(s) url",
+		"https://example.org/somewhere":            "",
+	} {
+		req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+		suite.NoError(err)
+
+		err = client.CheckRedirect(req, nil)
+		if expected == "" {
+			suite.NoError(err, rawurl)
+		} else {
+			suite.EqualError(err, expected, rawurl)
+		}
+	}
+}
+
+func (suite *ClientTestSuite) TestCheckPublicHost() {
+	ctx := context.Background()
+	suite.EqualError(transport.CheckPublicHost(ctx, "10.0.0.1"), "10.0.0.1 is not a public address")
+	suite.EqualError(transport.CheckPublicHost(ctx, "::1"), "::1 is not a public address")
+	suite.Error(transport.CheckPublicHost(ctx, "localhost"))
+	suite.NoError(transport.CheckPublicHost(ctx, "93.184.216.34"))
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
@@ -23,12 +23,14 @@ import (
 	"crypto"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-fed/activity/pub"
 	"github.com/go-fed/httpsig"
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Controller generates transports for use in making federation requests to other servers.
@@ -43,36 +45,37 @@ type controller struct {
 	clock    pub.Clock
 	client   pub.HttpClient
 	appAgent string
+	cache    *fetchCache
 	log      *logrus.Logger
 }
 
-// NewController returns an implementation of the Controller interface for creating new transports
+// NewController returns an implementation of the Controller interface for creating new transports.
+//
+// If the instance is in allowlist federation mode, transports from the controller will refuse to make
+// requests to any domain that hasn't been explicitly allowed.
+//
+// Actors and collections fetched by the controller's transports are cached according to the federation cache config.
 func NewController(config *config.Config, db db.DB, clock pub.Clock, client pub.HttpClient, log *logrus.Logger) Controller {
 	return &controller{
 		config:   config,
 		db:       db,
 		clock:    clock,
-		client:   client,
+		client:   withAllowlist(config, db, client),
 		appAgent: fmt.Sprintf("%s %s", config.ApplicationName, config.Host),
+		cache:    newFetchCache(time.Duration(config.FederationCacheConfig.TTLSeconds)*time.Second, config.FederationCacheConfig.MaxEntries),
 		log:      log,
 	}
 }
 
 // NewTransport returns a new http signature transport with the given public key id (a URL), and the given private key.
 func (c *controller) NewTransport(pubKeyID string, privkey crypto.PrivateKey) (Transport, error) {
-	prefs := []httpsig.Algorithm{httpsig.RSA_SHA256}
-	digestAlgo := httpsig.DigestSha256
-	getHeaders := []string{httpsig.RequestTarget, "host", "date"}
-	postHeaders := []string{httpsig.RequestTarget, "host", "date", "digest"}
-
-	getSigner, _, err := httpsig.NewSigner(prefs, digestAlgo, getHeaders, httpsig.Signature, 120)
-	if err != nil {
-		return nil, fmt.Errorf("error creating get signer: %s", err)
-	}
+	return c.newTransport(pubKeyID, privkey)
+}
 
-	postSigner, _, err := httpsig.NewSigner(prefs, digestAlgo, postHeaders, httpsig.Signature, 120)
+func (c *controller) newTransport(pubKeyID string, privkey crypto.PrivateKey) (*transport, error) {
+	getSigner, postSigner, err := newSigners(httpsig.RSA_SHA256)
 	if err != nil {
-		return nil, fmt.Errorf("error creating post signer: %s", err)
+		return nil, err
 	}
 
 	sigTransport := pub.NewHttpSigTransport(c.client, c.appAgent, c.clock, getSigner, postSigner, pubKeyID, privkey)
@@ -87,7 +90,15 @@ func (c *controller) NewTransport(pubKeyID string, privkey crypto.PrivateKey) (T
 		sigTransport: sigTransport,
 		getSigner:    getSigner,
 		getSignerMu:  &sync.Mutex{},
+		postSigner:   postSigner,
+		postSignerMu: &sync.Mutex{},
+		db:           c.db,
 		log:          c.log,
+		payloadSize:  c.config.FederationLimitsConfig.PayloadSize,
+		cache:        c.cache,
+
+		deliveryConcurrency: c.config.FederationLimitsConfig.DeliveryConcurrency,
+		deliveryAttempts:    c.config.FederationLimitsConfig.DeliveryAttempts,
 	}, nil
 }
 
@@ -107,9 +118,50 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 		return nil, fmt.Errorf("error getting account %s from db: %s", username, err)
 	}
 
-	transport, err := c.NewTransport(ourAccount.PublicKeyURI, ourAccount.PrivateKey)
+	transport, err := c.newTransport(ourAccount.PublicKeyURI, ourAccount.PrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("error creating transport for user %s: %s", username, err)
 	}
+
+	if ourAccount.Ed25519PrivateKey != "" {
+		// the account has an ed25519 key too, so prefer signing with that,
+		// the rsa key will still be used for remotes that reject it
+		ed25519Privkey, err := util.DecodeEd25519PrivateKey(ourAccount.Ed25519PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding ed25519 key for user %s: %s", username, err)
+		}
+		ed25519GetSigner, ed25519PostSigner, err := newSigners(httpsig.ED25519)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ed25519 signers for user %s: %s", username, err)
+		}
+		transport.ed25519PubKeyID = ourAccount.Ed25519PublicKeyURI
+		transport.ed25519Privkey = ed25519Privkey
+		transport.ed25519GetSigner = ed25519GetSigner
+		transport.ed25519PostSigner = ed25519PostSigner
+	}
+
 	return transport, nil
 }
+
+// newSigners returns a signer for GET requests and a signer for POST requests, using the given algorithm.
+//
+// Note that whatever the algorithm, httpsig puts algorithm="hs2019" in the signature header, so
+// the remote has to work out the actual algorithm from the key.
+func newSigners(algo httpsig.Algorithm) (httpsig.Signer, httpsig.Signer, error) {
+	prefs := []httpsig.Algorithm{algo}
+	digestAlgo := httpsig.DigestSha256
+	getHeaders := []string{httpsig.RequestTarget, "host", "date"}
+	postHeaders := []string{httpsig.RequestTarget, "host", "date", "digest"}
+
+	getSigner, _, err := httpsig.NewSigner(prefs, digestAlgo, getHeaders, httpsig.Signature, 120)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating get signer: %s", err)
+	}
+
+	postSigner, _, err := httpsig.NewSigner(prefs, digestAlgo, postHeaders, httpsig.Signature, 120)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating post signer: %s", err)
+	}
+
+	return getSigner, postSigner, nil
+}
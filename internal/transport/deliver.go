@@ -19,16 +19,173 @@
 package transport
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
 func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*url.URL) error {
-	return t.sigTransport.BatchDeliver(ctx, b, recipients)
+	// we don't use the batch deliver function of the underlying sigTransport here,
+	// because it would bypass our own Deliver, and so failures wouldn't be recorded
+	concurrency := t.deliveryConcurrency
+	if concurrency <= 0 || concurrency > len(recipients) {
+		concurrency = len(recipients)
+	}
+
+	// only deliver to so many inboxes at once, so that an account with followers on
+	// thousands of instances doesn't open thousands of connections in one go
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	errs := make(chan error, len(recipients))
+	for _, recipient := range recipients {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r *url.URL) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := t.Deliver(ctx, b, r); err != nil {
+				errs <- err
+			}
+		}(recipient)
+	}
+	wg.Wait()
+	close(errs)
+
+	errStrings := []string{}
+	for err := range errs {
+		errStrings = append(errStrings, err.Error())
+	}
+	if len(errStrings) != 0 {
+		return fmt.Errorf("batch deliver had at least one failure: %s", strings.Join(errStrings, "; "))
+	}
+	return nil
 }
 
 func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
-	l := t.log.WithField("func", "Deliver")
-	l.Debugf("performing POST to %s", to.String())
-	return t.sigTransport.Deliver(ctx, b, to)
+	l := t.log.WithContext(ctx).WithField("func", "Deliver")
+	l.WithField("to", to.String()).Debug("performing POST")
+
+	deliverErr := t.deliver(ctx, b, to)
+	if err := t.putDeliveryReceipt(ctx, b, to, deliverErr); err != nil {
+		l.WithError(err).WithField("to", to.String()).Error("error storing delivery receipt")
+	}
+	if deliverErr != nil && !errors.Is(deliverErr, ErrDomainNotAllowed) && t.deliveryAttempts != 1 {
+		// store the failure so that the delivery can be retried later
+		if err := t.putFailedDelivery(ctx, b, to, deliverErr); err != nil {
+			l.WithError(err).WithField("to", to.String()).Error("error storing failed delivery")
+		}
+	}
+	return deliverErr
+}
+
+func (t *transport) Redeliver(ctx context.Context, failedDelivery *gtsmodel.FailedDelivery) error {
+	l := t.log.WithContext(ctx).WithField("func", "Redeliver")
+
+	to, err := url.Parse(failedDelivery.InboxURI)
+	if err != nil {
+		return fmt.Errorf("error parsing inbox uri %s: %s", failedDelivery.InboxURI, err)
+	}
+
+	l.WithFields(logrus.Fields{
+		"to":      to.String(),
+		"attempt": failedDelivery.Attempts + 1,
+	}).Debug("performing POST")
+	deliverErr := t.deliver(ctx, []byte(failedDelivery.Payload), to)
+	if err := t.putDeliveryReceipt(ctx, []byte(failedDelivery.Payload), to, deliverErr); err != nil {
+		l.WithError(err).WithField("to", to.String()).Error("error storing delivery receipt")
+	}
+	if deliverErr != nil {
+		failedDelivery.Attempts = failedDelivery.Attempts + 1
+		failedDelivery.LastError = deliverErr.Error()
+
+		if t.deliveryAttempts != 0 && failedDelivery.Attempts >= t.deliveryAttempts {
+			// that was the last try, so stop keeping it around
+			l.WithFields(logrus.Fields{
+				"to":       to.String(),
+				"attempts": failedDelivery.Attempts,
+			}).Info("giving up on delivery")
+			if err := t.db.DeleteByID(ctx, failedDelivery.ID, &gtsmodel.FailedDelivery{}); err != nil {
+				l.WithError(err).WithField("failedDeliveryID", failedDelivery.ID).Error("error deleting failed delivery")
+			}
+			return deliverErr
+		}
+
+		if err := t.db.UpdateByPrimaryKey(ctx, failedDelivery); err != nil {
+			l.WithError(err).WithField("failedDeliveryID", failedDelivery.ID).Error("error updating failed delivery")
+		}
+		return deliverErr
+	}
+
+	// delivered this time, so we don't need to keep it around anymore
+	return t.db.DeleteByID(ctx, failedDelivery.ID, &gtsmodel.FailedDelivery{})
+}
+
+// deliver does the same as the Deliver function of the underlying sigTransport, but also
+// sets the Collection-Synchronization header on the request where appropriate, and signs
+// with the account's ed25519 key if it has one.
+func (t *transport) deliver(ctx context.Context, b []byte, to *url.URL) error {
+	collectionSync, err := t.collectionSynchronization(ctx, b, to)
+	if err != nil {
+		// not a reason to hold up delivery, the receiver just won't be able to synchronize this time
+		t.log.WithContext(ctx).WithError(err).WithField("to", to.String()).Warn("error working out collection synchronization")
+	}
+
+	newReq := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, to.String(), bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Content-Type", "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"")
+		req.Header.Add("Accept-Charset", "utf-8")
+		req.Header.Add("Date", t.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
+		req.Header.Add("User-Agent", fmt.Sprintf("%s %s", t.appAgent, t.gofedAgent))
+		req.Header.Set("Host", to.Host)
+		if collectionSync != nil {
+			req.Header.Set(CollectionSynchronizationHeader, collectionSync.String())
+		}
+		return req, nil
+	}
+
+	resp, err := t.signedDo(ctx, newReq, b)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("POST request to %s failed (%d): %s", to.String(), resp.StatusCode, resp.Status)
+	}
+	return nil
+}
+
+func (t *transport) putFailedDelivery(ctx context.Context, b []byte, to *url.URL, deliverErr error) error {
+	if t.db == nil {
+		return nil
+	}
+
+	failedDeliveryID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+
+	return t.db.Put(ctx, &gtsmodel.FailedDelivery{
+		ID:        failedDeliveryID,
+		Domain:    to.Hostname(),
+		InboxURI:  to.String(),
+		PubKeyID:  t.pubKeyID,
+		Payload:   string(b),
+		Attempts:  1,
+		LastError: deliverErr.Error(),
+	})
 }
@@ -0,0 +1,302 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DeliverTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *DeliverTestSuite) TestDeliverFailureIsStored() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	payload := []byte(`{"type":"Create"}`)
+
+	// every request fails
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Status:     "503 Service Unavailable",
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	}), suite.db)
+
+	t, err := tc.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	to, err := url.Parse("http://fossbros-anonymous.io/users/foss_satan/inbox")
+	suite.NoError(err)
+
+	err = t.Deliver(ctx, payload, to)
+	suite.Error(err)
+
+	failedDeliveries := []*gtsmodel.FailedDelivery{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: "fossbros-anonymous.io"}}, &failedDeliveries)
+	suite.NoError(err)
+	suite.Len(failedDeliveries, 1)
+
+	fd := failedDeliveries[0]
+	suite.Equal(to.String(), fd.InboxURI)
+	suite.Equal(account.PublicKeyURI, fd.PubKeyID)
+	suite.Equal(string(payload), fd.Payload)
+	suite.Equal(1, fd.Attempts)
+	suite.NotEmpty(fd.LastError)
+
+	// failing again should bump the attempt count
+	err = t.Redeliver(ctx, fd)
+	suite.Error(err)
+
+	dbFD := &gtsmodel.FailedDelivery{}
+	err = suite.db.GetByID(ctx, fd.ID, dbFD)
+	suite.NoError(err)
+	suite.Equal(2, dbFD.Attempts)
+}
+
+func (suite *DeliverTestSuite) TestRedeliverSuccessRemovesFailedDelivery() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	fd := &gtsmodel.FailedDelivery{
+		ID:        "01FHE1BKNF3H0MJYQJY6FJEDG7",
+		Domain:    "fossbros-anonymous.io",
+		InboxURI:  "http://fossbros-anonymous.io/users/foss_satan/inbox",
+		PubKeyID:  account.PublicKeyURI,
+		Payload:   `{"type":"Create"}`,
+		Attempts:  3,
+		LastError: "POST request to http://fossbros-anonymous.io/users/foss_satan/inbox failed (502): 502 Bad Gateway",
+	}
+	suite.NoError(suite.db.Put(ctx, fd))
+
+	// the remote is back up again
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db)
+	t, err := tc.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	err = t.Redeliver(ctx, fd)
+	suite.NoError(err)
+
+	err = suite.db.GetByID(ctx, fd.ID, &gtsmodel.FailedDelivery{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DeliverTestSuite) TestDeliveryReceipts() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	payload := []byte(`{"id":"http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/activity","type":"Create","object":{"id":"http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY","type":"Note"}}`)
+
+	// the first request fails, the rest succeed
+	failed := false
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if !failed {
+			failed = true
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Status:     "502 Bad Gateway",
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+			}, nil
+		}
+		return testrig.NewMockHTTPClient(nil).Do(req)
+	}), suite.db)
+
+	t, err := tc.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	to, err := url.Parse("http://fossbros-anonymous.io/users/foss_satan/inbox")
+	suite.NoError(err)
+
+	err = t.Deliver(ctx, payload, to)
+	suite.Error(err)
+
+	receipt := &gtsmodel.DeliveryReceipt{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "inbox_uri", Value: to.String()}}, receipt)
+	suite.NoError(err)
+	suite.Equal("http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/activity", receipt.ActivityURI)
+	suite.Equal("Create", receipt.ActivityType)
+	suite.Equal("http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY", receipt.ObjectURI)
+	suite.Equal("fossbros-anonymous.io", receipt.Domain)
+	suite.False(receipt.Delivered)
+	suite.Equal(1, receipt.Attempts)
+	suite.NotEmpty(receipt.LastError)
+
+	// retrying the failed delivery should update the same receipt
+	fd := &gtsmodel.FailedDelivery{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "inbox_uri", Value: to.String()}}, fd)
+	suite.NoError(err)
+	err = t.Redeliver(ctx, fd)
+	suite.NoError(err)
+
+	receipts := []*gtsmodel.DeliveryReceipt{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "inbox_uri", Value: to.String()}}, &receipts)
+	suite.NoError(err)
+	suite.Len(receipts, 1)
+	suite.True(receipts[0].Delivered)
+	suite.Equal(2, receipts[0].Attempts)
+	suite.Empty(receipts[0].LastError)
+}
+
+func (suite *DeliverTestSuite) TestDeliverFallsBackToRSA() {
+	ctx := context.Background()
+
+	// give the account an ed25519 key as well as its rsa key
+	account := &gtsmodel.Account{}
+	*account = *suite.testAccounts["local_account_1"]
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	suite.NoError(err)
+	account.Ed25519PrivateKey, err = util.EncodeEd25519PrivateKey(privateKey)
+	suite.NoError(err)
+	account.Ed25519PublicKeyURI = account.URI + "#ed25519-key"
+	suite.NoError(suite.db.UpdateByPrimaryKey(ctx, account))
+
+	// the remote only understands rsa signatures
+	keyIDs := []string{}
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		signature := req.Header.Get("Signature")
+		for _, keyID := range []string{account.Ed25519PublicKeyURI, account.PublicKeyURI} {
+			if strings.Contains(signature, `keyId="`+keyID+`"`) {
+				keyIDs = append(keyIDs, keyID)
+			}
+		}
+
+		statusCode := http.StatusAccepted
+		if strings.Contains(signature, account.Ed25519PublicKeyURI) {
+			statusCode = http.StatusUnauthorized
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Status:     http.StatusText(statusCode),
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	}), suite.db)
+
+	t, err := tc.NewTransportForUsername(ctx, account.Username)
+	suite.NoError(err)
+
+	to, err := url.Parse("http://fossbros-anonymous.io/users/foss_satan/inbox")
+	suite.NoError(err)
+
+	err = t.Deliver(ctx, []byte(`{"type":"Create"}`), to)
+	suite.NoError(err)
+
+	// first attempt should have been ed25519, then rsa
+	suite.Equal([]string{account.Ed25519PublicKeyURI, account.PublicKeyURI}, keyIDs)
+}
+
+func (suite *DeliverTestSuite) TestBatchDeliverLimitsConcurrency() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	suite.config.FederationLimitsConfig.DeliveryConcurrency = 3
+
+	var inFlight, maxInFlight, delivered int32
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		atomic.AddInt32(&delivered, 1)
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Status:     "202 Accepted",
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	})
+
+	t, err := transport.NewController(suite.config, suite.db, &federation.Clock{}, client, suite.log).NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	recipients := []*url.URL{}
+	for i := 0; i < 20; i++ {
+		to, err := url.Parse(fmt.Sprintf("http://instance%d.example.org/inbox", i))
+		suite.NoError(err)
+		recipients = append(recipients, to)
+	}
+
+	suite.NoError(t.BatchDeliver(ctx, []byte(`{"type":"Create"}`), recipients))
+	suite.EqualValues(20, delivered)
+	suite.LessOrEqual(maxInFlight, int32(3))
+}
+
+func (suite *DeliverTestSuite) TestRedeliverGivesUpAfterMaxAttempts() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	suite.config.FederationLimitsConfig.DeliveryAttempts = 4
+
+	fd := &gtsmodel.FailedDelivery{
+		ID:        "01FHE1BKNF3H0MJYQJY6FJEDG7",
+		Domain:    "fossbros-anonymous.io",
+		InboxURI:  "http://fossbros-anonymous.io/users/foss_satan/inbox",
+		PubKeyID:  account.PublicKeyURI,
+		Payload:   `{"type":"Create"}`,
+		Attempts:  2,
+		LastError: "POST request to http://fossbros-anonymous.io/users/foss_satan/inbox failed (502): 502 Bad Gateway",
+	}
+	suite.NoError(suite.db.Put(ctx, fd))
+
+	// the remote is still down
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Status:     "502 Bad Gateway",
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	})
+	t, err := transport.NewController(suite.config, suite.db, &federation.Clock{}, client, suite.log).NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	// third attempt fails, but there's one more to go
+	suite.Error(t.Redeliver(ctx, fd))
+	dbFD := &gtsmodel.FailedDelivery{}
+	suite.NoError(suite.db.GetByID(ctx, fd.ID, dbFD))
+	suite.Equal(3, dbFD.Attempts)
+
+	// fourth attempt fails too, and that's the last one
+	suite.Error(t.Redeliver(ctx, dbFD))
+	err = suite.db.GetByID(ctx, fd.ID, &gtsmodel.FailedDelivery{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestDeliverTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliverTestSuite))
+}
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// putDeliveryReceipt records the outcome of an attempt to deliver the activity b to the given inbox, creating
+// a new delivery receipt for the activity and inbox if this is the first attempt, or updating the existing one.
+func (t *transport) putDeliveryReceipt(ctx context.Context, b []byte, to *url.URL, deliverErr error) error {
+	if t.db == nil {
+		return nil
+	}
+
+	activityURI, activityType, objectURI := activityIdentifiers(b)
+	if activityURI == "" {
+		// we can't keep track of an activity without an id
+		return nil
+	}
+
+	receipt := &gtsmodel.DeliveryReceipt{}
+	err := t.db.GetWhere(ctx, []db.Where{
+		{Key: "activity_uri", Value: activityURI},
+		{Key: "inbox_uri", Value: to.String()},
+	}, receipt)
+	if err != nil && err != db.ErrNoEntries {
+		return err
+	}
+	isNew := err == db.ErrNoEntries
+
+	if isNew {
+		receiptID, err := id.NewULID()
+		if err != nil {
+			return err
+		}
+		receipt.ID = receiptID
+		receipt.ActivityURI = activityURI
+		receipt.ActivityType = activityType
+		receipt.ObjectURI = objectURI
+		receipt.Domain = to.Hostname()
+		receipt.InboxURI = to.String()
+	}
+
+	receipt.Attempts = receipt.Attempts + 1
+	receipt.Delivered = deliverErr == nil
+	receipt.LastError = ""
+	if deliverErr != nil {
+		receipt.LastError = deliverErr.Error()
+	}
+
+	if isNew {
+		return t.db.Put(ctx, receipt)
+	}
+	receipt.UpdatedAt = time.Now()
+	return t.db.UpdateByPrimaryKey(ctx, receipt)
+}
+
+// activityIdentifiers returns the id and type of the serialized activity b, and the id of its
+// object, if it has one. Any of these will be empty if they can't be worked out.
+func activityIdentifiers(b []byte) (activityURI string, activityType string, objectURI string) {
+	activity := make(map[string]interface{})
+	if err := json.Unmarshal(b, &activity); err != nil {
+		return
+	}
+
+	activityURI, _ = activity["id"].(string)
+	activityType, _ = activity["type"].(string)
+
+	switch object := activity["object"].(type) {
+	case string:
+		objectURI = object
+	case map[string]interface{}:
+		objectURI, _ = object["id"].(string)
+	}
+	return
+}
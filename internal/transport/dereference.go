@@ -20,11 +20,72 @@ package transport
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
 )
 
 func (t *transport) Dereference(ctx context.Context, iri *url.URL) ([]byte, error) {
-	l := t.log.WithField("func", "Dereference")
-	l.Debugf("performing GET to %s", iri.String())
-	return t.sigTransport.Dereference(ctx, iri)
+	l := t.log.WithContext(ctx).WithField("func", "Dereference")
+
+	// see if we've fetched this recently enough to not have to ask again
+	var cached *fetchCacheEntry
+	cacheKey := t.pubKeyID + " " + iri.String()
+	if t.cache != nil {
+		cached = t.cache.get(cacheKey)
+		if cached != nil && !revalidate(ctx) && t.cache.fresh(cached, t.clock.Now()) {
+			l.WithField("iri", iri.String()).Debug("using cached document")
+			return cached.body, nil
+		}
+	}
+
+	l.WithField("iri", iri.String()).Debug("performing GET")
+
+	newReq := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, iri.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Accept", "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"")
+		req.Header.Add("Accept-Charset", "utf-8")
+		req.Header.Add("Date", t.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
+		req.Header.Add("User-Agent", fmt.Sprintf("%s %s", t.appAgent, t.gofedAgent))
+		req.Header.Set("Host", iri.Host)
+		if cached != nil {
+			// we have a stale copy, so only ask for the document again if it's changed since
+			if cached.etag != "" {
+				req.Header.Set("If-None-Match", cached.etag)
+			}
+			if cached.lastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.lastModified)
+			}
+		}
+		return req, nil
+	}
+
+	resp, err := t.signedDo(ctx, newReq, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		l.WithField("iri", iri.String()).Debug("cached document not modified")
+		t.cache.revalidated(cacheKey, t.clock.Now())
+		return cached.body, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET request to %s failed (%d): %s", iri.String(), resp.StatusCode, resp.Status)
+	}
+
+	b, err := readPayload(resp.Body, t.payloadSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.cache != nil && cacheable(b, resp.Header) {
+		t.cache.put(cacheKey, b, resp.Header, t.clock.Now())
+	}
+	return b, nil
 }
@@ -0,0 +1,165 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DereferenceTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *DereferenceTestSuite) TestDereferencePayloadSize() {
+	account := suite.testAccounts["local_account_1"]
+	note := []byte(`{"@context":"https://www.w3.org/ns/activitystreams","id":"http://fossbros-anonymous.io/objects/1","type":"Note"}`)
+
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(note)),
+		}, nil
+	})
+
+	iri, err := url.Parse("http://fossbros-anonymous.io/objects/1")
+	suite.NoError(err)
+
+	// a document of exactly the limit is fine
+	suite.config.FederationLimitsConfig.PayloadSize = len(note)
+	t, err := transport.NewController(suite.config, suite.db, &federation.Clock{}, client, suite.log).NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	b, err := t.Dereference(context.Background(), iri)
+	suite.NoError(err)
+	suite.Equal(note, b)
+
+	// one byte less and it's too big
+	suite.config.FederationLimitsConfig.PayloadSize = len(note) - 1
+	t, err = transport.NewController(suite.config, suite.db, &federation.Clock{}, client, suite.log).NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	_, err = t.Dereference(context.Background(), iri)
+	suite.ErrorIs(err, transport.ErrPayloadTooLarge)
+}
+
+// testClock is a pub.Clock whose time only moves when it's told to
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (suite *DereferenceTestSuite) TestDereferenceCache() {
+	account := suite.testAccounts["local_account_1"]
+	person := []byte(`{"@context":"https://www.w3.org/ns/activitystreams","id":"http://fossbros-anonymous.io/users/foss_satan","type":"Person"}`)
+	note := []byte(`{"@context":"https://www.w3.org/ns/activitystreams","id":"http://fossbros-anonymous.io/objects/1","type":"Note"}`)
+
+	requests := map[string]int{}
+	ifNoneMatch := ""
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		requests[req.URL.String()]++
+		if req.URL.Path == "/objects/1" {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(note)),
+			}, nil
+		}
+
+		ifNoneMatch = req.Header.Get("If-None-Match")
+		if ifNoneMatch == `"v1"` {
+			return &http.Response{
+				StatusCode: http.StatusNotModified,
+				Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Etag": []string{`"v1"`}},
+			Body:       ioutil.NopCloser(bytes.NewReader(person)),
+		}, nil
+	})
+
+	suite.config.FederationCacheConfig.TTLSeconds = 60
+	clock := &testClock{now: time.Now()}
+	t, err := transport.NewController(suite.config, suite.db, clock, client, suite.log).NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	personIRI, err := url.Parse("http://fossbros-anonymous.io/users/foss_satan")
+	suite.NoError(err)
+
+	// the first fetch goes to the remote
+	b, err := t.Dereference(context.Background(), personIRI)
+	suite.NoError(err)
+	suite.Equal(person, b)
+	suite.Equal(1, requests[personIRI.String()])
+	suite.Empty(ifNoneMatch)
+
+	// the second is served from the cache
+	b, err = t.Dereference(context.Background(), personIRI)
+	suite.NoError(err)
+	suite.Equal(person, b)
+	suite.Equal(1, requests[personIRI.String()])
+
+	// once the ttl has passed, the remote is asked whether the person has changed, and it hasn't
+	clock.now = clock.now.Add(2 * time.Minute)
+	b, err = t.Dereference(context.Background(), personIRI)
+	suite.NoError(err)
+	suite.Equal(person, b)
+	suite.Equal(2, requests[personIRI.String()])
+	suite.Equal(`"v1"`, ifNoneMatch)
+
+	// which makes the cached copy fresh again
+	b, err = t.Dereference(context.Background(), personIRI)
+	suite.NoError(err)
+	suite.Equal(person, b)
+	suite.Equal(2, requests[personIRI.String()])
+
+	// unless we ask for it to be revalidated
+	b, err = t.Dereference(transport.WithRevalidate(context.Background()), personIRI)
+	suite.NoError(err)
+	suite.Equal(person, b)
+	suite.Equal(3, requests[personIRI.String()])
+
+	// notes aren't cached at all
+	noteIRI, err := url.Parse("http://fossbros-anonymous.io/objects/1")
+	suite.NoError(err)
+	for i := 0; i < 2; i++ {
+		b, err = t.Dereference(context.Background(), noteIRI)
+		suite.NoError(err)
+		suite.Equal(note, b)
+	}
+	suite.Equal(2, requests[noteIRI.String()])
+}
+
+func TestDereferenceTestSuite(t *testing.T) {
+	suite.Run(t, new(DereferenceTestSuite))
+}
@@ -23,7 +23,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -36,7 +35,7 @@ import (
 )
 
 func (t *transport) DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error) {
-	l := t.log.WithField("func", "DereferenceInstance")
+	l := t.log.WithContext(ctx).WithField("func", "DereferenceInstance")
 
 	var i *gtsmodel.Instance
 	var err error
@@ -45,26 +44,26 @@ func (t *transport) DereferenceInstance(ctx context.Context, iri *url.URL) (*gts
 	// This will provide the most complete picture of an instance, and avoid unnecessary api calls.
 	//
 	// This will only work with Mastodon-api compatible instances: Mastodon, some Pleroma instances, GoToSocial.
-	l.Debugf("trying to dereference instance %s by /api/v1/instance", iri.Host)
+	l.WithField("host", iri.Host).Debug("trying to dereference instance by /api/v1/instance")
 	i, err = dereferenceByAPIV1Instance(ctx, t, iri)
 	if err == nil {
-		l.Debugf("successfully dereferenced instance using /api/v1/instance")
+		l.Debug("successfully dereferenced instance using /api/v1/instance")
 		return i, nil
 	}
-	l.Debugf("couldn't dereference instance using /api/v1/instance: %s", err)
+	l.WithError(err).Debug("couldn't dereference instance using /api/v1/instance")
 
 	// If that doesn't work, try to dereference using /.well-known/nodeinfo.
 	// This will involve two API calls and return less info overall, but should be more widely compatible.
-	l.Debugf("trying to dereference instance %s by /.well-known/nodeinfo", iri.Host)
+	l.WithField("host", iri.Host).Debug("trying to dereference instance by /.well-known/nodeinfo")
 	i, err = dereferenceByNodeInfo(ctx, t, iri)
 	if err == nil {
-		l.Debugf("successfully dereferenced instance using /.well-known/nodeinfo")
+		l.Debug("successfully dereferenced instance using /.well-known/nodeinfo")
 		return i, nil
 	}
-	l.Debugf("couldn't dereference instance using /.well-known/nodeinfo: %s", err)
+	l.WithError(err).Debug("couldn't dereference instance using /.well-known/nodeinfo")
 
 	// we couldn't dereference the instance using any of the known methods, so just return a minimal representation
-	l.Debugf("returning minimal representation of instance %s", iri.Host)
+	l.WithField("host", iri.Host).Debug("returning minimal representation of instance")
 	id, err := id.NewRandomULID()
 	if err != nil {
 		return nil, fmt.Errorf("error creating new id for instance %s: %s", iri.Host, err)
@@ -78,7 +77,7 @@ func (t *transport) DereferenceInstance(ctx context.Context, iri *url.URL) (*gts
 }
 
 func dereferenceByAPIV1Instance(ctx context.Context, t *transport, iri *url.URL) (*gtsmodel.Instance, error) {
-	l := t.log.WithField("func", "dereferenceByAPIV1Instance")
+	l := t.log.WithContext(ctx).WithField("func", "dereferenceByAPIV1Instance")
 
 	cleanIRI := &url.URL{
 		Scheme: iri.Scheme,
@@ -86,7 +85,7 @@ func dereferenceByAPIV1Instance(ctx context.Context, t *transport, iri *url.URL)
 		Path:   "api/v1/instance",
 	}
 
-	l.Debugf("performing GET to %s", cleanIRI.String())
+	l.WithField("iri", cleanIRI.String()).Debug("performing GET")
 	req, err := http.NewRequestWithContext(ctx, "GET", cleanIRI.String(), nil)
 	if err != nil {
 		return nil, err
@@ -109,7 +108,7 @@ func dereferenceByAPIV1Instance(ctx context.Context, t *transport, iri *url.URL)
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("GET request to %s failed (%d): %s", cleanIRI.String(), resp.StatusCode, resp.Status)
 	}
-	b, err := ioutil.ReadAll(resp.Body)
+	b, err := readPayload(resp.Body, t.payloadSize)
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +234,7 @@ func dereferenceByNodeInfo(c context.Context, t *transport, iri *url.URL) (*gtsm
 }
 
 func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*url.URL, error) {
-	l := t.log.WithField("func", "callNodeInfoWellKnown")
+	l := t.log.WithContext(ctx).WithField("func", "callNodeInfoWellKnown")
 
 	cleanIRI := &url.URL{
 		Scheme: iri.Scheme,
@@ -243,7 +242,7 @@ func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*ur
 		Path:   ".well-known/nodeinfo",
 	}
 
-	l.Debugf("performing GET to %s", cleanIRI.String())
+	l.WithField("iri", cleanIRI.String()).Debug("performing GET")
 	req, err := http.NewRequestWithContext(ctx, "GET", cleanIRI.String(), nil)
 	if err != nil {
 		return nil, err
@@ -267,7 +266,7 @@ func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*ur
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("callNodeInfoWellKnown: GET request to %s failed (%d): %s", cleanIRI.String(), resp.StatusCode, resp.Status)
 	}
-	b, err := ioutil.ReadAll(resp.Body)
+	b, err := readPayload(resp.Body, t.payloadSize)
 	if err != nil {
 		return nil, err
 	}
@@ -300,9 +299,9 @@ func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*ur
 }
 
 func callNodeInfo(ctx context.Context, t *transport, iri *url.URL) (*apimodel.Nodeinfo, error) {
-	l := t.log.WithField("func", "callNodeInfo")
+	l := t.log.WithContext(ctx).WithField("func", "callNodeInfo")
 
-	l.Debugf("performing GET to %s", iri.String())
+	l.WithField("iri", iri.String()).Debug("performing GET")
 	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
 	if err != nil {
 		return nil, err
@@ -326,7 +325,7 @@ func callNodeInfo(ctx context.Context, t *transport, iri *url.URL) (*apimodel.No
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("callNodeInfo: GET request to %s failed (%d): %s", iri.String(), resp.StatusCode, resp.Status)
 	}
-	b, err := ioutil.ReadAll(resp.Body)
+	b, err := readPayload(resp.Body, t.payloadSize)
 	if err != nil {
 		return nil, err
 	}
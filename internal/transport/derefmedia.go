@@ -27,8 +27,8 @@ import (
 )
 
 func (t *transport) DereferenceMedia(ctx context.Context, iri *url.URL, expectedContentType string) ([]byte, error) {
-	l := t.log.WithField("func", "DereferenceMedia")
-	l.Debugf("performing GET to %s", iri.String())
+	l := t.log.WithContext(ctx).WithField("func", "DereferenceMedia")
+	l.WithField("iri", iri.String()).Debug("performing GET")
 	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
 	if err != nil {
 		return nil, err
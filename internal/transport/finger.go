@@ -20,31 +20,90 @@ package transport
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (t *transport) Finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error) {
-	l := t.log.WithField("func", "Finger")
-	urlString := fmt.Sprintf("https://%s/.well-known/webfinger?resource=acct:%s@%s", targetDomain, targetUsername, targetDomain)
-	l.Debugf("performing GET to %s", urlString)
+	l := t.log.WithContext(ctx).WithField("func", "Finger")
+	// hidden services are served over plain http, since the connection through the proxy is already encrypted
+	scheme := "https"
+	if util.IsHiddenService(targetDomain) {
+		scheme = "http"
+	}
+
+	resource := fmt.Sprintf("acct:%s@%s", targetUsername, targetDomain)
+	urlString := fmt.Sprintf("%s://%s/.well-known/webfinger?resource=%s", scheme, targetDomain, resource)
+
+	b, err := t.fingerGET(ctx, urlString, "application/json", "application/jrd+json")
+	if err == nil {
+		if json.Valid(b) {
+			return b, nil
+		}
+		err = fmt.Errorf("response from %s was not json", urlString)
+	}
 
+	// The target domain might just be the account domain of a server that lives somewhere else, and
+	// not serve (or redirect) webfinger requests itself, perhaps serving a web page instead. In that case
+	// it should still serve a host-meta document telling us where the real webfinger endpoint is, so try
+	// that before giving up.
+	l.WithError(err).WithField("targetDomain", targetDomain).Debug("webfinger request failed, trying host-meta")
+	template, hostMetaErr := t.lrddTemplate(ctx, fmt.Sprintf("%s://%s/.well-known/host-meta", scheme, targetDomain))
+	if hostMetaErr != nil {
+		return nil, fmt.Errorf("Finger: webfinger request failed (%s) and so did host-meta lookup (%s)", err, hostMetaErr)
+	}
+
+	lrddString := strings.ReplaceAll(template, "{uri}", url.QueryEscape(resource))
+	return t.fingerGET(ctx, lrddString, "application/json", "application/jrd+json")
+}
+
+// lrddTemplate fetches the host-meta document at the given url, and returns the template of the lrdd link in it,
+// which is something like https://example.org/.well-known/webfinger?resource={uri}
+func (t *transport) lrddTemplate(ctx context.Context, hostMetaString string) (string, error) {
+	b, err := t.fingerGET(ctx, hostMetaString, "application/xrd+xml", "application/xml")
+	if err != nil {
+		return "", err
+	}
+
+	hostMeta := &apimodel.HostMeta{}
+	if err := xml.Unmarshal(b, hostMeta); err != nil {
+		return "", fmt.Errorf("could not unmarshal host-meta: %s", err)
+	}
+
+	for _, l := range hostMeta.Links {
+		if l.Rel == "lrdd" && strings.Contains(l.Template, "{uri}") {
+			return l.Template, nil
+		}
+	}
+
+	return "", fmt.Errorf("no lrdd template found in host-meta at %s", hostMetaString)
+}
+
+// fingerGET performs a signed GET request to the given url, accepting the given content types,
+// and returns the bytes of the response body if the request succeeded.
+func (t *transport) fingerGET(ctx context.Context, urlString string, accept ...string) ([]byte, error) {
 	iri, err := url.Parse(urlString)
 	if err != nil {
 		return nil, fmt.Errorf("Finger: error parsing url %s: %s", urlString, err)
 	}
 
-	l.Debugf("performing GET to %s", iri.String())
+	t.log.WithContext(ctx).WithField("func", "Finger").WithField("iri", iri.String()).Debug("performing GET")
 
 	req, err := http.NewRequestWithContext(ctx, "GET", iri.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Accept", "application/jrd+json")
+	for _, a := range accept {
+		req.Header.Add("Accept", a)
+	}
 	req.Header.Add("Date", t.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
 	req.Header.Add("User-Agent", fmt.Sprintf("%s %s", t.appAgent, t.gofedAgent))
 	req.Header.Set("Host", iri.Host)
@@ -62,5 +121,5 @@ func (t *transport) Finger(ctx context.Context, targetUsername string, targetDom
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("GET request to %s failed (%d): %s", iri.String(), resp.StatusCode, resp.Status)
 	}
-	return ioutil.ReadAll(resp.Body)
+	return readPayload(resp.Body, t.payloadSize)
 }
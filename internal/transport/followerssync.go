@@ -0,0 +1,151 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// CollectionSynchronizationHeader is the header used by Mastodon's follower synchronization mechanism, which lets the
+// receiver of a delivery detect whether its idea of who follows the sender on its instance has drifted from the sender's.
+//
+// See https://docs.joinmastodon.org/spec/activitypub/#follower-synchronization-mechanism
+const CollectionSynchronizationHeader = "Collection-Synchronization"
+
+var collectionSynchronizationParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// CollectionSynchronization models the parameters of a Collection-Synchronization header.
+type CollectionSynchronization struct {
+	// CollectionID is the followers collection of the sender, eg https://example.org/users/some_user/followers
+	CollectionID string
+	// URL is where the partial followers collection for the receiver's instance can be fetched from, with a signed GET.
+	URL string
+	// Digest is the FollowersDigest of the partial followers collection.
+	Digest string
+}
+
+// String returns the collection synchronization formatted as a header value.
+func (c *CollectionSynchronization) String() string {
+	return fmt.Sprintf(`collectionId="%s", url="%s", digest="%s"`, c.CollectionID, c.URL, c.Digest)
+}
+
+// ParseCollectionSynchronization parses the value of a Collection-Synchronization header.
+func ParseCollectionSynchronization(header string) (*CollectionSynchronization, error) {
+	c := &CollectionSynchronization{}
+	for _, match := range collectionSynchronizationParam.FindAllStringSubmatch(header, -1) {
+		switch match[1] {
+		case "collectionId":
+			c.CollectionID = match[2]
+		case "url":
+			c.URL = match[2]
+		case "digest":
+			c.Digest = match[2]
+		}
+	}
+
+	if c.CollectionID == "" || c.URL == "" || c.Digest == "" {
+		return nil, fmt.Errorf("collection synchronization header %s was missing collectionId, url, or digest", header)
+	}
+	return c, nil
+}
+
+// FollowersDigest returns the hex encoded XOR of the SHA256 digests of the given account URIs.
+// The order of the URIs doesn't matter.
+func FollowersDigest(uris []string) string {
+	digest := make([]byte, sha256.Size)
+	for _, uri := range uris {
+		sum := sha256.Sum256([]byte(uri))
+		for i := range digest {
+			digest[i] ^= sum[i]
+		}
+	}
+	return hex.EncodeToString(digest)
+}
+
+// collectionSynchronization returns the Collection-Synchronization header that should accompany delivery of b to the
+// inbox at to, or nil if there shouldn't be one. A header is only sent along with activities from a local account that
+// are addressed to its followers, since those are the deliveries that depend on the receiver knowing who they are.
+func (t *transport) collectionSynchronization(ctx context.Context, b []byte, to *url.URL) (*CollectionSynchronization, error) {
+	if t.db == nil {
+		return nil, nil
+	}
+
+	sender := &gtsmodel.Account{}
+	if err := t.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: t.pubKeyID}}, sender); err != nil {
+		return nil, fmt.Errorf("error getting account with public key id %s: %s", t.pubKeyID, err)
+	}
+
+	if sender.Domain != "" || sender.FollowersURI == "" {
+		return nil, nil
+	}
+
+	activity := make(map[string]interface{})
+	if err := json.Unmarshal(b, &activity); err != nil {
+		return nil, fmt.Errorf("error unmarshalling activity: %s", err)
+	}
+
+	if !addressedTo(activity["to"], sender.FollowersURI) && !addressedTo(activity["cc"], sender.FollowersURI) {
+		return nil, nil
+	}
+
+	follows, err := t.db.GetAccountFollowersByDomain(ctx, sender.ID, to.Host)
+	if err != nil {
+		return nil, fmt.Errorf("error getting followers of account %s on %s: %s", sender.ID, to.Host, err)
+	}
+
+	uris := make([]string, 0, len(follows))
+	for _, f := range follows {
+		if f.Account == nil {
+			return nil, errors.New("follow account was not populated")
+		}
+		uris = append(uris, f.Account.URI)
+	}
+
+	return &CollectionSynchronization{
+		CollectionID: sender.FollowersURI,
+		URL:          sender.URI + "/" + util.FollowersSynchronizationPath,
+		Digest:       FollowersDigest(uris),
+	}, nil
+}
+
+// addressedTo returns true if the given to or cc value of an activity contains uri.
+func addressedTo(addressees interface{}, uri string) bool {
+	switch a := addressees.(type) {
+	case string:
+		return a == uri
+	case []interface{}:
+		for _, item := range a {
+			if s, ok := item.(string); ok && s == uri {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FollowersSyncTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *FollowersSyncTestSuite) TestParseCollectionSynchronization() {
+	header := `collectionId="https://example.org/users/some_user/followers", url="https://example.org/users/some_user/followers_synchronization", digest="b08ab6951c7d6cc2b91e17ebd9557da7fae02489728e9ffe4a8c2cd6ac4e0e3b"`
+
+	collectionSync, err := transport.ParseCollectionSynchronization(header)
+	suite.NoError(err)
+	suite.Equal("https://example.org/users/some_user/followers", collectionSync.CollectionID)
+	suite.Equal("https://example.org/users/some_user/followers_synchronization", collectionSync.URL)
+	suite.Equal("b08ab6951c7d6cc2b91e17ebd9557da7fae02489728e9ffe4a8c2cd6ac4e0e3b", collectionSync.Digest)
+	suite.Equal(header, collectionSync.String())
+
+	_, err = transport.ParseCollectionSynchronization(`collectionId="https://example.org/users/some_user/followers"`)
+	suite.Error(err)
+}
+
+func (suite *FollowersSyncTestSuite) TestFollowersDigest() {
+	uris := []string{
+		"http://fossbros-anonymous.io/users/foss_satan",
+		"http://fossbros-anonymous.io/users/someone_else",
+	}
+
+	// order doesn't matter
+	suite.Equal(transport.FollowersDigest(uris), transport.FollowersDigest([]string{uris[1], uris[0]}))
+	suite.NotEqual(transport.FollowersDigest(uris), transport.FollowersDigest(uris[:1]))
+
+	// nobody at all is all zeroes
+	suite.Equal("0000000000000000000000000000000000000000000000000000000000000000", transport.FollowersDigest(nil))
+}
+
+func (suite *FollowersSyncTestSuite) TestDeliverSetsCollectionSynchronization() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	follower := suite.testAccounts["remote_account_1"]
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01FQ2AR3XVFTGDB0QB6KR1QHNQ",
+		AccountID:       follower.ID,
+		TargetAccountID: account.ID,
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follow/01FQ2AR3XVFTGDB0QB6KR1QHNQ",
+	}))
+
+	var header string
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		header = req.Header.Get(transport.CollectionSynchronizationHeader)
+		return &http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+		}, nil
+	}), suite.db)
+
+	t, err := tc.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	suite.NoError(err)
+
+	to, err := url.Parse("http://fossbros-anonymous.io/users/foss_satan/inbox")
+	suite.NoError(err)
+
+	// not addressed to followers, so no header
+	err = t.Deliver(ctx, []byte(`{"type":"Create","to":"`+follower.URI+`"}`), to)
+	suite.NoError(err)
+	suite.Empty(header)
+
+	err = t.Deliver(ctx, []byte(`{"type":"Create","to":"https://www.w3.org/ns/activitystreams#Public","cc":["`+account.FollowersURI+`"]}`), to)
+	suite.NoError(err)
+
+	collectionSync, err := transport.ParseCollectionSynchronization(header)
+	suite.NoError(err)
+	suite.Equal(account.FollowersURI, collectionSync.CollectionID)
+	suite.Equal(account.URI+"/followers_synchronization", collectionSync.URL)
+	suite.Equal(transport.FollowersDigest([]string{follower.URI}), collectionSync.Digest)
+}
+
+func TestFollowersSyncTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowersSyncTestSuite))
+}
@@ -0,0 +1,48 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+// ErrPayloadTooLarge is returned when a document fetched from a remote instance
+// is bigger than the configured federation limits allow.
+var ErrPayloadTooLarge = errors.New("payload is too large")
+
+// readPayload reads the whole of the given response body, or returns ErrPayloadTooLarge
+// without reading any further if it turns out to be bigger than limit bytes. A limit of 0
+// means no limit.
+func readPayload(body io.Reader, limit int) ([]byte, error) {
+	if limit <= 0 {
+		return ioutil.ReadAll(body)
+	}
+
+	// read one byte more than the limit, so we can tell a body of exactly limit bytes apart from a bigger one
+	b, err := ioutil.ReadAll(io.LimitReader(body, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > limit {
+		return nil, ErrPayloadTooLarge
+	}
+	return b, nil
+}
@@ -21,12 +21,14 @@ package transport
 import (
 	"context"
 	"crypto"
+	"net/http"
 	"net/url"
 	"sync"
 
 	"github.com/go-fed/activity/pub"
 	"github.com/go-fed/httpsig"
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -40,6 +42,9 @@ type Transport interface {
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 	// Finger performs a webfinger request with the given username and domain, and returns the bytes from the response body.
 	Finger(ctx context.Context, targetUsername string, targetDomains string) ([]byte, error)
+	// Redeliver retries the given failed delivery. If delivery succeeds, the failed delivery will be removed from the database,
+	// otherwise its attempt count and last error will be updated, and an error returned.
+	Redeliver(ctx context.Context, failedDelivery *gtsmodel.FailedDelivery) error
 }
 
 // transport implements the Transport interface
@@ -53,5 +58,88 @@ type transport struct {
 	sigTransport *pub.HttpSigTransport
 	getSigner    httpsig.Signer
 	getSignerMu  *sync.Mutex
+	postSigner   httpsig.Signer
+	postSignerMu *sync.Mutex
+	db           db.DB
 	log          *logrus.Logger
+
+	// max size in bytes of documents fetched with this transport, 0 means no limit
+	payloadSize int
+
+	// max number of inboxes to deliver to at once in BatchDeliver, 0 means no limit
+	deliveryConcurrency int
+
+	// max number of times to try a delivery before giving up on it, 0 means no limit
+	deliveryAttempts int
+
+	// cache of fetched actors and collections shared with the controller's other transports, nil if caching is disabled
+	cache *fetchCache
+
+	// ed25519 key and signers, only set if the account this transport
+	// is for has an ed25519 key; see signedDo for how they're used
+	ed25519PubKeyID   string
+	ed25519Privkey    crypto.PrivateKey
+	ed25519GetSigner  httpsig.Signer
+	ed25519PostSigner httpsig.Signer
+}
+
+// signedDo signs the request returned by newReq, performs it, and returns the response.
+// The given body should be the body of the request, or nil if it's a GET request.
+//
+// If this transport has an ed25519 key, the request is first signed with that. Not every
+// implementation understands ed25519 signatures though, so if the remote rejects the request
+// as unauthorized, newReq is called again to get a fresh request, which is signed with the
+// rsa key instead and retried.
+func (t *transport) signedDo(ctx context.Context, newReq func() (*http.Request, error), body []byte) (*http.Response, error) {
+	if t.ed25519PubKeyID != "" {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := t.sign(req, body, t.ed25519GetSigner, t.ed25519PostSigner, t.ed25519Privkey, t.ed25519PubKeyID); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+			return resp, nil
+		}
+
+		// the remote didn't like our ed25519 signature, fall back to rsa
+		resp.Body.Close()
+		t.log.WithContext(ctx).WithFields(logrus.Fields{
+			"func":   "signedDo",
+			"url":    req.URL.String(),
+			"status": resp.Status,
+		}).Debug("ed25519 signature rejected, retrying with rsa")
+	}
+
+	req, err := newReq()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.sign(req, body, t.getSigner, t.postSigner, t.privkey, t.pubKeyID); err != nil {
+		return nil, err
+	}
+
+	return t.client.Do(req)
+}
+
+// sign signs the given request with the given key, using getSigner for GET requests and postSigner otherwise.
+func (t *transport) sign(req *http.Request, body []byte, getSigner httpsig.Signer, postSigner httpsig.Signer, privkey crypto.PrivateKey, pubKeyID string) error {
+	if req.Method == http.MethodGet {
+		t.getSignerMu.Lock()
+		defer t.getSignerMu.Unlock()
+		return getSigner.SignRequest(privkey, pubKeyID, req, nil)
+	}
+
+	t.postSignerMu.Lock()
+	defer t.postSignerMu.Unlock()
+	return postSigner.SignRequest(privkey, pubKeyID, req, body)
 }
@@ -0,0 +1,87 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trends
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// findLinks returns the deduplicated links in the given html status content,
+// leaving out links that are really mentions or hashtags.
+func findLinks(content string) []string {
+	links := []string{}
+
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return links
+	}
+
+	seen := map[string]bool{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if link := linkOf(n); link != "" && !seen[link] {
+				seen[link] = true
+				links = append(links, link)
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return links
+}
+
+// linkOf returns the normalized href of the given anchor, or an empty string if it's not a link to share.
+func linkOf(a *html.Node) string {
+	href := ""
+	for _, attr := range a.Attr {
+		switch attr.Key {
+		case "href":
+			href = attr.Val
+		case "class":
+			for _, class := range strings.Fields(attr.Val) {
+				if class == "mention" || class == "hashtag" {
+					return ""
+				}
+			}
+		case "rel":
+			for _, rel := range strings.Fields(attr.Val) {
+				if rel == "tag" {
+					return ""
+				}
+			}
+		}
+	}
+
+	u, err := url.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+
+	// the same page linked with a different fragment is still the same link
+	u.Fragment = ""
+	return u.String()
+}
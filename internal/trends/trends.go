@@ -0,0 +1,288 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trends
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// HistoryDays is how many days of history are kept for each trending tag or link, including today.
+	HistoryDays = 7
+	// recentDays is how many days, including today, count towards how much something is trending right now.
+	recentDays = 2
+	// minimumScore is how many accounts need to have recently used a tag or link, or how many
+	// times a status needs to have recently been faved or boosted, before it's trending.
+	minimumScore = 2
+	// maxItems is the maximum amount of trending tags, statuses and links to keep.
+	maxItems = 100
+	// batchSize is how many statuses to select at a time while aggregating.
+	batchSize = 200
+)
+
+// Day is how much a trending tag or link was used on one day.
+type Day struct {
+	// Day is midnight (UTC) at the start of the day.
+	Day time.Time
+	// Uses is how many statuses used the tag or link on that day.
+	Uses int
+	// Accounts is how many different accounts used the tag or link on that day.
+	Accounts int
+}
+
+// Tag is a trending hashtag.
+type Tag struct {
+	// TagID is the database ID of the tag.
+	TagID string
+	// History is how much the tag was used on each of the last HistoryDays days, today first.
+	History []Day
+}
+
+// Link is a trending link.
+type Link struct {
+	// URL of the link.
+	URL string
+	// History is how much the link was shared on each of the last HistoryDays days, today first.
+	History []Day
+}
+
+// Trends works out and keeps track of which hashtags, statuses and links are currently trending on this instance.
+//
+// Only public statuses by discoverable accounts count towards trends. Tags and links are ranked by how many
+// different accounts have used them recently, and statuses by how many times they've recently been faved or boosted.
+type Trends interface {
+	// Update aggregates recent public statuses, and replaces what's currently trending with the result.
+	Update(ctx context.Context) error
+	// Tags returns the currently trending hashtags, most trending first.
+	Tags() []*Tag
+	// StatusIDs returns the IDs of the currently trending statuses, most trending first.
+	StatusIDs() []string
+	// Links returns the currently trending links, most trending first.
+	Links() []*Link
+}
+
+type trends struct {
+	db  db.DB
+	log *logrus.Logger
+
+	mu        sync.RWMutex
+	tags      []*Tag
+	statusIDs []string
+	links     []*Link
+}
+
+// New returns a new Trends, which won't have anything trending until it's updated for the first time.
+func New(db db.DB, log *logrus.Logger) Trends {
+	return &trends{
+		db:        db,
+		log:       log,
+		tags:      []*Tag{},
+		statusIDs: []string{},
+		links:     []*Link{},
+	}
+}
+
+func (t *trends) Tags() []*Tag {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tags
+}
+
+func (t *trends) StatusIDs() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.statusIDs
+}
+
+func (t *trends) Links() []*Link {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.links
+}
+
+func (t *trends) Update(ctx context.Context) error {
+	today := startOfDay(time.Now())
+	since := today.AddDate(0, 0, -(HistoryDays - 1))
+	recentSince := today.AddDate(0, 0, -(recentDays - 1))
+
+	interactions, err := t.db.CountStatusInteractionsSince(ctx, recentSince)
+	if err != nil && err != db.ErrNoEntries {
+		return err
+	}
+
+	tagUsages := map[string]*usage{}
+	linkUsages := map[string]*usage{}
+	statusScores := map[string]int{}
+
+	maxID := ""
+	for {
+		statuses, err := t.db.GetTrendableStatuses(ctx, since, maxID, batchSize)
+		if err != nil && err != db.ErrNoEntries {
+			return err
+		}
+		if len(statuses) == 0 {
+			break
+		}
+
+		for _, s := range statuses {
+			// how many days ago the status was created, 0 being today
+			day := int(today.Sub(startOfDay(s.CreatedAt)).Hours() / 24)
+			if day < 0 || day >= HistoryDays {
+				continue
+			}
+
+			for _, tagID := range util.UniqueStrings(s.TagIDs) {
+				usageOf(tagUsages, tagID).add(day, s.AccountID)
+			}
+
+			for _, link := range findLinks(s.Content) {
+				usageOf(linkUsages, link).add(day, s.AccountID)
+			}
+
+			if day < recentDays && !s.Sensitive && interactions[s.ID] >= minimumScore {
+				statusScores[s.ID] = interactions[s.ID]
+			}
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+	}
+
+	tags := []*Tag{}
+	for _, tagID := range rankUsages(tagUsages) {
+		tags = append(tags, &Tag{
+			TagID:   tagID,
+			History: tagUsages[tagID].history(today),
+		})
+	}
+
+	links := []*Link{}
+	for _, url := range rankUsages(linkUsages) {
+		links = append(links, &Link{
+			URL:     url,
+			History: linkUsages[url].history(today),
+		})
+	}
+
+	statusIDs := rank(statusScores)
+
+	t.mu.Lock()
+	t.tags = tags
+	t.statusIDs = statusIDs
+	t.links = links
+	t.mu.Unlock()
+
+	t.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":     "Update",
+		"tags":     len(tags),
+		"statuses": len(statusIDs),
+		"links":    len(links),
+	}).Debug("updated trends")
+	return nil
+}
+
+// startOfDay returns midnight (UTC) at the start of the day of the given time.
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// usage keeps track of how much a tag or link was used on each day, and by whom.
+type usage struct {
+	uses     [HistoryDays]int
+	accounts [HistoryDays]map[string]bool
+}
+
+func usageOf(usages map[string]*usage, key string) *usage {
+	u, ok := usages[key]
+	if !ok {
+		u = &usage{}
+		usages[key] = u
+	}
+	return u
+}
+
+// add records one use on the given day (0 being today) by the given account.
+func (u *usage) add(day int, accountID string) {
+	u.uses[day]++
+	if u.accounts[day] == nil {
+		u.accounts[day] = map[string]bool{}
+	}
+	u.accounts[day][accountID] = true
+}
+
+// score returns how many different accounts have recently used the tag or link.
+func (u *usage) score() int {
+	accounts := map[string]bool{}
+	for day := 0; day < recentDays; day++ {
+		for accountID := range u.accounts[day] {
+			accounts[accountID] = true
+		}
+	}
+	return len(accounts)
+}
+
+func (u *usage) history(today time.Time) []Day {
+	history := make([]Day, 0, HistoryDays)
+	for day := 0; day < HistoryDays; day++ {
+		history = append(history, Day{
+			Day:      today.AddDate(0, 0, -day),
+			Uses:     u.uses[day],
+			Accounts: len(u.accounts[day]),
+		})
+	}
+	return history
+}
+
+func rankUsages(usages map[string]*usage) []string {
+	scores := make(map[string]int, len(usages))
+	for key, u := range usages {
+		if score := u.score(); score >= minimumScore {
+			scores[key] = score
+		}
+	}
+	return rank(scores)
+}
+
+// rank returns the keys of the given scores, highest score first, up to maxItems of them.
+func rank(scores map[string]int) []string {
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] != scores[keys[j]] {
+			return scores[keys[i]] > scores[keys[j]]
+		}
+		// newer IDs sort higher, and it keeps the order stable
+		return keys[i] > keys[j]
+	})
+
+	if len(keys) > maxItems {
+		keys = keys[:maxItems]
+	}
+	return keys
+}
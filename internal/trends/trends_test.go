@@ -0,0 +1,140 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trends_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/trends"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const trendingContent = `<p>read this <a href="https://example.org/article#comments" rel="nofollow">https://example.org/article#comments</a> <a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag">#<span>welcome</span></a> <span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span></p>`
+
+type TrendsTestSuite struct {
+	suite.Suite
+	db           db.DB
+	testAccounts map[string]*gtsmodel.Account
+	testTags     map[string]*gtsmodel.Tag
+	trends       trends.Trends
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	suite.db = testrig.NewTestDB()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTags = testrig.NewTestTags()
+	testrig.StandardDBSetup(suite.db, nil)
+	suite.trends = trends.New(suite.db, testrig.NewTestLog())
+}
+
+func (suite *TrendsTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *TrendsTestSuite) putStatus(accountName string, createdAt time.Time) *gtsmodel.Status {
+	statusID, err := id.NewULIDFromTime(createdAt)
+	suite.NoError(err)
+
+	account := suite.testAccounts[accountName]
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 account.URI + "/statuses/" + statusID,
+		URL:                 account.URL + "/" + statusID,
+		Content:             trendingContent,
+		TagIDs:              []string{suite.testTags["welcome"].ID},
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		Local:               true,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: "Note",
+	}
+	suite.NoError(suite.db.PutStatus(context.Background(), status))
+	return status
+}
+
+func (suite *TrendsTestSuite) putFave(accountName string, status *gtsmodel.Status) {
+	faveID, err := id.NewULID()
+	suite.NoError(err)
+
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.StatusFave{
+		ID:              faveID,
+		AccountID:       suite.testAccounts[accountName].ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             "http://localhost:8080/fave/" + faveID,
+	}))
+}
+
+func (suite *TrendsTestSuite) TestUpdate() {
+	ctx := context.Background()
+
+	trending := suite.putStatus("local_account_1", time.Now())
+	suite.putStatus("local_account_2", time.Now())
+	suite.putStatus("local_account_2", time.Now().AddDate(0, 0, -3))
+	// the admin account isn't discoverable, so its statuses don't count
+	suite.putStatus("admin_account", time.Now())
+
+	suite.putFave("local_account_2", trending)
+	suite.putFave("admin_account", trending)
+
+	suite.NoError(suite.trends.Update(ctx))
+
+	tags := suite.trends.Tags()
+	suite.Len(tags, 1)
+	suite.Equal(suite.testTags["welcome"].ID, tags[0].TagID)
+	suite.Len(tags[0].History, trends.HistoryDays)
+	suite.Equal(2, tags[0].History[0].Uses)
+	suite.Equal(2, tags[0].History[0].Accounts)
+	suite.Equal(1, tags[0].History[3].Uses)
+	suite.Equal(1, tags[0].History[3].Accounts)
+
+	// mentions and hashtags aren't links, and fragments are dropped
+	links := suite.trends.Links()
+	suite.Len(links, 1)
+	suite.Equal("https://example.org/article", links[0].URL)
+	suite.Equal(2, links[0].History[0].Accounts)
+
+	suite.Equal([]string{trending.ID}, suite.trends.StatusIDs())
+}
+
+func (suite *TrendsTestSuite) TestUpdateNotEnoughUse() {
+	ctx := context.Background()
+
+	// one account on its own can't make something trend
+	status := suite.putStatus("local_account_1", time.Now())
+	suite.putStatus("local_account_1", time.Now())
+	suite.putFave("local_account_2", status)
+
+	suite.NoError(suite.trends.Update(ctx))
+	suite.Empty(suite.trends.Tags())
+	suite.Empty(suite.trends.Links())
+	suite.Empty(suite.trends.StatusIDs())
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendsTestSuite))
+}
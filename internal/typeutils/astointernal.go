@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable ap.Accountable, update bool) (*gtsmodel.Account, error) {
@@ -84,6 +85,11 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 		acct.DisplayName = displayName
 	}
 
+	// emojis used in the display name or note, to dereference and fetch later on
+	if emojis, err := ap.ExtractEmojis(accountable); err == nil {
+		acct.Emojis = emojis
+	}
+
 	// TODO: fields aka attachment array
 
 	// note aka summary
@@ -101,8 +107,9 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	case ap.ActorApplication, ap.ActorService:
 		acct.Bot = true
 	default:
-		// we don't know what this is!
-		return nil, fmt.Errorf("type name %s not recognised or not convertible to ap.ActivityStreamsActor", accountable.GetTypeName())
+		// we don't know what this is, but it has everything an actor needs,
+		// so treat it like a person rather than refusing to talk to it
+		acct.Bot = false
 	}
 	acct.ActorType = accountable.GetTypeName()
 
@@ -136,6 +143,12 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 		acct.InboxURI = accountable.GetActivityStreamsInbox().GetIRI().String()
 	}
 
+	// SharedInboxURI
+	// not every instance has a shared inbox, so it doesn't matter if this isn't set
+	if sharedInbox, err := ap.ExtractSharedInbox(accountable); err == nil {
+		acct.SharedInboxURI = sharedInbox.String()
+	}
+
 	// OutboxURI
 	if accountable.GetActivityStreamsOutbox() != nil && accountable.GetActivityStreamsOutbox().GetIRI() != nil {
 		acct.OutboxURI = accountable.GetActivityStreamsOutbox().GetIRI().String()
@@ -158,7 +171,20 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 
 	// TODO: FeaturedTagsURI
 
-	// TODO: alsoKnownAs
+	// alsoKnownAs
+	acct.AlsoKnownAsURIs = []string{}
+	for _, alias := range ap.ExtractAlsoKnownAs(accountable) {
+		acct.AlsoKnownAsURIs = append(acct.AlsoKnownAsURIs, alias.String())
+	}
+
+	// movedTo
+	// we can only point to an account we already know about here; if we don't have
+	// the target yet, it'll be dereferenced when we receive the Move activity itself
+	if movedTo, err := ap.ExtractMovedTo(accountable); err == nil {
+		if movedToAccount, err := c.db.GetAccountByURI(ctx, movedTo.String()); err == nil {
+			acct.MovedToAccountID = movedToAccount.ID
+		}
+	}
 
 	// publicKey
 	pkey, pkeyURL, err := ap.ExtractPublicKeyForOwner(accountable, uri)
@@ -181,53 +207,53 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	}
 	status.URI = uriProp.GetIRI().String()
 
-	l := c.log.WithField("statusURI", status.URI)
+	l := c.log.WithContext(ctx).WithField("statusURI", status.URI)
 
 	// web url for viewing this status
 	if statusURL, err := ap.ExtractURL(statusable); err != nil {
-		l.Infof("ASStatusToStatus: error extracting status URL: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status URL")
 	} else {
 		status.URL = statusURL.String()
 	}
 
 	// the html-formatted content of this status
 	if content, err := ap.ExtractContent(statusable); err != nil {
-		l.Infof("ASStatusToStatus: error extracting status content: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status content")
 	} else {
 		status.Content = content
 	}
 
 	// attachments to dereference and fetch later on (we don't do that here)
 	if attachments, err := ap.ExtractAttachments(statusable); err != nil {
-		l.Infof("ASStatusToStatus: error extracting status attachments: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status attachments")
 	} else {
 		status.Attachments = attachments
 	}
 
 	// hashtags to dereference later on
 	if hashtags, err := ap.ExtractHashtags(statusable); err != nil {
-		l.Infof("ASStatusToStatus: error extracting status hashtags: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status hashtags")
 	} else {
 		status.Tags = hashtags
 	}
 
 	// emojis to dereference and fetch later on
 	if emojis, err := ap.ExtractEmojis(statusable); err != nil {
-		l.Infof("ASStatusToStatus: error extracting status emojis: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status emojis")
 	} else {
 		status.Emojis = emojis
 	}
 
 	// mentions to dereference later on
 	if mentions, err := ap.ExtractMentions(statusable); err != nil {
-		l.Infof("ASStatusToStatus: error extracting status mentions: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status mentions")
 	} else {
 		status.Mentions = mentions
 	}
 
 	// cw string for this status
 	if cw, err := ap.ExtractSummary(statusable); err != nil {
-		l.Infof("ASStatusToStatus: error extracting status summary: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status summary")
 	} else {
 		status.ContentWarning = cw
 	}
@@ -235,7 +261,7 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// when was this status created?
 	published, err := ap.ExtractPublished(statusable)
 	if err != nil {
-		l.Infof("ASStatusToStatus: error extracting status published: %s", err)
+		l.WithError(err).Info("ASStatusToStatus: error extracting status published")
 	} else {
 		status.CreatedAt = published
 		status.UpdatedAt = published
@@ -335,7 +361,13 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// TODO: this is a bool
 
 	// language
-	// we might be able to extract this from the contentMap field
+	// go-fed only keeps the contentMap if there's no plain content, so
+	// fall back to guessing the language from the content if we have to
+	if lang, err := ap.ExtractLanguage(statusable); err == nil {
+		status.Language = lang
+	} else {
+		status.Language = text.DetectLanguage(text.RemoveHTML(status.Content))
+	}
 
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
@@ -494,6 +526,98 @@ func (c *converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable)
 	}, nil
 }
 
+func (c *converter) ASLikeToStatusReaction(ctx context.Context, likeable ap.Likeable) (*gtsmodel.StatusReaction, error) {
+	content, err := ap.ExtractContent(likeable)
+	if err != nil {
+		return nil, fmt.Errorf("ASLikeToStatusReaction: like carried no emoji content: %s", err)
+	}
+
+	// apart from the content, a reaction is parsed exactly like a fave
+	fave, err := c.ASLikeToFave(ctx, likeable)
+	if err != nil {
+		return nil, fmt.Errorf("ASLikeToStatusReaction: %s", err)
+	}
+
+	return &gtsmodel.StatusReaction{
+		AccountID:       fave.AccountID,
+		Account:         fave.Account,
+		TargetAccountID: fave.TargetAccountID,
+		TargetAccount:   fave.TargetAccount,
+		StatusID:        fave.StatusID,
+		Status:          fave.Status,
+		Content:         strings.TrimSpace(content),
+		URI:             fave.URI,
+	}, nil
+}
+
+func (c *converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable) (*gtsmodel.Report, error) {
+	idProp := flaggable.GetJSONLDId()
+	if idProp == nil || !idProp.IsIRI() {
+		return nil, errors.New("ASFlagToReport: no id property set on flag, or was not an iri")
+	}
+	uri := idProp.GetIRI().String()
+
+	origin, err := ap.ExtractActor(flaggable)
+	if err != nil {
+		return nil, errors.New("ASFlagToReport: error extracting actor property from flag")
+	}
+	originAccount, err := c.db.GetAccountByURI(ctx, origin.String())
+	if err != nil {
+		return nil, fmt.Errorf("ASFlagToReport: error extracting account with uri %s from the database: %s", origin.String(), err)
+	}
+
+	objects, err := ap.ExtractObjects(flaggable)
+	if err != nil {
+		return nil, errors.New("ASFlagToReport: error extracting object property from flag")
+	}
+
+	// the objects of a flag are the flagged account, and any of its statuses that were flagged along with it,
+	// in no particular order, so sort them out here; anything that isn't ours is none of our business
+	var targetAccount *gtsmodel.Account
+	statuses := []*gtsmodel.Status{}
+	for _, object := range objects {
+		if object.Host != c.config.Host {
+			continue
+		}
+
+		if a, err := c.db.GetAccountByURI(ctx, object.String()); err == nil {
+			targetAccount = a
+			continue
+		}
+
+		if s, err := c.db.GetStatusByURI(ctx, object.String()); err == nil {
+			statuses = append(statuses, s)
+		}
+	}
+
+	if targetAccount == nil {
+		return nil, errors.New("ASFlagToReport: flag didn't contain a local account")
+	}
+
+	statusIDs := []string{}
+	for _, s := range statuses {
+		if s.AccountID != targetAccount.ID {
+			return nil, fmt.Errorf("ASFlagToReport: flagged status %s doesn't belong to flagged account %s", s.URI, targetAccount.URI)
+		}
+		statusIDs = append(statusIDs, s.ID)
+	}
+
+	// content is optional, it's just the comment that the reporter gave;
+	// flags don't carry a category, so remote reports are always filed as other
+	comment, _ := ap.ExtractContent(flaggable)
+
+	return &gtsmodel.Report{
+		URI:             uri,
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		StatusIDs:       statusIDs,
+		Comment:         comment,
+		Category:        gtsmodel.ReportCategoryOther,
+	}, nil
+}
+
 func (c *converter) ASAnnounceToStatus(ctx context.Context, announceable ap.Announceable) (*gtsmodel.Status, bool, error) {
 	status := &gtsmodel.Status{}
 	isNew := true
@@ -513,7 +637,7 @@ func (c *converter) ASAnnounceToStatus(ctx context.Context, announceable ap.Anno
 	status.URI = uri
 
 	// get the URI of the announced/boosted status
-	boostedStatusURI, err := ap.ExtractObject(announceable)
+	boostedStatusURI, err := ap.ExtractAnnouncedObject(announceable)
 	if err != nil {
 		return nil, isNew, fmt.Errorf("ASAnnounceToStatus: error getting object from announce: %s", err)
 	}
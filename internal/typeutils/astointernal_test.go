@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type ASToInternalTestSuite struct {
@@ -57,6 +58,38 @@ func (suite *ASToInternalTestSuite) TestParsePerson() {
 	suite.False(acct.Locked)
 }
 
+func (suite *ASToInternalTestSuite) TestParsePersonWithEmoji() {
+	testPerson := testrig.NewTestFediPeople()["https://unknown-instance.com/users/brand_new_person"]
+
+	// give the person's display name a custom emoji
+	emoji := streams.NewTootEmoji()
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(testrig.URLMustParse("https://unknown-instance.com/emojis/1"))
+	emoji.SetJSONLDId(idProp)
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString(":partyparrot:")
+	emoji.SetActivityStreamsName(nameProp)
+	iconImage := streams.NewActivityStreamsImage()
+	urlProp := streams.NewActivityStreamsUrlProperty()
+	urlProp.AppendIRI(testrig.URLMustParse("https://unknown-instance.com/files/partyparrot.png"))
+	iconImage.SetActivityStreamsUrl(urlProp)
+	iconProp := streams.NewActivityStreamsIconProperty()
+	iconProp.AppendActivityStreamsImage(iconImage)
+	emoji.SetActivityStreamsIcon(iconProp)
+	tagProp := streams.NewActivityStreamsTagProperty()
+	tagProp.AppendTootEmoji(emoji)
+	testPerson.SetActivityStreamsTag(tagProp)
+
+	acct, err := suite.typeconverter.ASRepresentationToAccount(context.Background(), testPerson, false)
+	suite.NoError(err)
+
+	suite.Len(acct.Emojis, 1)
+	suite.Equal("https://unknown-instance.com/emojis/1", acct.Emojis[0].URI)
+	suite.Equal("partyparrot", acct.Emojis[0].Shortcode)
+	suite.Equal("unknown-instance.com", acct.Emojis[0].Domain)
+	suite.Equal("https://unknown-instance.com/files/partyparrot.png", acct.Emojis[0].ImageRemoteURL)
+}
+
 func (suite *ASToInternalTestSuite) TestParseGargron() {
 	m := make(map[string]interface{})
 	err := json.Unmarshal([]byte(gargronAsActivityJson), &m)
@@ -112,6 +145,7 @@ func (suite *ASToInternalTestSuite) TestParseReplyWithMention() {
 	suite.True(status.Replyable)
 	suite.True(status.Likeable)
 	suite.Equal(`<p><span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> nice there it is:</p><p><a href="http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/activity" rel="nofollow noopener noreferrer" target="_blank"><span class="invisible">https://</span><span class="ellipsis">social.pixie.town/users/f0x/st</span><span class="invisible">atuses/106221628567855262/activity</span></a></p>`, status.Content)
+	suite.Equal("en", status.Language)
 	suite.Len(status.Mentions, 1)
 	m1 := status.Mentions[0]
 	suite.Equal(inReplyToAccount.URI, m1.TargetAccountURI)
@@ -119,6 +153,146 @@ func (suite *ASToInternalTestSuite) TestParseReplyWithMention() {
 	suite.Equal(gtsmodel.VisibilityUnlocked, status.Visibility)
 }
 
+func (suite *ASToInternalTestSuite) TestParseStatusWithEmojisAndTags() {
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(statusWithEmojisAndTagsAsActivityJson), &m)
+	suite.NoError(err)
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	create, ok := t.(vocab.ActivityStreamsCreate)
+	suite.True(ok)
+
+	statusable := create.GetActivityStreamsObject().Begin().GetActivityStreamsNote()
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), statusable)
+	suite.NoError(err)
+
+	suite.Len(status.Tags, 2)
+	suite.Equal("tags", status.Tags[0].Name)
+	suite.Equal("https://ondergrond.org/tags/tags", status.Tags[0].URL)
+	suite.Equal("emoji", status.Tags[1].Name)
+	suite.Equal("https://ondergrond.org/tags/emoji", status.Tags[1].URL)
+
+	suite.NotEmpty(status.Emojis)
+}
+
+func (suite *ASToInternalTestSuite) TestParseFlag() {
+	reportingAccount := suite.testAccounts["remote_account_1"]
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	flagJson := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/01FP6Z0D0MM0ZM2RC6Q5XAJ3ZS",
+  "type": "Flag",
+  "actor": "` + reportingAccount.URI + `",
+  "content": "this is a bad account, bad i tell you",
+  "object": [
+    "` + reportedAccount.URI + `",
+    "` + reportedStatus.URI + `",
+    "http://fossbros-anonymous.io/users/foss_satan/statuses/some_other_status"
+  ]
+}`
+
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(flagJson), &m)
+	suite.NoError(err)
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	flag, ok := t.(vocab.ActivityStreamsFlag)
+	suite.True(ok)
+
+	report, err := suite.typeconverter.ASFlagToReport(context.Background(), flag)
+	suite.NoError(err)
+
+	suite.Equal("http://fossbros-anonymous.io/01FP6Z0D0MM0ZM2RC6Q5XAJ3ZS", report.URI)
+	suite.Equal(reportingAccount.ID, report.AccountID)
+	suite.Equal(reportedAccount.ID, report.TargetAccountID)
+	suite.Equal([]string{reportedStatus.ID}, report.StatusIDs)
+	suite.Equal("this is a bad account, bad i tell you", report.Comment)
+}
+
+func (suite *ASToInternalTestSuite) TestParseEmojiReaction() {
+	reactingAccount := suite.testAccounts["remote_account_1"]
+	reactedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	likeJson := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/likes/01FPZ9M3QWQ7AZ6RZ3D7W0KD0C",
+  "type": "Like",
+  "actor": "` + reactingAccount.URI + `",
+  "content": "🦊",
+  "object": "` + reactedStatus.URI + `"
+}`
+
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(likeJson), &m)
+	suite.NoError(err)
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	like, ok := t.(vocab.ActivityStreamsLike)
+	suite.True(ok)
+
+	reaction, err := suite.typeconverter.ASLikeToStatusReaction(context.Background(), like)
+	suite.NoError(err)
+
+	suite.Equal("http://fossbros-anonymous.io/likes/01FPZ9M3QWQ7AZ6RZ3D7W0KD0C", reaction.URI)
+	suite.Equal(reactingAccount.ID, reaction.AccountID)
+	suite.Equal(reactedStatus.ID, reaction.StatusID)
+	suite.Equal(reactedStatus.AccountID, reaction.TargetAccountID)
+	suite.Equal("🦊", reaction.Content)
+}
+
+func (suite *ASToInternalTestSuite) TestParseGroupAnnounceEmbeddedCreate() {
+	// use a remote account we already have as the group, since the announcing account has to be in the db
+	groupAccount := suite.testAccounts["remote_account_1"]
+
+	announceJson := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://fossbros-anonymous.io/activities/announce/01FQ0KZ6Y4WJ5T6N5Z6W0M3Q2R",
+  "type": "Announce",
+  "actor": "` + groupAccount.URI + `",
+  "published": "2021-12-27T10:00:00Z",
+  "to": ["https://www.w3.org/ns/activitystreams#Public"],
+  "cc": ["` + groupAccount.FollowersURI + `"],
+  "object": {
+    "id": "http://example.org/activities/create/1",
+    "type": "Create",
+    "actor": "http://example.org/users/someone",
+    "object": {
+      "id": "http://example.org/objects/1",
+      "type": "Note",
+      "content": "hello group",
+      "attributedTo": "http://example.org/users/someone"
+    }
+  }
+}`
+
+	m := make(map[string]interface{})
+	err := json.Unmarshal([]byte(announceJson), &m)
+	suite.NoError(err)
+
+	t, err := streams.ToType(context.Background(), m)
+	suite.NoError(err)
+
+	announce, ok := t.(vocab.ActivityStreamsAnnounce)
+	suite.True(ok)
+
+	boost, isNew, err := suite.typeconverter.ASAnnounceToStatus(context.Background(), announce)
+	suite.NoError(err)
+	suite.True(isNew)
+
+	suite.Equal("http://fossbros-anonymous.io/activities/announce/01FQ0KZ6Y4WJ5T6N5Z6W0M3Q2R", boost.URI)
+	suite.Equal(groupAccount.ID, boost.AccountID)
+	suite.Equal("http://example.org/objects/1", boost.BoostOf.URI)
+	suite.Equal(gtsmodel.VisibilityPublic, boost.Visibility)
+}
+
 func TestASToInternalTestSuite(t *testing.T) {
 	suite.Run(t, new(ASToInternalTestSuite))
 }
@@ -78,16 +78,60 @@ type TypeConverter interface {
 	//
 	// Requesting account can be nil.
 	StatusToMasto(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*model.Status, error)
+	// PollToMasto converts a gts model poll into its mastodon (frontend) representation, with vote tallies, and
+	// the votes of the requesting account if it's not nil.
+	PollToMasto(ctx context.Context, p *gtsmodel.Poll, requestingAccount *gtsmodel.Account) (*model.Poll, error)
 	// VisToMasto converts a gts visibility into its mastodon equivalent
 	VisToMasto(ctx context.Context, m gtsmodel.Visibility) model.Visibility
 	// InstanceToMasto converts a gts instance into its mastodon equivalent for serving at /api/v1/instance
 	InstanceToMasto(ctx context.Context, i *gtsmodel.Instance) (*model.Instance, error)
+	// InstanceToMastoV2 converts a gts instance into its mastodon equivalent for serving at /api/v2/instance
+	InstanceToMastoV2(ctx context.Context, i *gtsmodel.Instance) (*model.InstanceV2, error)
+	// RuleToMasto converts a gts instance rule into its mastodon equivalent
+	RuleToMasto(ctx context.Context, r *gtsmodel.Rule) (*model.Rule, error)
 	// RelationshipToMasto converts a gts relationship into its mastodon equivalent for serving in various places
 	RelationshipToMasto(ctx context.Context, r *gtsmodel.Relationship) (*model.Relationship, error)
 	// NotificationToMasto converts a gts notification into a mastodon notification
 	NotificationToMasto(ctx context.Context, n *gtsmodel.Notification) (*model.Notification, error)
+	// NotificationPreferencesToMasto converts gts notification preferences into their frontend representation
+	NotificationPreferencesToMasto(ctx context.Context, p *gtsmodel.NotificationPreferences) (*model.NotificationPreferences, error)
+	// WebPushSubscriptionToMasto converts a gts web push subscription into its frontend representation, without the server key
+	WebPushSubscriptionToMasto(ctx context.Context, s *gtsmodel.WebPushSubscription) (*model.PushSubscription, error)
 	// DomainBlockTomasto converts a gts model domin block into a mastodon domain block, for serving at /api/v1/admin/domain_blocks
 	DomainBlockToMasto(ctx context.Context, b *gtsmodel.DomainBlock, export bool) (*model.DomainBlock, error)
+	// DomainAllowToMasto converts a gts model domain allow into a frontend domain allow, for serving at /api/v1/admin/domain_allows
+	DomainAllowToMasto(ctx context.Context, a *gtsmodel.DomainAllow) (*model.DomainAllow, error)
+	// DomainPolicyToMasto converts a gts model domain policy into a frontend domain policy, for serving at /api/v1/admin/domain_policies
+	DomainPolicyToMasto(ctx context.Context, p *gtsmodel.DomainPolicy) (*model.DomainPolicy, error)
+	// DeliveryReceiptToMasto converts a gts model delivery receipt into its frontend representation, for serving at /api/v1/admin/deliveries
+	DeliveryReceiptToMasto(ctx context.Context, r *gtsmodel.DeliveryReceipt) (*model.DeliveryReceipt, error)
+	// SpamFlagToMasto converts a gts model spam flag into its frontend representation, for serving at /api/v1/admin/spam_flags
+	SpamFlagToMasto(ctx context.Context, f *gtsmodel.SpamFlag, requestingAccount *gtsmodel.Account) (*model.SpamFlag, error)
+	// ReportToAdminMasto converts a gts model report into its admin frontend representation, for serving at /api/v1/admin/reports
+	ReportToAdminMasto(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*model.AdminReportInfo, error)
+	// AccountToAdminMasto converts a gts model account into its admin frontend representation, for serving at /api/v1/admin/accounts.
+	// For local accounts, details of the user that owns the account are included, if it still exists.
+	AccountToAdminMasto(ctx context.Context, a *gtsmodel.Account) (*model.AdminAccountInfo, error)
+	// AdminAuditLogToMasto converts a gts model admin audit log entry into its frontend representation, for serving at /api/v1/admin/audit_logs
+	AdminAuditLogToMasto(ctx context.Context, l *gtsmodel.AdminAuditLog) (*model.AdminAuditLog, error)
+	// ModerationNoteToAdminMasto converts a gts model moderation note into its admin frontend representation
+	ModerationNoteToAdminMasto(ctx context.Context, n *gtsmodel.ModerationNote) (*model.AdminModerationNote, error)
+	// AccountWarningToAdminMasto converts a gts model account warning into its admin frontend representation
+	AccountWarningToAdminMasto(ctx context.Context, w *gtsmodel.AccountWarning) (*model.AdminAccountWarning, error)
+	// ReportToMasto converts a gts model report into its frontend representation, for serving back to the account that filed it.
+	ReportToMasto(ctx context.Context, r *gtsmodel.Report) (*model.Report, error)
+	// InstancePageToMasto converts a gts model instance page into its frontend representation. The source
+	// of the page is only included if withSource is true, which should only be the case for admins.
+	InstancePageToMasto(ctx context.Context, p *gtsmodel.InstancePage, withSource bool) (*model.InstancePage, error)
+	// ListToMasto converts a gts model list into its frontend representation, for serving at /api/v1/lists
+	ListToMasto(ctx context.Context, l *gtsmodel.List) (*model.List, error)
+	// FilterToMastoV1 converts one keyword of a gts model filter into a version 1 frontend filter, for serving at /api/v1/filters.
+	// The keyword is expected to have its Filter set.
+	FilterToMastoV1(ctx context.Context, k *gtsmodel.FilterKeyword) (*model.Filter, error)
+	// FilterToMastoV2 converts a gts model filter into its version 2 frontend representation, for serving at /api/v2/filters
+	FilterToMastoV2(ctx context.Context, f *gtsmodel.Filter) (*model.FilterV2, error)
+	// StatusEditToMasto converts a previous version of a status into its frontend representation, for serving in the status history.
+	StatusEditToMasto(ctx context.Context, e *gtsmodel.StatusEdit) (*model.StatusEdit, error)
 
 	/*
 		FRONTEND (mastodon) MODEL TO INTERNAL (gts) MODEL
@@ -114,8 +158,13 @@ type TypeConverter interface {
 	ASFollowToFollow(ctx context.Context, followable ap.Followable) (*gtsmodel.Follow, error)
 	// ASLikeToFave converts a remote activitystreams 'like' representation into a gts model status fave.
 	ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gtsmodel.StatusFave, error)
+	// ASLikeToStatusReaction converts a remote activitystreams 'like' representation that carries emoji content into a gts model status reaction.
+	ASLikeToStatusReaction(ctx context.Context, likeable ap.Likeable) (*gtsmodel.StatusReaction, error)
 	// ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 	ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error)
+	// ASFlagToReport converts a remote activitystreams 'flag' representation into a gts model report.
+	// The flagged account must be a local account, and any flagged statuses must be statuses of that account.
+	ASFlagToReport(ctx context.Context, flaggable ap.Flaggable) (*gtsmodel.Report, error)
 	// ASAnnounceToStatus converts an activitystreams 'announce' into a status.
 	//
 	// The returned bool indicates whether this status is new (true) or not new (false).
@@ -148,18 +197,34 @@ type TypeConverter interface {
 	FollowToAS(ctx context.Context, f *gtsmodel.Follow, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsFollow, error)
 	// MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 	MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error)
+	// TagToAS converts a gts model tag into an activity streams Hashtag, suitable for federation as a tag
+	TagToAS(ctx context.Context, t *gtsmodel.Tag) (vocab.ActivityStreamsLink, error)
+	// EmojiToAS converts a gts model emoji into an activity streams Emoji, suitable for federation as a tag
+	EmojiToAS(ctx context.Context, e *gtsmodel.Emoji) (vocab.TootEmoji, error)
 	// AttachmentToAS converts a gts model media attachment into an activity streams Attachment, suitable for federation
-	AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachment) (vocab.ActivityStreamsDocument, error)
+	AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachment) (vocab.Type, error)
 	// FaveToAS converts a gts model status fave into an activityStreams LIKE, suitable for federation.
 	FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab.ActivityStreamsLike, error)
 	// BoostToAS converts a gts model boost into an activityStreams ANNOUNCE, suitable for federation
 	BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error)
 	// BlockToAS converts a gts model block into an activityStreams BLOCK, suitable for federation.
 	BlockToAS(ctx context.Context, block *gtsmodel.Block) (vocab.ActivityStreamsBlock, error)
+	// MoveToAS converts the move of originAccount to targetAccount into an activityStreams MOVE, suitable for federation.
+	MoveToAS(ctx context.Context, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsMove, error)
+	// ReportToASFlag converts a gts model report into an activityStreams FLAG, suitable for federation to the instance of the reported account.
+	ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error)
 	// StatusToASRepliesCollection converts a gts model status into an activityStreams REPLIES collection.
 	StatusToASRepliesCollection(ctx context.Context, status *gtsmodel.Status, onlyOtherAccounts bool) (vocab.ActivityStreamsCollection, error)
 	// StatusURIsToASRepliesPage returns a collection page with appropriate next/part of pagination.
 	StatusURIsToASRepliesPage(ctx context.Context, status *gtsmodel.Status, onlyOtherAccounts bool, minID string, replies map[string]*url.URL) (vocab.ActivityStreamsCollectionPage, error)
+	// OutboxToASCollection returns an activityStreams OUTBOX collection for the given account, with a link to the first page.
+	OutboxToASCollection(ctx context.Context, account *gtsmodel.Account) (vocab.ActivityStreamsOrderedCollection, error)
+	// StatusesToASOutboxPage returns an outbox collection page containing the given statuses, with appropriate next/prev/part of pagination.
+	//
+	// Statuses are expected to be passed in newest first. Boosts are included as Announce activities, everything else as Create.
+	StatusesToASOutboxPage(ctx context.Context, account *gtsmodel.Account, maxID string, minID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollectionPage, error)
+	// StatusesToASFeaturedCollection converts the given pinned statuses of account into an activityStreams FEATURED collection.
+	StatusesToASFeaturedCollection(ctx context.Context, account *gtsmodel.Account, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error)
 	/*
 		INTERNAL (gts) MODEL TO INTERNAL MODEL
 	*/
@@ -168,6 +233,8 @@ type TypeConverter interface {
 	FollowRequestToFollow(ctx context.Context, f *gtsmodel.FollowRequest) *gtsmodel.Follow
 	// StatusToBoost wraps the given status into a boosting status.
 	StatusToBoost(ctx context.Context, s *gtsmodel.Status, boostingAccount *gtsmodel.Account) (*gtsmodel.Status, error)
+	// StatusToStatusEdit takes a snapshot of the current version of the given status, to be stored in its edit history.
+	StatusToStatusEdit(ctx context.Context, s *gtsmodel.Status) (*gtsmodel.StatusEdit, error)
 
 	/*
 		WRAPPER CONVENIENCE FUNCTIONS
@@ -175,6 +242,10 @@ type TypeConverter interface {
 
 	// WrapPersonInUpdate
 	WrapPersonInUpdate(person vocab.ActivityStreamsPerson, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
+	// WrapNoteInUpdate wraps the given note in an Update, addressed to the same audience as the note itself.
+	WrapNoteInUpdate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
+	// WrapNoteInCreate wraps the given note in a Create, addressed to the same audience as the note itself.
+	WrapNoteInCreate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsCreate, error)
 }
 
 type converter struct {
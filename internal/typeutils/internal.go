@@ -72,8 +72,11 @@ func (c *converter) StatusToBoost(ctx context.Context, s *gtsmodel.Status, boost
 		Visibility:          s.Visibility,
 		Federated:           s.Federated,
 		Boostable:           s.Boostable,
+		BoostPolicy:         s.BoostPolicy,
 		Replyable:           s.Replyable,
+		ReplyPolicy:         s.ReplyPolicy,
 		Likeable:            s.Likeable,
+		LikePolicy:          s.LikePolicy,
 
 		// attach these here for convenience -- the boosted status/account won't go in the DB
 		// but they're needed in the processor and for the frontend. Since we have them, we can
@@ -83,3 +86,27 @@ func (c *converter) StatusToBoost(ctx context.Context, s *gtsmodel.Status, boost
 
 	return boostWrapperStatus, nil
 }
+
+func (c *converter) StatusToStatusEdit(ctx context.Context, s *gtsmodel.Status) (*gtsmodel.StatusEdit, error) {
+	editID, err := id.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	// this version of the status came into being either when it was last edited, or when it was created
+	createdAt := s.EditedAt
+	if createdAt.IsZero() {
+		createdAt = s.CreatedAt
+	}
+
+	return &gtsmodel.StatusEdit{
+		ID:             editID,
+		CreatedAt:      createdAt,
+		StatusID:       s.ID,
+		AccountID:      s.AccountID,
+		Content:        s.Content,
+		Text:           s.Text,
+		ContentWarning: s.ContentWarning,
+		Sensitive:      s.Sensitive,
+	}, nil
+}
@@ -24,11 +24,15 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Converts a gts model account into an Activity Streams person type, following
@@ -189,11 +193,28 @@ func (c *converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	// append the public key to the public key property
 	publicKeyProp.AppendW3IDSecurityV1PublicKey(publicKey)
 
+	// append the ed25519 public key too, if this account has one
+	if a.Ed25519PrivateKey != "" {
+		ed25519PublicKey, err := ed25519PublicKeyToAS(a, profileIDURI)
+		if err != nil {
+			return nil, err
+		}
+		publicKeyProp.AppendW3IDSecurityV1PublicKey(ed25519PublicKey)
+	}
+
 	// set the public key property on the Person
 	person.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
 	// tag
-	// TODO: Any tags used in the summary of this profile
+	// Any custom emojis used in the display name or summary of this profile.
+	// TODO: hashtags used in the summary
+	if len(a.Emojis) != 0 || len(a.EmojiIDs) != 0 {
+		tagProp := streams.NewActivityStreamsTagProperty()
+		if err := c.emojisToASTags(ctx, a.Emojis, a.EmojiIDs, tagProp); err != nil {
+			return nil, fmt.Errorf("AccountToAS: error converting emojis to AS emojis: %s", err)
+		}
+		person.SetActivityStreamsTag(tagProp)
+	}
 
 	// attachment
 	// Used for profile fields.
@@ -264,6 +285,26 @@ func (c *converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 		person.SetActivityStreamsImage(headerProperty)
 	}
 
+	// alsoKnownAs
+	// Other accounts that this account claims to also be; go-fed doesn't know about this property so set it by name.
+	if len(a.AlsoKnownAsURIs) != 0 {
+		aliases := make([]interface{}, 0, len(a.AlsoKnownAsURIs))
+		for _, alias := range a.AlsoKnownAsURIs {
+			aliases = append(aliases, alias)
+		}
+		person.GetUnknownProperties()[ap.PropertyAlsoKnownAs] = aliases
+	}
+
+	// movedTo
+	// The account that this account has moved to, if any.
+	if a.MovedToAccountID != "" {
+		movedTo, err := c.db.GetAccountByID(ctx, a.MovedToAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("AccountToAS: error retrieving moved to account from db: %s", err)
+		}
+		person.GetUnknownProperties()[ap.PropertyMovedTo] = movedTo.URI
+	}
+
 	return person, nil
 }
 
@@ -327,6 +368,15 @@ func (c *converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account)
 	// append the public key to the public key property
 	publicKeyProp.AppendW3IDSecurityV1PublicKey(publicKey)
 
+	// append the ed25519 public key too, if this account has one
+	if a.Ed25519PrivateKey != "" {
+		ed25519PublicKey, err := ed25519PublicKeyToAS(a, profileIDURI)
+		if err != nil {
+			return nil, err
+		}
+		publicKeyProp.AppendW3IDSecurityV1PublicKey(ed25519PublicKey)
+	}
+
 	// set the public key property on the Person
 	person.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
@@ -334,8 +384,13 @@ func (c *converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account)
 }
 
 func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.ActivityStreamsNote, error) {
+	// local-only statuses must never leave this instance
+	if !s.Federated {
+		return nil, fmt.Errorf("StatusToAS: status %s is local-only and can't be converted to AS format", s.ID)
+	}
+
 	// first check if we have this note in our asCache already
-	if noteI, err := c.asCache.Fetch(s.ID); err == nil {
+	if noteI, err := c.asCache.Fetch(statusCacheKey(s)); err == nil {
 		if note, ok := noteI.(vocab.ActivityStreamsNote); ok {
 			// we have it, so just return it as-is
 			return note, nil
@@ -399,6 +454,14 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	// only set if the status has actually been edited
+	if !s.EditedAt.IsZero() {
+		updatedProp := streams.NewActivityStreamsUpdatedProperty()
+		updatedProp.Set(s.EditedAt)
+		status.SetActivityStreamsUpdated(updatedProp)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -433,10 +496,27 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	}
 
 	// tag -- emojis
-	// TODO
+	if err := c.emojisToASTags(ctx, s.Emojis, s.EmojiIDs, tagProp); err != nil {
+		return nil, fmt.Errorf("StatusToAS: error converting emojis to AS emojis: %s", err)
+	}
 
 	// tag -- hashtags
-	// TODO
+	if s.Tags == nil {
+		for _, tagID := range s.TagIDs {
+			t := &gtsmodel.Tag{}
+			if err := c.db.GetByID(ctx, tagID, t); err != nil {
+				return nil, fmt.Errorf("StatusToAS: error getting tag %s from database: %s", tagID, err)
+			}
+			s.Tags = append(s.Tags, t)
+		}
+	}
+	for _, t := range s.Tags {
+		asHashtag, err := c.TagToAS(ctx, t)
+		if err != nil {
+			return nil, fmt.Errorf("StatusToAS: error converting tag to AS hashtag: %s", err)
+		}
+		tagProp.AppendActivityStreamsLink(asHashtag)
+	}
 
 	status.SetActivityStreamsTag(tagProp)
 
@@ -458,9 +538,9 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	case gtsmodel.VisibilityDirect:
 		// if DIRECT, then only mentioned users should be added to TO, and nothing to CC
 		for _, m := range s.Mentions {
-			iri, err := url.Parse(m.OriginAccount.URI)
+			iri, err := url.Parse(m.TargetAccount.URI)
 			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.OriginAccount.URI, err)
+				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.TargetAccount.URI, err)
 			}
 			toProp.AppendIRI(iri)
 		}
@@ -469,39 +549,61 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	case gtsmodel.VisibilityFollowersOnly:
 		// if FOLLOWERS ONLY then we want to add followers to TO, and mentions to CC
 		toProp.AppendIRI(authorFollowersURI)
-		for _, m := range s.Mentions {
-			iri, err := url.Parse(m.OriginAccount.URI)
-			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.OriginAccount.URI, err)
-			}
-			ccProp.AppendIRI(iri)
+		if err := appendMentionIRIs(s.Mentions, toProp, ccProp); err != nil {
+			return nil, fmt.Errorf("StatusToAS: %s", err)
 		}
 	case gtsmodel.VisibilityUnlocked:
 		// if UNLOCKED, we want to add followers to TO, and public and mentions to CC
 		toProp.AppendIRI(authorFollowersURI)
 		ccProp.AppendIRI(publicURI)
-		for _, m := range s.Mentions {
-			iri, err := url.Parse(m.OriginAccount.URI)
-			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.OriginAccount.URI, err)
-			}
-			ccProp.AppendIRI(iri)
+		if err := appendMentionIRIs(s.Mentions, toProp, ccProp); err != nil {
+			return nil, fmt.Errorf("StatusToAS: %s", err)
 		}
 	case gtsmodel.VisibilityPublic:
 		// if PUBLIC, we want to add public to TO, and followers and mentions to CC
 		toProp.AppendIRI(publicURI)
 		ccProp.AppendIRI(authorFollowersURI)
-		for _, m := range s.Mentions {
-			iri, err := url.Parse(m.OriginAccount.URI)
-			if err != nil {
-				return nil, fmt.Errorf("StatusToAS: error parsing uri %s: %s", m.OriginAccount.URI, err)
-			}
-			ccProp.AppendIRI(iri)
+		if err := appendMentionIRIs(s.Mentions, toProp, ccProp); err != nil {
+			return nil, fmt.Errorf("StatusToAS: %s", err)
 		}
 	}
 	status.SetActivityStreamsTo(toProp)
 	status.SetActivityStreamsCc(ccProp)
 
+	// canReply, canAnnounce, canLike -- who may interact with this status, apart from the author; each is
+	// left out altogether if anyone can interact that way, since that's what everyone assumes anyway
+	for _, p := range []struct {
+		property  string
+		permitted bool
+		policy    gtsmodel.InteractionPolicy
+	}{
+		{ap.PropertyCanReply, s.Replyable, s.ReplyPolicy},
+		{ap.PropertyCanAnnounce, s.Boostable, s.BoostPolicy},
+		{ap.PropertyCanLike, s.Likeable, s.LikePolicy},
+	} {
+		if p.permitted && (p.policy == "" || p.policy == gtsmodel.InteractionPolicyPublic) {
+			continue
+		}
+
+		can := []interface{}{s.Account.URI}
+		if p.permitted {
+			if p.policy == gtsmodel.InteractionPolicyFollowers {
+				can = append(can, s.Account.FollowersURI)
+			}
+			for _, m := range s.Mentions {
+				if m.TargetAccount == nil {
+					a, err := c.db.GetAccountByID(ctx, m.TargetAccountID)
+					if err != nil {
+						return nil, fmt.Errorf("StatusToAS: error getting mentioned account with id %s: %s", m.TargetAccountID, err)
+					}
+					m.TargetAccount = a
+				}
+				can = append(can, m.TargetAccount.URI)
+			}
+		}
+		status.GetUnknownProperties()[p.property] = can
+	}
+
 	// conversation
 	// TODO
 
@@ -517,7 +619,9 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		if err != nil {
 			return nil, fmt.Errorf("StatusToAS: error converting attachment: %s", err)
 		}
-		attachmentProp.AppendActivityStreamsDocument(doc)
+		if err := attachmentProp.AppendType(doc); err != nil {
+			return nil, fmt.Errorf("StatusToAS: error appending attachment: %s", err)
+		}
 	}
 	status.SetActivityStreamsAttachment(attachmentProp)
 
@@ -532,7 +636,7 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	status.SetActivityStreamsReplies(repliesProp)
 
 	// put the note in our cache in case we need it again soon
-	if err := c.asCache.Store(s.ID, status); err != nil {
+	if err := c.asCache.Store(statusCacheKey(s), status); err != nil {
 		return nil, err
 	}
 
@@ -622,9 +726,145 @@ func (c *converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab
 	return mention, nil
 }
 
-func (c *converter) AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachment) (vocab.ActivityStreamsDocument, error) {
-	// type -- Document
-	doc := streams.NewActivityStreamsDocument()
+/*
+	We want to end up with something like this:
+
+	{
+	"type": "Hashtag",
+	"href": "https://example.org/tags/welcome",
+	"name": "#welcome"
+	}
+*/
+func (c *converter) TagToAS(ctx context.Context, t *gtsmodel.Tag) (vocab.ActivityStreamsLink, error) {
+	// go-fed doesn't have a Hashtag type, so make a Link and give it the Hashtag type instead
+	hashtag := streams.NewActivityStreamsLink()
+
+	typeProp := streams.NewJSONLDTypeProperty()
+	typeProp.AppendXMLSchemaString(ap.TagHashtag)
+	hashtag.SetJSONLDType(typeProp)
+
+	// href -- where the tag can be found on this instance
+	tagURL, err := url.Parse(t.URL)
+	if err != nil {
+		return nil, fmt.Errorf("TagToAS: error parsing url %s: %s", t.URL, err)
+	}
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(tagURL)
+	hashtag.SetActivityStreamsHref(hrefProp)
+
+	// name -- the tag with its hash, as it appears in text
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("#" + t.Name)
+	hashtag.SetActivityStreamsName(nameProp)
+
+	return hashtag, nil
+}
+
+/*
+	We want to end up with something like this:
+
+	{
+	"id": "https://example.org/emoji/01FPSX8Z58R2CD4WGQ1DDMXB3C",
+	"type": "Emoji",
+	"name": ":rainbow:",
+	"updated": "2021-12-14T10:58:22Z",
+	"icon": {"type": "Image", "mediaType": "image/png", "url": "https://example.org/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01FPSX8Z58R2CD4WGQ1DDMXB3C.png"}
+	}
+*/
+func (c *converter) EmojiToAS(ctx context.Context, e *gtsmodel.Emoji) (vocab.TootEmoji, error) {
+	// create the emoji
+	emoji := streams.NewTootEmoji()
+
+	// id -- the activitypub uri of the emoji
+	emojiURI, err := url.Parse(e.URI)
+	if err != nil {
+		return nil, fmt.Errorf("EmojiToAS: error parsing uri %s: %s", e.URI, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(emojiURI)
+	emoji.SetJSONLDId(idProp)
+
+	// name -- the shortcode wrapped in colons, as it appears in text
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString(":" + e.Shortcode + ":")
+	emoji.SetActivityStreamsName(nameProp)
+
+	// updated -- when the image last changed
+	updatedProp := streams.NewActivityStreamsUpdatedProperty()
+	updatedProp.Set(e.ImageUpdatedAt)
+	emoji.SetActivityStreamsUpdated(updatedProp)
+
+	// icon -- the image itself, served from here
+	iconImage := streams.NewActivityStreamsImage()
+
+	mediaType := streams.NewActivityStreamsMediaTypeProperty()
+	mediaType.Set(e.ImageContentType)
+	iconImage.SetActivityStreamsMediaType(mediaType)
+
+	imageURL, err := url.Parse(e.ImageURL)
+	if err != nil {
+		return nil, fmt.Errorf("EmojiToAS: error parsing url %s: %s", e.ImageURL, err)
+	}
+	urlProp := streams.NewActivityStreamsUrlProperty()
+	urlProp.AppendIRI(imageURL)
+	iconImage.SetActivityStreamsUrl(urlProp)
+
+	iconProp := streams.NewActivityStreamsIconProperty()
+	iconProp.AppendActivityStreamsImage(iconImage)
+	emoji.SetActivityStreamsIcon(iconProp)
+
+	return emoji, nil
+}
+
+// emojisToASTags appends the given emojis to tagProp as activity streams Emojis. If emojis
+// haven't been set on the model that the ids come from, they're fetched from the database.
+func (c *converter) emojisToASTags(ctx context.Context, emojis []*gtsmodel.Emoji, emojiIDs []string, tagProp vocab.ActivityStreamsTagProperty) error {
+	if len(emojis) == 0 {
+		for _, emojiID := range emojiIDs {
+			e := &gtsmodel.Emoji{}
+			if err := c.db.GetByID(ctx, emojiID, e); err != nil {
+				return fmt.Errorf("error getting emoji %s from database: %s", emojiID, err)
+			}
+			emojis = append(emojis, e)
+		}
+	}
+
+	for _, e := range emojis {
+		asEmoji, err := c.EmojiToAS(ctx, e)
+		if err != nil {
+			return err
+		}
+		tagProp.AppendTootEmoji(asEmoji)
+	}
+
+	return nil
+}
+
+// attachmentType is the set of properties shared by the Document and Video types that attachments are serialized as.
+type attachmentType interface {
+	vocab.Type
+	SetActivityStreamsMediaType(i vocab.ActivityStreamsMediaTypeProperty)
+	SetActivityStreamsUrl(i vocab.ActivityStreamsUrlProperty)
+	SetActivityStreamsName(i vocab.ActivityStreamsNameProperty)
+	SetTootBlurhash(i vocab.TootBlurhashProperty)
+	GetUnknownProperties() map[string]interface{}
+}
+
+func (c *converter) AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachment) (vocab.Type, error) {
+	// type -- Video for videos and soundless videos, Document for everything else
+	var doc attachmentType
+	switch a.Type {
+	case gtsmodel.FileTypeVideo, gtsmodel.FileTypeGif:
+		video := streams.NewActivityStreamsVideo()
+		if a.FileMeta.Original.Duration != 0 {
+			durationProp := streams.NewActivityStreamsDurationProperty()
+			durationProp.Set(time.Duration(float64(a.FileMeta.Original.Duration) * float64(time.Second)))
+			video.SetActivityStreamsDuration(durationProp)
+		}
+		doc = video
+	default:
+		doc = streams.NewActivityStreamsDocument()
+	}
 
 	// mediaType aka mime content type
 	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
@@ -650,8 +890,10 @@ func (c *converter) AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachm
 	blurProp.Set(a.Blurhash)
 	doc.SetTootBlurhash(blurProp)
 
-	// focalpoint
-	// TODO
+	// focalpoint -- only if it's been moved away from the center
+	if a.FileMeta.Focus.X != 0 || a.FileMeta.Focus.Y != 0 {
+		doc.GetUnknownProperties()[ap.PropertyFocalPoint] = []interface{}{a.FileMeta.Focus.X, a.FileMeta.Focus.Y}
+	}
 
 	return doc, nil
 }
@@ -880,6 +1122,131 @@ func (c *converter) BlockToAS(ctx context.Context, b *gtsmodel.Block) (vocab.Act
 	return block, nil
 }
 
+func (c *converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
+	if r.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, r.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error getting report account from database: %s", err)
+		}
+		r.Account = a
+	}
+
+	if r.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, r.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error getting report target account from database: %s", err)
+		}
+		r.TargetAccount = a
+	}
+
+	// create the flag
+	flag := streams.NewActivityStreamsFlag()
+
+	// set the ID property to the report's URI
+	idProp := streams.NewJSONLDIdProperty()
+	idIRI, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", r.URI, err)
+	}
+	idProp.Set(idIRI)
+	flag.SetJSONLDId(idProp)
+
+	// set the actor property to the reporting account's URI
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorIRI, err := url.Parse(r.Account.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", r.Account.URI, err)
+	}
+	actorProp.AppendIRI(actorIRI)
+	flag.SetActivityStreamsActor(actorProp)
+
+	// set the object property to the target account's URI, followed by the URIs of any reported statuses
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	targetIRI, err := url.Parse(r.TargetAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", r.TargetAccount.URI, err)
+	}
+	objectProp.AppendIRI(targetIRI)
+	for _, statusID := range r.StatusIDs {
+		s, err := c.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error getting reported status %s from database: %s", statusID, err)
+		}
+		statusIRI, err := url.Parse(s.URI)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToASFlag: error parsing uri %s: %s", s.URI, err)
+		}
+		objectProp.AppendIRI(statusIRI)
+	}
+	flag.SetActivityStreamsObject(objectProp)
+
+	// set the TO property to the target account's IRI
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(targetIRI)
+	flag.SetActivityStreamsTo(toProp)
+
+	// set the content property to the comment, if there is one
+	if r.Comment != "" {
+		contentProp := streams.NewActivityStreamsContentProperty()
+		contentProp.AppendXMLSchemaString(r.Comment)
+		flag.SetActivityStreamsContent(contentProp)
+	}
+
+	return flag, nil
+}
+
+func (c *converter) MoveToAS(ctx context.Context, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) (vocab.ActivityStreamsMove, error) {
+	// create the move
+	move := streams.NewActivityStreamsMove()
+
+	// set the ID property to a fresh move URI
+	moveID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idString := util.GenerateURIForMove(originAccount.Username, c.config.Protocol, c.config.Host, moveID)
+	idIRI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("MoveToAS: error parsing uri %s: %s", idString, err)
+	}
+	idProp.Set(idIRI)
+	move.SetJSONLDId(idProp)
+
+	// the actor and the object are both the moving account
+	originIRI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("MoveToAS: error parsing uri %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(originIRI)
+	move.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(originIRI)
+	move.SetActivityStreamsObject(objectProp)
+
+	// the target is the account being moved to
+	targetIRI, err := url.Parse(targetAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("MoveToAS: error parsing uri %s: %s", targetAccount.URI, err)
+	}
+	targetProp := streams.NewActivityStreamsTargetProperty()
+	targetProp.AppendIRI(targetIRI)
+	move.SetActivityStreamsTarget(targetProp)
+
+	// address the move to the followers of the moving account, since they're the ones who need to act on it
+	followersIRI, err := url.Parse(originAccount.FollowersURI)
+	if err != nil {
+		return nil, fmt.Errorf("MoveToAS: error parsing uri %s: %s", originAccount.FollowersURI, err)
+	}
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(followersIRI)
+	move.SetActivityStreamsTo(toProp)
+
+	return move, nil
+}
+
 /*
 	the goal is to end up with something like this:
 
@@ -1014,3 +1381,242 @@ func (c *converter) StatusURIsToASRepliesPage(ctx context.Context, status *gtsmo
 
 	return page, nil
 }
+
+/*
+	the goal is to end up with something like this:
+	{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/whatever/outbox",
+		"type": "OrderedCollection",
+		"first": "https://example.org/users/whatever/outbox?page=true"
+	}
+*/
+func (c *converter) OutboxToASCollection(ctx context.Context, account *gtsmodel.Account) (vocab.ActivityStreamsOrderedCollection, error) {
+	collectionIDURI, err := url.Parse(account.OutboxURI)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxToASCollection: error parsing url %s: %s", account.OutboxURI, err)
+	}
+
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	// collection.id
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	collectionIDProp.SetIRI(collectionIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	// collection.first
+	firstProp := streams.NewActivityStreamsFirstProperty()
+	firstPageID, err := url.Parse(fmt.Sprintf("%s?page=true", account.OutboxURI))
+	if err != nil {
+		return nil, fmt.Errorf("OutboxToASCollection: error parsing first page url: %s", err)
+	}
+	firstProp.SetIRI(firstPageID)
+	collection.SetActivityStreamsFirst(firstProp)
+
+	return collection, nil
+}
+
+/*
+	the goal is to end up with something like this:
+	{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/whatever/outbox?page=true",
+		"type": "OrderedCollectionPage",
+		"next": "https://example.org/users/whatever/outbox?max_id=01FH57SBBQ5TZ2ZGKAYSM8RV55&page=true",
+		"prev": "https://example.org/users/whatever/outbox?min_id=01FJ1S8DX3STJJ6CEYPMZ1M0R3&page=true",
+		"partOf": "https://example.org/users/whatever/outbox",
+		"orderedItems": [
+			{
+				"id": "https://example.org/users/whatever/statuses/01FJ1S8DX3STJJ6CEYPMZ1M0R3/activity",
+				"type": "Create",
+				"object": { ... }
+			}
+		]
+	}
+*/
+func (c *converter) StatusesToASOutboxPage(ctx context.Context, account *gtsmodel.Account, maxID string, minID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollectionPage, error) {
+	collectionID := account.OutboxURI
+
+	page := streams.NewActivityStreamsOrderedCollectionPage()
+
+	// .id
+	pageIDProp := streams.NewJSONLDIdProperty()
+	pageIDString := fmt.Sprintf("%s?page=true", collectionID)
+	if maxID != "" {
+		pageIDString = fmt.Sprintf("%s&max_id=%s", pageIDString, maxID)
+	}
+	if minID != "" {
+		pageIDString = fmt.Sprintf("%s&min_id=%s", pageIDString, minID)
+	}
+
+	pageID, err := url.Parse(pageIDString)
+	if err != nil {
+		return nil, fmt.Errorf("StatusesToASOutboxPage: error parsing url %s: %s", pageIDString, err)
+	}
+	pageIDProp.SetIRI(pageID)
+	page.SetJSONLDId(pageIDProp)
+
+	// .partOf
+	collectionIDURI, err := url.Parse(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("StatusesToASOutboxPage: error parsing url %s: %s", collectionID, err)
+	}
+	partOfProp := streams.NewActivityStreamsPartOfProperty()
+	partOfProp.SetIRI(collectionIDURI)
+	page.SetActivityStreamsPartOf(partOfProp)
+
+	// .orderedItems
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, s := range statuses {
+		if s.BoostOfID != "" {
+			boostedAccount, err := c.db.GetAccountByID(ctx, s.BoostOfAccountID)
+			if err != nil {
+				return nil, fmt.Errorf("StatusesToASOutboxPage: error getting boosted account %s: %s", s.BoostOfAccountID, err)
+			}
+
+			announce, err := c.BoostToAS(ctx, s, account, boostedAccount)
+			if err != nil {
+				return nil, fmt.Errorf("StatusesToASOutboxPage: error converting boost %s: %s", s.ID, err)
+			}
+			itemsProp.AppendActivityStreamsAnnounce(announce)
+			continue
+		}
+
+		note, err := c.StatusToAS(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("StatusesToASOutboxPage: error converting status %s: %s", s.ID, err)
+		}
+
+		create, err := c.WrapNoteInCreate(note, account)
+		if err != nil {
+			return nil, fmt.Errorf("StatusesToASOutboxPage: error wrapping status %s: %s", s.ID, err)
+		}
+		itemsProp.AppendActivityStreamsCreate(create)
+	}
+	page.SetActivityStreamsOrderedItems(itemsProp)
+
+	// .next and .prev only make sense if we actually have items to page from
+	if len(statuses) != 0 {
+		nextPropIDString := fmt.Sprintf("%s?max_id=%s&page=true", collectionID, statuses[len(statuses)-1].ID)
+		nextPropID, err := url.Parse(nextPropIDString)
+		if err != nil {
+			return nil, fmt.Errorf("StatusesToASOutboxPage: error parsing url %s: %s", nextPropIDString, err)
+		}
+		nextProp := streams.NewActivityStreamsNextProperty()
+		nextProp.SetIRI(nextPropID)
+		page.SetActivityStreamsNext(nextProp)
+
+		prevPropIDString := fmt.Sprintf("%s?min_id=%s&page=true", collectionID, statuses[0].ID)
+		prevPropID, err := url.Parse(prevPropIDString)
+		if err != nil {
+			return nil, fmt.Errorf("StatusesToASOutboxPage: error parsing url %s: %s", prevPropIDString, err)
+		}
+		prevProp := streams.NewActivityStreamsPrevProperty()
+		prevProp.SetIRI(prevPropID)
+		page.SetActivityStreamsPrev(prevProp)
+	}
+
+	return page, nil
+}
+
+/*
+	the goal is to end up with something like this:
+	{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/whatever/collections/featured",
+		"type": "OrderedCollection",
+		"totalItems": 1,
+		"orderedItems": [
+			{
+				"id": "https://example.org/users/whatever/statuses/01FJ1S8DX3STJJ6CEYPMZ1M0R3",
+				"type": "Note",
+				...
+			}
+		]
+	}
+*/
+func (c *converter) StatusesToASFeaturedCollection(ctx context.Context, account *gtsmodel.Account, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error) {
+	collectionIDURI, err := url.Parse(account.FeaturedCollectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("StatusesToASFeaturedCollection: error parsing url %s: %s", account.FeaturedCollectionURI, err)
+	}
+
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	// collection.id
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	collectionIDProp.SetIRI(collectionIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	// collection.orderedItems
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, s := range statuses {
+		note, err := c.StatusToAS(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("StatusesToASFeaturedCollection: error converting status %s: %s", s.ID, err)
+		}
+		itemsProp.AppendActivityStreamsNote(note)
+	}
+	collection.SetActivityStreamsOrderedItems(itemsProp)
+
+	// collection.totalItems
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(statuses))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
+// ed25519PublicKeyToAS returns the ed25519 public key of the given local account as a publicKey
+// that can be appended to the account's publicKey property, alongside its rsa key.
+func ed25519PublicKeyToAS(a *gtsmodel.Account, owner *url.URL) (vocab.W3IDSecurityV1PublicKey, error) {
+	publicKey := streams.NewW3IDSecurityV1PublicKey()
+
+	publicKeyIDProp := streams.NewJSONLDIdProperty()
+	publicKeyURI, err := url.Parse(a.Ed25519PublicKeyURI)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyIDProp.SetIRI(publicKeyURI)
+	publicKey.SetJSONLDId(publicKeyIDProp)
+
+	publicKeyOwnerProp := streams.NewW3IDSecurityV1OwnerProperty()
+	publicKeyOwnerProp.SetIRI(owner)
+	publicKey.SetW3IDSecurityV1Owner(publicKeyOwnerProp)
+
+	privateKey, err := util.DecodeEd25519PrivateKey(a.Ed25519PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	encodedPublicKey, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	publicKeyBytes := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: encodedPublicKey,
+	})
+	publicKeyPEMProp := streams.NewW3IDSecurityV1PublicKeyPemProperty()
+	publicKeyPEMProp.Set(string(publicKeyBytes))
+	publicKey.SetW3IDSecurityV1PublicKeyPem(publicKeyPEMProp)
+
+	return publicKey, nil
+}
+
+// appendMentionIRIs adds the IRIs of the given mentions to the CC property, except for
+// mentioned groups, which go in TO instead: group actors only redistribute posts that
+// are addressed to them directly. The target account of each mention must be populated.
+func appendMentionIRIs(mentions []*gtsmodel.Mention, toProp vocab.ActivityStreamsToProperty, ccProp vocab.ActivityStreamsCcProperty) error {
+	for _, m := range mentions {
+		iri, err := url.Parse(m.TargetAccount.URI)
+		if err != nil {
+			return fmt.Errorf("error parsing uri %s: %s", m.TargetAccount.URI, err)
+		}
+		if m.TargetAccount.ActorType == ap.ActorGroup {
+			toProp.AppendIRI(iri)
+			continue
+		}
+		ccProp.AppendIRI(iri)
+	}
+	return nil
+}
@@ -27,6 +27,9 @@ import (
 	"github.com/go-fed/activity/streams"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type InternalToASTestSuite struct {
@@ -49,6 +52,148 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
 	// TODO: write assertions here, rn we're just eyeballing the output
 }
 
+func (suite *InternalToASTestSuite) TestReportToASFlag() {
+	reportingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	report := &gtsmodel.Report{
+		ID:              "01FPZ5FS0GQ1YA3R7N4ZNX8B0X",
+		URI:             "http://localhost:8080/reports/01FPZ5FS0GQ1YA3R7N4ZNX8B0X",
+		AccountID:       reportingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+		Comment:         "this account keeps posting spam",
+		Forwarded:       true,
+	}
+
+	asFlag, err := suite.typeconverter.ReportToASFlag(context.Background(), report)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asFlag)
+	suite.NoError(err)
+
+	suite.Equal("Flag", ser["type"])
+	suite.Equal(report.URI, ser["id"])
+	suite.Equal(reportingAccount.URI, ser["actor"])
+	suite.Equal(targetAccount.URI, ser["object"])
+	suite.Equal(targetAccount.URI, ser["to"])
+	suite.Equal(report.Comment, ser["content"])
+}
+
+func (suite *InternalToASTestSuite) TestStatusToASMentionedGroup() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+
+	mentionedAccount := suite.testAccounts["remote_account_1"]
+	groupAccount := &gtsmodel.Account{
+		ID:        "01FQ0M6B5V4Y4PZ0XK8C2W9D1S",
+		Username:  "some_group",
+		Domain:    "unknown-instance.com",
+		URI:       "https://unknown-instance.com/groups/some_group",
+		ActorType: ap.ActorGroup,
+	}
+	testStatus.Mentions = []*gtsmodel.Mention{
+		{TargetAccountID: mentionedAccount.ID},
+		{TargetAccountID: groupAccount.ID, TargetAccount: groupAccount},
+	}
+
+	asStatus, err := suite.typeconverter.StatusToAS(context.Background(), testStatus)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asStatus)
+	suite.NoError(err)
+
+	// the group should be addressed directly so it redistributes the post, everyone else is just cc'd
+	suite.Equal([]interface{}{"https://www.w3.org/ns/activitystreams#Public", groupAccount.URI}, ser["to"])
+	suite.Equal([]interface{}{testStatus.Account.FollowersURI, mentionedAccount.URI}, ser["cc"])
+}
+
+func (suite *InternalToASTestSuite) TestStatusToASInteractionPolicies() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.BoostPolicy = gtsmodel.InteractionPolicyFollowers
+
+	asStatus, err := suite.typeconverter.StatusToAS(context.Background(), testStatus)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asStatus)
+	suite.NoError(err)
+
+	// anyone can reply and like, so only canAnnounce should be set
+	suite.Equal([]interface{}{testStatus.Account.URI, testStatus.Account.FollowersURI}, ser["canAnnounce"])
+	suite.NotContains(ser, "canReply")
+	suite.NotContains(ser, "canLike")
+}
+
+func (suite *InternalToASTestSuite) TestStatusToASLocalOnly() {
+	testStatus := suite.testStatuses["local_account_1_status_2"]
+
+	asStatus, err := suite.typeconverter.StatusToAS(context.Background(), testStatus)
+	suite.Error(err)
+	suite.Nil(asStatus)
+}
+
+func (suite *InternalToASTestSuite) TestAttachmentToASFocalPoint() {
+	testAttachment := testrig.NewTestAttachments()["admin_account_status_1_attachment_1"]
+	testAttachment.FileMeta.Focus = gtsmodel.Focus{X: -0.5, Y: 0.25}
+
+	asAttachment, err := suite.typeconverter.AttachmentToAS(context.Background(), testAttachment)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asAttachment)
+	suite.NoError(err)
+	suite.Equal([]interface{}{float32(-0.5), float32(0.25)}, ser["focalPoint"])
+}
+
+func (suite *InternalToASTestSuite) TestAttachmentToASVideo() {
+	testAttachment := testrig.NewTestAttachments()["admin_account_status_1_attachment_1"]
+	testAttachment.Type = gtsmodel.FileTypeVideo
+	testAttachment.File.ContentType = "video/mp4"
+	testAttachment.FileMeta.Original.Duration = 12
+
+	asAttachment, err := suite.typeconverter.AttachmentToAS(context.Background(), testAttachment)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asAttachment)
+	suite.NoError(err)
+	suite.Equal("Video", ser["type"])
+	suite.Equal("video/mp4", ser["mediaType"])
+	suite.Equal("PT12S", ser["duration"])
+}
+
+func (suite *InternalToASTestSuite) TestStatusToASWithEmojiAndHashtag() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+
+	asStatus, err := suite.typeconverter.StatusToAS(context.Background(), testStatus)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asStatus)
+	suite.NoError(err)
+
+	tags, ok := ser["tag"].([]interface{})
+	suite.True(ok)
+	suite.Len(tags, 2)
+
+	emoji, ok := tags[0].(map[string]interface{})
+	suite.True(ok)
+	suite.Equal(map[string]interface{}{
+		"type":    "Emoji",
+		"id":      "http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ",
+		"name":    ":rainbow:",
+		"updated": emoji["updated"],
+		"icon": map[string]interface{}{
+			"type":      "Image",
+			"mediaType": "image/png",
+			"url":       "http://localhost:8080/fileserver/01F8MH261H1KSV3GW3016GZRY3/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png",
+		},
+	}, emoji)
+
+	suite.Equal(map[string]interface{}{
+		"type": "Hashtag",
+		"href": "http://localhost:8080/tags/welcome",
+		"name": "#welcome",
+	}, tags[1])
+}
+
 func TestInternalToASTestSuite(t *testing.T) {
 	suite.Run(t, new(InternalToASTestSuite))
 }
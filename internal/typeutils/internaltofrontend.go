@@ -21,12 +21,18 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (c *converter) AccountToMastoSensitive(ctx context.Context, a *gtsmodel.Account) (*model.Account, error) {
@@ -39,21 +45,16 @@ func (c *converter) AccountToMastoSensitive(ctx context.Context, a *gtsmodel.Acc
 	// then adding the Source object to it...
 
 	// check pending follow requests aimed at this account
-	frs, err := c.db.GetAccountFollowRequests(ctx, a.ID)
+	frc, err := c.db.CountAccountFollowRequests(ctx, a.ID)
 	if err != nil {
-		if err != db.ErrNoEntries {
-			return nil, fmt.Errorf("error getting follow requests: %s", err)
-		}
-	}
-	var frc int
-	if frs != nil {
-		frc = len(frs)
+		return nil, fmt.Errorf("error counting follow requests: %s", err)
 	}
 
 	mastoAccount.Source = &model.Source{
 		Privacy:             c.VisToMasto(ctx, a.Privacy),
 		Sensitive:           a.Sensitive,
 		Language:            a.Language,
+		PrivateBlocks:       a.PrivateBlocks,
 		Note:                a.Note,
 		Fields:              mastoAccount.Fields,
 		FollowRequestsCount: frc,
@@ -136,8 +137,30 @@ func (c *converter) AccountToMastoPublic(ctx context.Context, a *gtsmodel.Accoun
 		fields = append(fields, mField)
 	}
 
+	// the account might not have gts emojis on it yet, in which case pull them from the db by id
 	emojis := []model.Emoji{}
-	// TODO: account emojis
+	gtsEmojis := a.Emojis
+	if len(gtsEmojis) == 0 {
+		for _, e := range a.EmojiIDs {
+			gtsEmoji := &gtsmodel.Emoji{}
+			if err := c.db.GetByID(ctx, e, gtsEmoji); err != nil {
+				c.log.WithError(err).WithField("emojiID", e).Error("error getting emoji")
+				continue
+			}
+			gtsEmojis = append(gtsEmojis, gtsEmoji)
+		}
+	}
+	for _, gtsEmoji := range gtsEmojis {
+		if gtsEmoji.Disabled {
+			continue
+		}
+		mastoEmoji, err := c.EmojiToMasto(ctx, gtsEmoji)
+		if err != nil {
+			c.log.WithError(err).WithField("emojiID", gtsEmoji.ID).Error("error converting emoji")
+			continue
+		}
+		emojis = append(emojis, mastoEmoji)
+	}
 
 	var acct string
 	if a.Domain != "" {
@@ -160,6 +183,7 @@ func (c *converter) AccountToMastoPublic(ctx context.Context, a *gtsmodel.Accoun
 		DisplayName:    a.DisplayName,
 		Locked:         a.Locked,
 		Bot:            a.Bot,
+		Group:          a.ActorType == ap.ActorGroup,
 		CreatedAt:      a.CreatedAt.Format(time.RFC3339),
 		Note:           a.Note,
 		URL:            a.URL,
@@ -171,9 +195,31 @@ func (c *converter) AccountToMastoPublic(ctx context.Context, a *gtsmodel.Accoun
 		FollowingCount: followingCount,
 		StatusesCount:  statusesCount,
 		LastStatusAt:   lastStatusAt,
-		Emojis:         emojis, // TODO: implement this
+		Emojis:         emojis,
 		Fields:         fields,
 		Suspended:      suspended,
+		EnableRSS:      a.EnableRSS,
+	}
+
+	if c.config.AccountsConfig.AllowCustomCSS {
+		accountFrontend.CustomCSS = a.CustomCSS
+	}
+
+	if a.MovedToAccountID != "" {
+		movedTo, err := c.db.GetAccountByID(ctx, a.MovedToAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving moved to account: %s", err)
+		}
+
+		// only show one hop of a chain of moves, otherwise
+		// two accounts moved to each other would loop forever
+		movedToCopy := *movedTo
+		movedToCopy.MovedToAccountID = ""
+
+		accountFrontend.Moved, err = c.AccountToMastoPublic(ctx, &movedToCopy)
+		if err != nil {
+			return nil, fmt.Errorf("error converting moved to account: %s", err)
+		}
 	}
 
 	return accountFrontend, nil
@@ -200,6 +246,7 @@ func (c *converter) AccountToMastoBlocked(ctx context.Context, a *gtsmodel.Accou
 		Acct:        acct,
 		DisplayName: a.DisplayName,
 		Bot:         a.Bot,
+		Group:       a.ActorType == ap.ActorGroup,
 		CreatedAt:   a.CreatedAt.Format(time.RFC3339),
 		URL:         a.URL,
 		Suspended:   suspended,
@@ -225,19 +272,35 @@ func (c *converter) AppToMastoPublic(ctx context.Context, a *gtsmodel.Applicatio
 }
 
 func (c *converter) AttachmentToMasto(ctx context.Context, a *gtsmodel.MediaAttachment) (model.Attachment, error) {
+	attachmentType := strings.ToLower(string(a.Type))
+	if a.Type == gtsmodel.FileTypeGif {
+		// the client api calls soundless looping videos 'gifv'
+		attachmentType = "gifv"
+	}
+
+	var frameRate string
+	if a.FileMeta.Original.Framerate != 0 {
+		frameRate = strconv.FormatFloat(float64(a.FileMeta.Original.Framerate), 'f', -1, 32)
+	}
+
 	return model.Attachment{
 		ID:               a.ID,
-		Type:             strings.ToLower(string(a.Type)),
+		Type:             attachmentType,
 		URL:              a.URL,
 		PreviewURL:       a.Thumbnail.URL,
 		RemoteURL:        a.RemoteURL,
 		PreviewRemoteURL: a.Thumbnail.RemoteURL,
 		Meta: model.MediaMeta{
+			Duration: a.FileMeta.Original.Duration,
+			FPS:      uint16(math.Round(float64(a.FileMeta.Original.Framerate))),
 			Original: model.MediaDimensions{
-				Width:  a.FileMeta.Original.Width,
-				Height: a.FileMeta.Original.Height,
-				Size:   fmt.Sprintf("%dx%d", a.FileMeta.Original.Width, a.FileMeta.Original.Height),
-				Aspect: float32(a.FileMeta.Original.Aspect),
+				Width:     a.FileMeta.Original.Width,
+				Height:    a.FileMeta.Original.Height,
+				FrameRate: frameRate,
+				Duration:  a.FileMeta.Original.Duration,
+				Bitrate:   int(a.FileMeta.Original.Bitrate),
+				Size:      fmt.Sprintf("%dx%d", a.FileMeta.Original.Width, a.FileMeta.Original.Height),
+				Aspect:    float32(a.FileMeta.Original.Aspect),
 			},
 			Small: model.MediaDimensions{
 				Width:  a.FileMeta.Small.Width,
@@ -380,7 +443,7 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, gtsAttachment := range s.Attachments {
 			mastoAttachment, err := c.AttachmentToMasto(ctx, gtsAttachment)
 			if err != nil {
-				l.Errorf("error converting attachment with id %s: %s", gtsAttachment.ID, err)
+				l.WithError(err).WithField("attachmentID", gtsAttachment.ID).Error("error converting attachment")
 				continue
 			}
 			mastoAttachments = append(mastoAttachments, mastoAttachment)
@@ -391,12 +454,12 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, aID := range s.AttachmentIDs {
 			gtsAttachment, err := c.db.GetAttachmentByID(ctx, aID)
 			if err != nil {
-				l.Errorf("error getting attachment with id %s: %s", aID, err)
+				l.WithError(err).WithField("attachmentID", aID).Error("error getting attachment")
 				continue
 			}
 			mastoAttachment, err := c.AttachmentToMasto(ctx, gtsAttachment)
 			if err != nil {
-				l.Errorf("error converting attachment with id %s: %s", aID, err)
+				l.WithError(err).WithField("attachmentID", aID).Error("error converting attachment")
 				continue
 			}
 			mastoAttachments = append(mastoAttachments, mastoAttachment)
@@ -410,7 +473,7 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, gtsMention := range s.Mentions {
 			mastoMention, err := c.MentionToMasto(ctx, gtsMention)
 			if err != nil {
-				l.Errorf("error converting mention with id %s: %s", gtsMention.ID, err)
+				l.WithError(err).WithField("mentionID", gtsMention.ID).Error("error converting mention")
 				continue
 			}
 			mastoMentions = append(mastoMentions, mastoMention)
@@ -421,12 +484,12 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, mID := range s.MentionIDs {
 			gtsMention, err := c.db.GetMention(ctx, mID)
 			if err != nil {
-				l.Errorf("error getting mention with id %s: %s", mID, err)
+				l.WithError(err).WithField("mentionID", mID).Error("error getting mention")
 				continue
 			}
 			mastoMention, err := c.MentionToMasto(ctx, gtsMention)
 			if err != nil {
-				l.Errorf("error converting mention with id %s: %s", gtsMention.ID, err)
+				l.WithError(err).WithField("mentionID", gtsMention.ID).Error("error converting mention")
 				continue
 			}
 			mastoMentions = append(mastoMentions, mastoMention)
@@ -440,7 +503,7 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, gtsTag := range s.Tags {
 			mastoTag, err := c.TagToMasto(ctx, gtsTag)
 			if err != nil {
-				l.Errorf("error converting tag with id %s: %s", gtsTag.ID, err)
+				l.WithError(err).WithField("tagID", gtsTag.ID).Error("error converting tag")
 				continue
 			}
 			mastoTags = append(mastoTags, mastoTag)
@@ -451,12 +514,12 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, t := range s.TagIDs {
 			gtsTag := &gtsmodel.Tag{}
 			if err := c.db.GetByID(ctx, t, gtsTag); err != nil {
-				l.Errorf("error getting tag with id %s: %s", t, err)
+				l.WithError(err).WithField("tagID", t).Error("error getting tag")
 				continue
 			}
 			mastoTag, err := c.TagToMasto(ctx, gtsTag)
 			if err != nil {
-				l.Errorf("error converting tag with id %s: %s", gtsTag.ID, err)
+				l.WithError(err).WithField("tagID", gtsTag.ID).Error("error converting tag")
 				continue
 			}
 			mastoTags = append(mastoTags, mastoTag)
@@ -470,7 +533,7 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, gtsEmoji := range s.Emojis {
 			mastoEmoji, err := c.EmojiToMasto(ctx, gtsEmoji)
 			if err != nil {
-				l.Errorf("error converting emoji with id %s: %s", gtsEmoji.ID, err)
+				l.WithError(err).WithField("emojiID", gtsEmoji.ID).Error("error converting emoji")
 				continue
 			}
 			mastoEmojis = append(mastoEmojis, mastoEmoji)
@@ -481,20 +544,58 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		for _, e := range s.EmojiIDs {
 			gtsEmoji := &gtsmodel.Emoji{}
 			if err := c.db.GetByID(ctx, e, gtsEmoji); err != nil {
-				l.Errorf("error getting emoji with id %s: %s", e, err)
+				l.WithError(err).WithField("emojiID", e).Error("error getting emoji")
 				continue
 			}
 			mastoEmoji, err := c.EmojiToMasto(ctx, gtsEmoji)
 			if err != nil {
-				l.Errorf("error converting emoji with id %s: %s", gtsEmoji.ID, err)
+				l.WithError(err).WithField("emojiID", gtsEmoji.ID).Error("error converting emoji")
 				continue
 			}
 			mastoEmojis = append(mastoEmojis, mastoEmoji)
 		}
 	}
 
+	reactions, err := c.db.GetStatusReactions(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("error getting emoji reactions: %s", err)
+	}
+
+	// group the reactions by emoji, keeping the order in which each emoji was first used
+	mastoReactions := []model.StatusReaction{}
+	reactionIndexes := make(map[string]int)
+	for _, r := range reactions {
+		i, ok := reactionIndexes[r.Content]
+		if !ok {
+			i = len(mastoReactions)
+			reactionIndexes[r.Content] = i
+			mastoReactions = append(mastoReactions, model.StatusReaction{Name: r.Content})
+		}
+		mastoReactions[i].Count++
+		if requestingAccount != nil && r.AccountID == requestingAccount.ID {
+			mastoReactions[i].Me = true
+		}
+	}
+
 	var mastoCard *model.Card
+
 	var mastoPoll *model.Poll
+	poll := s.Poll
+	if poll == nil {
+		poll, err = c.db.GetStatusPoll(ctx, s.ID)
+		if err != nil && err != db.ErrNoEntries {
+			return nil, fmt.Errorf("error getting poll of status %s: %s", s.ID, err)
+		}
+	}
+	if poll != nil {
+		if poll.Status == nil {
+			poll.Status = s
+		}
+		mastoPoll, err = c.PollToMasto(ctx, poll, requestingAccount)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	statusInteractions := &statusInteractions{}
 	si, err := c.interactionsWithStatusForAccount(ctx, s, requestingAccount)
@@ -510,6 +611,7 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		Sensitive:          s.Sensitive,
 		SpoilerText:        s.ContentWarning,
 		Visibility:         c.VisToMasto(ctx, s.Visibility),
+		LocalOnly:          !s.Federated,
 		Language:           s.Language,
 		URI:                s.URI,
 		URL:                s.URL,
@@ -528,11 +630,16 @@ func (c *converter) StatusToMasto(ctx context.Context, s *gtsmodel.Status, reque
 		Mentions:           mastoMentions,
 		Tags:               mastoTags,
 		Emojis:             mastoEmojis,
+		EmojiReactions:     mastoReactions,
 		Card:               mastoCard, // TODO: implement cards
-		Poll:               mastoPoll, // TODO: implement polls
+		Poll:               mastoPoll,
 		Text:               s.Text,
 	}
 
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = s.EditedAt.Format(time.RFC3339)
+	}
+
 	if mastoRebloggedStatus != nil {
 		apiStatus.Reblog = &model.StatusReblogged{Status: mastoRebloggedStatus}
 	}
@@ -563,8 +670,10 @@ func (c *converter) InstanceToMasto(ctx context.Context, i *gtsmodel.Instance) (
 		ShortDescription: i.ShortDescription,
 		Email:            i.ContactEmail,
 		Version:          i.Version,
+		CustomCSS:        i.CustomCSS,
 		Stats:            make(map[string]int),
 		ContactAccount:   &model.Account{},
+		Rules:            []model.Rule{},
 	}
 
 	// if the requested instance is *this* instance, we can add some extra information
@@ -589,13 +698,35 @@ func (c *converter) InstanceToMasto(ctx context.Context, i *gtsmodel.Instance) (
 		}
 
 		mi.Registrations = c.config.AccountsConfig.OpenRegistration
-		mi.ApprovalRequired = c.config.AccountsConfig.RequireApproval
+		mi.ApprovalRequired = c.config.AccountsConfig.RequireApproval || !c.config.AccountsConfig.OpenRegistration
 		mi.InvitesEnabled = false // TODO
 		mi.MaxTootChars = uint(c.config.StatusesConfig.MaxChars)
 		mi.URLS = &model.InstanceURLs{
 			StreamingAPI: fmt.Sprintf("wss://%s", c.config.Host),
 		}
 		mi.Version = c.config.SoftwareVersion
+		if c.config.CaptchaConfig.Provider != "" {
+			mi.Captcha = &model.InstanceCaptcha{
+				Provider: c.config.CaptchaConfig.Provider,
+				SiteKey:  c.config.CaptchaConfig.SiteKey,
+				URL:      c.config.CaptchaConfig.URL,
+			}
+		}
+
+		pages := []*gtsmodel.InstancePage{}
+		if err := c.db.GetAll(ctx, &pages); err == nil {
+			sort.Slice(pages, func(i, j int) bool { return pages[i].Slug < pages[j].Slug })
+			for _, p := range pages {
+				mi.Pages = append(mi.Pages, model.InstancePageLink{
+					Slug:  p.Slug,
+					Title: p.Title,
+					URL:   util.GenerateURLForInstancePage(c.config.Protocol, c.config.Host, p.Slug),
+				})
+			}
+		}
+
+		mi.Rules = c.instanceRules(ctx)
+		mi.Configuration = c.instanceConfiguration()
 	}
 
 	// get the instance account if it exists and just skip if it doesn't
@@ -623,6 +754,127 @@ func (c *converter) InstanceToMasto(ctx context.Context, i *gtsmodel.Instance) (
 	return mi, nil
 }
 
+func (c *converter) InstanceToMastoV2(ctx context.Context, i *gtsmodel.Instance) (*model.InstanceV2, error) {
+	mi := &model.InstanceV2{
+		Domain:        i.Domain,
+		Title:         i.Title,
+		Version:       c.config.SoftwareVersion,
+		SourceURL:     "https://github.com/superseriousbusiness/gotosocial",
+		Description:   i.ShortDescription,
+		Languages:     []string{},
+		Configuration: *c.instanceConfiguration(),
+		Registrations: model.InstanceV2Registrations{
+			Enabled:          c.config.AccountsConfig.OpenRegistration,
+			ApprovalRequired: c.config.AccountsConfig.RequireApproval || !c.config.AccountsConfig.OpenRegistration,
+		},
+		Contact: model.InstanceV2Contact{
+			Email: i.ContactEmail,
+		},
+		Rules: c.instanceRules(ctx),
+	}
+
+	mi.Configuration.URLs = &model.InstanceConfigurationURLs{
+		Streaming: fmt.Sprintf("wss://%s", c.config.Host),
+	}
+
+	activeUsers, err := c.db.CountInstanceActiveUsers(ctx, time.Now().AddDate(0, 0, -30))
+	if err == nil {
+		mi.Usage.Users.ActiveMonth = activeUsers
+	}
+
+	if c.config.CaptchaConfig.Provider != "" {
+		mi.Captcha = &model.InstanceCaptcha{
+			Provider: c.config.CaptchaConfig.Provider,
+			SiteKey:  c.config.CaptchaConfig.SiteKey,
+			URL:      c.config.CaptchaConfig.URL,
+		}
+	}
+
+	pages := []*gtsmodel.InstancePage{}
+	if err := c.db.GetAll(ctx, &pages); err == nil {
+		sort.Slice(pages, func(i, j int) bool { return pages[i].Slug < pages[j].Slug })
+		for _, p := range pages {
+			mi.Pages = append(mi.Pages, model.InstancePageLink{
+				Slug:  p.Slug,
+				Title: p.Title,
+				URL:   util.GenerateURLForInstancePage(c.config.Protocol, c.config.Host, p.Slug),
+			})
+		}
+	}
+
+	// the thumbnail is the header of the instance account, if it has one
+	ia, err := c.db.GetInstanceAccount(ctx, "")
+	if err == nil && ia.HeaderMediaAttachment != nil {
+		mi.Thumbnail.URL = ia.HeaderMediaAttachment.URL
+		mi.Thumbnail.Blurhash = ia.HeaderMediaAttachment.Blurhash
+	}
+
+	// contact account is optional but let's try to get it
+	if i.ContactAccountID != "" {
+		if i.ContactAccount == nil {
+			contactAccount, err := c.db.GetAccountByID(ctx, i.ContactAccountID)
+			if err == nil {
+				i.ContactAccount = contactAccount
+			}
+		}
+		if i.ContactAccount != nil {
+			ma, err := c.AccountToMastoPublic(ctx, i.ContactAccount)
+			if err == nil {
+				mi.Contact.Account = ma
+			}
+		}
+	}
+
+	return mi, nil
+}
+
+// instanceConfiguration returns the limits of this instance, as set in the config.
+func (c *converter) instanceConfiguration() *model.InstanceConfiguration {
+	return &model.InstanceConfiguration{
+		Statuses: model.InstanceConfigurationStatuses{
+			MaxCharacters:               c.config.StatusesConfig.MaxChars,
+			MaxContentWarningCharacters: c.config.StatusesConfig.CWMaxChars,
+			MaxMediaAttachments:         c.config.StatusesConfig.MaxMediaFiles,
+		},
+		MediaAttachments: model.InstanceConfigurationMediaAttachments{
+			SupportedMimeTypes: append(append([]string{}, media.SupportedImageTypes...), media.SupportedVideoTypes...),
+			ImageSizeLimit:     c.config.MediaConfig.MaxImageSize,
+			VideoSizeLimit:     c.config.MediaConfig.MaxVideoSize,
+			DescriptionLimit:   c.config.MediaConfig.MaxDescriptionChars,
+		},
+		Polls: model.InstanceConfigurationPolls{
+			MaxOptions:             c.config.StatusesConfig.PollMaxOptions,
+			MaxCharactersPerOption: c.config.StatusesConfig.PollOptionMaxChars,
+		},
+	}
+}
+
+// instanceRules returns the rules of this instance, or an empty slice if they can't be fetched.
+func (c *converter) instanceRules(ctx context.Context) []model.Rule {
+	rules := []model.Rule{}
+
+	gtsRules, err := c.db.GetInstanceRules(ctx)
+	if err != nil {
+		return rules
+	}
+
+	for _, r := range gtsRules {
+		if mr, err := c.RuleToMasto(ctx, r); err == nil {
+			rules = append(rules, *mr)
+		}
+	}
+
+	return rules
+}
+
+func (c *converter) RuleToMasto(ctx context.Context, r *gtsmodel.Rule) (*model.Rule, error) {
+	return &model.Rule{
+		ID:   r.ID,
+		Text: r.Text,
+		Hint: r.Hint,
+	}, nil
+}
+
 func (c *converter) RelationshipToMasto(ctx context.Context, r *gtsmodel.Relationship) (*model.Relationship, error) {
 	return &model.Relationship{
 		ID:                  r.ID,
@@ -688,12 +940,127 @@ func (c *converter) NotificationToMasto(ctx context.Context, n *gtsmodel.Notific
 		}
 	}
 
+	var mastoReport *model.AdminReportInfo
+	var mastoResolvedReport *model.Report
+	if n.ReportID != "" {
+		if n.Report == nil {
+			report := &gtsmodel.Report{}
+			if err := c.db.GetByID(ctx, n.ReportID, report); err != nil {
+				return nil, fmt.Errorf("NotificationToMasto: error getting report with id %s from the db: %s", n.ReportID, err)
+			}
+			n.Report = report
+		}
+
+		var err error
+		if n.NotificationType == gtsmodel.NotificationReportResolved {
+			// the reporter only gets to see their own view of the report
+			mastoResolvedReport, err = c.ReportToMasto(ctx, n.Report)
+		} else {
+			mastoReport, err = c.ReportToAdminMasto(ctx, n.Report, n.TargetAccount)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("NotificationToMasto: error converting report to masto: %s", err)
+		}
+	}
+
 	return &model.Notification{
-		ID:        n.ID,
-		Type:      string(n.NotificationType),
-		CreatedAt: n.CreatedAt.Format(time.RFC3339),
-		Account:   mastoAccount,
-		Status:    mastoStatus,
+		ID:             n.ID,
+		Type:           string(n.NotificationType),
+		CreatedAt:      n.CreatedAt.Format(time.RFC3339),
+		Account:        mastoAccount,
+		Status:         mastoStatus,
+		Report:         mastoReport,
+		ResolvedReport: mastoResolvedReport,
+	}, nil
+}
+
+func (c *converter) PollToMasto(ctx context.Context, p *gtsmodel.Poll, requestingAccount *gtsmodel.Account) (*model.Poll, error) {
+	votes, err := c.db.GetPollVotes(ctx, p.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("error getting votes of poll %s: %s", p.ID, err)
+	}
+
+	counts := make([]int, len(p.Options))
+	voters := map[string]bool{}
+	ownVotes := []int{}
+	for _, v := range votes {
+		if v.Choice < 0 || v.Choice >= len(counts) {
+			continue
+		}
+		counts[v.Choice]++
+		voters[v.AccountID] = true
+		if requestingAccount != nil && v.AccountID == requestingAccount.ID {
+			ownVotes = append(ownVotes, v.Choice)
+		}
+	}
+	sort.Ints(ownVotes)
+
+	expired := p.Expired()
+	mastoPoll := &model.Poll{
+		ID:         p.ID,
+		Expired:    expired,
+		Multiple:   p.Multiple,
+		VotesCount: len(votes),
+		Options:    make([]model.PollOptions, len(p.Options)),
+		Emojis:     []model.Emoji{},
+	}
+
+	if !p.ExpiresAt.IsZero() {
+		mastoPoll.ExpiresAt = p.ExpiresAt.Format(time.RFC3339)
+	}
+
+	if p.Multiple {
+		mastoPoll.VotersCount = len(voters)
+	}
+
+	if requestingAccount != nil {
+		mastoPoll.Voted = len(ownVotes) != 0
+		mastoPoll.OwnVotes = ownVotes
+	}
+
+	// counts are hidden until the poll has ended if the author wants, though the author can always see them
+	showCounts := !p.HideCounts || expired || (requestingAccount != nil && p.Status != nil && p.Status.AccountID == requestingAccount.ID)
+	for i, o := range p.Options {
+		mastoPoll.Options[i].Title = o
+		if showCounts {
+			count := counts[i]
+			mastoPoll.Options[i].VotesCount = &count
+		}
+	}
+
+	return mastoPoll, nil
+}
+
+func (c *converter) NotificationPreferencesToMasto(ctx context.Context, p *gtsmodel.NotificationPreferences) (*model.NotificationPreferences, error) {
+	return &model.NotificationPreferences{
+		Follow:         p.Follow,
+		FollowRequest:  p.FollowRequest,
+		FollowReject:   p.FollowReject,
+		Mention:        p.Mention,
+		Reblog:         p.Reblog,
+		Favourite:      p.Favourite,
+		Poll:           p.Poll,
+		Status:         p.Status,
+		AdminReport:    p.AdminReport,
+		ReportResolved: p.ReportResolved,
+		AdminSignUp:    p.AdminSignUp,
+	}, nil
+}
+
+func (c *converter) WebPushSubscriptionToMasto(ctx context.Context, s *gtsmodel.WebPushSubscription) (*model.PushSubscription, error) {
+	return &model.PushSubscription{
+		ID:       s.ID,
+		Endpoint: s.Endpoint,
+		Alerts: &model.PushSubscriptionAlerts{
+			Follow:        s.AlertFollow,
+			FollowRequest: s.AlertFollowRequest,
+			Favourite:     s.AlertFavourite,
+			Mention:       s.AlertMention,
+			Reblog:        s.AlertReblog,
+			Poll:          s.AlertPoll,
+			Status:        s.AlertStatus,
+		},
+		Policy: string(s.Policy),
 	}, nil
 }
 
@@ -702,12 +1069,20 @@ func (c *converter) DomainBlockToMasto(ctx context.Context, b *gtsmodel.DomainBl
 	domainBlock := &model.DomainBlock{
 		Domain:        b.Domain,
 		PublicComment: b.PublicComment,
+		Severity:      string(b.Severity),
+		RejectMedia:   b.RejectMedia,
+		RejectReports: b.RejectReports,
+		Obfuscate:     b.Obfuscate,
+	}
+
+	if domainBlock.Severity == "" {
+		// the db defaults an unset severity to suspend
+		domainBlock.Severity = string(gtsmodel.DomainBlockSeveritySuspend)
 	}
 
 	// if we're exporting a domain block, return it with minimal information attached
 	if !export {
 		domainBlock.ID = b.ID
-		domainBlock.Obfuscate = b.Obfuscate
 		domainBlock.PrivateComment = b.PrivateComment
 		domainBlock.SubscriptionID = b.SubscriptionID
 		domainBlock.CreatedBy = b.CreatedByAccountID
@@ -716,3 +1091,603 @@ func (c *converter) DomainBlockToMasto(ctx context.Context, b *gtsmodel.DomainBl
 
 	return domainBlock, nil
 }
+
+func (c *converter) DomainAllowToMasto(ctx context.Context, a *gtsmodel.DomainAllow) (*model.DomainAllow, error) {
+	return &model.DomainAllow{
+		ID:             a.ID,
+		Domain:         a.Domain,
+		PrivateComment: a.PrivateComment,
+		PublicComment:  a.PublicComment,
+		CreatedBy:      a.CreatedByAccountID,
+		CreatedAt:      a.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (c *converter) DomainPolicyToMasto(ctx context.Context, p *gtsmodel.DomainPolicy) (*model.DomainPolicy, error) {
+	return &model.DomainPolicy{
+		ID:             p.ID,
+		Domain:         p.Domain,
+		RejectMedia:    p.RejectMedia,
+		SensitiveMedia: p.SensitiveMedia,
+		RejectBoosts:   p.RejectBoosts,
+		Silence:        p.Silence,
+		PrivateComment: p.PrivateComment,
+		PublicComment:  p.PublicComment,
+		CreatedBy:      p.CreatedByAccountID,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      p.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (c *converter) DeliveryReceiptToMasto(ctx context.Context, r *gtsmodel.DeliveryReceipt) (*model.DeliveryReceipt, error) {
+	return &model.DeliveryReceipt{
+		ID:           r.ID,
+		ActivityURI:  r.ActivityURI,
+		ActivityType: r.ActivityType,
+		ObjectURI:    r.ObjectURI,
+		Domain:       r.Domain,
+		InboxURI:     r.InboxURI,
+		Delivered:    r.Delivered,
+		Attempts:     r.Attempts,
+		LastError:    r.LastError,
+		CreatedAt:    r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    r.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (c *converter) SpamFlagToMasto(ctx context.Context, f *gtsmodel.SpamFlag, requestingAccount *gtsmodel.Account) (*model.SpamFlag, error) {
+	if f.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, f.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account %s: %s", f.AccountID, err)
+		}
+		f.Account = a
+	}
+
+	if f.Status == nil {
+		s, err := c.db.GetStatusByID(ctx, f.StatusID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting status %s: %s", f.StatusID, err)
+		}
+		f.Status = s
+	}
+
+	mastoAccount, err := c.AccountToMastoPublic(ctx, f.Account)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account %s: %s", f.AccountID, err)
+	}
+
+	mastoStatus, err := c.StatusToMasto(ctx, f.Status, requestingAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error converting status %s: %s", f.StatusID, err)
+	}
+
+	reasons := f.Reasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+
+	return &model.SpamFlag{
+		ID:        f.ID,
+		CreatedAt: f.CreatedAt.Format(time.RFC3339),
+		Action:    string(f.Action),
+		Score:     f.Score,
+		Reasons:   reasons,
+		Account:   mastoAccount,
+		Status:    mastoStatus,
+	}, nil
+}
+
+func (c *converter) ReportToAdminMasto(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*model.AdminReportInfo, error) {
+	if r.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, r.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account %s: %s", r.AccountID, err)
+		}
+		r.Account = a
+	}
+
+	if r.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, r.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting target account %s: %s", r.TargetAccountID, err)
+		}
+		r.TargetAccount = a
+	}
+
+	mastoAccount, err := c.AccountToMastoPublic(ctx, r.Account)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account %s: %s", r.AccountID, err)
+	}
+
+	mastoTargetAccount, err := c.AccountToMastoPublic(ctx, r.TargetAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error converting target account %s: %s", r.TargetAccountID, err)
+	}
+
+	mastoStatuses := []model.Status{}
+	for _, statusID := range r.StatusIDs {
+		s, err := c.db.GetStatusByID(ctx, statusID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// the status has been deleted since it was reported
+				continue
+			}
+			return nil, fmt.Errorf("error getting status %s: %s", statusID, err)
+		}
+
+		mastoStatus, err := c.StatusToMasto(ctx, s, requestingAccount)
+		if err != nil {
+			return nil, fmt.Errorf("error converting status %s: %s", statusID, err)
+		}
+		mastoStatuses = append(mastoStatuses, *mastoStatus)
+	}
+
+	mastoRules := []model.Rule{}
+	for _, ruleID := range r.RuleIDs {
+		rule := &gtsmodel.Rule{}
+		if err := c.db.GetByID(ctx, ruleID, rule); err != nil {
+			if err == db.ErrNoEntries {
+				// the rule has been removed since it was reported
+				continue
+			}
+			return nil, fmt.Errorf("error getting rule %s: %s", ruleID, err)
+		}
+
+		mastoRule, err := c.RuleToMasto(ctx, rule)
+		if err != nil {
+			return nil, fmt.Errorf("error converting rule %s: %s", ruleID, err)
+		}
+		mastoRules = append(mastoRules, *mastoRule)
+	}
+
+	violations, err := c.ruleViolations(ctx, r.TargetAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	mastoReport := &model.AdminReportInfo{
+		ID:                      r.ID,
+		ActionTaken:             r.ActionTaken,
+		Category:                string(r.Category),
+		Comment:                 r.Comment,
+		CreatedAt:               r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:               r.UpdatedAt.Format(time.RFC3339),
+		Account:                 mastoAccount,
+		TargetAccount:           mastoTargetAccount,
+		Statuses:                mastoStatuses,
+		Rules:                   mastoRules,
+		TargetAccountViolations: violations,
+	}
+
+	if r.ActionTaken {
+		mastoReport.ActionTakenAt = r.ActionTakenAt.Format(time.RFC3339)
+		mastoReport.ActionTakenComment = r.ActionTakenComment
+	}
+
+	if r.ActionTakenByAccountID != "" {
+		actionTakenBy, err := c.db.GetAccountByID(ctx, r.ActionTakenByAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting action taken by account %s: %s", r.ActionTakenByAccountID, err)
+		}
+
+		mastoReport.ActionTakenByAccount, err = c.AccountToMastoPublic(ctx, actionTakenBy)
+		if err != nil {
+			return nil, fmt.Errorf("error converting action taken by account %s: %s", r.ActionTakenByAccountID, err)
+		}
+	}
+
+
+	if r.AssignedAccountID != "" {
+		assigned, err := c.db.GetAccountByID(ctx, r.AssignedAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting assigned account %s: %s", r.AssignedAccountID, err)
+		}
+
+		mastoReport.AssignedAccount, err = c.AccountToMastoPublic(ctx, assigned)
+		if err != nil {
+			return nil, fmt.Errorf("error converting assigned account %s: %s", r.AssignedAccountID, err)
+		}
+	}
+	return mastoReport, nil
+}
+
+func (c *converter) AccountToAdminMasto(ctx context.Context, a *gtsmodel.Account) (*model.AdminAccountInfo, error) {
+	mastoAccount, err := c.AccountToMastoPublic(ctx, a)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account %s: %s", a.ID, err)
+	}
+
+	violations, err := c.ruleViolations(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mastoAdminAccount := &model.AdminAccountInfo{
+		ID:              a.ID,
+		Username:        a.Username,
+		Domain:          a.Domain,
+		CreatedAt:       a.CreatedAt.Format(time.RFC3339),
+		InviteRequest:   a.Reason,
+		Role:            "user",
+		Silenced:        !a.SilencedAt.IsZero(),
+		Suspended:       !a.SuspendedAt.IsZero(),
+		Account:         mastoAccount,
+		RuleViolations:  violations,
+		ModerationNotes: []model.AdminModerationNote{},
+		Warnings:        []model.AdminAccountWarning{},
+	}
+
+	if a.Domain != "" {
+		// remote accounts don't have a user on this instance
+		return mastoAdminAccount, nil
+	}
+
+	// the moderation history of a local account is kept even if its user is removed
+	mastoAdminAccount.ModerationNotes, mastoAdminAccount.Warnings, err = c.accountModerationHistory(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &gtsmodel.User{}
+	if err := c.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: a.ID}}, user); err != nil {
+		if err == db.ErrNoEntries {
+			// the user is removed when a local account is deleted or suspended
+			return mastoAdminAccount, nil
+		}
+		return nil, fmt.Errorf("error getting user for account %s: %s", a.ID, err)
+	}
+
+	mastoAdminAccount.Email = user.Email
+	if mastoAdminAccount.Email == "" {
+		mastoAdminAccount.Email = user.UnconfirmedEmail
+	}
+
+	if user.CurrentSignInIP != nil {
+		mastoAdminAccount.IP = user.CurrentSignInIP.String()
+	} else if user.SignUpIP != nil {
+		mastoAdminAccount.IP = user.SignUpIP.String()
+	}
+
+	switch {
+	case user.Admin:
+		mastoAdminAccount.Role = "admin"
+	case user.Moderator:
+		mastoAdminAccount.Role = "moderator"
+	}
+
+	mastoAdminAccount.Locale = user.Locale
+	mastoAdminAccount.Confirmed = !user.ConfirmedAt.IsZero()
+	mastoAdminAccount.Approved = user.Approved
+	mastoAdminAccount.Disabled = user.Disabled
+	mastoAdminAccount.CreatedByApplicationID = user.CreatedByApplicationID
+
+	if !user.RulesAcknowledgedAt.IsZero() {
+		mastoAdminAccount.RulesAcknowledgedAt = user.RulesAcknowledgedAt.Format(time.RFC3339)
+	}
+
+	return mastoAdminAccount, nil
+}
+
+// accountModerationHistory returns the moderation notes and warnings that have been given to the account with the given ID, newest first.
+func (c *converter) accountModerationHistory(ctx context.Context, accountID string) ([]model.AdminModerationNote, []model.AdminAccountWarning, error) {
+	notes := []*gtsmodel.ModerationNote{}
+	if err := c.db.GetWhere(ctx, []db.Where{{Key: "target_account_id", Value: accountID}}, &notes); err != nil && err != db.ErrNoEntries {
+		return nil, nil, fmt.Errorf("error getting moderation notes for account %s: %s", accountID, err)
+	}
+
+	warnings := []*gtsmodel.AccountWarning{}
+	if err := c.db.GetWhere(ctx, []db.Where{{Key: "target_account_id", Value: accountID}}, &warnings); err != nil && err != db.ErrNoEntries {
+		return nil, nil, fmt.Errorf("error getting warnings for account %s: %s", accountID, err)
+	}
+
+	// ids are ulids, so going backwards through them gives newest first
+	mastoNotes := make([]model.AdminModerationNote, 0, len(notes))
+	for i := len(notes) - 1; i >= 0; i-- {
+		mastoNote, err := c.ModerationNoteToAdminMasto(ctx, notes[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("error converting moderation note %s: %s", notes[i].ID, err)
+		}
+		mastoNotes = append(mastoNotes, *mastoNote)
+	}
+
+	mastoWarnings := make([]model.AdminAccountWarning, 0, len(warnings))
+	for i := len(warnings) - 1; i >= 0; i-- {
+		mastoWarning, err := c.AccountWarningToAdminMasto(ctx, warnings[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("error converting warning %s: %s", warnings[i].ID, err)
+		}
+		mastoWarnings = append(mastoWarnings, *mastoWarning)
+	}
+
+	return mastoNotes, mastoWarnings, nil
+}
+
+// ruleViolations tallies up the rules that resolved reports against the given account say it broke.
+func (c *converter) ruleViolations(ctx context.Context, accountID string) ([]model.AdminRuleViolation, error) {
+	violations := []model.AdminRuleViolation{}
+
+	reports := []*gtsmodel.Report{}
+	if err := c.db.GetWhere(ctx, []db.Where{
+		{Key: "target_account_id", Value: accountID},
+		{Key: "action_taken", Value: true},
+	}, &reports); err != nil {
+		if err == db.ErrNoEntries {
+			return violations, nil
+		}
+		return nil, fmt.Errorf("error getting resolved reports against account %s: %s", accountID, err)
+	}
+
+	counts := map[string]int{}
+	lastViolated := map[string]time.Time{}
+	for _, r := range reports {
+		for _, ruleID := range r.RuleIDs {
+			counts[ruleID]++
+			if r.ActionTakenAt.After(lastViolated[ruleID]) {
+				lastViolated[ruleID] = r.ActionTakenAt
+			}
+		}
+	}
+
+	if len(counts) == 0 {
+		return violations, nil
+	}
+
+	// rules that have been removed since are left out
+	rules, err := c.db.GetInstanceRules(ctx)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("error getting instance rules: %s", err)
+	}
+
+	for _, rule := range rules {
+		if counts[rule.ID] == 0 {
+			continue
+		}
+
+		mastoRule, err := c.RuleToMasto(ctx, rule)
+		if err != nil {
+			return nil, fmt.Errorf("error converting rule %s: %s", rule.ID, err)
+		}
+
+		violations = append(violations, model.AdminRuleViolation{
+			Rule:           *mastoRule,
+			Count:          counts[rule.ID],
+			LastViolatedAt: lastViolated[rule.ID].Format(time.RFC3339),
+		})
+	}
+
+	return violations, nil
+}
+
+func (c *converter) AdminAuditLogToMasto(ctx context.Context, l *gtsmodel.AdminAuditLog) (*model.AdminAuditLog, error) {
+	if l.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, l.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account %s: %s", l.AccountID, err)
+		}
+		l.Account = a
+	}
+
+	mastoAccount, err := c.AccountToMastoPublic(ctx, l.Account)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account %s: %s", l.AccountID, err)
+	}
+
+	return &model.AdminAuditLog{
+		ID:         l.ID,
+		CreatedAt:  l.CreatedAt.Format(time.RFC3339),
+		Account:    mastoAccount,
+		Action:     string(l.Action),
+		TargetType: string(l.TargetType),
+		TargetID:   l.TargetID,
+		Summary:    l.Summary,
+	}, nil
+}
+
+func (c *converter) ModerationNoteToAdminMasto(ctx context.Context, n *gtsmodel.ModerationNote) (*model.AdminModerationNote, error) {
+	if n.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, n.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account %s: %s", n.AccountID, err)
+		}
+		n.Account = a
+	}
+
+	mastoAccount, err := c.AccountToMastoPublic(ctx, n.Account)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account %s: %s", n.AccountID, err)
+	}
+
+	return &model.AdminModerationNote{
+		ID:              n.ID,
+		CreatedAt:       n.CreatedAt.Format(time.RFC3339),
+		Account:         mastoAccount,
+		TargetAccountID: n.TargetAccountID,
+		ReportID:        n.ReportID,
+		Content:         n.Content,
+	}, nil
+}
+
+func (c *converter) AccountWarningToAdminMasto(ctx context.Context, w *gtsmodel.AccountWarning) (*model.AdminAccountWarning, error) {
+	if w.Account == nil {
+		a, err := c.db.GetAccountByID(ctx, w.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting account %s: %s", w.AccountID, err)
+		}
+		w.Account = a
+	}
+
+	mastoAccount, err := c.AccountToMastoPublic(ctx, w.Account)
+	if err != nil {
+		return nil, fmt.Errorf("error converting account %s: %s", w.AccountID, err)
+	}
+
+	return &model.AdminAccountWarning{
+		ID:              w.ID,
+		CreatedAt:       w.CreatedAt.Format(time.RFC3339),
+		Account:         mastoAccount,
+		TargetAccountID: w.TargetAccountID,
+		ReportID:        w.ReportID,
+		Action:          string(w.Action),
+		Text:            w.Text,
+		Emailed:         w.Emailed,
+	}, nil
+}
+
+func (c *converter) ReportToMasto(ctx context.Context, r *gtsmodel.Report) (*model.Report, error) {
+	if r.TargetAccount == nil {
+		a, err := c.db.GetAccountByID(ctx, r.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting target account %s: %s", r.TargetAccountID, err)
+		}
+		r.TargetAccount = a
+	}
+
+	mastoTargetAccount, err := c.AccountToMastoPublic(ctx, r.TargetAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error converting target account %s: %s", r.TargetAccountID, err)
+	}
+
+	statusIDs := r.StatusIDs
+	if statusIDs == nil {
+		statusIDs = []string{}
+	}
+
+	ruleIDs := r.RuleIDs
+	if ruleIDs == nil {
+		ruleIDs = []string{}
+	}
+
+	mastoReport := &model.Report{
+		ID:            r.ID,
+		ActionTaken:   r.ActionTaken,
+		Category:      string(r.Category),
+		Comment:       r.Comment,
+		Forwarded:     r.Forwarded,
+		CreatedAt:     r.CreatedAt.Format(time.RFC3339),
+		StatusIDs:     statusIDs,
+		RuleIDs:       ruleIDs,
+		TargetAccount: mastoTargetAccount,
+	}
+
+	if r.ActionTaken {
+		mastoReport.ActionTakenAt = r.ActionTakenAt.Format(time.RFC3339)
+	}
+
+	return mastoReport, nil
+}
+
+func (c *converter) InstancePageToMasto(ctx context.Context, p *gtsmodel.InstancePage, withSource bool) (*model.InstancePage, error) {
+	mp := &model.InstancePage{
+		Slug:      p.Slug,
+		Title:     p.Title,
+		URL:       util.GenerateURLForInstancePage(c.config.Protocol, c.config.Host, p.Slug),
+		Content:   p.Content,
+		UpdatedAt: p.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if withSource {
+		mp.Source = p.Source
+		mp.ContentType = p.ContentType
+	}
+
+	return mp, nil
+}
+
+func (c *converter) StatusEditToMasto(ctx context.Context, e *gtsmodel.StatusEdit) (*model.StatusEdit, error) {
+	account, err := c.db.GetAccountByID(ctx, e.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving status edit account: %s", err)
+	}
+
+	mastoAccount, err := c.AccountToMastoPublic(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("error converting status edit account: %s", err)
+	}
+
+	return &model.StatusEdit{
+		Content:          e.Content,
+		SpoilerText:      e.ContentWarning,
+		Sensitive:        e.Sensitive,
+		CreatedAt:        e.CreatedAt.Format(time.RFC3339),
+		Account:          mastoAccount,
+		MediaAttachments: []model.Attachment{},
+		Emojis:           []model.Emoji{},
+	}, nil
+}
+
+func (c *converter) ListToMasto(ctx context.Context, l *gtsmodel.List) (*model.List, error) {
+	return &model.List{
+		ID:            l.ID,
+		Title:         l.Title,
+		RepliesPolicy: string(l.RepliesPolicy),
+	}, nil
+}
+
+func (c *converter) FilterToMastoV1(ctx context.Context, k *gtsmodel.FilterKeyword) (*model.Filter, error) {
+	if k.Filter == nil {
+		return nil, fmt.Errorf("FilterToMastoV1: filter keyword %s has no filter", k.ID)
+	}
+
+	var expiresAt string
+	if !k.Filter.ExpiresAt.IsZero() {
+		expiresAt = k.Filter.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return &model.Filter{
+		ID:           k.ID,
+		Phrase:       k.Keyword,
+		Context:      filterContexts(k.Filter),
+		WholeWord:    k.WholeWord,
+		ExpiresAt:    expiresAt,
+		Irreversible: k.Filter.Action == gtsmodel.FilterActionHide,
+	}, nil
+}
+
+func (c *converter) FilterToMastoV2(ctx context.Context, f *gtsmodel.Filter) (*model.FilterV2, error) {
+	var expiresAt *string
+	if !f.ExpiresAt.IsZero() {
+		e := f.ExpiresAt.Format(time.RFC3339)
+		expiresAt = &e
+	}
+
+	keywords := []model.FilterKeyword{}
+	for _, k := range f.Keywords {
+		keywords = append(keywords, model.FilterKeyword{
+			ID:        k.ID,
+			Keyword:   k.Keyword,
+			WholeWord: k.WholeWord,
+		})
+	}
+
+	return &model.FilterV2{
+		ID:           f.ID,
+		Title:        f.Title,
+		Context:      filterContexts(f),
+		ExpiresAt:    expiresAt,
+		FilterAction: string(f.Action),
+		Keywords:     keywords,
+		Statuses:     []interface{}{},
+	}, nil
+}
+
+// filterContexts returns the names of the contexts that the given filter applies in.
+func filterContexts(f *gtsmodel.Filter) []string {
+	contexts := []string{}
+	if f.ContextHome {
+		contexts = append(contexts, string(gtsmodel.FilterContextHome))
+	}
+	if f.ContextNotifications {
+		contexts = append(contexts, string(gtsmodel.FilterContextNotifications))
+	}
+	if f.ContextPublic {
+		contexts = append(contexts, string(gtsmodel.FilterContextPublic))
+	}
+	if f.ContextThread {
+		contexts = append(contexts, string(gtsmodel.FilterContextThread))
+	}
+	if f.ContextAccount {
+		contexts = append(contexts, string(gtsmodel.FilterContextAccount))
+	}
+	return contexts
+}
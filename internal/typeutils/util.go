@@ -3,6 +3,7 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -45,3 +46,12 @@ type statusInteractions struct {
 	Bookmarked bool
 	Reblogged  bool
 }
+
+// statusCacheKey returns the key under which the activitystreams representation of a status
+// is cached. Edited statuses get a new key per edit, so that a stale note is never served.
+func statusCacheKey(s *gtsmodel.Status) string {
+	if s.EditedAt.IsZero() {
+		return s.ID
+	}
+	return s.ID + "@" + strconv.FormatInt(s.EditedAt.UnixNano(), 10)
+}
@@ -1,6 +1,7 @@
 package typeutils
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -65,3 +66,123 @@ func (c *converter) WrapPersonInUpdate(person vocab.ActivityStreamsPerson, origi
 
 	return update, nil
 }
+
+func (c *converter) WrapNoteInUpdate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error) {
+
+	update := streams.NewActivityStreamsUpdate()
+
+	// set the actor
+	actorURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInUpdate: error parsing url %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	update.SetActivityStreamsActor(actorProp)
+
+	// set the ID
+	newID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+
+	idString := util.GenerateURIForUpdate(originAccount.Username, c.config.Protocol, c.config.Host, newID)
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInUpdate: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	update.SetJSONLDId(idProp)
+
+	// set the note as the object here
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsNote(note)
+	update.SetActivityStreamsObject(objectProp)
+
+	// the update should reach everyone who could see the note in the first place, so copy its addressing
+	toProp := streams.NewActivityStreamsToProperty()
+	if noteTo := note.GetActivityStreamsTo(); noteTo != nil {
+		for iter := noteTo.Begin(); iter != noteTo.End(); iter = iter.Next() {
+			if iter.IsIRI() {
+				toProp.AppendIRI(iter.GetIRI())
+			}
+		}
+	}
+	update.SetActivityStreamsTo(toProp)
+
+	ccProp := streams.NewActivityStreamsCcProperty()
+	if noteCC := note.GetActivityStreamsCc(); noteCC != nil {
+		for iter := noteCC.Begin(); iter != noteCC.End(); iter = iter.Next() {
+			if iter.IsIRI() {
+				ccProp.AppendIRI(iter.GetIRI())
+			}
+		}
+	}
+	update.SetActivityStreamsCc(ccProp)
+
+	return update, nil
+}
+
+func (c *converter) WrapNoteInCreate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsCreate, error) {
+
+	create := streams.NewActivityStreamsCreate()
+
+	// set the actor
+	actorURI, err := url.Parse(originAccount.URI)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInCreate: error parsing url %s: %s", originAccount.URI, err)
+	}
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	create.SetActivityStreamsActor(actorProp)
+
+	// set the ID, derived from the note ID so that it's stable across requests
+	noteID := note.GetJSONLDId()
+	if noteID == nil || noteID.GetIRI() == nil {
+		return nil, errors.New("WrapNoteInCreate: note had no id")
+	}
+	idString := noteID.GetIRI().String() + "/activity"
+	idURI, err := url.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("WrapNoteInCreate: error parsing url %s: %s", idString, err)
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(idURI)
+	create.SetJSONLDId(idProp)
+
+	// the create was published at the same time as the note
+	if notePublished := note.GetActivityStreamsPublished(); notePublished != nil {
+		publishedProp := streams.NewActivityStreamsPublishedProperty()
+		publishedProp.Set(notePublished.Get())
+		create.SetActivityStreamsPublished(publishedProp)
+	}
+
+	// set the note as the object here
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsNote(note)
+	create.SetActivityStreamsObject(objectProp)
+
+	// the create should be addressed to everyone who can see the note
+	toProp := streams.NewActivityStreamsToProperty()
+	if noteTo := note.GetActivityStreamsTo(); noteTo != nil {
+		for iter := noteTo.Begin(); iter != noteTo.End(); iter = iter.Next() {
+			if iter.IsIRI() {
+				toProp.AppendIRI(iter.GetIRI())
+			}
+		}
+	}
+	create.SetActivityStreamsTo(toProp)
+
+	ccProp := streams.NewActivityStreamsCcProperty()
+	if noteCC := note.GetActivityStreamsCc(); noteCC != nil {
+		for iter := noteCC.Begin(); iter != noteCC.End(); iter = iter.Next() {
+			if iter.IsIRI() {
+				ccProp.AppendIRI(iter.GetIRI())
+			}
+		}
+	}
+	create.SetActivityStreamsCc(ccProp)
+
+	return create, nil
+}
@@ -0,0 +1,47 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import "strings"
+
+// IsOnionHost returns true if the given host, with or without a port, is a Tor onion service.
+func IsOnionHost(host string) bool {
+	return hasTLD(host, "onion")
+}
+
+// IsI2PHost returns true if the given host, with or without a port, is an I2P site.
+func IsI2PHost(host string) bool {
+	return hasTLD(host, "i2p")
+}
+
+// IsHiddenService returns true if the given host, with or without a port, is a Tor onion service or an I2P site.
+//
+// Hidden services can only be reached through a proxy, rather than by looking them up in dns, and they're
+// usually served over plain http, since the connection through the proxy is already encrypted and authenticated.
+func IsHiddenService(host string) bool {
+	return IsOnionHost(host) || IsI2PHost(host)
+}
+
+func hasTLD(host string, tld string) bool {
+	if i := strings.LastIndex(host, ":"); i != -1 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	return strings.HasSuffix(host, "."+tld)
+}
@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type HiddenServiceTestSuite struct {
+	suite.Suite
+}
+
+func (suite *HiddenServiceTestSuite) TestIsOnionHost() {
+	suite.True(util.IsOnionHost("2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion"))
+	suite.True(util.IsOnionHost("2gzyxa5ihm7nsggfxnu52rck2vv4rvmdlkiu3zzui5du4xyclen53wid.onion:8080"))
+	suite.True(util.IsOnionHost("SOMETHING.ONION."))
+	suite.False(util.IsOnionHost("onion"))
+	suite.False(util.IsOnionHost("onion.example.org"))
+	suite.False(util.IsOnionHost("example.org"))
+	suite.False(util.IsOnionHost("example.i2p"))
+}
+
+func (suite *HiddenServiceTestSuite) TestIsI2PHost() {
+	suite.True(util.IsI2PHost("example.i2p"))
+	suite.True(util.IsI2PHost("ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnkdq.b32.i2p:80"))
+	suite.False(util.IsI2PHost("i2p.example.org"))
+	suite.False(util.IsI2PHost("example.onion"))
+}
+
+func (suite *HiddenServiceTestSuite) TestIsHiddenService() {
+	suite.True(util.IsHiddenService("example.onion"))
+	suite.True(util.IsHiddenService("example.i2p"))
+	suite.False(util.IsHiddenService("example.org"))
+	suite.False(util.IsHiddenService("[::1]:8080"))
+	suite.False(util.IsHiddenService(""))
+}
+
+func TestHiddenServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HiddenServiceTestSuite))
+}
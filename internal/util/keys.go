@@ -0,0 +1,58 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// EncodeEd25519PrivateKey encodes the given ed25519 private key as a PKCS #8 PEM block, suitable for storing in the database.
+func EncodeEd25519PrivateKey(key ed25519.PrivateKey) (string, error) {
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("EncodeEd25519PrivateKey: error marshalling key: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: b,
+	})), nil
+}
+
+// DecodeEd25519PrivateKey decodes an ed25519 private key previously encoded with EncodeEd25519PrivateKey.
+func DecodeEd25519PrivateKey(encoded string) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("DecodeEd25519PrivateKey: could not decode PRIVATE KEY pem block")
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeEd25519PrivateKey: error parsing key: %s", err)
+	}
+
+	key, ok := k.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("DecodeEd25519PrivateKey: key was %T, not ed25519", k)
+	}
+	return key, nil
+}
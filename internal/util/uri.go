@@ -38,6 +38,8 @@ const (
 	OutboxPath = "outbox"
 	// FollowersPath represents the webfinger followers location
 	FollowersPath = "followers"
+	// FollowersSynchronizationPath is for serving the partial followers collections used by follower synchronization
+	FollowersSynchronizationPath = "followers_synchronization"
 	// FollowingPath represents the webfinger following location
 	FollowingPath = "following"
 	// LikedPath represents the webfinger liked location
@@ -48,12 +50,26 @@ const (
 	FeaturedPath = "featured"
 	// PublicKeyPath is for serving an account's public key
 	PublicKeyPath = "main-key"
+	// Ed25519PublicKeyFragment is appended to a user URI to identify that user's ed25519 public key, if they have one
+	Ed25519PublicKeyFragment = "ed25519-key"
 	// FollowPath used to generate the URI for an individual follow or follow request
 	FollowPath = "follow"
 	// UpdatePath is used to generate the URI for an account update
 	UpdatePath = "updates"
+	// MovesPath is used to generate the URI for an account move
+	MovesPath = "moves"
 	// BlocksPath is used to generate the URI for a block
 	BlocksPath = "blocks"
+	// PagesPath is for serving static pages set by the instance admin
+	PagesPath = "pages"
+	// ReportsPath is used to generate the URI for a report
+	ReportsPath = "reports"
+	// ConfirmEmailPath is for serving the page that confirms a user's email address
+	ConfirmEmailPath = "confirm_email"
+	// ForgotPasswordPath is for serving the page where users can ask for a password reset email
+	ForgotPasswordPath = "forgot_password"
+	// ResetPasswordPath is for serving the page where users can choose a new password
+	ResetPasswordPath = "reset_password"
 )
 
 // APContextKey is a type used specifically for settings values on contexts within go-fed AP request chains
@@ -110,6 +126,8 @@ type UserURIs struct {
 	CollectionURI string
 	// The URI for this user's public key, eg., https://example.org/users/example_user/publickey
 	PublicKeyURI string
+	// The URI for this user's ed25519 public key, eg., https://example.org/users/example_user#ed25519-key
+	Ed25519PublicKeyURI string
 }
 
 // GenerateURIForFollow returns the AP URI for a new follow -- something like:
@@ -130,12 +148,46 @@ func GenerateURIForUpdate(username string, protocol string, host string, thisUpd
 	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, UpdatePath, thisUpdateID)
 }
 
+// GenerateURIForMove returns the AP URI for a new move activity -- something like:
+// https://example.org/users/whatever_user#moves/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForMove(username string, protocol string, host string, thisMoveID string) string {
+	return fmt.Sprintf("%s://%s/%s/%s#%s/%s", protocol, host, UsersPath, username, MovesPath, thisMoveID)
+}
+
 // GenerateURIForBlock returns the AP URI for a new block activity -- something like:
 // https://example.org/users/whatever_user/blocks/01F7XTH1QGBAPMGF49WJZ91XGC
 func GenerateURIForBlock(username string, protocol string, host string, thisBlockID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, BlocksPath, thisBlockID)
 }
 
+// GenerateURIForReport returns the AP URI for a new report/flag activity -- something like:
+// https://example.org/reports/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForReport(protocol string, host string, thisReportID string) string {
+	return fmt.Sprintf("%s://%s/%s/%s", protocol, host, ReportsPath, thisReportID)
+}
+
+// GenerateURLForInstancePage returns the web URL of the static instance page with the given slug. The about page and
+// privacy policy get short urls like https://example.org/about, and other pages are served under /pages, eg.,
+// https://example.org/pages/rules
+func GenerateURLForInstancePage(protocol string, host string, slug string) string {
+	if slug == "about" || slug == "privacy" {
+		return fmt.Sprintf("%s://%s/%s", protocol, host, slug)
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", protocol, host, PagesPath, slug)
+}
+
+// GenerateURLForConfirmEmail returns the link a user can follow to confirm their email address with the given token -- something like:
+// https://example.org/confirm_email?token=6a4c6ee6-8d2b-4c32-b8ff-8b4c3a7a5a1e
+func GenerateURLForConfirmEmail(protocol string, host string, token string) string {
+	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, url.QueryEscape(token))
+}
+
+// GenerateURLForResetPassword returns the link a user can follow to choose a new password with the given token -- something like:
+// https://example.org/reset_password?token=8e3b57b4-1e4f-4f4a-a3f5-3d9e3c3ff1d0
+func GenerateURLForResetPassword(protocol string, host string, token string) string {
+	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ResetPasswordPath, url.QueryEscape(token))
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string, protocol string, host string) *UserURIs {
 	// The below URLs are used for serving web requests
@@ -153,6 +205,7 @@ func GenerateURIsForAccount(username string, protocol string, host string) *User
 	likedURI := fmt.Sprintf("%s/%s", userURI, LikedPath)
 	collectionURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, FeaturedPath)
 	publicKeyURI := fmt.Sprintf("%s/%s", userURI, PublicKeyPath)
+	ed25519PublicKeyURI := fmt.Sprintf("%s#%s", userURI, Ed25519PublicKeyFragment)
 
 	return &UserURIs{
 		HostURL:     hostURL,
@@ -168,6 +221,8 @@ func GenerateURIsForAccount(username string, protocol string, host string) *User
 		LikedURI:      likedURI,
 		CollectionURI: collectionURI,
 		PublicKeyURI:  publicKeyURI,
+
+		Ed25519PublicKeyURI: ed25519PublicKeyURI,
 	}
 }
 
@@ -243,6 +298,18 @@ func ParseStatusesPath(id *url.URL) (username string, ulid string, err error) {
 	return
 }
 
+// ParseStatusesWebPath returns the username and ulid from a path such as /@example_username/statuses/SOME_ULID_OF_A_STATUS
+func ParseStatusesWebPath(id *url.URL) (username string, ulid string, err error) {
+	matches := regexes.StatusesWebPath.FindStringSubmatch(id.Path)
+	if len(matches) != 3 {
+		err = fmt.Errorf("expected 3 matches but matches length was %d", len(matches))
+		return
+	}
+	username = matches[1]
+	ulid = matches[2]
+	return
+}
+
 // ParseUserPath returns the username from a path such as /users/example_username
 func ParseUserPath(id *url.URL) (username string, err error) {
 	matches := regexes.UserPath.FindStringSubmatch(id.Path)
@@ -22,8 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"unicode/utf8"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	pwv "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/language"
@@ -38,7 +40,23 @@ const (
 	maximumShortDescriptionLength = 500
 	maximumDescriptionLength      = 5000
 	maximumSiteTermsLength        = 5000
+	maximumSiteCustomCSSLength    = 50000
 	maximumUsernameLength         = 64
+	maximumPageSlugLength         = 64
+	maximumPageTitleLength        = 200
+	maximumPageContentLength      = 50000
+	maximumRuleTextLength         = 500
+	maximumRuleHintLength         = 5000
+	maximumListTitleLength        = 200
+	maximumFilterTitleLength      = 200
+	maximumFilterKeywordLength    = 200
+	maximumAccountNoteLength      = 2000
+	maximumReportCommentLength    = 1000
+	maximumModerationNoteLength   = 5000
+	maximumAccountWarningLength   = 5000
+	maximumProfileFields          = 4
+	maximumProfileFieldLength     = 255
+	maximumStatusExpiryDays       = 3650
 	// maximumEmojiShortcodeLength   = 30
 	// maximumHashtagLength          = 30
 )
@@ -125,6 +143,28 @@ func Note(note string) error {
 	return nil
 }
 
+// ProfileFields checks that there aren't too many of the given profile fields, and that their names and values aren't too long.
+func ProfileFields(fields []apimodel.UpdateField) error {
+	if len(fields) > maximumProfileFields {
+		return fmt.Errorf("too many profile fields, %d provided but limit is %d", len(fields), maximumProfileFields)
+	}
+
+	for _, f := range fields {
+		if f.Name != nil {
+			if length := utf8.RuneCountInString(*f.Name); length > maximumProfileFieldLength {
+				return fmt.Errorf("profile field name should be no more than %d chars but given name was %d", maximumProfileFieldLength, length)
+			}
+		}
+		if f.Value != nil {
+			if length := utf8.RuneCountInString(*f.Value); length > maximumProfileFieldLength {
+				return fmt.Errorf("profile field value should be no more than %d chars but given value was %d", maximumProfileFieldLength, length)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Privacy checks that the desired privacy setting is valid
 func Privacy(privacy string) error {
 	if privacy == "" {
@@ -183,6 +223,223 @@ func SiteTerms(t string) error {
 	return nil
 }
 
+// SiteCustomCSS ensures that the given site custom css string is within spec.
+func SiteCustomCSS(css string) error {
+	return CustomCSS(css, maximumSiteCustomCSSLength)
+}
+
+// InstancePageSlug ensures that the given static instance page slug is within spec, ie., up to 64 characters of
+// lowercase letters and numbers, optionally separated by single dashes.
+func InstancePageSlug(slug string) error {
+	if len(slug) > maximumPageSlugLength || !regexes.InstancePageSlug.MatchString(slug) {
+		return fmt.Errorf("page slug %s was invalid: must contain only lowercase letters, numbers, and single dashes between them, max %d characters", slug, maximumPageSlugLength)
+	}
+
+	return nil
+}
+
+// InstancePageTitle ensures that the given static instance page title is within spec.
+func InstancePageTitle(title string) error {
+	if title == "" {
+		return errors.New("no page title provided")
+	}
+
+	if length := utf8.RuneCountInString(title); length > maximumPageTitleLength {
+		return fmt.Errorf("page title should be no more than %d chars but given title was %d", maximumPageTitleLength, length)
+	}
+
+	return nil
+}
+
+// InstancePageContent ensures that the given static instance page content is within spec.
+func InstancePageContent(content string) error {
+	if length := utf8.RuneCountInString(content); length > maximumPageContentLength {
+		return fmt.Errorf("page content should be no more than %d chars but given content was %d", maximumPageContentLength, length)
+	}
+
+	return nil
+}
+
+// ListTitle ensures that the given list title is within spec.
+func ListTitle(title string) error {
+	if title == "" {
+		return errors.New("no list title provided")
+	}
+
+	if length := utf8.RuneCountInString(title); length > maximumListTitleLength {
+		return fmt.Errorf("list title should be no more than %d chars but given title was %d", maximumListTitleLength, length)
+	}
+
+	return nil
+}
+
+// AccountNote ensures that the given private note on an account is within spec. An empty note is fine, since it removes the note.
+func AccountNote(comment string) error {
+	if length := utf8.RuneCountInString(comment); length > maximumAccountNoteLength {
+		return fmt.Errorf("account note should be no more than %d chars but given note was %d", maximumAccountNoteLength, length)
+	}
+
+	return nil
+}
+
+// ReportComment ensures that the given report comment is within spec. An empty comment is fine, since it's optional.
+func ReportComment(comment string) error {
+	if length := utf8.RuneCountInString(comment); length > maximumReportCommentLength {
+		return fmt.Errorf("report comment should be no more than %d chars but given comment was %d", maximumReportCommentLength, length)
+	}
+
+	return nil
+}
+
+// ReportCategory ensures that the given report category is one of spam, legal, violation, or other.
+func ReportCategory(category string) error {
+	switch gtsmodel.ReportCategory(category) {
+	case gtsmodel.ReportCategorySpam, gtsmodel.ReportCategoryLegal, gtsmodel.ReportCategoryViolation, gtsmodel.ReportCategoryOther:
+		return nil
+	}
+	return fmt.Errorf("report category %s not recognised, must be one of spam, legal, violation, or other", category)
+}
+
+// DomainBlockSeverity ensures that the given domain block severity is one of suspend, silence, or noop.
+func DomainBlockSeverity(severity string) error {
+	switch gtsmodel.DomainBlockSeverity(severity) {
+	case gtsmodel.DomainBlockSeveritySuspend, gtsmodel.DomainBlockSeveritySilence, gtsmodel.DomainBlockSeverityNoop:
+		return nil
+	}
+	return fmt.Errorf("domain block severity %s not recognised, must be one of suspend, silence, or noop", severity)
+}
+
+// ListRepliesPolicy ensures that the given list replies policy is one of followed, list, or none.
+func ListRepliesPolicy(policy string) error {
+	switch gtsmodel.ListRepliesPolicy(policy) {
+	case gtsmodel.ListRepliesPolicyFollowed, gtsmodel.ListRepliesPolicyList, gtsmodel.ListRepliesPolicyNone:
+		return nil
+	}
+	return fmt.Errorf("list replies policy %s not recognised, must be one of followed, list, or none", policy)
+}
+
+// FilterTitle ensures that the given filter title is within spec.
+func FilterTitle(title string) error {
+	if title == "" {
+		return errors.New("no filter title provided")
+	}
+
+	if length := utf8.RuneCountInString(title); length > maximumFilterTitleLength {
+		return fmt.Errorf("filter title should be no more than %d chars but given title was %d", maximumFilterTitleLength, length)
+	}
+
+	return nil
+}
+
+// FilterKeyword ensures that the given filter keyword or phrase is within spec.
+func FilterKeyword(keyword string) error {
+	if keyword == "" {
+		return errors.New("no filter keyword provided")
+	}
+
+	if length := utf8.RuneCountInString(keyword); length > maximumFilterKeywordLength {
+		return fmt.Errorf("filter keyword should be no more than %d chars but given keyword was %d", maximumFilterKeywordLength, length)
+	}
+
+	return nil
+}
+
+// FilterContexts ensures that at least one filter context is given, and that all given contexts are recognised.
+func FilterContexts(contexts []string) error {
+	if len(contexts) == 0 {
+		return errors.New("no filter context provided")
+	}
+
+	for _, c := range contexts {
+		switch gtsmodel.FilterContext(c) {
+		case gtsmodel.FilterContextHome, gtsmodel.FilterContextNotifications, gtsmodel.FilterContextPublic, gtsmodel.FilterContextThread, gtsmodel.FilterContextAccount:
+			continue
+		}
+		return fmt.Errorf("filter context %s not recognised, must be one of home, notifications, public, thread, or account", c)
+	}
+
+	return nil
+}
+
+// FilterAction ensures that the given filter action is one of warn or hide.
+func FilterAction(action string) error {
+	switch gtsmodel.FilterAction(action) {
+	case gtsmodel.FilterActionWarn, gtsmodel.FilterActionHide:
+		return nil
+	}
+	return fmt.Errorf("filter action %s not recognised, must be one of warn or hide", action)
+}
+
+// RuleText ensures that the given instance rule text is within spec.
+func RuleText(text string) error {
+	if text == "" {
+		return errors.New("no rule text provided")
+	}
+
+	if length := utf8.RuneCountInString(text); length > maximumRuleTextLength {
+		return fmt.Errorf("rule text should be no more than %d chars but given text was %d", maximumRuleTextLength, length)
+	}
+
+	return nil
+}
+
+// RuleHint ensures that the given instance rule hint is within spec.
+func RuleHint(hint string) error {
+	if length := utf8.RuneCountInString(hint); length > maximumRuleHintLength {
+		return fmt.Errorf("rule hint should be no more than %d chars but given hint was %d", maximumRuleHintLength, length)
+	}
+
+	return nil
+}
+
+// ModerationNote ensures that the given moderation note content is within spec.
+func ModerationNote(content string) error {
+	if content == "" {
+		return errors.New("no note content provided")
+	}
+
+	if length := utf8.RuneCountInString(content); length > maximumModerationNoteLength {
+		return fmt.Errorf("moderation note should be no more than %d chars but given note was %d", maximumModerationNoteLength, length)
+	}
+
+	return nil
+}
+
+// AccountWarningText ensures that the given account warning text is within spec.
+func AccountWarningText(text string) error {
+	if length := utf8.RuneCountInString(text); length > maximumAccountWarningLength {
+		return fmt.Errorf("warning text should be no more than %d chars but given text was %d", maximumAccountWarningLength, length)
+	}
+
+	return nil
+}
+
+// ExpandMedia ensures that the given reading:expand:media preference is one of default, show_all or hide_all.
+func ExpandMedia(expandMedia string) error {
+	switch expandMedia {
+	case gtsmodel.ExpandMediaDefault, gtsmodel.ExpandMediaShowAll, gtsmodel.ExpandMediaHideAll:
+		return nil
+	}
+	return fmt.Errorf("expand media preference %s was not recognized, must be one of %s, %s or %s", expandMedia, gtsmodel.ExpandMediaDefault, gtsmodel.ExpandMediaShowAll, gtsmodel.ExpandMediaHideAll)
+}
+
+// StatusExpiryDays ensures that the given posting:expiry:days preference is 0 (never delete statuses), or a positive number of days no more than ten years.
+func StatusExpiryDays(days int) error {
+	if days < 0 || days > maximumStatusExpiryDays {
+		return fmt.Errorf("status expiry should be between 0 and %d days but given expiry was %d", maximumStatusExpiryDays, days)
+	}
+	return nil
+}
+
+// CustomCSS ensures that the given custom css is no longer than maxLength characters.
+func CustomCSS(css string, maxLength int) error {
+	if length := utf8.RuneCountInString(css); length > maxLength {
+		return fmt.Errorf("custom css should be no more than %d chars but given css was %d", maxLength, length)
+	}
+
+	return nil
+}
+
 // ULID returns true if the passed string is a valid ULID.
 func ULID(i string) bool {
 	return regexes.ULID.MatchString(i)
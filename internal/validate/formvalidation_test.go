@@ -21,6 +21,7 @@ package validate_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -278,6 +279,125 @@ func (suite *ValidationTestSuite) TestValidateReason() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateCustomCSS() {
+	css := "body { color: #ffffff; }"
+	var err error
+
+	err = validate.CustomCSS("", 10)
+	if assert.NoError(suite.T(), err) {
+		assert.Equal(suite.T(), nil, err)
+	}
+
+	err = validate.CustomCSS(css, 100)
+	if assert.NoError(suite.T(), err) {
+		assert.Equal(suite.T(), nil, err)
+	}
+
+	err = validate.CustomCSS(css, 10)
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("custom css should be no more than 10 chars but given css was 24"), err)
+	}
+
+	// length is counted in characters, not bytes
+	err = validate.CustomCSS("/* 🦥🦥🦥 */", 12)
+	if assert.NoError(suite.T(), err) {
+		assert.Equal(suite.T(), nil, err)
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateInstancePage() {
+	var err error
+
+	for _, slug := range []string{"about", "privacy", "code-of-conduct", "rules2"} {
+		err = validate.InstancePageSlug(slug)
+		assert.NoError(suite.T(), err, slug)
+	}
+
+	for _, slug := range []string{"", "About", "code_of_conduct", "-rules", "rules-", "double--dash", "../admin", strings.Repeat("a", 65)} {
+		err = validate.InstancePageSlug(slug)
+		assert.Error(suite.T(), err, slug)
+	}
+
+	err = validate.InstancePageTitle("")
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("no page title provided"), err)
+	}
+
+	err = validate.InstancePageTitle(strings.Repeat("a", 201))
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("page title should be no more than 200 chars but given title was 201"), err)
+	}
+
+	err = validate.InstancePageContent(strings.Repeat("a", 50000))
+	assert.NoError(suite.T(), err)
+
+	err = validate.InstancePageContent(strings.Repeat("a", 50001))
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("page content should be no more than 50000 chars but given content was 50001"), err)
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateList() {
+	err := validate.ListTitle("best friends")
+	assert.NoError(suite.T(), err)
+
+	err = validate.ListTitle("")
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("no list title provided"), err)
+	}
+
+	err = validate.ListTitle(strings.Repeat("a", 201))
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("list title should be no more than 200 chars but given title was 201"), err)
+	}
+
+	for _, policy := range []string{"followed", "list", "none"} {
+		err = validate.ListRepliesPolicy(policy)
+		assert.NoError(suite.T(), err, policy)
+	}
+
+	err = validate.ListRepliesPolicy("everyone")
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("list replies policy everyone not recognised, must be one of followed, list, or none"), err)
+	}
+}
+
+func (suite *ValidationTestSuite) TestValidateFilter() {
+	err := validate.FilterTitle("spoilers")
+	assert.NoError(suite.T(), err)
+
+	err = validate.FilterTitle("")
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("no filter title provided"), err)
+	}
+
+	err = validate.FilterKeyword(strings.Repeat("a", 201))
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("filter keyword should be no more than 200 chars but given keyword was 201"), err)
+	}
+
+	err = validate.FilterContexts([]string{"home", "notifications", "public", "thread", "account"})
+	assert.NoError(suite.T(), err)
+
+	err = validate.FilterContexts([]string{})
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("no filter context provided"), err)
+	}
+
+	err = validate.FilterContexts([]string{"home", "everywhere"})
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("filter context everywhere not recognised, must be one of home, notifications, public, thread, or account"), err)
+	}
+
+	err = validate.FilterAction("hide")
+	assert.NoError(suite.T(), err)
+
+	err = validate.FilterAction("delete")
+	if assert.Error(suite.T(), err) {
+		assert.Equal(suite.T(), errors.New("filter action delete not recognised, must be one of warn or hide"), err)
+	}
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
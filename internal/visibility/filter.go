@@ -38,10 +38,29 @@ type Filter interface {
 	// This function will call StatusVisible internally, so it's not necessary to call it beforehand.
 	StatusHometimelineable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
 
+	// StatusListtimelineable returns true if targetStatus should be in the timeline of the given list, based on
+	// the home timeline of the list owner and the replies policy of the list. It doesn't check list membership
+	// of the status author, so do that separately.
+	//
+	// This function will call StatusVisible internally, so it's not necessary to call it beforehand.
+	StatusListtimelineable(ctx context.Context, targetStatus *gtsmodel.Status, list *gtsmodel.List) (bool, error)
+
 	// StatusPublictimelineable returns true if targetStatus should be in the public timeline of the requesting account.
 	//
 	// This function will call StatusVisible internally, so it's not necessary to call it beforehand.
 	StatusPublictimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error)
+
+	// StatusReplyable returns true if requestingAccount is permitted to reply to targetStatus, based on the
+	// replyable flag and reply policy of the status. It doesn't check visibility or blocks, so do that separately.
+	StatusReplyable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
+
+	// StatusBoostable returns true if requestingAccount is permitted to boost targetStatus, based on the
+	// boostable flag and boost policy of the status. It doesn't check visibility or blocks, so do that separately.
+	StatusBoostable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
+
+	// StatusLikeable returns true if requestingAccount is permitted to like targetStatus, based on the
+	// likeable flag and like policy of the status. It doesn't check visibility or blocks, so do that separately.
+	StatusLikeable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
 }
 
 type filter struct {
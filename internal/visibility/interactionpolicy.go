@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// interactionPermitted returns true if the given interaction policy of targetStatus allows requestingAccount to interact with it.
+// The author of a status, and accounts mentioned in it, are always allowed by the policy.
+func (f *filter) interactionPermitted(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account, policy gtsmodel.InteractionPolicy) (bool, error) {
+	if targetStatus.AccountID == requestingAccount.ID {
+		return true, nil
+	}
+
+	// accounts mentioned in the status can always interact with it, whatever the policy
+	if targetStatus.Mentions == nil && len(targetStatus.MentionIDs) != 0 {
+		mentions, err := f.db.GetMentions(ctx, targetStatus.MentionIDs)
+		if err != nil {
+			return false, fmt.Errorf("error getting mentions of status with id %s: %s", targetStatus.ID, err)
+		}
+		targetStatus.Mentions = mentions
+	}
+	for _, m := range targetStatus.Mentions {
+		if m.TargetAccountID == requestingAccount.ID {
+			return true, nil
+		}
+	}
+
+	switch policy {
+	case gtsmodel.InteractionPolicyMentioned:
+		return false, nil
+	case gtsmodel.InteractionPolicyFollowers:
+		if targetStatus.Account == nil {
+			a, err := f.db.GetAccountByID(ctx, targetStatus.AccountID)
+			if err != nil {
+				return false, fmt.Errorf("error getting author of status with id %s: %s", targetStatus.ID, err)
+			}
+			targetStatus.Account = a
+		}
+
+		follows, err := f.db.IsFollowing(ctx, requestingAccount, targetStatus.Account)
+		if err != nil {
+			return false, fmt.Errorf("error checking follow: %s", err)
+		}
+		return follows, nil
+	}
+
+	return true, nil
+}
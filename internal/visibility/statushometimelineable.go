@@ -27,7 +27,7 @@ import (
 )
 
 func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error) {
-	l := f.log.WithFields(logrus.Fields{
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":     "StatusHometimelineable",
 		"statusID": targetStatus.ID,
 	})
@@ -47,6 +47,16 @@ func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmo
 		return false, nil
 	}
 
+	muted, err := f.statusMutedBy(ctx, targetStatus, timelineOwnerAccount)
+	if err != nil {
+		return false, fmt.Errorf("StatusHometimelineable: error checking mutes of status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if muted {
+		l.Debug("status is not hometimelineable because the timeline owner has muted its author")
+		return false, nil
+	}
+
 	for _, m := range targetStatus.Mentions {
 		if m.TargetAccountID == timelineOwnerAccount.ID {
 			// if we're mentioned we should be able to see the post
@@ -99,3 +109,21 @@ func (f *filter) StatusHometimelineable(ctx context.Context, targetStatus *gtsmo
 
 	return true, nil
 }
+
+// statusMutedBy returns true if the given account has muted the author of the given status, or the author of the status it boosts.
+func (f *filter) statusMutedBy(ctx context.Context, targetStatus *gtsmodel.Status, account *gtsmodel.Account) (bool, error) {
+	if account == nil {
+		return false, nil
+	}
+
+	muted, err := f.db.IsMuted(ctx, account.ID, targetStatus.AccountID, false)
+	if err != nil || muted {
+		return muted, err
+	}
+
+	if targetStatus.BoostOfAccountID != "" && targetStatus.BoostOfAccountID != account.ID {
+		return f.db.IsMuted(ctx, account.ID, targetStatus.BoostOfAccountID, false)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,73 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (f *filter) StatusListtimelineable(ctx context.Context, targetStatus *gtsmodel.Status, list *gtsmodel.List) (bool, error) {
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":     "StatusListtimelineable",
+		"statusID": targetStatus.ID,
+		"listID":   list.ID,
+	})
+
+	// pin the list owner on to the list if it hasn't been done already
+	if list.Account == nil {
+		a, err := f.db.GetAccountByID(ctx, list.AccountID)
+		if err != nil {
+			return false, fmt.Errorf("StatusListtimelineable: error getting list owner account with id %s: %s", list.AccountID, err)
+		}
+		list.Account = a
+	}
+
+	// anything that can't go in the home timeline of the list owner can't go in their lists either
+	timelineable, err := f.StatusHometimelineable(ctx, targetStatus, list.Account)
+	if err != nil {
+		return false, fmt.Errorf("StatusListtimelineable: error checking home timelineability of status with id %s: %s", targetStatus.ID, err)
+	}
+	if !timelineable {
+		return false, nil
+	}
+
+	// replies to the author's own statuses or to the list owner are always shown
+	if targetStatus.InReplyToAccountID == "" || targetStatus.InReplyToAccountID == targetStatus.AccountID || targetStatus.InReplyToAccountID == list.AccountID {
+		return true, nil
+	}
+
+	switch list.RepliesPolicy {
+	case gtsmodel.ListRepliesPolicyNone:
+		l.Trace("status is not listtimelineable because it's a reply and the list shows no replies")
+		return false, nil
+	case gtsmodel.ListRepliesPolicyList:
+		inList, err := f.db.IsAccountInList(ctx, list.ID, targetStatus.InReplyToAccountID)
+		if err != nil {
+			return false, fmt.Errorf("StatusListtimelineable: error checking whether account %s is in list %s: %s", targetStatus.InReplyToAccountID, list.ID, err)
+		}
+		return inList, nil
+	}
+
+	// the home timeline check already made sure the replied-to account is followed
+	return true, nil
+}
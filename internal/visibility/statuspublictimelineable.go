@@ -21,13 +21,14 @@ package visibility
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error) {
-	l := f.log.WithFields(logrus.Fields{
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":     "StatusPublictimelineable",
 		"statusID": targetStatus.ID,
 	})
@@ -47,6 +48,36 @@ func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gts
 		return true, nil
 	}
 
+	// Don't timeline statuses from silenced domains
+	if !targetStatus.Local {
+		accountURI, err := url.Parse(targetStatus.AccountURI)
+		if err != nil {
+			return false, fmt.Errorf("StatusPublictimelineable: error parsing account uri %s: %s", targetStatus.AccountURI, err)
+		}
+		policy, err := f.db.GetDomainPolicy(ctx, accountURI.Hostname())
+		if err != nil {
+			return false, fmt.Errorf("StatusPublictimelineable: error getting domain policy for %s: %s", accountURI.Host, err)
+		}
+		if policy.Silence {
+			l.Debug("status is not publicTimelineable because its domain is silenced")
+			return false, nil
+		}
+	}
+
+	// Don't timeline statuses from silenced accounts
+	statusAccount := targetStatus.Account
+	if statusAccount == nil {
+		a, err := f.db.GetAccountByID(ctx, targetStatus.AccountID)
+		if err != nil {
+			return false, fmt.Errorf("StatusPublictimelineable: error getting status author %s: %s", targetStatus.AccountID, err)
+		}
+		statusAccount = a
+	}
+	if !statusAccount.SilencedAt.IsZero() {
+		l.Debug("status is not publicTimelineable because its author is silenced")
+		return false, nil
+	}
+
 	v, err := f.StatusVisible(ctx, targetStatus, timelineOwnerAccount)
 	if err != nil {
 		return false, fmt.Errorf("StatusPublictimelineable: error checking visibility of status with id %s: %s", targetStatus.ID, err)
@@ -57,5 +88,15 @@ func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gts
 		return false, nil
 	}
 
+	muted, err := f.statusMutedBy(ctx, targetStatus, timelineOwnerAccount)
+	if err != nil {
+		return false, fmt.Errorf("StatusPublictimelineable: error checking mutes of status with id %s: %s", targetStatus.ID, err)
+	}
+
+	if muted {
+		l.Debug("status is not publicTimelineable because the requester has muted its author")
+		return false, nil
+	}
+
 	return true, nil
 }
@@ -0,0 +1,43 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (f *filter) StatusReplyable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error) {
+	// the author can always reply to their own status, to make threads
+	if targetStatus.AccountID == requestingAccount.ID {
+		return true, nil
+	}
+
+	if !targetStatus.Replyable {
+		return false, nil
+	}
+
+	permitted, err := f.interactionPermitted(ctx, targetStatus, requestingAccount, targetStatus.ReplyPolicy)
+	if err != nil {
+		return false, fmt.Errorf("StatusReplyable: %s", err)
+	}
+	return permitted, nil
+}
@@ -30,7 +30,7 @@ import (
 func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error) {
 	const getBoosted = true
 
-	l := f.log.WithFields(logrus.Fields{
+	l := f.log.WithContext(ctx).WithFields(logrus.Fields{
 		"func":     "StatusVisible",
 		"statusID": targetStatus.ID,
 	})
@@ -38,7 +38,7 @@ func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Statu
 	// Fetch any relevant accounts for the target status
 	relevantAccounts, err := f.relevantAccounts(ctx, targetStatus, getBoosted)
 	if err != nil {
-		l.Debugf("error pulling relevant accounts for status %s: %s", targetStatus.ID, err)
+		l.WithError(err).WithField("statusID", targetStatus.ID).Debug("error pulling relevant accounts for status")
 		return false, fmt.Errorf("StatusVisible: error pulling relevant accounts for status %s: %s", targetStatus.ID, err)
 	}
 
@@ -52,7 +52,7 @@ func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Statu
 	// Check for domain blocks among relevant accounts
 	domainBlocked, err := f.domainBlockedRelevant(ctx, relevantAccounts)
 	if err != nil {
-		l.Debugf("error checking domain block: %s", err)
+		l.WithError(err).Debug("error checking domain block")
 		return false, fmt.Errorf("error checking domain block: %s", err)
 	} else if domainBlocked {
 		return false, nil
@@ -94,6 +94,12 @@ func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Statu
 		return false, nil
 	}
 
+	// local-only statuses are never shown to accounts on other instances
+	if !targetStatus.Federated && requestingAccount.Domain != "" {
+		l.Trace("target status is local-only but the requesting account is remote")
+		return false, nil
+	}
+
 	// if the requesting user doesn't exist (anymore) then the status also shouldn't be visible
 	// note: we only do this for local users
 	if requestingAccount.Domain == "" {
@@ -128,7 +134,7 @@ func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Statu
 	// At this point we have a populated targetAccount, targetStatus, and requestingAccount, so we can check for blocks and whathaveyou
 	// First check if a block exists directly between the target account (which authored the status) and the requesting account.
 	if blocked, err := f.db.IsBlocked(ctx, targetAccount.ID, requestingAccount.ID, true); err != nil {
-		l.Debugf("something went wrong figuring out if the accounts have a block: %s", err)
+		l.WithError(err).Debug("something went wrong figuring out if the accounts have a block")
 		return false, err
 	} else if blocked {
 		// don't allow the status to be viewed if a block exists in *either* direction between these two accounts, no creepy stalking please
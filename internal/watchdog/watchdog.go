@@ -0,0 +1,132 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package watchdog tells systemd when gotosocial is up and healthy, using the sd_notify protocol.
+//
+// See https://www.freedesktop.org/software/systemd/man/sd_notify.html
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	msgReady    = "READY=1"
+	msgWatchdog = "WATCHDOG=1"
+	msgStopping = "STOPPING=1"
+)
+
+// CheckFunc reports whether gotosocial is healthy enough for the watchdog to be fed.
+type CheckFunc func(ctx context.Context) bool
+
+// Run tells systemd that gotosocial has started, then, if systemd is running it with a watchdog, keeps
+// notifying the watchdog at half its interval for as long as check reports that things are healthy.
+// If check fails, the watchdog isn't notified, so systemd restarts gotosocial once the interval runs out.
+//
+// Run blocks until ctx is done, at which point it tells systemd that gotosocial is stopping.
+// If gotosocial wasn't started by systemd with a notify socket, Run does nothing and returns straight away.
+func Run(ctx context.Context, check CheckFunc, log *logrus.Logger) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return
+	}
+	l := log.WithField("func", "watchdog.Run")
+
+	if err := notify(socket, msgReady); err != nil {
+		l.WithError(err).Error("error notifying systemd of readiness")
+	}
+
+	interval, err := watchdogInterval()
+	if err != nil {
+		l.WithError(err).Error("error reading systemd watchdog interval, watchdog won't be notified")
+	}
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		l.Infof("notifying systemd watchdog every %s", interval/2)
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-tick:
+			if !check(ctx) {
+				l.Warn("health checks failed, not notifying systemd watchdog")
+				continue
+			}
+			if err := notify(socket, msgWatchdog); err != nil {
+				l.WithError(err).Error("error notifying systemd watchdog")
+			}
+		case <-ctx.Done():
+			if err := notify(socket, msgStopping); err != nil {
+				l.WithError(err).Error("error notifying systemd of shutdown")
+			}
+			return
+		}
+	}
+}
+
+// watchdogInterval returns the watchdog interval that systemd has set for this process, or 0 if there isn't one.
+func watchdogInterval() (time.Duration, error) {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return 0, nil
+	}
+
+	// if the watchdog is meant for a different process (eg., a wrapper script), it's not ours to notify
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't parse WATCHDOG_USEC %s: %s", usec, err)
+	}
+	if i <= 0 {
+		return 0, fmt.Errorf("WATCHDOG_USEC %s must be greater than 0", usec)
+	}
+
+	return time.Duration(i) * time.Microsecond, nil
+}
+
+// notify sends the given state message to the systemd notify socket.
+func notify(socket string, msg string) error {
+	// a leading @ means a socket in the abstract namespace
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(msg))
+	return err
+}
@@ -0,0 +1,129 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package watchdog_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/watchdog"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WatchdogTestSuite struct {
+	suite.Suite
+	conn *net.UnixConn
+}
+
+func (suite *WatchdogTestSuite) SetupTest() {
+	socket := filepath.Join(suite.T().TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	suite.NoError(err)
+	suite.conn = conn
+
+	suite.T().Setenv("NOTIFY_SOCKET", socket)
+	suite.T().Setenv("WATCHDOG_USEC", "20000")
+	suite.T().Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+}
+
+func (suite *WatchdogTestSuite) TearDownTest() {
+	suite.conn.Close()
+}
+
+// read returns the next message sent to the notify socket.
+func (suite *WatchdogTestSuite) read() string {
+	b := make([]byte, 64)
+	suite.NoError(suite.conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, err := suite.conn.Read(b)
+	suite.NoError(err)
+	return string(b[:n])
+}
+
+func (suite *WatchdogTestSuite) TestRunHealthy() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watchdog.Run(ctx, func(context.Context) bool { return true }, testrig.NewTestLog())
+		close(done)
+	}()
+
+	suite.Equal("READY=1", suite.read())
+	suite.Equal("WATCHDOG=1", suite.read())
+	suite.Equal("WATCHDOG=1", suite.read())
+
+	cancel()
+	<-done
+	// skip over any watchdog notifications sent before stopping
+	msg := suite.read()
+	for msg == "WATCHDOG=1" {
+		msg = suite.read()
+	}
+	suite.Equal("STOPPING=1", msg)
+}
+
+func (suite *WatchdogTestSuite) TestRunUnhealthy() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watchdog.Run(ctx, func(context.Context) bool { return false }, testrig.NewTestLog())
+		close(done)
+	}()
+
+	suite.Equal("READY=1", suite.read())
+
+	// give the watchdog a few intervals in which to (not) be notified
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+	suite.Equal("STOPPING=1", suite.read())
+}
+
+func (suite *WatchdogTestSuite) TestRunOtherPID() {
+	suite.T().Setenv("WATCHDOG_PID", "1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watchdog.Run(ctx, func(context.Context) bool { return true }, testrig.NewTestLog())
+		close(done)
+	}()
+
+	suite.Equal("READY=1", suite.read())
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+	suite.Equal("STOPPING=1", suite.read())
+}
+
+func (suite *WatchdogTestSuite) TestRunNoSocket() {
+	suite.T().Setenv("NOTIFY_SOCKET", "")
+
+	// should return straight away rather than blocking until the context is done
+	watchdog.Run(context.Background(), func(context.Context) bool { return true }, testrig.NewTestLog())
+}
+
+func TestWatchdogTestSuite(t *testing.T) {
+	suite.Run(t, new(WatchdogTestSuite))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Module implements the api.ClientModule interface for web pages.
@@ -49,12 +50,12 @@ func New(config *config.Config, processor processing.Processor, log *logrus.Logg
 }
 
 func (m *Module) baseHandler(c *gin.Context) {
-	l := m.log.WithField("func", "BaseGETHandler")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "BaseGETHandler")
 	l.Trace("serving index html")
 
 	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
 	if err != nil {
-		l.Debugf("error getting instance from processor: %s", err)
+		l.WithError(err).Debug("error getting instance from processor")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
@@ -66,12 +67,12 @@ func (m *Module) baseHandler(c *gin.Context) {
 
 // NotFoundHandler serves a 404 html page instead of a blank 404 error.
 func (m *Module) NotFoundHandler(c *gin.Context) {
-	l := m.log.WithField("func", "404")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "404")
 	l.Trace("serving 404 html")
 
 	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
 	if err != nil {
-		l.Debugf("error getting instance from processor: %s", err)
+		l.WithError(err).Debug("error getting instance from processor")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
@@ -99,9 +100,37 @@ func (m *Module) Route(s router.Router) error {
 	// serve front-page
 	s.AttachHandler(http.MethodGet, "/", m.baseHandler)
 
+	// serve custom css set by the instance admin
+	s.AttachHandler(http.MethodGet, "/custom.css", m.instanceCustomCSSHandler)
+
+	// serve static pages set by the instance admin
+	s.AttachHandler(http.MethodGet, "/about", m.aboutPageHandler)
+	s.AttachHandler(http.MethodGet, "/privacy", m.privacyPageHandler)
+	s.AttachHandler(http.MethodGet, "/"+util.PagesPath+"/:slug", m.pageTemplateHandler)
+
+	// serve the page that email confirmation links point to
+	s.AttachHandler(http.MethodGet, "/"+util.ConfirmEmailPath, m.confirmEmailHandler)
+
+	// serve the pages for resetting forgotten passwords
+	s.AttachHandler(http.MethodGet, "/"+util.ForgotPasswordPath, m.forgotPasswordGETHandler)
+	s.AttachHandler(http.MethodPost, "/"+util.ForgotPasswordPath, m.forgotPasswordPOSTHandler)
+	s.AttachHandler(http.MethodGet, "/"+util.ResetPasswordPath, m.resetPasswordGETHandler)
+	s.AttachHandler(http.MethodPost, "/"+util.ResetPasswordPath, m.resetPasswordPOSTHandler)
+
+	// serve profiles, and any custom css set by their owners
+	s.AttachHandler(http.MethodGet, "/:user", m.profileTemplateHandler)
+	s.AttachHandler(http.MethodGet, "/:user/custom.css", m.accountCustomCSSHandler)
+
 	// serve statuses
 	s.AttachHandler(http.MethodGet, "/:user/statuses/:id", m.threadTemplateHandler)
 
+	// serve embeddable statuses
+	s.AttachHandler(http.MethodGet, "/embed/:id", m.embedTemplateHandler)
+
+	// serve rss/atom feeds of public posts
+	s.AttachHandler(http.MethodGet, "/:user/feed.rss", m.rssFeedHandler)
+	s.AttachHandler(http.MethodGet, "/:user/feed.atom", m.atomFeedHandler)
+
 	// 404 handler
 	s.AttachNoRouteHandler(m.NotFoundHandler)
 
@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// confirmEmailHandler confirms the email address of the user who was sent the token in the
+// query, and renders a page telling them whether that worked.
+func (m *Module) confirmEmailHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "confirmEmailGET")
+	l.Trace("confirming email address")
+
+	ctx := c.Request.Context()
+
+	instance, err := m.processor.InstanceGet(ctx, m.config.Host)
+	if err != nil {
+		l.WithError(err).Debug("error getting instance from processor")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	user, errWithCode := m.processor.UserConfirmEmail(ctx, c.Query("token"))
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error confirming email address")
+		if errWithCode.Code() == http.StatusInternalServerError {
+			c.Error(errWithCode)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		c.HTML(errWithCode.Code(), "confirmed.tmpl", gin.H{
+			"instance": instance,
+			"error":    errWithCode.Safe(),
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, "confirmed.tmpl", gin.H{
+		"instance": instance,
+		"username": user.Account.Username,
+		"email":    user.Email,
+		"approved": user.Approved,
+	})
+}
@@ -0,0 +1,64 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const cssContentType = "text/css; charset=utf-8"
+
+// instanceCustomCSSHandler serves the custom css set by the instance admin, which is included on every page.
+func (m *Module) instanceCustomCSSHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "instanceCustomCSSGET")
+
+	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
+	if err != nil {
+		l.WithError(err).Debug("error getting instance from processor")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Data(http.StatusOK, cssContentType, []byte(instance.CustomCSS))
+}
+
+// accountCustomCSSHandler serves the custom css set by an account, which is included on its profile and status pages.
+func (m *Module) accountCustomCSSHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "accountCustomCSSGET")
+
+	var uriParts profileLink
+	if err := c.ShouldBindUri(&uriParts); err != nil || !strings.HasPrefix(uriParts.User, "@") {
+		m.NotFoundHandler(c)
+		return
+	}
+
+	// custom css is the same for everyone, so there's no need to auth the request
+	account, errWithCode := m.processor.AccountGetLocalByUsername(c.Request.Context(), &oauth.Auth{}, strings.TrimPrefix(uriParts.User, "@"))
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting account for custom css")
+		m.NotFoundHandler(c)
+		return
+	}
+
+	c.Data(http.StatusOK, cssContentType, []byte(account.CustomCSS))
+}
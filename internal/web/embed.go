@@ -0,0 +1,61 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// embedTemplateHandler serves a bare view of a single public status, for use in iframes on other sites.
+func (m *Module) embedTemplateHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "embedTemplateGET")
+	l.Trace("rendering embed template")
+
+	ctx := c.Request.Context()
+
+	statusID := c.Param("id")
+	if statusID == "" {
+		m.NotFoundHandler(c)
+		return
+	}
+
+	// embeds are always rendered as if for a logged-out viewer, so only public statuses show up
+	status, err := m.processor.StatusGet(ctx, &oauth.Auth{}, statusID)
+	if err != nil {
+		l.WithError(err).Debug("error getting status for embed")
+		m.NotFoundHandler(c)
+		return
+	}
+
+	instance, err := m.processor.InstanceGet(ctx, m.config.Host)
+	if err != nil {
+		l.WithError(err).Debug("error getting instance from processor")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.HTML(http.StatusOK, "embed.tmpl", gin.H{
+		"instance":    instance,
+		"status":      status,
+		"stylesheets": []string{"/assets/Fork-Awesome/css/fork-awesome.min.css", "/assets/status.css", "/assets/embed.css"},
+	})
+}
@@ -0,0 +1,95 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	rssContentType  = "application/rss+xml; charset=utf-8"
+	atomContentType = "application/atom+xml; charset=utf-8"
+)
+
+type feedLink struct {
+	User string `uri:"user" binding:"required"`
+}
+
+func (m *Module) rssFeedHandler(c *gin.Context) {
+	m.feedHandler(c, false)
+}
+
+func (m *Module) atomFeedHandler(c *gin.Context) {
+	m.feedHandler(c, true)
+}
+
+func (m *Module) feedHandler(c *gin.Context, atom bool) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "feedGET")
+	l.Trace("rendering account feed")
+
+	var uriParts feedLink
+	if err := c.ShouldBindUri(&uriParts); err != nil || !strings.HasPrefix(uriParts.User, "@") {
+		m.NotFoundHandler(c)
+		return
+	}
+
+	f, errWithCode := m.processor.AccountFeedGet(c.Request.Context(), strings.TrimPrefix(uriParts.User, "@"))
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting account feed")
+		if errWithCode.Code() == http.StatusNotFound {
+			m.NotFoundHandler(c)
+			return
+		}
+		c.Error(errWithCode)
+		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	// let feed readers skip downloading the feed again if nothing has been posted since they last checked
+	lastModified := f.Updated.UTC().Truncate(time.Second)
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !lastModified.After(since) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	var (
+		b           []byte
+		err         error
+		contentType string
+	)
+	if atom {
+		b, err = f.Atom(m.config.Protocol + "://" + m.config.Host + c.Request.URL.Path)
+		contentType = atomContentType
+	} else {
+		b, err = f.RSS()
+		contentType = rssContentType
+	}
+	if err != nil {
+		l.WithError(err).Error("error rendering account feed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, b)
+}
@@ -0,0 +1,137 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// maxOGDescriptionLength is the maximum number of characters used for og:description, longer text is cut short.
+const maxOGDescriptionLength = 160
+
+// ogMeta holds the OpenGraph (and twitter card) metadata for a page, used by
+// other sites and chat apps to build link previews. See https://ogp.me/.
+type ogMeta struct {
+	Title       string
+	Type        string
+	URL         string
+	SiteName    string
+	Description string
+	Image       string
+	ImageAlt    string
+	TwitterCard string
+
+	// set for og:type profile
+	ProfileUsername string
+
+	// set for og:type article
+	ArticlePublishedTime string
+	ArticleAuthor        string
+}
+
+// ogBase returns metadata for a generic page of the given instance.
+func ogBase(instance *apimodel.Instance) *ogMeta {
+	return &ogMeta{
+		Title:       instance.Title,
+		Type:        "website",
+		URL:         instance.URI,
+		SiteName:    instance.Title,
+		Description: ogDescription(instance.ShortDescription),
+		Image:       instance.Thumbnail,
+		TwitterCard: "summary",
+	}
+}
+
+// withAccount adapts the metadata for the profile page of the given account.
+func (o *ogMeta) withAccount(account *apimodel.Account, accountDomain string) *ogMeta {
+	o.Title = fmt.Sprintf("%s (@%s@%s)", displayName(account), account.Username, accountDomain)
+	o.Type = "profile"
+	o.URL = account.URL
+	o.Description = ogDescription(account.Note)
+	if o.Description == "" {
+		o.Description = fmt.Sprintf("Posts from @%s@%s", account.Username, accountDomain)
+	}
+	o.Image = account.Avatar
+	o.ImageAlt = fmt.Sprintf("Avatar for %s", account.Username)
+	o.ProfileUsername = account.Username
+	return o
+}
+
+// withStatus adapts the metadata for the page of the given status.
+func (o *ogMeta) withStatus(status *apimodel.Status, accountDomain string) *ogMeta {
+	o.Title = fmt.Sprintf("Post by %s (@%s@%s)", displayName(status.Account), status.Account.Username, accountDomain)
+	o.Type = "article"
+	o.URL = status.URL
+	o.ArticlePublishedTime = status.CreatedAt
+	o.ArticleAuthor = status.Account.URL
+
+	// don't put the content of a post behind a content warning into link previews
+	if status.SpoilerText != "" {
+		o.Description = "Content warning: " + ogDescription(status.SpoilerText)
+	} else {
+		o.Description = ogDescription(status.Content)
+	}
+
+	o.Image = status.Account.Avatar
+	o.ImageAlt = fmt.Sprintf("Avatar for %s", status.Account.Username)
+	if !status.Sensitive {
+		for _, a := range status.MediaAttachments {
+			if a.Type == "image" {
+				o.Image = a.PreviewURL
+				o.ImageAlt = a.Description
+				o.TwitterCard = "summary_large_image"
+				break
+			}
+		}
+	}
+
+	return o
+}
+
+// withPage adapts the metadata for the given static instance page.
+func (o *ogMeta) withPage(page *apimodel.InstancePage) *ogMeta {
+	o.Title = page.Title
+	o.URL = page.URL
+	o.Description = ogDescription(page.Content)
+	return o
+}
+
+// ogDescription turns the given html into plain text suitable for a description tag.
+func ogDescription(in string) string {
+	// bluemonday escapes the text it returns, but the template will escape it again
+	description := html.UnescapeString(text.RemoveHTML(in))
+	description = strings.Join(strings.Fields(description), " ")
+	if utf8.RuneCountInString(description) > maxOGDescriptionLength {
+		description = string([]rune(description)[:maxOGDescriptionLength-1]) + "…"
+	}
+	return description
+}
+
+func displayName(account *apimodel.Account) string {
+	if account.DisplayName != "" {
+		return account.DisplayName
+	}
+	return account.Username
+}
@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type pageLink struct {
+	Slug string `uri:"slug" binding:"required"`
+}
+
+func (m *Module) aboutPageHandler(c *gin.Context) {
+	m.renderPage(c, gtsmodel.InstancePageAbout)
+}
+
+func (m *Module) privacyPageHandler(c *gin.Context) {
+	m.renderPage(c, gtsmodel.InstancePagePrivacy)
+}
+
+func (m *Module) pageTemplateHandler(c *gin.Context) {
+	var uriParts pageLink
+	if err := c.ShouldBindUri(&uriParts); err != nil {
+		m.NotFoundHandler(c)
+		return
+	}
+
+	// the about page and privacy policy have their own short urls, so send people there instead
+	if uriParts.Slug == gtsmodel.InstancePageAbout || uriParts.Slug == gtsmodel.InstancePagePrivacy {
+		c.Redirect(http.StatusMovedPermanently, util.GenerateURLForInstancePage(m.config.Protocol, m.config.Host, uriParts.Slug))
+		return
+	}
+
+	m.renderPage(c, uriParts.Slug)
+}
+
+// renderPage renders the static instance page with the given slug, or a 404 page if it doesn't exist.
+func (m *Module) renderPage(c *gin.Context, slug string) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "pageTemplateGET")
+	l.Trace("rendering page template")
+
+	ctx := c.Request.Context()
+
+	instance, err := m.processor.InstanceGet(ctx, m.config.Host)
+	if err != nil {
+		l.WithError(err).Debug("error getting instance from processor")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	page, errWithCode := m.processor.InstancePageGet(ctx, slug)
+	if errWithCode != nil {
+		if errWithCode.Code() != http.StatusNotFound {
+			l.WithError(errWithCode).Debug("error getting page from processor")
+			c.Error(errWithCode)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		// without an about page, fall back to the description set in the instance settings
+		if slug != gtsmodel.InstancePageAbout || instance.Description == "" {
+			m.NotFoundHandler(c)
+			return
+		}
+		page = &apimodel.InstancePage{
+			Slug:    slug,
+			Title:   "About " + instance.Title,
+			URL:     util.GenerateURLForInstancePage(m.config.Protocol, m.config.Host, slug),
+			Content: instance.Description,
+		}
+	}
+
+	// the about page is where people look for the rules of the instance, so list them there
+	rules := []apimodel.Rule{}
+	if slug == gtsmodel.InstancePageAbout {
+		rules = instance.Rules
+	}
+
+	c.HTML(http.StatusOK, "page.tmpl", gin.H{
+		"instance": instance,
+		"page":     page,
+		"rules":    rules,
+		"ogMeta":   ogBase(instance).withPage(page),
+	})
+}
@@ -0,0 +1,89 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+// forgotPasswordGETHandler renders the form for asking for a password reset email.
+func (m *Module) forgotPasswordGETHandler(c *gin.Context) {
+	m.renderPasswordPage(c, http.StatusOK, "forgot-password.tmpl", gin.H{})
+}
+
+// forgotPasswordPOSTHandler emails a password reset link to the address submitted in the form, if it belongs to a user.
+func (m *Module) forgotPasswordPOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "forgotPasswordPOST")
+
+	form := &apimodel.PasswordResetRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		m.renderPasswordPage(c, http.StatusBadRequest, "forgot-password.tmpl", gin.H{"error": "couldn't parse form"})
+		return
+	}
+
+	if errWithCode := m.processor.UserPasswordResetRequest(c.Request.Context(), form); errWithCode != nil {
+		l.WithError(errWithCode).Debug("error requesting password reset")
+		c.Error(errWithCode)
+		m.renderPasswordPage(c, errWithCode.Code(), "forgot-password.tmpl", gin.H{"error": errWithCode.Safe()})
+		return
+	}
+
+	m.renderPasswordPage(c, http.StatusOK, "forgot-password.tmpl", gin.H{"sent": true})
+}
+
+// resetPasswordGETHandler renders the form for choosing a new password, using the token from the link in the query.
+func (m *Module) resetPasswordGETHandler(c *gin.Context) {
+	m.renderPasswordPage(c, http.StatusOK, "reset-password.tmpl", gin.H{"token": c.Query("token")})
+}
+
+// resetPasswordPOSTHandler sets the new password submitted in the form, if the token in the form is valid.
+func (m *Module) resetPasswordPOSTHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "resetPasswordPOST")
+
+	form := &apimodel.PasswordResetConfirmRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		m.renderPasswordPage(c, http.StatusBadRequest, "reset-password.tmpl", gin.H{"error": "couldn't parse form"})
+		return
+	}
+
+	if errWithCode := m.processor.UserPasswordReset(c.Request.Context(), form); errWithCode != nil {
+		l.WithError(errWithCode).Debug("error resetting password")
+		c.Error(errWithCode)
+		m.renderPasswordPage(c, errWithCode.Code(), "reset-password.tmpl", gin.H{"token": form.Token, "error": errWithCode.Safe()})
+		return
+	}
+
+	m.renderPasswordPage(c, http.StatusOK, "reset-password.tmpl", gin.H{"reset": true})
+}
+
+// renderPasswordPage renders one of the password pages with the given data, plus the instance for the header and footer.
+func (m *Module) renderPasswordPage(c *gin.Context, code int, template string, data gin.H) {
+	instance, err := m.processor.InstanceGet(c.Request.Context(), m.config.Host)
+	if err != nil {
+		m.log.WithContext(c.Request.Context()).WithError(err).Debug("error getting instance from processor")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	data["instance"] = instance
+	c.HTML(code, template, data)
+}
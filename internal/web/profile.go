@@ -0,0 +1,108 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// profileStatusesPerPage is the number of statuses shown per page of a profile.
+const profileStatusesPerPage = 20
+
+type profileLink struct {
+	User string `uri:"user" binding:"required"`
+}
+
+func (m *Module) profileTemplateHandler(c *gin.Context) {
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "profileTemplateGET")
+	l.Trace("rendering profile template")
+
+	ctx := c.Request.Context()
+
+	var uriParts profileLink
+	if err := c.ShouldBindUri(&uriParts); err != nil || !strings.HasPrefix(uriParts.User, "@") {
+		m.NotFoundHandler(c)
+		return
+	}
+	username := strings.TrimPrefix(uriParts.User, "@")
+
+	authed, err := oauth.Authed(c, false, false, false, false)
+	if err != nil {
+		l.WithError(err).Error("error authing profile GET request")
+		m.NotFoundHandler(c)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGet(ctx, m.config.Host)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting instance from processor")
+		c.Error(errWithCode)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	account, errWithCode := m.processor.AccountGetLocalByUsername(ctx, authed, username)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting account for profile")
+		m.NotFoundHandler(c)
+		return
+	}
+
+	// pinned posts are only shown at the top of the first page
+	maxID := c.Query("max_id")
+	pinned := []apimodel.Status{}
+	if maxID == "" {
+		pinned, errWithCode = m.processor.AccountPinnedWebStatusesGet(ctx, account.ID)
+		if errWithCode != nil {
+			l.WithError(errWithCode).Debug("error getting pinned statuses for profile")
+			c.Error(errWithCode)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+	}
+
+	statuses, errWithCode := m.processor.AccountWebStatusesGet(ctx, account.ID, profileStatusesPerPage, maxID)
+	if errWithCode != nil {
+		l.WithError(errWithCode).Debug("error getting statuses for profile")
+		c.Error(errWithCode)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var nextPage string
+	if len(statuses) == profileStatusesPerPage {
+		nextPage = account.URL + "?max_id=" + statuses[len(statuses)-1].ID
+	}
+
+	c.HTML(http.StatusOK, "profile.tmpl", gin.H{
+		"instance":    instance,
+		"account":     account,
+		"pinned":      pinned,
+		"statuses":    statuses,
+		"firstPage":   maxID == "",
+		"nextPage":    nextPage,
+		"ogMeta":      ogBase(instance).withAccount(account, m.config.AccountDomain),
+		"stylesheets": []string{"/assets/Fork-Awesome/css/fork-awesome.min.css", "/assets/status.css", "/assets/profile.css"},
+	})
+}
@@ -19,7 +19,9 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -31,7 +33,7 @@ type statusLink struct {
 }
 
 func (m *Module) threadTemplateHandler(c *gin.Context) {
-	l := m.log.WithField("func", "threadTemplateGET")
+	l := m.log.WithContext(c.Request.Context()).WithField("func", "threadTemplateGET")
 	l.Trace("rendering thread template")
 
 	ctx := c.Request.Context()
@@ -45,14 +47,14 @@ func (m *Module) threadTemplateHandler(c *gin.Context) {
 
 	authed, err := oauth.Authed(c, false, false, false, false)
 	if err != nil {
-		l.Errorf("error authing status GET request: %s", err)
+		l.WithError(err).Error("error authing status GET request")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "status not found"})
 		return
 	}
 
 	instance, err := m.processor.InstanceGet(ctx, m.config.Host)
 	if err != nil {
-		l.Debugf("error getting instance from processor: %s", err)
+		l.WithError(err).Debug("error getting instance from processor")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
@@ -76,8 +78,11 @@ func (m *Module) threadTemplateHandler(c *gin.Context) {
 
 	c.HTML(http.StatusOK, "thread.tmpl", gin.H{
 		"instance":    instance,
+		"account":     status.Account,
 		"status":      status,
 		"context":     context,
+		"ogMeta":      ogBase(instance).withStatus(status, m.config.AccountDomain),
+		"oembed":      fmt.Sprintf("%s://%s/api/oembed?url=%s", m.config.Protocol, m.config.Host, url.QueryEscape(status.URL)),
 		"stylesheets": []string{"/assets/Fork-Awesome/css/fork-awesome.min.css", "/assets/status.css"},
 	})
 }
@@ -0,0 +1,162 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// recordSize is the record size we use for aes128gcm encoded push messages. Push messages are sent as a single
+// record, so this is also the maximum size of an encrypted push message.
+const recordSize = 4096
+
+// MaxPayloadSize is the largest plaintext payload that fits in a single push message record, allowing for the
+// 16 byte authentication tag and the 1 byte padding delimiter.
+const MaxPayloadSize = recordSize - 16 - 1
+
+// encrypt encrypts a push message payload for a subscription with the given base64url encoded client public key
+// and auth secret, as described in RFC 8291, returning the aes128gcm encoded body for the push message.
+func encrypt(payload []byte, p256dh string, auth string) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, fmt.Errorf("payload of %d bytes is larger than the maximum of %d", len(payload), MaxPayloadSize)
+	}
+
+	uaPublic, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding p256dh key: %s", err)
+	}
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding auth secret: %s", err)
+	}
+
+	curve := elliptic.P256()
+	uaX, uaY := elliptic.Unmarshal(curve, uaPublic)
+	if uaX == nil {
+		return nil, errors.New("p256dh key was not a valid P-256 public key")
+	}
+
+	// generate a new key pair just for this message, and use it to derive a shared secret with the client
+	asPrivate, asX, asY, err := elliptic.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating message key: %s", err)
+	}
+	asPublic := elliptic.Marshal(curve, asX, asY)
+	sharedX, _ := curve.ScalarMult(uaX, uaY, asPrivate)
+	ecdhSecret := sharedX.FillBytes(make([]byte, 32))
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %s", err)
+	}
+
+	cek, nonce := deriveKeys(ecdhSecret, authSecret, salt, uaPublic, asPublic)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// the payload is followed by a 0x02 delimiter to mark it as the last (and only) record
+	record := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+
+	// header is salt, record size, and the message public key, prefixed with its length
+	body := make([]byte, 0, 16+4+1+len(asPublic)+len(record)+gcm.Overhead())
+	body = append(body, salt...)
+	body = append(body, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(body[16:20], recordSize)
+	body = append(body, byte(len(asPublic)))
+	body = append(body, asPublic...)
+
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+// deriveKeys derives the content encryption key and nonce for a push message, as described in RFC 8291 section 3.4.
+func deriveKeys(ecdhSecret []byte, authSecret []byte, salt []byte, uaPublic []byte, asPublic []byte) (cek []byte, nonce []byte) {
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm := hkdfExpand(hkdfExtract(authSecret, ecdhSecret), keyInfo, 32)
+
+	prk := hkdfExtract(salt, ikm)
+	cek = hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce = hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	return cek, nonce
+}
+
+// hkdfExtract is HKDF-Extract from RFC 5869, using sha256.
+func hkdfExtract(salt []byte, ikm []byte) []byte {
+	m := hmac.New(sha256.New, salt)
+	m.Write(ikm)
+	return m.Sum(nil)
+}
+
+// hkdfExpand is HKDF-Expand from RFC 5869, using sha256. We never need more than one
+// block of output, so length must be no more than 32.
+func hkdfExpand(prk []byte, info []byte, length int) []byte {
+	m := hmac.New(sha256.New, prk)
+	m.Write(info)
+	m.Write([]byte{0x01})
+	return m.Sum(nil)[:length]
+}
+
+// ValidateSubscriptionKeys checks that the given base64url encoded client public key and auth secret, as
+// given by a client when subscribing to push notifications, can be used to encrypt push messages.
+func ValidateSubscriptionKeys(p256dh string, auth string) error {
+	uaPublic, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return fmt.Errorf("error decoding p256dh key: %s", err)
+	}
+	if x, _ := elliptic.Unmarshal(elliptic.P256(), uaPublic); x == nil {
+		return errors.New("p256dh key was not a valid P-256 public key")
+	}
+
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return fmt.Errorf("error decoding auth secret: %s", err)
+	}
+	if len(authSecret) != 16 {
+		return errors.New("auth secret was not 16 bytes")
+	}
+
+	return nil
+}
+
+// decodeBase64URL decodes base64url, with or without padding, since clients aren't consistent about it.
+func decodeBase64URL(s string) ([]byte, error) {
+	if len(s)%4 == 0 {
+		if b, err := base64.URLEncoding.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
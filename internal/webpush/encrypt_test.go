@@ -0,0 +1,140 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EncryptTestSuite struct {
+	suite.Suite
+}
+
+// decrypt does what a client does with a push message, to check that encrypt got it right
+func (suite *EncryptTestSuite) decrypt(body []byte, uaPrivate *ecdsa.PrivateKey, authSecret []byte) []byte {
+	salt := body[:16]
+	rs := binary.BigEndian.Uint32(body[16:20])
+	suite.Equal(uint32(recordSize), rs)
+	idlen := int(body[20])
+	asPublic := body[21 : 21+idlen]
+	ciphertext := body[21+idlen:]
+
+	curve := elliptic.P256()
+	asX, asY := elliptic.Unmarshal(curve, asPublic)
+	suite.NotNil(asX)
+	sharedX, _ := curve.ScalarMult(asX, asY, uaPrivate.D.Bytes())
+	uaPublic := elliptic.Marshal(curve, uaPrivate.X, uaPrivate.Y)
+
+	cek, nonce := deriveKeys(sharedX.FillBytes(make([]byte, 32)), authSecret, salt, uaPublic, asPublic)
+	block, err := aes.NewCipher(cek)
+	suite.NoError(err)
+	gcm, err := cipher.NewGCM(block)
+	suite.NoError(err)
+
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	suite.NoError(err)
+	suite.Equal(byte(0x02), record[len(record)-1])
+	return record[:len(record)-1]
+}
+
+func (suite *EncryptTestSuite) TestEncryptRoundTrip() {
+	uaPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.NoError(err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	suite.NoError(err)
+
+	p256dh := base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), uaPrivate.X, uaPrivate.Y))
+	// clients sometimes pad their base64, so check that works too
+	auth := base64.URLEncoding.EncodeToString(authSecret)
+
+	body, err := encrypt([]byte(`{"title":"hello"}`), p256dh, auth)
+	suite.NoError(err)
+	suite.Equal(`{"title":"hello"}`, string(suite.decrypt(body, uaPrivate, authSecret)))
+}
+
+func (suite *EncryptTestSuite) TestEncryptTooLarge() {
+	publicKey, _, err := GenerateVAPIDKeys()
+	suite.NoError(err)
+
+	_, err = encrypt(make([]byte, MaxPayloadSize+1), publicKey, "AAAAAAAAAAAAAAAAAAAAAA")
+	suite.EqualError(err, "payload of 4080 bytes is larger than the maximum of 4079")
+}
+
+func (suite *EncryptTestSuite) TestValidateSubscriptionKeys() {
+	publicKey, _, err := GenerateVAPIDKeys()
+	suite.NoError(err)
+
+	suite.NoError(ValidateSubscriptionKeys(publicKey, "AAAAAAAAAAAAAAAAAAAAAA=="))
+	suite.EqualError(ValidateSubscriptionKeys("AAAA", "AAAAAAAAAAAAAAAAAAAAAA"), "p256dh key was not a valid P-256 public key")
+	suite.EqualError(ValidateSubscriptionKeys(publicKey, "AAAA"), "auth secret was not 16 bytes")
+}
+
+func (suite *EncryptTestSuite) TestVAPIDAuthorization() {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	suite.NoError(err)
+	key, err := parseVAPIDPrivateKey(privateKey)
+	suite.NoError(err)
+
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	authorization, err := vapidAuthorization("https://push.example.org/send/abcdef", "https://localhost:8080", publicKey, key, now)
+	suite.NoError(err)
+
+	suite.True(strings.HasPrefix(authorization, "vapid t="))
+	suite.True(strings.HasSuffix(authorization, ", k="+publicKey))
+	jwt := strings.TrimSuffix(strings.TrimPrefix(authorization, "vapid t="), ", k="+publicKey)
+
+	parts := strings.Split(jwt, ".")
+	suite.Len(parts, 3)
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	suite.NoError(err)
+	claims := map[string]interface{}{}
+	suite.NoError(json.Unmarshal(claimsJSON, &claims))
+	suite.Equal("https://push.example.org", claims["aud"])
+	suite.Equal("https://localhost:8080", claims["sub"])
+	suite.Equal(float64(now.Add(12*time.Hour).Unix()), claims["exp"])
+
+	// the signature should verify with the public key
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	suite.NoError(err)
+	suite.Len(signature, 64)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	suite.True(ecdsa.Verify(&key.PublicKey, hash[:], r, s))
+}
+
+func TestEncryptTestSuite(t *testing.T) {
+	suite.Run(t, &EncryptTestSuite{})
+}
@@ -0,0 +1,377 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// messageTTL is how long push services should hold on to a push message for a device that's offline.
+	messageTTL = 48 * time.Hour
+	// maxAttempts is how many times to try delivering a push message before giving up.
+	maxAttempts = 3
+	// maxBodyRunes is how much of a status to include in the body of a push message.
+	maxBodyRunes = 500
+)
+
+var tagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Sender delivers notifications to the web push subscriptions of local accounts.
+type Sender interface {
+	// VAPIDPublicKey returns the instance's VAPID public key, base64url encoded, creating the instance's VAPID key
+	// pair if it doesn't exist yet. Clients pass this to push services when subscribing.
+	VAPIDPublicKey(ctx context.Context) (string, error)
+	// Send pushes the given notification to every web push subscription of the target account that wants it.
+	//
+	// Delivery happens in the background, with retries; Send only returns an error if the subscriptions
+	// couldn't be looked up. Subscriptions that the push service says no longer exist are deleted.
+	Send(ctx context.Context, targetAccount *gtsmodel.Account, notification *apimodel.Notification) error
+}
+
+type sender struct {
+	config       *config.Config
+	db           db.DB
+	client       *http.Client
+	log          *logrus.Logger
+	retryBackoff time.Duration
+
+	keysLock   sync.Mutex
+	publicKey  string
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewSender returns a new Sender which uses the given http client to POST to push endpoints.
+func NewSender(config *config.Config, db db.DB, client *http.Client, log *logrus.Logger) Sender {
+	return &sender{
+		config:       config,
+		db:           db,
+		client:       client,
+		log:          log,
+		retryBackoff: 10 * time.Second,
+	}
+}
+
+func (s *sender) VAPIDPublicKey(ctx context.Context) (string, error) {
+	publicKey, _, err := s.keys(ctx)
+	return publicKey, err
+}
+
+// keys returns the instance's VAPID key pair. If a key pair is configured, that's used; otherwise it's loaded
+// from the database, or created, on first use.
+func (s *sender) keys(ctx context.Context) (string, *ecdsa.PrivateKey, error) {
+	s.keysLock.Lock()
+	defer s.keysLock.Unlock()
+
+	if s.privateKey != nil {
+		return s.publicKey, s.privateKey, nil
+	}
+
+	if s.config.WebPushConfig.VAPIDPrivateKey != "" {
+		privateKey, err := parseVAPIDPrivateKey(s.config.WebPushConfig.VAPIDPrivateKey)
+		if err != nil {
+			return "", nil, err
+		}
+		if publicKeyFor(privateKey) != s.config.WebPushConfig.VAPIDPublicKey {
+			return "", nil, errors.New("configured vapid public key doesn't belong to the configured vapid private key")
+		}
+		s.publicKey = s.config.WebPushConfig.VAPIDPublicKey
+		s.privateKey = privateKey
+		return s.publicKey, s.privateKey, nil
+	}
+
+	pairs := []*gtsmodel.VAPIDKeyPair{}
+	if err := s.db.GetAll(ctx, &pairs); err != nil && err != db.ErrNoEntries {
+		return "", nil, fmt.Errorf("error getting vapid keys: %s", err)
+	}
+
+	var pair *gtsmodel.VAPIDKeyPair
+	if len(pairs) != 0 {
+		pair = pairs[0]
+	} else {
+		publicKey, privateKey, err := GenerateVAPIDKeys()
+		if err != nil {
+			return "", nil, err
+		}
+		pairID, err := id.NewULID()
+		if err != nil {
+			return "", nil, err
+		}
+		pair = &gtsmodel.VAPIDKeyPair{
+			ID:         pairID,
+			PublicKey:  publicKey,
+			PrivateKey: privateKey,
+		}
+		if err := s.db.Put(ctx, pair); err != nil {
+			return "", nil, fmt.Errorf("error putting vapid keys: %s", err)
+		}
+	}
+
+	privateKey, err := parseVAPIDPrivateKey(pair.PrivateKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	s.publicKey = pair.PublicKey
+	s.privateKey = privateKey
+	return s.publicKey, s.privateKey, nil
+}
+
+func (s *sender) Send(ctx context.Context, targetAccount *gtsmodel.Account, notification *apimodel.Notification) error {
+	subscriptions := []*gtsmodel.WebPushSubscription{}
+	if err := s.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: targetAccount.ID}}, &subscriptions); err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("error getting web push subscriptions for account %s: %s", targetAccount.ID, err)
+	}
+
+	for _, sub := range subscriptions {
+		wanted, err := s.wants(ctx, sub, targetAccount, notification)
+		if err != nil {
+			return err
+		}
+		if !wanted {
+			continue
+		}
+
+		// the request that caused the notification won't hang around until the push is delivered,
+		// so carry over just the request id for logging
+		go s.deliver(log.WithRequestID(context.Background(), log.RequestID(ctx)), sub, notification)
+	}
+
+	return nil
+}
+
+// wants returns true if the subscription wants the notification, according to its alerts and policy.
+func (s *sender) wants(ctx context.Context, sub *gtsmodel.WebPushSubscription, targetAccount *gtsmodel.Account, notification *apimodel.Notification) (bool, error) {
+	var alert bool
+	switch gtsmodel.NotificationType(notification.Type) {
+	case gtsmodel.NotificationFollow:
+		alert = sub.AlertFollow
+	case gtsmodel.NotificationFollowRequest:
+		alert = sub.AlertFollowRequest
+	case gtsmodel.NotificationFave:
+		alert = sub.AlertFavourite
+	case gtsmodel.NotificationMention:
+		alert = sub.AlertMention
+	case gtsmodel.NotificationReblog:
+		alert = sub.AlertReblog
+	case gtsmodel.NotificationPoll:
+		alert = sub.AlertPoll
+	case gtsmodel.NotificationStatus:
+		alert = sub.AlertStatus
+	}
+	if !alert {
+		return false, nil
+	}
+
+	switch sub.Policy {
+	case gtsmodel.WebPushPolicyNone:
+		return false, nil
+	case gtsmodel.WebPushPolicyFollowed, gtsmodel.WebPushPolicyFollower:
+		if notification.Account == nil {
+			return false, nil
+		}
+		originAccount, err := s.db.GetAccountByID(ctx, notification.Account.ID)
+		if err != nil {
+			return false, fmt.Errorf("error getting account %s: %s", notification.Account.ID, err)
+		}
+		if sub.Policy == gtsmodel.WebPushPolicyFollowed {
+			return s.db.IsFollowing(ctx, targetAccount, originAccount)
+		}
+		return s.db.IsFollowing(ctx, originAccount, targetAccount)
+	default:
+		return true, nil
+	}
+}
+
+// deliver encrypts the notification for the subscription and POSTs it to the push endpoint,
+// retrying if the push service is unavailable.
+func (s *sender) deliver(ctx context.Context, sub *gtsmodel.WebPushSubscription, notification *apimodel.Notification) {
+	l := s.log.WithContext(ctx).WithFields(logrus.Fields{
+		"func":           "deliver",
+		"subscriptionID": sub.ID,
+		"notificationID": notification.ID,
+	})
+
+	body, err := s.encryptedPayload(ctx, sub, notification)
+	if err != nil {
+		l.WithError(err).Error("error preparing push message")
+		return
+	}
+
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, sub, body)
+		if err == nil {
+			l.Trace("delivered push message")
+			return
+		}
+
+		l := l.WithError(err).WithField("attempt", attempt)
+		if !retry || attempt == maxAttempts {
+			l.Info("giving up on delivering push message")
+			return
+		}
+
+		l.Debug("error delivering push message, will retry")
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// subject returns the contact url sent to push services along with our VAPID key: the configured contact email
+// if there is one, or the url of the instance if not.
+func (s *sender) subject() string {
+	if s.config.WebPushConfig.ContactEmail != "" {
+		return "mailto:" + s.config.WebPushConfig.ContactEmail
+	}
+	return s.config.Protocol + "://" + s.config.Host
+}
+
+// post does one POST of the encrypted push message to the subscription's endpoint. If it fails, it returns
+// whether it's worth trying again. If the push service says the subscription is gone, it's deleted.
+func (s *sender) post(ctx context.Context, sub *gtsmodel.WebPushSubscription, body []byte) (bool, error) {
+	publicKey, privateKey, err := s.keys(ctx)
+	if err != nil {
+		return true, err
+	}
+
+	authorization, err := vapidAuthorization(sub.Endpoint, s.subject(), publicKey, privateKey, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("error creating push request: %s", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(messageTTL.Seconds())))
+	req.Header.Set("Urgency", "normal")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("error posting push message: %s", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// the subscription has expired or been unsubscribed, so there's no point keeping it around
+		if err := s.db.DeleteByID(ctx, sub.ID, &gtsmodel.WebPushSubscription{}); err != nil {
+			return false, fmt.Errorf("push service returned %s, and there was an error deleting the subscription: %s", resp.Status, err)
+		}
+		return false, fmt.Errorf("push service returned %s, so the subscription was deleted", resp.Status)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("push service returned %s", resp.Status)
+	default:
+		return false, fmt.Errorf("push service returned %s", resp.Status)
+	}
+}
+
+// encryptedPayload builds the push message for the notification, in the format that mastodon clients expect,
+// and encrypts it for the subscription.
+func (s *sender) encryptedPayload(ctx context.Context, sub *gtsmodel.WebPushSubscription, notification *apimodel.Notification) ([]byte, error) {
+	token := &gtsmodel.Token{}
+	if err := s.db.GetByID(ctx, sub.TokenID, token); err != nil {
+		return nil, fmt.Errorf("error getting token %s: %s", sub.TokenID, err)
+	}
+
+	name := ""
+	icon := ""
+	if notification.Account != nil {
+		name = notification.Account.DisplayName
+		if name == "" {
+			name = notification.Account.Username
+		}
+		icon = notification.Account.AvatarStatic
+	}
+
+	var title string
+	switch gtsmodel.NotificationType(notification.Type) {
+	case gtsmodel.NotificationFollow:
+		title = name + " followed you"
+	case gtsmodel.NotificationFollowRequest:
+		title = name + " requested to follow you"
+	case gtsmodel.NotificationFave:
+		title = name + " favourited your post"
+	case gtsmodel.NotificationMention:
+		title = name + " mentioned you"
+	case gtsmodel.NotificationReblog:
+		title = name + " boosted your post"
+	case gtsmodel.NotificationPoll:
+		title = "A poll has ended"
+	case gtsmodel.NotificationStatus:
+		title = name + " just posted"
+	default:
+		title = "New notification"
+	}
+
+	body := ""
+	if notification.Status != nil {
+		if notification.Status.SpoilerText != "" {
+			body = notification.Status.SpoilerText
+		} else {
+			body = strings.TrimSpace(html.UnescapeString(tagRegex.ReplaceAllString(notification.Status.Content, " ")))
+		}
+		if r := []rune(body); len(r) > maxBodyRunes {
+			body = string(r[:maxBodyRunes-1]) + "…"
+		}
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"access_token":      token.Access,
+		"notification_id":   notification.ID,
+		"notification_type": notification.Type,
+		"icon":              icon,
+		"title":             title,
+		"body":              body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling push message: %s", err)
+	}
+
+	return encrypt(payload, sub.P256dh, sub.Auth)
+}
@@ -0,0 +1,220 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SenderTestSuite struct {
+	suite.Suite
+	config       *config.Config
+	db           db.DB
+	log          *logrus.Logger
+	testAccounts map[string]*gtsmodel.Account
+	testTokens   map[string]*gtsmodel.Token
+
+	// push service being sent to
+	server    *httptest.Server
+	lock      sync.Mutex
+	statuses  []int
+	requests  []*http.Request
+	responses int
+
+	sender webpush.Sender
+}
+
+func (suite *SenderTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTokens = testrig.NewTestTokens()
+}
+
+func (suite *SenderTestSuite) SetupTest() {
+	suite.config = testrig.NewTestConfig()
+	suite.db = testrig.NewTestDB()
+	suite.log = testrig.NewTestLog()
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.statuses = []int{}
+	suite.requests = []*http.Request{}
+	suite.responses = 0
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.lock.Lock()
+		defer suite.lock.Unlock()
+		suite.requests = append(suite.requests, r)
+		status := http.StatusCreated
+		if suite.responses < len(suite.statuses) {
+			status = suite.statuses[suite.responses]
+		}
+		suite.responses++
+		w.WriteHeader(status)
+	}))
+
+	suite.sender = webpush.NewSender(suite.config, suite.db, suite.server.Client(), suite.log)
+	webpush.SetRetryBackoff(suite.sender, time.Millisecond)
+}
+
+func (suite *SenderTestSuite) TearDownTest() {
+	suite.server.Close()
+	testrig.StandardDBTeardown(suite.db)
+}
+
+func (suite *SenderTestSuite) putSubscription() *gtsmodel.WebPushSubscription {
+	p256dh, _, err := webpush.GenerateVAPIDKeys()
+	suite.NoError(err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	suite.NoError(err)
+
+	sub := &gtsmodel.WebPushSubscription{
+		ID:           "01FJ1S8DX3STJJ6CEYPMZ1M0R3",
+		AccountID:    suite.testAccounts["local_account_1"].ID,
+		TokenID:      suite.testTokens["local_account_1"].ID,
+		Endpoint:     suite.server.URL + "/push/abcdef",
+		Auth:         base64.RawURLEncoding.EncodeToString(auth),
+		P256dh:       p256dh,
+		AlertMention: true,
+		Policy:       gtsmodel.WebPushPolicyAll,
+	}
+	suite.NoError(suite.db.Put(context.Background(), sub))
+	return sub
+}
+
+func (suite *SenderTestSuite) send(notificationType string) {
+	err := suite.sender.Send(context.Background(), suite.testAccounts["local_account_1"], &apimodel.Notification{
+		ID:   "01FJ1SCC7AQ9JKB6DAMJGRBP9G",
+		Type: notificationType,
+		Account: &apimodel.Account{
+			ID:       suite.testAccounts["remote_account_1"].ID,
+			Username: "foss_satan",
+		},
+		Status: &apimodel.Status{
+			Content: "<p>hello &amp; welcome</p>",
+		},
+	})
+	suite.NoError(err)
+}
+
+func (suite *SenderTestSuite) requestCount() int {
+	suite.lock.Lock()
+	defer suite.lock.Unlock()
+	return len(suite.requests)
+}
+
+func (suite *SenderTestSuite) TestSend() {
+	suite.putSubscription()
+	suite.send("mention")
+
+	suite.Eventually(func() bool { return suite.requestCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	vapidKey, err := suite.sender.VAPIDPublicKey(context.Background())
+	suite.NoError(err)
+
+	req := suite.requests[0]
+	suite.Equal("/push/abcdef", req.URL.Path)
+	suite.Equal("aes128gcm", req.Header.Get("Content-Encoding"))
+	suite.Equal("172800", req.Header.Get("TTL"))
+	suite.Contains(req.Header.Get("Authorization"), "vapid t=")
+	suite.Contains(req.Header.Get("Authorization"), ", k="+vapidKey)
+}
+
+func (suite *SenderTestSuite) TestSendNotWanted() {
+	suite.putSubscription()
+
+	// the subscription only wants mentions
+	suite.send("favourite")
+	time.Sleep(50 * time.Millisecond)
+	suite.Equal(0, suite.requestCount())
+}
+
+func (suite *SenderTestSuite) TestSendRetry() {
+	suite.putSubscription()
+	suite.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+	suite.send("mention")
+
+	// two failures, then a success
+	suite.Eventually(func() bool { return suite.requestCount() == 3 }, time.Second, 10*time.Millisecond)
+}
+
+func (suite *SenderTestSuite) TestSendGone() {
+	sub := suite.putSubscription()
+	suite.statuses = []int{http.StatusGone}
+	suite.send("mention")
+
+	// the dead subscription gets cleaned up, and isn't retried
+	suite.Eventually(func() bool {
+		return suite.db.GetByID(context.Background(), sub.ID, &gtsmodel.WebPushSubscription{}) == db.ErrNoEntries
+	}, time.Second, 10*time.Millisecond)
+	suite.Equal(1, suite.requestCount())
+}
+
+func (suite *SenderTestSuite) TestVAPIDPublicKeyStable() {
+	key1, err := suite.sender.VAPIDPublicKey(context.Background())
+	suite.NoError(err)
+	suite.NotEmpty(key1)
+
+	// a new sender picks up the same key from the database
+	key2, err := webpush.NewSender(suite.config, suite.db, http.DefaultClient, suite.log).VAPIDPublicKey(context.Background())
+	suite.NoError(err)
+	suite.Equal(key1, key2)
+}
+
+func (suite *SenderTestSuite) TestVAPIDPublicKeyConfigured() {
+	publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+	suite.NoError(err)
+	suite.config.WebPushConfig.VAPIDPublicKey = publicKey
+	suite.config.WebPushConfig.VAPIDPrivateKey = privateKey
+
+	key, err := suite.sender.VAPIDPublicKey(context.Background())
+	suite.NoError(err)
+	suite.Equal(publicKey, key)
+
+	// nothing should have been stored in the database
+	pairs := []*gtsmodel.VAPIDKeyPair{}
+	err = suite.db.GetAll(context.Background(), &pairs)
+	suite.True(err == nil || err == db.ErrNoEntries)
+	suite.Empty(pairs)
+
+	// a public key that doesn't match the private key is refused
+	otherPublicKey, _, err := webpush.GenerateVAPIDKeys()
+	suite.NoError(err)
+	suite.config.WebPushConfig.VAPIDPublicKey = otherPublicKey
+	_, err = webpush.NewSender(suite.config, suite.db, http.DefaultClient, suite.log).VAPIDPublicKey(context.Background())
+	suite.Error(err)
+}
+
+func TestSenderTestSuite(t *testing.T) {
+	suite.Run(t, &SenderTestSuite{})
+}
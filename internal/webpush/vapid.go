@@ -0,0 +1,101 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+)
+
+// vapidExpiry is how long the VAPID JWTs we sign are valid for. RFC 8292 says this must be no more than 24 hours.
+const vapidExpiry = 12 * time.Hour
+
+// GenerateVAPIDKeys returns a new P-256 key pair for identifying this instance to push services, as described in
+// RFC 8292. The public key is returned as a base64url encoded uncompressed point, which is the format clients pass
+// to push services when subscribing, and the private key as a base64url encoded scalar.
+func GenerateVAPIDKeys() (publicKey string, privateKey string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating vapid key: %s", err)
+	}
+
+	private := key.D.FillBytes(make([]byte, 32))
+
+	return publicKeyFor(key), base64.RawURLEncoding.EncodeToString(private), nil
+}
+
+// publicKeyFor returns the public key belonging to the given private key, in the same format as GenerateVAPIDKeys.
+func publicKeyFor(key *ecdsa.PrivateKey) string {
+	return base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), key.X, key.Y))
+}
+
+// parseVAPIDPrivateKey parses a private key as returned from GenerateVAPIDKeys.
+func parseVAPIDPrivateKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	d, err := base64.RawURLEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding vapid private key: %s", err)
+	}
+	if len(d) != 32 {
+		return nil, errors.New("vapid private key was not 32 bytes")
+	}
+
+	key := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(d)}
+	key.Curve = elliptic.P256()
+	key.X, key.Y = key.Curve.ScalarBaseMult(d)
+	return key, nil
+}
+
+// vapidAuthorization returns the value of the Authorization header for a push message sent to the given endpoint,
+// containing a JWT signed with the given private key, as described in RFC 8292. subject should be a mailto: or
+// https: url that the push service can use to contact the instance admin.
+func vapidAuthorization(endpoint string, subject string, publicKey string, privateKey *ecdsa.PrivateKey, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("error parsing push endpoint: %s", err)
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidExpiry).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error marshalling vapid claims: %s", err)
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing vapid jwt: %s", err)
+	}
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+
+	return fmt.Sprintf("vapid t=%s.%s, k=%s", signingInput, base64.RawURLEncoding.EncodeToString(signature), publicKey), nil
+}
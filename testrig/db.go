@@ -34,6 +34,8 @@ var testModels []interface{} = []interface{}{
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainAllow{},
+	&gtsmodel.DomainPolicy{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
@@ -43,16 +45,44 @@ var testModels []interface{} = []interface{}{
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.StatusMute{},
+	&gtsmodel.StatusEdit{},
 	&gtsmodel.Tag{},
 	&gtsmodel.User{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
+	&gtsmodel.InstancePage{},
 	&gtsmodel.Notification{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
+	&gtsmodel.Tombstone{},
 	&gtsmodel.Client{},
+	&gtsmodel.FailedDelivery{},
+	&gtsmodel.DeliveryReceipt{},
+	&gtsmodel.InboxActivity{},
+	&gtsmodel.List{},
+	&gtsmodel.ListEntry{},
+	&gtsmodel.Filter{},
+	&gtsmodel.FilterKeyword{},
+	&gtsmodel.AccountMute{},
+	&gtsmodel.NotificationPreferences{},
+	&gtsmodel.SpamFlag{},
+	&gtsmodel.Report{},
+	&gtsmodel.WebPushSubscription{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.Poll{},
+	&gtsmodel.PollVote{},
+	&gtsmodel.AccountNote{},
+	&gtsmodel.TagFollow{},
+	&gtsmodel.Rule{},
+	&gtsmodel.AccountExport{},
+	&gtsmodel.SuggestionDismissal{},
+	&gtsmodel.AdminAuditLog{},
+	&gtsmodel.ModerationNote{},
+	&gtsmodel.AccountWarning{},
+	&gtsmodel.PasswordReset{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
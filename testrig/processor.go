@@ -19,13 +19,30 @@
 package testrig
 
 import (
+	"net/http"
+	"time"
+
 	"git.iim.gay/grufwub/go-store/kv"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/relme"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // NewTestProcessor returns a Processor suitable for testing purposes
 func NewTestProcessor(db db.DB, storage *kv.KVStore, federator federation.Federator) processing.Processor {
-	return processing.NewProcessor(NewTestConfig(), NewTestTypeConverter(db), federator, NewTestOauthServer(db), NewTestMediaHandler(db, storage), storage, NewTestTimelineManager(db), db, NewTestLog())
+	return processing.NewProcessor(NewTestConfig(), NewTestTypeConverter(db), federator, NewTestOauthServer(db), NewTestMediaHandler(db, storage), storage, NewTestTimelineManager(db), db, NewTestFieldVerifier(), NewTestWebPushSender(db), NewTestLog())
+}
+
+// NewTestFieldVerifier returns a relme verifier for testing purposes, which unlike the one
+// used in production is allowed to fetch pages from test servers on loopback addresses.
+func NewTestFieldVerifier() relme.Verifier {
+	return relme.NewVerifier(&http.Client{Timeout: 10 * time.Second})
+}
+
+// NewTestWebPushSender returns a web push sender for testing purposes, which unlike the one
+// used in production is allowed to post to test servers on loopback addresses.
+func NewTestWebPushSender(db db.DB) webpush.Sender {
+	return webpush.NewSender(NewTestConfig(), db, &http.Client{Timeout: 30 * time.Second}, NewTestLog())
 }
@@ -0,0 +1,269 @@
+/*
+   GoToSocial
+   Copyright (C) 2021 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testrig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"git.iim.gay/grufwub/go-store/kv"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// SeedPassword is the password set on every account created by Seed.
+const SeedPassword = "password"
+
+// SeedCounts describes how much demo data Seed should create.
+type SeedCounts struct {
+	// Number of local accounts to create.
+	Accounts int
+	// Number of other seeded accounts that each seeded account follows.
+	Follows int
+	// Number of statuses to create per account.
+	Statuses int
+	// Number of each account's statuses that get a media attachment.
+	Media int
+}
+
+// Seed populates the given db and storage with generated accounts, follows, statuses and media, using
+// the standard test models as templates. Media files are read from the testrig media directory at mediaPath.
+//
+// Unlike StandardDBSetup, Seed is meant to be run against a real instance, so it doesn't create any tables,
+// and everything it creates gets fresh IDs and URIs based on the host in the given config.
+func Seed(ctx context.Context, c *config.Config, dbService db.DB, storage *kv.KVStore, mediaPath string, counts SeedCounts) error {
+	if counts.Follows >= counts.Accounts && counts.Accounts > 0 {
+		counts.Follows = counts.Accounts - 1
+	}
+	if counts.Media > counts.Statuses {
+		counts.Media = counts.Statuses
+	}
+
+	accounts := make([]*gtsmodel.Account, 0, counts.Accounts)
+	for i := 0; i < counts.Accounts; i++ {
+		account, err := seedAccount(ctx, dbService)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, account)
+	}
+
+	for i, account := range accounts {
+		// follow the next few accounts along, wrapping around at the end
+		for j := 1; j <= counts.Follows; j++ {
+			target := accounts[(i+j)%len(accounts)]
+			if err := seedFollow(ctx, c, dbService, account, target); err != nil {
+				return err
+			}
+		}
+	}
+
+	statusTemplates := seedStatusTemplates()
+	attachmentTemplates, storedTemplates := seedAttachmentTemplates()
+	for _, account := range accounts {
+		for i := 0; i < counts.Statuses; i++ {
+			var attachment *gtsmodel.MediaAttachment
+			if i < counts.Media {
+				k := attachmentTemplates[i%len(attachmentTemplates)]
+				var err error
+				attachment, err = seedAttachment(ctx, c, dbService, storage, mediaPath, account, NewTestAttachments()[k], storedTemplates[k])
+				if err != nil {
+					return err
+				}
+			}
+
+			template := statusTemplates[i%len(statusTemplates)]
+			if err := seedStatus(ctx, c, dbService, account, template, attachment); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func seedAccount(ctx context.Context, dbService db.DB) (*gtsmodel.Account, error) {
+	newID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+	username := "seed_" + strings.ToLower(newID[len(newID)-10:])
+
+	user, err := dbService.NewSignup(ctx, username, "", false, username+"@example.org", SeedPassword, nil, "en", "", true, false)
+	if err != nil {
+		return nil, fmt.Errorf("error creating account %s: %s", username, err)
+	}
+
+	account, err := dbService.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account %s: %s", username, err)
+	}
+	return account, nil
+}
+
+func seedFollow(ctx context.Context, c *config.Config, dbService db.DB, account *gtsmodel.Account, target *gtsmodel.Account) error {
+	followID, err := id.NewRandomULID()
+	if err != nil {
+		return err
+	}
+
+	follow := &gtsmodel.Follow{
+		ID:              followID,
+		URI:             util.GenerateURIForFollow(account.Username, c.Protocol, c.Host, followID),
+		AccountID:       account.ID,
+		TargetAccountID: target.ID,
+		ShowReblogs:     true,
+	}
+	if err := dbService.Put(ctx, follow); err != nil {
+		return fmt.Errorf("error creating follow from %s to %s: %s", account.Username, target.Username, err)
+	}
+	return nil
+}
+
+func seedStatus(ctx context.Context, c *config.Config, dbService db.DB, account *gtsmodel.Account, template *gtsmodel.Status, attachment *gtsmodel.MediaAttachment) error {
+	statusID, err := id.NewRandomULID()
+	if err != nil {
+		return err
+	}
+	uris := util.GenerateURIsForAccount(account.Username, c.Protocol, c.Host)
+
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 uris.StatusesURI + "/" + statusID,
+		URL:                 uris.StatusesURL + "/" + statusID,
+		Content:             template.Content,
+		ContentWarning:      template.ContentWarning,
+		Sensitive:           template.Sensitive,
+		Language:            template.Language,
+		Local:               true,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		Federated:           true,
+		Boostable:           true,
+		Replyable:           true,
+		Likeable:            true,
+		ActivityStreamsType: template.ActivityStreamsType,
+	}
+
+	if attachment != nil {
+		status.AttachmentIDs = []string{attachment.ID}
+		attachment.StatusID = statusID
+		if err := dbService.UpdateByPrimaryKey(ctx, attachment); err != nil {
+			return fmt.Errorf("error attaching media to status: %s", err)
+		}
+	}
+
+	if err := dbService.PutStatus(ctx, status); err != nil {
+		return fmt.Errorf("error creating status for %s: %s", account.Username, err)
+	}
+	return nil
+}
+
+func seedAttachment(ctx context.Context, c *config.Config, dbService db.DB, storage *kv.KVStore, mediaPath string, account *gtsmodel.Account, template *gtsmodel.MediaAttachment, stored filenames) (*gtsmodel.MediaAttachment, error) {
+	attachmentID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := os.ReadFile(filepath.Join(mediaPath, stored.Original))
+	if err != nil {
+		return nil, fmt.Errorf("error reading template media: %s", err)
+	}
+	small, err := os.ReadFile(filepath.Join(mediaPath, stored.Small))
+	if err != nil {
+		return nil, fmt.Errorf("error reading template media: %s", err)
+	}
+
+	// same layout as the media handler uses
+	extension := strings.TrimPrefix(filepath.Ext(template.File.Path), ".")
+	urlBase := fmt.Sprintf("%s://%s%s", c.StorageConfig.ServeProtocol, c.StorageConfig.ServeHost, c.StorageConfig.ServeBasePath)
+	originalPath := fmt.Sprintf("%s/attachment/original/%s.%s", account.ID, attachmentID, extension)
+	smallPath := fmt.Sprintf("%s/attachment/small/%s.jpeg", account.ID, attachmentID)
+
+	if err := storage.Put(originalPath, original); err != nil {
+		return nil, fmt.Errorf("error storing media: %s", err)
+	}
+	if err := storage.Put(smallPath, small); err != nil {
+		return nil, fmt.Errorf("error storing media: %s", err)
+	}
+
+	now := time.Now()
+	attachment := template
+	attachment.ID = attachmentID
+	attachment.AccountID = account.ID
+	attachment.StatusID = ""
+	attachment.CreatedAt = now
+	attachment.UpdatedAt = now
+	attachment.URL = fmt.Sprintf("%s/%s", urlBase, originalPath)
+	attachment.File.Path = originalPath
+	attachment.File.FileSize = len(original)
+	attachment.File.UpdatedAt = now
+	attachment.Thumbnail.Path = smallPath
+	attachment.Thumbnail.URL = fmt.Sprintf("%s/%s", urlBase, smallPath)
+	attachment.Thumbnail.FileSize = len(small)
+	attachment.Thumbnail.UpdatedAt = now
+
+	if err := dbService.Put(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("error creating media attachment: %s", err)
+	}
+	return attachment, nil
+}
+
+// seedStatusTemplates returns the local test statuses that don't depend on anything else, in a stable order.
+func seedStatusTemplates() []*gtsmodel.Status {
+	statuses := NewTestStatuses()
+
+	keys := []string{}
+	for k, s := range statuses {
+		if s.Local && s.Content != "" && s.InReplyToID == "" && s.BoostOfID == "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	templates := make([]*gtsmodel.Status, 0, len(keys))
+	for _, k := range keys {
+		templates = append(templates, statuses[k])
+	}
+	return templates
+}
+
+// seedAttachmentTemplates returns the keys of test attachments with stored files that can be used as templates, in a stable order.
+func seedAttachmentTemplates() ([]string, map[string]filenames) {
+	stored := newTestStoredAttachments()
+	attachments := NewTestAttachments()
+
+	keys := []string{}
+	for k := range stored {
+		if a, ok := attachments[k]; ok && !a.Avatar && !a.Header && a.Type == gtsmodel.FileTypeImage {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, stored
+}